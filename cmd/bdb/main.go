@@ -3,18 +3,26 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"path"
 	"sync"
 
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/internal/bench"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/server"
 	"github.com/spf13/cobra"
 )
 
 var (
 	configPath string
+	devMode    bool
+	devDir     string
 	// PathEnv is an environment variable that can hold
 	// the absolute path of the config file
 	pathEnv = "BCDB_CONFIG_PATH"
@@ -37,6 +45,9 @@ func bdbCmd() *cobra.Command {
 	}
 	cmd.AddCommand(versionCmd())
 	cmd.AddCommand(startCmd())
+	cmd.AddCommand(benchCmd())
+	cmd.AddCommand(exportCmd())
+	cmd.AddCommand(importCmd())
 	return cmd
 }
 
@@ -64,19 +75,30 @@ func startCmd() *cobra.Command {
 		Use:   "start",
 		Short: "Starts a blockchain database",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			var path string
-			switch {
-			case configPath != "":
-				path = configPath
-			case os.Getenv(pathEnv) != "":
-				path = os.Getenv(pathEnv)
-			default:
-				log.Fatalf("Neither --configpath nor %s path environment is set", pathEnv)
-			}
+			var conf *config.Configurations
+			var err error
+			if devMode {
+				conf, err = config.DevConfig(devDir)
+				if err != nil {
+					return err
+				}
+				log.Printf("Development mode: crypto material and ledger are in %s, admin credentials are %s.pem and %s.key in %s",
+					devDir, config.DevAdminID, config.DevAdminID, path.Join(devDir, "crypto"))
+			} else {
+				var configFilePath string
+				switch {
+				case configPath != "":
+					configFilePath = configPath
+				case os.Getenv(pathEnv) != "":
+					configFilePath = os.Getenv(pathEnv)
+				default:
+					log.Fatalf("Neither --configpath nor %s path environment is set", pathEnv)
+				}
 
-			conf, err := config.Read(path)
-			if err != nil {
-				return err
+				conf, err = config.Read(configFilePath)
+				if err != nil {
+					return err
+				}
 			}
 
 			cmd.SilenceUsage = true
@@ -102,5 +124,187 @@ func startCmd() *cobra.Command {
 	}
 
 	cmd.PersistentFlags().StringVar(&configPath, "configpath", "", "set the absolute path of config directory")
+	cmd.PersistentFlags().BoolVar(&devMode, "dev", false, "start a single-node development cluster with auto-generated crypto material; not for production use")
+	cmd.PersistentFlags().StringVar(&devDir, "devdir", "./orion-dev", "set the directory that holds the crypto material and ledger in development mode")
+	return cmd
+}
+
+func benchCmd() *cobra.Command {
+	benchConf := &bench.Config{}
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Drives the block processing pipeline with a synthetic workload and reports throughput and latency per commit phase.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("Trailing arguments detected")
+			}
+
+			if benchConf.Dir == "" {
+				dir, err := ioutil.TempDir("", "orion-bench")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(dir)
+				benchConf.Dir = dir
+			}
+
+			lg, err := logger.New(&logger.Config{
+				Level:         "warn",
+				OutputPath:    []string{"stderr"},
+				ErrOutputPath: []string{"stderr"},
+				Encoding:      "console",
+				Name:          "bench",
+			})
+			if err != nil {
+				return err
+			}
+			benchConf.Logger = lg
+
+			cmd.SilenceUsage = true
+			report, err := bench.Run(benchConf)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				out, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(out))
+				return nil
+			}
+			return report.WriteText(cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&benchConf.Dir, "benchdir", "", "set the directory that holds the crypto material and the ledger of the run; it must not hold a ledger. A temporary directory is used and removed if not set")
+	cmd.Flags().Uint32Var(&benchConf.Blocks, "blocks", 100, "set the number of data blocks to commit")
+	cmd.Flags().Uint32Var(&benchConf.BlockSize, "block-size", 100, "set the number of transactions in a block")
+	cmd.Flags().Uint32Var(&benchConf.OpsPerTx, "ops-per-tx", 1, "set the number of operations in a transaction")
+	cmd.Flags().Uint32Var(&benchConf.Mix.Reads, "read-weight", 0, "set the relative weight of reads in the transaction mix")
+	cmd.Flags().Uint32Var(&benchConf.Mix.Writes, "write-weight", 1, "set the relative weight of writes in the transaction mix")
+	cmd.Flags().Uint32Var(&benchConf.Mix.Deletes, "delete-weight", 0, "set the relative weight of deletes in the transaction mix")
+	cmd.Flags().StringVar(&benchConf.KeyDistribution, "key-distribution", bench.KeyDistributionUniform, "set the key distribution: uniform, zipf, or sequential")
+	cmd.Flags().Uint32Var(&benchConf.KeySpace, "key-space", 10000, "set the number of distinct keys")
+	cmd.Flags().Uint32Var(&benchConf.ValueSize, "value-size", 128, "set the size of a written value in bytes")
+	cmd.Flags().Int64Var(&benchConf.Seed, "seed", 1, "set the seed of the random source, to repeat a run")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "print the report as JSON")
+	return cmd
+}
+
+func exportCmd() *cobra.Command {
+	var archivePath string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Exports the ledger of a stopped node to an archive in the ledger interchange format.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("Trailing arguments detected")
+			}
+			if archivePath == "" {
+				return fmt.Errorf("The archive path is not set")
+			}
+
+			conf, lg, err := readToolConfig("export")
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			f, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+			if err != nil {
+				return err
+			}
+			header, err := bcdb.ExportLedger(conf, f, lg)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(archivePath)
+				return err
+			}
+
+			cmd.Printf("Exported %d blocks to %s\n", header.Height, archivePath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&configPath, "configpath", "", "set the absolute path of config directory")
+	cmd.Flags().StringVar(&archivePath, "archive", "", "set the path of the archive to create; it must not exist")
 	return cmd
 }
+
+func importCmd() *cobra.Command {
+	var archivePath string
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Imports an archive in the ledger interchange format into the empty ledger of a stopped node.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("Trailing arguments detected")
+			}
+			if archivePath == "" {
+				return fmt.Errorf("The archive path is not set")
+			}
+
+			conf, lg, err := readToolConfig("import")
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			f, err := os.Open(archivePath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			height, err := bcdb.ImportLedger(conf, f, lg, nil)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Imported %d blocks from %s\n", height, archivePath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&configPath, "configpath", "", "set the absolute path of config directory")
+	cmd.Flags().StringVar(&archivePath, "archive", "", "set the path of the archive to import")
+	return cmd
+}
+
+// readToolConfig reads the configuration of the node that an offline tool operates on, from --configpath or from the
+// path environment variable, and creates a logger that writes warnings and errors to stderr.
+func readToolConfig(name string) (*config.Configurations, *logger.SugarLogger, error) {
+	configFilePath := configPath
+	if configFilePath == "" {
+		configFilePath = os.Getenv(pathEnv)
+	}
+	if configFilePath == "" {
+		return nil, nil, fmt.Errorf("Neither --configpath nor %s path environment is set", pathEnv)
+	}
+
+	conf, err := config.Read(configFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lg, err := logger.New(&logger.Config{
+		Level:         "warn",
+		OutputPath:    []string{"stderr"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+		Name:          name,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conf, lg, nil
+}
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"math"
 	"os"
 	"path"
 	"time"
@@ -31,20 +32,66 @@ type LocalConfiguration struct {
 	BlockCreation BlockCreationConf
 	Replication   ReplicationConf
 	Bootstrap     BootstrapConf
+	Witness       WitnessConf
+	Standby       StandbyConf
 }
 
 // ReplicationConf provides local configuration parameters for replication and server to server communication.
 type ReplicationConf struct {
 	// WALDir defines the directory used to store the WAL of the consensus algorithm.
+	// The WAL is fsync-ed on every write, hence it may be placed on a different (faster) disk than the ledger.
+	// If empty, it defaults to `<ledgerDirectory>/raft/wal`.
 	WALDir string
 	// SnapDir defines the directory used to store snapshots produced by the consensus algorithm.
+	// If empty, it defaults to `<ledgerDirectory>/raft/snap`.
 	SnapDir string
+	// SnapshotIntervalBlocks defines the number of blocks after which a snapshot is taken, in addition to the data
+	// size interval defined by the shared RaftConfig.SnapshotIntervalSize. Zero disables the block interval.
+	SnapshotIntervalBlocks uint64
+	// SnapshotCatchUpEntries defines the number of raft log entries that are retained after a snapshot is taken,
+	// so that slow followers can catch up without being sent a snapshot. If zero, a default is used.
+	SnapshotCatchUpEntries uint64
+	// MaxSnapshotFiles defines the number of snapshot files retained on disk. WAL files older than the oldest
+	// retained snapshot are purged. If zero, a default is used.
+	MaxSnapshotFiles uint32
 	// AuxDir defines the directory used to store auxiliary and temporary files during replication.
 	AuxDir string
 	// Network defines the listen address and port used for server to server communication.
 	Network NetworkConf
 	// TLS defines TLS settings for server to server communication.
 	TLS TLSConf
+	// AllowedCIDRs defines the networks, in CIDR notation, from which other servers may connect for server to server
+	// communication. If empty, connections from all networks are accepted.
+	AllowedCIDRs []string
+	// Compression defines the compression of the blocks transferred between servers.
+	Compression ReplicationCompressionConf
+	// Throttle defines the bandwidth limits of the bulk transfers between servers.
+	Throttle ReplicationThrottleConf
+}
+
+// ReplicationCompressionConf holds the compression of the blocks transferred between servers, i.e., the blocks pulled
+// during catch-up, and the blocks replicated in the entries of the Raft log. It does not affect the format of the ledger.
+type ReplicationCompressionConf struct {
+	// Codecs defines the codecs that may compress the blocks, in order of preference: `snappy` and `gzip`. The codec of
+	// a catch-up transfer is negotiated between the two servers, and the blocks proposed by a leader are compressed with
+	// the first codec, once the cluster protocol version allows it. If empty, the blocks are not compressed.
+	Codecs []string
+	// MinEntryBytes defines the size of a marshaled block below which it is not compressed in the Raft log, as the
+	// compression of small blocks saves little. If zero, a default is used.
+	MinEntryBytes uint64
+}
+
+// ReplicationThrottleConf holds the bandwidth limits of the bulk transfers between servers, so that rebuilding a
+// server does not starve the traffic of the cluster on a shared link. The limits apply to the bytes sent on the
+// wire, i.e., after compression. Raft messages other than snapshots are never throttled.
+type ReplicationThrottleConf struct {
+	// CatchUpBytesPerSecond defines the maximal rate at which blocks are served to the servers that catch-up, and
+	// the maximal rate at which blocks are pulled when catching-up, each shared by all concurrent transfers.
+	// If zero, catch-up is not throttled.
+	CatchUpBytesPerSecond uint64
+	// SnapshotBytesPerSecond defines the maximal rate at which Raft snapshots are sent to other servers.
+	// If zero, snapshots are not throttled.
+	SnapshotBytesPerSecond uint64
 }
 
 // TLSConf holds TLS configuration settings.
@@ -76,8 +123,40 @@ type ServerConf struct {
 	Network NetworkConf
 	// The database configuration of the local node.
 	Database DatabaseConf
+	// The provenance store configuration of the local node.
+	Provenance ProvenanceConf
 	// The lengths of various queues that buffer between internal components.
 	QueueLength QueueLengthConf
+	// The runtime diagnostics endpoints of the local node.
+	Diagnostics DiagnosticsConf
+	// The Prometheus metrics endpoint of the local node.
+	Metrics MetricsConf
+	// The memory budget of snapshots, query results, and block assembly.
+	MemoryBudget MemoryBudgetConf
+	// The JSON encoding of the responses to clients.
+	ResponseEncoding ResponseEncodingConf
+	// The batching of the state database writes of consecutive small blocks.
+	CommitBatching CommitBatchingConf
+	// The webhook to which the local node posts the alerts of the database quotas.
+	QuotaWebhook QuotaWebhookConf
+	// The protection of signed queries against replay.
+	QueryReplayProtection QueryReplayProtectionConf
+	// The protection of transactions against replay across restarts.
+	TxReplayProtection TxReplayProtectionConf
+	// The listeners that serve groups of endpoints, and the networks from which they are reachable.
+	Exposure ExposureConf
+	// The maximal sizes of the bodies of requests.
+	RequestLimits RequestLimitsConf
+	// The access log of the requests of clients.
+	AccessLog AccessLogConf
+	// The synthetic write and read of a canary key, which checks the health of the whole pipeline.
+	Canary CanaryConf
+	// The log of the data queries whose execution is slow.
+	SlowQueryLog SlowQueryLogConf
+	// The background compaction of the index databases of the data databases.
+	IndexCompaction IndexCompactionConf
+	// The masking of the values and keys of the users in the server log.
+	LogMasking LogMaskingConf
 	// Server logging level.
 	LogLevel string
 }
@@ -107,6 +186,283 @@ type NetworkConf struct {
 type DatabaseConf struct {
 	Name            string
 	LedgerDirectory string
+	// The number of the last committed blocks that are kept in memory, so that the reads of the recent blocks, e.g., by
+	// the ledger queries and by the replication of lagging nodes, do not read the block files; zero disables the cache.
+	BlockCacheSize uint32
+	// The number of the user and node records that are kept in memory, so that the access checks of the requests do
+	// not read the records from the state database; zero disables the cache.
+	IdentityCacheSize uint32
+	// The time after which a snapshot of the state database that is not read, e.g., of a stuck query, is released by
+	// force, so that it does not pin the memory and the files of the database; the later reads of the snapshot fail.
+	// It should exceed the TTLs of the data cursors, whose snapshots are read only when their pages are. Zero disables
+	// the forced release.
+	SnapshotLeakTimeout time.Duration
+	// The large values of the data databases, which are uploaded to the blob store and written by their manifests.
+	Blobs BlobsConf
+	// The encryption of the values of the databases in the world state.
+	Encryption EncryptionConf
+}
+
+// BlobsConf holds the limits of the values of the data databases, above which a value must be uploaded to the blob
+// store and written by its manifest, and the garbage collection of the chunks that are no longer referenced.
+type BlobsConf struct {
+	// The size in bytes above which a value must be uploaded to the blob store; a transaction that writes a larger value
+	// in place is rejected upon submission. If zero, 1 MiB is used.
+	ValueSizeThreshold uint64
+	// The size in bytes of the chunks an uploaded value is split into. If zero, 256 KiB is used.
+	ChunkSize uint64
+	// The interval between the runs of the garbage collection of the chunks; zero disables the garbage collection.
+	GCInterval time.Duration
+	// The time a chunk is kept after it is found to be unreferenced. It must exceed the time an uploaded value may wait
+	// for the transaction that writes it to commit, including the expiry of pending transactions, and the time a
+	// snapshot of the state database may be read. If zero, 24 hours are used.
+	GCGracePeriod time.Duration
+}
+
+// EncryptionConf holds the keys of the world state value encryption: the values of the databases are encrypted in the
+// world state, so that the state of the databases of different tenants is encrypted with different keys. A value is
+// encrypted with AES-256-GCM and tagged with the ID of its key. Changing the key of a database rotates it: when the
+// node starts, the values are re-encrypted with the new key in the background, hence the previous key must be kept in
+// the keys directory until the re-encryption is complete; likewise, the values of a database removed from the list are
+// decrypted in the background. Only the values in the world state are encrypted: the blocks and the provenance store,
+// which hold the values written by the transactions, the large values held by the blob store, and the keys of the
+// indexes are stored in the clear.
+type EncryptionConf struct {
+	// The directory of the keys. The key with ID <id> is read from the file <id>.key, which holds its 32 hex-encoded
+	// bytes. The directory must be set as long as any value is encrypted.
+	KeysDirectory string
+	// The current key of every encrypted database.
+	Databases []DatabaseKeyConf
+}
+
+// DatabaseKeyConf holds the ID of the key with which the values of a database are encrypted.
+type DatabaseKeyConf struct {
+	Name  string
+	KeyID string
+}
+
+// ProvenanceConf holds the backend of the provenance store.
+type ProvenanceConf struct {
+	// The backend of the provenance store: "leveldb" (the default) maintains the provenance graph in an embedded graph
+	// database, whereas "neo4j" also exports the lineage of every committed block to a Neo4j database, where multi-hop
+	// lineage queries are efficient. The lineage is exported through the outbox, so that the commit of blocks does not
+	// depend on the availability of Neo4j.
+	Backend string
+	// The connection parameters of the Neo4j database, used by the "neo4j" backend.
+	Neo4j Neo4jConf
+}
+
+// Neo4jConf holds the connection parameters of a Neo4j database.
+type Neo4jConf struct {
+	// The URL of the HTTP endpoint of the Neo4j server, e.g., http://localhost:7474.
+	URL string
+	// The name of the database; if empty, "neo4j" is used.
+	Database string
+	Username string
+	Password string
+	// The timeout of a request to the Neo4j server; if zero, a default is used.
+	Timeout time.Duration
+}
+
+// DiagnosticsConf holds the configuration of the runtime diagnostics endpoints: the profiles of net/http/pprof,
+// goroutine dumps, and GC statistics. The endpoints are served on a dedicated port, which can be firewalled separately
+// from the port that serves clients, and every request must be signed by an admin.
+type DiagnosticsConf struct {
+	// Enabled turns on the diagnostics endpoints; they are disabled by default.
+	Enabled bool
+	// The listen address and port of the diagnostics endpoints. The port must differ from the port used to serve
+	// client requests.
+	Network NetworkConf
+}
+
+// MetricsConf holds the configuration of the Prometheus metrics endpoint. As a scraper does not sign its requests, the
+// metrics are served on a dedicated port, which can be firewalled separately from the port that serves clients, and
+// which is reachable only from the allowed networks.
+type MetricsConf struct {
+	// Enabled turns on the metrics endpoint; it is disabled by default.
+	Enabled bool
+	// The listen address and port of the metrics endpoint. The port must differ from the port used to serve client
+	// requests.
+	Network NetworkConf
+	// The networks, in CIDR notation, from which the metrics endpoint is reachable. If empty, it is reachable from all
+	// networks.
+	AllowedCIDRs []string
+}
+
+// MemoryBudgetConf holds the global memory budget of the node, which limits the memory held concurrently by DB
+// snapshots, the result sets of in-flight queries, and the data transactions batched into the next block.
+// A query that does not fit in the budget waits for memory to be released, and is rejected if it waits longer than
+// QueueTimeout, whereas block assembly is throttled until memory is released.
+type MemoryBudgetConf struct {
+	// The total number of bytes that may be held concurrently; zero disables the budget.
+	LimitBytes uint64
+	// The memory charged to the budget for every DB snapshot held by a query; if zero, a default is used.
+	SnapshotBytes uint64
+	// The maximal time a query waits for memory before it is rejected; if zero, the query is rejected immediately.
+	QueueTimeout time.Duration
+}
+
+// ResponseEncodingConf holds the parameters of the JSON encoding of the responses to clients. Responses are encoded
+// into pooled buffers, except for large responses, e.g., blocks or query results, which are streamed to the client.
+type ResponseEncodingConf struct {
+	// The size of the JSON encoding of a response above which the response is streamed; if zero, a default is used.
+	StreamThresholdBytes uint64
+	// The capacity of the largest encoding buffer that is kept in the pool; if zero, a default is used.
+	MaxPooledBufferBytes uint64
+}
+
+// CommitBatchingConf holds the batching of the state database writes of consecutive small data blocks, which shortens
+// catching up when many tiny blocks are committed back to back, e.g., after a leader recovery. The updates of a small
+// block are visible to reads as soon as it is committed, and are written together with the updates of the following
+// blocks, in a single batch per database. Blocks whose writes are lost in a crash are replayed from the block store.
+// When enabled, the consecutive data blocks delivered together by the replication are also group-committed to the
+// block store, in a single write that is synced once.
+type CommitBatchingConf struct {
+	// The maximal number of blocks whose writes are batched; 0 or 1 disables the batching.
+	MaxBlocks uint32
+	// The maximal number of writes and deletes of a block whose writes may be batched; if zero, a default is used.
+	MaxBlockUpdates uint32
+	// The time to wait for the next block before the batched updates are written; if zero, a default is used.
+	FlushTimeout time.Duration
+}
+
+// QuotaWebhookConf holds the webhook that receives the alerts raised when a block makes the usage of a database reach
+// a soft limit of its quota. Every alert is posted as JSON, with an Idempotency-Key header, at least once by every
+// node with a webhook, hence the receiver discards the alerts whose key it has already seen.
+type QuotaWebhookConf struct {
+	// The URL of the webhook; if empty, the alerts are not posted.
+	URL string
+	// The timeout of a request to the webhook; if zero, a default is used.
+	Timeout time.Duration
+}
+
+// QueryReplayProtectionConf holds the protection of signed queries against replay. A query that carries a nonce and a
+// timestamp is signed together with them, and is rejected if its timestamp is outside the window around the clock of
+// the server, or if its nonce was already used by the querier within the window.
+type QueryReplayProtectionConf struct {
+	// Whether every signed query must carry a nonce and a timestamp; if false, they are optional. It is true when it is
+	// not set in the configuration file.
+	Required bool
+	// The maximal difference between the timestamp of a query and the clock of the server; if zero, a default is used.
+	TimestampWindow time.Duration
+	// The maximal number of nonces remembered at once; if zero, a default is used.
+	MaxNonces uint32
+	// The maximal number of nonces of a single user remembered at once, such that a user cannot exhaust the nonces of
+	// all users; if zero, a default is used.
+	MaxNoncesPerUser uint32
+}
+
+// TxReplayProtectionConf holds the protection of transactions against replay. The leader persists the TxIDs of the
+// transactions it accepts for ordering until they are committed, so that a transaction submitted again with the same
+// TxId is rejected as a duplicate, also after the leader restarts. The TxIDs of committed transactions are always
+// rejected.
+type TxReplayProtectionConf struct {
+	// The time the TxId of an accepted transaction that is not committed is remembered; if zero, a default is used.
+	Window time.Duration
+}
+
+// ExposureConf binds groups of endpoints to listeners, and restricts the networks from which every listener is
+// reachable, e.g., so that the admin endpoints are reachable only from the management network. The endpoints are
+// grouped into "tx", the submission of data transactions; "admin", the configuration, user and database administration
+// transactions, along with the reads of the configuration and the node state that are limited to admins; and "query",
+// all other requests. The listener defined by ServerConf.Network serves the groups that are not bound to any of the
+// Listeners.
+type ExposureConf struct {
+	// The networks, in CIDR notation, from which the listener defined by ServerConf.Network is reachable. If empty, it
+	// is reachable from all networks.
+	AllowedCIDRs []string
+	// Additional listeners, each serving a set of endpoint groups. A group may be bound to a single listener.
+	Listeners []ListenerConf
+}
+
+// ListenerConf holds a listener that serves a set of endpoint groups, see ExposureConf.
+type ListenerConf struct {
+	// The listen address and port. The port must differ from the other ports of the node.
+	Network NetworkConf
+	// The endpoint groups served by the listener: "tx", "query", or "admin".
+	EndpointGroups []string
+	// The networks, in CIDR notation, from which the listener is reachable. If empty, it is reachable from all networks.
+	AllowedCIDRs []string
+}
+
+// RequestLimitsConf holds the maximal sizes of the bodies of requests, per endpoint group, see ExposureConf. A request
+// whose Content-Length exceeds the limit of its group is rejected before its body is read, and a request whose body
+// turns out to be larger is rejected as soon as the limit is reached while the body is decoded.
+type RequestLimitsConf struct {
+	// The maximal body size of the submission of data transactions; if zero, a default is used.
+	MaxTxBodyBytes uint64
+	// The maximal body size of queries; if zero, a default is used.
+	MaxQueryBodyBytes uint64
+	// The maximal body size of admin requests; if zero, a default is used.
+	MaxAdminBodyBytes uint64
+}
+
+// AccessLogConf holds the access log of the requests of clients, which is written as JSON lines to a sink separate from
+// the logs of the server, e.g., so that it can be shipped to a SIEM. Every entry holds the method, path, endpoint group,
+// status, latency, sizes of the request and the response, and the user of a request. The reads of the cluster config,
+// including config blocks, history, diffs, and dry-runs, are audited: they are logged regardless of the sample rates,
+// and their entries are marked with "audit".
+type AccessLogConf struct {
+	// Enabled turns on the access log; it is disabled by default.
+	Enabled bool
+	// The path of the file to which the access log is appended, or "stdout" or "stderr".
+	OutputPath string
+	// The fraction, between 0 and 1, of the successful requests of an endpoint group ("tx", "query", or "admin") that
+	// are logged; all the requests of a group that is not listed are logged. Failed requests are always logged.
+	SampleRates map[string]float64
+	// Regular expressions matching the keys that are replaced with their hash in the log, whether they appear in the
+	// path or in the query parameters of a request.
+	ScrubKeys []string
+	// Whether user IDs are replaced with their hash in the log.
+	HashUserIDs bool
+}
+
+// CanaryConf holds the canary of the node, which periodically writes a canary key in a dedicated system database with a
+// transaction signed by the node, waits for the transaction to commit, and reads the key back. The outcome and the
+// latencies of every check are exported as metrics. A canary transaction is submitted only while the node is the leader.
+type CanaryConf struct {
+	// Enabled turns on the canary; it is disabled by default.
+	Enabled bool
+	// The interval between checks; if zero, a default is used.
+	Interval time.Duration
+	// The maximal time a check waits for the canary transaction to commit; if zero, a default is used.
+	Timeout time.Duration
+}
+
+// SlowQueryLogConf holds the log of slow data queries. A JSON or SQL query whose execution takes more than the
+// threshold is recorded with the querier, its plan, and the number of index entries and keys it scanned, so that the
+// queries that are not served well by the indexes can be found. The log is held in memory, and is read by admins.
+type SlowQueryLogConf struct {
+	// The execution time above which a query is logged; if zero, no query is logged.
+	Threshold time.Duration
+	// The maximal number of slow queries held, after which the oldest ones are dropped; if zero, a default is used.
+	MaxQueries uint32
+}
+
+// IndexCompactionConf holds the background compaction of the index databases of the data databases. The index entries of
+// a key are deleted whenever its indexed attributes change or it is deleted, hence an index database accumulates the
+// tombstones of the deleted entries, e.g., after a cleanup of a prefix of keys, which slow down the queries and hold
+// disk space until LevelDB compacts them. An index database is compacted once enough of its entries were deleted since
+// its last compaction. Admins can also compact the index of a database on demand.
+type IndexCompactionConf struct {
+	// The interval between checks of the index databases; zero disables the scheduled compaction.
+	Interval time.Duration
+	// The number of entries deleted from an index database since its last compaction, or since the node started,
+	// above which it is compacted; if zero, a default is used.
+	MinDeletes uint64
+}
+
+// LogMaskingConf holds the masking of the values and keys of the users in the server log. The components that log the
+// contents of transactions and of their writes, mostly at debug level, mask them, so that debug logging can be enabled
+// in production without leaking the data of the users into the log aggregation.
+type LogMaskingConf struct {
+	// The masking of the values: "hash" replaces a value with a prefix of its hash and its length, "truncate" keeps its
+	// leading bytes, and "none" logs it as it is. If empty, the values are hashed.
+	Mode string
+	// The number of leading bytes of a value that are logged in truncate mode; if zero, a default is used.
+	TruncateLength int
+	// Regular expressions matching the keys that are replaced with their hash in the log.
+	RedactKeys []string
 }
 
 // QueueLengthConf holds the queue length of all queues within the node.
@@ -119,9 +475,69 @@ type QueueLengthConf struct {
 // BlockCreationConf holds the block creation parameters.
 // TODO consider moving this to shared-config if we want to have it consistent across nodes
 type BlockCreationConf struct {
-	MaxBlockSize                uint64
+	// MaxBlockSize is the maximal size of a block in MB. It is superseded by MaxBlockSizeBytes, and may not be set
+	// together with it.
+	MaxBlockSize uint64
+	// MaxBlockSizeBytes is the maximal size of a serialized block in bytes. Data transactions are batched so that
+	// blocks, along with their header and the validation info of their transactions, do not exceed this size. A
+	// transaction that cannot fit in a block is rejected upon submission as too large; it never enters a block.
+	MaxBlockSizeBytes           uint64
 	MaxTransactionCountPerBlock uint32
 	BlockTimeout                time.Duration
+	AdaptiveTimeout             AdaptiveBlockTimeoutConf
+}
+
+// MaxBlockSizeInBytes returns the maximal size of a serialized block in bytes: MaxBlockSizeBytes if set, or else
+// MaxBlockSize converted from MB. Zero means that the size is not limited.
+func (c *BlockCreationConf) MaxBlockSizeInBytes() (uint64, error) {
+	if c.MaxBlockSize > 0 && c.MaxBlockSizeBytes > 0 {
+		return 0, errors.Errorf("blockCreation.maxBlockSize [%d MB] and blockCreation.maxBlockSizeBytes [%d bytes] are mutually exclusive",
+			c.MaxBlockSize, c.MaxBlockSizeBytes)
+	}
+	if c.MaxBlockSizeBytes > 0 {
+		return c.MaxBlockSizeBytes, nil
+	}
+	if c.MaxBlockSize > math.MaxUint64>>20 {
+		return 0, errors.Errorf("blockCreation.maxBlockSize [%d MB] is too large", c.MaxBlockSize)
+	}
+	return c.MaxBlockSize << 20, nil
+}
+
+// AdaptiveBlockTimeoutConf holds the bounds of the adaptive block timeout. When enabled, the block timeout starts at
+// BlockTimeout and adapts to the load: it is shortened when the blocks cut by the timeout are mostly empty, to lower the
+// latency of the transactions, and lengthened when they are mostly full or are cut by the size or count limits, to
+// batch more transactions in a block.
+type AdaptiveBlockTimeoutConf struct {
+	Enabled bool
+	// The bounds of the block timeout; the maximum must not be lower than the minimum, which must be positive.
+	MinBlockTimeout time.Duration
+	MaxBlockTimeout time.Duration
+}
+
+// WitnessConf holds the configuration of a witness node.
+// A witness follows the ledger by pulling blocks from the consensus members, independently re-validates every block
+// and re-computes its trie roots, and raises an alert on any discrepancy with the block it received. A witness does not
+// take part in consensus and does not serve client requests. It must be defined as an observer in the consensus config.
+type WitnessConf struct {
+	// Enabled turns the node into a witness.
+	Enabled bool
+	// HaltOnDiscrepancy stops following the ledger after the first discrepancy is detected, so that the ledger of the
+	// witness is kept at the last block that was verified successfully.
+	HaltOnDiscrepancy bool
+}
+
+// StandbyConf holds the configuration of a node of a standby cluster.
+// A standby cluster is a passive copy of a primary cluster in another region, for disaster recovery. A standby node
+// follows the ledger of the primary cluster asynchronously, by pulling the committed blocks from the primary members,
+// and re-validates every block like a witness. It serves queries, but does not accept transactions until the standby
+// cluster is promoted to active, by a config tx that makes the standby nodes the consensus members. A standby node is
+// bootstrapped with a join block of the primary cluster, and must not be a member or an observer of the primary cluster.
+type StandbyConf struct {
+	// Enabled turns the node into a standby node.
+	Enabled bool
+	// LagProbeInterval is the interval at which the ledger height of the primary members is probed, to compute the
+	// replication lag. If zero, a default is used.
+	LagProbeInterval time.Duration
 }
 
 // BootstrapConf specifies the method of starting a new node with an empty ledger and database.
@@ -195,6 +611,8 @@ func readLocalConfig(localConfigFile string) (*LocalConfiguration, error) {
 
 	v.SetDefault("server.database.name", "leveldb")
 	v.SetDefault("server.database.ledgerDirectory", "./tmp/")
+	v.SetDefault("server.provenance.backend", "leveldb")
+	v.SetDefault("server.queryReplayProtection.required", true)
 
 	if err := v.ReadInConfig(); err != nil {
 		return nil, errors.Wrap(err, "error reading local config file")
@@ -204,5 +622,17 @@ func readLocalConfig(localConfigFile string) (*LocalConfiguration, error) {
 	if err := v.UnmarshalExact(conf); err != nil {
 		return nil, errors.Wrapf(err, "unable to unmarshal local config file: '%s' into struct", localConfigFile)
 	}
+
+	if _, err := conf.BlockCreation.MaxBlockSizeInBytes(); err != nil {
+		return nil, err
+	}
+
+	if conf.Replication.WALDir == "" {
+		conf.Replication.WALDir = path.Join(conf.Server.Database.LedgerDirectory, "raft", "wal")
+	}
+	if conf.Replication.SnapDir == "" {
+		conf.Replication.SnapDir = path.Join(conf.Server.Database.LedgerDirectory, "raft", "snap")
+	}
+
 	return conf, nil
 }
@@ -3,6 +3,10 @@
 package config
 
 import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
 	"testing"
 	"time"
 
@@ -21,25 +25,137 @@ var expectedLocalConfig = &LocalConfiguration{
 			Port:    6001,
 		},
 		Database: DatabaseConf{
-			Name:            "leveldb",
-			LedgerDirectory: "./tmp/",
+			Name:                "leveldb",
+			LedgerDirectory:     "./tmp/",
+			BlockCacheSize:      100,
+			IdentityCacheSize:   1000,
+			SnapshotLeakTimeout: 30 * time.Minute,
+			Blobs: BlobsConf{
+				ValueSizeThreshold: 1048576,
+				ChunkSize:          262144,
+				GCInterval:         time.Hour,
+				GCGracePeriod:      24 * time.Hour,
+			},
+			Encryption: EncryptionConf{
+				KeysDirectory: "./keys/",
+				Databases: []DatabaseKeyConf{
+					{Name: "tenant1", KeyID: "tenant1-2026"},
+					{Name: "tenant2", KeyID: "tenant2-2026"},
+				},
+			},
+		},
+		Provenance: ProvenanceConf{
+			Backend: "leveldb",
 		},
 		QueueLength: QueueLengthConf{
 			Transaction:               1000,
 			ReorderedTransactionBatch: 100,
 			Block:                     100,
 		},
+		Diagnostics: DiagnosticsConf{
+			Enabled: true,
+			Network: NetworkConf{
+				Address: "127.0.0.1",
+				Port:    6101,
+			},
+		},
+		Metrics: MetricsConf{
+			Enabled: true,
+			Network: NetworkConf{
+				Address: "127.0.0.1",
+				Port:    6102,
+			},
+			AllowedCIDRs: []string{"127.0.0.1/32"},
+		},
+		MemoryBudget: MemoryBudgetConf{
+			LimitBytes:    1073741824,
+			SnapshotBytes: 4194304,
+			QueueTimeout:  time.Second,
+		},
+		ResponseEncoding: ResponseEncodingConf{
+			StreamThresholdBytes: 524288,
+			MaxPooledBufferBytes: 131072,
+		},
+		CommitBatching: CommitBatchingConf{
+			MaxBlocks:       16,
+			MaxBlockUpdates: 500,
+			FlushTimeout:    20 * time.Millisecond,
+		},
+		QuotaWebhook: QuotaWebhookConf{
+			URL:     "http://127.0.0.1:9090/quota-alerts",
+			Timeout: 5 * time.Second,
+		},
+		QueryReplayProtection: QueryReplayProtectionConf{
+			Required:         true,
+			TimestampWindow:  2 * time.Minute,
+			MaxNonces:        50000,
+			MaxNoncesPerUser: 5000,
+		},
+		TxReplayProtection: TxReplayProtectionConf{
+			Window: 15 * time.Minute,
+		},
+		Exposure: ExposureConf{
+			AllowedCIDRs: []string{"127.0.0.0/8", "10.0.0.0/8"},
+			Listeners: []ListenerConf{
+				{
+					Network: NetworkConf{
+						Address: "127.0.0.1",
+						Port:    6201,
+					},
+					EndpointGroups: []string{"admin"},
+					AllowedCIDRs:   []string{"127.0.0.1/32"},
+				},
+			},
+		},
+		RequestLimits: RequestLimitsConf{
+			MaxTxBodyBytes:    8388608,
+			MaxQueryBodyBytes: 1048576,
+			MaxAdminBodyBytes: 4194304,
+		},
+		AccessLog: AccessLogConf{
+			Enabled:     true,
+			OutputPath:  "./tmp/access.log",
+			SampleRates: map[string]float64{"query": 0.1},
+			ScrubKeys:   []string{"^ssn-"},
+			HashUserIDs: true,
+		},
+		Canary: CanaryConf{
+			Enabled:  true,
+			Interval: 30 * time.Second,
+			Timeout:  5 * time.Second,
+		},
+		SlowQueryLog: SlowQueryLogConf{
+			Threshold:  500 * time.Millisecond,
+			MaxQueries: 200,
+		},
+		IndexCompaction: IndexCompactionConf{
+			Interval:   10 * time.Minute,
+			MinDeletes: 100000,
+		},
+		LogMasking: LogMaskingConf{
+			Mode:           "truncate",
+			TruncateLength: 16,
+			RedactKeys:     []string{"^ssn-"},
+		},
 		LogLevel: "info",
 	},
 	BlockCreation: BlockCreationConf{
-		MaxBlockSize:                2,
+		MaxBlockSizeBytes:           4194304,
 		MaxTransactionCountPerBlock: 1,
 		BlockTimeout:                50 * time.Millisecond,
+		AdaptiveTimeout: AdaptiveBlockTimeoutConf{
+			Enabled:         true,
+			MinBlockTimeout: 10 * time.Millisecond,
+			MaxBlockTimeout: 500 * time.Millisecond,
+		},
 	},
 	Replication: ReplicationConf{
-		WALDir:  "./tmp/etcdraft/wal",
-		SnapDir: "./tmp/etcdraft/snapshot",
-		AuxDir:  "./tmp/orion/auxiliary",
+		WALDir:                 "./tmp/etcdraft/wal",
+		SnapDir:                "./tmp/etcdraft/snapshot",
+		SnapshotIntervalBlocks: 1000,
+		SnapshotCatchUpEntries: 4,
+		MaxSnapshotFiles:       4,
+		AuxDir:                 "./tmp/orion/auxiliary",
 		Network: NetworkConf{
 			Address: "127.0.0.1",
 			Port:    7050,
@@ -56,6 +172,15 @@ var expectedLocalConfig = &LocalConfiguration{
 				IntermediateCACertsPath: []string{"./testdata/cluster/midca.cert"},
 			},
 		},
+		AllowedCIDRs: []string{"127.0.0.0/8"},
+		Compression: ReplicationCompressionConf{
+			Codecs:        []string{"snappy", "gzip"},
+			MinEntryBytes: 1024,
+		},
+		Throttle: ReplicationThrottleConf{
+			CatchUpBytesPerSecond:  10485760,
+			SnapshotBytesPerSecond: 5242880,
+		},
 	},
 	Bootstrap: BootstrapConf{
 		Method: "genesis",
@@ -115,6 +240,20 @@ func TestLocalConfig(t *testing.T) {
 		require.Equal(t, expectedLocalConfig, config)
 	})
 
+	t.Run("default raft directories", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "local-config")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		configFile := path.Join(dir, "config.yml")
+		require.NoError(t, ioutil.WriteFile(configFile, []byte("server:\n  database:\n    ledgerDirectory: /var/orion/ledger\n"), 0644))
+
+		config, err := readLocalConfig(configFile)
+		require.NoError(t, err)
+		require.Equal(t, "/var/orion/ledger/raft/wal", config.Replication.WALDir)
+		require.Equal(t, "/var/orion/ledger/raft/snap", config.Replication.SnapDir)
+	})
+
 	t.Run("empty-config-path", func(t *testing.T) {
 		config, err := readLocalConfig("")
 		require.EqualError(t, err, "path to the local configuration file is empty")
@@ -133,3 +272,23 @@ func TestLocalConfig(t *testing.T) {
 		require.Nil(t, config)
 	})
 }
+
+func TestBlockCreationConf_MaxBlockSizeInBytes(t *testing.T) {
+	size, err := (&BlockCreationConf{}).MaxBlockSizeInBytes()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), size)
+
+	size, err = (&BlockCreationConf{MaxBlockSize: 2}).MaxBlockSizeInBytes()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2*1024*1024), size)
+
+	size, err = (&BlockCreationConf{MaxBlockSizeBytes: 4096}).MaxBlockSizeInBytes()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4096), size)
+
+	_, err = (&BlockCreationConf{MaxBlockSize: 2, MaxBlockSizeBytes: 4096}).MaxBlockSizeInBytes()
+	require.EqualError(t, err, "blockCreation.maxBlockSize [2 MB] and blockCreation.maxBlockSizeBytes [4096 bytes] are mutually exclusive")
+
+	_, err = (&BlockCreationConf{MaxBlockSize: math.MaxUint64 >> 10}).MaxBlockSizeInBytes()
+	require.EqualError(t, err, "blockCreation.maxBlockSize [18014398509481983 MB] is too large")
+}
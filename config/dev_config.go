@@ -0,0 +1,278 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DevNodeID is the identity of the single node started in development mode.
+	DevNodeID = "bdb-dev-node"
+	// DevAdminID is the identity of the cluster admin created in development mode.
+	DevAdminID = "admin"
+
+	devRootCAName = "rootCA"
+	devCryptoDir  = "crypto"
+	devLedgerDir  = "ledger"
+	devHost       = "127.0.0.1"
+	devNodePort   = 6001
+	devPeerPort   = 7050
+)
+
+// DevConfig creates the configuration of a single-node development cluster rooted at `dir`.
+// On first use, it generates a self-signed root CA and issues the node and admin certificates under `dir/crypto`.
+// On subsequent uses the existing crypto material is reused, so that a ledger created earlier can be reopened.
+// The returned configuration bootstraps the genesis block from the generated shared configuration,
+// and uses relaxed timeouts that are suitable for a developer workstation. It must not be used in production.
+func DevConfig(dir string) (*Configurations, error) {
+	if dir == "" {
+		return nil, errors.New("path to the development directory is empty")
+	}
+
+	cryptoDir := path.Join(dir, devCryptoDir)
+	if err := generateDevCrypto(cryptoDir); err != nil {
+		return nil, errors.WithMessagef(err, "failed to generate development crypto material in: '%s'", cryptoDir)
+	}
+
+	ledgerDir := path.Join(dir, devLedgerDir)
+	conf := &Configurations{
+		LocalConfig: &LocalConfiguration{
+			Server: ServerConf{
+				Identity: IdentityConf{
+					ID:              DevNodeID,
+					CertificatePath: path.Join(cryptoDir, DevNodeID+".pem"),
+					KeyPath:         path.Join(cryptoDir, DevNodeID+".key"),
+				},
+				Network: NetworkConf{
+					Address: devHost,
+					Port:    devNodePort,
+				},
+				Database: DatabaseConf{
+					Name:            "leveldb",
+					LedgerDirectory: ledgerDir,
+				},
+				QueueLength: QueueLengthConf{
+					Transaction:               1000,
+					ReorderedTransactionBatch: 100,
+					Block:                     100,
+				},
+				LogLevel: "info",
+			},
+			BlockCreation: BlockCreationConf{
+				MaxBlockSizeBytes:           4 * 1024 * 1024,
+				MaxTransactionCountPerBlock: 100,
+				BlockTimeout:                100 * time.Millisecond,
+			},
+			Replication: ReplicationConf{
+				WALDir:  path.Join(ledgerDir, "etcdraft", "wal"),
+				SnapDir: path.Join(ledgerDir, "etcdraft", "snapshot"),
+				AuxDir:  path.Join(ledgerDir, "auxiliary"),
+				Network: NetworkConf{
+					Address: devHost,
+					Port:    devPeerPort,
+				},
+			},
+			Bootstrap: BootstrapConf{
+				Method: "genesis",
+			},
+		},
+		SharedConfig: &SharedConfiguration{
+			Nodes: []*NodeConf{
+				{
+					NodeID:          DevNodeID,
+					Host:            devHost,
+					Port:            devNodePort,
+					CertificatePath: path.Join(cryptoDir, DevNodeID+".pem"),
+				},
+			},
+			Consensus: &ConsensusConf{
+				Algorithm: "raft",
+				Members: []*PeerConf{
+					{
+						NodeId:   DevNodeID,
+						RaftId:   1,
+						PeerHost: devHost,
+						PeerPort: devPeerPort,
+					},
+				},
+				RaftConfig: &RaftConf{
+					TickInterval:         "100ms",
+					ElectionTicks:        100,
+					HeartbeatTicks:       10,
+					MaxInflightBlocks:    50,
+					SnapshotIntervalSize: math.MaxUint64,
+				},
+			},
+			CAConfig: CAConfiguration{
+				RootCACertsPath: []string{path.Join(cryptoDir, devRootCAName+".pem")},
+			},
+			Admin: AdminConf{
+				ID:              DevAdminID,
+				CertificatePath: path.Join(cryptoDir, DevAdminID+".pem"),
+			},
+		},
+	}
+
+	return conf, nil
+}
+
+// generateDevCrypto creates the key pairs of the root CA, the node, and the admin in `cryptoDir`, unless they already
+// exist. A missing certificate is issued by the existing root CA. If the root CA itself is missing, a new one is
+// created and all certificates are re-issued, as the existing ones would not verify against it.
+func generateDevCrypto(cryptoDir string) error {
+	if err := os.MkdirAll(cryptoDir, 0755); err != nil {
+		return errors.Wrap(err, "error while creating the crypto directory")
+	}
+
+	caCert, caKey, err := loadDevKeyPair(cryptoDir, devRootCAName)
+	if err != nil {
+		return err
+	}
+	reissue := caCert == nil
+	if caCert == nil {
+		caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return errors.Wrap(err, "error while generating the CA key")
+		}
+		caTemplate, err := devCertTemplate("Orion Dev RootCA")
+		if err != nil {
+			return err
+		}
+		caTemplate.KeyUsage |= x509.KeyUsageCertSign
+		caTemplate.IsCA = true
+
+		caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+		if err != nil {
+			return errors.Wrap(err, "error while creating the CA certificate")
+		}
+		if err = writeDevKeyPair(cryptoDir, devRootCAName, caDER, caKey); err != nil {
+			return err
+		}
+
+		caCert, err = x509.ParseCertificate(caDER)
+		if err != nil {
+			return errors.Wrap(err, "error while parsing the CA certificate")
+		}
+	}
+
+	for _, name := range []string{DevNodeID, DevAdminID} {
+		if !reissue {
+			cert, _, err := loadDevKeyPair(cryptoDir, name)
+			if err != nil {
+				return err
+			}
+			if cert != nil {
+				continue
+			}
+		}
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return errors.Wrapf(err, "error while generating the key of %s", name)
+		}
+		template, err := devCertTemplate("Orion Dev " + name)
+		if err != nil {
+			return err
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+		if err != nil {
+			return errors.Wrapf(err, "error while issuing the certificate of %s", name)
+		}
+		if err = writeDevKeyPair(cryptoDir, name, der, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadDevKeyPair reads the certificate and the key of `name` from `dir`. It returns nil if either file is missing.
+func loadDevKeyPair(dir, name string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certPEM, err := ioutil.ReadFile(path.Join(dir, name+".pem"))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while reading the certificate of %s", name)
+	}
+	keyPEM, err := ioutil.ReadFile(path.Join(dir, name+".key"))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while reading the key of %s", name)
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, nil, errors.Errorf("error while decoding the certificate of %s", name)
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while parsing the certificate of %s", name)
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, errors.Errorf("error while decoding the key of %s", name)
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while parsing the key of %s", name)
+	}
+
+	return cert, key, nil
+}
+
+func devCertTemplate(subjectCN string) (*x509.Certificate, error) {
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	sn, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while generating a certificate serial number")
+	}
+
+	return &x509.Certificate{
+		Subject:               pkix.Name{CommonName: subjectCN},
+		SerialNumber:          sn,
+		NotBefore:             time.Now().Add(-5 * time.Minute),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IPAddresses:           []net.IP{net.ParseIP(devHost)},
+	}, nil
+}
+
+func writeDevKeyPair(dir, name string, certDER []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Wrapf(err, "error while marshaling the key of %s", name)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	if err = ioutil.WriteFile(path.Join(dir, name+".pem"), certPEM, 0644); err != nil {
+		return errors.Wrapf(err, "error while writing the certificate of %s", name)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err = ioutil.WriteFile(path.Join(dir, name+".key"), keyPEM, 0600); err != nil {
+		return errors.Wrapf(err, "error while writing the key of %s", name)
+	}
+
+	return nil
+}
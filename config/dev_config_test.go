@@ -0,0 +1,91 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package config
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevConfig(t *testing.T) {
+	t.Run("empty dir", func(t *testing.T) {
+		conf, err := DevConfig("")
+		require.EqualError(t, err, "path to the development directory is empty")
+		require.Nil(t, conf)
+	})
+
+	t.Run("generate and reuse crypto", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "dev-config")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		conf, err := DevConfig(dir)
+		require.NoError(t, err)
+		require.Equal(t, "genesis", conf.LocalConfig.Bootstrap.Method)
+		require.Equal(t, DevNodeID, conf.LocalConfig.Server.Identity.ID)
+		require.Len(t, conf.SharedConfig.Nodes, 1)
+		require.Len(t, conf.SharedConfig.Consensus.Members, 1)
+		require.Equal(t, DevAdminID, conf.SharedConfig.Admin.ID)
+		require.Equal(t, path.Join(dir, "ledger"), conf.LocalConfig.Server.Database.LedgerDirectory)
+
+		caCert := readDevCert(t, conf.SharedConfig.CAConfig.RootCACertsPath[0])
+		require.True(t, caCert.IsCA)
+		for _, certPath := range []string{conf.SharedConfig.Nodes[0].CertificatePath, conf.SharedConfig.Admin.CertificatePath} {
+			cert := readDevCert(t, certPath)
+			require.NoError(t, cert.CheckSignatureFrom(caCert))
+		}
+		_, err = os.Stat(conf.LocalConfig.Server.Identity.KeyPath)
+		require.NoError(t, err)
+
+		conf2, err := DevConfig(dir)
+		require.NoError(t, err)
+		require.Equal(t, conf, conf2)
+		require.Equal(t, caCert.Raw, readDevCert(t, conf2.SharedConfig.CAConfig.RootCACertsPath[0]).Raw)
+	})
+
+	t.Run("issue missing certificates", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "dev-config")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		conf, err := DevConfig(dir)
+		require.NoError(t, err)
+		caCert := readDevCert(t, conf.SharedConfig.CAConfig.RootCACertsPath[0])
+		adminCert := readDevCert(t, conf.SharedConfig.Admin.CertificatePath)
+
+		require.NoError(t, os.Remove(conf.LocalConfig.Server.Identity.KeyPath))
+		_, err = DevConfig(dir)
+		require.NoError(t, err)
+		require.Equal(t, caCert.Raw, readDevCert(t, conf.SharedConfig.CAConfig.RootCACertsPath[0]).Raw)
+		require.Equal(t, adminCert.Raw, readDevCert(t, conf.SharedConfig.Admin.CertificatePath).Raw)
+		nodeCert := readDevCert(t, conf.SharedConfig.Nodes[0].CertificatePath)
+		require.NoError(t, nodeCert.CheckSignatureFrom(caCert))
+		_, err = os.Stat(conf.LocalConfig.Server.Identity.KeyPath)
+		require.NoError(t, err)
+
+		require.NoError(t, os.Remove(conf.SharedConfig.CAConfig.RootCACertsPath[0]))
+		_, err = DevConfig(dir)
+		require.NoError(t, err)
+		newCACert := readDevCert(t, conf.SharedConfig.CAConfig.RootCACertsPath[0])
+		require.NotEqual(t, caCert.Raw, newCACert.Raw)
+		for _, certPath := range []string{conf.SharedConfig.Nodes[0].CertificatePath, conf.SharedConfig.Admin.CertificatePath} {
+			require.NoError(t, readDevCert(t, certPath).CheckSignatureFrom(newCACert))
+		}
+	})
+}
+
+func readDevCert(t *testing.T, certPath string) *x509.Certificate {
+	b, err := ioutil.ReadFile(certPath)
+	require.NoError(t, err)
+	bl, _ := pem.Decode(b)
+	require.NotNil(t, bl)
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	require.NoError(t, err)
+	return cert
+}
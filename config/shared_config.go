@@ -28,15 +28,18 @@ type SharedConfiguration struct {
 // The Host and Port are those that are accessible from clients.
 // The certificate is the one used to authenticate with clients and validate the server;s signature on
 // blocks and transaction/query responses.
+// The Region and Zone are optional locality labels, exposed to clients so that they can prefer nearby nodes for queries.
 type NodeConf struct {
 	NodeID          string
 	Host            string
 	Port            uint32
 	CertificatePath string
+	Region          string
+	Zone            string
 }
 
 type ConsensusConf struct {
-	// The consensus algorithm, currently only "raft" is supported.
+	// The consensus algorithm: "raft", or the algorithm of an external ordering service registered with the server.
 	Algorithm string
 	// Peers that take part in consensus.
 	Members []*PeerConf
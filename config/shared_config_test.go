@@ -15,18 +15,24 @@ var expectedSharedConfig = &SharedConfiguration{
 			Host:            "bcdb1.example.com",
 			Port:            6001,
 			CertificatePath: "./testdata/cluster/bcdb-node1/node.cert",
+			Region:          "us-east",
+			Zone:            "us-east-1a",
 		},
 		{
 			NodeID:          "bcdb-node2",
 			Host:            "bcdb2.example.com",
 			Port:            6001,
 			CertificatePath: "./testdata/cluster/bcdb-node2/node.cert",
+			Region:          "us-east",
+			Zone:            "us-east-1b",
 		},
 		{
 			NodeID:          "bcdb-node3",
 			Host:            "bcdb3.example.com",
 			Port:            6001,
 			CertificatePath: "./testdata/cluster/bcdb-node3/node.cert",
+			Region:          "eu-west",
+			Zone:            "eu-west-1a",
 		},
 	},
 	Consensus: &ConsensusConf{
@@ -0,0 +1,267 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package accesslog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// keyQueryParams are the query parameters whose values are keys, or parts of keys
+var keyQueryParams = []string{"prefix", "after", "key", "value"}
+
+// keyRoutes are the routes whose last path segment is a key
+var keyRoutes = []string{
+	constants.GetData,
+	constants.GetDataProof,
+	constants.GetHistoricalData,
+	constants.GetDataReaders,
+	constants.GetDataWriters,
+}
+
+// auditRoutes are the routes of the reads of the cluster config by admins, whose requests are audited: they are always
+// logged, regardless of the sample rates, and their entries are marked as audit entries
+var auditRoutes = []struct {
+	path   string
+	method string
+}{
+	{path: constants.GetConfig, method: http.MethodGet},
+	{path: constants.GetLastConfigBlock, method: http.MethodGet},
+	{path: constants.GetConfigHistory, method: http.MethodGet},
+	{path: constants.GetConfigDiff, method: http.MethodGet},
+	{path: constants.PostConfigTxDryRun, method: http.MethodPost},
+}
+
+// Logger writes the access log of HTTP requests, see config.AccessLogConf
+type Logger struct {
+	logger      *zap.Logger
+	sampleRates map[string]float64
+	scrubKeys   []*regexp.Regexp
+	hashUserIDs bool
+	keyRouter   *mux.Router
+	auditRouter *mux.Router
+	// groupOf returns the endpoint group of a request
+	groupOf func(r *http.Request) string
+
+	randLock sync.Mutex
+	randFn   func() float64
+}
+
+// New creates an access log, whose entries carry the endpoint groups returned by groupOf
+func New(conf *config.AccessLogConf, groupOf func(r *http.Request) string) (*Logger, error) {
+	for group, rate := range conf.SampleRates {
+		if rate < 0 || rate > 1 {
+			return nil, errors.Errorf("the sample rate [%v] of the endpoint group [%s] must be between 0 and 1", rate, group)
+		}
+	}
+
+	var scrubKeys []*regexp.Regexp
+	for _, expr := range conf.ScrubKeys {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid expression [%s] of the keys to scrub", expr)
+		}
+		scrubKeys = append(scrubKeys, re)
+	}
+
+	outputPath := conf.OutputPath
+	if outputPath == "" {
+		outputPath = "stdout"
+	}
+	zapConf := zap.Config{
+		Encoding:         "json",
+		Level:            zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		OutputPaths:      []string{outputPath},
+		ErrorOutputPaths: []string{"stderr"},
+		EncoderConfig: zapcore.EncoderConfig{
+			MessageKey:     "message",
+			TimeKey:        "time",
+			EncodeTime:     zapcore.ISO8601TimeEncoder,
+			EncodeDuration: zapcore.MillisDurationEncoder,
+		},
+	}
+	l, err := zapConf.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating the access log")
+	}
+
+	keyRouter := mux.NewRouter()
+	for _, route := range keyRoutes {
+		keyRouter.NewRoute().Path(route)
+	}
+
+	auditRouter := mux.NewRouter()
+	for _, route := range auditRoutes {
+		auditRouter.NewRoute().Path(route.path).Methods(route.method)
+	}
+
+	return &Logger{
+		logger:      l,
+		sampleRates: conf.SampleRates,
+		scrubKeys:   scrubKeys,
+		hashUserIDs: conf.HashUserIDs,
+		keyRouter:   keyRouter,
+		auditRouter: auditRouter,
+		groupOf:     groupOf,
+		randFn:      rand.Float64,
+	}, nil
+}
+
+// Handler returns a handler that serves requests with next, and writes them to the access log
+func (l *Logger) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		body := &countingReader{}
+		if r.Body != nil {
+			body.ReadCloser = r.Body
+			r.Body = body
+		}
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		group := l.groupOf(r)
+		audit := l.auditRouter.Match(r, &mux.RouteMatch{})
+		if !audit && rw.status < http.StatusBadRequest && !l.sampled(group) {
+			return
+		}
+
+		userID := r.Header.Get(constants.UserHeader)
+		if l.hashUserIDs && userID != "" {
+			userID = hash(userID)
+		}
+		l.logger.Info("request",
+			zap.String("method", r.Method),
+			zap.String("path", l.scrubPath(r)),
+			zap.String("query", l.scrubQuery(r.URL.Query())),
+			zap.String("group", group),
+			zap.Int("status", rw.status),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int64("request_bytes", body.n),
+			zap.Int64("response_bytes", rw.n),
+			zap.String("user_id", userID),
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.Bool("audit", audit),
+		)
+	})
+}
+
+// Close flushes the access log
+func (l *Logger) Close() error {
+	// syncing stdout or stderr fails on some platforms, which is harmless
+	_ = l.logger.Sync()
+	return nil
+}
+
+func (l *Logger) sampled(group string) bool {
+	rate, ok := l.sampleRates[group]
+	if !ok || rate >= 1 {
+		return true
+	}
+
+	l.randLock.Lock()
+	defer l.randLock.Unlock()
+	return l.randFn() < rate
+}
+
+// scrubPath returns the path of a request, in which a key that must be scrubbed is replaced with its hash
+func (l *Logger) scrubPath(r *http.Request) string {
+	p := r.URL.EscapedPath()
+	match := &mux.RouteMatch{}
+	if !l.keyRouter.Match(r, match) {
+		return p
+	}
+
+	key := match.Vars["key"]
+	if !l.mustScrub(key) {
+		return p
+	}
+	return p[:strings.LastIndex(p, "/")+1] + hash(key)
+}
+
+// scrubQuery returns the query parameters of a request, in which a key that must be scrubbed is replaced with its hash
+func (l *Logger) scrubQuery(params url.Values) string {
+	for _, name := range keyQueryParams {
+		values, ok := params[name]
+		if !ok {
+			continue
+		}
+		for i, v := range values {
+			if l.mustScrub(v) {
+				values[i] = hash(v)
+			}
+		}
+	}
+	return params.Encode()
+}
+
+func (l *Logger) mustScrub(key string) bool {
+	for _, re := range l.scrubKeys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func hash(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(h[:8])
+}
+
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// responseWriter records the status and the size of a response
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	n           int64
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.n += int64(n)
+	return n, err
+}
+
+// Flush allows to stream a response through the access log
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
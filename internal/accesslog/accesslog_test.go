@@ -0,0 +1,114 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package accesslog
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "accesslog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	logPath := path.Join(dir, "access.log")
+
+	l, err := New(&config.AccessLogConf{
+		Enabled:     true,
+		OutputPath:  logPath,
+		SampleRates: map[string]float64{"query": 0.5},
+		ScrubKeys:   []string{"^ssn-"},
+		HashUserIDs: true,
+	}, func(r *http.Request) string {
+		if r.Method == http.MethodPost {
+			return "tx"
+		}
+		return "query"
+	})
+	require.NoError(t, err)
+	sample := 0.0
+	l.randFn = func() float64 { return sample }
+
+	h := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = ioutil.ReadAll(r.Body)
+		if strings.HasSuffix(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	serve := func(method, target, body string) {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(constants.UserHeader, "alice")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve(http.MethodPost, constants.PostDataTx, "0123456789")
+	serve(http.MethodGet, constants.URLForGetData("db1", "ssn-123"), "")
+	serve(http.MethodGet, constants.URLForGetData("db1", "public-key"), "")
+	serve(http.MethodGet, constants.URLForGetDataKeys("db1", "ssn-", false), "")
+	// not sampled
+	sample = 0.9
+	serve(http.MethodGet, constants.URLForGetData("db1", "k1"), "")
+	// failures are always logged
+	serve(http.MethodGet, constants.URLForGetData("db1", "missing"), "")
+	// the reads of the cluster config are audited, and always logged
+	serve(http.MethodGet, constants.URLForGetConfig(), "")
+	serve(http.MethodGet, constants.URLForGetConfigDiff(1, 5), "")
+	require.NoError(t, l.Close())
+
+	f, err := os.Open(logPath)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 7)
+
+	require.Equal(t, "POST", entries[0]["method"])
+	require.Equal(t, constants.PostDataTx, entries[0]["path"])
+	require.Equal(t, "tx", entries[0]["group"])
+	require.Equal(t, float64(http.StatusOK), entries[0]["status"])
+	require.Equal(t, float64(10), entries[0]["request_bytes"])
+	require.Equal(t, float64(5), entries[0]["response_bytes"])
+	require.Equal(t, hash("alice"), entries[0]["user_id"])
+	require.Contains(t, entries[0], "latency")
+
+	require.Equal(t, "/data/db1/"+hash("ssn-123"), entries[1]["path"])
+	require.Equal(t, "/data/db1/public-key", entries[2]["path"])
+	require.Equal(t, "prefix="+strings.Replace(hash("ssn-"), ":", "%3A", 1), entries[3]["query"])
+	require.Equal(t, "/data/db1/missing", entries[4]["path"])
+	require.Equal(t, float64(http.StatusNotFound), entries[4]["status"])
+	require.Equal(t, false, entries[4]["audit"])
+	require.Equal(t, constants.GetConfig, entries[5]["path"])
+	require.Equal(t, true, entries[5]["audit"])
+	require.Equal(t, "/config/diff/1/5", entries[6]["path"])
+	require.Equal(t, true, entries[6]["audit"])
+}
+
+func TestAccessLogInvalidConfig(t *testing.T) {
+	groupOf := func(r *http.Request) string { return "query" }
+
+	l, err := New(&config.AccessLogConf{SampleRates: map[string]float64{"query": 1.5}}, groupOf)
+	require.EqualError(t, err, "the sample rate [1.5] of the endpoint group [query] must be between 0 and 1")
+	require.Nil(t, l)
+
+	l, err = New(&config.AccessLogConf{ScrubKeys: []string{"("}}, groupOf)
+	require.EqualError(t, err, "invalid expression [(] of the keys to scrub: error parsing regexp: missing closing ): `(`")
+	require.Nil(t, l)
+}
@@ -0,0 +1,163 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package arrowipc
+
+import (
+	"encoding/binary"
+)
+
+// builder builds a flatbuffer from its end towards its front, as the reference flatbuffers builder does, so that an
+// object refers only to the objects built before it. It supports the few constructs of the Arrow IPC metadata, i.e.,
+// tables of scalars and offsets, strings, and vectors of offsets and structs. Offsets are measured from the end of the
+// buffer.
+type builder struct {
+	bytes     []byte
+	minalign  int
+	vtable    []int
+	objectEnd int
+}
+
+func (b *builder) offset() int {
+	return len(b.bytes)
+}
+
+func (b *builder) place(v []byte) {
+	b.bytes = append(v, b.bytes...)
+}
+
+// prep pads the buffer so that a scalar of the given size is aligned once the additional bytes are written
+func (b *builder) prep(size, additional int) {
+	if size > b.minalign {
+		b.minalign = size
+	}
+	if pad := (size - (len(b.bytes)+additional)%size) % size; pad > 0 {
+		b.place(make([]byte, pad))
+	}
+}
+
+func (b *builder) prependUint8(v uint8) {
+	b.prep(1, 0)
+	b.place([]byte{v})
+}
+
+func (b *builder) prependUint16(v uint16) {
+	b.prep(2, 0)
+	buf := make([]byte, 2)
+	binary.LittleEndian.PutUint16(buf, v)
+	b.place(buf)
+}
+
+func (b *builder) prependUint32(v uint32) {
+	b.prep(4, 0)
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, v)
+	b.place(buf)
+}
+
+func (b *builder) prependUint64(v uint64) {
+	b.prep(8, 0)
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, v)
+	b.place(buf)
+}
+
+// prependOffset prepends the offset of an object built before, relative to the position of the offset itself
+func (b *builder) prependOffset(off int) {
+	b.prep(4, 0)
+	b.prependUint32(uint32(b.offset() + 4 - off))
+}
+
+func (b *builder) createString(s string) int {
+	b.prep(4, len(s)+1)
+	b.place(append([]byte(s), 0))
+	b.prependUint32(uint32(len(s)))
+	return b.offset()
+}
+
+// startVector prepares the buffer for the elements of a vector, which are then prepended in reverse order
+func (b *builder) startVector(elemSize, numElems, alignment int) {
+	b.prep(4, elemSize*numElems)
+	b.prep(alignment, elemSize*numElems)
+}
+
+func (b *builder) endVector(numElems int) int {
+	b.prependUint32(uint32(numElems))
+	return b.offset()
+}
+
+func (b *builder) startObject(numFields int) {
+	b.vtable = make([]int, numFields)
+	b.objectEnd = b.offset()
+}
+
+// The add functions skip a field that holds its default value, which a reader returns for a field that is absent
+
+func (b *builder) addUint8(slot int, v, def uint8) {
+	if v != def {
+		b.prependUint8(v)
+		b.vtable[slot] = b.offset()
+	}
+}
+
+func (b *builder) addUint16(slot int, v, def uint16) {
+	if v != def {
+		b.prependUint16(v)
+		b.vtable[slot] = b.offset()
+	}
+}
+
+func (b *builder) addUint32(slot int, v, def uint32) {
+	if v != def {
+		b.prependUint32(v)
+		b.vtable[slot] = b.offset()
+	}
+}
+
+func (b *builder) addUint64(slot int, v, def uint64) {
+	if v != def {
+		b.prependUint64(v)
+		b.vtable[slot] = b.offset()
+	}
+}
+
+func (b *builder) addOffset(slot, off int) {
+	if off != 0 {
+		b.prependOffset(off)
+		b.vtable[slot] = b.offset()
+	}
+}
+
+// endObject writes the table and its vtable, which precedes it and is referred to by the signed offset at the start of
+// the table
+func (b *builder) endObject() int {
+	b.prependUint32(0)
+	objectOffset := b.offset()
+
+	numFields := len(b.vtable)
+	for numFields > 0 && b.vtable[numFields-1] == 0 {
+		numFields--
+	}
+	for i := numFields - 1; i >= 0; i-- {
+		var fieldOffset uint16
+		if b.vtable[i] != 0 {
+			fieldOffset = uint16(objectOffset - b.vtable[i])
+		}
+		b.prependUint16(fieldOffset)
+	}
+	b.prependUint16(uint16(objectOffset - b.objectEnd))
+	b.prependUint16(uint16((numFields + 2) * 2))
+
+	vtableOffset := b.offset()
+	binary.LittleEndian.PutUint32(b.bytes[len(b.bytes)-objectOffset:], uint32(vtableOffset-objectOffset))
+	b.vtable = nil
+
+	return objectOffset
+}
+
+// finish writes the offset of the root table, and pads the buffer so that its start is aligned as its end
+func (b *builder) finish(root int) []byte {
+	b.prep(b.minalign, 4)
+	b.prependOffset(root)
+	return b.bytes
+}
@@ -0,0 +1,238 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package arrowipc writes key-value pairs in the Apache Arrow IPC streaming format, which analytical tools such as
+// pandas and Spark load directly, without decoding JSON. See https://arrow.apache.org/docs/format/Columnar.html for the
+// format.
+package arrowipc
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RowsPerBatch is the maximum number of key-value pairs in a record batch of the stream
+	RowsPerBatch = 4096
+
+	// metadataVersionV5 is the version of the Arrow IPC metadata
+	metadataVersionV5 = 4
+
+	// the types of the headers of the messages
+	headerSchema      = 1
+	headerRecordBatch = 3
+
+	// the types of the columns
+	typeInt    = 2
+	typeBinary = 4
+	typeUtf8   = 5
+
+	// alignment is the alignment of the messages and the buffers of the stream
+	alignment = 8
+)
+
+// continuationMarker precedes the length of the metadata of every message
+var continuationMarker = []byte{0xff, 0xff, 0xff, 0xff}
+
+type column struct {
+	name     string
+	typeType uint8
+	bitWidth uint32
+}
+
+// columns are the columns of the stream: the key and the value, and the version of the value
+var columns = []column{
+	{name: "key", typeType: typeUtf8},
+	{name: "value", typeType: typeBinary},
+	{name: "block_num", typeType: typeInt, bitWidth: 64},
+	{name: "tx_num", typeType: typeInt, bitWidth: 64},
+}
+
+// WriteKVs writes the key-value pairs as an Arrow IPC stream, which holds a column for the key (utf8), the value
+// (binary), and the block and transaction numbers of the version of the value (uint64). No column is nullable. The
+// key-value pairs are written in record batches of up to RowsPerBatch rows.
+func WriteKVs(w io.Writer, kvs []*types.KVWithMetadata) error {
+	if err := writeMessage(w, schemaMessage(), nil); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(kvs); start += RowsPerBatch {
+		end := start + RowsPerBatch
+		if end > len(kvs) {
+			end = len(kvs)
+		}
+
+		metadata, body, err := recordBatchMessage(kvs[start:end])
+		if err != nil {
+			return err
+		}
+		if err := writeMessage(w, metadata, body); err != nil {
+			return err
+		}
+	}
+
+	// the end of the stream is marked by a message without metadata
+	if _, err := w.Write(append(continuationMarker, 0, 0, 0, 0)); err != nil {
+		return errors.Wrap(err, "error while writing the end of the stream")
+	}
+	return nil
+}
+
+// writeMessage writes the continuation marker, the length of the metadata, the metadata padded to the alignment, and
+// the body
+func writeMessage(w io.Writer, metadata, body []byte) error {
+	padded := paddedLen(len(metadata))
+	msg := make([]byte, 8+padded, 8+padded+len(body))
+	copy(msg, continuationMarker)
+	binary.LittleEndian.PutUint32(msg[4:], uint32(padded))
+	copy(msg[8:], metadata)
+	msg = append(msg, body...)
+
+	if _, err := w.Write(msg); err != nil {
+		return errors.Wrap(err, "error while writing a message of the stream")
+	}
+	return nil
+}
+
+func paddedLen(n int) int {
+	return (n + alignment - 1) / alignment * alignment
+}
+
+func schemaMessage() []byte {
+	b := &builder{}
+
+	fields := make([]int, len(columns))
+	for i, c := range columns {
+		name := b.createString(c.name)
+
+		b.startObject(2)
+		if c.typeType == typeInt {
+			b.addUint32(0, c.bitWidth, 0)
+		}
+		colType := b.endObject()
+
+		b.startVector(4, 0, 4)
+		children := b.endVector(0)
+
+		b.startObject(7)
+		b.addOffset(0, name)
+		b.addUint8(2, c.typeType, 0)
+		b.addOffset(3, colType)
+		b.addOffset(5, children)
+		fields[i] = b.endObject()
+	}
+
+	b.startVector(4, len(fields), 4)
+	for i := len(fields) - 1; i >= 0; i-- {
+		b.prependOffset(fields[i])
+	}
+	fieldsVector := b.endVector(len(fields))
+
+	b.startObject(4)
+	b.addOffset(1, fieldsVector)
+	schema := b.endObject()
+
+	return finishMessage(b, headerSchema, schema, 0)
+}
+
+// buffer is the location of a buffer in the body of a record batch
+type buffer struct {
+	offset uint64
+	length uint64
+}
+
+func recordBatchMessage(kvs []*types.KVWithMetadata) ([]byte, []byte, error) {
+	var body []byte
+	var buffers []buffer
+	addBuffer := func(data []byte) {
+		buffers = append(buffers, buffer{offset: uint64(len(body)), length: uint64(len(data))})
+		body = append(body, data...)
+		body = append(body, make([]byte, paddedLen(len(data))-len(data))...)
+	}
+
+	// a column that has no nulls has an empty validity buffer
+	keys, err := variableLengthBuffers(kvs, func(kv *types.KVWithMetadata) []byte { return []byte(kv.Key) })
+	if err != nil {
+		return nil, nil, err
+	}
+	addBuffer(nil)
+	addBuffer(keys[0])
+	addBuffer(keys[1])
+
+	values, err := variableLengthBuffers(kvs, func(kv *types.KVWithMetadata) []byte { return kv.Value })
+	if err != nil {
+		return nil, nil, err
+	}
+	addBuffer(nil)
+	addBuffer(values[0])
+	addBuffer(values[1])
+
+	addBuffer(nil)
+	addBuffer(uint64Buffer(kvs, func(kv *types.KVWithMetadata) uint64 { return kv.GetMetadata().GetVersion().GetBlockNum() }))
+	addBuffer(nil)
+	addBuffer(uint64Buffer(kvs, func(kv *types.KVWithMetadata) uint64 { return kv.GetMetadata().GetVersion().GetTxNum() }))
+
+	b := &builder{}
+
+	b.startVector(16, len(buffers), 8)
+	for i := len(buffers) - 1; i >= 0; i-- {
+		b.prep(8, 16)
+		b.prependUint64(buffers[i].length)
+		b.prependUint64(buffers[i].offset)
+	}
+	buffersVector := b.endVector(len(buffers))
+
+	// every column holds a field node of its length and null count
+	b.startVector(16, len(columns), 8)
+	for range columns {
+		b.prep(8, 16)
+		b.prependUint64(0)
+		b.prependUint64(uint64(len(kvs)))
+	}
+	nodesVector := b.endVector(len(columns))
+
+	b.startObject(5)
+	b.addUint64(0, uint64(len(kvs)), 0)
+	b.addOffset(1, nodesVector)
+	b.addOffset(2, buffersVector)
+	recordBatch := b.endObject()
+
+	return finishMessage(b, headerRecordBatch, recordBatch, uint64(len(body))), body, nil
+}
+
+// variableLengthBuffers returns the offsets buffer and the data buffer of a column of variable length values. The
+// offsets are 32-bit, hence the values of a record batch cannot exceed 2 GiB in total.
+func variableLengthBuffers(kvs []*types.KVWithMetadata, get func(kv *types.KVWithMetadata) []byte) ([2][]byte, error) {
+	offsets := make([]byte, 4*(len(kvs)+1))
+	var data []byte
+	for i, kv := range kvs {
+		data = append(data, get(kv)...)
+		if len(data) > math.MaxInt32 {
+			return [2][]byte{}, errors.New("the values of a record batch exceed 2 GiB")
+		}
+		binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(len(data)))
+	}
+
+	return [2][]byte{offsets, data}, nil
+}
+
+func uint64Buffer(kvs []*types.KVWithMetadata, get func(kv *types.KVWithMetadata) uint64) []byte {
+	buf := make([]byte, 8*len(kvs))
+	for i, kv := range kvs {
+		binary.LittleEndian.PutUint64(buf[8*i:], get(kv))
+	}
+	return buf
+}
+
+func finishMessage(b *builder, headerType uint8, header int, bodyLength uint64) []byte {
+	b.startObject(5)
+	b.addUint64(3, bodyLength, 0)
+	b.addOffset(2, header)
+	b.addUint16(0, metadataVersionV5, 0)
+	b.addUint8(1, headerType, 0)
+	return b.finish(b.endObject())
+}
@@ -0,0 +1,271 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package arrowipc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fbTable reads a table of a flatbuffer, and checks that every scalar it reads is aligned to its size
+type fbTable struct {
+	t   *testing.T
+	buf []byte
+	pos int
+}
+
+func rootTable(t *testing.T, buf []byte) fbTable {
+	return fbTable{t: t, buf: buf, pos: int(binary.LittleEndian.Uint32(buf))}
+}
+
+func (tb fbTable) fieldPos(slot int) int {
+	require.Zero(tb.t, tb.pos%4)
+	vtable := tb.pos - int(int32(binary.LittleEndian.Uint32(tb.buf[tb.pos:])))
+	require.Zero(tb.t, vtable%2)
+	vtableSize := int(binary.LittleEndian.Uint16(tb.buf[vtable:]))
+	if 4+2*slot >= vtableSize {
+		return 0
+	}
+	off := int(binary.LittleEndian.Uint16(tb.buf[vtable+4+2*slot:]))
+	if off == 0 {
+		return 0
+	}
+	return tb.pos + off
+}
+
+func (tb fbTable) uint(slot, size int) uint64 {
+	p := tb.fieldPos(slot)
+	if p == 0 {
+		return 0
+	}
+	require.Zero(tb.t, p%size)
+	switch size {
+	case 1:
+		return uint64(tb.buf[p])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(tb.buf[p:]))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(tb.buf[p:]))
+	default:
+		return binary.LittleEndian.Uint64(tb.buf[p:])
+	}
+}
+
+func (tb fbTable) indirect(slot int) int {
+	p := tb.fieldPos(slot)
+	require.NotZero(tb.t, p, "slot %d is absent", slot)
+	require.Zero(tb.t, p%4)
+	return p + int(binary.LittleEndian.Uint32(tb.buf[p:]))
+}
+
+func (tb fbTable) table(slot int) fbTable {
+	return fbTable{t: tb.t, buf: tb.buf, pos: tb.indirect(slot)}
+}
+
+func (tb fbTable) str(slot int) string {
+	p := tb.indirect(slot)
+	n := int(binary.LittleEndian.Uint32(tb.buf[p:]))
+	require.Equal(tb.t, byte(0), tb.buf[p+4+n])
+	return string(tb.buf[p+4 : p+4+n])
+}
+
+// vector returns the position of the first element of a vector and the number of its elements
+func (tb fbTable) vector(slot int) (int, int) {
+	p := tb.indirect(slot)
+	return p + 4, int(binary.LittleEndian.Uint32(tb.buf[p:]))
+}
+
+func (tb fbTable) tables(slot int) []fbTable {
+	start, n := tb.vector(slot)
+	var tables []fbTable
+	for i := 0; i < n; i++ {
+		p := start + 4*i
+		tables = append(tables, fbTable{t: tb.t, buf: tb.buf, pos: p + int(binary.LittleEndian.Uint32(tb.buf[p:]))})
+	}
+	return tables
+}
+
+// structs returns the pairs of 64-bit integers of a vector of FieldNode or Buffer structs
+func (tb fbTable) structs(slot int) [][2]uint64 {
+	start, n := tb.vector(slot)
+	require.Zero(tb.t, start%8)
+	var structs [][2]uint64
+	for i := 0; i < n; i++ {
+		p := start + 16*i
+		structs = append(structs, [2]uint64{binary.LittleEndian.Uint64(tb.buf[p:]), binary.LittleEndian.Uint64(tb.buf[p+8:])})
+	}
+	return structs
+}
+
+type message struct {
+	header     fbTable
+	headerType uint64
+	body       []byte
+}
+
+// readMessages reads the messages of a stream up to the end of stream marker
+func readMessages(t *testing.T, stream []byte) []message {
+	var msgs []message
+	r := bytes.NewReader(stream)
+	for {
+		prefix := make([]byte, 8)
+		_, err := r.Read(prefix)
+		require.NoError(t, err)
+		require.Equal(t, continuationMarker, prefix[:4])
+
+		metadataLen := int(binary.LittleEndian.Uint32(prefix[4:]))
+		if metadataLen == 0 {
+			require.Zero(t, r.Len())
+			return msgs
+		}
+		require.Zero(t, metadataLen%8)
+
+		metadata := make([]byte, metadataLen)
+		_, err = r.Read(metadata)
+		require.NoError(t, err)
+
+		msg := rootTable(t, metadata)
+		require.Equal(t, uint64(metadataVersionV5), msg.uint(0, 2))
+		bodyLen := int(msg.uint(3, 8))
+		require.Zero(t, bodyLen%8)
+		body := make([]byte, bodyLen)
+		if bodyLen > 0 {
+			_, err = r.Read(body)
+			require.NoError(t, err)
+		}
+
+		msgs = append(msgs, message{header: msg.table(2), headerType: msg.uint(1, 1), body: body})
+	}
+}
+
+func kvsForTest(n int) []*types.KVWithMetadata {
+	var kvs []*types.KVWithMetadata
+	for i := 0; i < n; i++ {
+		kvs = append(kvs, &types.KVWithMetadata{
+			Key:   fmt.Sprintf("key%d", i),
+			Value: bytes.Repeat([]byte{byte(i)}, i%13),
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: uint64(i + 2),
+					TxNum:    uint64(i % 3),
+				},
+			},
+		})
+	}
+	return kvs
+}
+
+func TestWriteKVs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		kvs             []*types.KVWithMetadata
+		expectedBatches []int
+	}{
+		{
+			name: "no key-value pairs",
+		},
+		{
+			name: "key-value pair without metadata",
+			kvs: []*types.KVWithMetadata{
+				{
+					Key:   "key",
+					Value: []byte("value"),
+				},
+			},
+			expectedBatches: []int{1},
+		},
+		{
+			name:            "single batch",
+			kvs:             kvsForTest(5),
+			expectedBatches: []int{5},
+		},
+		{
+			name:            "multiple batches",
+			kvs:             kvsForTest(2*RowsPerBatch + 1),
+			expectedBatches: []int{RowsPerBatch, RowsPerBatch, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stream := &bytes.Buffer{}
+			require.NoError(t, WriteKVs(stream, tt.kvs))
+
+			msgs := readMessages(t, stream.Bytes())
+			require.Len(t, msgs, 1+len(tt.expectedBatches))
+
+			require.Equal(t, uint64(headerSchema), msgs[0].headerType)
+			require.Empty(t, msgs[0].body)
+			fields := msgs[0].header.tables(1)
+			require.Len(t, fields, len(columns))
+			for i, f := range fields {
+				require.Equal(t, columns[i].name, f.str(0))
+				require.Zero(t, f.uint(1, 1), "the column must not be nullable")
+				require.Equal(t, uint64(columns[i].typeType), f.uint(2, 1))
+				colType := f.table(3)
+				require.Equal(t, uint64(columns[i].bitWidth), colType.uint(0, 4))
+				require.Zero(t, colType.uint(1, 1), "the column must be unsigned")
+				_, numChildren := f.vector(5)
+				require.Zero(t, numChildren)
+			}
+
+			var kvs []*types.KVWithMetadata
+			for i, rows := range tt.expectedBatches {
+				msg := msgs[1+i]
+				require.Equal(t, uint64(headerRecordBatch), msg.headerType)
+				require.Equal(t, uint64(rows), msg.header.uint(0, 8))
+
+				nodes := msg.header.structs(1)
+				require.Len(t, nodes, len(columns))
+				for _, n := range nodes {
+					require.Equal(t, [2]uint64{uint64(rows), 0}, n)
+				}
+
+				buffers := msg.header.structs(2)
+				require.Len(t, buffers, 10)
+				bufs := make([][]byte, len(buffers))
+				for j, buf := range buffers {
+					require.Zero(t, buf[0]%8, "the buffer must be aligned")
+					bufs[j] = msg.body[buf[0] : buf[0]+buf[1]]
+				}
+				for _, j := range []int{0, 3, 6, 8} {
+					require.Empty(t, bufs[j], "the validity buffer must be empty")
+				}
+
+				for r := 0; r < rows; r++ {
+					keyStart, keyEnd := binary.LittleEndian.Uint32(bufs[1][4*r:]), binary.LittleEndian.Uint32(bufs[1][4*r+4:])
+					valueStart, valueEnd := binary.LittleEndian.Uint32(bufs[4][4*r:]), binary.LittleEndian.Uint32(bufs[4][4*r+4:])
+					kvs = append(kvs, &types.KVWithMetadata{
+						Key:   string(bufs[2][keyStart:keyEnd]),
+						Value: bufs[5][valueStart:valueEnd],
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: binary.LittleEndian.Uint64(bufs[7][8*r:]),
+								TxNum:    binary.LittleEndian.Uint64(bufs[9][8*r:]),
+							},
+						},
+					})
+				}
+			}
+
+			require.Len(t, kvs, len(tt.kvs))
+			for i, kv := range tt.kvs {
+				require.Equal(t, kv.Key, kvs[i].Key)
+				require.Equal(t, string(kv.Value), string(kvs[i].Value))
+				require.Equal(t, kv.GetMetadata().GetVersion().GetBlockNum(), kvs[i].Metadata.Version.BlockNum)
+				require.Equal(t, kv.GetMetadata().GetVersion().GetTxNum(), kvs[i].Metadata.Version.TxNum)
+			}
+		})
+	}
+}
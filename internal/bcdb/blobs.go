@@ -0,0 +1,180 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultBlobGCGracePeriod is the time an unreferenced chunk is kept when no grace period is configured
+const DefaultBlobGCGracePeriod = 24 * time.Hour
+
+// blobsConfWithDefaults returns the configuration of the blobs, with the defaults of the limits that are not set
+func blobsConfWithDefaults(conf config.BlobsConf) config.BlobsConf {
+	if conf.ValueSizeThreshold == 0 {
+		conf.ValueSizeThreshold = blobstore.DefaultValueSizeThreshold
+	}
+	if conf.ChunkSize == 0 {
+		conf.ChunkSize = blobstore.DefaultChunkSize
+	}
+	if conf.GCGracePeriod == 0 {
+		conf.GCGracePeriod = DefaultBlobGCGracePeriod
+	}
+	return conf
+}
+
+// PutBlob stores a value uploaded by a user in the blob store, and returns the manifest of the blob
+func (d *db) PutBlob(userID string, value []byte) (*types.PutBlobResponseEnvelope, error) {
+	if len(value) == 0 {
+		return nil, &ierrors.BadRequestError{ErrMsg: "the blob is empty"}
+	}
+
+	manifest := blobstore.NewManifest(value, d.blobsConf.ChunkSize)
+	if err := d.blobStore.PutValue(manifest, value); err != nil {
+		return nil, err
+	}
+	d.logger.Debugf("user [%s] uploaded a blob of %d bytes in %d chunks", userID, manifest.Size, len(manifest.ChunkHashes))
+
+	response := &types.PutBlobResponse{
+		Header:   d.responseHeader(),
+		Manifest: manifest,
+	}
+	sign, err := d.signature(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PutBlobResponseEnvelope{
+		Response:  response,
+		Signature: sign,
+	}, nil
+}
+
+// checkBlobWrites checks, upon submission, that the values written in place by a data transaction do not exceed the
+// threshold above which a value must be uploaded to the blob store, and that the blob store holds the chunks of the
+// blobs written by their manifests. The chunks are retained until the transaction commits, as long as it commits
+// within the grace period of the garbage collection.
+func checkBlobWrites(blobs *blobstore.Store, valueSizeThreshold uint64, tx *types.DataTx) error {
+	for _, ops := range tx.GetDbOperations() {
+		for _, w := range ops.GetDataWrites() {
+			if w.BlobManifest == nil {
+				if uint64(len(w.Value)) > valueSizeThreshold {
+					return &ierrors.BadRequestError{
+						ErrMsg: fmt.Sprintf("the value of the key [%s] in database [%s] is %d bytes, which is larger than %d bytes; upload it to %s and write its manifest instead",
+							w.Key, ops.DbName, len(w.Value), valueSizeThreshold, constants.PostBlob),
+					}
+				}
+				continue
+			}
+
+			if err := blobstore.ValidateManifest(w.BlobManifest); err != nil {
+				return &ierrors.BadRequestError{
+					ErrMsg: fmt.Sprintf("the blob manifest of the key [%s] in database [%s] is invalid: %s", w.Key, ops.DbName, err),
+				}
+			}
+			if blobs == nil {
+				return errors.New("no blob store is configured")
+			}
+			missing, err := blobs.Retain(w.BlobManifest)
+			if err != nil {
+				return err
+			}
+			if len(missing) > 0 {
+				return &ierrors.BadRequestError{
+					ErrMsg: fmt.Sprintf("the blob of the key [%s] in database [%s] is missing %d chunks; upload it to %s of the node the transaction is submitted to",
+						w.Key, ops.DbName, len(missing), constants.PostBlob),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// chunkReferences returns the hashes of the chunks of the blobs referenced by the world state
+type chunkReferences interface {
+	ReferencedChunks() (map[string]struct{}, error)
+}
+
+// blobCollector periodically removes the chunks of the blob store that are no longer referenced by the world state,
+// once they have been unreferenced for the grace period.
+type blobCollector struct {
+	interval    time.Duration
+	gracePeriod time.Duration
+	references  chunkReferences
+	blobs       *blobstore.Store
+	logger      *logger.SugarLogger
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+}
+
+func newBlobCollector(conf config.BlobsConf, references chunkReferences, blobs *blobstore.Store, logger *logger.SugarLogger) *blobCollector {
+	return &blobCollector{
+		interval:    conf.GCInterval,
+		gracePeriod: conf.GCGracePeriod,
+		references:  references,
+		blobs:       blobs,
+		logger:      logger,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// start runs the garbage collection periodically, unless it is disabled
+func (c *blobCollector) start() {
+	if c.interval == 0 {
+		close(c.doneCh)
+		return
+	}
+
+	go c.run()
+}
+
+func (c *blobCollector) run() {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			if _, err := c.collect(time.Now()); err != nil {
+				c.logger.Warnf("failed to collect the unreferenced chunks of the blob store: %s", err)
+			}
+		}
+	}
+}
+
+func (c *blobCollector) stop() {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	<-c.doneCh
+}
+
+// collect marks the unreferenced chunks, and removes those that were marked before the grace period
+func (c *blobCollector) collect(now time.Time) (int, error) {
+	referenced, err := c.references.ReferencedChunks()
+	if err != nil {
+		return 0, err
+	}
+
+	return c.blobs.CollectGarbage(func(hash []byte) bool {
+		_, ok := referenced[string(hash)]
+		return ok
+	}, now, c.gracePeriod)
+}
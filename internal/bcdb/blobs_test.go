@@ -0,0 +1,128 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobs(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	// setup opens a world state with the database db1, and its blob store
+	setup := func(t *testing.T) (*leveldb.LevelDB, *blobstore.Store) {
+		dir, err := ioutil.TempDir("/tmp", "blobs")
+		require.NoError(t, err)
+		blobs, err := blobstore.Open(&blobstore.Config{StoreDir: filepath.Join(dir, "blobs"), Logger: lg})
+		require.NoError(t, err)
+		db, err := leveldb.Open(&leveldb.Config{DBRootDir: filepath.Join(dir, "worldstate"), BlobStore: blobs, Logger: lg})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+			require.NoError(t, blobs.Close())
+			require.NoError(t, os.RemoveAll(dir))
+		})
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}}},
+		}, 1))
+		return db, blobs
+	}
+	dataTx := func(writes ...*types.DataWrite) *types.DataTx {
+		return &types.DataTx{
+			TxId:         "tx1",
+			DbOperations: []*types.DBOperation{{DbName: "db1", DataWrites: writes}},
+		}
+	}
+
+	t.Run("blob writes are checked upon submission", func(t *testing.T) {
+		_, blobs := setup(t)
+
+		value := []byte("0123456789")
+		manifest := blobstore.NewManifest(value, 4)
+
+		require.NoError(t, checkBlobWrites(blobs, 8, dataTx(&types.DataWrite{Key: "key1", Value: []byte("01234567")})))
+
+		err := checkBlobWrites(blobs, 8, dataTx(&types.DataWrite{Key: "key1", Value: value}))
+		require.EqualError(t, err, "the value of the key [key1] in database [db1] is 10 bytes, which is larger than 8 bytes; upload it to /data/blob and write its manifest instead")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+
+		err = checkBlobWrites(blobs, 8, dataTx(&types.DataWrite{Key: "key1", BlobManifest: manifest}))
+		require.EqualError(t, err, "the blob of the key [key1] in database [db1] is missing 3 chunks; upload it to /data/blob of the node the transaction is submitted to")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+
+		err = checkBlobWrites(blobs, 8, dataTx(&types.DataWrite{Key: "key1", BlobManifest: &types.BlobManifest{Size: 10}}))
+		require.EqualError(t, err, "the blob manifest of the key [key1] in database [db1] is invalid: the size [10] and the chunk size [0] of a blob must be positive")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+
+		require.NoError(t, blobs.PutValue(manifest, value))
+		require.NoError(t, checkBlobWrites(blobs, 8, dataTx(&types.DataWrite{Key: "key1", BlobManifest: manifest})))
+	})
+
+	t.Run("unreferenced chunks are collected after the grace period", func(t *testing.T) {
+		db, blobs := setup(t)
+
+		committed := []byte("0123456789")
+		committedManifest := blobstore.NewManifest(committed, 4)
+		require.NoError(t, blobs.PutValue(committedManifest, committed))
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			"db1": {Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:          "key1",
+					BlobManifest: committedManifest,
+					Metadata:     &types.Metadata{Version: &types.Version{BlockNum: 2}},
+				},
+			}},
+		}, 2))
+
+		// the blob is uploaded, but never written by a transaction
+		abandoned := []byte("abcdefgh")
+		abandonedManifest := blobstore.NewManifest(abandoned, 4)
+		require.NoError(t, blobs.PutValue(abandonedManifest, abandoned))
+
+		c := newBlobCollector(config.BlobsConf{GCGracePeriod: time.Hour}, db, blobs, lg)
+		now := time.Now()
+		removed, err := c.collect(now)
+		require.NoError(t, err)
+		require.Equal(t, 0, removed)
+		removed, err = c.collect(now.Add(2 * time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 2, removed)
+
+		value, err := blobs.GetValue(committedManifest)
+		require.NoError(t, err)
+		require.Equal(t, committed, value)
+		missing, err := blobs.Retain(abandonedManifest)
+		require.NoError(t, err)
+		require.Equal(t, abandonedManifest.ChunkHashes, missing)
+	})
+
+	t.Run("the collector is disabled without an interval", func(t *testing.T) {
+		db, blobs := setup(t)
+
+		c := newBlobCollector(blobsConfWithDefaults(config.BlobsConf{}), db, blobs, lg)
+		require.Equal(t, DefaultBlobGCGracePeriod, c.gracePeriod)
+		c.start()
+		c.stop()
+	})
+}
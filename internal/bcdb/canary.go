@@ -0,0 +1,213 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultCanaryInterval is the interval between canary checks when none is configured
+	DefaultCanaryInterval = time.Minute
+	// DefaultCanaryTimeout is the maximal time a canary check waits for its transaction when none is configured
+	DefaultCanaryTimeout = 10 * time.Second
+
+	canaryResultSuccess = "success"
+	canaryResultFailure = "failure"
+)
+
+var (
+	canaryChecksCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "canary",
+		Name:      "checks_total",
+		Help:      "The number of canary checks, by result.",
+	}, []string{"node", "result"})
+
+	canaryWriteLatencyHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "orion",
+		Subsystem: "canary",
+		Name:      "write_latency_seconds",
+		Help:      "The time from the submission of a canary transaction until it is committed.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"node"})
+
+	canaryReadLatencyHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "orion",
+		Subsystem: "canary",
+		Name:      "read_latency_seconds",
+		Help:      "The time it takes to read back the canary key.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	}, []string{"node"})
+
+	canaryLastSuccessGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "canary",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "The time of the last successful canary check, in seconds since the Unix epoch.",
+	}, []string{"node"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		canaryChecksCounter,
+		canaryWriteLatencyHistogram,
+		canaryReadLatencyHistogram,
+		canaryLastSuccessGauge,
+	)
+}
+
+// canary periodically writes the canary key of the node, which is the node ID, with a transaction signed by the node,
+// and reads it back once the transaction is committed. It covers the whole pipeline: the transaction queue, block
+// creation, consensus, validation, commit, and the state database.
+type canary struct {
+	nodeID   string
+	interval time.Duration
+	timeout  time.Duration
+	signer   crypto.Signer
+	isLeader func() bool
+	submit   func(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error)
+	read     func(key string) ([]byte, error)
+	nowFn    func() time.Time
+	logger   *logger.SugarLogger
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+}
+
+func newCanary(conf config.CanaryConf, d *db) *canary {
+	interval := conf.Interval
+	if interval == 0 {
+		interval = DefaultCanaryInterval
+	}
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = DefaultCanaryTimeout
+	}
+
+	return &canary{
+		nodeID:   d.nodeID,
+		interval: interval,
+		timeout:  timeout,
+		signer:   d.signer,
+		isLeader: func() bool { return d.IsLeader() == nil },
+		submit:   d.SubmitTransaction,
+		read: func(key string) ([]byte, error) {
+			val, _, err := d.db.Get(worldstate.CanaryDBName, key)
+			return val, err
+		},
+		nowFn:  time.Now,
+		logger: d.logger,
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+}
+
+func (c *canary) start() {
+	go c.run()
+}
+
+func (c *canary) run() {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			if !c.isLeader() {
+				continue
+			}
+			if err := c.check(); err != nil {
+				c.logger.Warnf("The canary check failed: %s", err)
+			}
+		}
+	}
+}
+
+func (c *canary) stop() {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	<-c.doneCh
+}
+
+// check writes the canary key, waits for the transaction to commit, and reads the key back
+func (c *canary) check() error {
+	labels := prometheus.Labels{"node": c.nodeID}
+
+	err := c.writeAndRead(labels)
+	result := canaryResultSuccess
+	if err != nil {
+		result = canaryResultFailure
+	} else {
+		canaryLastSuccessGauge.With(labels).Set(float64(c.nowFn().Unix()))
+	}
+	canaryChecksCounter.With(prometheus.Labels{"node": c.nodeID, "result": result}).Inc()
+
+	return err
+}
+
+func (c *canary) writeAndRead(labels prometheus.Labels) error {
+	start := c.nowFn()
+	value := []byte(strconv.FormatInt(start.UnixNano(), 10))
+	tx := &types.DataTx{
+		MustSignUserIds: []string{c.nodeID},
+		TxId:            fmt.Sprintf("canary-%s-%d", c.nodeID, start.UnixNano()),
+		DbOperations: []*types.DBOperation{
+			{
+				DbName:     worldstate.CanaryDBName,
+				DataWrites: []*types.DataWrite{{Key: c.nodeID, Value: value}},
+			},
+		},
+	}
+	sig, err := cryptoservice.SignTx(c.signer, tx)
+	if err != nil {
+		return errors.WithMessage(err, "error while signing the canary transaction")
+	}
+
+	receipt, err := c.submit(&types.DataTxEnvelope{
+		Payload:    tx,
+		Signatures: map[string][]byte{c.nodeID: sig},
+	}, c.timeout)
+	if err != nil {
+		return errors.WithMessage(err, "error while submitting the canary transaction")
+	}
+	r := receipt.GetResponse().GetReceipt()
+	valInfo := r.GetHeader().GetValidationInfo()
+	if int(r.GetTxIndex()) >= len(valInfo) {
+		return errors.Errorf("the receipt of the canary transaction [%s] holds no validation info", tx.TxId)
+	}
+	if flag := valInfo[r.GetTxIndex()]; flag.Flag != types.Flag_VALID {
+		return errors.Errorf("the canary transaction [%s] is invalid: %s: %s", tx.TxId, flag.Flag, flag.ReasonIfInvalid)
+	}
+	written := c.nowFn()
+	canaryWriteLatencyHistogram.With(labels).Observe(written.Sub(start).Seconds())
+
+	read, err := c.read(c.nodeID)
+	if err != nil {
+		return errors.WithMessage(err, "error while reading the canary key")
+	}
+	if !bytes.Equal(read, value) {
+		return errors.Errorf("the canary key holds [%s] rather than the value [%s] written by the transaction [%s]", read, value, tx.TxId)
+	}
+	canaryReadLatencyHistogram.With(labels).Observe(c.nowFn().Sub(written).Seconds())
+
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanary(t *testing.T) {
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node1"})
+	_, nodeSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "node1")
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	newTestCanary := func(nodeID string) (*canary, map[string][]byte) {
+		state := make(map[string][]byte)
+		c := &canary{
+			nodeID:   nodeID,
+			interval: time.Minute,
+			timeout:  time.Second,
+			signer:   nodeSigner,
+			isLeader: func() bool { return true },
+			read: func(key string) ([]byte, error) {
+				return state[key], nil
+			},
+			nowFn:  func() time.Time { return now },
+			logger: lg,
+		}
+		c.submit = func(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
+			require.Equal(t, c.timeout, timeout)
+			env := tx.(*types.DataTxEnvelope)
+			require.Equal(t, []string{nodeID}, env.Payload.MustSignUserIds)
+			require.Contains(t, env.Signatures, nodeID)
+			ops := env.Payload.DbOperations
+			require.Len(t, ops, 1)
+			require.Equal(t, worldstate.CanaryDBName, ops[0].DbName)
+			state[ops[0].DataWrites[0].Key] = ops[0].DataWrites[0].Value
+			return receiptWithFlag(types.Flag_VALID), nil
+		}
+		return c, state
+	}
+	checks := func(nodeID, result string) float64 {
+		return testutil.ToFloat64(canaryChecksCounter.With(prometheus.Labels{"node": nodeID, "result": result}))
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		c := newCanary(config.CanaryConf{}, &db{nodeID: "node1", logger: lg})
+		require.Equal(t, DefaultCanaryInterval, c.interval)
+		require.Equal(t, DefaultCanaryTimeout, c.timeout)
+	})
+
+	t.Run("the canary key is written and read", func(t *testing.T) {
+		c, state := newTestCanary("canary-ok")
+		require.NoError(t, c.check())
+		require.NoError(t, c.check())
+
+		require.Len(t, state, 1)
+		require.Contains(t, state, "canary-ok")
+		require.Equal(t, float64(2), checks("canary-ok", canaryResultSuccess))
+		require.Equal(t, float64(0), checks("canary-ok", canaryResultFailure))
+		require.Equal(t, float64(now.Unix()),
+			testutil.ToFloat64(canaryLastSuccessGauge.With(prometheus.Labels{"node": "canary-ok"})))
+	})
+
+	t.Run("the submission fails", func(t *testing.T) {
+		c, _ := newTestCanary("canary-submit")
+		c.submit = func(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
+			return nil, errors.New("timeout")
+		}
+		require.EqualError(t, c.check(), "error while submitting the canary transaction: timeout")
+		require.Equal(t, float64(1), checks("canary-submit", canaryResultFailure))
+	})
+
+	t.Run("the transaction is invalid", func(t *testing.T) {
+		c, _ := newTestCanary("canary-invalid")
+		c.submit = func(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
+			return receiptWithFlag(types.Flag_INVALID_UNAUTHORISED), nil
+		}
+		require.EqualError(t, c.check(), "the canary transaction [canary-canary-invalid-1000000000000] is invalid: INVALID_UNAUTHORISED: ")
+		require.Equal(t, float64(1), checks("canary-invalid", canaryResultFailure))
+	})
+
+	t.Run("the canary key holds another value", func(t *testing.T) {
+		c, _ := newTestCanary("canary-read")
+		c.read = func(key string) ([]byte, error) {
+			return []byte("1"), nil
+		}
+		require.EqualError(t, c.check(), "the canary key holds [1] rather than the value [1000000000000] written by the transaction [canary-canary-read-1000000000000]")
+		require.Equal(t, float64(1), checks("canary-read", canaryResultFailure))
+	})
+
+	t.Run("only the leader runs checks", func(t *testing.T) {
+		c, state := newTestCanary("canary-follower")
+		c.interval = time.Millisecond
+		c.isLeader = func() bool { return false }
+		c.stopCh = make(chan struct{})
+		c.doneCh = make(chan struct{})
+		c.start()
+		time.Sleep(20 * time.Millisecond)
+		c.stop()
+
+		require.Empty(t, state)
+		require.Equal(t, float64(0), checks("canary-follower", canaryResultSuccess))
+	})
+}
+
+func receiptWithFlag(flag types.Flag) *types.TxReceiptResponseEnvelope {
+	return &types.TxReceiptResponseEnvelope{
+		Response: &types.TxReceiptResponse{
+			Receipt: &types.TxReceipt{
+				Header:  &types.BlockHeader{ValidationInfo: []*types.ValidationInfo{{Flag: flag}}},
+				TxIndex: 0,
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// CommitListener is an extension point for custom integrations, e.g., notifications or secondary stores, that need to
+// act on every block committed by the server.
+//
+// BlockCommitted is called after the block is committed to the ledger and the database, with the block and the
+// validation info of its transactions, in block order. It is called from the go-routine of the committer, which waits
+// for it to return before committing the next block; hence, a listener that does heavy or blocking work should hand
+// the block over to its own go-routine. The block must not be modified. An error returned by a listener is logged, and
+// does not stop the committer.
+//
+// The genesis block, committed when the server bootstraps a new ledger, is not delivered to the listeners.
+type CommitListener interface {
+	BlockCommitted(block *types.Block, validationInfo []*types.ValidationInfo) error
+}
+
+// commitListenerAdapter adapts a CommitListener to the post-commit listener of the block processor.
+type commitListenerAdapter struct {
+	name     string
+	listener CommitListener
+	logger   *logger.SugarLogger
+}
+
+func (a *commitListenerAdapter) PostBlockCommitProcessing(block *types.Block) error {
+	if err := a.listener.BlockCommitted(block, block.GetHeader().GetValidationInfo()); err != nil {
+		a.logger.Errorf("Commit listener [%s] failed to process block [%d]: %s",
+			a.name, block.GetHeader().GetBaseHeader().GetNumber(), err)
+	}
+
+	return nil
+}
+
+// registerCommitListeners registers the commit listeners with the block processor. It must be called before the block
+// processor is started, so that no block is missed.
+func registerCommitListeners(blockProcessor *blockprocessor.BlockProcessor, listeners map[string]CommitListener, logger *logger.SugarLogger) error {
+	for name, listener := range listeners {
+		if name == "" || name == commitListenerName {
+			return errors.Errorf("commit listener name [%s] is not allowed", name)
+		}
+		if listener == nil {
+			return errors.Errorf("commit listener [%s] is nil", name)
+		}
+
+		adapter := &commitListenerAdapter{
+			name:     name,
+			listener: listener,
+			logger:   logger,
+		}
+		if err := blockProcessor.RegisterBlockCommitListener(name, adapter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
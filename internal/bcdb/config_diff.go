@@ -0,0 +1,157 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// diffClusterConfigs returns the changes that take the `from` cluster config to the `to` cluster config. A nil `from`
+// config is treated as an empty config, so that everything in `to` is reported as added.
+func diffClusterConfigs(from, to *types.ClusterConfig) *types.ClusterConfigDiff {
+	diff := &types.ClusterConfigDiff{
+		FromProtocolVersion: from.GetProtocolVersion(),
+		ToProtocolVersion:   to.GetProtocolVersion(),
+	}
+
+	diff.AddedNodes, diff.RemovedNodes, diff.UpdatedNodes = diffNodes(from.GetNodes(), to.GetNodes())
+	diff.AddedAdmins, diff.RemovedAdmins, diff.UpdatedAdmins = diffAdmins(from.GetAdmins(), to.GetAdmins())
+	diff.AddedRootCas, diff.RemovedRootCas = diffCertificates(
+		from.GetCertAuthConfig().GetRoots(), to.GetCertAuthConfig().GetRoots())
+	diff.AddedIntermediateCas, diff.RemovedIntermediateCas = diffCertificates(
+		from.GetCertAuthConfig().GetIntermediates(), to.GetCertAuthConfig().GetIntermediates())
+	diff.AddedMembers, diff.RemovedMembers, diff.UpdatedMembers = diffPeers(
+		from.GetConsensusConfig().GetMembers(), to.GetConsensusConfig().GetMembers())
+	diff.AddedObservers, diff.RemovedObservers, diff.UpdatedObservers = diffPeers(
+		from.GetConsensusConfig().GetObservers(), to.GetConsensusConfig().GetObservers())
+
+	fromRaft := from.GetConsensusConfig().GetRaftConfig()
+	toRaft := to.GetConsensusConfig().GetRaftConfig()
+	if !proto.Equal(fromRaft, toRaft) {
+		diff.FromRaftConfig = fromRaft
+		diff.ToRaftConfig = toRaft
+	}
+
+	return diff
+}
+
+func nodesChanged(diff *types.ClusterConfigDiff) bool {
+	return len(diff.AddedNodes)+len(diff.RemovedNodes)+len(diff.UpdatedNodes) > 0
+}
+
+func adminsChanged(diff *types.ClusterConfigDiff) bool {
+	return len(diff.AddedAdmins)+len(diff.RemovedAdmins)+len(diff.UpdatedAdmins) > 0
+}
+
+func caChanged(diff *types.ClusterConfigDiff) bool {
+	return len(diff.AddedRootCas)+len(diff.RemovedRootCas)+
+		len(diff.AddedIntermediateCas)+len(diff.RemovedIntermediateCas) > 0
+}
+
+func consensusChanged(diff *types.ClusterConfigDiff) bool {
+	return len(diff.AddedMembers)+len(diff.RemovedMembers)+len(diff.UpdatedMembers)+
+		len(diff.AddedObservers)+len(diff.RemovedObservers)+len(diff.UpdatedObservers) > 0 ||
+		diff.FromRaftConfig != nil || diff.ToRaftConfig != nil
+}
+
+func diffNodes(from, to []*types.NodeConfig) (added, removed, updated []*types.NodeConfig) {
+	fromByID := make(map[string]*types.NodeConfig)
+	for _, n := range from {
+		fromByID[n.Id] = n
+	}
+	toByID := make(map[string]*types.NodeConfig)
+	for _, n := range to {
+		toByID[n.Id] = n
+		old, ok := fromByID[n.Id]
+		switch {
+		case !ok:
+			added = append(added, n)
+		case !proto.Equal(old, n):
+			updated = append(updated, n)
+		}
+	}
+	for _, n := range from {
+		if _, ok := toByID[n.Id]; !ok {
+			removed = append(removed, n)
+		}
+	}
+
+	return added, removed, updated
+}
+
+func diffAdmins(from, to []*types.Admin) (added, removed, updated []*types.Admin) {
+	fromByID := make(map[string]*types.Admin)
+	for _, a := range from {
+		fromByID[a.Id] = a
+	}
+	toByID := make(map[string]*types.Admin)
+	for _, a := range to {
+		toByID[a.Id] = a
+		old, ok := fromByID[a.Id]
+		switch {
+		case !ok:
+			added = append(added, a)
+		case !proto.Equal(old, a):
+			updated = append(updated, a)
+		}
+	}
+	for _, a := range from {
+		if _, ok := toByID[a.Id]; !ok {
+			removed = append(removed, a)
+		}
+	}
+
+	return added, removed, updated
+}
+
+func diffPeers(from, to []*types.PeerConfig) (added, removed, updated []*types.PeerConfig) {
+	fromByID := make(map[string]*types.PeerConfig)
+	for _, p := range from {
+		fromByID[p.NodeId] = p
+	}
+	toByID := make(map[string]*types.PeerConfig)
+	for _, p := range to {
+		toByID[p.NodeId] = p
+		old, ok := fromByID[p.NodeId]
+		switch {
+		case !ok:
+			added = append(added, p)
+		case !proto.Equal(old, p):
+			updated = append(updated, p)
+		}
+	}
+	for _, p := range from {
+		if _, ok := toByID[p.NodeId]; !ok {
+			removed = append(removed, p)
+		}
+	}
+
+	return added, removed, updated
+}
+
+func diffCertificates(from, to [][]byte) (added, removed [][]byte) {
+	for _, c := range to {
+		if !containsCertificate(from, c) {
+			added = append(added, c)
+		}
+	}
+	for _, c := range from {
+		if !containsCertificate(to, c) {
+			removed = append(removed, c)
+		}
+	}
+
+	return added, removed
+}
+
+func containsCertificate(certs [][]byte, cert []byte) bool {
+	for _, c := range certs {
+		if bytes.Equal(c, cert) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffClusterConfigs(t *testing.T) {
+	config := &types.ClusterConfig{
+		Nodes: []*types.NodeConfig{
+			{Id: "node1", Address: "127.0.0.1", Port: 6090, Certificate: []byte("node1-cert")},
+			{Id: "node2", Address: "127.0.0.1", Port: 6091, Certificate: []byte("node2-cert")},
+		},
+		Admins: []*types.Admin{
+			{Id: "admin1", Certificate: []byte("admin1-cert")},
+			{Id: "admin2", Certificate: []byte("admin2-cert")},
+		},
+		CertAuthConfig: &types.CAConfig{
+			Roots:         [][]byte{[]byte("root-ca")},
+			Intermediates: [][]byte{[]byte("intermediate-ca")},
+		},
+		ConsensusConfig: &types.ConsensusConfig{
+			Algorithm: "raft",
+			Members: []*types.PeerConfig{
+				{NodeId: "node1", RaftId: 1, PeerHost: "127.0.0.1", PeerPort: 7090},
+				{NodeId: "node2", RaftId: 2, PeerHost: "127.0.0.1", PeerPort: 7091},
+			},
+			RaftConfig: &types.RaftConfig{
+				TickInterval:   "100ms",
+				ElectionTicks:  100,
+				HeartbeatTicks: 10,
+			},
+		},
+		ProtocolVersion: 1,
+	}
+
+	t.Run("no changes", func(t *testing.T) {
+		diff := diffClusterConfigs(config, proto.Clone(config).(*types.ClusterConfig))
+		require.True(t, proto.Equal(&types.ClusterConfigDiff{FromProtocolVersion: 1, ToProtocolVersion: 1}, diff))
+		require.False(t, nodesChanged(diff))
+		require.False(t, adminsChanged(diff))
+		require.False(t, caChanged(diff))
+		require.False(t, consensusChanged(diff))
+	})
+
+	t.Run("from an empty config", func(t *testing.T) {
+		diff := diffClusterConfigs(nil, config)
+		require.Len(t, diff.AddedNodes, 2)
+		require.Len(t, diff.AddedAdmins, 2)
+		require.Equal(t, config.CertAuthConfig.Roots, diff.AddedRootCas)
+		require.Equal(t, config.CertAuthConfig.Intermediates, diff.AddedIntermediateCas)
+		require.Len(t, diff.AddedMembers, 2)
+		require.Nil(t, diff.FromRaftConfig)
+		require.True(t, proto.Equal(config.ConsensusConfig.RaftConfig, diff.ToRaftConfig))
+		require.True(t, nodesChanged(diff))
+		require.True(t, adminsChanged(diff))
+		require.True(t, caChanged(diff))
+		require.True(t, consensusChanged(diff))
+	})
+
+	t.Run("changes in every section", func(t *testing.T) {
+		updated := proto.Clone(config).(*types.ClusterConfig)
+		updated.Nodes[1].Port = 6092
+		updated.Nodes = append(updated.Nodes, &types.NodeConfig{Id: "node3", Address: "127.0.0.1", Port: 6093})
+		updated.Admins = []*types.Admin{
+			{Id: "admin1", Certificate: []byte("admin1-new-cert")},
+		}
+		updated.CertAuthConfig.Roots = [][]byte{[]byte("new-root-ca")}
+		updated.ConsensusConfig.Members = updated.ConsensusConfig.Members[1:]
+		updated.ConsensusConfig.Observers = []*types.PeerConfig{
+			{NodeId: "node3", PeerHost: "127.0.0.1", PeerPort: 7093},
+		}
+		updated.ConsensusConfig.RaftConfig.ElectionTicks = 200
+		updated.ProtocolVersion = 2
+
+		diff := diffClusterConfigs(config, updated)
+		require.Equal(t, "node3", diff.AddedNodes[0].Id)
+		require.Empty(t, diff.RemovedNodes)
+		require.Equal(t, uint32(6092), diff.UpdatedNodes[0].Port)
+		require.Empty(t, diff.AddedAdmins)
+		require.Equal(t, "admin2", diff.RemovedAdmins[0].Id)
+		require.Equal(t, []byte("admin1-new-cert"), diff.UpdatedAdmins[0].Certificate)
+		require.Equal(t, [][]byte{[]byte("new-root-ca")}, diff.AddedRootCas)
+		require.Equal(t, [][]byte{[]byte("root-ca")}, diff.RemovedRootCas)
+		require.Empty(t, diff.AddedIntermediateCas)
+		require.Empty(t, diff.RemovedIntermediateCas)
+		require.Empty(t, diff.AddedMembers)
+		require.Equal(t, "node1", diff.RemovedMembers[0].NodeId)
+		require.Empty(t, diff.UpdatedMembers)
+		require.Equal(t, "node3", diff.AddedObservers[0].NodeId)
+		require.Equal(t, uint32(100), diff.FromRaftConfig.ElectionTicks)
+		require.Equal(t, uint32(200), diff.ToRaftConfig.ElectionTicks)
+		require.Equal(t, uint32(1), diff.FromProtocolVersion)
+		require.Equal(t, uint32(2), diff.ToProtocolVersion)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
 	crypto_mocks "github.com/hyperledger-labs/orion-server/pkg/crypto/mocks"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -186,7 +187,8 @@ func setupConfigQueryTest(t *testing.T, env *configQueryTestEnv, blocksNum int)
 	genesisBlock := &types.Block{
 		Header: &types.BlockHeader{
 			BaseHeader: &types.BlockHeaderBase{
-				Number: 1,
+				Number:    1,
+				Timestamp: 1000,
 			},
 			ValidationInfo: []*types.ValidationInfo{
 				{
@@ -209,6 +211,21 @@ func setupConfigQueryTest(t *testing.T, env *configQueryTestEnv, blocksNum int)
 
 	configSerialized, err := proto.Marshal(genesisConfig)
 	require.NoError(t, err)
+	require.NoError(t, env.ledgerQP.provenanceStore.Commit(1, 1000, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  worldstate.ConfigDBName,
+			UserID:  "adminUser",
+			TxID:    "configTx1",
+			Writes: []*types.KVWithMetadata{
+				{
+					Key:      worldstate.ConfigKey,
+					Value:    configSerialized,
+					Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}},
+				},
+			},
+		},
+	}))
 
 	adminUpdates, err := identity.ConstructDBEntriesForClusterAdmins(nil, genesisConfig.Admins, &types.Version{BlockNum: 1})
 	require.NoError(t, err)
@@ -286,6 +303,8 @@ func setupConfigQueryTest(t *testing.T, env *configQueryTestEnv, blocksNum int)
 		Address:     "127.0.0.1",
 		Port:        6091,
 		Certificate: []byte("bogus-cert"),
+		Region:      "eu-west",
+		Zone:        "eu-west-1a",
 	})
 	newConfig.ConsensusConfig.Members = append(newConfig.ConsensusConfig.Members, &types.PeerConfig{
 		NodeId:   "node2",
@@ -293,10 +312,12 @@ func setupConfigQueryTest(t *testing.T, env *configQueryTestEnv, blocksNum int)
 		PeerHost: "127.0.0.1",
 		PeerPort: 7091,
 	})
+	newConfig.CertAuthConfig.Intermediates = append(newConfig.CertAuthConfig.Intermediates, []byte("bogus-intermediate-ca"))
 	configBlock := &types.Block{
 		Header: &types.BlockHeader{
 			BaseHeader: &types.BlockHeaderBase{
-				Number: uint64(blocksNum),
+				Number:    uint64(blocksNum),
+				Timestamp: 2000,
 			},
 			ValidationInfo: []*types.ValidationInfo{
 				{
@@ -308,7 +329,7 @@ func setupConfigQueryTest(t *testing.T, env *configQueryTestEnv, blocksNum int)
 			ConfigTxEnvelope: &types.ConfigTxEnvelope{
 				Payload: &types.ConfigTx{
 					UserId:               "adminUser",
-					TxId:                 "configTx1",
+					TxId:                 "configTx2",
 					ReadOldConfigVersion: nil,
 					NewConfig:            newConfig,
 				},
@@ -319,6 +340,24 @@ func setupConfigQueryTest(t *testing.T, env *configQueryTestEnv, blocksNum int)
 
 	configSerialized, err = proto.Marshal(newConfig)
 	require.NoError(t, err)
+	require.NoError(t, env.ledgerQP.provenanceStore.Commit(uint64(blocksNum), 2000, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  worldstate.ConfigDBName,
+			UserID:  "adminUser",
+			TxID:    "configTx2",
+			Writes: []*types.KVWithMetadata{
+				{
+					Key:      worldstate.ConfigKey,
+					Value:    configSerialized,
+					Metadata: &types.Metadata{Version: &types.Version{BlockNum: uint64(blocksNum)}},
+				},
+			},
+			OldVersionOfWrites: map[string]*types.Version{
+				worldstate.ConfigKey: {BlockNum: 1},
+			},
+		},
+	}))
 
 	createConfig = map[string]*worldstate.DBUpdates{
 		worldstate.ConfigDBName: {
@@ -389,6 +428,103 @@ func TestGetConfigBlock(t *testing.T) {
 	})
 }
 
+func TestGetConfigHistory(t *testing.T) {
+	env := newConfigQueryTestEnv(t)
+	require.NotNil(t, env)
+	defer env.cleanup(t)
+	setupConfigQueryTest(t, env, 10)
+
+	t.Run("getConfigHistory returns all config blocks", func(t *testing.T) {
+		historyResp, err := env.ledgerQP.getConfigHistory("admin1")
+		require.NoError(t, err)
+		require.NotNil(t, historyResp)
+
+		expected := []*types.ConfigHistoryEntry{
+			{
+				BlockNumber:      1,
+				TxId:             "configTx1",
+				UserId:           "adminUser",
+				Timestamp:        1000,
+				NodesChanged:     true,
+				AdminsChanged:    true,
+				CaChanged:        true,
+				ConsensusChanged: true,
+			},
+			{
+				BlockNumber:      10,
+				TxId:             "configTx2",
+				UserId:           "adminUser",
+				Timestamp:        2000,
+				NodesChanged:     true,
+				CaChanged:        true,
+				ConsensusChanged: true,
+			},
+		}
+		require.Len(t, historyResp.GetEntries(), len(expected))
+		for i, entry := range historyResp.GetEntries() {
+			require.True(t, proto.Equal(expected[i], entry), "expected: %v, actual: %v", expected[i], entry)
+		}
+	})
+
+	t.Run("getConfigHistory error: not an admin user", func(t *testing.T) {
+		historyResp, err := env.ledgerQP.getConfigHistory("testUser")
+		require.EqualError(t, err, "the user [testUser] has no permission to read the config history")
+		require.Nil(t, historyResp)
+	})
+}
+
+func TestGetConfigDiff(t *testing.T) {
+	env := newConfigQueryTestEnv(t)
+	require.NotNil(t, env)
+	defer env.cleanup(t)
+	setupConfigQueryTest(t, env, 10)
+
+	t.Run("getConfigDiff between genesis and last config", func(t *testing.T) {
+		diffResp, err := env.ledgerQP.getConfigDiff("admin1", 1, 10)
+		require.NoError(t, err)
+		require.NotNil(t, diffResp)
+		require.Equal(t, uint64(1), diffResp.GetFromBlockNumber())
+		require.Equal(t, uint64(10), diffResp.GetToBlockNumber())
+
+		diff := diffResp.GetDiff()
+		require.Len(t, diff.GetAddedNodes(), 1)
+		require.Equal(t, "node2", diff.GetAddedNodes()[0].GetId())
+		require.Len(t, diff.GetAddedMembers(), 1)
+		require.Equal(t, "node2", diff.GetAddedMembers()[0].GetNodeId())
+		require.Equal(t, [][]byte{[]byte("bogus-intermediate-ca")}, diff.GetAddedIntermediateCas())
+		require.Empty(t, diff.GetRemovedNodes())
+		require.Empty(t, diff.GetUpdatedNodes())
+		require.Empty(t, diff.GetAddedAdmins())
+		require.Empty(t, diff.GetAddedRootCas())
+		require.Nil(t, diff.GetFromRaftConfig())
+		require.Nil(t, diff.GetToRaftConfig())
+	})
+
+	t.Run("getConfigDiff in reverse order", func(t *testing.T) {
+		diffResp, err := env.ledgerQP.getConfigDiff("admin1", 10, 1)
+		require.NoError(t, err)
+
+		diff := diffResp.GetDiff()
+		require.Empty(t, diff.GetAddedNodes())
+		require.Len(t, diff.GetRemovedNodes(), 1)
+		require.Equal(t, "node2", diff.GetRemovedNodes()[0].GetId())
+		require.Len(t, diff.GetRemovedMembers(), 1)
+		require.Equal(t, [][]byte{[]byte("bogus-intermediate-ca")}, diff.GetRemovedIntermediateCas())
+	})
+
+	t.Run("getConfigDiff error: not a config block", func(t *testing.T) {
+		diffResp, err := env.ledgerQP.getConfigDiff("admin1", 1, 2)
+		require.EqualError(t, err, "block [2] is not a committed config block")
+		require.Nil(t, diffResp)
+	})
+
+	t.Run("getConfigDiff error: not an admin user", func(t *testing.T) {
+		diffResp, err := env.ledgerQP.getConfigDiff("testUser", 1, 10)
+		require.EqualError(t, err, "the user [testUser] has no permission to read the config history")
+		require.Nil(t, diffResp)
+	})
+}
+
 func TestGetClusterStatus(t *testing.T) {
 	env := newConfigQueryTestEnv(t)
 	require.NotNil(t, env)
@@ -399,6 +535,7 @@ func TestGetClusterStatus(t *testing.T) {
 		worldstateQueryProcessor: env.stateQP,
 		ledgerQueryProcessor:     env.ledgerQP,
 		db:                       env.db,
+		nodeCert:                 []byte("bogus-node-cert"),
 		logger:                   env.logger,
 	}
 
@@ -409,7 +546,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("node1", []string{"node1", "node2"})
-		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+		signerMock.On("SignHash", mock.Anything).Return([]byte("bogus-sig"), nil)
 
 		status, err := bcdb.GetClusterStatus(false)
 		require.NoError(t, err)
@@ -421,10 +558,15 @@ func TestGetClusterStatus(t *testing.T) {
 		require.NotNil(t, status.Response.Nodes[0].Certificate)
 		require.Equal(t, "node2", status.Response.Nodes[1].Id)
 		require.NotNil(t, status.Response.Nodes[1].Certificate)
+		require.Equal(t, "eu-west", status.Response.Nodes[1].Region)
+		require.Equal(t, "eu-west-1a", status.Response.Nodes[1].Zone)
 
 		require.Equal(t, &types.Version{BlockNum: 10}, status.Response.Version)
 		require.Equal(t, "node1", status.Response.Leader)
 		require.Equal(t, []string{"node1", "node2"}, status.Response.Active)
+
+		// the CA config does not trust the certificate of the node
+		require.Equal(t, &types.NodeAttestation{Certificate: []byte("bogus-node-cert")}, status.Response.Attestation)
 	})
 
 	t.Run("valid: no leader", func(t *testing.T) {
@@ -434,7 +576,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("", []string{"node1"})
-		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+		signerMock.On("SignHash", mock.Anything).Return([]byte("bogus-sig"), nil)
 		status, err := bcdb.GetClusterStatus(false)
 		require.NoError(t, err)
 		require.NotNil(t, status)
@@ -458,7 +600,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("node1", []string{"node1", "node2"})
-		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+		signerMock.On("SignHash", mock.Anything).Return([]byte("bogus-sig"), nil)
 		status, err := bcdb.GetClusterStatus(true)
 		require.NoError(t, err)
 		require.NotNil(t, status)
@@ -482,7 +624,7 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("bogus-node", []string{"node1", "node2", "bogus-node"})
-		signerMock.On("Sign", mock.Anything).Return([]byte("bogus-sig"), nil)
+		signerMock.On("SignHash", mock.Anything).Return([]byte("bogus-sig"), nil)
 
 		status, err := bcdb.GetClusterStatus(false)
 		require.NoError(t, err)
@@ -507,9 +649,56 @@ func TestGetClusterStatus(t *testing.T) {
 		bcdb.signer = signerMock
 
 		txProcMock.On("ClusterStatus").Return("node1", []string{"node1", "node2"})
-		signerMock.On("Sign", mock.Anything).Return(nil, fmt.Errorf("oops"))
+		signerMock.On("SignHash", mock.Anything).Return(nil, fmt.Errorf("oops"))
 		status, err := bcdb.GetClusterStatus(false)
 		require.EqualError(t, err, "oops")
 		require.Nil(t, status)
 	})
+
+	t.Run("valid: attestation", func(t *testing.T) {
+		cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node1"}, true)
+		nodeCert, _ := testutils.LoadTestClientCrypto(t, cryptoDir, "node1")
+		rootCACert, _ := testutils.LoadTestClientCA(t, cryptoDir, testutils.RootCAFileName)
+		midCACert, _ := testutils.LoadTestClientCA(t, cryptoDir, testutils.IntermediateCAFileName)
+
+		config := &types.ClusterConfig{
+			Nodes: []*types.NodeConfig{{Id: "node1", Address: "127.0.0.1", Port: 6090, Certificate: nodeCert.Raw}},
+			CertAuthConfig: &types.CAConfig{
+				Roots:         [][]byte{rootCACert.Raw},
+				Intermediates: [][]byte{midCACert.Raw},
+			},
+		}
+		configSerialized, err := proto.Marshal(config)
+		require.NoError(t, err)
+		require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      worldstate.ConfigKey,
+						Value:    configSerialized,
+						Metadata: &types.Metadata{Version: &types.Version{BlockNum: 11}},
+					},
+				},
+			},
+		}, 11))
+
+		txProcMock := &mocks.TxProcessor{}
+		signerMock := &crypto_mocks.Signer{}
+		bcdb.txProcessor = txProcMock
+		bcdb.signer = signerMock
+		bcdb.nodeCert = nodeCert.Raw
+
+		txProcMock.On("ClusterStatus").Return("node1", []string{"node1"})
+		signerMock.On("SignHash", mock.Anything).Return([]byte("bogus-sig"), nil)
+		status, err := bcdb.GetClusterStatus(true)
+		require.NoError(t, err)
+		require.Equal(t, &types.NodeAttestation{
+			Certificate: nodeCert.Raw,
+			CaChain:     [][]byte{midCACert.Raw, rootCACert.Raw},
+		}, status.Response.Attestation)
+
+		nodeConfig, err := bcdb.GetNodeConfig("node1")
+		require.NoError(t, err)
+		require.Equal(t, status.Response.Attestation, nodeConfig.Response.Attestation)
+	})
 }
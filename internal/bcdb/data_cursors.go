@@ -0,0 +1,332 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
+	"github.com/hyperledger-labs/orion-server/internal/redaction"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+const (
+	// DefaultDataCursorTTL is the time a cursor is held without being read when no TTL is requested
+	DefaultDataCursorTTL = 10 * time.Minute
+	// MaxDataCursorTTL is the maximal time a cursor can be held without being read
+	MaxDataCursorTTL = 24 * time.Hour
+	// MaxDataCursors is the maximal number of cursors held at once
+	MaxDataCursors = 100
+	// DefaultDataCursorPageSize is the number of key-value pairs of a page when no limit is requested
+	DefaultDataCursorPageSize = 1000
+	// MaxDataCursorPageSize is the maximal number of key-value pairs of a page
+	MaxDataCursorPageSize = 10000
+)
+
+// dataCursor is a cursor over the keys of a database that start with a prefix. It reads a snapshot of the database,
+// so that an export that spans many requests sees a consistent state, and is resumed after a dropped connection from
+// the last key the client received.
+type dataCursor struct {
+	id        string
+	userID    string
+	dbName    string
+	prefix    string
+	ttl       time.Duration
+	expiresAt time.Time
+	snapshot  worldstate.DBsSnapshot
+	// readers is the number of pages being read; the snapshot of a closed cursor is released by its last reader
+	readers int
+	closed  bool
+}
+
+// dataCursorPool holds the open cursors. The pool is held in memory: the cursors outlive the connections they are read
+// over, but are lost when the server restarts. A cursor that is not read for its TTL is closed when the pool is next
+// used.
+type dataCursorPool struct {
+	lock    sync.Mutex
+	cursors map[string]*dataCursor
+	nowFn   func() time.Time
+}
+
+func newDataCursorPool() *dataCursorPool {
+	return &dataCursorPool{
+		cursors: make(map[string]*dataCursor),
+		nowFn:   time.Now,
+	}
+}
+
+// openDataCursor opens a cursor over the keys of a database that start with the prefix. The snapshot of the cursor is
+// charged to the memory budget until the cursor is closed.
+func (q *worldstateQueryProcessor) openDataCursor(querierUserID, dbName, prefix string, ttl time.Duration) (*types.DataCursorResponse, error) {
+	if ttl == 0 {
+		ttl = DefaultDataCursorTTL
+	}
+	if ttl < 0 || ttl > MaxDataCursorTTL {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the TTL [%s] of a cursor must be positive and up to %s", ttl, MaxDataCursorTTL),
+		}
+	}
+	if err := q.checkDataCursorAccess(querierUserID, dbName); err != nil {
+		return nil, err
+	}
+
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, err
+	}
+
+	p := q.cursors
+	p.lock.Lock()
+	p.removeExpired()
+	full := len(p.cursors) >= MaxDataCursors
+	p.lock.Unlock()
+	if full {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the number of cursors reached the limit of %d", MaxDataCursors),
+		}
+	}
+
+	snapshot, err := q.getDBsSnapshot(context.Background(), "data cursor", []string{dbName})
+	if err != nil {
+		return nil, err
+	}
+
+	c := &dataCursor{
+		id:       hex.EncodeToString(idBytes),
+		userID:   querierUserID,
+		dbName:   dbName,
+		prefix:   prefix,
+		ttl:      ttl,
+		snapshot: snapshot,
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.cursors) >= MaxDataCursors {
+		snapshot.Release()
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the number of cursors reached the limit of %d", MaxDataCursors),
+		}
+	}
+	c.expiresAt = p.nowFn().Add(ttl)
+	p.cursors[c.id] = c
+
+	return c.response(), nil
+}
+
+// getDataCursorPage returns up to limit key-value pairs of a cursor whose keys follow the given key, which the querier
+// can read. A value the querier reads a redacted view of is redacted. Reading a page extends the expiry of the cursor
+// by its TTL.
+func (q *worldstateQueryProcessor) getDataCursorPage(querierUserID, cursorID, after string, limit uint64) (*types.GetDataCursorPageResponse, error) {
+	if limit == 0 {
+		limit = DefaultDataCursorPageSize
+	}
+	if limit > MaxDataCursorPageSize {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the limit [%d] of a cursor page must be up to %d", limit, MaxDataCursorPageSize),
+		}
+	}
+
+	c, err := q.cursors.acquire(querierUserID, cursorID)
+	if err != nil {
+		return nil, err
+	}
+	defer q.cursors.release(c)
+
+	if err := q.checkDataCursorAccess(querierUserID, c.dbName); err != nil {
+		return nil, err
+	}
+
+	start := c.prefix
+	if after != "" {
+		if !strings.HasPrefix(after, c.prefix) {
+			return nil, &ierrors.BadRequestError{
+				ErrMsg: "the key [" + after + "] does not start with the prefix [" + c.prefix + "] of the cursor [" + cursorID + "]",
+			}
+		}
+		// the smallest key that follows the given key
+		start = after + "\x00"
+	}
+
+	result := q.memBudget.NewReservation(membudget.KindQueryResult)
+	defer result.Release()
+
+	itr, err := c.snapshot.GetIterator(c.dbName, start, "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	page := &types.GetDataCursorPageResponse{}
+	for itr.Next() {
+		key := string(itr.Key())
+		if !strings.HasPrefix(key, c.prefix) {
+			break
+		}
+
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, err
+		}
+
+		policy, canRead := redaction.ReadAccess(persisted.GetMetadata().GetAccessControl(), querierUserID)
+		if !canRead {
+			continue
+		}
+		value := persisted.Value
+		if policy != nil {
+			if value, err = redaction.Redact(value, policy); err != nil {
+				continue
+			}
+		}
+
+		if uint64(len(page.KVs)) == limit {
+			page.HasMore = true
+			break
+		}
+
+		if err := q.growResult(context.Background(), result, uint64(len(key)+len(value)+proto.Size(persisted.Metadata))); err != nil {
+			return nil, err
+		}
+		page.KVs = append(page.KVs, &types.KVWithMetadata{
+			Key:      key,
+			Value:    value,
+			Metadata: persisted.Metadata,
+		})
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+
+	page.ExpiresAt = c.expiresAt.UnixNano()
+	return page, nil
+}
+
+// closeDataCursor closes a cursor of the querier
+func (q *worldstateQueryProcessor) closeDataCursor(querierUserID, cursorID string) (*types.DataCursorResponse, error) {
+	p := q.cursors
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.removeExpired()
+
+	c, err := p.get(querierUserID, cursorID)
+	if err != nil {
+		return nil, err
+	}
+	p.remove(c)
+
+	return c.response(), nil
+}
+
+// closeDataCursors closes all cursors
+func (q *worldstateQueryProcessor) closeDataCursors() {
+	p := q.cursors
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, c := range p.cursors {
+		p.remove(c)
+	}
+}
+
+func (q *worldstateQueryProcessor) checkDataCursorAccess(querierUserID, dbName string) error {
+	if worldstate.IsSystemDB(dbName) {
+		return &ierrors.PermissionErr{
+			ErrMsg: "no user can directly read from a system database [" + dbName + "]. " +
+				"To read from a system database, use /config, /user, /db rest endpoints instead of /data",
+		}
+	}
+
+	hasPerm, err := q.identityQuerier.HasReadAccessOnDataDB(querierUserID, dbName)
+	if err != nil {
+		return err
+	}
+	if !hasPerm {
+		return &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to read from database [" + dbName + "]",
+		}
+	}
+
+	return nil
+}
+
+func (c *dataCursor) response() *types.DataCursorResponse {
+	return &types.DataCursorResponse{
+		CursorId:  c.id,
+		DbName:    c.dbName,
+		Prefix:    c.prefix,
+		ExpiresAt: c.expiresAt.UnixNano(),
+	}
+}
+
+// acquire returns a cursor of the querier and extends its expiry. The snapshot of the cursor is held until the cursor
+// is released.
+func (p *dataCursorPool) acquire(querierUserID, cursorID string) (*dataCursor, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.removeExpired()
+
+	c, err := p.get(querierUserID, cursorID)
+	if err != nil {
+		return nil, err
+	}
+	c.readers++
+	c.expiresAt = p.nowFn().Add(c.ttl)
+
+	return c, nil
+}
+
+func (p *dataCursorPool) release(c *dataCursor) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	c.readers--
+	if c.closed && c.readers == 0 {
+		c.snapshot.Release()
+	}
+}
+
+// get returns a cursor. Only the user who opened a cursor can use it.
+func (p *dataCursorPool) get(querierUserID, cursorID string) (*dataCursor, error) {
+	c, ok := p.cursors[cursorID]
+	if !ok {
+		return nil, &ierrors.NotFoundErr{Message: "there is no cursor with ID [" + cursorID + "]"}
+	}
+	if c.userID != querierUserID {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] did not open the cursor [" + cursorID + "]",
+		}
+	}
+
+	return c, nil
+}
+
+// remove removes a cursor from the pool, and releases its snapshot unless a page of the cursor is being read
+func (p *dataCursorPool) remove(c *dataCursor) {
+	delete(p.cursors, c.id)
+	c.closed = true
+	if c.readers == 0 {
+		c.snapshot.Release()
+	}
+}
+
+func (p *dataCursorPool) removeExpired() {
+	now := p.nowFn()
+	for _, c := range p.cursors {
+		if now.After(c.expiresAt) {
+			p.remove(c)
+		}
+	}
+}
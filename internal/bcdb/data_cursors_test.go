@@ -0,0 +1,253 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataCursors(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	var userWrites []*worldstate.KVWithMetadata
+	for _, user := range []*types.User{
+		{
+			Id: "alice",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					"test-db": types.Privilege_Read,
+				},
+			},
+		},
+		{
+			Id: "bob",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					"test-db": types.Privilege_ReadWrite,
+				},
+			},
+		},
+		{
+			Id: "charlie",
+		},
+	} {
+		u, err := proto.Marshal(user)
+		require.NoError(t, err)
+		userWrites = append(userWrites, &worldstate.KVWithMetadata{
+			Key:   string(identity.UserNamespace) + user.Id,
+			Value: u,
+		})
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: userWrites,
+		},
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "test-db"},
+			},
+		},
+	}, 1))
+
+	readableByBob := &types.Metadata{
+		AccessControl: &types.AccessControl{
+			ReadUsers: map[string]bool{
+				"bob": true,
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"test-db": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "item/1", Value: []byte("value1")},
+				{Key: "item/2", Value: []byte("value2"), Metadata: readableByBob},
+				{Key: "item/3", Value: []byte("value3")},
+				{Key: "item/4", Value: []byte("value4")},
+				{Key: "items", Value: []byte("value")},
+			},
+		},
+	}, 2))
+
+	now := time.Unix(1000, 0)
+	env.q.cursors.nowFn = func() time.Time { return now }
+
+	pageKeys := func(page *types.GetDataCursorPageResponse) []string {
+		var keys []string
+		for _, kv := range page.KVs {
+			keys = append(keys, kv.Key)
+		}
+		return keys
+	}
+
+	t.Run("read pages of a snapshot", func(t *testing.T) {
+		cursor, err := env.q.openDataCursor("bob", "test-db", "item/", 0)
+		require.NoError(t, err)
+		require.Len(t, cursor.CursorId, 32)
+		require.Equal(t, "test-db", cursor.DbName)
+		require.Equal(t, "item/", cursor.Prefix)
+		require.Equal(t, now.Add(DefaultDataCursorTTL).UnixNano(), cursor.ExpiresAt)
+
+		// a write committed after the cursor is opened is not seen by the cursor
+		require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+			"test-db": {
+				Writes:  []*worldstate.KVWithMetadata{{Key: "item/5", Value: []byte("value5")}},
+				Deletes: []string{"item/1"},
+			},
+		}, 3))
+
+		page, err := env.q.getDataCursorPage("bob", cursor.CursorId, "", 3)
+		require.NoError(t, err)
+		require.Equal(t, []string{"item/1", "item/2", "item/3"}, pageKeys(page))
+		require.Equal(t, []byte("value1"), page.KVs[0].Value)
+		require.True(t, page.HasMore)
+
+		// a page that is read again after a lost connection returns the same key-value pairs
+		for i := 0; i < 2; i++ {
+			page, err = env.q.getDataCursorPage("bob", cursor.CursorId, "item/3", 3)
+			require.NoError(t, err)
+			require.Equal(t, []string{"item/4"}, pageKeys(page))
+			require.False(t, page.HasMore)
+		}
+
+		closed, err := env.q.closeDataCursor("bob", cursor.CursorId)
+		require.NoError(t, err)
+		require.Equal(t, cursor.CursorId, closed.CursorId)
+
+		page, err = env.q.getDataCursorPage("bob", cursor.CursorId, "", 0)
+		require.EqualError(t, err, "there is no cursor with ID ["+cursor.CursorId+"]")
+		require.IsType(t, &ierrors.NotFoundErr{}, err)
+		require.Nil(t, page)
+	})
+
+	t.Run("skip the values the user cannot read", func(t *testing.T) {
+		cursor, err := env.q.openDataCursor("alice", "test-db", "item/", 0)
+		require.NoError(t, err)
+		defer env.q.closeDataCursor("alice", cursor.CursorId)
+
+		page, err := env.q.getDataCursorPage("alice", cursor.CursorId, "", 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{"item/3", "item/4", "item/5"}, pageKeys(page))
+		require.False(t, page.HasMore)
+	})
+
+	t.Run("only the user who opened the cursor can use it", func(t *testing.T) {
+		cursor, err := env.q.openDataCursor("bob", "test-db", "", 0)
+		require.NoError(t, err)
+		defer env.q.closeDataCursor("bob", cursor.CursorId)
+
+		page, err := env.q.getDataCursorPage("alice", cursor.CursorId, "", 0)
+		require.EqualError(t, err, "the user [alice] did not open the cursor ["+cursor.CursorId+"]")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+		require.Nil(t, page)
+
+		closed, err := env.q.closeDataCursor("alice", cursor.CursorId)
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+		require.Nil(t, closed)
+	})
+
+	t.Run("a cursor expires when it is not read for its TTL", func(t *testing.T) {
+		cursor, err := env.q.openDataCursor("bob", "test-db", "item/", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), cursor.ExpiresAt)
+
+		now = now.Add(50 * time.Second)
+		page, err := env.q.getDataCursorPage("bob", cursor.CursorId, "", 1)
+		require.NoError(t, err)
+		require.Equal(t, now.Add(time.Minute).UnixNano(), page.ExpiresAt)
+
+		now = now.Add(50 * time.Second)
+		page, err = env.q.getDataCursorPage("bob", cursor.CursorId, "item/1", 1)
+		require.NoError(t, err)
+		require.Equal(t, []string{"item/2"}, pageKeys(page))
+
+		now = now.Add(time.Minute + time.Second)
+		page, err = env.q.getDataCursorPage("bob", cursor.CursorId, "item/2", 1)
+		require.IsType(t, &ierrors.NotFoundErr{}, err)
+		require.Nil(t, page)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		cursor, err := env.q.openDataCursor("bob", "test-db", "", MaxDataCursorTTL+time.Second)
+		require.EqualError(t, err, "the TTL [24h0m1s] of a cursor must be positive and up to 24h0m0s")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+		require.Nil(t, cursor)
+
+		cursor, err = env.q.openDataCursor("charlie", "test-db", "", 0)
+		require.EqualError(t, err, "the user [charlie] has no permission to read from database [test-db]")
+		require.Nil(t, cursor)
+
+		cursor, err = env.q.openDataCursor("bob", worldstate.UsersDBName, "", 0)
+		require.EqualError(t, err, "no user can directly read from a system database [_users]. "+
+			"To read from a system database, use /config, /user, /db rest endpoints instead of /data")
+		require.Nil(t, cursor)
+
+		cursor, err = env.q.openDataCursor("bob", "test-db", "item/", 0)
+		require.NoError(t, err)
+		defer env.q.closeDataCursor("bob", cursor.CursorId)
+
+		page, err := env.q.getDataCursorPage("bob", cursor.CursorId, "", MaxDataCursorPageSize+1)
+		require.EqualError(t, err, "the limit [10001] of a cursor page must be up to 10000")
+		require.Nil(t, page)
+
+		page, err = env.q.getDataCursorPage("bob", cursor.CursorId, "other", 0)
+		require.EqualError(t, err, "the key [other] does not start with the prefix [item/] of the cursor ["+cursor.CursorId+"]")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+		require.Nil(t, page)
+	})
+
+	t.Run("limit on the number of cursors", func(t *testing.T) {
+		var ids []string
+		for i := 0; i < MaxDataCursors; i++ {
+			cursor, err := env.q.openDataCursor("bob", "test-db", "", 0)
+			require.NoError(t, err)
+			ids = append(ids, cursor.CursorId)
+		}
+
+		cursor, err := env.q.openDataCursor("bob", "test-db", "", 0)
+		require.EqualError(t, err, "the number of cursors reached the limit of 100")
+		require.Nil(t, cursor)
+
+		env.q.closeDataCursors()
+		for _, id := range ids {
+			_, err := env.q.closeDataCursor("bob", id)
+			require.IsType(t, &ierrors.NotFoundErr{}, err)
+		}
+	})
+
+	t.Run("the snapshot is charged to the memory budget until the cursor is closed", func(t *testing.T) {
+		memBudget := membudget.New(&membudget.Config{LimitBytes: 150, Logger: env.q.logger})
+		env.q.memBudget = memBudget
+		env.q.snapshotBytes = 80
+		env.q.memBudgetWait = 10 * time.Millisecond
+		defer func() {
+			env.q.memBudget = nil
+		}()
+
+		cursor, err := env.q.openDataCursor("bob", "test-db", "item/", 0)
+		require.NoError(t, err)
+		require.Equal(t, uint64(80), memBudget.Usage().UsedBytes)
+
+		page, err := env.q.getDataCursorPage("bob", cursor.CursorId, "", 2)
+		require.NoError(t, err)
+		require.Len(t, page.KVs, 2)
+		require.Equal(t, uint64(80), memBudget.Usage().UsedBytes)
+
+		second, err := env.q.openDataCursor("bob", "test-db", "item/", 0)
+		require.IsType(t, &ierrors.ResourceExhaustedError{}, err)
+		require.Nil(t, second)
+
+		_, err = env.q.closeDataCursor("bob", cursor.CursorId)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), memBudget.Usage().UsedBytes)
+	})
+}
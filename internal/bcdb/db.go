@@ -4,19 +4,31 @@ package bcdb
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
-	"encoding/json"
 	"encoding/pem"
+	"fmt"
 	"io/ioutil"
 	"time"
 
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/encryption"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
 	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/ordering"
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/queryexecutor"
+	"github.com/hyperledger-labs/orion-server/internal/quota"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
 	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
@@ -39,40 +51,146 @@ type DB interface {
 	// IsLeader returns whether the this server is the leader
 	IsLeader() *ierrors.NotLeaderError
 
+	// Ready returns an UnavailableError if the node halted the commit of blocks, e.g., on a quarantined block or on a
+	// failure of the state trie, and nil otherwise
+	Ready() error
+
 	// DoesUserExist checks whenever user with given userID exists
 	DoesUserExist(userID string) (bool, error)
 
+	// IsAdmin checks whether the user with given userID has the admin privilege
+	IsAdmin(userID string) (bool, error)
+
 	// GetCertificate returns the certificate associated with useID, if it exists.
 	GetCertificate(userID string) (*x509.Certificate, error)
 
 	// GetUser retrieves user' record
 	GetUser(querierUserID, targetUserID string) (*types.GetUserResponseEnvelope, error)
 
+	// GetUsers returns the users the querier can read whose ID starts with the prefix. The
+	// first offset users are skipped and up to limit users are returned, where zero means no limit
+	GetUsers(querierUserID, prefix string, offset, limit uint64) (*types.GetUsersResponseEnvelope, error)
+
 	// GetConfig returns database configuration.
 	// Limited access to admins only. Regular users can use the `GetNodeConfig` or `GetClusterStatus` APIs to discover
 	// and fetch the details of nodes that are needed for external cluster access.
 	GetConfig(querierUserID string) (*types.GetConfigResponseEnvelope, error)
 
 	// GetConfigBlock returns a config block.
-	// Only admin users can get a config block.
+	// By default, only admin users can get a config block.
 	// If blockNumber==0, the last config block is returned.
 	GetConfigBlock(querierUserID string, blockNumber uint64) (*types.GetConfigBlockResponseEnvelope, error)
 
+	// GetConfigHistory returns the committed config blocks, in ascending order, together with the transaction that
+	// committed each of them and the sections of the config it changed. By default, only admin users can get the
+	// config history.
+	GetConfigHistory(querierUserID string) (*types.GetConfigHistoryResponseEnvelope, error)
+
+	// GetConfigDiff returns the changes between the cluster configs committed in two config blocks.
+	// By default, only admin users can get a config diff.
+	GetConfigDiff(querierUserID string, fromBlockNumber, toBlockNumber uint64) (*types.GetConfigDiffResponseEnvelope, error)
+
 	// GetClusterStatus returns the cluster status:
 	// - the nodes, as defined in the ClusterConfig, without certificates if `noCert`=true;
 	// - the ID of the leader, if it exists;
 	// - the IDs of all active nodes, including the leader.
 	GetClusterStatus(noCerts bool) (*types.GetClusterStatusResponseEnvelope, error)
 
+	// TriggerSnapshot takes a consensus snapshot on the local node, and purges the WAL and snapshot files that are no
+	// longer retained. By default, only admin users can trigger a snapshot.
+	TriggerSnapshot(querierUserID string) (*types.TriggerSnapshotResponseEnvelope, error)
+
+	// TransferLeadership gracefully transfers the leadership from the local node to the target node, after draining
+	// the in-flight blocks. If the target node ID is empty, the local node chooses one of the active nodes.
+	// By default, only admin users can transfer the leadership.
+	TransferLeadership(querierUserID, targetNodeID string, timeout time.Duration) (*types.TransferLeadershipResponseEnvelope, error)
+
+	// GetConsensusDiagnostics returns the state of consensus on the local node: the Raft state, term, and indexes,
+	// the number of elections and leader changes, and the replication progress of the peers, if the node is the
+	// leader. By default, only admin users can get the consensus diagnostics.
+	GetConsensusDiagnostics(querierUserID string) (*types.GetConsensusDiagnosticsResponseEnvelope, error)
+
+	// GetWitnessStatus returns the height up to which a witness or standby node verified the blocks it pulled, whether
+	// verification halted, and the discrepancy alerts it most recently raised. By default, only admin users can get
+	// the witness status.
+	GetWitnessStatus(querierUserID string) (*types.GetWitnessStatusResponseEnvelope, error)
+
+	// GetStorageReport scans the data databases and the provenance store, and reports the duplicate values, the
+	// largest keys and key prefixes, and the keys with the most versions, with up to top entries in each list. Only
+	// admin users can get the storage report.
+	GetStorageReport(querierUserID string, top uint32, prefixDelimiter string) (*types.GetStorageReportResponseEnvelope, error)
+
+	// GetOpenSnapshots returns the snapshots of the state database that are not yet released, with their owners and
+	// ages. It serves the diagnostics endpoints, which check the access of the querier themselves.
+	GetOpenSnapshots() *types.OpenSnapshots
+
+	// GetStateHash computes the canonical hash of the full contents of a database at the current height of the world
+	// state, so that replicas can be compared without relying on the state trie. By default, only admin users can get
+	// the hash.
+	GetStateHash(querierUserID, dbName string) (*types.GetStateHashResponseEnvelope, error)
+
+	// CompactIndex compacts the storage of the index of a database, which drops the tombstones of the deleted index
+	// entries, and returns the sizes of the index before and after the compaction. By default, only admin users can
+	// compact an index.
+	CompactIndex(querierUserID, dbName string) (*types.CompactIndexResponseEnvelope, error)
+
+	// GetQuarantinedBlock returns the block that the node failed to validate or commit, and quarantined, with the
+	// diagnostic state of the failure, if any. While a block is quarantined, the node commits no further blocks and
+	// rejects transactions. By default, only admin users can get the quarantined block.
+	GetQuarantinedBlock(querierUserID string) (*types.GetQuarantinedBlockResponseEnvelope, error)
+
+	// RecordRejectedTx records a transaction that is rejected before it is submitted, e.g., because it cannot be
+	// decoded or its signature cannot be verified, in the dead-letter queue of the node. The transactions that
+	// SubmitTransaction rejects are recorded by the node itself.
+	RecordRejectedTx(txID, userID string, category types.RejectedTx_Category, reason string)
+
+	// GetRejectedTxs returns the dead-letter queue of the node, i.e., the transactions that the node most recently
+	// rejected before ordering, with the category and reason of each rejection. By default, only admin users can get
+	// the rejected transactions.
+	GetRejectedTxs(querierUserID string) (*types.GetRejectedTxsResponseEnvelope, error)
+
+	// GetSlowQueries returns the slow query log of the node, i.e., the data queries that the node most recently
+	// executed in more than the slow query threshold, with their plan and scan statistics. By default, only admin
+	// users can get the slow queries.
+	GetSlowQueries(querierUserID string) (*types.GetSlowQueriesResponseEnvelope, error)
+
+	// DryRunConfigTx validates a config transaction against the current config, and reports the changes it would make
+	// to the config, without submitting it. Only admin users can dry-run a config transaction.
+	DryRunConfigTx(txEnv *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponseEnvelope, error)
+
 	// GetNodeConfig returns single node subsection of database configuration
 	GetNodeConfig(nodeID string) (*types.GetNodeConfigResponseEnvelope, error)
 
 	// GetDBStatus returns status for database, checks whenever database was created
 	GetDBStatus(dbName string) (*types.GetDBStatusResponseEnvelope, error)
 
+	// GetDBs returns the databases the querier can access, limited to the hierarchical
+	// namespace denoted by the prefix when the prefix is not empty. The first offset
+	// databases are skipped and up to limit databases are returned, where zero means no limit
+	GetDBs(querierUserID, prefix string, offset, limit uint64) (*types.GetDBsResponseEnvelope, error)
+
+	// GetIndexUsage returns the number of queries that scanned the index of every indexed attribute of the data
+	// databases, and the time it was last scanned, since the node started. If unusedOnly is set, only the indexes that
+	// no query scanned are returned. By default, only admin users can get the index usage.
+	GetIndexUsage(querierUserID string, unusedOnly bool) (*types.GetIndexUsageResponseEnvelope, error)
+
+	// GetMigrations returns the applied version and the history of a migration or, if the name is empty, of all
+	// migrations. Only admin users and the users who hold a db administration privilege can get the migrations.
+	GetMigrations(querierUserID, name string) (*types.GetMigrationsResponseEnvelope, error)
+
 	// GetData retrieves values for given key
 	GetData(dbName, querierUserID, key string) (*types.GetDataResponseEnvelope, error)
 
+	// GetDataVersion retrieves the version of the value of a given key, subject to the same
+	// access control as GetData. The response is not signed, which makes it cheap to
+	// check whether a value has changed before retrieving it
+	GetDataVersion(dbName, querierUserID, key string) (*types.Version, error)
+
+	// GetDataKeys retrieves the keys of a database that start with the prefix and are readable
+	// by the querier, or only their count when countOnly is set. The keys are retrieved from the
+	// startKey, inclusive, and up to limit keys are retrieved, where zero means no limit
+	GetDataKeys(dbName, querierUserID, prefix, startKey string, limit uint64, countOnly bool) (*types.GetDataKeysResponseEnvelope, error)
+
 	// DataQuery executes a given JSON query and return key-value pairs which are matching
 	// the criteria provided in the query. The query is a json marshled bytes which needs
 	// to contain a top level combinational operator followed by a list of attributes and
@@ -96,6 +214,30 @@ type DB interface {
 	// }
 	DataQuery(ctx context.Context, dbName, querierUserID string, query []byte) (*types.DataQueryResponseEnvelope, error)
 
+	// StreamDataQuery executes a JSON query as DataQuery does, but writes the matching key-value pairs as they are read,
+	// one line of newline-delimited JSON per key-value pair, followed by a line holding the signed trailer of the
+	// result. An error that ends the query before any line is written is returned, while a later one is reported by
+	// the trailer. Nothing is written once the context is done.
+	StreamDataQuery(ctx context.Context, dbName, querierUserID string, query []byte, write func(line []byte) error) error
+
+	// DataSQLQuery executes a compiled query of the SQL dialect and returns the key-value pairs which are matching
+	// the predicates of the query, ordered and bounded as given in the query
+	DataSQLQuery(ctx context.Context, querierUserID string, query *queryexecutor.SQLQuery) (*types.DataQueryResponseEnvelope, error)
+
+	// ExplainDataQuery returns the plan that DataQuery would execute for a JSON query, i.e., the indexes it would
+	// scan and how their results would be combined, without executing the query
+	ExplainDataQuery(ctx context.Context, dbName, querierUserID string, query []byte) (*types.ExplainJSONQueryResponseEnvelope, error)
+
+	// OpenDataCursor opens a cursor over the keys of a database that start with the prefix, which reads a snapshot of
+	// the database until it is closed or is not read for the TTL
+	OpenDataCursor(querierUserID, dbName, prefix string, ttl time.Duration) (*types.DataCursorResponseEnvelope, error)
+
+	// GetDataCursorPage returns the next page of a cursor, which starts after the given key
+	GetDataCursorPage(querierUserID, cursorID, after string, limit uint64) (*types.GetDataCursorPageResponseEnvelope, error)
+
+	// CloseDataCursor closes a cursor and releases its snapshot
+	CloseDataCursor(querierUserID, cursorID string) (*types.DataCursorResponseEnvelope, error)
+
 	// GetBlockHeader returns ledger block header
 	GetBlockHeader(userID string, blockNum uint64) (*types.GetBlockResponseEnvelope, error)
 
@@ -108,57 +250,109 @@ type DB interface {
 	// GetDataProof returns hashes path from value to root in merkle-patricia trie
 	GetDataProof(userID string, blockNum uint64, dbname string, key string, deleted bool) (*types.GetDataProofResponseEnvelope, error)
 
+	// GetDBStateRoot returns the root hash of the state trie of a database at a given block, along with the path
+	// from the root hash to the state root hash stored in the block header
+	GetDBStateRoot(userID string, blockNum uint64, dbname string) (*types.GetDBStateRootResponseEnvelope, error)
+
 	// GetLedgerPath returns list of blocks that forms shortest path in skip list chain in ledger
 	GetLedgerPath(userID string, start, end uint64) (*types.GetLedgerPathResponseEnvelope, error)
 
-	// GetValues returns all values associated with a given key
-	GetValues(dbName, key string) (*types.GetHistoricalDataResponseEnvelope, error)
+	// GetValues returns the values associated with a given key, skipping the first offset values. The number of records
+	// returned would be limited by the limit parameter, where zero means no limit.
+	GetValues(querierUserID, dbName, key string, offset, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error)
 
 	// GetDeletedValues returns all deleted values associated with a given key
-	GetDeletedValues(dbname, key string) (*types.GetHistoricalDataResponseEnvelope, error)
+	GetDeletedValues(querierUserID, dbname, key string) (*types.GetHistoricalDataResponseEnvelope, error)
 
 	// GetValueAt returns the value of a given key at a particular version
-	GetValueAt(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error)
+	GetValueAt(querierUserID, dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error)
 
 	// GetMostRecentValueAtOrBelow returns the most recent value of a given key at or below the given version
-	GetMostRecentValueAtOrBelow(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error)
+	GetMostRecentValueAtOrBelow(querierUserID, dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error)
 
 	// GetPreviousValues returns previous values of a given key and a version. The number of records returned would be limited
-	// by the limit parameters.
-	GetPreviousValues(dbname, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error)
+	// by the limit parameter, where zero means no limit.
+	GetPreviousValues(querierUserID, dbname, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error)
 
 	// GetNextValues returns next values of a given key and a version. The number of records returned would be limited
-	// by the limit parameters.
-	GetNextValues(dbname, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error)
+	// by the limit parameter, where zero means no limit.
+	GetNextValues(querierUserID, dbname, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error)
 
 	// GetValuesReadByUser returns all values read by a given user
-	GetValuesReadByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error)
+	GetValuesReadByUser(querierUserID, userID string) (*types.GetDataProvenanceResponseEnvelope, error)
 
 	// GetValuesWrittenByUser returns all values written by a given user
-	GetValuesWrittenByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error)
+	GetValuesWrittenByUser(querierUserID, userID string) (*types.GetDataProvenanceResponseEnvelope, error)
 
 	// GetValuesDeletedByUser returns all values deleted by a given user
-	GetValuesDeletedByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error)
+	GetValuesDeletedByUser(querierUserID, userID string) (*types.GetDataProvenanceResponseEnvelope, error)
 
 	// GetReaders returns all userIDs who have accessed a given key as well as the access frequency
-	GetReaders(dbName, key string) (*types.GetDataReadersResponseEnvelope, error)
+	GetReaders(querierUserID, dbName, key string) (*types.GetDataReadersResponseEnvelope, error)
 
 	// GetWriters returns all userIDs who have updated a given key as well as the access frequency
-	GetWriters(dbName, key string) (*types.GetDataWritersResponseEnvelope, error)
+	GetWriters(querierUserID, dbName, key string) (*types.GetDataWritersResponseEnvelope, error)
 
 	// GetTxIDsSubmittedByUser returns all ids of all transactions submitted by a given user
-	GetTxIDsSubmittedByUser(userID string) (*types.GetTxIDsSubmittedByResponseEnvelope, error)
+	GetTxIDsSubmittedByUser(querierUserID, userID string) (*types.GetTxIDsSubmittedByResponseEnvelope, error)
+
+	// GetTxsByAnnotation returns the transactions that carry a given annotation, e.g., a correlation ID, skipping the
+	// first offset of them and up to the limit, where zero means no limit
+	GetTxsByAnnotation(querierUserID, key, value string, offset, limit uint64) (*types.GetTxsByAnnotationResponseEnvelope, error)
 
 	// GetTxReceipt returns transaction receipt - block header of ledger block that contains the transaction
 	// and transaction index inside the block
 	GetTxReceipt(userId string, txID string) (*types.TxReceiptResponseEnvelope, error)
 
+	// GetTxReceipts returns the receipts of many transactions at once, along with their proofs of inclusion, and the
+	// requested transactions that are not committed
+	GetTxReceipts(userId string, txIDs []string) (*types.GetTxReceiptsResponseEnvelope, error)
+
+	// GetTxResourceUsage returns the resources used by a valid data transaction - the bytes written, the keys touched,
+	// and the index entries generated, per database
+	GetTxResourceUsage(userId string, txID string) (*types.GetTxResourceUsageResponseEnvelope, error)
+
 	// SubmitTransaction submits transaction to the database with a timeout. If the timeout is
 	// set to 0, the submission would be treated as async while a non-zero timeout would be
 	// treated as a sync submission. When a timeout occurs with the sync submission, a
 	// timeout error will be returned
 	SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error)
 
+	// PutBlob stores a value uploaded by a user in the blob store, and returns the manifest of the blob, which a data
+	// write carries in place of the value. The transaction that writes the blob must be submitted to the same node.
+	PutBlob(userID string, value []byte) (*types.PutBlobResponseEnvelope, error)
+
+	// AddPendingDataTx adds a data transaction that is not yet signed by all its must sign users to the pending
+	// transactions held by the leader, until the remaining must sign users sign it or until the expiry elapses. An
+	// expiry of 0 means DefaultPendingDataTxExpiry. The signatures on the envelope must be verified by the caller.
+	AddPendingDataTx(txEnv *types.DataTxEnvelope, expiry time.Duration) (*types.PendingDataTxResponseEnvelope, error)
+
+	// GetPendingDataTx returns a pending data transaction. Only the must sign users of the transaction can get it.
+	GetPendingDataTx(querierUserID, txID string) (*types.PendingDataTxResponseEnvelope, error)
+
+	// GetPendingDataTxs returns the pending data transactions that await the signature of the querier.
+	GetPendingDataTxs(querierUserID string) (*types.GetPendingDataTxsResponseEnvelope, error)
+
+	// AddPendingDataTxSignature adds the signature of a must sign user to a pending data transaction. The signature
+	// must be verified by the caller. Once all must sign users signed, the transaction is no longer pending, and the
+	// returned transaction has no pending signers, and is ready to be submitted.
+	AddPendingDataTxSignature(txID, userID string, signature []byte) (*types.PendingDataTxResponseEnvelope, error)
+
+	// CreateSessionToken issues a session token to a user, which authenticates the queries of the user in place of
+	// their signatures until the TTL elapses. A TTL of 0 means DefaultSessionTokenTTL. The timestamp of the login, in
+	// unix nanoseconds, must be close to the clock of the server. The signature of the login must be verified by the
+	// caller.
+	CreateSessionToken(userID string, timestamp int64, ttl time.Duration) (*types.SessionTokenResponseEnvelope, error)
+
+	// VerifySessionToken returns the user of a session token that is issued by this node, has not expired, and whose
+	// user is neither disabled nor deleted
+	VerifySessionToken(token string) (string, error)
+
+	// CheckQueryNonce records the nonce of a signed query of a user, whose timestamp is in unix nanoseconds. It
+	// returns an error if the timestamp is outside the window around the clock of the server, or if the user already
+	// used the nonce within the window. The signature of the query must be verified by the caller.
+	CheckQueryNonce(userID, nonce string, timestamp int64) error
+
 	// IsDBExists returns true if database with given name is exists otherwise false
 	IsDBExists(name string) bool
 
@@ -170,8 +364,14 @@ type DB interface {
 type TxProcessor interface {
 	Close() error
 	ClusterStatus() (leader string, active []string)
+	CommitHalted() error
+	ConsensusDiagnostics() (*types.GetConsensusDiagnosticsResponse, error)
+	DryRunConfigTx(txEnv *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponse, error)
 	IsLeader() *ierrors.NotLeaderError
 	SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponse, error)
+	TriggerSnapshot() (*types.TriggerSnapshotResponse, error)
+	TransferLeadership(targetNodeID string, timeout time.Duration) (*types.TransferLeadershipResponse, error)
+	WitnessStatus() (*types.GetWitnessStatusResponse, error)
 }
 
 type db struct {
@@ -179,81 +379,143 @@ type db struct {
 	worldstateQueryProcessor *worldstateQueryProcessor
 	ledgerQueryProcessor     *ledgerQueryProcessor
 	provenanceQueryProcessor *provenanceQueryProcessor
+	storageAnalyzer          *storageAnalyzer
 	txProcessor              TxProcessor
 	db                       worldstate.DB
+	blobStore                *blobstore.Store
+	blobsConf                config.BlobsConf
+	blobCollector            *blobCollector
 	blockStore               *blockstore.Store
-	provenanceStore          *provenance.Store
+	provenanceStore          provenance.Store
 	stateTrieStore           *mptrieStore.Store
+	outbox                   *outbox.Outbox
+	erasure                  *erasure.Store
+	quarantine               *blockprocessor.Quarantine
+	rejectedTxs              *rejectedTxs
+	pendingTxPool            *pendingTxPool
+	sessionTokens            *sessionTokens
+	queryNonces              *queryNonces
+	canary                   *canary
+	indexCompactor           *indexCompactor
 	signer                   crypto.Signer
+	nodeCert                 []byte
 	logger                   *logger.SugarLogger
 }
 
+// Extensions holds the custom integrations registered with the database at construction.
+type Extensions struct {
+	// CommitListeners, keyed by name, are invoked after every block is committed.
+	CommitListeners map[string]CommitListener
+	// OutboxSubsystems record the external side effects of every committed block in the outbox, and publish them.
+	// The outbox store is created only if there are subsystems.
+	OutboxSubsystems []outbox.Subsystem
+	// OrderingServices, keyed by consensus algorithm, order the blocks instead of the built-in Raft consensus, when
+	// the ClusterConfig selects their algorithm.
+	OrderingServices map[string]ordering.Service
+}
+
 // NewDB creates a new database bcdb which handles both the queries and transactions.
-func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error) {
+// The extensions may be nil.
+func NewDB(conf *config.Configurations, logger *logger.SugarLogger, extensions *Extensions) (DB, error) {
+	if extensions == nil {
+		extensions = &Extensions{}
+	}
+
 	localConf := conf.LocalConfig
 	if localConf.Server.Database.Name != "leveldb" {
 		return nil, errors.New("only leveldb is supported as the state database")
 	}
 
-	ledgerDir := localConf.Server.Database.LedgerDirectory
-	if err := createLedgerDir(ledgerDir); err != nil {
+	stores, err := openLedgerStores(localConf, logger)
+	if err != nil {
 		return nil, err
 	}
+	ledgerDir := localConf.Server.Database.LedgerDirectory
+	blobStore := stores.blobStore
+	levelDB := stores.db
+	erasureStore := stores.erasure
+	blockStore := stores.blockStore
+	provenanceStore := stores.provenanceStore
+	stateTrieStore := stores.stateTrieStore
+
+	outboxSubsystems := extensions.OutboxSubsystems
+	if webhookConf := localConf.Server.QuotaWebhook; webhookConf.URL != "" {
+		outboxSubsystems = append(outboxSubsystems, quota.NewAlertsWebhook(
+			blockStore,
+			&quota.WebhookConfig{
+				URL:     webhookConf.URL,
+				Timeout: webhookConf.Timeout,
+			},
+			logger,
+		))
+	}
+	if provenanceConf := localConf.Server.Provenance; provenanceConf.Backend == provenance.Neo4jBackend {
+		neo4jExport, err := provenance.NewNeo4jExport(
+			&provenance.Neo4jConfig{
+				URL:      provenanceConf.Neo4j.URL,
+				Database: provenanceConf.Neo4j.Database,
+				Username: provenanceConf.Neo4j.Username,
+				Password: provenanceConf.Neo4j.Password,
+				Timeout:  provenanceConf.Neo4j.Timeout,
+			},
+			logger,
+		)
+		if err != nil {
+			return nil, err
+		}
+		outboxSubsystems = append(outboxSubsystems, neo4jExport)
+	}
 
-	levelDB, err := leveldb.Open(
-		&leveldb.Config{
-			DBRootDir: constructWorldStatePath(ledgerDir),
-			Logger:    logger,
-		},
-	)
-	if err != nil {
-		return nil, errors.WithMessage(err, "error while creating the world state database")
+	var outboxStore *outbox.Outbox
+	if len(outboxSubsystems) > 0 {
+		outboxStore, err = outbox.New(
+			&outbox.Config{
+				StoreDir: constructOutboxStorePath(ledgerDir),
+				Logger:   logger,
+			},
+		)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error while creating the outbox")
+		}
+		for _, subsystem := range outboxSubsystems {
+			if err = outboxStore.Register(subsystem); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	blockStore, err := blockstore.Open(
-		&blockstore.Config{
-			StoreDir: constructBlockStorePath(ledgerDir),
-			Logger:   logger,
-		},
-	)
+	identityCache := identity.NewCache(localConf.Server.Identity.ID, localConf.Server.Database.IdentityCacheSize)
+	querier := identity.NewCachedQuerier(levelDB, identityCache)
+
+	signer, err := crypto.NewSigner(&crypto.SignerOptions{KeyFilePath: localConf.Server.Identity.KeyPath})
 	if err != nil {
-		return nil, errors.WithMessage(err, "error while creating the block store")
+		return nil, errors.Wrap(err, "can't load private key")
 	}
-
-	provenanceStore, err := provenance.Open(
-		&provenance.Config{
-			StoreDir: constructProvenanceStorePath(ledgerDir),
-			Logger:   logger,
-		},
-	)
+	nodeCert, err := readNodeCert(localConf.Server.Identity.CertificatePath)
 	if err != nil {
-		return nil, errors.WithMessage(err, "error while creating the block store")
+		return nil, err
 	}
 
-	stateTrieStore, err := mptrieStore.Open(
-		&mptrieStore.Config{
-			StoreDir: constructStateTrieStorePath(ledgerDir),
-			Logger:   logger,
+	memBudgetConf := localConf.Server.MemoryBudget
+	memBudget := membudget.New(
+		&membudget.Config{
+			LimitBytes: memBudgetConf.LimitBytes,
+			Logger:     logger,
 		},
 	)
-	if err != nil {
-		return nil, errors.WithMessage(err, "error while creating the state trie store")
-	}
-
-	querier := identity.NewQuerier(levelDB)
-
-	signer, err := crypto.NewSigner(&crypto.SignerOptions{KeyFilePath: localConf.Server.Identity.KeyPath})
-	if err != nil {
-		return nil, errors.Wrap(err, "can't load private key")
-	}
 
 	worldstateQueryProcessor := newWorldstateQueryProcessor(
 		&worldstateQueryProcessorConfig{
-			nodeID:          localConf.Server.Identity.ID,
-			db:              levelDB,
-			blockStore:      blockStore,
-			identityQuerier: querier,
-			logger:          logger,
+			nodeID:             localConf.Server.Identity.ID,
+			db:                 levelDB,
+			blockStore:         blockStore,
+			identityQuerier:    querier,
+			memBudget:          memBudget,
+			snapshotBytes:      memBudgetConf.SnapshotBytes,
+			memBudgetWait:      memBudgetConf.QueueTimeout,
+			slowQueryThreshold: localConf.Server.SlowQueryLog.Threshold,
+			maxSlowQueries:     localConf.Server.SlowQueryLog.MaxQueries,
+			logger:             logger,
 		},
 	)
 
@@ -269,38 +531,104 @@ func NewDB(conf *config.Configurations, logger *logger.SugarLogger) (DB, error)
 
 	provenanceQueryProcessor := newProvenanceQueryProcessor(
 		&provenanceQueryProcessorConfig{
+			db:              levelDB,
 			provenanceStore: provenanceStore,
+			identityQuerier: querier,
 			logger:          logger,
 		},
 	)
 
-	txProcessor, err := newTransactionProcessor(
-		&txProcessorConfig{
-			config:          conf,
+	storageAnalyzer := newStorageAnalyzer(
+		&storageAnalyzerConfig{
 			db:              levelDB,
-			blockStore:      blockStore,
 			provenanceStore: provenanceStore,
-			stateTrieStore:  stateTrieStore,
 			logger:          logger,
 		},
 	)
+
+	quarantine, err := blockprocessor.NewQuarantine(localConf.Server.Identity.ID, constructQuarantineFilePath(ledgerDir), logger)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while loading the quarantine")
+	}
+
+	blobsConf := blobsConfWithDefaults(localConf.Server.Database.Blobs)
+	txProcConf := &txProcessorConfig{
+		config:          conf,
+		db:              levelDB,
+		blobStore:       blobStore,
+		blobsConf:       blobsConf,
+		blockStore:      blockStore,
+		provenanceStore: provenanceStore,
+		stateTrieStore:  stateTrieStore,
+		outbox:          outboxStore,
+		erasure:         erasureStore,
+		identityCache:   identityCache,
+		commitListeners: extensions.CommitListeners,
+		orderingSvcs:    extensions.OrderingServices,
+		memBudget:       memBudget,
+		quarantine:      quarantine,
+		logger:          logger,
+	}
+	if localConf.Witness.Enabled && localConf.Standby.Enabled {
+		return nil, errors.New("a node cannot be both a witness and a standby")
+	}
+	var txProcessor TxProcessor
+	switch {
+	case localConf.Witness.Enabled:
+		txProcessor, err = newWitnessProcessor(txProcConf)
+	case localConf.Standby.Enabled:
+		txProcessor, err = newStandbyProcessor(txProcConf)
+	default:
+		txProcessor, err = newTransactionProcessor(txProcConf)
+	}
 	if err != nil {
 		return nil, errors.WithMessage(err, "can't initiate tx processor")
 	}
 
-	return &db{
+	sessionTokens, err := newSessionTokens(levelDB)
+	if err != nil {
+		return nil, err
+	}
+
+	if outboxStore != nil {
+		outboxStore.Start()
+	}
+
+	d := &db{
 		nodeID:                   localConf.Server.Identity.ID,
 		worldstateQueryProcessor: worldstateQueryProcessor,
 		ledgerQueryProcessor:     ledgerQueryProcessor,
 		provenanceQueryProcessor: provenanceQueryProcessor,
+		storageAnalyzer:          storageAnalyzer,
 		txProcessor:              txProcessor,
 		db:                       levelDB,
+		blobStore:                blobStore,
+		blobsConf:                blobsConf,
 		blockStore:               blockStore,
 		provenanceStore:          provenanceStore,
 		stateTrieStore:           stateTrieStore,
+		outbox:                   outboxStore,
+		erasure:                  erasureStore,
+		quarantine:               quarantine,
+		rejectedTxs:              newRejectedTxs(localConf.Server.Identity.ID, logger),
+		pendingTxPool:            newPendingTxPool(),
+		sessionTokens:            sessionTokens,
+		queryNonces:              newQueryNonces(localConf.Server.QueryReplayProtection),
 		logger:                   logger,
 		signer:                   signer,
-	}, nil
+		nodeCert:                 nodeCert,
+	}
+
+	if localConf.Server.Canary.Enabled && !localConf.Witness.Enabled && !localConf.Standby.Enabled {
+		d.canary = newCanary(localConf.Server.Canary, d)
+		d.canary.start()
+	}
+	d.indexCompactor = newIndexCompactor(d.nodeID, localConf.Server.IndexCompaction, levelDB, logger)
+	d.indexCompactor.start()
+	d.blobCollector = newBlobCollector(blobsConf, levelDB, blobStore, logger)
+	d.blobCollector.start()
+
+	return d, nil
 }
 
 // LedgerHeight returns ledger height
@@ -323,10 +651,21 @@ func (d *db) DoesUserExist(userID string) (bool, error) {
 	return d.worldstateQueryProcessor.identityQuerier.DoesUserExist(userID)
 }
 
+// IsAdmin checks whether userID has the admin privilege
+func (d *db) IsAdmin(userID string) (bool, error) {
+	return d.worldstateQueryProcessor.identityQuerier.HasAdministrationPrivilege(userID)
+}
+
 func (d *db) GetCertificate(userID string) (*x509.Certificate, error) {
 	return d.worldstateQueryProcessor.identityQuerier.GetCertificate(userID)
 }
 
+// GetSigningKeys returns the API signing keys registered to the user. It makes the db a
+// cryptoservice.SigningKeysQuerier, so that requests signed by these keys are authenticated.
+func (d *db) GetSigningKeys(userID string) ([]*types.SigningKey, error) {
+	return d.worldstateQueryProcessor.identityQuerier.GetSigningKeys(userID)
+}
+
 // GetUser returns user's record
 func (d *db) GetUser(querierUserID, targetUserID string) (*types.GetUserResponseEnvelope, error) {
 	userResponse, err := d.worldstateQueryProcessor.getUser(querierUserID, targetUserID)
@@ -346,6 +685,25 @@ func (d *db) GetUser(querierUserID, targetUserID string) (*types.GetUserResponse
 	}, nil
 }
 
+// GetUsers returns the users readable by the querier
+func (d *db) GetUsers(querierUserID, prefix string, offset, limit uint64) (*types.GetUsersResponseEnvelope, error) {
+	usersResponse, err := d.worldstateQueryProcessor.getUsers(querierUserID, prefix, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	usersResponse.Header = d.responseHeader()
+	sign, err := d.signature(usersResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetUsersResponseEnvelope{
+		Response:  usersResponse,
+		Signature: sign,
+	}, nil
+}
+
 // GetNodeConfig returns single node subsection of database configuration
 func (d *db) GetNodeConfig(nodeID string) (*types.GetNodeConfigResponseEnvelope, error) {
 	nodeConfigResponse, err := d.worldstateQueryProcessor.getNodeConfig(nodeID)
@@ -354,6 +712,9 @@ func (d *db) GetNodeConfig(nodeID string) (*types.GetNodeConfigResponseEnvelope,
 	}
 
 	nodeConfigResponse.Header = d.responseHeader()
+	if nodeConfigResponse.Attestation, err = d.attestation(); err != nil {
+		return nil, err
+	}
 	sign, err := d.signature(nodeConfigResponse)
 	if err != nil {
 		return nil, err
@@ -406,140 +767,922 @@ func (d *db) GetConfigBlock(querierUserID string, blockNumber uint64) (*types.Ge
 	}, nil
 }
 
-// GetClusterStatus returns the cluster status
-func (d *db) GetClusterStatus(noCerts bool) (*types.GetClusterStatusResponseEnvelope, error) {
-	nodes, metadata, err := d.worldstateQueryProcessor.getNodeConfigAndMetadata()
+// GetConfigHistory returns the committed config blocks, in ascending order. Limited access to admins by default.
+func (d *db) GetConfigHistory(querierUserID string) (*types.GetConfigHistoryResponseEnvelope, error) {
+	configHistoryResponse, err := d.ledgerQueryProcessor.getConfigHistory(querierUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	configHistoryResponse.Header = d.responseHeader()
+	sign, err := d.signature(configHistoryResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetConfigHistoryResponseEnvelope{
+		Response:  configHistoryResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetConfigDiff returns the changes between the cluster configs committed in two config blocks. Limited access to
+// admins only.
+func (d *db) GetConfigDiff(querierUserID string, fromBlockNumber, toBlockNumber uint64) (*types.GetConfigDiffResponseEnvelope, error) {
+	configDiffResponse, err := d.ledgerQueryProcessor.getConfigDiff(querierUserID, fromBlockNumber, toBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	configDiffResponse.Header = d.responseHeader()
+	sign, err := d.signature(configDiffResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetConfigDiffResponseEnvelope{
+		Response:  configDiffResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetClusterStatus returns the cluster status
+func (d *db) GetClusterStatus(noCerts bool) (*types.GetClusterStatusResponseEnvelope, error) {
+	nodes, metadata, err := d.worldstateQueryProcessor.getNodeConfigAndMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterStatusResponse := &types.GetClusterStatusResponse{
+		Nodes:   nodes,
+		Version: metadata.GetVersion(),
+	}
+
+	leader, active := d.txProcessor.ClusterStatus()
+
+	// The configResponse is from the world-state-db, whereas the (leader, active) pair is from the block-replicator.
+	// Since they may be out of sync (if the request hits right in the middle of a config change), we make sure the
+	// response is self-consistent.
+	for _, activeID := range active {
+		for _, node := range nodes {
+			if activeID == node.Id {
+				clusterStatusResponse.Active = append(clusterStatusResponse.Active, activeID)
+				break
+			}
+		}
+	}
+	for _, node := range nodes {
+		if leader == node.Id {
+			clusterStatusResponse.Leader = leader
+			break
+		}
+	}
+
+	if noCerts {
+		for i := 0; i < len(clusterStatusResponse.Nodes); i++ {
+			clusterStatusResponse.Nodes[i].Certificate = nil
+		}
+	}
+
+	clusterStatusResponse.Header = d.responseHeader()
+	if clusterStatusResponse.Attestation, err = d.attestation(); err != nil {
+		return nil, err
+	}
+	sign, err := d.signature(clusterStatusResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetClusterStatusResponseEnvelope{
+		Response:  clusterStatusResponse,
+		Signature: sign,
+	}, nil
+}
+
+// TriggerSnapshot takes a consensus snapshot on the local node. Limited access to admins by default.
+func (d *db) TriggerSnapshot(querierUserID string) (*types.TriggerSnapshotResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to trigger a snapshot",
+		}
+	}
+
+	snapshotResponse, err := d.txProcessor.TriggerSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotResponse.Header = d.responseHeader()
+	sign, err := d.signature(snapshotResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TriggerSnapshotResponseEnvelope{
+		Response:  snapshotResponse,
+		Signature: sign,
+	}, nil
+}
+
+// TransferLeadership transfers the leadership from the local node to the target node. Limited access to admins by
+// default.
+func (d *db) TransferLeadership(querierUserID, targetNodeID string, timeout time.Duration) (*types.TransferLeadershipResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to transfer the leadership",
+		}
+	}
+
+	transferResponse, err := d.txProcessor.TransferLeadership(targetNodeID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	transferResponse.Header = d.responseHeader()
+	sign, err := d.signature(transferResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TransferLeadershipResponseEnvelope{
+		Response:  transferResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetConsensusDiagnostics returns the state of consensus on the local node. Limited access to admins by default.
+func (d *db) GetConsensusDiagnostics(querierUserID string) (*types.GetConsensusDiagnosticsResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the consensus diagnostics",
+		}
+	}
+
+	diagResponse, err := d.txProcessor.ConsensusDiagnostics()
+	if err != nil {
+		return nil, err
+	}
+
+	diagResponse.Header = d.responseHeader()
+	sign, err := d.signature(diagResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetConsensusDiagnosticsResponseEnvelope{
+		Response:  diagResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetWitnessStatus returns the verification status and alerts of a witness or standby node. Limited access to admins
+// by default.
+func (d *db) GetWitnessStatus(querierUserID string) (*types.GetWitnessStatusResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the witness status",
+		}
+	}
+
+	statusResponse, err := d.txProcessor.WitnessStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	statusResponse.Header = d.responseHeader()
+	sign, err := d.signature(statusResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetWitnessStatusResponseEnvelope{
+		Response:  statusResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetOpenSnapshots returns the snapshots of the state database that are not yet released
+func (d *db) GetOpenSnapshots() *types.OpenSnapshots {
+	now := time.Now()
+	open := &types.OpenSnapshots{Snapshots: []*types.OpenSnapshot{}}
+	for _, s := range d.db.OpenSnapshots() {
+		open.Snapshots = append(open.Snapshots, &types.OpenSnapshot{
+			ID:       s.ID,
+			Owner:    s.Owner,
+			DBs:      s.DBNames,
+			Height:   s.Height,
+			Acquired: s.Acquired.Format(time.RFC3339),
+			Age:      now.Sub(s.Acquired).Truncate(time.Millisecond).String(),
+			Idle:     now.Sub(s.LastRead).Truncate(time.Millisecond).String(),
+		})
+	}
+
+	return open
+}
+
+// GetStorageReport returns a report of the storage used by the data databases. Limited access to admins by default.
+func (d *db) GetStorageReport(querierUserID string, top uint32, prefixDelimiter string) (*types.GetStorageReportResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the storage report",
+		}
+	}
+
+	reportResponse, err := d.storageAnalyzer.report(top, prefixDelimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	reportResponse.Header = d.responseHeader()
+	sign, err := d.signature(reportResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetStorageReportResponseEnvelope{
+		Response:  reportResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetStateHash returns the canonical hash of the full contents of a database. Limited access to admins by default.
+func (d *db) GetStateHash(querierUserID, dbName string) (*types.GetStateHashResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the hash of a database",
+		}
+	}
+
+	hashResponse, err := computeStateHash(d.db, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	hashResponse.Header = d.responseHeader()
+	sign, err := d.signature(hashResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetStateHashResponseEnvelope{
+		Response:  hashResponse,
+		Signature: sign,
+	}, nil
+}
+
+// CompactIndex compacts the storage of the index of a database. Limited access to admins by default.
+func (d *db) CompactIndex(querierUserID, dbName string) (*types.CompactIndexResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to compact the index of a database",
+		}
+	}
+
+	switch {
+	case worldstate.IsSystemDB(dbName) || stateindex.IsIndexDB(dbName):
+		return nil, &ierrors.BadRequestError{ErrMsg: "database [" + dbName + "] is not a data database"}
+	case !d.db.Exist(dbName):
+		return nil, &ierrors.NotFoundErr{Message: "database [" + dbName + "] does not exist"}
+	case !d.db.Exist(stateindex.IndexDB(dbName)):
+		return nil, &ierrors.BadRequestError{ErrMsg: "database [" + dbName + "] has no index"}
+	}
+
+	compactResponse, err := d.indexCompactor.compact(dbName, indexCompactionAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	compactResponse.Header = d.responseHeader()
+	sign, err := d.signature(compactResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CompactIndexResponseEnvelope{
+		Response:  compactResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetQuarantinedBlock returns the quarantined block, if any. Limited access to admins by default.
+func (d *db) GetQuarantinedBlock(querierUserID string) (*types.GetQuarantinedBlockResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the quarantined block",
+		}
+	}
+
+	quarantineResponse := &types.GetQuarantinedBlockResponse{
+		Header:           d.responseHeader(),
+		QuarantinedBlock: d.quarantine.Block(),
+	}
+	sign, err := d.signature(quarantineResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetQuarantinedBlockResponseEnvelope{
+		Response:  quarantineResponse,
+		Signature: sign,
+	}, nil
+}
+
+// RecordRejectedTx records a transaction that is rejected before it is submitted
+func (d *db) RecordRejectedTx(txID, userID string, category types.RejectedTx_Category, reason string) {
+	d.rejectedTxs.add(txID, userID, category, reason)
+}
+
+// GetRejectedTxs returns the transactions rejected before ordering. Limited access to admins by default.
+func (d *db) GetRejectedTxs(querierUserID string) (*types.GetRejectedTxsResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the rejected transactions",
+		}
+	}
+
+	rejectedResponse := &types.GetRejectedTxsResponse{
+		Header:      d.responseHeader(),
+		RejectedTxs: d.rejectedTxs.list(),
+	}
+	sign, err := d.signature(rejectedResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetRejectedTxsResponseEnvelope{
+		Response:  rejectedResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetSlowQueries returns the data queries whose execution was slow. Limited access to admins by default.
+func (d *db) GetSlowQueries(querierUserID string) (*types.GetSlowQueriesResponseEnvelope, error) {
+	hasAccess, err := d.worldstateQueryProcessor.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the slow queries",
+		}
+	}
+
+	slowQueriesResponse := &types.GetSlowQueriesResponse{
+		Header:      d.responseHeader(),
+		SlowQueries: d.worldstateQueryProcessor.slowQueries.list(),
+	}
+	sign, err := d.signature(slowQueriesResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetSlowQueriesResponseEnvelope{
+		Response:  slowQueriesResponse,
+		Signature: sign,
+	}, nil
+}
+
+// DryRunConfigTx validates a config transaction without submitting it. Limited access to admins only.
+func (d *db) DryRunConfigTx(txEnv *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponseEnvelope, error) {
+	userID := txEnv.GetPayload().GetUserId()
+	isAdmin, err := d.worldstateQueryProcessor.identityQuerier.HasAdministrationPrivilege(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + userID + "] has no permission to dry-run a config transaction",
+		}
+	}
+	// the outcome of a dry-run reveals the committed config
+	if err := checkFullConfigAccess(d.worldstateQueryProcessor.db, userID); err != nil {
+		return nil, err
+	}
+
+	dryRunResponse, err := d.txProcessor.DryRunConfigTx(txEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRunResponse.Header = d.responseHeader()
+	sign, err := d.signature(dryRunResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ConfigTxDryRunResponseEnvelope{
+		Response:  dryRunResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetDBStatus returns database status
+func (d *db) GetDBStatus(dbName string) (*types.GetDBStatusResponseEnvelope, error) {
+	dbStatusResponse, err := d.worldstateQueryProcessor.getDBStatus(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	dbStatusResponse.Header = d.responseHeader()
+	sign, err := d.signature(dbStatusResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDBStatusResponseEnvelope{
+		Response:  dbStatusResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetIndexUsage returns the usage of the indexes by the queries. Limited access to admins by default.
+func (d *db) GetIndexUsage(querierUserID string, unusedOnly bool) (*types.GetIndexUsageResponseEnvelope, error) {
+	usageResponse, err := d.worldstateQueryProcessor.getIndexUsage(querierUserID, unusedOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	usageResponse.Header = d.responseHeader()
+	sign, err := d.signature(usageResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetIndexUsageResponseEnvelope{
+		Response:  usageResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetMigrations returns the states of the migrations
+func (d *db) GetMigrations(querierUserID, name string) (*types.GetMigrationsResponseEnvelope, error) {
+	migrationsResponse, err := d.worldstateQueryProcessor.getMigrations(querierUserID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsResponse.Header = d.responseHeader()
+	sign, err := d.signature(migrationsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetMigrationsResponseEnvelope{
+		Response:  migrationsResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetDBs returns the databases accessible by the querier
+func (d *db) GetDBs(querierUserID, prefix string, offset, limit uint64) (*types.GetDBsResponseEnvelope, error) {
+	dbsResponse, err := d.worldstateQueryProcessor.getDBs(querierUserID, prefix, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dbsResponse.Header = d.responseHeader()
+	sign, err := d.signature(dbsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDBsResponseEnvelope{
+		Response:  dbsResponse,
+		Signature: sign,
+	}, nil
+}
+
+// Ready returns an UnavailableError if the node halted the commit of blocks, e.g., on a quarantined block or on a
+// failure of the state trie, and nil otherwise
+func (d *db) Ready() error {
+	if record := d.quarantine.Block(); record != nil {
+		return &ierrors.UnavailableError{
+			ErrMsg: fmt.Sprintf("the node halted the commit of blocks, as block [%d] is quarantined",
+				record.GetBlock().GetHeader().GetBaseHeader().GetNumber()),
+		}
+	}
+	if err := d.txProcessor.CommitHalted(); err != nil {
+		return &ierrors.UnavailableError{
+			ErrMsg: "the node halted the commit of blocks until it restarts: " + err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// SubmitTransaction submits transaction to the database with a timeout. If the timeout is
+// set to 0, the submission would be treated as async while a non-zero timeout would be
+// treated as a sync submission. When a timeout occurs with the sync submission, a
+// timeout error will be returned
+func (d *db) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
+	var receipt *types.TxReceiptResponse
+	err := d.Ready()
+	if err == nil {
+		receipt, err = d.txProcessor.SubmitTransaction(tx, timeout)
+	}
+	if err != nil {
+		if category, rejected := rejectionCategory(err); rejected {
+			txID, userID := txSubmitter(tx)
+			d.rejectedTxs.add(txID, userID, category, err.Error())
+		}
+		return nil, err
+	}
+
+	receipt.Header = d.responseHeader()
+	sign, err := d.signature(receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TxReceiptResponseEnvelope{
+		Response:  receipt,
+		Signature: sign,
+	}, nil
+}
+
+// AddPendingDataTx adds a partially signed data transaction to the pending transactions held by the leader
+func (d *db) AddPendingDataTx(txEnv *types.DataTxEnvelope, expiry time.Duration) (*types.PendingDataTxResponseEnvelope, error) {
+	if err := d.txProcessor.IsLeader(); err != nil {
+		return nil, err
+	}
+
+	pendingTx, err := d.pendingTxPool.add(txEnv, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.pendingDataTxResponse(pendingTx)
+}
+
+// GetPendingDataTx returns a pending data transaction
+func (d *db) GetPendingDataTx(querierUserID, txID string) (*types.PendingDataTxResponseEnvelope, error) {
+	if err := d.txProcessor.IsLeader(); err != nil {
+		return nil, err
+	}
+
+	pendingTx, err := d.pendingTxPool.get(querierUserID, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.pendingDataTxResponse(pendingTx)
+}
+
+// GetPendingDataTxs returns the pending data transactions that await the signature of the querier
+func (d *db) GetPendingDataTxs(querierUserID string) (*types.GetPendingDataTxsResponseEnvelope, error) {
+	if err := d.txProcessor.IsLeader(); err != nil {
+		return nil, err
+	}
+
+	pendingTxsResponse := &types.GetPendingDataTxsResponse{
+		Header:     d.responseHeader(),
+		PendingTxs: d.pendingTxPool.getAwaitingSignature(querierUserID),
+	}
+	sign, err := d.signature(pendingTxsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetPendingDataTxsResponseEnvelope{
+		Response:  pendingTxsResponse,
+		Signature: sign,
+	}, nil
+}
+
+// AddPendingDataTxSignature adds the signature of a must sign user to a pending data transaction
+func (d *db) AddPendingDataTxSignature(txID, userID string, signature []byte) (*types.PendingDataTxResponseEnvelope, error) {
+	if err := d.txProcessor.IsLeader(); err != nil {
+		return nil, err
+	}
+
+	pendingTx, err := d.pendingTxPool.addSignature(txID, userID, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.pendingDataTxResponse(pendingTx)
+}
+
+func (d *db) pendingDataTxResponse(pendingTx *types.PendingDataTx) (*types.PendingDataTxResponseEnvelope, error) {
+	pendingTxResponse := &types.PendingDataTxResponse{
+		Header:    d.responseHeader(),
+		PendingTx: pendingTx,
+	}
+	sign, err := d.signature(pendingTxResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PendingDataTxResponseEnvelope{
+		Response:  pendingTxResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetData returns value for provided key
+func (d *db) GetData(dbName, querierUserID, key string) (*types.GetDataResponseEnvelope, error) {
+	dataResponse, err := d.worldstateQueryProcessor.getData(dbName, querierUserID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	dataResponse.Header = d.responseHeader()
+	sign, err := d.signature(dataResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDataResponseEnvelope{
+		Response:  dataResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetDataVersion returns the version of the value of a given key, or nil if the key does not exist
+func (d *db) GetDataVersion(dbName, querierUserID, key string) (*types.Version, error) {
+	dataResponse, err := d.worldstateQueryProcessor.getData(dbName, querierUserID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataResponse.GetMetadata().GetVersion(), nil
+}
+
+// GetDataKeys returns the keys of a database that start with the prefix, or only their count
+func (d *db) GetDataKeys(dbName, querierUserID, prefix, startKey string, limit uint64, countOnly bool) (*types.GetDataKeysResponseEnvelope, error) {
+	keysResponse, err := d.worldstateQueryProcessor.getDataKeys(dbName, querierUserID, prefix, startKey, limit, countOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	keysResponse.Header = d.responseHeader()
+	sign, err := d.signature(keysResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDataKeysResponseEnvelope{
+		Response:  keysResponse,
+		Signature: sign,
+	}, nil
+}
+
+// DataQuery executes a given JSON query and return key-value pairs which are matching
+// the criteria provided in the query
+func (d *db) DataQuery(ctx context.Context, dbName, querierUserID string, query []byte) (*types.DataQueryResponseEnvelope, error) {
+	queryResponse, err := d.worldstateQueryProcessor.executeJSONQuery(ctx, dbName, querierUserID, query)
+
+	select {
+	case <-ctx.Done():
+		return nil, nil
+	default:
+		if err != nil {
+			return nil, err
+		}
+		queryResponse.Header = d.responseHeader()
+		sign, err := d.signature(queryResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.DataQueryResponseEnvelope{
+			Response:  queryResponse,
+			Signature: sign,
+		}, nil
+	}
+
+}
+
+// StreamDataQuery executes a JSON query and writes the key-value pairs which are matching the criteria provided in the
+// query as they are read. The key-value pairs are read ahead of the writes through a bounded channel, so that the
+// memory held by the result does not grow with its size.
+func (d *db) StreamDataQuery(ctx context.Context, dbName, querierUserID string, query []byte, write func(line []byte) error) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	kvs := make(chan *types.KVWithMetadata, queryStreamBufferSize)
+	queryErr := make(chan error, 1)
+	go func() {
+		defer close(kvs)
+		queryErr <- d.worldstateQueryProcessor.streamJSONQuery(streamCtx, dbName, querierUserID, query, kvs)
+	}()
+
+	hash := sha256.New()
+	var count uint64
+	var writeErr error
+	for kv := range kvs {
+		if writeErr != nil {
+			// the query is cancelled, and the key-value pairs already read are dropped
+			continue
+		}
+
+		writeErr = utils.EncodeJSON(&types.DataQueryStreamItem{Kv: kv}, func(line []byte) error {
+			line = append(line, '\n')
+			hash.Write(line)
+			return write(line)
+		})
+		if writeErr != nil {
+			cancel()
+			continue
+		}
+		count++
+	}
+	err := <-queryErr
+
+	if writeErr != nil {
+		return writeErr
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil && count == 0 {
+		return err
+	}
+
+	trailer := &types.DataQueryStreamTrailer{
+		Header:  d.responseHeader(),
+		Count:   count,
+		KvsHash: hash.Sum(nil),
+	}
+	if err != nil {
+		trailer.ErrMsg = err.Error()
+	}
+	sign, err := d.signature(trailer)
+	if err != nil {
+		return err
+	}
+
+	end := &types.DataQueryStreamItem{
+		End: &types.DataQueryStreamTrailerEnvelope{
+			Trailer:   trailer,
+			Signature: sign,
+		},
+	}
+	return utils.EncodeJSON(end, func(line []byte) error {
+		return write(append(line, '\n'))
+	})
+}
+
+// DataSQLQuery executes a compiled query of the SQL dialect and returns the key-value pairs which are matching the
+// predicates of the query, ordered and bounded as given in the query
+func (d *db) DataSQLQuery(ctx context.Context, querierUserID string, query *queryexecutor.SQLQuery) (*types.DataQueryResponseEnvelope, error) {
+	queryResponse, err := d.worldstateQueryProcessor.executeSQLQuery(ctx, querierUserID, query)
+
+	select {
+	case <-ctx.Done():
+		return nil, nil
+	default:
+		if err != nil {
+			return nil, err
+		}
+		queryResponse.Header = d.responseHeader()
+		sign, err := d.signature(queryResponse)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.DataQueryResponseEnvelope{
+			Response:  queryResponse,
+			Signature: sign,
+		}, nil
+	}
+}
+
+// ExplainDataQuery returns the plan of a JSON query without executing it
+func (d *db) ExplainDataQuery(ctx context.Context, dbName, querierUserID string, query []byte) (*types.ExplainJSONQueryResponseEnvelope, error) {
+	explainResponse, err := d.worldstateQueryProcessor.explainJSONQuery(ctx, dbName, querierUserID, query)
 	if err != nil {
 		return nil, err
 	}
 
-	clusterStatusResponse := &types.GetClusterStatusResponse{
-		Nodes:   nodes,
-		Version: metadata.GetVersion(),
-	}
-
-	leader, active := d.txProcessor.ClusterStatus()
-
-	// The configResponse is from the world-state-db, whereas the (leader, active) pair is from the block-replicator.
-	// Since they may be out of sync (if the request hits right in the middle of a config change), we make sure the
-	// response is self-consistent.
-	for _, activeID := range active {
-		for _, node := range nodes {
-			if activeID == node.Id {
-				clusterStatusResponse.Active = append(clusterStatusResponse.Active, activeID)
-				break
-			}
-		}
-	}
-	for _, node := range nodes {
-		if leader == node.Id {
-			clusterStatusResponse.Leader = leader
-			break
-		}
+	explainResponse.Header = d.responseHeader()
+	sign, err := d.signature(explainResponse)
+	if err != nil {
+		return nil, err
 	}
 
-	if noCerts {
-		for i := 0; i < len(clusterStatusResponse.Nodes); i++ {
-			clusterStatusResponse.Nodes[i].Certificate = nil
-		}
-	}
+	return &types.ExplainJSONQueryResponseEnvelope{
+		Response:  explainResponse,
+		Signature: sign,
+	}, nil
+}
 
-	clusterStatusResponse.Header = d.responseHeader()
-	sign, err := d.signature(clusterStatusResponse)
+// OpenDataCursor opens a cursor over the keys of a database that start with the prefix, which reads a snapshot of the
+// database until it is closed or is not read for the TTL
+func (d *db) OpenDataCursor(querierUserID, dbName, prefix string, ttl time.Duration) (*types.DataCursorResponseEnvelope, error) {
+	cursorResponse, err := d.worldstateQueryProcessor.openDataCursor(querierUserID, dbName, prefix, ttl)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.GetClusterStatusResponseEnvelope{
-		Response:  clusterStatusResponse,
-		Signature: sign,
-	}, nil
+	return d.dataCursorEnvelope(cursorResponse)
 }
 
-// GetDBStatus returns database status
-func (d *db) GetDBStatus(dbName string) (*types.GetDBStatusResponseEnvelope, error) {
-	dbStatusResponse, err := d.worldstateQueryProcessor.getDBStatus(dbName)
+// GetDataCursorPage returns the next page of a cursor, which starts after the given key
+func (d *db) GetDataCursorPage(querierUserID, cursorID, after string, limit uint64) (*types.GetDataCursorPageResponseEnvelope, error) {
+	pageResponse, err := d.worldstateQueryProcessor.getDataCursorPage(querierUserID, cursorID, after, limit)
 	if err != nil {
 		return nil, err
 	}
 
-	dbStatusResponse.Header = d.responseHeader()
-	sign, err := d.signature(dbStatusResponse)
+	pageResponse.Header = d.responseHeader()
+	sign, err := d.signature(pageResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.GetDBStatusResponseEnvelope{
-		Response:  dbStatusResponse,
+	return &types.GetDataCursorPageResponseEnvelope{
+		Response:  pageResponse,
 		Signature: sign,
 	}, nil
 }
 
-// SubmitTransaction submits transaction to the database with a timeout. If the timeout is
-// set to 0, the submission would be treated as async while a non-zero timeout would be
-// treated as a sync submission. When a timeout occurs with the sync submission, a
-// timeout error will be returned
-func (d *db) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
-	receipt, err := d.txProcessor.SubmitTransaction(tx, timeout)
+// CloseDataCursor closes a cursor and releases its snapshot
+func (d *db) CloseDataCursor(querierUserID, cursorID string) (*types.DataCursorResponseEnvelope, error) {
+	cursorResponse, err := d.worldstateQueryProcessor.closeDataCursor(querierUserID, cursorID)
 	if err != nil {
 		return nil, err
 	}
 
-	receipt.Header = d.responseHeader()
-	sign, err := d.signature(receipt)
+	return d.dataCursorEnvelope(cursorResponse)
+}
+
+func (d *db) dataCursorEnvelope(cursorResponse *types.DataCursorResponse) (*types.DataCursorResponseEnvelope, error) {
+	cursorResponse.Header = d.responseHeader()
+	sign, err := d.signature(cursorResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.TxReceiptResponseEnvelope{
-		Response:  receipt,
+	return &types.DataCursorResponseEnvelope{
+		Response:  cursorResponse,
 		Signature: sign,
 	}, nil
 }
 
-// GetData returns value for provided key
-func (d *db) GetData(dbName, querierUserID, key string) (*types.GetDataResponseEnvelope, error) {
-	dataResponse, err := d.worldstateQueryProcessor.getData(dbName, querierUserID, key)
+// CreateSessionToken issues a session token to a user
+func (d *db) CreateSessionToken(userID string, timestamp int64, ttl time.Duration) (*types.SessionTokenResponseEnvelope, error) {
+	tokenResponse, err := d.sessionTokens.issue(userID, timestamp, ttl)
 	if err != nil {
 		return nil, err
 	}
 
-	dataResponse.Header = d.responseHeader()
-	sign, err := d.signature(dataResponse)
+	tokenResponse.Header = d.responseHeader()
+	sign, err := d.signature(tokenResponse)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.GetDataResponseEnvelope{
-		Response:  dataResponse,
+	return &types.SessionTokenResponseEnvelope{
+		Response:  tokenResponse,
 		Signature: sign,
 	}, nil
 }
 
-// DataQuery executes a given JSON query and return key-value pairs which are matching
-// the criteria provided in the query
-func (d *db) DataQuery(ctx context.Context, dbName, querierUserID string, query []byte) (*types.DataQueryResponseEnvelope, error) {
-	queryResponse, err := d.worldstateQueryProcessor.executeJSONQuery(ctx, dbName, querierUserID, query)
-
-	select {
-	case <-ctx.Done():
-		return nil, nil
-	default:
-		if err != nil {
-			return nil, err
-		}
-		queryResponse.Header = d.responseHeader()
-		sign, err := d.signature(queryResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		return &types.DataQueryResponseEnvelope{
-			Response:  queryResponse,
-			Signature: sign,
-		}, nil
-	}
+// VerifySessionToken returns the user of a session token
+func (d *db) VerifySessionToken(token string) (string, error) {
+	return d.sessionTokens.verify(token)
+}
 
+// CheckQueryNonce records the nonce of a signed query of a user
+func (d *db) CheckQueryNonce(userID, nonce string, timestamp int64) error {
+	return d.queryNonces.check(userID, nonce, timestamp)
 }
 
 func (d *db) IsDBExists(name string) bool {
@@ -618,6 +1761,24 @@ func (d *db) GetDataProof(userID string, blockNum uint64, dbname string, key str
 	}, nil
 }
 
+func (d *db) GetDBStateRoot(userID string, blockNum uint64, dbname string) (*types.GetDBStateRootResponseEnvelope, error) {
+	rootResponse, err := d.ledgerQueryProcessor.getDBStateRoot(userID, blockNum, dbname)
+	if err != nil {
+		return nil, err
+	}
+
+	rootResponse.Header = d.responseHeader()
+	sign, err := d.signature(rootResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDBStateRootResponseEnvelope{
+		Response:  rootResponse,
+		Signature: sign,
+	}, nil
+}
+
 func (d *db) GetLedgerPath(userID string, start, end uint64) (*types.GetLedgerPathResponseEnvelope, error) {
 	pathResponse, err := d.ledgerQueryProcessor.getPath(userID, start, end)
 	if err != nil {
@@ -654,9 +1815,46 @@ func (d *db) GetTxReceipt(userId string, txID string) (*types.TxReceiptResponseE
 	}, nil
 }
 
-// GetValues returns all values associated with a given key
-func (d *db) GetValues(dbName, key string) (*types.GetHistoricalDataResponseEnvelope, error) {
-	values, err := d.provenanceQueryProcessor.GetValues(dbName, key)
+func (d *db) GetTxReceipts(userId string, txIDs []string) (*types.GetTxReceiptsResponseEnvelope, error) {
+	receiptsResponse, err := d.ledgerQueryProcessor.getTxReceipts(userId, txIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptsResponse.Header = d.responseHeader()
+	sign, err := d.signature(receiptsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetTxReceiptsResponseEnvelope{
+		Response:  receiptsResponse,
+		Signature: sign,
+	}, nil
+}
+
+func (d *db) GetTxResourceUsage(userId string, txID string) (*types.GetTxResourceUsageResponseEnvelope, error) {
+	usageResponse, err := d.ledgerQueryProcessor.getTxResourceUsage(userId, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	usageResponse.Header = d.responseHeader()
+	sign, err := d.signature(usageResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetTxResourceUsageResponseEnvelope{
+		Response:  usageResponse,
+		Signature: sign,
+	}, nil
+}
+
+// GetValues returns the values associated with a given key, skipping the first offset values. The number of records
+// returned would be limited by the limit parameter, where zero means no limit.
+func (d *db) GetValues(querierUserID, dbName, key string, offset, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error) {
+	values, err := d.provenanceQueryProcessor.GetValues(querierUserID, dbName, key, offset, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -674,8 +1872,8 @@ func (d *db) GetValues(dbName, key string) (*types.GetHistoricalDataResponseEnve
 }
 
 // GetDeletedValues returns all deleted values associated with a given key
-func (d *db) GetDeletedValues(dbName, key string) (*types.GetHistoricalDataResponseEnvelope, error) {
-	deletedValues, err := d.provenanceQueryProcessor.GetDeletedValues(dbName, key)
+func (d *db) GetDeletedValues(querierUserID, dbName, key string) (*types.GetHistoricalDataResponseEnvelope, error) {
+	deletedValues, err := d.provenanceQueryProcessor.GetDeletedValues(querierUserID, dbName, key)
 	if err != nil {
 		return nil, err
 	}
@@ -693,8 +1891,8 @@ func (d *db) GetDeletedValues(dbName, key string) (*types.GetHistoricalDataRespo
 }
 
 // GetValueAt returns the value of a given key at a particular version
-func (d *db) GetValueAt(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	valueAt, err := d.provenanceQueryProcessor.GetValueAt(dbName, key, version)
+func (d *db) GetValueAt(querierUserID, dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
+	valueAt, err := d.provenanceQueryProcessor.GetValueAt(querierUserID, dbName, key, version)
 	if err != nil {
 		return nil, err
 	}
@@ -712,8 +1910,8 @@ func (d *db) GetValueAt(dbName, key string, version *types.Version) (*types.GetH
 }
 
 // GetMostRecentValueAtOrBelow returns the most recent value of a given key at or below the given version
-func (d *db) GetMostRecentValueAtOrBelow(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	valueAt, err := d.provenanceQueryProcessor.GetMostRecentValueAtOrBelow(dbName, key, version)
+func (d *db) GetMostRecentValueAtOrBelow(querierUserID, dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
+	valueAt, err := d.provenanceQueryProcessor.GetMostRecentValueAtOrBelow(querierUserID, dbName, key, version)
 	if err != nil {
 		return nil, err
 	}
@@ -731,9 +1929,9 @@ func (d *db) GetMostRecentValueAtOrBelow(dbName, key string, version *types.Vers
 }
 
 // GetPreviousValues returns previous values of a given key and a version. The number of records returned would be limited
-// by the limit parameters.
-func (d *db) GetPreviousValues(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	previousValues, err := d.provenanceQueryProcessor.GetPreviousValues(dbName, key, version)
+// by the limit parameter, where zero means no limit.
+func (d *db) GetPreviousValues(querierUserID, dbName, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error) {
+	previousValues, err := d.provenanceQueryProcessor.GetPreviousValues(querierUserID, dbName, key, version, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -751,9 +1949,9 @@ func (d *db) GetPreviousValues(dbName, key string, version *types.Version) (*typ
 }
 
 // GetNextValues returns next values of a given key and a version. The number of records returned would be limited
-// by the limit parameters.
-func (d *db) GetNextValues(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	nextValues, err := d.provenanceQueryProcessor.GetNextValues(dbName, key, version)
+// by the limit parameter, where zero means no limit.
+func (d *db) GetNextValues(querierUserID, dbName, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error) {
+	nextValues, err := d.provenanceQueryProcessor.GetNextValues(querierUserID, dbName, key, version, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -771,8 +1969,8 @@ func (d *db) GetNextValues(dbName, key string, version *types.Version) (*types.G
 }
 
 // GetValuesReadByUser returns all values read by a given user
-func (d *db) GetValuesReadByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
-	readByUser, err := d.provenanceQueryProcessor.GetValuesReadByUser(userID)
+func (d *db) GetValuesReadByUser(querierUserID, userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
+	readByUser, err := d.provenanceQueryProcessor.GetValuesReadByUser(querierUserID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -790,8 +1988,8 @@ func (d *db) GetValuesReadByUser(userID string) (*types.GetDataProvenanceRespons
 }
 
 // GetValuesWrittenByUser returns all values written by a given user
-func (d *db) GetValuesWrittenByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
-	writtenByUser, err := d.provenanceQueryProcessor.GetValuesWrittenByUser(userID)
+func (d *db) GetValuesWrittenByUser(querierUserID, userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
+	writtenByUser, err := d.provenanceQueryProcessor.GetValuesWrittenByUser(querierUserID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -809,8 +2007,8 @@ func (d *db) GetValuesWrittenByUser(userID string) (*types.GetDataProvenanceResp
 }
 
 // GetValuesDeletedByUser returns all values deleted by a given user
-func (d *db) GetValuesDeletedByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
-	deletedByUser, err := d.provenanceQueryProcessor.GetValuesDeletedByUser(userID)
+func (d *db) GetValuesDeletedByUser(querierUserID, userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
+	deletedByUser, err := d.provenanceQueryProcessor.GetValuesDeletedByUser(querierUserID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -828,8 +2026,8 @@ func (d *db) GetValuesDeletedByUser(userID string) (*types.GetDataProvenanceResp
 }
 
 // GetReaders returns all userIDs who have accessed a given key as well as the access frequency
-func (d *db) GetReaders(dbName, key string) (*types.GetDataReadersResponseEnvelope, error) {
-	readers, err := d.provenanceQueryProcessor.GetReaders(dbName, key)
+func (d *db) GetReaders(querierUserID, dbName, key string) (*types.GetDataReadersResponseEnvelope, error) {
+	readers, err := d.provenanceQueryProcessor.GetReaders(querierUserID, dbName, key)
 	if err != nil {
 		return nil, err
 	}
@@ -847,8 +2045,8 @@ func (d *db) GetReaders(dbName, key string) (*types.GetDataReadersResponseEnvelo
 }
 
 // GetReaders returns all userIDs who have accessed a given key as well as the access frequency
-func (d *db) GetWriters(dbName, key string) (*types.GetDataWritersResponseEnvelope, error) {
-	writers, err := d.provenanceQueryProcessor.GetWriters(dbName, key)
+func (d *db) GetWriters(querierUserID, dbName, key string) (*types.GetDataWritersResponseEnvelope, error) {
+	writers, err := d.provenanceQueryProcessor.GetWriters(querierUserID, dbName, key)
 	if err != nil {
 		return nil, err
 	}
@@ -866,8 +2064,8 @@ func (d *db) GetWriters(dbName, key string) (*types.GetDataWritersResponseEnvelo
 }
 
 // GetTxIDsSubmittedByUser returns all ids of all transactions submitted by a given user
-func (d *db) GetTxIDsSubmittedByUser(userID string) (*types.GetTxIDsSubmittedByResponseEnvelope, error) {
-	submittedByUser, err := d.provenanceQueryProcessor.GetTxIDsSubmittedByUser(userID)
+func (d *db) GetTxIDsSubmittedByUser(querierUserID, userID string) (*types.GetTxIDsSubmittedByResponseEnvelope, error) {
+	submittedByUser, err := d.provenanceQueryProcessor.GetTxIDsSubmittedByUser(querierUserID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -884,8 +2082,35 @@ func (d *db) GetTxIDsSubmittedByUser(userID string) (*types.GetTxIDsSubmittedByR
 	}, nil
 }
 
+// GetTxsByAnnotation returns the transactions that carry a given annotation, e.g., a correlation ID, skipping the
+// first offset of them and up to the limit, where zero means no limit
+func (d *db) GetTxsByAnnotation(querierUserID, key, value string, offset, limit uint64) (*types.GetTxsByAnnotationResponseEnvelope, error) {
+	annotatedTxs, err := d.provenanceQueryProcessor.GetTxsByAnnotation(querierUserID, key, value, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	annotatedTxs.Header = d.responseHeader()
+	sign, err := d.signature(annotatedTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetTxsByAnnotationResponseEnvelope{
+		Response:  annotatedTxs,
+		Signature: sign,
+	}, nil
+}
+
 // Close closes and release resources used by db
 func (d *db) Close() error {
+	if d.canary != nil {
+		d.canary.stop()
+	}
+	d.indexCompactor.stop()
+	d.blobCollector.stop()
+	d.worldstateQueryProcessor.closeDataCursors()
+
 	if err := d.txProcessor.Close(); err != nil {
 		return errors.WithMessage(err, "error while closing the transaction processor")
 	}
@@ -894,6 +2119,10 @@ func (d *db) Close() error {
 		return errors.WithMessage(err, "error while closing the worldstate database")
 	}
 
+	if err := d.blobStore.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the blob store")
+	}
+
 	if err := d.provenanceStore.Close(); err != nil {
 		return errors.WithMessage(err, "error while closing the provenance store")
 	}
@@ -906,6 +2135,16 @@ func (d *db) Close() error {
 		return errors.WithMessage(err, "error while closing the block store")
 	}
 
+	if d.outbox != nil {
+		if err := d.outbox.Close(); err != nil {
+			return errors.WithMessage(err, "error while closing the outbox")
+		}
+	}
+
+	if err := d.erasure.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the erasure key store")
+	}
+
 	d.logger.Info("Closed internal DB")
 	return nil
 }
@@ -916,13 +2155,53 @@ func (d *db) responseHeader() *types.ResponseHeader {
 	}
 }
 
+// signature signs the JSON encoding of a response, which is hashed as it is encoded rather than held in memory
 func (d *db) signature(response interface{}) ([]byte, error) {
-	responseBytes, err := json.Marshal(response)
+	hash, err := utils.HashJSON(response)
+	if err != nil {
+		return nil, err
+	}
+	return d.signer.SignHash(hash)
+}
+
+// attestation returns the certificate of the local node along with the chain of the CAs that issued it, according to
+// the current CA config, so that the clients can authenticate the responses of the node before they trust them. If
+// the current CA config does not trust the certificate of the node, e.g., because the CAs were rotated while the node
+// kept its old certificate, the chain is left out, and the clients will not trust the node.
+func (d *db) attestation() (*types.NodeAttestation, error) {
+	config, _, err := d.db.GetConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	return d.signer.Sign(responseBytes)
+	attestation := &types.NodeAttestation{
+		Certificate: d.nodeCert,
+	}
+
+	// the certificate of the node may be issued by retiring CAs
+	caCertCollection, err := certificateauthority.NewCACertCollection(certificateauthority.AllCAs(config.GetCertAuthConfig()))
+	if err != nil {
+		d.logger.Warnf("the CA chain of the node certificate is not attested, as the CA certificate collection cannot be created: %s", err)
+		return attestation, nil
+	}
+	if attestation.CaChain, err = caCertCollection.GetLeafCertChainCAs(d.nodeCert); err != nil {
+		d.logger.Warnf("the CA chain of the node certificate is not attested: %s", err)
+	}
+
+	return attestation, nil
+}
+
+// readNodeCert reads the certificate of the local node, which is the certificate of the key that signs the responses
+func readNodeCert(certPath string) ([]byte, error) {
+	certBytes, err := ioutil.ReadFile(certPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the node certificate: %s", certPath)
+	}
+	certPem, _ := pem.Decode(certBytes)
+	if certPem == nil {
+		return nil, errors.Errorf("the node certificate is not in PEM format: %s", certPath)
+	}
+	return certPem.Bytes, nil
 }
 
 type certsInGenesisConfig struct {
@@ -960,6 +2239,158 @@ func readCerts(conf *config.Configurations) (*certsInGenesisConfig, error) {
 	return certsInGen, nil
 }
 
+// loadEncryptionKeys loads the keys of the encrypted databases, or returns nil if no keys directory is configured
+func loadEncryptionKeys(conf *config.EncryptionConf) (*encryption.Keys, error) {
+	if conf.KeysDirectory == "" {
+		if len(conf.Databases) > 0 {
+			return nil, errors.New("the encrypted databases are configured without a keys directory")
+		}
+		return nil, nil
+	}
+
+	dbKeys := make(map[string]string, len(conf.Databases))
+	for _, db := range conf.Databases {
+		if _, ok := dbKeys[db.Name]; ok {
+			return nil, errors.Errorf("the key of database [%s] is configured twice", db.Name)
+		}
+		dbKeys[db.Name] = db.KeyID
+	}
+
+	keys, err := encryption.Load(&encryption.Config{
+		KeysDir: conf.KeysDirectory,
+		DBKeys:  dbKeys,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while loading the encryption keys")
+	}
+
+	return keys, nil
+}
+
+// ledgerStores holds the stores in the ledger directory, which hold the ledger and the state derived from it.
+type ledgerStores struct {
+	blobStore       *blobstore.Store
+	db              *leveldb.LevelDB
+	erasure         *erasure.Store
+	blockStore      *blockstore.Store
+	provenanceStore provenance.Store
+	stateTrieStore  *mptrieStore.Store
+}
+
+// openLedgerStores opens the stores in the ledger directory, and creates the directory if it does not exist.
+func openLedgerStores(localConf *config.LocalConfiguration, logger *logger.SugarLogger) (*ledgerStores, error) {
+	ledgerDir := localConf.Server.Database.LedgerDirectory
+	if err := createLedgerDir(ledgerDir); err != nil {
+		return nil, err
+	}
+
+	blobStore, err := blobstore.Open(
+		&blobstore.Config{
+			StoreDir: constructBlobStorePath(ledgerDir),
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the blob store")
+	}
+
+	encryptionKeys, err := loadEncryptionKeys(&localConf.Server.Database.Encryption)
+	if err != nil {
+		return nil, err
+	}
+
+	levelDB, err := leveldb.Open(
+		&leveldb.Config{
+			DBRootDir:           constructWorldStatePath(ledgerDir),
+			BlobStore:           blobStore,
+			Encryption:          encryptionKeys,
+			SnapshotLeakTimeout: localConf.Server.Database.SnapshotLeakTimeout,
+			Logger:              logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the world state database")
+	}
+
+	erasureStore, err := erasure.Open(
+		&erasure.Config{
+			StoreDir: constructErasureStorePath(ledgerDir),
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the erasure key store")
+	}
+
+	blockStore, err := blockstore.Open(
+		&blockstore.Config{
+			StoreDir:       constructBlockStorePath(ledgerDir),
+			Erasure:        erasureStore,
+			BlockCacheSize: localConf.Server.Database.BlockCacheSize,
+			Logger:         logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the block store")
+	}
+
+	provenanceConf := localConf.Server.Provenance
+	provenanceStore, err := provenance.Open(
+		&provenance.Config{
+			StoreDir: constructProvenanceStorePath(ledgerDir),
+			Backend:  provenanceConf.Backend,
+			Erasure:  erasureStore,
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the provenance store")
+	}
+
+	stateTrieStore, err := mptrieStore.Open(
+		&mptrieStore.Config{
+			StoreDir: constructStateTrieStorePath(ledgerDir),
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the state trie store")
+	}
+
+	return &ledgerStores{
+		blobStore:       blobStore,
+		db:              levelDB,
+		erasure:         erasureStore,
+		blockStore:      blockStore,
+		provenanceStore: provenanceStore,
+		stateTrieStore:  stateTrieStore,
+	}, nil
+}
+
+// close closes the stores, in the order db.Close does.
+func (s *ledgerStores) close() error {
+	if err := s.db.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the worldstate database")
+	}
+	if err := s.blobStore.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the blob store")
+	}
+	if err := s.provenanceStore.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the provenance store")
+	}
+	if err := s.blockStore.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the block store")
+	}
+	if err := s.stateTrieStore.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the state trie store")
+	}
+	if err := s.erasure.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the erasure key store")
+	}
+
+	return nil
+}
+
 func createLedgerDir(dir string) error {
 	exist, err := fileops.Exists(dir)
 	if err != nil {
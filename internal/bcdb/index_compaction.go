@@ -0,0 +1,210 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultIndexCompactionMinDeletes is the number of entries deleted from an index database above which it is
+	// compacted when none is configured
+	DefaultIndexCompactionMinDeletes = 100000
+
+	indexCompactionScheduled = "scheduled"
+	indexCompactionAdmin     = "admin"
+)
+
+var (
+	indexDBSizeGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "stateindex",
+		Name:      "db_size_bytes",
+		Help:      "The approximate size on disk of the index database of a data database, as of the last check or compaction.",
+	}, []string{"node", "db"})
+
+	indexDBDeletesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "stateindex",
+		Name:      "deleted_entries",
+		Help:      "The number of entries deleted from the index database of a data database since its last compaction, as of the last check or compaction.",
+	}, []string{"node", "db"})
+
+	indexCompactionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "stateindex",
+		Name:      "compactions_total",
+		Help:      "The number of compactions of the index database of a data database, by trigger: scheduled or admin.",
+	}, []string{"node", "db", "trigger"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		indexDBSizeGauge,
+		indexDBDeletesGauge,
+		indexCompactionsCounter,
+	)
+}
+
+// indexCompactor compacts the index databases of the data databases. When scheduled, it periodically measures the
+// index databases, and compacts those from which enough entries were deleted since their last compaction. The metrics
+// of the index databases are labeled by the name of their data database.
+type indexCompactor struct {
+	nodeID     string
+	interval   time.Duration
+	minDeletes uint64
+	db         worldstate.DB
+	logger     *logger.SugarLogger
+
+	// lock serializes the compactions, and guards measured
+	lock sync.Mutex
+	// measured holds the data databases whose index databases have metrics, so that the metrics of a deleted
+	// database are removed
+	measured map[string]struct{}
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+}
+
+func newIndexCompactor(nodeID string, conf config.IndexCompactionConf, db worldstate.DB, logger *logger.SugarLogger) *indexCompactor {
+	minDeletes := conf.MinDeletes
+	if minDeletes == 0 {
+		minDeletes = DefaultIndexCompactionMinDeletes
+	}
+
+	return &indexCompactor{
+		nodeID:     nodeID,
+		interval:   conf.Interval,
+		minDeletes: minDeletes,
+		db:         db,
+		logger:     logger,
+		measured:   make(map[string]struct{}),
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// start runs the scheduled compaction, unless it is disabled
+func (c *indexCompactor) start() {
+	if c.interval == 0 {
+		close(c.doneCh)
+		return
+	}
+
+	go c.run()
+}
+
+func (c *indexCompactor) run() {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.check()
+		}
+	}
+}
+
+func (c *indexCompactor) stop() {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	<-c.doneCh
+}
+
+// check measures the index databases, and compacts those from which at least minDeletes entries were deleted
+func (c *indexCompactor) check() {
+	present := make(map[string]struct{})
+	for _, indexDB := range c.db.ListDBs() {
+		if !stateindex.IsIndexDB(indexDB) {
+			continue
+		}
+		dbName := strings.TrimPrefix(indexDB, stateindex.IndexDB(""))
+		present[dbName] = struct{}{}
+
+		// a database deleted meanwhile is no longer measured
+		stats, err := c.db.DBStats(indexDB)
+		if err != nil {
+			c.logger.Debugf("failed to measure the index of database [%s]: %s", dbName, err)
+			continue
+		}
+		c.record(dbName, stats)
+
+		if stats.Deletes < c.minDeletes {
+			continue
+		}
+		if _, err := c.compact(dbName, indexCompactionScheduled); err != nil {
+			c.logger.Warnf("failed to compact the index of database [%s]: %s", dbName, err)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for dbName := range c.measured {
+		if _, ok := present[dbName]; !ok {
+			indexDBSizeGauge.DeleteLabelValues(c.nodeID, dbName)
+			indexDBDeletesGauge.DeleteLabelValues(c.nodeID, dbName)
+			delete(c.measured, dbName)
+		}
+	}
+}
+
+// compact compacts the index database of a data database, and returns its sizes before and after the compaction
+func (c *indexCompactor) compact(dbName, trigger string) (*types.CompactIndexResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	indexDB := stateindex.IndexDB(dbName)
+	before, err := c.db.DBStats(indexDB)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if err := c.db.CompactDB(indexDB); err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start)
+
+	after, err := c.db.DBStats(indexDB)
+	if err != nil {
+		return nil, err
+	}
+	c.recordLocked(dbName, after)
+	indexCompactionsCounter.WithLabelValues(c.nodeID, dbName, trigger).Inc()
+	c.logger.Infof("compacted the index of database [%s] (%s) in %s, after %d entries were deleted, from %d to %d bytes",
+		dbName, trigger, elapsed, before.Deletes, before.SizeBytes, after.SizeBytes)
+
+	return &types.CompactIndexResponse{
+		DbName:          dbName,
+		SizeBeforeBytes: before.SizeBytes,
+		SizeAfterBytes:  after.SizeBytes,
+	}, nil
+}
+
+func (c *indexCompactor) record(dbName string, stats *worldstate.DBStats) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.recordLocked(dbName, stats)
+}
+
+func (c *indexCompactor) recordLocked(dbName string, stats *worldstate.DBStats) {
+	indexDBSizeGauge.WithLabelValues(c.nodeID, dbName).Set(float64(stats.SizeBytes))
+	indexDBDeletesGauge.WithLabelValues(c.nodeID, dbName).Set(float64(stats.Deletes))
+	c.measured[dbName] = struct{}{}
+}
@@ -0,0 +1,131 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexCompactor(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	// setup opens a world state with the databases db1 and db2, where the index of db1 holds 1000 entries of which
+	// the given number are deleted
+	setup := func(t *testing.T, deletes int) *leveldb.LevelDB {
+		dir, err := ioutil.TempDir("/tmp", "indexCompaction")
+		require.NoError(t, err)
+		db, err := leveldb.Open(&leveldb.Config{DBRootDir: filepath.Join(dir, "worldstate"), Logger: lg})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+			require.NoError(t, os.RemoveAll(dir))
+		})
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "db1"}, {Key: stateindex.IndexDB("db1")}, {Key: "db2"}},
+			},
+		}, 1))
+
+		var writes []*worldstate.KVWithMetadata
+		var keys []string
+		for i := 0; i < 1000; i++ {
+			value := make([]byte, 256)
+			_, err := rand.Read(value)
+			require.NoError(t, err)
+			key := fmt.Sprintf("entry%04d", i)
+			writes = append(writes, &worldstate.KVWithMetadata{Key: key, Value: value})
+			keys = append(keys, key)
+		}
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{stateindex.IndexDB("db1"): {Writes: writes}}, 2))
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{stateindex.IndexDB("db1"): {Deletes: keys[:deletes]}}, 3))
+		return db
+	}
+
+	compactions := func(nodeID, trigger string) float64 {
+		return testutil.ToFloat64(indexCompactionsCounter.WithLabelValues(nodeID, "db1", trigger))
+	}
+
+	t.Run("an index with enough deletes is compacted", func(t *testing.T) {
+		db := setup(t, 800)
+		c := newIndexCompactor("compaction-node1", config.IndexCompactionConf{MinDeletes: 500}, db, lg)
+
+		c.check()
+		require.Equal(t, float64(1), compactions("compaction-node1", indexCompactionScheduled))
+		require.Equal(t, float64(0), testutil.ToFloat64(indexDBDeletesGauge.WithLabelValues("compaction-node1", "db1")))
+		stats, err := db.DBStats(stateindex.IndexDB("db1"))
+		require.NoError(t, err)
+		require.Equal(t, float64(stats.SizeBytes), testutil.ToFloat64(indexDBSizeGauge.WithLabelValues("compaction-node1", "db1")))
+		require.Equal(t, map[string]struct{}{"db1": {}}, c.measured)
+
+		// the index is not compacted again until entries are deleted
+		c.check()
+		require.Equal(t, float64(1), compactions("compaction-node1", indexCompactionScheduled))
+
+		// the metrics of a deleted index are removed
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {Deletes: []string{stateindex.IndexDB("db1")}},
+		}, 4))
+		c.check()
+		require.Empty(t, c.measured)
+	})
+
+	t.Run("an index with few deletes is only measured", func(t *testing.T) {
+		db := setup(t, 100)
+		c := newIndexCompactor("compaction-node2", config.IndexCompactionConf{MinDeletes: 500}, db, lg)
+
+		c.check()
+		require.Equal(t, float64(0), compactions("compaction-node2", indexCompactionScheduled))
+		require.Equal(t, float64(100), testutil.ToFloat64(indexDBDeletesGauge.WithLabelValues("compaction-node2", "db1")))
+	})
+
+	t.Run("an index is compacted on demand", func(t *testing.T) {
+		db := setup(t, 1000)
+		c := newIndexCompactor("compaction-node3", config.IndexCompactionConf{}, db, lg)
+		require.Equal(t, uint64(DefaultIndexCompactionMinDeletes), c.minDeletes)
+
+		resp, err := c.compact("db1", indexCompactionAdmin)
+		require.NoError(t, err)
+		require.Equal(t, "db1", resp.DbName)
+		require.Less(t, resp.SizeAfterBytes, resp.SizeBeforeBytes)
+		require.Equal(t, float64(1), compactions("compaction-node3", indexCompactionAdmin))
+
+		_, err = c.compact("db2", indexCompactionAdmin)
+		require.EqualError(t, err, "database "+stateindex.IndexDB("db2")+" does not exist")
+	})
+
+	t.Run("the scheduled compaction is stopped", func(t *testing.T) {
+		db := setup(t, 1000)
+		c := newIndexCompactor("compaction-node4", config.IndexCompactionConf{Interval: 10 * time.Millisecond, MinDeletes: 500}, db, lg)
+		c.start()
+		require.Eventually(t, func() bool {
+			return compactions("compaction-node4", indexCompactionScheduled) == 1
+		}, 10*time.Second, 10*time.Millisecond)
+		c.stop()
+
+		disabled := newIndexCompactor("compaction-node5", config.IndexCompactionConf{}, db, lg)
+		disabled.start()
+		disabled.stop()
+	})
+}
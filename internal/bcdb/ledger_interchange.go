@@ -0,0 +1,211 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/interchange"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// ExportLedger writes the ledger of a stopped node to w, in the ledger interchange format. The values of the keys
+// that were erased remain sealed in the archive, as their erasure keys were destroyed.
+func ExportLedger(conf *config.Configurations, w io.Writer, logger *logger.SugarLogger) (*interchange.Header, error) {
+	ledgerDir := conf.LocalConfig.Server.Database.LedgerDirectory
+	exist, err := fileops.Exists(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.Errorf("the ledger directory [%s] does not exist", ledgerDir)
+	}
+
+	stores, err := openLedgerStores(conf.LocalConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := stores.close(); err != nil {
+			logger.Warnf("error while closing the ledger stores: %s", err)
+		}
+	}()
+
+	height, err := stores.blockStore.Height()
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return nil, errors.Errorf("the ledger in [%s] is empty", ledgerDir)
+	}
+
+	clusterConfig, _, err := stores.db.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	genesisHash, err := stores.blockStore.GetHash(1)
+	if err != nil {
+		return nil, err
+	}
+	lastBlockHash, err := stores.blockStore.GetHash(height)
+	if err != nil {
+		return nil, err
+	}
+
+	header := &interchange.Header{
+		Height:        height,
+		GenesisHash:   genesisHash,
+		LastBlockHash: lastBlockHash,
+		CAConfig:      clusterConfig.GetCertAuthConfig(),
+		ExportedBy:    conf.LocalConfig.Server.Identity.ID,
+		ExportedAt:    time.Now().UnixNano(),
+	}
+	writer, err := interchange.NewWriter(w, header)
+	if err != nil {
+		return nil, err
+	}
+	for number := uint64(1); number <= height; number++ {
+		block, err := stores.blockStore.Get(number)
+		if err != nil {
+			return nil, err
+		}
+		if err = writer.WriteBlock(block); err != nil {
+			return nil, err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Exported [%d] blocks from the ledger in [%s]", height, ledgerDir)
+	return header, nil
+}
+
+// ImportLedger reads an archive in the ledger interchange format from r, into the empty ledger of a stopped node.
+// Every block is committed through the block processor, which validates it again and rebuilds the state database,
+// the provenance store, and the state trie; the block must then hash to the block in the archive. The outbox is not
+// notified of the imported blocks, as their side effects took place when they were first committed. The consensus
+// state, e.g., the Raft WAL and snapshots, is not part of the archive. An archive that holds erased values cannot be
+// imported, as the transactions that wrote them cannot be validated again. The extensions may be nil; only their
+// ordering services are used, to validate the consensus config. On error, the ledger directory holds a partial
+// ledger, and must be cleared before the import is retried.
+func ImportLedger(conf *config.Configurations, r io.Reader, logger *logger.SugarLogger, extensions *Extensions) (uint64, error) {
+	if extensions == nil {
+		extensions = &Extensions{}
+	}
+
+	reader, err := interchange.NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+	header := reader.Header()
+
+	ledgerDir := conf.LocalConfig.Server.Database.LedgerDirectory
+	stores, err := openLedgerStores(conf.LocalConfig, logger)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := stores.close(); err != nil {
+			logger.Warnf("error while closing the ledger stores: %s", err)
+		}
+	}()
+
+	height, err := stores.blockStore.Height()
+	if err != nil {
+		return 0, err
+	}
+	if height != 0 {
+		return 0, errors.Errorf("the ledger in [%s] is not empty, its height is [%d]", ledgerDir, height)
+	}
+
+	barrier := queue.NewOneQueueBarrier(logger)
+	processor := blockprocessor.New(
+		&blockprocessor.Config{
+			BlockOneQueueBarrier: barrier,
+			BlockStore:           stores.blockStore,
+			ProvenanceStore:      stores.provenanceStore,
+			StateTrieStore:       stores.stateTrieStore,
+			Erasure:              stores.erasure,
+			BlobStore:            stores.blobStore,
+			DB:                   stores.db,
+			TxValidator: txvalidation.NewValidator(
+				&txvalidation.Config{
+					DB:                  stores.db,
+					DBUsage:             stores.blockStore,
+					ConsensusAlgorithms: orderingAlgorithms(extensions.OrderingServices),
+					Logger:              logger,
+				},
+			),
+			Logger: logger,
+		},
+	)
+	go processor.Start()
+	processor.WaitTillStart()
+	defer processor.Stop()
+
+	for {
+		block, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return height, err
+		}
+		if err = importBlock(barrier, block); err != nil {
+			return height, err
+		}
+		height = block.GetHeader().GetBaseHeader().GetNumber()
+	}
+
+	clusterConfig, _, err := stores.db.GetConfig()
+	if err != nil {
+		return height, err
+	}
+	if !proto.Equal(clusterConfig.GetCertAuthConfig(), header.CAConfig) {
+		return height, errors.New("the CA material in the header of the archive does not match the CA config committed to the ledger")
+	}
+
+	logger.Infof("Imported [%d] blocks into the ledger in [%s]", height, ledgerDir)
+	return height, nil
+}
+
+// importBlock commits a block from an archive, and verifies that the block committed hashes to the archived block.
+func importBlock(barrier *queue.OneQueueBarrier, block *types.Block) error {
+	number := block.GetHeader().GetBaseHeader().GetNumber()
+	if len(block.GetSealedTxHashes()) > 0 {
+		return errors.Errorf("block [%d] holds values that were erased, and cannot be imported", number)
+	}
+
+	archivedHash, err := blockstore.ComputeBlockHash(block)
+	if err != nil {
+		return err
+	}
+
+	// The block processor overwrites the validation info and the roots with the ones it computes
+	if _, err = barrier.EnqueueWait(block); err != nil {
+		return errors.WithMessagef(err, "failed to commit block [%d]", number)
+	}
+
+	committedHash, err := blockstore.ComputeBlockHash(block)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(archivedHash, committedHash) {
+		return errors.Errorf("block [%d] was validated differently than in the archive: the hash of the committed block [%x] differs from the archived one [%x]",
+			number, committedHash, archivedHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockcreator"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newInterchangeTestLedger creates a ledger with a genesis block and a block that creates a database, and returns
+// the configuration of its node.
+func newInterchangeTestLedger(t *testing.T, dir string, lg *logger.SugarLogger) *config.Configurations {
+	conf, err := config.DevConfig(dir)
+	require.NoError(t, err)
+
+	stores, err := openLedgerStores(conf.LocalConfig, lg)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stores.close())
+	}()
+
+	barrier := queue.NewOneQueueBarrier(lg)
+	processor := blockprocessor.New(&blockprocessor.Config{
+		BlockOneQueueBarrier: barrier,
+		BlockStore:           stores.blockStore,
+		DB:                   stores.db,
+		ProvenanceStore:      stores.provenanceStore,
+		StateTrieStore:       stores.stateTrieStore,
+		Erasure:              stores.erasure,
+		TxValidator: txvalidation.NewValidator(&txvalidation.Config{
+			DB:      stores.db,
+			DBUsage: stores.blockStore,
+			Logger:  lg,
+		}),
+		Logger: lg,
+	})
+	go processor.Start()
+	processor.WaitTillStart()
+	defer processor.Stop()
+
+	configTx, err := PrepareBootstrapConfigTx(conf)
+	require.NoError(t, err)
+	genesisBlock, err := blockcreator.BootstrapBlock(configTx)
+	require.NoError(t, err)
+	_, err = barrier.EnqueueWait(genesisBlock)
+	require.NoError(t, err)
+
+	adminSigner, err := crypto.NewSigner(&crypto.SignerOptions{
+		Identity:    config.DevAdminID,
+		KeyFilePath: path.Join(dir, "crypto", config.DevAdminID+".key"),
+	})
+	require.NoError(t, err)
+	dbTx := &types.DBAdministrationTx{
+		UserId:    config.DevAdminID,
+		TxId:      "create-db",
+		CreateDbs: []string{"db1"},
+	}
+	sig, err := cryptoservice.SignTx(adminSigner, dbTx)
+	require.NoError(t, err)
+
+	genesisHash, err := stores.blockStore.GetHash(1)
+	require.NoError(t, err)
+	genesisBaseHash, err := stores.blockStore.GetBaseHeaderHash(1)
+	require.NoError(t, err)
+	dbBlock := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:                 2,
+				PreviousBaseHeaderHash: genesisBaseHash,
+				LastCommittedBlockHash: genesisHash,
+				LastCommittedBlockNum:  1,
+			},
+		},
+		Payload: &types.Block_DbAdministrationTxEnvelope{
+			DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+				Payload:   dbTx,
+				Signature: sig,
+			},
+		},
+	}
+	_, err = barrier.EnqueueWait(dbBlock)
+	require.NoError(t, err)
+	require.Equal(t, types.Flag_VALID, dbBlock.GetHeader().GetValidationInfo()[0].GetFlag())
+
+	return conf
+}
+
+func TestExportImportLedger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledger-interchange")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	conf := newInterchangeTestLedger(t, path.Join(dir, "source"), lg)
+
+	var archive bytes.Buffer
+	header, err := ExportLedger(conf, &archive, lg)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), header.Height)
+	require.Equal(t, config.DevNodeID, header.ExportedBy)
+	require.NotNil(t, header.CAConfig)
+
+	importConf := *conf
+	importLocalConf := *conf.LocalConfig
+	importLocalConf.Server.Database.LedgerDirectory = path.Join(dir, "imported")
+	importConf.LocalConfig = &importLocalConf
+
+	t.Run("import into an empty ledger", func(t *testing.T) {
+		height, err := ImportLedger(&importConf, bytes.NewReader(archive.Bytes()), lg, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), height)
+
+		stores, err := openLedgerStores(importConf.LocalConfig, lg)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, stores.close())
+		}()
+
+		importedHash, err := stores.blockStore.GetHash(2)
+		require.NoError(t, err)
+		require.Equal(t, header.LastBlockHash, importedHash)
+		require.True(t, stores.db.Exist("db1"))
+	})
+
+	t.Run("import into a ledger that is not empty", func(t *testing.T) {
+		_, err := ImportLedger(&importConf, bytes.NewReader(archive.Bytes()), lg, nil)
+		require.EqualError(t, err, "the ledger in ["+importLocalConf.Server.Database.LedgerDirectory+"] is not empty, its height is [2]")
+	})
+
+	t.Run("export a ledger that does not exist", func(t *testing.T) {
+		missingConf := importConf
+		missingLocalConf := importLocalConf
+		missingLocalConf.Server.Database.LedgerDirectory = path.Join(dir, "missing")
+		missingConf.LocalConfig = &missingLocalConf
+
+		_, err := ExportLedger(&missingConf, &bytes.Buffer{}, lg)
+		require.EqualError(t, err, "the ledger directory ["+missingLocalConf.Server.Database.LedgerDirectory+"] does not exist")
+	})
+
+	t.Run("import a modified archive", func(t *testing.T) {
+		tamperedConf := importConf
+		tamperedLocalConf := importLocalConf
+		tamperedLocalConf.Server.Database.LedgerDirectory = path.Join(dir, "tampered")
+		tamperedConf.LocalConfig = &tamperedLocalConf
+
+		tampered := strings.Replace(archive.String(), `"db1"`, `"db2"`, 1)
+		_, err := ImportLedger(&tamperedConf, strings.NewReader(tampered), lg, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "block [2] was validated differently than in the archive")
+	})
+}
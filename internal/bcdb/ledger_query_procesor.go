@@ -4,8 +4,9 @@ package bcdb
 
 import (
 	"fmt"
+	"sort"
 
-	"github.com/hyperledger-labs/orion-server/pkg/state"
+	"github.com/golang/protobuf/proto"
 
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
@@ -19,19 +20,23 @@ import (
 	"github.com/pkg/errors"
 )
 
+// MaxTxReceiptsPerQuery is the maximal number of transactions whose receipts are queried at once
+const MaxTxReceiptsPerQuery = 1000
+
 type ledgerQueryProcessor struct {
 	db              worldstate.DB
 	blockStore      *blockstore.Store
-	provenanceStore *provenance.Store
+	provenanceStore provenance.Store
 	trieStore       mptrie.Store
 	identityQuerier *identity.Querier
+	receipts        *receiptCache
 	logger          *logger.SugarLogger
 }
 
 type ledgerQueryProcessorConfig struct {
 	db              worldstate.DB
 	blockStore      *blockstore.Store
-	provenanceStore *provenance.Store
+	provenanceStore provenance.Store
 	trieStore       mptrie.Store
 	identityQuerier *identity.Querier
 	logger          *logger.SugarLogger
@@ -44,12 +49,13 @@ func newLedgerQueryProcessor(conf *ledgerQueryProcessorConfig) *ledgerQueryProce
 		provenanceStore: conf.provenanceStore,
 		trieStore:       conf.trieStore,
 		identityQuerier: conf.identityQuerier,
+		receipts:        newReceiptCache(receiptCacheSize),
 		logger:          conf.logger,
 	}
 }
 
 func (p *ledgerQueryProcessor) getBlockHeader(userId string, blockNum uint64) (*types.GetBlockResponse, error) {
-	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +74,7 @@ func (p *ledgerQueryProcessor) getBlockHeader(userId string, blockNum uint64) (*
 }
 
 func (p *ledgerQueryProcessor) getAugmentedBlockHeader(userId string, blockNum uint64) (*types.GetAugmentedBlockHeaderResponse, error) {
-	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +97,7 @@ func (p *ledgerQueryProcessor) getPath(userId string, startBlockIdx, endBlockIdx
 		return nil, errors.Errorf("can't find path from smaller block %d to bigger %d", endBlockIdx, startBlockIdx)
 	}
 
-	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +127,7 @@ func (p *ledgerQueryProcessor) getPath(userId string, startBlockIdx, endBlockIdx
 }
 
 func (p *ledgerQueryProcessor) getTxProof(userId string, blockNum uint64, txIdx uint64) (*types.GetTxProofResponse, error) {
-	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +150,7 @@ func (p *ledgerQueryProcessor) getTxProof(userId string, blockNum uint64, txIdx
 }
 
 func (p *ledgerQueryProcessor) getDataProof(userId string, blockNum uint64, dbname string, key string, isDeleted bool) (*types.GetDataProofResponse, error) {
-	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
 	if err != nil {
 		return nil, err
 	}
@@ -157,16 +163,12 @@ func (p *ledgerQueryProcessor) getDataProof(userId string, blockNum uint64, dbna
 		return nil, err
 	}
 
-	trie, err := mptrie.NewTrie(blockHeader.StateMerkelTreeRootHash, p.trieStore)
-	if err != nil {
-		return nil, err
-	}
-	trieKey, err := state.ConstructCompositeKey(dbname, key)
+	trie, err := mptrie.NewStateTrie(blockHeader.StateMerkelTreeRootHash, p.trieStore)
 	if err != nil {
 		return nil, err
 	}
 
-	proof, err := trie.GetProof(trieKey, isDeleted)
+	proof, err := trie.GetProof(dbname, key, isDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -182,8 +184,49 @@ func (p *ledgerQueryProcessor) getDataProof(userId string, blockNum uint64, dbna
 	return resp, nil
 }
 
+func (p *ledgerQueryProcessor) getDBStateRoot(userId string, blockNum uint64, dbname string) (*types.GetDBStateRootResponse, error) {
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasAccess {
+		return nil, &interrors.PermissionErr{ErrMsg: fmt.Sprintf("user %s has no permission to access the ledger", userId)}
+	}
+	blockHeader, err := p.blockStore.GetHeader(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	trie, err := mptrie.NewStateTrie(blockHeader.StateMerkelTreeRootHash, p.trieStore)
+	if err != nil {
+		return nil, err
+	}
+
+	rootHash, err := trie.GetDBRootHash(dbname)
+	if err == mptrie.ErrFlatLayout {
+		return nil, &interrors.BadRequestError{ErrMsg: err.Error()}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rootHash == nil {
+		return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("no state of db %s found in block %d", dbname, blockNum)}
+	}
+
+	proof, err := trie.GetDBRootProof(dbname)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetDBStateRootResponse{
+		RootHash: rootHash,
+		Path:     proof.GetPath(),
+	}, nil
+}
+
 func (p *ledgerQueryProcessor) getTxReceipt(userId string, txId string) (*types.TxReceiptResponse, error) {
-	hasAccess, err := p.identityQuerier.HasLedgerAccess(userId)
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
 	if err != nil {
 		return nil, err
 	}
@@ -191,24 +234,284 @@ func (p *ledgerQueryProcessor) getTxReceipt(userId string, txId string) (*types.
 	if !hasAccess {
 		return nil, &interrors.PermissionErr{ErrMsg: fmt.Sprintf("user %s has no permission to access the ledger", userId)}
 	}
-	txLoc, err := p.provenanceStore.GetTxIDLocation(txId)
+
+	if receipt := p.receipts.get(txId); receipt != nil {
+		return &types.TxReceiptResponse{
+			Receipt: receipt.Receipt,
+			TxProof: receipt.TxProof,
+		}, nil
+	}
+
+	// the receipt is constructed from the block store alone, so that it does not depend on the provenance store
+	txLoc, err := p.blockStore.GetTxLocation(txId)
 	if err != nil {
 		return nil, err
 	}
 
-	blockHeader, err := p.blockStore.GetHeader(txLoc.BlockNum)
+	receipts, err := p.constructReceipts(txLoc.BlockNum, []string{txId}, []uint64{txLoc.TxIndex})
 	if err != nil {
 		return nil, err
 	}
 
 	return &types.TxReceiptResponse{
-		Receipt: &types.TxReceipt{
-			Header:  blockHeader,
-			TxIndex: uint64(txLoc.TxIndex),
-		},
+		Receipt: receipts[0].Receipt,
+		TxProof: receipts[0].TxProof,
+	}, nil
+}
+
+// getTxReceipts returns the receipts of many transactions at once. The receipts of the transactions committed in the
+// same block share the read of the block and the Merkle tree of its transactions, which is built once.
+func (p *ledgerQueryProcessor) getTxReceipts(userId string, txIds []string) (*types.GetTxReceiptsResponse, error) {
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasAccess {
+		return nil, &interrors.PermissionErr{ErrMsg: fmt.Sprintf("user %s has no permission to access the ledger", userId)}
+	}
+
+	if len(txIds) > MaxTxReceiptsPerQuery {
+		return nil, &interrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the number of transactions [%d] exceeds the limit of %d receipts per query", len(txIds), MaxTxReceiptsPerQuery),
+		}
+	}
+
+	receipts := make(map[string]*types.TxReceiptWithProof)
+	type blockTxs struct {
+		txIds   []string
+		indexes []uint64
+	}
+	uncached := make(map[uint64]*blockTxs)
+	var blockNums []uint64
+	res := &types.GetTxReceiptsResponse{}
+
+	for _, txId := range txIds {
+		if _, ok := receipts[txId]; ok {
+			continue
+		}
+		if receipt := p.receipts.get(txId); receipt != nil {
+			receipts[txId] = receipt
+			continue
+		}
+
+		txLoc, err := p.blockStore.GetTxLocation(txId)
+		if err != nil {
+			if _, ok := err.(*interrors.NotFoundErr); ok {
+				res.NotFoundTxIds = append(res.NotFoundTxIds, txId)
+				continue
+			}
+			return nil, err
+		}
+
+		txs, ok := uncached[txLoc.BlockNum]
+		if !ok {
+			txs = &blockTxs{}
+			uncached[txLoc.BlockNum] = txs
+			blockNums = append(blockNums, txLoc.BlockNum)
+		}
+		txs.txIds = append(txs.txIds, txId)
+		txs.indexes = append(txs.indexes, txLoc.TxIndex)
+		// marks the transaction as located, so that a repeated ID is not located again
+		receipts[txId] = nil
+	}
+
+	for _, blockNum := range blockNums {
+		txs := uncached[blockNum]
+		blockReceipts, err := p.constructReceipts(blockNum, txs.txIds, txs.indexes)
+		if err != nil {
+			return nil, err
+		}
+		for _, receipt := range blockReceipts {
+			receipts[receipt.TxId] = receipt
+		}
+	}
+
+	for _, txId := range txIds {
+		if receipt := receipts[txId]; receipt != nil {
+			res.Receipts = append(res.Receipts, receipt)
+			// a repeated ID is returned once
+			delete(receipts, txId)
+		}
+	}
+
+	return res, nil
+}
+
+// constructReceipts constructs the receipts of transactions committed in a block, with their proofs of inclusion, and
+// adds them to the receipt cache
+func (p *ledgerQueryProcessor) constructReceipts(blockNum uint64, txIds []string, txIndexes []uint64) ([]*types.TxReceiptWithProof, error) {
+	block, err := p.blockStore.Get(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := mtree.BuildTreeForBlockTx(block)
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []*types.TxReceiptWithProof
+	for i, txId := range txIds {
+		txIndex := txIndexes[i]
+
+		var annotations map[string]string
+		if envs := block.GetDataTxEnvelopes().GetEnvelopes(); txIndex < uint64(len(envs)) {
+			annotations = envs[txIndex].GetPayload().GetAnnotations()
+		}
+
+		path, err := root.Proof(int(txIndex))
+		if err != nil {
+			return nil, err
+		}
+
+		receipt := &types.TxReceiptWithProof{
+			TxId: txId,
+			Receipt: &types.TxReceipt{
+				Header:      block.GetHeader(),
+				TxIndex:     txIndex,
+				Annotations: annotations,
+			},
+			TxProof: path,
+		}
+		p.receipts.put(receipt)
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+func (p *ledgerQueryProcessor) getTxResourceUsage(userId string, txId string) (*types.GetTxResourceUsageResponse, error) {
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_LEDGER)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasAccess {
+		return nil, &interrors.PermissionErr{ErrMsg: fmt.Sprintf("user %s has no permission to access the ledger", userId)}
+	}
+
+	usage, err := p.blockStore.GetTxResourceUsage(txId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetTxResourceUsageResponse{
+		Usage: usage,
+	}, nil
+}
+
+func (p *ledgerQueryProcessor) getConfigHistory(userId string) (*types.GetConfigHistoryResponse, error) {
+	if err := p.checkConfigHistoryAccess(userId); err != nil {
+		return nil, err
+	}
+
+	versions, err := p.configVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*types.ConfigHistoryEntry
+	var prevConfig *types.ClusterConfig
+	for _, v := range versions {
+		config := &types.ClusterConfig{}
+		if err := proto.Unmarshal(v.GetValue(), config); err != nil {
+			return nil, errors.Wrap(err, "error while unmarshaling committed cluster configuration")
+		}
+
+		blockNum := v.GetMetadata().GetVersion().GetBlockNum()
+		block, err := p.blockStore.Get(blockNum)
+		if err != nil {
+			return nil, err
+		}
+		configTx := block.GetConfigTxEnvelope().GetPayload()
+
+		diff := diffClusterConfigs(prevConfig, config)
+		entries = append(entries, &types.ConfigHistoryEntry{
+			BlockNumber:      blockNum,
+			TxId:             configTx.GetTxId(),
+			UserId:           configTx.GetUserId(),
+			Timestamp:        block.GetHeader().GetBaseHeader().GetTimestamp(),
+			NodesChanged:     nodesChanged(diff),
+			AdminsChanged:    adminsChanged(diff),
+			CaChanged:        caChanged(diff),
+			ConsensusChanged: consensusChanged(diff),
+			ProtocolVersion:  config.GetProtocolVersion(),
+		})
+		prevConfig = config
+	}
+
+	return &types.GetConfigHistoryResponse{
+		Entries: entries,
+	}, nil
+}
+
+func (p *ledgerQueryProcessor) getConfigDiff(userId string, fromBlockNum, toBlockNum uint64) (*types.GetConfigDiffResponse, error) {
+	if err := p.checkConfigHistoryAccess(userId); err != nil {
+		return nil, err
+	}
+
+	fromConfig, err := p.configAt(fromBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	toConfig, err := p.configAt(toBlockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GetConfigDiffResponse{
+		FromBlockNumber: fromBlockNum,
+		ToBlockNumber:   toBlockNum,
+		Diff:            diffClusterConfigs(fromConfig, toConfig),
 	}, nil
 }
 
+func (p *ledgerQueryProcessor) checkConfigHistoryAccess(userId string) error {
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(userId, types.EndpointPolicy_CONFIG_HISTORY)
+	if err != nil {
+		return err
+	}
+	if !hasAccess {
+		return &interrors.PermissionErr{
+			ErrMsg: "the user [" + userId + "] has no permission to read the config history",
+		}
+	}
+	return checkFullConfigAccess(p.db, userId)
+}
+
+// configVersions returns all the committed versions of the cluster configuration, ordered by the block number in
+// which they were committed. The provenance store does not return the values in a particular order.
+func (p *ledgerQueryProcessor) configVersions() ([]*types.ValueWithMetadata, error) {
+	versions, err := p.provenanceStore.GetValues(worldstate.ConfigDBName, worldstate.ConfigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].GetMetadata().GetVersion().GetBlockNum() < versions[j].GetMetadata().GetVersion().GetBlockNum()
+	})
+	return versions, nil
+}
+
+// configAt returns the cluster configuration committed in the given block. A config transaction is always the only
+// transaction in its block.
+func (p *ledgerQueryProcessor) configAt(blockNum uint64) (*types.ClusterConfig, error) {
+	value, err := p.provenanceStore.GetValueAt(worldstate.ConfigDBName, worldstate.ConfigKey, &types.Version{BlockNum: blockNum})
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("block [%d] is not a committed config block", blockNum)}
+	}
+
+	config := &types.ClusterConfig{}
+	if err := proto.Unmarshal(value.GetValue(), config); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshaling committed cluster configuration")
+	}
+	return config, nil
+}
+
 func (p *ledgerQueryProcessor) calculateProof(block *types.Block, txIdx uint64) ([][]byte, error) {
 	root, err := mtree.BuildTreeForBlockTx(block)
 	if err != nil {
@@ -224,6 +527,10 @@ func (p *ledgerQueryProcessor) calculateProof(block *types.Block, txIdx uint64)
 func (p *ledgerQueryProcessor) findPath(endBlock *types.BlockHeader, startIndex uint64) ([]*types.BlockHeader, error) {
 	headers := make([]*types.BlockHeader, 0)
 	headers = append(headers, endBlock)
+	// a block is its own ancestor, the path holds the block alone
+	if endBlock.GetBaseHeader().GetNumber() == startIndex {
+		return headers, nil
+	}
 	for currentBlock := endBlock; currentBlock.GetBaseHeader().GetNumber() > startIndex; {
 		blockSkipIndexes := blockstore.CalculateSkipListLinks(currentBlock.GetBaseHeader().GetNumber())
 		for i := len(blockSkipIndexes) - 1; i >= 0; i-- {
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/ledger"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -215,7 +216,7 @@ func setup(t *testing.T, env *ledgerProcessorTestEnv, blocksNum int) {
 
 	require.NoError(t, env.db.Commit(createUser, 1))
 
-	trie, err := mptrie.NewTrie(nil, env.p.trieStore)
+	trie, err := mptrie.NewStateTrie(nil, env.p.trieStore)
 	require.NoError(t, err)
 
 	for i := uint64(2); i < uint64(blocksNum); i++ {
@@ -234,10 +235,12 @@ func setup(t *testing.T, env *ledgerProcessorTestEnv, blocksNum int) {
 		blockprocessor.ApplyBlockOnStateTrie(trie, dataUpdates)
 		block.Header.StateMerkelTreeRootHash, err = trie.Hash()
 		require.NoError(t, err)
+		block.Header.DbStateRootHashes, err = trie.UpdatedDBRootHashes()
+		require.NoError(t, err)
 		require.NoError(t, env.p.blockStore.Commit(block))
 
 		pData := createProvenanceDataFromBlock(block)
-		err = env.p.provenanceStore.Commit(block.GetHeader().GetBaseHeader().GetNumber(), pData)
+		err = env.p.provenanceStore.Commit(block.GetHeader().GetBaseHeader().GetNumber(), block.GetHeader().GetBaseHeader().GetTimestamp(), pData)
 		require.NoError(t, err)
 
 		err = trie.Commit(block.GetHeader().GetBaseHeader().GetNumber())
@@ -514,6 +517,13 @@ func TestGetPath(t *testing.T) {
 			expectedBlocks: []*types.BlockHeader{env.blocks[16], env.blocks[8], env.blocks[4], env.blocks[2], env.blocks[1]},
 			user:           "testUser",
 		},
+		{
+			name:           "path 5 5",
+			startNumber:    5,
+			endNumber:      5,
+			expectedBlocks: []*types.BlockHeader{env.blocks[4]},
+			user:           "testUser",
+		},
 		{
 			name:           "path 90 6",
 			startNumber:    6,
@@ -562,6 +572,12 @@ func TestGetPath(t *testing.T) {
 				for idx, expectedBlock := range testCase.expectedBlocks {
 					require.True(t, proto.Equal(expectedBlock, payload.GetBlockHeaders()[idx]))
 				}
+
+				endBlockHash, err := env.p.blockStore.GetHash(testCase.endNumber)
+				require.NoError(t, err)
+				valid, err := ledger.VerifyPath(payload.GetBlockHeaders(), testCase.startNumber, testCase.endNumber, endBlockHash)
+				require.NoError(t, err)
+				require.True(t, valid)
 			}
 		})
 	}
@@ -775,6 +791,89 @@ func TestGetDataProof(t *testing.T) {
 	}
 }
 
+func TestGetDBStateRoot(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 100)
+
+	testCases := []struct {
+		name        string
+		blockNumber uint64
+		dbName      string
+		user        string
+		expectedErr error
+	}{
+		{
+			name:        "get root of db in block 5",
+			blockNumber: 5,
+			dbName:      worldstate.DefaultDBName,
+			user:        "testUser",
+		},
+		{
+			name:        "get root of db in block 95",
+			blockNumber: 95,
+			dbName:      worldstate.DefaultDBName,
+			user:        "testUser",
+		},
+		{
+			name:        "get root of db without state",
+			blockNumber: 95,
+			dbName:      "db1",
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "no state of db db1 found in block 95"},
+		},
+		{
+			name:        "get root from block 515 - not exist",
+			blockNumber: 515,
+			dbName:      worldstate.DefaultDBName,
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "block not found: 515"},
+		},
+		{
+			name:        "get root from block 40 - wrong user",
+			blockNumber: 40,
+			dbName:      worldstate.DefaultDBName,
+			user:        "userNotExist",
+			expectedErr: &interrors.PermissionErr{ErrMsg: "user userNotExist has no permission to access the ledger"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := env.p.getDBStateRoot(testCase.user, testCase.blockNumber, testCase.dbName)
+			if testCase.expectedErr != nil {
+				require.EqualError(t, err, testCase.expectedErr.Error())
+				require.IsType(t, testCase.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			header := env.blocks[testCase.blockNumber-1]
+			require.Len(t, header.DbStateRootHashes, 1)
+			require.Equal(t, testCase.dbName, header.DbStateRootHashes[0].DbName)
+			require.Equal(t, header.DbStateRootHashes[0].RootHash, resp.RootHash)
+
+			isValid, err := state.NewProof(resp.Path).Verify(resp.RootHash, header.StateMerkelTreeRootHash, false)
+			require.NoError(t, err)
+			require.True(t, isValid)
+
+			// the proof of a value in the database ends with the proof of the root of the database
+			dataProof, err := env.p.getDataProof(testCase.user, testCase.blockNumber, testCase.dbName, "key1", false)
+			require.NoError(t, err)
+			dbPathLen := len(dataProof.Path) - len(resp.Path)
+			require.Equal(t, resp.Path, dataProof.Path[dbPathLen:])
+
+			trieKey, err := state.ConstructCompositeKey(testCase.dbName, "key1")
+			require.NoError(t, err)
+			kvHash, err := state.CalculateKeyValueHash(trieKey, []byte(fmt.Sprintf("value_%d_%d", 1, testCase.blockNumber)))
+			require.NoError(t, err)
+			isValid, err = state.NewProof(dataProof.Path[:dbPathLen]).Verify(kvHash, resp.RootHash, false)
+			require.NoError(t, err)
+			require.True(t, isValid)
+		})
+	}
+}
+
 func TestGetTxReceipt(t *testing.T) {
 	env := newLedgerProcessorTestEnv(t)
 	defer env.cleanup(t)
@@ -840,6 +939,15 @@ func TestGetTxReceipt(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, tt.txIndex, receipt.GetReceipt().GetTxIndex())
 				require.True(t, proto.Equal(env.blocks[tt.blockNumber-1], receipt.GetReceipt().GetHeader()))
+
+				// the proof of inclusion leads from the transaction to the root of the block's transaction tree
+				require.NotEmpty(t, receipt.GetTxProof())
+				currRoot := receipt.GetTxProof()[0]
+				for _, h := range receipt.GetTxProof()[1:] {
+					currRoot, err = crypto.ConcatenateHashes(currRoot, h)
+					require.NoError(t, err)
+				}
+				require.Equal(t, receipt.GetReceipt().GetHeader().GetTxMerkelTreeRootHash(), currRoot)
 			} else {
 				require.Error(t, err)
 				require.EqualError(t, err, tt.expectedErr.Error())
@@ -849,6 +957,135 @@ func TestGetTxReceipt(t *testing.T) {
 	}
 }
 
+func TestGetTxReceipts(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 20)
+
+	assertReceipt := func(t *testing.T, receipt *types.TxReceiptWithProof, txId string, blockNumber, txIndex uint64) {
+		require.Equal(t, txId, receipt.GetTxId())
+		require.Equal(t, txIndex, receipt.GetReceipt().GetTxIndex())
+		require.True(t, proto.Equal(env.blocks[blockNumber-1], receipt.GetReceipt().GetHeader()))
+
+		currRoot := receipt.GetTxProof()[0]
+		for _, h := range receipt.GetTxProof()[1:] {
+			var err error
+			currRoot, err = crypto.ConcatenateHashes(currRoot, h)
+			require.NoError(t, err)
+		}
+		require.Equal(t, receipt.GetReceipt().GetHeader().GetTxMerkelTreeRootHash(), currRoot)
+	}
+
+	t.Run("receipts of many transactions", func(t *testing.T) {
+		res, err := env.p.getTxReceipts("testUser", []string{"Tx5key3", "Tx15key20", "Tx5key1", "Tx19key17", "Tx5key3"})
+		require.NoError(t, err)
+		require.Len(t, res.GetReceipts(), 3)
+		assertReceipt(t, res.GetReceipts()[0], "Tx5key3", 5, 3)
+		assertReceipt(t, res.GetReceipts()[1], "Tx5key1", 5, 1)
+		assertReceipt(t, res.GetReceipts()[2], "Tx19key17", 19, 17)
+		require.Equal(t, []string{"Tx15key20"}, res.GetNotFoundTxIds())
+
+		// the receipts are cached, and served to both the single and the batch queries
+		require.Same(t, res.GetReceipts()[2], env.p.receipts.get("Tx19key17"))
+		receipt, err := env.p.getTxReceipt("testUser", "Tx5key1")
+		require.NoError(t, err)
+		require.Same(t, res.GetReceipts()[1].GetReceipt(), receipt.GetReceipt())
+
+		res, err = env.p.getTxReceipts("testUser", []string{"Tx19key17", "Tx9key7"})
+		require.NoError(t, err)
+		require.Len(t, res.GetReceipts(), 2)
+		assertReceipt(t, res.GetReceipts()[0], "Tx19key17", 19, 17)
+		assertReceipt(t, res.GetReceipts()[1], "Tx9key7", 9, 7)
+		require.Empty(t, res.GetNotFoundTxIds())
+	})
+
+	t.Run("too many transactions", func(t *testing.T) {
+		txIds := make([]string, MaxTxReceiptsPerQuery+1)
+		res, err := env.p.getTxReceipts("testUser", txIds)
+		require.EqualError(t, err, "the number of transactions [1001] exceeds the limit of 1000 receipts per query")
+		require.IsType(t, &interrors.BadRequestError{}, err)
+		require.Nil(t, res)
+	})
+
+	t.Run("no ledger access", func(t *testing.T) {
+		res, err := env.p.getTxReceipts("nonExistUser", []string{"Tx5key3"})
+		require.EqualError(t, err, "user nonExistUser has no permission to access the ledger")
+		require.IsType(t, &interrors.PermissionErr{}, err)
+		require.Nil(t, res)
+	})
+}
+
+func TestReceiptCache(t *testing.T) {
+	c := newReceiptCache(2)
+	require.Nil(t, c.get("tx1"))
+
+	c.put(&types.TxReceiptWithProof{TxId: "tx1"})
+	c.put(&types.TxReceiptWithProof{TxId: "tx2"})
+	c.put(&types.TxReceiptWithProof{TxId: "tx2"})
+	require.Equal(t, "tx1", c.get("tx1").GetTxId())
+	require.Equal(t, "tx2", c.get("tx2").GetTxId())
+
+	// the oldest receipt is evicted
+	c.put(&types.TxReceiptWithProof{TxId: "tx3"})
+	require.Nil(t, c.get("tx1"))
+	require.Equal(t, "tx2", c.get("tx2").GetTxId())
+	require.Equal(t, "tx3", c.get("tx3").GetTxId())
+}
+
+func TestGetTxResourceUsage(t *testing.T) {
+	env := newLedgerProcessorTestEnv(t)
+	defer env.cleanup(t)
+	setup(t, env, 5)
+
+	usage := &types.TxResourceUsage{
+		TxId:     "Tx3key1",
+		BlockNum: 3,
+		TxIndex:  1,
+		UserIds:  []string{"testUser"},
+		DbUsages: []*types.DBResourceUsage{
+			{DbName: worldstate.DefaultDBName, BytesWritten: 16, KeysWritten: 1},
+		},
+	}
+	require.NoError(t, env.p.blockStore.CommitTxResourceUsage([]*types.TxResourceUsage{usage}))
+
+	testCases := []struct {
+		name        string
+		txId        string
+		user        string
+		expectedErr error
+	}{
+		{
+			name: "Getting usage of Tx3key1 - correct",
+			txId: "Tx3key1",
+			user: "testUser",
+		},
+		{
+			name:        "Getting usage of Tx3key2 - no usage exist",
+			txId:        "Tx3key2",
+			user:        "testUser",
+			expectedErr: &interrors.NotFoundErr{Message: "resource usage of TxID not found: Tx3key2"},
+		},
+		{
+			name:        "Getting usage of Tx3key1 - no user exist",
+			txId:        "Tx3key1",
+			user:        "nonExistUser",
+			expectedErr: &interrors.PermissionErr{ErrMsg: "user nonExistUser has no permission to access the ledger"},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := env.p.getTxResourceUsage(tt.user, tt.txId)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				require.True(t, proto.Equal(usage, response.GetUsage()))
+			} else {
+				require.EqualError(t, err, tt.expectedErr.Error())
+				require.IsType(t, tt.expectedErr, err)
+			}
+		})
+	}
+}
+
 func generateCrypto(t *testing.T) ([]byte, []byte) {
 	rootCAPemCert, caPrivKey, err := testutils.GenerateRootCA("BCDB RootCA", "127.0.0.1")
 	require.NoError(t, err)
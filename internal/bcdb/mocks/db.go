@@ -8,6 +8,8 @@ import (
 	errors "github.com/hyperledger-labs/orion-server/internal/errors"
 	mock "github.com/stretchr/testify/mock"
 
+	queryexecutor "github.com/hyperledger-labs/orion-server/internal/queryexecutor"
+
 	time "time"
 
 	types "github.com/hyperledger-labs/orion-server/pkg/types"
@@ -20,6 +22,66 @@ type DB struct {
 	mock.Mock
 }
 
+// AddPendingDataTx provides a mock function with given fields: txEnv, expiry
+func (_m *DB) AddPendingDataTx(txEnv *types.DataTxEnvelope, expiry time.Duration) (*types.PendingDataTxResponseEnvelope, error) {
+	ret := _m.Called(txEnv, expiry)
+
+	var r0 *types.PendingDataTxResponseEnvelope
+	if rf, ok := ret.Get(0).(func(*types.DataTxEnvelope, time.Duration) *types.PendingDataTxResponseEnvelope); ok {
+		r0 = rf(txEnv, expiry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PendingDataTxResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.DataTxEnvelope, time.Duration) error); ok {
+		r1 = rf(txEnv, expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddPendingDataTxSignature provides a mock function with given fields: txID, userID, signature
+func (_m *DB) AddPendingDataTxSignature(txID string, userID string, signature []byte) (*types.PendingDataTxResponseEnvelope, error) {
+	ret := _m.Called(txID, userID, signature)
+
+	var r0 *types.PendingDataTxResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, []byte) *types.PendingDataTxResponseEnvelope); ok {
+		r0 = rf(txID, userID, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PendingDataTxResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []byte) error); ok {
+		r1 = rf(txID, userID, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckQueryNonce provides a mock function with given fields: userID, nonce, timestamp
+func (_m *DB) CheckQueryNonce(userID string, nonce string, timestamp int64) error {
+	ret := _m.Called(userID, nonce, timestamp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int64) error); ok {
+		r0 = rf(userID, nonce, timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *DB) Close() error {
 	ret := _m.Called()
@@ -34,6 +96,75 @@ func (_m *DB) Close() error {
 	return r0
 }
 
+// CloseDataCursor provides a mock function with given fields: querierUserID, cursorID
+func (_m *DB) CloseDataCursor(querierUserID string, cursorID string) (*types.DataCursorResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, cursorID)
+
+	var r0 *types.DataCursorResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.DataCursorResponseEnvelope); ok {
+		r0 = rf(querierUserID, cursorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DataCursorResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, cursorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompactIndex provides a mock function with given fields: querierUserID, dbName
+func (_m *DB) CompactIndex(querierUserID string, dbName string) (*types.CompactIndexResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName)
+
+	var r0 *types.CompactIndexResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.CompactIndexResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.CompactIndexResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, dbName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateSessionToken provides a mock function with given fields: userID, timestamp, ttl
+func (_m *DB) CreateSessionToken(userID string, timestamp int64, ttl time.Duration) (*types.SessionTokenResponseEnvelope, error) {
+	ret := _m.Called(userID, timestamp, ttl)
+
+	var r0 *types.SessionTokenResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, int64, time.Duration) *types.SessionTokenResponseEnvelope); ok {
+		r0 = rf(userID, timestamp, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.SessionTokenResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, time.Duration) error); ok {
+		r1 = rf(userID, timestamp, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DataQuery provides a mock function with given fields: ctx, dbName, querierUserID, query
 func (_m *DB) DataQuery(ctx context.Context, dbName string, querierUserID string, query []byte) (*types.DataQueryResponseEnvelope, error) {
 	ret := _m.Called(ctx, dbName, querierUserID, query)
@@ -57,6 +188,29 @@ func (_m *DB) DataQuery(ctx context.Context, dbName string, querierUserID string
 	return r0, r1
 }
 
+// DataSQLQuery provides a mock function with given fields: ctx, querierUserID, query
+func (_m *DB) DataSQLQuery(ctx context.Context, querierUserID string, query *queryexecutor.SQLQuery) (*types.DataQueryResponseEnvelope, error) {
+	ret := _m.Called(ctx, querierUserID, query)
+
+	var r0 *types.DataQueryResponseEnvelope
+	if rf, ok := ret.Get(0).(func(context.Context, string, *queryexecutor.SQLQuery) *types.DataQueryResponseEnvelope); ok {
+		r0 = rf(ctx, querierUserID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DataQueryResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *queryexecutor.SQLQuery) error); ok {
+		r1 = rf(ctx, querierUserID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DoesUserExist provides a mock function with given fields: userID
 func (_m *DB) DoesUserExist(userID string) (bool, error) {
 	ret := _m.Called(userID)
@@ -78,6 +232,52 @@ func (_m *DB) DoesUserExist(userID string) (bool, error) {
 	return r0, r1
 }
 
+// DryRunConfigTx provides a mock function with given fields: txEnv
+func (_m *DB) DryRunConfigTx(txEnv *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponseEnvelope, error) {
+	ret := _m.Called(txEnv)
+
+	var r0 *types.ConfigTxDryRunResponseEnvelope
+	if rf, ok := ret.Get(0).(func(*types.ConfigTxEnvelope) *types.ConfigTxDryRunResponseEnvelope); ok {
+		r0 = rf(txEnv)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ConfigTxDryRunResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ConfigTxEnvelope) error); ok {
+		r1 = rf(txEnv)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExplainDataQuery provides a mock function with given fields: ctx, dbName, querierUserID, query
+func (_m *DB) ExplainDataQuery(ctx context.Context, dbName string, querierUserID string, query []byte) (*types.ExplainJSONQueryResponseEnvelope, error) {
+	ret := _m.Called(ctx, dbName, querierUserID, query)
+
+	var r0 *types.ExplainJSONQueryResponseEnvelope
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) *types.ExplainJSONQueryResponseEnvelope); ok {
+		r0 = rf(ctx, dbName, querierUserID, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ExplainJSONQueryResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, dbName, querierUserID, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAugmentedBlockHeader provides a mock function with given fields: userID, blockNum
 func (_m *DB) GetAugmentedBlockHeader(userID string, blockNum uint64) (*types.GetAugmentedBlockHeaderResponseEnvelope, error) {
 	ret := _m.Called(userID, blockNum)
@@ -216,22 +416,22 @@ func (_m *DB) GetConfigBlock(querierUserID string, blockNumber uint64) (*types.G
 	return r0, r1
 }
 
-// GetDBStatus provides a mock function with given fields: dbName
-func (_m *DB) GetDBStatus(dbName string) (*types.GetDBStatusResponseEnvelope, error) {
-	ret := _m.Called(dbName)
+// GetConfigDiff provides a mock function with given fields: querierUserID, fromBlockNumber, toBlockNumber
+func (_m *DB) GetConfigDiff(querierUserID string, fromBlockNumber uint64, toBlockNumber uint64) (*types.GetConfigDiffResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, fromBlockNumber, toBlockNumber)
 
-	var r0 *types.GetDBStatusResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string) *types.GetDBStatusResponseEnvelope); ok {
-		r0 = rf(dbName)
+	var r0 *types.GetConfigDiffResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64) *types.GetConfigDiffResponseEnvelope); ok {
+		r0 = rf(querierUserID, fromBlockNumber, toBlockNumber)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetDBStatusResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetConfigDiffResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(dbName)
+	if rf, ok := ret.Get(1).(func(string, uint64, uint64) error); ok {
+		r1 = rf(querierUserID, fromBlockNumber, toBlockNumber)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -239,22 +439,22 @@ func (_m *DB) GetDBStatus(dbName string) (*types.GetDBStatusResponseEnvelope, er
 	return r0, r1
 }
 
-// GetData provides a mock function with given fields: dbName, querierUserID, key
-func (_m *DB) GetData(dbName string, querierUserID string, key string) (*types.GetDataResponseEnvelope, error) {
-	ret := _m.Called(dbName, querierUserID, key)
+// GetConfigHistory provides a mock function with given fields: querierUserID
+func (_m *DB) GetConfigHistory(querierUserID string) (*types.GetConfigHistoryResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
 
-	var r0 *types.GetDataResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string, string) *types.GetDataResponseEnvelope); ok {
-		r0 = rf(dbName, querierUserID, key)
+	var r0 *types.GetConfigHistoryResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetConfigHistoryResponseEnvelope); ok {
+		r0 = rf(querierUserID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetDataResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetConfigHistoryResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(dbName, querierUserID, key)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(querierUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -262,22 +462,22 @@ func (_m *DB) GetData(dbName string, querierUserID string, key string) (*types.G
 	return r0, r1
 }
 
-// GetDataProof provides a mock function with given fields: userID, blockNum, dbname, key, deleted
-func (_m *DB) GetDataProof(userID string, blockNum uint64, dbname string, key string, deleted bool) (*types.GetDataProofResponseEnvelope, error) {
-	ret := _m.Called(userID, blockNum, dbname, key, deleted)
+// GetConsensusDiagnostics provides a mock function with given fields: querierUserID
+func (_m *DB) GetConsensusDiagnostics(querierUserID string) (*types.GetConsensusDiagnosticsResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
 
-	var r0 *types.GetDataProofResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, uint64, string, string, bool) *types.GetDataProofResponseEnvelope); ok {
-		r0 = rf(userID, blockNum, dbname, key, deleted)
+	var r0 *types.GetConsensusDiagnosticsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetConsensusDiagnosticsResponseEnvelope); ok {
+		r0 = rf(querierUserID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetDataProofResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetConsensusDiagnosticsResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint64, string, string, bool) error); ok {
-		r1 = rf(userID, blockNum, dbname, key, deleted)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(querierUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -285,22 +485,22 @@ func (_m *DB) GetDataProof(userID string, blockNum uint64, dbname string, key st
 	return r0, r1
 }
 
-// GetDeletedValues provides a mock function with given fields: dbname, key
-func (_m *DB) GetDeletedValues(dbname string, key string) (*types.GetHistoricalDataResponseEnvelope, error) {
-	ret := _m.Called(dbname, key)
+// GetDBStateRoot provides a mock function with given fields: userID, blockNum, dbname
+func (_m *DB) GetDBStateRoot(userID string, blockNum uint64, dbname string) (*types.GetDBStateRootResponseEnvelope, error) {
+	ret := _m.Called(userID, blockNum, dbname)
 
-	var r0 *types.GetHistoricalDataResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string) *types.GetHistoricalDataResponseEnvelope); ok {
-		r0 = rf(dbname, key)
+	var r0 *types.GetDBStateRootResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, string) *types.GetDBStateRootResponseEnvelope); ok {
+		r0 = rf(userID, blockNum, dbname)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetDBStateRootResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(dbname, key)
+	if rf, ok := ret.Get(1).(func(string, uint64, string) error); ok {
+		r1 = rf(userID, blockNum, dbname)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -308,22 +508,22 @@ func (_m *DB) GetDeletedValues(dbname string, key string) (*types.GetHistoricalD
 	return r0, r1
 }
 
-// GetLedgerPath provides a mock function with given fields: userID, start, end
-func (_m *DB) GetLedgerPath(userID string, start uint64, end uint64) (*types.GetLedgerPathResponseEnvelope, error) {
-	ret := _m.Called(userID, start, end)
+// GetDBStatus provides a mock function with given fields: dbName
+func (_m *DB) GetDBStatus(dbName string) (*types.GetDBStatusResponseEnvelope, error) {
+	ret := _m.Called(dbName)
 
-	var r0 *types.GetLedgerPathResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, uint64, uint64) *types.GetLedgerPathResponseEnvelope); ok {
-		r0 = rf(userID, start, end)
+	var r0 *types.GetDBStatusResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetDBStatusResponseEnvelope); ok {
+		r0 = rf(dbName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetLedgerPathResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetDBStatusResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint64, uint64) error); ok {
-		r1 = rf(userID, start, end)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(dbName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -331,22 +531,22 @@ func (_m *DB) GetLedgerPath(userID string, start uint64, end uint64) (*types.Get
 	return r0, r1
 }
 
-// GetMostRecentValueAtOrBelow provides a mock function with given fields: dbName, key, version
-func (_m *DB) GetMostRecentValueAtOrBelow(dbName string, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	ret := _m.Called(dbName, key, version)
+// GetDBs provides a mock function with given fields: querierUserID, prefix, offset, limit
+func (_m *DB) GetDBs(querierUserID string, prefix string, offset uint64, limit uint64) (*types.GetDBsResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, prefix, offset, limit)
 
-	var r0 *types.GetHistoricalDataResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string, *types.Version) *types.GetHistoricalDataResponseEnvelope); ok {
-		r0 = rf(dbName, key, version)
+	var r0 *types.GetDBsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, uint64, uint64) *types.GetDBsResponseEnvelope); ok {
+		r0 = rf(querierUserID, prefix, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetDBsResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, *types.Version) error); ok {
-		r1 = rf(dbName, key, version)
+	if rf, ok := ret.Get(1).(func(string, string, uint64, uint64) error); ok {
+		r1 = rf(querierUserID, prefix, offset, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -354,22 +554,22 @@ func (_m *DB) GetMostRecentValueAtOrBelow(dbName string, key string, version *ty
 	return r0, r1
 }
 
-// GetNextValues provides a mock function with given fields: dbname, key, version
-func (_m *DB) GetNextValues(dbname string, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	ret := _m.Called(dbname, key, version)
+// GetData provides a mock function with given fields: dbName, querierUserID, key
+func (_m *DB) GetData(dbName string, querierUserID string, key string) (*types.GetDataResponseEnvelope, error) {
+	ret := _m.Called(dbName, querierUserID, key)
 
-	var r0 *types.GetHistoricalDataResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string, *types.Version) *types.GetHistoricalDataResponseEnvelope); ok {
-		r0 = rf(dbname, key, version)
+	var r0 *types.GetDataResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string) *types.GetDataResponseEnvelope); ok {
+		r0 = rf(dbName, querierUserID, key)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetDataResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, *types.Version) error); ok {
-		r1 = rf(dbname, key, version)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(dbName, querierUserID, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -377,22 +577,22 @@ func (_m *DB) GetNextValues(dbname string, key string, version *types.Version) (
 	return r0, r1
 }
 
-// GetNodeConfig provides a mock function with given fields: nodeID
-func (_m *DB) GetNodeConfig(nodeID string) (*types.GetNodeConfigResponseEnvelope, error) {
-	ret := _m.Called(nodeID)
+// GetDataCursorPage provides a mock function with given fields: querierUserID, cursorID, after, limit
+func (_m *DB) GetDataCursorPage(querierUserID string, cursorID string, after string, limit uint64) (*types.GetDataCursorPageResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, cursorID, after, limit)
 
-	var r0 *types.GetNodeConfigResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string) *types.GetNodeConfigResponseEnvelope); ok {
-		r0 = rf(nodeID)
+	var r0 *types.GetDataCursorPageResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string, uint64) *types.GetDataCursorPageResponseEnvelope); ok {
+		r0 = rf(querierUserID, cursorID, after, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetNodeConfigResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetDataCursorPageResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(nodeID)
+	if rf, ok := ret.Get(1).(func(string, string, string, uint64) error); ok {
+		r1 = rf(querierUserID, cursorID, after, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -400,22 +600,22 @@ func (_m *DB) GetNodeConfig(nodeID string) (*types.GetNodeConfigResponseEnvelope
 	return r0, r1
 }
 
-// GetPreviousValues provides a mock function with given fields: dbname, key, version
-func (_m *DB) GetPreviousValues(dbname string, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	ret := _m.Called(dbname, key, version)
+// GetDataKeys provides a mock function with given fields: dbName, querierUserID, prefix, startKey, limit, countOnly
+func (_m *DB) GetDataKeys(dbName string, querierUserID string, prefix string, startKey string, limit uint64, countOnly bool) (*types.GetDataKeysResponseEnvelope, error) {
+	ret := _m.Called(dbName, querierUserID, prefix, startKey, limit, countOnly)
 
-	var r0 *types.GetHistoricalDataResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string, *types.Version) *types.GetHistoricalDataResponseEnvelope); ok {
-		r0 = rf(dbname, key, version)
+	var r0 *types.GetDataKeysResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string, string, uint64, bool) *types.GetDataKeysResponseEnvelope); ok {
+		r0 = rf(dbName, querierUserID, prefix, startKey, limit, countOnly)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetDataKeysResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, *types.Version) error); ok {
-		r1 = rf(dbname, key, version)
+	if rf, ok := ret.Get(1).(func(string, string, string, string, uint64, bool) error); ok {
+		r1 = rf(dbName, querierUserID, prefix, startKey, limit, countOnly)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -423,22 +623,22 @@ func (_m *DB) GetPreviousValues(dbname string, key string, version *types.Versio
 	return r0, r1
 }
 
-// GetReaders provides a mock function with given fields: dbName, key
-func (_m *DB) GetReaders(dbName string, key string) (*types.GetDataReadersResponseEnvelope, error) {
-	ret := _m.Called(dbName, key)
+// GetDataProof provides a mock function with given fields: userID, blockNum, dbname, key, deleted
+func (_m *DB) GetDataProof(userID string, blockNum uint64, dbname string, key string, deleted bool) (*types.GetDataProofResponseEnvelope, error) {
+	ret := _m.Called(userID, blockNum, dbname, key, deleted)
 
-	var r0 *types.GetDataReadersResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string) *types.GetDataReadersResponseEnvelope); ok {
-		r0 = rf(dbName, key)
+	var r0 *types.GetDataProofResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, string, string, bool) *types.GetDataProofResponseEnvelope); ok {
+		r0 = rf(userID, blockNum, dbname, key, deleted)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetDataReadersResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetDataProofResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(dbName, key)
+	if rf, ok := ret.Get(1).(func(string, uint64, string, string, bool) error); ok {
+		r1 = rf(userID, blockNum, dbname, key, deleted)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -446,22 +646,22 @@ func (_m *DB) GetReaders(dbName string, key string) (*types.GetDataReadersRespon
 	return r0, r1
 }
 
-// GetTxIDsSubmittedByUser provides a mock function with given fields: userID
-func (_m *DB) GetTxIDsSubmittedByUser(userID string) (*types.GetTxIDsSubmittedByResponseEnvelope, error) {
-	ret := _m.Called(userID)
+// GetDataVersion provides a mock function with given fields: dbName, querierUserID, key
+func (_m *DB) GetDataVersion(dbName string, querierUserID string, key string) (*types.Version, error) {
+	ret := _m.Called(dbName, querierUserID, key)
 
-	var r0 *types.GetTxIDsSubmittedByResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string) *types.GetTxIDsSubmittedByResponseEnvelope); ok {
-		r0 = rf(userID)
+	var r0 *types.Version
+	if rf, ok := ret.Get(0).(func(string, string, string) *types.Version); ok {
+		r0 = rf(dbName, querierUserID, key)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetTxIDsSubmittedByResponseEnvelope)
+			r0 = ret.Get(0).(*types.Version)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(dbName, querierUserID, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -469,22 +669,22 @@ func (_m *DB) GetTxIDsSubmittedByUser(userID string) (*types.GetTxIDsSubmittedBy
 	return r0, r1
 }
 
-// GetTxProof provides a mock function with given fields: userID, blockNum, txIdx
-func (_m *DB) GetTxProof(userID string, blockNum uint64, txIdx uint64) (*types.GetTxProofResponseEnvelope, error) {
-	ret := _m.Called(userID, blockNum, txIdx)
+// GetDeletedValues provides a mock function with given fields: querierUserID, dbname, key
+func (_m *DB) GetDeletedValues(querierUserID string, dbname string, key string) (*types.GetHistoricalDataResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbname, key)
 
-	var r0 *types.GetTxProofResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, uint64, uint64) *types.GetTxProofResponseEnvelope); ok {
-		r0 = rf(userID, blockNum, txIdx)
+	var r0 *types.GetHistoricalDataResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string) *types.GetHistoricalDataResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbname, key)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.GetTxProofResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, uint64, uint64) error); ok {
-		r1 = rf(userID, blockNum, txIdx)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(querierUserID, dbname, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -492,22 +692,22 @@ func (_m *DB) GetTxProof(userID string, blockNum uint64, txIdx uint64) (*types.G
 	return r0, r1
 }
 
-// GetTxReceipt provides a mock function with given fields: userId, txID
-func (_m *DB) GetTxReceipt(userId string, txID string) (*types.TxReceiptResponseEnvelope, error) {
-	ret := _m.Called(userId, txID)
+// GetIndexUsage provides a mock function with given fields: querierUserID, unusedOnly
+func (_m *DB) GetIndexUsage(querierUserID string, unusedOnly bool) (*types.GetIndexUsageResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, unusedOnly)
 
-	var r0 *types.TxReceiptResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string) *types.TxReceiptResponseEnvelope); ok {
-		r0 = rf(userId, txID)
+	var r0 *types.GetIndexUsageResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, bool) *types.GetIndexUsageResponseEnvelope); ok {
+		r0 = rf(querierUserID, unusedOnly)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*types.TxReceiptResponseEnvelope)
+			r0 = ret.Get(0).(*types.GetIndexUsageResponseEnvelope)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(userId, txID)
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(querierUserID, unusedOnly)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -515,8 +715,484 @@ func (_m *DB) GetTxReceipt(userId string, txID string) (*types.TxReceiptResponse
 	return r0, r1
 }
 
-// GetUser provides a mock function with given fields: querierUserID, targetUserID
-func (_m *DB) GetUser(querierUserID string, targetUserID string) (*types.GetUserResponseEnvelope, error) {
+// GetLedgerPath provides a mock function with given fields: userID, start, end
+func (_m *DB) GetLedgerPath(userID string, start uint64, end uint64) (*types.GetLedgerPathResponseEnvelope, error) {
+	ret := _m.Called(userID, start, end)
+
+	var r0 *types.GetLedgerPathResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64) *types.GetLedgerPathResponseEnvelope); ok {
+		r0 = rf(userID, start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetLedgerPathResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint64, uint64) error); ok {
+		r1 = rf(userID, start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMigrations provides a mock function with given fields: querierUserID, name
+func (_m *DB) GetMigrations(querierUserID string, name string) (*types.GetMigrationsResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, name)
+
+	var r0 *types.GetMigrationsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.GetMigrationsResponseEnvelope); ok {
+		r0 = rf(querierUserID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetMigrationsResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMostRecentValueAtOrBelow provides a mock function with given fields: querierUserID, dbName, key, version
+func (_m *DB) GetMostRecentValueAtOrBelow(querierUserID string, dbName string, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName, key, version)
+
+	var r0 *types.GetHistoricalDataResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string, *types.Version) *types.GetHistoricalDataResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName, key, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, *types.Version) error); ok {
+		r1 = rf(querierUserID, dbName, key, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNextValues provides a mock function with given fields: querierUserID, dbname, key, version, limit
+func (_m *DB) GetNextValues(querierUserID string, dbname string, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbname, key, version, limit)
+
+	var r0 *types.GetHistoricalDataResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string, *types.Version, uint64) *types.GetHistoricalDataResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbname, key, version, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, *types.Version, uint64) error); ok {
+		r1 = rf(querierUserID, dbname, key, version, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNodeConfig provides a mock function with given fields: nodeID
+func (_m *DB) GetNodeConfig(nodeID string) (*types.GetNodeConfigResponseEnvelope, error) {
+	ret := _m.Called(nodeID)
+
+	var r0 *types.GetNodeConfigResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetNodeConfigResponseEnvelope); ok {
+		r0 = rf(nodeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetNodeConfigResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nodeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenSnapshots provides a mock function with given fields:
+func (_m *DB) GetOpenSnapshots() *types.OpenSnapshots {
+	ret := _m.Called()
+
+	var r0 *types.OpenSnapshots
+	if rf, ok := ret.Get(0).(func() *types.OpenSnapshots); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.OpenSnapshots)
+		}
+	}
+
+	return r0
+}
+
+// GetPendingDataTx provides a mock function with given fields: querierUserID, txID
+func (_m *DB) GetPendingDataTx(querierUserID string, txID string) (*types.PendingDataTxResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, txID)
+
+	var r0 *types.PendingDataTxResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.PendingDataTxResponseEnvelope); ok {
+		r0 = rf(querierUserID, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PendingDataTxResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingDataTxs provides a mock function with given fields: querierUserID
+func (_m *DB) GetPendingDataTxs(querierUserID string) (*types.GetPendingDataTxsResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
+
+	var r0 *types.GetPendingDataTxsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetPendingDataTxsResponseEnvelope); ok {
+		r0 = rf(querierUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetPendingDataTxsResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(querierUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPreviousValues provides a mock function with given fields: querierUserID, dbname, key, version, limit
+func (_m *DB) GetPreviousValues(querierUserID string, dbname string, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbname, key, version, limit)
+
+	var r0 *types.GetHistoricalDataResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string, *types.Version, uint64) *types.GetHistoricalDataResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbname, key, version, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, *types.Version, uint64) error); ok {
+		r1 = rf(querierUserID, dbname, key, version, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuarantinedBlock provides a mock function with given fields: querierUserID
+func (_m *DB) GetQuarantinedBlock(querierUserID string) (*types.GetQuarantinedBlockResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
+
+	var r0 *types.GetQuarantinedBlockResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetQuarantinedBlockResponseEnvelope); ok {
+		r0 = rf(querierUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetQuarantinedBlockResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(querierUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReaders provides a mock function with given fields: querierUserID, dbName, key
+func (_m *DB) GetReaders(querierUserID string, dbName string, key string) (*types.GetDataReadersResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName, key)
+
+	var r0 *types.GetDataReadersResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string) *types.GetDataReadersResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetDataReadersResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(querierUserID, dbName, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRejectedTxs provides a mock function with given fields: querierUserID
+func (_m *DB) GetRejectedTxs(querierUserID string) (*types.GetRejectedTxsResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
+
+	var r0 *types.GetRejectedTxsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetRejectedTxsResponseEnvelope); ok {
+		r0 = rf(querierUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetRejectedTxsResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(querierUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSlowQueries provides a mock function with given fields: querierUserID
+func (_m *DB) GetSlowQueries(querierUserID string) (*types.GetSlowQueriesResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
+
+	var r0 *types.GetSlowQueriesResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetSlowQueriesResponseEnvelope); ok {
+		r0 = rf(querierUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetSlowQueriesResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(querierUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStateHash provides a mock function with given fields: querierUserID, dbName
+func (_m *DB) GetStateHash(querierUserID string, dbName string) (*types.GetStateHashResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName)
+
+	var r0 *types.GetStateHashResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.GetStateHashResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetStateHashResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, dbName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStorageReport provides a mock function with given fields: querierUserID, top, prefixDelimiter
+func (_m *DB) GetStorageReport(querierUserID string, top uint32, prefixDelimiter string) (*types.GetStorageReportResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, top, prefixDelimiter)
+
+	var r0 *types.GetStorageReportResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint32, string) *types.GetStorageReportResponseEnvelope); ok {
+		r0 = rf(querierUserID, top, prefixDelimiter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetStorageReportResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint32, string) error); ok {
+		r1 = rf(querierUserID, top, prefixDelimiter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxIDsSubmittedByUser provides a mock function with given fields: querierUserID, userID
+func (_m *DB) GetTxIDsSubmittedByUser(querierUserID string, userID string) (*types.GetTxIDsSubmittedByResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, userID)
+
+	var r0 *types.GetTxIDsSubmittedByResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.GetTxIDsSubmittedByResponseEnvelope); ok {
+		r0 = rf(querierUserID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetTxIDsSubmittedByResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxProof provides a mock function with given fields: userID, blockNum, txIdx
+func (_m *DB) GetTxProof(userID string, blockNum uint64, txIdx uint64) (*types.GetTxProofResponseEnvelope, error) {
+	ret := _m.Called(userID, blockNum, txIdx)
+
+	var r0 *types.GetTxProofResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64) *types.GetTxProofResponseEnvelope); ok {
+		r0 = rf(userID, blockNum, txIdx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetTxProofResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint64, uint64) error); ok {
+		r1 = rf(userID, blockNum, txIdx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxReceipt provides a mock function with given fields: userId, txID
+func (_m *DB) GetTxReceipt(userId string, txID string) (*types.TxReceiptResponseEnvelope, error) {
+	ret := _m.Called(userId, txID)
+
+	var r0 *types.TxReceiptResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.TxReceiptResponseEnvelope); ok {
+		r0 = rf(userId, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TxReceiptResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxReceipts provides a mock function with given fields: userId, txIDs
+func (_m *DB) GetTxReceipts(userId string, txIDs []string) (*types.GetTxReceiptsResponseEnvelope, error) {
+	ret := _m.Called(userId, txIDs)
+
+	var r0 *types.GetTxReceiptsResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, []string) *types.GetTxReceiptsResponseEnvelope); ok {
+		r0 = rf(userId, txIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetTxReceiptsResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(userId, txIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxResourceUsage provides a mock function with given fields: userId, txID
+func (_m *DB) GetTxResourceUsage(userId string, txID string) (*types.GetTxResourceUsageResponseEnvelope, error) {
+	ret := _m.Called(userId, txID)
+
+	var r0 *types.GetTxResourceUsageResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string) *types.GetTxResourceUsageResponseEnvelope); ok {
+		r0 = rf(userId, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetTxResourceUsageResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTxsByAnnotation provides a mock function with given fields: querierUserID, key, value, offset, limit
+func (_m *DB) GetTxsByAnnotation(querierUserID string, key string, value string, offset uint64, limit uint64) (*types.GetTxsByAnnotationResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, key, value, offset, limit)
+
+	var r0 *types.GetTxsByAnnotationResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string, uint64, uint64) *types.GetTxsByAnnotationResponseEnvelope); ok {
+		r0 = rf(querierUserID, key, value, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetTxsByAnnotationResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, uint64, uint64) error); ok {
+		r1 = rf(querierUserID, key, value, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: querierUserID, targetUserID
+func (_m *DB) GetUser(querierUserID string, targetUserID string) (*types.GetUserResponseEnvelope, error) {
 	ret := _m.Called(querierUserID, targetUserID)
 
 	var r0 *types.GetUserResponseEnvelope
@@ -538,13 +1214,36 @@ func (_m *DB) GetUser(querierUserID string, targetUserID string) (*types.GetUser
 	return r0, r1
 }
 
-// GetValueAt provides a mock function with given fields: dbName, key, version
-func (_m *DB) GetValueAt(dbName string, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
-	ret := _m.Called(dbName, key, version)
+// GetUsers provides a mock function with given fields: querierUserID, prefix, offset, limit
+func (_m *DB) GetUsers(querierUserID string, prefix string, offset uint64, limit uint64) (*types.GetUsersResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, prefix, offset, limit)
+
+	var r0 *types.GetUsersResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, uint64, uint64) *types.GetUsersResponseEnvelope); ok {
+		r0 = rf(querierUserID, prefix, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetUsersResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint64, uint64) error); ok {
+		r1 = rf(querierUserID, prefix, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetValueAt provides a mock function with given fields: querierUserID, dbName, key, version
+func (_m *DB) GetValueAt(querierUserID string, dbName string, key string, version *types.Version) (*types.GetHistoricalDataResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName, key, version)
 
 	var r0 *types.GetHistoricalDataResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string, *types.Version) *types.GetHistoricalDataResponseEnvelope); ok {
-		r0 = rf(dbName, key, version)
+	if rf, ok := ret.Get(0).(func(string, string, string, *types.Version) *types.GetHistoricalDataResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName, key, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
@@ -552,8 +1251,8 @@ func (_m *DB) GetValueAt(dbName string, key string, version *types.Version) (*ty
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, *types.Version) error); ok {
-		r1 = rf(dbName, key, version)
+	if rf, ok := ret.Get(1).(func(string, string, string, *types.Version) error); ok {
+		r1 = rf(querierUserID, dbName, key, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -561,13 +1260,13 @@ func (_m *DB) GetValueAt(dbName string, key string, version *types.Version) (*ty
 	return r0, r1
 }
 
-// GetValues provides a mock function with given fields: dbName, key
-func (_m *DB) GetValues(dbName string, key string) (*types.GetHistoricalDataResponseEnvelope, error) {
-	ret := _m.Called(dbName, key)
+// GetValues provides a mock function with given fields: querierUserID, dbName, key, offset, limit
+func (_m *DB) GetValues(querierUserID string, dbName string, key string, offset uint64, limit uint64) (*types.GetHistoricalDataResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName, key, offset, limit)
 
 	var r0 *types.GetHistoricalDataResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string) *types.GetHistoricalDataResponseEnvelope); ok {
-		r0 = rf(dbName, key)
+	if rf, ok := ret.Get(0).(func(string, string, string, uint64, uint64) *types.GetHistoricalDataResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName, key, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.GetHistoricalDataResponseEnvelope)
@@ -575,8 +1274,8 @@ func (_m *DB) GetValues(dbName string, key string) (*types.GetHistoricalDataResp
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(dbName, key)
+	if rf, ok := ret.Get(1).(func(string, string, string, uint64, uint64) error); ok {
+		r1 = rf(querierUserID, dbName, key, offset, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -584,13 +1283,13 @@ func (_m *DB) GetValues(dbName string, key string) (*types.GetHistoricalDataResp
 	return r0, r1
 }
 
-// GetValuesDeletedByUser provides a mock function with given fields: userID
-func (_m *DB) GetValuesDeletedByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
-	ret := _m.Called(userID)
+// GetValuesDeletedByUser provides a mock function with given fields: querierUserID, userID
+func (_m *DB) GetValuesDeletedByUser(querierUserID string, userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, userID)
 
 	var r0 *types.GetDataProvenanceResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string) *types.GetDataProvenanceResponseEnvelope); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string, string) *types.GetDataProvenanceResponseEnvelope); ok {
+		r0 = rf(querierUserID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.GetDataProvenanceResponseEnvelope)
@@ -598,8 +1297,8 @@ func (_m *DB) GetValuesDeletedByUser(userID string) (*types.GetDataProvenanceRes
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -607,13 +1306,13 @@ func (_m *DB) GetValuesDeletedByUser(userID string) (*types.GetDataProvenanceRes
 	return r0, r1
 }
 
-// GetValuesReadByUser provides a mock function with given fields: userID
-func (_m *DB) GetValuesReadByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
-	ret := _m.Called(userID)
+// GetValuesReadByUser provides a mock function with given fields: querierUserID, userID
+func (_m *DB) GetValuesReadByUser(querierUserID string, userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, userID)
 
 	var r0 *types.GetDataProvenanceResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string) *types.GetDataProvenanceResponseEnvelope); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string, string) *types.GetDataProvenanceResponseEnvelope); ok {
+		r0 = rf(querierUserID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.GetDataProvenanceResponseEnvelope)
@@ -621,8 +1320,8 @@ func (_m *DB) GetValuesReadByUser(userID string) (*types.GetDataProvenanceRespon
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -630,22 +1329,45 @@ func (_m *DB) GetValuesReadByUser(userID string) (*types.GetDataProvenanceRespon
 	return r0, r1
 }
 
-// GetValuesWrittenByUser provides a mock function with given fields: userID
-func (_m *DB) GetValuesWrittenByUser(userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
-	ret := _m.Called(userID)
+// GetValuesWrittenByUser provides a mock function with given fields: querierUserID, userID
+func (_m *DB) GetValuesWrittenByUser(querierUserID string, userID string) (*types.GetDataProvenanceResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, userID)
 
 	var r0 *types.GetDataProvenanceResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string) *types.GetDataProvenanceResponseEnvelope); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(string, string) *types.GetDataProvenanceResponseEnvelope); ok {
+		r0 = rf(querierUserID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.GetDataProvenanceResponseEnvelope)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(querierUserID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWitnessStatus provides a mock function with given fields: querierUserID
+func (_m *DB) GetWitnessStatus(querierUserID string) (*types.GetWitnessStatusResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
+
+	var r0 *types.GetWitnessStatusResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.GetWitnessStatusResponseEnvelope); ok {
+		r0 = rf(querierUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetWitnessStatusResponseEnvelope)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userID)
+		r1 = rf(querierUserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -653,13 +1375,13 @@ func (_m *DB) GetValuesWrittenByUser(userID string) (*types.GetDataProvenanceRes
 	return r0, r1
 }
 
-// GetWriters provides a mock function with given fields: dbName, key
-func (_m *DB) GetWriters(dbName string, key string) (*types.GetDataWritersResponseEnvelope, error) {
-	ret := _m.Called(dbName, key)
+// GetWriters provides a mock function with given fields: querierUserID, dbName, key
+func (_m *DB) GetWriters(querierUserID string, dbName string, key string) (*types.GetDataWritersResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName, key)
 
 	var r0 *types.GetDataWritersResponseEnvelope
-	if rf, ok := ret.Get(0).(func(string, string) *types.GetDataWritersResponseEnvelope); ok {
-		r0 = rf(dbName, key)
+	if rf, ok := ret.Get(0).(func(string, string, string) *types.GetDataWritersResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName, key)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*types.GetDataWritersResponseEnvelope)
@@ -667,8 +1389,8 @@ func (_m *DB) GetWriters(dbName string, key string) (*types.GetDataWritersRespon
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(dbName, key)
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(querierUserID, dbName, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -697,6 +1419,27 @@ func (_m *DB) Height() (uint64, error) {
 	return r0, r1
 }
 
+// IsAdmin provides a mock function with given fields: userID
+func (_m *DB) IsAdmin(userID string) (bool, error) {
+	ret := _m.Called(userID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsDBExists provides a mock function with given fields: name
 func (_m *DB) IsDBExists(name string) bool {
 	ret := _m.Called(name)
@@ -748,6 +1491,85 @@ func (_m *DB) LedgerHeight() (uint64, error) {
 	return r0, r1
 }
 
+// OpenDataCursor provides a mock function with given fields: querierUserID, dbName, prefix, ttl
+func (_m *DB) OpenDataCursor(querierUserID string, dbName string, prefix string, ttl time.Duration) (*types.DataCursorResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, dbName, prefix, ttl)
+
+	var r0 *types.DataCursorResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Duration) *types.DataCursorResponseEnvelope); ok {
+		r0 = rf(querierUserID, dbName, prefix, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DataCursorResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, time.Duration) error); ok {
+		r1 = rf(querierUserID, dbName, prefix, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutBlob provides a mock function with given fields: userID, value
+func (_m *DB) PutBlob(userID string, value []byte) (*types.PutBlobResponseEnvelope, error) {
+	ret := _m.Called(userID, value)
+
+	var r0 *types.PutBlobResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, []byte) *types.PutBlobResponseEnvelope); ok {
+		r0 = rf(userID, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PutBlobResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []byte) error); ok {
+		r1 = rf(userID, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Ready provides a mock function with given fields:
+func (_m *DB) Ready() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordRejectedTx provides a mock function with given fields: txID, userID, category, reason
+func (_m *DB) RecordRejectedTx(txID string, userID string, category types.RejectedTx_Category, reason string) {
+	_m.Called(txID, userID, category, reason)
+}
+
+// StreamDataQuery provides a mock function with given fields: ctx, dbName, querierUserID, query, write
+func (_m *DB) StreamDataQuery(ctx context.Context, dbName string, querierUserID string, query []byte, write func([]byte) error) error {
+	ret := _m.Called(ctx, dbName, querierUserID, query, write)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte, func([]byte) error) error); ok {
+		r0 = rf(ctx, dbName, querierUserID, query, write)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SubmitTransaction provides a mock function with given fields: tx, timeout
 func (_m *DB) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
 	ret := _m.Called(tx, timeout)
@@ -770,3 +1592,70 @@ func (_m *DB) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.T
 
 	return r0, r1
 }
+
+// TransferLeadership provides a mock function with given fields: querierUserID, targetNodeID, timeout
+func (_m *DB) TransferLeadership(querierUserID string, targetNodeID string, timeout time.Duration) (*types.TransferLeadershipResponseEnvelope, error) {
+	ret := _m.Called(querierUserID, targetNodeID, timeout)
+
+	var r0 *types.TransferLeadershipResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration) *types.TransferLeadershipResponseEnvelope); ok {
+		r0 = rf(querierUserID, targetNodeID, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TransferLeadershipResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, time.Duration) error); ok {
+		r1 = rf(querierUserID, targetNodeID, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerSnapshot provides a mock function with given fields: querierUserID
+func (_m *DB) TriggerSnapshot(querierUserID string) (*types.TriggerSnapshotResponseEnvelope, error) {
+	ret := _m.Called(querierUserID)
+
+	var r0 *types.TriggerSnapshotResponseEnvelope
+	if rf, ok := ret.Get(0).(func(string) *types.TriggerSnapshotResponseEnvelope); ok {
+		r0 = rf(querierUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TriggerSnapshotResponseEnvelope)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(querierUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifySessionToken provides a mock function with given fields: token
+func (_m *DB) VerifySessionToken(token string) (string, error) {
+	ret := _m.Called(token)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -53,6 +53,66 @@ func (_m *TxProcessor) ClusterStatus() (string, []string) {
 	return r0, r1
 }
 
+// CommitHalted provides a mock function with given fields:
+func (_m *TxProcessor) CommitHalted() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsensusDiagnostics provides a mock function with given fields:
+func (_m *TxProcessor) ConsensusDiagnostics() (*types.GetConsensusDiagnosticsResponse, error) {
+	ret := _m.Called()
+
+	var r0 *types.GetConsensusDiagnosticsResponse
+	if rf, ok := ret.Get(0).(func() *types.GetConsensusDiagnosticsResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetConsensusDiagnosticsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DryRunConfigTx provides a mock function with given fields: txEnv
+func (_m *TxProcessor) DryRunConfigTx(txEnv *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponse, error) {
+	ret := _m.Called(txEnv)
+
+	var r0 *types.ConfigTxDryRunResponse
+	if rf, ok := ret.Get(0).(func(*types.ConfigTxEnvelope) *types.ConfigTxDryRunResponse); ok {
+		r0 = rf(txEnv)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ConfigTxDryRunResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.ConfigTxEnvelope) error); ok {
+		r1 = rf(txEnv)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsLeader provides a mock function with given fields:
 func (_m *TxProcessor) IsLeader() *errors.NotLeaderError {
 	ret := _m.Called()
@@ -91,3 +151,72 @@ func (_m *TxProcessor) SubmitTransaction(tx interface{}, timeout time.Duration)
 
 	return r0, r1
 }
+
+// TransferLeadership provides a mock function with given fields: targetNodeID, timeout
+func (_m *TxProcessor) TransferLeadership(targetNodeID string, timeout time.Duration) (*types.TransferLeadershipResponse, error) {
+	ret := _m.Called(targetNodeID, timeout)
+
+	var r0 *types.TransferLeadershipResponse
+	if rf, ok := ret.Get(0).(func(string, time.Duration) *types.TransferLeadershipResponse); ok {
+		r0 = rf(targetNodeID, timeout)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TransferLeadershipResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(targetNodeID, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TriggerSnapshot provides a mock function with given fields:
+func (_m *TxProcessor) TriggerSnapshot() (*types.TriggerSnapshotResponse, error) {
+	ret := _m.Called()
+
+	var r0 *types.TriggerSnapshotResponse
+	if rf, ok := ret.Get(0).(func() *types.TriggerSnapshotResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TriggerSnapshotResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WitnessStatus provides a mock function with given fields:
+func (_m *TxProcessor) WitnessStatus() (*types.GetWitnessStatusResponse, error) {
+	ret := _m.Called()
+
+	var r0 *types.GetWitnessStatusResponse
+	if rf, ok := ret.Get(0).(func() *types.GetWitnessStatusResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.GetWitnessStatusResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -8,6 +8,10 @@ func constructWorldStatePath(dir string) string {
 	return filepath.Join(dir, "worldstate")
 }
 
+func constructBlobStorePath(dir string) string {
+	return filepath.Join(dir, "blobstore")
+}
+
 func constructBlockStorePath(dir string) string {
 	return filepath.Join(dir, "blockstore")
 }
@@ -19,3 +23,19 @@ func constructProvenanceStorePath(dir string) string {
 func constructStateTrieStorePath(dir string) string {
 	return filepath.Join(dir, "statetriestore")
 }
+
+func constructOutboxStorePath(dir string) string {
+	return filepath.Join(dir, "outbox")
+}
+
+func constructErasureStorePath(dir string) string {
+	return filepath.Join(dir, "erasurestore")
+}
+
+func constructTxIDWindowPath(dir string) string {
+	return filepath.Join(dir, "txidwindow")
+}
+
+func constructQuarantineFilePath(dir string) string {
+	return filepath.Join(dir, "quarantine")
+}
@@ -0,0 +1,199 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+const (
+	// DefaultPendingDataTxExpiry is the time a pending data transaction is held when no expiry is requested
+	DefaultPendingDataTxExpiry = time.Hour
+	// MaxPendingDataTxExpiry is the maximal time a pending data transaction can be held
+	MaxPendingDataTxExpiry = 24 * time.Hour
+	// MaxPendingDataTxs is the maximal number of pending data transactions held at once
+	MaxPendingDataTxs = 1000
+)
+
+// pendingTxPool holds data transactions that await the signatures of some of their must sign users. The pool is held
+// in memory by the leader: the pending transactions are lost when the leader changes or restarts, and have to be
+// added again.
+type pendingTxPool struct {
+	lock  sync.Mutex
+	txs   map[string]*types.PendingDataTx
+	nowFn func() time.Time
+}
+
+func newPendingTxPool() *pendingTxPool {
+	return &pendingTxPool{
+		txs:   make(map[string]*types.PendingDataTx),
+		nowFn: time.Now,
+	}
+}
+
+// add adds a partially signed data transaction to the pool. The signatures on the envelope are expected to be
+// verified by the caller. A transaction that is already signed by all must sign users cannot be added, as it can be
+// submitted directly.
+func (p *pendingTxPool) add(txEnv *types.DataTxEnvelope, expiry time.Duration) (*types.PendingDataTx, error) {
+	if expiry == 0 {
+		expiry = DefaultPendingDataTxExpiry
+	}
+	if expiry < 0 || expiry > MaxPendingDataTxExpiry {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the expiry [%s] of a pending transaction must be positive and up to %s", expiry, MaxPendingDataTxExpiry),
+		}
+	}
+
+	txID := txEnv.GetPayload().GetTxId()
+	if txID == "" {
+		return nil, &ierrors.BadRequestError{ErrMsg: "missing TxId in the transaction envelope payload"}
+	}
+
+	pending := pendingSigners(txEnv)
+	if len(pending) == 0 {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: "the transaction [" + txID + "] is signed by all must sign users and should be submitted directly",
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.removeExpired()
+
+	if _, ok := p.txs[txID]; ok {
+		return nil, &ierrors.DuplicateTxIDError{TxID: txID}
+	}
+	if len(p.txs) >= MaxPendingDataTxs {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the number of pending transactions reached the limit of %d", MaxPendingDataTxs),
+		}
+	}
+
+	tx := &types.PendingDataTx{
+		Envelope:         proto.Clone(txEnv).(*types.DataTxEnvelope),
+		PendingSignerIds: pending,
+		ExpiresAt:        p.nowFn().Add(expiry).UnixNano(),
+	}
+	p.txs[txID] = tx
+
+	return proto.Clone(tx).(*types.PendingDataTx), nil
+}
+
+// get returns a pending transaction. Only the must sign users of the transaction can get it.
+func (p *pendingTxPool) get(querierUserID, txID string) (*types.PendingDataTx, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.removeExpired()
+
+	tx, ok := p.txs[txID]
+	if !ok {
+		return nil, &ierrors.NotFoundErr{Message: "there is no pending transaction with txID [" + txID + "]"}
+	}
+	if !isMustSignUser(tx.Envelope, querierUserID) {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] is not a must sign user of the pending transaction [" + txID + "]",
+		}
+	}
+
+	return proto.Clone(tx).(*types.PendingDataTx), nil
+}
+
+// getAwaitingSignature returns the pending transactions that await the signature of the given user, ordered by their
+// expiry time.
+func (p *pendingTxPool) getAwaitingSignature(userID string) []*types.PendingDataTx {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.removeExpired()
+
+	var txs []*types.PendingDataTx
+	for _, tx := range p.txs {
+		for _, signer := range tx.PendingSignerIds {
+			if signer == userID {
+				txs = append(txs, proto.Clone(tx).(*types.PendingDataTx))
+				break
+			}
+		}
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].ExpiresAt != txs[j].ExpiresAt {
+			return txs[i].ExpiresAt < txs[j].ExpiresAt
+		}
+		return txs[i].Envelope.Payload.TxId < txs[j].Envelope.Payload.TxId
+	})
+
+	return txs
+}
+
+// addSignature adds the signature of a must sign user to a pending transaction. The signature is expected to be
+// verified by the caller. Once all must sign users signed the transaction, it is removed from the pool, and returned
+// with no pending signers, ready to be submitted.
+func (p *pendingTxPool) addSignature(txID, userID string, signature []byte) (*types.PendingDataTx, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.removeExpired()
+
+	tx, ok := p.txs[txID]
+	if !ok {
+		return nil, &ierrors.NotFoundErr{Message: "there is no pending transaction with txID [" + txID + "]"}
+	}
+	if !isMustSignUser(tx.Envelope, userID) {
+		return nil, &ierrors.PermissionErr{
+			ErrMsg: "the user [" + userID + "] is not a must sign user of the pending transaction [" + txID + "]",
+		}
+	}
+
+	if tx.Envelope.Signatures == nil {
+		tx.Envelope.Signatures = make(map[string][]byte)
+	}
+	tx.Envelope.Signatures[userID] = signature
+	tx.PendingSignerIds = pendingSigners(tx.Envelope)
+
+	if len(tx.PendingSignerIds) == 0 {
+		delete(p.txs, txID)
+		return tx, nil
+	}
+
+	return proto.Clone(tx).(*types.PendingDataTx), nil
+}
+
+func (p *pendingTxPool) removeExpired() {
+	now := p.nowFn().UnixNano()
+	for txID, tx := range p.txs {
+		if tx.ExpiresAt <= now {
+			delete(p.txs, txID)
+		}
+	}
+}
+
+func pendingSigners(txEnv *types.DataTxEnvelope) []string {
+	var pending []string
+	for _, userID := range txEnv.GetPayload().GetMustSignUserIds() {
+		if _, ok := txEnv.Signatures[userID]; !ok {
+			pending = append(pending, userID)
+		}
+	}
+	sort.Strings(pending)
+
+	return pending
+}
+
+func isMustSignUser(txEnv *types.DataTxEnvelope, userID string) bool {
+	for _, mustSignUserID := range txEnv.GetPayload().GetMustSignUserIds() {
+		if mustSignUserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"testing"
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingTxPool(t *testing.T) {
+	newTxEnv := func(txID string) *types.DataTxEnvelope {
+		return &types.DataTxEnvelope{
+			Payload: &types.DataTx{
+				MustSignUserIds: []string{"alice", "bob", "charlie"},
+				TxId:            txID,
+				DbOperations: []*types.DBOperation{
+					{
+						DbName: "db1",
+						DataWrites: []*types.DataWrite{
+							{
+								Key:   "key1",
+								Value: []byte("value1"),
+							},
+						},
+					},
+				},
+			},
+			Signatures: map[string][]byte{
+				"alice": []byte("alice-sig"),
+			},
+		}
+	}
+
+	now := time.Unix(1000, 0)
+	newPool := func() *pendingTxPool {
+		p := newPendingTxPool()
+		p.nowFn = func() time.Time { return now }
+		return p
+	}
+
+	t.Run("add, sign, and complete", func(t *testing.T) {
+		p := newPool()
+
+		tx, err := p.add(newTxEnv("tx1"), 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{"bob", "charlie"}, tx.PendingSignerIds)
+		require.Equal(t, now.Add(DefaultPendingDataTxExpiry).UnixNano(), tx.ExpiresAt)
+
+		tx, err = p.get("charlie", "tx1")
+		require.NoError(t, err)
+		require.Equal(t, "tx1", tx.Envelope.Payload.TxId)
+
+		txs := p.getAwaitingSignature("bob")
+		require.Len(t, txs, 1)
+		require.Empty(t, p.getAwaitingSignature("alice"))
+
+		tx, err = p.addSignature("tx1", "bob", []byte("bob-sig"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"charlie"}, tx.PendingSignerIds)
+		require.Empty(t, p.getAwaitingSignature("bob"))
+
+		tx, err = p.addSignature("tx1", "charlie", []byte("charlie-sig"))
+		require.NoError(t, err)
+		require.Empty(t, tx.PendingSignerIds)
+		require.Equal(t, map[string][]byte{
+			"alice":   []byte("alice-sig"),
+			"bob":     []byte("bob-sig"),
+			"charlie": []byte("charlie-sig"),
+		}, tx.Envelope.Signatures)
+
+		// a completed transaction leaves the pool
+		tx, err = p.get("alice", "tx1")
+		require.EqualError(t, err, "there is no pending transaction with txID [tx1]")
+		require.IsType(t, &ierrors.NotFoundErr{}, err)
+		require.Nil(t, tx)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		p := newPool()
+
+		_, err := p.add(newTxEnv("tx1"), time.Minute)
+		require.NoError(t, err)
+		_, err = p.add(newTxEnv("tx2"), 2*time.Minute)
+		require.NoError(t, err)
+
+		txs := p.getAwaitingSignature("bob")
+		require.Len(t, txs, 2)
+		require.Equal(t, "tx1", txs[0].Envelope.Payload.TxId)
+		require.Equal(t, "tx2", txs[1].Envelope.Payload.TxId)
+
+		now = now.Add(time.Minute)
+		defer func() { now = time.Unix(1000, 0) }()
+
+		txs = p.getAwaitingSignature("bob")
+		require.Len(t, txs, 1)
+		require.Equal(t, "tx2", txs[0].Envelope.Payload.TxId)
+
+		_, err = p.addSignature("tx1", "bob", []byte("bob-sig"))
+		require.EqualError(t, err, "there is no pending transaction with txID [tx1]")
+	})
+
+	t.Run("invalid adds", func(t *testing.T) {
+		p := newPool()
+
+		_, err := p.add(newTxEnv("tx1"), MaxPendingDataTxExpiry+time.Second)
+		require.EqualError(t, err, "the expiry [24h0m1s] of a pending transaction must be positive and up to 24h0m0s")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+
+		_, err = p.add(newTxEnv(""), 0)
+		require.EqualError(t, err, "missing TxId in the transaction envelope payload")
+
+		signed := newTxEnv("tx1")
+		signed.Signatures["bob"] = []byte("bob-sig")
+		signed.Signatures["charlie"] = []byte("charlie-sig")
+		_, err = p.add(signed, 0)
+		require.EqualError(t, err, "the transaction [tx1] is signed by all must sign users and should be submitted directly")
+
+		_, err = p.add(newTxEnv("tx1"), 0)
+		require.NoError(t, err)
+		_, err = p.add(newTxEnv("tx1"), 0)
+		require.EqualError(t, err, "the transaction has a duplicate txID [tx1]")
+		require.IsType(t, &ierrors.DuplicateTxIDError{}, err)
+	})
+
+	t.Run("not a must sign user", func(t *testing.T) {
+		p := newPool()
+
+		_, err := p.add(newTxEnv("tx1"), 0)
+		require.NoError(t, err)
+
+		_, err = p.get("dave", "tx1")
+		require.EqualError(t, err, "the user [dave] is not a must sign user of the pending transaction [tx1]")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+
+		_, err = p.addSignature("tx1", "dave", []byte("dave-sig"))
+		require.EqualError(t, err, "the user [dave] is not a must sign user of the pending transaction [tx1]")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+	})
+}
@@ -3,134 +3,199 @@
 package bcdb
 
 import (
+	"sort"
+	"strings"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 )
 
+// errHistoricalDataPageFull stops the iteration over historical values once a page is full
+var errHistoricalDataPageFull = errors.New("historical data page is full")
+
 type provenanceQueryProcessor struct {
-	provenanceStore *provenance.Store
+	db              worldstate.DB
+	provenanceStore provenance.Store
+	identityQuerier *identity.Querier
 	logger          *logger.SugarLogger
 }
 
 type provenanceQueryProcessorConfig struct {
-	provenanceStore *provenance.Store
+	db              worldstate.DB
+	provenanceStore provenance.Store
+	identityQuerier *identity.Querier
 	logger          *logger.SugarLogger
 }
 
 func newProvenanceQueryProcessor(conf *provenanceQueryProcessorConfig) *provenanceQueryProcessor {
 	return &provenanceQueryProcessor{
+		db:              conf.db,
 		provenanceStore: conf.provenanceStore,
+		identityQuerier: conf.identityQuerier,
 		logger:          conf.logger,
 	}
 }
 
-// GetValues returns all values associated with a given key
-func (p *provenanceQueryProcessor) GetValues(dbName, key string) (*types.GetHistoricalDataResponse, error) {
-	values, err := p.provenanceStore.GetValues(dbName, key)
-	if err != nil {
+// GetValues returns the values associated with a given key, skipping the first offset values. The number of records
+// returned would be limited by the limit parameter, where zero means no limit.
+func (p *provenanceQueryProcessor) GetValues(querierUserID, dbName, key string, offset, limit uint64) (*types.GetHistoricalDataResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
 		return nil, err
 	}
 
-	return p.composeHistoricalDataResponse(values)
+	return p.composeHistoricalDataPage(querierUserID, offset, limit, func(visit func(*types.ValueWithMetadata) error) error {
+		return p.provenanceStore.IterateValues(dbName, key, 0, 0, visit)
+	})
 }
 
 // GetValueAt returns the value of a given key at a particular version
-func (p *provenanceQueryProcessor) GetValueAt(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponse, error) {
+func (p *provenanceQueryProcessor) GetValueAt(querierUserID, dbName, key string, version *types.Version) (*types.GetHistoricalDataResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
+		return nil, err
+	}
+
 	value, err := p.provenanceStore.GetValueAt(dbName, key, version)
 	if err != nil {
 		return nil, err
 	}
 
 	if value == nil {
-		return p.composeHistoricalDataResponse(nil)
+		return p.composeHistoricalDataResponse(querierUserID, nil)
 	}
 
-	return p.composeHistoricalDataResponse([]*types.ValueWithMetadata{value})
+	return p.composeHistoricalDataResponse(querierUserID, []*types.ValueWithMetadata{value})
 }
 
 // GetMostRecentValueAtOrBelow returns the most recent value of a given key at or below the given version
-func (p *provenanceQueryProcessor) GetMostRecentValueAtOrBelow(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponse, error) {
+func (p *provenanceQueryProcessor) GetMostRecentValueAtOrBelow(querierUserID, dbName, key string, version *types.Version) (*types.GetHistoricalDataResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
+		return nil, err
+	}
+
 	value, err := p.provenanceStore.GetMostRecentValueAtOrBelow(dbName, key, version)
 	if err != nil {
 		return nil, err
 	}
 
 	if value == nil {
-		return p.composeHistoricalDataResponse(nil)
+		return p.composeHistoricalDataResponse(querierUserID, nil)
 	}
 
-	return p.composeHistoricalDataResponse([]*types.ValueWithMetadata{value})
+	return p.composeHistoricalDataResponse(querierUserID, []*types.ValueWithMetadata{value})
 }
 
 // GetPreviousValues returns previous values of a given key and a version. The number of records returned would be limited
-// by the limit parameters.
-func (p *provenanceQueryProcessor) GetPreviousValues(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponse, error) {
-	values, err := p.provenanceStore.GetPreviousValues(dbName, key, version, -1)
-	if err != nil {
+// by the limit parameter, where zero means no limit.
+func (p *provenanceQueryProcessor) GetPreviousValues(querierUserID, dbName, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
 		return nil, err
 	}
 
-	return p.composeHistoricalDataResponse(values)
+	return p.composeHistoricalDataPage(querierUserID, 0, limit, func(visit func(*types.ValueWithMetadata) error) error {
+		return p.provenanceStore.IteratePreviousValues(dbName, key, version, 0, visit)
+	})
 }
 
 // GetNextValues returns next values of a given key and a version. The number of records returned would be limited
-// by the limit parameters.
-func (p *provenanceQueryProcessor) GetNextValues(dbName, key string, version *types.Version) (*types.GetHistoricalDataResponse, error) {
-	values, err := p.provenanceStore.GetNextValues(dbName, key, version, -1)
-	if err != nil {
+// by the limit parameter, where zero means no limit.
+func (p *provenanceQueryProcessor) GetNextValues(querierUserID, dbName, key string, version *types.Version, limit uint64) (*types.GetHistoricalDataResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
 		return nil, err
 	}
 
-	return p.composeHistoricalDataResponse(values)
+	return p.composeHistoricalDataPage(querierUserID, 0, limit, func(visit func(*types.ValueWithMetadata) error) error {
+		return p.provenanceStore.IterateNextValues(dbName, key, version, 0, visit)
+	})
 }
 
-func (p *provenanceQueryProcessor) GetDeletedValues(dbName, key string) (*types.GetHistoricalDataResponse, error) {
+// GetDeletedValues returns all deleted values associated with a given key
+func (p *provenanceQueryProcessor) GetDeletedValues(querierUserID, dbName, key string) (*types.GetHistoricalDataResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
+		return nil, err
+	}
+
 	values, err := p.provenanceStore.GetDeletedValues(dbName, key)
 	if err != nil {
 		return nil, err
 	}
 
-	return p.composeHistoricalDataResponse(values)
+	return p.composeHistoricalDataResponse(querierUserID, values)
 }
 
 // GetValuesReadByUser returns all values read by a given user
-func (p *provenanceQueryProcessor) GetValuesReadByUser(userID string) (*types.GetDataProvenanceResponse, error) {
+func (p *provenanceQueryProcessor) GetValuesReadByUser(querierUserID, userID string) (*types.GetDataProvenanceResponse, error) {
+	if err := p.checkUserProvenanceAccess(querierUserID, userID); err != nil {
+		return nil, err
+	}
+
 	kvs, err := p.provenanceStore.GetValuesReadByUser(userID)
 	if err != nil {
 		return nil, err
 	}
 
+	readableKVs, err := p.filterReadableKVs(querierUserID, kvs)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.GetDataProvenanceResponse{
-		KVs: kvs,
+		KVs: readableKVs,
 	}, nil
 }
 
 // GetValuesReadByUser returns all values read by a given user
-func (p *provenanceQueryProcessor) GetValuesWrittenByUser(userID string) (*types.GetDataProvenanceResponse, error) {
+func (p *provenanceQueryProcessor) GetValuesWrittenByUser(querierUserID, userID string) (*types.GetDataProvenanceResponse, error) {
+	if err := p.checkUserProvenanceAccess(querierUserID, userID); err != nil {
+		return nil, err
+	}
+
 	kvs, err := p.provenanceStore.GetValuesWrittenByUser(userID)
 	if err != nil {
 		return nil, err
 	}
 
+	readableKVs, err := p.filterReadableKVs(querierUserID, kvs)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.GetDataProvenanceResponse{
-		KVs: kvs,
+		KVs: readableKVs,
 	}, nil
 }
 
-func (p *provenanceQueryProcessor) GetValuesDeletedByUser(userID string) (*types.GetDataProvenanceResponse, error) {
+// GetValuesDeletedByUser returns all values deleted by a given user
+func (p *provenanceQueryProcessor) GetValuesDeletedByUser(querierUserID, userID string) (*types.GetDataProvenanceResponse, error) {
+	if err := p.checkUserProvenanceAccess(querierUserID, userID); err != nil {
+		return nil, err
+	}
+
 	kvs, err := p.provenanceStore.GetValuesDeletedByUser(userID)
 	if err != nil {
 		return nil, err
 	}
 
+	readableKVs, err := p.filterReadableKVs(querierUserID, kvs)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.GetDataProvenanceResponse{
-		KVs: kvs,
+		KVs: readableKVs,
 	}, nil
 }
 
 // GetReaders returns all userIDs who have accessed a given key as well as the access frequency
-func (p *provenanceQueryProcessor) GetReaders(dbName, key string) (*types.GetDataReadersResponse, error) {
+func (p *provenanceQueryProcessor) GetReaders(querierUserID, dbName, key string) (*types.GetDataReadersResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
+		return nil, err
+	}
+
 	users, err := p.provenanceStore.GetReaders(dbName, key)
 	if err != nil {
 		return nil, err
@@ -146,7 +211,11 @@ func (p *provenanceQueryProcessor) GetReaders(dbName, key string) (*types.GetDat
 }
 
 // GetReaders returns all userIDs who have accessed a given key as well as the access frequency
-func (p *provenanceQueryProcessor) GetWriters(dbName, key string) (*types.GetDataWritersResponse, error) {
+func (p *provenanceQueryProcessor) GetWriters(querierUserID, dbName, key string) (*types.GetDataWritersResponse, error) {
+	if err := p.checkKeyAccess(querierUserID, dbName, key); err != nil {
+		return nil, err
+	}
+
 	users, err := p.provenanceStore.GetWriters(dbName, key)
 	if err != nil {
 		return nil, err
@@ -162,7 +231,11 @@ func (p *provenanceQueryProcessor) GetWriters(dbName, key string) (*types.GetDat
 }
 
 // GetTxIDsSubmittedByUser returns all ids of all transactions submitted by a given user
-func (p *provenanceQueryProcessor) GetTxIDsSubmittedByUser(userID string) (*types.GetTxIDsSubmittedByResponse, error) {
+func (p *provenanceQueryProcessor) GetTxIDsSubmittedByUser(querierUserID, userID string) (*types.GetTxIDsSubmittedByResponse, error) {
+	if err := p.checkUserProvenanceAccess(querierUserID, userID); err != nil {
+		return nil, err
+	}
+
 	txIDs, err := p.provenanceStore.GetTxIDsSubmittedByUser(userID)
 	if err != nil {
 		return nil, err
@@ -173,8 +246,256 @@ func (p *provenanceQueryProcessor) GetTxIDsSubmittedByUser(userID string) (*type
 	}, nil
 }
 
-func (p *provenanceQueryProcessor) composeHistoricalDataResponse(values []*types.ValueWithMetadata) (*types.GetHistoricalDataResponse, error) {
+// GetTxsByAnnotation returns the transactions that carry a given annotation, ordered by their location in the
+// ledger, skipping the first offset of them and up to the limit, where zero means no limit. A user with the provenance
+// read privilege finds all such transactions, while other users find only the valid transactions they submitted, as
+// the submitter of an invalid transaction is not recorded.
+func (p *provenanceQueryProcessor) GetTxsByAnnotation(querierUserID, key, value string, offset, limit uint64) (*types.GetTxsByAnnotationResponse, error) {
+	if err := p.checkProvenanceAccess(querierUserID); err != nil {
+		return nil, err
+	}
+
+	hasPerm, err := p.identityQuerier.HasProvenanceReadPrivilege(querierUserID)
+	if err != nil {
+		return nil, err
+	}
+	submitterID := ""
+	if !hasPerm {
+		submitterID = querierUserID
+	}
+
+	txIDs, err := p.provenanceStore.GetTxIDsByAnnotation(key, value, submitterID)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*types.AnnotatedTx, 0, len(txIDs))
+	for _, txID := range txIDs {
+		loc, err := p.provenanceStore.GetTxIDLocation(txID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while fetching the location of transaction [%s]", txID)
+		}
+		txs = append(txs, &types.AnnotatedTx{
+			TxId:        txID,
+			BlockNumber: loc.BlockNum,
+			TxIndex:     uint64(loc.TxIndex),
+			Timestamp:   loc.BlockTime,
+		})
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].BlockNumber != txs[j].BlockNumber {
+			return txs[i].BlockNumber < txs[j].BlockNumber
+		}
+		return txs[i].TxIndex < txs[j].TxIndex
+	})
+
+	if offset >= uint64(len(txs)) {
+		return &types.GetTxsByAnnotationResponse{}, nil
+	}
+	txs = txs[offset:]
+
+	hasMore := false
+	if limit > 0 && uint64(len(txs)) > limit {
+		txs = txs[:limit]
+		hasMore = true
+	}
+
+	return &types.GetTxsByAnnotationResponse{
+		Txs:     txs,
+		HasMore: hasMore,
+	}, nil
+}
+
+func (p *provenanceQueryProcessor) composeHistoricalDataResponse(querierUserID string, values []*types.ValueWithMetadata) (*types.GetHistoricalDataResponse, error) {
+	var readableValues []*types.ValueWithMetadata
+	for _, v := range values {
+		if canReadValue(querierUserID, v.GetMetadata()) {
+			readableValues = append(readableValues, v)
+		}
+	}
+
+	return &types.GetHistoricalDataResponse{
+		Values: readableValues,
+	}, nil
+}
+
+// composeHistoricalDataPage collects the values visited by iterate which the querier can read, skipping the first
+// offset of them and up to the limit. The iteration goes one readable value past the limit to find whether more values
+// are available. A zero limit means no limit. The offset and the limit count only readable values so that the pages do
+// not depend on values hidden from the querier.
+func (p *provenanceQueryProcessor) composeHistoricalDataPage(querierUserID string, offset, limit uint64, iterate func(visit func(*types.ValueWithMetadata) error) error) (*types.GetHistoricalDataResponse, error) {
+	var values []*types.ValueWithMetadata
+	hasMore := false
+	skipped := uint64(0)
+
+	err := iterate(func(v *types.ValueWithMetadata) error {
+		if !canReadValue(querierUserID, v.GetMetadata()) {
+			return nil
+		}
+		if skipped < offset {
+			skipped++
+			return nil
+		}
+		if limit > 0 && uint64(len(values)) == limit {
+			hasMore = true
+			return errHistoricalDataPageFull
+		}
+		values = append(values, v)
+		return nil
+	})
+	if err != nil && err != errHistoricalDataPageFull {
+		return nil, err
+	}
+
 	return &types.GetHistoricalDataResponse{
-		Values: values,
+		Values:  values,
+		HasMore: hasMore,
 	}, nil
 }
+
+// checkProvenanceAccess checks whether the access policy lets the querier query the provenance at all
+func (p *provenanceQueryProcessor) checkProvenanceAccess(querierUserID string) error {
+	hasAccess, err := p.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_PROVENANCE)
+	if err != nil {
+		return err
+	}
+	if !hasAccess {
+		return &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to query the provenance",
+		}
+	}
+
+	return nil
+}
+
+// checkKeyAccess checks whether the querier can read the provenance of a given key. On a data database, the querier
+// needs read access on the database and, if the key currently exists with an ACL, must be listed in it. On system
+// databases, the same rules as for the /user and /config endpoints apply.
+func (p *provenanceQueryProcessor) checkKeyAccess(querierUserID, dbName, key string) error {
+	if err := p.checkProvenanceAccess(querierUserID); err != nil {
+		return err
+	}
+
+	isAdmin, err := p.identityQuerier.HasAdministrationPrivilege(querierUserID)
+	if err != nil {
+		return err
+	}
+
+	if worldstate.IsSystemDB(dbName) {
+		return p.checkSystemKeyAccess(querierUserID, isAdmin, dbName, key)
+	}
+
+	hasPerm, err := p.identityQuerier.HasReadAccessOnDataDB(querierUserID, dbName)
+	if err != nil {
+		return err
+	}
+	if !hasPerm {
+		return &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to read from database [" + dbName + "]",
+		}
+	}
+
+	// the database might have been deleted, in which case the ACL of each historical value still applies
+	if !p.db.Exist(dbName) {
+		return nil
+	}
+
+	_, metadata, err := p.db.Get(dbName, key)
+	if err != nil {
+		return err
+	}
+	if !canReadValue(querierUserID, metadata) {
+		return &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to read the provenance of key [" + key + "] from database [" + dbName + "]",
+		}
+	}
+
+	return nil
+}
+
+func (p *provenanceQueryProcessor) checkSystemKeyAccess(querierUserID string, isAdmin bool, dbName, key string) error {
+	if isAdmin {
+		return nil
+	}
+
+	switch dbName {
+	case worldstate.UsersDBName:
+		targetUserID := strings.TrimPrefix(key, string(identity.UserNamespace))
+		hasPerm, err := p.identityQuerier.HasReadAccessOnTargetUser(querierUserID, targetUserID)
+		if err != nil {
+			// the target user might have been deleted, in which case the ACL of each
+			// historical value still applies
+			if _, ok := err.(*identity.NotFoundErr); ok {
+				return nil
+			}
+			return err
+		}
+		if hasPerm {
+			return nil
+		}
+
+	case worldstate.ConfigDBName:
+		// node entries are readable by all users via the /config/node endpoint while
+		// the cluster configuration is readable only by admins
+		if key != worldstate.ConfigKey {
+			return nil
+		}
+	}
+
+	return &ierrors.PermissionErr{
+		ErrMsg: "the user [" + querierUserID + "] has no permission to read the provenance of key [" + key + "] from database [" + dbName + "]",
+	}
+}
+
+// checkUserProvenanceAccess checks whether the querier can read the provenance of a given user. A user can always
+// read its own provenance while the provenance of other users can be read only with the provenance read privilege.
+func (p *provenanceQueryProcessor) checkUserProvenanceAccess(querierUserID, targetUserID string) error {
+	if err := p.checkProvenanceAccess(querierUserID); err != nil {
+		return err
+	}
+
+	if querierUserID == targetUserID {
+		return nil
+	}
+
+	hasPerm, err := p.identityQuerier.HasProvenanceReadPrivilege(querierUserID)
+	if err != nil {
+		return err
+	}
+	if !hasPerm {
+		return &ierrors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to read the provenance of user [" + targetUserID + "]",
+		}
+	}
+
+	return nil
+}
+
+// filterReadableKVs drops the values held by the databases the querier has no permission to read from, and the values
+// whose ACL does not allow the querier to read them
+func (p *provenanceQueryProcessor) filterReadableKVs(querierUserID string, kvs []*provenance.KVWithDBName) ([]*types.KVWithMetadata, error) {
+	readableDBs := make(map[string]bool)
+	var readableKVs []*types.KVWithMetadata
+	for _, kv := range kvs {
+		canReadDB, ok := readableDBs[kv.DBName]
+		if !ok {
+			var err error
+			if canReadDB, err = p.identityQuerier.HasReadAccessOnDataDB(querierUserID, kv.DBName); err != nil {
+				return nil, err
+			}
+			readableDBs[kv.DBName] = canReadDB
+		}
+
+		if canReadDB && canReadValue(querierUserID, kv.GetMetadata()) {
+			readableKVs = append(readableKVs, kv.KVWithMetadata)
+		}
+	}
+
+	return readableKVs, nil
+}
+
+func canReadValue(querierUserID string, metadata *types.Metadata) bool {
+	acl := metadata.GetAccessControl()
+	return acl == nil || acl.ReadUsers[querierUserID] || acl.ReadWriteUsers[querierUserID]
+}
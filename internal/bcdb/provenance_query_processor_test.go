@@ -5,16 +5,22 @@ package bcdb
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
 type provenanceQueryProcessorTestEnv struct {
-	p *provenanceQueryProcessor
+	p  *provenanceQueryProcessor
+	db *leveldb.LevelDB
 
 	cleanup func(t *testing.T)
 }
@@ -40,26 +46,111 @@ func newProvenanceQueryProcessorTestEnv(t *testing.T) *provenanceQueryProcessorT
 	)
 	require.NoError(t, err)
 
+	db, err := leveldb.Open(
+		&leveldb.Config{
+			DBRootDir: filepath.Join(path, "worldstate"),
+			Logger:    logger,
+		},
+	)
+	require.NoError(t, err)
+
 	cleanup := func(t *testing.T) {
 		if err := provenanceStore.Close(); err != nil {
 			t.Errorf("failed to close the provenance store: %v", err)
 		}
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close leveldb: %v", err)
+		}
 		if err := os.RemoveAll(path); err != nil {
 			t.Fatalf("failed to remove %s due to %v", path, err)
 		}
 	}
 
+	setupProvenanceQueryUsers(t, db)
+
 	return &provenanceQueryProcessorTestEnv{
 		p: newProvenanceQueryProcessor(
 			&provenanceQueryProcessorConfig{
+				db:              db,
 				provenanceStore: provenanceStore,
+				identityQuerier: identity.NewQuerier(db),
 				logger:          logger,
 			}),
+		db:      db,
 		cleanup: cleanup,
 	}
 }
 
-func setupProvenanceStore(t *testing.T, s *provenance.Store) {
+// setupProvenanceQueryUsers adds the users who run the provenance queries:
+// user1 and user2 can read-write db1, user3 has no access to db1, user4
+// can only read db1, user5 took part in no transaction, auditor holds the
+// provenance read privilege and can read db1, and admin is an admin
+func setupProvenanceQueryUsers(t *testing.T, db worldstate.DB) {
+	users := []*types.User{
+		{
+			Id: "user1",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{"db1": types.Privilege_ReadWrite},
+			},
+		},
+		{
+			Id: "user2",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{"db1": types.Privilege_ReadWrite},
+			},
+		},
+		{
+			Id: "user3",
+		},
+		{
+			Id: "user4",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{"db1": types.Privilege_Read},
+			},
+		},
+		{
+			Id: "user5",
+		},
+		{
+			Id: "auditor",
+			Privilege: &types.Privilege{
+				DbPermission:   map[string]types.Privilege_Access{"db1": types.Privilege_Read},
+				ProvenanceRead: true,
+			},
+		},
+		{
+			Id: "admin",
+			Privilege: &types.Privilege{
+				Admin: true,
+			},
+		},
+	}
+
+	var writes []*worldstate.KVWithMetadata
+	for _, u := range users {
+		val, err := proto.Marshal(u)
+		require.NoError(t, err)
+
+		writes = append(writes, &worldstate.KVWithMetadata{
+			Key:   string(identity.UserNamespace) + u.Id,
+			Value: val,
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: 1,
+					TxNum:    0,
+				},
+			},
+		})
+	}
+
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: writes,
+		},
+	}, 1))
+}
+
+func setupProvenanceStore(t *testing.T, s provenance.Store) {
 	block1TxsData := []*provenance.TxDataForProvenance{
 		{
 			IsValid: true,
@@ -269,12 +360,12 @@ func setupProvenanceStore(t *testing.T, s *provenance.Store) {
 		},
 	}
 
-	require.NoError(t, s.Commit(1, block1TxsData))
-	require.NoError(t, s.Commit(2, block2TxsData))
-	require.NoError(t, s.Commit(3, block3TxsData))
-	require.NoError(t, s.Commit(4, block4TxsData))
-	require.NoError(t, s.Commit(5, block5TxsData))
-	require.NoError(t, s.Commit(6, block6TxsData))
+	require.NoError(t, s.Commit(1, 0, block1TxsData))
+	require.NoError(t, s.Commit(2, 0, block2TxsData))
+	require.NoError(t, s.Commit(3, 0, block3TxsData))
+	require.NoError(t, s.Commit(4, 0, block4TxsData))
+	require.NoError(t, s.Commit(5, 0, block5TxsData))
+	require.NoError(t, s.Commit(6, 0, block6TxsData))
 }
 
 func TestGetValues(t *testing.T) {
@@ -345,12 +436,30 @@ func TestGetValues(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		payload, err := env.p.GetValues(tt.dbName, tt.key)
+		payload, err := env.p.GetValues("user1", tt.dbName, tt.key, 0, 0)
 		require.NoError(t, err)
 
 		require.NotNil(t, payload)
 		require.ElementsMatch(t, tt.expectedPayload.GetValues(), payload.GetValues())
+		require.False(t, payload.GetHasMore())
 	}
+
+	t.Run("fetch all values of key1 in pages", func(t *testing.T) {
+		allValues, err := env.p.GetValues("user1", "db1", "key1", 0, 0)
+		require.NoError(t, err)
+
+		firstPage, err := env.p.GetValues("user1", "db1", "key1", 0, 2)
+		require.NoError(t, err)
+		require.Len(t, firstPage.GetValues(), 2)
+		require.True(t, firstPage.GetHasMore())
+
+		lastPage, err := env.p.GetValues("user1", "db1", "key1", 2, uint64(len(allValues.GetValues())))
+		require.NoError(t, err)
+		require.Len(t, lastPage.GetValues(), len(allValues.GetValues())-2)
+		require.False(t, lastPage.GetHasMore())
+
+		require.Equal(t, allValues.GetValues(), append(firstPage.GetValues(), lastPage.GetValues()...))
+	})
 }
 
 func TestGetDeletedValues(t *testing.T) {
@@ -403,7 +512,7 @@ func TestGetDeletedValues(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		payload, err := env.p.GetDeletedValues(tt.dbName, tt.key)
+		payload, err := env.p.GetDeletedValues("user1", tt.dbName, tt.key)
 		require.NoError(t, err)
 
 		require.NotNil(t, payload)
@@ -422,6 +531,7 @@ func TestGetPreviousValues(t *testing.T) {
 		dbName          string
 		key             string
 		version         *types.Version
+		limit           uint64
 		expectedPayload *types.GetHistoricalDataResponse
 	}{
 		{
@@ -455,6 +565,30 @@ func TestGetPreviousValues(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "fetch one previous value of key1 at version{Blk 3, txNum 0}",
+			dbName: "db1",
+			key:    "key1",
+			version: &types.Version{
+				BlockNum: 3,
+				TxNum:    0,
+			},
+			limit: 1,
+			expectedPayload: &types.GetHistoricalDataResponse{
+				Values: []*types.ValueWithMetadata{
+					{
+						Value: []byte("value2"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 2,
+								TxNum:    0,
+							},
+						},
+					},
+				},
+				HasMore: true,
+			},
+		},
 		{
 			name:   "fetch the previous value of non-existing key",
 			dbName: "db1",
@@ -468,7 +602,7 @@ func TestGetPreviousValues(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		payload, err := env.p.GetPreviousValues(tt.dbName, tt.key, tt.version)
+		payload, err := env.p.GetPreviousValues("user1", tt.dbName, tt.key, tt.version, tt.limit)
 		require.NoError(t, err)
 
 		require.NotNil(t, payload)
@@ -535,7 +669,7 @@ func TestGetNextValues(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		envelope, err := env.p.GetNextValues(tt.dbName, tt.key, tt.version)
+		envelope, err := env.p.GetNextValues("user1", tt.dbName, tt.key, tt.version, 0)
 		require.NoError(t, err)
 
 		require.NotNil(t, envelope)
@@ -593,7 +727,7 @@ func TestGetValueAt(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		envelope, err := env.p.GetValueAt(tt.dbName, tt.key, tt.version)
+		envelope, err := env.p.GetValueAt("user1", tt.dbName, tt.key, tt.version)
 		require.NoError(t, err)
 
 		require.NotNil(t, envelope)
@@ -651,7 +785,7 @@ func TestGetMostRecentValueAtOrBelow(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		envelope, err := env.p.GetMostRecentValueAtOrBelow(tt.dbName, tt.key, tt.version)
+		envelope, err := env.p.GetMostRecentValueAtOrBelow("user1", tt.dbName, tt.key, tt.version)
 		require.NoError(t, err)
 
 		require.NotNil(t, envelope)
@@ -693,7 +827,7 @@ func TestGetReaders(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		envelope, err := env.p.GetReaders(tt.dbName, tt.key)
+		envelope, err := env.p.GetReaders("user1", tt.dbName, tt.key)
 		require.NoError(t, err)
 
 		require.NotNil(t, envelope)
@@ -735,7 +869,7 @@ func TestGetWriters(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		envelope, err := env.p.GetWriters(tt.dbName, tt.key)
+		envelope, err := env.p.GetWriters("user1", tt.dbName, tt.key)
 		require.NoError(t, err)
 		require.NotNil(t, envelope)
 		require.Equal(t, tt.expectedPayload, envelope)
@@ -781,7 +915,7 @@ func TestGetValuesReadByUser(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		envelope, err := env.p.GetValuesReadByUser(tt.user)
+		envelope, err := env.p.GetValuesReadByUser(tt.user, tt.user)
 		require.NoError(t, err)
 
 		require.NotNil(t, envelope)
@@ -854,7 +988,7 @@ func TestGetValuesWrittenByUser(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		payload, err := env.p.GetValuesWrittenByUser(tt.user)
+		payload, err := env.p.GetValuesWrittenByUser(tt.user, tt.user)
 		require.NoError(t, err)
 
 		require.NotNil(t, payload)
@@ -911,7 +1045,7 @@ func TestGetValuesDeletedByUser(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		payload, err := env.p.GetValuesDeletedByUser(tt.user)
+		payload, err := env.p.GetValuesDeletedByUser(tt.user, tt.user)
 		require.NoError(t, err)
 
 		require.NotNil(t, payload)
@@ -947,10 +1081,457 @@ func TestGetTxSubmittedByUser(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		payload, err := env.p.GetTxIDsSubmittedByUser(tt.user)
+		payload, err := env.p.GetTxIDsSubmittedByUser(tt.user, tt.user)
 		require.NoError(t, err)
 
 		require.NotNil(t, payload)
 		require.Equal(t, tt.expectedPayload, payload)
 	}
 }
+
+func TestGetTxsByAnnotation(t *testing.T) {
+	env := newProvenanceQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	block1TxsData := []*provenance.TxDataForProvenance{
+		{
+			IsValid:            true,
+			DBName:             "db1",
+			UserID:             "user1",
+			TxID:               "tx1",
+			OldVersionOfWrites: make(map[string]*types.Version),
+			Annotations:        map[string]string{"correlation-id": "c1"},
+		},
+		{
+			IsValid:     false,
+			TxID:        "tx2",
+			Annotations: map[string]string{"correlation-id": "c1"},
+		},
+	}
+	require.NoError(t, env.p.provenanceStore.Commit(1, 1001, block1TxsData))
+
+	block2TxsData := []*provenance.TxDataForProvenance{
+		{
+			IsValid:            true,
+			DBName:             "db1",
+			UserID:             "user2",
+			TxID:               "tx3",
+			OldVersionOfWrites: make(map[string]*types.Version),
+			Annotations:        map[string]string{"correlation-id": "c1"},
+		},
+		{
+			IsValid:            true,
+			DBName:             "db1",
+			UserID:             "user1",
+			TxID:               "tx4",
+			OldVersionOfWrites: make(map[string]*types.Version),
+			Annotations:        map[string]string{"correlation-id": "c1"},
+		},
+	}
+	require.NoError(t, env.p.provenanceStore.Commit(2, 1002, block2TxsData))
+
+	tests := []struct {
+		name            string
+		user            string
+		value           string
+		offset          uint64
+		limit           uint64
+		expectedPayload *types.GetTxsByAnnotationResponse
+	}{
+		{
+			name:  "auditor finds all txs",
+			user:  "auditor",
+			value: "c1",
+			expectedPayload: &types.GetTxsByAnnotationResponse{
+				Txs: []*types.AnnotatedTx{
+					{TxId: "tx1", BlockNumber: 1, TxIndex: 0, Timestamp: 1001},
+					{TxId: "tx2", BlockNumber: 1, TxIndex: 1, Timestamp: 1001},
+					{TxId: "tx3", BlockNumber: 2, TxIndex: 0, Timestamp: 1002},
+					{TxId: "tx4", BlockNumber: 2, TxIndex: 1, Timestamp: 1002},
+				},
+			},
+		},
+		{
+			name:  "admin finds all txs",
+			user:  "admin",
+			value: "c1",
+			expectedPayload: &types.GetTxsByAnnotationResponse{
+				Txs: []*types.AnnotatedTx{
+					{TxId: "tx1", BlockNumber: 1, TxIndex: 0, Timestamp: 1001},
+					{TxId: "tx2", BlockNumber: 1, TxIndex: 1, Timestamp: 1001},
+					{TxId: "tx3", BlockNumber: 2, TxIndex: 0, Timestamp: 1002},
+					{TxId: "tx4", BlockNumber: 2, TxIndex: 1, Timestamp: 1002},
+				},
+			},
+		},
+		{
+			name:  "user finds only own txs",
+			user:  "user1",
+			value: "c1",
+			expectedPayload: &types.GetTxsByAnnotationResponse{
+				Txs: []*types.AnnotatedTx{
+					{TxId: "tx1", BlockNumber: 1, TxIndex: 0, Timestamp: 1001},
+					{TxId: "tx4", BlockNumber: 2, TxIndex: 1, Timestamp: 1002},
+				},
+			},
+		},
+		{
+			name:  "first page",
+			user:  "auditor",
+			value: "c1",
+			limit: 3,
+			expectedPayload: &types.GetTxsByAnnotationResponse{
+				Txs: []*types.AnnotatedTx{
+					{TxId: "tx1", BlockNumber: 1, TxIndex: 0, Timestamp: 1001},
+					{TxId: "tx2", BlockNumber: 1, TxIndex: 1, Timestamp: 1001},
+					{TxId: "tx3", BlockNumber: 2, TxIndex: 0, Timestamp: 1002},
+				},
+				HasMore: true,
+			},
+		},
+		{
+			name:   "last page",
+			user:   "auditor",
+			value:  "c1",
+			offset: 3,
+			limit:  3,
+			expectedPayload: &types.GetTxsByAnnotationResponse{
+				Txs: []*types.AnnotatedTx{
+					{TxId: "tx4", BlockNumber: 2, TxIndex: 1, Timestamp: 1002},
+				},
+			},
+		},
+		{
+			name:   "page of own txs",
+			user:   "user1",
+			value:  "c1",
+			offset: 1,
+			limit:  1,
+			expectedPayload: &types.GetTxsByAnnotationResponse{
+				Txs: []*types.AnnotatedTx{
+					{TxId: "tx4", BlockNumber: 2, TxIndex: 1, Timestamp: 1002},
+				},
+			},
+		},
+		{
+			name:            "offset past the end",
+			user:            "auditor",
+			value:           "c1",
+			offset:          4,
+			expectedPayload: &types.GetTxsByAnnotationResponse{},
+		},
+		{
+			name:            "user without txs",
+			user:            "user3",
+			value:           "c1",
+			expectedPayload: &types.GetTxsByAnnotationResponse{},
+		},
+		{
+			name:            "annotation not found",
+			user:            "auditor",
+			value:           "c2",
+			expectedPayload: &types.GetTxsByAnnotationResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := env.p.GetTxsByAnnotation(tt.user, "correlation-id", tt.value, tt.offset, tt.limit)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedPayload, payload), "expected: %v, actual: %v", tt.expectedPayload, payload)
+		})
+	}
+}
+
+func TestProvenanceQueryAccessControl(t *testing.T) {
+	env := newProvenanceQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	setupProvenanceStore(t, env.p.provenanceStore)
+
+	// key4 was first written with an ACL that excludes user4 and then without an ACL
+	require.NoError(t, env.p.provenanceStore.Commit(7, 0, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  "db1",
+			UserID:  "user1",
+			TxID:    "tx7",
+			Writes: []*types.KVWithMetadata{
+				{
+					Key:   "key4",
+					Value: []byte("value1"),
+					Metadata: &types.Metadata{
+						AccessControl: &types.AccessControl{
+							ReadWriteUsers: map[string]bool{
+								"user1": true,
+							},
+						},
+						Version: &types.Version{
+							BlockNum: 7,
+							TxNum:    0,
+						},
+					},
+				},
+			},
+		},
+	}))
+	require.NoError(t, env.p.provenanceStore.Commit(8, 0, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  "db1",
+			UserID:  "user1",
+			TxID:    "tx8",
+			Writes: []*types.KVWithMetadata{
+				{
+					Key:   "key4",
+					Value: []byte("value2"),
+					Metadata: &types.Metadata{
+						Version: &types.Version{
+							BlockNum: 8,
+							TxNum:    0,
+						},
+					},
+				},
+			},
+			OldVersionOfWrites: map[string]*types.Version{
+				"key4": {
+					BlockNum: 7,
+					TxNum:    0,
+				},
+			},
+		},
+	}))
+	// key5 holds no ACL but none of the users can read db2
+	require.NoError(t, env.p.provenanceStore.Commit(9, 0, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  "db2",
+			UserID:  "user1",
+			TxID:    "tx9",
+			Writes: []*types.KVWithMetadata{
+				{
+					Key:   "key5",
+					Value: []byte("value1"),
+					Metadata: &types.Metadata{
+						Version: &types.Version{
+							BlockNum: 9,
+							TxNum:    0,
+						},
+					},
+				},
+			},
+		},
+	}))
+
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "db1",
+				},
+			},
+		},
+	}, 8))
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   "key2",
+					Value: []byte("value2"),
+					Metadata: &types.Metadata{
+						AccessControl: &types.AccessControl{
+							ReadWriteUsers: map[string]bool{
+								"user1": true,
+								"user2": true,
+							},
+						},
+						Version: &types.Version{
+							BlockNum: 3,
+							TxNum:    0,
+						},
+					},
+				},
+				{
+					Key:   "key4",
+					Value: []byte("value2"),
+					Metadata: &types.Metadata{
+						Version: &types.Version{
+							BlockNum: 8,
+							TxNum:    0,
+						},
+					},
+				},
+			},
+		},
+	}, 8))
+
+	t.Run("no read access on the database", func(t *testing.T) {
+		payload, err := env.p.GetValues("user3", "db1", "key1", 0, 0)
+		require.EqualError(t, err, "the user [user3] has no permission to read from database [db1]")
+		require.Nil(t, payload)
+
+		readers, err := env.p.GetReaders("user3", "db1", "key1")
+		require.EqualError(t, err, "the user [user3] has no permission to read from database [db1]")
+		require.Nil(t, readers)
+
+		// user1 does not see key5 even though it wrote it, as user1 cannot read db2, while the admin can read every
+		// database
+		written, err := env.p.GetValuesWrittenByUser("user1", "user1")
+		require.NoError(t, err)
+		for _, kv := range written.GetKVs() {
+			require.NotEqual(t, "key5", kv.GetKey())
+		}
+		require.Len(t, written.GetKVs(), 5)
+
+		written, err = env.p.GetValuesWrittenByUser("admin", "user1")
+		require.NoError(t, err)
+		require.Contains(t, written.GetKVs(), &types.KVWithMetadata{
+			Key:   "key5",
+			Value: []byte("value1"),
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: 9,
+					TxNum:    0,
+				},
+			},
+		})
+	})
+
+	t.Run("latest ACL excludes the querier", func(t *testing.T) {
+		payload, err := env.p.GetValues("user4", "db1", "key2", 0, 0)
+		require.EqualError(t, err, "the user [user4] has no permission to read the provenance of key [key2] from database [db1]")
+		require.Nil(t, payload)
+
+		writers, err := env.p.GetWriters("user4", "db1", "key2")
+		require.EqualError(t, err, "the user [user4] has no permission to read the provenance of key [key2] from database [db1]")
+		require.Nil(t, writers)
+
+		writers, err = env.p.GetWriters("user2", "db1", "key2")
+		require.NoError(t, err)
+		require.Equal(t, map[string]uint32{"user1": 1, "user2": 1}, writers.GetWrittenBy())
+	})
+
+	t.Run("historical ACL excludes the querier", func(t *testing.T) {
+		payload, err := env.p.GetValues("user4", "db1", "key4", 0, 0)
+		require.NoError(t, err)
+		require.Len(t, payload.GetValues(), 1)
+		require.Equal(t, []byte("value2"), payload.GetValues()[0].GetValue())
+
+		payload, err = env.p.GetValueAt("user4", "db1", "key4", &types.Version{BlockNum: 7, TxNum: 0})
+		require.NoError(t, err)
+		require.Nil(t, payload.GetValues())
+
+		payload, err = env.p.GetValues("user1", "db1", "key4", 0, 0)
+		require.NoError(t, err)
+		require.Len(t, payload.GetValues(), 2)
+
+		// pages count only the values the querier can read
+		payload, err = env.p.GetValues("user4", "db1", "key4", 0, 1)
+		require.NoError(t, err)
+		require.Len(t, payload.GetValues(), 1)
+		require.Equal(t, []byte("value2"), payload.GetValues()[0].GetValue())
+		require.False(t, payload.GetHasMore())
+
+		payload, err = env.p.GetValues("user1", "db1", "key4", 0, 1)
+		require.NoError(t, err)
+		require.Len(t, payload.GetValues(), 1)
+		require.True(t, payload.GetHasMore())
+	})
+
+	t.Run("provenance of another user", func(t *testing.T) {
+		payload, err := env.p.GetValuesWrittenByUser("user4", "user1")
+		require.EqualError(t, err, "the user [user4] has no permission to read the provenance of user [user1]")
+		require.Nil(t, payload)
+
+		txIDs, err := env.p.GetTxIDsSubmittedByUser("user4", "user2")
+		require.EqualError(t, err, "the user [user4] has no permission to read the provenance of user [user2]")
+		require.Nil(t, txIDs)
+
+		// the auditor does not see the values of key2 and the first value of key4 as their ACL excludes it, nor the
+		// value of key5 as it cannot read db2
+		payload, err = env.p.GetValuesWrittenByUser("auditor", "user1")
+		require.NoError(t, err)
+		for _, kv := range payload.GetKVs() {
+			require.NotEqual(t, "key2", kv.GetKey())
+		}
+		require.Len(t, payload.GetKVs(), 3)
+
+		txIDs, err = env.p.GetTxIDsSubmittedByUser("admin", "user2")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tx5", "tx50", "tx6"}, txIDs.GetTxIDs())
+	})
+
+	t.Run("system databases", func(t *testing.T) {
+		payload, err := env.p.GetValues("user4", worldstate.ConfigDBName, worldstate.ConfigKey, 0, 0)
+		require.EqualError(t, err, "the user [user4] has no permission to read the provenance of key [config] from database [_config]")
+		require.Nil(t, payload)
+
+		payload, err = env.p.GetValues("user4", worldstate.DatabasesDBName, "db1", 0, 0)
+		require.EqualError(t, err, "the user [user4] has no permission to read the provenance of key [db1] from database [_dbs]")
+		require.Nil(t, payload)
+
+		payload, err = env.p.GetMostRecentValueAtOrBelow("user4", worldstate.ConfigDBName, "node1", &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+		require.NotNil(t, payload)
+
+		payload, err = env.p.GetMostRecentValueAtOrBelow("user4", worldstate.UsersDBName, "user1", &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+		require.NotNil(t, payload)
+
+		payload, err = env.p.GetValues("admin", worldstate.ConfigDBName, worldstate.ConfigKey, 0, 0)
+		require.NoError(t, err)
+		require.NotNil(t, payload)
+	})
+}
+
+func TestProvenanceQueryAccessPolicy(t *testing.T) {
+	env := newProvenanceQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	setupProvenanceStore(t, env.p.provenanceStore)
+
+	config, err := proto.Marshal(&types.ClusterConfig{
+		AccessPolicy: &types.AccessPolicy{
+			Endpoints: []*types.EndpointPolicy{
+				{Group: types.EndpointPolicy_PROVENANCE, Privilege: types.EndpointPolicy_PROVENANCE_READ},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.ConfigDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:      worldstate.ConfigKey,
+					Value:    config,
+					Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}},
+				},
+			},
+		},
+	}, 2))
+
+	t.Run("no provenance read privilege", func(t *testing.T) {
+		// even the provenance of the querier itself requires the privilege
+		txIDs, err := env.p.GetTxIDsSubmittedByUser("user2", "user2")
+		require.EqualError(t, err, "the user [user2] has no permission to query the provenance")
+		require.Nil(t, txIDs)
+
+		values, err := env.p.GetValues("user1", "db1", "key1", 0, 0)
+		require.EqualError(t, err, "the user [user1] has no permission to query the provenance")
+		require.Nil(t, values)
+
+		txs, err := env.p.GetTxsByAnnotation("user1", "key", "value", 0, 0)
+		require.EqualError(t, err, "the user [user1] has no permission to query the provenance")
+		require.Nil(t, txs)
+	})
+
+	t.Run("provenance read privilege", func(t *testing.T) {
+		txIDs, err := env.p.GetTxIDsSubmittedByUser("auditor", "user2")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tx5", "tx50", "tx6"}, txIDs.TxIDs)
+
+		txIDs, err = env.p.GetTxIDsSubmittedByUser("admin", "user2")
+		require.NoError(t, err)
+		require.Equal(t, []string{"tx5", "tx50", "tx6"}, txIDs.TxIDs)
+	})
+}
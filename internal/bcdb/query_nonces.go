@@ -0,0 +1,126 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+)
+
+const (
+	// DefaultQueryTimestampWindow is the maximal difference between the timestamp of a query and the clock of the
+	// server when none is configured
+	DefaultQueryTimestampWindow = 5 * time.Minute
+	// DefaultMaxQueryNonces is the maximal number of nonces remembered at once when none is configured
+	DefaultMaxQueryNonces = 100000
+	// DefaultMaxQueryNoncesPerUser is the maximal number of nonces of a single user remembered at once when none is
+	// configured
+	DefaultMaxQueryNoncesPerUser = 10000
+)
+
+// queryNonces remembers the nonces of the signed queries of every user, so that a captured query cannot be replayed.
+// A nonce is remembered only until the timestamp of its query leaves the window, as the query is rejected by its
+// timestamp from then on. The nonces are held in memory and are lost when the server restarts; a query that is replayed
+// after a restart is still rejected once its timestamp leaves the window. Every user is limited to a share of the
+// nonces, such that a single user cannot exhaust them for all users.
+type queryNonces struct {
+	lock             sync.Mutex
+	window           time.Duration
+	maxNonces        int
+	maxNoncesPerUser int
+	// seen maps the user and nonce of a query to the time at which the timestamp of the query leaves the window
+	seen map[queryNonce]time.Time
+	// perUser counts the nonces of every user in seen
+	perUser   map[string]int
+	lastPrune time.Time
+	nowFn     func() time.Time
+}
+
+type queryNonce struct {
+	userID string
+	nonce  string
+}
+
+func newQueryNonces(conf config.QueryReplayProtectionConf) *queryNonces {
+	window := conf.TimestampWindow
+	if window == 0 {
+		window = DefaultQueryTimestampWindow
+	}
+	maxNonces := int(conf.MaxNonces)
+	if maxNonces == 0 {
+		maxNonces = DefaultMaxQueryNonces
+	}
+	maxNoncesPerUser := int(conf.MaxNoncesPerUser)
+	if maxNoncesPerUser == 0 {
+		maxNoncesPerUser = DefaultMaxQueryNoncesPerUser
+	}
+
+	return &queryNonces{
+		window:           window,
+		maxNonces:        maxNonces,
+		maxNoncesPerUser: maxNoncesPerUser,
+		seen:             make(map[queryNonce]time.Time),
+		perUser:          make(map[string]int),
+		nowFn:            time.Now,
+	}
+}
+
+// check records the nonce of a query of a user, whose timestamp is in unix nanoseconds. It returns an error if the
+// timestamp is outside the window, or if the user already used the nonce within the window. It returns a
+// ResourceExhaustedError if the nonces of the user, or of all users, reached their limit.
+func (n *queryNonces) check(userID, nonce string, timestamp int64) error {
+	now := n.nowFn()
+	ts := time.Unix(0, timestamp)
+	if skew := now.Sub(ts); skew > n.window || skew < -n.window {
+		return &ierrors.PermissionErr{
+			ErrMsg: fmt.Sprintf("the timestamp of the query differs from the clock of the server by more than %s", n.window),
+		}
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if len(n.seen) >= n.maxNonces || n.perUser[userID] >= n.maxNoncesPerUser || now.Sub(n.lastPrune) > n.window {
+		n.prune(now)
+	}
+
+	key := queryNonce{userID: userID, nonce: nonce}
+	if expiry, ok := n.seen[key]; ok && !now.After(expiry) {
+		return &ierrors.PermissionErr{
+			ErrMsg: "the nonce [" + nonce + "] was already used by the user [" + userID + "]",
+		}
+	}
+	if n.perUser[userID] >= n.maxNoncesPerUser {
+		return &ierrors.ResourceExhaustedError{
+			ErrMsg: fmt.Sprintf("the number of recent query nonces of the user [%s] reached the limit of %d", userID, n.maxNoncesPerUser),
+		}
+	}
+	if len(n.seen) >= n.maxNonces {
+		return &ierrors.ResourceExhaustedError{
+			ErrMsg: fmt.Sprintf("the number of recent query nonces reached the limit of %d", n.maxNonces),
+		}
+	}
+	if _, ok := n.seen[key]; !ok {
+		n.perUser[userID]++
+	}
+	n.seen[key] = ts.Add(n.window)
+
+	return nil
+}
+
+func (n *queryNonces) prune(now time.Time) {
+	for key, expiry := range n.seen {
+		if now.After(expiry) {
+			delete(n.seen, key)
+			if n.perUser[key.userID]--; n.perUser[key.userID] == 0 {
+				delete(n.perUser, key.userID)
+			}
+		}
+	}
+	n.lastPrune = now
+}
@@ -0,0 +1,93 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryNonces(t *testing.T) {
+	now := time.Unix(1000, 0)
+	newNonces := func(maxNonces uint32) *queryNonces {
+		n := newQueryNonces(config.QueryReplayProtectionConf{
+			TimestampWindow: time.Minute,
+			MaxNonces:       maxNonces,
+		})
+		n.nowFn = func() time.Time { return now }
+		return n
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		n := newQueryNonces(config.QueryReplayProtectionConf{})
+		require.Equal(t, DefaultQueryTimestampWindow, n.window)
+		require.Equal(t, DefaultMaxQueryNonces, n.maxNonces)
+		require.Equal(t, DefaultMaxQueryNoncesPerUser, n.maxNoncesPerUser)
+	})
+
+	t.Run("a nonce is accepted once per user", func(t *testing.T) {
+		n := newNonces(0)
+		require.NoError(t, n.check("alice", "n1", now.UnixNano()))
+		require.NoError(t, n.check("bob", "n1", now.UnixNano()))
+		require.NoError(t, n.check("alice", "n2", now.UnixNano()))
+
+		err := n.check("alice", "n1", now.Add(time.Second).UnixNano())
+		require.EqualError(t, err, "the nonce [n1] was already used by the user [alice]")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+	})
+
+	t.Run("a timestamp outside the window is not accepted", func(t *testing.T) {
+		n := newNonces(0)
+		for _, ts := range []time.Time{now.Add(-time.Minute - time.Second), now.Add(time.Minute + time.Second)} {
+			err := n.check("alice", "n1", ts.UnixNano())
+			require.EqualError(t, err, "the timestamp of the query differs from the clock of the server by more than 1m0s")
+			require.IsType(t, &ierrors.PermissionErr{}, err)
+		}
+		require.Empty(t, n.seen)
+	})
+
+	t.Run("a nonce is forgotten once its timestamp leaves the window", func(t *testing.T) {
+		n := newNonces(0)
+		require.NoError(t, n.check("alice", "n1", now.UnixNano()))
+		require.Len(t, n.seen, 1)
+
+		n.nowFn = func() time.Time { return now.Add(2 * time.Minute) }
+		require.NoError(t, n.check("alice", "n2", now.Add(2*time.Minute).UnixNano()))
+		require.Len(t, n.seen, 1)
+	})
+
+	t.Run("the number of nonces is limited", func(t *testing.T) {
+		n := newNonces(2)
+		require.NoError(t, n.check("alice", "n1", now.UnixNano()))
+		require.NoError(t, n.check("alice", "n2", now.UnixNano()))
+
+		err := n.check("alice", "n3", now.UnixNano())
+		require.EqualError(t, err, "the number of recent query nonces reached the limit of 2")
+		require.IsType(t, &ierrors.ResourceExhaustedError{}, err)
+
+		n.nowFn = func() time.Time { return now.Add(time.Minute + time.Second) }
+		require.NoError(t, n.check("alice", "n3", now.Add(time.Minute).UnixNano()))
+	})
+
+	t.Run("the number of nonces of a user is limited", func(t *testing.T) {
+		n := newNonces(0)
+		n.maxNoncesPerUser = 2
+		require.NoError(t, n.check("alice", "n1", now.UnixNano()))
+		require.NoError(t, n.check("alice", "n2", now.UnixNano()))
+
+		err := n.check("alice", "n3", now.UnixNano())
+		require.EqualError(t, err, "the number of recent query nonces of the user [alice] reached the limit of 2")
+		require.IsType(t, &ierrors.ResourceExhaustedError{}, err)
+
+		// the other users are not affected
+		require.NoError(t, n.check("bob", "n1", now.UnixNano()))
+
+		n.nowFn = func() time.Time { return now.Add(time.Minute + time.Second) }
+		require.NoError(t, n.check("alice", "n3", now.Add(time.Minute).UnixNano()))
+		require.Equal(t, map[string]int{"alice": 1}, n.perUser)
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// receiptCacheSize is the number of the receipts held by the receipt cache
+const receiptCacheSize = 4096
+
+// receiptCache holds the receipts of the transactions that were recently queried, along with their proofs of
+// inclusion, as reconciliation jobs query the receipts of the same transactions repeatedly. A receipt never changes
+// once its transaction is committed, hence the cache is never invalidated; the oldest receipt is evicted when the
+// cache is full. The cached receipts are shared by the responses, and must not be modified.
+type receiptCache struct {
+	lock     sync.Mutex
+	receipts map[string]*types.TxReceiptWithProof
+	order    []string
+	next     int
+}
+
+func newReceiptCache(size int) *receiptCache {
+	return &receiptCache{
+		receipts: make(map[string]*types.TxReceiptWithProof, size),
+		order:    make([]string, size),
+	}
+}
+
+func (c *receiptCache) get(txID string) *types.TxReceiptWithProof {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.receipts[txID]
+}
+
+func (c *receiptCache) put(receipt *types.TxReceiptWithProof) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.receipts[receipt.TxId]; ok {
+		return
+	}
+
+	if evicted := c.order[c.next]; evicted != "" {
+		delete(c.receipts, evicted)
+	}
+	c.order[c.next] = receipt.TxId
+	c.next = (c.next + 1) % len(c.order)
+	c.receipts[receipt.TxId] = receipt
+}
@@ -0,0 +1,125 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MaxRejectedTxs is the maximal number of rejected transactions held in the dead-letter queue
+	MaxRejectedTxs = 1000
+)
+
+var rejectedTxsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "orion",
+	Subsystem: "tx",
+	Name:      "rejected_total",
+	Help:      "The number of transactions rejected before ordering, by category.",
+}, []string{"node", "category"})
+
+func init() {
+	prometheus.MustRegister(rejectedTxsCounter)
+}
+
+// rejectedTxs is the dead-letter queue of the transactions that the node rejected before ordering, which would
+// otherwise vanish without a trace, as they appear in no block. It holds the last MaxRejectedTxs rejections in memory,
+// and drops the oldest ones first. The queue is lost when the node restarts.
+type rejectedTxs struct {
+	nodeID string
+	lock   sync.Mutex
+	txs    []*types.RejectedTx
+	// next is the index in txs of the slot of the next rejection, once txs is full
+	next   int
+	nowFn  func() time.Time
+	logger *logger.SugarLogger
+}
+
+func newRejectedTxs(nodeID string, logger *logger.SugarLogger) *rejectedTxs {
+	return &rejectedTxs{
+		nodeID: nodeID,
+		nowFn:  time.Now,
+		logger: logger,
+	}
+}
+
+// add records a rejected transaction, replacing the oldest one if the queue is full.
+func (r *rejectedTxs) add(txID, userID string, category types.RejectedTx_Category, reason string) {
+	tx := &types.RejectedTx{
+		TxId:       txID,
+		UserId:     userID,
+		Category:   category,
+		Reason:     reason,
+		RejectedAt: r.nowFn().UnixNano(),
+	}
+
+	rejectedTxsCounter.WithLabelValues(r.nodeID, strings.ToLower(category.String())).Inc()
+	r.logger.Debugf("transaction [%s] of user [%s] is rejected, category: %s, reason: %s", txID, userID, category, reason)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.txs) < MaxRejectedTxs {
+		r.txs = append(r.txs, tx)
+		return
+	}
+	r.txs[r.next] = tx
+	r.next = (r.next + 1) % MaxRejectedTxs
+}
+
+// list returns copies of the rejected transactions, oldest first.
+func (r *rejectedTxs) list() []*types.RejectedTx {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	txs := make([]*types.RejectedTx, 0, len(r.txs))
+	for i := range r.txs {
+		txs = append(txs, proto.Clone(r.txs[(r.next+i)%len(r.txs)]).(*types.RejectedTx))
+	}
+	return txs
+}
+
+// rejectionCategory returns the category of the rejection of a transaction submitted to the transaction processor,
+// and false if the error is not a rejection, e.g., a timeout of a sync submission, or a redirection to the leader.
+func rejectionCategory(err error) (types.RejectedTx_Category, bool) {
+	switch err.(type) {
+	case *ierrors.BadRequestError:
+		return types.RejectedTx_INVALID, true
+	case *ierrors.TxTooLargeError:
+		return types.RejectedTx_TOO_LARGE, true
+	case *ierrors.DuplicateTxIDError:
+		return types.RejectedTx_DUPLICATE_TX_ID, true
+	case *ierrors.ResourceExhaustedError:
+		return types.RejectedTx_QUEUE_FULL, true
+	case *ierrors.UnavailableError:
+		return types.RejectedTx_UNAVAILABLE, true
+	default:
+		return 0, false
+	}
+}
+
+// txSubmitter returns the TxId and the submitting user of a transaction envelope. The submitting users of a data
+// transaction are its must sign users.
+func txSubmitter(tx interface{}) (txID string, userID string) {
+	switch tx := tx.(type) {
+	case *types.DataTxEnvelope:
+		return tx.GetPayload().GetTxId(), strings.Join(tx.GetPayload().GetMustSignUserIds(), ",")
+	case *types.UserAdministrationTxEnvelope:
+		return tx.GetPayload().GetTxId(), tx.GetPayload().GetUserId()
+	case *types.DBAdministrationTxEnvelope:
+		return tx.GetPayload().GetTxId(), tx.GetPayload().GetUserId()
+	case *types.ConfigTxEnvelope:
+		return tx.GetPayload().GetTxId(), tx.GetPayload().GetUserId()
+	default:
+		return "", ""
+	}
+}
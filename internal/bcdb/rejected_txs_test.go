@@ -0,0 +1,213 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejectedTxs(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	newTestRejectedTxs := func() *rejectedTxs {
+		r := newRejectedTxs("node1", lg)
+		r.nowFn = func() time.Time { return now }
+		return r
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		r := newTestRejectedTxs()
+		require.Empty(t, r.list())
+	})
+
+	t.Run("oldest first", func(t *testing.T) {
+		r := newTestRejectedTxs()
+		r.add("tx1", "alice", types.RejectedTx_UNAUTHENTICATED, "signature verification failed")
+		r.add("", "", types.RejectedTx_MALFORMED, "unexpected EOF")
+
+		require.Equal(t, []*types.RejectedTx{
+			{
+				TxId:       "tx1",
+				UserId:     "alice",
+				Category:   types.RejectedTx_UNAUTHENTICATED,
+				Reason:     "signature verification failed",
+				RejectedAt: now.UnixNano(),
+			},
+			{
+				Category:   types.RejectedTx_MALFORMED,
+				Reason:     "unexpected EOF",
+				RejectedAt: now.UnixNano(),
+			},
+		}, r.list())
+
+		// the listed transactions are copies
+		r.list()[0].TxId = "tx2"
+		require.Equal(t, "tx1", r.list()[0].TxId)
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		r := newTestRejectedTxs()
+		for i := 0; i < MaxRejectedTxs+5; i++ {
+			r.add(fmt.Sprintf("tx%d", i), "alice", types.RejectedTx_QUEUE_FULL, "transaction queue is full")
+		}
+
+		txs := r.list()
+		require.Len(t, txs, MaxRejectedTxs)
+		for i, tx := range txs {
+			require.Equal(t, fmt.Sprintf("tx%d", i+5), tx.TxId)
+		}
+	})
+}
+
+func TestSubmitTransactionRecordsRejections(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	quarantine, err := blockprocessor.NewQuarantine("node1", filepath.Join(t.TempDir(), "quarantine"), lg)
+	require.NoError(t, err)
+
+	txEnv := &types.UserAdministrationTxEnvelope{
+		Payload: &types.UserAdministrationTx{
+			UserId: "alice",
+			TxId:   "tx1",
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		err              error
+		expectedRejected []*types.RejectedTx
+	}{
+		{
+			name: "duplicate TxId",
+			err:  &ierrors.DuplicateTxIDError{TxID: "tx1"},
+			expectedRejected: []*types.RejectedTx{
+				{
+					TxId:     "tx1",
+					UserId:   "alice",
+					Category: types.RejectedTx_DUPLICATE_TX_ID,
+					Reason:   "the transaction has a duplicate txID [tx1]",
+				},
+			},
+		},
+		{
+			name: "queue is full",
+			err:  &ierrors.ResourceExhaustedError{ErrMsg: "transaction queue is full"},
+			expectedRejected: []*types.RejectedTx{
+				{
+					TxId:     "tx1",
+					UserId:   "alice",
+					Category: types.RejectedTx_QUEUE_FULL,
+					Reason:   "transaction queue is full",
+				},
+			},
+		},
+		{
+			name: "invalid transaction",
+			err:  &ierrors.BadRequestError{ErrMsg: "bad TxId"},
+			expectedRejected: []*types.RejectedTx{
+				{
+					TxId:     "tx1",
+					UserId:   "alice",
+					Category: types.RejectedTx_INVALID,
+					Reason:   "bad TxId",
+				},
+			},
+		},
+		{
+			name: "transaction too large",
+			err:  &ierrors.TxTooLargeError{ErrMsg: "transaction size [5000 bytes] exceeds the maximal transaction size [2560 bytes] allowed by the maximal block size"},
+			expectedRejected: []*types.RejectedTx{
+				{
+					TxId:     "tx1",
+					UserId:   "alice",
+					Category: types.RejectedTx_TOO_LARGE,
+					Reason:   "transaction size [5000 bytes] exceeds the maximal transaction size [2560 bytes] allowed by the maximal block size",
+				},
+			},
+		},
+		{
+			name: "timeout is not a rejection",
+			err:  &ierrors.TimeoutErr{ErrMsg: "timeout has occurred after 1s while waiting for the transaction receipt"},
+		},
+		{
+			name: "not a leader is not a rejection",
+			err:  &ierrors.NotLeaderError{LeaderID: 2, LeaderHostPort: "node2:6001"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			txProcMock := &mocks.TxProcessor{}
+			txProcMock.On("CommitHalted").Return(nil)
+			txProcMock.On("SubmitTransaction", mock.Anything, mock.Anything).Return(nil, tt.err)
+			d := &db{
+				nodeID:      "node1",
+				txProcessor: txProcMock,
+				quarantine:  quarantine,
+				rejectedTxs: newRejectedTxs("node1", lg),
+				logger:      lg,
+			}
+
+			_, err := d.SubmitTransaction(txEnv, 0)
+			require.EqualError(t, err, tt.err.Error())
+
+			rejected := d.rejectedTxs.list()
+			for _, tx := range rejected {
+				require.NotZero(t, tx.RejectedAt)
+				tx.RejectedAt = 0
+			}
+			require.Equal(t, len(tt.expectedRejected), len(rejected))
+			for i := range rejected {
+				require.Equal(t, tt.expectedRejected[i], rejected[i])
+			}
+		})
+	}
+
+	t.Run("the commit of blocks is halted", func(t *testing.T) {
+		txProcMock := &mocks.TxProcessor{}
+		txProcMock.On("CommitHalted").Return(&blockprocessor.StateTrieError{BlockNum: 5, Attempts: 3, Err: errors.New("trie store I/O error")})
+		d := &db{
+			nodeID:      "node1",
+			txProcessor: txProcMock,
+			quarantine:  quarantine,
+			rejectedTxs: newRejectedTxs("node1", lg),
+			logger:      lg,
+		}
+
+		expectedErr := "the node halted the commit of blocks until it restarts: failed to update the state trie with block 5 in 3 attempt(s): trie store I/O error"
+		require.EqualError(t, d.Ready(), expectedErr)
+		_, err := d.SubmitTransaction(txEnv, 0)
+		require.EqualError(t, err, expectedErr)
+		require.IsType(t, &ierrors.UnavailableError{}, err)
+		txProcMock.AssertNotCalled(t, "SubmitTransaction", mock.Anything, mock.Anything)
+
+		rejected := d.rejectedTxs.list()
+		require.Len(t, rejected, 1)
+		require.Equal(t, types.RejectedTx_UNAVAILABLE, rejected[0].Category)
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSessionTokenTTL is the lifetime of a session token when no TTL is requested
+	DefaultSessionTokenTTL = 15 * time.Minute
+	// MaxSessionTokenTTL is the maximal lifetime of a session token
+	MaxSessionTokenTTL = time.Hour
+	// MaxSessionLoginClockSkew is the maximal difference between the timestamp of a login and the clock of the server
+	MaxSessionLoginClockSkew = 5 * time.Minute
+)
+
+// sessionTokens issues and verifies the session tokens of users. A token carries the user and its expiry, and is
+// authenticated by an HMAC, which is far cheaper to verify than the signature of a query. The HMAC key is generated
+// when the server starts and is never persisted: a token is accepted only by the node that issued it, and only until
+// the node restarts.
+type sessionTokens struct {
+	key             []byte
+	identityQuerier *identity.Querier
+	nowFn           func() time.Time
+}
+
+// sessionClaims are the claims of a session token, which is the base64 encoding of the JSON claims followed by the
+// base64 encoding of their HMAC, separated by a dot
+type sessionClaims struct {
+	UserID    string `json:"user_id"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+func newSessionTokens(db worldstate.DB) (*sessionTokens, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "error while generating the session token key")
+	}
+
+	return &sessionTokens{
+		key:             key,
+		identityQuerier: identity.NewQuerier(db),
+		nowFn:           time.Now,
+	}, nil
+}
+
+// issue returns a session token of a user. The signature of the login query is verified by the caller.
+func (s *sessionTokens) issue(userID string, timestamp int64, ttl time.Duration) (*types.SessionTokenResponse, error) {
+	if ttl == 0 {
+		ttl = DefaultSessionTokenTTL
+	}
+	if ttl < 0 || ttl > MaxSessionTokenTTL {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the TTL [%s] of a session token must be positive and up to %s", ttl, MaxSessionTokenTTL),
+		}
+	}
+
+	now := s.nowFn()
+	if skew := now.Sub(time.Unix(0, timestamp)); skew > MaxSessionLoginClockSkew || skew < -MaxSessionLoginClockSkew {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the timestamp of the login differs from the clock of the server by more than %s", MaxSessionLoginClockSkew),
+		}
+	}
+
+	claims := &sessionClaims{
+		UserID:    userID,
+		ExpiresAt: now.Add(ttl).UnixNano(),
+	}
+	claimsBytes, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.SessionTokenResponse{
+		Token:     base64.RawURLEncoding.EncodeToString(claimsBytes) + "." + base64.RawURLEncoding.EncodeToString(s.mac(claimsBytes)),
+		UserId:    userID,
+		ExpiresAt: claims.ExpiresAt,
+	}, nil
+}
+
+// verify returns the user of a session token. A token of a user who was disabled or deleted after the login is not
+// accepted.
+func (s *sessionTokens) verify(token string) (string, error) {
+	invalid := &ierrors.PermissionErr{ErrMsg: "the session token is invalid"}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return "", invalid
+	}
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", invalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(mac, s.mac(claimsBytes)) {
+		return "", invalid
+	}
+
+	claims := &sessionClaims{}
+	if err := json.Unmarshal(claimsBytes, claims); err != nil {
+		return "", invalid
+	}
+	if !s.nowFn().Before(time.Unix(0, claims.ExpiresAt)) {
+		return "", &ierrors.PermissionErr{ErrMsg: "the session token of the user [" + claims.UserID + "] has expired"}
+	}
+
+	user, _, err := s.identityQuerier.GetUser(claims.UserID)
+	if err != nil {
+		if _, ok := err.(*identity.NotFoundErr); ok {
+			return "", &ierrors.PermissionErr{ErrMsg: err.Error()}
+		}
+		return "", err
+	}
+	if user.GetDisabled() {
+		return "", &ierrors.PermissionErr{ErrMsg: identity.NewDisabledErr(claims.UserID).Error()}
+	}
+
+	return claims.UserID, nil
+}
+
+func (s *sessionTokens) mac(claims []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write(claims)
+	return h.Sum(nil)
+}
@@ -0,0 +1,135 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionTokens(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	commitUser := func(user *types.User, blockNum uint64) {
+		u, err := proto.Marshal(user)
+		require.NoError(t, err)
+		require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   string(identity.UserNamespace) + user.Id,
+						Value: u,
+					},
+				},
+			},
+		}, blockNum))
+	}
+	commitUser(&types.User{Id: "alice"}, 1)
+	commitUser(&types.User{Id: "bob"}, 2)
+
+	s, err := newSessionTokens(env.db)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	s.nowFn = func() time.Time { return now }
+
+	t.Run("issue and verify a token", func(t *testing.T) {
+		token, err := s.issue("alice", now.Add(-time.Minute).UnixNano(), 0)
+		require.NoError(t, err)
+		require.Equal(t, "alice", token.UserId)
+		require.Equal(t, now.Add(DefaultSessionTokenTTL).UnixNano(), token.ExpiresAt)
+
+		userID, err := s.verify(token.Token)
+		require.NoError(t, err)
+		require.Equal(t, "alice", userID)
+	})
+
+	t.Run("a token is not accepted once it expires", func(t *testing.T) {
+		token, err := s.issue("alice", now.UnixNano(), time.Minute)
+		require.NoError(t, err)
+
+		s.nowFn = func() time.Time { return now.Add(time.Minute) }
+		defer func() { s.nowFn = func() time.Time { return now } }()
+
+		userID, err := s.verify(token.Token)
+		require.EqualError(t, err, "the session token of the user [alice] has expired")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+		require.Empty(t, userID)
+	})
+
+	t.Run("a token is not accepted by another node", func(t *testing.T) {
+		token, err := s.issue("alice", now.UnixNano(), 0)
+		require.NoError(t, err)
+
+		other, err := newSessionTokens(env.db)
+		require.NoError(t, err)
+		other.nowFn = s.nowFn
+
+		_, err = other.verify(token.Token)
+		require.EqualError(t, err, "the session token is invalid")
+	})
+
+	t.Run("a tampered token is not accepted", func(t *testing.T) {
+		aliceToken, err := s.issue("alice", now.UnixNano(), 0)
+		require.NoError(t, err)
+		bobToken, err := s.issue("bob", now.UnixNano(), 0)
+		require.NoError(t, err)
+
+		aliceParts := strings.Split(aliceToken.Token, ".")
+		bobParts := strings.Split(bobToken.Token, ".")
+		for _, token := range []string{
+			bobParts[0] + "." + aliceParts[1],
+			aliceParts[0],
+			aliceToken.Token + ".",
+			"!" + aliceToken.Token,
+		} {
+			_, err = s.verify(token)
+			require.EqualError(t, err, "the session token is invalid")
+			require.IsType(t, &ierrors.PermissionErr{}, err)
+		}
+	})
+
+	t.Run("a token of a disabled user is not accepted", func(t *testing.T) {
+		token, err := s.issue("bob", now.UnixNano(), 0)
+		require.NoError(t, err)
+		commitUser(&types.User{Id: "bob", Disabled: true}, 3)
+
+		_, err = s.verify(token.Token)
+		require.EqualError(t, err, identity.NewDisabledErr("bob").Error())
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+	})
+
+	t.Run("a token of a user who does not exist is not accepted", func(t *testing.T) {
+		token, err := s.issue("charlie", now.UnixNano(), 0)
+		require.NoError(t, err)
+
+		_, err = s.verify(token.Token)
+		require.EqualError(t, err, "the user [charlie] does not exist")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+	})
+
+	t.Run("invalid logins", func(t *testing.T) {
+		token, err := s.issue("alice", now.UnixNano(), MaxSessionTokenTTL+time.Second)
+		require.EqualError(t, err, "the TTL [1h0m1s] of a session token must be positive and up to 1h0m0s")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+		require.Nil(t, token)
+
+		for _, timestamp := range []time.Time{
+			now.Add(-MaxSessionLoginClockSkew - time.Second),
+			now.Add(MaxSessionLoginClockSkew + time.Second),
+		} {
+			token, err = s.issue("alice", timestamp.UnixNano(), 0)
+			require.EqualError(t, err, "the timestamp of the login differs from the clock of the server by more than 5m0s")
+			require.IsType(t, &ierrors.BadRequestError{}, err)
+			require.Nil(t, token)
+		}
+	})
+}
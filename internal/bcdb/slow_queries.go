@@ -0,0 +1,93 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bcdb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultMaxSlowQueries is the maximal number of slow queries held in the slow query log, unless configured
+	// otherwise
+	DefaultMaxSlowQueries = 100
+)
+
+var slowQueriesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "orion",
+	Subsystem: "query",
+	Name:      "slow_total",
+	Help:      "The number of data queries whose execution took more than the slow query threshold, by database.",
+}, []string{"node", "database"})
+
+func init() {
+	prometheus.MustRegister(slowQueriesCounter)
+}
+
+// slowQueryLog holds the last data queries whose execution took more than the threshold, together with their plan and
+// scan statistics, so that the queries which are not served well by the indexes can be found. The oldest queries are
+// dropped first, and the log is lost when the node restarts.
+type slowQueryLog struct {
+	nodeID string
+	// threshold is the execution time above which a query is logged; zero disables the log
+	threshold  time.Duration
+	maxQueries int
+	lock       sync.Mutex
+	queries    []*types.SlowQuery
+	// next is the index in queries of the slot of the next slow query, once queries is full
+	next   int
+	logger *logger.SugarLogger
+}
+
+func newSlowQueryLog(nodeID string, threshold time.Duration, maxQueries uint32, logger *logger.SugarLogger) *slowQueryLog {
+	if maxQueries == 0 {
+		maxQueries = DefaultMaxSlowQueries
+	}
+
+	return &slowQueryLog{
+		nodeID:     nodeID,
+		threshold:  threshold,
+		maxQueries: int(maxQueries),
+		logger:     logger,
+	}
+}
+
+// isSlow returns true if a query that executed for the given duration must be logged
+func (l *slowQueryLog) isSlow(duration time.Duration) bool {
+	return l.threshold > 0 && duration > l.threshold
+}
+
+// add records a slow query, replacing the oldest one if the log is full.
+func (l *slowQueryLog) add(query *types.SlowQuery) {
+	slowQueriesCounter.WithLabelValues(l.nodeID, query.DbName).Inc()
+	l.logger.Infof("query of user [%s] on database [%s] took %s, plan: %v, keys read: %d, results: %d",
+		query.UserId, query.DbName, time.Duration(query.Duration), query.Plan, query.KeysRead, query.Results)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if len(l.queries) < l.maxQueries {
+		l.queries = append(l.queries, query)
+		return
+	}
+	l.queries[l.next] = query
+	l.next = (l.next + 1) % l.maxQueries
+}
+
+// list returns copies of the slow queries, oldest first.
+func (l *slowQueryLog) list() []*types.SlowQuery {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	queries := make([]*types.SlowQuery, 0, len(l.queries))
+	for i := range l.queries {
+		queries = append(queries, proto.Clone(l.queries[(l.next+i)%len(l.queries)]).(*types.SlowQuery))
+	}
+	return queries
+}
@@ -0,0 +1,110 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlowQueryLog(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	t.Run("threshold", func(t *testing.T) {
+		l := newSlowQueryLog("node1", time.Second, 0, lg)
+		require.Equal(t, DefaultMaxSlowQueries, l.maxQueries)
+		require.False(t, l.isSlow(time.Second))
+		require.True(t, l.isSlow(time.Second+1))
+
+		l = newSlowQueryLog("node1", 0, 0, lg)
+		require.False(t, l.isSlow(time.Hour))
+	})
+
+	t.Run("bounded and oldest first", func(t *testing.T) {
+		l := newSlowQueryLog("node1", time.Second, 3, lg)
+		require.Empty(t, l.list())
+
+		for i := 0; i < 5; i++ {
+			l.add(&types.SlowQuery{
+				UserId:   "alice",
+				DbName:   "db1",
+				Query:    fmt.Sprintf(`{"selector":{"attr1":{"$eq":"%d"}}}`, i),
+				Duration: int64(2 * time.Second),
+			})
+		}
+
+		queries := l.list()
+		require.Len(t, queries, 3)
+		for i, q := range queries {
+			require.Equal(t, fmt.Sprintf(`{"selector":{"attr1":{"$eq":"%d"}}}`, i+2), q.Query)
+		}
+
+		// the listed queries are copies
+		l.list()[0].UserId = "bob"
+		require.Equal(t, "alice", l.list()[0].UserId)
+	})
+}
+
+func TestExecuteQueryRecordsSlowQueries(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	setupAttr1IndexedDB(t, env.db)
+
+	query := []byte(`{"selector":{"attr1":{"$neq":["c"]}}}`)
+
+	// no query is logged with the default threshold
+	_, err := env.q.executeJSONQuery(context.Background(), "db1", "alice", query)
+	require.NoError(t, err)
+	require.Empty(t, env.q.slowQueries.list())
+
+	env.q.slowQueries = newSlowQueryLog("test-node-id1", time.Nanosecond, 0, env.q.logger)
+	start := time.Now()
+	response, err := env.q.executeJSONQuery(context.Background(), "db1", "alice", query)
+	require.NoError(t, err)
+	require.Len(t, response.KVs, 1)
+
+	slowQueries := env.q.slowQueries.list()
+	require.Len(t, slowQueries, 1)
+	slowQuery := slowQueries[0]
+	require.GreaterOrEqual(t, slowQuery.ExecutedAt, start.UnixNano())
+	require.Greater(t, slowQuery.Duration, int64(0))
+	slowQuery.ExecutedAt = 0
+	slowQuery.Duration = 0
+
+	expected := &types.SlowQuery{
+		UserId: "alice",
+		DbName: "db1",
+		Query:  string(query),
+		Plan: &types.QueryPlan{
+			Operator: constants.QueryOpAnd,
+			Scans: []*types.IndexScan{
+				{
+					Attribute:      "attr1",
+					Type:           types.IndexAttributeType_STRING,
+					Kind:           types.IndexScan_FULL,
+					Operators:      []string{constants.QueryOpNotEqual},
+					EntriesScanned: 2,
+				},
+			},
+		},
+		// the key that alice cannot read is filtered on its index entry
+		KeysRead: 1,
+		Results:  1,
+	}
+	require.True(t, proto.Equal(expected, slowQuery), "expected: %v, actual: %v", expected, slowQuery)
+}
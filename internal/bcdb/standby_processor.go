@@ -0,0 +1,307 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	internalerror "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/standby"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
+	"github.com/hyperledger-labs/orion-server/internal/witness"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// standbyProcessor replaces the transactionProcessor on a node of a standby cluster. It follows the ledger of the
+// primary cluster like a witness, and reports the replication lag. It accepts a single transaction: the config tx that
+// promotes the standby cluster to active. Once promoted, the node stops following the primary cluster, and must be
+// restarted with the standby mode disabled, to take part in the consensus of the promoted cluster.
+type standbyProcessor struct {
+	nodeID               string
+	blockOneQueueBarrier *queue.OneQueueBarrier
+	blockProcessor       *blockprocessor.BlockProcessor
+	configTxValidator    *txvalidation.ConfigTxValidator
+	blockStore           *blockstore.Store
+	witness              *witness.Witness
+	lagMonitor           *standby.LagMonitor
+	logger               *logger.SugarLogger
+
+	mutex     sync.Mutex
+	following bool
+	promoted  bool
+}
+
+func newStandbyProcessor(conf *txProcessorConfig) (*standbyProcessor, error) {
+	p := &standbyProcessor{}
+
+	localConfig := conf.config.LocalConfig
+
+	p.nodeID = localConfig.Server.Identity.ID
+	p.logger = conf.logger
+	p.blockStore = conf.blockStore
+	p.blockOneQueueBarrier = queue.NewOneQueueBarrier(conf.logger)
+
+	txValidator := txvalidation.NewValidator(
+		&txvalidation.Config{
+			DB:                  conf.db,
+			DBUsage:             conf.blockStore,
+			ConsensusAlgorithms: orderingAlgorithms(conf.orderingSvcs),
+			Logger:              conf.logger,
+		},
+	)
+	p.configTxValidator = txValidator.ConfigValidator()
+
+	p.blockProcessor = blockprocessor.New(
+		&blockprocessor.Config{
+			NodeID:               p.nodeID,
+			BlockOneQueueBarrier: p.blockOneQueueBarrier,
+			BlockStore:           conf.blockStore,
+			ProvenanceStore:      conf.provenanceStore,
+			StateTrieStore:       conf.stateTrieStore,
+			Outbox:               conf.outbox,
+			Erasure:              conf.erasure,
+			IdentityCache:        conf.identityCache,
+			BlobStore:            conf.blobStore,
+			DB:                   conf.db,
+			TxValidator:          txValidator,
+			Quarantine:           conf.quarantine,
+			Logger:               conf.logger,
+		},
+	)
+
+	ledgerHeight, err := conf.blockStore.Height()
+	if err != nil {
+		return nil, err
+	}
+	joinBlock := conf.config.JoinBlock
+	if ledgerHeight == 0 {
+		if joinBlock == nil {
+			return nil, errors.New("a standby node must be bootstrapped with a join block of the primary cluster")
+		}
+		p.logger.Infof("Bootstrapping the ledger and database from the primary cluster using a join block, number: %d",
+			joinBlock.GetHeader().GetBaseHeader().GetNumber())
+	}
+
+	// The join-block carries the most recent config only while the ledger is behind it.
+	var clusterConfig *types.ClusterConfig
+	if joinBlock != nil && ledgerHeight < joinBlock.GetHeader().GetBaseHeader().GetNumber() {
+		clusterConfig = joinBlock.GetPayload().(*types.Block_ConfigTxEnvelope).ConfigTxEnvelope.GetPayload().NewConfig
+		conf.logger.Debugf("Using cluster config from join-block: %+v", clusterConfig)
+	} else {
+		clusterConfig, _, err = conf.db.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		conf.logger.Debugf("Using cluster config from DB: %+v", clusterConfig)
+	}
+
+	if _, err = comm.MemberRaftID(p.nodeID, clusterConfig); err == nil {
+		return nil, errors.Errorf("standby node [%s] is a consensus member, as it was promoted; it must be restarted with the standby mode disabled", p.nodeID)
+	}
+	if isObserver(p.nodeID, clusterConfig) {
+		return nil, errors.Errorf("standby node [%s] must not be an observer of the primary cluster: %v", p.nodeID, clusterConfig.GetConsensusConfig())
+	}
+
+	// The transport is not started, as a standby node does not take part in consensus; it only provides the TLS
+	// configuration used to pull blocks from the primary members.
+	peerTransport, err := comm.NewHTTPTransport(&comm.Config{
+		LocalConf:    localConfig,
+		Logger:       conf.logger,
+		LedgerReader: conf.blockStore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	puller := comm.NewCatchUpClient(conf.logger, peerTransport.ClientTLSConfig())
+	puller.SetCodecs(localConfig.Replication.Compression.Codecs)
+	puller.SetThrottle(comm.NewThrottle(localConfig.Replication.Throttle.CatchUpBytesPerSecond))
+	maxBlockSize, err := localConfig.BlockCreation.MaxBlockSizeInBytes()
+	if err != nil {
+		return nil, err
+	}
+	puller.SetMaxBlockSize(maxBlockSize)
+	if err = puller.UpdateMembers(clusterConfig.GetConsensusConfig().GetMembers()); err != nil {
+		return nil, err
+	}
+	p.blockProcessor.SetChunkPuller(puller)
+
+	// A block of the primary cluster that the standby node validates differently would make the ledgers diverge,
+	// hence the standby node stops following the primary cluster at the first discrepancy.
+	p.witness = witness.New(
+		&witness.Config{
+			LedgerReader:         conf.blockStore,
+			BlockOneQueueBarrier: p.blockOneQueueBarrier,
+			BlockPuller:          puller,
+			HaltOnDiscrepancy:    true,
+			Logger:               conf.logger,
+		},
+	)
+
+	p.lagMonitor = standby.NewLagMonitor(
+		&standby.LagMonitorConfig{
+			NodeID:       p.nodeID,
+			Interval:     localConfig.Standby.LagProbeInterval,
+			LedgerReader: conf.blockStore,
+			HeightReader: puller,
+			ConfigReader: conf.db,
+			Logger:       conf.logger,
+		},
+	)
+
+	if err = registerCommitListeners(p.blockProcessor, conf.commitListeners, conf.logger); err != nil {
+		return nil, err
+	}
+
+	go p.blockProcessor.Start()
+	p.blockProcessor.WaitTillStart()
+
+	p.witness.Start()
+	p.lagMonitor.Start()
+	p.following = true
+
+	return p, nil
+}
+
+// SubmitTransaction accepts only the config tx that promotes the standby cluster to active, see promote.
+func (s *standbyProcessor) SubmitTransaction(tx interface{}, _ time.Duration) (*types.TxReceiptResponse, error) {
+	txEnv, ok := tx.(*types.ConfigTxEnvelope)
+	if !ok || txEnv.GetPayload().GetStandbyPromotion() == nil {
+		return nil, &internalerror.BadRequestError{ErrMsg: "node [" + s.nodeID + "] is a standby and does not accept transactions, except for the config tx that promotes it to active"}
+	}
+
+	return s.promote(txEnv)
+}
+
+// promote commits the config tx that promotes the standby cluster to active. The node first stops following the
+// primary cluster, irrevocably, and then commits the config tx in the block that follows the last block of its
+// ledger, which must be at the height of the promotion. As the block depends only on the config tx and on the ledger,
+// every standby node that is promoted at the same height commits the same block.
+func (s *standbyProcessor) promote(txEnv *types.ConfigTxEnvelope) (*types.TxReceiptResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.promoted {
+		return nil, &internalerror.BadRequestError{ErrMsg: "standby node [" + s.nodeID + "] is already promoted"}
+	}
+
+	if s.following {
+		s.logger.Info("Stopping to follow the primary cluster, for the promotion to active")
+		s.lagMonitor.Stop()
+		s.witness.Close()
+		s.following = false
+	}
+
+	height, err := s.blockStore.Height()
+	if err != nil {
+		return nil, err
+	}
+	if promotionHeight := txEnv.Payload.StandbyPromotion.LedgerHeight; height != promotionHeight {
+		return nil, &internalerror.BadRequestError{
+			ErrMsg: fmt.Sprintf("the ledger of standby node [%s] is at height [%d], while the promotion is at height [%d]", s.nodeID, height, promotionHeight),
+		}
+	}
+
+	valInfo, err := s.configTxValidator.Validate(txEnv)
+	if err != nil {
+		return nil, err
+	}
+	if valInfo.Flag != types.Flag_VALID {
+		return nil, &internalerror.BadRequestError{ErrMsg: fmt.Sprintf("Invalid config tx, reason: %s", valInfo.ReasonIfInvalid)}
+	}
+	if _, err = comm.MemberRaftID(s.nodeID, txEnv.Payload.NewConfig); err != nil {
+		return nil, &internalerror.BadRequestError{ErrMsg: "the promotion does not make standby node [" + s.nodeID + "] a consensus member"}
+	}
+
+	lastBlock, err := s.blockStore.Get(height)
+	if err != nil {
+		return nil, err
+	}
+	block, err := standby.PromotionBlock(txEnv, lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.blockOneQueueBarrier.EnqueueWait(block); err != nil {
+		return nil, err
+	}
+	s.promoted = true
+	s.logger.Infof("Standby node promoted to active in block [%d], it must be restarted with the standby mode disabled", block.GetHeader().GetBaseHeader().GetNumber())
+
+	return &types.TxReceiptResponse{
+		Receipt: &types.TxReceipt{
+			Header:  block.GetHeader(),
+			TxIndex: 0,
+		},
+	}, nil
+}
+
+func (s *standbyProcessor) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.following {
+		s.lagMonitor.Stop()
+		s.witness.Close()
+		s.following = false
+	}
+	s.blockProcessor.Stop()
+
+	return nil
+}
+
+// IsLeader always returns an error, as a standby node does not take part in consensus.
+func (s *standbyProcessor) IsLeader() *internalerror.NotLeaderError {
+	return &internalerror.NotLeaderError{}
+}
+
+// CommitHalted returns the error on which the block processor halted the commit of blocks, or nil if it did not.
+func (s *standbyProcessor) CommitHalted() error {
+	return s.blockProcessor.Halted()
+}
+
+// ClusterStatus returns no leader and no active nodes, as a standby node does not take part in consensus.
+func (s *standbyProcessor) ClusterStatus() (leader string, active []string) {
+	return "", nil
+}
+
+// ConsensusDiagnostics is not supported by a standby node.
+func (s *standbyProcessor) ConsensusDiagnostics() (*types.GetConsensusDiagnosticsResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + s.nodeID + "] is a standby and does not take part in consensus"}
+}
+
+// WitnessStatus returns the verification status of the blocks the standby pulled from the primary cluster, and the
+// discrepancies it most recently found.
+func (s *standbyProcessor) WitnessStatus() (*types.GetWitnessStatusResponse, error) {
+	return witnessStatus(s.witness), nil
+}
+
+// TriggerSnapshot is not supported by a standby node.
+func (s *standbyProcessor) TriggerSnapshot() (*types.TriggerSnapshotResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + s.nodeID + "] is a standby and does not take part in consensus"}
+}
+
+// DryRunConfigTx validates the config tx that promotes the standby cluster to active, against the current config.
+func (s *standbyProcessor) DryRunConfigTx(txEnv *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponse, error) {
+	if txEnv.GetPayload().GetStandbyPromotion() == nil {
+		return nil, &internalerror.BadRequestError{ErrMsg: "node [" + s.nodeID + "] is a standby and does not accept transactions, except for the config tx that promotes it to active"}
+	}
+
+	valInfo, err := s.configTxValidator.Validate(txEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ConfigTxDryRunResponse{ValidationInfo: valInfo}, nil
+}
+
+// TransferLeadership is not supported by a standby node.
+func (s *standbyProcessor) TransferLeadership(_ string, _ time.Duration) (*types.TransferLeadershipResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + s.nodeID + "] is a standby and does not take part in consensus"}
+}
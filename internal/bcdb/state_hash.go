@@ -0,0 +1,103 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// computeStateHash computes the canonical hash of the full contents of a database, read from a snapshot so that the
+// hash is of a single height of the world state. The hash is the SHA-256 of the stream of the keys of the database in
+// lexicographic order, each followed by its value and its metadata:
+//
+//	uvarint(len(key)) || key || uvarint(len(value)) || value || uvarint(len(metadata)) || metadata
+//
+// where the value is resolved from the blob store if it is stored there, and the metadata is the deterministic proto
+// encoding of the version and the access control of the key. The hash depends neither on the state trie nor on the
+// way the node stores the values, e.g., the blob threshold or the world state value encryption, hence it can be compared
+// across nodes.
+func computeStateHash(db worldstate.DB, dbName string) (*types.GetStateHashResponse, error) {
+	if dbName == worldstate.MetadataDBName {
+		return nil, &ierrors.BadRequestError{ErrMsg: "database [" + dbName + "] holds the local state of the node, which differs across nodes"}
+	}
+	if !db.Exist(dbName) {
+		return nil, &ierrors.NotFoundErr{Message: "database [" + dbName + "] does not exist"}
+	}
+
+	snapshots, err := db.GetDBsSnapshot("state hash", []string{dbName})
+	if err != nil {
+		return nil, err
+	}
+	defer snapshots.Release()
+
+	itr, err := snapshots.GetIterator(dbName, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	h := sha256.New()
+	var keys uint64
+	for itr.Next() {
+		key := string(itr.Key())
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling the value of key [%s] in database [%s]", key, dbName)
+		}
+
+		value := persisted.Value
+		if persisted.BlobManifest != nil {
+			if value, _, err = snapshots.Get(dbName, key); err != nil {
+				return nil, err
+			}
+		}
+
+		metadata, err := marshalDeterministic(persisted.Metadata)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while marshaling the metadata of key [%s] in database [%s]", key, dbName)
+		}
+
+		writeLengthPrefixed(h, itr.Key())
+		writeLengthPrefixed(h, value)
+		writeLengthPrefixed(h, metadata)
+		keys++
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "error while iterating over database [%s]", dbName)
+	}
+
+	return &types.GetStateHashResponse{
+		BlockHeight: snapshots.Height(),
+		DbName:      dbName,
+		Keys:        keys,
+		Hash:        h.Sum(nil),
+	}, nil
+}
+
+func marshalDeterministic(metadata *types.Metadata) ([]byte, error) {
+	if metadata == nil {
+		return nil, nil
+	}
+
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(metadata); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeLengthPrefixed(h hash.Hash, b []byte) {
+	var l [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(l[:], uint64(len(b)))
+	h.Write(l[:n])
+	h.Write(b)
+}
@@ -0,0 +1,146 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStateHash(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	// openDB opens a world state that stores the values larger than 8 bytes in the blob store when withBlobs is set
+	openDB := func(t *testing.T, withBlobs bool) (*leveldb.LevelDB, func(kvs []*worldstate.KVWithMetadata, height uint64)) {
+		dir, err := ioutil.TempDir("/tmp", "stateHash")
+		require.NoError(t, err)
+
+		conf := &leveldb.Config{DBRootDir: filepath.Join(dir, "worldstate"), Logger: lg}
+		if withBlobs {
+			conf.BlobStore, err = blobstore.Open(&blobstore.Config{StoreDir: filepath.Join(dir, "blobs"), Logger: lg})
+			require.NoError(t, err)
+		}
+		db, err := leveldb.Open(conf)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, db.Close())
+			if conf.BlobStore != nil {
+				require.NoError(t, conf.BlobStore.Close())
+			}
+			require.NoError(t, os.RemoveAll(dir))
+		})
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{{Key: "db1"}}},
+		}, 1))
+
+		commit := func(kvs []*worldstate.KVWithMetadata, height uint64) {
+			for _, kv := range kvs {
+				if withBlobs && len(kv.Value) > 8 {
+					// the blob is uploaded before the manifest is committed in place of the value
+					kv.BlobManifest = blobstore.NewManifest(kv.Value, 4)
+					require.NoError(t, conf.BlobStore.PutValue(kv.BlobManifest, kv.Value))
+					kv.Value = nil
+				}
+			}
+			require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{"db1": {Writes: kvs}}, height))
+		}
+		return db, commit
+	}
+	kvs := func(value2 string, acl *types.AccessControl) []*worldstate.KVWithMetadata {
+		return []*worldstate.KVWithMetadata{
+			{
+				Key:   "key2",
+				Value: []byte(value2),
+				Metadata: &types.Metadata{
+					Version:       &types.Version{BlockNum: 2, TxNum: 1},
+					AccessControl: acl,
+				},
+			},
+			{
+				Key:      "key1",
+				Value:    []byte("value1"),
+				Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2, TxNum: 0}},
+			},
+		}
+	}
+	acl := func(users ...string) *types.AccessControl {
+		acl := &types.AccessControl{ReadWriteUsers: make(map[string]bool)}
+		for _, u := range users {
+			acl.ReadWriteUsers[u] = true
+		}
+		return acl
+	}
+
+	t.Run("the hash does not depend on how the values are stored", func(t *testing.T) {
+		db1, commit1 := openDB(t, false)
+		db2, commit2 := openDB(t, true)
+		commit1(kvs("a value larger than the blob threshold", acl("alice", "bob", "carol")), 2)
+		commit2(kvs("a value larger than the blob threshold", acl("carol", "bob", "alice")), 2)
+
+		hash1, err := computeStateHash(db1, "db1")
+		require.NoError(t, err)
+		hash2, err := computeStateHash(db2, "db1")
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(2), hash1.BlockHeight)
+		require.Equal(t, "db1", hash1.DbName)
+		require.Equal(t, uint64(2), hash1.Keys)
+		require.Len(t, hash1.Hash, 32)
+		require.Equal(t, hash1, hash2)
+	})
+
+	t.Run("the hash covers the values and the metadata", func(t *testing.T) {
+		db1, commit1 := openDB(t, false)
+		commit1(kvs("value2", acl("alice")), 2)
+		base, err := computeStateHash(db1, "db1")
+		require.NoError(t, err)
+
+		for _, updated := range [][]*worldstate.KVWithMetadata{
+			kvs("value3", acl("alice")),
+			kvs("value2", acl("bob")),
+			kvs("value2", nil),
+		} {
+			db2, commit2 := openDB(t, false)
+			commit2(updated, 2)
+			hash, err := computeStateHash(db2, "db1")
+			require.NoError(t, err)
+			require.NotEqual(t, base.Hash, hash.Hash)
+		}
+	})
+
+	t.Run("an empty database", func(t *testing.T) {
+		db, _ := openDB(t, false)
+		hash, err := computeStateHash(db, "db1")
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), hash.BlockHeight)
+		require.Equal(t, uint64(0), hash.Keys)
+		require.Len(t, hash.Hash, 32)
+	})
+
+	t.Run("invalid database", func(t *testing.T) {
+		db, _ := openDB(t, false)
+		_, err := computeStateHash(db, "db2")
+		require.EqualError(t, err, "database [db2] does not exist")
+		require.IsType(t, &ierrors.NotFoundErr{}, err)
+
+		_, err = computeStateHash(db, worldstate.MetadataDBName)
+		require.EqualError(t, err, "database [_metadata] holds the local state of the node, which differs across nodes")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+	})
+}
@@ -0,0 +1,286 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultStorageReportTop is the number of entries in each list of a storage report when none is requested
+	DefaultStorageReportTop = 10
+	// MaxStorageReportTop is the maximal number of entries in each list of a storage report
+	MaxStorageReportTop = 1000
+	// DefaultStorageReportPrefixDelimiter ends the key prefixes of a storage report when no delimiter is requested
+	DefaultStorageReportPrefixDelimiter = "/"
+)
+
+// storageAnalyzer scans the data databases of the world state, and the provenance store, to report where the storage
+// goes: duplicate values, the largest keys and key prefixes, and the keys with the most versions. The scan reads every
+// key, hence it is meant for occasional capacity reviews rather than for monitoring.
+type storageAnalyzer struct {
+	db              worldstate.DB
+	provenanceStore provenance.Store
+	logger          *logger.SugarLogger
+}
+
+type storageAnalyzerConfig struct {
+	db              worldstate.DB
+	provenanceStore provenance.Store
+	logger          *logger.SugarLogger
+}
+
+func newStorageAnalyzer(conf *storageAnalyzerConfig) *storageAnalyzer {
+	return &storageAnalyzer{
+		db:              conf.db,
+		provenanceStore: conf.provenanceStore,
+		logger:          conf.logger,
+	}
+}
+
+// report scans the data databases and returns the storage report, with up to top entries in each list. A key prefix
+// ends at the first occurrence of the delimiter in the key, and a key without the delimiter has an empty prefix. The
+// world state is read from a snapshot so that a concurrent commit does not affect the report. Only the keys that exist
+// in the world state are counted, so the versions of deleted keys are left out of the version hot spots.
+func (a *storageAnalyzer) report(top uint32, delimiter string) (*types.GetStorageReportResponse, error) {
+	if top == 0 {
+		top = DefaultStorageReportTop
+	}
+	if top > MaxStorageReportTop {
+		return nil, &ierrors.BadRequestError{
+			ErrMsg: fmt.Sprintf("the number of entries [%d] in a storage report must be up to %d", top, MaxStorageReportTop),
+		}
+	}
+	if delimiter == "" {
+		delimiter = DefaultStorageReportPrefixDelimiter
+	}
+
+	height, err := a.db.Height()
+	if err != nil {
+		return nil, err
+	}
+
+	var dbNames []string
+	for _, dbName := range a.db.ListDBs() {
+		if worldstate.IsSystemDB(dbName) || stateindex.IsIndexDB(dbName) {
+			continue
+		}
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	snapshots, err := a.db.GetDBsSnapshot("storage report", dbNames)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshots.Release()
+
+	resp := &types.GetStorageReportResponse{
+		BlockHeight: height,
+	}
+	duplicates := make(map[[sha256.Size]byte]*types.DuplicateValue)
+	prefixes := make(map[string]*types.PrefixStorage)
+	largest := &topKeys{top: int(top)}
+	hotSpots := &topKeys{top: int(top)}
+
+	for _, dbName := range dbNames {
+		dbStorage := &types.DBStorage{DbName: dbName}
+
+		itr, err := snapshots.GetIterator(dbName, "", "")
+		if err != nil {
+			return nil, err
+		}
+
+		for itr.Next() {
+			key := string(itr.Key())
+			persisted := &types.ValueWithMetadata{}
+			if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+				itr.Release()
+				return nil, errors.Wrapf(err, "error while unmarshaling the value of key [%s] in database [%s]", key, dbName)
+			}
+
+			size, hash, err := valueSizeAndHash(persisted)
+			if err != nil {
+				itr.Release()
+				return nil, err
+			}
+
+			dbStorage.Keys++
+			dbStorage.ValueBytes += size
+			if persisted.BlobManifest != nil {
+				dbStorage.BlobKeys++
+			}
+
+			keyStorage := &types.KeyStorage{DbName: dbName, Key: key, Size: size}
+			largest.add(keyStorage, size)
+
+			duplicate, ok := duplicates[hash]
+			if !ok {
+				duplicate = &types.DuplicateValue{ValueHash: hash[:], Size: size}
+				duplicates[hash] = duplicate
+			}
+			duplicate.Count++
+			if len(duplicate.Keys) < int(top) {
+				duplicate.Keys = append(duplicate.Keys, keyStorage)
+			}
+
+			prefix := keyPrefix(key, delimiter)
+			prefixStorage, ok := prefixes[dbName+"\x00"+prefix]
+			if !ok {
+				prefixStorage = &types.PrefixStorage{DbName: dbName, Prefix: prefix}
+				prefixes[dbName+"\x00"+prefix] = prefixStorage
+			}
+			prefixStorage.Keys++
+			prefixStorage.ValueBytes += size
+
+			versions, err := a.countVersions(dbName, key)
+			if err != nil {
+				itr.Release()
+				return nil, err
+			}
+			hotSpots.add(&types.KeyStorage{DbName: dbName, Key: key, Size: size, Versions: versions}, versions)
+		}
+
+		err = itr.Error()
+		itr.Release()
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while iterating over database [%s]", dbName)
+		}
+
+		resp.Dbs = append(resp.Dbs, dbStorage)
+	}
+
+	for _, duplicate := range duplicates {
+		if duplicate.Count > 1 {
+			resp.DuplicateValues = append(resp.DuplicateValues, duplicate)
+		}
+	}
+	sort.Slice(resp.DuplicateValues, func(i, j int) bool {
+		wi := resp.DuplicateValues[i].Size * (resp.DuplicateValues[i].Count - 1)
+		wj := resp.DuplicateValues[j].Size * (resp.DuplicateValues[j].Count - 1)
+		if wi != wj {
+			return wi > wj
+		}
+		return string(resp.DuplicateValues[i].ValueHash) < string(resp.DuplicateValues[j].ValueHash)
+	})
+	if len(resp.DuplicateValues) > int(top) {
+		resp.DuplicateValues = resp.DuplicateValues[:top]
+	}
+
+	for _, prefixStorage := range prefixes {
+		resp.LargestPrefixes = append(resp.LargestPrefixes, prefixStorage)
+	}
+	sort.Slice(resp.LargestPrefixes, func(i, j int) bool {
+		pi, pj := resp.LargestPrefixes[i], resp.LargestPrefixes[j]
+		if pi.ValueBytes != pj.ValueBytes {
+			return pi.ValueBytes > pj.ValueBytes
+		}
+		if pi.DbName != pj.DbName {
+			return pi.DbName < pj.DbName
+		}
+		return pi.Prefix < pj.Prefix
+	})
+	if len(resp.LargestPrefixes) > int(top) {
+		resp.LargestPrefixes = resp.LargestPrefixes[:top]
+	}
+
+	resp.LargestKeys = largest.sorted()
+	resp.VersionHotSpots = hotSpots.sorted()
+
+	return resp, nil
+}
+
+func (a *storageAnalyzer) countVersions(dbName, key string) (uint64, error) {
+	var versions uint64
+	err := a.provenanceStore.IterateValues(dbName, key, 0, 0, func(*types.ValueWithMetadata) error {
+		versions++
+		return nil
+	})
+	if err != nil {
+		return 0, errors.WithMessagef(err, "error while counting the versions of key [%s] in database [%s]", key, dbName)
+	}
+
+	return versions, nil
+}
+
+// valueSizeAndHash returns the size of a persisted value, and the hash it is deduplicated by. A value that is stored
+// in the blob store is hashed by its manifest, which depends only on the value.
+func valueSizeAndHash(persisted *types.ValueWithMetadata) (uint64, [sha256.Size]byte, error) {
+	if persisted.BlobManifest == nil {
+		return uint64(len(persisted.Value)), sha256.Sum256(persisted.Value), nil
+	}
+
+	manifestBytes, err := proto.Marshal(persisted.BlobManifest)
+	if err != nil {
+		return 0, [sha256.Size]byte{}, errors.Wrap(err, "error while marshaling a blob manifest")
+	}
+	return persisted.BlobManifest.Size, sha256.Sum256(manifestBytes), nil
+}
+
+func keyPrefix(key, delimiter string) string {
+	i := strings.Index(key, delimiter)
+	if i < 0 {
+		return ""
+	}
+	return key[:i+len(delimiter)]
+}
+
+// topKeys keeps the keys with the largest weights. The keys are truncated once in a while rather than on every add,
+// so that adding a key is cheap on average.
+type topKeys struct {
+	top     int
+	keys    []*types.KeyStorage
+	weights []uint64
+}
+
+func (t *topKeys) add(key *types.KeyStorage, weight uint64) {
+	t.keys = append(t.keys, key)
+	t.weights = append(t.weights, weight)
+	if len(t.keys) >= 2*t.top+64 {
+		t.truncate()
+	}
+}
+
+func (t *topKeys) sorted() []*types.KeyStorage {
+	t.truncate()
+	return t.keys
+}
+
+func (t *topKeys) truncate() {
+	sort.Sort(t)
+	if len(t.keys) > t.top {
+		t.keys = t.keys[:t.top]
+		t.weights = t.weights[:t.top]
+	}
+}
+
+func (t *topKeys) Len() int {
+	return len(t.keys)
+}
+
+func (t *topKeys) Less(i, j int) bool {
+	if t.weights[i] != t.weights[j] {
+		return t.weights[i] > t.weights[j]
+	}
+	if t.keys[i].DbName != t.keys[j].DbName {
+		return t.keys[i].DbName < t.keys[j].DbName
+	}
+	return t.keys[i].Key < t.keys[j].Key
+}
+
+func (t *topKeys) Swap(i, j int) {
+	t.keys[i], t.keys[j] = t.keys[j], t.keys[i]
+	t.weights[i], t.weights[j] = t.weights[j], t.weights[i]
+}
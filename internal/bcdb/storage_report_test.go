@@ -0,0 +1,218 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+type storageAnalyzerTestEnv struct {
+	a               *storageAnalyzer
+	db              *leveldb.LevelDB
+	provenanceStore provenance.Store
+
+	cleanup func(t *testing.T)
+}
+
+func newStorageAnalyzerTestEnv(t *testing.T) *storageAnalyzerTestEnv {
+	path, err := ioutil.TempDir("/tmp", "storageAnalyzer")
+	require.NoError(t, err)
+
+	c := &logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(c)
+	require.NoError(t, err)
+
+	provenanceStore, err := provenance.Open(
+		&provenance.Config{
+			StoreDir: path,
+			Logger:   logger,
+		},
+	)
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(
+		&leveldb.Config{
+			DBRootDir: filepath.Join(path, "worldstate"),
+			Logger:    logger,
+		},
+	)
+	require.NoError(t, err)
+
+	cleanup := func(t *testing.T) {
+		if err := provenanceStore.Close(); err != nil {
+			t.Errorf("failed to close the provenance store: %v", err)
+		}
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close leveldb: %v", err)
+		}
+		if err := os.RemoveAll(path); err != nil {
+			t.Fatalf("failed to remove %s due to %v", path, err)
+		}
+	}
+
+	return &storageAnalyzerTestEnv{
+		a: newStorageAnalyzer(
+			&storageAnalyzerConfig{
+				db:              db,
+				provenanceStore: provenanceStore,
+				logger:          logger,
+			}),
+		db:              db,
+		provenanceStore: provenanceStore,
+		cleanup:         cleanup,
+	}
+}
+
+// setupStorageReportData creates db1 with the keys a/1, a/2, b/1, and c, and db2 with the key a/1. The keys a/1 and
+// a/2 of db1, and a/1 of db2, share the same value. The key a/1 of db1 has three versions in the provenance store, and
+// b/1 of db1 has one.
+func setupStorageReportData(t *testing.T, env *storageAnalyzerTestEnv) {
+	version := func(blockNum uint64) *types.Metadata {
+		return &types.Metadata{Version: &types.Version{BlockNum: blockNum}}
+	}
+
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1", Metadata: version(1)},
+				{Key: "db2", Metadata: version(1)},
+			},
+		},
+	}, 1))
+
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "a/1", Value: []byte("dup"), Metadata: version(3)},
+				{Key: "a/2", Value: []byte("dup"), Metadata: version(2)},
+				{Key: "b/1", Value: []byte("longvalue!"), Metadata: version(2)},
+				{Key: "c", Value: []byte("x"), Metadata: version(2)},
+			},
+		},
+		"db2": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "a/1", Value: []byte("dup"), Metadata: version(2)},
+			},
+		},
+	}, 3))
+
+	require.NoError(t, env.provenanceStore.Commit(1, 0, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  "db1",
+			UserID:  "user1",
+			TxID:    "tx1",
+			Writes: []*types.KVWithMetadata{
+				{Key: "a/1", Value: []byte("v1"), Metadata: version(1)},
+			},
+		},
+	}))
+	require.NoError(t, env.provenanceStore.Commit(2, 0, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  "db1",
+			UserID:  "user1",
+			TxID:    "tx2",
+			Writes: []*types.KVWithMetadata{
+				{Key: "a/1", Value: []byte("v2"), Metadata: version(2)},
+				{Key: "b/1", Value: []byte("longvalue!"), Metadata: version(2)},
+			},
+			OldVersionOfWrites: map[string]*types.Version{
+				"a/1": {BlockNum: 1},
+			},
+		},
+	}))
+	require.NoError(t, env.provenanceStore.Commit(3, 0, []*provenance.TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  "db1",
+			UserID:  "user1",
+			TxID:    "tx3",
+			Writes: []*types.KVWithMetadata{
+				{Key: "a/1", Value: []byte("dup"), Metadata: version(3)},
+			},
+			OldVersionOfWrites: map[string]*types.Version{
+				"a/1": {BlockNum: 2},
+			},
+		},
+	}))
+}
+
+func TestStorageReport(t *testing.T) {
+	env := newStorageAnalyzerTestEnv(t)
+	defer env.cleanup(t)
+
+	setupStorageReportData(t, env)
+
+	t.Run("report with two entries in each list", func(t *testing.T) {
+		report, err := env.a.report(2, "/")
+		require.NoError(t, err)
+
+		dupHash := sha256.Sum256([]byte("dup"))
+		expected := &types.GetStorageReportResponse{
+			BlockHeight: 3,
+			Dbs: []*types.DBStorage{
+				{DbName: "db1", Keys: 4, ValueBytes: 17},
+				{DbName: "db2", Keys: 1, ValueBytes: 3},
+			},
+			DuplicateValues: []*types.DuplicateValue{
+				{
+					ValueHash: dupHash[:],
+					Size:      3,
+					Count:     3,
+					Keys: []*types.KeyStorage{
+						{DbName: "db1", Key: "a/1", Size: 3},
+						{DbName: "db1", Key: "a/2", Size: 3},
+					},
+				},
+			},
+			LargestKeys: []*types.KeyStorage{
+				{DbName: "db1", Key: "b/1", Size: 10},
+				{DbName: "db1", Key: "a/1", Size: 3},
+			},
+			LargestPrefixes: []*types.PrefixStorage{
+				{DbName: "db1", Prefix: "b/", Keys: 1, ValueBytes: 10},
+				{DbName: "db1", Prefix: "a/", Keys: 2, ValueBytes: 6},
+			},
+			VersionHotSpots: []*types.KeyStorage{
+				{DbName: "db1", Key: "a/1", Size: 3, Versions: 3},
+				{DbName: "db1", Key: "b/1", Size: 10, Versions: 1},
+			},
+		}
+		require.Equal(t, expected, report)
+	})
+
+	t.Run("report with the default top and delimiter", func(t *testing.T) {
+		report, err := env.a.report(0, "")
+		require.NoError(t, err)
+
+		require.Len(t, report.LargestKeys, 5)
+		require.Len(t, report.LargestPrefixes, 4)
+		require.Len(t, report.DuplicateValues[0].Keys, 3)
+		require.Equal(t, &types.PrefixStorage{DbName: "db1", Prefix: "", Keys: 1, ValueBytes: 1}, report.LargestPrefixes[3])
+	})
+
+	t.Run("too many entries requested", func(t *testing.T) {
+		report, err := env.a.report(MaxStorageReportTop+1, "/")
+		require.EqualError(t, err, "the number of entries [1001] in a storage report must be up to 1000")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+		require.Nil(t, report)
+	})
+}
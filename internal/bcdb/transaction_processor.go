@@ -8,17 +8,25 @@ import (
 	"sync"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
 	"github.com/hyperledger-labs/orion-server/internal/blockcreator"
 	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
 	internalerror "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	"github.com/hyperledger-labs/orion-server/internal/ordering"
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
 	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/internal/txidwindow"
 	"github.com/hyperledger-labs/orion-server/internal/txreorderer"
 	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
@@ -39,21 +47,37 @@ type transactionProcessor struct {
 	blockOneQueueBarrier *queue.OneQueueBarrier
 	txReorderer          *txreorderer.TxReorderer
 	blockCreator         *blockcreator.BlockCreator
-	blockReplicator      *replication.BlockReplicator
+	dispatcher           ordering.Dispatcher
 	peerTransport        *comm.HTTPTransport
 	blockProcessor       *blockprocessor.BlockProcessor
+	configTxValidator    *txvalidation.ConfigTxValidator
 	blockStore           *blockstore.Store
 	pendingTxs           *queue.PendingTxs
-	logger               *logger.SugarLogger
+	txIDWindow           *txidwindow.Store
+	maxTxSize            uint64
+	// blobs holds the blobs written by their manifests; a value larger than blobsConf.ValueSizeThreshold must be
+	// uploaded to it rather than written in place
+	blobs     *blobstore.Store
+	blobsConf config.BlobsConf
+	logger    *logger.SugarLogger
 	sync.Mutex
 }
 
 type txProcessorConfig struct {
 	config          *config.Configurations
 	db              worldstate.DB
+	blobStore       *blobstore.Store
+	blobsConf       config.BlobsConf
 	blockStore      *blockstore.Store
-	provenanceStore *provenance.Store
+	provenanceStore provenance.Store
 	stateTrieStore  mptrie.Store
+	outbox          *outbox.Outbox
+	erasure         *erasure.Store
+	identityCache   *identity.Cache
+	commitListeners map[string]CommitListener
+	orderingSvcs    map[string]ordering.Service
+	memBudget       *membudget.Accountant
+	quarantine      *blockprocessor.Quarantine
 	logger          *logger.SugarLogger
 }
 
@@ -63,42 +87,94 @@ func newTransactionProcessor(conf *txProcessorConfig) (*transactionProcessor, er
 	localConfig := conf.config.LocalConfig
 
 	p.nodeID = localConfig.Server.Identity.ID
+	p.blobs = conf.blobStore
+	p.blobsConf = blobsConfWithDefaults(conf.blobsConf)
 	p.logger = conf.logger
+
+	maxBlockSize, err := localConfig.BlockCreation.MaxBlockSizeInBytes()
+	if err != nil {
+		return nil, err
+	}
+	if maxBlockSize > 0 {
+		if maxBlockSize <= txreorderer.MinBlockSize {
+			return nil, errors.Errorf("blockCreation.maxBlockSize [%d bytes] must be larger than %d bytes", maxBlockSize, txreorderer.MinBlockSize)
+		}
+		p.maxTxSize = txreorderer.MaxTxSize(maxBlockSize)
+	}
+	var adaptiveTimeout *txreorderer.AdaptiveTimeoutConfig
+	if conf := localConfig.BlockCreation.AdaptiveTimeout; conf.Enabled {
+		if conf.MinBlockTimeout <= 0 || conf.MaxBlockTimeout < conf.MinBlockTimeout {
+			return nil, errors.Errorf("blockCreation.adaptiveTimeout bounds [%s, %s] are invalid: the minimum must be positive and not higher than the maximum",
+				conf.MinBlockTimeout, conf.MaxBlockTimeout)
+		}
+		adaptiveTimeout = &txreorderer.AdaptiveTimeoutConfig{
+			MinBatchTimeout: conf.MinBlockTimeout,
+			MaxBatchTimeout: conf.MaxBlockTimeout,
+		}
+	}
 	p.txQueue = queue.New(localConfig.Server.QueueLength.Transaction)
 	p.txBatchQueue = queue.New(localConfig.Server.QueueLength.ReorderedTransactionBatch)
 	p.blockOneQueueBarrier = queue.NewOneQueueBarrier(conf.logger)
 	p.pendingTxs = queue.NewPendingTxs(conf.logger)
 
+	p.txIDWindow, err = txidwindow.Open(
+		&txidwindow.Config{
+			StoreDir: constructTxIDWindowPath(localConfig.Server.Database.LedgerDirectory),
+			Window:   localConfig.Server.TxReplayProtection.Window,
+			Logger:   conf.logger,
+		},
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the TxID window")
+	}
+
 	p.txReorderer = txreorderer.New(
 		&txreorderer.Config{
+			NodeID:             p.nodeID,
 			TxQueue:            p.txQueue,
 			TxBatchQueue:       p.txBatchQueue,
 			MaxTxCountPerBatch: localConfig.BlockCreation.MaxTransactionCountPerBlock,
+			MaxBlockSizeBytes:  maxBlockSize,
 			BatchTimeout:       localConfig.BlockCreation.BlockTimeout,
+			AdaptiveTimeout:    adaptiveTimeout,
+			MemBudget:          conf.memBudget,
 			Logger:             conf.logger,
 		},
 	)
 
-	var err error
-
 	// The txValidator is used by the block processor (commit-phase) as well as by some pre-order components that need
 	// it (or one of its sub-components), e.g. the config-validator is used by the block-replicator.
 	txValidator := txvalidation.NewValidator(
 		&txvalidation.Config{
-			DB:     conf.db,
-			Logger: conf.logger,
+			DB:                  conf.db,
+			DBUsage:             conf.blockStore,
+			ConsensusAlgorithms: orderingAlgorithms(conf.orderingSvcs),
+			Logger:              conf.logger,
 		},
 	)
 
+	p.configTxValidator = txValidator.ConfigValidator()
+
 	p.blockProcessor = blockprocessor.New(
 		&blockprocessor.Config{
+			NodeID:               p.nodeID,
 			BlockOneQueueBarrier: p.blockOneQueueBarrier,
 			BlockStore:           conf.blockStore,
 			ProvenanceStore:      conf.provenanceStore,
 			StateTrieStore:       conf.stateTrieStore,
+			Outbox:               conf.outbox,
+			Erasure:              conf.erasure,
+			IdentityCache:        conf.identityCache,
+			BlobStore:            conf.blobStore,
 			DB:                   conf.db,
 			TxValidator:          txValidator,
-			Logger:               conf.logger,
+			CommitBatching: blockprocessor.CommitBatchingConfig{
+				MaxBlocks:       localConfig.Server.CommitBatching.MaxBlocks,
+				MaxBlockUpdates: localConfig.Server.CommitBatching.MaxBlockUpdates,
+				FlushTimeout:    localConfig.Server.CommitBatching.FlushTimeout,
+			},
+			Quarantine: conf.quarantine,
+			Logger:     conf.logger,
 		},
 	)
 
@@ -142,15 +218,6 @@ func newTransactionProcessor(conf *txProcessorConfig) (*transactionProcessor, er
 		return nil, err
 	}
 
-	p.peerTransport, err = comm.NewHTTPTransport(&comm.Config{
-		LocalConf:    localConfig,
-		Logger:       conf.logger,
-		LedgerReader: conf.blockStore,
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	var clusterConfig *types.ClusterConfig
 	// A 'normal start' is when the server has the most current config known to it in the DB (and ledger), and has no
 	// join-block. This can happen when:
@@ -181,6 +248,81 @@ func newTransactionProcessor(conf *txProcessorConfig) (*transactionProcessor, er
 		return nil, errors.New("programming error, one of: 'normalStart || completedJoinStart || joinStart' must be true!")
 	}
 
+	if algorithm := clusterConfig.GetConsensusConfig().GetAlgorithm(); algorithm != ordering.RaftAlgorithm {
+		if joinStart {
+			return nil, errors.Errorf("cannot join a cluster with a join block when blocks are ordered by the ordering service [%s]", algorithm)
+		}
+		svc, ok := conf.orderingSvcs[algorithm]
+		if !ok {
+			return nil, errors.Errorf("no ordering service is registered for the consensus algorithm [%s]", algorithm)
+		}
+		p.dispatcher, err = ordering.NewExternalDispatcher(&ordering.ExternalConfig{
+			NodeID:               p.nodeID,
+			ClusterConfig:        clusterConfig,
+			Service:              svc,
+			LedgerReader:         conf.blockStore,
+			BlockOneQueueBarrier: p.blockOneQueueBarrier,
+			PendingTxs:           p.pendingTxs,
+			Logger:               conf.logger,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else if p.dispatcher, err = p.newBlockReplicator(conf, clusterConfig, joinStart); err != nil {
+		return nil, err
+	}
+	p.blockCreator.RegisterReplicator(p.dispatcher)
+
+	if err = p.blockProcessor.RegisterBlockCommitListener(commitListenerName, p); err != nil {
+		return nil, err
+	}
+	if err = registerCommitListeners(p.blockProcessor, conf.commitListeners, conf.logger); err != nil {
+		return nil, err
+	}
+
+	go p.txReorderer.Start()
+	p.txReorderer.WaitTillStart()
+
+	go p.blockCreator.Start()
+	p.blockCreator.WaitTillStart()
+
+	if p.peerTransport != nil {
+		if err = p.peerTransport.Start(); err != nil { // Starts internal goroutine
+			return nil, err
+		}
+	}
+
+	p.dispatcher.Start() // Starts internal goroutine
+
+	go p.blockProcessor.Start()
+	p.blockProcessor.WaitTillStart()
+
+	p.blockStore = conf.blockStore
+
+	return p, nil
+}
+
+// newBlockReplicator creates the peer transport and the block replicator of the built-in Raft consensus.
+func (p *transactionProcessor) newBlockReplicator(conf *txProcessorConfig, clusterConfig *types.ClusterConfig, joinStart bool) (*replication.BlockReplicator, error) {
+	localConfig := conf.config.LocalConfig
+
+	transportConf := &comm.Config{
+		LocalConf:    localConfig,
+		Logger:       conf.logger,
+		LedgerReader: conf.blockStore,
+	}
+	if conf.blobStore != nil {
+		transportConf.BlobReader = conf.blobStore
+	}
+
+	var err error
+	p.peerTransport, err = comm.NewHTTPTransport(transportConf)
+	if err != nil {
+		return nil, err
+	}
+	// the blobs are uploaded to the node a transaction is submitted to, the other members pull their chunks
+	p.blockProcessor.SetChunkPuller(p.peerTransport)
+
 	if err = p.peerTransport.SetClusterConfig(clusterConfig); err != nil {
 		return nil, err
 	}
@@ -192,46 +334,32 @@ func newTransactionProcessor(conf *txProcessorConfig) (*transactionProcessor, er
 		Transport:            p.peerTransport,
 		BlockOneQueueBarrier: p.blockOneQueueBarrier,
 		PendingTxs:           p.pendingTxs,
-		ConfigValidator:      txValidator.ConfigValidator(),
+		ConfigValidator:      p.configTxValidator,
 		Logger:               conf.logger,
 	}
 	if joinStart {
 		repConfig.JoinBlock = conf.config.JoinBlock
 	}
 
-	p.blockReplicator, err = replication.NewBlockReplicator(repConfig)
+	blockReplicator, err := replication.NewBlockReplicator(repConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = p.peerTransport.SetConsensusListener(p.blockReplicator); err != nil {
+	if err = p.peerTransport.SetConsensusListener(blockReplicator); err != nil {
 		return nil, err
 	}
-	p.blockCreator.RegisterReplicator(p.blockReplicator)
 
-	if err = p.blockProcessor.RegisterBlockCommitListener(commitListenerName, p); err != nil {
-		return nil, err
-	}
-
-	go p.txReorderer.Start()
-	p.txReorderer.WaitTillStart()
-
-	go p.blockCreator.Start()
-	p.blockCreator.WaitTillStart()
+	return blockReplicator, nil
+}
 
-	err = p.peerTransport.Start() // Starts internal goroutine
-	if err != nil {
-		return nil, err
+// orderingAlgorithms returns the consensus algorithms of the registered ordering services, in addition to Raft
+func orderingAlgorithms(orderingSvcs map[string]ordering.Service) []string {
+	algorithms := []string{ordering.RaftAlgorithm}
+	for algorithm := range orderingSvcs {
+		algorithms = append(algorithms, algorithm)
 	}
-
-	p.blockReplicator.Start() // Starts internal goroutine
-
-	go p.blockProcessor.Start()
-	p.blockProcessor.WaitTillStart()
-
-	p.blockStore = conf.blockStore
-
-	return p, nil
+	return algorithms
 }
 
 // SubmitTransaction enqueue the transaction to the transaction queue
@@ -257,6 +385,20 @@ func (t *transactionProcessor) SubmitTransaction(tx interface{}, timeout time.Du
 		return nil, &internalerror.BadRequestError{ErrMsg: errors.WithMessage(err, "bad TxId").Error()}
 	}
 
+	if t.maxTxSize > 0 {
+		if txSize := uint64(proto.Size(tx.(proto.Message))); txSize > t.maxTxSize {
+			return nil, &internalerror.TxTooLargeError{
+				ErrMsg: fmt.Sprintf("transaction size [%d bytes] exceeds the maximal transaction size [%d bytes] allowed by the maximal block size", txSize, t.maxTxSize),
+			}
+		}
+	}
+
+	if dataTxEnv, ok := tx.(*types.DataTxEnvelope); ok {
+		if err := checkBlobWrites(t.blobs, t.blobsConf.ValueSizeThreshold, dataTxEnv.Payload); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := t.IsLeader(); err != nil {
 		return nil, err
 	}
@@ -272,9 +414,16 @@ func (t *transactionProcessor) SubmitTransaction(tx interface{}, timeout time.Du
 		return nil, &internalerror.DuplicateTxIDError{TxID: txID}
 	}
 
+	if configTxEnv, ok := tx.(*types.ConfigTxEnvelope); ok {
+		if err = t.preValidateConfigTx(configTxEnv); err != nil {
+			t.Unlock()
+			return nil, err
+		}
+	}
+
 	if t.txQueue.IsFull() {
 		t.Unlock()
-		return nil, fmt.Errorf("transaction queue is full. It means the server load is high. Try after sometime")
+		return nil, &internalerror.ResourceExhaustedError{ErrMsg: "transaction queue is full. It means the server load is high. Try after sometime"}
 	}
 
 	jsonBytes, err := json.MarshalIndent(tx, "", "\t")
@@ -284,6 +433,11 @@ func (t *transactionProcessor) SubmitTransaction(tx interface{}, timeout time.Du
 	}
 	t.logger.Debugf("enqueuing transaction %s\n", string(jsonBytes))
 
+	if err = t.txIDWindow.Add(txID); err != nil {
+		t.Unlock()
+		return nil, err
+	}
+
 	t.txQueue.Enqueue(tx)
 	t.logger.Debug("transaction is enqueued for re-ordering")
 
@@ -298,11 +452,37 @@ func (t *transactionProcessor) SubmitTransaction(tx interface{}, timeout time.Du
 		return nil, err
 	}
 
+	if dataTxEnv, ok := tx.(*types.DataTxEnvelope); ok && receipt != nil {
+		receipt.Annotations = dataTxEnv.Payload.Annotations
+	}
+
 	return &types.TxReceiptResponse{
 		Receipt: receipt,
 	}, nil
 }
 
+// preValidateConfigTx validates a config tx before it is enqueued, so that a config that would be rejected, or that
+// would leave the cluster without a quorum, is declined before it reaches consensus.
+func (t *transactionProcessor) preValidateConfigTx(txEnv *types.ConfigTxEnvelope) error {
+	if txEnv.GetPayload().GetStandbyPromotion() != nil {
+		return &internalerror.BadRequestError{ErrMsg: "a config tx that promotes a standby cluster can only be submitted to a standby node"}
+	}
+
+	valInfo, err := t.configTxValidator.Validate(txEnv)
+	if err != nil {
+		return err
+	}
+	if valInfo.Flag != types.Flag_VALID {
+		return &internalerror.BadRequestError{ErrMsg: fmt.Sprintf("Invalid config tx, reason: %s", valInfo.ReasonIfInvalid)}
+	}
+
+	if err = t.dispatcher.VerifyQuorumAfterReConfig(txEnv.Payload.NewConfig); err != nil {
+		return &internalerror.BadRequestError{ErrMsg: err.Error()}
+	}
+
+	return nil
+}
+
 func (t *transactionProcessor) PostBlockCommitProcessing(block *types.Block) error {
 	t.logger.Debugf("received commit event for block[%d]", block.GetHeader().GetBaseHeader().GetNumber())
 
@@ -333,6 +513,12 @@ func (t *transactionProcessor) PostBlockCommitProcessing(block *types.Block) err
 
 	t.pendingTxs.DoneWithReceipt(txIDs, block.Header)
 
+	// the committed TxIDs are found in the block store; a TxID that is left behind expires with the window
+	if err := t.txIDWindow.Remove(txIDs); err != nil {
+		t.logger.Warnf("error while removing the TxIDs committed by block [%d] from the TxID window: %s",
+			block.GetHeader().GetBaseHeader().GetNumber(), err)
+	}
+
 	return nil
 }
 
@@ -341,6 +527,12 @@ func (t *transactionProcessor) isTxIDDuplicate(txID string) (bool, error) {
 		return true, nil
 	}
 
+	// the TxIDs of the transactions that were accepted but not yet committed before the node restarted
+	inWindow, err := t.txIDWindow.Has(txID)
+	if err != nil || inWindow {
+		return inWindow, err
+	}
+
 	isTxIDAlreadyCommitted, err := t.blockStore.DoesTxIDExist(txID)
 	if err != nil {
 		return false, err
@@ -354,18 +546,25 @@ func (t *transactionProcessor) Close() error {
 
 	t.txReorderer.Stop()
 	t.blockCreator.Stop()
-	t.blockReplicator.Close()
-	t.peerTransport.Close()
+	t.dispatcher.Close()
+	if t.peerTransport != nil {
+		t.peerTransport.Close()
+	}
 	t.blockProcessor.Stop()
 
-	return nil
+	return t.txIDWindow.Close()
 }
 
 func (t *transactionProcessor) IsLeader() *internalerror.NotLeaderError {
 	t.Lock()
 	defer t.Unlock()
 
-	return t.blockReplicator.IsLeader()
+	return t.dispatcher.IsLeader()
+}
+
+// CommitHalted returns the error on which the block processor halted the commit of blocks, or nil if it did not.
+func (t *transactionProcessor) CommitHalted() error {
+	return t.blockProcessor.Halted()
 }
 
 // ClusterStatus returns the leader NodeID, and the active nodes NodeIDs.
@@ -374,7 +573,7 @@ func (t *transactionProcessor) ClusterStatus() (leader string, active []string)
 	t.Lock()
 	defer t.Unlock()
 
-	leaderID, activePeers := t.blockReplicator.GetClusterStatus()
+	leaderID, activePeers := t.dispatcher.GetClusterStatus()
 	for _, peer := range activePeers {
 		active = append(active, peer.NodeId)
 		if peer.RaftId == leaderID {
@@ -385,6 +584,119 @@ func (t *transactionProcessor) ClusterStatus() (leader string, active []string)
 	return
 }
 
+// TriggerSnapshot takes a consensus snapshot and compacts the WAL of the local node.
+// The processor lock is not held, as taking a snapshot waits for the block replicator event loop.
+func (t *transactionProcessor) TriggerSnapshot() (*types.TriggerSnapshotResponse, error) {
+	info, err := t.dispatcher.TriggerSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TriggerSnapshotResponse{
+		BlockNumber: info.BlockNumber,
+		RaftIndex:   info.RaftIndex,
+	}, nil
+}
+
+// TransferLeadership transfers the leadership from the local node, which must be the leader, to the target node.
+// The processor lock is not held, as the transfer waits for in-flight blocks to commit.
+func (t *transactionProcessor) TransferLeadership(targetNodeID string, timeout time.Duration) (*types.TransferLeadershipResponse, error) {
+	leaderID, err := t.dispatcher.TransferLeadership(targetNodeID, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TransferLeadershipResponse{
+		LeaderId: leaderID,
+	}, nil
+}
+
+// WitnessStatus is not supported by a node that takes part in consensus.
+func (t *transactionProcessor) WitnessStatus() (*types.GetWitnessStatusResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + t.nodeID + "] is neither a witness nor a standby"}
+}
+
+// ConsensusDiagnostics returns the state of consensus on the local node.
+// The processor lock is not held, as the raft status is served by the raft node go-routine.
+func (t *transactionProcessor) ConsensusDiagnostics() (*types.GetConsensusDiagnosticsResponse, error) {
+	diag := t.dispatcher.Diagnostics()
+
+	resp := &types.GetConsensusDiagnosticsResponse{
+		RaftId:                   diag.RaftID,
+		RaftState:                diag.RaftState,
+		Term:                     diag.Term,
+		CommitIndex:              diag.CommitIndex,
+		AppliedIndex:             diag.AppliedIndex,
+		LeaderChanges:            diag.NumLeaderChanges,
+		Elections:                diag.NumElections,
+		InFlightBlocks:           diag.InFlightBlocks,
+		LastProposedBlockNumber:  diag.LastProposedBlockNumber,
+		LastCommittedBlockNumber: diag.LastCommittedBlockNumber,
+	}
+	if !diag.LastLeaderChangeTime.IsZero() {
+		resp.LastLeaderChangeTime = diag.LastLeaderChangeTime.UnixNano()
+	}
+	if diag.LeaderID == diag.RaftID {
+		resp.LeaderId = t.nodeID
+	}
+	for _, peer := range diag.Peers {
+		if peer.RaftID == diag.LeaderID {
+			resp.LeaderId = peer.NodeID
+		}
+		resp.Peers = append(resp.Peers, &types.PeerDiagnostics{
+			NodeId:        peer.NodeID,
+			RaftId:        peer.RaftID,
+			Active:        peer.Active,
+			MatchIndex:    peer.MatchIndex,
+			NextIndex:     peer.NextIndex,
+			ProgressState: peer.ProgressState,
+		})
+	}
+
+	return resp, nil
+}
+
+// DryRunConfigTx validates a config tx against the current config, and reports the changes it would make, without
+// submitting it. Like a submission, a dry run is served by the leader, which holds the most recent config.
+func (t *transactionProcessor) DryRunConfigTx(txEnv *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponse, error) {
+	if err := t.IsLeader(); err != nil {
+		return nil, err
+	}
+	if txEnv.GetPayload().GetStandbyPromotion() != nil {
+		return nil, &internalerror.BadRequestError{ErrMsg: "a config tx that promotes a standby cluster can only be submitted to a standby node"}
+	}
+
+	valInfo, err := t.configTxValidator.Validate(txEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	newConfig := txEnv.GetPayload().GetNewConfig()
+	if valInfo.Flag == types.Flag_VALID {
+		if err = t.dispatcher.VerifyQuorumAfterReConfig(newConfig); err != nil {
+			valInfo = &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: err.Error(),
+			}
+		}
+	}
+
+	changes := t.dispatcher.DescribeReConfig(newConfig)
+
+	return &types.ConfigTxDryRunResponse{
+		ValidationInfo:         valInfo,
+		NodesChanged:           changes.Nodes,
+		ConsensusChanged:       changes.Consensus,
+		CaChanged:              changes.CA,
+		AdminsChanged:          changes.Admins,
+		AddedMembers:           changes.AddedPeers,
+		RemovedMembers:         changes.RemovedPeers,
+		UpdatedMembers:         changes.ChangedPeers,
+		CurrentProtocolVersion: changes.CurrentProtocolVersion,
+		UpdatedProtocolVersion: changes.UpdatedProtocolVersion,
+	}, nil
+}
+
 func PrepareBootstrapConfigTx(conf *config.Configurations) (*types.ConfigTxEnvelope, error) {
 	certs, err := readCerts(conf)
 	if err != nil {
@@ -398,6 +710,8 @@ func PrepareBootstrapConfigTx(conf *config.Configurations) (*types.ConfigTxEnvel
 			Id:      node.NodeID,
 			Address: node.Host,
 			Port:    node.Port,
+			Region:  node.Region,
+			Zone:    node.Zone,
 		}
 		if cert, ok := certs.nodeCertificates[node.NodeID]; ok {
 			nc.Certificate = cert
@@ -480,10 +794,15 @@ func PrepareBootstrapConfigTx(conf *config.Configurations) (*types.ConfigTxEnvel
 			conf.LocalConfig.Server.Identity.ID, conf.SharedConfig.Consensus)
 	}
 	// TODO add support for observers, see issue: https://github.ibm.com/blockchaindb/server/issues/403
-	if inObservers {
+	// Currently, only a witness can be an observer, and a witness can only be an observer.
+	if inObservers && !conf.LocalConfig.Witness.Enabled {
 		return nil, errors.Errorf("not supported yet: local Server.Identity.ID [%s] is in SharedConfig.Consensus.Observers: %v",
 			conf.LocalConfig.Server.Identity.ID, conf.SharedConfig.Consensus)
 	}
+	if inMembers && conf.LocalConfig.Witness.Enabled {
+		return nil, errors.Errorf("a witness cannot take part in consensus: local Server.Identity.ID [%s] is in SharedConfig.Consensus.Members: %v",
+			conf.LocalConfig.Server.Identity.ID, conf.SharedConfig.Consensus)
+	}
 
 	return &types.ConfigTxEnvelope{
 		Payload: &types.ConfigTx{
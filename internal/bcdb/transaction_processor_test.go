@@ -9,6 +9,7 @@ import (
 	"math"
 	"os"
 	"path"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,13 +23,14 @@ import (
 	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
 	"github.com/hyperledger-labs/orion-server/internal/mtree"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/txidwindow"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
-	"github.com/hyperledger-labs/orion-server/pkg/state"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,6 +48,10 @@ type txProcessorTestEnv struct {
 }
 
 func newTxProcessorTestEnv(t *testing.T, cryptoDir string, conf *config.Configurations) *txProcessorTestEnv {
+	return newTxProcessorTestEnvWithCommitListeners(t, cryptoDir, conf, nil)
+}
+
+func newTxProcessorTestEnvWithCommitListeners(t *testing.T, cryptoDir string, conf *config.Configurations, commitListeners map[string]CommitListener) *txProcessorTestEnv {
 	dir := conf.LocalConfig.Server.Database.LedgerDirectory
 
 	c := &logger.Config{
@@ -122,6 +128,7 @@ func newTxProcessorTestEnv(t *testing.T, cryptoDir string, conf *config.Configur
 		blockStore:      blockStore,
 		provenanceStore: provenanceStore,
 		stateTrieStore:  stateTrieStore,
+		commitListeners: commitListeners,
 		logger:          lg,
 	}
 	txProcessor, err := newTransactionProcessor(txProcConf)
@@ -311,15 +318,17 @@ func TestTransactionProcessor(t *testing.T) {
 		require.NoError(t, err)
 		expectedBlock.Header.TxMerkelTreeRootHash = root.Hash()
 
-		stateTrie, err := mptrie.NewTrie(genesisHeader.StateMerkelTreeRootHash, env.stateTrieStore)
+		stateTrie, err := mptrie.NewStateTrie(genesisHeader.StateMerkelTreeRootHash, env.stateTrieStore)
 		require.NoError(t, err)
-		expectedBlock.Header.StateMerkelTreeRootHash = applyTxsOnTrie(t, env, expectedBlock.Payload.(*types.Block_DataTxEnvelopes).DataTxEnvelopes, stateTrie)
+		expectedBlock.Header.StateMerkelTreeRootHash, expectedBlock.Header.DbStateRootHashes = applyTxsOnTrie(t, env, expectedBlock.Payload.(*types.Block_DataTxEnvelopes).DataTxEnvelopes, stateTrie)
 
 		block, err := env.blockStore.Get(2)
 		require.NoError(t, err)
 		require.True(t, block.GetConsensusMetadata().GetRaftTerm() > 0)
 		require.True(t, block.GetConsensusMetadata().GetRaftIndex() > 0)
 		block.ConsensusMetadata = nil
+		require.True(t, block.GetHeader().GetBaseHeader().GetTimestamp() > 0)
+		expectedBlock.Header.BaseHeader.Timestamp = block.GetHeader().GetBaseHeader().GetTimestamp()
 		require.True(t, proto.Equal(expectedBlock, block), "expected: %+v, actual: %+v", expectedBlock, block)
 
 		noPendingTxs := func() bool {
@@ -399,9 +408,9 @@ func TestTransactionProcessor(t *testing.T) {
 			},
 		}
 
-		stateTrie, err := mptrie.NewTrie(genesisHeader.StateMerkelTreeRootHash, env.stateTrieStore)
+		stateTrie, err := mptrie.NewStateTrie(genesisHeader.StateMerkelTreeRootHash, env.stateTrieStore)
 		require.NoError(t, err)
-		expectedBlock.Header.StateMerkelTreeRootHash = applyTxsOnTrie(t, env, expectedBlock.Payload.(*types.Block_DataTxEnvelopes).DataTxEnvelopes, stateTrie)
+		expectedBlock.Header.StateMerkelTreeRootHash, expectedBlock.Header.DbStateRootHashes = applyTxsOnTrie(t, env, expectedBlock.Payload.(*types.Block_DataTxEnvelopes).DataTxEnvelopes, stateTrie)
 
 		root, err := mtree.BuildTreeForBlockTx(expectedBlock)
 		require.NoError(t, err)
@@ -411,6 +420,8 @@ func TestTransactionProcessor(t *testing.T) {
 		require.True(t, block.GetConsensusMetadata().GetRaftTerm() > 0)
 		require.True(t, block.GetConsensusMetadata().GetRaftIndex() > 0)
 		block.ConsensusMetadata = nil
+		require.True(t, block.GetHeader().GetBaseHeader().GetTimestamp() > 0)
+		expectedBlock.Header.BaseHeader.Timestamp = block.GetHeader().GetBaseHeader().GetTimestamp()
 		require.True(t, proto.Equal(expectedBlock, block))
 
 		expectedRespPayload := &types.TxReceiptResponse{
@@ -509,6 +520,52 @@ func TestTransactionProcessor(t *testing.T) {
 			return env.txProcessor.pendingTxs.Empty()
 		}
 		require.Eventually(t, noPendingTxs, time.Second*2, time.Millisecond*100)
+
+		committedTxIDsRemoved := func() bool {
+			for _, txID := range []string{"tx1", "tx2"} {
+				if inWindow, err := env.txProcessor.txIDWindow.Has(txID); err != nil || inWindow {
+					return false
+				}
+			}
+			return true
+		}
+		require.Eventually(t, committedTxIDsRemoved, time.Second*2, time.Millisecond*100)
+	})
+
+	t.Run("duplicate txID with a transaction accepted before a restart", func(t *testing.T) {
+		cryptoDir, conf := testConfiguration(t)
+		require.NotEqual(t, "", cryptoDir)
+		defer os.RemoveAll(conf.LocalConfig.Server.Database.LedgerDirectory)
+
+		lg, err := logger.New(&logger.Config{
+			Level:         "info",
+			OutputPath:    []string{"stdout"},
+			ErrOutputPath: []string{"stderr"},
+			Encoding:      "console",
+		})
+		require.NoError(t, err)
+
+		// tx1 was accepted, but lost before it was ordered, when the node restarted
+		window, err := txidwindow.Open(&txidwindow.Config{
+			StoreDir: constructTxIDWindowPath(conf.LocalConfig.Server.Database.LedgerDirectory),
+			Logger:   lg,
+		})
+		require.NoError(t, err)
+		require.NoError(t, window.Add("tx1"))
+		require.NoError(t, window.Close())
+
+		env := newTxProcessorTestEnv(t, cryptoDir, conf)
+		defer env.cleanup()
+
+		setupTxProcessor(t, env, worldstate.DefaultDBName)
+
+		dbTx := testutils.SignedDBAdministrationTxEnvelope(t, env.userSigner, &types.DBAdministrationTx{
+			UserId: "testUser",
+			TxId:   "tx1",
+		})
+		resp, err := env.txProcessor.SubmitTransaction(dbTx, 0)
+		require.EqualError(t, err, "the transaction has a duplicate txID [tx1]")
+		require.Nil(t, resp)
 	})
 
 	t.Run("unexpected transaction type", func(t *testing.T) {
@@ -557,6 +614,123 @@ func TestTransactionProcessor(t *testing.T) {
 		require.Nil(t, resp)
 	})
 
+	t.Run("transaction exceeds the maximal block size", func(t *testing.T) {
+		cryptoDir, conf := testConfiguration(t)
+		require.NotEqual(t, "", cryptoDir)
+		defer os.RemoveAll(conf.LocalConfig.Server.Database.LedgerDirectory)
+		conf.LocalConfig.BlockCreation.MaxBlockSizeBytes = 4096
+		env := newTxProcessorTestEnv(t, cryptoDir, conf)
+		defer env.cleanup()
+
+		setupTxProcessor(t, env, worldstate.DefaultDBName)
+
+		tx := testutils.SignedDataTxEnvelope(t, []crypto.Signer{env.userSigner}, &types.DataTx{
+			MustSignUserIds: []string{"testUser"},
+			TxId:            "large-tx",
+			DbOperations: []*types.DBOperation{
+				{
+					DbName: worldstate.DefaultDBName,
+					DataWrites: []*types.DataWrite{
+						{
+							Key:   "test-key1",
+							Value: make([]byte, 4096),
+						},
+					},
+				},
+			},
+		})
+
+		resp, err := env.txProcessor.SubmitTransaction(tx, 5*time.Second)
+		require.Error(t, err)
+		require.IsType(t, &internalerror.TxTooLargeError{}, err)
+		require.Contains(t, err.Error(), "exceeds the maximal transaction size [2560 bytes] allowed by the maximal block size")
+		require.Nil(t, resp)
+	})
+
+	t.Run("invalid config transaction is rejected before ordering", func(t *testing.T) {
+		cryptoDir, conf := testConfiguration(t)
+		require.NotEqual(t, "", cryptoDir)
+		defer os.RemoveAll(conf.LocalConfig.Server.Database.LedgerDirectory)
+		env := newTxProcessorTestEnv(t, cryptoDir, conf)
+		defer env.cleanup()
+
+		setupTxProcessor(t, env, worldstate.DefaultDBName)
+
+		_, adminSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "admin")
+		clusterConfig, _, err := env.db.GetConfig()
+		require.NoError(t, err)
+
+		configTx := testutils.SignedConfigTxEnvelope(t, adminSigner, &types.ConfigTx{
+			UserId:               "admin",
+			TxId:                 "config-tx1",
+			ReadOldConfigVersion: &types.Version{BlockNum: 10, TxNum: 0},
+			NewConfig:            clusterConfig,
+		})
+
+		resp, err := env.txProcessor.SubmitTransaction(configTx, 5*time.Second)
+		require.EqualError(t, err, "Invalid config tx, reason: mvcc conflict has occurred as the read old configuration does not match the committed version")
+		require.IsType(t, &internalerror.BadRequestError{}, err)
+		require.Nil(t, resp)
+		require.True(t, env.txProcessor.pendingTxs.Empty())
+
+		height, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), height)
+	})
+
+	t.Run("dry-run a config transaction", func(t *testing.T) {
+		cryptoDir, conf := testConfiguration(t)
+		require.NotEqual(t, "", cryptoDir)
+		defer os.RemoveAll(conf.LocalConfig.Server.Database.LedgerDirectory)
+		env := newTxProcessorTestEnv(t, cryptoDir, conf)
+		defer env.cleanup()
+
+		setupTxProcessor(t, env, worldstate.DefaultDBName)
+
+		_, adminSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "admin")
+		clusterConfig, metadata, err := env.db.GetConfig()
+		require.NoError(t, err)
+
+		newConfig := proto.Clone(clusterConfig).(*types.ClusterConfig)
+		newConfig.Nodes[0].Port++
+		configTx := testutils.SignedConfigTxEnvelope(t, adminSigner, &types.ConfigTx{
+			UserId:               "admin",
+			TxId:                 "config-tx1",
+			ReadOldConfigVersion: metadata.Version,
+			NewConfig:            newConfig,
+		})
+
+		resp, err := env.txProcessor.DryRunConfigTx(configTx)
+		require.NoError(t, err)
+		require.Equal(t, types.Flag_VALID, resp.GetValidationInfo().GetFlag())
+		require.True(t, resp.GetNodesChanged())
+		require.False(t, resp.GetConsensusChanged())
+		require.False(t, resp.GetCaChanged())
+		require.False(t, resp.GetAdminsChanged())
+		require.Len(t, resp.GetAddedMembers(), 0)
+		require.Len(t, resp.GetRemovedMembers(), 0)
+		require.Equal(t, uint32(1), resp.GetCurrentProtocolVersion())
+		require.Equal(t, uint32(1), resp.GetUpdatedProtocolVersion())
+
+		configTx = testutils.SignedConfigTxEnvelope(t, adminSigner, &types.ConfigTx{
+			UserId:               "admin",
+			TxId:                 "config-tx2",
+			ReadOldConfigVersion: &types.Version{BlockNum: 10, TxNum: 0},
+			NewConfig:            newConfig,
+		})
+
+		resp, err = env.txProcessor.DryRunConfigTx(configTx)
+		require.NoError(t, err)
+		require.Equal(t, types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE, resp.GetValidationInfo().GetFlag())
+		require.True(t, resp.GetNodesChanged())
+
+		// a dry run does not submit the transaction
+		height, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), height)
+		require.True(t, env.txProcessor.pendingTxs.Empty())
+	})
+
 	t.Run("create with a join block", func(t *testing.T) {
 		cryptoDir, conf := testJoinConfiguration(t)
 		require.NotEqual(t, "", cryptoDir)
@@ -585,6 +759,104 @@ func TestTransactionProcessor(t *testing.T) {
 	})
 }
 
+type recordingCommitListener struct {
+	mutex  sync.Mutex
+	blocks []uint64
+	flags  [][]types.Flag
+	err    error
+}
+
+func (l *recordingCommitListener) BlockCommitted(block *types.Block, validationInfo []*types.ValidationInfo) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.blocks = append(l.blocks, block.GetHeader().GetBaseHeader().GetNumber())
+	var flags []types.Flag
+	for _, info := range validationInfo {
+		flags = append(flags, info.Flag)
+	}
+	l.flags = append(l.flags, flags)
+
+	return l.err
+}
+
+func (l *recordingCommitListener) committed() ([]uint64, [][]types.Flag) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return append([]uint64(nil), l.blocks...), append([][]types.Flag(nil), l.flags...)
+}
+
+func TestTransactionProcessor_CommitListeners(t *testing.T) {
+	t.Run("listeners are invoked after every commit", func(t *testing.T) {
+		cryptoDir, conf := testConfiguration(t)
+		require.NotEqual(t, "", cryptoDir)
+		defer os.RemoveAll(conf.LocalConfig.Server.Database.LedgerDirectory)
+
+		listener := &recordingCommitListener{}
+		failingListener := &recordingCommitListener{err: errors.New("secondary store is down")}
+		env := newTxProcessorTestEnvWithCommitListeners(t, cryptoDir, conf, map[string]CommitListener{
+			"recorder": listener,
+			"failing":  failingListener,
+		})
+		defer env.cleanup()
+
+		setupTxProcessor(t, env, worldstate.DefaultDBName)
+
+		for i, txID := range []string{"tx1", "tx2"} {
+			tx := testutils.SignedDataTxEnvelope(t, []crypto.Signer{env.userSigner}, &types.DataTx{
+				MustSignUserIds: []string{"testUser"},
+				TxId:            txID,
+				DbOperations: []*types.DBOperation{
+					{
+						DbName: worldstate.DefaultDBName,
+						DataWrites: []*types.DataWrite{
+							{
+								Key:   "test-key1",
+								Value: []byte("test-value1"),
+							},
+						},
+					},
+				},
+			})
+
+			resp, err := env.txProcessor.SubmitTransaction(tx, 5*time.Second)
+			require.NoError(t, err)
+			require.Equal(t, uint64(i+2), resp.GetReceipt().GetHeader().GetBaseHeader().GetNumber())
+		}
+
+		// the genesis block is not delivered to the listeners, and a failing listener does not stop the committer
+		for _, l := range []*recordingCommitListener{listener, failingListener} {
+			require.Eventually(t, func() bool {
+				blocks, _ := l.committed()
+				return len(blocks) == 2
+			}, 5*time.Second, 100*time.Millisecond)
+			blocks, flags := l.committed()
+			require.Equal(t, []uint64{2, 3}, blocks)
+			require.Equal(t, [][]types.Flag{{types.Flag_VALID}, {types.Flag_VALID}}, flags)
+		}
+	})
+
+	t.Run("reserved listener name", func(t *testing.T) {
+		cryptoDir, conf := testConfiguration(t)
+		require.NotEqual(t, "", cryptoDir)
+		defer os.RemoveAll(conf.LocalConfig.Server.Database.LedgerDirectory)
+
+		env := newTxProcessorTestEnv(t, cryptoDir, conf)
+		defer env.cleanup()
+
+		err := registerCommitListeners(env.txProcessor.blockProcessor, map[string]CommitListener{
+			commitListenerName: &recordingCommitListener{},
+		}, env.txProcessor.logger)
+		require.EqualError(t, err, "commit listener name [transactionProcessor] is not allowed")
+
+		err = registerCommitListeners(env.txProcessor.blockProcessor, map[string]CommitListener{
+			"recorder": nil,
+		}, env.txProcessor.logger)
+		require.EqualError(t, err, "commit listener [recorder] is nil")
+	})
+}
+
 func testConfiguration(t *testing.T) (string, *config.Configurations) {
 	ledgerDir, err := ioutil.TempDir("/tmp", "server")
 	require.NoError(t, err)
@@ -615,7 +887,7 @@ func testConfiguration(t *testing.T) (string, *config.Configurations) {
 				LogLevel: "info",
 			},
 			BlockCreation: config.BlockCreationConf{
-				MaxBlockSize:                2,
+				MaxBlockSizeBytes:           1024 * 1024,
 				MaxTransactionCountPerBlock: 1,
 				BlockTimeout:                50 * time.Millisecond,
 			},
@@ -753,7 +1025,7 @@ func testJoinConfiguration(t *testing.T) (string, *config.Configurations) {
 				LogLevel: "info",
 			},
 			BlockCreation: config.BlockCreationConf{
-				MaxBlockSize:                2,
+				MaxBlockSizeBytes:           1024 * 1024,
 				MaxTransactionCountPerBlock: 1,
 				BlockTimeout:                50 * time.Millisecond,
 			},
@@ -767,7 +1039,7 @@ func testJoinConfiguration(t *testing.T) (string, *config.Configurations) {
 	}
 }
 
-func applyTxsOnTrie(t *testing.T, env *txProcessorTestEnv, payload interface{}, stateTrie *mptrie.MPTrie) []byte {
+func applyTxsOnTrie(t *testing.T, env *txProcessorTestEnv, payload interface{}, stateTrie *mptrie.StateTrie) ([]byte, []*types.DBStateRootHash) {
 	tempBlock := &types.Block{
 		Header: &types.BlockHeader{
 			ValidationInfo: []*types.ValidationInfo{
@@ -812,26 +1084,12 @@ func applyTxsOnTrie(t *testing.T, env *txProcessorTestEnv, payload interface{},
 
 	dbUpdates, err := blockprocessor.ConstructDBUpdatesForBlock(tempBlock, env.txProcessor.blockProcessor)
 	require.NoError(t, err)
-
-	for dbName, update := range dbUpdates {
-		for _, dbwrite := range update.Writes {
-			key, err := state.ConstructCompositeKey(dbName, dbwrite.Key)
-			require.NoError(t, err)
-			// TODO: should we add Metadata to value
-			value := dbwrite.Value
-			err = stateTrie.Update(key, value)
-			require.NoError(t, err)
-		}
-		for _, dbdelete := range update.Deletes {
-			key, err := state.ConstructCompositeKey(dbName, dbdelete)
-			require.NoError(t, err)
-			_, err = stateTrie.Delete(key)
-			require.NoError(t, err)
-		}
-	}
+	require.NoError(t, blockprocessor.ApplyBlockOnStateTrie(stateTrie, dbUpdates))
 
 	stateTrieRoot, err := stateTrie.Hash()
 	require.NoError(t, err)
+	dbStateTrieRoots, err := stateTrie.UpdatedDBRootHashes()
+	require.NoError(t, err)
 	require.NoError(t, env.stateTrieStore.RollbackChanges())
-	return stateTrieRoot
+	return stateTrieRoot, dbStateTrieRoots
 }
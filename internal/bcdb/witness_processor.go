@@ -0,0 +1,231 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package bcdb
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockcreator"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	internalerror "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
+	"github.com/hyperledger-labs/orion-server/internal/witness"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// witnessProcessor replaces the transactionProcessor on a witness node. It does not accept transactions and does not
+// take part in consensus; instead, it follows the ledger of the cluster and re-validates every block it commits.
+type witnessProcessor struct {
+	nodeID               string
+	blockOneQueueBarrier *queue.OneQueueBarrier
+	blockProcessor       *blockprocessor.BlockProcessor
+	witness              *witness.Witness
+	logger               *logger.SugarLogger
+}
+
+func newWitnessProcessor(conf *txProcessorConfig) (*witnessProcessor, error) {
+	p := &witnessProcessor{}
+
+	localConfig := conf.config.LocalConfig
+
+	p.nodeID = localConfig.Server.Identity.ID
+	p.logger = conf.logger
+	p.blockOneQueueBarrier = queue.NewOneQueueBarrier(conf.logger)
+
+	txValidator := txvalidation.NewValidator(
+		&txvalidation.Config{
+			DB:                  conf.db,
+			DBUsage:             conf.blockStore,
+			ConsensusAlgorithms: orderingAlgorithms(conf.orderingSvcs),
+			Logger:              conf.logger,
+		},
+	)
+
+	p.blockProcessor = blockprocessor.New(
+		&blockprocessor.Config{
+			NodeID:               p.nodeID,
+			BlockOneQueueBarrier: p.blockOneQueueBarrier,
+			BlockStore:           conf.blockStore,
+			ProvenanceStore:      conf.provenanceStore,
+			StateTrieStore:       conf.stateTrieStore,
+			Outbox:               conf.outbox,
+			Erasure:              conf.erasure,
+			IdentityCache:        conf.identityCache,
+			BlobStore:            conf.blobStore,
+			DB:                   conf.db,
+			TxValidator:          txValidator,
+			Quarantine:           conf.quarantine,
+			Logger:               conf.logger,
+		},
+	)
+
+	ledgerHeight, err := conf.blockStore.Height()
+	if err != nil {
+		return nil, err
+	}
+	if ledgerHeight == 0 {
+		p.logger.Info("Ledger is empty")
+		if conf.config.SharedConfig != nil {
+			p.logger.Info("Bootstrapping the ledger and database from SharedConfiguration")
+			tx, err := PrepareBootstrapConfigTx(conf.config)
+			if err != nil {
+				return nil, err
+			}
+			bootBlock, err := blockcreator.BootstrapBlock(tx)
+			if err != nil {
+				return nil, err
+			}
+			if err = p.blockProcessor.Bootstrap(bootBlock); err != nil {
+				return nil, err
+			}
+		} else if conf.config.JoinBlock != nil {
+			p.logger.Infof("Bootstrapping the ledger and database from the cluster using a join block, number: %d",
+				conf.config.JoinBlock.GetHeader().GetBaseHeader().GetNumber())
+		} else {
+			return nil, errors.New("missing bootstrap, no SharedConfig or JoinBlock")
+		}
+	}
+
+	// The join-block carries the most recent config only while the ledger is behind it.
+	var clusterConfig *types.ClusterConfig
+	if joinBlock := conf.config.JoinBlock; joinBlock != nil && ledgerHeight < joinBlock.GetHeader().GetBaseHeader().GetNumber() {
+		clusterConfig = joinBlock.GetPayload().(*types.Block_ConfigTxEnvelope).ConfigTxEnvelope.GetPayload().NewConfig
+		conf.logger.Debugf("Using cluster config from join-block: %+v", clusterConfig)
+	} else {
+		clusterConfig, _, err = conf.db.GetConfig()
+		if err != nil {
+			return nil, err
+		}
+		conf.logger.Debugf("Using cluster config from DB: %+v", clusterConfig)
+	}
+
+	if !isObserver(p.nodeID, clusterConfig) {
+		return nil, errors.Errorf("witness node [%s] must be defined as an observer in the consensus config: %v",
+			p.nodeID, clusterConfig.GetConsensusConfig())
+	}
+
+	// The transport is not started, as a witness does not take part in consensus; it only provides the TLS
+	// configuration used to pull blocks from the members.
+	peerTransport, err := comm.NewHTTPTransport(&comm.Config{
+		LocalConf:    localConfig,
+		Logger:       conf.logger,
+		LedgerReader: conf.blockStore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	puller := comm.NewCatchUpClient(conf.logger, peerTransport.ClientTLSConfig())
+	puller.SetCodecs(localConfig.Replication.Compression.Codecs)
+	puller.SetThrottle(comm.NewThrottle(localConfig.Replication.Throttle.CatchUpBytesPerSecond))
+	maxBlockSize, err := localConfig.BlockCreation.MaxBlockSizeInBytes()
+	if err != nil {
+		return nil, err
+	}
+	puller.SetMaxBlockSize(maxBlockSize)
+	if err = puller.UpdateMembers(clusterConfig.GetConsensusConfig().GetMembers()); err != nil {
+		return nil, err
+	}
+	p.blockProcessor.SetChunkPuller(puller)
+
+	p.witness = witness.New(
+		&witness.Config{
+			LedgerReader:         conf.blockStore,
+			BlockOneQueueBarrier: p.blockOneQueueBarrier,
+			BlockPuller:          puller,
+			HaltOnDiscrepancy:    localConfig.Witness.HaltOnDiscrepancy,
+			Logger:               conf.logger,
+		},
+	)
+
+	if err = registerCommitListeners(p.blockProcessor, conf.commitListeners, conf.logger); err != nil {
+		return nil, err
+	}
+
+	go p.blockProcessor.Start()
+	p.blockProcessor.WaitTillStart()
+
+	p.witness.Start()
+
+	return p, nil
+}
+
+func isObserver(nodeID string, clusterConfig *types.ClusterConfig) bool {
+	for _, o := range clusterConfig.GetConsensusConfig().GetObservers() {
+		if o.NodeId == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// SubmitTransaction is not supported by a witness node.
+func (w *witnessProcessor) SubmitTransaction(_ interface{}, _ time.Duration) (*types.TxReceiptResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + w.nodeID + "] is a witness and does not accept transactions"}
+}
+
+func (w *witnessProcessor) Close() error {
+	w.witness.Close()
+	w.blockProcessor.Stop()
+
+	return nil
+}
+
+// IsLeader always returns an error, as a witness node never leads the cluster.
+func (w *witnessProcessor) IsLeader() *internalerror.NotLeaderError {
+	return &internalerror.NotLeaderError{}
+}
+
+// CommitHalted returns the error on which the block processor halted the commit of blocks, or nil if it did not.
+func (w *witnessProcessor) CommitHalted() error {
+	return w.blockProcessor.Halted()
+}
+
+// ClusterStatus returns no leader and no active nodes, as a witness node does not take part in consensus.
+func (w *witnessProcessor) ClusterStatus() (leader string, active []string) {
+	return "", nil
+}
+
+// ConsensusDiagnostics is not supported by a witness node.
+func (w *witnessProcessor) ConsensusDiagnostics() (*types.GetConsensusDiagnosticsResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + w.nodeID + "] is a witness and does not take part in consensus"}
+}
+
+// WitnessStatus returns the verification status of the witness, and the discrepancies it most recently found.
+func (w *witnessProcessor) WitnessStatus() (*types.GetWitnessStatusResponse, error) {
+	return witnessStatus(w.witness), nil
+}
+
+// TriggerSnapshot is not supported by a witness node.
+func (w *witnessProcessor) TriggerSnapshot() (*types.TriggerSnapshotResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + w.nodeID + "] is a witness and does not take part in consensus"}
+}
+
+// DryRunConfigTx is not supported by a witness node.
+func (w *witnessProcessor) DryRunConfigTx(_ *types.ConfigTxEnvelope) (*types.ConfigTxDryRunResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + w.nodeID + "] is a witness and does not accept transactions"}
+}
+
+// TransferLeadership is not supported by a witness node.
+func (w *witnessProcessor) TransferLeadership(_ string, _ time.Duration) (*types.TransferLeadershipResponse, error) {
+	return nil, &internalerror.BadRequestError{ErrMsg: "node [" + w.nodeID + "] is a witness and does not take part in consensus"}
+}
+
+func witnessStatus(w *witness.Witness) *types.GetWitnessStatusResponse {
+	verifiedHeight, halted := w.Status()
+	resp := &types.GetWitnessStatusResponse{
+		VerifiedHeight: verifiedHeight,
+		Halted:         halted,
+	}
+	for _, alert := range w.Alerts() {
+		resp.Alerts = append(resp.Alerts, &types.WitnessAlert{
+			BlockNumber: alert.BlockNumber,
+			Reason:      alert.Reason,
+			Time:        alert.Time.UnixNano(),
+		})
+	}
+	return resp
+}
@@ -4,26 +4,49 @@
 package bcdb
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/dbfreeze"
 	"github.com/hyperledger-labs/orion-server/internal/errors"
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
+	"github.com/hyperledger-labs/orion-server/internal/migration"
 	"github.com/hyperledger-labs/orion-server/internal/queryexecutor"
+	"github.com/hyperledger-labs/orion-server/internal/redaction"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 )
 
+// defaultSnapshotBytes is the memory charged to the memory budget for every DB snapshot, unless configured otherwise
+const defaultSnapshotBytes = 4 * 1024 * 1024
+
+// queryStreamBufferSize is the number of the key-value pairs of a streamed query result that are read ahead of the
+// writes of the response
+const queryStreamBufferSize = 64
+
 type worldstateQueryProcessor struct {
 	nodeID          string
 	db              worldstate.DB
 	blockStore      *blockstore.Store
 	identityQuerier *identity.Querier
+	memBudget       *membudget.Accountant
+	snapshotBytes   uint64
+	memBudgetWait   time.Duration
+	cursors         *dataCursorPool
+	slowQueries     *slowQueryLog
+	indexUsage      *queryexecutor.IndexUsage
 	logger          *logger.SugarLogger
 }
 
@@ -32,19 +55,85 @@ type worldstateQueryProcessorConfig struct {
 	db              worldstate.DB
 	blockStore      *blockstore.Store
 	identityQuerier *identity.Querier
-	logger          *logger.SugarLogger
+	// memBudget limits the memory held by the snapshots and the results of the queries; nil means no limit.
+	memBudget *membudget.Accountant
+	// snapshotBytes is the memory charged for every snapshot; if zero, defaultSnapshotBytes is used.
+	snapshotBytes uint64
+	// memBudgetWait is the maximal time a query waits for memory before it is rejected.
+	memBudgetWait time.Duration
+	// slowQueryThreshold is the execution time above which a query is logged; zero disables the slow query log.
+	slowQueryThreshold time.Duration
+	// maxSlowQueries is the number of slow queries held; if zero, DefaultMaxSlowQueries is used.
+	maxSlowQueries uint32
+	logger         *logger.SugarLogger
 }
 
 func newWorldstateQueryProcessor(conf *worldstateQueryProcessorConfig) *worldstateQueryProcessor {
+	snapshotBytes := conf.snapshotBytes
+	if snapshotBytes == 0 {
+		snapshotBytes = defaultSnapshotBytes
+	}
+
 	return &worldstateQueryProcessor{
 		nodeID:          conf.nodeID,
 		db:              conf.db,
 		blockStore:      conf.blockStore,
 		identityQuerier: conf.identityQuerier,
+		memBudget:       conf.memBudget,
+		snapshotBytes:   snapshotBytes,
+		memBudgetWait:   conf.memBudgetWait,
+		cursors:         newDataCursorPool(),
+		slowQueries:     newSlowQueryLog(conf.nodeID, conf.slowQueryThreshold, conf.maxSlowQueries, conf.logger),
+		indexUsage:      queryexecutor.NewIndexUsage(),
 		logger:          conf.logger,
 	}
 }
 
+// budgetedSnapshot is a DBsSnapshot whose memory is charged to the memory budget until it is released
+type budgetedSnapshot struct {
+	worldstate.DBsSnapshot
+	release func()
+}
+
+func (s *budgetedSnapshot) Release() {
+	s.DBsSnapshot.Release()
+	s.release()
+}
+
+// getDBsSnapshot charges a snapshot to the memory budget, waiting at most memBudgetWait for the memory, and takes a
+// snapshot of the given databases on behalf of the owner. The memory is returned to the budget when the snapshot is released.
+func (q *worldstateQueryProcessor) getDBsSnapshot(ctx context.Context, owner string, dbNames []string) (worldstate.DBsSnapshot, error) {
+	ctx, cancel := context.WithTimeout(ctx, q.memBudgetWait)
+	defer cancel()
+	if err := q.memBudget.Acquire(ctx, membudget.KindSnapshot, q.snapshotBytes); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := q.db.GetDBsSnapshot(owner, dbNames)
+	if err != nil {
+		q.memBudget.Release(membudget.KindSnapshot, q.snapshotBytes)
+		return nil, err
+	}
+
+	return &budgetedSnapshot{
+		DBsSnapshot: snapshots,
+		release: func() {
+			q.memBudget.Release(membudget.KindSnapshot, q.snapshotBytes)
+		},
+	}, nil
+}
+
+// growResult charges n more bytes of a query result to the memory budget, waiting at most memBudgetWait for the memory
+func (q *worldstateQueryProcessor) growResult(ctx context.Context, result *membudget.Reservation, n uint64) error {
+	if !q.memBudget.Enabled() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, q.memBudgetWait)
+	defer cancel()
+	return result.Grow(ctx, n)
+}
+
 func (q *worldstateQueryProcessor) isDBExists(name string) bool {
 	return q.db.Exist(name)
 }
@@ -53,11 +142,108 @@ func (q *worldstateQueryProcessor) isDBExists(name string) bool {
 func (q *worldstateQueryProcessor) getDBStatus(dbName string) (*types.GetDBStatusResponse, error) {
 	// ACL is meaningless here as this call is to check whether a DB exist. Even with ACL,
 	// the user can infer the information.
+	exist := q.isDBExists(dbName)
+	if !exist {
+		return &types.GetDBStatusResponse{}, nil
+	}
+
+	freeze, err := dbfreeze.Get(q.db, dbName)
+	if err != nil {
+		return nil, err
+	}
+
 	return &types.GetDBStatusResponse{
-		Exist: q.isDBExists(dbName),
+		Exist:  exist,
+		Freeze: freeze,
 	}, nil
 }
 
+// getDBs returns the databases the querier can access, ordered by their name. When a prefix is given, only the
+// databases in the hierarchical namespace denoted by the prefix are returned, i.e., the prefix org1/app2 matches
+// org1/app2 and org1/app2/db but not org1/app20. A user can access a database when it holds a read or read-write
+// privilege on it, or a db administration privilege covering it, while an admin can access all databases. The first
+// offset databases are skipped, and up to limit databases are returned, where zero means no limit.
+func (q *worldstateQueryProcessor) getDBs(querierUserID, prefix string, offset, limit uint64) (*types.GetDBsResponse, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	dbNames := append(q.db.ListDBs(), worldstate.DefaultDBName)
+	sort.Strings(dbNames)
+
+	var accessibleDBs []string
+	for _, dbName := range dbNames {
+		if strings.HasPrefix(dbName, stateindex.IndexDB("")) {
+			continue
+		}
+		if prefix != "" && dbName != prefix && !strings.HasPrefix(dbName, prefix+"/") {
+			continue
+		}
+
+		hasPerm, err := q.identityQuerier.HasReadAccessOnDataDB(querierUserID, dbName)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPerm {
+			if hasPerm, err = q.identityQuerier.HasDBAdministrationPrivilege(querierUserID, dbName); err != nil {
+				return nil, err
+			}
+		}
+		if hasPerm {
+			accessibleDBs = append(accessibleDBs, dbName)
+		}
+	}
+
+	start, end, hasMore := pageBounds(len(accessibleDBs), offset, limit)
+
+	var names []string
+	var dbs []*types.DBInfo
+	for _, dbName := range accessibleDBs[start:end] {
+		indexDef, metadata, err := q.db.GetIndexDefinition(dbName)
+		if err != nil {
+			return nil, err
+		}
+
+		info := &types.DBInfo{
+			Name:    dbName,
+			Version: metadata.GetVersion(),
+		}
+		if indexDef != nil {
+			index := map[string]types.IndexAttributeType{}
+			if err := json.Unmarshal(indexDef, &index); err != nil {
+				return nil, err
+			}
+			for attr := range index {
+				info.IndexedAttributes = append(info.IndexedAttributes, attr)
+			}
+			sort.Strings(info.IndexedAttributes)
+		}
+
+		names = append(names, dbName)
+		dbs = append(dbs, info)
+	}
+
+	return &types.GetDBsResponse{
+		DbNames: names,
+		HasMore: hasMore,
+		Dbs:     dbs,
+	}, nil
+}
+
+// pageBounds returns the bounds of the page that skips the first offset items out of total items and holds up to
+// limit items, where zero means no limit, along with whether more items are available after the page.
+func pageBounds(total int, offset, limit uint64) (start, end int, hasMore bool) {
+	if offset >= uint64(total) {
+		return total, total, false
+	}
+
+	start = int(offset)
+	end = total
+	if limit > 0 && limit < uint64(total-start) {
+		end = start + int(limit)
+	}
+
+	return start, end, end < total
+}
+
 // getState return the state associated with a given key
 func (q *worldstateQueryProcessor) getData(dbName, querierUserID, key string) (*types.GetDataResponse, error) {
 	if worldstate.IsSystemDB(dbName) {
@@ -82,21 +268,118 @@ func (q *worldstateQueryProcessor) getData(dbName, querierUserID, key string) (*
 		return nil, err
 	}
 
-	acl := metadata.GetAccessControl()
-	if acl != nil {
-		if !acl.ReadUsers[querierUserID] && !acl.ReadWriteUsers[querierUserID] {
+	policy, canRead := redaction.ReadAccess(metadata.GetAccessControl(), querierUserID)
+	if !canRead {
+		return nil, &errors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to read key [" + key + "] from database [" + dbName + "]",
+		}
+	}
+	if policy != nil {
+		if value, err = redaction.Redact(value, policy); err != nil {
 			return nil, &errors.PermissionErr{
-				ErrMsg: "the user [" + querierUserID + "] has no permission to read key [" + key + "] from database [" + dbName + "]",
+				ErrMsg: "the user [" + querierUserID + "] can read only a redacted view of key [" + key + "] from database [" + dbName + "], " +
+					"but its value is not a JSON object",
 			}
 		}
+
+		return &types.GetDataResponse{
+			Value:    value,
+			Metadata: metadata,
+		}, nil
+	}
+
+	manifest, err := q.db.GetBlobManifest(dbName, key)
+	if err != nil {
+		return nil, err
 	}
 
 	return &types.GetDataResponse{
-		Value:    value,
-		Metadata: metadata,
+		Value:        value,
+		Metadata:     metadata,
+		BlobManifest: manifest,
 	}, nil
 }
 
+// getDataKeys returns the keys of a database that start with the given prefix, ordered lexicographically, or only
+// their count when countOnly is set. Keys whose access control does not list the querier as a reader are left out.
+// The keys are fetched from the startKey, inclusive, and up to limit keys are fetched, where zero means no limit. When
+// more keys are available, the response holds the key from which the next page is fetched. The keys are read from a
+// snapshot of the database so that a concurrent commit does not affect the result. Both the snapshot and the
+// collected keys are charged to the memory budget.
+func (q *worldstateQueryProcessor) getDataKeys(dbName, querierUserID, prefix, startKey string, limit uint64, countOnly bool) (*types.GetDataKeysResponse, error) {
+	if worldstate.IsSystemDB(dbName) {
+		return nil, &errors.PermissionErr{
+			ErrMsg: "no user can directly read from a system database [" + dbName + "]. " +
+				"To read from a system database, use /config, /user, /db rest endpoints instead of /data",
+		}
+	}
+
+	hasPerm, err := q.identityQuerier.HasReadAccessOnDataDB(querierUserID, dbName)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPerm {
+		return nil, &errors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to read from database [" + dbName + "]",
+		}
+	}
+
+	snapshots, err := q.getDBsSnapshot(context.Background(), "keys query", []string{dbName})
+	if err != nil {
+		return nil, err
+	}
+	defer snapshots.Release()
+
+	result := q.memBudget.NewReservation(membudget.KindQueryResult)
+	defer result.Release()
+
+	from := prefix
+	if startKey > from {
+		from = startKey
+	}
+	itr, err := snapshots.GetIterator(dbName, from, "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	resp := &types.GetDataKeysResponse{}
+	for itr.Next() {
+		key := string(itr.Key())
+		if !strings.HasPrefix(key, prefix) {
+			break
+		}
+
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, err
+		}
+
+		if _, canRead := redaction.ReadAccess(persisted.GetMetadata().GetAccessControl(), querierUserID); !canRead {
+			continue
+		}
+
+		if limit > 0 && resp.Count == limit {
+			resp.HasMore = true
+			resp.NextStartKey = key
+			break
+		}
+
+		resp.Count++
+		if !countOnly {
+			if err := q.growResult(context.Background(), result, uint64(len(key))); err != nil {
+				return nil, err
+			}
+			resp.Keys = append(resp.Keys, key)
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (q *worldstateQueryProcessor) getUser(querierUserID, targetUserID string) (*types.GetUserResponse, error) {
 	user, metadata, err := q.identityQuerier.GetUser(targetUserID)
 	if err != nil {
@@ -120,6 +403,48 @@ func (q *worldstateQueryProcessor) getUser(querierUserID, targetUserID string) (
 	}, nil
 }
 
+// getUsers returns the users the querier can read, ordered by their ID. When a prefix is given, only the users whose
+// ID starts with the prefix are returned. An admin can read all users while other users can read themselves and the
+// users whose access control lists them as readers. The first offset users are skipped, and up to limit users are
+// returned, where zero means no limit.
+func (q *worldstateQueryProcessor) getUsers(querierUserID, prefix string, offset, limit uint64) (*types.GetUsersResponse, error) {
+	isAdmin, err := q.identityQuerier.HasAdministrationPrivilege(querierUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, metadata, err := q.identityQuerier.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	var readableUsers []*types.UserInfo
+	for i, user := range users {
+		if !strings.HasPrefix(user.Id, prefix) {
+			continue
+		}
+
+		acl := metadata[i].GetAccessControl()
+		if !isAdmin && user.Id != querierUserID && !acl.GetReadUsers()[querierUserID] && !acl.GetReadWriteUsers()[querierUserID] {
+			continue
+		}
+
+		readableUsers = append(readableUsers, &types.UserInfo{
+			Id:        user.Id,
+			Version:   metadata[i].GetVersion(),
+			Privilege: user.Privilege,
+			Disabled:  user.Disabled,
+		})
+	}
+
+	start, end, hasMore := pageBounds(len(readableUsers), offset, limit)
+
+	return &types.GetUsersResponse{
+		Users:   readableUsers[start:end],
+		HasMore: hasMore,
+	}, nil
+}
+
 func (q *worldstateQueryProcessor) getConfig(querierUserID string) (*types.GetConfigResponse, error) {
 	// Limited access to admins only. Regular users can use the `GetNodeConfig` or `GetClusterStatus` APIs to discover
 	// and fetch the details of nodes that are needed for external cluster access.
@@ -138,12 +463,165 @@ func (q *worldstateQueryProcessor) getConfig(querierUserID string) (*types.GetCo
 		return nil, err
 	}
 
+	// An admin that is bound to the nodes of its organization reads only the sections of the config relevant to
+	// these nodes, unless it is a super admin. Admins that are not bound to any node read the full config.
+	if admin := scopedAdmin(config, querierUserID); admin != nil {
+		scoped, err := scopeClusterConfig(config, admin)
+		if err != nil {
+			return nil, err
+		}
+		q.logger.Infof("audit: admin [%s] read the cluster config scoped to nodes %v, config version: %s",
+			querierUserID, admin.NodeIds, metadata.GetVersion())
+
+		return &types.GetConfigResponse{
+			Config:   scoped,
+			Metadata: metadata,
+		}, nil
+	}
+
+	q.logger.Infof("audit: admin [%s] read the full cluster config, config version: %s", querierUserID, metadata.GetVersion())
+
 	return &types.GetConfigResponse{
 		Config:   config,
 		Metadata: metadata,
 	}, nil
 }
 
+// scopedAdmin returns the entry of the given user in the cluster config if the user is an admin that is bound to the
+// nodes of its organization and is not a super admin, i.e., an admin that reads only the sections of the config
+// relevant to these nodes, see scopeClusterConfig. Otherwise, it returns nil.
+func scopedAdmin(config *types.ClusterConfig, userID string) *types.Admin {
+	admin := findAdmin(config.GetAdmins(), userID)
+	if admin == nil || len(admin.NodeIds) == 0 || admin.SuperAdmin {
+		return nil
+	}
+	return admin
+}
+
+// checkFullConfigAccess rejects the reads of the full cluster config by a scoped admin, e.g., of a config block or of
+// the changes between two configs, as a signed block or a diff cannot be scoped.
+func checkFullConfigAccess(db worldstate.DB, userID string) error {
+	config, _, err := db.GetConfig()
+	if err != nil {
+		return err
+	}
+	if admin := scopedAdmin(config, userID); admin != nil {
+		return &errors.PermissionErr{
+			ErrMsg: fmt.Sprintf("the admin [%s] is scoped to the nodes %v and has no permission to read the full cluster config", userID, admin.NodeIds),
+		}
+	}
+	return nil
+}
+
+func findAdmin(admins []*types.Admin, adminID string) *types.Admin {
+	for _, a := range admins {
+		if a.GetId() == adminID {
+			return a
+		}
+	}
+	return nil
+}
+
+// scopeClusterConfig returns the parts of the cluster config that are relevant to the given admin: the nodes the admin
+// is bound to, their consensus peer entries, the admin itself, and the CA certificates that issued the certificates
+// of the admin and of its nodes. The consensus algorithm, raft parameters, and protocol version are kept as is.
+func scopeClusterConfig(config *types.ClusterConfig, admin *types.Admin) (*types.ClusterConfig, error) {
+	nodeIDs := make(map[string]bool)
+	for _, id := range admin.NodeIds {
+		nodeIDs[id] = true
+	}
+
+	scoped := &types.ClusterConfig{
+		Admins:          []*types.Admin{admin},
+		CertAuthConfig:  &types.CAConfig{},
+		ProtocolVersion: config.ProtocolVersion,
+	}
+
+	leafCerts := [][]byte{admin.Certificate}
+	for _, n := range config.Nodes {
+		if nodeIDs[n.Id] {
+			scoped.Nodes = append(scoped.Nodes, n)
+			leafCerts = append(leafCerts, n.Certificate)
+		}
+	}
+
+	if consensus := config.ConsensusConfig; consensus != nil {
+		scoped.ConsensusConfig = &types.ConsensusConfig{
+			Algorithm:  consensus.Algorithm,
+			RaftConfig: consensus.RaftConfig,
+		}
+		for _, m := range consensus.Members {
+			if nodeIDs[m.NodeId] {
+				scoped.ConsensusConfig.Members = append(scoped.ConsensusConfig.Members, m)
+			}
+		}
+		for _, o := range consensus.Observers {
+			if nodeIDs[o.NodeId] {
+				scoped.ConsensusConfig.Observers = append(scoped.ConsensusConfig.Observers, o)
+			}
+		}
+	}
+
+	// the certificates may be issued by retiring CAs
+	caCertCollection, err := certificateauthority.NewCACertCollection(certificateauthority.AllCAs(config.GetCertAuthConfig()))
+	if err != nil {
+		return nil, err
+	}
+	relevantCAs := make(map[string]bool)
+	for _, cert := range leafCerts {
+		cas, err := caCertCollection.GetLeafCertChainCAs(cert)
+		if err != nil {
+			return nil, err
+		}
+		for _, ca := range cas {
+			relevantCAs[string(ca)] = true
+		}
+	}
+	for _, root := range config.GetCertAuthConfig().GetRoots() {
+		if relevantCAs[string(root)] {
+			scoped.CertAuthConfig.Roots = append(scoped.CertAuthConfig.Roots, root)
+		}
+	}
+	for _, intermediate := range config.GetCertAuthConfig().GetIntermediates() {
+		if relevantCAs[string(intermediate)] {
+			scoped.CertAuthConfig.Intermediates = append(scoped.CertAuthConfig.Intermediates, intermediate)
+		}
+	}
+	for _, retiring := range config.GetCertAuthConfig().GetRetiring() {
+		scopedRetiring := &types.RetiringCAs{TrustedUntilBlock: retiring.TrustedUntilBlock}
+		for _, root := range retiring.Roots {
+			if relevantCAs[string(root)] {
+				scopedRetiring.Roots = append(scopedRetiring.Roots, root)
+			}
+		}
+		for _, intermediate := range retiring.Intermediates {
+			if relevantCAs[string(intermediate)] {
+				scopedRetiring.Intermediates = append(scopedRetiring.Intermediates, intermediate)
+			}
+		}
+		if len(scopedRetiring.Roots)+len(scopedRetiring.Intermediates) > 0 {
+			scoped.CertAuthConfig.Retiring = append(scoped.CertAuthConfig.Retiring, scopedRetiring)
+		}
+	}
+	for _, scope := range config.GetCertAuthConfig().GetScopes() {
+		scopedScope := &types.CAScope{
+			Organization:   scope.Organization,
+			UserIdPrefixes: scope.UserIdPrefixes,
+			NodeIds:        scope.NodeIds,
+		}
+		for _, ca := range scope.Cas {
+			if relevantCAs[string(ca)] {
+				scopedScope.Cas = append(scopedScope.Cas, ca)
+			}
+		}
+		if len(scopedScope.Cas) > 0 {
+			scoped.CertAuthConfig.Scopes = append(scoped.CertAuthConfig.Scopes, scopedScope)
+		}
+	}
+
+	return scoped, nil
+}
+
 func (q *worldstateQueryProcessor) getNodeConfigAndMetadata() ([]*types.NodeConfig, *types.Metadata, error) {
 	config, metadata, err := q.db.GetConfig()
 	if err != nil {
@@ -169,15 +647,18 @@ func (q *worldstateQueryProcessor) getNodeConfig(nodeID string) (*types.GetNodeC
 }
 
 func (q *worldstateQueryProcessor) getConfigBlock(querierUserID string, blockNumber uint64) (*types.GetConfigBlockResponse, error) {
-	isAdmin, err := q.identityQuerier.HasAdministrationPrivilege(querierUserID)
+	hasAccess, err := q.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_CONFIG_HISTORY)
 	if err != nil {
 		return nil, err
 	}
-	if !isAdmin {
+	if !hasAccess {
 		return nil, &errors.PermissionErr{
 			ErrMsg: "the user [" + querierUserID + "] has no permission to read a config block",
 		}
 	}
+	if err := checkFullConfigAccess(q.db, querierUserID); err != nil {
+		return nil, err
+	}
 
 	if blockNumber == 0 {
 		_, metadata, err := q.db.GetConfig()
@@ -203,9 +684,151 @@ func (q *worldstateQueryProcessor) getConfigBlock(querierUserID string, blockNum
 	}, nil
 }
 
+// executeJSONQuery returns the key-value pairs of a database that match the JSON query and are readable by the querier.
+// Both the snapshot and the collected key-value pairs are charged to the memory budget, and the query is rejected
+// with a *errors.ResourceExhaustedError if the memory cannot be acquired in time.
 func (q *worldstateQueryProcessor) executeJSONQuery(ctx context.Context, dbName, querierUserID string, query []byte) (*types.DataQueryResponse, error) {
-	if worldstate.IsSystemDB(dbName) {
+	return q.executeQuery(ctx, dbName, querierUserID, query, "")
+}
+
+// executeSQLQuery returns the key-value pairs that match a compiled SQL query and are readable by the querier, ordered
+// by the attribute of the query, or by key if the query has none, and bounded by the limit of the query
+func (q *worldstateQueryProcessor) executeSQLQuery(ctx context.Context, querierUserID string, query *queryexecutor.SQLQuery) (*types.DataQueryResponse, error) {
+	response, err := q.executeQuery(ctx, query.DBName, querierUserID, query.Selector, query.OrderBy)
+	if err != nil || response == nil {
+		return response, err
+	}
+
+	sortKVsByAttribute(response.KVs, query.OrderBy, query.Descending)
+	if query.Limit > 0 && uint64(len(response.KVs)) > query.Limit {
+		response.KVs = response.KVs[:query.Limit]
+	}
+
+	return response, nil
+}
+
+// explainJSONQuery returns the plan of a JSON query on a database that is readable by the querier, without executing
+// the query. A query that is not valid on the indexes of the database is rejected with a *errors.BadRequestError.
+func (q *worldstateQueryProcessor) explainJSONQuery(ctx context.Context, dbName, querierUserID string, query []byte) (*types.ExplainJSONQueryResponse, error) {
+	if err := q.checkReadAccessOnDataDB(dbName, querierUserID); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := q.getDBsSnapshot(ctx, "query plan", []string{worldstate.DatabasesDBName})
+	if err != nil {
+		return nil, err
+	}
+	defer snapshots.Release()
+
+	plan, err := queryexecutor.NewWorldStateJSONQueryExecutor(snapshots, q.logger).ExplainQuery(dbName, query)
+	if err != nil {
+		return nil, &ierrors.BadRequestError{ErrMsg: err.Error()}
+	}
+
+	return &types.ExplainJSONQueryResponse{
+		Plan: plan,
+	}, nil
+}
+
+// getIndexUsage returns the usage of the index of every indexed attribute of the data databases by the executed queries,
+// or of the indexes that no query used if unusedOnly is set. By default, only admin users can get the index usage.
+func (q *worldstateQueryProcessor) getIndexUsage(querierUserID string, unusedOnly bool) (*types.GetIndexUsageResponse, error) {
+	hasAccess, err := q.identityQuerier.HasEndpointAccess(querierUserID, types.EndpointPolicy_OPERATIONS)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
 		return nil, &errors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the index usage",
+		}
+	}
+
+	dbNames := append(q.db.ListDBs(), worldstate.DefaultDBName)
+	sort.Strings(dbNames)
+
+	var indexes []*types.IndexAttributeUsage
+	for _, dbName := range dbNames {
+		if stateindex.IsIndexDB(dbName) {
+			continue
+		}
+
+		index, err := stateindex.Index(q.db, dbName)
+		if err != nil {
+			return nil, err
+		}
+		attrs := make([]string, 0, len(index))
+		for attr := range index {
+			attrs = append(attrs, attr)
+		}
+		sort.Strings(attrs)
+
+		for _, attr := range attrs {
+			hits, lastUsed := q.indexUsage.Get(dbName, attr)
+			if unusedOnly && hits > 0 {
+				continue
+			}
+
+			usage := &types.IndexAttributeUsage{
+				DbName:    dbName,
+				Attribute: attr,
+				Type:      index[attr],
+				Hits:      hits,
+			}
+			if !lastUsed.IsZero() {
+				usage.LastUsed = lastUsed.UnixNano()
+			}
+			indexes = append(indexes, usage)
+		}
+	}
+
+	return &types.GetIndexUsageResponse{
+		Indexes:      indexes,
+		TrackedSince: q.indexUsage.Since().UnixNano(),
+	}, nil
+}
+
+// getMigrations returns the state of a migration or, if the name is empty, of all migrations. Only admin users and
+// the users who hold a db administration privilege can get the migrations. A migration of which no version was ever
+// applied is returned at version zero.
+func (q *worldstateQueryProcessor) getMigrations(querierUserID, name string) (*types.GetMigrationsResponse, error) {
+	user, _, err := q.identityQuerier.GetUser(querierUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.GetPrivilege().GetAdmin() && len(user.GetPrivilege().GetDbAdministrationPrefixes()) == 0 {
+		return nil, &errors.PermissionErr{
+			ErrMsg: "the user [" + querierUserID + "] has no permission to get the migrations",
+		}
+	}
+
+	if name == "" {
+		states, err := migration.List(q.db)
+		if err != nil {
+			return nil, err
+		}
+		return &types.GetMigrationsResponse{
+			Migrations: states,
+		}, nil
+	}
+
+	state, err := migration.Get(q.db, name)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state = &types.MigrationState{Name: name}
+	}
+
+	return &types.GetMigrationsResponse{
+		Migrations: []*types.MigrationState{state},
+	}, nil
+}
+
+// checkReadAccessOnDataDB returns a *errors.PermissionErr if the database is a system database, or the querier has no
+// permission to read from it
+func (q *worldstateQueryProcessor) checkReadAccessOnDataDB(dbName, querierUserID string) error {
+	if worldstate.IsSystemDB(dbName) {
+		return &errors.PermissionErr{
 			ErrMsg: "no user can directly read from a system database [" + dbName + "]. " +
 				"To read from a system database, use /config, /user, /db rest endpoints instead of /data",
 		}
@@ -213,15 +836,115 @@ func (q *worldstateQueryProcessor) executeJSONQuery(ctx context.Context, dbName,
 
 	hasPerm, err := q.identityQuerier.HasReadAccessOnDataDB(querierUserID, dbName)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if !hasPerm {
-		return nil, &errors.PermissionErr{
+		return &errors.PermissionErr{
 			ErrMsg: "the user [" + querierUserID + "] has no permission to read from database [" + dbName + "]",
 		}
 	}
 
-	snapshots, err := q.db.GetDBsSnapshot(
+	return nil
+}
+
+// executeQuery executes a JSON query. When the results are ordered by an attribute, a value is matched for a user who
+// reads a redacted view of it only if the attribute is not redacted either. A query whose execution takes more than the
+// slow query threshold is recorded in the slow query log, with its plan.
+func (q *worldstateQueryProcessor) executeQuery(ctx context.Context, dbName, querierUserID string, query []byte, orderBy string) (*types.DataQueryResponse, error) {
+	start := time.Now()
+
+	match, err := q.matchQuery(ctx, dbName, querierUserID, query, orderBy)
+	if err != nil || match == nil {
+		return nil, err
+	}
+	defer match.snapshots.Release()
+
+	result := q.memBudget.NewReservation(membudget.KindQueryResult)
+	defer result.Release()
+
+	var results []*types.KVWithMetadata
+
+	for k := range match.keys {
+		select {
+		case <-ctx.Done():
+			return nil, nil
+		default:
+			kv, err := q.readMatchedKey(match, dbName, querierUserID, k)
+			if err != nil {
+				return nil, err
+			}
+			if kv == nil {
+				continue
+			}
+
+			if err := q.growResult(ctx, result, uint64(len(kv.Key)+len(kv.Value)+proto.Size(kv.Metadata))); err != nil {
+				return nil, err
+			}
+			results = append(results, kv)
+		}
+	}
+
+	q.recordIfSlow(start, dbName, querierUserID, query, match, len(results))
+
+	return &types.DataQueryResponse{
+		KVs: results,
+	}, nil
+}
+
+// streamJSONQuery executes a JSON query, and sends the key-value pairs that match it and are readable by the querier to
+// the kvs channel as they are read, rather than collecting them, so that the memory held by the result is bounded by
+// the capacity of the channel. It returns once all the key-value pairs are sent, or the context is done.
+func (q *worldstateQueryProcessor) streamJSONQuery(ctx context.Context, dbName, querierUserID string, query []byte, kvs chan<- *types.KVWithMetadata) error {
+	start := time.Now()
+
+	match, err := q.matchQuery(ctx, dbName, querierUserID, query, "")
+	if err != nil || match == nil {
+		return err
+	}
+	defer match.snapshots.Release()
+
+	sent := 0
+	for k := range match.keys {
+		kv, err := q.readMatchedKey(match, dbName, querierUserID, k)
+		if err != nil {
+			return err
+		}
+		if kv == nil {
+			continue
+		}
+
+		select {
+		case kvs <- kv:
+			sent++
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	q.recordIfSlow(start, dbName, querierUserID, query, match, sent)
+	return nil
+}
+
+// queryMatch holds the keys matched by a JSON query, and the snapshot from which their values are read
+type queryMatch struct {
+	snapshots worldstate.DBsSnapshot
+	executor  *queryexecutor.WorldStateJSONQueryExecutor
+	keys      map[string]bool
+	// attrs are the attributes that the query refers to, which must not be redacted for a matched value to be read
+	attrs []string
+}
+
+// matchQuery returns the keys of a database that match a JSON query, except for the keys that the access control held
+// by their index entries does not allow the querier to read, or nil if the context is done. The snapshot of the match
+// must be released by the caller.
+func (q *worldstateQueryProcessor) matchQuery(ctx context.Context, dbName, querierUserID string, query []byte, orderBy string) (*queryMatch, error) {
+	if err := q.checkReadAccessOnDataDB(dbName, querierUserID); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := q.getDBsSnapshot(
+		ctx,
+		"JSON query",
 		[]string{
 			worldstate.DatabasesDBName,
 			dbName,
@@ -231,54 +954,194 @@ func (q *worldstateQueryProcessor) executeJSONQuery(ctx context.Context, dbName,
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		snapshots.Release()
-	}()
 
-	jsonQueryExecutor := queryexecutor.NewWorldStateJSONQueryExecutor(snapshots, q.logger)
+	jsonQueryExecutor := queryexecutor.NewWorldStateJSONQueryExecutor(snapshots, q.logger).FilterReadableBy(querierUserID)
 	keys, err := jsonQueryExecutor.ExecuteQuery(ctx, dbName, query)
 	select {
 	case <-ctx.Done():
+		snapshots.Release()
 		return nil, nil
 	default:
 		if err != nil {
+			snapshots.Release()
 			return nil, err
 		}
 	}
+	q.indexUsage.Record(dbName, jsonQueryExecutor.Plan())
 
-	var results []*types.KVWithMetadata
+	attrs, err := queryexecutor.QueryAttributes(query)
+	if err != nil {
+		snapshots.Release()
+		return nil, err
+	}
+	if orderBy != "" {
+		attrs = append(attrs, orderBy)
+	}
 
-	for k := range keys {
-		select {
-		case <-ctx.Done():
+	return &queryMatch{
+		snapshots: snapshots,
+		executor:  jsonQueryExecutor,
+		keys:      keys,
+		attrs:     attrs,
+	}, nil
+}
+
+// readMatchedKey returns the key-value pair of a matched key as the querier may read it, or nil if the querier cannot
+// read it
+func (q *worldstateQueryProcessor) readMatchedKey(match *queryMatch, dbName, querierUserID, key string) (*types.KVWithMetadata, error) {
+	value, metadata, err := match.snapshots.Get(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// the keys that the querier cannot read are filtered on their index entries, except for the keys indexed
+	// before the index entries held the access control
+	policy, canRead := redaction.ReadAccess(metadata.GetAccessControl(), querierUserID)
+	if !canRead {
+		return nil, nil
+	}
+	if policy != nil {
+		// a value is matched for a user who reads a redacted view of it only on the fields the user can see
+		if redactsAnyAttribute(policy, match.attrs) {
+			return nil, nil
+		}
+		if value, err = redaction.Redact(value, policy); err != nil {
 			return nil, nil
+		}
+	}
+
+	return &types.KVWithMetadata{
+		Key:      key,
+		Value:    value,
+		Metadata: metadata,
+	}, nil
+}
+
+// recordIfSlow records a query in the slow query log if its execution took more than the slow query threshold
+func (q *worldstateQueryProcessor) recordIfSlow(start time.Time, dbName, querierUserID string, query []byte, match *queryMatch, results int) {
+	if duration := time.Since(start); q.slowQueries.isSlow(duration) {
+		q.slowQueries.add(&types.SlowQuery{
+			UserId:     querierUserID,
+			DbName:     dbName,
+			Query:      string(query),
+			Plan:       match.executor.Plan(),
+			KeysRead:   uint64(len(match.keys)),
+			Results:    uint64(results),
+			ExecutedAt: start.UnixNano(),
+			Duration:   int64(duration),
+		})
+	}
+}
+
+// sortKVsByAttribute orders the key-value pairs by the value of a top-level attribute of their JSON values, and then by
+// key. Booleans come before numbers, and numbers before strings, while the values that do not hold the attribute as
+// any of these come last. The key-value pairs are ordered by key only if the attribute is empty. The descending order
+// is the reverse of the ascending one.
+func sortKVsByAttribute(kvs []*types.KVWithMetadata, attr string, descending bool) {
+	attrValues := make(map[string]interface{}, len(kvs))
+	if attr != "" {
+		for _, kv := range kvs {
+			attrValues[kv.Key] = attributeValue(kv.Value, attr)
+		}
+	}
+
+	sort.SliceStable(kvs, func(i, j int) bool {
+		c := compareAttributeValues(attrValues[kvs[i].Key], attrValues[kvs[j].Key])
+		if c == 0 {
+			c = strings.Compare(kvs[i].Key, kvs[j].Key)
+		}
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// attributeValue returns the value of a top-level attribute of a JSON object, or nil if the object does not hold it as
+// a boolean, a number or a string
+func attributeValue(value []byte, attr string) interface{} {
+	obj := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil
+	}
+
+	switch v := obj[attr].(type) {
+	case bool, json.Number, string:
+		return v
+	default:
+		return nil
+	}
+}
+
+func compareAttributeValues(a, b interface{}) int {
+	rank := func(v interface{}) int {
+		switch v.(type) {
+		case bool:
+			return 0
+		case json.Number:
+			return 1
+		case string:
+			return 2
 		default:
-			value, metadata, err := snapshots.Get(dbName, k)
-			if err != nil {
-				return nil, err
-			}
+			return 3
+		}
+	}
+
+	if ra, rb := rank(a), rank(b); ra != rb {
+		return ra - rb
+	}
 
-			// TODO: we can store the ACL as value in the indexEntry. With that, we can avoid reading the whole value
-			// to perform the access control - issue #152
-			acl := metadata.GetAccessControl()
-			if acl != nil {
-				if !acl.ReadUsers[querierUserID] && !acl.ReadWriteUsers[querierUserID] {
-					continue
-				}
+	switch va := a.(type) {
+	case bool:
+		vb := b.(bool)
+		switch {
+		case va == vb:
+			return 0
+		case !va:
+			return -1
+		default:
+			return 1
+		}
+	case json.Number:
+		vb := b.(json.Number)
+		ia, errA := va.Int64()
+		ib, errB := vb.Int64()
+		if errA != nil || errB != nil {
+			fa, _ := va.Float64()
+			fb, _ := vb.Float64()
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			default:
+				return 0
 			}
+		}
+		switch {
+		case ia < ib:
+			return -1
+		case ia > ib:
+			return 1
+		default:
+			return 0
+		}
+	case string:
+		return strings.Compare(va, b.(string))
+	default:
+		return 0
+	}
+}
 
-			results = append(
-				results,
-				&types.KVWithMetadata{
-					Key:      k,
-					Value:    value,
-					Metadata: metadata,
-				},
-			)
+// redactsAnyAttribute returns true if the redaction policy redacts any of the attributes
+func redactsAnyAttribute(policy *types.RedactionPolicy, attrs []string) bool {
+	for _, attr := range attrs {
+		if redaction.RedactsAttribute(policy, attr) {
+			return true
 		}
 	}
 
-	return &types.DataQueryResponse{
-		KVs: results,
-	}, nil
+	return false
 }
@@ -4,19 +4,35 @@ package bcdb
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
+	"github.com/hyperledger-labs/orion-server/internal/migration"
+	"github.com/hyperledger-labs/orion-server/internal/queryexecutor"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	crypto_mocks "github.com/hyperledger-labs/orion-server/pkg/crypto/mocks"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 type worldstateQueryProcessorTestEnv struct {
@@ -115,8 +131,345 @@ func TestGetDBStatus(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, status)
 			require.Equal(t, testCase.isExist, status.Exist)
+			require.Nil(t, status.Freeze)
 		}
 	})
+
+	t.Run("getDBStatus-Returns-Freeze", func(t *testing.T) {
+		env := newWorldstateQueryProcessorTestEnv(t)
+		defer env.cleanup(t)
+
+		freeze, err := proto.Marshal(&types.DatabaseFreeze{DbName: "test-db", Reason: "cutover", FrozenBy: "admin"})
+		require.NoError(t, err)
+		require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key: "test-db",
+					},
+				},
+			},
+			worldstate.FrozenDBsDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   "test-db",
+						Value: freeze,
+					},
+				},
+			},
+		}, 1))
+
+		status, err := env.q.getDBStatus("test-db")
+		require.NoError(t, err)
+		require.True(t, status.Exist)
+		require.True(t, proto.Equal(&types.DatabaseFreeze{DbName: "test-db", Reason: "cutover", FrozenBy: "admin"}, status.Freeze))
+	})
+}
+
+func TestGetDBs(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	var userWrites []*worldstate.KVWithMetadata
+	for _, user := range []*types.User{
+		{
+			Id: "admin",
+			Privilege: &types.Privilege{
+				Admin: true,
+			},
+		},
+		{
+			Id: "app2Reader",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					"org1/app2/db1": types.Privilege_Read,
+				},
+			},
+		},
+		{
+			Id: "org1Manager",
+			Privilege: &types.Privilege{
+				DbAdministrationPrefixes: []string{"org1"},
+			},
+		},
+		{
+			Id: "noPrivilege",
+		},
+	} {
+		u, err := proto.Marshal(user)
+		require.NoError(t, err)
+		userWrites = append(userWrites, &worldstate.KVWithMetadata{
+			Key:   string(identity.UserNamespace) + user.Id,
+			Value: u,
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: 1,
+				},
+			},
+		})
+	}
+
+	index, err := json.Marshal(map[string]types.IndexAttributeType{
+		"name": types.IndexAttributeType_STRING,
+		"age":  types.IndexAttributeType_NUMBER,
+	})
+	require.NoError(t, err)
+
+	dbVersion := func(txNum uint64) *types.Metadata {
+		return &types.Metadata{
+			Version: &types.Version{
+				BlockNum: 1,
+				TxNum:    txNum,
+			},
+		}
+	}
+	createUsersAndDBs := map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: userWrites,
+		},
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "org1/app2/db1", Value: index, Metadata: dbVersion(1)},
+				{Key: "org1/app2/db2", Metadata: dbVersion(2)},
+				{Key: "org1/app20/db1", Metadata: dbVersion(3)},
+				{Key: "org2/db1", Metadata: dbVersion(4)},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(createUsersAndDBs, 1))
+
+	dbNames := func(dbs []*types.DBInfo) []string {
+		var names []string
+		for _, db := range dbs {
+			names = append(names, db.Name)
+		}
+		return names
+	}
+
+	testCases := []struct {
+		name            string
+		userID          string
+		prefix          string
+		offset          uint64
+		limit           uint64
+		expectedDBNames []string
+		expectedHasMore bool
+	}{
+		{
+			name:            "admin lists all databases",
+			userID:          "admin",
+			expectedDBNames: []string{"bdb", "org1/app2/db1", "org1/app2/db2", "org1/app20/db1", "org2/db1"},
+		},
+		{
+			name:            "admin lists databases by prefix",
+			userID:          "admin",
+			prefix:          "org1/app2",
+			expectedDBNames: []string{"org1/app2/db1", "org1/app2/db2"},
+		},
+		{
+			name:            "a prefix with a trailing slash",
+			userID:          "admin",
+			prefix:          "org1/app2/",
+			expectedDBNames: []string{"org1/app2/db1", "org1/app2/db2"},
+		},
+		{
+			name:            "a prefix that is a database name",
+			userID:          "admin",
+			prefix:          "org2/db1",
+			expectedDBNames: []string{"org2/db1"},
+		},
+		{
+			name:            "first page",
+			userID:          "admin",
+			limit:           2,
+			expectedDBNames: []string{"bdb", "org1/app2/db1"},
+			expectedHasMore: true,
+		},
+		{
+			name:            "last page",
+			userID:          "admin",
+			offset:          4,
+			limit:           2,
+			expectedDBNames: []string{"org2/db1"},
+		},
+		{
+			name:   "offset beyond the last database",
+			userID: "admin",
+			offset: 5,
+		},
+		{
+			name:            "user lists databases it can read",
+			userID:          "app2Reader",
+			expectedDBNames: []string{"org1/app2/db1"},
+		},
+		{
+			name:            "user lists databases it can administer",
+			userID:          "org1Manager",
+			expectedDBNames: []string{"org1/app2/db1", "org1/app2/db2", "org1/app20/db1"},
+		},
+		{
+			name:   "user without privileges",
+			userID: "noPrivilege",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.q.getDBs(tt.userID, tt.prefix, tt.offset, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedDBNames, resp.DbNames)
+			require.Equal(t, tt.expectedDBNames, dbNames(resp.Dbs))
+			require.Equal(t, tt.expectedHasMore, resp.HasMore)
+		})
+	}
+
+	t.Run("database info", func(t *testing.T) {
+		resp, err := env.q.getDBs("admin", "org1/app2", 0, 0)
+		require.NoError(t, err)
+		require.Len(t, resp.Dbs, 2)
+		require.True(t, proto.Equal(&types.DBInfo{
+			Name:              "org1/app2/db1",
+			Version:           &types.Version{BlockNum: 1, TxNum: 1},
+			IndexedAttributes: []string{"age", "name"},
+		}, resp.Dbs[0]))
+		require.True(t, proto.Equal(&types.DBInfo{
+			Name:    "org1/app2/db2",
+			Version: &types.Version{BlockNum: 1, TxNum: 2},
+		}, resp.Dbs[1]))
+	})
+}
+
+func TestGetUsers(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	var userWrites []*worldstate.KVWithMetadata
+	for i, user := range []*types.User{
+		{
+			Id: "admin",
+			Privilege: &types.Privilege{
+				Admin: true,
+			},
+		},
+		{
+			Id: "alice",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					"db1": types.Privilege_ReadWrite,
+				},
+			},
+		},
+		{
+			Id:       "bob",
+			Disabled: true,
+		},
+		{
+			Id: "bobby",
+		},
+	} {
+		u, err := proto.Marshal(user)
+		require.NoError(t, err)
+
+		metadata := &types.Metadata{
+			Version: &types.Version{
+				BlockNum: 1,
+				TxNum:    uint64(i),
+			},
+		}
+		if user.Id == "bobby" {
+			metadata.AccessControl = &types.AccessControl{
+				ReadUsers: map[string]bool{
+					"alice": true,
+				},
+			}
+		}
+
+		userWrites = append(userWrites, &worldstate.KVWithMetadata{
+			Key:      string(identity.UserNamespace) + user.Id,
+			Value:    u,
+			Metadata: metadata,
+		})
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: userWrites,
+		},
+	}, 1))
+
+	userIDs := func(users []*types.UserInfo) []string {
+		var ids []string
+		for _, user := range users {
+			ids = append(ids, user.Id)
+		}
+		return ids
+	}
+
+	testCases := []struct {
+		name            string
+		userID          string
+		prefix          string
+		offset          uint64
+		limit           uint64
+		expectedUserIDs []string
+		expectedHasMore bool
+	}{
+		{
+			name:            "admin lists all users",
+			userID:          "admin",
+			expectedUserIDs: []string{"admin", "alice", "bob", "bobby"},
+		},
+		{
+			name:            "admin lists users by prefix",
+			userID:          "admin",
+			prefix:          "bob",
+			expectedUserIDs: []string{"bob", "bobby"},
+		},
+		{
+			name:            "admin lists a page of users",
+			userID:          "admin",
+			offset:          1,
+			limit:           2,
+			expectedUserIDs: []string{"alice", "bob"},
+			expectedHasMore: true,
+		},
+		{
+			name:            "user lists itself and the users it can read",
+			userID:          "alice",
+			expectedUserIDs: []string{"alice", "bobby"},
+		},
+		{
+			name:            "user lists only itself",
+			userID:          "bob",
+			expectedUserIDs: []string{"bob"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.q.getUsers(tt.userID, tt.prefix, tt.offset, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedUserIDs, userIDs(resp.Users))
+			require.Equal(t, tt.expectedHasMore, resp.HasMore)
+		})
+	}
+
+	t.Run("user info", func(t *testing.T) {
+		resp, err := env.q.getUsers("admin", "b", 0, 1)
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 1)
+		require.True(t, proto.Equal(&types.UserInfo{
+			Id:       "bob",
+			Version:  &types.Version{BlockNum: 1, TxNum: 2},
+			Disabled: true,
+		}, resp.Users[0]))
+		require.True(t, resp.HasMore)
+	})
+
+	t.Run("querier does not exist", func(t *testing.T) {
+		resp, err := env.q.getUsers("nouser", "", 0, 0)
+		require.EqualError(t, err, "the user [nouser] does not exist")
+		require.Nil(t, resp)
+	})
 }
 
 func TestGetData(t *testing.T) {
@@ -278,6 +631,60 @@ func TestGetData(t *testing.T) {
 		require.Nil(t, actualVal)
 	})
 
+	t.Run("getData returns a redacted view", func(t *testing.T) {
+		env := newWorldstateQueryProcessorTestEnv(t)
+		defer env.cleanup(t)
+
+		setup(env.db, "testUser", "test-db")
+
+		metadata := &types.Metadata{
+			Version: &types.Version{
+				BlockNum: 2,
+				TxNum:    1,
+			},
+			AccessControl: &types.AccessControl{
+				ReadWriteUsers: map[string]bool{
+					"user5": true,
+				},
+				RedactedReadUsers: map[string]*types.RedactionPolicy{
+					"testUser": {
+						StrippedFields: []string{"salary", "address.street"},
+						HashedFields:   []string{"ssn"},
+					},
+				},
+			},
+		}
+
+		dbsUpdates := map[string]*worldstate.DBUpdates{
+			"test-db": {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      "key1",
+						Value:    []byte(`{"name":"alice","salary":100,"ssn":"123","address":{"city":"Haifa","street":"Herzl"}}`),
+						Metadata: metadata,
+					},
+					{
+						Key:      "key2",
+						Value:    []byte("value2"),
+						Metadata: metadata,
+					},
+				},
+			},
+		}
+		require.NoError(t, env.db.Commit(dbsUpdates, 2))
+
+		payload, err := env.q.getData("test-db", "testUser", "key1")
+		require.NoError(t, err)
+		ssnHash := sha256.Sum256([]byte(`"123"`))
+		require.Equal(t, `{"address":{"city":"Haifa"},"name":"alice","ssn":"`+hex.EncodeToString(ssnHash[:])+`"}`, string(payload.Value))
+		require.True(t, proto.Equal(metadata, payload.Metadata))
+
+		payload, err = env.q.getData("test-db", "testUser", "key2")
+		require.EqualError(t, err, "the user [testUser] can read only a redacted view of key [key2] from database [test-db], "+
+			"but its value is not a JSON object")
+		require.Nil(t, payload)
+	})
+
 	t.Run("getData returns permission error due to directly accessing system database", func(t *testing.T) {
 		env := newWorldstateQueryProcessorTestEnv(t)
 		defer env.cleanup(t)
@@ -322,64 +729,286 @@ func TestGetData(t *testing.T) {
 	})
 }
 
-func TestExecuteJSONQuery(t *testing.T) {
-	m := &types.Metadata{
-		Version: &types.Version{
-			BlockNum: 3,
-			TxNum:    0,
-		},
-		AccessControl: &types.AccessControl{
-			ReadUsers: map[string]bool{
-				"user1": true,
+func TestGetDataKeys(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	var userWrites []*worldstate.KVWithMetadata
+	for _, user := range []*types.User{
+		{
+			Id: "alice",
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					"test-db": types.Privilege_Read,
+				},
 			},
 		},
-	}
-	db1 := "db1"
-
-	setup := func(db worldstate.DB, userID string) {
-		user := &types.User{
-			Id: userID,
+		{
+			Id: "bob",
 			Privilege: &types.Privilege{
 				DbPermission: map[string]types.Privilege_Access{
-					db1: types.Privilege_ReadWrite,
+					"test-db": types.Privilege_ReadWrite,
 				},
 			},
-		}
-
+		},
+		{
+			Id: "charlie",
+		},
+	} {
 		u, err := proto.Marshal(user)
 		require.NoError(t, err)
+		userWrites = append(userWrites, &worldstate.KVWithMetadata{
+			Key:   string(identity.UserNamespace) + user.Id,
+			Value: u,
+		})
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: userWrites,
+		},
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "test-db"},
+			},
+		},
+	}, 1))
 
-		createUser := map[string]*worldstate.DBUpdates{
-			worldstate.UsersDBName: {
-				Writes: []*worldstate.KVWithMetadata{
-					{
-						Key:   string(identity.UserNamespace) + userID,
-						Value: u,
-						Metadata: &types.Metadata{
-							Version: &types.Version{
-								BlockNum: 2,
-								TxNum:    1,
-							},
-						},
-					},
+	readableBy := func(userID string) *types.Metadata {
+		return &types.Metadata{
+			AccessControl: &types.AccessControl{
+				ReadUsers: map[string]bool{
+					userID: true,
 				},
 			},
 		}
-		require.NoError(t, db.Commit(createUser, 2))
-
-		indexDef := map[string]types.IndexAttributeType{
-			"attr1": types.IndexAttributeType_STRING,
-			"attr2": types.IndexAttributeType_BOOLEAN,
-			"attr3": types.IndexAttributeType_STRING,
-		}
-		marshaledIndexDef, err := json.Marshal(indexDef)
-		require.NoError(t, err)
-
-		indexDBName := stateindex.IndexDB(db1)
-
-		createDB := map[string]*worldstate.DBUpdates{
-			worldstate.DatabasesDBName: {
-				Writes: []*worldstate.KVWithMetadata{
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"test-db": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "item/1", Value: []byte("value")},
+				{Key: "item/2", Value: []byte("value"), Metadata: readableBy("bob")},
+				{Key: "item/3", Value: []byte("value")},
+				{Key: "items", Value: []byte("value")},
+				{Key: "other/1", Value: []byte("value")},
+			},
+		},
+	}, 2))
+
+	testCases := []struct {
+		name                 string
+		userID               string
+		prefix               string
+		startKey             string
+		limit                uint64
+		countOnly            bool
+		expectedKeys         []string
+		expectedCount        uint64
+		expectedHasMore      bool
+		expectedNextStartKey string
+	}{
+		{
+			name:          "all keys",
+			userID:        "bob",
+			expectedKeys:  []string{"item/1", "item/2", "item/3", "items", "other/1"},
+			expectedCount: 5,
+		},
+		{
+			name:          "keys by prefix",
+			userID:        "bob",
+			prefix:        "item/",
+			expectedKeys:  []string{"item/1", "item/2", "item/3"},
+			expectedCount: 3,
+		},
+		{
+			name:          "keys by prefix skip the keys the user cannot read",
+			userID:        "alice",
+			prefix:        "item/",
+			expectedKeys:  []string{"item/1", "item/3"},
+			expectedCount: 2,
+		},
+		{
+			name:          "count only",
+			userID:        "alice",
+			prefix:        "item",
+			countOnly:     true,
+			expectedCount: 3,
+		},
+		{
+			name:   "no matching keys",
+			userID: "bob",
+			prefix: "zzz",
+		},
+		{
+			name:                 "first page of keys",
+			userID:               "alice",
+			prefix:               "item",
+			limit:                2,
+			expectedKeys:         []string{"item/1", "item/3"},
+			expectedCount:        2,
+			expectedHasMore:      true,
+			expectedNextStartKey: "items",
+		},
+		{
+			name:          "last page of keys",
+			userID:        "alice",
+			prefix:        "item",
+			startKey:      "items",
+			limit:         2,
+			expectedKeys:  []string{"items"},
+			expectedCount: 1,
+		},
+		{
+			name:          "page that ends at the last key",
+			userID:        "bob",
+			prefix:        "item/",
+			startKey:      "item/2",
+			limit:         2,
+			expectedKeys:  []string{"item/2", "item/3"},
+			expectedCount: 2,
+		},
+		{
+			name:                 "count only a page",
+			userID:               "bob",
+			limit:                3,
+			countOnly:            true,
+			expectedCount:        3,
+			expectedHasMore:      true,
+			expectedNextStartKey: "items",
+		},
+		{
+			name:          "start key before the prefix",
+			userID:        "bob",
+			prefix:        "other/",
+			startKey:      "item/3",
+			expectedKeys:  []string{"other/1"},
+			expectedCount: 1,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := env.q.getDataKeys("test-db", tt.userID, tt.prefix, tt.startKey, tt.limit, tt.countOnly)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedKeys, resp.Keys)
+			require.Equal(t, tt.expectedCount, resp.Count)
+			require.Equal(t, tt.expectedHasMore, resp.HasMore)
+			require.Equal(t, tt.expectedNextStartKey, resp.NextStartKey)
+		})
+	}
+
+	t.Run("user has no permission to read the db", func(t *testing.T) {
+		resp, err := env.q.getDataKeys("test-db", "charlie", "", "", 0, false)
+		require.EqualError(t, err, "the user [charlie] has no permission to read from database [test-db]")
+		require.Nil(t, resp)
+	})
+
+	t.Run("system database", func(t *testing.T) {
+		resp, err := env.q.getDataKeys(worldstate.UsersDBName, "bob", "", "", 0, false)
+		require.EqualError(t, err, "no user can directly read from a system database [_users]. "+
+			"To read from a system database, use /config, /user, /db rest endpoints instead of /data")
+		require.Nil(t, resp)
+	})
+
+	t.Run("memory budget", func(t *testing.T) {
+		memBudget := membudget.New(&membudget.Config{LimitBytes: 100, Logger: env.q.logger})
+		env.q.memBudget = memBudget
+		env.q.snapshotBytes = 80
+		env.q.memBudgetWait = 10 * time.Millisecond
+		defer func() {
+			env.q.memBudget = nil
+		}()
+
+		resp, err := env.q.getDataKeys("test-db", "bob", "item/", "", 0, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"item/1", "item/2", "item/3"}, resp.Keys)
+		require.Equal(t, uint64(0), memBudget.Usage().UsedBytes)
+
+		resp, err = env.q.getDataKeys("test-db", "bob", "", "", 0, false)
+		require.EqualError(t, err, "the memory budget is exhausted: query_result requires 5 bytes, used [98] out of [100] bytes")
+		require.IsType(t, &ierrors.ResourceExhaustedError{}, err)
+		require.Nil(t, resp)
+		require.Equal(t, uint64(0), memBudget.Usage().UsedBytes)
+
+		require.True(t, memBudget.TryAcquire(membudget.KindBlockAssembly, 30))
+		resp, err = env.q.getDataKeys("test-db", "bob", "item/", "", 0, true)
+		require.EqualError(t, err, "the memory budget is exhausted: snapshot requires 80 bytes, used [30] out of [100] bytes")
+		require.Nil(t, resp)
+	})
+}
+
+func TestExecuteJSONQuery(t *testing.T) {
+	m := &types.Metadata{
+		Version: &types.Version{
+			BlockNum: 3,
+			TxNum:    0,
+		},
+		AccessControl: &types.AccessControl{
+			ReadUsers: map[string]bool{
+				"user1": true,
+			},
+		},
+	}
+	redactedMetadata := &types.Metadata{
+		Version: &types.Version{
+			BlockNum: 3,
+			TxNum:    0,
+		},
+		AccessControl: &types.AccessControl{
+			RedactedReadUsers: map[string]*types.RedactionPolicy{
+				"user3": {
+					StrippedFields: []string{"attr4"},
+					HashedFields:   []string{"attr3"},
+				},
+			},
+		},
+	}
+	attr3Hash := sha256.Sum256([]byte(`"p"`))
+	db1 := "db1"
+
+	setup := func(db worldstate.DB, userID string) {
+		user := &types.User{
+			Id: userID,
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					db1: types.Privilege_ReadWrite,
+				},
+			},
+		}
+
+		u, err := proto.Marshal(user)
+		require.NoError(t, err)
+
+		createUser := map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   string(identity.UserNamespace) + userID,
+						Value: u,
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 2,
+								TxNum:    1,
+							},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(createUser, 2))
+
+		indexDef := map[string]types.IndexAttributeType{
+			"attr1": types.IndexAttributeType_STRING,
+			"attr2": types.IndexAttributeType_BOOLEAN,
+			"attr3": types.IndexAttributeType_STRING,
+		}
+		marshaledIndexDef, err := json.Marshal(indexDef)
+		require.NoError(t, err)
+
+		indexDBName := stateindex.IndexDB(db1)
+
+		createDB := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
 					{
 						Key:   db1,
 						Value: marshaledIndexDef,
@@ -428,6 +1057,11 @@ func TestExecuteJSONQuery(t *testing.T) {
 						Value:    []byte(`{"attr1":"h","attr2":true,"attr3":"o","attr4":-102}`),
 						Metadata: m,
 					},
+					{
+						Key:      "key7",
+						Value:    []byte(`{"attr1":"i","attr2":true,"attr3":"p","attr4":-103}`),
+						Metadata: redactedMetadata,
+					},
 				},
 			},
 		}
@@ -515,98 +1149,530 @@ func TestExecuteJSONQuery(t *testing.T) {
 			},
 		},
 		{
-			name:   "empty result due to cancelled context",
-			dbName: "db1",
-			userID: "user1",
-			query: []byte(
-				`{
-					"attr1": {
-						"$gt": "",
-						"$lte": "d"
-					}
-				}`,
-			),
-			useCancelledContext: true,
-			expectedKVs:         nil,
+			name:   "empty result due to cancelled context",
+			dbName: "db1",
+			userID: "user1",
+			query: []byte(
+				`{
+					"attr1": {
+						"$gt": "",
+						"$lte": "d"
+					}
+				}`,
+			),
+			useCancelledContext: true,
+			expectedKVs:         nil,
+		},
+		{
+			name:   "empty result due to acl",
+			dbName: "db1",
+			userID: "user2",
+			query: []byte(
+				`{
+					"selector": {
+						"attr2": {
+							"$eq": true
+						}
+					}
+				}`,
+			),
+			useCancelledContext: false,
+		},
+		{
+			name:   "redacted view of records",
+			dbName: "db1",
+			userID: "user3",
+			query: []byte(
+				`{
+					"selector": {
+						"attr2": {
+							"$eq": true
+						}
+					}
+				}`,
+			),
+			useCancelledContext: false,
+			expectedKVs: map[string]*types.KVWithMetadata{
+				"key7": {
+					Key:      "key7",
+					Value:    []byte(`{"attr1":"i","attr2":true,"attr3":"` + hex.EncodeToString(attr3Hash[:]) + `"}`),
+					Metadata: redactedMetadata,
+				},
+			},
+		},
+		{
+			name:   "empty result due to a condition on a redacted attribute",
+			dbName: "db1",
+			userID: "user3",
+			query: []byte(
+				`{
+					"selector": {
+						"$and": {
+							"attr2": {
+								"$eq": true
+							},
+							"attr3": {
+								"$eq": "p"
+							}
+						}
+					}
+				}`,
+			),
+			useCancelledContext: false,
+		},
+		{
+			name:   "user cannot read from system database",
+			dbName: worldstate.ConfigDBName,
+			userID: "user1",
+			query: []byte(
+				`{
+					"selector": {
+						"attr1": {
+							"$gt": "",
+							"$lte": "d"
+						}
+					}
+				}`,
+			),
+			useCancelledContext: false,
+			expectedErr:         "no user can directly read from a system database [" + worldstate.ConfigDBName + "]",
+		},
+		{
+			name:   "user does not have read permission",
+			dbName: "db2",
+			userID: "user1",
+			query: []byte(
+				`{
+					"selector": {
+						"attr1": {
+							"$gt": "",
+							"$lte": "d"
+						}
+					}
+				}`,
+			),
+			useCancelledContext: false,
+			expectedErr:         "the user [user1] has no permission to read from database [db2]",
+		},
+		{
+			name:   "query syntax error",
+			dbName: "db1",
+			userID: "user1",
+			query: []byte(
+				`{
+					"selector": {
+						"attr1": {
+							"$gt": "",
+							"$lte": "d",
+						}
+					}
+				}`,
+			),
+			useCancelledContext: false,
+			expectedErr:         "error decoding the query",
+		},
+		{
+			name:   "query syntax error",
+			dbName: "db1",
+			userID: "user1",
+			query: []byte(
+				`{
+					"attr1": {
+						"$lte": "d"
+					}
+				}`,
+			),
+			expectedErr: "selector field is missing in the query",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			env := newWorldstateQueryProcessorTestEnv(t)
+			defer env.cleanup(t)
+
+			setup(env.db, tt.userID)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.useCancelledContext {
+				cancel()
+			}
+			result, err := env.q.executeJSONQuery(ctx, tt.dbName, tt.userID, tt.query)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				if tt.useCancelledContext {
+					require.Nil(t, result)
+					return
+				}
+
+				require.Equal(t, len(tt.expectedKVs), len(result.KVs))
+				for _, kv := range result.KVs {
+					require.True(t, proto.Equal(kv, tt.expectedKVs[kv.Key]))
+				}
+			} else {
+				require.Nil(t, result)
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), tt.expectedErr)
+			}
+		})
+	}
+}
+
+// setupAttr1IndexedDB creates the database db1 with an index on the attribute attr1, which is readable by alice, and
+// holds the keys key1, readable by alice, and key2, readable by bob only
+func setupAttr1IndexedDB(t *testing.T, db *leveldb.LevelDB) {
+	user := &types.User{
+		Id: "alice",
+		Privilege: &types.Privilege{
+			DbPermission: map[string]types.Privilege_Access{
+				"db1": types.Privilege_Read,
+			},
+		},
+	}
+	u, err := proto.Marshal(user)
+	require.NoError(t, err)
+	marshaledIndexDef, err := json.Marshal(map[string]types.IndexAttributeType{
+		"attr1": types.IndexAttributeType_STRING,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   string(identity.UserNamespace) + "alice",
+					Value: u,
+				},
+			},
+		},
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   "db1",
+					Value: marshaledIndexDef,
+				},
+				{
+					Key: stateindex.IndexDB("db1"),
+				},
+			},
+		},
+	}, 1))
+
+	dbsUpdates := map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   "key1",
+					Value: []byte(`{"attr1":"a"}`),
+				},
+				{
+					Key:   "key2",
+					Value: []byte(`{"attr1":"b"}`),
+					Metadata: &types.Metadata{
+						AccessControl: &types.AccessControl{
+							ReadUsers: map[string]bool{"bob": true},
+						},
+					},
+				},
+			},
+		},
+	}
+	indexUpdates, err := stateindex.ConstructIndexEntries(dbsUpdates, db)
+	require.NoError(t, err)
+	for indexDB, updates := range indexUpdates {
+		dbsUpdates[indexDB] = updates
+	}
+	require.NoError(t, db.Commit(dbsUpdates, 2))
+}
+
+func TestStreamDataQuery(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	setupAttr1IndexedDB(t, env.db)
+
+	// more key-value pairs than are read ahead of the writes
+	dbsUpdates := map[string]*worldstate.DBUpdates{"db1": {}}
+	for i := 0; i < 2*queryStreamBufferSize; i++ {
+		dbsUpdates["db1"].Writes = append(dbsUpdates["db1"].Writes, &worldstate.KVWithMetadata{
+			Key:   fmt.Sprintf("more%03d", i),
+			Value: []byte(`{"attr1":"z"}`),
+		})
+	}
+	indexUpdates, err := stateindex.ConstructIndexEntries(dbsUpdates, env.db)
+	require.NoError(t, err)
+	for indexDB, updates := range indexUpdates {
+		dbsUpdates[indexDB] = updates
+	}
+	require.NoError(t, env.db.Commit(dbsUpdates, 3))
+
+	signer := &crypto_mocks.Signer{}
+	signer.On("SignHash", mock.Anything).Return([]byte("signature"), nil)
+	bcdb := &db{
+		nodeID:                   "test-node-id1",
+		worldstateQueryProcessor: env.q,
+		signer:                   signer,
+		logger:                   env.q.logger,
+	}
+	query := []byte(`{"selector":{"attr1":{"$neq":["c"]}}}`)
+
+	t.Run("key-value pairs and trailer", func(t *testing.T) {
+		var lines [][]byte
+		err := bcdb.StreamDataQuery(context.Background(), "db1", "alice", query, func(line []byte) error {
+			lines = append(lines, append([]byte(nil), line...))
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, lines, 2*queryStreamBufferSize+2)
+
+		hash := sha256.New()
+		keys := make(map[string]bool)
+		for _, line := range lines[:len(lines)-1] {
+			require.True(t, strings.HasSuffix(string(line), "\n"))
+			hash.Write(line)
+
+			item := &types.DataQueryStreamItem{}
+			require.NoError(t, json.Unmarshal(line, item))
+			require.Nil(t, item.End)
+			keys[item.Kv.Key] = true
+		}
+		require.True(t, keys["key1"])
+		require.False(t, keys["key2"])
+
+		end := &types.DataQueryStreamItem{}
+		require.NoError(t, json.Unmarshal(lines[len(lines)-1], end))
+		require.Nil(t, end.Kv)
+		require.Equal(t, []byte("signature"), end.End.Signature)
+		trailer := end.End.Trailer
+		require.Equal(t, "test-node-id1", trailer.Header.NodeId)
+		require.Equal(t, uint64(len(lines)-1), trailer.Count)
+		require.Equal(t, hash.Sum(nil), trailer.KvsHash)
+		require.Empty(t, trailer.ErrMsg)
+	})
+
+	t.Run("error before any line is written", func(t *testing.T) {
+		written := false
+		err := bcdb.StreamDataQuery(context.Background(), "db1", "bob", query, func(line []byte) error {
+			written = true
+			return nil
+		})
+		require.EqualError(t, err, "the user [bob] does not exist")
+		require.False(t, written)
+	})
+
+	t.Run("failed write", func(t *testing.T) {
+		writes := 0
+		err := bcdb.StreamDataQuery(context.Background(), "db1", "alice", query, func(line []byte) error {
+			writes++
+			return errors.New("connection reset")
+		})
+		require.EqualError(t, err, "connection reset")
+		require.Equal(t, 1, writes)
+	})
+}
+
+func TestExplainJSONQuery(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	setupAttr1IndexedDB(t, env.db)
+
+	t.Run("plan", func(t *testing.T) {
+		response, err := env.q.explainJSONQuery(context.Background(), "db1", "alice", []byte(`{"selector":{"attr1":{"$gt":"a"}}}`))
+		require.NoError(t, err)
+		expected := &types.QueryPlan{
+			Operator: constants.QueryOpAnd,
+			Scans: []*types.IndexScan{
+				{
+					Attribute: "attr1",
+					Type:      types.IndexAttributeType_STRING,
+					Kind:      types.IndexScan_RANGE,
+					Operators: []string{constants.QueryOpGreaterThan},
+				},
+			},
+		}
+		require.True(t, proto.Equal(expected, response.Plan), "expected: %v, actual: %v", expected, response.Plan)
+	})
+
+	t.Run("attribute is not indexed", func(t *testing.T) {
+		response, err := env.q.explainJSONQuery(context.Background(), "db1", "alice", []byte(`{"selector":{"attr2":{"$eq":"a"}}}`))
+		require.EqualError(t, err, "attribute [attr2] given in the query condition is not indexed")
+		require.IsType(t, &ierrors.BadRequestError{}, err)
+		require.Nil(t, response)
+	})
+
+	t.Run("user does not exist", func(t *testing.T) {
+		response, err := env.q.explainJSONQuery(context.Background(), "db1", "bob", []byte(`{"selector":{"attr1":{"$gt":"a"}}}`))
+		require.EqualError(t, err, "the user [bob] does not exist")
+		require.Nil(t, response)
+	})
+
+	t.Run("system database", func(t *testing.T) {
+		response, err := env.q.explainJSONQuery(context.Background(), worldstate.UsersDBName, "alice", []byte(`{"selector":{"attr1":{"$gt":"a"}}}`))
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+		require.Nil(t, response)
+	})
+}
+
+func TestExecuteSQLQuery(t *testing.T) {
+	m := &types.Metadata{
+		Version: &types.Version{
+			BlockNum: 3,
+			TxNum:    0,
+		},
+		AccessControl: &types.AccessControl{
+			ReadUsers: map[string]bool{
+				"user1": true,
+			},
+			RedactedReadUsers: map[string]*types.RedactionPolicy{
+				"user2": {
+					StrippedFields: []string{"attr2"},
+				},
+			},
+		},
+	}
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		var userWrites []*worldstate.KVWithMetadata
+		for _, userID := range []string{"user1", "user2"} {
+			u, err := proto.Marshal(&types.User{
+				Id: userID,
+				Privilege: &types.Privilege{
+					DbPermission: map[string]types.Privilege_Access{
+						"db1": types.Privilege_Read,
+					},
+				},
+			})
+			require.NoError(t, err)
+			userWrites = append(userWrites, &worldstate.KVWithMetadata{
+				Key:   string(identity.UserNamespace) + userID,
+				Value: u,
+				Metadata: &types.Metadata{
+					Version: &types.Version{
+						BlockNum: 2,
+						TxNum:    1,
+					},
+				},
+			})
+		}
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: userWrites,
+			},
+		}, 2))
+
+		marshaledIndexDef, err := json.Marshal(map[string]types.IndexAttributeType{
+			"attr1": types.IndexAttributeType_BOOLEAN,
+			"attr2": types.IndexAttributeType_NUMBER,
+		})
+		require.NoError(t, err)
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   "db1",
+						Value: marshaledIndexDef,
+					},
+					{
+						Key: stateindex.IndexDB("db1"),
+					},
+				},
+			},
+		}, 2))
+
+		dbsUpdates := map[string]*worldstate.DBUpdates{
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      "key1",
+						Value:    []byte(`{"attr1":true,"attr2":30,"attr3":"b"}`),
+						Metadata: m,
+					},
+					{
+						Key:      "key2",
+						Value:    []byte(`{"attr1":true,"attr2":-5,"attr3":"c"}`),
+						Metadata: m,
+					},
+					{
+						Key:      "key3",
+						Value:    []byte(`{"attr1":true,"attr2":12,"attr3":"a"}`),
+						Metadata: m,
+					},
+					{
+						Key:      "key4",
+						Value:    []byte(`{"attr1":true,"attr2":12}`),
+						Metadata: m,
+					},
+					{
+						Key:      "key5",
+						Value:    []byte(`{"attr1":false,"attr2":7,"attr3":"d"}`),
+						Metadata: m,
+					},
+				},
+			},
+		}
+		indexUpdates, err := stateindex.ConstructIndexEntries(dbsUpdates, db)
+		require.NoError(t, err)
+		for indexDB, updates := range indexUpdates {
+			dbsUpdates[indexDB] = updates
+		}
+		require.NoError(t, db.Commit(dbsUpdates, 3))
+	}
+
+	tests := []struct {
+		name         string
+		userID       string
+		sql          string
+		expectedKeys []string
+		expectedErr  string
+	}{
+		{
+			name:         "ordered by key",
+			userID:       "user1",
+			sql:          "SELECT * FROM db1 WHERE attr1 = true",
+			expectedKeys: []string{"key1", "key2", "key3", "key4"},
+		},
+		{
+			name:         "ordered by a number attribute, then by key",
+			userID:       "user1",
+			sql:          "SELECT * FROM db1 WHERE attr1 = true ORDER BY attr2",
+			expectedKeys: []string{"key2", "key3", "key4", "key1"},
 		},
 		{
-			name:   "empty result due to acl",
-			dbName: "db1",
-			userID: "user2",
-			query: []byte(
-				`{
-					"selector": {
-						"attr2": {
-							"$eq": true
-						}
-					}
-				}`,
-			),
-			useCancelledContext: false,
+			name:         "ordered by a string attribute, which a value does not hold",
+			userID:       "user1",
+			sql:          "SELECT * FROM db1 WHERE attr1 = true ORDER BY attr3",
+			expectedKeys: []string{"key3", "key1", "key2", "key4"},
 		},
 		{
-			name:   "user cannot read from system database",
-			dbName: worldstate.ConfigDBName,
-			userID: "user1",
-			query: []byte(
-				`{
-					"selector": {
-						"attr1": {
-							"$gt": "",
-							"$lte": "d"
-						}
-					}
-				}`,
-			),
-			useCancelledContext: false,
-			expectedErr:         "no user can directly read from a system database [" + worldstate.ConfigDBName + "]",
+			name:         "descending order with limit",
+			userID:       "user1",
+			sql:          "SELECT * FROM db1 WHERE attr1 = true ORDER BY attr3 DESC LIMIT 2",
+			expectedKeys: []string{"key4", "key2"},
 		},
 		{
-			name:   "user does not have read permission",
-			dbName: "db2",
-			userID: "user1",
-			query: []byte(
-				`{
-					"selector": {
-						"attr1": {
-							"$gt": "",
-							"$lte": "d"
-						}
-					}
-				}`,
-			),
-			useCancelledContext: false,
-			expectedErr:         "the user [user1] has no permission to read from database [db2]",
+			name:         "limit larger than the results",
+			userID:       "user1",
+			sql:          "SELECT * FROM db1 WHERE attr2 >= 10 LIMIT 10",
+			expectedKeys: []string{"key1", "key3", "key4"},
 		},
 		{
-			name:   "query syntax error",
-			dbName: "db1",
-			userID: "user1",
-			query: []byte(
-				`{
-					"selector": {
-						"attr1": {
-							"$gt": "",
-							"$lte": "d",
-						}
-					}
-				}`,
-			),
-			useCancelledContext: false,
-			expectedErr:         "error decoding the query",
+			name:         "redacted view of records ordered by an attribute that is not redacted",
+			userID:       "user2",
+			sql:          "SELECT * FROM db1 WHERE attr1 = true ORDER BY attr3 LIMIT 1",
+			expectedKeys: []string{"key3"},
 		},
 		{
-			name:   "query syntax error",
-			dbName: "db1",
-			userID: "user1",
-			query: []byte(
-				`{
-					"attr1": {
-						"$lte": "d"
-					}
-				}`,
-			),
-			expectedErr: "selector field is missing in the query",
+			name:   "empty result due to ordering by a redacted attribute",
+			userID: "user2",
+			sql:    "SELECT * FROM db1 WHERE attr1 = true ORDER BY attr2",
+		},
+		{
+			name:        "predicate on an attribute that is not indexed",
+			userID:      "user1",
+			sql:         "SELECT * FROM db1 WHERE attr3 = 'a'",
+			expectedErr: "attribute [attr3] given in the query condition is not indexed",
 		},
 	}
 
@@ -616,30 +1682,24 @@ func TestExecuteJSONQuery(t *testing.T) {
 			env := newWorldstateQueryProcessorTestEnv(t)
 			defer env.cleanup(t)
 
-			setup(env.db, tt.userID)
+			setup(t, env.db)
 
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			if tt.useCancelledContext {
-				cancel()
-			}
-			result, err := env.q.executeJSONQuery(ctx, tt.dbName, tt.userID, tt.query)
-			if tt.expectedErr == "" {
-				require.NoError(t, err)
-				if tt.useCancelledContext {
-					require.Nil(t, result)
-					return
-				}
+			query, err := queryexecutor.CompileSQL(tt.sql)
+			require.NoError(t, err)
 
-				require.Equal(t, len(tt.expectedKVs), len(result.KVs))
-				for _, kv := range result.KVs {
-					require.True(t, proto.Equal(kv, tt.expectedKVs[kv.Key]))
-				}
-			} else {
+			result, err := env.q.executeSQLQuery(context.Background(), tt.userID, query)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
 				require.Nil(t, result)
-				require.NotNil(t, err)
-				require.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			var keys []string
+			for _, kv := range result.KVs {
+				keys = append(keys, kv.Key)
 			}
+			require.Equal(t, tt.expectedKeys, keys)
 		})
 	}
 }
@@ -1168,3 +2228,304 @@ func TestGetConfig(t *testing.T) {
 		require.True(t, proto.Equal(expectedSingleNodeConfig, singleNodeConfigEnvelope))
 	})
 }
+
+func TestGetConfigScopedToAdminNodes(t *testing.T) {
+	cryptoDir1 := testutils.GenerateTestClientCrypto(t, []string{"admin1", "node1"}, true)
+	admin1Cert, _ := testutils.LoadTestClientCrypto(t, cryptoDir1, "admin1")
+	node1Cert, _ := testutils.LoadTestClientCrypto(t, cryptoDir1, "node1")
+	rootCA1, _ := testutils.LoadTestClientCA(t, cryptoDir1, testutils.RootCAFileName)
+	midCA1, _ := testutils.LoadTestClientCA(t, cryptoDir1, testutils.IntermediateCAFileName)
+
+	cryptoDir2 := testutils.GenerateTestClientCrypto(t, []string{"admin2", "node2"}, true)
+	admin2Cert, _ := testutils.LoadTestClientCrypto(t, cryptoDir2, "admin2")
+	node2Cert, _ := testutils.LoadTestClientCrypto(t, cryptoDir2, "node2")
+	rootCA2, _ := testutils.LoadTestClientCA(t, cryptoDir2, testutils.RootCAFileName)
+	midCA2, _ := testutils.LoadTestClientCA(t, cryptoDir2, testutils.IntermediateCAFileName)
+
+	clusterConfig := &types.ClusterConfig{
+		Nodes: []*types.NodeConfig{
+			{Id: "node1", Address: "127.0.0.1", Port: 6090, Certificate: node1Cert.Raw},
+			{Id: "node2", Address: "127.0.0.1", Port: 6091, Certificate: node2Cert.Raw},
+		},
+		Admins: []*types.Admin{
+			{Id: "admin1", Certificate: admin1Cert.Raw, NodeIds: []string{"node1"}},
+			{Id: "admin2", Certificate: admin2Cert.Raw, NodeIds: []string{"node2"}, SuperAdmin: true},
+			{Id: "admin3", Certificate: admin2Cert.Raw},
+		},
+		CertAuthConfig: &types.CAConfig{
+			Roots:         [][]byte{rootCA1.Raw, rootCA2.Raw},
+			Intermediates: [][]byte{midCA1.Raw, midCA2.Raw},
+		},
+		ConsensusConfig: &types.ConsensusConfig{
+			Algorithm: "raft",
+			Members: []*types.PeerConfig{
+				{NodeId: "node1", RaftId: 1, PeerHost: "127.0.0.1", PeerPort: 7090},
+				{NodeId: "node2", RaftId: 2, PeerHost: "127.0.0.1", PeerPort: 7091},
+			},
+			RaftConfig: &types.RaftConfig{
+				TickInterval:   "100ms",
+				ElectionTicks:  100,
+				HeartbeatTicks: 10,
+			},
+		},
+		ProtocolVersion: 1,
+	}
+
+	metadata := &types.Metadata{
+		Version: &types.Version{
+			BlockNum: 1,
+			TxNum:    5,
+		},
+	}
+
+	setup := func(t *testing.T) (*worldstateQueryProcessorTestEnv, *[]string) {
+		env := newWorldstateQueryProcessorTestEnv(t)
+
+		var auditLogs []string
+		auditHook := func(entry zapcore.Entry) error {
+			if strings.HasPrefix(entry.Message, "audit:") {
+				auditLogs = append(auditLogs, entry.Message)
+			}
+			return nil
+		}
+		c := &logger.Config{
+			Level:         "info",
+			OutputPath:    []string{"stdout"},
+			ErrOutputPath: []string{"stderr"},
+			Encoding:      "console",
+		}
+		lg, err := logger.New(c, zap.Hooks(auditHook))
+		require.NoError(t, err)
+		env.q.logger = lg
+
+		config, err := proto.Marshal(clusterConfig)
+		require.NoError(t, err)
+		adminUpdates, err := identity.ConstructDBEntriesForClusterAdmins(nil, clusterConfig.Admins, metadata.Version)
+		require.NoError(t, err)
+
+		dbUpdates := map[string]*worldstate.DBUpdates{
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      worldstate.ConfigKey,
+						Value:    config,
+						Metadata: metadata,
+					},
+				},
+			},
+			worldstate.UsersDBName: {
+				Writes: adminUpdates.Writes,
+			},
+		}
+		require.NoError(t, env.db.Commit(dbUpdates, 1))
+
+		return env, &auditLogs
+	}
+
+	t.Run("admin bound to nodes reads a scoped config", func(t *testing.T) {
+		env, auditLogs := setup(t)
+		defer env.cleanup(t)
+
+		configEnvelope, err := env.q.getConfig("admin1")
+		require.NoError(t, err)
+
+		expectedConfig := &types.ClusterConfig{
+			Nodes:  clusterConfig.Nodes[:1],
+			Admins: clusterConfig.Admins[:1],
+			CertAuthConfig: &types.CAConfig{
+				Roots:         [][]byte{rootCA1.Raw},
+				Intermediates: [][]byte{midCA1.Raw},
+			},
+			ConsensusConfig: &types.ConsensusConfig{
+				Algorithm:  "raft",
+				Members:    clusterConfig.ConsensusConfig.Members[:1],
+				RaftConfig: clusterConfig.ConsensusConfig.RaftConfig,
+			},
+			ProtocolVersion: 1,
+		}
+		require.True(t, proto.Equal(expectedConfig, configEnvelope.Config))
+		require.True(t, proto.Equal(metadata, configEnvelope.Metadata))
+
+		require.Len(t, *auditLogs, 1)
+		require.Contains(t, (*auditLogs)[0], "admin [admin1] read the cluster config scoped to nodes [node1]")
+	})
+
+	t.Run("super admin reads the full config", func(t *testing.T) {
+		env, auditLogs := setup(t)
+		defer env.cleanup(t)
+
+		configEnvelope, err := env.q.getConfig("admin2")
+		require.NoError(t, err)
+		require.True(t, proto.Equal(clusterConfig, configEnvelope.Config))
+
+		require.Len(t, *auditLogs, 1)
+		require.Contains(t, (*auditLogs)[0], "admin [admin2] read the full cluster config")
+	})
+
+	t.Run("admin not bound to nodes reads the full config", func(t *testing.T) {
+		env, auditLogs := setup(t)
+		defer env.cleanup(t)
+
+		configEnvelope, err := env.q.getConfig("admin3")
+		require.NoError(t, err)
+		require.True(t, proto.Equal(clusterConfig, configEnvelope.Config))
+
+		require.Len(t, *auditLogs, 1)
+		require.Contains(t, (*auditLogs)[0], "admin [admin3] read the full cluster config")
+	})
+
+	t.Run("admin bound to nodes cannot read the full config by other queries", func(t *testing.T) {
+		env, _ := setup(t)
+		defer env.cleanup(t)
+
+		configBlock, err := env.q.getConfigBlock("admin1", 0)
+		require.EqualError(t, err, "the admin [admin1] is scoped to the nodes [node1] and has no permission to read the full cluster config")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+		require.Nil(t, configBlock)
+
+		require.NoError(t, checkFullConfigAccess(env.db, "admin2"))
+		require.NoError(t, checkFullConfigAccess(env.db, "admin3"))
+	})
+}
+
+func TestGetIndexUsage(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	setupAttr1IndexedDB(t, env.db)
+
+	admin, err := proto.Marshal(&types.User{
+		Id: "admin",
+		Privilege: &types.Privilege{
+			Admin: true,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   string(identity.UserNamespace) + "admin",
+					Value: admin,
+				},
+			},
+		},
+	}, 3))
+
+	t.Run("unused index", func(t *testing.T) {
+		resp, err := env.q.getIndexUsage("admin", false)
+		require.NoError(t, err)
+		require.Greater(t, resp.TrackedSince, int64(0))
+		require.Len(t, resp.Indexes, 1)
+		require.True(t, proto.Equal(&types.IndexAttributeUsage{
+			DbName:    "db1",
+			Attribute: "attr1",
+			Type:      types.IndexAttributeType_STRING,
+		}, resp.Indexes[0]))
+
+		resp, err = env.q.getIndexUsage("admin", true)
+		require.NoError(t, err)
+		require.Len(t, resp.Indexes, 1)
+	})
+
+	t.Run("used index", func(t *testing.T) {
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			_, err := env.q.executeJSONQuery(context.Background(), "db1", "alice", []byte(`{"selector":{"attr1":{"$eq":"a"}}}`))
+			require.NoError(t, err)
+		}
+
+		resp, err := env.q.getIndexUsage("admin", false)
+		require.NoError(t, err)
+		require.Len(t, resp.Indexes, 1)
+		require.Equal(t, uint64(2), resp.Indexes[0].Hits)
+		require.GreaterOrEqual(t, resp.Indexes[0].LastUsed, start.UnixNano())
+
+		resp, err = env.q.getIndexUsage("admin", true)
+		require.NoError(t, err)
+		require.Empty(t, resp.Indexes)
+	})
+
+	t.Run("user is not an admin", func(t *testing.T) {
+		resp, err := env.q.getIndexUsage("alice", false)
+		require.EqualError(t, err, "the user [alice] has no permission to get the index usage")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+		require.Nil(t, resp)
+	})
+}
+
+func TestGetMigrations(t *testing.T) {
+	env := newWorldstateQueryProcessorTestEnv(t)
+	defer env.cleanup(t)
+
+	var userWrites []*worldstate.KVWithMetadata
+	for _, u := range []*types.User{
+		{Id: "admin", Privilege: &types.Privilege{Admin: true}},
+		{Id: "dbadmin", Privilege: &types.Privilege{DbAdministrationPrefixes: []string{"team1_"}}},
+		{Id: "alice"},
+	} {
+		user, err := proto.Marshal(u)
+		require.NoError(t, err)
+		userWrites = append(userWrites, &worldstate.KVWithMetadata{
+			Key:   string(identity.UserNamespace) + u.Id,
+			Value: user,
+		})
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: userWrites,
+		},
+	}, 1))
+
+	for i, name := range []string{"schema", "accounts"} {
+		updates, err := migration.ConstructDBEntriesForDBAdminTx(env.db, &types.DBAdministrationTx{
+			UserId:    "admin",
+			TxId:      "tx-" + name,
+			Migration: &types.Migration{Name: name, Version: 1},
+		}, &types.Version{BlockNum: uint64(i + 2)})
+		require.NoError(t, err)
+		require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{worldstate.MigrationsDBName: updates}, uint64(i+2)))
+	}
+
+	expectedAccounts := &types.MigrationState{
+		Name:    "accounts",
+		Version: 1,
+		History: []*types.MigrationStep{{Version: 1, TxId: "tx-accounts", UserId: "admin", BlockNumber: 3}},
+	}
+	expectedSchema := &types.MigrationState{
+		Name:    "schema",
+		Version: 1,
+		History: []*types.MigrationStep{{Version: 1, TxId: "tx-schema", UserId: "admin", BlockNumber: 2}},
+	}
+
+	t.Run("all migrations", func(t *testing.T) {
+		for _, userID := range []string{"admin", "dbadmin"} {
+			resp, err := env.q.getMigrations(userID, "")
+			require.NoError(t, err)
+			require.Len(t, resp.Migrations, 2)
+			require.True(t, proto.Equal(expectedAccounts, resp.Migrations[0]))
+			require.True(t, proto.Equal(expectedSchema, resp.Migrations[1]))
+		}
+	})
+
+	t.Run("single migration", func(t *testing.T) {
+		resp, err := env.q.getMigrations("admin", "schema")
+		require.NoError(t, err)
+		require.Len(t, resp.Migrations, 1)
+		require.True(t, proto.Equal(expectedSchema, resp.Migrations[0]))
+	})
+
+	t.Run("unknown migration", func(t *testing.T) {
+		resp, err := env.q.getMigrations("admin", "orders")
+		require.NoError(t, err)
+		require.Len(t, resp.Migrations, 1)
+		require.True(t, proto.Equal(&types.MigrationState{Name: "orders"}, resp.Migrations[0]))
+	})
+
+	t.Run("user is not a database administrator", func(t *testing.T) {
+		resp, err := env.q.getMigrations("alice", "")
+		require.EqualError(t, err, "the user [alice] has no permission to get the migrations")
+		require.IsType(t, &ierrors.PermissionErr{}, err)
+		require.Nil(t, resp)
+	})
+}
@@ -0,0 +1,347 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bench drives the block processing pipeline of a single node with a synthetic workload, and measures the
+// throughput and the latency of every commit phase. The workload bypasses the network, the transaction queues, and
+// consensus, so that runs with different storage or configuration settings can be compared apples-to-apples.
+package bench
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/blockcreator"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// UserID is the identity of the user that submits the data transactions of the workload
+	UserID = "bench"
+
+	KeyDistributionUniform    = "uniform"
+	KeyDistributionZipf       = "zipf"
+	KeyDistributionSequential = "sequential"
+)
+
+// Config holds the parameters of a benchmark run
+type Config struct {
+	// Dir holds the crypto material and the ledger of the run. The ledger must not exist yet.
+	Dir string
+	// Blocks is the number of data blocks to commit
+	Blocks uint32
+	// BlockSize is the number of transactions in a data block
+	BlockSize uint32
+	// OpsPerTx is the number of operations in a transaction. Operations on a key that the transaction already
+	// accesses are skipped, so a transaction may have fewer operations.
+	OpsPerTx uint32
+	// Mix holds the relative weights of reads, writes, and deletes
+	Mix TxMix
+	// KeyDistribution is one of uniform, zipf, or sequential
+	KeyDistribution string
+	// KeySpace is the number of distinct keys the workload accesses
+	KeySpace uint32
+	// ValueSize is the size, in bytes, of a written value
+	ValueSize uint32
+	// Seed initializes the random source, so that runs can be repeated
+	Seed   int64
+	Logger *logger.SugarLogger
+}
+
+// TxMix holds the relative weights of the operations in the workload. A delete of a key that does not exist is
+// turned into a write.
+type TxMix struct {
+	Reads   uint32
+	Writes  uint32
+	Deletes uint32
+}
+
+func (c *Config) validate() error {
+	switch {
+	case c.Dir == "":
+		return errors.New("the benchmark directory is empty")
+	case c.Blocks == 0:
+		return errors.New("the number of blocks must be greater than 0")
+	case c.BlockSize == 0:
+		return errors.New("the block size must be greater than 0")
+	case c.OpsPerTx == 0:
+		return errors.New("the number of operations per transaction must be greater than 0")
+	case c.KeySpace == 0:
+		return errors.New("the key space must be greater than 0")
+	case c.Mix.Reads+c.Mix.Writes+c.Mix.Deletes == 0:
+		return errors.New("at least one of the read, write, or delete weights must be greater than 0")
+	case c.Logger == nil:
+		return errors.New("logger is nil")
+	}
+
+	switch c.KeyDistribution {
+	case KeyDistributionUniform, KeyDistributionZipf, KeyDistributionSequential:
+		return nil
+	default:
+		return errors.Errorf("unknown key distribution [%s], expected one of: %s, %s, %s",
+			c.KeyDistribution, KeyDistributionUniform, KeyDistributionZipf, KeyDistributionSequential)
+	}
+}
+
+// Run sets up a single node ledger in the benchmark directory, commits the workload block by block through the
+// block processor, and reports the measurements. The genesis block and the block that creates the benchmark user
+// are not measured.
+func Run(conf *Config) (*Report, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	env, err := newEnv(conf)
+	if err != nil {
+		return nil, err
+	}
+	defer env.close()
+
+	if err = env.setup(); err != nil {
+		return nil, err
+	}
+
+	w := newWorkload(conf, env.db, env.adminSigner)
+	recorder := env.recorder
+	recorder.reset()
+
+	var txs, validTxs uint64
+	runStart := time.Now()
+	for blockNum := uint64(3); blockNum < uint64(conf.Blocks)+3; blockNum++ {
+		block, err := w.nextBlock(blockNum)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		if _, err = env.barrier.EnqueueWait(block); err != nil {
+			return nil, errors.WithMessagef(err, "failed to commit block %d", blockNum)
+		}
+		recorder.observeBlock(time.Since(start))
+
+		for _, info := range block.GetHeader().GetValidationInfo() {
+			txs++
+			if info.GetFlag() == types.Flag_VALID {
+				validTxs++
+			}
+		}
+	}
+
+	return recorder.report(uint64(conf.Blocks), txs, validTxs, time.Since(runStart)), nil
+}
+
+type env struct {
+	conf        *Config
+	devConf     *config.Configurations
+	blobStore   *blobstore.Store
+	db          *leveldb.LevelDB
+	blockStore  *blockstore.Store
+	provenance  provenance.Store
+	trieStore   *mptrieStore.Store
+	barrier     *queue.OneQueueBarrier
+	processor   *blockprocessor.BlockProcessor
+	recorder    *phaseRecorder
+	adminSigner crypto.Signer
+	closers     []func() error
+}
+
+func newEnv(conf *Config) (*env, error) {
+	devConf, err := config.DevConfig(conf.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerDir := devConf.LocalConfig.Server.Database.LedgerDirectory
+	if files, err := ioutil.ReadDir(ledgerDir); err == nil && len(files) > 0 {
+		return nil, errors.Errorf("the ledger directory [%s] is not empty, a benchmark must start from an empty ledger", ledgerDir)
+	}
+
+	e := &env{
+		conf:     conf,
+		devConf:  devConf,
+		recorder: newPhaseRecorder(),
+	}
+
+	e.adminSigner, err = crypto.NewSigner(&crypto.SignerOptions{
+		Identity:    config.DevAdminID,
+		KeyFilePath: path.Join(conf.Dir, "crypto", config.DevAdminID+".key"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "can't load the private key of the admin")
+	}
+
+	if e.blobStore, err = blobstore.Open(&blobstore.Config{
+		StoreDir: filepath.Join(ledgerDir, "blobstore"),
+		Logger:   conf.Logger,
+	}); err != nil {
+		return nil, errors.WithMessage(err, "error while creating the blob store")
+	}
+	e.closers = append(e.closers, e.blobStore.Close)
+
+	if e.db, err = leveldb.Open(&leveldb.Config{
+		DBRootDir: filepath.Join(ledgerDir, "worldstate"),
+		BlobStore: e.blobStore,
+		Logger:    conf.Logger,
+	}); err != nil {
+		e.close()
+		return nil, errors.WithMessage(err, "error while creating the world state database")
+	}
+	e.closers = append(e.closers, e.db.Close)
+
+	if e.blockStore, err = blockstore.Open(&blockstore.Config{
+		StoreDir: filepath.Join(ledgerDir, "blockstore"),
+		Logger:   conf.Logger,
+	}); err != nil {
+		e.close()
+		return nil, errors.WithMessage(err, "error while creating the block store")
+	}
+	e.closers = append(e.closers, e.blockStore.Close)
+
+	if e.provenance, err = provenance.Open(&provenance.Config{
+		StoreDir: filepath.Join(ledgerDir, "provenancestore"),
+		Logger:   conf.Logger,
+	}); err != nil {
+		e.close()
+		return nil, errors.WithMessage(err, "error while creating the provenance store")
+	}
+	e.closers = append(e.closers, e.provenance.Close)
+
+	if e.trieStore, err = mptrieStore.Open(&mptrieStore.Config{
+		StoreDir: filepath.Join(ledgerDir, "statetriestore"),
+		Logger:   conf.Logger,
+	}); err != nil {
+		e.close()
+		return nil, errors.WithMessage(err, "error while creating the state trie store")
+	}
+	e.closers = append(e.closers, e.trieStore.Close)
+
+	e.barrier = queue.NewOneQueueBarrier(conf.Logger)
+	e.processor = blockprocessor.New(&blockprocessor.Config{
+		BlockOneQueueBarrier: e.barrier,
+		BlockStore:           e.blockStore,
+		DB:                   e.db,
+		ProvenanceStore:      e.provenance,
+		StateTrieStore:       e.trieStore,
+		TxValidator: txvalidation.NewValidator(&txvalidation.Config{
+			DB:      e.db,
+			DBUsage: e.blockStore,
+			Logger:  conf.Logger,
+		}),
+		PhaseObserver: e.recorder,
+		Logger:        conf.Logger,
+	})
+
+	go e.processor.Start()
+	e.processor.WaitTillStart()
+	e.closers = append([]func() error{func() error {
+		e.processor.Stop()
+		return nil
+	}}, e.closers...)
+
+	return e, nil
+}
+
+// setup commits the genesis block and a block that creates the benchmark user, with read-write access to the
+// default database. The benchmark user shares the certificate of the admin, so that the admin key signs the workload.
+func (e *env) setup() error {
+	configTx, err := bcdb.PrepareBootstrapConfigTx(e.devConf)
+	if err != nil {
+		return err
+	}
+	genesisBlock, err := blockcreator.BootstrapBlock(configTx)
+	if err != nil {
+		return err
+	}
+	if _, err = e.barrier.EnqueueWait(genesisBlock); err != nil {
+		return errors.WithMessage(err, "failed to commit the genesis block")
+	}
+
+	adminCertPEM, err := ioutil.ReadFile(e.devConf.SharedConfig.Admin.CertificatePath)
+	if err != nil {
+		return errors.Wrap(err, "can't read the certificate of the admin")
+	}
+	adminCert, _ := pem.Decode(adminCertPEM)
+	if adminCert == nil {
+		return errors.Errorf("failed to decode the certificate of the admin in [%s]", e.devConf.SharedConfig.Admin.CertificatePath)
+	}
+
+	userTx := &types.UserAdministrationTx{
+		UserId: config.DevAdminID,
+		TxId:   "bench-create-user",
+		UserWrites: []*types.UserWrite{
+			{
+				User: &types.User{
+					Id:          UserID,
+					Certificate: adminCert.Bytes,
+					Privilege: &types.Privilege{
+						DbPermission: map[string]types.Privilege_Access{
+							worldstate.DefaultDBName: types.Privilege_ReadWrite,
+						},
+					},
+				},
+			},
+		},
+	}
+	sig, err := cryptoservice.SignTx(e.adminSigner, userTx)
+	if err != nil {
+		return err
+	}
+
+	userBlock := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: 2,
+			},
+		},
+		Payload: &types.Block_UserAdministrationTxEnvelope{
+			UserAdministrationTxEnvelope: &types.UserAdministrationTxEnvelope{
+				Payload:   userTx,
+				Signature: sig,
+			},
+		},
+	}
+	if _, err = e.barrier.EnqueueWait(userBlock); err != nil {
+		return errors.WithMessage(err, "failed to commit the block that creates the benchmark user")
+	}
+
+	exist, err := identity.NewQuerier(e.db).DoesUserExist(UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.Errorf("the benchmark user was not created, validation info: %v", userBlock.GetHeader().GetValidationInfo())
+	}
+
+	return nil
+}
+
+func (e *env) close() {
+	for _, closeFunc := range e.closers {
+		if err := closeFunc(); err != nil {
+			e.conf.Logger.Warnf("error while closing the benchmark environment: %s", err)
+		}
+	}
+}
+
+func benchKey(index uint64) string {
+	return fmt.Sprintf("key-%d", index)
+}
@@ -0,0 +1,135 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bench
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(t *testing.T, dir string) *Config {
+	c := &logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(c)
+	require.NoError(t, err)
+
+	return &Config{
+		Dir:             dir,
+		Blocks:          5,
+		BlockSize:       10,
+		OpsPerTx:        3,
+		Mix:             TxMix{Reads: 2, Writes: 6, Deletes: 2},
+		KeyDistribution: KeyDistributionZipf,
+		KeySpace:        50,
+		ValueSize:       64,
+		Seed:            7,
+		Logger:          logger,
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "bench")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	conf := newTestConfig(t, dir)
+	report, err := Run(conf)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(5), report.Blocks)
+	require.Equal(t, uint64(50), report.Txs)
+	require.True(t, report.ValidTxs > 0)
+	require.True(t, report.ValidTxs <= report.Txs)
+	require.True(t, report.TxsPerSecond > 0)
+
+	var phases []string
+	for _, l := range report.Latencies {
+		phases = append(phases, l.Phase)
+		require.Equal(t, 5, l.Count)
+		require.True(t, l.P50 <= l.P90 && l.P90 <= l.P99 && l.P99 <= l.Max)
+	}
+	require.Equal(t, []string{
+		string(blockprocessor.PhaseValidate),
+		string(blockprocessor.PhaseStateTrie),
+		string(blockprocessor.PhaseBlockStore),
+		string(blockprocessor.PhaseProvenance),
+		string(blockprocessor.PhaseStateDB),
+		string(blockprocessor.PhaseTrieCommit),
+		string(blockprocessor.PhaseOutbox),
+		PhaseBlock,
+	}, phases)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, report.WriteText(out))
+	require.Contains(t, out.String(), "blocks: 5, txs: 50")
+	require.Contains(t, out.String(), "trie_commit")
+
+	_, err = Run(conf)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not empty, a benchmark must start from an empty ledger")
+}
+
+func TestRunBadConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		update      func(c *Config)
+		expectedErr string
+	}{
+		{
+			name:        "no blocks",
+			update:      func(c *Config) { c.Blocks = 0 },
+			expectedErr: "the number of blocks must be greater than 0",
+		},
+		{
+			name:        "empty mix",
+			update:      func(c *Config) { c.Mix = TxMix{} },
+			expectedErr: "at least one of the read, write, or delete weights must be greater than 0",
+		},
+		{
+			name:        "unknown key distribution",
+			update:      func(c *Config) { c.KeyDistribution = "normal" },
+			expectedErr: "unknown key distribution [normal], expected one of: uniform, zipf, sequential",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := newTestConfig(t, "/tmp/bench-never-created")
+			tt.update(conf)
+			report, err := Run(conf)
+			require.EqualError(t, err, tt.expectedErr)
+			require.Nil(t, report)
+		})
+	}
+}
+
+func TestLatencyStats(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	require.Equal(t, &LatencyStats{
+		Phase: "p",
+		Count: 100,
+		Mean:  50500 * time.Microsecond,
+		P50:   50 * time.Millisecond,
+		P90:   90 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}, latencyStats("p", latencies))
+	require.Equal(t, 100*time.Millisecond, latencies[0], "the input must not be reordered")
+	require.Nil(t, latencyStats("p", nil))
+}
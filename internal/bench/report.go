@@ -0,0 +1,151 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bench
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor"
+)
+
+// PhaseBlock is the end-to-end latency of a block, from enqueueing it to the block processor until it is committed
+const PhaseBlock = "block"
+
+// Report holds the measurements of a benchmark run
+type Report struct {
+	Blocks          uint64          `json:"blocks"`
+	Txs             uint64          `json:"txs"`
+	ValidTxs        uint64          `json:"valid_txs"`
+	Duration        time.Duration   `json:"duration_ns"`
+	TxsPerSecond    float64         `json:"txs_per_second"`
+	BlocksPerSecond float64         `json:"blocks_per_second"`
+	Latencies       []*LatencyStats `json:"latencies"`
+}
+
+// LatencyStats holds the latency percentiles of a commit phase, or of the whole block
+type LatencyStats struct {
+	Phase string        `json:"phase"`
+	Count int           `json:"count"`
+	Mean  time.Duration `json:"mean_ns"`
+	P50   time.Duration `json:"p50_ns"`
+	P90   time.Duration `json:"p90_ns"`
+	P99   time.Duration `json:"p99_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// WriteText writes the report as a human readable table
+func (r *Report) WriteText(out io.Writer) error {
+	if _, err := fmt.Fprintf(out, "blocks: %d, txs: %d, valid txs: %d, duration: %s\nthroughput: %.1f txs/s, %.1f blocks/s\n\n",
+		r.Blocks, r.Txs, r.ValidTxs, r.Duration, r.TxsPerSecond, r.BlocksPerSecond); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "phase\tcount\tmean\tp50\tp90\tp99\tmax\t")
+	for _, l := range r.Latencies {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t\n", l.Phase, l.Count, l.Mean, l.P50, l.P90, l.P99, l.Max)
+	}
+	return tw.Flush()
+}
+
+// phaseRecorder collects the latencies of the commit phases reported by the block processor
+type phaseRecorder struct {
+	lock      sync.Mutex
+	latencies map[string][]time.Duration
+}
+
+func newPhaseRecorder() *phaseRecorder {
+	return &phaseRecorder{
+		latencies: make(map[string][]time.Duration),
+	}
+}
+
+func (p *phaseRecorder) ObservePhase(_ uint64, phase blockprocessor.CommitPhase, elapsed time.Duration) {
+	p.observe(string(phase), elapsed)
+}
+
+func (p *phaseRecorder) observeBlock(elapsed time.Duration) {
+	p.observe(PhaseBlock, elapsed)
+}
+
+func (p *phaseRecorder) observe(phase string, elapsed time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.latencies[phase] = append(p.latencies[phase], elapsed)
+}
+
+func (p *phaseRecorder) reset() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.latencies = make(map[string][]time.Duration)
+}
+
+func (p *phaseRecorder) report(blocks, txs, validTxs uint64, duration time.Duration) *Report {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r := &Report{
+		Blocks:   blocks,
+		Txs:      txs,
+		ValidTxs: validTxs,
+		Duration: duration,
+	}
+	if seconds := duration.Seconds(); seconds > 0 {
+		r.TxsPerSecond = float64(txs) / seconds
+		r.BlocksPerSecond = float64(blocks) / seconds
+	}
+
+	for _, phase := range blockprocessor.CommitPhases {
+		if stats := latencyStats(string(phase), p.latencies[string(phase)]); stats != nil {
+			r.Latencies = append(r.Latencies, stats)
+		}
+	}
+	if stats := latencyStats(PhaseBlock, p.latencies[PhaseBlock]); stats != nil {
+		r.Latencies = append(r.Latencies, stats)
+	}
+
+	return r
+}
+
+func latencyStats(phase string, latencies []time.Duration) *LatencyStats {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, l := range sorted {
+		sum += l
+	}
+
+	return &LatencyStats{
+		Phase: phase,
+		Count: len(sorted),
+		Mean:  sum / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P99:   percentile(sorted, 99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,168 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// zipfS is the skew of the zipf key distribution; the larger it is, the hotter the first keys are
+const zipfS = 1.1
+
+type operation int
+
+const (
+	opRead operation = iota
+	opWrite
+	opDelete
+)
+
+// workload generates data blocks. It reads the versions of the keys from the world state database, hence the next
+// block must be generated only after the previous one has been committed. Transactions in the same block that access
+// the same key may conflict, and are then marked invalid by the validator, as it would happen under real load.
+type workload struct {
+	conf    *Config
+	db      worldstate.DB
+	signer  crypto.Signer
+	rand    *rand.Rand
+	zipf    *rand.Zipf
+	nextSeq uint64
+	value   []byte
+	txNum   uint64
+}
+
+func newWorkload(conf *Config, db worldstate.DB, signer crypto.Signer) *workload {
+	r := rand.New(rand.NewSource(conf.Seed))
+	value := make([]byte, conf.ValueSize)
+	r.Read(value)
+
+	w := &workload{
+		conf:   conf,
+		db:     db,
+		signer: signer,
+		rand:   r,
+		value:  value,
+	}
+	if conf.KeyDistribution == KeyDistributionZipf {
+		w.zipf = rand.NewZipf(r, zipfS, 1, uint64(conf.KeySpace)-1)
+	}
+
+	return w
+}
+
+func (w *workload) nextBlock(number uint64) (*types.Block, error) {
+	envelopes := make([]*types.DataTxEnvelope, 0, w.conf.BlockSize)
+	for i := uint32(0); i < w.conf.BlockSize; i++ {
+		env, err := w.nextTx()
+		if err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, env)
+	}
+
+	return &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: number,
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: envelopes,
+			},
+		},
+	}, nil
+}
+
+func (w *workload) nextTx() (*types.DataTxEnvelope, error) {
+	w.txNum++
+	ops := &types.DBOperation{
+		DbName: worldstate.DefaultDBName,
+	}
+
+	accessed := make(map[string]struct{})
+	for i := uint32(0); i < w.conf.OpsPerTx; i++ {
+		key := w.nextKey()
+		if _, ok := accessed[key]; ok {
+			continue
+		}
+		accessed[key] = struct{}{}
+
+		switch w.nextOp() {
+		case opRead:
+			version, err := w.db.GetVersion(worldstate.DefaultDBName, key)
+			if err != nil {
+				return nil, err
+			}
+			ops.DataReads = append(ops.DataReads, &types.DataRead{
+				Key:     key,
+				Version: version,
+			})
+		case opDelete:
+			exist, err := w.db.Has(worldstate.DefaultDBName, key)
+			if err != nil {
+				return nil, err
+			}
+			if exist {
+				ops.DataDeletes = append(ops.DataDeletes, &types.DataDelete{Key: key})
+				continue
+			}
+			fallthrough
+		case opWrite:
+			ops.DataWrites = append(ops.DataWrites, &types.DataWrite{
+				Key:   key,
+				Value: w.value,
+			})
+		}
+	}
+
+	tx := &types.DataTx{
+		MustSignUserIds: []string{UserID},
+		TxId:            fmt.Sprintf("bench-tx-%d", w.txNum),
+		DbOperations:    []*types.DBOperation{ops},
+	}
+	sig, err := cryptoservice.SignTx(w.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DataTxEnvelope{
+		Payload:    tx,
+		Signatures: map[string][]byte{UserID: sig},
+	}, nil
+}
+
+func (w *workload) nextKey() string {
+	var index uint64
+	switch w.conf.KeyDistribution {
+	case KeyDistributionZipf:
+		index = w.zipf.Uint64()
+	case KeyDistributionSequential:
+		index = w.nextSeq % uint64(w.conf.KeySpace)
+		w.nextSeq++
+	default:
+		index = uint64(w.rand.Int63n(int64(w.conf.KeySpace)))
+	}
+
+	return benchKey(index)
+}
+
+func (w *workload) nextOp() operation {
+	mix := w.conf.Mix
+	n := uint32(w.rand.Int63n(int64(mix.Reads + mix.Writes + mix.Deletes)))
+	switch {
+	case n < mix.Reads:
+		return opRead
+	case n < mix.Reads+mix.Writes:
+		return opWrite
+	default:
+		return opDelete
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blobstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"path/filepath"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/storeformat"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	// DefaultValueSizeThreshold is the default size in bytes above which a value of a data database must be uploaded
+	// to the blob store, and written by its manifest.
+	DefaultValueSizeThreshold = 1 << 20
+	// DefaultChunkSize is the default size in bytes of the chunks an uploaded value is split into.
+	DefaultChunkSize = 256 << 10
+)
+
+var (
+	// blobsDBName holds the chunks, keyed by their SHA-256 hash
+	blobsDBName = "blobs"
+
+	// underCreationFlag is used to mark that the store
+	// is being created. If a failure happens during the
+	// creation, the retry logic will use this file to
+	// detect the partially created store and do cleanup
+	// before creating a new store
+	underCreationFlag = "undercreation"
+
+	// Namespaces for different data types:
+	// chunks, keyed by their hash
+	chunkNs = []byte{0}
+	// the time since which a chunk is not referenced by the world state, keyed by the hash of the chunk
+	unreferencedSinceNs = []byte{1}
+)
+
+// format is the on-disk format of the blob store.
+var format = &storeformat.Format{
+	Store:   "blob store",
+	Version: 2,
+	Migrations: map[uint32]*storeformat.Migration{
+		1: {
+			Description: "move the chunks under a namespace, next to the marks of the garbage collection",
+			Migrate:     moveChunksToNamespace,
+		},
+	},
+}
+
+// Store holds the large values of the data databases as content-addressed chunks. A chunk is stored once, however many
+// values it is part of. The chunks that are no longer referenced by the world state are removed by CollectGarbage.
+type Store struct {
+	db     *leveldb.DB
+	logger *logger.SugarLogger
+}
+
+// Config holds the configuration of the blob store.
+type Config struct {
+	StoreDir string
+	Logger   *logger.SugarLogger
+}
+
+// Open opens the blob store, and creates it if it does not exist.
+func Open(conf *Config) (*Store, error) {
+	exist, err := fileops.Exists(conf.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		partial, err := isExistingStoreCreatedPartially(conf.StoreDir)
+		if err != nil {
+			return nil, err
+		}
+		if !partial {
+			if err := format.Upgrade(conf.StoreDir, conf.Logger); err != nil {
+				return nil, err
+			}
+			db, err := leveldb.OpenFile(filepath.Join(conf.StoreDir, blobsDBName), &opt.Options{ErrorIfMissing: true})
+			if err != nil {
+				return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the blob store")
+			}
+			return &Store{db: db, logger: conf.Logger}, nil
+		}
+
+		if err := fileops.RemoveAll(conf.StoreDir); err != nil {
+			return nil, errors.Wrap(err, "error while removing the existing partially created store")
+		}
+	}
+
+	if err := fileops.CreateDir(conf.StoreDir); err != nil {
+		return nil, errors.WithMessagef(err, "error while creating directory [%s]", conf.StoreDir)
+	}
+
+	underCreationFlagPath := filepath.Join(conf.StoreDir, underCreationFlag)
+	if err := fileops.CreateFile(underCreationFlagPath); err != nil {
+		return nil, err
+	}
+
+	db, err := leveldb.OpenFile(filepath.Join(conf.StoreDir, blobsDBName), &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the blob store database")
+	}
+
+	if err := format.Init(conf.StoreDir); err != nil {
+		return nil, err
+	}
+
+	if err := fileops.Remove(underCreationFlagPath); err != nil {
+		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
+	}
+
+	return &Store{db: db, logger: conf.Logger}, nil
+}
+
+func isExistingStoreCreatedPartially(storeDir string) (bool, error) {
+	empty, err := fileops.IsDirEmpty(storeDir)
+	if err != nil || empty {
+		return true, err
+	}
+
+	return fileops.Exists(filepath.Join(storeDir, underCreationFlag))
+}
+
+// moveChunksToNamespace migrates a blob store of the first format, which held the chunks under their bare hashes.
+func moveChunksToNamespace(storeDir string, _ *logger.SugarLogger) error {
+	db, err := leveldb.OpenFile(filepath.Join(storeDir, blobsDBName), &opt.Options{ErrorIfMissing: true})
+	if err != nil {
+		return errors.WithMessage(err, "error while opening the existing leveldb file for the blob store")
+	}
+
+	batch := &leveldb.Batch{}
+	itr := db.NewIterator(nil, nil)
+	for itr.Next() {
+		// the keys of the chunks moved by an interrupted migration are longer than a hash, and are left as they are
+		if len(itr.Key()) != sha256.Size {
+			continue
+		}
+		batch.Put(chunkKey(itr.Key()), itr.Value())
+		batch.Delete(itr.Key())
+	}
+	itr.Release()
+	if err = itr.Error(); err != nil {
+		db.Close()
+		return errors.Wrap(err, "error while iterating over the chunks of the blob store")
+	}
+
+	if err = db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		db.Close()
+		return errors.Wrap(err, "error while moving the chunks of the blob store")
+	}
+	return db.Close()
+}
+
+// Close closes the blob store.
+func (s *Store) Close() error {
+	if err := s.db.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the blob store database")
+	}
+	return nil
+}
+
+// NewManifest splits the value into chunks of the given size and returns the manifest of the value. The manifest
+// depends only on the value and the chunk size, hence it is the same on all nodes.
+func NewManifest(value []byte, chunkSize uint64) *types.BlobManifest {
+	manifest := &types.BlobManifest{
+		Size:      uint64(len(value)),
+		ChunkSize: chunkSize,
+	}
+	for _, chunk := range split(value, chunkSize) {
+		hash := sha256.Sum256(chunk)
+		manifest.ChunkHashes = append(manifest.ChunkHashes, hash[:])
+	}
+
+	return manifest
+}
+
+// ValidateManifest checks that a manifest describes a non-empty value, split into chunks of its chunk size, each of
+// which is addressed by a SHA-256 hash. It depends only on the manifest, hence it gives the same result on all nodes.
+func ValidateManifest(manifest *types.BlobManifest) error {
+	if manifest.GetSize() == 0 || manifest.GetChunkSize() == 0 {
+		return errors.Errorf("the size [%d] and the chunk size [%d] of a blob must be positive",
+			manifest.GetSize(), manifest.GetChunkSize())
+	}
+
+	chunks := (manifest.GetSize() + manifest.GetChunkSize() - 1) / manifest.GetChunkSize()
+	if uint64(len(manifest.GetChunkHashes())) != chunks {
+		return errors.Errorf("a blob of size [%d] in chunks of size [%d] has [%d] chunks, but the manifest lists [%d]",
+			manifest.GetSize(), manifest.GetChunkSize(), chunks, len(manifest.GetChunkHashes()))
+	}
+	for _, hash := range manifest.GetChunkHashes() {
+		if len(hash) != sha256.Size {
+			return errors.Errorf("the chunk hash [%x] is not a SHA-256 hash", hash)
+		}
+	}
+	return nil
+}
+
+// PutValue stores the chunks of a value as described by its manifest. Chunks that are already stored are written
+// again, which is harmless as a chunk is addressed by its content, and lose their garbage collection mark.
+func (s *Store) PutValue(manifest *types.BlobManifest, value []byte) error {
+	chunks := split(value, manifest.GetChunkSize())
+	if uint64(len(value)) != manifest.GetSize() || len(chunks) != len(manifest.GetChunkHashes()) {
+		return errors.Errorf("the value of size [%d] does not match the manifest of size [%d] with [%d] chunks",
+			len(value), manifest.GetSize(), len(manifest.GetChunkHashes()))
+	}
+
+	batch := &leveldb.Batch{}
+	for i, chunk := range chunks {
+		batch.Put(chunkKey(manifest.ChunkHashes[i]), chunk)
+		batch.Delete(unreferencedSinceKey(manifest.ChunkHashes[i]))
+	}
+
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrap(err, "error while writing the chunks of a value to the blob store")
+	}
+	return nil
+}
+
+// PutChunk stores a chunk pulled from another node, after it checks the chunk against its hash.
+func (s *Store) PutChunk(hash, chunk []byte) error {
+	actual := sha256.Sum256(chunk)
+	if !bytes.Equal(actual[:], hash) {
+		return errors.Errorf("the chunk does not match its hash [%x]", hash)
+	}
+
+	batch := &leveldb.Batch{}
+	batch.Put(chunkKey(hash), chunk)
+	batch.Delete(unreferencedSinceKey(hash))
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrapf(err, "error while writing the chunk [%x] to the blob store", hash)
+	}
+	return nil
+}
+
+// GetChunk returns a chunk, or nil if the store does not hold it.
+func (s *Store) GetChunk(hash []byte) ([]byte, error) {
+	chunk, err := s.db.Get(chunkKey(hash), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the chunk [%x] from the blob store", hash)
+	}
+	return chunk, nil
+}
+
+// Retain returns the hashes of the chunks of a manifest that the store does not hold, and clears the garbage
+// collection mark of the chunks it holds, so that they are not removed before the manifest is committed, as long as
+// the manifest is committed within the grace period of the garbage collection.
+func (s *Store) Retain(manifest *types.BlobManifest) ([][]byte, error) {
+	var missing [][]byte
+	batch := &leveldb.Batch{}
+	for _, hash := range manifest.GetChunkHashes() {
+		found, err := s.db.Has(chunkKey(hash), nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while looking up the chunk [%x] in the blob store", hash)
+		}
+		if !found {
+			missing = append(missing, hash)
+			continue
+		}
+		batch.Delete(unreferencedSinceKey(hash))
+	}
+
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return nil, errors.Wrap(err, "error while clearing the garbage collection marks of the blob store")
+	}
+	return missing, nil
+}
+
+// GetValue assembles a value from the chunks listed in its manifest, and verifies the hash of every chunk.
+func (s *Store) GetValue(manifest *types.BlobManifest) ([]byte, error) {
+	value := make([]byte, 0, manifest.GetSize())
+	for _, hash := range manifest.GetChunkHashes() {
+		chunk, err := s.GetChunk(hash)
+		if err != nil {
+			return nil, err
+		}
+		if chunk == nil {
+			return nil, errors.Errorf("the chunk [%x] is missing in the blob store", hash)
+		}
+
+		actual := sha256.Sum256(chunk)
+		if !bytes.Equal(actual[:], hash) {
+			return nil, errors.Errorf("the chunk [%x] in the blob store is corrupted", hash)
+		}
+		value = append(value, chunk...)
+	}
+
+	if uint64(len(value)) != manifest.GetSize() {
+		return nil, errors.Errorf("the assembled value of size [%d] does not match the manifest of size [%d]",
+			len(value), manifest.GetSize())
+	}
+	return value, nil
+}
+
+// CollectGarbage removes the chunks that have not been referenced for the grace period. A chunk that is referenced,
+// as reported by referenced, loses its mark. A chunk that is not referenced is marked with the current time when it
+// is not marked yet, and is removed when it was marked before the grace period. The grace period must exceed the time
+// an uploaded chunk may wait for the transaction that references it to commit, and the time a snapshot of the world
+// state that references an overwritten value may be read. It returns the number of chunks removed.
+func (s *Store) CollectGarbage(referenced func(hash []byte) bool, now time.Time, gracePeriod time.Duration) (int, error) {
+	batch := &leveldb.Batch{}
+	removed := 0
+
+	itr := s.db.NewIterator(util.BytesPrefix(chunkNs), nil)
+	for itr.Next() {
+		hash := append([]byte(nil), itr.Key()[len(chunkNs):]...)
+		if referenced(hash) {
+			batch.Delete(unreferencedSinceKey(hash))
+			continue
+		}
+
+		since, err := s.db.Get(unreferencedSinceKey(hash), nil)
+		switch {
+		case err != nil && err != leveldb.ErrNotFound:
+			itr.Release()
+			return 0, errors.Wrapf(err, "error while reading the garbage collection mark of the chunk [%x]", hash)
+		case len(since) != 8:
+			// the chunk is not marked yet, or its mark is corrupted
+			batch.Put(unreferencedSinceKey(hash), encodeTime(now))
+		case now.Sub(decodeTime(since)) > gracePeriod:
+			batch.Delete(chunkKey(hash))
+			batch.Delete(unreferencedSinceKey(hash))
+			removed++
+		}
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return 0, errors.Wrap(err, "error while iterating over the chunks of the blob store")
+	}
+
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return 0, errors.Wrap(err, "error while removing the unreferenced chunks of the blob store")
+	}
+	if removed > 0 {
+		s.logger.Infof("removed %d chunks that were not referenced for %s from the blob store", removed, gracePeriod)
+	}
+	return removed, nil
+}
+
+func chunkKey(hash []byte) []byte {
+	return append(append([]byte{}, chunkNs...), hash...)
+}
+
+func unreferencedSinceKey(hash []byte) []byte {
+	return append(append([]byte{}, unreferencedSinceNs...), hash...)
+}
+
+func encodeTime(t time.Time) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(t.UnixNano()))
+	return b
+}
+
+func decodeTime(b []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+}
+
+func split(value []byte, chunkSize uint64) [][]byte {
+	if chunkSize == 0 {
+		return nil
+	}
+
+	var chunks [][]byte
+	for start := uint64(0); start < uint64(len(value)); start += chunkSize {
+		end := start + chunkSize
+		if end > uint64(len(value)) {
+			end = uint64(len(value))
+		}
+		chunks = append(chunks, value[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,243 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blobstore
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/storeformat"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func newTestStore(t *testing.T) (*Store, string) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+		Name:          "blobstore",
+	})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "blobstore")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	storeDir := filepath.Join(dir, "blobstore")
+	s, err := Open(&Config{StoreDir: storeDir, Logger: lg})
+	require.NoError(t, err)
+
+	return s, storeDir
+}
+
+func TestNewManifest(t *testing.T) {
+	value := []byte("0123456789")
+	manifest := NewManifest(value, 4)
+
+	hash := func(chunk string) []byte {
+		h := sha256.Sum256([]byte(chunk))
+		return h[:]
+	}
+	require.Equal(t, &types.BlobManifest{
+		Size:        10,
+		ChunkSize:   4,
+		ChunkHashes: [][]byte{hash("0123"), hash("4567"), hash("89")},
+	}, manifest)
+
+	require.Equal(t, manifest, NewManifest([]byte("0123456789"), 4))
+	require.NotEqual(t, manifest, NewManifest([]byte("0123456788"), 4))
+}
+
+func TestValidateManifest(t *testing.T) {
+	require.NoError(t, ValidateManifest(NewManifest([]byte("0123456789"), 4)))
+
+	require.EqualError(t, ValidateManifest(&types.BlobManifest{ChunkSize: 4}),
+		"the size [0] and the chunk size [4] of a blob must be positive")
+
+	manifest := NewManifest([]byte("0123456789"), 4)
+	manifest.ChunkHashes = manifest.ChunkHashes[:2]
+	require.EqualError(t, ValidateManifest(manifest),
+		"a blob of size [10] in chunks of size [4] has [3] chunks, but the manifest lists [2]")
+
+	manifest = NewManifest([]byte("0123"), 4)
+	manifest.ChunkHashes[0] = []byte("short")
+	require.EqualError(t, ValidateManifest(manifest), "the chunk hash [73686f7274] is not a SHA-256 hash")
+}
+
+func TestStore(t *testing.T) {
+	t.Run("put and get values", func(t *testing.T) {
+		s, _ := newTestStore(t)
+		defer s.Close()
+
+		value1 := []byte("0123456789")
+		manifest1 := NewManifest(value1, 4)
+		require.NoError(t, s.PutValue(manifest1, value1))
+
+		// the second value shares the first chunk with the first value
+		value2 := []byte("0123abc")
+		manifest2 := NewManifest(value2, 4)
+		require.NoError(t, s.PutValue(manifest2, value2))
+
+		actual, err := s.GetValue(manifest1)
+		require.NoError(t, err)
+		require.Equal(t, value1, actual)
+
+		actual, err = s.GetValue(manifest2)
+		require.NoError(t, err)
+		require.Equal(t, value2, actual)
+	})
+
+	t.Run("value does not match the manifest", func(t *testing.T) {
+		s, _ := newTestStore(t)
+		defer s.Close()
+
+		err := s.PutValue(NewManifest([]byte("0123456789"), 4), []byte("0123"))
+		require.EqualError(t, err, "the value of size [4] does not match the manifest of size [10] with [3] chunks")
+	})
+
+	t.Run("missing chunk", func(t *testing.T) {
+		s, _ := newTestStore(t)
+		defer s.Close()
+
+		manifest := NewManifest([]byte("0123"), 4)
+		_, err := s.GetValue(manifest)
+		require.EqualError(t, err, "the chunk [1be2e452b46d7a0d9656bbb1f768e8248eba1b75baed65f5d99eafa948899a6a] is missing in the blob store")
+	})
+
+	t.Run("put chunks and retain a manifest", func(t *testing.T) {
+		s, _ := newTestStore(t)
+		defer s.Close()
+
+		value := []byte("0123456789")
+		manifest := NewManifest(value, 4)
+
+		missing, err := s.Retain(manifest)
+		require.NoError(t, err)
+		require.Equal(t, manifest.ChunkHashes, missing)
+
+		require.EqualError(t, s.PutChunk(manifest.ChunkHashes[0], []byte("4567")),
+			"the chunk does not match its hash [1be2e452b46d7a0d9656bbb1f768e8248eba1b75baed65f5d99eafa948899a6a]")
+		require.NoError(t, s.PutChunk(manifest.ChunkHashes[0], []byte("0123")))
+		require.NoError(t, s.PutChunk(manifest.ChunkHashes[2], []byte("89")))
+
+		missing, err = s.Retain(manifest)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{manifest.ChunkHashes[1]}, missing)
+
+		chunk, err := s.GetChunk(manifest.ChunkHashes[1])
+		require.NoError(t, err)
+		require.Nil(t, chunk)
+		require.NoError(t, s.PutChunk(manifest.ChunkHashes[1], []byte("4567")))
+
+		actual, err := s.GetValue(manifest)
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+	})
+
+	t.Run("collect garbage", func(t *testing.T) {
+		s, _ := newTestStore(t)
+		defer s.Close()
+
+		kept := []byte("0123456789")
+		keptManifest := NewManifest(kept, 4)
+		require.NoError(t, s.PutValue(keptManifest, kept))
+		dropped := []byte("abcdefgh")
+		droppedManifest := NewManifest(dropped, 4)
+		require.NoError(t, s.PutValue(droppedManifest, dropped))
+
+		referenced := func(hash []byte) bool {
+			for _, h := range keptManifest.ChunkHashes {
+				if string(h) == string(hash) {
+					return true
+				}
+			}
+			return false
+		}
+
+		// the unreferenced chunks are marked by the first run, and removed once they are marked for the grace period
+		now := time.Now()
+		removed, err := s.CollectGarbage(referenced, now, time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 0, removed)
+		removed, err = s.CollectGarbage(referenced, now.Add(30*time.Minute), time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 0, removed)
+
+		// a chunk that is uploaded again loses its mark
+		require.NoError(t, s.PutChunk(droppedManifest.ChunkHashes[0], []byte("abcd")))
+		removed, err = s.CollectGarbage(referenced, now.Add(2*time.Hour), time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 1, removed)
+
+		missing, err := s.Retain(droppedManifest)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{droppedManifest.ChunkHashes[1]}, missing)
+
+		// a retained chunk is marked again by the next run, as long as it is not referenced
+		removed, err = s.CollectGarbage(referenced, now.Add(4*time.Hour), time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 0, removed)
+		removed, err = s.CollectGarbage(referenced, now.Add(6*time.Hour), time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 1, removed)
+
+		actual, err := s.GetValue(keptManifest)
+		require.NoError(t, err)
+		require.Equal(t, kept, actual)
+		_, err = s.GetValue(droppedManifest)
+		require.Error(t, err)
+	})
+
+	t.Run("migrate a store of the first format", func(t *testing.T) {
+		s, storeDir := newTestStore(t)
+		require.NoError(t, s.Close())
+
+		// the first format held the chunks under their bare hashes, and recorded no format version
+		value := []byte("0123456789")
+		manifest := NewManifest(value, 4)
+		db, err := leveldb.OpenFile(filepath.Join(storeDir, blobsDBName), nil)
+		require.NoError(t, err)
+		for i, chunk := range split(value, 4) {
+			require.NoError(t, db.Put(manifest.ChunkHashes[i], chunk, nil))
+		}
+		require.NoError(t, db.Close())
+		require.NoError(t, os.Remove(filepath.Join(storeDir, "formatversion")))
+
+		s, err = Open(&Config{StoreDir: storeDir, Logger: s.logger})
+		require.NoError(t, err)
+		defer s.Close()
+
+		actual, err := s.GetValue(manifest)
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+		version, err := storeformat.ReadVersion(storeDir)
+		require.NoError(t, err)
+		require.Equal(t, format.Version, version)
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		s, storeDir := newTestStore(t)
+
+		value := []byte("0123456789")
+		manifest := NewManifest(value, 4)
+		require.NoError(t, s.PutValue(manifest, value))
+		require.NoError(t, s.Close())
+
+		s, err := Open(&Config{StoreDir: storeDir, Logger: s.logger})
+		require.NoError(t, err)
+		defer s.Close()
+
+		actual, err := s.GetValue(manifest)
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultChunkPullTimeout is the time the committer waits for the missing chunks of a block to be pulled from the
+// other nodes.
+var DefaultChunkPullTimeout = time.Minute
+
+// ChunkPuller pulls the chunks of the blobs from the other nodes of the cluster, as a blob is uploaded to a single
+// node, the one the transaction that writes it is submitted to.
+type ChunkPuller interface {
+	// PullChunk pulls the chunk with the given hash, which is at most maxSize bytes, from the members of the cluster.
+	PullChunk(ctx context.Context, hash []byte, maxSize uint64) ([]byte, error)
+}
+
+// SetChunkPuller sets the puller of the chunks of the blobs written by a block, which the blob store of the node does
+// not hold. It must be called before Start.
+func (b *BlockProcessor) SetChunkPuller(puller ChunkPuller) {
+	b.committer.chunkPuller = puller
+}
+
+// pullMissingChunks makes sure that the blob store holds the chunks of every blob written by the valid transactions of
+// a data block, and pulls the missing ones from the other nodes, so that the committed manifests can be read.
+func (c *committer) pullMissingChunks(block *types.Block) error {
+	envelopes := block.GetDataTxEnvelopes().GetEnvelopes()
+	for txNum, txValidationInfo := range block.GetHeader().GetValidationInfo() {
+		if txValidationInfo.Flag != types.Flag_VALID || txNum >= len(envelopes) {
+			continue
+		}
+
+		for _, ops := range envelopes[txNum].GetPayload().GetDbOperations() {
+			for _, write := range ops.DataWrites {
+				if write.BlobManifest == nil {
+					continue
+				}
+				if err := c.pullMissingChunksOf(write.BlobManifest); err != nil {
+					return errors.WithMessagef(err, "error while pulling the blob of the key [%s] in database [%s]", write.Key, ops.DbName)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *committer) pullMissingChunksOf(manifest *types.BlobManifest) error {
+	if c.blobs == nil {
+		return errors.New("no blob store is configured")
+	}
+
+	missing, err := c.blobs.Retain(manifest)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+	if c.chunkPuller == nil {
+		return errors.Errorf("the blob store is missing %d chunks, and there are no nodes to pull them from", len(missing))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultChunkPullTimeout)
+	defer cancel()
+	for _, hash := range missing {
+		chunk, err := c.chunkPuller.PullChunk(ctx, hash, manifest.ChunkSize)
+		if err != nil {
+			return err
+		}
+		if err = c.blobs.PutChunk(hash, chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
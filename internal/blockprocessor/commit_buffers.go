@@ -0,0 +1,160 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+const (
+	// maxPooledSliceCap is the capacity of the largest slice that is kept for the next block, so that an occasional
+	// large block does not pin its memory for the lifetime of the node
+	maxPooledSliceCap = 64 * 1024
+	// maxPooledSlices is the number of slices of each kind that are kept for the next block
+	maxPooledSlices = 64
+)
+
+// commitBuffers holds the map and slices the committer fills with the state and provenance changes of a block, so
+// that the next block reuses them rather than allocating them again. The committer commits one block at a time,
+// hence the buffers are used by a single goroutine, and the changes of a block are valid until the buffers are
+// recycled.
+type commitBuffers struct {
+	dbsUpdates map[string]*worldstate.DBUpdates
+	// updateCounts holds the number of writes and deletes of each database in the block, used to pre-size the
+	// updates
+	updateCounts map[string]updateCounts
+	// inUse holds the updates handed out for the current block. The updates of the index databases that are added to
+	// dbsUpdates later are not pooled.
+	inUse          []*worldstate.DBUpdates
+	freeWrites     [][]*worldstate.KVWithMetadata
+	freeDeletes    [][]string
+	provenanceData []*provenance.TxDataForProvenance
+}
+
+type updateCounts struct {
+	writes  int
+	deletes int
+}
+
+func newCommitBuffers() *commitBuffers {
+	return &commitBuffers{
+		dbsUpdates:   make(map[string]*worldstate.DBUpdates),
+		updateCounts: make(map[string]updateCounts),
+	}
+}
+
+// updates returns the pooled updates map, which is empty until the buffers are prepared for a data block
+func (b *commitBuffers) updates() map[string]*worldstate.DBUpdates {
+	return b.dbsUpdates
+}
+
+// prepareForDataTxs adds to the pooled updates map an entry for every database modified by the valid data
+// transactions of the block, pre-sized to the number of writes and deletes of the database. It returns an empty slice
+// pre-sized to the number of provenance entries of the block, i.e., one per invalid transaction and one per database
+// operation of a valid transaction.
+func (b *commitBuffers) prepareForDataTxs(envs []*types.DataTxEnvelope, validationInfo []*types.ValidationInfo) []*provenance.TxDataForProvenance {
+	provenanceCount := 0
+	for txNum, info := range validationInfo {
+		if info.Flag != types.Flag_VALID {
+			provenanceCount++
+			continue
+		}
+
+		for _, ops := range envs[txNum].GetPayload().GetDbOperations() {
+			provenanceCount++
+			counts := b.updateCounts[ops.DbName]
+			counts.writes += len(ops.DataWrites) + len(ops.AclWrites)
+			counts.deletes += len(ops.DataDeletes)
+			b.updateCounts[ops.DbName] = counts
+		}
+	}
+
+	for dbName, counts := range b.updateCounts {
+		if counts.writes+counts.deletes == 0 {
+			continue
+		}
+		b.dbsUpdates[dbName] = b.newDBUpdates(counts)
+	}
+
+	if cap(b.provenanceData) < provenanceCount {
+		b.provenanceData = make([]*provenance.TxDataForProvenance, 0, provenanceCount)
+	}
+	return b.provenanceData[:0]
+}
+
+func (b *commitBuffers) newDBUpdates(counts updateCounts) *worldstate.DBUpdates {
+	updates := &worldstate.DBUpdates{}
+
+	// a database without writes or deletes keeps a nil slice, as the updates constructed without pooling do
+	if counts.writes > 0 {
+		for i, writes := range b.freeWrites {
+			if cap(writes) >= counts.writes {
+				updates.Writes = writes
+				b.freeWrites = append(b.freeWrites[:i], b.freeWrites[i+1:]...)
+				break
+			}
+		}
+		if updates.Writes == nil {
+			updates.Writes = make([]*worldstate.KVWithMetadata, 0, counts.writes)
+		}
+	}
+	if counts.deletes > 0 {
+		for i, deletes := range b.freeDeletes {
+			if cap(deletes) >= counts.deletes {
+				updates.Deletes = deletes
+				b.freeDeletes = append(b.freeDeletes[:i], b.freeDeletes[i+1:]...)
+				break
+			}
+		}
+		if updates.Deletes == nil {
+			updates.Deletes = make([]string, 0, counts.deletes)
+		}
+	}
+
+	b.inUse = append(b.inUse, updates)
+	return updates
+}
+
+// recycle makes the buffers handed out for the last block available to the next block. The elements are cleared so
+// that the buffers do not keep the keys and values of the block alive.
+func (b *commitBuffers) recycle() {
+	for _, updates := range b.inUse {
+		if c := cap(updates.Writes); c > 0 && c <= maxPooledSliceCap && len(b.freeWrites) < maxPooledSlices {
+			writes := updates.Writes[:c]
+			for i := range writes {
+				writes[i] = nil
+			}
+			b.freeWrites = append(b.freeWrites, writes[:0])
+		}
+		if c := cap(updates.Deletes); c > 0 && c <= maxPooledSliceCap && len(b.freeDeletes) < maxPooledSlices {
+			deletes := updates.Deletes[:c]
+			for i := range deletes {
+				deletes[i] = ""
+			}
+			b.freeDeletes = append(b.freeDeletes, deletes[:0])
+		}
+	}
+	for i := range b.inUse {
+		b.inUse[i] = nil
+	}
+	b.inUse = b.inUse[:0]
+
+	for dbName := range b.dbsUpdates {
+		delete(b.dbsUpdates, dbName)
+	}
+	for dbName := range b.updateCounts {
+		delete(b.updateCounts, dbName)
+	}
+
+	if c := cap(b.provenanceData); c > maxPooledSliceCap {
+		b.provenanceData = nil
+	} else {
+		provenanceData := b.provenanceData[:c]
+		for i := range provenanceData {
+			provenanceData[i] = nil
+		}
+	}
+}
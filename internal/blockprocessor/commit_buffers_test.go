@@ -0,0 +1,107 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitBuffers(t *testing.T) {
+	t.Parallel()
+
+	envs := []*types.DataTxEnvelope{
+		{
+			Payload: &types.DataTx{
+				DbOperations: []*types.DBOperation{
+					{
+						DbName: "db1",
+						DataWrites: []*types.DataWrite{
+							{Key: "key1"},
+							{Key: "key2"},
+						},
+						DataDeletes: []*types.DataDelete{
+							{Key: "key3"},
+						},
+					},
+					{
+						DbName:    "db2",
+						DataReads: []*types.DataRead{{Key: "key1"}},
+					},
+				},
+			},
+		},
+		{
+			Payload: &types.DataTx{
+				DbOperations: []*types.DBOperation{
+					{
+						DbName:    "db1",
+						AclWrites: []*types.AclWrite{{Key: "key4"}},
+					},
+				},
+			},
+		},
+		{
+			Payload: &types.DataTx{
+				DbOperations: []*types.DBOperation{
+					{
+						DbName:     "db3",
+						DataWrites: []*types.DataWrite{{Key: "key1"}},
+					},
+				},
+			},
+		},
+	}
+	validationInfo := []*types.ValidationInfo{
+		{Flag: types.Flag_VALID},
+		{Flag: types.Flag_VALID},
+		{Flag: types.Flag_VALID},
+	}
+
+	b := newCommitBuffers()
+	provenanceData := b.prepareForDataTxs(envs, validationInfo)
+	require.Len(t, provenanceData, 0)
+	require.Equal(t, 4, cap(provenanceData))
+
+	dbsUpdates := b.updates()
+	require.Len(t, dbsUpdates, 2)
+	db1Updates := dbsUpdates["db1"]
+	require.Len(t, db1Updates.Writes, 0)
+	require.Equal(t, 3, cap(db1Updates.Writes))
+	require.Len(t, db1Updates.Deletes, 0)
+	require.Equal(t, 1, cap(db1Updates.Deletes))
+	// a database with only writes keeps nil deletes
+	require.Equal(t, 1, cap(dbsUpdates["db3"].Writes))
+	require.Nil(t, dbsUpdates["db3"].Deletes)
+
+	db1Updates.Writes = append(db1Updates.Writes, &worldstate.KVWithMetadata{Key: "key1"})
+	db1Updates.Deletes = append(db1Updates.Deletes, "key3")
+	dbsUpdates["_index_db1"] = &worldstate.DBUpdates{}
+
+	b.recycle()
+	require.Empty(t, b.updates())
+	require.Len(t, b.freeWrites, 2)
+	for _, writes := range b.freeWrites {
+		require.Nil(t, writes[:1][0])
+	}
+	require.Len(t, b.freeDeletes, 1)
+	require.Equal(t, "", b.freeDeletes[0][:1][0])
+
+	// the second block writes fewer keys to db1, and reuses the slices of the first block that fit
+	validationInfo[1].Flag = types.Flag_INVALID_NO_PERMISSION
+	validationInfo[2].Flag = types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE
+	provenanceData = b.prepareForDataTxs(envs, validationInfo)
+	require.Equal(t, 4, cap(provenanceData))
+
+	dbsUpdates = b.updates()
+	require.Len(t, dbsUpdates, 1)
+	require.Equal(t, 3, cap(dbsUpdates["db1"].Writes))
+	require.Equal(t, 1, cap(dbsUpdates["db1"].Deletes))
+	require.Len(t, b.freeWrites, 1)
+	require.Equal(t, 1, cap(b.freeWrites[0]))
+	require.Empty(t, b.freeDeletes)
+}
@@ -3,17 +3,25 @@
 package blockprocessor
 
 import (
+	"bytes"
 	"encoding/json"
+	"time"
 
 	"github.com/golang/protobuf/proto"
-	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/constraints"
+	"github.com/hyperledger-labs/orion-server/internal/dbfreeze"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
+	"github.com/hyperledger-labs/orion-server/internal/failpoint"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/legalhold"
+	"github.com/hyperledger-labs/orion-server/internal/migration"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
-	"github.com/hyperledger-labs/orion-server/pkg/state"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
 )
@@ -25,50 +33,117 @@ const (
 )
 
 type committer struct {
+	nodeID          string
 	db              worldstate.DB
-	blockStore      *blockstore.Store
-	provenanceStore *provenance.Store
-	stateTrieStore  mptrie.Store
-	stateTrie       *mptrie.MPTrie
-	logger          *logger.SugarLogger
+	blockStore      BlockStore
+	provenanceStore ProvenanceStore
+	stateTrieStore  TrieStore
+	stateTrie       *mptrie.StateTrie
+	outbox          *outbox.Outbox
+	erasure         *erasure.Store
+	identityCache   *identity.Cache
+	// blobs holds the chunks of the blobs written by their manifests, which are pulled from the other nodes by
+	// chunkPuller when they were uploaded to another node
+	blobs       *blobstore.Store
+	chunkPuller ChunkPuller
+	// buffers are reused across blocks to hold the state and provenance changes of the block being committed
+	buffers *commitBuffers
+	// deferredBlocks holds the blocks whose block store writes are deferred, which are recorded in the outbox once
+	// they are written
+	deferredBlocks []*types.Block
+	commitBatching CommitBatchingConfig
+	stateTrieRetry StateTrieRetryConfig
+	phaseObserver  PhaseObserver
+	logger         *logger.SugarLogger
 }
 
 func newCommitter(conf *Config) *committer {
+	commitBatching := conf.CommitBatching
+	if commitBatching.MaxBlockUpdates == 0 {
+		commitBatching.MaxBlockUpdates = DefaultCommitBatchMaxBlockUpdates
+	}
+	if commitBatching.FlushTimeout == 0 {
+		commitBatching.FlushTimeout = DefaultCommitBatchFlushTimeout
+	}
+	stateTrieRetry := conf.StateTrieRetry
+	if stateTrieRetry.MaxAttempts == 0 {
+		stateTrieRetry.MaxAttempts = DefaultStateTrieMaxAttempts
+	}
+	if stateTrieRetry.Backoff == 0 {
+		stateTrieRetry.Backoff = DefaultStateTrieRetryBackoff
+	}
+
+	// the state database holds the updates of a block only once the block store does, hence the deferred blocks are
+	// written before the deferred updates
+	conf.DB.SetBeforeFlushDeferred(conf.BlockStore.FlushDeferred)
+
 	return &committer{
+		nodeID:          conf.NodeID,
 		db:              conf.DB,
 		blockStore:      conf.BlockStore,
 		provenanceStore: conf.ProvenanceStore,
 		stateTrieStore:  conf.StateTrieStore,
+		outbox:          conf.Outbox,
+		erasure:         conf.Erasure,
+		identityCache:   conf.IdentityCache,
+		blobs:           conf.BlobStore,
+		buffers:         newCommitBuffers(),
+		commitBatching:  commitBatching,
+		stateTrieRetry:  stateTrieRetry,
+		phaseObserver:   conf.PhaseObserver,
 		logger:          conf.Logger,
 	}
 }
 
 func (c *committer) commitBlock(block *types.Block) error {
-	// Calculate expected changes to world state db and provenance db
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	start := time.Now()
+
+	// Calculate expected changes to world state db and provenance db. The changes are held by buffers that are reused
+	// by the next block, hence they are released once the block is committed.
+	defer c.buffers.recycle()
+	if err := c.pullMissingChunks(block); err != nil {
+		return errors.WithMessagef(err, "error while pulling the missing chunks of block %d", blockNum)
+	}
 	dbsUpdates, provenanceData, err := c.constructDBAndProvenanceEntries(block)
 	if err != nil {
-		return errors.WithMessagef(err, "error while constructing database and provenance entries for block %d", block.GetHeader().GetBaseHeader().GetNumber())
+		return errors.WithMessagef(err, "error while constructing database and provenance entries for block %d", blockNum)
 	}
 
-	// Update state trie with expected world state db changes
-	if err := c.applyBlockOnStateTrie(dbsUpdates); err != nil {
-		panic(err)
+	if err = c.adoptSingleTrieLayout(block); err != nil {
+		return err
 	}
-	stateTrieRootHash, err := c.stateTrie.Hash()
+
+	// Update state trie with expected world state db changes
+	stateTrieRootHash, dbStateTrieRootHashes, err := c.updateStateTrie(blockNum, dbsUpdates)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	// Update block with state trie root
+	// the state trie refers to the erased values of a block committed from another node by their hashes, hence it
+	// reaches the state root the block was committed with
+	if len(block.GetSealedTxHashes()) > 0 && !bytes.Equal(stateTrieRootHash, block.GetHeader().GetStateMerkelTreeRootHash()) {
+		return errors.Errorf("the state trie root of block %d, whose values were erased, differs from the one it was committed with", blockNum)
+	}
+	// Update block with state trie root and the roots of the updated databases
 	block.Header.StateMerkelTreeRootHash = stateTrieRootHash
+	block.Header.DbStateRootHashes = dbStateTrieRootHashes
+	observePhase(c.phaseObserver, blockNum, PhaseStateTrie, start)
 
 	// Commit block to block store
-	if err := c.commitToBlockStore(block); err != nil {
+	start = time.Now()
+	deferred := c.deferBlockStoreCommit(block)
+	if err := c.commitToBlockStore(block, deferred); err != nil {
 		return errors.WithMessagef(
 			err,
 			"error while committing block %d to the block store",
-			block.GetHeader().GetBaseHeader().GetNumber(),
+			blockNum,
 		)
 	}
+	observePhase(c.phaseObserver, blockNum, PhaseBlockStore, start)
+
+	if err := failpoint.Inject(failpoint.AfterBlockStoreCommit); err != nil {
+		return err
+	}
 
 	// Commit block to world state db and provenance db
 	if err = c.commitToDBs(dbsUpdates, provenanceData, block); err != nil {
@@ -76,35 +151,139 @@ func (c *committer) commitBlock(block *types.Block) error {
 	}
 
 	// Commit state trie changes to trie store
-	return c.commitTrie(block.GetHeader().GetBaseHeader().GetNumber())
+	start = time.Now()
+	if err = c.commitTrie(blockNum); err != nil {
+		return err
+	}
+	observePhase(c.phaseObserver, blockNum, PhaseTrieCommit, start)
+
+	// Record the external side effects of the block in the outbox. A deferred block is recorded once it is written
+	// to the block store.
+	if deferred {
+		return nil
+	}
+	start = time.Now()
+	if err = c.commitToOutbox(block); err != nil {
+		return err
+	}
+	observePhase(c.phaseObserver, blockNum, PhaseOutbox, start)
+
+	return nil
 }
 
-func (c *committer) commitToBlockStore(block *types.Block) error {
+func (c *committer) commitToOutbox(block *types.Block) error {
+	if c.outbox == nil {
+		return nil
+	}
+
+	if err := c.outbox.RecordBlock(block); err != nil {
+		return errors.WithMessagef(err, "failed to record block %d in the outbox", block.GetHeader().GetBaseHeader().GetNumber())
+	}
+
+	return nil
+}
+
+func (c *committer) commitToBlockStore(block *types.Block, deferred bool) error {
+	if deferred {
+		if err := c.blockStore.CommitDeferred(block); err != nil {
+			return errors.WithMessagef(err, "failed to commit block %d to block store", block.Header.BaseHeader.Number)
+		}
+		c.deferredBlocks = append(c.deferredBlocks, block)
+		return nil
+	}
+
 	if err := c.blockStore.Commit(block); err != nil {
 		return errors.WithMessagef(err, "failed to commit block %d to block store", block.Header.BaseHeader.Number)
 	}
 
+	// the deferred blocks were written along with the block
+	return c.flushDeferredBlocks()
+}
+
+// deferBlockStoreCommit returns true if the block store write of the block is to be group-committed with the writes of
+// the blocks enqueued along with it, i.e., the commit batching is enabled and the block is a data block. The state
+// database holds the updates of a block only once the block store does, hence the deferred blocks are written before
+// the state database writes of a block that are not deferred, and before the deferred state database writes are
+// written. The state trie and the provenance store may hold the changes of a deferred block that is lost in a crash,
+// and the block is committed to them again once it is delivered again by the replication.
+func (c *committer) deferBlockStoreCommit(block *types.Block) bool {
+	return c.commitBatching.MaxBlocks > 1 && block.GetDataTxEnvelopes() != nil
+}
+
+// flushDeferredBlocks writes the deferred blocks to the block store, and records them in the outbox
+func (c *committer) flushDeferredBlocks() error {
+	if len(c.deferredBlocks) == 0 {
+		return nil
+	}
+
+	if err := c.blockStore.FlushDeferred(); err != nil {
+		return errors.WithMessage(err, "failed to write the deferred blocks to block store")
+	}
+	for _, block := range c.deferredBlocks {
+		if err := c.commitToOutbox(block); err != nil {
+			return err
+		}
+	}
+	c.deferredBlocks = nil
+
 	return nil
 }
 
 func (c *committer) commitToDBs(dbsUpdates map[string]*worldstate.DBUpdates, provenanceData []*provenance.TxDataForProvenance, block *types.Block) error {
 	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	blockTime := block.GetHeader().GetBaseHeader().GetTimestamp()
 
-	if err := c.commitToProvenanceStore(blockNum, provenanceData); err != nil {
+	start := time.Now()
+	if err := c.commitToProvenanceStore(blockNum, blockTime, provenanceData); err != nil {
 		return errors.WithMessagef(err, "error while committing block %d to the block store", blockNum)
 	}
+	observePhase(c.phaseObserver, blockNum, PhaseProvenance, start)
 
-	return c.commitToStateDB(blockNum, dbsUpdates)
+	start = time.Now()
+	if err := c.commitTxResourceUsage(block); err != nil {
+		return err
+	}
+	if err := c.commitDBUsage(block); err != nil {
+		return err
+	}
+	if err := c.addBackfilledIndexEntries(block, dbsUpdates); err != nil {
+		return err
+	}
+	if err := c.commitToStateDB(blockNum, dbsUpdates); err != nil {
+		return err
+	}
+	if err := c.commitErasure(block); err != nil {
+		return err
+	}
+	observePhase(c.phaseObserver, blockNum, PhaseStateDB, start)
+
+	return nil
 }
 
-func (c *committer) commitToProvenanceStore(blockNum uint64, provenanceData []*provenance.TxDataForProvenance) error {
-	if err := c.provenanceStore.Commit(blockNum, provenanceData); err != nil {
+func (c *committer) commitToProvenanceStore(blockNum uint64, blockTime int64, provenanceData []*provenance.TxDataForProvenance) error {
+	if err := c.provenanceStore.Commit(blockNum, blockTime, provenanceData); err != nil {
 		return errors.WithMessagef(err, "failed to commit block %d to provenance store", blockNum)
 	}
 
 	return nil
 }
 
+// commitTxResourceUsage stores the resource usage of the valid data transactions of the block. The usage is computed
+// before the block is committed to the state database, so that a block replayed during recovery is accounted for the
+// same.
+func (c *committer) commitTxResourceUsage(block *types.Block) error {
+	usages, err := constructTxResourceUsage(block, c.db)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to compute the resource usage of block %d", block.GetHeader().GetBaseHeader().GetNumber())
+	}
+
+	if err := c.blockStore.CommitTxResourceUsage(usages); err != nil {
+		return errors.WithMessagef(err, "failed to commit the resource usage of block %d", block.GetHeader().GetBaseHeader().GetNumber())
+	}
+
+	return nil
+}
+
 func (c *committer) commitToStateDB(blockNum uint64, dbsUpdates map[string]*worldstate.DBUpdates) error {
 	indexUpdates, err := stateindex.ConstructIndexEntries(dbsUpdates, c.db)
 	if err != nil {
@@ -116,15 +295,59 @@ func (c *committer) commitToStateDB(blockNum uint64, dbsUpdates map[string]*worl
 		dbsUpdates[indexDB] = updates
 	}
 
+	if c.deferStateDBCommit(dbsUpdates) {
+		if err := c.db.CommitDeferred(dbsUpdates, blockNum); err != nil {
+			return errors.WithMessagef(err, "failed to commit block %d to state database", blockNum)
+		}
+		return nil
+	}
+
+	if err := c.flushDeferredBlocks(); err != nil {
+		return err
+	}
 	if err := c.db.Commit(dbsUpdates, blockNum); err != nil {
 		return errors.WithMessagef(err, "failed to commit block %d to state database", blockNum)
 	}
+	// the deferred blocks update only data databases, hence the user and node records are updated only here
+	c.identityCache.Invalidate(dbsUpdates)
 
 	return nil
 }
 
+// deferStateDBCommit returns true if the state database writes of the block are to be batched with the writes of the
+// following blocks, i.e., the batching is enabled, the block is a small block that updates only data databases, and
+// the block does not complete the batch. The block that completes the batch is committed together with the batched
+// blocks.
+func (c *committer) deferStateDBCommit(dbsUpdates map[string]*worldstate.DBUpdates) bool {
+	if c.commitBatching.MaxBlocks <= 1 || uint32(c.db.DeferredBlocks())+1 >= c.commitBatching.MaxBlocks {
+		return false
+	}
+
+	updates := 0
+	for dbName, dbUpdates := range dbsUpdates {
+		if worldstate.IsSystemDB(dbName) {
+			return false
+		}
+		updates += len(dbUpdates.Writes) + len(dbUpdates.Deletes)
+	}
+
+	return updates <= int(c.commitBatching.MaxBlockUpdates)
+}
+
+// maxStateDBLag returns the maximal number of blocks by which the state database may fall behind the block store
+// after a crash
+func (c *committer) maxStateDBLag() uint64 {
+	if c.commitBatching.MaxBlocks <= 1 {
+		return 1
+	}
+	return uint64(c.commitBatching.MaxBlocks)
+}
+
+// constructDBAndProvenanceEntries returns the state and provenance changes of the block. The changes are held by the
+// buffers of the committer, and remain valid until the changes of the next block are constructed.
 func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[string]*worldstate.DBUpdates, []*provenance.TxDataForProvenance, error) {
-	dbsUpdates := make(map[string]*worldstate.DBUpdates)
+	c.buffers.recycle()
+	dbsUpdates := c.buffers.updates()
 	var provenanceData []*provenance.TxDataForProvenance
 	blockValidationInfo := block.Header.ValidationInfo
 
@@ -132,14 +355,16 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 	switch block.Payload.(type) {
 	case *types.Block_DataTxEnvelopes:
 		txsEnvelopes := block.GetDataTxEnvelopes().Envelopes
+		provenanceData = c.buffers.prepareForDataTxs(txsEnvelopes, blockValidationInfo)
 
 		for txNum, txValidationInfo := range blockValidationInfo {
 			if txValidationInfo.Flag != types.Flag_VALID {
 				provenanceData = append(
 					provenanceData,
 					&provenance.TxDataForProvenance{
-						IsValid: false,
-						TxID:    txsEnvelopes[txNum].Payload.TxId,
+						IsValid:     false,
+						TxID:        txsEnvelopes[txNum].Payload.TxId,
+						Annotations: txsEnvelopes[txNum].Payload.Annotations,
 					},
 				)
 				continue
@@ -150,7 +375,12 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 				TxNum:    uint64(txNum),
 			}
 
-			tx := txsEnvelopes[txNum].Payload
+			// the writes that carry no access control are given the default access control of their database, in
+			// both the state and the provenance
+			tx, err := constraints.WithDefaultACLs(c.db, txsEnvelopes[txNum].Payload)
+			if err != nil {
+				return nil, nil, errors.WithMessage(err, "error while applying the default access controls of the databases")
+			}
 
 			pData, err := constructProvenanceEntriesForDataTx(c.db, tx, version)
 			if err != nil {
@@ -159,6 +389,9 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 			provenanceData = append(provenanceData, pData...)
 
 			AddDBEntriesForDataTx(tx, version, dbsUpdates)
+			if err := addDBEntriesForAclWrites(c.db, tx, version, dbsUpdates); err != nil {
+				return nil, nil, err
+			}
 		}
 		c.logger.Debugf("constructed %d, updates for data transactions, block number %d",
 			len(blockValidationInfo),
@@ -179,7 +412,10 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 			TxNum:    userAdminTxIndex,
 		}
 
-		tx := block.GetUserAdministrationTxEnvelope().GetPayload()
+		tx, err := identity.ResolveCertificateRenewal(block.GetUserAdministrationTxEnvelope().GetPayload(), c.db)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "error while resolving the certificate renewal of the user admin transaction")
+		}
 		entries, err := identity.ConstructDBEntriesForUserAdminTx(tx, version)
 		if err != nil {
 			return nil, nil, errors.WithMessage(err, "error while creating entries for the user admin transaction")
@@ -211,6 +447,41 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 		if err != nil {
 			return nil, nil, errors.WithMessage(err, "error while creating entries for db admin transaction")
 		}
+
+		holdUpdates, err := legalhold.ConstructDBEntriesForDBAdminTx(tx, version)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "error while creating legal hold entries for db admin transaction")
+		}
+		if holdUpdates != nil {
+			dbsUpdates[worldstate.LegalHoldsDBName] = holdUpdates
+			c.auditLegalHolds(tx)
+		}
+
+		constraintUpdates, err := constraints.ConstructDBEntriesForDBAdminTx(c.db, tx, version)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "error while creating constraint entries for db admin transaction")
+		}
+		if constraintUpdates != nil {
+			dbsUpdates[worldstate.ConstraintsDBName] = constraintUpdates
+		}
+
+		freezeUpdates, err := dbfreeze.ConstructDBEntriesForDBAdminTx(tx, version)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "error while creating freeze entries for db admin transaction")
+		}
+		if freezeUpdates != nil {
+			dbsUpdates[worldstate.FrozenDBsDBName] = freezeUpdates
+			c.auditFreezes(tx)
+		}
+
+		migrationUpdates, err := migration.ConstructDBEntriesForDBAdminTx(c.db, tx, version)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "error while creating migration entries for db admin transaction")
+		}
+		if migrationUpdates != nil {
+			dbsUpdates[worldstate.MigrationsDBName] = migrationUpdates
+			c.auditMigration(tx)
+		}
 		c.logger.Debugf("constructed db admin update, block number %d",
 			block.GetHeader().GetBaseHeader().GetNumber())
 
@@ -261,40 +532,129 @@ func (c *committer) constructDBAndProvenanceEntries(block *types.Block) (map[str
 	return dbsUpdates, provenanceData, nil
 }
 
+// adoptSingleTrieLayout keeps the state of a ledger created before the state trie was split into a trie per database
+// in a single trie, such that all the nodes of the ledger calculate the same state roots. The stores of the existing
+// nodes are marked when they are upgraded, while a node that joins the ledger learns the layout from the genesis block
+// it pulls, whose header holds a state root but no database root hashes.
+func (c *committer) adoptSingleTrieLayout(block *types.Block) error {
+	header := block.GetHeader()
+	if header.GetBaseHeader().GetNumber() != 1 || header.GetStateMerkelTreeRootHash() == nil || len(header.GetDbStateRootHashes()) > 0 {
+		return nil
+	}
+
+	flat, err := c.stateTrieStore.FlatLayout()
+	if err != nil || flat {
+		return err
+	}
+
+	c.logger.Info("The ledger was created before the state trie was split into a trie per database, the state is kept in a single trie")
+	if err = c.stateTrieStore.SetFlatLayout(); err != nil {
+		return err
+	}
+	c.stateTrie, err = mptrie.NewStateTrie(nil, c.stateTrieStore)
+	return err
+}
+
 func (c *committer) applyBlockOnStateTrie(worldStateUpdates map[string]*worldstate.DBUpdates) error {
 	return ApplyBlockOnStateTrie(c.stateTrie, worldStateUpdates)
 }
 
+// updateStateTrie applies the changes of a block on the state trie, and returns the root hash of the state trie and
+// the root hashes of the updated databases. A failed attempt is rolled back, and retried after a backoff.
+func (c *committer) updateStateTrie(blockNum uint64, dbsUpdates map[string]*worldstate.DBUpdates) ([]byte, []*types.DBStateRootHash, error) {
+	backoff := c.stateTrieRetry.Backoff
+	for attempt := uint32(1); ; attempt++ {
+		stateTrieRootHash, dbStateTrieRootHashes, err := c.tryUpdateStateTrie(dbsUpdates)
+		if err == nil {
+			return stateTrieRootHash, dbStateTrieRootHashes, nil
+		}
+
+		stateTrieFailuresCounter.WithLabelValues(c.nodeID).Inc()
+		c.logger.Warnf("attempt %d of %d to update the state trie with block %d failed: %s", attempt, c.stateTrieRetry.MaxAttempts, blockNum, err)
+
+		if rollbackErr := c.rollbackStateTrie(); rollbackErr != nil {
+			return nil, nil, errors.WithMessagef(rollbackErr, "error while rolling back the state trie after the failure to update it with block %d: %s", blockNum, err)
+		}
+		if attempt >= c.stateTrieRetry.MaxAttempts {
+			return nil, nil, &StateTrieError{BlockNum: blockNum, Attempts: attempt, Err: err}
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (c *committer) tryUpdateStateTrie(dbsUpdates map[string]*worldstate.DBUpdates) ([]byte, []*types.DBStateRootHash, error) {
+	if err := failpoint.Inject(failpoint.StateTrieUpdate); err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.applyBlockOnStateTrie(dbsUpdates); err != nil {
+		return nil, nil, err
+	}
+	stateTrieRootHash, err := c.stateTrie.Hash()
+	if err != nil {
+		return nil, nil, err
+	}
+	dbStateTrieRootHashes, err := c.stateTrie.UpdatedDBRootHashes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stateTrieRootHash, dbStateTrieRootHashes, nil
+}
+
+// rollbackStateTrie discards the changes of the state trie since the last committed block, by dropping the uncommitted
+// nodes of the trie store and reloading the trie at the state root of the last committed block.
+func (c *committer) rollbackStateTrie() error {
+	if err := c.stateTrieStore.RollbackChanges(); err != nil {
+		return err
+	}
+	// the trie is loaded at the state root of the last block of the block store, hence the deferred blocks are written
+	// first
+	if err := c.flushDeferredBlocks(); err != nil {
+		return err
+	}
+
+	_, _, stateTrie, err := loadStateTrie(c.stateTrieStore, c.blockStore)
+	if err != nil {
+		return err
+	}
+	c.stateTrie = stateTrie
+
+	return nil
+}
+
 func (c *committer) commitTrie(height uint64) error {
 	return c.stateTrie.Commit(height)
 }
 
-func ApplyBlockOnStateTrie(trie *mptrie.MPTrie, worldStateUpdates map[string]*worldstate.DBUpdates) error {
+// ApplyBlockOnStateTrie applies the updates of a block to the state trie. The trie holds the marshaled manifest of a
+// blob that a write carries in place of the value, hence the proof of such a value covers its manifest.
+func ApplyBlockOnStateTrie(trie *mptrie.StateTrie, worldStateUpdates map[string]*worldstate.DBUpdates) error {
+	updates := make(map[string][]*mptrie.KeyUpdate, len(worldStateUpdates))
 	for dbName, dbUpdate := range worldStateUpdates {
+		if n := len(dbUpdate.Writes) + len(dbUpdate.Deletes); n > 0 {
+			updates[dbName] = make([]*mptrie.KeyUpdate, 0, n)
+		}
 		for _, dbWrite := range dbUpdate.Writes {
-			key, err := state.ConstructCompositeKey(dbName, dbWrite.Key)
-			if err != nil {
-				return err
-			}
-			// TODO: should we add Metadata to value
 			value := dbWrite.Value
-			err = trie.Update(key, value)
-			if err != nil {
-				return err
+			if dbWrite.BlobManifest != nil {
+				var err error
+				if value, err = proto.Marshal(dbWrite.BlobManifest); err != nil {
+					return errors.Wrapf(err, "error while marshaling the blob manifest of key [%s] in database [%s]", dbWrite.Key, dbName)
+				}
 			}
+			// TODO: should we add Metadata to value
+			updates[dbName] = append(updates[dbName], &mptrie.KeyUpdate{Key: []byte(dbWrite.Key), Value: value, ValueHash: dbWrite.ErasedValueHash})
 		}
 		for _, dbDelete := range dbUpdate.Deletes {
-			key, err := state.ConstructCompositeKey(dbName, dbDelete)
-			if err != nil {
-				return err
-			}
-			_, err = trie.Delete(key)
-			if err != nil {
-				return err
-			}
+			updates[dbName] = append(updates[dbName], &mptrie.KeyUpdate{Key: []byte(dbDelete), Delete: true})
 		}
 	}
-	return nil
+
+	// the tries of the databases, and the disjoint sub-tries within each of them, are updated in parallel
+	return trie.ApplyUpdates(updates)
 }
 
 func AddDBEntriesForDataTx(tx *types.DataTx, version *types.Version, dbsUpdates map[string]*worldstate.DBUpdates) {
@@ -313,6 +673,10 @@ func AddDBEntriesForDataTx(tx *types.DataTx, version *types.Version, dbsUpdates
 					Version:       version,
 					AccessControl: write.Acl,
 				},
+				BlobManifest: write.BlobManifest,
+			}
+			if write.Erased {
+				kv.ErasedValueHash = write.ValueHash
 			}
 			updates.Writes = append(updates.Writes, kv)
 		}
@@ -323,6 +687,53 @@ func AddDBEntriesForDataTx(tx *types.DataTx, version *types.Version, dbsUpdates
 	}
 }
 
+// addDBEntriesForAclWrites adds a write for each acl write of the transaction that keeps the committed value of the
+// key and replaces its access control. As a key is modified at most once within a block, the committed value is the
+// latest value of the key.
+func addDBEntriesForAclWrites(db worldstate.DB, tx *types.DataTx, version *types.Version, dbsUpdates map[string]*worldstate.DBUpdates) error {
+	for _, ops := range tx.DbOperations {
+		for _, w := range ops.AclWrites {
+			value, _, manifest, err := committedValue(db, ops.DbName, w.Key)
+			if err != nil {
+				return errors.WithMessagef(err, "error while fetching the value of the key [%s] in database [%s] for an acl write", w.Key, ops.DbName)
+			}
+
+			updates, ok := dbsUpdates[ops.DbName]
+			if !ok {
+				updates = &worldstate.DBUpdates{}
+				dbsUpdates[ops.DbName] = updates
+			}
+			updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+				Key:   w.Key,
+				Value: value,
+				Metadata: &types.Metadata{
+					Version:       version,
+					AccessControl: w.NewAcl,
+				},
+				BlobManifest: manifest,
+			})
+		}
+	}
+
+	return nil
+}
+
+// committedValue returns the committed value of a key and its version, or the manifest of the value when the value is
+// a blob, as an acl write keeps the value as it was written.
+func committedValue(db worldstate.DB, dbName, key string) ([]byte, *types.Version, *types.BlobManifest, error) {
+	manifest, err := db.GetBlobManifest(dbName, key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if manifest != nil {
+		version, err := db.GetVersion(dbName, key)
+		return nil, version, manifest, err
+	}
+
+	value, metadata, err := db.Get(dbName, key)
+	return value, metadata.GetVersion(), nil, err
+}
+
 func constructDBEntriesForDBAdminTx(tx *types.DBAdministrationTx, version *types.Version, db worldstate.DB) (*worldstate.DBUpdates, error) {
 	var indexForExistingDBs []*worldstate.KVWithMetadata
 
@@ -388,17 +799,26 @@ func createEntriesForIndexUpdates(
 ) ([]*worldstate.KVWithMetadata, []string, error) {
 	var indexForExistingDBs []*worldstate.KVWithMetadata
 	var toDeleteDBs []string
-	var err error
 
 	for dbName, dbIndex := range dbsIndex {
 		indexExist := db.Exist(stateindex.IndexDB(dbName))
 		deleteExistingIndex := dbIndex == nil || dbIndex.GetAttributeAndType() == nil
 
+		// the entry of the database keeps the version of the transaction that created the database
+		_, metadata, err := db.GetIndexDefinition(dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+		createdAt := metadata.GetVersion()
+		if createdAt == nil {
+			createdAt = version
+		}
+
 		updateDBIndex := &worldstate.KVWithMetadata{
 			Key:   dbName,
 			Value: nil,
 			Metadata: &types.Metadata{
-				Version: version,
+				Version: createdAt,
 			},
 		}
 
@@ -493,8 +913,9 @@ func constructProvenanceEntriesForDataTx(db worldstate.DB, tx *types.DataTx, ver
 			DBName:             ops.DbName,
 			UserID:             tx.MustSignUserIds[0],
 			TxID:               tx.TxId,
-			Deletes:            make(map[string]*types.Version),
-			OldVersionOfWrites: make(map[string]*types.Version),
+			Deletes:            make(map[string]*types.Version, len(ops.DataDeletes)),
+			OldVersionOfWrites: make(map[string]*types.Version, len(ops.DataWrites)+len(ops.AclWrites)),
+			Annotations:        tx.Annotations,
 		}
 
 		for _, read := range ops.DataReads {
@@ -513,39 +934,72 @@ func constructProvenanceEntriesForDataTx(db worldstate.DB, tx *types.DataTx, ver
 					Version:       version,
 					AccessControl: write.Acl,
 				},
+				BlobManifest: write.BlobManifest,
 			}
 			pData.Writes = append(pData.Writes, kv)
+			if write.Erased {
+				if pData.ErasedWrites == nil {
+					pData.ErasedWrites = make(map[string]bool)
+				}
+				pData.ErasedWrites[write.Key] = true
+			}
+		}
 
-			// we assume a block to write a key only once. If more than
-			// one transaction in a block writes to the same key (blind write),
-			// only the first valid transaction gets committed while others get
-			// invalidated. Hence, the old version of the key can only exist in
-			// the committed state and not in the pending writes of previous
-			// transactions within the block
-			v, err := db.GetVersion(ops.DbName, write.Key)
-			if err != nil {
+		// we assume a block to write or delete a key only once. If more than
+		// one transaction in a block writes to the same key (blind write) or
+		// deletes the same key, only the first valid transaction gets committed
+		// while others get invalidated. Hence, the old version of the key can
+		// only exist in the committed state and not in the pending writes of
+		// previous transactions within the block, and the versions of all keys
+		// of the operation are fetched from the committed state at once
+		keys := make([]string, 0, len(ops.DataWrites)+len(ops.DataDeletes))
+		for _, write := range ops.DataWrites {
+			keys = append(keys, write.Key)
+		}
+		for _, d := range ops.DataDeletes {
+			keys = append(keys, d.Key)
+		}
+
+		var versions map[string]*types.Version
+		if len(keys) > 0 {
+			var err error
+			if versions, err = db.GetVersions(ops.DbName, keys); err != nil {
 				return nil, err
 			}
-			if v == nil {
-				continue
-			}
+		}
 
-			pData.OldVersionOfWrites[write.Key] = v
+		for _, write := range ops.DataWrites {
+			if v, ok := versions[write.Key]; ok {
+				pData.OldVersionOfWrites[write.Key] = v
+			}
 		}
 
-		// we assume a block to delete a key only once. If more than
-		// one transaction in a block deletes the same key, only the
-		// first valid transaction gets committed while others get
-		// invalidated.
 		for _, d := range ops.DataDeletes {
-			v, err := db.GetVersion(ops.DbName, d.Key)
+			// for a delete to be valid, the value must exist and hence, the version will
+			// never be nil
+			pData.Deletes[d.Key] = versions[d.Key]
+		}
+
+		// an acl write is recorded as a write of the committed value with the new access control. As the provenance
+		// store holds the value bytes in a content-addressed vertex, the value is not stored again.
+		for _, w := range ops.AclWrites {
+			value, oldVersion, manifest, err := committedValue(db, ops.DbName, w.Key)
 			if err != nil {
 				return nil, err
 			}
 
-			// for a delete to be valid, the value must exist and hence, the version will
-			// never be nil
-			pData.Deletes[d.Key] = v
+			pData.Writes = append(pData.Writes, &types.KVWithMetadata{
+				Key:   w.Key,
+				Value: value,
+				Metadata: &types.Metadata{
+					Version:       version,
+					AccessControl: w.NewAcl,
+				},
+				BlobManifest: manifest,
+			})
+			if oldVersion != nil {
+				pData.OldVersionOfWrites[w.Key] = oldVersion
+			}
 		}
 
 		txpData[i] = pData
@@ -3,31 +3,47 @@
 package blockprocessor
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/blockprocessor/mocks"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/constraints"
+	"github.com/hyperledger-labs/orion-server/internal/dbfreeze"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/legalhold"
+	"github.com/hyperledger-labs/orion-server/internal/migration"
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
 	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
 	"github.com/hyperledger-labs/orion-server/internal/provenance"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/state"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 type committerTestEnv struct {
 	db              *leveldb.LevelDB
 	dbPath          string
+	blobStore       *blobstore.Store
 	blockStore      *blockstore.Store
 	blockStorePath  string
+	provenanceStore provenance.Store
 	identityQuerier *identity.Querier
 	committer       *committer
 	cleanup         func()
@@ -46,10 +62,24 @@ func newCommitterTestEnv(t *testing.T) *committerTestEnv {
 	dir, err := ioutil.TempDir("/tmp", "committer")
 	require.NoError(t, err)
 
+	blobStore, err := blobstore.Open(
+		&blobstore.Config{
+			StoreDir: filepath.Join(dir, "blobstore"),
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			t.Errorf("error while removing directory %s, %v", dir, rmErr)
+		}
+		t.Fatalf("error while creating blobstore, %v", err)
+	}
+
 	dbPath := filepath.Join(dir, "leveldb")
 	db, err := leveldb.Open(
 		&leveldb.Config{
 			DBRootDir: dbPath,
+			BlobStore: blobStore,
 			Logger:    logger,
 		},
 	)
@@ -106,6 +136,10 @@ func newCommitterTestEnv(t *testing.T) *committerTestEnv {
 			t.Errorf("error while closing the db instance, %v", err)
 		}
 
+		if err := blobStore.Close(); err != nil {
+			t.Errorf("error while closing blobstore, %v", err)
+		}
+
 		if err := blockStore.Close(); err != nil {
 			t.Errorf("error while closing blockstore, %v", err)
 		}
@@ -120,13 +154,16 @@ func newCommitterTestEnv(t *testing.T) *committerTestEnv {
 		DB:              db,
 		ProvenanceStore: provenanceStore,
 		StateTrieStore:  mptrieStore,
+		BlobStore:       blobStore,
 		Logger:          logger,
 	}
 	env := &committerTestEnv{
 		db:              db,
 		dbPath:          dbPath,
+		blobStore:       blobStore,
 		blockStore:      blockStore,
 		blockStorePath:  blockStorePath,
+		provenanceStore: provenanceStore,
 		identityQuerier: identity.NewQuerier(db),
 		committer:       newCommitter(c),
 		cleanup:         cleanup,
@@ -136,6 +173,18 @@ func newCommitterTestEnv(t *testing.T) *committerTestEnv {
 	return env
 }
 
+type chunkPullerStub struct {
+	chunks map[string][]byte
+}
+
+func (p *chunkPullerStub) PullChunk(_ context.Context, hash []byte, _ uint64) ([]byte, error) {
+	chunk, ok := p.chunks[string(hash)]
+	if !ok {
+		return nil, errors.Errorf("no member holds the chunk [%x]", hash)
+	}
+	return chunk, nil
+}
+
 func TestCommitter(t *testing.T) {
 	t.Parallel()
 
@@ -243,6 +292,355 @@ func TestCommitter(t *testing.T) {
 		stateTrieHash, err := env.committer.stateTrie.Hash()
 		require.NoError(t, err)
 		require.Equal(t, block.GetHeader().GetStateMerkelTreeRootHash(), stateTrieHash)
+
+		dbRoots := block.GetHeader().GetDbStateRootHashes()
+		require.Len(t, dbRoots, 3)
+		for i, db := range []string{"db1", "db2", "db3"} {
+			require.Equal(t, db, dbRoots[i].GetDbName())
+			dbRootHash, err := env.committer.stateTrie.GetDBRootHash(db)
+			require.NoError(t, err)
+			require.Equal(t, dbRootHash, dbRoots[i].GetRootHash())
+		}
+	})
+
+	t.Run("commit blobs written by their manifests", func(t *testing.T) {
+		t.Parallel()
+
+		env := newCommitterTestEnv(t)
+		defer env.cleanup()
+
+		createDB := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key: "db1",
+					},
+				},
+			},
+		}
+		require.NoError(t, env.db.Commit(createDB, 1))
+
+		// the first blob was uploaded to this node, whereas the chunks of the second one are pulled from another node
+		uploadedValue := []byte("uploaded-value-in-chunks")
+		uploadedManifest := blobstore.NewManifest(uploadedValue, 4)
+		require.NoError(t, env.blobStore.PutValue(uploadedManifest, uploadedValue))
+		pulledValue := []byte("pulled-value-in-chunks")
+		pulledManifest := blobstore.NewManifest(pulledValue, 4)
+		puller := &chunkPullerStub{chunks: make(map[string][]byte)}
+		for start := 0; start < len(pulledValue); start += 4 {
+			end := start + 4
+			if end > len(pulledValue) {
+				end = len(pulledValue)
+			}
+			hash := sha256.Sum256(pulledValue[start:end])
+			puller.chunks[string(hash[:])] = pulledValue[start:end]
+		}
+
+		block1 := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: 1,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"testUser"},
+								TxId:            "dataTx1",
+								DbOperations: []*types.DBOperation{
+									{
+										DbName: "db1",
+										DataWrites: []*types.DataWrite{
+											{
+												Key:   "small",
+												Value: []byte("value-1"),
+											},
+											{
+												Key:          "uploaded",
+												BlobManifest: uploadedManifest,
+											},
+											{
+												Key:          "pulled",
+												BlobManifest: pulledManifest,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		// the chunks of the second blob cannot be pulled without a puller
+		err := env.committer.commitBlock(block1)
+		require.EqualError(t, err, "error while pulling the missing chunks of block 1: error while pulling the blob of the key [pulled] in database [db1]: the blob store is missing 6 chunks, and there are no nodes to pull them from")
+
+		env.committer.chunkPuller = puller
+		require.NoError(t, env.committer.commitBlock(block1))
+
+		val, _, err := env.db.Get("db1", "small")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value-1"), val)
+		manifest, err := env.db.GetBlobManifest("db1", "small")
+		require.NoError(t, err)
+		require.Nil(t, manifest)
+
+		val, _, err = env.db.Get("db1", "uploaded")
+		require.NoError(t, err)
+		require.Equal(t, uploadedValue, val)
+		val, _, err = env.db.Get("db1", "pulled")
+		require.NoError(t, err)
+		require.Equal(t, pulledValue, val)
+		manifest, err = env.db.GetBlobManifest("db1", "pulled")
+		require.NoError(t, err)
+		require.True(t, proto.Equal(pulledManifest, manifest))
+
+		// the state trie holds the manifest in place of the value
+		manifestBytes, err := proto.Marshal(manifest)
+		require.NoError(t, err)
+		compositeKey, err := state.ConstructCompositeKey("db1", "pulled")
+		require.NoError(t, err)
+		kvHash, err := state.CalculateKeyValueHash(compositeKey, manifestBytes)
+		require.NoError(t, err)
+
+		proof, err := env.committer.stateTrie.GetProof("db1", "pulled", false)
+		require.NoError(t, err)
+		stateTrieHash, err := env.committer.stateTrie.Hash()
+		require.NoError(t, err)
+		valid, err := proof.Verify(kvHash, stateTrieHash, false)
+		require.NoError(t, err)
+		require.True(t, valid)
+	})
+}
+
+// failingTrieStore fails the first writes of values to the trie store
+type failingTrieStore struct {
+	mptrie.Store
+	failures int
+	lock     sync.Mutex
+}
+
+func (s *failingTrieStore) PutValue(valuePtr, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("trie store I/O error")
+	}
+	return s.Store.PutValue(valuePtr, value)
+}
+
+func TestCommitterStateTrieRetry(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, env *committerTestEnv, failures int) *failingTrieStore {
+		createDB := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key: "db1",
+					},
+				},
+			},
+		}
+		require.NoError(t, env.db.Commit(createDB, 1))
+
+		trieStore := &failingTrieStore{Store: env.committer.stateTrieStore, failures: failures}
+		env.committer.stateTrieStore = trieStore
+		var err error
+		_, _, env.committer.stateTrie, err = loadStateTrie(trieStore, env.blockStore)
+		require.NoError(t, err)
+		env.committer.stateTrieRetry = StateTrieRetryConfig{MaxAttempts: 3, Backoff: time.Millisecond}
+		return trieStore
+	}
+
+	block1 := func() *types.Block {
+		return &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: 1,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"testUser"},
+								TxId:            "dataTx1",
+								DbOperations: []*types.DBOperation{
+									{
+										DbName: "db1",
+										DataWrites: []*types.DataWrite{
+											{
+												Key:   "key1",
+												Value: []byte("value-1"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("transient failures are retried", func(t *testing.T) {
+		t.Parallel()
+
+		env := newCommitterTestEnv(t)
+		defer env.cleanup()
+		trieStore := setup(t, env, 2)
+
+		block := block1()
+		require.NoError(t, env.committer.commitBlock(block))
+		require.Equal(t, 0, trieStore.failures)
+
+		height, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), height)
+		stateTrieHash, err := env.committer.stateTrie.Hash()
+		require.NoError(t, err)
+		require.Equal(t, block.GetHeader().GetStateMerkelTreeRootHash(), stateTrieHash)
+	})
+
+	t.Run("persistent failures return an error after all attempts", func(t *testing.T) {
+		t.Parallel()
+
+		env := newCommitterTestEnv(t)
+		defer env.cleanup()
+		trieStore := setup(t, env, 100)
+
+		err := env.committer.commitBlock(block1())
+		require.EqualError(t, err, "failed to update the state trie with block 1 in 3 attempt(s): error while updating the state trie of database [db1]: trie store I/O error")
+		stateTrieErr, ok := err.(*StateTrieError)
+		require.True(t, ok)
+		require.Equal(t, uint64(1), stateTrieErr.BlockNum)
+
+		height, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), height)
+
+		// nothing was committed and the state trie was rolled back, hence the block can be committed again
+		trieStore.failures = 0
+		block := block1()
+		require.NoError(t, env.committer.commitBlock(block))
+		stateTrieHash, err := env.committer.stateTrie.Hash()
+		require.NoError(t, err)
+		require.Equal(t, block.GetHeader().GetStateMerkelTreeRootHash(), stateTrieHash)
+
+		expected := newCommitterTestEnv(t)
+		defer expected.cleanup()
+		setup(t, expected, 0)
+		expectedBlock := block1()
+		require.NoError(t, expected.committer.commitBlock(expectedBlock))
+		require.Equal(t, expectedBlock.GetHeader().GetStateMerkelTreeRootHash(), block.GetHeader().GetStateMerkelTreeRootHash())
+	})
+}
+
+func TestCommitterWithMockStores(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, env *committerTestEnv) *types.Block {
+		createDB := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key: "db1",
+					},
+				},
+			},
+		}
+		require.NoError(t, env.db.Commit(createDB, 1))
+
+		return &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: 1,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"testUser"},
+								TxId:            "dataTx1",
+								DbOperations: []*types.DBOperation{
+									{
+										DbName: "db1",
+										DataWrites: []*types.DataWrite{
+											{
+												Key:   "key1",
+												Value: []byte("value-1"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("block store error", func(t *testing.T) {
+		t.Parallel()
+
+		env := newCommitterTestEnv(t)
+		defer env.cleanup()
+		block := setup(t, env)
+
+		blockStore := &mocks.BlockStore{}
+		blockStore.On("Commit", block).Return(errors.New("disk full"))
+		env.committer.blockStore = blockStore
+
+		require.EqualError(t, env.committer.commitBlock(block),
+			"error while committing block 1 to the block store: failed to commit block 1 to block store: disk full")
+		blockStore.AssertExpectations(t)
+	})
+
+	t.Run("provenance store error", func(t *testing.T) {
+		t.Parallel()
+
+		env := newCommitterTestEnv(t)
+		defer env.cleanup()
+		block := setup(t, env)
+
+		provenanceStore := &mocks.ProvenanceStore{}
+		provenanceStore.On("Commit", uint64(1), int64(0), mock.Anything).Return(errors.New("disk full"))
+		env.committer.provenanceStore = provenanceStore
+
+		require.EqualError(t, env.committer.commitBlock(block),
+			"error while committing block 1 to the block store: failed to commit block 1 to provenance store: disk full")
+		provenanceStore.AssertExpectations(t)
+
+		height, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), height)
 	})
 }
 
@@ -315,7 +713,7 @@ func TestBlockStoreCommitter(t *testing.T) {
 
 		for blockNumber := uint64(1); blockNumber <= 100; blockNumber++ {
 			block := getSampleBlock(blockNumber)
-			require.NoError(t, env.committer.commitToBlockStore(block))
+			require.NoError(t, env.committer.commitToBlockStore(block, false))
 			expectedBlocks = append(expectedBlocks, block)
 		}
 
@@ -337,7 +735,7 @@ func TestBlockStoreCommitter(t *testing.T) {
 		defer env.cleanup()
 
 		block := getSampleBlock(10)
-		err := env.committer.commitToBlockStore(block)
+		err := env.committer.commitToBlockStore(block, false)
 		require.EqualError(t, err, "failed to commit block 10 to block store: expected block number [1] but received [10]")
 	})
 }
@@ -705,7 +1103,7 @@ func TestStateDBCommitterForUserBlock(t *testing.T) {
 						},
 					},
 				}
-				require.NoError(t, env.committer.provenanceStore.Commit(1, txsData))
+				require.NoError(t, env.provenanceStore.Commit(1, 0, txsData))
 			},
 			expectedUsersBefore: []string{"user1", "user2", "user3", "user4"},
 			tx: &types.UserAdministrationTx{
@@ -843,6 +1241,7 @@ func TestStateDBCommitterForDBBlock(t *testing.T) {
 		expectedIndexAfter      map[string]*types.DBIndex
 		expectedIndexDBsAfter   []string
 		expectedNoIndexDBsAfter []string
+		expectedVersionsAfter   map[string]*types.Version
 		valInfo                 []*types.ValidationInfo
 	}{
 		{
@@ -925,6 +1324,9 @@ func TestStateDBCommitterForDBBlock(t *testing.T) {
 							{
 								Key:   "db1",
 								Value: indexDB1,
+								Metadata: &types.Metadata{
+									Version: &types.Version{BlockNum: 1},
+								},
 							},
 							{
 								Key:   "db2",
@@ -932,6 +1334,9 @@ func TestStateDBCommitterForDBBlock(t *testing.T) {
 							},
 							{
 								Key: "db3",
+								Metadata: &types.Metadata{
+									Version: &types.Version{BlockNum: 1, TxNum: 1},
+								},
 							},
 							{
 								Key: "db6",
@@ -1015,6 +1420,11 @@ func TestStateDBCommitterForDBBlock(t *testing.T) {
 			},
 			expectedIndexDBsAfter:   []string{"db1", "db3", "db4"},
 			expectedNoIndexDBsAfter: []string{"db2", "db5", "db6"},
+			expectedVersionsAfter: map[string]*types.Version{
+				"db1": {BlockNum: 1},
+				"db3": {BlockNum: 1, TxNum: 1},
+				"db4": {BlockNum: 2},
+			},
 			valInfo: []*types.ValidationInfo{
 				{
 					Flag: types.Flag_VALID,
@@ -1039,94 +1449,446 @@ func TestStateDBCommitterForDBBlock(t *testing.T) {
 
 				require.NoError(t, db.Commit(createDBs, 1))
 			},
-			expectedDBsBefore: []string{"db1", "db2"},
-			expectedIndexBefore: map[string]*types.DBIndex{
-				"db1": nil,
-				"db2": nil,
+			expectedDBsBefore: []string{"db1", "db2"},
+			expectedIndexBefore: map[string]*types.DBIndex{
+				"db1": nil,
+				"db2": nil,
+			},
+			dbAdminTx: &types.DBAdministrationTx{
+				DeleteDbs: []string{"db1", "db2"},
+			},
+			expectedDBsAfter: []string{"db1", "db2"},
+			expectedIndexAfter: map[string]*types.DBIndex{
+				"db1": nil,
+				"db2": nil,
+			},
+			valInfo: []*types.ValidationInfo{
+				{
+					Flag: types.Flag_INVALID_NO_PERMISSION,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newCommitterTestEnv(t)
+			defer env.cleanup()
+
+			tt.setup(env.db)
+
+			for _, dbName := range tt.expectedDBsBefore {
+				require.True(t, env.db.Exist(dbName))
+				index, _, err := env.db.GetIndexDefinition(dbName)
+				require.NoError(t, err)
+
+				var expectedIndex []byte
+				if tt.expectedIndexBefore[dbName] != nil {
+					expectedIndex, err = json.Marshal(tt.expectedIndexBefore[dbName].GetAttributeAndType())
+				}
+				require.NoError(t, err)
+				require.Equal(t, expectedIndex, index)
+			}
+
+			for _, dbName := range tt.expectedIndexDBsBefore {
+				require.True(t, env.db.Exist(stateindex.IndexDB(dbName)))
+			}
+
+			block := &types.Block{
+				Header: &types.BlockHeader{
+					BaseHeader: &types.BlockHeaderBase{
+						Number: 2,
+					},
+					ValidationInfo: tt.valInfo,
+				},
+				Payload: &types.Block_DbAdministrationTxEnvelope{
+					DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+						Payload: tt.dbAdminTx,
+					},
+				},
+			}
+
+			dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+			require.NoError(t, err)
+			require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+
+			for _, dbName := range tt.expectedDBsAfter {
+				require.True(t, env.db.Exist(dbName))
+				index, _, err := env.db.GetIndexDefinition(dbName)
+				require.NoError(t, err)
+
+				var expectedIndex []byte
+				if tt.expectedIndexAfter[dbName] != nil {
+					expectedIndex, err = json.Marshal(tt.expectedIndexAfter[dbName].GetAttributeAndType())
+				}
+				require.NoError(t, err)
+				require.Equal(t, expectedIndex, index)
+			}
+
+			for _, dbName := range tt.expectedIndexDBsAfter {
+				require.True(t, env.db.Exist(stateindex.IndexDB(dbName)))
+			}
+
+			for _, dbName := range tt.expectedNoIndexDBsAfter {
+				require.False(t, env.db.Exist(stateindex.IndexDB(dbName)))
+			}
+
+			for dbName, expectedVersion := range tt.expectedVersionsAfter {
+				_, metadata, err := env.db.GetIndexDefinition(dbName)
+				require.NoError(t, err)
+				require.True(t, proto.Equal(expectedVersion, metadata.GetVersion()))
+			}
+		})
+	}
+}
+
+func TestStateDBCommitterForLegalHolds(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	createDBs := map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "db1",
+				},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(createDBs, 1))
+
+	commitDBAdminTx := func(blockNum uint64, tx *types.DBAdministrationTx) {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: blockNum,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DbAdministrationTxEnvelope{
+				DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+					Payload: tx,
+				},
+			},
+		}
+
+		dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+	}
+
+	commitDBAdminTx(2, &types.DBAdministrationTx{
+		UserId: "admin",
+		TxId:   "tx2",
+		PlaceLegalHolds: []*types.LegalHold{
+			{DbName: "db1", Reason: "case 1"},
+			{DbName: "db1", Key: "key1", Reason: "case 2", PlacedBy: "someone"},
+		},
+	})
+
+	holds, err := legalhold.DBHolds(env.db, "db1")
+	require.NoError(t, err)
+	require.Len(t, holds, 2)
+	require.True(t, proto.Equal(&types.LegalHold{DbName: "db1", Reason: "case 1", PlacedBy: "admin"}, holds[0]))
+	require.True(t, proto.Equal(&types.LegalHold{DbName: "db1", Key: "key1", Reason: "case 2", PlacedBy: "admin"}, holds[1]))
+
+	commitDBAdminTx(3, &types.DBAdministrationTx{
+		UserId:            "admin",
+		TxId:              "tx3",
+		ReleaseLegalHolds: []*types.LegalHold{{DbName: "db1"}},
+	})
+
+	holds, err = legalhold.DBHolds(env.db, "db1")
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	require.Equal(t, "key1", holds[0].Key)
+}
+
+func TestStateDBCommitterForConstraints(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	createDBs := map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "customers",
+				},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(createDBs, 1))
+
+	commitDBAdminTx := func(blockNum uint64, tx *types.DBAdministrationTx) {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: blockNum,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DbAdministrationTxEnvelope{
+				DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+					Payload: tx,
+				},
 			},
-			dbAdminTx: &types.DBAdministrationTx{
-				DeleteDbs: []string{"db1", "db2"},
+		}
+
+		dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+	}
+
+	defaultACL := &types.AccessControl{ReadWriteUsers: map[string]bool{"user1": true}}
+	ordersConstraints := &types.DBConstraints{
+		References: []*types.ReferenceConstraint{{Attribute: "customer", ReferencedDb: "customers"}},
+		Schema:     &types.DocumentSchema{RequiredAttributes: []string{"customer"}},
+		DefaultAcl: defaultACL,
+	}
+	commitDBAdminTx(2, &types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx2",
+		CreateDbs: []string{"orders"},
+		DbsConstraints: map[string]*types.DBConstraints{
+			"orders": ordersConstraints,
+		},
+	})
+
+	c, err := constraints.Get(env.db, "orders")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ordersConstraints, c))
+
+	// the keys written without an access control are given the default access control of the database
+	ownACL := &types.AccessControl{ReadUsers: map[string]bool{"user2": true}}
+	dataTx := &types.DataTx{
+		MustSignUserIds: []string{"user1"},
+		TxId:            "tx3",
+		DbOperations: []*types.DBOperation{
+			{
+				DbName: "orders",
+				DataWrites: []*types.DataWrite{
+					{Key: "o1", Value: []byte(`{"customer":"c1"}`)},
+					{Key: "o2", Value: []byte(`{"customer":"c1"}`), Acl: ownACL},
+				},
 			},
-			expectedDBsAfter: []string{"db1", "db2"},
-			expectedIndexAfter: map[string]*types.DBIndex{
-				"db1": nil,
-				"db2": nil,
+		},
+	}
+	dataBlock := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader:     &types.BlockHeaderBase{Number: 3},
+			ValidationInfo: []*types.ValidationInfo{{Flag: types.Flag_VALID}},
+		},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: []*types.DataTxEnvelope{{Payload: dataTx}},
 			},
-			valInfo: []*types.ValidationInfo{
+		},
+	}
+	dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(dataBlock)
+	require.NoError(t, err)
+	require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, dataBlock))
+
+	_, metadata, err := env.db.Get("orders", "o1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(defaultACL, metadata.AccessControl))
+	_, metadata, err = env.db.Get("orders", "o2")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ownACL, metadata.AccessControl))
+	require.Nil(t, dataTx.DbOperations[0].DataWrites[0].Acl)
+
+	commitDBAdminTx(4, &types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx4",
+		DeleteDbs: []string{"orders"},
+	})
+
+	c, err = constraints.Get(env.db, "orders")
+	require.NoError(t, err)
+	require.Nil(t, c)
+}
+
+func TestStateDBCommitterForFreezes(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	createDBs := map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
 				{
-					Flag: types.Flag_INVALID_NO_PERMISSION,
+					Key: "db1",
 				},
 			},
 		},
 	}
+	require.NoError(t, env.db.Commit(createDBs, 1))
 
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
+	commitDBAdminTx := func(blockNum uint64, tx *types.DBAdministrationTx) {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: blockNum,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
+					},
+				},
+			},
+			Payload: &types.Block_DbAdministrationTxEnvelope{
+				DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+					Payload: tx,
+				},
+			},
+		}
 
-			env := newCommitterTestEnv(t)
-			defer env.cleanup()
+		dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+	}
 
-			tt.setup(env.db)
+	commitDBAdminTx(2, &types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx2",
+		FreezeDbs: []*types.DatabaseFreeze{{DbName: "db1", Reason: "cutover", FrozenBy: "someone"}},
+	})
 
-			for _, dbName := range tt.expectedDBsBefore {
-				require.True(t, env.db.Exist(dbName))
-				index, _, err := env.db.GetIndexDefinition(dbName)
-				require.NoError(t, err)
+	freeze, err := dbfreeze.Get(env.db, "db1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.DatabaseFreeze{DbName: "db1", Reason: "cutover", FrozenBy: "admin"}, freeze))
 
-				var expectedIndex []byte
-				if tt.expectedIndexBefore[dbName] != nil {
-					expectedIndex, err = json.Marshal(tt.expectedIndexBefore[dbName].GetAttributeAndType())
-				}
-				require.NoError(t, err)
-				require.Equal(t, expectedIndex, index)
-			}
+	commitDBAdminTx(3, &types.DBAdministrationTx{
+		UserId:  "admin",
+		TxId:    "tx3",
+		ThawDbs: []string{"db1"},
+	})
 
-			for _, dbName := range tt.expectedIndexDBsBefore {
-				require.True(t, env.db.Exist(stateindex.IndexDB(dbName)))
-			}
+	freeze, err = dbfreeze.Get(env.db, "db1")
+	require.NoError(t, err)
+	require.Nil(t, freeze)
+}
 
-			block := &types.Block{
-				Header: &types.BlockHeader{
-					BaseHeader: &types.BlockHeaderBase{
-						Number: 2,
-					},
-					ValidationInfo: tt.valInfo,
+func TestStateDBCommitterForMigrations(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	aclAlice := &types.AccessControl{ReadUsers: map[string]bool{"alice": true}}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key: "db1",
 				},
-				Payload: &types.Block_DbAdministrationTxEnvelope{
-					DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
-						Payload: tt.dbAdminTx,
+			},
+		},
+	}, 1))
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte(`{"a1":"one"}`), Metadata: &types.Metadata{AccessControl: aclAlice}},
+				{Key: "key2", Value: []byte(`{"a1":"two"}`)},
+			},
+		},
+	}, 2))
+
+	commitDBAdminTx := func(blockNum uint64, tx *types.DBAdministrationTx) {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number: blockNum,
+				},
+				ValidationInfo: []*types.ValidationInfo{
+					{
+						Flag: types.Flag_VALID,
 					},
 				},
-			}
+			},
+			Payload: &types.Block_DbAdministrationTxEnvelope{
+				DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+					Payload: tx,
+				},
+			},
+		}
 
-			dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
-			require.NoError(t, err)
-			require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+		dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+	}
 
-			for _, dbName := range tt.expectedDBsAfter {
-				require.True(t, env.db.Exist(dbName))
-				index, _, err := env.db.GetIndexDefinition(dbName)
-				require.NoError(t, err)
+	// the stored values of an existing database and the values of a created database are indexed
+	index := &types.DBIndex{AttributeAndType: map[string]types.IndexAttributeType{"a1": types.IndexAttributeType_STRING}}
+	commitDBAdminTx(3, &types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx3",
+		CreateDbs: []string{"db2"},
+		DbsIndex:  map[string]*types.DBIndex{"db1": index, "db2": index},
+		Migration: &types.Migration{
+			Name:             "schema",
+			Version:          1,
+			Description:      "index a1",
+			BackfillIndexDbs: []string{"db1", "db2"},
+		},
+	})
 
-				var expectedIndex []byte
-				if tt.expectedIndexAfter[dbName] != nil {
-					expectedIndex, err = json.Marshal(tt.expectedIndexAfter[dbName].GetAttributeAndType())
-				}
-				require.NoError(t, err)
-				require.Equal(t, expectedIndex, index)
-			}
+	state, err := migration.Get(env.db, "schema")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.MigrationState{
+		Name:    "schema",
+		Version: 1,
+		History: []*types.MigrationStep{
+			{Version: 1, Description: "index a1", TxId: "tx3", UserId: "admin", BlockNumber: 3},
+		},
+	}, state))
 
-			for _, dbName := range tt.expectedIndexDBsAfter {
-				require.True(t, env.db.Exist(stateindex.IndexDB(dbName)))
-			}
+	require.True(t, env.db.Exist("db2"))
+	require.True(t, env.db.Exist(stateindex.IndexDB("db2")))
 
-			for _, dbName := range tt.expectedNoIndexDBsAfter {
-				require.False(t, env.db.Exist(stateindex.IndexDB(dbName)))
-			}
-		})
+	itr, err := env.db.GetIterator(stateindex.IndexDB("db1"), "", "")
+	require.NoError(t, err)
+	var entries []string
+	for itr.Next() {
+		entries = append(entries, string(itr.Key()))
 	}
+	itr.Release()
+	require.Equal(t, []string{
+		`{"a":"a1","t":1,"vp":2,"v":"one","kp":2,"k":"key1"}`,
+		`{"a":"a1","t":1,"vp":2,"v":"two","kp":2,"k":"key2"}`,
+	}, entries)
+
+	_, metadata, err := env.db.Get(stateindex.IndexDB("db1"), entries[0])
+	require.NoError(t, err)
+	require.True(t, proto.Equal(aclAlice, metadata.GetAccessControl()))
+
+	commitDBAdminTx(4, &types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx4",
+		Migration: &types.Migration{Name: "schema", Version: 1, Direction: types.Migration_DOWN},
+	})
+
+	state, err = migration.Get(env.db, "schema")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), state.Version)
+	require.Len(t, state.History, 2)
+	require.Equal(t, types.Migration_DOWN, state.History[1].Direction)
+	require.Equal(t, uint64(4), state.History[1].BlockNumber)
 }
 
 func TestStateDBCommitterForConfigBlock(t *testing.T) {
@@ -1591,14 +2353,14 @@ func TestProvenanceStoreCommitterForDataBlockWithValidTxs(t *testing.T) {
 				},
 			},
 		}
-		require.NoError(t, env.committer.provenanceStore.Commit(1, txsData))
+		require.NoError(t, env.provenanceStore.Commit(1, 0, txsData))
 	}
 
 	tests := []struct {
 		name         string
 		txs          []*types.DataTxEnvelope
 		valInfo      []*types.ValidationInfo
-		query        func(s *provenance.Store, dbName string) ([]*types.ValueWithMetadata, error)
+		query        func(s provenance.Store, dbName string) ([]*types.ValueWithMetadata, error)
 		expectedData []*types.ValueWithMetadata
 	}{
 		{
@@ -1636,7 +2398,7 @@ func TestProvenanceStoreCommitterForDataBlockWithValidTxs(t *testing.T) {
 					Flag: types.Flag_VALID,
 				},
 			},
-			query: func(s *provenance.Store, dbName string) ([]*types.ValueWithMetadata, error) {
+			query: func(s provenance.Store, dbName string) ([]*types.ValueWithMetadata, error) {
 				return s.GetPreviousValues(
 					dbName,
 					"key0",
@@ -1694,7 +2456,7 @@ func TestProvenanceStoreCommitterForDataBlockWithValidTxs(t *testing.T) {
 					Flag: types.Flag_VALID,
 				},
 			},
-			query: func(s *provenance.Store, _ string) ([]*types.ValueWithMetadata, error) {
+			query: func(s provenance.Store, _ string) ([]*types.ValueWithMetadata, error) {
 				kvs, err := s.GetValuesReadByUser("user1")
 				if err != nil {
 					return nil, err
@@ -1758,7 +2520,7 @@ func TestProvenanceStoreCommitterForDataBlockWithValidTxs(t *testing.T) {
 					Flag: types.Flag_VALID,
 				},
 			},
-			query: func(s *provenance.Store, dbName string) ([]*types.ValueWithMetadata, error) {
+			query: func(s provenance.Store, dbName string) ([]*types.ValueWithMetadata, error) {
 				return s.GetDeletedValues(
 					dbName,
 					"key0",
@@ -1805,7 +2567,7 @@ func TestProvenanceStoreCommitterForDataBlockWithValidTxs(t *testing.T) {
 					Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
 				},
 			},
-			query: func(s *provenance.Store, _ string) ([]*types.ValueWithMetadata, error) {
+			query: func(s provenance.Store, _ string) ([]*types.ValueWithMetadata, error) {
 				kvs, err := s.GetValuesReadByUser("user1")
 				if err != nil {
 					return nil, err
@@ -1853,10 +2615,10 @@ func TestProvenanceStoreCommitterForDataBlockWithValidTxs(t *testing.T) {
 
 			_, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
 			require.NoError(t, err)
-			require.NoError(t, env.committer.commitToProvenanceStore(2, provenanceData))
+			require.NoError(t, env.committer.commitToProvenanceStore(2, 0, provenanceData))
 
 			for _, dbName := range []string{worldstate.DefaultDBName, "db1"} {
-				actualData, err := tt.query(env.committer.provenanceStore, dbName)
+				actualData, err := tt.query(env.provenanceStore, dbName)
 				require.NoError(t, err)
 				require.ElementsMatch(t, tt.expectedData, actualData)
 			}
@@ -1902,14 +2664,14 @@ func TestProvenanceStoreCommitterForUserBlockWithValidTxs(t *testing.T) {
 				},
 			},
 		}
-		require.NoError(t, env.committer.provenanceStore.Commit(1, txsData))
+		require.NoError(t, env.provenanceStore.Commit(1, 0, txsData))
 	}
 
 	tests := []struct {
 		name         string
 		tx           *types.UserAdministrationTxEnvelope
 		valInfo      *types.ValidationInfo
-		query        func(s *provenance.Store) ([]*types.ValueWithMetadata, error)
+		query        func(s provenance.Store) ([]*types.ValueWithMetadata, error)
 		expectedData []*types.ValueWithMetadata
 	}{
 		{
@@ -1929,7 +2691,7 @@ func TestProvenanceStoreCommitterForUserBlockWithValidTxs(t *testing.T) {
 				},
 				Signature: nil,
 			},
-			query: func(s *provenance.Store) ([]*types.ValueWithMetadata, error) {
+			query: func(s provenance.Store) ([]*types.ValueWithMetadata, error) {
 				return s.GetPreviousValues(
 					worldstate.UsersDBName,
 					"user1",
@@ -1964,7 +2726,7 @@ func TestProvenanceStoreCommitterForUserBlockWithValidTxs(t *testing.T) {
 			valInfo: &types.ValidationInfo{
 				Flag: types.Flag_VALID,
 			},
-			query: func(s *provenance.Store) ([]*types.ValueWithMetadata, error) {
+			query: func(s provenance.Store) ([]*types.ValueWithMetadata, error) {
 				return s.GetDeletedValues(
 					worldstate.UsersDBName,
 					"user1",
@@ -2004,9 +2766,9 @@ func TestProvenanceStoreCommitterForUserBlockWithValidTxs(t *testing.T) {
 
 			_, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
 			require.NoError(t, err)
-			require.NoError(t, env.committer.commitToProvenanceStore(2, provenanceData))
+			require.NoError(t, env.committer.commitToProvenanceStore(2, 0, provenanceData))
 
-			actualData, err := tt.query(env.committer.provenanceStore)
+			actualData, err := tt.query(env.provenanceStore)
 			require.NoError(t, err)
 			require.Len(t, actualData, len(tt.expectedData))
 			for i, expected := range tt.expectedData {
@@ -2194,16 +2956,16 @@ func TestProvenanceStoreCommitterForConfigBlockWithValidTxs(t *testing.T) {
 				OldVersionOfWrites: make(map[string]*types.Version),
 			},
 		}
-		require.NoError(t, env.committer.provenanceStore.Commit(1, provenanceData))
+		require.NoError(t, env.provenanceStore.Commit(1, 0, provenanceData))
 	}
 
 	tests := []struct {
 		name                     string
 		tx                       *types.ConfigTx
 		valInfo                  *types.ValidationInfo
-		queryAdmin               func(s *provenance.Store) ([]*types.ValueWithMetadata, error)
+		queryAdmin               func(s provenance.Store) ([]*types.ValueWithMetadata, error)
 		expectedAdminQueryResult []*types.ValueWithMetadata
-		queryNode                func(s *provenance.Store) ([]*types.ValueWithMetadata, error)
+		queryNode                func(s provenance.Store) ([]*types.ValueWithMetadata, error)
 		expectedNodeQueryResult  []*types.ValueWithMetadata
 	}{
 		{
@@ -2220,7 +2982,7 @@ func TestProvenanceStoreCommitterForConfigBlockWithValidTxs(t *testing.T) {
 			valInfo: &types.ValidationInfo{
 				Flag: types.Flag_VALID,
 			},
-			queryAdmin: func(s *provenance.Store) ([]*types.ValueWithMetadata, error) {
+			queryAdmin: func(s provenance.Store) ([]*types.ValueWithMetadata, error) {
 				return s.GetPreviousValues(
 					worldstate.ConfigDBName,
 					worldstate.ConfigKey,
@@ -2242,7 +3004,7 @@ func TestProvenanceStoreCommitterForConfigBlockWithValidTxs(t *testing.T) {
 					},
 				},
 			},
-			queryNode: func(s *provenance.Store) ([]*types.ValueWithMetadata, error) {
+			queryNode: func(s provenance.Store) ([]*types.ValueWithMetadata, error) {
 				return s.GetDeletedValues(
 					worldstate.ConfigDBName,
 					"bdb-node-2",
@@ -2274,7 +3036,7 @@ func TestProvenanceStoreCommitterForConfigBlockWithValidTxs(t *testing.T) {
 			valInfo: &types.ValidationInfo{
 				Flag: types.Flag_VALID,
 			},
-			queryAdmin: func(s *provenance.Store) ([]*types.ValueWithMetadata, error) {
+			queryAdmin: func(s provenance.Store) ([]*types.ValueWithMetadata, error) {
 				return s.GetNextValues(
 					worldstate.UsersDBName,
 					"admin1",
@@ -2296,7 +3058,7 @@ func TestProvenanceStoreCommitterForConfigBlockWithValidTxs(t *testing.T) {
 					},
 				},
 			},
-			queryNode: func(s *provenance.Store) ([]*types.ValueWithMetadata, error) {
+			queryNode: func(s provenance.Store) ([]*types.ValueWithMetadata, error) {
 				return s.GetValues(
 					worldstate.ConfigDBName,
 					"bdb-node-1",
@@ -2343,23 +3105,23 @@ func TestProvenanceStoreCommitterForConfigBlockWithValidTxs(t *testing.T) {
 
 			_, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
 			require.NoError(t, err)
-			require.NoError(t, env.committer.commitToProvenanceStore(2, provenanceData))
+			require.NoError(t, env.committer.commitToProvenanceStore(2, 0, provenanceData))
 
-			actualData, err := tt.queryAdmin(env.committer.provenanceStore)
+			actualData, err := tt.queryAdmin(env.provenanceStore)
 			require.NoError(t, err)
 			require.Len(t, actualData, len(tt.expectedAdminQueryResult))
 			for i, expected := range tt.expectedAdminQueryResult {
 				require.True(t, proto.Equal(expected, actualData[i]))
 			}
 
-			actualData, err = tt.queryNode(env.committer.provenanceStore)
+			actualData, err = tt.queryNode(env.provenanceStore)
 			require.NoError(t, err)
 			require.Len(t, actualData, len(tt.expectedNodeQueryResult))
 			for i, expected := range tt.expectedNodeQueryResult {
 				require.True(t, proto.Equal(expected, actualData[i]))
 			}
 
-			txIDs, err := env.committer.provenanceStore.GetTxIDsSubmittedByUser("user1")
+			txIDs, err := env.provenanceStore.GetTxIDsSubmittedByUser("user1")
 			require.NoError(t, err)
 			require.ElementsMatch(t, []string{"tx1"}, txIDs)
 		})
@@ -2372,7 +3134,7 @@ func TestProvenanceStoreCommitterWithInvalidTxs(t *testing.T) {
 	tests := []struct {
 		name         string
 		block        *types.Block
-		query        func(s *provenance.Store) (*provenance.TxIDLocation, error)
+		query        func(s provenance.Store) (*provenance.TxIDLocation, error)
 		expectedData *provenance.TxIDLocation
 		expectedErr  string
 	}{
@@ -2421,7 +3183,7 @@ func TestProvenanceStoreCommitterWithInvalidTxs(t *testing.T) {
 					},
 				},
 			},
-			query: func(s *provenance.Store) (*provenance.TxIDLocation, error) {
+			query: func(s provenance.Store) (*provenance.TxIDLocation, error) {
 				return s.GetTxIDLocation("tx2")
 			},
 			expectedData: &provenance.TxIDLocation{
@@ -2451,7 +3213,7 @@ func TestProvenanceStoreCommitterWithInvalidTxs(t *testing.T) {
 					},
 				},
 			},
-			query: func(s *provenance.Store) (*provenance.TxIDLocation, error) {
+			query: func(s provenance.Store) (*provenance.TxIDLocation, error) {
 				return s.GetTxIDLocation("tx1")
 			},
 			expectedData: &provenance.TxIDLocation{
@@ -2481,7 +3243,7 @@ func TestProvenanceStoreCommitterWithInvalidTxs(t *testing.T) {
 					},
 				},
 			},
-			query: func(s *provenance.Store) (*provenance.TxIDLocation, error) {
+			query: func(s provenance.Store) (*provenance.TxIDLocation, error) {
 				return s.GetTxIDLocation("tx1")
 			},
 			expectedData: &provenance.TxIDLocation{
@@ -2520,7 +3282,7 @@ func TestProvenanceStoreCommitterWithInvalidTxs(t *testing.T) {
 					},
 				},
 			},
-			query: func(s *provenance.Store) (*provenance.TxIDLocation, error) {
+			query: func(s provenance.Store) (*provenance.TxIDLocation, error) {
 				return s.GetTxIDLocation("tx-not-there")
 			},
 			expectedErr: "TxID not found: tx-not-there",
@@ -2537,9 +3299,9 @@ func TestProvenanceStoreCommitterWithInvalidTxs(t *testing.T) {
 
 			_, provenanceData, err := env.committer.constructDBAndProvenanceEntries(tt.block)
 			require.NoError(t, err)
-			require.NoError(t, env.committer.commitToProvenanceStore(2, provenanceData))
+			require.NoError(t, env.committer.commitToProvenanceStore(2, 0, provenanceData))
 
-			actualData, err := tt.query(env.committer.provenanceStore)
+			actualData, err := tt.query(env.provenanceStore)
 			if tt.expectedErr == "" {
 				require.NoError(t, err)
 				require.Equal(t, tt.expectedData, actualData)
@@ -2838,6 +3600,88 @@ func TestConstructProvenanceEntriesForDataTx(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "tx with acl writes",
+			tx: &types.DataTx{
+				MustSignUserIds: []string{"user1"},
+				TxId:            "tx3",
+				DbOperations: []*types.DBOperation{
+					{
+						DbName: worldstate.DefaultDBName,
+						AclWrites: []*types.AclWrite{
+							{
+								Key: "key1",
+								NewAcl: &types.AccessControl{
+									ReadUsers: map[string]bool{
+										"user2": true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			version: &types.Version{
+				BlockNum: 10,
+				TxNum:    4,
+			},
+			setup: func(db worldstate.DB) {
+				update := map[string]*worldstate.DBUpdates{
+					worldstate.DefaultDBName: {
+						Writes: []*worldstate.KVWithMetadata{
+							{
+								Key:   "key1",
+								Value: []byte("value1"),
+								Metadata: &types.Metadata{
+									Version: &types.Version{
+										BlockNum: 3,
+										TxNum:    3,
+									},
+									AccessControl: &types.AccessControl{
+										ReadWriteUsers: map[string]bool{
+											"user1": true,
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				require.NoError(t, db.Commit(update, 1))
+			},
+			expectedProvenanceData: []*provenance.TxDataForProvenance{
+				{
+					IsValid: true,
+					DBName:  worldstate.DefaultDBName,
+					UserID:  "user1",
+					TxID:    "tx3",
+					Writes: []*types.KVWithMetadata{
+						{
+							Key:   "key1",
+							Value: []byte("value1"),
+							Metadata: &types.Metadata{
+								Version: &types.Version{
+									BlockNum: 10,
+									TxNum:    4,
+								},
+								AccessControl: &types.AccessControl{
+									ReadUsers: map[string]bool{
+										"user2": true,
+									},
+								},
+							},
+						},
+					},
+					Deletes: make(map[string]*types.Version),
+					OldVersionOfWrites: map[string]*types.Version{
+						"key1": {
+							BlockNum: 3,
+							TxNum:    3,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2854,6 +3698,90 @@ func TestConstructProvenanceEntriesForDataTx(t *testing.T) {
 	}
 }
 
+func TestAddDBEntriesForAclWrites(t *testing.T) {
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	update := map[string]*worldstate.DBUpdates{
+		worldstate.DefaultDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   "key1",
+					Value: []byte("value1"),
+					Metadata: &types.Metadata{
+						Version: &types.Version{
+							BlockNum: 3,
+							TxNum:    3,
+						},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, env.db.Commit(update, 1))
+
+	tx := &types.DataTx{
+		MustSignUserIds: []string{"user1"},
+		TxId:            "tx1",
+		DbOperations: []*types.DBOperation{
+			{
+				DbName: worldstate.DefaultDBName,
+				DataWrites: []*types.DataWrite{
+					{
+						Key:   "key2",
+						Value: []byte("value2"),
+					},
+				},
+				AclWrites: []*types.AclWrite{
+					{
+						Key: "key1",
+						NewAcl: &types.AccessControl{
+							ReadWriteUsers: map[string]bool{
+								"user1": true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	version := &types.Version{
+		BlockNum: 4,
+		TxNum:    1,
+	}
+
+	dbsUpdates := make(map[string]*worldstate.DBUpdates)
+	AddDBEntriesForDataTx(tx, version, dbsUpdates)
+	require.NoError(t, addDBEntriesForAclWrites(env.db, tx, version, dbsUpdates))
+
+	expectedUpdates := map[string]*worldstate.DBUpdates{
+		worldstate.DefaultDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   "key2",
+					Value: []byte("value2"),
+					Metadata: &types.Metadata{
+						Version: version,
+					},
+				},
+				{
+					Key:   "key1",
+					Value: []byte("value1"),
+					Metadata: &types.Metadata{
+						Version: version,
+						AccessControl: &types.AccessControl{
+							ReadWriteUsers: map[string]bool{
+								"user1": true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	require.Equal(t, expectedUpdates, dbsUpdates)
+}
+
 func TestConstructProvenanceEntriesForConfigTx(t *testing.T) {
 	clusterConfig := &types.ClusterConfig{
 		Nodes: []*types.NodeConfig{
@@ -0,0 +1,21 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// auditFreezes logs the databases frozen and thawed by a valid database administration transaction. As the log is
+// written when the entries of the transaction are constructed, a block replayed during recovery is logged again.
+func (c *committer) auditFreezes(tx *types.DBAdministrationTx) {
+	for _, f := range tx.FreezeDbs {
+		c.logger.Infof("database freeze audit: user [%s] froze the database [%s] in transaction [%s] for the reason [%s]",
+			tx.UserId, f.DbName, tx.TxId, f.Reason)
+	}
+	for _, dbName := range tx.ThawDbs {
+		c.logger.Infof("database freeze audit: user [%s] thawed the database [%s] in transaction [%s]",
+			tx.UserId, dbName, tx.TxId)
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/quota"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// dbUsageDelta is the change a block makes to the usage of a database
+type dbUsageDelta struct {
+	storage int64
+	txs     uint64
+}
+
+// commitDBUsage accounts the usage of the databases with a quota up to the block, and stores it together with the
+// quota alerts the block raises. The usage is accounted before the block is committed to the state database, which
+// holds the sizes of the values the block overwrites and deletes. When a block is committed again during recovery,
+// the usage that was already accounted for it is kept.
+func (c *committer) commitDBUsage(block *types.Block) error {
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+
+	quotas, err := c.quotasAfter(block)
+	if err != nil {
+		return err
+	}
+	usages, err := c.blockStore.GetDBUsages()
+	if err != nil {
+		return err
+	}
+	if len(quotas) == 0 && len(usages) == 0 {
+		return nil
+	}
+
+	deletedDBs := make(map[string]bool)
+	if dbAdminTx := block.GetDbAdministrationTxEnvelope().GetPayload(); dbAdminTx != nil && blockValid(block) {
+		for _, dbName := range dbAdminTx.DeleteDbs {
+			deletedDBs[dbName] = true
+		}
+	}
+
+	var removed []string
+	for dbName := range usages {
+		if quotas[dbName] == nil || deletedDBs[dbName] {
+			removed = append(removed, dbName)
+		}
+	}
+	sort.Strings(removed)
+
+	deltas, err := c.dataBlockUsageDeltas(block, quotas)
+	if err != nil {
+		return err
+	}
+
+	dbNames := make([]string, 0, len(quotas))
+	for dbName := range quotas {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	minuteStart := quota.MinuteStart(block.GetHeader().GetBaseHeader().GetTimestamp())
+	var updated []*types.DBUsage
+	var alerts []*types.QuotaAlert
+	for _, dbName := range dbNames {
+		if deletedDBs[dbName] || !c.db.Exist(dbName) {
+			continue
+		}
+
+		prev := usages[dbName]
+		if prev != nil && prev.BlockNum >= blockNum {
+			// the block was accounted before a crash
+			continue
+		}
+
+		var usage *types.DBUsage
+		if prev == nil {
+			// the first block committed after the quota is configured accounts the data already stored
+			storage, err := c.storedBytes(dbName)
+			if err != nil {
+				return err
+			}
+			usage = &types.DBUsage{DbName: dbName, StorageBytes: storage}
+			prev = &types.DBUsage{}
+		} else {
+			usage = proto.Clone(prev).(*types.DBUsage)
+		}
+
+		d := deltas[dbName]
+		if d.storage < 0 && uint64(-d.storage) > usage.StorageBytes {
+			usage.StorageBytes = 0
+		} else {
+			usage.StorageBytes = uint64(int64(usage.StorageBytes) + d.storage)
+		}
+		usage.MinuteTxs = quota.MinuteTxs(usage, minuteStart) + d.txs
+		usage.MinuteStart = minuteStart
+		usage.BlockNum = blockNum
+		updated = append(updated, usage)
+
+		q := quotas[dbName]
+		if crossed(prev.StorageBytes, usage.StorageBytes, q.SoftStorageBytes) {
+			alerts = append(alerts, &types.QuotaAlert{
+				DbName:    dbName,
+				BlockNum:  blockNum,
+				Resource:  types.QuotaAlert_STORAGE,
+				Usage:     usage.StorageBytes,
+				SoftLimit: q.SoftStorageBytes,
+				HardLimit: q.HardStorageBytes,
+			})
+		}
+		if crossed(quota.MinuteTxs(prev, minuteStart), usage.MinuteTxs, q.SoftTxsPerMinute) {
+			alerts = append(alerts, &types.QuotaAlert{
+				DbName:    dbName,
+				BlockNum:  blockNum,
+				Resource:  types.QuotaAlert_TX_RATE,
+				Usage:     usage.MinuteTxs,
+				SoftLimit: q.SoftTxsPerMinute,
+				HardLimit: q.HardTxsPerMinute,
+			})
+		}
+	}
+
+	for _, a := range alerts {
+		c.logger.Warnf("Block %d made the %s usage of database [%s] reach %d, the soft limit is %d", blockNum, a.Resource, a.DbName, a.Usage, a.SoftLimit)
+	}
+
+	if err := c.blockStore.CommitDBUsage(blockNum, updated, removed, alerts); err != nil {
+		return errors.WithMessagef(err, "failed to commit the database usage of block %d", blockNum)
+	}
+
+	return nil
+}
+
+// quotasAfter returns the quotas of the databases, by name, as configured once the block is committed
+func (c *committer) quotasAfter(block *types.Block) (map[string]*types.DatabaseQuota, error) {
+	if configTx := block.GetConfigTxEnvelope().GetPayload(); configTx != nil && blockValid(block) {
+		return quota.ByDBName(configTx.NewConfig), nil
+	}
+
+	config, _, err := c.db.GetConfig()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get the cluster configuration")
+	}
+
+	return quota.ByDBName(config), nil
+}
+
+// dataBlockUsageDeltas returns the changes the valid data transactions of the block make to the usage of the
+// databases with a quota. The storage of a written or deleted key changes by the difference between the size of its
+// new value and the size of its stored value, if any.
+func (c *committer) dataBlockUsageDeltas(block *types.Block, quotas map[string]*types.DatabaseQuota) (map[string]dbUsageDelta, error) {
+	deltas := make(map[string]dbUsageDelta)
+
+	dataTxEnvs := block.GetDataTxEnvelopes().GetEnvelopes()
+	validationInfo := block.GetHeader().GetValidationInfo()
+	for txIndex, txEnv := range dataTxEnvs {
+		if validationInfo[txIndex].GetFlag() != types.Flag_VALID {
+			continue
+		}
+
+		for _, ops := range txEnv.GetPayload().GetDbOperations() {
+			if quotas[ops.DbName] == nil {
+				continue
+			}
+
+			d := deltas[ops.DbName]
+			d.txs++
+			for _, w := range ops.DataWrites {
+				size, err := c.storedKeySize(ops.DbName, w.Key)
+				if err != nil {
+					return nil, err
+				}
+				d.storage += int64(len(w.Key)+len(w.Value)) + int64(w.GetBlobManifest().GetSize()) - size
+			}
+			for _, del := range ops.DataDeletes {
+				size, err := c.storedKeySize(ops.DbName, del.Key)
+				if err != nil {
+					return nil, err
+				}
+				d.storage -= size
+			}
+			deltas[ops.DbName] = d
+		}
+	}
+
+	return deltas, nil
+}
+
+// storedKeySize returns the size of a key and its value in the state database, or zero if the key does not exist. A
+// value held by the blob store is not read, as its manifest holds its size.
+func (c *committer) storedKeySize(dbName, key string) (int64, error) {
+	manifest, err := c.db.GetBlobManifest(dbName, key)
+	if err != nil {
+		return 0, err
+	}
+	if manifest != nil {
+		return int64(len(key)) + int64(manifest.Size), nil
+	}
+
+	value, metadata, err := c.db.Get(dbName, key)
+	if err != nil {
+		return 0, err
+	}
+	if value == nil && metadata == nil {
+		return 0, nil
+	}
+
+	return int64(len(key) + len(value)), nil
+}
+
+// storedBytes returns the total size of the keys and values stored in the database
+func (c *committer) storedBytes(dbName string) (uint64, error) {
+	itr, err := c.db.GetIterator(dbName, "", "")
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Release()
+
+	var size uint64
+	for itr.Next() {
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return 0, errors.Wrapf(err, "failed to unmarshal a value of database %s", dbName)
+		}
+
+		size += uint64(len(itr.Key()))
+		if persisted.BlobManifest != nil {
+			size += persisted.BlobManifest.Size
+		} else {
+			size += uint64(len(persisted.Value))
+		}
+	}
+
+	return size, errors.Wrapf(itr.Error(), "failed to read database %s", dbName)
+}
+
+// crossed returns true if a soft limit is set, and the usage reached it
+func crossed(before, after, softLimit uint64) bool {
+	return softLimit > 0 && before < softLimit && after >= softLimit
+}
+
+// blockValid returns true if the single transaction of a user administration, database administration, or
+// configuration block is valid
+func blockValid(block *types.Block) bool {
+	validationInfo := block.GetHeader().GetValidationInfo()
+	return len(validationInfo) > 0 && validationInfo[0].GetFlag() == types.Flag_VALID
+}
@@ -0,0 +1,190 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitDBUsage(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	config, err := proto.Marshal(&types.ClusterConfig{
+		DbQuotas: []*types.DatabaseQuota{
+			{
+				DbName:           "db1",
+				SoftStorageBytes: 30,
+				HardStorageBytes: 100,
+				SoftTxsPerMinute: 2,
+				HardTxsPerMinute: 10,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1"},
+				{Key: "db2"},
+			},
+		},
+		worldstate.ConfigDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: worldstate.ConfigKey, Value: config},
+			},
+		},
+	}, 1))
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "k0", Value: []byte("v0"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}}},
+			},
+		},
+	}, 1))
+
+	dataBlock := func(number uint64, timestamp time.Duration, flags []types.Flag, ops ...[]*types.DBOperation) *types.Block {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:    number,
+					Timestamp: int64(timestamp),
+				},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{},
+			},
+		}
+		for i, txOps := range ops {
+			block.Header.ValidationInfo = append(block.Header.ValidationInfo, &types.ValidationInfo{Flag: flags[i]})
+			block.GetDataTxEnvelopes().Envelopes = append(block.GetDataTxEnvelopes().Envelopes, &types.DataTxEnvelope{
+				Payload: &types.DataTx{
+					MustSignUserIds: []string{"alice"},
+					TxId:            fmt.Sprintf("tx%d-%d", number, i),
+					DbOperations:    txOps,
+				},
+				Signatures: map[string][]byte{"alice": []byte("sig")},
+			})
+		}
+		return block
+	}
+
+	commit := func(block *types.Block) {
+		dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+	}
+
+	minute := time.Minute
+	block2 := dataBlock(2, 5*minute+10*time.Second,
+		[]types.Flag{types.Flag_VALID, types.Flag_INVALID_NO_PERMISSION, types.Flag_VALID},
+		[]*types.DBOperation{
+			{DbName: "db1", DataWrites: []*types.DataWrite{{Key: "k1", Value: []byte("value1")}}},
+		},
+		[]*types.DBOperation{
+			{DbName: "db1", DataWrites: []*types.DataWrite{{Key: "k9", Value: []byte("invalid")}}},
+		},
+		[]*types.DBOperation{
+			{DbName: "db1", DataWrites: []*types.DataWrite{{Key: "k2", Value: []byte("value00")}}},
+			{DbName: "db2", DataWrites: []*types.DataWrite{{Key: "x", Value: []byte("y")}}},
+		},
+	)
+	commit(block2)
+
+	// the key k0, stored before the quota was configured, is accounted by the first block
+	expectedUsage := &types.DBUsage{
+		DbName:       "db1",
+		BlockNum:     2,
+		StorageBytes: uint64(len("k0") + len("v0") + len("k1") + len("value1") + len("k2") + len("value00")),
+		MinuteStart:  int64(5 * minute),
+		MinuteTxs:    2,
+	}
+	usage, err := env.blockStore.GetDBUsage("db1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expectedUsage, usage), "expected: %v, actual: %v", expectedUsage, usage)
+
+	usage, err = env.blockStore.GetDBUsage("db2")
+	require.NoError(t, err)
+	require.Nil(t, usage)
+
+	alerts, err := env.blockStore.GetQuotaAlerts(2)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.True(t, proto.Equal(&types.QuotaAlert{
+		DbName:    "db1",
+		BlockNum:  2,
+		Resource:  types.QuotaAlert_TX_RATE,
+		Usage:     2,
+		SoftLimit: 2,
+		HardLimit: 10,
+	}, alerts[0]))
+
+	// a block committed again during recovery is not accounted twice
+	require.NoError(t, env.committer.commitDBUsage(block2))
+	usage, err = env.blockStore.GetDBUsage("db1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expectedUsage, usage), "expected: %v, actual: %v", expectedUsage, usage)
+
+	// the transaction rate is accounted per minute, and the storage of the overwritten and deleted values is released
+	block3 := dataBlock(3, 6*minute,
+		[]types.Flag{types.Flag_VALID},
+		[]*types.DBOperation{
+			{
+				DbName:      "db1",
+				DataWrites:  []*types.DataWrite{{Key: "k1", Value: []byte("a value of 24 bytes long")}},
+				DataDeletes: []*types.DataDelete{{Key: "k2"}},
+			},
+		},
+	)
+	commit(block3)
+
+	expectedUsage = &types.DBUsage{
+		DbName:       "db1",
+		BlockNum:     3,
+		StorageBytes: uint64(len("k0") + len("v0") + len("k1") + len("a value of 24 bytes long")),
+		MinuteStart:  int64(6 * minute),
+		MinuteTxs:    1,
+	}
+	usage, err = env.blockStore.GetDBUsage("db1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expectedUsage, usage), "expected: %v, actual: %v", expectedUsage, usage)
+
+	alerts, err = env.blockStore.GetQuotaAlerts(3)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.True(t, proto.Equal(&types.QuotaAlert{
+		DbName:    "db1",
+		BlockNum:  3,
+		Resource:  types.QuotaAlert_STORAGE,
+		Usage:     expectedUsage.StorageBytes,
+		SoftLimit: 30,
+		HardLimit: 100,
+	}, alerts[0]))
+
+	// the usage of a database is removed with its quota
+	configBlock := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader:     &types.BlockHeaderBase{Number: 4, Timestamp: int64(7 * minute)},
+			ValidationInfo: []*types.ValidationInfo{{Flag: types.Flag_VALID}},
+		},
+		Payload: &types.Block_ConfigTxEnvelope{
+			ConfigTxEnvelope: &types.ConfigTxEnvelope{
+				Payload: &types.ConfigTx{NewConfig: &types.ClusterConfig{}},
+			},
+		},
+	}
+	require.NoError(t, env.committer.commitDBUsage(configBlock))
+	usage, err = env.blockStore.GetDBUsage("db1")
+	require.NoError(t, err)
+	require.Nil(t, usage)
+}
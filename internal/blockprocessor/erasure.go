@@ -0,0 +1,94 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// commitErasure applies the changes a committed block makes to the erasure keys: a config transaction sets the
+// erasable databases, and a database administration transaction destroys the erasure keys of the keys it erases. The
+// erasure keys are destroyed after the block is committed to the state database, such that a block replayed during
+// recovery can still unseal the values it reads. A block whose erasure is lost in a crash is the last block committed
+// to the state database, and its erasure is applied again during recovery.
+func (c *committer) commitErasure(block *types.Block) error {
+	if c.erasure == nil || !blockValid(block) {
+		return nil
+	}
+
+	if configTx := block.GetConfigTxEnvelope().GetPayload(); configTx != nil {
+		c.erasure.SetErasableDBs(configTx.GetNewConfig().GetErasableDbs())
+		return nil
+	}
+
+	if eraseKeys := block.GetDbAdministrationTxEnvelope().GetPayload().GetEraseKeys(); len(eraseKeys) > 0 {
+		if err := c.erasure.Erase(eraseKeys); err != nil {
+			return errors.WithMessagef(err, "failed to erase the keys of block %d", block.GetHeader().GetBaseHeader().GetNumber())
+		}
+	}
+
+	return nil
+}
+
+// checkErasedBlock checks that a block whose values were erased, which is committed from another node, is a data block
+// that holds the validation info of each of its transactions
+func checkErasedBlock(block *types.Block) error {
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	envelopes := block.GetDataTxEnvelopes().GetEnvelopes()
+	if envelopes == nil {
+		return errors.Errorf("block [%d] holds values that were erased, but is not a data block", blockNum)
+	}
+	if len(block.GetHeader().GetValidationInfo()) != len(envelopes) {
+		return errors.Errorf("block [%d] holds values that were erased, and the validation info of %d of its %d transactions",
+			blockNum, len(block.GetHeader().GetValidationInfo()), len(envelopes))
+	}
+
+	return nil
+}
+
+// loadErasableDBs sets the erasable databases as configured in the state database, if the node is bootstrapped
+func (c *committer) loadErasableDBs() error {
+	if c.erasure == nil {
+		return nil
+	}
+
+	config, _, err := c.db.GetConfig()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get the cluster configuration")
+	}
+	c.erasure.SetErasableDBs(config.GetErasableDbs())
+
+	return nil
+}
+
+// recoverErasureIfNeeded applies again the erasure of the last block committed to the state database, which might
+// have been lost in a crash. Destroying an erasure key again has no effect. If the block store holds later blocks,
+// the erasure was applied before they were committed, and is not applied again, as a later block may have written an
+// erased key with a new erasure key.
+func (b *BlockProcessor) recoverErasureIfNeeded() error {
+	if b.committer.erasure == nil {
+		return nil
+	}
+
+	height, err := b.committer.db.Height()
+	if err != nil || height == 0 {
+		return err
+	}
+	blockStoreHeight, err := b.blockStore.Height()
+	if err != nil || blockStoreHeight != height {
+		return err
+	}
+
+	block, err := b.blockStore.Get(height)
+	if err != nil {
+		return err
+	}
+	if block.GetConfigTxEnvelope() != nil {
+		// the erasable databases are loaded from the state database
+		return nil
+	}
+
+	return b.committer.commitErasure(block)
+}
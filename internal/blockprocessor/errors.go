@@ -0,0 +1,24 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import "fmt"
+
+// StateTrieError is returned when the state trie cannot be updated with the changes of a block in any of the attempts,
+// e.g., due to an I/O error of the state trie store. Nothing of the block was committed, and the state trie was rolled
+// back to the last committed block, hence the commit of the block may be retried.
+type StateTrieError struct {
+	BlockNum uint64
+	Attempts uint32
+	// Err is the error of the last attempt
+	Err error
+}
+
+func (e *StateTrieError) Error() string {
+	return fmt.Sprintf("failed to update the state trie with block %d in %d attempt(s): %s", e.BlockNum, e.Attempts, e.Err)
+}
+
+func (e *StateTrieError) Unwrap() error {
+	return e.Err
+}
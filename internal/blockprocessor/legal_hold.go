@@ -0,0 +1,22 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/legalhold"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// auditLegalHolds logs the legal holds placed and released by a valid database administration transaction. As the
+// log is written when the entries of the transaction are constructed, a block replayed during recovery is logged again.
+func (c *committer) auditLegalHolds(tx *types.DBAdministrationTx) {
+	for _, h := range tx.PlaceLegalHolds {
+		c.logger.Infof("legal hold audit: user [%s] placed a legal hold on %s in transaction [%s] for the reason [%s]",
+			tx.UserId, legalhold.LogTarget(c.logger, h), tx.TxId, h.Reason)
+	}
+	for _, h := range tx.ReleaseLegalHolds {
+		c.logger.Infof("legal hold audit: user [%s] released the legal hold on %s in transaction [%s]",
+			tx.UserId, legalhold.LogTarget(c.logger, h), tx.TxId)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	quarantinedBlockGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "blockprocessor",
+		Name:      "quarantined_block",
+		Help:      "The number of the block the node failed to validate or commit, and quarantined, zero when none is.",
+	}, []string{"node"})
+
+	stateTrieFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "blockprocessor",
+		Name:      "state_trie_failures_total",
+		Help:      "The number of failed attempts to update the state trie with the changes of a block.",
+	}, []string{"node"})
+
+	haltedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "blockprocessor",
+		Name:      "halted",
+		Help:      "One when the node halted the commit of blocks on a failure of the state trie, zero otherwise.",
+	}, []string{"node"})
+
+	txValidationCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "blockprocessor",
+		Name:      "tx_validations_total",
+		Help: "The number of committed transactions, by validation flag, submitting user, and target database. A data " +
+			"transaction is counted once for every pair of its must sign users and databases.",
+	}, []string{"node", "flag", "user", "database"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		quarantinedBlockGauge,
+		stateTrieFailuresCounter,
+		haltedGauge,
+		txValidationCounter,
+	)
+}
+
+// observeValidationFlags counts the validation flags of the transactions of a committed block, by submitting user and
+// target database. The target database of an administration transaction is the system database it updates.
+func observeValidationFlags(nodeID string, block *types.Block) {
+	validationInfo := block.GetHeader().GetValidationInfo()
+	observe := func(txNum int, user, dbName string) {
+		if txNum < len(validationInfo) {
+			txValidationCounter.WithLabelValues(nodeID, validationInfo[txNum].Flag.String(), user, dbName).Inc()
+		}
+	}
+
+	switch block.Payload.(type) {
+	case *types.Block_DataTxEnvelopes:
+		for txNum, txEnv := range block.GetDataTxEnvelopes().GetEnvelopes() {
+			for _, user := range txEnv.GetPayload().GetMustSignUserIds() {
+				for _, ops := range txEnv.GetPayload().GetDbOperations() {
+					observe(txNum, user, ops.GetDbName())
+				}
+			}
+		}
+	case *types.Block_UserAdministrationTxEnvelope:
+		observe(0, block.GetUserAdministrationTxEnvelope().GetPayload().GetUserId(), worldstate.UsersDBName)
+	case *types.Block_DbAdministrationTxEnvelope:
+		observe(0, block.GetDbAdministrationTxEnvelope().GetPayload().GetUserId(), worldstate.DatabasesDBName)
+	case *types.Block_ConfigTxEnvelope:
+		observe(0, block.GetConfigTxEnvelope().GetPayload().GetUserId(), worldstate.ConfigDBName)
+	}
+}
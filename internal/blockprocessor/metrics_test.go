@@ -0,0 +1,97 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockprocessor
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserveValidationFlags(t *testing.T) {
+	count := func(nodeID string, flag types.Flag, user, dbName string) float64 {
+		return testutil.ToFloat64(txValidationCounter.WithLabelValues(nodeID, flag.String(), user, dbName))
+	}
+
+	t.Run("data transactions", func(t *testing.T) {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				ValidationInfo: []*types.ValidationInfo{
+					{Flag: types.Flag_VALID},
+					{Flag: types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE},
+					{Flag: types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE},
+				},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"alice", "bob"},
+								DbOperations:    []*types.DBOperation{{DbName: "db1"}},
+							},
+						},
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"alice"},
+								DbOperations:    []*types.DBOperation{{DbName: "db1"}, {DbName: "db2"}},
+							},
+						},
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"alice"},
+								DbOperations:    []*types.DBOperation{{DbName: "db1"}},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		observeValidationFlags("flags-data", block)
+		require.Equal(t, float64(1), count("flags-data", types.Flag_VALID, "alice", "db1"))
+		require.Equal(t, float64(1), count("flags-data", types.Flag_VALID, "bob", "db1"))
+		require.Equal(t, float64(2), count("flags-data", types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE, "alice", "db1"))
+		require.Equal(t, float64(1), count("flags-data", types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE, "alice", "db2"))
+		require.Equal(t, float64(0), count("flags-data", types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE, "bob", "db1"))
+	})
+
+	t.Run("administration transactions", func(t *testing.T) {
+		newBlock := func(flag types.Flag) *types.Block {
+			return &types.Block{
+				Header: &types.BlockHeader{
+					ValidationInfo: []*types.ValidationInfo{{Flag: flag}},
+				},
+			}
+		}
+
+		userAdminBlock := newBlock(types.Flag_VALID)
+		userAdminBlock.Payload = &types.Block_UserAdministrationTxEnvelope{
+			UserAdministrationTxEnvelope: &types.UserAdministrationTxEnvelope{
+				Payload: &types.UserAdministrationTx{UserId: "admin"},
+			},
+		}
+		dbAdminBlock := newBlock(types.Flag_INVALID_NO_PERMISSION)
+		dbAdminBlock.Payload = &types.Block_DbAdministrationTxEnvelope{
+			DbAdministrationTxEnvelope: &types.DBAdministrationTxEnvelope{
+				Payload: &types.DBAdministrationTx{UserId: "alice"},
+			},
+		}
+		configBlock := newBlock(types.Flag_VALID)
+		configBlock.Payload = &types.Block_ConfigTxEnvelope{
+			ConfigTxEnvelope: &types.ConfigTxEnvelope{
+				Payload: &types.ConfigTx{UserId: "admin"},
+			},
+		}
+
+		for _, block := range []*types.Block{userAdminBlock, dbAdminBlock, configBlock} {
+			observeValidationFlags("flags-admin", block)
+		}
+		require.Equal(t, float64(1), count("flags-admin", types.Flag_VALID, "admin", worldstate.UsersDBName))
+		require.Equal(t, float64(1), count("flags-admin", types.Flag_INVALID_NO_PERMISSION, "alice", worldstate.DatabasesDBName))
+		require.Equal(t, float64(1), count("flags-admin", types.Flag_VALID, "admin", worldstate.ConfigDBName))
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// auditMigration logs the migration applied or reverted by a valid database administration transaction. As the log is
+// written when the entries of the transaction are constructed, a block replayed during recovery is logged again.
+func (c *committer) auditMigration(tx *types.DBAdministrationTx) {
+	m := tx.Migration
+	action := "applied"
+	if m.Direction == types.Migration_DOWN {
+		action = "reverted"
+	}
+	c.logger.Infof("migration audit: user [%s] %s the version [%d] of the migration [%s] in transaction [%s]: %s",
+		tx.UserId, action, m.Version, m.Name, tx.TxId, m.Description)
+}
+
+// addBackfilledIndexEntries adds to the updates of a valid database administration block the index entries of the
+// values stored in the databases whose index is defined by the migration of the block. The index entries are written
+// to the index databases created by the block in the same commit, and as they are derived from the committed values, a
+// block replayed during recovery constructs the same entries. The index databases are not part of the state trie.
+func (c *committer) addBackfilledIndexEntries(block *types.Block, dbsUpdates map[string]*worldstate.DBUpdates) error {
+	dbAdminTxEnv := block.GetDbAdministrationTxEnvelope()
+	if dbAdminTxEnv == nil || block.GetHeader().GetValidationInfo()[dbAdminTxIndex].GetFlag() != types.Flag_VALID {
+		return nil
+	}
+
+	dbNames := dbAdminTxEnv.GetPayload().GetMigration().GetBackfillIndexDbs()
+	if len(dbNames) == 0 {
+		return nil
+	}
+
+	// the index definitions are taken from the updates of the databases database, as the construction of the entries
+	// of a created database consumes its index definition from the transaction
+	definitions := make(map[string][]byte)
+	if updates, ok := dbsUpdates[worldstate.DatabasesDBName]; ok {
+		for _, w := range updates.Writes {
+			definitions[w.Key] = w.Value
+		}
+	}
+
+	for _, dbName := range dbNames {
+		// a database created by the block holds no value
+		if !c.db.Exist(dbName) {
+			continue
+		}
+
+		index := map[string]types.IndexAttributeType{}
+		if err := json.Unmarshal(definitions[dbName], &index); err != nil {
+			return errors.Wrapf(err, "error while unmarshaling the index of database [%s]", dbName)
+		}
+
+		entries, err := stateindex.ConstructIndexEntriesForStoredValues(c.db, dbName, index)
+		if err != nil {
+			return errors.WithMessagef(err, "error while indexing the stored values of database [%s]", dbName)
+		}
+		if entries == nil {
+			continue
+		}
+
+		dbsUpdates[stateindex.IndexDB(dbName)] = entries
+		c.logger.Infof("indexed the stored values of database [%s] with %d index entries in block %d",
+			dbName, len(entries.Writes), block.GetHeader().GetBaseHeader().GetNumber())
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import "time"
+
+// CommitPhase identifies a step the block processor goes through while validating and committing a block
+type CommitPhase string
+
+const (
+	// PhaseValidate covers the validation of the transactions, the skip list links, and the tx merkle tree
+	PhaseValidate CommitPhase = "validate"
+	// PhaseStateTrie covers the construction of the state changes and their application on the state trie
+	PhaseStateTrie CommitPhase = "state_trie"
+	// PhaseBlockStore covers the commit of the block to the block store
+	PhaseBlockStore CommitPhase = "block_store"
+	// PhaseProvenance covers the commit of the block to the provenance store
+	PhaseProvenance CommitPhase = "provenance"
+	// PhaseStateDB covers the accounting of the resource usage of the transactions and of the databases with a quota,
+	// the construction of the index entries, and the commit to the world state database
+	PhaseStateDB CommitPhase = "state_db"
+	// PhaseTrieCommit covers the commit of the state trie changes to the trie store
+	PhaseTrieCommit CommitPhase = "trie_commit"
+	// PhaseOutbox covers the recording of the external side effects of the block in the outbox
+	PhaseOutbox CommitPhase = "outbox"
+)
+
+// CommitPhases lists the commit phases in the order in which the block processor goes through them
+var CommitPhases = []CommitPhase{
+	PhaseValidate,
+	PhaseStateTrie,
+	PhaseBlockStore,
+	PhaseProvenance,
+	PhaseStateDB,
+	PhaseTrieCommit,
+	PhaseOutbox,
+}
+
+// PhaseObserver is notified of the time each commit phase of a block took. ObservePhase is called synchronously by
+// the block processor, hence it must return quickly.
+type PhaseObserver interface {
+	ObservePhase(blockNum uint64, phase CommitPhase, elapsed time.Duration)
+}
+
+func observePhase(observer PhaseObserver, blockNum uint64, phase CommitPhase, start time.Time) {
+	if observer == nil {
+		return
+	}
+
+	observer.ObservePhase(blockNum, phase, time.Since(start))
+}
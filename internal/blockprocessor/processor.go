@@ -4,12 +4,18 @@
 package blockprocessor
 
 import (
+	"bytes"
+	"fmt"
+	"runtime/debug"
 	"sync"
+	"time"
 
-	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie"
 	"github.com/hyperledger-labs/orion-server/internal/mtree"
-	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
 	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
@@ -21,11 +27,16 @@ import (
 
 // BlockProcessor holds block Validator and committer
 type BlockProcessor struct {
+	nodeID               string
 	blockOneQueueBarrier *queue.OneQueueBarrier
-	blockStore           *blockstore.Store
+	blockStore           BlockStore
 	validator            *txvalidation.Validator
 	committer            *committer
 	listeners            *blockCommitListeners
+	phaseObserver        PhaseObserver
+	quarantine           *Quarantine
+	haltLock             sync.RWMutex
+	haltErr              error
 	started              chan struct{}
 	stop                 chan struct{}
 	stopped              chan struct{}
@@ -35,23 +46,90 @@ type BlockProcessor struct {
 // Config holds the configuration information needed to bootstrap the
 // block processor
 type Config struct {
+	// NodeID labels the metrics of the block processor.
+	NodeID               string
 	BlockOneQueueBarrier *queue.OneQueueBarrier
-	BlockStore           *blockstore.Store
+	BlockStore           BlockStore
 	DB                   worldstate.DB
-	ProvenanceStore      *provenance.Store
-	StateTrieStore       mptrie.Store
-	TxValidator          *txvalidation.Validator
-	Logger               *logger.SugarLogger
+	ProvenanceStore      ProvenanceStore
+	StateTrieStore       TrieStore
+	// Outbox, if not nil, records the external side effects of every committed block.
+	Outbox *outbox.Outbox
+	// Erasure, if not nil, holds the erasure keys of the erasable databases, which are destroyed when keys are erased.
+	Erasure *erasure.Store
+	// IdentityCache, if not nil, caches the user and node records read by the identity queriers, and is invalidated
+	// with the records written by every block committed to the state database.
+	IdentityCache *identity.Cache
+	// BlobStore holds the chunks of the blobs written by their manifests. The missing chunks of a block are pulled
+	// from the other nodes by the ChunkPuller set with SetChunkPuller before the block is committed.
+	BlobStore   *blobstore.Store
+	TxValidator *txvalidation.Validator
+	// PhaseObserver, if not nil, is notified of the time each commit phase of a block took.
+	PhaseObserver PhaseObserver
+	// CommitBatching, if enabled, batches the state database writes of consecutive small data blocks, and
+	// group-commits to the block store the data blocks enqueued together.
+	CommitBatching CommitBatchingConfig
+	// StateTrieRetry holds the retries of a failed update of the state trie with the changes of a block. If all
+	// attempts fail, the block processor halts the commit of blocks, and reports the failure by Halted.
+	StateTrieRetry StateTrieRetryConfig
+	// Quarantine, if not nil, quarantines a block that fails to validate or commit, and the block processor halts
+	// the commit of blocks, rather than panicking. If the quarantine holds a block at start, the block processor
+	// halts the commit of blocks without recovering.
+	Quarantine *Quarantine
+	Logger     *logger.SugarLogger
 }
 
+// CommitBatchingConfig holds the batching of the state database writes of consecutive small data blocks. The updates
+// of a small block are applied to the state seen by the point reads of the state database, and are written together
+// with the updates of the following blocks, in a single batch per database. Blocks whose updates are lost in a crash
+// are replayed from the block store during recovery.
+type CommitBatchingConfig struct {
+	// MaxBlocks is the maximal number of blocks whose writes are batched; 0 or 1 disables the batching.
+	MaxBlocks uint32
+	// MaxBlockUpdates is the maximal number of writes and deletes of a block whose writes may be batched; if zero,
+	// DefaultCommitBatchMaxBlockUpdates is used.
+	MaxBlockUpdates uint32
+	// FlushTimeout is the time the block processor waits for the next block before it writes the batched updates; if
+	// zero, DefaultCommitBatchFlushTimeout is used.
+	FlushTimeout time.Duration
+}
+
+// StateTrieRetryConfig holds the retries of the update of the state trie with the changes of a block, which fails,
+// e.g., on an I/O error of the state trie store. The update takes place before anything of the block is committed;
+// before every retry, the changes of the failed attempt are rolled back. If all attempts fail, the block processor
+// halts the commit of blocks on the StateTrieError, without committing the block, which is committed again once the
+// node restarts.
+type StateTrieRetryConfig struct {
+	// MaxAttempts is the maximal number of attempts to update the state trie with a block; if zero,
+	// DefaultStateTrieMaxAttempts is used.
+	MaxAttempts uint32
+	// Backoff is the time to wait before the first retry, which doubles with every retry; if zero,
+	// DefaultStateTrieRetryBackoff is used.
+	Backoff time.Duration
+}
+
+const (
+	// DefaultCommitBatchMaxBlockUpdates is the default maximal number of writes and deletes of a batched block
+	DefaultCommitBatchMaxBlockUpdates = 1000
+	// DefaultCommitBatchFlushTimeout is the default time to wait for the next block before writing the batched updates
+	DefaultCommitBatchFlushTimeout = 50 * time.Millisecond
+	// DefaultStateTrieMaxAttempts is the default maximal number of attempts to update the state trie with a block
+	DefaultStateTrieMaxAttempts = 3
+	// DefaultStateTrieRetryBackoff is the default time to wait before the first retry of an update of the state trie
+	DefaultStateTrieRetryBackoff = 100 * time.Millisecond
+)
+
 // New creates a ValidatorAndCommitter
 func New(conf *Config) *BlockProcessor {
 	return &BlockProcessor{
+		nodeID:               conf.NodeID,
 		blockOneQueueBarrier: conf.BlockOneQueueBarrier,
 		blockStore:           conf.BlockStore,
 		validator:            conf.TxValidator,
 		committer:            newCommitter(conf),
 		listeners:            newBlockCommitListeners(conf.Logger),
+		phaseObserver:        conf.PhaseObserver,
+		quarantine:           conf.Quarantine,
 		started:              make(chan struct{}),
 		stop:                 make(chan struct{}),
 		stopped:              make(chan struct{}),
@@ -74,6 +152,22 @@ func (b *BlockProcessor) Start() {
 	b.logger.Debug("starting the block processor")
 	defer close(b.stopped)
 
+	if b.quarantine != nil && b.quarantine.Block() != nil {
+		b.logger.Errorf("block [%d] is quarantined, the commit of blocks is halted",
+			b.quarantine.Block().GetBlock().GetHeader().GetBaseHeader().GetNumber())
+		close(b.started)
+		b.waitTillStop()
+		return
+	}
+
+	if err := b.committer.loadErasableDBs(); err != nil {
+		panic(errors.WithMessage(err, "error while loading the erasable databases"))
+	}
+
+	if err := b.recoverErasureIfNeeded(); err != nil {
+		panic(errors.WithMessage(err, "error while recovering the erasure of keys"))
+	}
+
 	if err := b.recoverWorldStateDBIfNeeded(); err != nil {
 		panic(errors.WithMessage(err, "error while recovering node"))
 	}
@@ -82,6 +176,10 @@ func (b *BlockProcessor) Start() {
 		panic(errors.WithMessage(err, "error while recovering node state trie"))
 	}
 
+	if err := b.recoverOutboxIfNeeded(); err != nil {
+		panic(errors.WithMessage(err, "error while recovering the outbox"))
+	}
+
 	b.logger.Debug("block processor has been started successfully")
 	close(b.started)
 	for {
@@ -93,21 +191,38 @@ func (b *BlockProcessor) Start() {
 		default:
 			// The replication layer go-routine that enqueued the block will be blocked until after commit; it must be
 			// released by calling OneQueueBarrier.Reply().
-			blockData, err := b.blockOneQueueBarrier.Dequeue()
+			blockData, halted, err := b.dequeueBlockOrHalt()
+			if halted {
+				b.waitTillStop()
+				return
+			}
 			if err != nil {
 				// when the queue is closed during the teardown/cleanup
 				b.logger.Debugf("OneQueueBarrier error: %s", err)
 				continue
 			}
-			block := blockData.(*types.Block)
+			// The replication layer enqueues either a single block, or a group of consecutive data blocks, whose
+			// block store writes are group-committed.
+			var blocks []*types.Block
+			switch data := blockData.(type) {
+			case *types.Block:
+				blocks = []*types.Block{data}
+			case []*types.Block:
+				blocks = data
+			}
 
-			if err = b.validateAndCommit(block); err != nil {
-				panic(err)
+			if halted := b.validateAndCommitBlocksOrHalt(blocks); halted {
+				// The replication layer go-routine that enqueued the blocks is not released, so that no further
+				// blocks are enqueued.
+				b.waitTillStop()
+				return
 			}
 
 			// Detect config changes that affect the replication component and return an appropriate non-nil object
-			// to instruct it to reconfigure itself. Only valid config transactions are passed on.
+			// to instruct it to reconfigure itself. Only valid config transactions are passed on. A config block is
+			// never enqueued in a group.
 			var reConfig interface{}
+			block := blocks[len(blocks)-1]
 			switch block.Payload.(type) {
 			case *types.Block_ConfigTxEnvelope:
 				if validInfo := block.GetHeader().GetValidationInfo(); (len(validInfo) != 0) && (validInfo[0].Flag == types.Flag_VALID) {
@@ -125,43 +240,170 @@ func (b *BlockProcessor) Start() {
 				continue
 			}
 
-			if err = b.listeners.invoke(block); err != nil {
-				panic(err)
+			for _, block := range blocks {
+				if err = b.listeners.invoke(block); err != nil {
+					panic(err)
+				}
 			}
 		}
 	}
 }
 
+// dequeueBlockOrHalt waits for the next block. While the state database writes of earlier blocks are batched, it waits
+// at most the flush timeout, and writes the batched updates if no block arrives, so that they are not held back while
+// the node is idle. The commit of blocks is halted if the batched updates cannot be written, and it returns whether it
+// is halted.
+func (b *BlockProcessor) dequeueBlockOrHalt() (blockData interface{}, halted bool, err error) {
+	if b.committer.db.DeferredBlocks() > 0 {
+		blockData, err = b.blockOneQueueBarrier.DequeueWithWaitLimit(b.committer.commitBatching.FlushTimeout)
+		if err != nil || blockData != nil {
+			return blockData, false, err
+		}
+
+		if err = b.committer.db.FlushDeferred(); err != nil {
+			b.halt(errors.WithMessage(err, "error while writing the batched state database updates"))
+			return nil, true, nil
+		}
+	}
+
+	blockData, err = b.blockOneQueueBarrier.Dequeue()
+	return blockData, false, err
+}
+
 func (b *BlockProcessor) validateAndCommit(block *types.Block) error {
-	b.logger.Debugf("validating and committing block %d", block.GetHeader().GetBaseHeader().GetNumber())
-	validationInfo, err := b.validator.ValidateBlock(block)
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	b.logger.Debugf("validating and committing block %d", blockNum)
+	start := time.Now()
+
+	var err error
+	erased := len(block.GetSealedTxHashes()) > 0
+	if erased {
+		// the values erased from a block that the node catches up with cannot be validated again, hence the block is
+		// committed with the validation info it was committed with by the node that served it
+		err = checkErasedBlock(block)
+	} else {
+		var validationInfo []*types.ValidationInfo
+		if validationInfo, err = b.validator.ValidateBlock(block); err == nil {
+			block.Header.ValidationInfo = validationInfo
+		}
+	}
 	if err != nil {
-		if block.GetHeader().GetBaseHeader().GetNumber() > 1 {
+		if blockNum > 1 {
 			panic(err)
 		}
 		return err
 	}
 
-	block.Header.ValidationInfo = validationInfo
-
 	if err = b.blockStore.AddSkipListLinks(block); err != nil {
 		panic(err)
 	}
 
+	// the transactions whose values were erased are hashed as they were committed, by their sealed hashes
 	root, err := mtree.BuildTreeForBlockTx(block)
 	if err != nil {
 		panic(err)
 	}
+	if erased && !bytes.Equal(root.Hash(), block.GetHeader().GetTxMerkelTreeRootHash()) {
+		panic(errors.Errorf("the tx Merkle tree root of block [%d] does not match the hashes of its transactions", blockNum))
+	}
 	block.Header.TxMerkelTreeRootHash = root.Hash()
+	observePhase(b.phaseObserver, blockNum, PhaseValidate, start)
 
 	if err = b.committer.commitBlock(block); err != nil {
+		// nothing of the block was committed on a failure of the state trie, hence the commit can be halted
+		if _, ok := err.(*StateTrieError); ok {
+			return err
+		}
 		panic(err)
 	}
+	observeValidationFlags(b.nodeID, block)
 
-	b.logger.Debugf("validated and committed block %d\n", block.GetHeader().GetBaseHeader().GetNumber())
+	b.logger.Debugf("validated and committed block %d\n", blockNum)
 	return err
 }
 
+// validateAndCommitBlocksOrHalt validates and commits the blocks one after the other, and returns whether the commit
+// of blocks is halted. The block store writes of the data blocks are deferred by the committer while commit batching
+// is enabled, and the blocks are written to the block store together once they are all committed, before the
+// replication layer is released. Once the commit of blocks is halted, the blocks committed before the failure are
+// still written to the block store.
+func (b *BlockProcessor) validateAndCommitBlocksOrHalt(blocks []*types.Block) (halted bool) {
+	for _, block := range blocks {
+		if halted = b.validateAndCommitOrHalt(block); halted {
+			break
+		}
+	}
+
+	if err := b.committer.flushDeferredBlocks(); err != nil {
+		if halted {
+			b.logger.Errorf("failed to write the deferred blocks once the commit of blocks is halted: %s", err)
+			return true
+		}
+		b.halt(err)
+		return true
+	}
+
+	return halted
+}
+
+// validateAndCommitOrHalt validates and commits a block, and returns whether the commit of blocks is halted. It is
+// halted on a StateTrieError, and on any other failure if a quarantine is configured, in which case the block is
+// quarantined. Otherwise, a failure panics.
+func (b *BlockProcessor) validateAndCommitOrHalt(block *types.Block) (halted bool) {
+	if b.quarantine == nil {
+		if err := b.validateAndCommit(block); err != nil {
+			if _, ok := err.(*StateTrieError); !ok {
+				panic(err)
+			}
+			b.halt(err)
+			return true
+		}
+		return false
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b.quarantine.add(block, fmt.Sprintf("%v", r), string(debug.Stack()))
+			halted = true
+		}
+	}()
+
+	if err := b.validateAndCommit(block); err != nil {
+		if _, ok := err.(*StateTrieError); ok {
+			b.halt(err)
+			return true
+		}
+		b.quarantine.add(block, err.Error(), fmt.Sprintf("%+v", err))
+		return true
+	}
+	return false
+}
+
+// halt records the error on which the commit of blocks is halted
+func (b *BlockProcessor) halt(err error) {
+	b.logger.Errorf("the commit of blocks is halted until the node restarts: %s", err)
+
+	b.haltLock.Lock()
+	b.haltErr = err
+	b.haltLock.Unlock()
+	haltedGauge.WithLabelValues(b.nodeID).Set(1)
+}
+
+// Halted returns the error on which the commit of blocks was halted, e.g., a StateTrieError, or nil if it is not
+// halted. A quarantined block is reported by the quarantine.
+func (b *BlockProcessor) Halted() error {
+	b.haltLock.RLock()
+	defer b.haltLock.RUnlock()
+
+	return b.haltErr
+}
+
+// waitTillStop waits till the block processor is stopped, while the commit of blocks is halted.
+func (b *BlockProcessor) waitTillStop() {
+	<-b.stop
+	b.logger.Info("stopping block processing")
+}
+
 // WaitTillStart waits till the block processor is started
 func (b *BlockProcessor) WaitTillStart() {
 	<-b.started
@@ -196,18 +438,21 @@ func (b *BlockProcessor) recoverWorldStateDBIfNeeded() error {
 			stateDBHeight,
 			blockStoreHeight,
 		)
-	case blockStoreHeight-stateDBHeight > 1:
+	case blockStoreHeight-stateDBHeight > b.committer.maxStateDBLag():
 		// Note: when we support rollback, the different in height can be more than 1.
-		// For now, a failure can occur before committing the block to the block store or after.
-		// As a result, the height of block store would be at most 1 higher than the state database
-		// height.
+		// For now, a failure can occur before committing the block to the block store or after, and the writes of
+		// the blocks batched by the committer can be lost. As a result, the height of block store would be at most
+		// the number of batched blocks higher than the state database height.
 		return errors.Errorf(
-			"the difference between the height of the block store [%d] and the state database [%d] cannot be greater than 1 block. The node cannot be recovered",
+			"the difference between the height of the block store [%d] and the state database [%d] cannot be greater than %d block(s). The node cannot be recovered",
 			blockStoreHeight,
 			stateDBHeight,
+			b.committer.maxStateDBLag(),
 		)
-	case blockStoreHeight-stateDBHeight == 1:
-		block, err := b.blockStore.Get(blockStoreHeight)
+	}
+
+	for blockNum := stateDBHeight + 1; blockNum <= blockStoreHeight; blockNum++ {
+		block, err := b.blockStore.Get(blockNum)
 		if err != nil {
 			return err
 		}
@@ -215,10 +460,12 @@ func (b *BlockProcessor) recoverWorldStateDBIfNeeded() error {
 		if err != nil {
 			return err
 		}
-		return b.committer.commitToDBs(dbsUpdates, provenanceData, block)
+		if err = b.committer.commitToDBs(dbsUpdates, provenanceData, block); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return b.committer.db.FlushDeferred()
 }
 
 func (b *BlockProcessor) initAndRecoverStateTrieIfNeeded() error {
@@ -256,6 +503,48 @@ func (b *BlockProcessor) initAndRecoverStateTrieIfNeeded() error {
 	return nil
 }
 
+// recoverOutboxIfNeeded records in the outbox the blocks that were committed to the block store but not recorded,
+// e.g., due to a crash after commit. An outbox that never recorded a block starts recording from the next block.
+func (b *BlockProcessor) recoverOutboxIfNeeded() error {
+	if b.committer.outbox == nil {
+		return nil
+	}
+
+	outboxHeight, err := b.committer.outbox.Height()
+	if err != nil {
+		return err
+	}
+	blockStoreHeight, err := b.blockStore.Height()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case outboxHeight == 0:
+		b.logger.Infof("the outbox is empty, recording starts after block [%d]", blockStoreHeight)
+		return nil
+	case outboxHeight > blockStoreHeight:
+		return errors.Errorf(
+			"the height of the outbox [%d] is higher than the height of block store [%d]. The node cannot be recovered",
+			outboxHeight,
+			blockStoreHeight,
+		)
+	}
+
+	for blockNum := outboxHeight + 1; blockNum <= blockStoreHeight; blockNum++ {
+		b.logger.Warnf("block [%d] was not recorded in the outbox, recording it", blockNum)
+		block, err := b.blockStore.Get(blockNum)
+		if err != nil {
+			return err
+		}
+		if err = b.committer.commitToOutbox(block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RegisterBlockCommitListener registers a commit listener with the block processor
 func (b *BlockProcessor) RegisterBlockCommitListener(name string, listener BlockCommitListener) error {
 	return b.listeners.add(name, listener)
@@ -309,30 +598,29 @@ func (l *blockCommitListeners) invoke(block *types.Block) error {
 	return nil
 }
 
-func loadStateTrie(mpTrieStore mptrie.Store, blockStore *blockstore.Store) (uint64, uint64, *mptrie.MPTrie, error) {
+func loadStateTrie(mpTrieStore mptrie.Store, blockStore BlockStore) (uint64, uint64, *mptrie.StateTrie, error) {
 	blockStoreHeight, err := blockStore.Height()
 	if err != nil {
 		return 0, 0, nil, err
 	}
 
 	if blockStoreHeight == 0 {
-		trie, err := mptrie.NewTrie(nil, mpTrieStore)
+		trie, err := mptrie.NewStateTrie(nil, mpTrieStore)
 		return 0, 0, trie, err
 	}
 
 	trieStoreHeight, err := mpTrieStore.Height()
 	if err == leveldb.ErrNotFound {
-		trie, err := mptrie.NewTrie(nil, mpTrieStore)
+		trie, err := mptrie.NewStateTrie(nil, mpTrieStore)
 		return 0, blockStoreHeight, trie, err
 	}
 
+	// The trie store is ahead of the block store if the deferred blocks were lost in a crash, in which case the trie
+	// is loaded at the state root of the last block of the block store, as the nodes of the trie are never removed.
+	// The lost blocks are committed again once they are delivered again by the replication.
 	height := blockStoreHeight
 	if trieStoreHeight < height {
 		height = trieStoreHeight
-	} else if trieStoreHeight > height {
-		// Impossible situation, because commit to block store executed before commit to trie store
-		// this indicate problem with underline database
-		return trieStoreHeight, blockStoreHeight, nil, errors.Errorf("height of block store (%d) is smalled that height of trie store (%d), check underline database", blockStoreHeight, trieStoreHeight)
 	}
 
 	lastTrieBlockHeader, err := blockStore.GetHeader(height)
@@ -340,6 +628,6 @@ func loadStateTrie(mpTrieStore mptrie.Store, blockStore *blockstore.Store) (uint
 		return 0, blockStoreHeight, nil, err
 	}
 
-	trie, err := mptrie.NewTrie(lastTrieBlockHeader.GetStateMerkelTreeRootHash(), mpTrieStore)
+	trie, err := mptrie.NewStateTrie(lastTrieBlockHeader.GetStateMerkelTreeRootHash(), mpTrieStore)
 	return height, blockStoreHeight, trie, err
 }
@@ -0,0 +1,131 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build failpoints
+// +build failpoints
+
+package blockprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/failpoint"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailpointsAndRecovery(t *testing.T) {
+	// restart mimics a node restart by starting the block processor goroutine again
+	restart := func(env *testEnv) {
+		env.blockProcessor.Stop()
+		env.blockProcessor.started = make(chan struct{})
+		env.blockProcessor.stop = make(chan struct{})
+		env.blockProcessor.stopped = make(chan struct{})
+		env.blockProcessor.blockOneQueueBarrier = queue.NewOneQueueBarrier(env.blockProcessor.logger)
+		go env.blockProcessor.Start()
+		env.blockProcessor.WaitTillStart()
+	}
+	newBlock := func(env *testEnv) *types.Block {
+		block := createSampleBlock(2, createSampleTx(t, "dataTx1", []string{"key1"}, [][]byte{[]byte("value-1")}, env.userSigner))
+		block.Header.ValidationInfo = []*types.ValidationInfo{{Flag: types.Flag_VALID}}
+		return block
+	}
+
+	t.Run("crash after the block store commit", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup(false)
+		setup(t, env)
+
+		require.NoError(t, failpoint.Enable(failpoint.AfterBlockStoreCommit, "1*error"))
+		defer failpoint.Disable(failpoint.AfterBlockStoreCommit)
+		require.EqualError(t, env.blockProcessor.committer.commitBlock(newBlock(env)),
+			"failpoint [after-block-store-commit] injected an error")
+
+		blockStoreHeight, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), blockStoreHeight)
+		stateDBHeight, err := env.db.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), stateDBHeight)
+
+		restart(env)
+		defer env.blockProcessor.Stop()
+		require.Eventually(t, func() bool {
+			stateDBHeight, err := env.db.Height()
+			return err == nil && stateDBHeight == 2
+		}, 2*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("trie store error", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup(false)
+		setup(t, env)
+
+		require.NoError(t, failpoint.Enable(failpoint.TrieStoreCommit, "1*error"))
+		defer failpoint.Disable(failpoint.TrieStoreCommit)
+		require.EqualError(t, env.blockProcessor.committer.commitBlock(newBlock(env)),
+			"failpoint [trie-store-commit] injected an error")
+
+		trieStoreHeight, err := env.blockProcessor.committer.stateTrieStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), trieStoreHeight)
+		stateDBHeight, err := env.db.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), stateDBHeight)
+
+		restart(env)
+		defer env.blockProcessor.Stop()
+		trieStoreHeight, err = env.blockProcessor.committer.stateTrieStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), trieStoreHeight)
+	})
+
+	t.Run("transient state trie update error", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup(true)
+		setup(t, env)
+
+		require.NoError(t, failpoint.Enable(failpoint.StateTrieUpdate, "1*error"))
+		defer failpoint.Disable(failpoint.StateTrieUpdate)
+		_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(newBlock(env))
+		require.NoError(t, err)
+
+		blockStoreHeight, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), blockStoreHeight)
+		require.Empty(t, failpoint.List())
+	})
+
+	t.Run("persistent state trie update error halts the commit of blocks", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup(false)
+		setup(t, env)
+
+		require.NoError(t, failpoint.Enable(failpoint.StateTrieUpdate, "error"))
+		defer failpoint.Disable(failpoint.StateTrieUpdate)
+		go env.blockProcessor.blockOneQueueBarrier.EnqueueWait(newBlock(env))
+
+		require.Eventually(t, func() bool {
+			return env.blockProcessor.Halted() != nil
+		}, 5*time.Second, 50*time.Millisecond)
+		require.IsType(t, &StateTrieError{}, env.blockProcessor.Halted())
+
+		blockStoreHeight, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), blockStoreHeight)
+	})
+
+	t.Run("slow provenance commit", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup(false)
+		setup(t, env)
+
+		require.NoError(t, failpoint.Enable(failpoint.ProvenanceCommit, "sleep(200ms)"))
+		defer failpoint.Disable(failpoint.ProvenanceCommit)
+		start := time.Now()
+		require.NoError(t, env.blockProcessor.committer.commitBlock(newBlock(env)))
+		require.True(t, time.Since(start) >= 200*time.Millisecond)
+	})
+}
@@ -10,12 +10,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/blockprocessor/mocks"
 	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie"
 	mptrieStore "github.com/hyperledger-labs/orion-server/internal/mptrie/store"
@@ -30,6 +32,7 @@ import (
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/state"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -41,6 +44,8 @@ type testEnv struct {
 	dbPath              string
 	blockStore          *blockstore.Store
 	blockStorePath      string
+	provenanceStore     provenance.Store
+	erasure             *erasure.Store
 	userID              string
 	userCert            *x509.Certificate
 	userSigner          crypto.Signer
@@ -50,6 +55,10 @@ type testEnv struct {
 }
 
 func newTestEnv(t *testing.T) *testEnv {
+	return newTestEnvWithCommitBatching(t, CommitBatchingConfig{})
+}
+
+func newTestEnvWithCommitBatching(t *testing.T, commitBatching CommitBatchingConfig) *testEnv {
 	c := &logger.Config{
 		Level:         "debug",
 		OutputPath:    []string{"stdout"},
@@ -76,10 +85,24 @@ func newTestEnv(t *testing.T) *testEnv {
 		t.Fatalf("error while creating the leveldb instance, %v", err)
 	}
 
+	erasureStore, err := erasure.Open(
+		&erasure.Config{
+			StoreDir: filepath.Join(dir, "erasure"),
+			Logger:   logger,
+		},
+	)
+	if err != nil {
+		if rmErr := os.RemoveAll(dir); rmErr != nil {
+			t.Errorf("error while removing directory %s, %v", dir, err)
+		}
+		t.Fatalf("error while creating the erasure key store, %v", err)
+	}
+
 	blockStorePath := filepath.Join(dir, "blockstore")
 	blockStore, err := blockstore.Open(
 		&blockstore.Config{
 			StoreDir: blockStorePath,
+			Erasure:  erasureStore,
 			Logger:   logger,
 		},
 	)
@@ -94,6 +117,7 @@ func newTestEnv(t *testing.T) *testEnv {
 	provenanceStore, err := provenance.Open(
 		&provenance.Config{
 			StoreDir: provenanceStorePath,
+			Erasure:  erasureStore,
 			Logger:   logger,
 		},
 	)
@@ -138,7 +162,9 @@ func newTestEnv(t *testing.T) *testEnv {
 		StateTrieStore:       mptrieStore,
 		ProvenanceStore:      provenanceStore,
 		DB:                   db,
+		Erasure:              erasureStore,
 		TxValidator:          txValidator,
+		CommitBatching:       commitBatching,
 		Logger:               logger,
 	})
 
@@ -215,23 +241,29 @@ func newTestEnv(t *testing.T) *testEnv {
 			t.Errorf("failed to close the blockstore, %v", err)
 		}
 
+		if err := erasureStore.Close(); err != nil {
+			t.Errorf("failed to close the erasure key store, %v", err)
+		}
+
 		if err := os.RemoveAll(dir); err != nil {
 			t.Errorf("failed to remove directory %s, %v", dir, err)
 		}
 	}
 
 	env := &testEnv{
-		blockProcessor: b,
-		db:             db,
-		dbPath:         dir,
-		blockStore:     blockStore,
-		blockStorePath: blockStorePath,
-		userID:         "testUser",
-		userCert:       userCert,
-		userSigner:     userSigner,
-		genesisConfig:  genesisConfig,
-		genesisBlock:   genesisBlock,
-		cleanup:        cleanup,
+		blockProcessor:  b,
+		db:              db,
+		dbPath:          dir,
+		blockStore:      blockStore,
+		blockStorePath:  blockStorePath,
+		provenanceStore: provenanceStore,
+		erasure:         erasureStore,
+		userID:          "testUser",
+		userCert:        userCert,
+		userSigner:      userSigner,
+		genesisConfig:   genesisConfig,
+		genesisBlock:    genesisBlock,
+		cleanup:         cleanup,
 	}
 
 	go env.blockProcessor.Start()
@@ -426,8 +458,10 @@ func TestValidatorAndCommitter(t *testing.T) {
 				require.NoError(t, env.blockProcessor.committer.applyBlockOnStateTrie(dbsUpdates))
 				block.Header.StateMerkelTreeRootHash, err = env.blockProcessor.committer.stateTrie.Hash()
 				require.NoError(t, err)
+				block.Header.DbStateRootHashes, err = env.blockProcessor.committer.stateTrie.UpdatedDBRootHashes()
+				require.NoError(t, err)
 			}
-			env.blockProcessor.committer.stateTrie, err = mptrie.NewTrie(stateTrieRootOrg, env.blockProcessor.committer.stateTrieStore)
+			env.blockProcessor.committer.stateTrie, err = mptrie.NewStateTrie(stateTrieRootOrg, env.blockProcessor.committer.stateTrieStore)
 		}
 
 		for _, tt := range testCases {
@@ -442,7 +476,7 @@ func TestValidatorAndCommitter(t *testing.T) {
 				if err != nil {
 					return false
 				}
-				proof, err := env.blockProcessor.committer.stateTrie.GetProof(stateTrieKey, false)
+				proof, err := env.blockProcessor.committer.stateTrie.GetProof(worldstate.DefaultDBName, "key1", false)
 				if err != nil || proof == nil {
 					return false
 				}
@@ -549,7 +583,7 @@ func TestFailureAndRecovery(t *testing.T) {
 
 		dbsUpdates, provenanceData, err := env.blockProcessor.committer.constructDBAndProvenanceEntries(block2)
 		require.NoError(t, err)
-		require.NoError(t, env.blockProcessor.committer.commitToProvenanceStore(2, provenanceData))
+		require.NoError(t, env.blockProcessor.committer.commitToProvenanceStore(2, 0, provenanceData))
 		require.NoError(t, env.blockProcessor.committer.commitToStateDB(2, dbsUpdates))
 
 		blockStoreHeight, err := env.blockStore.Height()
@@ -583,7 +617,7 @@ func TestFailureAndRecovery(t *testing.T) {
 				Flag: types.Flag_VALID,
 			},
 		}
-		require.NoError(t, env.blockProcessor.committer.commitToBlockStore(block2))
+		require.NoError(t, env.blockProcessor.committer.commitToBlockStore(block2, false))
 
 		block3 := createSampleBlock(3, tx[1:])
 		block3.Header.ValidationInfo = []*types.ValidationInfo{
@@ -591,7 +625,7 @@ func TestFailureAndRecovery(t *testing.T) {
 				Flag: types.Flag_VALID,
 			},
 		}
-		require.NoError(t, env.blockProcessor.committer.commitToBlockStore(block3))
+		require.NoError(t, env.blockProcessor.committer.commitToBlockStore(block3, false))
 
 		blockStoreHeight, err := env.blockStore.Height()
 		require.NoError(t, err)
@@ -610,10 +644,418 @@ func TestFailureAndRecovery(t *testing.T) {
 		assertPanic := func() {
 			env.blockProcessor.Start()
 		}
-		require.PanicsWithError(t, "error while recovering node: the difference between the height of the block store [3] and the state database [1] cannot be greater than 1 block. The node cannot be recovered", assertPanic)
+		require.PanicsWithError(t, "error while recovering node: the difference between the height of the block store [3] and the state database [1] cannot be greater than 1 block(s). The node cannot be recovered", assertPanic)
 	})
 }
 
+func TestCommitBatching(t *testing.T) {
+	assertCommittedValue := func(t *testing.T, env *testEnv, key string, value []byte, blockNum uint64) {
+		val, metadata, err := env.db.Get(worldstate.DefaultDBName, key)
+		require.NoError(t, err)
+		require.Equal(t, value, val)
+		require.True(t, proto.Equal(&types.Version{BlockNum: blockNum}, metadata.GetVersion()))
+	}
+
+	t.Run("small blocks are batched until the batch is full", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: time.Hour})
+		defer env.cleanup(true)
+
+		setup(t, env)
+
+		expectedDeferredBlocks := []int{1, 2, 0}
+		for i, expectedDeferred := range expectedDeferredBlocks {
+			blockNum := uint64(i + 2)
+			key := fmt.Sprintf("key%d", blockNum)
+			block := createSampleBlock(blockNum, createSampleTx(t, fmt.Sprintf("dataTx%d", blockNum), []string{key}, [][]byte{[]byte("value")}, env.userSigner))
+			_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(block)
+			require.NoError(t, err)
+
+			require.Equal(t, expectedDeferred, env.db.DeferredBlocks())
+			height, err := env.db.Height()
+			require.NoError(t, err)
+			require.Equal(t, blockNum, height)
+			assertCommittedValue(t, env, key, []byte("value"), blockNum)
+		}
+	})
+
+	t.Run("a large block is not batched", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, MaxBlockUpdates: 1, FlushTimeout: time.Hour})
+		defer env.cleanup(true)
+
+		setup(t, env)
+
+		block2 := createSampleBlock(2, createSampleTx(t, "dataTx2", []string{"key1"}, [][]byte{[]byte("value")}, env.userSigner))
+		_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(block2)
+		require.NoError(t, err)
+		require.Equal(t, 1, env.db.DeferredBlocks())
+
+		tx := createSampleTx(t, "dataTx3", []string{"key2", "key3"}, [][]byte{[]byte("value"), []byte("value")}, env.userSigner)
+		block3 := createSampleBlock(3, tx)
+		block3.Header.ValidationInfo = append(block3.Header.ValidationInfo, &types.ValidationInfo{Flag: types.Flag_VALID})
+		_, err = env.blockProcessor.blockOneQueueBarrier.EnqueueWait(block3)
+		require.NoError(t, err)
+		require.Equal(t, 0, env.db.DeferredBlocks())
+		assertCommittedValue(t, env, "key1", []byte("value"), 2)
+		assertCommittedValue(t, env, "key2", []byte("value"), 3)
+	})
+
+	t.Run("batched updates are written when no block arrives", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: 10 * time.Millisecond})
+		defer env.cleanup(true)
+
+		setup(t, env)
+
+		block2 := createSampleBlock(2, createSampleTx(t, "dataTx2", []string{"key1"}, [][]byte{[]byte("value")}, env.userSigner))
+		_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(block2)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return env.db.DeferredBlocks() == 0 }, 2*time.Second, 10*time.Millisecond)
+		assertCommittedValue(t, env, "key1", []byte("value"), 2)
+	})
+
+	t.Run("a failure to write the batched updates halts the commit of blocks", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: 10 * time.Millisecond})
+		defer env.cleanup(true)
+
+		setup(t, env)
+
+		env.db.SetBeforeFlushDeferred(func() error {
+			return errors.New("disk is full")
+		})
+		block2 := createSampleBlock(2, createSampleTx(t, "dataTx2", []string{"key1"}, [][]byte{[]byte("value")}, env.userSigner))
+		_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(block2)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return env.blockProcessor.Halted() != nil }, 2*time.Second, 10*time.Millisecond)
+		require.EqualError(t, env.blockProcessor.Halted(), "error while writing the batched state database updates: error before writing the deferred updates: disk is full")
+		require.Equal(t, 1, env.db.DeferredBlocks())
+	})
+
+	t.Run("a group of blocks is written to the block store together", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: time.Hour})
+		defer env.cleanup(true)
+
+		setup(t, env)
+
+		var blocks []*types.Block
+		for blockNum := uint64(2); blockNum <= 5; blockNum++ {
+			key := fmt.Sprintf("key%d", blockNum)
+			blocks = append(blocks, createSampleBlock(blockNum, createSampleTx(t, fmt.Sprintf("dataTx%d", blockNum), []string{key}, [][]byte{[]byte("value")}, env.userSigner)))
+		}
+		_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(blocks)
+		require.NoError(t, err)
+
+		require.Equal(t, 0, env.blockStore.DeferredBlocks())
+		blockStoreHeight, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), blockStoreHeight)
+		for _, block := range blocks {
+			blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+			assertCommittedValue(t, env, fmt.Sprintf("key%d", blockNum), []byte("value"), blockNum)
+
+			// the skip list links of a block hold the hashes of the blocks written along with it
+			stored, err := env.blockStore.Get(blockNum)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(block, stored))
+			previousHash, err := env.blockStore.GetHash(blockNum - 1)
+			require.NoError(t, err)
+			require.Equal(t, previousHash, stored.GetHeader().GetSkipchainHashes()[0])
+		}
+	})
+
+	t.Run("the deferred blocks are written before the batched updates", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: time.Hour})
+		defer env.cleanup(false)
+
+		setup(t, env)
+
+		// the block is committed as the first block of a group
+		env.blockProcessor.Stop()
+		block := createSampleBlock(2, createSampleTx(t, "dataTx2", []string{"key1"}, [][]byte{[]byte("value")}, env.userSigner))
+		require.NoError(t, env.blockStore.AddSkipListLinks(block))
+		require.NoError(t, env.blockProcessor.committer.commitBlock(block))
+		require.Equal(t, 1, env.blockStore.DeferredBlocks())
+		require.Equal(t, 1, env.db.DeferredBlocks())
+
+		// an iterator writes the batched updates
+		itr, err := env.db.GetIterator(worldstate.DefaultDBName, "", "")
+		require.NoError(t, err)
+		itr.Release()
+		require.Equal(t, 0, env.db.DeferredBlocks())
+		require.Equal(t, 0, env.blockStore.DeferredBlocks())
+		blockStoreHeight, err := env.blockStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), blockStoreHeight)
+	})
+
+	t.Run("the state trie is ahead of the block store by the deferred blocks lost in a crash -- will recover successfully", func(t *testing.T) {
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: time.Hour})
+		defer env.cleanup(false)
+
+		setup(t, env)
+
+		// the block is committed to the state trie but not to the block store and the stateDB, as if the deferred block
+		// was lost in a crash
+		env.blockProcessor.Stop()
+		committer := env.blockProcessor.committer
+		block := createSampleBlock(2, createSampleTx(t, "dataTx2", []string{"key1"}, [][]byte{[]byte("value")}, env.userSigner))
+		dbsUpdates, _, err := committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, committer.applyBlockOnStateTrie(dbsUpdates))
+		expectedStateRoot, err := committer.stateTrie.Hash()
+		require.NoError(t, err)
+		require.NoError(t, committer.commitTrie(2))
+		trieHeight, err := committer.stateTrieStore.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), trieHeight)
+
+		// mimic node restart by starting the block processor goroutine
+		env.blockProcessor.started = make(chan struct{})
+		env.blockProcessor.stop = make(chan struct{})
+		env.blockProcessor.stopped = make(chan struct{})
+		env.blockProcessor.blockOneQueueBarrier = queue.NewOneQueueBarrier(env.blockProcessor.logger)
+		defer env.blockProcessor.Stop()
+		go env.blockProcessor.Start()
+		env.blockProcessor.WaitTillStart()
+
+		// the lost block is delivered again
+		block = createSampleBlock(2, createSampleTx(t, "dataTx2", []string{"key1"}, [][]byte{[]byte("value")}, env.userSigner))
+		_, err = env.blockProcessor.blockOneQueueBarrier.EnqueueWait([]*types.Block{block})
+		require.NoError(t, err)
+		assertCommittedValue(t, env, "key1", []byte("value"), 2)
+
+		header, err := env.blockStore.GetHeader(2)
+		require.NoError(t, err)
+		require.Equal(t, expectedStateRoot, header.GetStateMerkelTreeRootHash())
+	})
+
+	t.Run("blockstore is ahead of stateDB by the batched blocks -- will recover successfully", func(t *testing.T) {
+		env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: time.Hour})
+		defer env.cleanup(false)
+
+		setup(t, env)
+
+		// the blocks are committed to all the stores but the stateDB, as if their batched updates were lost in a crash
+		env.blockProcessor.Stop()
+		committer := env.blockProcessor.committer
+		tx := createSampleTx(t, "dataTx1", []string{"key1", "key1"}, [][]byte{[]byte("value-1"), []byte("value-2")}, env.userSigner)
+		for i, block := range []*types.Block{createSampleBlock(2, tx[:1]), createSampleBlock(3, tx[1:])} {
+			dbsUpdates, _, err := committer.constructDBAndProvenanceEntries(block)
+			require.NoError(t, err)
+			require.NoError(t, committer.applyBlockOnStateTrie(dbsUpdates))
+			block.Header.StateMerkelTreeRootHash, err = committer.stateTrie.Hash()
+			require.NoError(t, err)
+			require.NoError(t, committer.commitToBlockStore(block, false))
+			require.NoError(t, committer.commitTrie(uint64(i+2)))
+		}
+
+		// mimic node restart by starting the block processor goroutine
+		env.blockProcessor.started = make(chan struct{})
+		env.blockProcessor.stop = make(chan struct{})
+		env.blockProcessor.stopped = make(chan struct{})
+		env.blockProcessor.blockOneQueueBarrier = queue.NewOneQueueBarrier(env.blockProcessor.logger)
+		defer env.blockProcessor.Stop()
+		go env.blockProcessor.Start()
+		env.blockProcessor.WaitTillStart()
+
+		require.Equal(t, 0, env.db.DeferredBlocks())
+		stateDBHeight, err := env.db.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), stateDBHeight)
+		assertCommittedValue(t, env, "key1", []byte("value-2"), 3)
+	})
+}
+
+// Scenario: the node crashes after the batched blocks are committed to all the stores but the stateDB. The blocks are
+// replayed on restart, which commits their provenance again, and the provenance is left unchanged.
+func TestRecoveryReplayLeavesProvenanceUnchanged(t *testing.T) {
+	env := newTestEnvWithCommitBatching(t, CommitBatchingConfig{MaxBlocks: 3, FlushTimeout: time.Hour})
+	defer env.cleanup(false)
+	env.genesisConfig.ErasableDbs = []string{worldstate.DefaultDBName}
+	setup(t, env)
+
+	annotatedTx := func(txID, key string, value []byte, order string) []*types.DataTxEnvelope {
+		return []*types.DataTxEnvelope{
+			testutils.SignedDataTxEnvelope(t, []crypto.Signer{env.userSigner}, &types.DataTx{
+				MustSignUserIds: []string{"testUser"},
+				TxId:            txID,
+				DbOperations: []*types.DBOperation{
+					{
+						DbName:     worldstate.DefaultDBName,
+						DataWrites: []*types.DataWrite{{Key: key, Value: value}},
+					},
+				},
+				Annotations: map[string]string{"order": order},
+			}),
+		}
+	}
+
+	_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(createSampleBlock(2, annotatedTx("dataTx2", "key1", []byte("value-1"), "o1")))
+	require.NoError(t, err)
+	require.NoError(t, env.db.FlushDeferred())
+
+	// key1 is written again, and the blocks are committed to all the stores but the stateDB, as if their batched
+	// updates were lost in a crash
+	env.blockProcessor.Stop()
+	committer := env.blockProcessor.committer
+	for _, block := range []*types.Block{
+		createSampleBlock(3, annotatedTx("dataTx3", "key1", []byte("value-2"), "o1")),
+		createSampleBlock(4, annotatedTx("dataTx4", "key2", []byte("value-1"), "o2")),
+		createSampleBlock(5, annotatedTx("dataTx5", "key3", []byte("value-1"), "o1")),
+	} {
+		blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+		dbsUpdates, provenanceData, err := committer.constructDBAndProvenanceEntries(block)
+		require.NoError(t, err)
+		require.NoError(t, committer.commitToProvenanceStore(blockNum, 0, provenanceData))
+		require.NoError(t, committer.applyBlockOnStateTrie(dbsUpdates))
+		block.Header.StateMerkelTreeRootHash, err = committer.stateTrie.Hash()
+		require.NoError(t, err)
+		require.NoError(t, committer.commitToBlockStore(block, false))
+		require.NoError(t, committer.commitTrie(blockNum))
+	}
+
+	type provenanceSnapshot struct {
+		values      map[string][]*types.ValueWithMetadata
+		written     []*provenance.KVWithDBName
+		annotated   map[string][]string
+		annotations map[string]map[string]string
+	}
+	takeSnapshot := func() *provenanceSnapshot {
+		snapshot := &provenanceSnapshot{
+			values:      make(map[string][]*types.ValueWithMetadata),
+			annotated:   make(map[string][]string),
+			annotations: make(map[string]map[string]string),
+		}
+		for _, key := range []string{"key1", "key2", "key3"} {
+			values, err := env.provenanceStore.GetValues(worldstate.DefaultDBName, key)
+			require.NoError(t, err)
+			snapshot.values[key] = values
+		}
+		snapshot.written, err = env.provenanceStore.GetValuesWrittenByUser("testUser")
+		require.NoError(t, err)
+		for _, order := range []string{"o1", "o2"} {
+			txIDs, err := env.provenanceStore.GetTxIDsByAnnotation("order", order, "")
+			require.NoError(t, err)
+			snapshot.annotated[order] = txIDs
+		}
+		for _, txID := range []string{"dataTx2", "dataTx3", "dataTx4", "dataTx5"} {
+			annotations, err := env.provenanceStore.GetTxAnnotations(txID)
+			require.NoError(t, err)
+			snapshot.annotations[txID] = annotations
+		}
+		return snapshot
+	}
+
+	expected := takeSnapshot()
+	require.Len(t, expected.values["key1"], 2)
+	require.Len(t, expected.written, 4)
+	require.ElementsMatch(t, []string{"dataTx2", "dataTx3", "dataTx5"}, expected.annotated["o1"])
+
+	// mimic node restart by starting the block processor goroutine
+	env.blockProcessor.started = make(chan struct{})
+	env.blockProcessor.stop = make(chan struct{})
+	env.blockProcessor.stopped = make(chan struct{})
+	env.blockProcessor.blockOneQueueBarrier = queue.NewOneQueueBarrier(env.blockProcessor.logger)
+	defer env.blockProcessor.Stop()
+	go env.blockProcessor.Start()
+	env.blockProcessor.WaitTillStart()
+
+	stateDBHeight, err := env.db.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), stateDBHeight)
+
+	replayed := takeSnapshot()
+	for key, values := range expected.values {
+		require.ElementsMatch(t, values, replayed.values[key], key)
+	}
+	require.ElementsMatch(t, expected.written, replayed.written)
+	for order, txIDs := range expected.annotated {
+		require.ElementsMatch(t, txIDs, replayed.annotated[order], order)
+	}
+	require.Equal(t, expected.annotations, replayed.annotations)
+}
+
+// Scenario: a node catches up with a block whose value was erased on the node that serves it. The block is committed
+// as it was committed, hence both nodes hold the same chain of blocks and reach the same state roots, and the erased
+// value is held as erased.
+func TestCatchUpWithErasedBlock(t *testing.T) {
+	serving := newTestEnv(t)
+	defer serving.cleanup(true)
+	serving.genesisConfig.ErasableDbs = []string{worldstate.DefaultDBName}
+	setup(t, serving)
+
+	commitBlock := func(env *testEnv, block *types.Block) {
+		_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(block)
+		require.NoError(t, err)
+	}
+
+	// the value of key1 is erased after it is committed, whereas the value of key2 is not, and key1 is written again
+	tx := createSampleTx(t, "dataTx1", []string{"key1", "key2"}, [][]byte{[]byte("value-1"), []byte("value-2")}, serving.userSigner)
+	commitBlock(serving, createSampleBlock(2, tx))
+	require.NoError(t, serving.erasure.Erase([]*types.KeyErasure{{DbName: worldstate.DefaultDBName, Key: "key1"}}))
+	tx = createSampleTx(t, "dataTx2", []string{"key1"}, [][]byte{[]byte("value-3")}, serving.userSigner)
+	commitBlock(serving, createSampleBlock(3, tx))
+
+	erasedBlock, err := serving.blockStore.Get(2)
+	require.NoError(t, err)
+	require.Len(t, erasedBlock.GetSealedTxHashes(), 1)
+	erasedWrite := erasedBlock.GetDataTxEnvelopes().GetEnvelopes()[0].GetPayload().GetDbOperations()[0].GetDataWrites()[0]
+	require.True(t, erasedWrite.GetErased())
+	require.Nil(t, erasedWrite.GetValue())
+	require.NotNil(t, erasedWrite.GetValueHash())
+
+	catchingUp := newTestEnv(t)
+	defer catchingUp.cleanup(true)
+	catchingUp.genesisConfig = serving.genesisConfig
+	catchingUp.genesisBlock = serving.genesisBlock
+	catchingUp.userCert = serving.userCert
+	setup(t, catchingUp)
+
+	for blockNum := uint64(2); blockNum <= 3; blockNum++ {
+		block, err := serving.blockStore.Get(blockNum)
+		require.NoError(t, err)
+		commitBlock(catchingUp, block)
+
+		expectedHeader, err := serving.blockStore.GetHeader(blockNum)
+		require.NoError(t, err)
+		header, err := catchingUp.blockStore.GetHeader(blockNum)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expectedHeader, header))
+	}
+
+	// the block is served in the same form by both nodes
+	block, err := catchingUp.blockStore.Get(2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(erasedBlock, block))
+
+	value, err := catchingUp.provenanceStore.GetValueAt(worldstate.DefaultDBName, "key1", &types.Version{BlockNum: 2, TxNum: 0})
+	require.NoError(t, err)
+	require.Nil(t, value.GetValue())
+	require.NotNil(t, value.GetEncryptedValue())
+	value, err = catchingUp.provenanceStore.GetValueAt(worldstate.DefaultDBName, "key2", &types.Version{BlockNum: 2, TxNum: 1})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-2"), value.GetValue())
+	value, err = catchingUp.provenanceStore.GetValueAt(worldstate.DefaultDBName, "key1", &types.Version{BlockNum: 3, TxNum: 0})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-3"), value.GetValue())
+
+	stateValue, _, err := catchingUp.db.Get(worldstate.DefaultDBName, "key1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-3"), stateValue)
+}
+
 func TestBlockCommitListener(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup(true)
@@ -639,7 +1081,9 @@ func TestBlockCommitListener(t *testing.T) {
 	require.NoError(t, env.blockProcessor.committer.applyBlockOnStateTrie(dbsUpdates))
 	expectedBlock.Header.StateMerkelTreeRootHash, err = env.blockProcessor.committer.stateTrie.Hash()
 	require.NoError(t, err)
-	env.blockProcessor.committer.stateTrie, err = mptrie.NewTrie(stateTrieRootOrg, env.blockProcessor.committer.stateTrieStore)
+	expectedBlock.Header.DbStateRootHashes, err = env.blockProcessor.committer.stateTrie.UpdatedDBRootHashes()
+	require.NoError(t, err)
+	env.blockProcessor.committer.stateTrie, err = mptrie.NewStateTrie(stateTrieRootOrg, env.blockProcessor.committer.stateTrieStore)
 
 	listener1 := &mocks.BlockCommitListener{}
 	listener1.On("PostBlockCommitProcessing", mock.Anything).Return(func(arg mock.Arguments) {
@@ -678,6 +1122,38 @@ func TestBlockCommitListener(t *testing.T) {
 	require.Eventually(t, assertCommittedBlock, 2*time.Second, 100*time.Millisecond)
 }
 
+type phaseObserver struct {
+	lock   sync.Mutex
+	phases []CommitPhase
+}
+
+func (o *phaseObserver) ObservePhase(blockNum uint64, phase CommitPhase, elapsed time.Duration) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.phases = append(o.phases, phase)
+}
+
+func TestPhaseObserver(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup(true)
+
+	setup(t, env)
+
+	// the observer is set after the genesis block has been committed, hence it observes only block 2
+	observer := &phaseObserver{}
+	env.blockProcessor.phaseObserver = observer
+	env.blockProcessor.committer.phaseObserver = observer
+
+	block2 := createSampleBlock(2, createSampleTx(t, "dataTx1", []string{"key1"}, [][]byte{[]byte("value-1")}, env.userSigner))
+	_, err := env.blockProcessor.blockOneQueueBarrier.EnqueueWait(block2)
+	require.NoError(t, err)
+
+	observer.lock.Lock()
+	defer observer.lock.Unlock()
+	require.Equal(t, CommitPhases, observer.phases)
+}
+
 func createSampleBlock(blockNumber uint64, env []*types.DataTxEnvelope) *types.Block {
 	return &types.Block{
 		Header: &types.BlockHeader{
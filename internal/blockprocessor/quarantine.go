@@ -0,0 +1,121 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Quarantine holds the block that the block processor failed to validate or commit, with the diagnostic state of the
+// failure. The block processor quarantines such a block, a.k.a. a poison block, and halts the commit of blocks, rather
+// than panicking, which would crash the node, and crash it again on every restart. The record is persisted, such that
+// a node that restarts with a quarantined block halts the commit of blocks again, without recovering its stores.
+//
+// A quarantined block is released by stopping the node, fixing the cause of the failure, removing the quarantine
+// file, and starting the node again.
+type Quarantine struct {
+	nodeID   string
+	filePath string
+	record   *types.QuarantinedBlock
+	logger   *logger.SugarLogger
+	sync.RWMutex
+}
+
+// NewQuarantine creates a quarantine that persists its record in the given file, and loads the record if the file
+// exists.
+func NewQuarantine(nodeID, filePath string, logger *logger.SugarLogger) (*Quarantine, error) {
+	q := &Quarantine{
+		nodeID:   nodeID,
+		filePath: filePath,
+		logger:   logger,
+	}
+
+	exist, err := fileops.Exists(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while reading the quarantine file [%s]", filePath)
+		}
+		record := &types.QuarantinedBlock{}
+		if err = proto.Unmarshal(content, record); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling the quarantine file [%s]", filePath)
+		}
+		q.record = record
+		logger.Errorf("block [%d] is quarantined since %s, because: %s",
+			record.GetBlock().GetHeader().GetBaseHeader().GetNumber(), time.Unix(0, record.QuarantinedAt), record.Error)
+	}
+	quarantinedBlockGauge.WithLabelValues(nodeID).Set(float64(q.record.GetBlock().GetHeader().GetBaseHeader().GetNumber()))
+
+	return q, nil
+}
+
+// Block returns the diagnostic record of the quarantined block, or nil if no block is quarantined.
+func (q *Quarantine) Block() *types.QuarantinedBlock {
+	q.RLock()
+	defer q.RUnlock()
+
+	return q.record
+}
+
+// add quarantines a block. The block remains quarantined even if the record cannot be persisted, until the node
+// restarts.
+func (q *Quarantine) add(block *types.Block, cause, stack string) {
+	record := &types.QuarantinedBlock{
+		Block:         block,
+		Error:         cause,
+		Stack:         stack,
+		QuarantinedAt: time.Now().UnixNano(),
+	}
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+
+	q.Lock()
+	q.record = record
+	q.Unlock()
+
+	quarantinedBlockGauge.WithLabelValues(q.nodeID).Set(float64(blockNum))
+	q.logger.Errorf("block [%d] is quarantined and the commit of blocks is halted, because: %s\n%s", blockNum, cause, stack)
+
+	if err := q.persist(record); err != nil {
+		q.logger.Errorf("error while persisting the quarantine of block [%d]: %s", blockNum, err)
+	}
+}
+
+// persist replaces the quarantine file atomically, such that a crash leaves either no record or a complete one.
+func (q *Quarantine) persist(record *types.QuarantinedBlock) error {
+	content, err := proto.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "error while marshaling the quarantine record")
+	}
+
+	tmpFilePath := q.filePath + ".tmp"
+	f, err := os.OpenFile(tmpFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating the quarantine file [%s]", tmpFilePath)
+	}
+	if _, err = fileops.Write(f, content); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrapf(err, "error while closing the quarantine file [%s]", tmpFilePath)
+	}
+
+	if err = os.Rename(tmpFilePath, q.filePath); err != nil {
+		return errors.Wrapf(err, "error while replacing the quarantine file [%s]", q.filePath)
+	}
+	return fileops.SyncDir(filepath.Dir(q.filePath))
+}
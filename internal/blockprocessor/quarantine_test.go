@@ -0,0 +1,77 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantine(t *testing.T) {
+	// restart mimics a node restart with a quarantine by starting the block processor goroutine again
+	restart := func(env *testEnv, q *Quarantine) {
+		env.blockProcessor.Stop()
+		env.blockProcessor.quarantine = q
+		env.blockProcessor.started = make(chan struct{})
+		env.blockProcessor.stop = make(chan struct{})
+		env.blockProcessor.stopped = make(chan struct{})
+		env.blockProcessor.blockOneQueueBarrier = queue.NewOneQueueBarrier(env.blockProcessor.logger)
+		go env.blockProcessor.Start()
+		env.blockProcessor.WaitTillStart()
+	}
+	enqueue := func(env *testEnv, block *types.Block) chan error {
+		released := make(chan error, 1)
+		barrier := env.blockProcessor.blockOneQueueBarrier
+		go func() {
+			_, err := barrier.EnqueueWait(block)
+			released <- err
+		}()
+		return released
+	}
+
+	env := newTestEnv(t)
+	defer env.cleanup(true)
+	setup(t, env)
+
+	quarantineFilePath := filepath.Join(env.dbPath, "quarantine")
+	q, err := NewQuarantine("node1", quarantineFilePath, env.blockProcessor.logger)
+	require.NoError(t, err)
+	require.Nil(t, q.Block())
+	restart(env, q)
+
+	// block 5 cannot follow block 1, and fails to commit
+	poisonBlock := createSampleBlock(5, createSampleTx(t, "dataTx1", []string{"key1"}, [][]byte{[]byte("value-1")}, env.userSigner))
+	released := enqueue(env, poisonBlock)
+	require.Eventually(t, func() bool { return q.Block() != nil }, 2*time.Second, 100*time.Millisecond)
+
+	record := q.Block()
+	require.Equal(t, uint64(5), record.GetBlock().GetHeader().GetBaseHeader().GetNumber())
+	require.NotEmpty(t, record.Error)
+	require.NotEmpty(t, record.Stack)
+	require.NotZero(t, record.QuarantinedAt)
+
+	// the commit of blocks is halted, and the go-routine that enqueued the block is not released until the stop
+	require.Never(t, func() bool { return len(released) > 0 }, 500*time.Millisecond, 100*time.Millisecond)
+	height, err := env.blockStore.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), height)
+
+	// the quarantine is persisted, and a restarted block processor halts the commit of blocks
+	reloaded, err := NewQuarantine("node1", quarantineFilePath, env.blockProcessor.logger)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(record, reloaded.Block()))
+	restart(env, reloaded)
+	require.Error(t, <-released)
+
+	released = enqueue(env, createSampleBlock(2, createSampleTx(t, "dataTx2", []string{"key2"}, [][]byte{[]byte("value-2")}, env.userSigner)))
+	require.Never(t, func() bool { return len(released) > 0 }, 500*time.Millisecond, 100*time.Millisecond)
+	height, err = env.blockStore.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), height)
+}
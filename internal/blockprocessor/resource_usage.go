@@ -0,0 +1,84 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// constructTxResourceUsage returns the resources used by each valid data transaction of the block, per database it
+// operates on. The index entries are counted with the index definitions held by the state database before the block
+// is committed, as a data block cannot change an index definition.
+func constructTxResourceUsage(block *types.Block, db worldstate.DB) ([]*types.TxResourceUsage, error) {
+	dataTxEnvs := block.GetDataTxEnvelopes().GetEnvelopes()
+	if len(dataTxEnvs) == 0 {
+		return nil, nil
+	}
+
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	validationInfo := block.GetHeader().GetValidationInfo()
+	indexes := make(map[string]map[string]types.IndexAttributeType)
+
+	var usages []*types.TxResourceUsage
+	for txIndex, txEnv := range dataTxEnvs {
+		if validationInfo[txIndex].GetFlag() != types.Flag_VALID {
+			continue
+		}
+
+		tx := txEnv.GetPayload()
+		usage := &types.TxResourceUsage{
+			TxId:     tx.GetTxId(),
+			BlockNum: blockNum,
+			TxIndex:  uint64(txIndex),
+		}
+		for userID := range txEnv.GetSignatures() {
+			usage.UserIds = append(usage.UserIds, userID)
+		}
+		sort.Strings(usage.UserIds)
+
+		for _, ops := range tx.GetDbOperations() {
+			index, ok := indexes[ops.DbName]
+			if !ok {
+				var err error
+				if index, err = stateindex.Index(db, ops.DbName); err != nil {
+					return nil, errors.WithMessagef(err, "failed to get the index of database %s", ops.DbName)
+				}
+				indexes[ops.DbName] = index
+			}
+
+			usage.DbUsages = append(usage.DbUsages, dbResourceUsage(ops, index))
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+func dbResourceUsage(ops *types.DBOperation, index map[string]types.IndexAttributeType) *types.DBResourceUsage {
+	usage := &types.DBResourceUsage{
+		DbName:      ops.DbName,
+		KeysRead:    uint64(len(ops.DataReads)),
+		KeysWritten: uint64(len(ops.DataWrites) + len(ops.AclWrites)),
+		KeysDeleted: uint64(len(ops.DataDeletes)),
+	}
+
+	for _, w := range ops.DataWrites {
+		usage.BytesWritten += uint64(len(w.Key)+len(w.Value)+proto.Size(w.Acl)) + w.GetBlobManifest().GetSize()
+		if index != nil {
+			usage.IndexEntries += uint64(stateindex.CountIndexEntries(w.Key, w.Value, index))
+		}
+	}
+	for _, w := range ops.AclWrites {
+		usage.BytesWritten += uint64(len(w.Key) + proto.Size(w.NewAcl))
+	}
+
+	return usage
+}
@@ -0,0 +1,176 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitTxResourceUsage(t *testing.T) {
+	t.Parallel()
+
+	env := newCommitterTestEnv(t)
+	defer env.cleanup()
+
+	indexDef, err := json.Marshal(map[string]types.IndexAttributeType{
+		"title": types.IndexAttributeType_STRING,
+		"year":  types.IndexAttributeType_NUMBER,
+	})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1", Value: indexDef},
+				{Key: stateindex.IndexDB("db1")},
+				{Key: "db2"},
+			},
+		},
+	}, 1))
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db2": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte("value1"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}}},
+			},
+		},
+	}, 2))
+
+	acl := &types.AccessControl{ReadWriteUsers: map[string]bool{"alice": true}}
+	book := []byte(`{"title":"book1","year":2015,"bestseller":true}`)
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: 3,
+			},
+			ValidationInfo: []*types.ValidationInfo{
+				{Flag: types.Flag_VALID},
+				{Flag: types.Flag_INVALID_NO_PERMISSION},
+				{Flag: types.Flag_VALID},
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: []*types.DataTxEnvelope{
+					{
+						Payload: &types.DataTx{
+							MustSignUserIds: []string{"bob", "alice"},
+							TxId:            "tx1",
+							DbOperations: []*types.DBOperation{
+								{
+									DbName: "db1",
+									DataWrites: []*types.DataWrite{
+										{Key: "book1", Value: book, Acl: acl},
+										{Key: "note", Value: []byte("not json")},
+									},
+								},
+								{
+									DbName:      "db2",
+									DataReads:   []*types.DataRead{{Key: "key1", Version: &types.Version{BlockNum: 2}}},
+									DataDeletes: []*types.DataDelete{{Key: "key1"}},
+								},
+							},
+						},
+						Signatures: map[string][]byte{"bob": []byte("sig"), "alice": []byte("sig")},
+					},
+					{
+						Payload: &types.DataTx{
+							MustSignUserIds: []string{"alice"},
+							TxId:            "tx2",
+							DbOperations: []*types.DBOperation{
+								{
+									DbName:     "db2",
+									DataWrites: []*types.DataWrite{{Key: "key2", Value: []byte("value2")}},
+								},
+							},
+						},
+						Signatures: map[string][]byte{"alice": []byte("sig")},
+					},
+					{
+						Payload: &types.DataTx{
+							MustSignUserIds: []string{"alice"},
+							TxId:            "tx3",
+							DbOperations: []*types.DBOperation{
+								{
+									DbName:     "db2",
+									DataWrites: []*types.DataWrite{{Key: "key3", Value: []byte("value3")}},
+								},
+							},
+						},
+						Signatures: map[string][]byte{"alice": []byte("sig")},
+					},
+				},
+			},
+		},
+	}
+
+	dbsUpdates, provenanceData, err := env.committer.constructDBAndProvenanceEntries(block)
+	require.NoError(t, err)
+	require.NoError(t, env.committer.commitToDBs(dbsUpdates, provenanceData, block))
+
+	expected := []*types.TxResourceUsage{
+		{
+			TxId:     "tx1",
+			BlockNum: 3,
+			UserIds:  []string{"alice", "bob"},
+			DbUsages: []*types.DBResourceUsage{
+				{
+					DbName:       "db1",
+					BytesWritten: uint64(len("book1") + len(book) + proto.Size(acl) + len("note") + len("not json")),
+					KeysWritten:  2,
+					IndexEntries: 2,
+				},
+				{
+					DbName:      "db2",
+					KeysRead:    1,
+					KeysDeleted: 1,
+				},
+			},
+		},
+		{
+			TxId:     "tx3",
+			BlockNum: 3,
+			TxIndex:  2,
+			UserIds:  []string{"alice"},
+			DbUsages: []*types.DBResourceUsage{
+				{
+					DbName:       "db2",
+					BytesWritten: uint64(len("key3") + len("value3")),
+					KeysWritten:  1,
+				},
+			},
+		},
+	}
+	for _, expectedUsage := range expected {
+		usage, err := env.blockStore.GetTxResourceUsage(expectedUsage.TxId)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expectedUsage, usage), "expected: %v, actual: %v", expectedUsage, usage)
+	}
+
+	// an invalid transaction uses no resources
+	usage, err := env.blockStore.GetTxResourceUsage("tx2")
+	require.EqualError(t, err, "resource usage of TxID not found: tx2")
+	require.Nil(t, usage)
+}
+
+func TestDBResourceUsageOfAclWrites(t *testing.T) {
+	t.Parallel()
+
+	acl := &types.AccessControl{ReadUsers: map[string]bool{"alice": true}}
+	usage := dbResourceUsage(&types.DBOperation{
+		DbName:    "db1",
+		AclWrites: []*types.AclWrite{{Key: "key1", NewAcl: acl}},
+	}, nil)
+
+	require.True(t, proto.Equal(&types.DBResourceUsage{
+		DbName:       "db1",
+		BytesWritten: uint64(len("key1") + proto.Size(acl)),
+		KeysWritten:  1,
+	}, usage))
+}
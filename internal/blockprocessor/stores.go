@@ -0,0 +1,54 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockprocessor
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/mptrie"
+	"github.com/hyperledger-labs/orion-server/internal/provenance"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+//go:generate mockery --dir . --name BlockStore --case underscore --output mocks/
+
+// BlockStore is the store the block processor commits the blocks to, and from which it recovers the other stores. It
+// is implemented by *blockstore.Store.
+type BlockStore interface {
+	// Height returns the number of the last committed block
+	Height() (uint64, error)
+	// Get returns the committed block with the given number
+	Get(blockNumber uint64) (*types.Block, error)
+	// GetHeader returns the header of the committed block with the given number
+	GetHeader(blockNumber uint64) (*types.BlockHeader, error)
+	// AddSkipListLinks adds the skip list links to the header of the block that is committed next
+	AddSkipListLinks(block *types.Block) error
+	// Commit commits the next block, after the deferred blocks
+	Commit(block *types.Block) error
+	// CommitDeferred defers writing the next block until FlushDeferred or the next Commit is called
+	CommitDeferred(block *types.Block) error
+	// FlushDeferred writes the deferred blocks, in a single write that is synced once
+	FlushDeferred() error
+	// CommitTxResourceUsage records the resources used by the valid data transactions of a block
+	CommitTxResourceUsage(usages []*types.TxResourceUsage) error
+	// GetDBUsages returns the usage of every database, as of the last committed block
+	GetDBUsages() (map[string]*types.DBUsage, error)
+	// CommitDBUsage records the usages of the databases updated by a block, and the quota alerts the block raised
+	CommitDBUsage(blockNum uint64, usages []*types.DBUsage, removed []string, alerts []*types.QuotaAlert) error
+}
+
+//go:generate mockery --dir . --name ProvenanceStore --case underscore --output mocks/
+
+// ProvenanceStore is the store the block processor commits the provenance data of the blocks to. It is implemented by
+// every provenance.Store.
+type ProvenanceStore interface {
+	// Commit commits the provenance data of the transactions of a block
+	Commit(blockNum uint64, blockTime int64, txsData []*provenance.TxDataForProvenance) error
+}
+
+//go:generate mockery --dir . --name TrieStore --case underscore --output mocks/
+
+// TrieStore is the store of the nodes of the state trie. It is implemented by *store.Store of the mptrie/store
+// package.
+type TrieStore interface {
+	mptrie.Store
+}
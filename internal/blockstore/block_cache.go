@@ -0,0 +1,58 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// blockCache holds the last committed blocks in a ring buffer, so that the reads of the recent blocks, which are most
+// of the reads, do not read and decode the block files. A block is cached as it is stored, i.e., marshaled and with
+// the erasable values sealed, hence a cached block is unsealed on every read, exactly like a block read from a file,
+// and the values erased after the block was cached are not returned. A nil cache caches no block.
+type blockCache struct {
+	numbers []uint64
+	blocks  [][]byte
+}
+
+func newBlockCache(size uint32) *blockCache {
+	if size == 0 {
+		return nil
+	}
+
+	return &blockCache{
+		numbers: make([]uint64, size),
+		blocks:  make([][]byte, size),
+	}
+}
+
+// put caches a committed block, evicting the block committed size blocks before it
+func (c *blockCache) put(number uint64, block []byte) {
+	if c == nil {
+		return
+	}
+
+	slot := number % uint64(len(c.blocks))
+	c.numbers[slot] = number
+	c.blocks[slot] = block
+}
+
+// get returns a cached block, or nil if the block is not cached
+func (c *blockCache) get(number uint64) (*types.Block, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	slot := number % uint64(len(c.blocks))
+	if c.blocks[slot] == nil || c.numbers[slot] != number {
+		return nil, nil
+	}
+
+	block := &types.Block{}
+	if err := proto.Unmarshal(c.blocks[slot], block); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the cached block %d", number)
+	}
+	return block, nil
+}
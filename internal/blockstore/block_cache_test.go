@@ -0,0 +1,101 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("last committed blocks are cached", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup(true)
+		env.s.blockCache = newBlockCache(3)
+
+		var blocks []*types.Block
+		for blockNumber := uint64(1); blockNumber <= 5; blockNumber++ {
+			blocks = append(blocks, createSampleDataTxBlock(blockNumber, nil, nil, 2))
+		}
+		for _, block := range blocks {
+			require.NoError(t, env.s.Commit(block))
+		}
+
+		for _, expectedBlock := range blocks {
+			blockNumber := expectedBlock.GetHeader().GetBaseHeader().GetNumber()
+			cached, err := env.s.blockCache.get(blockNumber)
+			require.NoError(t, err)
+			if blockNumber <= 2 {
+				require.Nil(t, cached)
+			} else {
+				require.True(t, proto.Equal(expectedBlock, cached))
+			}
+
+			block, err := env.s.Get(blockNumber)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expectedBlock, block))
+		}
+
+		// a returned block is a copy of the cached block
+		block, err := env.s.Get(5)
+		require.NoError(t, err)
+		block.GetDataTxEnvelopes().Envelopes[0].Payload.TxId = "modified"
+		block, err = env.s.Get(5)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(blocks[4], block))
+	})
+
+	t.Run("erased values are not served from the cache", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup(true)
+		env.s.blockCache = newBlockCache(3)
+
+		erasureDir, err := ioutil.TempDir("", "erasure")
+		require.NoError(t, err)
+		defer os.RemoveAll(erasureDir)
+
+		erasureStore, err := erasure.Open(&erasure.Config{StoreDir: erasureDir, Logger: env.s.logger})
+		require.NoError(t, err)
+		defer erasureStore.Close()
+		erasureStore.SetErasableDBs([]string{"db1"})
+		env.s.erasure = erasureStore
+
+		block := createSampleDataTxBlock(1, nil, nil, 1)
+		block.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations = []*types.DBOperation{
+			{
+				DbName:     "db1",
+				DataWrites: []*types.DataWrite{{Key: "key1", Value: []byte("value1")}},
+			},
+		}
+		require.NoError(t, env.s.Commit(block))
+
+		stored, err := env.s.Get(1)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(block, stored))
+
+		require.NoError(t, erasureStore.Erase([]*types.KeyErasure{{DbName: "db1", Key: "key1"}}))
+
+		stored, err = env.s.Get(1)
+		require.NoError(t, err)
+		erased := stored.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations[0].DataWrites[0]
+		require.Empty(t, erased.Value)
+		require.True(t, erased.Erased)
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		c := newBlockCache(0)
+		require.Nil(t, c)
+		c.put(1, []byte("block"))
+		block, err := c.get(1)
+		require.NoError(t, err)
+		require.Nil(t, block)
+	})
+}
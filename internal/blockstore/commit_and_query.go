@@ -27,7 +27,8 @@ const (
 	nonDataTxIndex = 0
 )
 
-// Commit commits the block to the block store
+// Commit commits the block to the block store. The blocks deferred by CommitDeferred are written first, together
+// with the block.
 func (s *Store) Commit(block *types.Block) error {
 	if block == nil {
 		return errors.New("block cannot be nil")
@@ -36,36 +37,10 @@ func (s *Store) Commit(block *types.Block) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	blockNumber := block.GetHeader().GetBaseHeader().GetNumber()
-	if blockNumber != s.lastCommittedBlockNum+1 {
-		return errors.Errorf(
-			"expected block number [%d] but received [%d]",
-			s.lastCommittedBlockNum+1,
-			blockNumber,
-		)
-	}
-
-	b, err := proto.Marshal(block)
-	if err != nil {
-		return errors.Wrapf(err, "error while marshaling block, %v", block)
-	}
-
-	encodedBlock := snappy.Encode(nil, b)
-	n := binary.PutUvarint(s.reusableBuffer, uint64(len(encodedBlock)))
-	content := append(s.reusableBuffer[:n], encodedBlock...)
-
-	if !s.canCurrentFileChunkHold(len(content)) {
-		if err := s.moveToNextFileChunk(); err != nil {
-			return err
-		}
-	}
-
-	blockLocation, err := s.appendBlock(blockNumber, content)
-	if err != nil {
+	if err := s.deferBlock(block); err != nil {
 		return err
 	}
-
-	return s.storeMetadataInDB(block, blockLocation)
+	return s.flushDeferred()
 }
 
 func (s *Store) canCurrentFileChunkHold(toBeAddedBytesLength int) bool {
@@ -115,31 +90,36 @@ func (s *Store) appendBlock(number uint64, content []byte) (*BlockLocation, erro
 	)
 }
 
-func (s *Store) storeMetadataInDB(block *types.Block, location *BlockLocation) error {
-	// we can commit to metadata DBs in any order. If the node fails, partial update to
-	// metadata DBs is recovered by the recovery logic implemented in recover() when the
-	// the node is restarted.
+func (s *Store) storeMetadataInDB(blocks []*types.Block, locations []*BlockLocation) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	// we can commit to the header, validation info, and tx location DBs in any order, but the
+	// index is committed last, so that an indexed block always has all its metadata. If the node
+	// fails, the blocks that are not indexed are recovered by the recovery logic implemented
+	// in recover() when the node is restarted.
 	var wg sync.WaitGroup
 	errC := make(chan error, 3)
 	wg.Add(3)
 
 	go func() {
 		defer wg.Done()
-		if err := s.storeIndexForBlock(block.Header.BaseHeader.Number, location); err != nil {
+		if err := s.storeBlocksValidationInfo(blocks); err != nil {
 			errC <- err
 		}
 	}()
 
 	go func() {
 		defer wg.Done()
-		if err := s.storeBlockValidationInfo(block); err != nil {
+		if err := s.storeBlocksHeaders(blocks); err != nil {
 			errC <- err
 		}
 	}()
 
 	go func() {
 		defer wg.Done()
-		if err := s.storeBlockHeaders(block); err != nil {
+		if err := s.storeBlocksTxLocations(blocks); err != nil {
 			errC <- err
 		}
 	}()
@@ -150,31 +130,31 @@ func (s *Store) storeMetadataInDB(block *types.Block, location *BlockLocation) e
 	case err := <-errC:
 		return err
 	default:
-		return nil
 	}
+
+	return s.storeIndexForBlocks(blocks, locations)
 }
 
-func (s *Store) storeIndexForBlock(number uint64, location *BlockLocation) error {
-	value, err := proto.Marshal(location)
-	if err != nil {
-		return errors.Wrap(err, "error while marshaling BlockLocation")
+func (s *Store) storeIndexForBlocks(blocks []*types.Block, locations []*BlockLocation) error {
+	batch := &leveldb.Batch{}
+	for i, block := range blocks {
+		value, err := proto.Marshal(locations[i])
+		if err != nil {
+			return errors.Wrap(err, "error while marshaling BlockLocation")
+		}
+
+		batch.Put(encodeOrderPreservingVarUint64(block.GetHeader().GetBaseHeader().GetNumber()), value)
 	}
 
-	return s.blockIndexDB.Put(
-		encodeOrderPreservingVarUint64(number),
-		value,
-		&opt.WriteOptions{
-			Sync: true,
-		},
-	)
+	return s.blockIndexDB.Write(batch, &opt.WriteOptions{Sync: true})
 }
 
-// AddSkipListLinks calculated and add skip list block number to the block
+// AddSkipListLinks calculated and add skip list block number to the block. The linked blocks may be deferred blocks.
 func (s *Store) AddSkipListLinks(block *types.Block) error {
 	skipListHashes := make([][]byte, 0)
 
 	for _, linkedBlockNum := range CalculateSkipListLinks(block.Header.GetBaseHeader().GetNumber()) {
-		hash, err := s.GetHash(linkedBlockNum)
+		hash, err := s.getLinkedHash(linkedBlockNum)
 		if err != nil {
 			return err
 		}
@@ -184,6 +164,18 @@ func (s *Store) AddSkipListLinks(block *types.Block) error {
 	return nil
 }
 
+// getLinkedHash returns the hash of a committed block, or of a block whose write is deferred
+func (s *Store) getLinkedHash(blockNumber uint64) ([]byte, error) {
+	s.mu.RLock()
+	hash, deferred, err := s.deferredHash(blockNumber)
+	s.mu.RUnlock()
+	if deferred || err != nil {
+		return hash, err
+	}
+
+	return s.GetHash(blockNumber)
+}
+
 func skipListHeight(blockNum uint64) uint64 {
 	if blockNum%SkipListBase != 0 {
 		return 1
@@ -204,14 +196,24 @@ func CalculateSkipListLinks(blockNum uint64) []uint64 {
 	return links
 }
 
-func (s *Store) storeBlockValidationInfo(block *types.Block) error {
+func (s *Store) storeBlocksValidationInfo(blocks []*types.Block) error {
+	updateBatch := &leveldb.Batch{}
+	for _, block := range blocks {
+		if err := addBlockValidationInfo(updateBatch, block); err != nil {
+			return err
+		}
+	}
+
+	return s.txValidationInfoDB.Write(updateBatch, &opt.WriteOptions{Sync: true})
+}
+
+func addBlockValidationInfo(updateBatch *leveldb.Batch, block *types.Block) error {
 	blockNum := block.Header.BaseHeader.Number
 	var txID string
 
 	switch block.Payload.(type) {
 	case *types.Block_DataTxEnvelopes:
 		dataTxs := block.GetDataTxEnvelopes().Envelopes
-		updateBatch := &leveldb.Batch{}
 
 		for txNum, tx := range dataTxs {
 			key := []byte(tx.Payload.TxId)
@@ -223,7 +225,7 @@ func (s *Store) storeBlockValidationInfo(block *types.Block) error {
 			updateBatch.Put(key, value)
 		}
 
-		return s.txValidationInfoDB.Write(updateBatch, &opt.WriteOptions{Sync: true})
+		return nil
 
 	case *types.Block_ConfigTxEnvelope:
 		txID = block.GetConfigTxEnvelope().Payload.TxId
@@ -244,10 +246,60 @@ func (s *Store) storeBlockValidationInfo(block *types.Block) error {
 		return errors.Wrapf(err, "error while marshaling validation info of non-data transaction in block %d", blockNum)
 	}
 
-	return s.txValidationInfoDB.Put(key, value, &opt.WriteOptions{Sync: true})
+	updateBatch.Put(key, value)
+	return nil
+}
+
+func (s *Store) storeBlocksTxLocations(blocks []*types.Block) error {
+	batch := &leveldb.Batch{}
+	for _, block := range blocks {
+		if err := addBlockTxLocations(batch, block); err != nil {
+			return err
+		}
+	}
+
+	if err := s.txLocationDB.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return err
+	}
+
+	// the index is complete up to the last of the blocks only if it was complete up to the block that precedes them;
+	// otherwise, the blocks in between are indexed when the store is opened
+	if blocks[0].GetHeader().GetBaseHeader().GetNumber() > s.lastTxLocationsIndexed+1 {
+		return nil
+	}
+	return s.setLastTxLocationsIndexed(blocks[len(blocks)-1].GetHeader().GetBaseHeader().GetNumber())
+}
+
+func addBlockTxLocations(batch *leveldb.Batch, block *types.Block) error {
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	txIDs, err := utils.BlockPayloadToTxIDs(block.GetPayload())
+	if err != nil {
+		return errors.Wrapf(err, "can't access block tx ids {%d, %v}", blockNum, block)
+	}
+
+	for txIndex, txID := range txIDs {
+		value, err := proto.Marshal(&TxLocation{BlockNum: blockNum, TxIndex: uint64(txIndex)})
+		if err != nil {
+			return errors.Wrapf(err, "error while marshaling the location of transaction %d in block %d", txIndex, blockNum)
+		}
+		batch.Put([]byte(txID), value)
+	}
+
+	return nil
+}
+
+func (s *Store) storeBlocksHeaders(blocks []*types.Block) error {
+	batch := &leveldb.Batch{}
+	for _, block := range blocks {
+		if err := addBlockHeaders(batch, block); err != nil {
+			return err
+		}
+	}
+
+	return s.blockHeaderDB.Write(batch, &opt.WriteOptions{Sync: true})
 }
 
-func (s *Store) storeBlockHeaders(block *types.Block) error {
+func addBlockHeaders(batch *leveldb.Batch, block *types.Block) error {
 	header := block.GetHeader()
 	number := header.GetBaseHeader().GetNumber()
 	blockHeaderBaseBytes, err := proto.Marshal(header.GetBaseHeader())
@@ -280,14 +332,13 @@ func (s *Store) storeBlockHeaders(block *types.Block) error {
 		return errors.Wrapf(err, "can't marshal block txs ids {%d, %v}", number, blockTxsID)
 	}
 
-	batch := &leveldb.Batch{}
 	batch.Put(constructHeaderBaseHashKey(number), blockHeaderBaseHash)
 	batch.Put(constructHeaderHashKey(number), blockHash)
 	batch.Put(constructHeaderBytesKey(number), blockHeaderBytes)
 	batch.Put(constructHeaderHashIndexKey(blockHash), encodeOrderPreservingVarUint64(number))
 	batch.Put(constructBlockTxsIDKey(number), txsIdBytes)
 
-	return s.blockHeaderDB.Write(batch, &opt.WriteOptions{Sync: true})
+	return nil
 }
 
 // Height returns the height of the block store, i.e., the last committed block number
@@ -298,7 +349,8 @@ func (s *Store) Height() (uint64, error) {
 	return s.lastCommittedBlockNum, nil
 }
 
-// Get returns the requested block
+// Get returns the requested block. The values of the keys that were erased since they were written are returned
+// sealed.
 func (s *Store) Get(blockNumber uint64) (*types.Block, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -315,6 +367,17 @@ func (s *Store) Get(blockNumber uint64) (*types.Block, error) {
 		}
 	}
 
+	block, err := s.blockCache.get(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block != nil {
+		if err := s.unsealBlock(block); err != nil {
+			return nil, errors.WithMessagef(err, "error while unsealing the erasable values of block %d", blockNumber)
+		}
+		return block, nil
+	}
+
 	location, err := s.getLocation(blockNumber)
 	if err != nil {
 		return nil, err
@@ -341,7 +404,15 @@ func (s *Store) Get(blockNumber uint64) (*types.Block, error) {
 		}()
 	}
 
-	return readBlockFromFile(f, location.Offset)
+	block, err = readBlockFromFile(f, location.Offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.unsealBlock(block); err != nil {
+		return nil, errors.WithMessagef(err, "error while unsealing the erasable values of block %d", blockNumber)
+	}
+
+	return block, nil
 }
 
 // GetHeader returns block header by block number, operation should be faster that regular Get,
@@ -496,6 +567,72 @@ func (s *Store) GetValidationInfo(txID string) (*types.ValidationInfo, error) {
 	return valInfo, nil
 }
 
+// GetTxLocation returns the number of the block that holds the transaction with the given txID, and the index of the
+// transaction in the block
+func (s *Store) GetTxLocation(txID string) (*TxLocation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	val, err := s.txLocationDB.Get([]byte(txID), &opt.ReadOptions{})
+	if err == leveldb.ErrNotFound {
+		return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("TxID not found: %s", txID)}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while fetching the location of txID [%s] from the block store", txID)
+	}
+
+	txLocation := &TxLocation{}
+	if err := proto.Unmarshal(val, txLocation); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshalling the stored location of txID [%s]", txID)
+	}
+
+	return txLocation, nil
+}
+
+// CommitTxResourceUsage stores the resource usage of the transactions of a block, which is computed when the block is
+// committed to the state database. The usage of a transaction is stored again if the block is committed again during
+// recovery.
+func (s *Store) CommitTxResourceUsage(usages []*types.TxResourceUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	batch := &leveldb.Batch{}
+	for _, usage := range usages {
+		value, err := proto.Marshal(usage)
+		if err != nil {
+			return errors.Wrapf(err, "error while marshaling the resource usage of transaction [%s]", usage.TxId)
+		}
+		batch.Put([]byte(usage.TxId), value)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.txUsageDB.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+// GetTxResourceUsage returns the resource usage of the transaction with the given txID
+func (s *Store) GetTxResourceUsage(txID string) (*types.TxResourceUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	val, err := s.txUsageDB.Get([]byte(txID), &opt.ReadOptions{})
+	if err == leveldb.ErrNotFound {
+		return nil, &interrors.NotFoundErr{Message: fmt.Sprintf("resource usage of TxID not found: %s", txID)}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while fetching the resource usage of txID [%s] from the block store", txID)
+	}
+
+	usage := &types.TxResourceUsage{}
+	if err := proto.Unmarshal(val, usage); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshalling the stored resource usage of txID [%s]", txID)
+	}
+
+	return usage, nil
+}
+
 func (s *Store) getLocation(blockNumber uint64) (*BlockLocation, error) {
 	val, err := s.blockIndexDB.Get(encodeOrderPreservingVarUint64(blockNumber), nil)
 	if err == leveldb.ErrNotFound {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -258,6 +259,118 @@ func TestCommitAndQuery(t *testing.T) {
 	})
 }
 
+func TestGetTxLocation(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup(false)
+
+	var prevBlockBaseHash, prevBlockHash []byte
+	for blockNumber := uint64(1); blockNumber <= 5; blockNumber++ {
+		var b *types.Block
+		if blockNumber == 3 {
+			b = createSampleUserTxBlock(blockNumber, prevBlockBaseHash, prevBlockHash)
+		} else {
+			b = createSampleDataTxBlock(blockNumber, prevBlockBaseHash, prevBlockHash, 3)
+		}
+		require.NoError(t, env.s.Commit(b))
+
+		var err error
+		prevBlockBaseHash, err = env.s.GetBaseHeaderHash(blockNumber)
+		require.NoError(t, err)
+		prevBlockHash, err = env.s.GetHash(blockNumber)
+		require.NoError(t, err)
+	}
+
+	assertTxLocations := func() {
+		for _, blockNumber := range []uint64{1, 2, 4, 5} {
+			for txIndex := uint64(0); txIndex < 3; txIndex++ {
+				txLocation, err := env.s.GetTxLocation(fmt.Sprintf("tx-%d-%d", blockNumber, txIndex))
+				require.NoError(t, err)
+				require.True(t, proto.Equal(&TxLocation{BlockNum: blockNumber, TxIndex: txIndex}, txLocation))
+			}
+		}
+
+		txLocation, err := env.s.GetTxLocation("txid-3")
+		require.NoError(t, err)
+		require.True(t, proto.Equal(&TxLocation{BlockNum: 3}, txLocation))
+
+		txLocation, err = env.s.GetTxLocation("tx-6-0")
+		require.EqualError(t, err, "TxID not found: tx-6-0")
+		require.IsType(t, &errors.NotFoundErr{}, err)
+		require.Nil(t, txLocation)
+	}
+
+	assertTxLocations()
+
+	// a store without the index, i.e., created before the transaction locations were indexed, builds it when opened
+	require.NoError(t, env.s.Close())
+	require.NoError(t, os.RemoveAll(filepath.Join(env.storeDir, txLocationDBName)))
+	store, err := Open(&Config{StoreDir: env.storeDir, Logger: env.s.logger})
+	require.NoError(t, err)
+	env.s = store
+	assertTxLocations()
+	require.Equal(t, uint64(5), store.lastTxLocationsIndexed)
+
+	// building the index is resumed from the last block indexed, when it was interrupted
+	require.NoError(t, store.setLastTxLocationsIndexed(3))
+	for _, blockNumber := range []uint64{4, 5} {
+		for txIndex := uint64(0); txIndex < 3; txIndex++ {
+			require.NoError(t, store.txLocationDB.Delete([]byte(fmt.Sprintf("tx-%d-%d", blockNumber, txIndex)), nil))
+		}
+	}
+	require.NoError(t, store.Close())
+	store, err = Open(&Config{StoreDir: env.storeDir, Logger: env.s.logger})
+	require.NoError(t, err)
+	env.s = store
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	assertTxLocations()
+	require.Equal(t, uint64(5), store.lastTxLocationsIndexed)
+}
+
+func TestTxResourceUsage(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup(false)
+
+	usages := []*types.TxResourceUsage{
+		{
+			TxId:     "tx1",
+			BlockNum: 2,
+			UserIds:  []string{"alice", "bob"},
+			DbUsages: []*types.DBResourceUsage{
+				{DbName: "db1", BytesWritten: 10, KeysRead: 1, KeysWritten: 2, IndexEntries: 3},
+				{DbName: "db2", KeysDeleted: 1},
+			},
+		},
+		{
+			TxId:     "tx2",
+			BlockNum: 2,
+			TxIndex:  2,
+			UserIds:  []string{"alice"},
+		},
+	}
+	require.NoError(t, env.s.CommitTxResourceUsage(usages))
+	require.NoError(t, env.s.CommitTxResourceUsage(nil))
+
+	for _, expected := range usages {
+		usage, err := env.s.GetTxResourceUsage(expected.TxId)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, usage))
+	}
+
+	// the usage of a block committed again during recovery is overwritten
+	require.NoError(t, env.s.CommitTxResourceUsage(usages[1:]))
+	usage, err := env.s.GetTxResourceUsage("tx2")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(usages[1], usage))
+
+	usage, err = env.s.GetTxResourceUsage("tx3")
+	require.EqualError(t, err, "resource usage of TxID not found: tx3")
+	require.IsType(t, &errors.NotFoundErr{}, err)
+	require.Nil(t, usage)
+}
+
 func TestTxValidationInfo(t *testing.T) {
 	t.Parallel()
 
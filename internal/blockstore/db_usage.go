@@ -0,0 +1,116 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// CommitDBUsage stores the usage of the databases with a quota, as accounted up to the given block, together with the
+// quota alerts raised by the block, in a single write. The usage of the removed databases, i.e., of the databases
+// that were deleted or whose quota was removed, is deleted.
+func (s *Store) CommitDBUsage(blockNum uint64, usages []*types.DBUsage, removed []string, alerts []*types.QuotaAlert) error {
+	batch := &leveldb.Batch{}
+	for _, usage := range usages {
+		value, err := proto.Marshal(usage)
+		if err != nil {
+			return errors.Wrapf(err, "error while marshaling the usage of database [%s]", usage.DbName)
+		}
+		batch.Put(dbUsageKey(usage.DbName), value)
+	}
+
+	for _, dbName := range removed {
+		batch.Delete(dbUsageKey(dbName))
+	}
+
+	for i, alert := range alerts {
+		value, err := proto.Marshal(alert)
+		if err != nil {
+			return errors.Wrapf(err, "error while marshaling a quota alert of database [%s]", alert.DbName)
+		}
+		batch.Put(append(quotaAlertsPrefix(blockNum), encodeOrderPreservingVarUint64(uint64(i))...), value)
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.dbUsageDB.Write(batch, &opt.WriteOptions{Sync: true})
+}
+
+// GetDBUsage returns the usage of a database with a quota, or nil if its usage is not accounted
+func (s *Store) GetDBUsage(dbName string) (*types.DBUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	val, err := s.dbUsageDB.Get(dbUsageKey(dbName), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while fetching the usage of database [%s] from the block store", dbName)
+	}
+
+	usage := &types.DBUsage{}
+	if err := proto.Unmarshal(val, usage); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshalling the stored usage of database [%s]", dbName)
+	}
+
+	return usage, nil
+}
+
+// GetDBUsages returns the usage of all the databases whose usage is accounted, by database name
+func (s *Store) GetDBUsages() (map[string]*types.DBUsage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	itr := s.dbUsageDB.NewIterator(util.BytesPrefix(dbUsageNs), nil)
+	defer itr.Release()
+
+	usages := make(map[string]*types.DBUsage)
+	for itr.Next() {
+		usage := &types.DBUsage{}
+		if err := proto.Unmarshal(itr.Value(), usage); err != nil {
+			return nil, errors.Wrap(err, "error while unmarshalling the stored usage of a database")
+		}
+		usages[usage.DbName] = usage
+	}
+
+	return usages, errors.Wrap(itr.Error(), "error while reading the usage of the databases")
+}
+
+// GetQuotaAlerts returns the quota alerts raised by the given block, if any
+func (s *Store) GetQuotaAlerts(blockNum uint64) ([]*types.QuotaAlert, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	itr := s.dbUsageDB.NewIterator(util.BytesPrefix(quotaAlertsPrefix(blockNum)), nil)
+	defer itr.Release()
+
+	var alerts []*types.QuotaAlert
+	for itr.Next() {
+		alert := &types.QuotaAlert{}
+		if err := proto.Unmarshal(itr.Value(), alert); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshalling a stored quota alert of block [%d]", blockNum)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, errors.Wrapf(itr.Error(), "error while reading the quota alerts of block [%d]", blockNum)
+}
+
+func dbUsageKey(dbName string) []byte {
+	return append(append([]byte{}, dbUsageNs...), dbName...)
+}
+
+func quotaAlertsPrefix(blockNum uint64) []byte {
+	return append(append([]byte{}, quotaAlertsNs...), encodeOrderPreservingVarUint64(blockNum)...)
+}
@@ -0,0 +1,67 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBUsage(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup(false)
+
+	usage, err := env.s.GetDBUsage("db1")
+	require.NoError(t, err)
+	require.Nil(t, usage)
+	usages, err := env.s.GetDBUsages()
+	require.NoError(t, err)
+	require.Empty(t, usages)
+
+	db1Usage := &types.DBUsage{DbName: "db1", BlockNum: 2, StorageBytes: 100, MinuteStart: 60, MinuteTxs: 3}
+	db2Usage := &types.DBUsage{DbName: "db2", BlockNum: 2, StorageBytes: 10}
+	alerts := []*types.QuotaAlert{
+		{DbName: "db1", BlockNum: 2, Resource: types.QuotaAlert_STORAGE, Usage: 100, SoftLimit: 90, HardLimit: 120},
+		{DbName: "db1", BlockNum: 2, Resource: types.QuotaAlert_TX_RATE, Usage: 3, SoftLimit: 3},
+	}
+	require.NoError(t, env.s.CommitDBUsage(2, []*types.DBUsage{db1Usage, db2Usage}, nil, alerts))
+
+	usage, err = env.s.GetDBUsage("db1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(db1Usage, usage))
+
+	usages, err = env.s.GetDBUsages()
+	require.NoError(t, err)
+	require.Len(t, usages, 2)
+	require.True(t, proto.Equal(db1Usage, usages["db1"]))
+	require.True(t, proto.Equal(db2Usage, usages["db2"]))
+
+	storedAlerts, err := env.s.GetQuotaAlerts(2)
+	require.NoError(t, err)
+	require.Len(t, storedAlerts, 2)
+	for i := range alerts {
+		require.True(t, proto.Equal(alerts[i], storedAlerts[i]))
+	}
+	storedAlerts, err = env.s.GetQuotaAlerts(3)
+	require.NoError(t, err)
+	require.Empty(t, storedAlerts)
+
+	// the usage of a removed database is deleted
+	db1Usage = &types.DBUsage{DbName: "db1", BlockNum: 3, StorageBytes: 80, MinuteStart: 60, MinuteTxs: 4}
+	require.NoError(t, env.s.CommitDBUsage(3, []*types.DBUsage{db1Usage}, []string{"db2"}, nil))
+
+	usage, err = env.s.GetDBUsage("db2")
+	require.NoError(t, err)
+	require.Nil(t, usage)
+	usages, err = env.s.GetDBUsages()
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	require.True(t, proto.Equal(db1Usage, usages["db1"]))
+
+	storedAlerts, err = env.s.GetQuotaAlerts(2)
+	require.NoError(t, err)
+	require.Len(t, storedAlerts, 2)
+}
@@ -0,0 +1,187 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockstore
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// deferredBlock is a block committed with CommitDeferred, which is sealed and encoded as it is appended to the file
+// chunks, but is not yet written
+type deferredBlock struct {
+	block *types.Block
+	// marshaled holds the marshaled sealed block, which is cached once it is written
+	marshaled []byte
+	// content holds the encoded block, prefixed by its length
+	content []byte
+}
+
+// CommitDeferred defers writing the block until FlushDeferred or the next Commit is called, so that several
+// consecutive blocks are group-committed: they are appended to the file chunk in a single write, which is synced
+// once, and their metadata is written in a single batch per metadata database. A deferred block is neither counted by
+// Height nor returned by the queries until it is written; only its hash is used by AddSkipListLinks of the following
+// blocks.
+//
+// Each block remains atomic, as it is appended with its length to the file chunk. If the node fails while writing
+// the deferred blocks, a partially appended block is truncated, and the fully appended blocks that are not indexed are
+// indexed again during recovery.
+func (s *Store) CommitDeferred(block *types.Block) error {
+	if block == nil {
+		return errors.New("block cannot be nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.deferBlock(block)
+}
+
+// FlushDeferred writes the deferred blocks to the block store
+func (s *Store) FlushDeferred() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flushDeferred()
+}
+
+// DeferredBlocks returns the number of blocks whose write is deferred
+func (s *Store) DeferredBlocks() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.deferred)
+}
+
+// deferBlock must be called while holding mu
+func (s *Store) deferBlock(block *types.Block) error {
+	blockNumber := block.GetHeader().GetBaseHeader().GetNumber()
+	expectedBlockNumber := s.lastCommittedBlockNum + uint64(len(s.deferred)) + 1
+	if blockNumber != expectedBlockNumber {
+		return errors.Errorf(
+			"expected block number [%d] but received [%d]",
+			expectedBlockNumber,
+			blockNumber,
+		)
+	}
+
+	stored, err := s.sealBlock(block)
+	if err != nil {
+		return errors.WithMessagef(err, "error while sealing the erasable values of block %d", blockNumber)
+	}
+	b, err := proto.Marshal(stored)
+	if err != nil {
+		return errors.Wrapf(err, "error while marshaling block, %v", block)
+	}
+
+	encodedBlock := snappy.Encode(nil, b)
+	n := binary.PutUvarint(s.reusableBuffer, uint64(len(encodedBlock)))
+	content := make([]byte, 0, n+len(encodedBlock))
+	content = append(content, s.reusableBuffer[:n]...)
+	content = append(content, encodedBlock...)
+
+	s.deferred = append(s.deferred, &deferredBlock{
+		block:     block,
+		marshaled: b,
+		content:   content,
+	})
+	return nil
+}
+
+// flushDeferred must be called while holding mu. The deferred blocks that fit in the current file chunk are written
+// together, and the rest are written to the next file chunk.
+func (s *Store) flushDeferred() error {
+	for len(s.deferred) > 0 {
+		n := s.deferredBlocksFittingCurrentFileChunk()
+		if n == 0 {
+			if err := s.moveToNextFileChunk(); err != nil {
+				return err
+			}
+			if n = s.deferredBlocksFittingCurrentFileChunk(); n == 0 {
+				// a block larger than a file chunk is written alone to a file chunk
+				n = 1
+			}
+		}
+
+		if err := s.writeBlocks(s.deferred[:n]); err != nil {
+			return err
+		}
+		s.deferred = s.deferred[n:]
+	}
+
+	s.deferred = nil
+	return nil
+}
+
+func (s *Store) deferredBlocksFittingCurrentFileChunk() int {
+	size := 0
+	for i, d := range s.deferred {
+		size += len(d.content)
+		if !s.canCurrentFileChunkHold(size) {
+			return i
+		}
+	}
+
+	return len(s.deferred)
+}
+
+// writeBlocks appends the blocks to the current file chunk in a single write, syncs the file chunk, and then stores
+// the metadata of the blocks. The blocks are cached once their metadata is stored, as the cache must not serve a block
+// that the store does not hold.
+func (s *Store) writeBlocks(blocks []*deferredBlock) error {
+	size := 0
+	for _, d := range blocks {
+		size += len(d.content)
+	}
+	content := make([]byte, 0, size)
+	for _, d := range blocks {
+		content = append(content, d.content...)
+	}
+
+	lastBlockNumber := blocks[len(blocks)-1].block.GetHeader().GetBaseHeader().GetNumber()
+	location, err := s.appendBlock(lastBlockNumber, content)
+	if err != nil {
+		return err
+	}
+	if err = s.currentFileChunk.Sync(); err != nil {
+		return errors.Wrapf(err, "error while syncing the file chunk [%s]", s.currentFileChunk.Name())
+	}
+
+	committed := make([]*types.Block, len(blocks))
+	locations := make([]*BlockLocation, len(blocks))
+	offset := location.Offset
+	for i, d := range blocks {
+		committed[i] = d.block
+		locations[i] = &BlockLocation{
+			FileChunkNum: location.FileChunkNum,
+			Offset:       offset,
+			Length:       int64(len(d.content)),
+		}
+		offset += int64(len(d.content))
+	}
+
+	if err = s.storeMetadataInDB(committed, locations); err != nil {
+		return err
+	}
+	for _, d := range blocks {
+		s.blockCache.put(d.block.GetHeader().GetBaseHeader().GetNumber(), d.marshaled)
+	}
+
+	return nil
+}
+
+// deferredHash returns the hash of the deferred block with the given number, if its write is deferred. It must be
+// called while holding mu.
+func (s *Store) deferredHash(blockNumber uint64) ([]byte, bool, error) {
+	if blockNumber <= s.lastCommittedBlockNum || blockNumber > s.lastCommittedBlockNum+uint64(len(s.deferred)) {
+		return nil, false, nil
+	}
+
+	hash, err := ComputeBlockHash(s.deferred[blockNumber-s.lastCommittedBlockNum-1].block)
+	return hash, true, err
+}
@@ -0,0 +1,111 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package blockstore
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitDeferred(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup(false)
+
+	var blocks []*types.Block
+	for blockNumber := uint64(1); blockNumber <= 20; blockNumber++ {
+		blocks = append(blocks, createSampleDataTxBlock(blockNumber, nil, nil, 20))
+	}
+
+	require.NoError(t, env.s.AddSkipListLinks(blocks[0]))
+	require.NoError(t, env.s.Commit(blocks[0]))
+
+	// the deferred blocks are linked by the following blocks, but are not yet held by the store
+	for _, block := range blocks[1:6] {
+		require.NoError(t, env.s.AddSkipListLinks(block))
+		require.NoError(t, env.s.CommitDeferred(block))
+	}
+	require.EqualError(t, env.s.CommitDeferred(blocks[7]), "expected block number [7] but received [8]")
+	require.EqualError(t, env.s.CommitDeferred(nil), "block cannot be nil")
+	require.Equal(t, 5, env.s.DeferredBlocks())
+	deferredHash, err := ComputeBlockHash(blocks[4])
+	require.NoError(t, err)
+	require.Equal(t, deferredHash, blocks[5].GetHeader().GetSkipchainHashes()[0])
+
+	height, err := env.s.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), height)
+	_, err = env.s.Get(2)
+	require.IsType(t, &errors.NotFoundErr{}, err)
+	_, err = env.s.GetHash(2)
+	require.IsType(t, &errors.NotFoundErr{}, err)
+
+	require.NoError(t, env.s.FlushDeferred())
+	require.Equal(t, 0, env.s.DeferredBlocks())
+
+	// the deferred blocks are written along with the committed block, across the file chunks they fill
+	for _, block := range blocks[6:19] {
+		require.NoError(t, env.s.AddSkipListLinks(block))
+		require.NoError(t, env.s.CommitDeferred(block))
+	}
+	require.NoError(t, env.s.AddSkipListLinks(blocks[19]))
+	require.NoError(t, env.s.Commit(blocks[19]))
+	require.Equal(t, 0, env.s.DeferredBlocks())
+	require.Greater(t, env.s.currentChunkNum, uint64(0))
+
+	assertBlocks := func() {
+		height, err := env.s.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(20), height)
+
+		for _, expectedBlock := range blocks {
+			blockNumber := expectedBlock.GetHeader().GetBaseHeader().GetNumber()
+			block, err := env.s.Get(blockNumber)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expectedBlock, block))
+
+			blockHeaderBytes, err := proto.Marshal(expectedBlock.GetHeader())
+			require.NoError(t, err)
+			expectedHash, err := crypto.ComputeSHA256Hash(blockHeaderBytes)
+			require.NoError(t, err)
+			hash, err := env.s.GetHash(blockNumber)
+			require.NoError(t, err)
+			require.Equal(t, expectedHash, hash)
+
+			for txNum, tx := range expectedBlock.GetDataTxEnvelopes().GetEnvelopes() {
+				valInfo, err := env.s.GetValidationInfo(tx.Payload.TxId)
+				require.NoError(t, err)
+				require.True(t, proto.Equal(expectedBlock.GetHeader().GetValidationInfo()[txNum], valInfo))
+			}
+		}
+	}
+
+	assertBlocks()
+	env.closeAndReOpenStore(t)
+	defer env.cleanup(true)
+	assertBlocks()
+}
+
+func TestCloseWritesDeferredBlocks(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup(false)
+
+	blocks := []*types.Block{createSampleDataTxBlock(1, nil, nil, 2), createSampleDataTxBlock(2, nil, nil, 2)}
+	require.NoError(t, env.s.Commit(blocks[0]))
+	require.NoError(t, env.s.CommitDeferred(blocks[1]))
+
+	env.closeAndReOpenStore(t)
+	defer env.cleanup(true)
+
+	height, err := env.s.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), height)
+	block, err := env.s.Get(2)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(blocks[1], block))
+}
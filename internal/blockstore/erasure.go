@@ -0,0 +1,131 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/mtree"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// sealBlock returns the block as it is stored, i.e., with the values written to the erasable databases sealed by the
+// erasure keys of their keys, along with the hashes of the values and of the transactions that write them. The block
+// hash is not affected, as it covers the block header only. A block committed from another node after keys were
+// erased holds the erased writes as they are served, and they are stored as is. The given block is not modified; if
+// no value is sealed, it is returned as is.
+func (s *Store) sealBlock(block *types.Block) (*types.Block, error) {
+	sealed := block
+	clone := func() {
+		if sealed == block {
+			sealed = proto.Clone(block).(*types.Block)
+			// the hashes of the erased transactions are kept by the loop below
+			sealed.SealedTxHashes = nil
+		}
+	}
+
+	var txHashes [][]byte
+	for txIndex, txEnv := range block.GetDataTxEnvelopes().GetEnvelopes() {
+		sealedTx := false
+		for opIndex, ops := range txEnv.GetPayload().GetDbOperations() {
+			if !s.erasure.Erasable(ops.DbName) {
+				continue
+			}
+
+			for wIndex, w := range ops.DataWrites {
+				if w.Erased {
+					clone()
+					sealedTx = true
+					continue
+				}
+				if len(w.Value) == 0 {
+					continue
+				}
+
+				encrypted, err := s.erasure.Seal(ops.DbName, w.Key, w.Value)
+				if err != nil {
+					return nil, err
+				}
+				valueHash, err := crypto.ComputeSHA256Hash(w.Value)
+				if err != nil {
+					return nil, err
+				}
+				clone()
+				sealedWrite := sealed.GetDataTxEnvelopes().Envelopes[txIndex].Payload.DbOperations[opIndex].DataWrites[wIndex]
+				sealedWrite.Value = nil
+				sealedWrite.EncryptedValue = encrypted
+				sealedWrite.ValueHash = valueHash
+				sealedTx = true
+			}
+		}
+		if !sealedTx {
+			continue
+		}
+
+		if txHashes == nil {
+			var err error
+			if txHashes, err = mtree.CalculateBlockTxHashes(block); err != nil {
+				return nil, err
+			}
+		}
+		sealed.SealedTxHashes = append(sealed.SealedTxHashes, &types.TxHash{TxIndex: uint64(txIndex), Hash: txHashes[txIndex]})
+	}
+
+	return sealed, nil
+}
+
+// unsealBlock restores the sealed values of the sealed transactions of a stored block. The value of a key that was
+// erased since it was written is left empty, only its hash is kept, and the write is marked as erased, such that every
+// node serves the block in the same form, and the committed hashes of the transactions whose values were erased are
+// kept, such that the block can be verified against its tx Merkle tree root. If no value was erased, the block is
+// restored as it was committed.
+func (s *Store) unsealBlock(block *types.Block) error {
+	if len(block.SealedTxHashes) == 0 {
+		return nil
+	}
+
+	envelopes := block.GetDataTxEnvelopes().GetEnvelopes()
+	erasedTxs := make(map[uint64]bool)
+	for _, h := range block.SealedTxHashes {
+		if h.TxIndex >= uint64(len(envelopes)) {
+			return errors.Errorf("the sealed transaction [%d] is out of the range of the block", h.TxIndex)
+		}
+		txIndex := h.TxIndex
+		for _, ops := range envelopes[txIndex].GetPayload().GetDbOperations() {
+			for _, w := range ops.DataWrites {
+				if w.Erased {
+					// the block was committed from another node after the key was erased
+					erasedTxs[txIndex] = true
+					continue
+				}
+				if w.EncryptedValue == nil {
+					continue
+				}
+
+				value, ok, err := s.erasure.Unseal(ops.DbName, w.Key, w.EncryptedValue)
+				if err != nil {
+					return err
+				}
+				w.EncryptedValue = nil
+				if ok {
+					w.Value = value
+					w.ValueHash = nil
+				} else {
+					w.Erased = true
+					erasedTxs[txIndex] = true
+				}
+			}
+		}
+	}
+
+	var txHashes []*types.TxHash
+	for _, h := range block.SealedTxHashes {
+		if erasedTxs[h.TxIndex] {
+			txHashes = append(txHashes, h)
+		}
+	}
+	block.SealedTxHashes = txHashes
+
+	return nil
+}
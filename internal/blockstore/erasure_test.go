@@ -0,0 +1,87 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package blockstore
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
+	"github.com/hyperledger-labs/orion-server/internal/mtree"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitAndQueryErasableValues(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup(true)
+
+	erasureDir, err := ioutil.TempDir("", "erasure")
+	require.NoError(t, err)
+	defer os.RemoveAll(erasureDir)
+
+	erasureStore, err := erasure.Open(&erasure.Config{StoreDir: erasureDir, Logger: env.s.logger})
+	require.NoError(t, err)
+	defer erasureStore.Close()
+	erasureStore.SetErasableDBs([]string{"db1"})
+	env.s.erasure = erasureStore
+
+	block := createSampleDataTxBlock(1, nil, nil, 1)
+	block.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations = []*types.DBOperation{
+		{
+			DbName: "db1",
+			DataWrites: []*types.DataWrite{
+				{Key: "key1", Value: []byte("value1")},
+				{Key: "key2", Value: []byte("value2")},
+			},
+		},
+		{
+			DbName: "db2",
+			DataWrites: []*types.DataWrite{
+				{Key: "key1", Value: []byte("value1")},
+			},
+		},
+	}
+	root, err := mtree.BuildTreeForBlockTx(block)
+	require.NoError(t, err)
+	block.Header.TxMerkelTreeRootHash = root.Hash()
+	original := proto.Clone(block).(*types.Block)
+
+	require.NoError(t, env.s.Commit(block))
+	require.True(t, proto.Equal(original, block))
+
+	stored, err := env.s.Get(1)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(original, stored))
+
+	require.NoError(t, erasureStore.Erase([]*types.KeyErasure{{DbName: "db1", Key: "key1"}}))
+
+	stored, err = env.s.Get(1)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(original.GetHeader(), stored.GetHeader()))
+
+	ops := stored.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations
+	erased := ops[0].DataWrites[0]
+	require.Equal(t, "key1", erased.Key)
+	require.Empty(t, erased.Value)
+	require.Nil(t, erased.EncryptedValue)
+	require.True(t, erased.Erased)
+	require.True(t, proto.Equal(original.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations[0].DataWrites[1], ops[0].DataWrites[1]))
+	require.True(t, proto.Equal(original.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations[1], ops[1]))
+
+	// the committed hash of the transaction is kept, such that the block still matches its tx Merkle tree root
+	require.Len(t, stored.SealedTxHashes, 1)
+	require.Equal(t, uint64(0), stored.SealedTxHashes[0].TxIndex)
+	root, err = mtree.BuildTreeForBlockTx(stored)
+	require.NoError(t, err)
+	require.Equal(t, original.Header.TxMerkelTreeRootHash, root.Hash())
+
+	// every read serves the same form of the block
+	again, err := env.s.Get(1)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(stored, again))
+}
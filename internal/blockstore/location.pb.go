@@ -75,24 +75,76 @@ func (m *BlockLocation) GetLength() int64 {
 	return 0
 }
 
+// TxLocation is the position of a transaction in the ledger
+type TxLocation struct {
+	BlockNum             uint64   `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxIndex              uint64   `protobuf:"varint,2,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxLocation) Reset()         { *m = TxLocation{} }
+func (m *TxLocation) String() string { return proto.CompactTextString(m) }
+func (*TxLocation) ProtoMessage()    {}
+func (*TxLocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4d60b60ab7420348, []int{1}
+}
+
+func (m *TxLocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxLocation.Unmarshal(m, b)
+}
+func (m *TxLocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxLocation.Marshal(b, m, deterministic)
+}
+func (m *TxLocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxLocation.Merge(m, src)
+}
+func (m *TxLocation) XXX_Size() int {
+	return xxx_messageInfo_TxLocation.Size(m)
+}
+func (m *TxLocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxLocation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxLocation proto.InternalMessageInfo
+
+func (m *TxLocation) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *TxLocation) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*BlockLocation)(nil), "blockstore.BlockLocation")
+	proto.RegisterType((*TxLocation)(nil), "blockstore.TxLocation")
 }
 
 func init() { proto.RegisterFile("blockstore/location.proto", fileDescriptor_4d60b60ab7420348) }
 
 var fileDescriptor_4d60b60ab7420348 = []byte{
-	// 189 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0xce, 0xb1, 0x6b, 0x84, 0x30,
-	0x14, 0xc7, 0x71, 0xac, 0xc5, 0x21, 0xb4, 0x1d, 0x1c, 0x8a, 0xdd, 0xa4, 0x74, 0x70, 0xd1, 0x0c,
-	0x5d, 0x4b, 0x07, 0xbb, 0x96, 0x1b, 0x1c, 0x6f, 0x11, 0x93, 0x7b, 0x9a, 0x60, 0xcc, 0x93, 0x97,
-	0xe4, 0xe0, 0xfe, 0xfb, 0x23, 0xa7, 0xe0, 0xf8, 0xfd, 0xbc, 0x37, 0xfc, 0xd8, 0x87, 0x30, 0x28,
-	0x67, 0xe7, 0x91, 0x80, 0x1b, 0x94, 0x83, 0xd7, 0x68, 0x9b, 0x95, 0xd0, 0x63, 0xce, 0x8e, 0xd3,
-	0x27, 0xb0, 0xd7, 0x36, 0xd6, 0xff, 0xfe, 0x92, 0x7f, 0xb1, 0xb7, 0x51, 0x1b, 0xe8, 0xa5, 0x0a,
-	0x76, 0xee, 0x6d, 0x58, 0x8a, 0xa4, 0x4c, 0xaa, 0xe7, 0xee, 0x25, 0xea, 0x5f, 0xc4, 0x53, 0x58,
-	0xf2, 0x77, 0x96, 0xe1, 0x38, 0x3a, 0xf0, 0xc5, 0x53, 0x99, 0x54, 0x69, 0xb7, 0x57, 0x74, 0x03,
-	0x76, 0xf2, 0xaa, 0x48, 0x37, 0xdf, 0xaa, 0xfd, 0x3d, 0xff, 0x4c, 0xda, 0xab, 0x20, 0x1a, 0x89,
-	0x0b, 0x57, 0xb7, 0x15, 0xc8, 0xc0, 0x65, 0x02, 0xaa, 0xcd, 0x20, 0x1c, 0x47, 0xd2, 0x68, 0x6b,
-	0x07, 0x74, 0x05, 0xe2, 0xda, 0x7a, 0x20, 0x3b, 0x18, 0x7e, 0xcc, 0x14, 0xd9, 0x63, 0xf9, 0xf7,
-	0x3d, 0x00, 0x00, 0xff, 0xff, 0xfe, 0x94, 0x38, 0x7a, 0xd6, 0x00, 0x00, 0x00,
+	// 225 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x8f, 0x31, 0x4f, 0xc3, 0x40,
+	0x0c, 0x85, 0x15, 0x5a, 0x95, 0x62, 0x01, 0x43, 0x06, 0x94, 0x8a, 0xa5, 0xaa, 0x18, 0xba, 0xb4,
+	0x37, 0xb0, 0x22, 0x86, 0xc2, 0x82, 0x84, 0x18, 0x22, 0x26, 0x96, 0x28, 0x49, 0x9d, 0xe4, 0xd4,
+	0x8b, 0x5d, 0x39, 0x0e, 0x0a, 0xff, 0x1e, 0xdd, 0xb5, 0x22, 0x8c, 0xdf, 0x67, 0xeb, 0xd9, 0x0f,
+	0x16, 0x85, 0xe3, 0xf2, 0xd0, 0x29, 0x0b, 0x1a, 0xc7, 0x65, 0xae, 0x96, 0x69, 0x7b, 0x14, 0x56,
+	0x8e, 0x61, 0x1c, 0xad, 0x10, 0x6e, 0x76, 0x9e, 0xde, 0xcf, 0x2b, 0xf1, 0x03, 0xdc, 0x56, 0xd6,
+	0x61, 0x56, 0x36, 0x3d, 0x1d, 0x32, 0xea, 0xdb, 0x24, 0x5a, 0x46, 0xeb, 0x69, 0x7a, 0xed, 0xed,
+	0x8b, 0x97, 0x1f, 0x7d, 0x1b, 0xdf, 0xc1, 0x8c, 0xab, 0xaa, 0x43, 0x4d, 0x2e, 0x96, 0xd1, 0x7a,
+	0x92, 0x9e, 0xc9, 0x7b, 0x87, 0x54, 0x6b, 0x93, 0x4c, 0x4e, 0xfe, 0x44, 0xab, 0x57, 0x80, 0xcf,
+	0xe1, 0xef, 0xc6, 0x3d, 0x5c, 0x85, 0x17, 0xfe, 0xc5, 0xcf, 0x83, 0xf0, 0xd1, 0x0b, 0x98, 0xeb,
+	0x90, 0x59, 0xda, 0xe3, 0x10, 0xc2, 0xa7, 0xe9, 0xa5, 0x0e, 0x6f, 0x1e, 0x77, 0xcf, 0x5f, 0x4f,
+	0xb5, 0xd5, 0xa6, 0x2f, 0xb6, 0x25, 0xb7, 0xa6, 0xf9, 0x39, 0xa2, 0x38, 0xdc, 0xd7, 0x28, 0x1b,
+	0x97, 0x17, 0x9d, 0x61, 0xb1, 0x4c, 0x9b, 0x0e, 0xe5, 0x1b, 0xc5, 0x58, 0x52, 0x14, 0xca, 0x9d,
+	0x19, 0xcb, 0x16, 0xb3, 0xd0, 0xff, 0xf1, 0x77, 0x00, 0xb2, 0xbc, 0x08, 0x37, 0x1c, 0x01, 0x00,
+	0x00,
 }
@@ -11,8 +11,11 @@ import (
 	"sync"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/storeformat"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
@@ -34,6 +37,9 @@ var (
 	blockIndexDBName       = "blockindex"
 	blockHeaderDBName      = "blockheader"
 	txValidationInfoDBName = "txvalidationinfo"
+	txLocationDBName       = "txlocation"
+	txUsageDBName          = "txresourceusage"
+	dbUsageDBName          = "dbusage"
 
 	// underCreationFlag is used to mark that the store
 	// is being created. If a failure happens during the
@@ -42,6 +48,10 @@ var (
 	// before creating a new store
 	underCreationFlag = "undercreation"
 
+	// txLocationIndexBatchSize is the number of transaction locations written at once when the index of the
+	// transaction locations is built from the blocks in the store
+	txLocationIndexBatchSize = 10000
+
 	// Namespaces for block header and block hash storage:
 	// number -> header bytes
 	headerBytesNs = []byte{0}
@@ -53,8 +63,23 @@ var (
 	headerBaseHashNs = []byte{3}
 	// number -> block tx ids array
 	blockTxsIDNs = []byte{4}
+	// the number of the last block whose transaction locations are indexed
+	lastTxLocationsIndexedKey = []byte{5}
+
+	// Namespaces for the usage of the databases with a quota:
+	// database name -> usage
+	dbUsageNs = []byte{0}
+	// number -> quota alerts raised by the block
+	quotaAlertsNs = []byte{1}
 )
 
+// format is the on-disk format of the block store. A change of the layout of the file chunks or of the indexes
+// increments the version, and registers the migration from the previous version.
+var format = &storeformat.Format{
+	Store:   "block store",
+	Version: 1,
+}
+
 // Store maintains a chain of blocks in an append-only
 // filesystem
 type Store struct {
@@ -63,18 +88,31 @@ type Store struct {
 	currentOffset         int64
 	currentChunkNum       uint64
 	lastCommittedBlockNum uint64
-	blockIndexDB          *leveldb.DB
-	blockHeaderDB         *leveldb.DB
-	txValidationInfoDB    *leveldb.DB
-	reusableBuffer        []byte
-	logger                *logger.SugarLogger
-	mu                    sync.RWMutex
+	// lastTxLocationsIndexed is the number of the last block up to which the transaction locations are indexed
+	lastTxLocationsIndexed uint64
+	blockIndexDB           *leveldb.DB
+	blockHeaderDB          *leveldb.DB
+	txValidationInfoDB     *leveldb.DB
+	txLocationDB           *leveldb.DB
+	txUsageDB              *leveldb.DB
+	dbUsageDB              *leveldb.DB
+	erasure                *erasure.Store
+	blockCache             *blockCache
+	reusableBuffer         []byte
+	logger                 *logger.SugarLogger
+	mu                     sync.RWMutex
+	// deferred holds the blocks committed with CommitDeferred that are not yet written
+	deferred []*deferredBlock
 }
 
 // Config holds the configuration of a block store
 type Config struct {
 	StoreDir string
-	Logger   *logger.SugarLogger
+	// Erasure seals the values written to the erasable databases. If nil, no value is sealed.
+	Erasure *erasure.Store
+	// BlockCacheSize is the number of the last committed blocks that are kept in memory. If zero, no block is cached.
+	BlockCacheSize uint32
+	Logger         *logger.SugarLogger
 }
 
 // Open opens the store to maintains a chain of blocks
@@ -100,6 +138,9 @@ func Open(c *Config) (*Store, error) {
 
 		return openNewStore(c)
 	default:
+		if err := format.Upgrade(c.StoreDir, c.Logger); err != nil {
+			return nil, err
+		}
 		return openExistingStore(c)
 	}
 }
@@ -131,6 +172,9 @@ func openNewStore(c *Config) (*Store, error) {
 	blockIndexDBPath := filepath.Join(c.StoreDir, blockIndexDBName)
 	blockHeaderDBPath := filepath.Join(c.StoreDir, blockHeaderDBName)
 	txValidationInfoDBPath := filepath.Join(c.StoreDir, txValidationInfoDBName)
+	txLocationDBPath := filepath.Join(c.StoreDir, txLocationDBName)
+	txUsageDBPath := filepath.Join(c.StoreDir, txUsageDBName)
+	dbUsageDBPath := filepath.Join(c.StoreDir, dbUsageDBName)
 
 	file, err := openFileChunk(fileChunksDirPath, 0)
 	if err != nil {
@@ -152,6 +196,25 @@ func openNewStore(c *Config) (*Store, error) {
 		return nil, errors.WithMessage(err, "error while creating a leveldb database to store the transaction validation info")
 	}
 
+	txLocationDB, err := leveldb.OpenFile(txLocationDBPath, &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating a leveldb database to store the transaction locations")
+	}
+
+	txUsageDB, err := leveldb.OpenFile(txUsageDBPath, &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating a leveldb database to store the transaction resource usage")
+	}
+
+	dbUsageDB, err := leveldb.OpenFile(dbUsageDBPath, &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating a leveldb database to store the database usage")
+	}
+
+	if err := format.Init(c.StoreDir); err != nil {
+		return nil, err
+	}
+
 	if err := fileops.Remove(underCreationFlagPath); err != nil {
 		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
 	}
@@ -165,6 +228,11 @@ func openNewStore(c *Config) (*Store, error) {
 		blockIndexDB:          indexDB,
 		blockHeaderDB:         headersDB,
 		txValidationInfoDB:    txValidationInfoDB,
+		txLocationDB:          txLocationDB,
+		txUsageDB:             txUsageDB,
+		dbUsageDB:             dbUsageDB,
+		erasure:               c.Erasure,
+		blockCache:            newBlockCache(c.BlockCacheSize),
 		reusableBuffer:        make([]byte, binary.MaxVarintLen64),
 		logger:                c.Logger,
 	}, nil
@@ -175,6 +243,9 @@ func openExistingStore(c *Config) (*Store, error) {
 	blockIndexDBPath := filepath.Join(c.StoreDir, blockIndexDBName)
 	blockHeaderDBPath := filepath.Join(c.StoreDir, blockHeaderDBName)
 	txValidationInfoDBPath := filepath.Join(c.StoreDir, txValidationInfoDBName)
+	txLocationDBPath := filepath.Join(c.StoreDir, txLocationDBName)
+	txUsageDBPath := filepath.Join(c.StoreDir, txUsageDBName)
+	dbUsageDBPath := filepath.Join(c.StoreDir, dbUsageDBName)
 
 	currentFileChunk, currentChunkNum, err := findAndOpenLastFileChunk(fileChunksDirPath)
 	if err != nil {
@@ -201,6 +272,29 @@ func openExistingStore(c *Config) (*Store, error) {
 		return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the transaction validation info")
 	}
 
+	// a store created before the transaction locations were indexed has no index, which is created and then built
+	// from the blocks in the store
+	txLocationIndexExist, err := fileops.Exists(txLocationDBPath)
+	if err != nil {
+		return nil, err
+	}
+	txLocationDB, err := leveldb.OpenFile(txLocationDBPath, &opt.Options{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the leveldb file for the transaction locations")
+	}
+
+	// the resource usage of the transactions committed before it was accounted is not available
+	txUsageDB, err := leveldb.OpenFile(txUsageDBPath, &opt.Options{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the leveldb file for the transaction resource usage")
+	}
+
+	// the usage of the databases is accounted from the first block committed after their quotas are configured
+	dbUsageDB, err := leveldb.OpenFile(dbUsageDBPath, &opt.Options{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while opening the leveldb file for the database usage")
+	}
+
 	s := &Store{
 		fileChunksDirPath:  fileChunksDirPath,
 		currentFileChunk:   currentFileChunk,
@@ -209,10 +303,24 @@ func openExistingStore(c *Config) (*Store, error) {
 		blockIndexDB:       indexDB,
 		blockHeaderDB:      headersDB,
 		txValidationInfoDB: txValidationInfoDB,
+		txLocationDB:       txLocationDB,
+		txUsageDB:          txUsageDB,
+		dbUsageDB:          dbUsageDB,
+		erasure:            c.Erasure,
+		blockCache:         newBlockCache(c.BlockCacheSize),
 		reusableBuffer:     make([]byte, binary.MaxVarintLen64),
 		logger:             c.Logger,
 	}
-	return s, s.recover()
+	if txLocationIndexExist {
+		if s.lastTxLocationsIndexed, err = s.getLastTxLocationsIndexed(); err != nil {
+			return s, err
+		}
+	}
+	if err := s.recover(); err != nil {
+		return s, err
+	}
+
+	return s, s.indexTxLocations()
 }
 
 func (s *Store) recover() error {
@@ -245,13 +353,40 @@ func (s *Store) recover() error {
 		}
 	}()
 
-	nextBlockAndLocation, err := chunkFileStream.nextBlockWithLocation()
+	var unindexedBlocks []*types.Block
+	var unindexedLocations []*BlockLocation
+	var lastUnindexed *blockAndLocation
+	partialBlockWrite := false
+
+	for {
+		nextBlockAndLocation, err := chunkFileStream.nextBlockWithLocation()
+		if err == ErrUnexpectedEndOfBlockfile {
+			partialBlockWrite = true
+			break
+		}
+		if err != nil {
+			// internal error will result in a panic at the caller
+			return errors.WithMessage(err, "error while recovering block store")
+		}
+		if nextBlockAndLocation == nil {
+			break
+		}
+
+		lastUnindexed = nextBlockAndLocation
+		unindexedBlocks = append(unindexedBlocks, nextBlockAndLocation.block)
+		unindexedLocations = append(unindexedLocations, &BlockLocation{
+			FileChunkNum: nextBlockAndLocation.fileChunkNum,
+			Offset:       nextBlockAndLocation.blockStartOffset,
+			Length:       nextBlockAndLocation.blockEndOffset - nextBlockAndLocation.blockStartOffset,
+		})
+	}
+
 	switch {
 	// Scenario 1: no partial block write to file chunk and the block index
 	// DB is sync with the file-based block store. To keep the recovery logic simple,
 	// we reply the last block onto the index DB, block header DB and validationInfo
 	// DB though it might be already in sync with the file-based block store.
-	case nextBlockAndLocation == nil && err == nil:
+	case lastUnindexed == nil && !partialBlockWrite:
 		if lastBlockNumberInIndex == 0 {
 			return nil
 		}
@@ -275,39 +410,43 @@ func (s *Store) recover() error {
 			return err
 		}
 
-		if err = s.storeMetadataInDB(block, lastBlockLocation); err != nil {
-			return err
+		return s.storeMetadataInDB([]*types.Block{block}, []*BlockLocation{lastBlockLocation})
+
+	// Scenario 2: one or more blocks, e.g., of a group commit, are fully written to
+	// the file chunk but the block index DB is NOT in sync with the file-based block
+	// store. Here, we need to reply these blocks onto the block index DB, block header
+	// DB, and validationInfo DB. A partially written block that follows them is discarded.
+	case lastUnindexed != nil:
+		for i, block := range unindexedBlocks {
+			if block.GetHeader().GetBaseHeader().GetNumber() != lastBlockNumberInIndex+uint64(i)+1 {
+				return errors.Errorf("the block store has a block [%d] which is not indexed, whereas the block "+
+					"[%d] is expected. The node cannot be recovered",
+					block.GetHeader().GetBaseHeader().GetNumber(), lastBlockNumberInIndex+uint64(i)+1)
+			}
 		}
 
-		return nil
-
-	// Scenario 2: no partial block write to file chunk but the block index
-	// DB is NOT in sync with the file-based block store. Here, we need to
-	// reply the last block onto the block index DB, block header DB, and
-	// validationInfo DB.
-	case nextBlockAndLocation != nil && err == nil:
-		secondNextBlockAndLocation, err := chunkFileStream.nextBlockWithLocation()
-		if err != nil {
+		if err = s.storeMetadataInDB(unindexedBlocks, unindexedLocations); err != nil {
 			return err
 		}
-		if secondNextBlockAndLocation != nil {
-			return errors.Errorf("the block store can have exactly one fully committed block " +
-				"which is not indexed or exaclty one partially written block. Any other case is " +
-				"an unexpected behavior")
-		}
 
-		location := &BlockLocation{
-			FileChunkNum: nextBlockAndLocation.fileChunkNum,
-			Offset:       nextBlockAndLocation.blockStartOffset,
-			Length:       nextBlockAndLocation.blockEndOffset - nextBlockAndLocation.blockStartOffset,
-		}
+		s.lastCommittedBlockNum = lastUnindexed.block.Header.BaseHeader.Number
+		if s.currentChunkNum != lastUnindexed.fileChunkNum {
+			// the failure happened just after creating a new file chunk, which is either empty or
+			// holds only a partially written block
+			newChunkFilePath := constructBlockFileChunkPath(s.fileChunksDirPath, s.currentChunkNum)
 
-		if err = s.storeMetadataInDB(nextBlockAndLocation.block, location); err != nil {
-			return err
+			if err := s.moveToChunk(lastUnindexed.fileChunkNum); err != nil {
+				return err
+			}
+			if err := fileops.RemoveAll(newChunkFilePath); err != nil {
+				return err
+			}
 		}
+		s.currentOffset = lastUnindexed.blockEndOffset
 
-		s.lastCommittedBlockNum = nextBlockAndLocation.block.Header.BaseHeader.Number
-		s.currentOffset = nextBlockAndLocation.blockEndOffset
+		if partialBlockWrite {
+			return fileops.Truncate(s.currentFileChunk, s.currentOffset)
+		}
 		return nil
 
 	// Scenario 3: partial block write to file chunk. This can occur when the node
@@ -315,7 +454,7 @@ func (s *Store) recover() error {
 	// need to discard the partial write by setting current offset appropriately and
 	// then truncating the file. No need to reply the block onto other DBs as they
 	// all would be in sync with the last complete block
-	case nextBlockAndLocation == nil && err == ErrUnexpectedEndOfBlockfile:
+	default:
 		s.lastCommittedBlockNum = lastBlockNumberInIndex
 		s.currentOffset = lastBlockLocation.Offset + lastBlockLocation.Length
 
@@ -334,12 +473,70 @@ func (s *Store) recover() error {
 
 		// for partial appends to the existing chunk file, we can simply truncate
 		return fileops.Truncate(s.currentFileChunk, s.currentOffset)
+	}
+}
 
-	// internal error will result in a panic at the caller
-	case nextBlockAndLocation == nil && err != nil:
-		return errors.WithMessage(err, "error while recovering block store")
+// indexTxLocations builds the index of the transaction locations from the blocks in the store that follow the last
+// block whose transaction locations are indexed. The index is resumed from where it stopped when building it failed,
+// or when the store was last opened by a version that did not index them.
+func (s *Store) indexTxLocations() error {
+	lastIndexed := s.lastTxLocationsIndexed
+	if lastIndexed >= s.lastCommittedBlockNum {
+		return nil
 	}
 
+	s.logger.Infof("Indexing the transaction locations of blocks [%d,%d]", lastIndexed+1, s.lastCommittedBlockNum)
+
+	batch := &leveldb.Batch{}
+	for blockNum := lastIndexed + 1; blockNum <= s.lastCommittedBlockNum; blockNum++ {
+		block, err := s.Get(blockNum)
+		if err != nil {
+			return err
+		}
+		if err := addBlockTxLocations(batch, block); err != nil {
+			return err
+		}
+
+		if batch.Len() >= txLocationIndexBatchSize || blockNum == s.lastCommittedBlockNum {
+			if err := s.txLocationDB.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+				return errors.Wrap(err, "error while indexing the transaction locations")
+			}
+			batch.Reset()
+
+			if err := s.setLastTxLocationsIndexed(blockNum); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getLastTxLocationsIndexed returns the number of the last block whose transaction locations are indexed, or zero if
+// none is
+func (s *Store) getLastTxLocationsIndexed() (uint64, error) {
+	val, err := s.blockHeaderDB.Get(lastTxLocationsIndexedKey, &opt.ReadOptions{})
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "error while fetching the last block whose transaction locations are indexed")
+	}
+
+	blockNum, _, err := decodeOrderPreservingVarUint64(val)
+	if err != nil {
+		return 0, errors.Wrap(err, "error while decoding the last block whose transaction locations are indexed")
+	}
+	return blockNum, nil
+}
+
+// setLastTxLocationsIndexed records the number of the last block whose transaction locations are indexed. It is
+// recorded once the locations are written, so that a failure in between only indexes some of them again.
+func (s *Store) setLastTxLocationsIndexed(blockNum uint64) error {
+	if err := s.blockHeaderDB.Put(lastTxLocationsIndexedKey, encodeOrderPreservingVarUint64(blockNum), &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrapf(err, "error while recording block [%d] as the last block whose transaction locations are indexed", blockNum)
+	}
+	s.lastTxLocationsIndexed = blockNum
 	return nil
 }
 
@@ -373,6 +570,12 @@ func (s *Store) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	// the deferred blocks are delivered again by the replication once the node restarts, hence a failure to write them
+	// is not fatal
+	if err := s.flushDeferred(); err != nil {
+		s.logger.Warnf("failed to write the deferred blocks before closing: %s", err)
+	}
+
 	if err := s.currentFileChunk.Close(); err != nil {
 		return errors.WithMessage(err, "error while closing the store")
 	}
@@ -389,6 +592,18 @@ func (s *Store) Close() error {
 		return errors.WithMessage(err, "error while closing the tx validation info database")
 	}
 
+	if err := s.txLocationDB.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the tx location database")
+	}
+
+	if err := s.txUsageDB.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the tx resource usage database")
+	}
+
+	if err := s.dbUsageDB.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the database usage database")
+	}
+
 	return nil
 }
 
@@ -36,8 +36,9 @@ func TestOpenStore(t *testing.T) {
 		require.Equal(t, uint64(0), s.currentChunkNum)
 		require.Equal(t, uint64(0), s.lastCommittedBlockNum)
 		require.NoFileExists(t, filepath.Join(storeDir, "undercreation"))
+		require.FileExists(t, filepath.Join(storeDir, "formatversion"))
 
-		for _, dbName := range []string{blockIndexDBName, blockHeaderDBName, txValidationInfoDBName} {
+		for _, dbName := range []string{blockIndexDBName, blockHeaderDBName, txValidationInfoDBName, txLocationDBName, txUsageDBName, dbUsageDBName} {
 			dbPath := filepath.Join(storeDir, dbName)
 			require.DirExists(t, dbPath)
 		}
@@ -216,6 +217,28 @@ func TestOpenStore(t *testing.T) {
 		require.Equal(t, offset, s.currentOffset)
 		require.Equal(t, uint64(1000), s.lastCommittedBlockNum)
 	})
+
+	t.Run("reopen a store in a newer format version", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir("", "opentest")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		storeDir := filepath.Join(testDir, "newer-format-store")
+		c := &Config{
+			StoreDir: storeDir,
+			Logger:   logger,
+		}
+		s, err := Open(c)
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(storeDir, "formatversion"), []byte("2\n"), 0644))
+		s, err = Open(c)
+		require.EqualError(t, err, "the block store in ["+storeDir+"] is in format version [2], which is newer than the supported version [1]")
+		require.Nil(t, s)
+	})
 }
 
 func TestRecovery(t *testing.T) {
@@ -239,7 +262,7 @@ func TestRecovery(t *testing.T) {
 		blockLocation, err := env.s.appendBlock(1, content)
 		require.NoError(t, err)
 
-		require.NoError(t, env.s.storeIndexForBlock(1, blockLocation))
+		require.NoError(t, env.s.storeIndexForBlocks([]*types.Block{block}, []*BlockLocation{blockLocation}))
 		txID := block.GetUserAdministrationTxEnvelope().Payload.TxId
 
 		assertIndexExist(t, env.s, 1, blockLocation)
@@ -471,45 +494,43 @@ func TestRecovery(t *testing.T) {
 	})
 
 	// scenario 7:
-	//  - append block 1 and 2 to the file and keep metadata store as empty
-	//  - ensure that the store is unrecoverable as this scenario should never occur unless there is a bug
-	t.Run("unrecoverable store", func(t *testing.T) {
+	//  - append block 1, 2, and a partial block 3 to the file and keep metadata store as empty,
+	//    as happens when the node fails while group-committing the blocks
+	//  - ensure that the metadata of block 1 and 2 is recovered and the partial block 3 is truncated
+	t.Run("multiple blocks are not indexed", func(t *testing.T) {
 		env := newTestEnv(t)
 		defer env.cleanup(false)
 
-		block1 := createSampleUserTxBlock(1, nil, nil)
-		require.NoError(t, env.s.AddSkipListLinks(block1))
+		encode := func(block *types.Block) []byte {
+			b, err := proto.Marshal(block)
+			require.NoError(t, err)
+			encodedBlock := snappy.Encode(nil, b)
+			buf := make([]byte, binary.MaxVarintLen64)
+			n := binary.PutUvarint(buf, uint64(len(encodedBlock)))
+			return append(buf[:n], encodedBlock...)
+		}
 
-		b, err := proto.Marshal(block1)
+		block1 := createSampleUserTxBlock(1, nil, nil)
+		block1Location, err := env.s.appendBlock(1, encode(block1))
 		require.NoError(t, err)
-		encodedBlock := snappy.Encode(nil, b)
-		buf := make([]byte, binary.MaxVarintLen64)
-		n := binary.PutUvarint(buf, uint64(len(encodedBlock)))
-		content := append(buf[:n], encodedBlock...)
 
-		_, err = env.s.appendBlock(1, content)
+		block1BaseHeaderBytes, err := proto.Marshal(block1.GetHeader().GetBaseHeader())
+		require.NoError(t, err)
+		block1BaseHeaderHash, err := crypto.ComputeSHA256Hash(block1BaseHeaderBytes)
+		require.NoError(t, err)
+		block1HeaderBytes, err := proto.Marshal(block1.GetHeader())
+		require.NoError(t, err)
+		block1HeaderHash, err := crypto.ComputeSHA256Hash(block1HeaderBytes)
 		require.NoError(t, err)
-
-		block1BaseHeaderHash, err := env.s.GetBaseHeaderHash(1)
-		require.EqualError(t, err, "block header base hash not found: 1")
-		require.IsType(t, &errors.NotFoundErr{}, err)
-		block1HeaderHash, err := env.s.GetHash(1)
-		require.EqualError(t, err, "block hash not found: 1")
-		require.IsType(t, &errors.NotFoundErr{}, err)
 
 		block2 := createSampleUserTxBlock(2, block1BaseHeaderHash, block1HeaderHash)
-		err = env.s.AddSkipListLinks(block2)
-		require.EqualError(t, err, "block hash not found: 1")
-		require.IsType(t, &errors.NotFoundErr{}, err)
-
-		b, err = proto.Marshal(block2)
+		block2Location, err := env.s.appendBlock(2, encode(block2))
 		require.NoError(t, err)
-		encodedBlock = snappy.Encode(nil, b)
-		buf = make([]byte, binary.MaxVarintLen64)
-		n = binary.PutUvarint(buf, uint64(len(encodedBlock)))
-		content = append(buf[:n], encodedBlock...)
+		block2Offset := env.s.currentOffset
 
-		_, err = env.s.appendBlock(1, content)
+		block3 := createSampleUserTxBlock(3, nil, nil)
+		content := encode(block3)
+		_, err = env.s.appendBlock(3, content[:len(content)-5])
 		require.NoError(t, err)
 
 		txID1 := block1.GetUserAdministrationTxEnvelope().Payload.TxId
@@ -517,14 +538,18 @@ func TestRecovery(t *testing.T) {
 		txID2 := block2.GetUserAdministrationTxEnvelope().Payload.TxId
 		assertBlockMetadataDoesNotExist(t, env.s, 2, txID2)
 
-		require.NoError(t, env.s.Close())
-		_, err = Open(&Config{
-			StoreDir: env.storeDir,
-			Logger:   env.s.logger,
-		})
-		require.EqualError(t, err, "the block store can have exactly one"+
-			" fully committed block which is not indexed or exaclty one partially"+
-			" written block. Any other case is an unexpected behavior")
+		env.closeAndReOpenStore(t)
+		defer env.cleanup(true)
+
+		assertBlockMetadataExist(t, env.s, block1, block1Location)
+		assertBlockMetadataExist(t, env.s, block2, block2Location)
+		txID3 := block3.GetUserAdministrationTxEnvelope().Payload.TxId
+		assertBlockMetadataDoesNotExist(t, env.s, 3, txID3)
+		require.Equal(t, block2Offset, env.s.currentOffset)
+
+		height, err := env.s.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), height)
 	})
 
 	// scenario 8:
@@ -579,6 +604,7 @@ func assertBlockMetadataDoesNotExist(t *testing.T, s *Store, blockNum uint64, tx
 	assertHashDoesNotExist(t, s, blockNum)
 	assertHeaderDoesNotExist(t, s, blockNum)
 	assertValidationInfoDoesNotExist(t, s, txID)
+	assertTxLocationDoesNotExist(t, s, txID)
 }
 
 func assertIndexDoesNotExist(t *testing.T, s *Store, blockNum uint64) {
@@ -626,6 +652,7 @@ func assertBlockMetadataExist(t *testing.T, s *Store, block *types.Block, expect
 	assertHashExist(t, s, block)
 	assertHeaderExist(t, s, block)
 	assertValidationInfoExist(t, s, txID, block.Header.ValidationInfo[0])
+	assertTxLocationExist(t, s, txID, &TxLocation{BlockNum: blockNum})
 }
 
 func assertIndexExist(t *testing.T, s *Store, blockNum uint64, expectedLocation *BlockLocation) {
@@ -678,3 +705,16 @@ func assertValidationInfoExist(t *testing.T, s *Store, txID string, expectedValI
 	require.NoError(t, err)
 	require.True(t, proto.Equal(expectedValInfo, valInfo))
 }
+
+func assertTxLocationDoesNotExist(t *testing.T, s *Store, txID string) {
+	txLocation, err := s.GetTxLocation(txID)
+	require.EqualError(t, err, fmt.Sprintf("TxID not found: %s", txID))
+	require.IsType(t, &errors.NotFoundErr{}, err)
+	require.Nil(t, txLocation)
+}
+
+func assertTxLocationExist(t *testing.T, s *Store, txID string, expectedTxLocation *TxLocation) {
+	txLocation, err := s.GetTxLocation(txID)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expectedTxLocation, txLocation))
+}
@@ -4,11 +4,15 @@
 package comm
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
 	"math/rand"
 	"mime"
 	"mime/multipart"
@@ -29,10 +33,24 @@ import (
 var RetryIntervalMin = 10 * time.Millisecond
 var RetryIntervalMax = 10 * time.Second
 
+// CatchUpRangeSize is the number of blocks in a range pulled from a single member, when PullBlocks pulls several
+// ranges in parallel
+var CatchUpRangeSize uint64 = 64
+
+// CatchUpMaxParallelRanges is the maximal number of ranges pulled in parallel by a single call to PullBlocks, which
+// bounds the number of blocks it returns
+var CatchUpMaxParallelRanges = 4
+
 type catchUpClient struct {
 	httpClient *http.Client
 	logger     *logger.SugarLogger
 	tlsConfig  *tls.Config
+	// codecs are the codecs with which the blocks pulled may be compressed, in order of preference
+	codecs []string
+	// throttle limits the rate at which the blocks of all requests are pulled
+	throttle *Throttle
+	// maxBlockBytes is the size above which a block pulled is rejected, see MaxDecompressedBlockBytes
+	maxBlockBytes uint64
 
 	mutex   sync.Mutex
 	members map[uint64]*url.URL
@@ -40,14 +58,35 @@ type catchUpClient struct {
 
 func NewCatchUpClient(lg *logger.SugarLogger, tlsConfig *tls.Config) *catchUpClient {
 	c := &catchUpClient{
-		httpClient: newHTTPClient(tlsConfig),
-		tlsConfig:  tlsConfig,
-		logger:     lg,
-		members:    make(map[uint64]*url.URL),
+		httpClient:    newHTTPClient(tlsConfig),
+		tlsConfig:     tlsConfig,
+		logger:        lg,
+		maxBlockBytes: DefaultMaxDecompressedBlockBytes,
+		members:       make(map[uint64]*url.URL),
 	}
 	return c
 }
 
+// SetCodecs sets the codecs with which the blocks pulled may be compressed, in order of preference. The members
+// compress the blocks with the first of these codecs they support; a member that supports none of them, or predates
+// compression, sends the blocks uncompressed. It must be called before blocks are pulled.
+func (c *catchUpClient) SetCodecs(codecs []string) {
+	c.codecs = codecs
+}
+
+// SetThrottle sets the throttle that limits the rate at which the blocks of all requests are pulled. A nil throttle
+// does not limit the rate. It must be called before blocks are pulled.
+func (c *catchUpClient) SetThrottle(throttle *Throttle) {
+	c.throttle = throttle
+}
+
+// SetMaxBlockSize sets the maximal size of a block when it is cut, zero if not limited, from which the size above
+// which a block pulled is rejected is derived, see MaxDecompressedBlockBytes. It must be called before blocks are
+// pulled.
+func (c *catchUpClient) SetMaxBlockSize(maxBlockBytes uint64) {
+	c.maxBlockBytes = MaxDecompressedBlockBytes(maxBlockBytes)
+}
+
 // UpdateMembers updates the peer member list, must not include the self RaftID.
 func (c *catchUpClient) UpdateMembers(memberList []*types.PeerConfig) error {
 	members := make(map[uint64]*url.URL)
@@ -77,6 +116,11 @@ func (c *catchUpClient) UpdateMembers(memberList []*types.PeerConfig) error {
 	return nil
 }
 
+// PullBlocks pulls the blocks [start, end], or a prefix of them, from the members of the cluster. The blocks are split
+// into ranges of CatchUpRangeSize blocks, and up to CatchUpMaxParallelRanges ranges are pulled in parallel, each from a
+// different member first, starting with the leader hint. The blocks returned are verified to be in sequence and linked
+// by the hash of the previous base header; the link of the first block to the ledger of the caller is left to the
+// caller. PullBlocks retries with back-off until some blocks are pulled, and returns an error only when canceled.
 func (c *catchUpClient) PullBlocks(ctx context.Context, start, end uint64, leaderHint uint64) ([]*types.Block, error) {
 	curRetryInterval := RetryIntervalMin
 
@@ -86,23 +130,21 @@ func (c *catchUpClient) PullBlocks(ctx context.Context, start, end uint64, leade
 		if leaderHint != 0 {
 			memberIDs = append(memberIDs, leaderHint)
 		}
-		memberIDs = append(memberIDs, c.memberIDs()...)
+		for _, id := range c.memberIDs() {
+			if id != leaderHint {
+				memberIDs = append(memberIDs, id)
+			}
+		}
 		c.logger.Debugf("going to try getting blocks [%d,%d] from members: %v, in that order", start, end, memberIDs)
 
-		for _, id := range memberIDs {
-			select {
-			case <-ctx.Done():
-				c.logger.Infof("PulledBlocks canceled: %s", ctx.Err())
-				return nil, errors.WithMessage(ctx.Err(), "PullBlocks canceled")
-			default:
-				blocks, err := c.GetBlocks(ctx, id, start, end)
-				if err != nil {
-					c.logger.Debugf("failed to get blocks from member [%d], error: %s", id, err)
-					continue
-				}
-
+		select {
+		case <-ctx.Done():
+			c.logger.Infof("PulledBlocks canceled: %s", ctx.Err())
+			return nil, errors.WithMessage(ctx.Err(), "PullBlocks canceled")
+		default:
+			if blocks := c.pullRanges(ctx, start, end, memberIDs); len(blocks) > 0 {
 				last := blocks[len(blocks)-1].Header.BaseHeader.Number
-				c.logger.Infof("Pulled blocks [%d,%d] from member [%d]", start, last, id)
+				c.logger.Infof("Pulled blocks [%d,%d]", start, last)
 				return blocks, nil
 			}
 		}
@@ -170,6 +212,8 @@ func (c *catchUpClient) GetBlocks(ctx context.Context, targetID, start, end uint
 		return nil, err
 	}
 	req.Header.Add("Accept", utils.MultiPartFormData)
+	req.Header.Add(AcceptEncodingHeader, acceptEncoding(c.codecs))
+	req.Header.Add(ProtocolVersionHeader, strconv.FormatUint(uint64(ProtocolVersion), 10))
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -184,7 +228,7 @@ func (c *catchUpClient) GetBlocks(ctx context.Context, targetID, start, end uint
 		return nil, eRes
 	}
 
-	return multipartResponseToBlocks(c.logger, resp)
+	return multipartResponseToBlocks(c.logger, resp, c.throttle.Reader(ctx, resp.Body), end-start+1, c.maxBlockBytes)
 }
 
 func (c *catchUpClient) GetHeight(ctx context.Context, targetID uint64) (uint64, error) {
@@ -199,6 +243,7 @@ func (c *catchUpClient) GetHeight(ctx context.Context, targetID uint64) (uint64,
 		return 0, err
 	}
 	req.Header.Add("Accept", "application/json")
+	req.Header.Add(ProtocolVersionHeader, strconv.FormatUint(uint64(ProtocolVersion), 10))
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return 0, err
@@ -221,6 +266,114 @@ func (c *catchUpClient) GetHeight(ctx context.Context, targetID uint64) (uint64,
 	return hRes.Height, nil
 }
 
+// GetProtocolVersion returns the protocol versions supported by a member. A member that predates versioning does not
+// serve the version request, and is reported as supporting only version 1.
+func (c *catchUpClient) GetProtocolVersion(ctx context.Context, targetID uint64) (*VersionResponse, error) {
+	baseURL := c.getMemberURL(targetID)
+	if baseURL == nil {
+		return nil, errors.Errorf("target ID [%d] not found", targetID)
+	}
+
+	url := baseURL.ResolveReference(&url.URL{Path: GetVersionPath})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add(ProtocolVersionHeader, strconv.FormatUint(uint64(ProtocolVersion), 10))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && resp.Header.Get(ProtocolVersionHeader) == "" {
+		return &VersionResponse{ProtocolVersion: 1, MinProtocolVersion: 1}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		eRes := &types.HttpResponseErr{}
+		if err = json.NewDecoder(resp.Body).Decode(eRes); err != nil {
+			return nil, err
+		}
+		return nil, eRes
+	}
+
+	vRes := &VersionResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(vRes); err != nil {
+		return nil, err
+	}
+
+	return vRes, nil
+}
+
+// PullChunk pulls the chunk of a blob with the given hash from the members of the cluster, which it tries in turn until
+// one of them holds the chunk. The chunk is read up to maxSize bytes, and must match its hash.
+func (c *catchUpClient) PullChunk(ctx context.Context, hash []byte, maxSize uint64) ([]byte, error) {
+	var lastErr error
+	for _, id := range c.memberIDs() {
+		chunk, err := c.GetChunk(ctx, id, hash, maxSize)
+		if err == nil {
+			return chunk, nil
+		}
+		c.logger.Debugf("failed to pull chunk [%x] from member [%d]: %s", hash, id, err)
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if lastErr == nil {
+		return nil, errors.Errorf("no member to pull chunk [%x] from", hash)
+	}
+	return nil, errors.WithMessagef(lastErr, "failed to pull chunk [%x] from the members", hash)
+}
+
+// GetChunk gets the chunk of a blob with the given hash from a member. The chunk is read up to maxSize bytes, and must
+// match its hash.
+func (c *catchUpClient) GetChunk(ctx context.Context, targetID uint64, hash []byte, maxSize uint64) ([]byte, error) {
+	baseURL := c.getMemberURL(targetID)
+	if baseURL == nil {
+		return nil, errors.Errorf("target ID [%d] not found", targetID)
+	}
+
+	url := baseURL.ResolveReference(&url.URL{Path: GetChunkPath + fmt.Sprintf("%x", hash)})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/octet-stream")
+	req.Header.Add(ProtocolVersionHeader, strconv.FormatUint(uint64(ProtocolVersion), 10))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		eRes := &types.HttpResponseErr{}
+		if err = json.NewDecoder(resp.Body).Decode(eRes); err != nil {
+			return nil, err
+		}
+		return nil, eRes
+	}
+
+	// a chunk larger than maxSize is read one byte over, and fails to match its hash
+	chunk, err := ioutil.ReadAll(io.LimitReader(c.throttle.Reader(ctx, resp.Body), int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(chunk)) > maxSize {
+		return nil, errors.Errorf("chunk [%x] is larger than %d bytes", hash, maxSize)
+	}
+	actual := sha256.Sum256(chunk)
+	if !bytes.Equal(actual[:], hash) {
+		return nil, errors.Errorf("chunk [%x] received from member [%d] does not match its hash", hash, targetID)
+	}
+
+	return chunk, nil
+}
+
 func newHTTPClient(tlsConfig *tls.Config) *http.Client {
 	//TODO expose some transport parameters
 	httpClient := &http.Client{
@@ -242,7 +395,13 @@ func newHTTPClient(tlsConfig *tls.Config) *http.Client {
 	return httpClient
 }
 
-func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response) ([]*types.Block, error) {
+// multipartPartOverheadBytes bounds the size of the boundary and the headers of a part of a multipart response
+const multipartPartOverheadBytes = 1024
+
+// multipartResponseToBlocks reads the blocks of a multipart response to the request of numBlocks blocks. A block
+// larger than maxBlockBytes fails the read, and so does a compressed response that decompresses to more than the
+// parts of numBlocks such blocks.
+func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response, body io.Reader, numBlocks, maxBlockBytes uint64) ([]*types.Block, error) {
 	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse Content-Type header")
@@ -255,15 +414,30 @@ func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response) ([]*
 		return nil, errors.Errorf("%s boundary not found", utils.MultiPartFormData)
 	}
 
-	mr := multipart.NewReader(resp.Body, boundary)
+	codec := resp.Header.Get(ContentEncodingHeader)
+	if codec != "" {
+		maxBytes := uint64(math.MaxInt64)
+		if partBytes := maxBlockBytes + multipartPartOverheadBytes; numBlocks <= maxBytes/partBytes {
+			maxBytes = numBlocks * partBytes
+		}
+		if body, err = newDecompressor(codec, body, maxBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	mr := multipart.NewReader(body, boundary)
 	var blocks []*types.Block
 	var totalBytes int
 	for part, errP := mr.NextPart(); errP == nil; part, errP = mr.NextPart() {
 		lg.Debugf("reading part: %s, block: %s", part.FormName(), part.FileName())
-		blockBytes, err := ioutil.ReadAll(part)
+		// a block larger than maxBlockBytes is read one byte over
+		blockBytes, err := ioutil.ReadAll(io.LimitReader(part, limitOver(maxBlockBytes)))
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to read block, part name: %s, file name: %s", part.FormName(), part.FileName())
 		}
+		if uint64(len(blockBytes)) > maxBlockBytes {
+			return nil, errors.Errorf("block is larger than %d bytes, part name: %s, file name: %s", maxBlockBytes, part.FormName(), part.FileName())
+		}
 
 		block := &types.Block{}
 		if err := proto.Unmarshal(blockBytes, block); err != nil {
@@ -273,7 +447,7 @@ func multipartResponseToBlocks(lg *logger.SugarLogger, resp *http.Response) ([]*
 		totalBytes += len(blockBytes)
 	}
 
-	lg.Debugf("num blocks: %d, total-bytes: %d", len(blocks), totalBytes)
+	lg.Debugf("num blocks: %d, total-bytes: %d, codec: [%s]", len(blocks), totalBytes, codec)
 	if len(blocks) == 0 {
 		return nil, errors.Errorf("empty %s, no blocks found", utils.MultiPartFormData)
 	}
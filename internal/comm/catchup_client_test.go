@@ -5,6 +5,7 @@ package comm_test
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
@@ -15,8 +16,10 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	"github.com/hyperledger-labs/orion-server/internal/comm"
 	"github.com/hyperledger-labs/orion-server/internal/comm/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/mtree"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
@@ -95,6 +98,35 @@ func TestCatchUpClient_GetHeight(t *testing.T) {
 	require.Equal(t, uint64(5), h)
 }
 
+func TestCatchUpClient_GetProtocolVersion(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+
+	tr1, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 0, 5)
+	require.NoError(t, err)
+	defer tr1.Close()
+
+	cc := comm.NewCatchUpClient(lg, nil)
+	require.NotNil(t, cc)
+	err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+	require.NoError(t, err)
+
+	v, err := cc.GetProtocolVersion(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, &comm.VersionResponse{ProtocolVersion: comm.ProtocolVersion, MinProtocolVersion: comm.MinProtocolVersion}, v)
+
+	v, err = cc.GetProtocolVersion(context.Background(), 3)
+	require.EqualError(t, err, "target ID [3] not found")
+	require.Nil(t, v)
+}
+
 func TestCatchUpClient_GetBlocks(t *testing.T) {
 	lg, err := logger.New(&logger.Config{
 		Level:         "info",
@@ -167,6 +199,111 @@ func TestCatchUpClient_GetBlocks(t *testing.T) {
 	})
 }
 
+func TestCatchUpClient_GetBlocksCompressed(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+	localConfigs[0].Replication.Compression.Codecs = []string{comm.CodecGzip, comm.CodecSnappy}
+
+	tr1, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 0, 5)
+	require.NoError(t, err)
+	defer tr1.Close()
+
+	for _, codecs := range [][]string{{comm.CodecSnappy}, {comm.CodecGzip}, {"br"}, nil} {
+		t.Run(fmt.Sprintf("client codecs: %v", codecs), func(t *testing.T) {
+			cc := comm.NewCatchUpClient(lg, nil)
+			require.NotNil(t, cc)
+			cc.SetCodecs(codecs)
+			err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+			require.NoError(t, err)
+
+			blocks, err := cc.GetBlocks(context.Background(), 1, 2, 4)
+			require.NoError(t, err)
+			require.Equal(t, 3, len(blocks))
+			for i, block := range blocks {
+				require.Equal(t, uint64(i+2), block.GetHeader().GetBaseHeader().GetNumber())
+			}
+		})
+	}
+
+	for _, codecs := range [][]string{{comm.CodecGzip}, nil} {
+		t.Run(fmt.Sprintf("block too large, client codecs: %v", codecs), func(t *testing.T) {
+			cc := comm.NewCatchUpClient(lg, nil)
+			cc.SetCodecs(codecs)
+			cc.SetMaxBlockSize(8)
+			err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+			require.NoError(t, err)
+
+			blocks, err := cc.GetBlocks(context.Background(), 1, 2, 4)
+			require.EqualError(t, err, "block is larger than 16 bytes, part name: block-0, file name: num-2")
+			require.Nil(t, blocks)
+		})
+	}
+}
+
+func TestCatchUpClient_GetBlocksThrottled(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+	localConfigs[1].Replication.Throttle.CatchUpBytesPerSecond = 1000
+
+	tr1, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 0, 5)
+	require.NoError(t, err)
+	defer tr1.Close()
+	tr2, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 1, 5)
+	require.NoError(t, err)
+	defer tr2.Close()
+
+	t.Run("not throttled", func(t *testing.T) {
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+		require.NoError(t, err)
+
+		start := time.Now()
+		blocks, err := cc.GetBlocks(context.Background(), 1, 2, 4)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(blocks))
+		require.True(t, time.Since(start) < 200*time.Millisecond)
+	})
+
+	t.Run("server throttled", func(t *testing.T) {
+		cc := comm.NewCatchUpClient(lg, nil)
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+		require.NoError(t, err)
+
+		start := time.Now()
+		blocks, err := cc.GetBlocks(context.Background(), 2, 2, 4)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(blocks))
+		require.True(t, time.Since(start) >= 200*time.Millisecond)
+	})
+
+	t.Run("client throttled", func(t *testing.T) {
+		cc := comm.NewCatchUpClient(lg, nil)
+		cc.SetThrottle(comm.NewThrottle(1000))
+		err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+		require.NoError(t, err)
+
+		start := time.Now()
+		blocks, err := cc.GetBlocks(context.Background(), 1, 2, 4)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(blocks))
+		require.True(t, time.Since(start) >= 200*time.Millisecond)
+	})
+}
+
 func TestCatchUpClient_PullBlocks(t *testing.T) {
 	lg, err := logger.New(&logger.Config{
 		Level:         "info",
@@ -195,10 +332,10 @@ func TestCatchUpClient_PullBlocks(t *testing.T) {
 	blocks, err := cc.PullBlocks(context.Background(), 1, 3, 1)
 	require.NoError(t, err)
 	require.Equal(t, 3, len(blocks))
-	//get some from the leader hint
+	//get some from the leader hint, and the rest from member 2
 	blocks, err = cc.PullBlocks(context.Background(), 1, 8, 1)
 	require.NoError(t, err)
-	require.Equal(t, 5, len(blocks))
+	require.Equal(t, 8, len(blocks))
 	//get all from member 2, wrong leader hint
 	blocks, err = cc.PullBlocks(context.Background(), 6, 9, 1)
 	require.NoError(t, err)
@@ -274,6 +411,131 @@ func TestCatchUpClient_PullBlocksLoop(t *testing.T) {
 	}
 }
 
+func TestCatchUpClient_PullBlocksParallelRanges(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	rangeSize := comm.CatchUpRangeSize
+	comm.CatchUpRangeSize = 10
+	defer func() {
+		comm.CatchUpRangeSize = rangeSize
+	}()
+
+	localConfigs, sharedConfig := newTestSetup(t, 4)
+
+	tr1, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 0, 100)
+	require.NoError(t, err)
+	defer tr1.Close()
+
+	tr2, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 1, 100)
+	require.NoError(t, err)
+	defer tr2.Close()
+
+	cc := comm.NewCatchUpClient(lg, nil)
+	require.NotNil(t, cc)
+	err = cc.UpdateMembers(sharedConfig.ConsensusConfig.Members)
+	require.NoError(t, err)
+
+	t.Run("a call pulls up to the max parallel ranges", func(t *testing.T) {
+		blocks, err := cc.PullBlocks(context.Background(), 1, 100, 1)
+		require.NoError(t, err)
+		require.Len(t, blocks, 40)
+		for i, block := range blocks {
+			require.Equal(t, uint64(i+1), block.GetHeader().GetBaseHeader().GetNumber())
+		}
+
+		blocks, err = cc.PullBlocks(context.Background(), 95, 100, 0)
+		require.NoError(t, err)
+		require.Len(t, blocks, 6)
+	})
+
+	t.Run("blocks of a forked member are skipped", func(t *testing.T) {
+		// member 3 serves a ledger that forks at block 30, its blocks are discarded whenever it is asked for them
+		tr3, _, err := startTransport(t, lg, localConfigs, sharedConfig, 2, newLinkedLedger(t, 100, 30))
+		require.NoError(t, err)
+		defer tr3.Close()
+
+		ledger4 := &memLedger{}
+		var num uint64
+		for num < 100 {
+			blocks, err := cc.PullBlocks(context.Background(), num+1, 100, 1)
+			require.NoError(t, err)
+			for _, block := range blocks {
+				if num > 0 {
+					prev, err := ledger4.Get(num)
+					require.NoError(t, err)
+					require.NoError(t, comm.VerifyBlockLink(prev, block))
+				}
+				require.NoError(t, ledger4.Append(block))
+				num = block.Header.BaseHeader.Number
+			}
+		}
+
+		expected, err := newLinkedLedger(t, 100, 0).Get(100)
+		require.NoError(t, err)
+		last, err := ledger4.Get(100)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(expected, last))
+	})
+}
+
+func TestVerifyBlockLink(t *testing.T) {
+	ledger := newLinkedLedger(t, 3, 0)
+	forked := newLinkedLedger(t, 3, 2)
+
+	block1, err := ledger.Get(1)
+	require.NoError(t, err)
+	block2, err := ledger.Get(2)
+	require.NoError(t, err)
+	block3, err := ledger.Get(3)
+	require.NoError(t, err)
+	forkedBlock2, err := forked.Get(2)
+	require.NoError(t, err)
+
+	require.NoError(t, comm.VerifyBlockLink(block1, block2))
+	require.NoError(t, comm.VerifyBlockLink(block2, block3))
+	// the forked block 2 is not linked to block 1, and block 3 is not linked to it
+	err = comm.VerifyBlockLink(block1, forkedBlock2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "of block [2] does not match the base header hash")
+	err = comm.VerifyBlockLink(forkedBlock2, block3)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "of block [3] does not match the base header hash")
+
+	// block 3 is linked to the base header of block 2, but not to its header
+	tampered := proto.Clone(block2).(*types.Block)
+	tampered.Header.ValidationInfo[0].Flag = types.Flag_INVALID_NO_PERMISSION
+	err = comm.VerifyBlockLink(tampered, block3)
+	require.EqualError(t, err, fmt.Sprintf("the skip chain hashes of block [3] do not link it to the header hash [%x] of block [2]", mustBlockHash(t, tampered)))
+}
+
+func TestVerifyBlockTxs(t *testing.T) {
+	block, err := newLinkedLedger(t, 1, 0).Get(1)
+	require.NoError(t, err)
+	require.NoError(t, comm.VerifyBlockTxs(block))
+
+	tampered := proto.Clone(block).(*types.Block)
+	tampered.GetDataTxEnvelopes().Envelopes[0].Payload.TxId = "tampered"
+	err = comm.VerifyBlockTxs(tampered)
+	require.EqualError(t, err, fmt.Sprintf("the transactions of block [1] do not match its tx Merkle tree root hash [%x]", block.Header.TxMerkelTreeRootHash))
+
+	tampered = proto.Clone(block).(*types.Block)
+	tampered.Header.ValidationInfo = nil
+	err = comm.VerifyBlockTxs(tampered)
+	require.EqualError(t, err, "block [1] has [1] transactions, but [0] validation info entries")
+}
+
+func mustBlockHash(t *testing.T, block *types.Block) []byte {
+	hash, err := blockstore.ComputeBlockHash(block)
+	require.NoError(t, err)
+	return hash
+}
+
 // Scenario:
 // - Define a 3 node cluster.
 // - Start pulling blocks until block 150.
@@ -459,10 +721,10 @@ func TestCatchUpClient_PullBlocksWithTLS(t *testing.T) {
 	blocks, err := cc.PullBlocks(context.Background(), 1, 3, 1)
 	require.NoError(t, err)
 	require.Equal(t, 3, len(blocks))
-	//get some from the leader hint
+	//get some from the leader hint, and the rest from member 2
 	blocks, err = cc.PullBlocks(context.Background(), 1, 8, 1)
 	require.NoError(t, err)
-	require.Equal(t, 5, len(blocks))
+	require.Equal(t, 8, len(blocks))
 	//get all from member 2, wrong leader hint
 	blocks, err = cc.PullBlocks(context.Background(), 6, 9, 1)
 	require.NoError(t, err)
@@ -478,10 +740,11 @@ func TestCatchUpClient_PullBlocksWithTLS(t *testing.T) {
 }
 
 func startTransportWithLedger(t *testing.T, lg *logger.SugarLogger, localConfigs []*config.LocalConfiguration, sharedConfig *types.ClusterConfig, index, height uint64) (*comm.HTTPTransport, *mocks.ConsensusListener, error) {
-	ledger := &memLedger{}
-	for n := uint64(1); n <= height; n++ {
-		ledger.Append(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: n}}})
-	}
+	ledger := newLinkedLedger(t, height, 0)
+	return startTransport(t, lg, localConfigs, sharedConfig, index, ledger)
+}
+
+func startTransport(t *testing.T, lg *logger.SugarLogger, localConfigs []*config.LocalConfiguration, sharedConfig *types.ClusterConfig, index uint64, ledger *memLedger) (*comm.HTTPTransport, *mocks.ConsensusListener, error) {
 	cl := &mocks.ConsensusListener{}
 	tr, err := comm.NewHTTPTransport(&comm.Config{
 		LocalConf:    localConfigs[index],
@@ -499,3 +762,49 @@ func startTransportWithLedger(t *testing.T, lg *logger.SugarLogger, localConfigs
 	require.NoError(t, err)
 	return tr, cl, err
 }
+
+// newLinkedLedger creates a ledger of blocks linked by the hash of the previous base header, and by the header hash of
+// the previous block, each carrying a transaction that matches its tx Merkle tree root. If forkAt is not zero,
+// the blocks from forkAt on differ from the blocks of the same number in a ledger that is not forked, and block forkAt
+// is not linked to the block before it.
+func newLinkedLedger(t *testing.T, height uint64, forkAt uint64) *memLedger {
+	ledger := &memLedger{}
+	var prevBaseHash, prevHash []byte
+	for n := uint64(1); n <= height; n++ {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                 n,
+					PreviousBaseHeaderHash: prevBaseHash,
+				},
+				ValidationInfo: []*types.ValidationInfo{{Flag: types.Flag_VALID}},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{Payload: &types.DataTx{TxId: fmt.Sprintf("tx%d", n)}},
+					},
+				},
+			},
+		}
+		if prevHash != nil {
+			block.Header.SkipchainHashes = [][]byte{prevHash}
+		}
+		if forkAt != 0 && n >= forkAt {
+			block.Header.BaseHeader.LastCommittedBlockHash = []byte("fork")
+		}
+		if n == forkAt {
+			block.Header.BaseHeader.PreviousBaseHeaderHash = []byte("fork")
+		}
+		root, err := mtree.BuildTreeForBlockTx(block)
+		require.NoError(t, err)
+		block.Header.TxMerkelTreeRootHash = root.Hash()
+		require.NoError(t, ledger.Append(block))
+
+		prevBaseHash, err = blockstore.ComputeBlockBaseHash(block)
+		require.NoError(t, err)
+		prevHash, err = blockstore.ComputeBlockHash(block)
+		require.NoError(t, err)
+	}
+	return ledger
+}
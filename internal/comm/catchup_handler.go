@@ -4,9 +4,12 @@
 package comm
 
 import (
+	"encoding/hex"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/gorilla/mux"
@@ -19,6 +22,8 @@ const (
 	BCDBPeerEndpoint = "/bcdb-peer/"
 	GetBlocksPath    = BCDBPeerEndpoint + "blocks"
 	GetHeightPath    = BCDBPeerEndpoint + "height"
+	GetVersionPath   = BCDBPeerEndpoint + "version"
+	GetChunkPath     = BCDBPeerEndpoint + "chunks/"
 
 	maxResponseBytesDefault = 100 * 1024 * 1024 // protects the server against huge requests from a client
 )
@@ -30,11 +35,23 @@ type LedgerReader interface {
 	Get(blockNumber uint64) (*types.Block, error)
 }
 
+// BlobReader reads the chunks of the blobs held by the blob store of the node.
+type BlobReader interface {
+	// GetChunk returns the chunk with the given hash, or nil if the blob store does not hold it.
+	GetChunk(hash []byte) ([]byte, error)
+}
+
 type catchupHandler struct {
 	router           *mux.Router
 	lg               *logger.SugarLogger
 	ledgerReader     LedgerReader
 	maxResponseBytes int
+	// codecs are the codecs that may compress the blocks of a response, in order of preference
+	codecs []string
+	// throttle limits the rate at which the blocks of all responses are sent
+	throttle *Throttle
+	// blobReader serves the chunks of the blobs, which the members that commit a blob uploaded to another node pull
+	blobReader BlobReader
 }
 
 func NewCatchupHandler(lg *logger.SugarLogger, ledgerReader LedgerReader, maxResponseBytes int) *catchupHandler {
@@ -51,12 +68,45 @@ func NewCatchupHandler(lg *logger.SugarLogger, ledgerReader LedgerReader, maxRes
 
 	h.router.HandleFunc(GetBlocksPath, h.blocksRequest).Methods(http.MethodGet).Headers("Accept", "multipart/form-data").Queries("start", "{startId:[0-9]+}", "end", "{endId:[0-9]+}")
 	h.router.HandleFunc(GetHeightPath, h.heightRequest).Methods(http.MethodGet)
+	h.router.HandleFunc(GetVersionPath, h.versionRequest).Methods(http.MethodGet)
+	h.router.HandleFunc(GetChunkPath+"{hash:[0-9a-f]{64}}", h.chunkRequest).Methods(http.MethodGet)
 
 	return h
 }
 
+// SetCodecs sets the codecs that may compress the blocks of a response, in order of preference. The codec of a
+// response is the first codec that is accepted by the client. It must be called before the handler serves requests.
+func (h *catchupHandler) SetCodecs(codecs []string) {
+	h.codecs = codecs
+}
+
+// SetThrottle sets the throttle that limits the rate at which the blocks of all responses are sent. A nil throttle
+// does not limit the rate. It must be called before the handler serves requests.
+func (h *catchupHandler) SetThrottle(throttle *Throttle) {
+	h.throttle = throttle
+}
+
+// SetBlobReader sets the reader of the chunks of the blobs served to the members. Without a reader, no chunk is found.
+// It must be called before the handler serves requests.
+func (h *catchupHandler) SetBlobReader(blobReader BlobReader) {
+	h.blobReader = blobReader
+}
+
 func (h *catchupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.lg.Debugf("request: %s", r.URL)
+
+	w.Header().Set(ProtocolVersionHeader, strconv.FormatUint(uint64(ProtocolVersion), 10))
+	peerVersion, err := parseProtocolVersion(r.Header.Get(ProtocolVersionHeader))
+	if err != nil {
+		utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+	if peerVersion < MinProtocolVersion {
+		utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{
+			ErrMsg: fmt.Sprintf("peer protocol version [%d] is not supported, minimal supported version is [%d]", peerVersion, MinProtocolVersion)})
+		return
+	}
+
 	h.router.ServeHTTP(w, r)
 }
 
@@ -114,11 +164,28 @@ func (h *catchupHandler) blocksRequest(response http.ResponseWriter, request *ht
 		blocks = append(blocks, blockBytes)
 	}
 
-	sendHTTPMultiPartResponse(response, blocks, startBlockNum)
+	codec := negotiateCodec(request.Header.Get(AcceptEncodingHeader), h.codecs)
+	h.lg.Debugf("sending %d blocks starting at [%d], codec: [%s]", len(blocks), startBlockNum, codec)
+	sendHTTPMultiPartResponse(response, blocks, startBlockNum, codec, h.throttle.Writer(request.Context(), response))
 }
 
-func sendHTTPMultiPartResponse(w http.ResponseWriter, blocks [][]byte, startBlockNum uint64) {
-	mw := multipart.NewWriter(w)
+// sendHTTPMultiPartResponse sends the blocks as a multipart response, compressed as a whole with the codec, if any.
+// The response body is written to body, which wraps w, e.g., to throttle it. The maximal size of a response applies
+// to the blocks before they are compressed.
+func sendHTTPMultiPartResponse(w http.ResponseWriter, blocks [][]byte, startBlockNum uint64, codec string, body io.Writer) {
+	if codec != "" {
+		compressor, err := newCompressor(codec, body)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return
+		}
+		defer compressor.Close()
+
+		w.Header().Set(ContentEncodingHeader, codec)
+		body = compressor
+	}
+
+	mw := multipart.NewWriter(body)
 	w.Header().Set("Content-Type", mw.FormDataContentType())
 	for i, blockBytes := range blocks {
 		fw, err := mw.CreateFormFile(
@@ -153,3 +220,35 @@ func (h *catchupHandler) heightRequest(w http.ResponseWriter, r *http.Request) {
 
 	utils.SendHTTPResponse(w, http.StatusOK, HeightResponse{Height: height})
 }
+
+func (h *catchupHandler) versionRequest(w http.ResponseWriter, r *http.Request) {
+	h.lg.Debugf("version request: %s", r.URL)
+	utils.SendHTTPResponse(w, http.StatusOK, VersionResponse{ProtocolVersion: ProtocolVersion, MinProtocolVersion: MinProtocolVersion})
+}
+
+func (h *catchupHandler) chunkRequest(w http.ResponseWriter, r *http.Request) {
+	h.lg.Debugf("chunk request: %s", r.URL)
+	hash, err := hex.DecodeString(mux.Vars(r)["hash"])
+	if err != nil {
+		utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	var chunk []byte
+	if h.blobReader != nil {
+		if chunk, err = h.blobReader.GetChunk(hash); err != nil {
+			utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return
+		}
+	}
+	if chunk == nil {
+		utils.SendHTTPResponse(w, http.StatusNotFound, &types.HttpResponseErr{ErrMsg: fmt.Sprintf("chunk [%x] not found", hash)})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err = h.throttle.Writer(r.Context(), w).Write(chunk); err != nil {
+		h.lg.Warnf("failed to send chunk [%x]: %s", hash, err)
+	}
+}
@@ -4,6 +4,8 @@
 package comm_test
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -90,6 +92,70 @@ func TestCatchupHandler_ServeHTTP_Height(t *testing.T) {
 	})
 }
 
+func TestCatchupHandler_ServeHTTP_Version(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	t.Run("version ok", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, 0)
+		require.NotNil(t, h)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetVersionPath, nil)
+		req.Header.Set("Accept", "application/json")
+
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		assert.Equal(t, fmt.Sprintf("%d", comm.ProtocolVersion), resp.Result().Header.Get(comm.ProtocolVersionHeader))
+
+		vResp := &comm.VersionResponse{}
+		err = json.NewDecoder(resp.Result().Body).Decode(vResp)
+		require.NoError(t, err)
+		assert.Equal(t, &comm.VersionResponse{ProtocolVersion: comm.ProtocolVersion, MinProtocolVersion: comm.MinProtocolVersion}, vResp)
+	})
+
+	t.Run("bad: peer version not supported", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, 0)
+		require.NotNil(t, h)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetHeightPath, nil)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set(comm.ProtocolVersionHeader, "0")
+
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Result().StatusCode)
+
+		errResp := &types.HttpResponseErr{}
+		err = json.NewDecoder(resp.Result().Body).Decode(errResp)
+		require.NoError(t, err)
+		assert.Equal(t, "peer protocol version [0] is not supported, minimal supported version is [1]", errResp.ErrMsg)
+	})
+
+	t.Run("bad: peer version malformed", func(t *testing.T) {
+		h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, 0)
+		require.NotNil(t, h)
+
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetHeightPath, nil)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set(comm.ProtocolVersionHeader, "v2")
+
+		h.ServeHTTP(resp, req)
+		assert.Equal(t, http.StatusBadRequest, resp.Result().StatusCode)
+
+		errResp := &types.HttpResponseErr{}
+		err = json.NewDecoder(resp.Result().Body).Decode(errResp)
+		require.NoError(t, err)
+		assert.Contains(t, errResp.ErrMsg, "failed to parse protocol version [v2]")
+	})
+}
+
 func TestCatchupHandler_ServeHTTP_Blocks(t *testing.T) {
 	lg, err := logger.New(&logger.Config{
 		Level:         "debug",
@@ -361,3 +427,123 @@ func TestCatchupHandler_ServeHTTP_LargeResponse(t *testing.T) {
 		require.Equal(t, uint64(3), bNum) // block 2 in response
 	})
 }
+
+func TestCatchupHandler_ServeHTTP_Compression(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	ledger1 := &memLedger{}
+	for n := uint64(1); n < 6; n++ {
+		ledger1.Append(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: n}}})
+	}
+
+	h := comm.NewCatchupHandler(lg, ledger1, 0)
+	require.NotNil(t, h)
+	h.SetCodecs([]string{comm.CodecGzip, comm.CodecSnappy})
+
+	testCases := []struct {
+		name           string
+		acceptEncoding string
+		expectedCodec  string
+	}{
+		{name: "snappy", acceptEncoding: "snappy", expectedCodec: comm.CodecSnappy},
+		{name: "gzip", acceptEncoding: "GZIP, snappy", expectedCodec: comm.CodecGzip},
+		{name: "gzip rejected", acceptEncoding: "gzip;q=0, snappy", expectedCodec: comm.CodecSnappy},
+		{name: "not enabled", acceptEncoding: "br", expectedCodec: ""},
+		{name: "identity", acceptEncoding: "identity", expectedCodec: ""},
+		{name: "no header", acceptEncoding: "", expectedCodec: ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, comm.GetBlocksPath, nil)
+			q := req.URL.Query()
+			q.Add("start", "2")
+			q.Add("end", "4")
+			req.URL.RawQuery = q.Encode()
+			req.Header.Set("Accept", utils.MultiPartFormData)
+			if tt.acceptEncoding != "" {
+				req.Header.Set(comm.AcceptEncodingHeader, tt.acceptEncoding)
+			}
+
+			h.ServeHTTP(resp, req)
+			require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+			require.Equal(t, tt.expectedCodec, resp.Result().Header.Get(comm.ContentEncodingHeader))
+
+			body, err := ioutil.ReadAll(resp.Result().Body)
+			require.NoError(t, err)
+			if tt.expectedCodec != "" {
+				body, err = comm.Decompress(tt.expectedCodec, body, comm.DefaultMaxDecompressedBlockBytes)
+				require.NoError(t, err)
+			}
+
+			_, params, err := mime.ParseMediaType(resp.Result().Header.Get("Content-Type"))
+			require.NoError(t, err)
+			mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+			bNum := uint64(2)
+			for part, errP := mr.NextPart(); errP == nil; part, errP = mr.NextPart() {
+				blockBytes, err := ioutil.ReadAll(part)
+				require.NoError(t, err)
+
+				block := &types.Block{}
+				err = proto.Unmarshal(blockBytes, block)
+				require.NoError(t, err)
+				assert.Equal(t, bNum, block.Header.BaseHeader.Number)
+				bNum++
+			}
+			require.Equal(t, uint64(5), bNum)
+		})
+	}
+}
+
+type chunkMap map[string][]byte
+
+func (m chunkMap) GetChunk(hash []byte) ([]byte, error) {
+	return m[string(hash)], nil
+}
+
+func TestCatchupHandler_ServeHTTP_Chunk(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	chunk := []byte("0123")
+	hash := sha256.Sum256(chunk)
+	h := comm.NewCatchupHandler(lg, &mocks.LedgerReader{}, 0)
+	h.SetBlobReader(chunkMap{string(hash[:]): chunk})
+
+	t.Run("chunk ok", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetChunkPath+fmt.Sprintf("%x", hash), nil)
+		req.Header.Set("Accept", "application/octet-stream")
+
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Result().StatusCode)
+		body, err := ioutil.ReadAll(resp.Result().Body)
+		require.NoError(t, err)
+		require.Equal(t, chunk, body)
+	})
+
+	t.Run("chunk not found", func(t *testing.T) {
+		missing := sha256.Sum256([]byte("4567"))
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, comm.GetChunkPath+fmt.Sprintf("%x", missing), nil)
+		req.Header.Set("Accept", "application/octet-stream")
+
+		h.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusNotFound, resp.Result().StatusCode)
+		errResp := &types.HttpResponseErr{}
+		require.NoError(t, json.NewDecoder(resp.Result().Body).Decode(errResp))
+		require.Equal(t, fmt.Sprintf("chunk [%x] not found", missing), errResp.ErrMsg)
+	})
+}
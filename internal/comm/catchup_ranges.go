@@ -0,0 +1,208 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/mtree"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// pullRanges pulls up to CatchUpMaxParallelRanges ranges of the blocks [start, end] in parallel, and returns the
+// longest verified prefix of the blocks pulled. Range i is requested first from member i, in the order of memberIDs,
+// so that the ranges are spread across the members.
+func (c *catchUpClient) pullRanges(ctx context.Context, start, end uint64, memberIDs []uint64) []*types.Block {
+	if len(memberIDs) == 0 || start > end {
+		return nil
+	}
+
+	rangeSize := CatchUpRangeSize
+	if rangeSize == 0 {
+		rangeSize = end - start + 1
+	}
+	maxRanges := CatchUpMaxParallelRanges
+	if maxRanges < 1 {
+		maxRanges = 1
+	}
+
+	type blockRange struct {
+		first, last uint64
+	}
+	var ranges []blockRange
+	for first := start; len(ranges) < maxRanges; first += rangeSize {
+		last := first + rangeSize - 1
+		if last > end || last < first {
+			last = end
+		}
+		ranges = append(ranges, blockRange{first: first, last: last})
+		if last == end {
+			break
+		}
+	}
+
+	results := make([][]*types.Block, len(ranges))
+	var wg sync.WaitGroup
+	for i, r := range ranges {
+		order := make([]uint64, 0, len(memberIDs))
+		order = append(order, memberIDs[i%len(memberIDs):]...)
+		order = append(order, memberIDs[:i%len(memberIDs)]...)
+
+		wg.Add(1)
+		go func(i int, r blockRange, order []uint64) {
+			defer wg.Done()
+			results[i] = c.pullRange(ctx, r.first, r.last, order)
+		}(i, r, order)
+	}
+	wg.Wait()
+
+	var blocks []*types.Block
+	for i, rangeBlocks := range results {
+		if len(rangeBlocks) == 0 {
+			break
+		}
+		if len(blocks) > 0 {
+			if err := VerifyBlockLink(blocks[len(blocks)-1], rangeBlocks[0]); err != nil {
+				c.logger.Warnf("Discarding blocks [%d,%d]: %s", ranges[i].first, end, err)
+				break
+			}
+		}
+		blocks = append(blocks, rangeBlocks...)
+
+		// the blocks that follow a range that was not pulled entirely are not in sequence
+		if uint64(len(rangeBlocks)) < ranges[i].last-ranges[i].first+1 {
+			break
+		}
+	}
+
+	return blocks
+}
+
+// pullRange pulls the blocks [first, last] from the members, in the order given, and returns the prefix of the range
+// pulled. A member that serves only part of the range, as a response is limited in size, is asked for the rest of it.
+// A member that fails, or that serves blocks that are out of sequence or not linked to each other, is skipped.
+func (c *catchUpClient) pullRange(ctx context.Context, first, last uint64, memberIDs []uint64) []*types.Block {
+	var blocks []*types.Block
+	next := first
+
+	for _, id := range memberIDs {
+		for next <= last {
+			if ctx.Err() != nil {
+				return blocks
+			}
+
+			received, err := c.GetBlocks(ctx, id, next, last)
+			if err != nil {
+				c.logger.Debugf("failed to get blocks [%d,%d] from member [%d], error: %s", next, last, id, err)
+				break
+			}
+
+			var prev *types.Block
+			if len(blocks) > 0 {
+				prev = blocks[len(blocks)-1]
+			}
+			if err := verifyBlocks(prev, next, last, received); err != nil {
+				c.logger.Warnf("Member [%d] served invalid blocks for the range [%d,%d]: %s", id, next, last, err)
+				break
+			}
+
+			blocks = append(blocks, received...)
+			next += uint64(len(received))
+		}
+
+		if next > last {
+			break
+		}
+	}
+
+	return blocks
+}
+
+// verifyBlocks verifies that the blocks are numbered in sequence from first, up to last, that the transactions of every
+// block match its header, and that every block is linked to the block before it. The first block is linked to prev,
+// unless prev is nil.
+func verifyBlocks(prev *types.Block, first, last uint64, blocks []*types.Block) error {
+	for i, block := range blocks {
+		number := block.GetHeader().GetBaseHeader().GetNumber()
+		if expected := first + uint64(i); number != expected || number > last {
+			return errors.Errorf("received block number [%d] while expecting block number [%d] in range [%d,%d]", number, expected, first, last)
+		}
+
+		if err := VerifyBlockTxs(block); err != nil {
+			return err
+		}
+
+		if prev != nil {
+			if err := VerifyBlockLink(prev, block); err != nil {
+				return err
+			}
+		}
+		prev = block
+	}
+
+	return nil
+}
+
+// VerifyBlockLink verifies that the previous base header hash of a block is the hash of the base header of the block
+// that precedes it, and that the first skip chain hash of the block is the hash of the whole header of that block, which
+// covers its tx Merkle tree root and its validation info.
+func VerifyBlockLink(prev, block *types.Block) error {
+	prevNum := prev.GetHeader().GetBaseHeader().GetNumber()
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+
+	prevBaseHash, err := blockstore.ComputeBlockBaseHash(prev)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compute the base hash of block [%d]", prevNum)
+	}
+
+	if !bytes.Equal(prevBaseHash, block.GetHeader().GetBaseHeader().GetPreviousBaseHeaderHash()) {
+		return errors.Errorf("previous base header hash [%x] of block [%d] does not match the base header hash [%x] of block [%d]",
+			block.GetHeader().GetBaseHeader().GetPreviousBaseHeaderHash(), blockNum, prevBaseHash, prevNum)
+	}
+
+	prevHash, err := blockstore.ComputeBlockHash(prev)
+	if err != nil {
+		return errors.Wrapf(err, "failed to compute the hash of block [%d]", prevNum)
+	}
+
+	// the first skip chain hash of a block links it to the block that precedes it
+	if skipchainHashes := block.GetHeader().GetSkipchainHashes(); len(skipchainHashes) == 0 || !bytes.Equal(prevHash, skipchainHashes[0]) {
+		return errors.Errorf("the skip chain hashes of block [%d] do not link it to the header hash [%x] of block [%d]",
+			blockNum, prevHash, prevNum)
+	}
+
+	return nil
+}
+
+// VerifyBlockTxs verifies that the transactions of a block, along with their validation info, are the ones the tx
+// Merkle tree root in the header of the block was computed from.
+func VerifyBlockTxs(block *types.Block) error {
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+
+	// every transaction is hashed along with its validation info
+	txs := 1
+	if envelopes := block.GetDataTxEnvelopes(); envelopes != nil {
+		txs = len(envelopes.GetEnvelopes())
+	}
+	if len(block.GetHeader().GetValidationInfo()) != txs {
+		return errors.Errorf("block [%d] has [%d] transactions, but [%d] validation info entries",
+			blockNum, txs, len(block.GetHeader().GetValidationInfo()))
+	}
+
+	root, err := mtree.BuildTreeForBlockTx(block)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to build the tx Merkle tree of block [%d]", blockNum)
+	}
+
+	if !bytes.Equal(root.Hash(), block.GetHeader().GetTxMerkelTreeRootHash()) {
+		return errors.Errorf("the transactions of block [%d] do not match its tx Merkle tree root hash [%x]",
+			blockNum, block.GetHeader().GetTxMerkelTreeRootHash())
+	}
+
+	return nil
+}
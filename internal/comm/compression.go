@@ -0,0 +1,202 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+const (
+	// CodecSnappy compresses with the framing format of snappy, which is fast, with a moderate ratio.
+	CodecSnappy = "snappy"
+	// CodecGzip compresses with gzip, which is slower than snappy, with a higher ratio.
+	CodecGzip = "gzip"
+
+	// AcceptEncodingHeader carries the codecs with which the client of a catch-up request accepts the blocks to be
+	// compressed, in order of preference.
+	AcceptEncodingHeader = "Accept-Encoding"
+	// ContentEncodingHeader carries the codec with which the blocks of a catch-up response are compressed. A missing
+	// header means that the blocks are not compressed, e.g., by a server that predates compression.
+	ContentEncodingHeader = "Content-Encoding"
+	// identityEncoding asks a server not to compress a response
+	identityEncoding = "identity"
+
+	// DefaultMaxDecompressedBlockBytes is the size above which the decompression of a block is aborted, when the size
+	// of blocks is not limited
+	DefaultMaxDecompressedBlockBytes = 256 * 1024 * 1024
+)
+
+// MaxDecompressedBlockBytes returns the size above which the decompression of a marshaled block is aborted, so that a
+// compressed payload that expands without bound cannot exhaust the memory. maxBlockBytes is the maximal size of a block
+// when it is cut, zero if not limited. A block grows after it is cut, by its consensus metadata, signatures and
+// validation info, hence the limit is twice maxBlockBytes.
+func MaxDecompressedBlockBytes(maxBlockBytes uint64) uint64 {
+	if maxBlockBytes == 0 {
+		return DefaultMaxDecompressedBlockBytes
+	}
+	if maxBlockBytes > math.MaxInt64/2 {
+		return math.MaxInt64
+	}
+	return 2 * maxBlockBytes
+}
+
+// VerifyCodecs checks that the codecs are supported, and that none is listed twice.
+func VerifyCodecs(codecs []string) error {
+	listed := make(map[string]bool)
+	for _, codec := range codecs {
+		switch codec {
+		case CodecSnappy, CodecGzip:
+		default:
+			return errors.Errorf("compression codec [%s] is not supported, supported codecs: [%s, %s]", codec, CodecSnappy, CodecGzip)
+		}
+		if listed[codec] {
+			return errors.Errorf("compression codec [%s] is listed more than once", codec)
+		}
+		listed[codec] = true
+	}
+	return nil
+}
+
+// acceptEncoding returns the value of the AcceptEncodingHeader of a request from a client that accepts the codecs.
+// A client that accepts no codec asks for the identity encoding, so that the response is not compressed.
+func acceptEncoding(codecs []string) string {
+	if len(codecs) == 0 {
+		return identityEncoding
+	}
+	return strings.Join(codecs, ", ")
+}
+
+// negotiateCodec returns the codec of a response: the first codec listed in the AcceptEncodingHeader of the request
+// that is enabled on the server. A codec with a quality value of zero is not accepted. It returns an empty string if
+// the response is not compressed.
+func negotiateCodec(acceptEncoding string, enabled []string) string {
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(item, ";")
+		codec := strings.ToLower(strings.TrimSpace(params[0]))
+		if isRejected(params[1:]) {
+			continue
+		}
+
+		for _, e := range enabled {
+			if e == codec {
+				return e
+			}
+		}
+	}
+	return ""
+}
+
+// isRejected checks whether the parameters of a codec in the AcceptEncodingHeader hold a quality value of zero.
+func isRejected(params []string) bool {
+	for _, p := range params {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) != "q" {
+			continue
+		}
+		if q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64); err == nil && q == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// newCompressor returns a writer that compresses with a codec what it writes to w. The writer must be closed to flush
+// the compressed stream; w is not closed.
+func newCompressor(codec string, w io.Writer) (io.WriteCloser, error) {
+	switch codec {
+	case CodecSnappy:
+		return snappy.NewBufferedWriter(w), nil
+	case CodecGzip:
+		return gzip.NewWriter(w), nil
+	default:
+		return nil, errors.Errorf("compression codec [%s] is not supported", codec)
+	}
+}
+
+// newDecompressor returns a reader that decompresses with a codec what it reads from r. The reader fails once it
+// decompressed more than maxBytes.
+func newDecompressor(codec string, r io.Reader, maxBytes uint64) (io.Reader, error) {
+	var zr io.Reader
+	switch codec {
+	case CodecSnappy:
+		zr = snappy.NewReader(r)
+	case CodecGzip:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the gzip header")
+		}
+		zr = gr
+	default:
+		return nil, errors.Errorf("compression codec [%s] is not supported", codec)
+	}
+
+	// the reader is limited one byte over maxBytes, so that reading that byte tells the payload is too large
+	return &limitedDecompressor{
+		codec:    codec,
+		maxBytes: maxBytes,
+		r:        &io.LimitedReader{R: zr, N: limitOver(maxBytes)},
+	}, nil
+}
+
+// limitedDecompressor fails the reads once more than maxBytes are decompressed
+type limitedDecompressor struct {
+	codec    string
+	maxBytes uint64
+	r        *io.LimitedReader
+}
+
+func (d *limitedDecompressor) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	if d.r.N <= 0 {
+		return n, errors.Errorf("the payload decompressed with codec [%s] is larger than %d bytes", d.codec, d.maxBytes)
+	}
+	return n, err
+}
+
+// limitOver returns the limit of a reader that reads one byte over maxBytes
+func limitOver(maxBytes uint64) int64 {
+	if maxBytes >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(maxBytes) + 1
+}
+
+// Compress compresses a payload with a codec.
+func Compress(codec string, payload []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, err := newCompressor(codec, buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(payload); err != nil {
+		return nil, errors.Wrapf(err, "failed to compress with codec [%s]", codec)
+	}
+	if err = w.Close(); err != nil {
+		return nil, errors.Wrapf(err, "failed to compress with codec [%s]", codec)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses a payload that was compressed with a codec. It fails if the decompressed payload is larger
+// than maxBytes.
+func Decompress(codec string, payload []byte, maxBytes uint64) ([]byte, error) {
+	r, err := newDecompressor(codec, bytes.NewReader(payload), maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	decompressed, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decompress with codec [%s]", codec)
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyCodecs(t *testing.T) {
+	require.NoError(t, comm.VerifyCodecs(nil))
+	require.NoError(t, comm.VerifyCodecs([]string{comm.CodecSnappy}))
+	require.NoError(t, comm.VerifyCodecs([]string{comm.CodecGzip, comm.CodecSnappy}))
+
+	err := comm.VerifyCodecs([]string{comm.CodecSnappy, "br"})
+	require.EqualError(t, err, "compression codec [br] is not supported, supported codecs: [snappy, gzip]")
+
+	err = comm.VerifyCodecs([]string{comm.CodecGzip, comm.CodecSnappy, comm.CodecGzip})
+	require.EqualError(t, err, "compression codec [gzip] is listed more than once")
+}
+
+func TestCompressDecompress(t *testing.T) {
+	payload := bytes.Repeat([]byte("orion block "), 1000)
+
+	for _, codec := range []string{comm.CodecSnappy, comm.CodecGzip} {
+		t.Run(codec, func(t *testing.T) {
+			compressed, err := comm.Compress(codec, payload)
+			require.NoError(t, err)
+			require.Less(t, len(compressed), len(payload))
+
+			decompressed, err := comm.Decompress(codec, compressed, uint64(len(payload)))
+			require.NoError(t, err)
+			require.Equal(t, payload, decompressed)
+
+			_, err = comm.Decompress(codec, payload, uint64(len(payload)))
+			require.Error(t, err)
+
+			decompressed, err = comm.Decompress(codec, compressed, uint64(len(payload)-1))
+			require.EqualError(t, err, fmt.Sprintf("failed to decompress with codec [%s]: the payload decompressed with codec [%s] is larger than %d bytes", codec, codec, len(payload)-1))
+			require.Nil(t, decompressed)
+		})
+	}
+
+	t.Run("not supported", func(t *testing.T) {
+		compressed, err := comm.Compress("br", payload)
+		require.EqualError(t, err, "compression codec [br] is not supported")
+		require.Nil(t, compressed)
+
+		decompressed, err := comm.Decompress("br", payload, uint64(len(payload)))
+		require.EqualError(t, err, "compression codec [br] is not supported")
+		require.Nil(t, decompressed)
+	})
+}
+
+func TestMaxDecompressedBlockBytes(t *testing.T) {
+	require.Equal(t, uint64(comm.DefaultMaxDecompressedBlockBytes), comm.MaxDecompressedBlockBytes(0))
+	require.Equal(t, uint64(8*1024*1024), comm.MaxDecompressedBlockBytes(4*1024*1024))
+	require.Equal(t, uint64(math.MaxInt64), comm.MaxDecompressedBlockBytes(math.MaxUint64))
+}
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -25,6 +26,7 @@ import (
 	stats "go.etcd.io/etcd/etcdserver/api/v2stats"
 	"go.etcd.io/etcd/pkg/transport"
 	etcd_types "go.etcd.io/etcd/pkg/types"
+	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
 )
 
@@ -65,6 +67,8 @@ type HTTPTransport struct {
 	catchupHandler  *catchupHandler
 	httpServer      *http.Server
 
+	snapshotThrottle *Throttle // limits the rate at which snapshots are sent
+
 	stopCh chan struct{} // signals HTTPTransport to shut-down
 	doneCh chan struct{} // signals HTTPTransport shutdown complete
 
@@ -75,6 +79,8 @@ type Config struct {
 	LocalConf    *config.LocalConfiguration
 	Logger       *logger.SugarLogger
 	LedgerReader LedgerReader
+	// BlobReader, if not nil, serves the chunks of the blobs held by the node to the members.
+	BlobReader BlobReader
 }
 
 // NewHTTPTransport creates a new instance of HTTPTransport.
@@ -82,6 +88,9 @@ func NewHTTPTransport(config *Config) (*HTTPTransport, error) {
 	if config.LocalConf.Replication.TLS.Enabled && config.LocalConf.Replication.TLS.ClientAuthRequired {
 		return nil, errors.New("TLS Client authentication not supported yet")
 	}
+	if err := VerifyCodecs(config.LocalConf.Replication.Compression.Codecs); err != nil {
+		return nil, errors.WithMessage(err, "error in the compression of server to server communication")
+	}
 
 	tr := &HTTPTransport{
 		logger:         config.Logger,
@@ -91,6 +100,7 @@ func NewHTTPTransport(config *Config) (*HTTPTransport, error) {
 		stopCh:         make(chan struct{}),
 		doneCh:         make(chan struct{}),
 	}
+	tr.snapshotThrottle = NewThrottle(config.LocalConf.Replication.Throttle.SnapshotBytesPerSecond)
 
 	if config.LocalConf.Replication.TLS.Enabled {
 		// load and check the CA certificates
@@ -176,6 +186,19 @@ func NewHTTPTransport(config *Config) (*HTTPTransport, error) {
 		}
 	}
 
+	tr.catchUpClient.SetCodecs(config.LocalConf.Replication.Compression.Codecs)
+	tr.catchupHandler.SetCodecs(config.LocalConf.Replication.Compression.Codecs)
+	tr.catchUpClient.SetThrottle(NewThrottle(config.LocalConf.Replication.Throttle.CatchUpBytesPerSecond))
+	maxBlockSize, err := config.LocalConf.BlockCreation.MaxBlockSizeInBytes()
+	if err != nil {
+		return nil, err
+	}
+	tr.catchUpClient.SetMaxBlockSize(maxBlockSize)
+	tr.catchupHandler.SetThrottle(NewThrottle(config.LocalConf.Replication.Throttle.CatchUpBytesPerSecond))
+	if config.BlobReader != nil {
+		tr.catchupHandler.SetBlobReader(config.BlobReader)
+	}
+
 	return tr, nil
 }
 
@@ -235,12 +258,18 @@ func (p *HTTPTransport) Start() error {
 		p.logger.Panic("Must update ClusterConfig before Start()")
 	}
 
+	allowed, err := utils.ParseCIDRs(p.localConf.Replication.AllowedCIDRs)
+	if err != nil {
+		return errors.WithMessage(err, "error in the allowed networks of server to server communication")
+	}
+
 	netConf := p.localConf.Replication.Network
 	addr := fmt.Sprintf("%s:%d", netConf.Address, netConf.Port)
 	netListener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return errors.Wrap(err, "error while creating a tcp listener")
 	}
+	netListener = utils.NewAllowListListener(netListener, allowed, p.logger)
 
 	p.transport = &rafthttp.Transport{
 		Logger:      p.logger.Desugar(),
@@ -388,11 +417,54 @@ func (p *HTTPTransport) SendConsensus(msgs []raftpb.Message) error {
 		p.logger.Debugf("SendConsensus (%d/%d): Type: %s, From: %d, To: %d", i+1, len(msgs), m.Type, p.raftID, m.To)
 	}
 
-	p.transport.Send(msgs)
+	if p.snapshotThrottle == nil {
+		p.transport.Send(msgs)
+		return nil
+	}
+
+	// Snapshots are sent by their own go-routines, so that throttling them does not block the other messages. Raft
+	// tolerates the reordering of messages.
+	var others []raftpb.Message
+	for _, m := range msgs {
+		if m.Type == raftpb.MsgSnap {
+			go p.sendSnapshot(m)
+		} else {
+			others = append(others, m)
+		}
+	}
+	p.transport.Send(others)
 
 	return nil
 }
 
+// sendSnapshot sends a snapshot once it is within the rate of the snapshot throttle. If the transport is closed
+// while waiting, the snapshot is reported as failed.
+func (p *HTTPTransport) sendSnapshot(m raftpb.Message) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-p.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	p.logger.Debugf("throttling snapshot: To: %d, size: %d", m.To, m.Size())
+	if err := p.snapshotThrottle.Wait(ctx, m.Size()); err != nil {
+		p.logger.Infof("snapshot to %d not sent, transport is closed", m.To)
+		p.mutex.Lock()
+		listener := p.consensusListener
+		p.mutex.Unlock()
+		if listener != nil {
+			listener.ReportSnapshot(m.To, raft.SnapshotFailure)
+		}
+		return
+	}
+
+	p.transport.Send([]raftpb.Message{m})
+}
+
 func (p *HTTPTransport) ClientTLSConfig() *tls.Config {
 	return p.tlsClientConfig
 }
@@ -407,6 +479,11 @@ func (p *HTTPTransport) PullBlocks(ctx context.Context, startBlock, endBlock, le
 	return p.catchUpClient.PullBlocks(ctx, startBlock, endBlock, leaderID)
 }
 
+// PullChunk pulls the chunk of a blob with the given hash, which is at most maxSize bytes, from the members.
+func (p *HTTPTransport) PullChunk(ctx context.Context, hash []byte, maxSize uint64) ([]byte, error) {
+	return p.catchUpClient.PullChunk(ctx, hash, maxSize)
+}
+
 // ActivePeers returns the peers that are active for more than `minDuration`.
 // The returned peers  include the self node if includeSelf==true.
 func (p *HTTPTransport) ActivePeers(minDuration time.Duration, includeSelf bool) map[string]*types.PeerConfig {
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
 	"github.com/xiang90/probing"
+	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -59,6 +60,14 @@ func TestNewHTTPTransport(t *testing.T) {
 	require.NoError(t, err)
 	err = tr1.SetClusterConfig(sharedConfig)
 	require.EqualError(t, err, "cluster config already exists")
+
+	localConfigs[0].Replication.Compression.Codecs = []string{comm.CodecSnappy, "br"}
+	tr2, err := comm.NewHTTPTransport(&comm.Config{
+		LocalConf: localConfigs[0],
+		Logger:    lg,
+	})
+	require.EqualError(t, err, "error in the compression of server to server communication: compression codec [br] is not supported, supported codecs: [snappy, gzip]")
+	require.Nil(t, tr2)
 }
 
 // Scenario: send consensus messages from one peer to the next.
@@ -122,6 +131,102 @@ func TestHTTPTransport_SendConsensus(t *testing.T) {
 	)
 }
 
+// Scenario: send snapshots and other consensus messages from one peer to the next, with a snapshot throttle.
+// The other messages are not delayed by the snapshots, the snapshots arrive within the rate, and a snapshot that is
+// waiting when the transport closes is reported as failed.
+func TestHTTPTransport_SendConsensus_SnapshotThrottle(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+	localConfigs[0].Replication.Throttle.SnapshotBytesPerSecond = 20000
+
+	cl1 := &mocks.ConsensusListener{}
+	tr1, _ := comm.NewHTTPTransport(&comm.Config{
+		LocalConf: localConfigs[0],
+		Logger:    lg,
+	})
+	require.NotNil(t, tr1)
+	err = tr1.SetConsensusListener(cl1)
+	require.NoError(t, err)
+	err = tr1.SetClusterConfig(sharedConfig)
+	require.NoError(t, err)
+
+	cl2 := &mocks.ConsensusListener{}
+	tr2, _ := comm.NewHTTPTransport(&comm.Config{
+		LocalConf: localConfigs[1],
+		Logger:    lg,
+	})
+	require.NotNil(t, tr2)
+	err = tr2.SetConsensusListener(cl2)
+	require.NoError(t, err)
+	err = tr2.SetClusterConfig(sharedConfig)
+	require.NoError(t, err)
+
+	err = tr1.Start()
+	require.NoError(t, err)
+
+	err = tr2.Start()
+	require.NoError(t, err)
+	defer tr2.Close()
+
+	snapMsg := raftpb.Message{
+		Type:     raftpb.MsgSnap,
+		To:       2,
+		Snapshot: raftpb.Snapshot{Data: make([]byte, 10000), Metadata: raftpb.SnapshotMetadata{Index: 5, Term: 1}},
+	}
+	start := time.Now()
+	tr1.SendConsensus([]raftpb.Message{snapMsg, {Type: raftpb.MsgHeartbeat, To: 2}})
+	require.Eventually(t,
+		func() bool {
+			return cl2.ProcessCallCount() == 1
+		},
+		10*time.Second, 10*time.Millisecond,
+	)
+	_, m := cl2.ProcessArgsForCall(0)
+	require.Equal(t, raftpb.MsgHeartbeat, m.Type)
+
+	require.Eventually(t,
+		func() bool {
+			return cl2.ProcessCallCount() == 2
+		},
+		10*time.Second, 10*time.Millisecond,
+	)
+	require.True(t, time.Since(start) >= 500*time.Millisecond)
+	_, m = cl2.ProcessArgsForCall(1)
+	require.Equal(t, raftpb.MsgSnap, m.Type)
+	require.Equal(t, uint64(5), m.Snapshot.Metadata.Index)
+
+	require.Eventually(t,
+		func() bool {
+			return cl1.ReportSnapshotCallCount() == 1
+		},
+		10*time.Second, 10*time.Millisecond,
+	)
+	_, status := cl1.ReportSnapshotArgsForCall(0)
+	require.Equal(t, raft.SnapshotFinish, status)
+
+	snapMsg.Snapshot.Data = make([]byte, 1000000)
+	tr1.SendConsensus([]raftpb.Message{snapMsg})
+	time.Sleep(100 * time.Millisecond)
+	tr1.Close()
+	require.Eventually(t,
+		func() bool {
+			return cl1.ReportSnapshotCallCount() == 2
+		},
+		10*time.Second, 10*time.Millisecond,
+	)
+	to, status := cl1.ReportSnapshotArgsForCall(1)
+	require.Equal(t, uint64(2), to)
+	require.Equal(t, raft.SnapshotFailure, status)
+	require.Equal(t, 2, cl2.ProcessCallCount())
+}
+
 // Scenario: send consensus messages from one peer to the next.
 // Both sides enable TLS.
 // Messages arrive.
@@ -866,6 +971,48 @@ func TestHTTPTransport_ActivePeers(t *testing.T) {
 	}
 }
 
+func TestHTTPTransport_VerifyPeersSupportProtocolVersion(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	localConfigs, sharedConfig := newTestSetup(t, 2)
+
+	tr1, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 0, 1)
+	require.NoError(t, err)
+	defer tr1.Close()
+
+	tr2, _, err := startTransportWithLedger(t, lg, localConfigs, sharedConfig, 1, 1)
+	require.NoError(t, err)
+
+	err = tr1.VerifyPeersSupportProtocolVersion(context.Background(), comm.ProtocolVersion)
+	require.NoError(t, err)
+
+	err = tr1.VerifyPeersSupportProtocolVersion(context.Background(), comm.ProtocolVersion+1)
+	require.EqualError(t, err, fmt.Sprintf("protocol version [%d] is not supported by the local server, supported versions: [%d,%d]",
+		comm.ProtocolVersion+1, comm.MinProtocolVersion, comm.ProtocolVersion))
+
+	tr2.Close()
+	err = tr1.VerifyPeersSupportProtocolVersion(context.Background(), comm.ProtocolVersion)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to get the protocol version of member [node2]")
+}
+
+func TestClusterProtocolVersion(t *testing.T) {
+	require.Equal(t, uint32(1), comm.ClusterProtocolVersion(nil))
+	require.Equal(t, uint32(1), comm.ClusterProtocolVersion(&types.ClusterConfig{}))
+	require.Equal(t, uint32(3), comm.ClusterProtocolVersion(&types.ClusterConfig{ProtocolVersion: 3}))
+
+	require.NoError(t, comm.VerifyProtocolVersionSupported(&types.ClusterConfig{}))
+	err := comm.VerifyProtocolVersionSupported(&types.ClusterConfig{ProtocolVersion: comm.ProtocolVersion + 1})
+	require.EqualError(t, err, fmt.Sprintf("cluster protocol version [%d] is not supported by this server, supported versions: [%d,%d]",
+		comm.ProtocolVersion+1, comm.MinProtocolVersion, comm.ProtocolVersion))
+}
+
 func newTestSetup(t *testing.T, numServers int) ([]*config.LocalConfiguration, *types.ClusterConfig) {
 	var nodeIDs []string
 	for i := 0; i < numServers; i++ {
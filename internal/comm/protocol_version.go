@@ -0,0 +1,97 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProtocolVersion is the highest cluster protocol version supported by this server.
+	// It must be incremented whenever a feature is introduced that requires all the cluster members to support it.
+	ProtocolVersion uint32 = 2
+	// MinProtocolVersion is the lowest cluster protocol version supported by this server.
+	MinProtocolVersion uint32 = 1
+
+	// ProtocolVersionCompressedEntries is the cluster protocol version from which the blocks in the entries of the Raft
+	// log may be compressed, as every member must be able to decompress them.
+	ProtocolVersionCompressedEntries uint32 = 2
+
+	// ProtocolVersionHeader carries the highest protocol version supported by the sender of an intra-cluster
+	// request or response. A missing header is equivalent to version 1, i.e., a server that predates versioning.
+	ProtocolVersionHeader = "Orion-Protocol-Version"
+)
+
+// VersionResponse is the response to a protocol version request from a peer.
+type VersionResponse struct {
+	ProtocolVersion    uint32
+	MinProtocolVersion uint32
+}
+
+// ClusterProtocolVersion returns the protocol version that is active in the cluster, according to the cluster config.
+func ClusterProtocolVersion(clusterConfig *types.ClusterConfig) uint32 {
+	if v := clusterConfig.GetProtocolVersion(); v > 0 {
+		return v
+	}
+	return 1
+}
+
+// VerifyProtocolVersionSupported checks that this server supports the protocol version that is active in the cluster.
+func VerifyProtocolVersionSupported(clusterConfig *types.ClusterConfig) error {
+	v := ClusterProtocolVersion(clusterConfig)
+	if v > ProtocolVersion || v < MinProtocolVersion {
+		return errors.Errorf("cluster protocol version [%d] is not supported by this server, supported versions: [%d,%d]",
+			v, MinProtocolVersion, ProtocolVersion)
+	}
+	return nil
+}
+
+// parseProtocolVersion parses the value of the ProtocolVersionHeader.
+func parseProtocolVersion(value string) (uint32, error) {
+	if value == "" {
+		return 1, nil
+	}
+
+	v, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse protocol version [%s]", value)
+	}
+	return uint32(v), nil
+}
+
+// VerifyPeersSupportProtocolVersion checks that all the consensus members, including the local server, support
+// the protocol version `version`. The remote members are asked for the versions they support; a member that does not
+// respond is considered as not supporting the version, so that a version is activated only when all the members
+// are known to have been upgraded.
+func (p *HTTPTransport) VerifyPeersSupportProtocolVersion(ctx context.Context, version uint32) error {
+	if version > ProtocolVersion || version < MinProtocolVersion {
+		return errors.Errorf("protocol version [%d] is not supported by the local server, supported versions: [%d,%d]",
+			version, MinProtocolVersion, ProtocolVersion)
+	}
+
+	p.mutex.Lock()
+	members := p.clusterConfig.GetConsensusConfig().GetMembers()
+	p.mutex.Unlock()
+
+	for _, m := range members {
+		if m.RaftId == p.raftID {
+			continue
+		}
+
+		resp, err := p.catchUpClient.GetProtocolVersion(ctx, m.RaftId)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get the protocol version of member [%s]", m.NodeId)
+		}
+		if version > resp.ProtocolVersion || version < resp.MinProtocolVersion {
+			return errors.Errorf("protocol version [%d] is not supported by member [%s], supported versions: [%d,%d]",
+				version, m.NodeId, resp.MinProtocolVersion, resp.ProtocolVersion)
+		}
+	}
+
+	return nil
+}
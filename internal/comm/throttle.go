@@ -0,0 +1,102 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Throttle limits the rate at which bytes are transferred, across all the transfers that share it. A transfer that
+// exceeds the rate is delayed until the bytes it transferred are within the rate. A nil *Throttle does not limit the
+// rate.
+//
+// The component is thread safe.
+type Throttle struct {
+	bytesPerSecond uint64
+
+	mutex sync.Mutex
+	// next is the time at which the bytes transferred so far are within the rate
+	next time.Time
+}
+
+// NewThrottle creates a throttle that limits the rate to bytesPerSecond. It returns nil if bytesPerSecond is zero,
+// i.e., if the rate is not limited.
+func NewThrottle(bytesPerSecond uint64) *Throttle {
+	if bytesPerSecond == 0 {
+		return nil
+	}
+	return &Throttle{bytesPerSecond: bytesPerSecond}
+}
+
+// Wait accounts for n bytes transferred, and blocks until they are within the rate, or until the context is done.
+func (t *Throttle) Wait(ctx context.Context, n int) error {
+	if t == nil || n <= 0 {
+		return nil
+	}
+
+	t.mutex.Lock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	t.next = t.next.Add(time.Duration(float64(n) / float64(t.bytesPerSecond) * float64(time.Second)))
+	delay := t.next.Sub(now)
+	t.mutex.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Reader returns a reader that reads from r within the rate. Reading fails if the context is done while waiting.
+func (t *Throttle) Reader(ctx context.Context, r io.Reader) io.Reader {
+	if t == nil {
+		return r
+	}
+	return &throttledReader{ctx: ctx, throttle: t, r: r}
+}
+
+// Writer returns a writer that writes to w within the rate. Writing fails if the context is done while waiting.
+func (t *Throttle) Writer(ctx context.Context, w io.Writer) io.Writer {
+	if t == nil {
+		return w
+	}
+	return &throttledWriter{ctx: ctx, throttle: t, w: w}
+}
+
+type throttledReader struct {
+	ctx      context.Context
+	throttle *Throttle
+	r        io.Reader
+}
+
+func (tr *throttledReader) Read(p []byte) (int, error) {
+	n, err := tr.r.Read(p)
+	if errW := tr.throttle.Wait(tr.ctx, n); errW != nil {
+		return n, errW
+	}
+	return n, err
+}
+
+type throttledWriter struct {
+	ctx      context.Context
+	throttle *Throttle
+	w        io.Writer
+}
+
+func (tw *throttledWriter) Write(p []byte) (int, error) {
+	if err := tw.throttle.Wait(tw.ctx, len(p)); err != nil {
+		return 0, err
+	}
+	return tw.w.Write(p)
+}
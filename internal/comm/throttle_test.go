@@ -0,0 +1,86 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package comm_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle(t *testing.T) {
+	t.Run("not limited", func(t *testing.T) {
+		throttle := comm.NewThrottle(0)
+		require.Nil(t, throttle)
+
+		require.NoError(t, throttle.Wait(context.Background(), 1000000))
+		r := bytes.NewReader([]byte("data"))
+		require.Equal(t, r, throttle.Reader(context.Background(), r))
+		w := &bytes.Buffer{}
+		require.Equal(t, w, throttle.Writer(context.Background(), w))
+	})
+
+	t.Run("reader", func(t *testing.T) {
+		throttle := comm.NewThrottle(100000)
+		payload := make([]byte, 50000)
+
+		start := time.Now()
+		read, err := ioutil.ReadAll(throttle.Reader(context.Background(), bytes.NewReader(payload)))
+		require.NoError(t, err)
+		require.Equal(t, payload, read)
+		require.True(t, time.Since(start) >= 450*time.Millisecond)
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		throttle := comm.NewThrottle(100000)
+		w := &bytes.Buffer{}
+
+		start := time.Now()
+		tw := throttle.Writer(context.Background(), w)
+		for i := 0; i < 5; i++ {
+			n, err := tw.Write(make([]byte, 10000))
+			require.NoError(t, err)
+			require.Equal(t, 10000, n)
+		}
+		require.Equal(t, 50000, w.Len())
+		require.True(t, time.Since(start) >= 450*time.Millisecond)
+	})
+
+	t.Run("shared by concurrent transfers", func(t *testing.T) {
+		throttle := comm.NewThrottle(100000)
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.NoError(t, throttle.Wait(context.Background(), 10000))
+			}()
+		}
+		wg.Wait()
+		require.True(t, time.Since(start) >= 450*time.Millisecond)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		throttle := comm.NewThrottle(1000)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := throttle.Wait(ctx, 1000000)
+		require.EqualError(t, err, "context deadline exceeded")
+		require.True(t, time.Since(start) < 10*time.Second)
+
+		n, err := throttle.Writer(ctx, &bytes.Buffer{}).Write([]byte("data"))
+		require.EqualError(t, err, "context deadline exceeded")
+		require.Equal(t, 0, n)
+	})
+}
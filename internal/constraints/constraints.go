@@ -0,0 +1,254 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package constraints
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Get returns the constraints of a database. It returns nil if the database has no constraints.
+func Get(db worldstate.DB, dbName string) (*types.DBConstraints, error) {
+	val, _, err := db.Get(worldstate.ConstraintsDBName, dbName)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while fetching the constraints of database [%s]", dbName)
+	}
+	if val == nil {
+		return nil, nil
+	}
+
+	c := &types.DBConstraints{}
+	if err := proto.Unmarshal(val, c); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the constraints of database [%s]", dbName)
+	}
+	return c, nil
+}
+
+// ReferencingDBs returns the databases whose reference constraints refer to the given database, mapped to the
+// attributes that refer to it
+func ReferencingDBs(db worldstate.DB, referencedDB string) (map[string][]string, error) {
+	itr, err := db.GetIterator(worldstate.ConstraintsDBName, "", "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while iterating over constraints")
+	}
+	defer itr.Release()
+
+	referencing := make(map[string][]string)
+	for itr.Next() {
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling persisted value of key [%s]", itr.Key())
+		}
+
+		c := &types.DBConstraints{}
+		if err := proto.Unmarshal(persisted.Value, c); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling the constraints of database [%s]", itr.Key())
+		}
+		for _, r := range c.References {
+			if r.ReferencedDb == referencedDB {
+				referencing[string(itr.Key())] = append(referencing[string(itr.Key())], r.Attribute)
+			}
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over constraints")
+	}
+
+	return referencing, nil
+}
+
+// ReferencedKey returns the key that a document refers to through an attribute. It returns false if the document is
+// not a JSON object, or the attribute is absent or null, as such a document refers to no key. It returns an error if
+// the attribute is not a string.
+func ReferencedKey(document []byte, attribute string) (string, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(document, &fields); err != nil {
+		return "", false, nil
+	}
+
+	raw, ok := fields[attribute]
+	if !ok || string(raw) == "null" {
+		return "", false, nil
+	}
+
+	var key string
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return "", false, errors.Errorf("the attribute [%s] holds %s, which is not a string", attribute, raw)
+	}
+	return key, true, nil
+}
+
+// ValidateDocument checks that a value conforms to a schema: the value must be a JSON object, whose required
+// attributes are present and not null, and whose typed attributes, when present and not null, hold a value of their
+// type. As in the indexes, a number must be an integer. A nil schema accepts any value. The attributes are checked in
+// order, so that the error is the same on all nodes.
+func ValidateDocument(schema *types.DocumentSchema, value []byte) error {
+	if schema == nil {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(value, &fields); err != nil || fields == nil {
+		return errors.New("the value is not a JSON object")
+	}
+
+	for _, attr := range schema.RequiredAttributes {
+		if raw, ok := fields[attr]; !ok || isNull(raw) {
+			return errors.Errorf("the required attribute [%s] is absent or null", attr)
+		}
+	}
+
+	attrs := make([]string, 0, len(schema.AttributeAndType))
+	for attr := range schema.AttributeAndType {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	for _, attr := range attrs {
+		raw, ok := fields[attr]
+		if !ok || isNull(raw) {
+			continue
+		}
+		t := schema.AttributeAndType[attr]
+		if !hasType(raw, t) {
+			return errors.Errorf("the attribute [%s] holds %s, which is not of type %s", attr, raw, t)
+		}
+	}
+
+	return nil
+}
+
+func isNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+func hasType(raw json.RawMessage, t types.IndexAttributeType) bool {
+	raw = bytes.TrimSpace(raw)
+	switch t {
+	case types.IndexAttributeType_STRING:
+		var s string
+		return json.Unmarshal(raw, &s) == nil
+	case types.IndexAttributeType_BOOLEAN:
+		var b bool
+		return json.Unmarshal(raw, &b) == nil
+	case types.IndexAttributeType_NUMBER:
+		_, err := strconv.ParseInt(string(raw), 10, 64)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+// WithDefaultACLs returns the data transaction with the default access control of a database set on the writes to
+// the database that carry no access control. The transaction itself is left unchanged, as it is held by the block,
+// and only the modified operations and writes are copied. It returns the transaction as is if no write needs a
+// default access control.
+func WithDefaultACLs(db worldstate.DB, tx *types.DataTx) (*types.DataTx, error) {
+	var resolved *types.DataTx
+
+	for i, ops := range tx.DbOperations {
+		var acl *types.AccessControl
+		for j, w := range ops.DataWrites {
+			if w.Acl != nil {
+				continue
+			}
+
+			if acl == nil {
+				c, err := Get(db, ops.DbName)
+				if err != nil {
+					return nil, err
+				}
+				if c.GetDefaultAcl() == nil {
+					break
+				}
+				acl = c.DefaultAcl
+			}
+
+			if resolved == nil {
+				txCopy := *tx
+				txCopy.DbOperations = append([]*types.DBOperation{}, tx.DbOperations...)
+				resolved = &txCopy
+			}
+			if resolved.DbOperations[i] == ops {
+				opsCopy := *ops
+				opsCopy.DataWrites = append([]*types.DataWrite{}, ops.DataWrites...)
+				resolved.DbOperations[i] = &opsCopy
+			}
+
+			wCopy := *w
+			wCopy.Acl = acl
+			resolved.DbOperations[i].DataWrites[j] = &wCopy
+		}
+	}
+
+	if resolved == nil {
+		return tx, nil
+	}
+	return resolved, nil
+}
+
+// isEmpty returns true if the constraints hold no constraint, in which case a database administration transaction
+// removes the constraints of the database
+func isEmpty(c *types.DBConstraints) bool {
+	return len(c.GetReferences()) == 0 && c.GetSchema() == nil && c.GetDefaultAcl() == nil
+}
+
+// ConstructDBEntriesForDBAdminTx constructs the entries of the constraints database for a database administration
+// transaction. The constraints set by the transaction replace the existing constraints of their databases, and the
+// constraints of a deleted database are removed. It returns nil if the transaction neither sets constraints nor
+// deletes a database that has constraints.
+func ConstructDBEntriesForDBAdminTx(db worldstate.DB, tx *types.DBAdministrationTx, version *types.Version) (*worldstate.DBUpdates, error) {
+	var dbNames []string
+	for dbName := range tx.DbsConstraints {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	updates := &worldstate.DBUpdates{}
+	for _, dbName := range dbNames {
+		c := tx.DbsConstraints[dbName]
+		if !isEmpty(c) {
+			cSerialized, err := proto.Marshal(c)
+			if err != nil {
+				return nil, errors.Wrap(err, "error while marshaling constraints")
+			}
+			updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+				Key:   dbName,
+				Value: cSerialized,
+				Metadata: &types.Metadata{
+					Version: version,
+				},
+			})
+		}
+	}
+
+	// the constraints of a deleted database, or of a database whose entry holds no constraint, are removed
+	removed := append([]string{}, tx.DeleteDbs...)
+	for _, dbName := range dbNames {
+		if isEmpty(tx.DbsConstraints[dbName]) {
+			removed = append(removed, dbName)
+		}
+	}
+	for _, dbName := range removed {
+		c, err := Get(db, dbName)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			updates.Deletes = append(updates.Deletes, dbName)
+		}
+	}
+
+	if len(updates.Writes) == 0 && len(updates.Deletes) == 0 {
+		return nil, nil
+	}
+	return updates, nil
+}
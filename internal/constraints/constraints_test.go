@@ -0,0 +1,303 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package constraints
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) worldstate.DB {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("/tmp", "constraints")
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(&leveldb.Config{
+		DBRootDir: filepath.Join(dir, "leveldb"),
+		Logger:    lg,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close the db instance, %v", err)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("failed to remove directory %s, %v", dir, err)
+		}
+	})
+	return db
+}
+
+func TestConstraints(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	updates, err := ConstructDBEntriesForDBAdminTx(db, &types.DBAdministrationTx{CreateDbs: []string{"db1"}}, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.Nil(t, updates)
+
+	ordersConstraints := &types.DBConstraints{
+		References: []*types.ReferenceConstraint{
+			{Attribute: "customer", ReferencedDb: "customers"},
+			{Attribute: "product", ReferencedDb: "products"},
+		},
+	}
+	tx := &types.DBAdministrationTx{
+		UserId: "admin",
+		DbsConstraints: map[string]*types.DBConstraints{
+			"orders": ordersConstraints,
+			"invoices": {
+				References: []*types.ReferenceConstraint{
+					{Attribute: "customer", ReferencedDb: "customers"},
+				},
+			},
+			// removing the constraints of a database that has none is a no-op
+			"products": {},
+		},
+	}
+	updates, err = ConstructDBEntriesForDBAdminTx(db, tx, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.Len(t, updates.Writes, 2)
+	require.Equal(t, "invoices", updates.Writes[0].Key)
+	require.Equal(t, "orders", updates.Writes[1].Key)
+	require.Empty(t, updates.Deletes)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.ConstraintsDBName: updates}, 1))
+
+	c, err := Get(db, "orders")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(ordersConstraints, c))
+
+	c, err = Get(db, "customers")
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	referencing, err := ReferencingDBs(db, "customers")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"orders": {"customer"}, "invoices": {"customer"}}, referencing)
+
+	referencing, err = ReferencingDBs(db, "orders")
+	require.NoError(t, err)
+	require.Empty(t, referencing)
+
+	tx = &types.DBAdministrationTx{
+		UserId:    "admin",
+		DeleteDbs: []string{"invoices", "customers"},
+		DbsConstraints: map[string]*types.DBConstraints{
+			"orders": {},
+		},
+	}
+	updates, err = ConstructDBEntriesForDBAdminTx(db, tx, &types.Version{BlockNum: 2})
+	require.NoError(t, err)
+	require.Empty(t, updates.Writes)
+	require.Equal(t, []string{"invoices", "orders"}, updates.Deletes)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.ConstraintsDBName: updates}, 2))
+
+	referencing, err = ReferencingDBs(db, "customers")
+	require.NoError(t, err)
+	require.Empty(t, referencing)
+}
+
+func TestReferencedKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		document       string
+		expectedKey    string
+		expectedRefers bool
+		expectedErr    string
+	}{
+		{
+			name:           "string attribute",
+			document:       `{"customer":"c1","amount":10}`,
+			expectedKey:    "c1",
+			expectedRefers: true,
+		},
+		{
+			name:     "absent attribute",
+			document: `{"amount":10}`,
+		},
+		{
+			name:     "null attribute",
+			document: `{"customer":null}`,
+		},
+		{
+			name:     "not a JSON object",
+			document: `binary value`,
+		},
+		{
+			name:        "attribute is not a string",
+			document:    `{"customer":{"id":"c1"}}`,
+			expectedErr: `the attribute [customer] holds {"id":"c1"}, which is not a string`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, refers, err := ReferencedKey([]byte(tt.document), "customer")
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedKey, key)
+			require.Equal(t, tt.expectedRefers, refers)
+		})
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	t.Parallel()
+
+	schema := &types.DocumentSchema{
+		AttributeAndType: map[string]types.IndexAttributeType{
+			"customer": types.IndexAttributeType_STRING,
+			"amount":   types.IndexAttributeType_NUMBER,
+			"paid":     types.IndexAttributeType_BOOLEAN,
+		},
+		RequiredAttributes: []string{"customer", "id"},
+	}
+
+	tests := []struct {
+		name        string
+		schema      *types.DocumentSchema
+		document    string
+		expectedErr string
+	}{
+		{
+			name:     "no schema",
+			document: `binary value`,
+		},
+		{
+			name:     "conforming document",
+			schema:   schema,
+			document: `{"id":1,"customer":"c1","amount":10,"paid":true,"note":{"text":"n1"}}`,
+		},
+		{
+			name:     "absent and null typed attributes",
+			schema:   schema,
+			document: `{"id":"i1","customer":"c1","amount":null}`,
+		},
+		{
+			name:        "not a JSON object",
+			schema:      schema,
+			document:    `["c1"]`,
+			expectedErr: "the value is not a JSON object",
+		},
+		{
+			name:        "null document",
+			schema:      schema,
+			document:    `null`,
+			expectedErr: "the value is not a JSON object",
+		},
+		{
+			name:        "null required attribute",
+			schema:      schema,
+			document:    `{"id":1,"customer":null}`,
+			expectedErr: "the required attribute [customer] is absent or null",
+		},
+		{
+			name:        "absent required attribute",
+			schema:      schema,
+			document:    `{"customer":"c1"}`,
+			expectedErr: "the required attribute [id] is absent or null",
+		},
+		{
+			name:        "float number",
+			schema:      schema,
+			document:    `{"id":1,"customer":"c1","amount":1.5}`,
+			expectedErr: "the attribute [amount] holds 1.5, which is not of type NUMBER",
+		},
+		{
+			name:        "attributes checked in order",
+			schema:      schema,
+			document:    `{"id":1,"customer":1,"paid":"yes"}`,
+			expectedErr: "the attribute [customer] holds 1, which is not of type STRING",
+		},
+		{
+			name:        "string instead of boolean",
+			schema:      schema,
+			document:    `{"id":1,"customer":"c1","paid":"yes"}`,
+			expectedErr: `the attribute [paid] holds "yes", which is not of type BOOLEAN`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateDocument(tt.schema, []byte(tt.document))
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWithDefaultACLs(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	defaultACL := &types.AccessControl{ReadWriteUsers: map[string]bool{"alice": true}}
+	updates, err := ConstructDBEntriesForDBAdminTx(db, &types.DBAdministrationTx{
+		UserId: "admin",
+		DbsConstraints: map[string]*types.DBConstraints{
+			"orders": {DefaultAcl: defaultACL},
+		},
+	}, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.Len(t, updates.Writes, 1)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.ConstraintsDBName: updates}, 1))
+
+	// no write to a database that has a default access control lacks one
+	ownACL := &types.AccessControl{ReadUsers: map[string]bool{"bob": true}}
+	tx := &types.DataTx{
+		TxId: "tx1",
+		DbOperations: []*types.DBOperation{
+			{DbName: "customers", DataWrites: []*types.DataWrite{{Key: "c1", Value: []byte("v")}}},
+			{DbName: "orders", DataWrites: []*types.DataWrite{{Key: "o1", Value: []byte("v"), Acl: ownACL}}},
+		},
+	}
+	resolved, err := WithDefaultACLs(db, tx)
+	require.NoError(t, err)
+	require.True(t, resolved == tx)
+
+	tx.DbOperations[1].DataWrites = append(tx.DbOperations[1].DataWrites, &types.DataWrite{Key: "o2", Value: []byte("v")})
+	resolved, err = WithDefaultACLs(db, tx)
+	require.NoError(t, err)
+	require.False(t, resolved == tx)
+	require.Equal(t, "tx1", resolved.TxId)
+	require.True(t, resolved.DbOperations[0] == tx.DbOperations[0])
+	require.True(t, proto.Equal(ownACL, resolved.DbOperations[1].DataWrites[0].Acl))
+	require.True(t, proto.Equal(defaultACL, resolved.DbOperations[1].DataWrites[1].Acl))
+	require.Equal(t, "o2", resolved.DbOperations[1].DataWrites[1].Key)
+
+	// the transaction held by the block is left unchanged
+	require.Nil(t, tx.DbOperations[1].DataWrites[1].Acl)
+}
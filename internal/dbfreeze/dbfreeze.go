@@ -0,0 +1,62 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package dbfreeze
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Get returns the freeze of a database. It returns nil if the database is not frozen.
+func Get(db worldstate.DB, dbName string) (*types.DatabaseFreeze, error) {
+	val, _, err := db.Get(worldstate.FrozenDBsDBName, dbName)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while fetching the freeze of database [%s]", dbName)
+	}
+	if val == nil {
+		return nil, nil
+	}
+
+	freeze := &types.DatabaseFreeze{}
+	if err := proto.Unmarshal(val, freeze); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the freeze of database [%s]", dbName)
+	}
+	return freeze, nil
+}
+
+// ConstructDBEntriesForDBAdminTx constructs the entries of the frozen databases database for the databases frozen and
+// thawed by a database administration transaction. It returns nil if the transaction neither freezes nor thaws a
+// database.
+func ConstructDBEntriesForDBAdminTx(tx *types.DBAdministrationTx, version *types.Version) (*worldstate.DBUpdates, error) {
+	if len(tx.FreezeDbs) == 0 && len(tx.ThawDbs) == 0 {
+		return nil, nil
+	}
+
+	updates := &worldstate.DBUpdates{}
+	for _, f := range tx.FreezeDbs {
+		freeze := &types.DatabaseFreeze{
+			DbName:   f.DbName,
+			Reason:   f.Reason,
+			FrozenBy: tx.UserId,
+		}
+		freezeSerialized, err := proto.Marshal(freeze)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while marshaling database freeze")
+		}
+
+		updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+			Key:   f.DbName,
+			Value: freezeSerialized,
+			Metadata: &types.Metadata{
+				Version: version,
+			},
+		})
+	}
+
+	updates.Deletes = append(updates.Deletes, tx.ThawDbs...)
+
+	return updates, nil
+}
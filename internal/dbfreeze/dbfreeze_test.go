@@ -0,0 +1,98 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package dbfreeze
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) worldstate.DB {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("/tmp", "dbfreeze")
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(&leveldb.Config{
+		DBRootDir: filepath.Join(dir, "leveldb"),
+		Logger:    lg,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close the db instance, %v", err)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("failed to remove directory %s, %v", dir, err)
+		}
+	})
+	return db
+}
+
+func TestFreezes(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	updates, err := ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{CreateDbs: []string{"db1"}}, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.Nil(t, updates)
+
+	updates, err = ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{
+		UserId: "admin",
+		FreezeDbs: []*types.DatabaseFreeze{
+			{DbName: "db1", Reason: "cutover"},
+			{DbName: "db2", Reason: "investigation", FrozenBy: "someone"},
+		},
+	}, &types.Version{BlockNum: 2})
+	require.NoError(t, err)
+	require.Len(t, updates.Writes, 2)
+	require.Empty(t, updates.Deletes)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.FrozenDBsDBName: updates}, 2))
+
+	freeze, err := Get(db, "db1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.DatabaseFreeze{DbName: "db1", Reason: "cutover", FrozenBy: "admin"}, freeze))
+
+	freeze, err = Get(db, "db2")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.DatabaseFreeze{DbName: "db2", Reason: "investigation", FrozenBy: "admin"}, freeze))
+
+	freeze, err = Get(db, "db3")
+	require.NoError(t, err)
+	require.Nil(t, freeze)
+
+	updates, err = ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{
+		UserId:  "admin",
+		ThawDbs: []string{"db1"},
+	}, &types.Version{BlockNum: 3})
+	require.NoError(t, err)
+	require.Empty(t, updates.Writes)
+	require.Equal(t, []string{"db1"}, updates.Deletes)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.FrozenDBsDBName: updates}, 3))
+
+	freeze, err = Get(db, "db1")
+	require.NoError(t, err)
+	require.Nil(t, freeze)
+
+	freeze, err = Get(db, "db2")
+	require.NoError(t, err)
+	require.NotNil(t, freeze)
+}
@@ -0,0 +1,160 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// KeyFileExtension is the extension of the files that hold the keys; the key with ID <id> is read from <id>.key
+	KeyFileExtension = ".key"
+	// KeySize is the size in bytes of a key, which is an AES-256 key
+	KeySize = 32
+)
+
+// Config holds the keys with which the values of the databases are encrypted in the world state
+type Config struct {
+	// KeysDir holds the key files. Each file holds the hex encoding of a key.
+	KeysDir string
+	// DBKeys maps the name of every encrypted database to the ID of its current key
+	DBKeys map[string]string
+}
+
+// Keys encrypts the values of every database with its current key, and decrypts the values encrypted with any key of
+// the keys directory, which includes the previous keys of the databases whose keys were rotated. A value is bound to
+// its database and key, hence an encrypted value that is moved to another key or database cannot be decrypted.
+type Keys struct {
+	aeads  map[string]cipher.AEAD
+	dbKeys map[string]string
+}
+
+// Load reads all the keys of the keys directory, and checks that the current key of every database is among them
+func Load(conf *Config) (*Keys, error) {
+	files, err := ioutil.ReadDir(conf.KeysDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the keys directory [%s]", conf.KeysDir)
+	}
+
+	k := &Keys{
+		aeads:  make(map[string]cipher.AEAD),
+		dbKeys: make(map[string]string, len(conf.DBKeys)),
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != KeyFileExtension {
+			continue
+		}
+
+		keyID := strings.TrimSuffix(f.Name(), KeyFileExtension)
+		aead, err := loadKey(filepath.Join(conf.KeysDir, f.Name()))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while loading key [%s]", keyID)
+		}
+		k.aeads[keyID] = aead
+	}
+
+	for dbName, keyID := range conf.DBKeys {
+		if worldstate.IsSystemDB(dbName) {
+			return nil, errors.Errorf("the values of database [%s] cannot be encrypted", dbName)
+		}
+		if _, ok := k.aeads[keyID]; !ok {
+			return nil, errors.Errorf("the key [%s] of database [%s] is not found in the keys directory [%s]", keyID, dbName, conf.KeysDir)
+		}
+		k.dbKeys[dbName] = keyID
+	}
+
+	return k, nil
+}
+
+func loadKey(path string) (cipher.AEAD, error) {
+	encoded, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the key file [%s]", path)
+	}
+
+	key, err := hex.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while decoding the key file [%s]", path)
+	}
+	if len(key) != KeySize {
+		return nil, errors.Errorf("the key file [%s] holds a key of %d bytes, whereas a key of %d bytes is expected", path, len(key), KeySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating the cipher")
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// DBKeyID returns the ID of the current key of the database, or an empty string if the database is not encrypted
+func (k *Keys) DBKeyID(dbName string) string {
+	if k == nil {
+		return ""
+	}
+
+	return k.dbKeys[dbName]
+}
+
+// Encrypt encrypts the value of a key with the current key of its database. It returns nil if the database is not
+// encrypted.
+func (k *Keys) Encrypt(dbName, key string, value []byte) (*types.EncryptedValue, error) {
+	keyID := k.DBKeyID(dbName)
+	if keyID == "" {
+		return nil, nil
+	}
+
+	aead := k.aeads[keyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "error while generating a nonce")
+	}
+
+	return &types.EncryptedValue{
+		KeyId:      keyID,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, value, additionalData(dbName, key)),
+	}, nil
+}
+
+// Decrypt decrypts the value of a key of a database
+func (k *Keys) Decrypt(dbName, key string, v *types.EncryptedValue) ([]byte, error) {
+	var aead cipher.AEAD
+	if k != nil {
+		aead = k.aeads[v.KeyId]
+	}
+	if aead == nil {
+		return nil, errors.Errorf("the value of key [%s] of database [%s] is encrypted with key [%s], which is not found", key, dbName, v.KeyId)
+	}
+	if len(v.Nonce) != aead.NonceSize() {
+		return nil, errors.Errorf("the value of key [%s] of database [%s] has a nonce of %d bytes", key, dbName, len(v.Nonce))
+	}
+
+	value, err := aead.Open(nil, v.Nonce, v.Ciphertext, additionalData(dbName, key))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while decrypting the value of key [%s] of database [%s] with key [%s]", key, dbName, v.KeyId)
+	}
+
+	return value, nil
+}
+
+// additionalData binds a value to its database and key. The database name cannot hold a zero byte.
+func additionalData(dbName, key string) []byte {
+	data := make([]byte, 0, len(dbName)+1+len(key))
+	data = append(data, dbName...)
+	data = append(data, 0)
+	return append(data, key...)
+}
@@ -0,0 +1,142 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeysDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("/tmp", "keys")
+	require.NoError(t, err)
+
+	return dir, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("failed to remove directory %s, %v", dir, err)
+		}
+	}
+}
+
+func writeKey(t *testing.T, dir, keyID string, key []byte) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, keyID+KeyFileExtension), []byte(hex.EncodeToString(key)+"\n"), 0600))
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("keys are loaded", func(t *testing.T) {
+		dir, cleanup := newKeysDir(t)
+		defer cleanup()
+		writeKey(t, dir, "key1", bytes.Repeat([]byte{1}, KeySize))
+		writeKey(t, dir, "key2", bytes.Repeat([]byte{2}, KeySize))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a key"), 0600))
+
+		keys, err := Load(&Config{KeysDir: dir, DBKeys: map[string]string{"db1": "key1"}})
+		require.NoError(t, err)
+		require.Len(t, keys.aeads, 2)
+		require.Equal(t, "key1", keys.DBKeyID("db1"))
+		require.Equal(t, "", keys.DBKeyID("db2"))
+	})
+
+	t.Run("key of a database is missing", func(t *testing.T) {
+		dir, cleanup := newKeysDir(t)
+		defer cleanup()
+		writeKey(t, dir, "key1", bytes.Repeat([]byte{1}, KeySize))
+
+		keys, err := Load(&Config{KeysDir: dir, DBKeys: map[string]string{"db1": "key2"}})
+		require.EqualError(t, err, "the key [key2] of database [db1] is not found in the keys directory ["+dir+"]")
+		require.Nil(t, keys)
+	})
+
+	t.Run("system database", func(t *testing.T) {
+		dir, cleanup := newKeysDir(t)
+		defer cleanup()
+		writeKey(t, dir, "key1", bytes.Repeat([]byte{1}, KeySize))
+
+		keys, err := Load(&Config{KeysDir: dir, DBKeys: map[string]string{worldstate.UsersDBName: "key1"}})
+		require.EqualError(t, err, "the values of database ["+worldstate.UsersDBName+"] cannot be encrypted")
+		require.Nil(t, keys)
+	})
+
+	t.Run("key of a wrong size", func(t *testing.T) {
+		dir, cleanup := newKeysDir(t)
+		defer cleanup()
+		writeKey(t, dir, "key1", bytes.Repeat([]byte{1}, 16))
+
+		keys, err := Load(&Config{KeysDir: dir})
+		require.EqualError(t, err, "error while loading key [key1]: the key file ["+filepath.Join(dir, "key1.key")+"] holds a key of 16 bytes, whereas a key of 32 bytes is expected")
+		require.Nil(t, keys)
+	})
+
+	t.Run("key that is not hex-encoded", func(t *testing.T) {
+		dir, cleanup := newKeysDir(t)
+		defer cleanup()
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key1.key"), []byte("not hex"), 0600))
+
+		keys, err := Load(&Config{KeysDir: dir})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while decoding the key file")
+		require.Nil(t, keys)
+	})
+
+	t.Run("missing keys directory", func(t *testing.T) {
+		keys, err := Load(&Config{KeysDir: "/tmp/missing-keys-dir"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while reading the keys directory")
+		require.Nil(t, keys)
+	})
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	dir, cleanup := newKeysDir(t)
+	defer cleanup()
+	writeKey(t, dir, "key1", bytes.Repeat([]byte{1}, KeySize))
+	writeKey(t, dir, "key2", bytes.Repeat([]byte{2}, KeySize))
+	keys, err := Load(&Config{KeysDir: dir, DBKeys: map[string]string{"db1": "key1", "db2": "key2"}})
+	require.NoError(t, err)
+
+	encrypted, err := keys.Encrypt("db1", "k1", []byte("value1"))
+	require.NoError(t, err)
+	require.Equal(t, "key1", encrypted.KeyId)
+	require.NotContains(t, string(encrypted.Ciphertext), "value1")
+
+	value, err := keys.Decrypt("db1", "k1", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+
+	// the nonce is random, hence the same value is encrypted differently
+	again, err := keys.Encrypt("db1", "k1", []byte("value1"))
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted.Ciphertext, again.Ciphertext)
+
+	// a value is bound to its database and key
+	_, err = keys.Decrypt("db1", "k2", encrypted)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while decrypting the value of key [k2] of database [db1] with key [key1]")
+	_, err = keys.Decrypt("db2", "k1", encrypted)
+	require.Error(t, err)
+
+	// a database without a key is not encrypted
+	encrypted, err = keys.Encrypt("db3", "k1", []byte("value1"))
+	require.NoError(t, err)
+	require.Nil(t, encrypted)
+
+	// a value encrypted with a key that is not loaded cannot be decrypted
+	encrypted, err = keys.Encrypt("db2", "k1", []byte("value1"))
+	require.NoError(t, err)
+	var noKeys *Keys
+	_, err = noKeys.Decrypt("db2", "k1", encrypted)
+	require.EqualError(t, err, "the value of key [k1] of database [db2] is encrypted with key [key2], which is not found")
+	require.Equal(t, "", noKeys.DBKeyID("db2"))
+}
@@ -0,0 +1,299 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package erasure
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"path/filepath"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	// keySize is the size in bytes of an erasure key, which is an AES-256 key
+	keySize = 32
+	// keyIDSize is the size in bytes of the random ID of an erasure key
+	keyIDSize = 8
+)
+
+var (
+	// erasureKeysDBName holds the erasure key of every key of an erasable database that was written since it was last
+	// erased
+	erasureKeysDBName = "erasurekeys"
+
+	// underCreationFlag is used to mark that the store
+	// is being created. If a failure happens during the
+	// creation, the retry logic will use this file to
+	// detect the partially created store and do cleanup
+	// before creating a new store
+	underCreationFlag = "undercreation"
+)
+
+// Store maintains the erasure keys with which the values of the erasable databases are sealed in the block store and
+// the provenance store. Every key of an erasable database has its own erasure key, which is created when a value is
+// first sealed for it, and destroyed when the key is erased. A sealed value is bound to its database and key, and
+// refers to the erasure key it is sealed with by a random ID; hence, the values written after a key is erased are
+// sealed with a new erasure key, and the values written before remain unreadable.
+//
+// The erasure keys are created and destroyed with synced writes, so that a value is never persisted sealed with a key
+// that is lost in a crash, and an erased key is not restored by one.
+type Store struct {
+	db          *leveldb.DB
+	erasableDBs map[string]bool
+	mutex       sync.RWMutex
+	logger      *logger.SugarLogger
+}
+
+// Config holds the configuration of the erasure key store
+type Config struct {
+	StoreDir string
+	Logger   *logger.SugarLogger
+}
+
+// Open opens the erasure key store
+func Open(conf *Config) (*Store, error) {
+	exist, err := fileops.Exists(conf.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		partial, err := isExistingStoreCreatedPartially(conf.StoreDir)
+		if err != nil {
+			return nil, err
+		}
+		if !partial {
+			db, err := leveldb.OpenFile(filepath.Join(conf.StoreDir, erasureKeysDBName), &opt.Options{ErrorIfMissing: true})
+			if err != nil {
+				return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the erasure keys")
+			}
+			return newStore(db, conf.Logger), nil
+		}
+
+		if err := fileops.RemoveAll(conf.StoreDir); err != nil {
+			return nil, errors.Wrap(err, "error while removing the existing partially created store")
+		}
+	}
+
+	if err := fileops.CreateDir(conf.StoreDir); err != nil {
+		return nil, errors.WithMessagef(err, "error while creating directory [%s]", conf.StoreDir)
+	}
+
+	underCreationFlagPath := filepath.Join(conf.StoreDir, underCreationFlag)
+	if err := fileops.CreateFile(underCreationFlagPath); err != nil {
+		return nil, err
+	}
+
+	db, err := leveldb.OpenFile(filepath.Join(conf.StoreDir, erasureKeysDBName), &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the erasure keys database")
+	}
+
+	if err := fileops.Remove(underCreationFlagPath); err != nil {
+		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
+	}
+
+	return newStore(db, conf.Logger), nil
+}
+
+func newStore(db *leveldb.DB, lg *logger.SugarLogger) *Store {
+	return &Store{
+		db:          db,
+		erasableDBs: make(map[string]bool),
+		logger:      lg,
+	}
+}
+
+func isExistingStoreCreatedPartially(storeDir string) (bool, error) {
+	empty, err := fileops.IsDirEmpty(storeDir)
+	if err != nil || empty {
+		return true, err
+	}
+
+	return fileops.Exists(filepath.Join(storeDir, underCreationFlag))
+}
+
+// Close closes the store
+func (s *Store) Close() error {
+	if err := s.db.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the erasure keys database")
+	}
+	return nil
+}
+
+// SetErasableDBs sets the databases whose values are sealed, as configured in the cluster configuration
+func (s *Store) SetErasableDBs(dbNames []string) {
+	if s == nil {
+		return
+	}
+
+	erasableDBs := make(map[string]bool, len(dbNames))
+	for _, dbName := range dbNames {
+		erasableDBs[dbName] = true
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.erasableDBs = erasableDBs
+}
+
+// Erasable returns true if the values written to the database are sealed
+func (s *Store) Erasable(dbName string) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.erasableDBs[dbName]
+}
+
+// Seal encrypts the value of a key with the erasure key of the key, which is created if the key has none
+func (s *Store) Seal(dbName, key string, value []byte) (*types.EncryptedValue, error) {
+	keyID, aead, err := s.getOrCreateKey(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "error while generating a nonce")
+	}
+
+	return &types.EncryptedValue{
+		KeyId:      keyID,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, value, additionalData(dbName, key)),
+	}, nil
+}
+
+// Unseal decrypts the sealed value of a key. It returns false if the erasure key the value is sealed with was
+// destroyed, i.e., the key was erased after the value was written.
+func (s *Store) Unseal(dbName, key string, v *types.EncryptedValue) ([]byte, bool, error) {
+	if s == nil {
+		return nil, false, errors.Errorf("the value of key [%s] of database [%s] is sealed, but there is no erasure key store", key, dbName)
+	}
+
+	s.mutex.RLock()
+	keyID, aead, err := s.getKey(dbName, key)
+	s.mutex.RUnlock()
+	if err != nil {
+		return nil, false, err
+	}
+	if aead == nil || keyID != v.KeyId {
+		return nil, false, nil
+	}
+	if len(v.Nonce) != aead.NonceSize() {
+		return nil, false, errors.Errorf("the sealed value of key [%s] of database [%s] has a nonce of %d bytes", key, dbName, len(v.Nonce))
+	}
+
+	value, err := aead.Open(nil, v.Nonce, v.Ciphertext, additionalData(dbName, key))
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "error while unsealing the value of key [%s] of database [%s]", key, dbName)
+	}
+
+	return value, true, nil
+}
+
+// Erase destroys the erasure keys of the given keys. A key without an erasure key is skipped, hence erasing a key
+// again has no effect. The store is compacted after the deletion, such that the destroyed erasure keys are not left
+// behind in its journal or in its tables.
+func (s *Store) Erase(keys []*types.KeyErasure) error {
+	if s == nil || len(keys) == 0 {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	batch := &leveldb.Batch{}
+	for _, k := range keys {
+		batch.Delete(erasureKeyKey(k.DbName, k.Key))
+	}
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrap(err, "error while destroying the erasure keys")
+	}
+	if err := s.db.CompactRange(util.Range{}); err != nil {
+		return errors.Wrap(err, "error while compacting the destroyed erasure keys")
+	}
+
+	for _, k := range keys {
+		s.logger.Infof("erased key [%s] of database [%s]", s.logger.MaskKey(k.Key), k.DbName)
+	}
+	return nil
+}
+
+func (s *Store) getOrCreateKey(dbName, key string) (string, cipher.AEAD, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keyID, aead, err := s.getKey(dbName, key)
+	if err != nil || aead != nil {
+		return keyID, aead, err
+	}
+
+	entry := make([]byte, keySize+keyIDSize)
+	if _, err := rand.Read(entry); err != nil {
+		return "", nil, errors.Wrap(err, "error while generating an erasure key")
+	}
+	if err := s.db.Put(erasureKeyKey(dbName, key), entry, &opt.WriteOptions{Sync: true}); err != nil {
+		return "", nil, errors.Wrapf(err, "error while storing the erasure key of key [%s] of database [%s]", key, dbName)
+	}
+
+	return decodeEntry(entry)
+}
+
+// getKey returns the ID of the erasure key of a key, and the cipher of the erasure key, or nil if the key has none
+func (s *Store) getKey(dbName, key string) (string, cipher.AEAD, error) {
+	entry, err := s.db.Get(erasureKeyKey(dbName, key), nil)
+	if err == leveldb.ErrNotFound {
+		return "", nil, nil
+	}
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "error while reading the erasure key of key [%s] of database [%s]", key, dbName)
+	}
+
+	return decodeEntry(entry)
+}
+
+// decodeEntry decodes a stored erasure key, which holds the key followed by its ID
+func decodeEntry(entry []byte) (string, cipher.AEAD, error) {
+	if len(entry) != keySize+keyIDSize {
+		return "", nil, errors.Errorf("the erasure key entry has %d bytes, whereas %d bytes are expected", len(entry), keySize+keyIDSize)
+	}
+
+	block, err := aes.NewCipher(entry[:keySize])
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error while creating the cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "error while creating the cipher")
+	}
+
+	return hex.EncodeToString(entry[keySize:]), aead, nil
+}
+
+// erasureKeyKey returns the key of the erasure key of a key. The database name cannot hold a zero byte.
+func erasureKeyKey(dbName, key string) []byte {
+	return additionalData(dbName, key)
+}
+
+// additionalData binds a value to its database and key
+func additionalData(dbName, key string) []byte {
+	data := make([]byte, 0, len(dbName)+1+len(key))
+	data = append(data, dbName...)
+	data = append(data, 0)
+	return append(data, key...)
+}
@@ -0,0 +1,146 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package erasure
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) (*Store, *Config) {
+	storeDir, err := ioutil.TempDir("/tmp", "erasure")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := os.RemoveAll(storeDir); err != nil {
+			t.Errorf("error while removing directory %s, %v", storeDir, err)
+		}
+	})
+
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	conf := &Config{StoreDir: storeDir, Logger: lg}
+	s, err := Open(conf)
+	require.NoError(t, err)
+	return s, conf
+}
+
+func TestSealAndUnseal(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newTestStore(t)
+	defer s.Close()
+
+	sealed1, err := s.Seal("db1", "key1", []byte("value1"))
+	require.NoError(t, err)
+	require.NotContains(t, string(sealed1.Ciphertext), "value1")
+	sealed2, err := s.Seal("db1", "key1", []byte("value2"))
+	require.NoError(t, err)
+	require.Equal(t, sealed1.KeyId, sealed2.KeyId)
+
+	value, ok, err := s.Unseal("db1", "key1", sealed1)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("value1"), value)
+
+	value, ok, err = s.Unseal("db1", "key1", sealed2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("value2"), value)
+
+	t.Run("every key has its own erasure key", func(t *testing.T) {
+		sealed, err := s.Seal("db1", "key2", []byte("value1"))
+		require.NoError(t, err)
+		require.NotEqual(t, sealed1.KeyId, sealed.KeyId)
+	})
+
+	t.Run("a sealed value is bound to its key", func(t *testing.T) {
+		_, ok, err := s.Unseal("db2", "key1", sealed1)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		moved := &types.EncryptedValue{KeyId: sealed1.KeyId, Nonce: sealed1.Nonce, Ciphertext: sealed1.Ciphertext}
+		sealed, err := s.Seal("db1", "key3", []byte("value3"))
+		require.NoError(t, err)
+		moved.KeyId = sealed.KeyId
+		_, _, err = s.Unseal("db1", "key3", moved)
+		require.EqualError(t, err, "error while unsealing the value of key [key3] of database [db1]: cipher: message authentication failed")
+	})
+}
+
+func TestErase(t *testing.T) {
+	t.Parallel()
+
+	s, conf := newTestStore(t)
+
+	sealed1, err := s.Seal("db1", "key1", []byte("value1"))
+	require.NoError(t, err)
+	sealed2, err := s.Seal("db1", "key2", []byte("value2"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Erase([]*types.KeyErasure{{DbName: "db1", Key: "key1"}, {DbName: "db1", Key: "key3"}}))
+	value, ok, err := s.Unseal("db1", "key1", sealed1)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, value)
+
+	// a value written after the key is erased is sealed with a new erasure key
+	sealed3, err := s.Seal("db1", "key1", []byte("value3"))
+	require.NoError(t, err)
+	require.NotEqual(t, sealed1.KeyId, sealed3.KeyId)
+
+	// the erasure keys are persisted, and the erased keys are not restored
+	require.NoError(t, s.Close())
+	s, err = Open(conf)
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, ok, err = s.Unseal("db1", "key1", sealed1)
+	require.NoError(t, err)
+	require.False(t, ok)
+	value, ok, err = s.Unseal("db1", "key1", sealed3)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("value3"), value)
+	value, ok, err = s.Unseal("db1", "key2", sealed2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("value2"), value)
+
+	// erasing a key again has no effect
+	require.NoError(t, s.Erase([]*types.KeyErasure{{DbName: "db1", Key: "key1"}}))
+	require.NoError(t, s.Erase([]*types.KeyErasure{{DbName: "db1", Key: "key1"}}))
+}
+
+func TestErasableDBs(t *testing.T) {
+	t.Parallel()
+
+	var nilStore *Store
+	require.False(t, nilStore.Erasable("db1"))
+	nilStore.SetErasableDBs([]string{"db1"})
+	require.NoError(t, nilStore.Erase([]*types.KeyErasure{{DbName: "db1", Key: "key1"}}))
+	_, _, err := nilStore.Unseal("db1", "key1", &types.EncryptedValue{})
+	require.EqualError(t, err, "the value of key [key1] of database [db1] is sealed, but there is no erasure key store")
+
+	s, _ := newTestStore(t)
+	defer s.Close()
+
+	require.False(t, s.Erasable("db1"))
+	s.SetErasableDBs([]string{"db1", "db2"})
+	require.True(t, s.Erasable("db1"))
+	require.True(t, s.Erasable("db2"))
+	s.SetErasableDBs([]string{"db2"})
+	require.False(t, s.Erasable("db1"))
+	require.True(t, s.Erasable("db2"))
+}
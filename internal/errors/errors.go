@@ -74,4 +74,35 @@ type BadRequestError struct {
 
 func (c *BadRequestError) Error() string {
 	return c.ErrMsg
-}
\ No newline at end of file
+}
+
+// TxTooLargeError is used when a transaction is rejected because it is larger than the maximal transaction size
+// allowed by the maximal block size. Such a transaction never fits in a block, hence it cannot be flagged by a
+// validation result in a block, and it is rejected upon submission instead.
+type TxTooLargeError struct {
+	ErrMsg string
+}
+
+func (t *TxTooLargeError) Error() string {
+	return t.ErrMsg
+}
+
+// ResourceExhaustedError is used when a request is rejected because a node resource, e.g., the memory budget, is
+// exhausted. The request may succeed when retried later.
+type ResourceExhaustedError struct {
+	ErrMsg string
+}
+
+func (r *ResourceExhaustedError) Error() string {
+	return r.ErrMsg
+}
+
+// UnavailableError is used when a request is rejected because the node cannot serve it, e.g., because it halted the
+// commit of blocks. The request may succeed on another node.
+type UnavailableError struct {
+	ErrMsg string
+}
+
+func (u *UnavailableError) Error() string {
+	return u.ErrMsg
+}
@@ -0,0 +1,46 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package failpoint injects failures into the commit pipeline, so that the recovery of a node can be tested
+// deterministically. Failpoints are compiled in only by builds with the tag [failpoints]; in any other build Inject
+// does nothing and failpoints cannot be enabled.
+//
+// A failpoint is enabled with an action, which is one of:
+//   - "error": Inject returns an error
+//   - "panic": Inject panics, which mimics a crash of the node
+//   - "sleep(<duration>)": Inject sleeps, e.g., "sleep(500ms)", and then returns no error
+//
+// An action may be prefixed by a count, e.g., "1*error", after which the failpoint disables itself.
+//
+// Failpoints are enabled by the environment variable ORION_FAILPOINTS, e.g.,
+// ORION_FAILPOINTS="after-block-store-commit=panic;provenance-commit=sleep(1s)", or by admins through the diagnostics
+// endpoint.
+package failpoint
+
+// EnvVar holds the failpoints enabled when the process starts, as a ';' separated list of <name>=<action>
+const EnvVar = "ORION_FAILPOINTS"
+
+// The failpoints of the commit pipeline
+const (
+	// AfterBlockStoreCommit is reached after a block is committed to the block store, and before it is committed to
+	// the provenance store and the state database
+	AfterBlockStoreCommit = "after-block-store-commit"
+	// ProvenanceCommit is reached before a block is committed to the provenance store
+	ProvenanceCommit = "provenance-commit"
+	// StateTrieUpdate is reached before the changes of a block are applied on the state trie
+	StateTrieUpdate = "state-trie-update"
+	// TrieStoreCommit is reached before the changes of the state trie are committed to the trie store
+	TrieStoreCommit = "trie-store-commit"
+)
+
+// Names holds the names of all failpoints
+var Names = []string{AfterBlockStoreCommit, ProvenanceCommit, StateTrieUpdate, TrieStoreCommit}
+
+func isFailpoint(name string) bool {
+	for _, n := range Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
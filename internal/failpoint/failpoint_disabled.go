@@ -0,0 +1,30 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !failpoints
+// +build !failpoints
+
+package failpoint
+
+import "github.com/pkg/errors"
+
+// Enabled tells whether failpoints are compiled into this build
+const Enabled = false
+
+// Inject does nothing, as failpoints are not compiled into this build
+func Inject(name string) error {
+	return nil
+}
+
+// Enable fails, as failpoints are not compiled into this build
+func Enable(name, action string) error {
+	return errors.New("failpoints are not supported by this build, which must be built with the tag [failpoints]")
+}
+
+// Disable does nothing, as failpoints are not compiled into this build
+func Disable(name string) {}
+
+// List returns no failpoint, as failpoints are not compiled into this build
+func List() map[string]string {
+	return nil
+}
@@ -0,0 +1,20 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !failpoints
+// +build !failpoints
+
+package failpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailpointsDisabled(t *testing.T) {
+	require.False(t, Enabled)
+	require.EqualError(t, Enable(AfterBlockStoreCommit, "panic"), "failpoints are not supported by this build, which must be built with the tag [failpoints]")
+	require.NoError(t, Inject(AfterBlockStoreCommit))
+	require.Empty(t, List())
+}
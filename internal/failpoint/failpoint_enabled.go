@@ -0,0 +1,144 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build failpoints
+// +build failpoints
+
+package failpoint
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Enabled tells whether failpoints are compiled into this build
+const Enabled = true
+
+type action struct {
+	spec  string
+	kind  string
+	sleep time.Duration
+	// remaining is the number of times the failpoint fires before it disables itself, or zero if it does not
+	remaining int
+}
+
+var (
+	lock    sync.Mutex
+	actions = make(map[string]*action)
+)
+
+func init() {
+	env := os.Getenv(EnvVar)
+	if env == "" {
+		return
+	}
+
+	for _, fp := range strings.Split(env, ";") {
+		if fp = strings.TrimSpace(fp); fp == "" {
+			continue
+		}
+		nameAndAction := strings.SplitN(fp, "=", 2)
+		if len(nameAndAction) != 2 {
+			panic(fmt.Sprintf("the failpoint [%s] in %s must be of the form <name>=<action>", fp, EnvVar))
+		}
+		if err := Enable(nameAndAction[0], nameAndAction[1]); err != nil {
+			panic(fmt.Sprintf("error in %s: %s", EnvVar, err))
+		}
+	}
+}
+
+// Inject executes the action of a failpoint, if the failpoint is enabled
+func Inject(name string) error {
+	lock.Lock()
+	a, ok := actions[name]
+	if ok && a.remaining > 0 {
+		a.remaining--
+		if a.remaining == 0 {
+			delete(actions, name)
+		}
+	}
+	lock.Unlock()
+	if !ok {
+		return nil
+	}
+
+	switch a.kind {
+	case "error":
+		return errors.Errorf("failpoint [%s] injected an error", name)
+	case "panic":
+		panic(fmt.Sprintf("failpoint [%s] injected a panic", name))
+	default:
+		time.Sleep(a.sleep)
+		return nil
+	}
+}
+
+// Enable enables a failpoint with an action, replacing its former action
+func Enable(name, spec string) error {
+	if !isFailpoint(name) {
+		return errors.Errorf("unknown failpoint [%s], expected one of %v", name, Names)
+	}
+	a, err := parseAction(spec)
+	if err != nil {
+		return errors.WithMessagef(err, "invalid action of the failpoint [%s]", name)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	actions[name] = a
+	return nil
+}
+
+// Disable disables a failpoint
+func Disable(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(actions, name)
+}
+
+// List returns the actions of the enabled failpoints
+func List() map[string]string {
+	lock.Lock()
+	defer lock.Unlock()
+
+	enabled := make(map[string]string, len(actions))
+	for name, a := range actions {
+		enabled[name] = a.spec
+	}
+	return enabled
+}
+
+func parseAction(spec string) (*action, error) {
+	a := &action{spec: spec}
+	kind := spec
+	if i := strings.Index(spec, "*"); i >= 0 {
+		count, err := strconv.Atoi(spec[:i])
+		if err != nil || count <= 0 {
+			return nil, errors.Errorf("the count of the action [%s] must be a positive integer", spec)
+		}
+		a.remaining = count
+		kind = spec[i+1:]
+	}
+
+	switch {
+	case kind == "error", kind == "panic":
+		a.kind = kind
+	case strings.HasPrefix(kind, "sleep(") && strings.HasSuffix(kind, ")"):
+		d, err := time.ParseDuration(kind[len("sleep(") : len(kind)-1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "the duration of the action [%s] is not valid", spec)
+		}
+		a.kind = "sleep"
+		a.sleep = d
+	default:
+		return nil, errors.Errorf("unknown action [%s], expected error, panic, or sleep(<duration>)", spec)
+	}
+
+	return a, nil
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build failpoints
+// +build failpoints
+
+package failpoint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailpoints(t *testing.T) {
+	require.True(t, Enabled)
+
+	t.Run("error", func(t *testing.T) {
+		defer Disable(TrieStoreCommit)
+
+		require.NoError(t, Inject(TrieStoreCommit))
+		require.NoError(t, Enable(TrieStoreCommit, "error"))
+		require.Equal(t, map[string]string{TrieStoreCommit: "error"}, List())
+		require.EqualError(t, Inject(TrieStoreCommit), "failpoint [trie-store-commit] injected an error")
+		require.EqualError(t, Inject(TrieStoreCommit), "failpoint [trie-store-commit] injected an error")
+		require.NoError(t, Inject(ProvenanceCommit))
+
+		Disable(TrieStoreCommit)
+		require.NoError(t, Inject(TrieStoreCommit))
+		require.Empty(t, List())
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer Disable(AfterBlockStoreCommit)
+
+		require.NoError(t, Enable(AfterBlockStoreCommit, "panic"))
+		require.PanicsWithValue(t, "failpoint [after-block-store-commit] injected a panic", func() {
+			_ = Inject(AfterBlockStoreCommit)
+		})
+	})
+
+	t.Run("sleep", func(t *testing.T) {
+		defer Disable(ProvenanceCommit)
+
+		require.NoError(t, Enable(ProvenanceCommit, "sleep(20ms)"))
+		start := time.Now()
+		require.NoError(t, Inject(ProvenanceCommit))
+		require.True(t, time.Since(start) >= 20*time.Millisecond)
+	})
+
+	t.Run("count", func(t *testing.T) {
+		require.NoError(t, Enable(TrieStoreCommit, "2*error"))
+		require.Error(t, Inject(TrieStoreCommit))
+		require.Error(t, Inject(TrieStoreCommit))
+		require.NoError(t, Inject(TrieStoreCommit))
+		require.Empty(t, List())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.EqualError(t, Enable("bogus", "error"), "unknown failpoint [bogus], expected one of [after-block-store-commit provenance-commit state-trie-update trie-store-commit]")
+		require.EqualError(t, Enable(TrieStoreCommit, "exit"),
+			"invalid action of the failpoint [trie-store-commit]: unknown action [exit], expected error, panic, or sleep(<duration>)")
+		require.EqualError(t, Enable(TrieStoreCommit, "0*error"),
+			"invalid action of the failpoint [trie-store-commit]: the count of the action [0*error] must be a positive integer")
+		require.EqualError(t, Enable(TrieStoreCommit, "sleep(1x)"),
+			"invalid action of the failpoint [trie-store-commit]: the duration of the action [sleep(1x)] is not valid: time: unknown unit \"x\" in duration \"1x\"")
+		require.Empty(t, List())
+	})
+}
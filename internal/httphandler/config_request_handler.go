@@ -41,8 +41,37 @@ func NewConfigRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handle
 
 	handler.router.HandleFunc(constants.GetConfig, handler.configQuery).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.GetLastConfigBlock, handler.configBlockQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/history" returns the list of committed config blocks
+	handler.router.HandleFunc(constants.GetConfigHistory, handler.configHistoryQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/diff/{fromBlockId}/{toBlockId}" returns the changes between the configs committed in two config blocks
+	handler.router.HandleFunc(constants.GetConfigDiff, handler.configDiffQuery).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.GetNodeConfig, handler.nodeQuery).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.PostConfigTx, handler.configTransaction).Methods(http.MethodPost)
+	// HTTP POST "/config/tx/dryrun" validates a config transaction and reports the changes it would make, without submitting it
+	handler.router.HandleFunc(constants.PostConfigTxDryRun, handler.configTransactionDryRun).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.PostSnapshot, handler.triggerSnapshot).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.GetConsensusDiag, handler.consensusDiagnosticsQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/storage/report?top=10&delimiter=/" reports duplicate values, the largest keys and key prefixes, and version hot spots
+	handler.router.HandleFunc(constants.GetStorageReport, handler.storageReportQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/state/hash/{dbname}" returns the canonical hash of the full contents of a database, to compare replicas
+	handler.router.HandleFunc(constants.GetStateHash, handler.stateHashQuery).Methods(http.MethodGet)
+	// HTTP POST "/config/index/compact/{dbname}" compacts the storage of the index of a database
+	handler.router.HandleFunc(constants.PostCompactIndex, handler.compactIndex).Methods(http.MethodPost)
+	// HTTP GET "/config/quarantine" returns the block that the node failed to validate or commit, and quarantined, if any
+	handler.router.HandleFunc(constants.GetQuarantine, handler.quarantinedBlockQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/rejectedtxs" returns the transactions that the node most recently rejected before ordering
+	handler.router.HandleFunc(constants.GetRejectedTxs, handler.rejectedTxsQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/slowqueries" returns the data queries that the node most recently executed in more than the
+	// slow query threshold
+	handler.router.HandleFunc(constants.GetSlowQueries, handler.slowQueriesQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/witness" returns the verification status and the discrepancy alerts of a witness or standby node
+	handler.router.HandleFunc(constants.GetWitnessStatus, handler.witnessStatusQuery).Methods(http.MethodGet)
+	// HTTP GET "/config/indexusage" returns the usage of the indexes of the data databases by queries
+	handler.router.HandleFunc(constants.GetIndexUsage, handler.indexUsageQuery).Methods(http.MethodGet)
+	// HTTP POST "/config/leader/transfer/{nodeId}" transfers the leadership to the given node
+	handler.router.HandleFunc(constants.PostTransferLeadership, handler.transferLeadership).Methods(http.MethodPost)
+	// HTTP POST "/config/leader/transfer" transfers the leadership to a node chosen by the leader
+	handler.router.HandleFunc(constants.PostTransferLeadershipPrefix, handler.transferLeadership).Methods(http.MethodPost)
 	// HTTP GET "/config/cluster?nocert=true" returns nodes without certificates
 	handler.router.HandleFunc(constants.GetClusterStatus, handler.clusterStatusQuery).Methods(http.MethodGet).Queries("nocert", "{noCertificates:true|false}")
 	// HTTP GET "/config/cluster" returns nodes with certificates
@@ -56,7 +85,7 @@ func (c *configRequestHandler) ServeHTTP(response http.ResponseWriter, request *
 }
 
 func (c *configRequestHandler) configQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetConfig, c.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetConfig, c.sigVerifier, c.db)
 	if respondedErr {
 		return
 	}
@@ -75,7 +104,7 @@ func (c *configRequestHandler) configQuery(response http.ResponseWriter, request
 }
 
 func (c *configRequestHandler) configBlockQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetLastConfigBlock, c.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetLastConfigBlock, c.sigVerifier, c.db)
 	if respondedErr {
 		return
 	}
@@ -108,8 +137,70 @@ func (c *configRequestHandler) configBlockQuery(response http.ResponseWriter, re
 	utils.SendHTTPResponse(response, http.StatusOK, configBlockResponseEnvelope)
 }
 
+func (c *configRequestHandler) configHistoryQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetConfigHistory, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetConfigHistoryQuery)
+
+	configHistoryResponseEnvelope, err := c.db.GetConfigHistory(query.GetUserId())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, configHistoryResponseEnvelope)
+}
+
+func (c *configRequestHandler) configDiffQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetConfigDiff, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetConfigDiffQuery)
+
+	configDiffResponseEnvelope, err := c.db.GetConfigDiff(query.GetUserId(), query.GetFromBlockNumber(), query.GetToBlockNumber())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.NotFoundErr:
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, configDiffResponseEnvelope)
+}
+
 func (c *configRequestHandler) clusterStatusQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetClusterStatus, c.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetClusterStatus, c.sigVerifier, c.db)
 	if respondedErr {
 		return
 	}
@@ -129,8 +220,371 @@ func (c *configRequestHandler) clusterStatusQuery(response http.ResponseWriter,
 	utils.SendHTTPResponse(response, http.StatusOK, clusterStatus)
 }
 
+func (c *configRequestHandler) triggerSnapshot(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostSnapshot, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.TriggerSnapshotQuery)
+
+	snapshotResponseEnvelope, err := c.db.TriggerSnapshot(query.GetUserId())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.ClosedError:
+			status = http.StatusServiceUnavailable
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, snapshotResponseEnvelope)
+}
+
+func (c *configRequestHandler) consensusDiagnosticsQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetConsensusDiag, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetConsensusDiagnosticsQuery)
+
+	diagResponseEnvelope, err := c.db.GetConsensusDiagnostics(query.GetUserId())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, diagResponseEnvelope)
+}
+
+func (c *configRequestHandler) storageReportQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetStorageReport, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetStorageReportQuery)
+
+	reportResponseEnvelope, err := c.db.GetStorageReport(query.GetUserId(), query.GetTop(), query.GetPrefixDelimiter())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, reportResponseEnvelope)
+}
+
+func (c *configRequestHandler) stateHashQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetStateHash, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetStateHashQuery)
+
+	hashResponseEnvelope, err := c.db.GetStateHash(query.GetUserId(), query.GetDbName())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.NotFoundErr:
+			status = http.StatusNotFound
+		case *ierrors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, hashResponseEnvelope)
+}
+
+func (c *configRequestHandler) compactIndex(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostCompactIndex, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.CompactIndexQuery)
+
+	compactResponseEnvelope, err := c.db.CompactIndex(query.GetUserId(), query.GetDbName())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.NotFoundErr:
+			status = http.StatusNotFound
+		case *ierrors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, compactResponseEnvelope)
+}
+
+func (c *configRequestHandler) quarantinedBlockQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetQuarantine, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetQuarantinedBlockQuery)
+
+	quarantineResponseEnvelope, err := c.db.GetQuarantinedBlock(query.GetUserId())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, quarantineResponseEnvelope)
+}
+
+func (c *configRequestHandler) rejectedTxsQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetRejectedTxs, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetRejectedTxsQuery)
+
+	rejectedResponseEnvelope, err := c.db.GetRejectedTxs(query.GetUserId())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, rejectedResponseEnvelope)
+}
+
+func (c *configRequestHandler) slowQueriesQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetSlowQueries, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetSlowQueriesQuery)
+
+	slowQueriesResponseEnvelope, err := c.db.GetSlowQueries(query.GetUserId())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, slowQueriesResponseEnvelope)
+}
+
+func (c *configRequestHandler) witnessStatusQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetWitnessStatus, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetWitnessStatusQuery)
+
+	witnessResponseEnvelope, err := c.db.GetWitnessStatus(query.GetUserId())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, witnessResponseEnvelope)
+}
+
+func (c *configRequestHandler) indexUsageQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetIndexUsage, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetIndexUsageQuery)
+
+	indexUsageResponseEnvelope, err := c.db.GetIndexUsage(query.GetUserId(), query.GetUnusedOnly())
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, indexUsageResponseEnvelope)
+}
+
+func (c *configRequestHandler) transferLeadership(response http.ResponseWriter, request *http.Request) {
+	timeout, err := validateAndParseTxPostHeader(&request.Header)
+	if err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostTransferLeadership, c.sigVerifier, c.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.TransferLeadershipQuery)
+
+	transferResponseEnvelope, err := c.db.TransferLeadership(query.GetUserId(), query.GetTargetNodeId(), timeout)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.NotLeaderError:
+			leaderErr := err.(*ierrors.NotLeaderError)
+			if leaderErr.GetLeaderID() != 0 {
+				utils.SendHTTPRedirectServer(response, request, leaderErr.GetLeaderHostPort())
+				return
+			}
+			status = http.StatusServiceUnavailable
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.BadRequestError:
+			status = http.StatusBadRequest
+		case *ierrors.TimeoutErr, *ierrors.ClosedError:
+			status = http.StatusServiceUnavailable
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, transferResponseEnvelope)
+}
+
 func (c *configRequestHandler) nodeQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetNodeConfig, c.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetNodeConfig, c.sigVerifier, c.db)
 	if respondedErr {
 		return
 	}
@@ -153,41 +607,99 @@ func (c *configRequestHandler) nodeQuery(response http.ResponseWriter, request *
 func (c *configRequestHandler) configTransaction(response http.ResponseWriter, request *http.Request) {
 	timeout, err := validateAndParseTxPostHeader(&request.Header)
 	if err != nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		c.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED, err.Error())
 		return
 	}
 
+	txEnv, respondedErr := c.extractVerifiedConfigTx(response, request, true)
+	if respondedErr {
+		return
+	}
+
+	c.txHandler.handleTransaction(response, request, txEnv, timeout)
+}
+
+func (c *configRequestHandler) configTransactionDryRun(response http.ResponseWriter, request *http.Request) {
+	txEnv, respondedErr := c.extractVerifiedConfigTx(response, request, false)
+	if respondedErr {
+		return
+	}
+
+	dryRunResponseEnvelope, err := c.db.DryRunConfigTx(txEnv)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.NotLeaderError:
+			leaderErr := err.(*ierrors.NotLeaderError)
+			if leaderErr.GetLeaderID() != 0 {
+				utils.SendHTTPRedirectServer(response, request, leaderErr.GetLeaderHostPort())
+				return
+			}
+			status = http.StatusServiceUnavailable
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		case *ierrors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, dryRunResponseEnvelope)
+}
+
+// extractVerifiedConfigTx decodes a config transaction envelope from the request body and verifies its signature.
+// If it fails, it responds with an error, and returns true. The failure is recorded in the dead-letter queue if the
+// transaction is being submitted.
+func (c *configRequestHandler) extractVerifiedConfigTx(response http.ResponseWriter, request *http.Request, submit bool) (*types.ConfigTxEnvelope, bool) {
+	reject := func(status int, txID, userID string, category types.RejectedTx_Category, errMsg string) {
+		if submit {
+			c.txHandler.rejectTransaction(response, status, txID, userID, category, errMsg)
+			return
+		}
+		utils.SendHTTPResponse(response, status, &types.HttpResponseErr{ErrMsg: errMsg})
+	}
+
 	d := json.NewDecoder(request.Body)
 	d.DisallowUnknownFields()
 
 	txEnv := &types.ConfigTxEnvelope{}
 	if err := d.Decode(txEnv); err != nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
-		return
+		reject(decodeErrorStatus(err), "", "", types.RejectedTx_MALFORMED, err.Error())
+		return nil, true
 	}
 
 	if txEnv.Payload == nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload)})
-		return
+		reject(http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED, fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload))
+		return nil, true
 	}
 
+	txID := txEnv.Payload.TxId
 	if txEnv.Payload.UserId == "" {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload)})
-		return
+		reject(http.StatusBadRequest, txID, "", types.RejectedTx_MALFORMED,
+			fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload))
+		return nil, true
 	}
 
 	if len(txEnv.Signature) == 0 {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing Signature in transaction envelope payload (%T)", txEnv.Payload)})
-		return
+		reject(http.StatusBadRequest, txID, txEnv.Payload.UserId, types.RejectedTx_UNAUTHENTICATED,
+			fmt.Sprintf("missing Signature in transaction envelope payload (%T)", txEnv.Payload))
+		return nil, true
 	}
 
 	if err, code := VerifyRequestSignature(c.sigVerifier, txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload); err != nil {
-		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
-		return
+		reject(code, txID, txEnv.Payload.UserId, types.RejectedTx_UNAUTHENTICATED, err.Error())
+		return nil, true
 	}
 
-	c.txHandler.handleTransaction(response, request, txEnv, timeout)
+	return txEnv, false
 }
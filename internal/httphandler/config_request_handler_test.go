@@ -321,6 +321,7 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "time: invalid duration \"asdf\"").Return()
 				return db
 			},
 			timeoutStr:   "asdf",
@@ -340,6 +341,7 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "timeout can't be negative \"-2s\"").Return()
 				return db
 			},
 			timeoutStr:   "-2s",
@@ -356,6 +358,7 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing transaction envelope payload (*types.ConfigTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -374,6 +377,7 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing UserID in transaction envelope payload (*types.ConfigTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -389,6 +393,7 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "missing Signature in transaction envelope payload (*types.ConfigTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -408,6 +413,7 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
@@ -431,6 +437,7 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 			createMockAndInstrument: func(t *testing.T, configTx *types.ConfigTxEnvelope, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", "not-admin").Return(nil, errors.New("no such user"))
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
@@ -544,6 +551,168 @@ func TestConfigRequestHandler_SubmitConfig(t *testing.T) {
 	}
 }
 
+func TestConfigRequestHandler_DryRunConfigTx(t *testing.T) {
+	submittingUserName := "admin"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"admin", "bob"})
+	adminCert, adminSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "admin")
+	_, bobSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "bob")
+
+	configTx := &types.ConfigTx{
+		UserId: submittingUserName,
+		TxId:   "1",
+		NewConfig: &types.ClusterConfig{
+			Admins: []*types.Admin{
+				{
+					Id:          "admin1",
+					Certificate: []byte("bogus"),
+				},
+			},
+		},
+		ReadOldConfigVersion: &types.Version{
+			BlockNum: 1,
+			TxNum:    1,
+		},
+	}
+	sigAdmin := testutils.SignatureFromTx(t, adminSigner, configTx)
+	sigBob := testutils.SignatureFromTx(t, bobSigner, configTx)
+
+	dryRunResp := &types.ConfigTxDryRunResponseEnvelope{
+		Response: &types.ConfigTxDryRunResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "testNodeId",
+			},
+			ValidationInfo: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+			ConsensusChanged: true,
+			RemovedMembers: []*types.PeerConfig{
+				{
+					NodeId:   "node3",
+					RaftId:   3,
+					PeerHost: "10.10.10.13",
+					PeerPort: 7003,
+				},
+			},
+			CurrentProtocolVersion: 1,
+			UpdatedProtocolVersion: 1,
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		txEnv              *types.ConfigTxEnvelope
+		dbMockFactory      func(txEnv *types.ConfigTxEnvelope) bcdb.DB
+		expectedResponse   *types.ConfigTxDryRunResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+		expectedLocation   string
+	}{
+		{
+			name:  "successfully dry-run a config tx",
+			txEnv: &types.ConfigTxEnvelope{Payload: configTx, Signature: sigAdmin},
+			dbMockFactory: func(txEnv *types.ConfigTxEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("DryRunConfigTx", mock.Anything).Run(func(args mock.Arguments) {
+					require.Equal(t, txEnv, args[0].(*types.ConfigTxEnvelope))
+				}).Return(dryRunResp, nil)
+				return db
+			},
+			expectedResponse:   dryRunResp,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "missing payload",
+			txEnv: &types.ConfigTxEnvelope{Signature: sigAdmin},
+			dbMockFactory: func(txEnv *types.ConfigTxEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "missing transaction envelope payload (*types.ConfigTx)",
+		},
+		{
+			name:  "fail to verify signature of submitting user",
+			txEnv: &types.ConfigTxEnvelope{Payload: configTx, Signature: sigBob},
+			dbMockFactory: func(txEnv *types.ConfigTxEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name:  "user is not an admin",
+			txEnv: &types.ConfigTxEnvelope{Payload: configTx, Signature: sigAdmin},
+			dbMockFactory: func(txEnv *types.ConfigTxEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("DryRunConfigTx", mock.Anything).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [admin] has no permission to dry-run a config transaction"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'POST http://server1.example.com:6091/config/tx/dryrun' because the user [admin] has no permission to dry-run a config transaction",
+		},
+		{
+			name:  "not a leader",
+			txEnv: &types.ConfigTxEnvelope{Payload: configTx, Signature: sigAdmin},
+			dbMockFactory: func(txEnv *types.ConfigTxEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("DryRunConfigTx", mock.Anything).Return(nil, &interrors.NotLeaderError{LeaderID: 3, LeaderHostPort: "server3.example.com:6091"})
+				return db
+			},
+			expectedStatusCode: http.StatusTemporaryRedirect,
+			expectedLocation:   "http://server3.example.com:6091/config/tx/dryrun",
+		},
+		{
+			name:  "node is a witness",
+			txEnv: &types.ConfigTxEnvelope{Payload: configTx, Signature: sigAdmin},
+			dbMockFactory: func(txEnv *types.ConfigTxEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("DryRunConfigTx", mock.Anything).Return(nil, &interrors.BadRequestError{ErrMsg: "node [node4] is a witness and does not accept transactions"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'POST http://server1.example.com:6091/config/tx/dryrun' because node [node4] is a witness and does not accept transactions",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("DryRunConfigTx %s", tt.name), func(t *testing.T) {
+			txBytes, err := json.Marshal(tt.txEnv)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "http://server1.example.com:6091"+constants.PostConfigTxDryRun, bytes.NewReader(txBytes))
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(tt.dbMockFactory(tt.txEnv), logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			switch tt.expectedStatusCode {
+			case http.StatusOK:
+				res := &types.ConfigTxDryRunResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			case http.StatusTemporaryRedirect:
+				require.Equal(t, tt.expectedLocation, rr.Header().Get("Location"))
+			default:
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+		})
+	}
+}
+
 func TestConfigRequestHandler_GetNodesConfig(t *testing.T) {
 	submittingUserName := "alice"
 	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice", "bob"})
@@ -894,7 +1063,7 @@ func TestConfigRequestHandler_GetLastConfigBlock(t *testing.T) {
 	}
 }
 
-func TestConfigRequestHandler_GetClusterStatus(t *testing.T) {
+func TestConfigRequestHandler_GetConfigHistory(t *testing.T) {
 	submittingUserName := "alice"
 	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice", "bob"})
 	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
@@ -903,142 +1072,388 @@ func TestConfigRequestHandler_GetClusterStatus(t *testing.T) {
 	testCases := []struct {
 		name               string
 		requestFactory     func() *http.Request
-		dbMockFactory      func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB
-		expectedResponse   *types.GetClusterStatusResponseEnvelope
+		dbMockFactory      func(response *types.GetConfigHistoryResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetConfigHistoryResponseEnvelope
 		expectedStatusCode int
 		expectedErr        string
 	}{
 		{
-			name: "successfully retrieve cluster status",
+			name: "successfully retrieve config history",
 			requestFactory: func() *http.Request {
-				req := httptest.NewRequest(http.MethodGet, constants.GetClusterStatus, nil)
+				req := httptest.NewRequest(http.MethodGet, constants.GetConfigHistory, nil)
 				req.Header.Set(constants.UserHeader, submittingUserName)
-				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetClusterStatusQuery{UserId: submittingUserName})
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetConfigHistoryQuery{UserId: submittingUserName})
 				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
 				return req
 			},
-			dbMockFactory: func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB {
+			dbMockFactory: func(response *types.GetConfigHistoryResponseEnvelope) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetClusterStatus", false).Return(response, nil)
+				db.On("GetConfigHistory", submittingUserName).Return(response, nil)
 				return db
 			},
-			expectedResponse: &types.GetClusterStatusResponseEnvelope{
-				Response: &types.GetClusterStatusResponse{
+			expectedResponse: &types.GetConfigHistoryResponseEnvelope{
+				Response: &types.GetConfigHistoryResponse{
 					Header: &types.ResponseHeader{
-						NodeId: "testNodeId1",
+						NodeId: "testNodeId",
 					},
-					Nodes: []*types.NodeConfig{
-						{
-							Id:          "testNodeId1",
-							Address:     "10.10.10.11",
-							Port:        23001,
-							Certificate: []byte("bogus-cert"),
-						},
+					Entries: []*types.ConfigHistoryEntry{
 						{
-							Id:          "testNodeId2",
-							Address:     "10.10.10.12",
-							Port:        23001,
-							Certificate: []byte("bogus-cert"),
+							BlockNumber:      1,
+							TxId:             "tx1",
+							UserId:           "admin",
+							Timestamp:        1000,
+							NodesChanged:     true,
+							AdminsChanged:    true,
+							CaChanged:        true,
+							ConsensusChanged: true,
 						},
 						{
-							Id:          "testNodeId3",
-							Address:     "10.10.10.13",
-							Port:        23001,
-							Certificate: []byte("bogus-cert"),
+							BlockNumber: 5,
+							TxId:        "tx5",
+							UserId:      "admin",
+							Timestamp:   5000,
+							CaChanged:   true,
 						},
 					},
-					Version: &types.Version{BlockNum: 1, TxNum: 0},
-					Leader:  "testNodeId1",
-					Active:  []string{"testNodeId1", "testNodeId2", "testNodeId3"},
 				},
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedErr:        "",
 		},
 		{
-			name: "successfully retrieve cluster status, query noCert=true",
+			name: "fail to verify signature of submitting user",
 			requestFactory: func() *http.Request {
-				req := httptest.NewRequest(http.MethodGet, constants.GetClusterStatus+"?nocert=true", nil)
+				req := httptest.NewRequest(http.MethodGet, constants.GetConfigHistory, nil)
 				req.Header.Set(constants.UserHeader, submittingUserName)
-				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetClusterStatusQuery{
-					UserId:         submittingUserName,
-					NoCertificates: true,
-				})
+				sig := testutils.SignatureFromQuery(t, bobSigner, &types.GetConfigHistoryQuery{UserId: submittingUserName})
 				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
 				return req
 			},
-			dbMockFactory: func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB {
+			dbMockFactory: func(response *types.GetConfigHistoryResponseEnvelope) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetClusterStatus", true).Return(response, nil)
 				return db
 			},
-			expectedResponse: &types.GetClusterStatusResponseEnvelope{
-				Response: &types.GetClusterStatusResponse{
+			expectedResponse:   nil,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name: "submitting user is not an admin",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, constants.GetConfigHistory, nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetConfigHistoryQuery{UserId: submittingUserName})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.GetConfigHistoryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetConfigHistory", submittingUserName).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to read the config history"})
+				return db
+			},
+			expectedResponse:   nil,
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/history' because the user [alice] has no permission to read the config history",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.requestFactory()
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetConfigHistoryResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetConfigDiff(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() *http.Request
+		dbMockFactory      func(response *types.GetConfigDiffResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetConfigDiffResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "successfully retrieve config diff",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, constants.URLForGetConfigDiff(1, 5), nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetConfigDiffQuery{UserId: submittingUserName, FromBlockNumber: 1, ToBlockNumber: 5})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.GetConfigDiffResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetConfigDiff", submittingUserName, uint64(1), uint64(5)).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetConfigDiffResponseEnvelope{
+				Response: &types.GetConfigDiffResponse{
 					Header: &types.ResponseHeader{
-						NodeId: "testNodeId1",
+						NodeId: "testNodeId",
 					},
-					Nodes: []*types.NodeConfig{
-						{
-							Id:      "testNodeId1",
-							Address: "10.10.10.11",
-							Port:    23001,
-						},
-						{
-							Id:      "testNodeId2",
-							Address: "10.10.10.12",
-							Port:    23001,
-						},
-						{
-							Id:      "testNodeId3",
-							Address: "10.10.10.13",
-							Port:    23001,
-						},
+					FromBlockNumber: 1,
+					ToBlockNumber:   5,
+					Diff: &types.ClusterConfigDiff{
+						AddedRootCas:   [][]byte{[]byte("new-root-ca")},
+						RemovedRootCas: [][]byte{[]byte("old-root-ca")},
 					},
-					Version: &types.Version{BlockNum: 1, TxNum: 0},
-					Leader:  "testNodeId1",
-					Active:  []string{"testNodeId1", "testNodeId2", "testNodeId3"},
 				},
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedErr:        "",
 		},
 		{
-			name: "successfully retrieve cluster status, query noCert=false",
+			name: "signature is not on the block numbers",
 			requestFactory: func() *http.Request {
-				req := httptest.NewRequest(http.MethodGet, constants.GetClusterStatus+"?nocert=false", nil)
+				req := httptest.NewRequest(http.MethodGet, constants.URLForGetConfigDiff(1, 5), nil)
 				req.Header.Set(constants.UserHeader, submittingUserName)
-				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetClusterStatusQuery{
-					UserId:         submittingUserName,
-					NoCertificates: false,
-				})
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetConfigDiffQuery{UserId: submittingUserName, FromBlockNumber: 1, ToBlockNumber: 6})
 				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
 				return req
 			},
-			dbMockFactory: func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB {
+			dbMockFactory: func(response *types.GetConfigDiffResponseEnvelope) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetClusterStatus", false).Return(response, nil)
 				return db
 			},
-			expectedResponse: &types.GetClusterStatusResponseEnvelope{
-				Response: &types.GetClusterStatusResponse{
-					Header: &types.ResponseHeader{
-						NodeId: "testNodeId1",
-					},
-					Nodes: []*types.NodeConfig{
-						{
-							Id:          "testNodeId1",
-							Address:     "10.10.10.11",
-							Port:        23001,
-							Certificate: []byte("bogus-cert"),
-						},
-						{
-							Id:          "testNodeId2",
-							Address:     "10.10.10.12",
-							Port:        23001,
-							Certificate: []byte("bogus-cert"),
+			expectedResponse:   nil,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name: "block is not a config block",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, constants.URLForGetConfigDiff(1, 2), nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetConfigDiffQuery{UserId: submittingUserName, FromBlockNumber: 1, ToBlockNumber: 2})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.GetConfigDiffResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetConfigDiff", submittingUserName, uint64(1), uint64(2)).Return(nil, &interrors.NotFoundErr{Message: "block [2] is not a committed config block"})
+				return db
+			},
+			expectedResponse:   nil,
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET /config/diff/1/2' because block [2] is not a committed config block",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.requestFactory()
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetConfigDiffResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetClusterStatus(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice", "bob"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	_, bobSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "bob")
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() *http.Request
+		dbMockFactory      func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetClusterStatusResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "successfully retrieve cluster status",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, constants.GetClusterStatus, nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetClusterStatusQuery{UserId: submittingUserName})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetClusterStatus", false).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetClusterStatusResponseEnvelope{
+				Response: &types.GetClusterStatusResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeId1",
+					},
+					Nodes: []*types.NodeConfig{
+						{
+							Id:          "testNodeId1",
+							Address:     "10.10.10.11",
+							Port:        23001,
+							Certificate: []byte("bogus-cert"),
+						},
+						{
+							Id:          "testNodeId2",
+							Address:     "10.10.10.12",
+							Port:        23001,
+							Certificate: []byte("bogus-cert"),
+						},
+						{
+							Id:          "testNodeId3",
+							Address:     "10.10.10.13",
+							Port:        23001,
+							Certificate: []byte("bogus-cert"),
+						},
+					},
+					Version: &types.Version{BlockNum: 1, TxNum: 0},
+					Leader:  "testNodeId1",
+					Active:  []string{"testNodeId1", "testNodeId2", "testNodeId3"},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedErr:        "",
+		},
+		{
+			name: "successfully retrieve cluster status, query noCert=true",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, constants.GetClusterStatus+"?nocert=true", nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetClusterStatusQuery{
+					UserId:         submittingUserName,
+					NoCertificates: true,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetClusterStatus", true).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetClusterStatusResponseEnvelope{
+				Response: &types.GetClusterStatusResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeId1",
+					},
+					Nodes: []*types.NodeConfig{
+						{
+							Id:      "testNodeId1",
+							Address: "10.10.10.11",
+							Port:    23001,
+						},
+						{
+							Id:      "testNodeId2",
+							Address: "10.10.10.12",
+							Port:    23001,
+						},
+						{
+							Id:      "testNodeId3",
+							Address: "10.10.10.13",
+							Port:    23001,
+						},
+					},
+					Version: &types.Version{BlockNum: 1, TxNum: 0},
+					Leader:  "testNodeId1",
+					Active:  []string{"testNodeId1", "testNodeId2", "testNodeId3"},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedErr:        "",
+		},
+		{
+			name: "successfully retrieve cluster status, query noCert=false",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, constants.GetClusterStatus+"?nocert=false", nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetClusterStatusQuery{
+					UserId:         submittingUserName,
+					NoCertificates: false,
+				})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.GetClusterStatusResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetClusterStatus", false).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetClusterStatusResponseEnvelope{
+				Response: &types.GetClusterStatusResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeId1",
+					},
+					Nodes: []*types.NodeConfig{
+						{
+							Id:          "testNodeId1",
+							Address:     "10.10.10.11",
+							Port:        23001,
+							Certificate: []byte("bogus-cert"),
+						},
+						{
+							Id:          "testNodeId2",
+							Address:     "10.10.10.12",
+							Port:        23001,
+							Certificate: []byte("bogus-cert"),
 						},
 						{
 							Id:          "testNodeId3",
@@ -1221,3 +1636,1303 @@ func TestConfigRequestHandler_GetClusterStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigRequestHandler_TriggerSnapshot(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice", "bob"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	_, bobSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "bob")
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() *http.Request
+		dbMockFactory      func(response *types.TriggerSnapshotResponseEnvelope) bcdb.DB
+		expectedResponse   *types.TriggerSnapshotResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "successfully trigger a snapshot",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, constants.PostSnapshot, nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.TriggerSnapshotQuery{UserId: submittingUserName})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.TriggerSnapshotResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TriggerSnapshot", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.TriggerSnapshotResponseEnvelope{
+				Response: &types.TriggerSnapshotResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeId",
+					},
+					BlockNumber: 10,
+					RaftIndex:   12,
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "fail to verify signature of submitting user",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, constants.PostSnapshot, nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, bobSigner, &types.TriggerSnapshotQuery{UserId: submittingUserName})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.TriggerSnapshotResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name: "user is not an admin",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, constants.PostSnapshot, nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.TriggerSnapshotQuery{UserId: submittingUserName})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.TriggerSnapshotResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TriggerSnapshot", submittingUserName).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to trigger a snapshot"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'POST /config/snapshot' because the user [alice] has no permission to trigger a snapshot",
+		},
+		{
+			name: "a session token is not accepted",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, constants.PostSnapshot, nil)
+				req.Header.Set(constants.SessionTokenHeader, "alice-token")
+				return req
+			},
+			dbMockFactory: func(response *types.TriggerSnapshotResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("VerifySessionToken", "alice-token").Return(submittingUserName, nil)
+				db.On("TriggerSnapshot", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "a session token is not accepted by /config/snapshot, the query must be signed",
+		},
+		{
+			name: "failing to take a snapshot",
+			requestFactory: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, constants.PostSnapshot, nil)
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.TriggerSnapshotQuery{UserId: submittingUserName})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+				return req
+			},
+			dbMockFactory: func(response *types.TriggerSnapshotResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TriggerSnapshot", submittingUserName).Return(nil, errors.New("no entries were applied since the last snapshot"))
+				return db
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'POST /config/snapshot' because no entries were applied since the last snapshot",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("TriggerSnapshot %s", tt.name), func(t *testing.T) {
+			req := tt.requestFactory()
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.TriggerSnapshotResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetConsensusDiagnostics(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, constants.GetConsensusDiag, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetConsensusDiagnosticsQuery{UserId: submittingUserName})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		dbMockFactory      func(response *types.GetConsensusDiagnosticsResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetConsensusDiagnosticsResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "successfully get the consensus diagnostics",
+			dbMockFactory: func(response *types.GetConsensusDiagnosticsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetConsensusDiagnostics", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetConsensusDiagnosticsResponseEnvelope{
+				Response: &types.GetConsensusDiagnosticsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					RaftId:                   1,
+					RaftState:                "StateLeader",
+					Term:                     3,
+					LeaderId:                 "node1",
+					CommitIndex:              20,
+					AppliedIndex:             20,
+					LeaderChanges:            2,
+					Elections:                1,
+					InFlightBlocks:           1,
+					LastProposedBlockNumber:  11,
+					LastCommittedBlockNumber: 10,
+					Peers: []*types.PeerDiagnostics{
+						{
+							NodeId:        "node2",
+							RaftId:        2,
+							Active:        true,
+							MatchIndex:    20,
+							NextIndex:     21,
+							ProgressState: "StateReplicate",
+						},
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "user is not an admin",
+			dbMockFactory: func(response *types.GetConsensusDiagnosticsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetConsensusDiagnostics", submittingUserName).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the consensus diagnostics"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/consensus/diagnostics' because the user [alice] has no permission to get the consensus diagnostics",
+		},
+		{
+			name: "node does not take part in consensus",
+			dbMockFactory: func(response *types.GetConsensusDiagnosticsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetConsensusDiagnostics", submittingUserName).Return(nil, &interrors.BadRequestError{ErrMsg: "node [node4] is a witness and does not take part in consensus"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'GET /config/consensus/diagnostics' because node [node4] is a witness and does not take part in consensus",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetConsensusDiagnostics %s", tt.name), func(t *testing.T) {
+			req := requestFactory()
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetConsensusDiagnosticsResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetStorageReport(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func(top uint32, delimiter string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, constants.URLForGetStorageReport(top, delimiter), nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetStorageReportQuery{UserId: submittingUserName, Top: top, PrefixDelimiter: delimiter})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		dbMockFactory      func(response *types.GetStorageReportResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetStorageReportResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:    "successfully get the storage report",
+			request: requestFactory(5, ":"),
+			dbMockFactory: func(response *types.GetStorageReportResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetStorageReport", submittingUserName, uint32(5), ":").Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetStorageReportResponseEnvelope{
+				Response: &types.GetStorageReportResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					BlockHeight: 10,
+					Dbs: []*types.DBStorage{
+						{DbName: "db1", Keys: 3, ValueBytes: 30},
+					},
+					DuplicateValues: []*types.DuplicateValue{
+						{
+							ValueHash: []byte("hash"),
+							Size:      10,
+							Count:     2,
+							Keys: []*types.KeyStorage{
+								{DbName: "db1", Key: "a:1", Size: 10},
+								{DbName: "db1", Key: "a:2", Size: 10},
+							},
+						},
+					},
+					LargestKeys: []*types.KeyStorage{
+						{DbName: "db1", Key: "a:1", Size: 10},
+					},
+					LargestPrefixes: []*types.PrefixStorage{
+						{DbName: "db1", Prefix: "a:", Keys: 2, ValueBytes: 20},
+					},
+					VersionHotSpots: []*types.KeyStorage{
+						{DbName: "db1", Key: "a:1", Size: 10, Versions: 4},
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "top is not a number",
+			request: func() *http.Request {
+				req := requestFactory(0, "")
+				req.URL.RawQuery = "top=many"
+				return req
+			}(),
+			dbMockFactory: func(response *types.GetStorageReportResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the top parameter must be a non-negative integer \"many\"",
+		},
+		{
+			name:    "top is too large",
+			request: requestFactory(5000, ""),
+			dbMockFactory: func(response *types.GetStorageReportResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetStorageReport", submittingUserName, uint32(5000), "").Return(nil, &interrors.BadRequestError{ErrMsg: "the number of entries [5000] in a storage report must be up to 1000"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'GET /config/storage/report?top=5000' because the number of entries [5000] in a storage report must be up to 1000",
+		},
+		{
+			name:    "user is not an admin",
+			request: requestFactory(0, ""),
+			dbMockFactory: func(response *types.GetStorageReportResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetStorageReport", submittingUserName, uint32(0), "").Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the storage report"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/storage/report' because the user [alice] has no permission to get the storage report",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetStorageReport %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetStorageReportResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetStateHash(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func(dbName string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, constants.URLForGetStateHash(dbName), nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetStateHashQuery{UserId: submittingUserName, DbName: dbName})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		dbMockFactory      func(response *types.GetStateHashResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetStateHashResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:    "successfully get the hash",
+			request: requestFactory("db1"),
+			dbMockFactory: func(response *types.GetStateHashResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetStateHash", submittingUserName, "db1").Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetStateHashResponseEnvelope{
+				Response: &types.GetStateHashResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					BlockHeight: 10,
+					DbName:      "db1",
+					Keys:        3,
+					Hash:        []byte("hash"),
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "database does not exist",
+			request: requestFactory("db2"),
+			dbMockFactory: func(response *types.GetStateHashResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetStateHash", submittingUserName, "db2").Return(nil, &interrors.NotFoundErr{Message: "database [db2] does not exist"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET /config/state/hash/db2' because database [db2] does not exist",
+		},
+		{
+			name:    "user is not an admin",
+			request: requestFactory("db1"),
+			dbMockFactory: func(response *types.GetStateHashResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetStateHash", submittingUserName, "db1").Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the hash of a database"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/state/hash/db1' because the user [alice] has no permission to get the hash of a database",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetStateHash %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetStateHashResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_CompactIndex(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func(dbName string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, constants.URLForPostCompactIndex(dbName), nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.CompactIndexQuery{UserId: submittingUserName, DbName: dbName})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		dbMockFactory      func(response *types.CompactIndexResponseEnvelope) bcdb.DB
+		expectedResponse   *types.CompactIndexResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:    "successfully compact the index",
+			request: requestFactory("db1"),
+			dbMockFactory: func(response *types.CompactIndexResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("CompactIndex", submittingUserName, "db1").Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.CompactIndexResponseEnvelope{
+				Response: &types.CompactIndexResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					DbName:          "db1",
+					SizeBeforeBytes: 4096,
+					SizeAfterBytes:  1024,
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "database has no index",
+			request: requestFactory("db2"),
+			dbMockFactory: func(response *types.CompactIndexResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("CompactIndex", submittingUserName, "db2").Return(nil, &interrors.BadRequestError{ErrMsg: "database [db2] has no index"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'POST /config/index/compact/db2' because database [db2] has no index",
+		},
+		{
+			name:    "database does not exist",
+			request: requestFactory("db3"),
+			dbMockFactory: func(response *types.CompactIndexResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("CompactIndex", submittingUserName, "db3").Return(nil, &interrors.NotFoundErr{Message: "database [db3] does not exist"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'POST /config/index/compact/db3' because database [db3] does not exist",
+		},
+		{
+			name:    "user is not an admin",
+			request: requestFactory("db1"),
+			dbMockFactory: func(response *types.CompactIndexResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("CompactIndex", submittingUserName, "db1").Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to compact the index of a database"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'POST /config/index/compact/db1' because the user [alice] has no permission to compact the index of a database",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("CompactIndex %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.CompactIndexResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetQuarantinedBlock(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, constants.GetQuarantine, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetQuarantinedBlockQuery{UserId: submittingUserName})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		dbMockFactory      func(response *types.GetQuarantinedBlockResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetQuarantinedBlockResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "a block is quarantined",
+			dbMockFactory: func(response *types.GetQuarantinedBlockResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetQuarantinedBlock", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetQuarantinedBlockResponseEnvelope{
+				Response: &types.GetQuarantinedBlockResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					QuarantinedBlock: &types.QuarantinedBlock{
+						Block: &types.Block{
+							Header: &types.BlockHeader{
+								BaseHeader: &types.BlockHeaderBase{
+									Number: 5,
+								},
+							},
+						},
+						Error:         "error while committing the state trie",
+						Stack:         "stack",
+						QuarantinedAt: 1000,
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "no block is quarantined",
+			dbMockFactory: func(response *types.GetQuarantinedBlockResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetQuarantinedBlock", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetQuarantinedBlockResponseEnvelope{
+				Response: &types.GetQuarantinedBlockResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "user is not an admin",
+			dbMockFactory: func(response *types.GetQuarantinedBlockResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetQuarantinedBlock", submittingUserName).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the quarantined block"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/quarantine' because the user [alice] has no permission to get the quarantined block",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetQuarantinedBlock %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, requestFactory())
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetQuarantinedBlockResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetRejectedTxs(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, constants.GetRejectedTxs, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetRejectedTxsQuery{UserId: submittingUserName})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		dbMockFactory      func(response *types.GetRejectedTxsResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetRejectedTxsResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "transactions are rejected",
+			dbMockFactory: func(response *types.GetRejectedTxsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetRejectedTxs", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetRejectedTxsResponseEnvelope{
+				Response: &types.GetRejectedTxsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					RejectedTxs: []*types.RejectedTx{
+						{
+							TxId:       "tx1",
+							UserId:     "bob",
+							Category:   types.RejectedTx_UNAUTHENTICATED,
+							Reason:     "signature verification failed",
+							RejectedAt: 1000,
+						},
+						{
+							TxId:       "tx2",
+							UserId:     "alice",
+							Category:   types.RejectedTx_QUEUE_FULL,
+							Reason:     "transaction queue is full. It means the server load is high. Try after sometime",
+							RejectedAt: 2000,
+						},
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "no transaction is rejected",
+			dbMockFactory: func(response *types.GetRejectedTxsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetRejectedTxs", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetRejectedTxsResponseEnvelope{
+				Response: &types.GetRejectedTxsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "user is not an admin",
+			dbMockFactory: func(response *types.GetRejectedTxsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetRejectedTxs", submittingUserName).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the rejected transactions"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/rejectedtxs' because the user [alice] has no permission to get the rejected transactions",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetRejectedTxs %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, requestFactory())
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetRejectedTxsResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetSlowQueries(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, constants.GetSlowQueries, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetSlowQueriesQuery{UserId: submittingUserName})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		dbMockFactory      func(response *types.GetSlowQueriesResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetSlowQueriesResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "queries are slow",
+			dbMockFactory: func(response *types.GetSlowQueriesResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetSlowQueries", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetSlowQueriesResponseEnvelope{
+				Response: &types.GetSlowQueriesResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					SlowQueries: []*types.SlowQuery{
+						{
+							UserId: "bob",
+							DbName: "db1",
+							Query:  `{"selector":{"age":{"$neq":[30]}}}`,
+							Plan: &types.QueryPlan{
+								Operator: "$and",
+								Scans: []*types.IndexScan{
+									{
+										Attribute:      "age",
+										Type:           types.IndexAttributeType_NUMBER,
+										Kind:           types.IndexScan_FULL,
+										Operators:      []string{"$neq"},
+										EntriesScanned: 100000,
+										Seeks:          1,
+									},
+								},
+							},
+							KeysRead:   99000,
+							Results:    1000,
+							ExecutedAt: 1000,
+							Duration:   2000000000,
+						},
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "user is not an admin",
+			dbMockFactory: func(response *types.GetSlowQueriesResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetSlowQueries", submittingUserName).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the slow queries"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/slowqueries' because the user [alice] has no permission to get the slow queries",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetSlowQueries %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, requestFactory())
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetSlowQueriesResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetWitnessStatus(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, constants.GetWitnessStatus, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetWitnessStatusQuery{UserId: submittingUserName})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		dbMockFactory      func(response *types.GetWitnessStatusResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetWitnessStatusResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "witness raised alerts",
+			dbMockFactory: func(response *types.GetWitnessStatusResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetWitnessStatus", submittingUserName).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetWitnessStatusResponseEnvelope{
+				Response: &types.GetWitnessStatusResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "witness1",
+					},
+					VerifiedHeight: 9,
+					Halted:         true,
+					Alerts: []*types.WitnessAlert{
+						{
+							BlockNumber: 10,
+							Reason:      "the hash of the previous block does not match",
+							Time:        1000,
+						},
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "node is not a witness",
+			dbMockFactory: func(response *types.GetWitnessStatusResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetWitnessStatus", submittingUserName).Return(nil, &interrors.BadRequestError{ErrMsg: "node [node1] is neither a witness nor a standby"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'GET /config/witness' because node [node1] is neither a witness nor a standby",
+		},
+		{
+			name: "user is not an admin",
+			dbMockFactory: func(response *types.GetWitnessStatusResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetWitnessStatus", submittingUserName).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the witness status"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/witness' because the user [alice] has no permission to get the witness status",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetWitnessStatus %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, requestFactory())
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetWitnessStatusResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_GetIndexUsage(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func(url string, unusedOnly bool) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetIndexUsageQuery{UserId: submittingUserName, UnusedOnly: unusedOnly})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	indexes := []*types.IndexAttributeUsage{
+		{
+			DbName:    "db1",
+			Attribute: "age",
+			Type:      types.IndexAttributeType_NUMBER,
+			Hits:      10,
+			LastUsed:  2000,
+		},
+		{
+			DbName:    "db1",
+			Attribute: "name",
+			Type:      types.IndexAttributeType_STRING,
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		dbMockFactory      func(response *types.GetIndexUsageResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetIndexUsageResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:    "all indexes",
+			request: requestFactory(constants.URLForGetIndexUsage(false), false),
+			dbMockFactory: func(response *types.GetIndexUsageResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetIndexUsage", submittingUserName, false).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetIndexUsageResponseEnvelope{
+				Response: &types.GetIndexUsageResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					Indexes:      indexes,
+					TrackedSince: 1000,
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "unused indexes",
+			request: requestFactory(constants.URLForGetIndexUsage(true), true),
+			dbMockFactory: func(response *types.GetIndexUsageResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetIndexUsage", submittingUserName, true).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetIndexUsageResponseEnvelope{
+				Response: &types.GetIndexUsageResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "node1",
+					},
+					Indexes:      indexes[1:],
+					TrackedSince: 1000,
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "unusedOnly is not a boolean",
+			request: requestFactory(constants.GetIndexUsage+"?unusedOnly=yes", false),
+			dbMockFactory: func(response *types.GetIndexUsageResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "unusedOnly must be a boolean, but it is [yes]",
+		},
+		{
+			name:    "user is not an admin",
+			request: requestFactory(constants.URLForGetIndexUsage(false), false),
+			dbMockFactory: func(response *types.GetIndexUsageResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetIndexUsage", submittingUserName, false).Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the index usage"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /config/indexusage' because the user [alice] has no permission to get the index usage",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("GetIndexUsage %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetIndexUsageResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestConfigRequestHandler_TransferLeadership(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func(targetNodeID string, timeout string) *http.Request {
+		urlPath := constants.PostTransferLeadershipPrefix
+		if targetNodeID != "" {
+			urlPath = urlPath + "/" + targetNodeID
+		}
+		req := httptest.NewRequest(http.MethodPost, "http://server1.example.com:6091"+urlPath, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		if timeout != "" {
+			req.Header.Set(constants.TimeoutHeader, timeout)
+		}
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.TransferLeadershipQuery{UserId: submittingUserName, TargetNodeId: targetNodeID})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		dbMockFactory      func(response *types.TransferLeadershipResponseEnvelope) bcdb.DB
+		expectedResponse   *types.TransferLeadershipResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+		expectedLocation   string
+	}{
+		{
+			name:    "successfully transfer to a chosen node",
+			request: requestFactory("node2", "5s"),
+			dbMockFactory: func(response *types.TransferLeadershipResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", 5*time.Second).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.TransferLeadershipResponseEnvelope{
+				Response: &types.TransferLeadershipResponse{
+					Header:   &types.ResponseHeader{NodeId: "node1"},
+					LeaderId: "node2",
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "successfully transfer to any node",
+			request: requestFactory("", ""),
+			dbMockFactory: func(response *types.TransferLeadershipResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TransferLeadership", submittingUserName, "", time.Duration(0)).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.TransferLeadershipResponseEnvelope{
+				Response: &types.TransferLeadershipResponse{
+					Header:   &types.ResponseHeader{NodeId: "node1"},
+					LeaderId: "node3",
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:    "redirect to the leader",
+			request: requestFactory("node2", ""),
+			dbMockFactory: func(response *types.TransferLeadershipResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", time.Duration(0)).Return(nil, &interrors.NotLeaderError{LeaderID: 3, LeaderHostPort: "10.10.10.13:6003"})
+				return db
+			},
+			expectedStatusCode: http.StatusTemporaryRedirect,
+			expectedLocation:   "http://10.10.10.13:6003/config/leader/transfer/node2",
+		},
+		{
+			name:    "bad target node",
+			request: requestFactory("node7", ""),
+			dbMockFactory: func(response *types.TransferLeadershipResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node7", time.Duration(0)).Return(nil, &interrors.BadRequestError{ErrMsg: "node [node7] is not a consensus member"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'POST http://server1.example.com:6091/config/leader/transfer/node7' because node [node7] is not a consensus member",
+		},
+		{
+			name:    "transfer timeout",
+			request: requestFactory("node2", ""),
+			dbMockFactory: func(response *types.TransferLeadershipResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("TransferLeadership", submittingUserName, "node2", time.Duration(0)).Return(nil, &interrors.TimeoutErr{ErrMsg: "timeout has occurred while transferring leadership"})
+				return db
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedErr:        "error while processing 'POST http://server1.example.com:6091/config/leader/transfer/node2' because timeout has occurred while transferring leadership",
+		},
+		{
+			name:    "bad timeout",
+			request: requestFactory("node2", "-5s"),
+			dbMockFactory: func(response *types.TransferLeadershipResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "timeout can't be negative \"-5s\"",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(fmt.Sprintf("TransferLeadership %s", tt.name), func(t *testing.T) {
+			db := tt.dbMockFactory(tt.expectedResponse)
+
+			rr := httptest.NewRecorder()
+			handler := NewConfigRequestHandler(db, logger)
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedLocation != "" {
+				require.Equal(t, tt.expectedLocation, rr.Header().Get("Location"))
+				return
+			}
+
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.TransferLeadershipResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
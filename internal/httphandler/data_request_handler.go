@@ -4,15 +4,23 @@ package httphandler
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"mime"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/hyperledger-labs/orion-server/internal/arrowipc"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb"
 	"github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/queryexecutor"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
@@ -42,9 +50,23 @@ func NewDataRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handler
 		logger: logger,
 	}
 
+	handler.router.HandleFunc(constants.PostPendingDataTx, handler.pendingDataTransaction).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.GetPendingDataTx, handler.pendingDataTxQuery).Methods(http.MethodGet)
+	handler.router.HandleFunc(constants.PostPendingDataTxSignature, handler.pendingDataTxSignature).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.GetPendingDataTxs, handler.pendingDataTxsQuery).Methods(http.MethodGet)
+	handler.router.HandleFunc(constants.PostDataCursor, handler.openDataCursor).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.PostDataCursorClose, handler.closeDataCursor).Methods(http.MethodPost)
+	// the keys and cursor routes must be registered before the data route as the latter matches them as well
+	handler.router.HandleFunc(constants.GetDataCursorPage, handler.dataCursorPageQuery).Methods(http.MethodGet)
+	handler.router.HandleFunc(constants.GetDataKeys, handler.dataKeysQuery).Methods(http.MethodGet)
+	handler.router.HandleFunc(constants.GetData, handler.rawDataQuery).Methods(http.MethodGet).MatcherFunc(acceptsOctetStream)
 	handler.router.HandleFunc(constants.GetData, handler.dataQuery).Methods(http.MethodGet)
+	handler.router.HandleFunc(constants.PutData, handler.rawDataTransaction).Methods(http.MethodPut)
 	handler.router.HandleFunc(constants.PostDataTx, handler.dataTransaction).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.PostBlob, handler.blobUpload).Methods(http.MethodPost)
 	handler.router.HandleFunc(constants.PostDataQuery, handler.dataJSONQuery).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.PostDataQueryExplain, handler.dataJSONQueryExplain).Methods(http.MethodPost)
+	handler.router.HandleFunc(constants.PostDataSQLQuery, handler.dataSQLQuery).Methods(http.MethodPost)
 
 	return handler
 }
@@ -54,26 +76,153 @@ func (d *dataRequestHandler) ServeHTTP(response http.ResponseWriter, request *ht
 }
 
 func (d *dataRequestHandler) dataQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetData, d.sigVerifier)
-	if respondedErr {
+	data, responded := d.getData(response, request)
+	if responded {
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, data)
+}
+
+// rawDataQuery serves a data read that accepts an octet stream. The value is returned as is, which spares binary values
+// the base64 encoding of a JSON response, while the metadata, the node ID, and the signature of the node on the
+// equivalent JSON response are returned in the headers.
+func (d *dataRequestHandler) rawDataQuery(response http.ResponseWriter, request *http.Request) {
+	data, responded := d.getData(response, request)
+	if responded {
+		return
+	}
+
+	if data.GetResponse().GetMetadata() == nil {
+		query := mux.Vars(request)
+		utils.SendHTTPResponse(response, http.StatusNotFound, &types.HttpResponseErr{
+			ErrMsg: "the key [" + query["key"] + "] does not exist in database [" + query["dbname"] + "]",
+		})
 		return
 	}
+
+	metadata, err := json.Marshal(data.Response.Metadata)
+	if err != nil {
+		utils.SendHTTPResponse(response, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	response.Header().Set("Content-Type", constants.OctetStreamContentType)
+	response.Header().Set(constants.MetadataHeader, string(metadata))
+	response.Header().Set(constants.NodeIDHeader, data.Response.GetHeader().GetNodeId())
+	response.Header().Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(data.Signature))
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write(data.Response.Value); err != nil {
+		d.logger.Warnf("failed to write the value to the response writer: %s", err)
+	}
+}
+
+// acceptsOctetStream matches a request whose Accept headers list the octet stream media type, with any parameters,
+// unless its quality is zero
+func acceptsOctetStream(request *http.Request, _ *mux.RouteMatch) bool {
+	for _, header := range request.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || mediaType != constants.OctetStreamContentType {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// getData fetches the value of a key for both the JSON and the raw data reads, and sets the ETag header. When the
+// request fails, or the value has not changed since the version in the If-None-Match header, it responds and returns
+// true.
+func (d *dataRequestHandler) getData(response http.ResponseWriter, request *http.Request) (*types.GetDataResponseEnvelope, bool) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetData, d.sigVerifier, d.db)
+	if respondedErr {
+		return nil, true
+	}
 	query := payload.(*types.GetDataQuery)
 
 	if !d.db.IsDBExists(query.DbName) {
 		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
 			ErrMsg: "error db '" + query.DbName + "' doesn't exist",
 		})
-		return
+		return nil, true
+	}
+
+	// a conditional read is answered with 304 when the value has not changed, which saves
+	// signing and sending a response the client already holds
+	if ifNoneMatch := request.Header.Get(constants.IfNoneMatchHeader); ifNoneMatch != "" {
+		version, err := d.db.GetDataVersion(query.DbName, query.UserId, query.Key)
+		if err != nil {
+			sendDataQueryError(response, request, err)
+			return nil, true
+		}
+
+		if etag := constants.ETagForVersion(version); etag != "" && etagMatches(ifNoneMatch, etag) {
+			response.Header().Set(constants.ETagHeader, etag)
+			response.WriteHeader(http.StatusNotModified)
+			return nil, true
+		}
 	}
 
 	data, err := d.db.GetData(query.DbName, query.UserId, query.Key)
+	if err != nil {
+		sendDataQueryError(response, request, err)
+		return nil, true
+	}
+
+	if etag := constants.ETagForVersion(data.GetResponse().GetMetadata().GetVersion()); etag != "" {
+		response.Header().Set(constants.ETagHeader, etag)
+	}
+	return data, false
+}
+
+func sendDataQueryError(response http.ResponseWriter, request *http.Request, err error) {
+	var status int
+
+	switch err.(type) {
+	case *errors.PermissionErr:
+		status = http.StatusForbidden
+	case *errors.ResourceExhaustedError:
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	utils.SendHTTPResponse(
+		response,
+		status,
+		&types.HttpResponseErr{
+			ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+		})
+}
+
+func (d *dataRequestHandler) dataKeysQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDataKeys, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetDataKeysQuery)
+
+	if !d.db.IsDBExists(query.DbName) {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
+			ErrMsg: "error db '" + query.DbName + "' doesn't exist",
+		})
+		return
+	}
+
+	keys, err := d.db.GetDataKeys(query.DbName, query.UserId, query.Prefix, query.StartKey, query.Limit, query.CountOnly)
 	if err != nil {
 		var status int
 
 		switch err.(type) {
 		case *errors.PermissionErr:
 			status = http.StatusForbidden
+		case *errors.ResourceExhaustedError:
+			status = http.StatusServiceUnavailable
 		default:
 			status = http.StatusInternalServerError
 		}
@@ -87,13 +236,13 @@ func (d *dataRequestHandler) dataQuery(response http.ResponseWriter, request *ht
 		return
 	}
 
-	utils.SendHTTPResponse(response, http.StatusOK, data)
+	utils.SendHTTPResponse(response, http.StatusOK, keys)
 }
 
 func (d *dataRequestHandler) dataTransaction(response http.ResponseWriter, request *http.Request) {
 	timeout, err := validateAndParseTxPostHeader(&request.Header)
 	if err != nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED, err.Error())
 		return
 	}
 
@@ -102,53 +251,437 @@ func (d *dataRequestHandler) dataTransaction(response http.ResponseWriter, reque
 
 	txEnv := &types.DataTxEnvelope{}
 	if err := requestData.Decode(txEnv); err != nil {
+		d.txHandler.rejectTransaction(response, decodeErrorStatus(err), "", "", types.RejectedTx_MALFORMED, err.Error())
+		return
+	}
+
+	txID := txEnv.GetPayload().GetTxId()
+	mustSignUsers := strings.Join(txEnv.GetPayload().GetMustSignUserIds(), ",")
+	if errMsg := checkDataTxEnvelope(txEnv); errMsg != "" {
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, mustSignUsers, types.RejectedTx_MALFORMED, errMsg)
+		return
+	}
+
+	var notSigned []string
+	for _, user := range txEnv.Payload.MustSignUserIds {
+		if _, ok := txEnv.Signatures[user]; !ok {
+			notSigned = append(notSigned, user)
+		}
+	}
+	if len(notSigned) > 0 {
+		sort.Strings(notSigned)
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, mustSignUsers, types.RejectedTx_UNAUTHENTICATED,
+			"users ["+strings.Join(notSigned, ",")+"] in the must sign list have not signed the transaction")
+		return
+	}
+
+	for _, userID := range txEnv.Payload.MustSignUserIds {
+		if err, code := VerifyRequestSignature(d.sigVerifier, userID, txEnv.Signatures[userID], txEnv.Payload); err != nil {
+			d.txHandler.rejectTransaction(response, code, txID, userID, types.RejectedTx_UNAUTHENTICATED, err.Error())
+			return
+		}
+	}
+
+	d.txHandler.handleTransaction(response, request, txEnv, timeout)
+}
+
+// rawDataTransaction submits a data transaction that writes the body of the request, as is, to a single key, which
+// spares binary values the base64 encoding of a JSON transaction. The transaction is built from the request: the user
+// in the UserID header is its only must sign user, its ID is in the TxID header, and the access control of the value
+// is in the optional ACL header. The Signature header holds the signature of the user on the JSON encoding of the
+// payload of the transaction, as for a transaction submitted as JSON.
+func (d *dataRequestHandler) rawDataTransaction(response http.ResponseWriter, request *http.Request) {
+	timeout, err := validateAndParseTxPostHeader(&request.Header)
+	if err != nil {
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED, err.Error())
+		return
+	}
+
+	txID := request.Header.Get(constants.TxIDHeader)
+	if txID == "" {
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED,
+			constants.TxIDHeader+" is not set in the http request header")
+		return
+	}
+
+	userID, signature, err := validateAndParseHeader(&request.Header)
+	if err != nil {
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, userID, types.RejectedTx_UNAUTHENTICATED, err.Error())
+		return
+	}
+
+	var acl *types.AccessControl
+	if aclHeader := request.Header.Get(constants.ACLHeader); aclHeader != "" {
+		acl = &types.AccessControl{}
+		if err := json.Unmarshal([]byte(aclHeader), acl); err != nil {
+			d.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, userID, types.RejectedTx_MALFORMED,
+				constants.ACLHeader+" is not a valid access control: "+err.Error())
+			return
+		}
+	}
+
+	value, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		d.txHandler.rejectTransaction(response, decodeErrorStatus(err), txID, userID, types.RejectedTx_MALFORMED, err.Error())
+		return
+	}
+
+	params := mux.Vars(request)
+	txEnv := &types.DataTxEnvelope{
+		Payload: &types.DataTx{
+			MustSignUserIds: []string{userID},
+			TxId:            txID,
+			DbOperations: []*types.DBOperation{
+				{
+					DbName: params["dbname"],
+					DataWrites: []*types.DataWrite{
+						{
+							Key:   params["key"],
+							Value: value,
+							Acl:   acl,
+						},
+					},
+				},
+			},
+		},
+		Signatures: map[string][]byte{
+			userID: signature,
+		},
+	}
+
+	if err, code := VerifyRequestSignature(d.sigVerifier, userID, signature, txEnv.Payload); err != nil {
+		d.txHandler.rejectTransaction(response, code, txID, userID, types.RejectedTx_UNAUTHENTICATED, err.Error())
+		return
+	}
+
+	d.txHandler.handleTransaction(response, request, txEnv, timeout)
+}
+
+// blobUpload stores the value sent as an octet stream in the blob store, and responds with the manifest of the blob,
+// which a data write carries in place of the value. The Signature header holds the signature of the user on the value.
+func (d *dataRequestHandler) blobUpload(response http.ResponseWriter, request *http.Request) {
+	userID, signature, err := validateAndParseHeader(&request.Header)
+	if err != nil {
 		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
 		return
 	}
 
-	if txEnv.Payload == nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload)})
+	value, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		utils.SendHTTPResponse(response, decodeErrorStatus(err), &types.HttpResponseErr{ErrMsg: err.Error()})
 		return
 	}
 
-	if len(txEnv.Payload.MustSignUserIds) == 0 {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload)})
+	if err, code := verifySignature(d.sigVerifier, userID, signature, value); err != nil {
+		utils.SendHTTPResponse(response, code, err)
 		return
 	}
 
-	var notSigned []string
+	blob, err := d.db.PutBlob(userID, value)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if _, ok := err.(*errors.BadRequestError); ok {
+			status = http.StatusBadRequest
+		}
+		utils.SendHTTPResponse(response, status, &types.HttpResponseErr{
+			ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+		})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, blob)
+}
+
+// checkDataTxEnvelope checks the fields of a data transaction envelope that are required before verifying its
+// signatures, and returns the reason if the envelope is malformed
+func checkDataTxEnvelope(txEnv *types.DataTxEnvelope) string {
+	if txEnv.Payload == nil {
+		return fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload)
+	}
+
+	if len(txEnv.Payload.MustSignUserIds) == 0 {
+		return fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload)
+	}
+
+	if valRes := txvalidation.ValidateDataTxAnnotations(txEnv.Payload.Annotations); valRes.Flag != types.Flag_VALID {
+		return valRes.ReasonIfInvalid
+	}
+
 	for _, user := range txEnv.Payload.MustSignUserIds {
 		if user == "" {
-			utils.SendHTTPResponse(response, http.StatusBadRequest,
-				&types.HttpResponseErr{ErrMsg: "an empty UserID in MustSignUserIDs list present in the transaction envelope"})
-			return
+			return "an empty UserID in MustSignUserIDs list present in the transaction envelope"
 		}
+	}
 
-		if _, ok := txEnv.Signatures[user]; !ok {
-			notSigned = append(notSigned, user)
+	return ""
+}
+
+// pendingDataTransaction adds a data transaction that is not yet signed by all its must sign users to the pending
+// transactions, so that the remaining must sign users can fetch it and add their signatures
+func (d *dataRequestHandler) pendingDataTransaction(response http.ResponseWriter, request *http.Request) {
+	expiry, err := validateAndParseExpiryHeader(&request.Header)
+	if err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	requestData := json.NewDecoder(request.Body)
+	requestData.DisallowUnknownFields()
+
+	txEnv := &types.DataTxEnvelope{}
+	if err := requestData.Decode(txEnv); err != nil {
+		utils.SendHTTPResponse(response, decodeErrorStatus(err), &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	if errMsg := checkDataTxEnvelope(txEnv); errMsg != "" {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: errMsg})
+		return
+	}
+
+	// the transaction must be initiated by one of its must sign users, and all signatures it carries must be valid
+	signed := false
+	for _, user := range txEnv.Payload.MustSignUserIds {
+		if _, ok := txEnv.Signatures[user]; ok {
+			signed = true
+			break
 		}
 	}
-	if len(notSigned) > 0 {
-		sort.Strings(notSigned)
+	if !signed {
 		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: "users [" + strings.Join(notSigned, ",") + "] in the must sign list have not signed the transaction"})
+			&types.HttpResponseErr{ErrMsg: "none of the users in the must sign list have signed the transaction"})
 		return
 	}
 
-	for _, userID := range txEnv.Payload.MustSignUserIds {
-		if err, code := VerifyRequestSignature(d.sigVerifier, userID, txEnv.Signatures[userID], txEnv.Payload); err != nil {
+	for userID, signature := range txEnv.Signatures {
+		if err, code := VerifyRequestSignature(d.sigVerifier, userID, signature, txEnv.Payload); err != nil {
 			utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
 			return
 		}
 	}
 
-	d.txHandler.handleTransaction(response, request, txEnv, timeout)
+	pendingTx, err := d.db.AddPendingDataTx(txEnv, expiry)
+	if err != nil {
+		d.sendPendingTxError(response, request, err)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, pendingTx)
+}
+
+func (d *dataRequestHandler) pendingDataTxQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetPendingDataTx, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetPendingDataTxQuery)
+
+	pendingTx, err := d.db.GetPendingDataTx(query.UserId, query.TxId)
+	if err != nil {
+		d.sendPendingTxError(response, request, err)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, pendingTx)
+}
+
+func (d *dataRequestHandler) pendingDataTxsQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetPendingDataTxs, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetPendingDataTxsQuery)
+
+	pendingTxs, err := d.db.GetPendingDataTxs(query.UserId)
+	if err != nil {
+		d.sendPendingTxError(response, request, err)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, pendingTxs)
+}
+
+// pendingDataTxSignature adds the signature of a must sign user to a pending data transaction. Once all must sign
+// users signed, the transaction is submitted as done by a data transaction POST, and the response carries the receipt.
+func (d *dataRequestHandler) pendingDataTxSignature(response http.ResponseWriter, request *http.Request) {
+	timeout, err := validateAndParseTxPostHeader(&request.Header)
+	if err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	requestData := json.NewDecoder(request.Body)
+	requestData.DisallowUnknownFields()
+
+	sig := &types.PendingDataTxSignature{}
+	if err := requestData.Decode(sig); err != nil {
+		utils.SendHTTPResponse(response, decodeErrorStatus(err), &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	txID := mux.Vars(request)["txId"]
+	switch {
+	case sig.TxId != txID:
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: "the txID [" + sig.TxId + "] in the signature does not match the txID [" + txID + "] in the URL"})
+		return
+	case sig.UserId == "":
+		utils.SendHTTPResponse(response, http.StatusBadRequest,
+			&types.HttpResponseErr{ErrMsg: "missing UserID in the pending transaction signature"})
+		return
+	}
+
+	pendingTx, err := d.db.GetPendingDataTx(sig.UserId, sig.TxId)
+	if err != nil {
+		d.sendPendingTxError(response, request, err)
+		return
+	}
+
+	payload := pendingTx.GetResponse().GetPendingTx().GetEnvelope().GetPayload()
+	if err, code := VerifyRequestSignature(d.sigVerifier, sig.UserId, sig.Signature, payload); err != nil {
+		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	pendingTx, err = d.db.AddPendingDataTxSignature(sig.TxId, sig.UserId, sig.Signature)
+	if err != nil {
+		d.sendPendingTxError(response, request, err)
+		return
+	}
+
+	if len(pendingTx.GetResponse().GetPendingTx().GetPendingSignerIds()) > 0 {
+		utils.SendHTTPResponse(response, http.StatusOK, pendingTx)
+		return
+	}
+
+	d.txHandler.handleTransaction(response, request, pendingTx.GetResponse().GetPendingTx().GetEnvelope(), timeout)
+}
+
+func (d *dataRequestHandler) sendPendingTxError(response http.ResponseWriter, request *http.Request, err error) {
+	var status int
+
+	switch err.(type) {
+	case *errors.NotLeaderError:
+		leaderErr := err.(*errors.NotLeaderError)
+		if leaderErr.GetLeaderID() == 0 {
+			utils.SendHTTPResponse(response, http.StatusServiceUnavailable, &types.HttpResponseErr{ErrMsg: "Cluster leader unavailable"})
+		} else {
+			utils.SendHTTPRedirectServer(response, request, leaderErr.GetLeaderHostPort())
+		}
+		return
+	case *errors.BadRequestError, *errors.DuplicateTxIDError:
+		status = http.StatusBadRequest
+	case *errors.PermissionErr:
+		status = http.StatusForbidden
+	case *errors.NotFoundErr:
+		status = http.StatusNotFound
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	utils.SendHTTPResponse(
+		response,
+		status,
+		&types.HttpResponseErr{
+			ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+		})
+}
+
+// openDataCursor opens a cursor over the keys of a database, which an export reads page by page. The cursor reads a
+// snapshot of the database, and outlives the connection it was opened over, so that an export that loses its
+// connection resumes from the last key it received.
+func (d *dataRequestHandler) openDataCursor(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostDataCursor, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.OpenDataCursorQuery)
+
+	var ttl time.Duration
+	if query.Ttl != "" {
+		var err error
+		if ttl, err = time.ParseDuration(query.Ttl); err != nil || ttl <= 0 {
+			utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
+				ErrMsg: "the TTL of a cursor must be a positive duration " + strconv.Quote(query.Ttl),
+			})
+			return
+		}
+	}
+
+	if !d.db.IsDBExists(query.DbName) {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
+			ErrMsg: "error db '" + query.DbName + "' doesn't exist",
+		})
+		return
+	}
+
+	cursor, err := d.db.OpenDataCursor(query.UserId, query.DbName, query.Prefix, ttl)
+	if err != nil {
+		d.sendDataCursorError(response, request, err)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, cursor)
+}
+
+func (d *dataRequestHandler) dataCursorPageQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDataCursorPage, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetDataCursorPageQuery)
+
+	page, err := d.db.GetDataCursorPage(query.UserId, query.CursorId, query.After, query.Limit)
+	if err != nil {
+		d.sendDataCursorError(response, request, err)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, page)
+}
+
+func (d *dataRequestHandler) closeDataCursor(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostDataCursorClose, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.CloseDataCursorQuery)
+
+	cursor, err := d.db.CloseDataCursor(query.UserId, query.CursorId)
+	if err != nil {
+		d.sendDataCursorError(response, request, err)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, cursor)
+}
+
+func (d *dataRequestHandler) sendDataCursorError(response http.ResponseWriter, request *http.Request, err error) {
+	var status int
+
+	switch err.(type) {
+	case *errors.BadRequestError:
+		status = http.StatusBadRequest
+	case *errors.PermissionErr:
+		status = http.StatusForbidden
+	case *errors.NotFoundErr:
+		status = http.StatusNotFound
+	case *errors.ResourceExhaustedError:
+		status = http.StatusServiceUnavailable
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	utils.SendHTTPResponse(
+		response,
+		status,
+		&types.HttpResponseErr{
+			ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+		})
 }
 
 func (d *dataRequestHandler) dataJSONQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostDataQuery, d.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostDataQuery, d.sigVerifier, d.db)
 	if respondedErr {
 		return
 	}
@@ -161,9 +694,95 @@ func (d *dataRequestHandler) dataJSONQuery(response http.ResponseWriter, request
 		return
 	}
 
+	if request.Header.Get("Accept") == constants.NDJSONContentType {
+		d.streamQueryResult(response, request, query)
+		return
+	}
+
 	parent := request.Context()
 	data, err := d.db.DataQuery(parent, query.DbName, query.UserId, []byte(query.Query))
+	d.sendQueryResult(response, request, data, err)
+}
 
+// streamQueryResult streams the result of a JSON query as newline-delimited JSON, flushing every key-value pair as it
+// is read. The status of the response is sent with its first line, hence a query that fails before any line is written
+// is answered as by sendQueryResult, while a later failure is reported by the trailer of the result.
+func (d *dataRequestHandler) streamQueryResult(response http.ResponseWriter, request *http.Request, query *types.DataJSONQuery) {
+	flusher, _ := response.(http.Flusher)
+	started := false
+	write := func(line []byte) error {
+		if !started {
+			started = true
+			response.Header().Set("Content-Type", constants.NDJSONContentType)
+			response.WriteHeader(http.StatusOK)
+		}
+		if _, err := response.Write(line); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	err := d.db.StreamDataQuery(request.Context(), query.DbName, query.UserId, []byte(query.Query), write)
+	if started {
+		if err != nil {
+			d.logger.Warnf("failed to stream the result of the query to the response writer: %s", err)
+		}
+		return
+	}
+	if err != nil || request.Context().Err() != nil {
+		d.sendQueryResult(response, request, nil, err)
+	}
+}
+
+func (d *dataRequestHandler) dataJSONQueryExplain(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostDataQueryExplain, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.ExplainJSONQuery)
+
+	if !d.db.IsDBExists(query.DbName) {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
+			ErrMsg: "'" + query.DbName + "' does not exist",
+		})
+		return
+	}
+
+	explainResponse, err := d.db.ExplainDataQuery(request.Context(), query.DbName, query.UserId, []byte(query.ExplainedQuery))
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.PermissionErr:
+			status = http.StatusForbidden
+		case *errors.BadRequestError:
+			status = http.StatusBadRequest
+		case *errors.ResourceExhaustedError:
+			status = http.StatusServiceUnavailable
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, explainResponse)
+}
+
+// sendQueryResult sends the result of a JSON or SQL query, unless the http client context is done. A request that
+// accepts an Arrow stream is sent the key-value pairs of the result as an Arrow IPC stream, which analytical tools load
+// without decoding JSON. As the stream does not carry the access control of the values, it is not signed.
+func (d *dataRequestHandler) sendQueryResult(response http.ResponseWriter, request *http.Request, data *types.DataQueryResponseEnvelope, err error) {
+	parent := request.Context()
 	select {
 	case <-parent.Done():
 		if parent.Err() == context.DeadlineExceeded {
@@ -180,6 +799,8 @@ func (d *dataRequestHandler) dataJSONQuery(response http.ResponseWriter, request
 			switch err.(type) {
 			case *errors.PermissionErr:
 				status = http.StatusForbidden
+			case *errors.ResourceExhaustedError:
+				status = http.StatusServiceUnavailable
 			default:
 				status = http.StatusInternalServerError
 			}
@@ -193,6 +814,47 @@ func (d *dataRequestHandler) dataJSONQuery(response http.ResponseWriter, request
 			return
 		}
 
+		if request.Header.Get("Accept") == constants.ArrowStreamContentType {
+			d.sendArrowStream(response, data)
+			return
+		}
+
 		utils.SendHTTPResponse(response, http.StatusOK, data)
 	}
 }
+
+func (d *dataRequestHandler) sendArrowStream(response http.ResponseWriter, data *types.DataQueryResponseEnvelope) {
+	response.Header().Set("Content-Type", constants.ArrowStreamContentType)
+	response.Header().Set(constants.NodeIDHeader, data.GetResponse().GetHeader().GetNodeId())
+	response.WriteHeader(http.StatusOK)
+	if err := arrowipc.WriteKVs(response, data.GetResponse().GetKVs()); err != nil {
+		d.logger.Warnf("failed to write the arrow stream to the response writer: %s", err)
+	}
+}
+
+func (d *dataRequestHandler) dataSQLQuery(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostDataSQLQuery, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.DataSQLQuery)
+
+	compiled, err := queryexecutor.CompileSQL(query.Query)
+	if err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
+			ErrMsg: err.Error(),
+		})
+		return
+	}
+
+	if !d.db.IsDBExists(compiled.DBName) {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
+			ErrMsg: "'" + compiled.DBName + "' does not exist",
+		})
+		return
+	}
+
+	parent := request.Context()
+	data, err := d.db.DataSQLQuery(parent, query.UserId, compiled)
+	d.sendQueryResult(response, request, data, err)
+}
@@ -14,9 +14,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger-labs/orion-server/internal/arrowipc"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/queryexecutor"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -241,6 +243,504 @@ func TestDataRequestHandler_DataQuery(t *testing.T) {
 	}
 }
 
+func TestDataRequestHandler_ConditionalDataQuery(t *testing.T) {
+	dbName := "test_database"
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	sigFoo := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataQuery{
+		UserId: submittingUserName,
+		DbName: dbName,
+		Key:    "foo",
+	})
+
+	version := &types.Version{
+		BlockNum: 5,
+		TxNum:    2,
+	}
+	dataResponse := &types.GetDataResponseEnvelope{
+		Response: &types.GetDataResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "testNodeID",
+			},
+			Value: []byte("bar"),
+			Metadata: &types.Metadata{
+				Version: version,
+			},
+		},
+		Signature: []byte{0, 0, 0},
+	}
+
+	testCases := []struct {
+		name               string
+		ifNoneMatch        string
+		dbMockFactory      func() bcdb.DB
+		expectedStatusCode int
+		expectedETag       string
+		expectedResponse   *types.GetDataResponseEnvelope
+		expectedErr        string
+	}{
+		{
+			name: "unconditional read returns the etag",
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetData", dbName, submittingUserName, "foo").Return(dataResponse, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       `"5-2"`,
+			expectedResponse:   dataResponse,
+		},
+		{
+			name:        "value not modified",
+			ifNoneMatch: `"5-2"`,
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataVersion", dbName, submittingUserName, "foo").Return(version, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusNotModified,
+			expectedETag:       `"5-2"`,
+		},
+		{
+			name:        "value modified",
+			ifNoneMatch: `"4-0"`,
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataVersion", dbName, submittingUserName, "foo").Return(version, nil)
+				db.On("GetData", dbName, submittingUserName, "foo").Return(dataResponse, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedETag:       `"5-2"`,
+			expectedResponse:   dataResponse,
+		},
+		{
+			name:        "key does not exist",
+			ifNoneMatch: "*",
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataVersion", dbName, submittingUserName, "foo").Return(nil, nil)
+				db.On("GetData", dbName, submittingUserName, "foo").Return(&types.GetDataResponseEnvelope{
+					Response: &types.GetDataResponse{},
+				}, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &types.GetDataResponseEnvelope{
+				Response: &types.GetDataResponse{},
+			},
+		},
+		{
+			name:        "submitting user has no permission to read the key",
+			ifNoneMatch: `"5-2"`,
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataVersion", dbName, submittingUserName, "foo").
+					Return(nil, &interrors.PermissionErr{ErrMsg: "access forbidden"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /data/test_database/foo' because access forbidden",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, constants.URLForGetData(dbName, "foo"), nil)
+			require.NoError(t, err)
+			req.Header.Set(constants.UserHeader, submittingUserName)
+			req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+			if tt.ifNoneMatch != "" {
+				req.Header.Set(constants.IfNoneMatchHeader, tt.ifNoneMatch)
+			}
+
+			db := tt.dbMockFactory()
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			require.Equal(t, tt.expectedETag, rr.Header().Get(constants.ETagHeader))
+			db.(*mocks.DB).AssertExpectations(t)
+
+			switch {
+			case tt.expectedErr != "":
+				respErr := &types.HttpResponseErr{}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			case tt.expectedResponse != nil:
+				res := &types.GetDataResponseEnvelope{}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(res))
+				require.Equal(t, tt.expectedResponse, res)
+			default:
+				require.Empty(t, rr.Body.Bytes())
+			}
+		})
+	}
+}
+
+func TestDataRequestHandler_RawDataQuery(t *testing.T) {
+	dbName := "test_database"
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	sigFoo := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataQuery{
+		UserId: submittingUserName,
+		DbName: dbName,
+		Key:    "foo",
+	})
+
+	value := []byte{0x25, 0x50, 0x44, 0x46, 0x00, 0xff}
+	metadata := &types.Metadata{
+		Version: &types.Version{
+			BlockNum: 5,
+			TxNum:    2,
+		},
+		AccessControl: &types.AccessControl{
+			ReadUsers: map[string]bool{
+				"alice": true,
+			},
+		},
+	}
+
+	newRequest := func(accept string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, constants.URLForGetData(dbName, "foo"), nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+		req.Header.Set("Accept", accept)
+		return req
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	t.Run("value is returned as an octet stream", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+		db.On("IsDBExists", dbName).Return(true)
+		db.On("GetData", dbName, submittingUserName, "foo").Return(&types.GetDataResponseEnvelope{
+			Response: &types.GetDataResponse{
+				Header: &types.ResponseHeader{
+					NodeId: "testNodeID",
+				},
+				Value:    value,
+				Metadata: metadata,
+			},
+			Signature: []byte{0, 0, 0},
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest(constants.OctetStreamContentType))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, value, rr.Body.Bytes())
+		require.Equal(t, constants.OctetStreamContentType, rr.Header().Get("Content-Type"))
+		require.Equal(t, `"5-2"`, rr.Header().Get(constants.ETagHeader))
+		require.Equal(t, "testNodeID", rr.Header().Get(constants.NodeIDHeader))
+		require.Equal(t, base64.StdEncoding.EncodeToString([]byte{0, 0, 0}), rr.Header().Get(constants.SignatureHeader))
+
+		respMetadata := &types.Metadata{}
+		require.NoError(t, json.Unmarshal([]byte(rr.Header().Get(constants.MetadataHeader)), respMetadata))
+		require.Equal(t, metadata, respMetadata)
+	})
+
+	t.Run("octet stream is one of the accepted media types", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+		db.On("IsDBExists", dbName).Return(true)
+		db.On("GetData", dbName, submittingUserName, "foo").Return(&types.GetDataResponseEnvelope{
+			Response: &types.GetDataResponse{
+				Header: &types.ResponseHeader{
+					NodeId: "testNodeID",
+				},
+				Value:    value,
+				Metadata: metadata,
+			},
+			Signature: []byte{0, 0, 0},
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest("application/json;q=0.5, Application/Octet-Stream;q=0.9"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, value, rr.Body.Bytes())
+		require.Equal(t, constants.OctetStreamContentType, rr.Header().Get("Content-Type"))
+	})
+
+	t.Run("key does not exist", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+		db.On("IsDBExists", dbName).Return(true)
+		db.On("GetData", dbName, submittingUserName, "foo").Return(&types.GetDataResponseEnvelope{
+			Response: &types.GetDataResponse{
+				Header: &types.ResponseHeader{
+					NodeId: "testNodeID",
+				},
+			},
+			Signature: []byte{0, 0, 0},
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest(constants.OctetStreamContentType))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		respErr := &types.HttpResponseErr{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+		require.Equal(t, "the key [foo] does not exist in database [test_database]", respErr.ErrMsg)
+	})
+
+	for _, accept := range []string{"application/json", "application/octet-stream;q=0"} {
+		t.Run("json is returned when an octet stream is not accepted: "+accept, func(t *testing.T) {
+			expected := &types.GetDataResponseEnvelope{
+				Response: &types.GetDataResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Value:    value,
+					Metadata: metadata,
+				},
+				Signature: []byte{0, 0, 0},
+			}
+
+			db := &mocks.DB{}
+			db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+			db.On("IsDBExists", dbName).Return(true)
+			db.On("GetData", dbName, submittingUserName, "foo").Return(expected, nil)
+
+			rr := httptest.NewRecorder()
+			NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest(accept))
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			res := &types.GetDataResponseEnvelope{}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(res))
+			require.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestDataRequestHandler_DataKeysQuery(t *testing.T) {
+	dbName := "org1/db1"
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	sigKeys := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataKeysQuery{
+		UserId: submittingUserName,
+		DbName: dbName,
+		Prefix: "user/",
+	})
+	sigCount := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataKeysQuery{
+		UserId:    submittingUserName,
+		DbName:    dbName,
+		Prefix:    "user/",
+		CountOnly: true,
+	})
+	sigPage := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataKeysQuery{
+		UserId:   submittingUserName,
+		DbName:   dbName,
+		Prefix:   "user/",
+		StartKey: "user/2",
+		Limit:    1,
+	})
+
+	testCases := []struct {
+		name               string
+		url                string
+		signature          []byte
+		dbMockFactory      func(response *types.GetDataKeysResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetDataKeysResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:      "valid keys request",
+			url:       constants.URLForGetDataKeys(dbName, "user/", false),
+			signature: sigKeys,
+			expectedResponse: &types.GetDataKeysResponseEnvelope{
+				Response: &types.GetDataKeysResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Keys:  []string{"user/1", "user/2"},
+					Count: 2,
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataKeys", dbName, submittingUserName, "user/", "", uint64(0), false).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "valid count only request",
+			url:       constants.URLForGetDataKeys(dbName, "user/", true),
+			signature: sigCount,
+			expectedResponse: &types.GetDataKeysResponseEnvelope{
+				Response: &types.GetDataKeysResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Count: 2,
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataKeys", dbName, submittingUserName, "user/", "", uint64(0), true).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "valid page request",
+			url:       constants.URLForGetDataKeysPage(dbName, "user/", "user/2", 1, false),
+			signature: sigPage,
+			expectedResponse: &types.GetDataKeysResponseEnvelope{
+				Response: &types.GetDataKeysResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Keys:         []string{"user/2"},
+					Count:        1,
+					HasMore:      true,
+					NextStartKey: "user/3",
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataKeys", dbName, submittingUserName, "user/", "user/2", uint64(1), false).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "invalid limit parameter",
+			url:       "/data/org1/db1/keys?limit=all",
+			signature: sigPage,
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the limit parameter must be a non-negative integer \"all\"",
+		},
+		{
+			name:      "invalid count only parameter",
+			url:       "/data/org1/db1/keys?countOnly=maybe",
+			signature: sigCount,
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "countOnly must be a boolean, but it is [maybe]",
+		},
+		{
+			name:      "db does not exist",
+			url:       constants.URLForGetDataKeys(dbName, "user/", false),
+			signature: sigKeys,
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(false)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error db 'org1/db1' doesn't exist",
+		},
+		{
+			name:      "submitting user has no permission to read the db",
+			url:       constants.URLForGetDataKeys(dbName, "user/", false),
+			signature: sigKeys,
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("GetDataKeys", dbName, submittingUserName, "user/", "", uint64(0), false).
+					Return(nil, &interrors.PermissionErr{ErrMsg: "access forbidden"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /data/org1/db1/keys?prefix=user%2F' because access forbidden",
+		},
+		{
+			name:      "fail to verify signature",
+			url:       constants.URLForGetDataKeys(dbName, "user/", false),
+			signature: sigCount,
+			dbMockFactory: func(response *types.GetDataKeysResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			req.Header.Set(constants.UserHeader, submittingUserName)
+			req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(tt.signature))
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetDataKeysResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
 func TestDataRequestHandler_DataJSONQuery(t *testing.T) {
 	dbName := "test_database"
 
@@ -300,15 +800,106 @@ func TestDataRequestHandler_DataJSONQuery(t *testing.T) {
 			},
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
 				db := &mocks.DB{}
-				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("IsDBExists", dbName).Return(true)
-				db.On("DataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).Return(response, nil)
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "database does not exist",
+			requestFactory: func() (*http.Request, error) {
+				queryReader := bytes.NewReader(queryBytes)
+				require.NotNil(t, queryReader)
+				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(false)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "'test_database' does not exist",
+		},
+		{
+			name: "submitting user is not eligible to query the database",
+			requestFactory: func() (*http.Request, error) {
+				queryReader := bytes.NewReader(queryBytes)
+				require.NotNil(t, queryReader)
+				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).
+					Return(nil, &interrors.PermissionErr{ErrMsg: "access forbidden"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'POST /data/test_database/jsonquery' because access forbidden",
+		},
+		{
+			name: "failed to execute the query",
+			requestFactory: func() (*http.Request, error) {
+				queryReader := bytes.NewReader(queryBytes)
+				require.NotNil(t, queryReader)
+				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).
+					Return(nil, errors.New("failed to execute the query"))
+				return db
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'POST /data/test_database/jsonquery' because failed to execute the query",
+		},
+		{
+			name: "user does not exist",
+			requestFactory: func() (*http.Request, error) {
+				queryReader := bytes.NewReader(queryBytes)
+				require.NotNil(t, queryReader)
+				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(nil, errors.New("user does not exist"))
 				return db
 			},
-			expectedStatusCode: http.StatusOK,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
 		},
 		{
-			name: "database does not exist",
+			name: "failed to verify signature",
 			requestFactory: func() (*http.Request, error) {
 				queryReader := bytes.NewReader(queryBytes)
 				require.NotNil(t, queryReader)
@@ -322,15 +913,14 @@ func TestDataRequestHandler_DataJSONQuery(t *testing.T) {
 			},
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
 				db := &mocks.DB{}
-				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("IsDBExists", dbName).Return(false)
+				db.On("GetCertificate", submittingUserName).Return(bobCert, nil)
 				return db
 			},
-			expectedStatusCode: http.StatusBadRequest,
-			expectedErr:        "'test_database' does not exist",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
 		},
 		{
-			name: "submitting user is not eligible to query the database",
+			name: "missing userID http header",
 			requestFactory: func() (*http.Request, error) {
 				queryReader := bytes.NewReader(queryBytes)
 				require.NotNil(t, queryReader)
@@ -338,23 +928,16 @@ func TestDataRequestHandler_DataJSONQuery(t *testing.T) {
 				if err != nil {
 					return nil, err
 				}
-				req.Header.Set(constants.UserHeader, submittingUserName)
-				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
 				return req, nil
 			},
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
-				db := &mocks.DB{}
-				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("IsDBExists", dbName).Return(true)
-				db.On("DataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).
-					Return(nil, &interrors.PermissionErr{ErrMsg: "access forbidden"})
-				return db
+				return &mocks.DB{}
 			},
-			expectedStatusCode: http.StatusForbidden,
-			expectedErr:        "error while processing 'POST /data/test_database/jsonquery' because access forbidden",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "UserID is not set in the http request header",
 		},
 		{
-			name: "failed to execute the query",
+			name: "missing signature http header",
 			requestFactory: func() (*http.Request, error) {
 				queryReader := bytes.NewReader(queryBytes)
 				require.NotNil(t, queryReader)
@@ -363,26 +946,18 @@ func TestDataRequestHandler_DataJSONQuery(t *testing.T) {
 					return nil, err
 				}
 				req.Header.Set(constants.UserHeader, submittingUserName)
-				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
 				return req, nil
 			},
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
-				db := &mocks.DB{}
-				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("IsDBExists", dbName).Return(true)
-				db.On("DataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).
-					Return(nil, errors.New("failed to execute the query"))
-				return db
+				return &mocks.DB{}
 			},
-			expectedStatusCode: http.StatusInternalServerError,
-			expectedErr:        "error while processing 'POST /data/test_database/jsonquery' because failed to execute the query",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "Signature is not set in the http request header",
 		},
 		{
-			name: "user does not exist",
+			name: "empty query",
 			requestFactory: func() (*http.Request, error) {
-				queryReader := bytes.NewReader(queryBytes)
-				require.NotNil(t, queryReader)
-				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
+				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), nil)
 				if err != nil {
 					return nil, err
 				}
@@ -392,84 +967,350 @@ func TestDataRequestHandler_DataJSONQuery(t *testing.T) {
 			},
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
 				db := &mocks.DB{}
-				db.On("GetCertificate", submittingUserName).Return(nil, errors.New("user does not exist"))
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "query is empty",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.requestFactory()
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.DataQueryResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+				//TODO verify signature on response
+			}
+		})
+	}
+}
+
+func TestDataRequestHandler_DataJSONQueryExplain(t *testing.T) {
+	dbName := "test_database"
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	q := `{"selector":{"attr1":{"$eq":true}}}`
+	queryBytes, err := json.Marshal(q)
+	require.NoError(t, err)
+
+	sigExplain := testutils.SignatureFromQuery(t, aliceSigner, &types.ExplainJSONQuery{
+		UserId:         submittingUserName,
+		DbName:         dbName,
+		ExplainedQuery: q,
+	})
+	sigExecute := testutils.SignatureFromQuery(t, aliceSigner, &types.DataJSONQuery{
+		UserId: submittingUserName,
+		DbName: dbName,
+		Query:  q,
+	})
+
+	requestFactory := func(sig []byte) (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQueryExplain(dbName), bytes.NewReader(queryBytes))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req, nil
+	}
+
+	testCases := []struct {
+		name               string
+		signature          []byte
+		dbMockFactory      func(response *types.ExplainJSONQueryResponseEnvelope) bcdb.DB
+		expectedResponse   *types.ExplainJSONQueryResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:      "valid json query",
+			signature: sigExplain,
+			expectedResponse: &types.ExplainJSONQueryResponseEnvelope{
+				Response: &types.ExplainJSONQueryResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Plan: &types.QueryPlan{
+						Operator: "$and",
+						Scans: []*types.IndexScan{
+							{
+								Attribute: "attr1",
+								Type:      types.IndexAttributeType_BOOLEAN,
+								Kind:      types.IndexScan_POINT,
+								Operators: []string{"$eq"},
+							},
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			dbMockFactory: func(response *types.ExplainJSONQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("ExplainDataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:      "database does not exist",
+			signature: sigExplain,
+			dbMockFactory: func(response *types.ExplainJSONQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(false)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "'test_database' does not exist",
+		},
+		{
+			name:      "submitting user is not eligible to query the database",
+			signature: sigExplain,
+			dbMockFactory: func(response *types.ExplainJSONQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("ExplainDataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).
+					Return(nil, &interrors.PermissionErr{ErrMsg: "access forbidden"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'POST /data/test_database/query/explain' because access forbidden",
+		},
+		{
+			name:      "attribute is not indexed",
+			signature: sigExplain,
+			dbMockFactory: func(response *types.ExplainJSONQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("ExplainDataQuery", mock.Anything, dbName, submittingUserName, []byte(q)).
+					Return(nil, &interrors.BadRequestError{ErrMsg: "attribute [attr1] given in the query condition is not indexed"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'POST /data/test_database/query/explain' because attribute [attr1] given in the query condition is not indexed",
+		},
+		{
+			name:      "signature of the execution of the query",
+			signature: sigExecute,
+			dbMockFactory: func(response *types.ExplainJSONQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := requestFactory(tt.signature)
+			require.NoError(t, err)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.ExplainJSONQueryResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestDataRequestHandler_DataSQLQuery(t *testing.T) {
+	dbName := "test_database"
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice", "bob"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	bobCert, _ := testutils.LoadTestClientCrypto(t, cryptoDir, "bob")
+
+	validQuery := "SELECT * FROM test_database WHERE attr1 = true ORDER BY attr2 DESC LIMIT 10"
+	compiledQuery, err := queryexecutor.CompileSQL(validQuery)
+	require.NoError(t, err)
+
+	requestFactory := func(q string) func() (*http.Request, error) {
+		return func() (*http.Request, error) {
+			queryBytes, err := json.Marshal(q)
+			if err != nil {
+				return nil, err
+			}
+			req, err := http.NewRequest(http.MethodPost, constants.PostDataSQLQuery, bytes.NewReader(queryBytes))
+			if err != nil {
+				return nil, err
+			}
+			sig := testutils.SignatureFromQuery(t, aliceSigner, &types.DataSQLQuery{
+				UserId: submittingUserName,
+				Query:  q,
+			})
+			req.Header.Set(constants.UserHeader, submittingUserName)
+			req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+			return req, nil
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() (*http.Request, error)
+		dbMockFactory      func(response *types.DataQueryResponseEnvelope) bcdb.DB
+		expectedResponse   *types.DataQueryResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid sql query",
+			expectedResponse: &types.DataQueryResponseEnvelope{
+				Response: &types.DataQueryResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					KVs: []*types.KVWithMetadata{
+						{
+							Key:   "key1",
+							Value: []byte(`{"attr1":true,"attr2":2}`),
+						},
+						{
+							Key:   "key2",
+							Value: []byte(`{"attr1":true,"attr2":1}`),
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: requestFactory(validQuery),
+			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataSQLQuery", mock.Anything, submittingUserName, compiledQuery).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:           "query syntax error",
+			requestFactory: requestFactory("SELECT * FROM test_database"),
+			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "query syntax error: expected [WHERE] but found the end of the query",
+		},
+		{
+			name:           "database does not exist",
+			requestFactory: requestFactory(validQuery),
+			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(false)
 				return db
 			},
-			expectedStatusCode: http.StatusUnauthorized,
-			expectedErr:        "signature verification failed",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "'test_database' does not exist",
 		},
 		{
-			name: "failed to verify signature",
-			requestFactory: func() (*http.Request, error) {
-				queryReader := bytes.NewReader(queryBytes)
-				require.NotNil(t, queryReader)
-				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
-				if err != nil {
-					return nil, err
-				}
-				req.Header.Set(constants.UserHeader, submittingUserName)
-				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
-				return req, nil
-			},
+			name:           "submitting user is not eligible to query the database",
+			requestFactory: requestFactory(validQuery),
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
 				db := &mocks.DB{}
-				db.On("GetCertificate", submittingUserName).Return(bobCert, nil)
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataSQLQuery", mock.Anything, submittingUserName, compiledQuery).
+					Return(nil, &interrors.PermissionErr{ErrMsg: "access forbidden"})
 				return db
 			},
-			expectedStatusCode: http.StatusUnauthorized,
-			expectedErr:        "signature verification failed",
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'POST /data/sql' because access forbidden",
 		},
 		{
-			name: "missing userID http header",
-			requestFactory: func() (*http.Request, error) {
-				queryReader := bytes.NewReader(queryBytes)
-				require.NotNil(t, queryReader)
-				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
-				if err != nil {
-					return nil, err
-				}
-				return req, nil
-			},
+			name:           "failed to execute the query",
+			requestFactory: requestFactory(validQuery),
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
-				return &mocks.DB{}
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataSQLQuery", mock.Anything, submittingUserName, compiledQuery).
+					Return(nil, errors.New("failed to execute the query"))
+				return db
 			},
-			expectedStatusCode: http.StatusBadRequest,
-			expectedErr:        "UserID is not set in the http request header",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'POST /data/sql' because failed to execute the query",
 		},
 		{
-			name: "missing signature http header",
-			requestFactory: func() (*http.Request, error) {
-				queryReader := bytes.NewReader(queryBytes)
-				require.NotNil(t, queryReader)
-				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), queryReader)
-				if err != nil {
-					return nil, err
-				}
-				req.Header.Set(constants.UserHeader, submittingUserName)
-				return req, nil
-			},
+			name:           "failed to verify signature",
+			requestFactory: requestFactory(validQuery),
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
-				return &mocks.DB{}
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(bobCert, nil)
+				return db
 			},
-			expectedStatusCode: http.StatusBadRequest,
-			expectedErr:        "Signature is not set in the http request header",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
 		},
 		{
 			name: "empty query",
 			requestFactory: func() (*http.Request, error) {
-				req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), nil)
+				req, err := http.NewRequest(http.MethodPost, constants.PostDataSQLQuery, nil)
 				if err != nil {
 					return nil, err
 				}
 				req.Header.Set(constants.UserHeader, submittingUserName)
-				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigFoo))
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString([]byte{0}))
 				return req, nil
 			},
 			dbMockFactory: func(response *types.DataQueryResponseEnvelope) bcdb.DB {
-				db := &mocks.DB{}
-				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("IsDBExists", dbName).Return(true)
-				return db
+				return &mocks.DB{}
 			},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedErr:        "query is empty",
@@ -504,12 +1345,196 @@ func TestDataRequestHandler_DataJSONQuery(t *testing.T) {
 				err = json.NewDecoder(rr.Body).Decode(res)
 				require.NoError(t, err)
 				require.Equal(t, tt.expectedResponse, res)
-				//TODO verify signature on response
 			}
 		})
 	}
 }
 
+func TestDataRequestHandler_ArrowQueryResult(t *testing.T) {
+	dbName := "test_database"
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	kvs := []*types.KVWithMetadata{
+		{
+			Key:   "key1",
+			Value: []byte(`{"attr1":true}`),
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: 2,
+					TxNum:    1,
+				},
+			},
+		},
+	}
+	queryResponse := &types.DataQueryResponseEnvelope{
+		Response: &types.DataQueryResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "testNodeID",
+			},
+			KVs: kvs,
+		},
+		Signature: []byte{0, 0, 0},
+	}
+	expectedStream := &bytes.Buffer{}
+	require.NoError(t, arrowipc.WriteKVs(expectedStream, kvs))
+
+	newRequest := func(t *testing.T, url, q string, query interface{}) *http.Request {
+		queryBytes, err := json.Marshal(q)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(queryBytes))
+		require.NoError(t, err)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, query)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		req.Header.Set("Accept", constants.ArrowStreamContentType)
+		return req
+	}
+
+	jsonQuery := `{"selector":{"attr1":{"$eq":true}}}`
+	sqlQuery := "SELECT * FROM test_database WHERE attr1 = true"
+	compiledQuery, err := queryexecutor.CompileSQL(sqlQuery)
+	require.NoError(t, err)
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		request *http.Request
+		db      func() bcdb.DB
+	}{
+		{
+			name: "json query",
+			request: newRequest(t, constants.URLForJSONQuery(dbName), jsonQuery, &types.DataJSONQuery{
+				UserId: submittingUserName,
+				DbName: dbName,
+				Query:  jsonQuery,
+			}),
+			db: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataQuery", mock.Anything, dbName, submittingUserName, []byte(jsonQuery)).Return(queryResponse, nil)
+				return db
+			},
+		},
+		{
+			name: "sql query",
+			request: newRequest(t, constants.PostDataSQLQuery, sqlQuery, &types.DataSQLQuery{
+				UserId: submittingUserName,
+				Query:  sqlQuery,
+			}),
+			db: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("IsDBExists", dbName).Return(true)
+				db.On("DataSQLQuery", mock.Anything, submittingUserName, compiledQuery).Return(queryResponse, nil)
+				return db
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(tt.db(), logger)
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.Equal(t, constants.ArrowStreamContentType, rr.Header().Get("Content-Type"))
+			require.Equal(t, "testNodeID", rr.Header().Get(constants.NodeIDHeader))
+			require.Equal(t, expectedStream.Bytes(), rr.Body.Bytes())
+		})
+	}
+}
+
+func TestDataRequestHandler_StreamedQueryResult(t *testing.T) {
+	dbName := "test_database"
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	jsonQuery := `{"selector":{"attr1":{"$eq":true}}}`
+	newRequest := func(t *testing.T) *http.Request {
+		queryBytes, err := json.Marshal(jsonQuery)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, constants.URLForJSONQuery(dbName), bytes.NewReader(queryBytes))
+		require.NoError(t, err)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.DataJSONQuery{
+			UserId: submittingUserName,
+			DbName: dbName,
+			Query:  jsonQuery,
+		})
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		req.Header.Set("Accept", constants.NDJSONContentType)
+		return req
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                string
+		lines               []string
+		streamErr           error
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "streamed lines",
+			lines:               []string{"{\"kv\":{\"key\":\"key1\"}}\n", "{\"end\":{}}\n"},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: constants.NDJSONContentType,
+			expectedBody:        "{\"kv\":{\"key\":\"key1\"}}\n{\"end\":{}}\n",
+		},
+		{
+			name:                "error before any line",
+			streamErr:           &interrors.PermissionErr{ErrMsg: "access forbidden"},
+			expectedStatusCode:  http.StatusForbidden,
+			expectedContentType: "application/json",
+			expectedBody:        "access forbidden",
+		},
+		{
+			name:                "error after a line",
+			lines:               []string{"{\"kv\":{\"key\":\"key1\"}}\n"},
+			streamErr:           errors.New("broken pipe"),
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: constants.NDJSONContentType,
+			expectedBody:        "{\"kv\":{\"key\":\"key1\"}}\n",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.DB{}
+			db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+			db.On("IsDBExists", dbName).Return(true)
+			db.On("StreamDataQuery", mock.Anything, dbName, submittingUserName, []byte(jsonQuery), mock.Anything).Return(
+				func(_ context.Context, _, _ string, _ []byte, write func([]byte) error) error {
+					for _, line := range tt.lines {
+						require.NoError(t, write([]byte(line)))
+					}
+					return tt.streamErr
+				})
+
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(db, logger)
+			handler.ServeHTTP(rr, newRequest(t))
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			require.Equal(t, tt.expectedContentType, rr.Header().Get("Content-Type"))
+			require.Contains(t, rr.Body.String(), tt.expectedBody)
+			db.AssertNotCalled(t, "DataQuery", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestDataRequestHandler_DataTransaction(t *testing.T) {
 	alice := "alice"
 	bob := "bob"
@@ -638,6 +1663,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "time: invalid duration \"asdf\"").Return()
 				return db
 			},
 			timeoutStr:   "asdf",
@@ -660,6 +1686,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "timeout can't be negative \"-2s\"").Return()
 				return db
 			},
 			timeoutStr:   "-2s",
@@ -681,6 +1708,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing transaction envelope payload (*types.DataTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -704,6 +1732,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing UserID in transaction envelope payload (*types.DataTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -727,6 +1756,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "users [bob,charlie] in the must sign list have not signed the transaction").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -750,6 +1780,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "an empty UserID in MustSignUserIDs list present in the transaction envelope").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -773,6 +1804,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 				db := &mocks.DB{}
 				db.On("GetCertificate", alice).Return(aliceCert, nil)
 				db.On("GetCertificate", bob).Return(bobCert, nil)
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
@@ -829,6 +1861,7 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", "not-alice").Return(nil, errors.New("no such user"))
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
@@ -890,6 +1923,33 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 			},
 			expectedCode: http.StatusTemporaryRedirect,
 		},
+		{
+			name: "transaction too large",
+			txEnvFactory: func() *types.DataTxEnvelope {
+				return &types.DataTxEnvelope{
+					Payload: dataTx,
+					Signatures: map[string][]byte{
+						alice:   aliceSig,
+						bob:     bobSig,
+						charlie: charlieSig,
+					},
+				}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return correctTxRespEnv
+			},
+			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", alice).Return(aliceCert, nil)
+				db.On("GetCertificate", bob).Return(bobCert, nil)
+				db.On("SubmitTransaction", mock.Anything, mock.Anything).Return(nil, &interrors.TxTooLargeError{
+					ErrMsg: "transaction size [5000 bytes] exceeds the maximal transaction size [2560 bytes] allowed by the maximal block size",
+				})
+				return db
+			},
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedErr:  "transaction size [5000 bytes] exceeds the maximal transaction size [2560 bytes] allowed by the maximal block size",
+		},
 	}
 
 	logger, err := createLogger("debug")
@@ -954,6 +2014,226 @@ func TestDataRequestHandler_DataTransaction(t *testing.T) {
 	}
 }
 
+func TestDataRequestHandler_RawDataTransaction(t *testing.T) {
+	dbName := "org1/db1"
+	alice := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	value := []byte{0x25, 0x50, 0x44, 0x46, 0x00, 0xff}
+	acl := &types.AccessControl{
+		ReadWriteUsers: map[string]bool{
+			alice: true,
+		},
+	}
+	dataTx := &types.DataTx{
+		MustSignUserIds: []string{alice},
+		TxId:            "tx1",
+		DbOperations: []*types.DBOperation{
+			{
+				DbName: dbName,
+				DataWrites: []*types.DataWrite{
+					{
+						Key:   "doc.pdf",
+						Value: value,
+						Acl:   acl,
+					},
+				},
+			},
+		},
+	}
+	aliceSig := testutils.SignatureFromTx(t, aliceSigner, dataTx)
+	aclHeader, err := json.Marshal(acl)
+	require.NoError(t, err)
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	newRequest := func(headers map[string]string) *http.Request {
+		req, err := http.NewRequest(http.MethodPut, constants.URLForGetData(dbName, "doc.pdf"), bytes.NewReader(value))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", constants.OctetStreamContentType)
+		req.Header.Set(constants.UserHeader, alice)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(aliceSig))
+		req.Header.Set(constants.TxIDHeader, "tx1")
+		req.Header.Set(constants.ACLHeader, string(aclHeader))
+		req.Header.Set(constants.TimeoutHeader, "2s")
+		for name, value := range headers {
+			if value == "" {
+				req.Header.Del(name)
+			} else {
+				req.Header.Set(name, value)
+			}
+		}
+		return req
+	}
+
+	t.Run("value is written as is", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("SubmitTransaction", mock.Anything, 2*time.Second).
+			Run(func(args mock.Arguments) {
+				require.Equal(t, &types.DataTxEnvelope{
+					Payload:    dataTx,
+					Signatures: map[string][]byte{alice: aliceSig},
+				}, args[0].(*types.DataTxEnvelope))
+			}).
+			Return(correctTxRespEnv, nil)
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest(nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp := &types.TxReceiptResponseEnvelope{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+		require.Equal(t, correctTxRespEnv, resp)
+	})
+
+	testCases := []struct {
+		name         string
+		headers      map[string]string
+		expectedCode int
+		expectedErr  string
+		category     types.RejectedTx_Category
+	}{
+		{
+			name:         "missing tx ID",
+			headers:      map[string]string{constants.TxIDHeader: ""},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "TxID is not set in the http request header",
+			category:     types.RejectedTx_MALFORMED,
+		},
+		{
+			name:         "missing signature",
+			headers:      map[string]string{constants.SignatureHeader: ""},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "Signature is not set in the http request header",
+			category:     types.RejectedTx_UNAUTHENTICATED,
+		},
+		{
+			name:         "invalid ACL",
+			headers:      map[string]string{constants.ACLHeader: "{"},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "ACL is not a valid access control: unexpected end of JSON input",
+			category:     types.RejectedTx_MALFORMED,
+		},
+		{
+			name:         "signature does not cover the ACL",
+			headers:      map[string]string{constants.ACLHeader: ""},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed",
+			category:     types.RejectedTx_UNAUTHENTICATED,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.DB{}
+			db.On("GetCertificate", alice).Return(aliceCert, nil)
+			db.On("RecordRejectedTx", mock.Anything, mock.Anything, tt.category, tt.expectedErr).Return()
+
+			rr := httptest.NewRecorder()
+			NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest(tt.headers))
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			respErr := &types.HttpResponseErr{}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+			require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			db.AssertCalled(t, "RecordRejectedTx", mock.Anything, mock.Anything, tt.category, tt.expectedErr)
+		})
+	}
+}
+
+func TestDataRequestHandler_BlobUpload(t *testing.T) {
+	alice := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	value := []byte{0x25, 0x50, 0x44, 0x46, 0x00, 0xff}
+	aliceSig, err := aliceSigner.Sign(value)
+	require.NoError(t, err)
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	newRequest := func(signature []byte) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, constants.PostBlob, bytes.NewReader(value))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", constants.OctetStreamContentType)
+		req.Header.Set(constants.UserHeader, alice)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(signature))
+		return req
+	}
+
+	t.Run("blob is stored", func(t *testing.T) {
+		blobResp := &types.PutBlobResponseEnvelope{
+			Response: &types.PutBlobResponse{
+				Header:   &types.ResponseHeader{NodeId: "testNodeID"},
+				Manifest: &types.BlobManifest{Size: 6, ChunkSize: 4, ChunkHashes: [][]byte{[]byte("hash1"), []byte("hash2")}},
+			},
+			Signature: []byte{0, 0, 0},
+		}
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("PutBlob", alice, value).Return(blobResp, nil)
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest(aliceSig))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp := &types.PutBlobResponseEnvelope{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+		require.Equal(t, blobResp, resp)
+	})
+
+	testCases := []struct {
+		name         string
+		signature    []byte
+		putErr       error
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name:         "signature does not cover the value",
+			signature:    []byte("bad-signature"),
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed",
+		},
+		{
+			name:         "blob is rejected",
+			signature:    aliceSig,
+			putErr:       &interrors.BadRequestError{ErrMsg: "the blob is empty"},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "error while processing 'POST /data/blob' because the blob is empty",
+		},
+		{
+			name:         "blob store fails",
+			signature:    aliceSig,
+			putErr:       errors.New("disk is full"),
+			expectedCode: http.StatusInternalServerError,
+			expectedErr:  "error while processing 'POST /data/blob' because disk is full",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &mocks.DB{}
+			db.On("GetCertificate", alice).Return(aliceCert, nil)
+			db.On("PutBlob", alice, value).Return(nil, tt.putErr)
+
+			rr := httptest.NewRecorder()
+			NewDataRequestHandler(db, logger).ServeHTTP(rr, newRequest(tt.signature))
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			respErr := &types.HttpResponseErr{}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+			require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+		})
+	}
+}
+
 func TestDataRequestHandler_DataJSONQueryWithContext(t *testing.T) {
 	dbName := "test_database"
 
@@ -1095,3 +2375,529 @@ func TestDataRequestHandler_DataJSONQueryWithContext(t *testing.T) {
 		})
 	}
 }
+
+func TestDataRequestHandler_PendingDataTransaction(t *testing.T) {
+	alice := "alice"
+	bob := "bob"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice", "bob"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	_, bobSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "bob")
+
+	dataTx := &types.DataTx{
+		MustSignUserIds: []string{alice, bob},
+		TxId:            "1",
+		DbOperations: []*types.DBOperation{
+			{
+				DbName: "testDB",
+				DataWrites: []*types.DataWrite{
+					{
+						Key:   "xxx",
+						Value: []byte("yyy"),
+					},
+				},
+			},
+		},
+	}
+	aliceSig := testutils.SignatureFromTx(t, aliceSigner, dataTx)
+	bobSig := testutils.SignatureFromTx(t, bobSigner, dataTx)
+
+	pendingTxRespEnv := &types.PendingDataTxResponseEnvelope{
+		Response: &types.PendingDataTxResponse{
+			Header: &types.ResponseHeader{NodeId: "node1"},
+			PendingTx: &types.PendingDataTx{
+				Envelope: &types.DataTxEnvelope{
+					Payload:    dataTx,
+					Signatures: map[string][]byte{alice: aliceSig},
+				},
+				PendingSignerIds: []string{bob},
+				ExpiresAt:        1000,
+			},
+		},
+		Signature: []byte{0, 0, 0},
+	}
+
+	testCases := []struct {
+		name          string
+		txEnv         *types.DataTxEnvelope
+		expiryStr     string
+		dbMockFactory func() bcdb.DB
+		expectedCode  int
+		expectedErr   string
+	}{
+		{
+			name: "add pending data transaction",
+			txEnv: &types.DataTxEnvelope{
+				Payload:    dataTx,
+				Signatures: map[string][]byte{alice: aliceSig},
+			},
+			expiryStr: "10m",
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", alice).Return(aliceCert, nil)
+				db.On("AddPendingDataTx", mock.Anything, 10*time.Minute).Return(pendingTxRespEnv, nil)
+				return db
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "no must sign user signed",
+			txEnv: &types.DataTxEnvelope{
+				Payload: dataTx,
+			},
+			dbMockFactory: func() bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "none of the users in the must sign list have signed the transaction",
+		},
+		{
+			name: "invalid signature",
+			txEnv: &types.DataTxEnvelope{
+				Payload:    dataTx,
+				Signatures: map[string][]byte{alice: bobSig},
+			},
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", alice).Return(aliceCert, nil)
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed",
+		},
+		{
+			name: "invalid expiry",
+			txEnv: &types.DataTxEnvelope{
+				Payload:    dataTx,
+				Signatures: map[string][]byte{alice: aliceSig},
+			},
+			expiryStr: "-1s",
+			dbMockFactory: func() bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "expiry must be positive \"-1s\"",
+		},
+		{
+			name: "duplicate txID",
+			txEnv: &types.DataTxEnvelope{
+				Payload:    dataTx,
+				Signatures: map[string][]byte{alice: aliceSig},
+			},
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", alice).Return(aliceCert, nil)
+				db.On("AddPendingDataTx", mock.Anything, time.Duration(0)).Return(nil, &interrors.DuplicateTxIDError{TxID: "1"})
+				return db
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "error while processing 'POST /data/pending/tx' because the transaction has a duplicate txID [1]",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			txBytes, err := json.Marshal(tt.txEnv)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, constants.PostPendingDataTx, bytes.NewReader(txBytes))
+			require.NoError(t, err)
+			if tt.expiryStr != "" {
+				req.Header.Set(constants.ExpiryHeader, tt.expiryStr)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(tt.dbMockFactory(), logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusOK {
+				resp := &types.PendingDataTxResponseEnvelope{}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+				require.Equal(t, pendingTxRespEnv, resp)
+			} else {
+				respErr := &types.HttpResponseErr{}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+		})
+	}
+}
+
+func TestDataRequestHandler_PendingDataTxQuery(t *testing.T) {
+	alice := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	sigTx := testutils.SignatureFromQuery(t, aliceSigner, &types.GetPendingDataTxQuery{
+		UserId: alice,
+		TxId:   "tx1",
+	})
+	sigTxs := testutils.SignatureFromQuery(t, aliceSigner, &types.GetPendingDataTxsQuery{
+		UserId: alice,
+	})
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	t.Run("get pending data transaction", func(t *testing.T) {
+		respEnv := &types.PendingDataTxResponseEnvelope{
+			Response: &types.PendingDataTxResponse{
+				Header: &types.ResponseHeader{NodeId: "node1"},
+				PendingTx: &types.PendingDataTx{
+					Envelope: &types.DataTxEnvelope{
+						Payload: &types.DataTx{MustSignUserIds: []string{alice, "bob"}, TxId: "tx1"},
+					},
+					PendingSignerIds: []string{alice, "bob"},
+				},
+			},
+		}
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("GetPendingDataTx", alice, "tx1").Return(respEnv, nil)
+
+		req, err := http.NewRequest(http.MethodGet, constants.URLForGetPendingDataTx("tx1"), nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, alice)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigTx))
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp := &types.PendingDataTxResponseEnvelope{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+		require.Equal(t, respEnv, resp)
+	})
+
+	t.Run("pending data transaction not found", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("GetPendingDataTx", alice, "tx1").Return(nil, &interrors.NotFoundErr{Message: "there is no pending transaction with txID [tx1]"})
+
+		req, err := http.NewRequest(http.MethodGet, constants.URLForGetPendingDataTx("tx1"), nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, alice)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigTx))
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+		respErr := &types.HttpResponseErr{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+		require.Equal(t, "error while processing 'GET /data/pending/tx/tx1' because there is no pending transaction with txID [tx1]", respErr.ErrMsg)
+	})
+
+	t.Run("get pending data transactions on a follower", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("GetPendingDataTxs", alice).Return(nil, &interrors.NotLeaderError{
+			LeaderID:       3,
+			LeaderHostPort: "server3.example.com:6091",
+		})
+
+		req, err := http.NewRequest(http.MethodGet, "http://server1.example.com:6091"+constants.GetPendingDataTxs, nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, alice)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sigTxs))
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		require.Equal(t, "http://server3.example.com:6091/data/pending", rr.Header().Get("Location"))
+	})
+}
+
+func TestDataRequestHandler_PendingDataTxSignature(t *testing.T) {
+	alice := "alice"
+	bob := "bob"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice", "bob"})
+	_, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	bobCert, bobSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "bob")
+
+	dataTx := &types.DataTx{
+		MustSignUserIds: []string{alice, bob},
+		TxId:            "tx1",
+		DbOperations: []*types.DBOperation{
+			{
+				DbName: "testDB",
+				DataWrites: []*types.DataWrite{
+					{
+						Key:   "xxx",
+						Value: []byte("yyy"),
+					},
+				},
+			},
+		},
+	}
+	aliceSig := testutils.SignatureFromTx(t, aliceSigner, dataTx)
+	bobSig := testutils.SignatureFromTx(t, bobSigner, dataTx)
+
+	pendingRespEnv := func(signatures map[string][]byte, pendingSigners []string) *types.PendingDataTxResponseEnvelope {
+		return &types.PendingDataTxResponseEnvelope{
+			Response: &types.PendingDataTxResponse{
+				Header: &types.ResponseHeader{NodeId: "node1"},
+				PendingTx: &types.PendingDataTx{
+					Envelope: &types.DataTxEnvelope{
+						Payload:    dataTx,
+						Signatures: signatures,
+					},
+					PendingSignerIds: pendingSigners,
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		sig           *types.PendingDataTxSignature
+		dbMockFactory func() bcdb.DB
+		expectedCode  int
+		expectedErr   string
+	}{
+		{
+			name: "last signature submits the transaction",
+			sig:  &types.PendingDataTxSignature{TxId: "tx1", UserId: bob, Signature: bobSig},
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", bob).Return(bobCert, nil)
+				db.On("GetPendingDataTx", bob, "tx1").Return(pendingRespEnv(map[string][]byte{alice: aliceSig}, []string{bob}), nil)
+				completed := pendingRespEnv(map[string][]byte{alice: aliceSig, bob: bobSig}, nil)
+				db.On("AddPendingDataTxSignature", "tx1", bob, bobSig).Return(completed, nil)
+				db.On("SubmitTransaction", completed.Response.PendingTx.Envelope, time.Duration(0)).Return(correctTxRespEnv, nil)
+				return db
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "invalid signature",
+			sig:  &types.PendingDataTxSignature{TxId: "tx1", UserId: bob, Signature: aliceSig},
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", bob).Return(bobCert, nil)
+				db.On("GetPendingDataTx", bob, "tx1").Return(pendingRespEnv(map[string][]byte{alice: aliceSig}, []string{bob}), nil)
+				return db
+			},
+			expectedCode: http.StatusUnauthorized,
+			expectedErr:  "signature verification failed",
+		},
+		{
+			name: "mismatched txID",
+			sig:  &types.PendingDataTxSignature{TxId: "tx2", UserId: bob, Signature: bobSig},
+			dbMockFactory: func() bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "the txID [tx2] in the signature does not match the txID [tx1] in the URL",
+		},
+		{
+			name: "not a must sign user",
+			sig:  &types.PendingDataTxSignature{TxId: "tx1", UserId: "charlie", Signature: bobSig},
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetPendingDataTx", "charlie", "tx1").Return(nil, &interrors.PermissionErr{
+					ErrMsg: "the user [charlie] is not a must sign user of the pending transaction [tx1]",
+				})
+				return db
+			},
+			expectedCode: http.StatusForbidden,
+			expectedErr:  "error while processing 'POST /data/pending/tx/tx1/sign' because the user [charlie] is not a must sign user of the pending transaction [tx1]",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			sigBytes, err := json.Marshal(tt.sig)
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, constants.URLForPostPendingDataTxSignature("tx1"), bytes.NewReader(sigBytes))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler := NewDataRequestHandler(tt.dbMockFactory(), logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusOK {
+				resp := &types.TxReceiptResponseEnvelope{}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+				require.Equal(t, correctTxRespEnv, resp)
+			} else {
+				respErr := &types.HttpResponseErr{}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+		})
+	}
+}
+
+func TestDataRequestHandler_DataCursors(t *testing.T) {
+	alice := "alice"
+	cursorID := "0123456789abcdef0123456789abcdef"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	sigOpen := testutils.SignatureFromQuery(t, aliceSigner, &types.OpenDataCursorQuery{
+		UserId: alice,
+		DbName: "db1",
+		Prefix: "item/",
+		Ttl:    "1h0m0s",
+	})
+	sigPage := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDataCursorPageQuery{
+		UserId:   alice,
+		CursorId: cursorID,
+		After:    "item/3",
+		Limit:    2,
+	})
+	sigClose := testutils.SignatureFromQuery(t, aliceSigner, &types.CloseDataCursorQuery{
+		UserId:   alice,
+		CursorId: cursorID,
+	})
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	serve := func(db bcdb.DB, method, url string, sig []byte) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, alice)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+
+		rr := httptest.NewRecorder()
+		NewDataRequestHandler(db, logger).ServeHTTP(rr, req)
+		return rr
+	}
+	requireErr := func(rr *httptest.ResponseRecorder, status int, errMsg string) {
+		require.Equal(t, status, rr.Code)
+		respErr := &types.HttpResponseErr{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+		require.Equal(t, errMsg, respErr.ErrMsg)
+	}
+
+	cursorEnv := &types.DataCursorResponseEnvelope{
+		Response: &types.DataCursorResponse{
+			Header:    &types.ResponseHeader{NodeId: "node1"},
+			CursorId:  cursorID,
+			DbName:    "db1",
+			Prefix:    "item/",
+			ExpiresAt: 1000,
+		},
+		Signature: []byte{0, 0, 0},
+	}
+
+	t.Run("open a cursor", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("IsDBExists", "db1").Return(true)
+		db.On("OpenDataCursor", alice, "db1", "item/", time.Hour).Return(cursorEnv, nil)
+
+		rr := serve(db, http.MethodPost, constants.URLForPostDataCursor("db1", "item/", time.Hour), sigOpen)
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp := &types.DataCursorResponseEnvelope{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+		require.Equal(t, cursorEnv, resp)
+	})
+
+	t.Run("open a cursor with an invalid TTL", func(t *testing.T) {
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.OpenDataCursorQuery{
+			UserId: alice,
+			DbName: "db1",
+			Ttl:    "-1h",
+		})
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+
+		rr := serve(db, http.MethodPost, "/data/cursor?db=db1&ttl=-1h", sig)
+		requireErr(rr, http.StatusBadRequest, `the TTL of a cursor must be a positive duration "-1h"`)
+	})
+
+	t.Run("open a cursor on a database that does not exist", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("IsDBExists", "db1").Return(false)
+
+		rr := serve(db, http.MethodPost, constants.URLForPostDataCursor("db1", "item/", time.Hour), sigOpen)
+		requireErr(rr, http.StatusBadRequest, "error db 'db1' doesn't exist")
+	})
+
+	t.Run("open too many cursors", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("IsDBExists", "db1").Return(true)
+		db.On("OpenDataCursor", alice, "db1", "item/", time.Hour).
+			Return(nil, &interrors.BadRequestError{ErrMsg: "the number of cursors reached the limit of 100"})
+
+		rr := serve(db, http.MethodPost, constants.URLForPostDataCursor("db1", "item/", time.Hour), sigOpen)
+		requireErr(rr, http.StatusBadRequest,
+			"error while processing 'POST /data/cursor?db=db1&prefix=item%2F&ttl=1h0m0s' because the number of cursors reached the limit of 100")
+	})
+
+	t.Run("get a page of a cursor", func(t *testing.T) {
+		pageEnv := &types.GetDataCursorPageResponseEnvelope{
+			Response: &types.GetDataCursorPageResponse{
+				Header: &types.ResponseHeader{NodeId: "node1"},
+				KVs: []*types.KVWithMetadata{
+					{Key: "item/4", Value: []byte("value4")},
+					{Key: "item/5", Value: []byte("value5")},
+				},
+				HasMore:   true,
+				ExpiresAt: 2000,
+			},
+			Signature: []byte{0, 0, 0},
+		}
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("GetDataCursorPage", alice, cursorID, "item/3", uint64(2)).Return(pageEnv, nil)
+
+		rr := serve(db, http.MethodGet, constants.URLForGetDataCursorPage(cursorID, "item/3", 2), sigPage)
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp := &types.GetDataCursorPageResponseEnvelope{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+		require.Equal(t, pageEnv, resp)
+	})
+
+	t.Run("get a page of an expired cursor", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("GetDataCursorPage", alice, cursorID, "item/3", uint64(2)).
+			Return(nil, &interrors.NotFoundErr{Message: "there is no cursor with ID [" + cursorID + "]"})
+
+		rr := serve(db, http.MethodGet, constants.URLForGetDataCursorPage(cursorID, "item/3", 2), sigPage)
+		requireErr(rr, http.StatusNotFound,
+			"error while processing 'GET /data/cursor/"+cursorID+"?after=item%2F3&limit=2' because there is no cursor with ID ["+cursorID+"]")
+	})
+
+	t.Run("get a page of a cursor of another user", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("GetDataCursorPage", alice, cursorID, "item/3", uint64(2)).
+			Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] did not open the cursor [" + cursorID + "]"})
+
+		rr := serve(db, http.MethodGet, constants.URLForGetDataCursorPage(cursorID, "item/3", 2), sigPage)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("get a page with an invalid limit", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+
+		rr := serve(db, http.MethodGet, "/data/cursor/"+cursorID+"?limit=all", sigPage)
+		requireErr(rr, http.StatusBadRequest, `the limit parameter must be a non-negative integer "all"`)
+	})
+
+	t.Run("close a cursor", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("GetCertificate", alice).Return(aliceCert, nil)
+		db.On("CloseDataCursor", alice, cursorID).Return(cursorEnv, nil)
+
+		rr := serve(db, http.MethodPost, constants.URLForPostDataCursorClose(cursorID), sigClose)
+		require.Equal(t, http.StatusOK, rr.Code)
+		resp := &types.DataCursorResponseEnvelope{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(resp))
+		require.Equal(t, cursorEnv, resp)
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 	backend "github.com/hyperledger-labs/orion-server/internal/bcdb"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
@@ -38,6 +39,8 @@ func NewDBRequestHandler(db backend.DB, logger *logger.SugarLogger) http.Handler
 		logger: logger,
 	}
 
+	handler.router.HandleFunc(constants.GetDBs, handler.dbs).Methods(http.MethodGet)
+	handler.router.HandleFunc(constants.GetMigrations, handler.migrations).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.GetDBStatus, handler.dbStatus).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.PostDBTx, handler.dbTransaction).Methods(http.MethodPost)
 
@@ -49,7 +52,7 @@ func (d *dbRequestHandler) ServeHTTP(response http.ResponseWriter, request *http
 }
 
 func (d *dbRequestHandler) dbStatus(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDBStatus, d.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDBStatus, d.sigVerifier, d.db)
 	if respondedErr {
 		return
 	}
@@ -70,10 +73,63 @@ func (d *dbRequestHandler) dbStatus(response http.ResponseWriter, request *http.
 	utils.SendHTTPResponse(response, http.StatusOK, dbStatus)
 }
 
+func (d *dbRequestHandler) dbs(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDBs, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetDBsQuery)
+
+	dbs, err := d.db.GetDBs(query.UserId, query.Prefix, query.Offset, query.Limit)
+	if err != nil {
+		utils.SendHTTPResponse(
+			response,
+			http.StatusInternalServerError,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			},
+		)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, dbs)
+}
+
+func (d *dbRequestHandler) migrations(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetMigrations, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetMigrationsQuery)
+
+	migrations, err := d.db.GetMigrations(query.UserId, query.Name)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *ierrors.PermissionErr:
+			status = http.StatusForbidden
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			},
+		)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, migrations)
+}
+
 func (d *dbRequestHandler) dbTransaction(response http.ResponseWriter, request *http.Request) {
 	timeout, err := validateAndParseTxPostHeader(&request.Header)
 	if err != nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED, err.Error())
 		return
 	}
 
@@ -82,30 +138,31 @@ func (d *dbRequestHandler) dbTransaction(response http.ResponseWriter, request *
 
 	txEnv := &types.DBAdministrationTxEnvelope{}
 	if err := dbRequestBody.Decode(txEnv); err != nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		d.txHandler.rejectTransaction(response, decodeErrorStatus(err), "", "", types.RejectedTx_MALFORMED, err.Error())
 		return
 	}
 
 	if txEnv.Payload == nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload)})
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED,
+			fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload))
 		return
 	}
 
+	txID := txEnv.Payload.TxId
 	if txEnv.Payload.UserId == "" {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload)})
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, "", types.RejectedTx_MALFORMED,
+			fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload))
 		return
 	}
 
 	if len(txEnv.Signature) == 0 {
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing Signature in transaction envelope payload (%T)", txEnv.Payload)})
+		d.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, txEnv.Payload.UserId, types.RejectedTx_UNAUTHENTICATED,
+			fmt.Sprintf("missing Signature in transaction envelope payload (%T)", txEnv.Payload))
 		return
 	}
 
 	if err, code := VerifyRequestSignature(d.sigVerifier, txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload); err != nil {
-		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		d.txHandler.rejectTransaction(response, code, txID, txEnv.Payload.UserId, types.RejectedTx_UNAUTHENTICATED, err.Error())
 		return
 	}
 
@@ -69,6 +69,36 @@ func TestDBRequestHandler_DBStatus(t *testing.T) {
 			},
 			expectedStatusCode: http.StatusOK,
 		},
+		{
+			name: "valid dbStatus request of a hierarchical database",
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetDBStatus("org1/app2/db"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDBStatusQuery{UserId: submittingUserName, DbName: "org1/app2/db"})
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDBStatusResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDBStatus", "org1/app2/db").Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetDBStatusResponseEnvelope{
+				Response: &types.GetDBStatusResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Exist: true,
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
 		{
 			name: "invalid dbStatus request missing user header",
 			requestFactory: func() (*http.Request, error) {
@@ -212,6 +242,271 @@ func TestDBRequestHandler_DBStatus(t *testing.T) {
 	}
 }
 
+func TestDBRequestHandler_DBs(t *testing.T) {
+	submittingUserName := "alice"
+
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	testCases := []struct {
+		name               string
+		url                string
+		query              *types.GetDBsQuery
+		dbMockFactory      func(response *types.GetDBsResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetDBsResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:  "list all databases",
+			url:   constants.URLForGetDBs(""),
+			query: &types.GetDBsQuery{UserId: submittingUserName},
+			dbMockFactory: func(response *types.GetDBsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDBs", submittingUserName, "", uint64(0), uint64(0)).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetDBsResponseEnvelope{
+				Response: &types.GetDBsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					DbNames: []string{"bdb", "org1/app1/db", "org1/app2/db"},
+					Dbs: []*types.DBInfo{
+						{Name: "bdb"},
+						{Name: "org1/app1/db", Version: &types.Version{BlockNum: 2}, IndexedAttributes: []string{"age", "name"}},
+						{Name: "org1/app2/db", Version: &types.Version{BlockNum: 3}},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "list a page of databases by prefix",
+			url:   constants.URLForGetDBsPage("org1/app2", 1, 2),
+			query: &types.GetDBsQuery{UserId: submittingUserName, Prefix: "org1/app2", Offset: 1, Limit: 2},
+			dbMockFactory: func(response *types.GetDBsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDBs", submittingUserName, "org1/app2", uint64(1), uint64(2)).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetDBsResponseEnvelope{
+				Response: &types.GetDBsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					DbNames: []string{"org1/app2/db2", "org1/app2/db3"},
+					Dbs: []*types.DBInfo{
+						{Name: "org1/app2/db2", Version: &types.Version{BlockNum: 4}},
+						{Name: "org1/app2/db3", Version: &types.Version{BlockNum: 5}},
+					},
+					HasMore: true,
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "invalid limit",
+			url:   constants.GetDBs + "?limit=-1",
+			query: &types.GetDBsQuery{UserId: submittingUserName},
+			dbMockFactory: func(response *types.GetDBsResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the limit parameter must be a non-negative integer \"-1\"",
+		},
+		{
+			name:  "failed to list databases",
+			url:   constants.URLForGetDBs("org1"),
+			query: &types.GetDBsQuery{UserId: submittingUserName, Prefix: "org1"},
+			dbMockFactory: func(response *types.GetDBsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDBs", submittingUserName, "org1", uint64(0), uint64(0)).Return(nil, errors.New("failed to list databases"))
+				return db
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'GET /db/?prefix=org1' because failed to list databases",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			req.Header.Set(constants.UserHeader, submittingUserName)
+			sig := testutils.SignatureFromQuery(t, aliceSigner, tt.query)
+			req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			handler := NewDBRequestHandler(db, logger)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetDBsResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestDBRequestHandler_Migrations(t *testing.T) {
+	submittingUserName := "alice"
+
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	testCases := []struct {
+		name               string
+		url                string
+		query              *types.GetMigrationsQuery
+		dbMockFactory      func(response *types.GetMigrationsResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetMigrationsResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:  "list all migrations",
+			url:   constants.URLForGetMigrations(""),
+			query: &types.GetMigrationsQuery{UserId: submittingUserName},
+			dbMockFactory: func(response *types.GetMigrationsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetMigrations", submittingUserName, "").Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetMigrationsResponseEnvelope{
+				Response: &types.GetMigrationsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Migrations: []*types.MigrationState{
+						{
+							Name:    "accounts",
+							Version: 1,
+							History: []*types.MigrationStep{{Version: 1, TxId: "tx1", UserId: "admin", BlockNumber: 2}},
+						},
+						{
+							Name:    "schema",
+							Version: 0,
+							History: []*types.MigrationStep{
+								{Version: 1, TxId: "tx2", UserId: "admin", BlockNumber: 3},
+								{Version: 1, Direction: types.Migration_DOWN, TxId: "tx3", UserId: "admin", BlockNumber: 4},
+							},
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "get a migration by name",
+			url:   constants.URLForGetMigrations("schema"),
+			query: &types.GetMigrationsQuery{UserId: submittingUserName, Name: "schema"},
+			dbMockFactory: func(response *types.GetMigrationsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetMigrations", submittingUserName, "schema").Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetMigrationsResponseEnvelope{
+				Response: &types.GetMigrationsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Migrations: []*types.MigrationState{{Name: "schema"}},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "user has no permission",
+			url:   constants.URLForGetMigrations(""),
+			query: &types.GetMigrationsQuery{UserId: submittingUserName},
+			dbMockFactory: func(response *types.GetMigrationsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetMigrations", submittingUserName, "").Return(nil, &interrors.PermissionErr{ErrMsg: "the user [alice] has no permission to get the migrations"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /db/migrations' because the user [alice] has no permission to get the migrations",
+		},
+		{
+			name:  "failed to get the migrations",
+			url:   constants.URLForGetMigrations("schema"),
+			query: &types.GetMigrationsQuery{UserId: submittingUserName, Name: "schema"},
+			dbMockFactory: func(response *types.GetMigrationsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetMigrations", submittingUserName, "schema").Return(nil, errors.New("failed to get the migrations"))
+				return db
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'GET /db/migrations?name=schema' because failed to get the migrations",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			req.Header.Set(constants.UserHeader, submittingUserName)
+			sig := testutils.SignatureFromQuery(t, aliceSigner, tt.query)
+			req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			handler := NewDBRequestHandler(db, logger)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetMigrationsResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
 func TestDBRequestHandler_DBTransaction(t *testing.T) {
 	userID := "alice"
 	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
@@ -300,6 +595,7 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "time: invalid duration \"asdf\"").Return()
 				return db
 			},
 			timeoutStr:   "asdf",
@@ -319,6 +615,7 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "timeout can't be negative \"-2s\"").Return()
 				return db
 			},
 			timeoutStr:   "-2s",
@@ -335,6 +632,7 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing transaction envelope payload (*types.DBAdministrationTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -353,6 +651,7 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing UserID in transaction envelope payload (*types.DBAdministrationTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -368,6 +667,7 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "missing Signature in transaction envelope payload (*types.DBAdministrationTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -387,6 +687,7 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", userID).Return(aliceCert, nil)
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
@@ -410,6 +711,7 @@ func TestDBRequestHandler_DBTransaction(t *testing.T) {
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", "not-alice").Return(nil, errors.New("no such user"))
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
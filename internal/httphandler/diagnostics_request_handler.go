@@ -0,0 +1,165 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package httphandler
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	rpprof "runtime/pprof"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/internal/failpoint"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// maxRecentGCPauses is the number of recent GC pauses reported by the GC statistics
+const maxRecentGCPauses = 16
+
+// diagnosticsRequestHandler serves the runtime diagnostics of the node: the profiles of net/http/pprof, goroutine
+// dumps, GC statistics, and the open snapshots of the state database. It also controls the failpoints of builds with the tag [failpoints]. Every request must be
+// signed by an admin.
+type diagnosticsRequestHandler struct {
+	db          bcdb.DB
+	sigVerifier *cryptoservice.SignatureVerifier
+	router      *mux.Router
+	logger      *logger.SugarLogger
+}
+
+// NewDiagnosticsRequestHandler returns the handler of the diagnostics endpoints
+func NewDiagnosticsRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handler {
+	handler := &diagnosticsRequestHandler{
+		db:          db,
+		sigVerifier: cryptoservice.NewVerifier(db, logger),
+		router:      mux.NewRouter(),
+		logger:      logger,
+	}
+
+	handler.router.HandleFunc(constants.GetDiagnosticsPprof+"cmdline", pprof.Cmdline)
+	handler.router.HandleFunc(constants.GetDiagnosticsPprof+"profile", pprof.Profile)
+	handler.router.HandleFunc(constants.GetDiagnosticsPprof+"symbol", pprof.Symbol)
+	handler.router.HandleFunc(constants.GetDiagnosticsPprof+"trace", pprof.Trace)
+	// HTTP GET "/debug/pprof/" lists the profiles, and "/debug/pprof/{profile}", e.g., heap, serves a profile
+	handler.router.PathPrefix(constants.GetDiagnosticsPprof).HandlerFunc(pprof.Index)
+	// HTTP GET "/debug/goroutines" returns the stack traces of all goroutines
+	handler.router.HandleFunc(constants.GetDiagnosticsGoroutines, handler.goroutines).Methods(http.MethodGet)
+	// HTTP GET "/debug/gcstats" returns the garbage collection and memory statistics
+	handler.router.HandleFunc(constants.GetDiagnosticsGCStats, handler.gcStats).Methods(http.MethodGet)
+	// HTTP GET "/debug/snapshots" returns the open snapshots of the state database
+	handler.router.HandleFunc(constants.GetDiagnosticsSnapshots, handler.snapshots).Methods(http.MethodGet)
+	// HTTP GET "/debug/failpoints" returns the enabled failpoints
+	handler.router.HandleFunc(constants.GetDiagnosticsFailpoints, handler.failpoints).Methods(http.MethodGet)
+	// HTTP PUT "/debug/failpoints/{name}/{action}" enables a failpoint
+	handler.router.HandleFunc(constants.PutDiagnosticsFailpoint, handler.enableFailpoint).Methods(http.MethodPut)
+	// HTTP DELETE "/debug/failpoints/{name}" disables a failpoint
+	handler.router.HandleFunc(constants.DeleteDiagnosticsFailpoint, handler.disableFailpoint).Methods(http.MethodDelete)
+
+	return handler
+}
+
+func (d *diagnosticsRequestHandler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.DiagnosticsEndpoint, d.sigVerifier, d.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetDiagnosticsQuery)
+
+	isAdmin, err := d.db.IsAdmin(query.GetUserId())
+	if err != nil {
+		utils.SendHTTPResponse(
+			response,
+			http.StatusInternalServerError,
+			&types.HttpResponseErr{ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error()},
+		)
+		return
+	}
+	if !isAdmin {
+		utils.SendHTTPResponse(
+			response,
+			http.StatusForbidden,
+			&types.HttpResponseErr{ErrMsg: "the user [" + query.GetUserId() + "] has no permission to access the diagnostics endpoints"},
+		)
+		return
+	}
+
+	d.logger.Infof("user [%s] accesses the diagnostics endpoint: %s", query.GetUserId(), request.URL.String())
+	d.router.ServeHTTP(response, request)
+}
+
+func (d *diagnosticsRequestHandler) goroutines(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := rpprof.Lookup("goroutine").WriteTo(response, 2); err != nil {
+		d.logger.Errorf("failed to write the goroutine dump: %s", err)
+	}
+}
+
+func (d *diagnosticsRequestHandler) gcStats(response http.ResponseWriter, request *http.Request) {
+	gcStats := &debug.GCStats{}
+	debug.ReadGCStats(gcStats)
+	memStats := &runtime.MemStats{}
+	runtime.ReadMemStats(memStats)
+
+	stats := &types.GCStats{
+		Goroutines:    runtime.NumGoroutine(),
+		NumGC:         gcStats.NumGC,
+		PauseTotal:    gcStats.PauseTotal.String(),
+		GCCPUFraction: memStats.GCCPUFraction,
+		HeapAlloc:     memStats.HeapAlloc,
+		HeapInuse:     memStats.HeapInuse,
+		HeapIdle:      memStats.HeapIdle,
+		HeapReleased:  memStats.HeapReleased,
+		HeapObjects:   memStats.HeapObjects,
+		NextGC:        memStats.NextGC,
+		Sys:           memStats.Sys,
+	}
+	if !gcStats.LastGC.IsZero() {
+		stats.LastGC = gcStats.LastGC.Format(time.RFC3339)
+	}
+	for i, pause := range gcStats.Pause {
+		if i == maxRecentGCPauses {
+			break
+		}
+		stats.RecentPauses = append(stats.RecentPauses, pause.String())
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, stats)
+}
+
+func (d *diagnosticsRequestHandler) snapshots(response http.ResponseWriter, request *http.Request) {
+	utils.SendHTTPResponse(response, http.StatusOK, d.db.GetOpenSnapshots())
+}
+
+func (d *diagnosticsRequestHandler) failpoints(response http.ResponseWriter, request *http.Request) {
+	enabled := failpoint.List()
+	if enabled == nil {
+		enabled = make(map[string]string)
+	}
+	utils.SendHTTPResponse(response, http.StatusOK, &types.Failpoints{Enabled: enabled})
+}
+
+func (d *diagnosticsRequestHandler) enableFailpoint(response http.ResponseWriter, request *http.Request) {
+	params := mux.Vars(request)
+	if err := failpoint.Enable(params["name"], params["action"]); err != nil {
+		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	d.logger.Warnf("failpoint [%s] is enabled with the action [%s]", params["name"], params["action"])
+	utils.SendHTTPResponse(response, http.StatusOK, &types.Failpoints{Enabled: failpoint.List()})
+}
+
+func (d *diagnosticsRequestHandler) disableFailpoint(response http.ResponseWriter, request *http.Request) {
+	name := mux.Vars(request)["name"]
+	failpoint.Disable(name)
+
+	d.logger.Infof("failpoint [%s] is disabled", name)
+	d.failpoints(response, request)
+}
@@ -0,0 +1,217 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package httphandler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/failpoint"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticsRequestHandler(t *testing.T) {
+	submittingUserName := "admin"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"admin"})
+	adminCert, adminSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "admin")
+
+	requestFactory := func(path, signedPath string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, adminSigner, &types.GetDiagnosticsQuery{UserId: submittingUserName, Path: signedPath})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		dbMockFactory      func() bcdb.DB
+		expectedStatusCode int
+		expectedErr        string
+		assertBody         func(t *testing.T, body []byte)
+	}{
+		{
+			name:    "gc stats",
+			request: requestFactory(constants.GetDiagnosticsGCStats, constants.GetDiagnosticsGCStats),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(true, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				stats := &types.GCStats{}
+				require.NoError(t, json.Unmarshal(body, stats))
+				require.True(t, stats.Goroutines > 0)
+				require.True(t, stats.HeapAlloc > 0)
+				require.NotEmpty(t, stats.PauseTotal)
+			},
+		},
+		{
+			name:    "open snapshots",
+			request: requestFactory(constants.GetDiagnosticsSnapshots, constants.GetDiagnosticsSnapshots),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(true, nil)
+				db.On("GetOpenSnapshots").Return(&types.OpenSnapshots{
+					Snapshots: []*types.OpenSnapshot{
+						{ID: 7, Owner: "data cursor", DBs: []string{"db1"}, Height: 3, Age: "1m0s", Idle: "30s"},
+					},
+				})
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				open := &types.OpenSnapshots{}
+				require.NoError(t, json.Unmarshal(body, open))
+				require.Len(t, open.Snapshots, 1)
+				require.Equal(t, uint64(7), open.Snapshots[0].ID)
+				require.Equal(t, "data cursor", open.Snapshots[0].Owner)
+				require.Equal(t, "30s", open.Snapshots[0].Idle)
+			},
+		},
+		{
+			name:    "goroutine dump",
+			request: requestFactory(constants.GetDiagnosticsGoroutines, constants.GetDiagnosticsGoroutines),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(true, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				require.Contains(t, string(body), "goroutine ")
+				require.Contains(t, string(body), "TestDiagnosticsRequestHandler")
+			},
+		},
+		{
+			name:    "pprof heap profile",
+			request: requestFactory(constants.GetDiagnosticsPprof+"heap?debug=1", constants.GetDiagnosticsPprof+"heap"),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(true, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				require.Contains(t, string(body), "heap profile")
+			},
+		},
+		{
+			name:    "failpoints",
+			request: requestFactory(constants.GetDiagnosticsFailpoints, constants.GetDiagnosticsFailpoints),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(true, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			assertBody: func(t *testing.T, body []byte) {
+				fps := &types.Failpoints{}
+				require.NoError(t, json.Unmarshal(body, fps))
+				require.Empty(t, fps.Enabled)
+			},
+		},
+		{
+			name: "enable a failpoint with an invalid action",
+			request: func() *http.Request {
+				path := "/debug/failpoints/" + failpoint.TrieStoreCommit + "/bogus"
+				req := requestFactory(path, path)
+				req.Method = http.MethodPut
+				return req
+			}(),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(true, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			// the error depends on whether failpoints are compiled in
+			expectedErr: failpoint.Enable(failpoint.TrieStoreCommit, "bogus").Error(),
+		},
+		{
+			name:    "user is not an admin",
+			request: requestFactory(constants.GetDiagnosticsGCStats, constants.GetDiagnosticsGCStats),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(false, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "the user [admin] has no permission to access the diagnostics endpoints",
+		},
+		{
+			name:    "admin check fails",
+			request: requestFactory(constants.GetDiagnosticsGCStats, constants.GetDiagnosticsGCStats),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				db.On("IsAdmin", submittingUserName).Return(false, errors.New("leveldb is closed"))
+				return db
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'GET /debug/gcstats' because leveldb is closed",
+		},
+		{
+			name:    "signature of another path",
+			request: requestFactory(constants.GetDiagnosticsPprof+"profile", constants.GetDiagnosticsGCStats),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(adminCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name:    "missing user header",
+			request: httptest.NewRequest(http.MethodGet, constants.GetDiagnosticsGCStats, nil),
+			dbMockFactory: func() bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "UserID is not set in the http request header",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler := NewDiagnosticsRequestHandler(tt.dbMockFactory(), logger)
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedErr != "" {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+			if tt.assertBody != nil {
+				tt.assertBody(t, rr.Body.Bytes())
+			}
+		})
+	}
+}
@@ -48,8 +48,14 @@ func NewLedgerRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handle
 	handler.router.HandleFunc(constants.GetDataProof, handler.dataProof).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}", "deleted", "{deleted:true|false}")
 	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}" gets proof for value associated with (dbname, key) in block blockId
 	handler.router.HandleFunc(constants.GetDataProof, handler.dataProof).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}")
+	// HTTP GET "/ledger/state/root/{dbname}?block={blockId}" gets the root hash of the state trie of dbname in block blockId
+	handler.router.HandleFunc(constants.GetDBStateRoot, handler.dbStateRoot).Methods(http.MethodGet).Queries("block", "{blockId:[0-9]+}")
 	// HTTP GET "/ledger/tx/receipt/{txId}" gets transaction receipt
 	handler.router.HandleFunc(constants.GetTxReceipt, handler.txReceipt).Methods(http.MethodGet)
+	// HTTP GET "/ledger/tx/receipts?txIds={txId},{txId}..." gets the receipts of many transactions
+	handler.router.HandleFunc(constants.GetTxReceipts, handler.txReceipts).Methods(http.MethodGet)
+	// HTTP GET "/ledger/tx/usage/{txId}" gets the resource usage of a transaction
+	handler.router.HandleFunc(constants.GetTxResourceUsage, handler.txResourceUsage).Methods(http.MethodGet)
 	// HTTP GET "/ledger/path?start={startId}&end={endId}" with invalid query params
 	handler.router.HandleFunc(constants.GetPath, handler.invalidPathQuery).Methods(http.MethodGet)
 	// HTTP GET "/ledger/proof/tx/{blockId}?idx={idx}" with invalid query params
@@ -62,6 +68,10 @@ func NewLedgerRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handle
 	handler.router.HandleFunc(constants.GetDataProofPrefix+"/{dbname}", handler.invalidDataProof).Methods(http.MethodGet)
 	// HTTP GET "/ledger/proof/data/{blockId}/{dbname}/{key}" with invalid query params
 	handler.router.HandleFunc(constants.GetDataProofPrefix+"/{dbname}/{key}", handler.invalidDataProof).Methods(http.MethodGet)
+	// HTTP GET "/ledger/state/root/{dbname}?block={blockId}" with invalid query params
+	handler.router.HandleFunc(constants.GetDBStateRootPrefix, handler.invalidDBStateRoot).Methods(http.MethodGet)
+	// HTTP GET "/ledger/state/root/{dbname}?block={blockId}" with invalid query params
+	handler.router.HandleFunc(constants.GetDBStateRootPrefix+"/{dbname}", handler.invalidDBStateRoot).Methods(http.MethodGet)
 
 	return handler
 }
@@ -71,7 +81,7 @@ func (p *ledgerRequestHandler) ServeHTTP(responseWriter http.ResponseWriter, req
 }
 
 func (p *ledgerRequestHandler) blockQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetBlockHeader, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetBlockHeader, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -109,7 +119,7 @@ func (p *ledgerRequestHandler) blockQuery(response http.ResponseWriter, request
 }
 
 func (p *ledgerRequestHandler) lastBlockQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetLastBlockHeader, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetLastBlockHeader, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -146,7 +156,7 @@ func (p *ledgerRequestHandler) lastBlockQuery(response http.ResponseWriter, requ
 }
 
 func (p *ledgerRequestHandler) pathQuery(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetPath, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetPath, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -178,7 +188,7 @@ func (p *ledgerRequestHandler) pathQuery(response http.ResponseWriter, request *
 }
 
 func (p *ledgerRequestHandler) txProof(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxProof, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxProof, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -210,7 +220,7 @@ func (p *ledgerRequestHandler) txProof(response http.ResponseWriter, request *ht
 }
 
 func (p *ledgerRequestHandler) dataProof(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDataProof, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDataProof, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -240,8 +250,39 @@ func (p *ledgerRequestHandler) dataProof(response http.ResponseWriter, request *
 	utils.SendHTTPResponse(response, http.StatusOK, data)
 }
 
+func (p *ledgerRequestHandler) dbStateRoot(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetDBStateRoot, p.sigVerifier, p.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetDBStateRootQuery)
+	data, err := p.db.GetDBStateRoot(query.UserId, query.BlockNumber, query.DbName)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.PermissionErr:
+			status = http.StatusForbidden
+		case *errors.NotFoundErr:
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, data)
+}
+
 func (p *ledgerRequestHandler) txReceipt(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxReceipt, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxReceipt, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -272,6 +313,70 @@ func (p *ledgerRequestHandler) txReceipt(response http.ResponseWriter, request *
 	utils.SendHTTPResponse(response, http.StatusOK, data)
 }
 
+func (p *ledgerRequestHandler) txReceipts(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxReceipts, p.sigVerifier, p.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetTxReceiptsQuery)
+
+	data, err := p.db.GetTxReceipts(query.UserId, query.TxIds)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.PermissionErr:
+			status = http.StatusForbidden
+		case *errors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, data)
+}
+
+func (p *ledgerRequestHandler) txResourceUsage(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetTxResourceUsage, p.sigVerifier, p.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetTxResourceUsageQuery)
+
+	data, err := p.db.GetTxResourceUsage(query.UserId, query.TxId)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.PermissionErr:
+			status = http.StatusForbidden
+		case *errors.NotFoundErr:
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{
+				ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error(),
+			})
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, data)
+}
+
 func (p *ledgerRequestHandler) invalidPathQuery(response http.ResponseWriter, request *http.Request) {
 	err := &types.HttpResponseErr{
 		ErrMsg: "query error - bad or missing start/end block number",
@@ -292,3 +397,10 @@ func (p *ledgerRequestHandler) invalidDataProof(response http.ResponseWriter, re
 	}
 	utils.SendHTTPResponse(response, http.StatusBadRequest, err)
 }
+
+func (p *ledgerRequestHandler) invalidDBStateRoot(response http.ResponseWriter, request *http.Request) {
+	err := &types.HttpResponseErr{
+		ErrMsg: "db state root query error - bad or missing query parameter",
+	}
+	utils.SendHTTPResponse(response, http.StatusBadRequest, err)
+}
@@ -984,6 +984,128 @@ func TestDataProofQuery(t *testing.T) {
 	}
 }
 
+func TestDBStateRootQuery(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	signedRequest := func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, constants.URLDBStateRoot(2, "bdb"), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetDBStateRootQuery{
+			UserId:      submittingUserName,
+			BlockNumber: 2,
+			DbName:      "bdb",
+		})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req, nil
+	}
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() (*http.Request, error)
+		dbMockFactory      func(response *types.GetDBStateRootResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetDBStateRootResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid get db state root request",
+			expectedResponse: &types.GetDBStateRootResponseEnvelope{
+				Response: &types.GetDBStateRootResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					RootHash: []byte("root"),
+					Path: []*types.MPTrieProofElement{
+						{
+							Hashes: [][]byte{[]byte("hash1"), []byte("root")},
+						},
+						{
+							Hashes: [][]byte{[]byte("hash2")},
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: signedRequest,
+			dbMockFactory: func(response *types.GetDBStateRootResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDBStateRoot", submittingUserName, uint64(2), "bdb").Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:             "no state of db exist",
+			expectedResponse: nil,
+			requestFactory:   signedRequest,
+			dbMockFactory: func(response *types.GetDBStateRootResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetDBStateRoot", submittingUserName, uint64(2), "bdb").Return(response, &interrors.NotFoundErr{Message: "no state of db bdb found in block 2"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET /ledger/state/root/bdb?block=2' because no state of db bdb found in block 2",
+		},
+		{
+			name:             "wrong url, block param missing",
+			expectedResponse: nil,
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, path.Join(constants.LedgerEndpoint, "state", "root", "bdb"), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, submittingUserName)
+				req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString([]byte{0}))
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetDBStateRootResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "db state root query error - bad or missing query parameter",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.requestFactory()
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewLedgerRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetDBStateRootResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
 func TestTxReceiptQuery(t *testing.T) {
 	submittingUserName := "alice"
 	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
@@ -1121,3 +1243,251 @@ func TestTxReceiptQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestTxReceiptsQuery(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	signedRequest := func(url string, txIDs []string) (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetTxReceiptsQuery{
+			UserId: submittingUserName,
+			TxIds:  txIDs,
+		})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req, nil
+	}
+
+	testCases := []struct {
+		name               string
+		requestFactory     func() (*http.Request, error)
+		dbMockFactory      func(response *types.GetTxReceiptsResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetTxReceiptsResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid get receipts request",
+			expectedResponse: &types.GetTxReceiptsResponseEnvelope{
+				Response: &types.GetTxReceiptsResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Receipts: []*types.TxReceiptWithProof{
+						{
+							TxId: "tx1",
+							Receipt: &types.TxReceipt{
+								Header: &types.BlockHeader{
+									BaseHeader: &types.BlockHeaderBase{
+										Number: 2,
+									},
+								},
+								TxIndex: 1,
+							},
+							TxProof: [][]byte{[]byte("hash1"), []byte("hash2")},
+						},
+					},
+					NotFoundTxIds: []string{"tx2"},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			requestFactory: func() (*http.Request, error) {
+				return signedRequest(constants.URLForGetTxReceipts([]string{"tx1", "tx2"}), []string{"tx1", "tx2"})
+			},
+			dbMockFactory: func(response *types.GetTxReceiptsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxReceipts", submittingUserName, []string{"tx1", "tx2"}).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "no transaction IDs",
+			requestFactory: func() (*http.Request, error) {
+				return signedRequest(constants.LedgerEndpoint+"tx/receipts", nil)
+			},
+			dbMockFactory: func(response *types.GetTxReceiptsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the IDs of the transactions must be set",
+		},
+		{
+			name: "too many transaction IDs",
+			requestFactory: func() (*http.Request, error) {
+				return signedRequest(constants.URLForGetTxReceipts([]string{"tx1", "tx2"}), []string{"tx1", "tx2"})
+			},
+			dbMockFactory: func(response *types.GetTxReceiptsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxReceipts", submittingUserName, []string{"tx1", "tx2"}).Return(nil, &interrors.BadRequestError{ErrMsg: "too many transactions"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "error while processing 'GET /ledger/tx/receipts?txIds=tx1%2Ctx2' because too many transactions",
+		},
+		{
+			name: "no ledger access",
+			requestFactory: func() (*http.Request, error) {
+				return signedRequest(constants.URLForGetTxReceipts([]string{"tx1"}), []string{"tx1"})
+			},
+			dbMockFactory: func(response *types.GetTxReceiptsResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxReceipts", submittingUserName, []string{"tx1"}).Return(nil, &interrors.PermissionErr{ErrMsg: "no access"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /ledger/tx/receipts?txIds=tx1' because no access",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.requestFactory()
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewLedgerRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetTxReceiptsResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestTxResourceUsageQuery(t *testing.T) {
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	requestFactory := func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, constants.URLForGetTxResourceUsage("tx1"), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		sig := testutils.SignatureFromQuery(t, aliceSigner, &types.GetTxResourceUsageQuery{
+			UserId: submittingUserName,
+			TxId:   "tx1",
+		})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req, nil
+	}
+
+	testCases := []struct {
+		name               string
+		dbMockFactory      func(response *types.GetTxResourceUsageResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetTxResourceUsageResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid get usage request",
+			expectedResponse: &types.GetTxResourceUsageResponseEnvelope{
+				Response: &types.GetTxResourceUsageResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Usage: &types.TxResourceUsage{
+						TxId:     "tx1",
+						BlockNum: 2,
+						UserIds:  []string{"alice"},
+						DbUsages: []*types.DBResourceUsage{
+							{DbName: "db1", BytesWritten: 10, KeysWritten: 1, IndexEntries: 2},
+						},
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			dbMockFactory: func(response *types.GetTxResourceUsageResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxResourceUsage", submittingUserName, "tx1").Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "no permission to access the ledger",
+			dbMockFactory: func(response *types.GetTxResourceUsageResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxResourceUsage", submittingUserName, "tx1").Return(response, &interrors.PermissionErr{ErrMsg: "user alice has no permission to access the ledger"})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET /ledger/tx/usage/tx1' because user alice has no permission to access the ledger",
+		},
+		{
+			name: "usage not exist",
+			dbMockFactory: func(response *types.GetTxResourceUsageResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxResourceUsage", submittingUserName, "tx1").Return(response, &interrors.NotFoundErr{Message: "resource usage of TxID not found: tx1"})
+				return db
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedErr:        "error while processing 'GET /ledger/tx/usage/tx1' because resource usage of TxID not found: tx1",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := requestFactory()
+			require.NoError(t, err)
+			require.NotNil(t, req)
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			rr := httptest.NewRecorder()
+			handler := NewLedgerRequestHandler(db, logger)
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetTxResourceUsageResponseEnvelope{}
+				err = json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
@@ -64,6 +65,7 @@ func NewProvenanceRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Ha
 	handler.router.HandleFunc(constants.GetDataWrittenBy, handler.getDataWrittenByUser).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.GetDataDeletedBy, handler.getDataDeletedByUser).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.GetTxIDsSubmittedBy, handler.getTxIDsSubmittedBy).Methods(http.MethodGet)
+	handler.router.HandleFunc(constants.GetTxsByAnnotation, handler.getTxsByAnnotation).Methods(http.MethodGet)
 	handler.router.HandleFunc(constants.GetMostRecentUserOrNode, handler.getMostRecentUserOrNode).Methods(http.MethodGet).Queries(version...)
 
 	return handler
@@ -74,7 +76,7 @@ func (p *provenanceRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 }
 
 func (p *provenanceRequestHandler) getHistoricalData(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetHistoricalData, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetHistoricalData, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -85,25 +87,26 @@ func (p *provenanceRequestHandler) getHistoricalData(w http.ResponseWriter, r *h
 
 	switch {
 	case query.OnlyDeletes:
-		response, err = p.db.GetDeletedValues(query.DbName, query.Key)
+		response, err = p.db.GetDeletedValues(query.UserId, query.DbName, query.Key)
 	case query.Version == nil:
-		response, err = p.db.GetValues(query.DbName, query.Key)
+		response, err = p.db.GetValues(query.UserId, query.DbName, query.Key, query.Offset, query.Limit)
 	case query.Direction == "" && query.MostRecent:
-		response, err = p.db.GetMostRecentValueAtOrBelow(query.DbName, query.Key, query.Version)
+		response, err = p.db.GetMostRecentValueAtOrBelow(query.UserId, query.DbName, query.Key, query.Version)
 	case query.Direction == "":
-		response, err = p.db.GetValueAt(query.DbName, query.Key, query.Version)
+		response, err = p.db.GetValueAt(query.UserId, query.DbName, query.Key, query.Version)
 	case query.Direction == "previous":
-		response, err = p.db.GetPreviousValues(query.DbName, query.Key, query.Version)
+		response, err = p.db.GetPreviousValues(query.UserId, query.DbName, query.Key, query.Version, query.Limit)
 	case query.Direction == "next":
-		response, err = p.db.GetNextValues(query.DbName, query.Key, query.Version)
+		response, err = p.db.GetNextValues(query.UserId, query.DbName, query.Key, query.Version, query.Limit)
 	default:
 		utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{
 			ErrMsg: "direction must be either [previous] or [next]",
 		})
+		return
 	}
 
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
@@ -111,15 +114,15 @@ func (p *provenanceRequestHandler) getHistoricalData(w http.ResponseWriter, r *h
 }
 
 func (p *provenanceRequestHandler) getDataReaders(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataReaders, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataReaders, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
 	query := payload.(*types.GetDataReadersQuery)
 
-	response, err := p.db.GetReaders(query.DbName, query.Key)
+	response, err := p.db.GetReaders(query.UserId, query.DbName, query.Key)
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
@@ -127,15 +130,15 @@ func (p *provenanceRequestHandler) getDataReaders(w http.ResponseWriter, r *http
 }
 
 func (p *provenanceRequestHandler) getDataWriters(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataWriters, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataWriters, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
 	query := payload.(*types.GetDataWritersQuery)
 
-	response, err := p.db.GetWriters(query.DbName, query.Key)
+	response, err := p.db.GetWriters(query.UserId, query.DbName, query.Key)
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
@@ -143,15 +146,15 @@ func (p *provenanceRequestHandler) getDataWriters(w http.ResponseWriter, r *http
 }
 
 func (p *provenanceRequestHandler) getDataReadByUser(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataReadBy, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataReadBy, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
 	query := payload.(*types.GetDataReadByQuery)
 
-	response, err := p.db.GetValuesReadByUser(query.TargetUserId)
+	response, err := p.db.GetValuesReadByUser(query.UserId, query.TargetUserId)
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
@@ -159,15 +162,15 @@ func (p *provenanceRequestHandler) getDataReadByUser(w http.ResponseWriter, r *h
 }
 
 func (p *provenanceRequestHandler) getDataWrittenByUser(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataWrittenBy, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataWrittenBy, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
 	query := payload.(*types.GetDataWrittenByQuery)
 
-	response, err := p.db.GetValuesWrittenByUser(query.TargetUserId)
+	response, err := p.db.GetValuesWrittenByUser(query.UserId, query.TargetUserId)
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
@@ -175,15 +178,15 @@ func (p *provenanceRequestHandler) getDataWrittenByUser(w http.ResponseWriter, r
 }
 
 func (p *provenanceRequestHandler) getDataDeletedByUser(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataDeletedBy, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetDataDeletedBy, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
 	query := payload.(*types.GetDataDeletedByQuery)
 
-	response, err := p.db.GetValuesDeletedByUser(query.TargetUserId)
+	response, err := p.db.GetValuesDeletedByUser(query.UserId, query.TargetUserId)
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
@@ -191,25 +194,46 @@ func (p *provenanceRequestHandler) getDataDeletedByUser(w http.ResponseWriter, r
 }
 
 func (p *provenanceRequestHandler) getTxIDsSubmittedBy(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetTxIDsSubmittedBy, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetTxIDsSubmittedBy, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
 	query := payload.(*types.GetTxIDsSubmittedByQuery)
 
-	response, err := p.db.GetTxIDsSubmittedByUser(query.TargetUserId)
+	response, err := p.db.GetTxIDsSubmittedByUser(query.UserId, query.TargetUserId)
+	if err != nil {
+		processQueryError(w, r, err)
+		return
+	}
+
+	utils.SendHTTPResponse(w, http.StatusOK, response)
+}
+
+func (p *provenanceRequestHandler) getTxsByAnnotation(w http.ResponseWriter, r *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetTxsByAnnotation, p.sigVerifier, p.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetTxsByAnnotationQuery)
+
+	response, err := p.db.GetTxsByAnnotation(query.UserId, query.AnnotationKey, query.AnnotationValue, query.Offset, query.Limit)
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
 	utils.SendHTTPResponse(w, http.StatusOK, response)
 }
 
-func processInternalError(w http.ResponseWriter, r *http.Request, err error) {
+func processQueryError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	if _, ok := err.(*errors.PermissionErr); ok {
+		status = http.StatusForbidden
+	}
+
 	utils.SendHTTPResponse(
 		w,
-		http.StatusInternalServerError,
+		status,
 		&types.HttpResponseErr{
 			ErrMsg: "error while processing '" + r.Method + " " + r.URL.String() + "' because " + err.Error(),
 		},
@@ -217,7 +241,7 @@ func processInternalError(w http.ResponseWriter, r *http.Request, err error) {
 }
 
 func (p *provenanceRequestHandler) getMostRecentUserOrNode(w http.ResponseWriter, r *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetMostRecentUserOrNode, p.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(w, r, constants.GetMostRecentUserOrNode, p.sigVerifier, p.db)
 	if respondedErr {
 		return
 	}
@@ -230,9 +254,9 @@ func (p *provenanceRequestHandler) getMostRecentUserOrNode(w http.ResponseWriter
 		dbName = worldstate.UsersDBName
 	}
 
-	response, err := p.db.GetMostRecentValueAtOrBelow(dbName, query.Id, query.Version)
+	response, err := p.db.GetMostRecentValueAtOrBelow(query.UserId, dbName, query.Id, query.Version)
 	if err != nil {
-		processInternalError(w, r, err)
+		processQueryError(w, r, err)
 		return
 	}
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger-labs/orion-server/internal/bcdb"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
+	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
@@ -72,7 +73,7 @@ func TestGetHistoricalData(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValues", dbName, key).Return(genericResponse, nil)
+				db.On("GetValues", submittingUserName, dbName, key, uint64(0), uint64(0)).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -95,7 +96,7 @@ func TestGetHistoricalData(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetDeletedValues", dbName, key).Return(genericResponse, nil)
+				db.On("GetDeletedValues", submittingUserName, dbName, key).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -118,7 +119,7 @@ func TestGetHistoricalData(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValueAt", dbName, key, version).Return(response, nil)
+				db.On("GetValueAt", submittingUserName, dbName, key, version).Return(response, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -142,7 +143,7 @@ func TestGetHistoricalData(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetMostRecentValueAtOrBelow", dbName, key, version).Return(response, nil)
+				db.On("GetMostRecentValueAtOrBelow", submittingUserName, dbName, key, version).Return(response, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -166,7 +167,7 @@ func TestGetHistoricalData(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetPreviousValues", dbName, key, version).Return(response, nil)
+				db.On("GetPreviousValues", submittingUserName, dbName, key, version, uint64(0)).Return(response, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -190,12 +191,102 @@ func TestGetHistoricalData(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetNextValues", dbName, key, version).Return(response, nil)
+				db.On("GetNextValues", submittingUserName, dbName, key, version, uint64(0)).Return(response, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedResponse:   genericResponse,
 		},
+		{
+			name: "valid: GetValues with offset and limit",
+			request: constructRequestForTestCase(
+				t,
+				constants.URLForGetHistoricalDataPage(dbName, key, 20, 10),
+				&types.GetHistoricalDataQuery{
+					UserId: submittingUserName,
+					DbName: dbName,
+					Key:    key,
+					Offset: 20,
+					Limit:  10,
+				},
+				aliceSigner,
+				submittingUserName,
+			),
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetValues", submittingUserName, dbName, key, uint64(20), uint64(10)).Return(genericResponse, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   genericResponse,
+		},
+		{
+			name: "valid: GetPreviousValues with limit",
+			request: constructRequestForTestCase(
+				t,
+				constants.URLForGetPreviousHistoricalDataPage(dbName, key, version, 5),
+				&types.GetHistoricalDataQuery{
+					UserId:    submittingUserName,
+					DbName:    dbName,
+					Key:       key,
+					Version:   version,
+					Direction: "previous",
+					Limit:     5,
+				},
+				aliceSigner,
+				submittingUserName,
+			),
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetPreviousValues", submittingUserName, dbName, key, version, uint64(5)).Return(response, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   genericResponse,
+		},
+		{
+			name: "invalid: limit is not a number",
+			request: constructRequestForTestCase(
+				t,
+				constants.URLForGetHistoricalData(dbName, key)+"?limit=ten",
+				&types.GetHistoricalDataQuery{
+					UserId: submittingUserName,
+					DbName: dbName,
+					Key:    key,
+				},
+				aliceSigner,
+				submittingUserName,
+			),
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the limit parameter must be a non-negative integer \"ten\"",
+		},
+		{
+			name: "invalid: offset is set with a version",
+			request: constructRequestForTestCase(
+				t,
+				constants.URLForGetNextHistoricalData(dbName, key, version)+"&offset=3",
+				&types.GetHistoricalDataQuery{
+					UserId:    submittingUserName,
+					DbName:    dbName,
+					Key:       key,
+					Version:   version,
+					Direction: "next",
+					Offset:    3,
+				},
+				aliceSigner,
+				submittingUserName,
+			),
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the offset parameter can be set only when fetching all values of a key",
+		},
 		{
 			name: "internal server error",
 			request: constructRequestForTestCase(
@@ -212,12 +303,36 @@ func TestGetHistoricalData(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValues", dbName, key).Return(nil, errors.New("error in provenance db"))
+				db.On("GetValues", submittingUserName, dbName, key, uint64(0), uint64(0)).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedErr:        "error while processing 'GET " + constants.URLForGetHistoricalData(dbName, key) + "' because error in provenance db",
 		},
+		{
+			name: "permission error",
+			request: constructRequestForTestCase(
+				t,
+				constants.URLForGetHistoricalData(dbName, key),
+				&types.GetHistoricalDataQuery{
+					UserId: submittingUserName,
+					DbName: dbName,
+					Key:    key,
+				},
+				aliceSigner,
+				submittingUserName,
+			),
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetValues", submittingUserName, dbName, key, uint64(0), uint64(0)).Return(nil, &interrors.PermissionErr{
+					ErrMsg: "the user [alice] has no permission to read from database [db1]",
+				})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET " + constants.URLForGetHistoricalData(dbName, key) + "' because the user [alice] has no permission to read from database [db1]",
+		},
 		constructTestCaseForSigVerificationFailure(t, constants.URLForGetHistoricalData(dbName, key), submittingUserName),
 	}
 
@@ -272,7 +387,7 @@ func TestGetDataReaders(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetReaders", dbName, key).Return(genericResponse, nil)
+				db.On("GetReaders", submittingUserName, dbName, key).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -284,7 +399,7 @@ func TestGetDataReaders(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetReaders", dbName, key).Return(nil, errors.New("error in provenance db"))
+				db.On("GetReaders", submittingUserName, dbName, key).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -340,7 +455,7 @@ func TestGetDataWriters(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetWriters", dbName, key).Return(genericResponse, nil)
+				db.On("GetWriters", submittingUserName, dbName, key).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -352,7 +467,7 @@ func TestGetDataWriters(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetWriters", dbName, key).Return(nil, errors.New("error in provenance db"))
+				db.On("GetWriters", submittingUserName, dbName, key).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -409,7 +524,7 @@ func TestGetDataReadBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValuesReadByUser", targetUserID).Return(genericResponse, nil)
+				db.On("GetValuesReadByUser", submittingUserName, targetUserID).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -421,12 +536,26 @@ func TestGetDataReadBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValuesReadByUser", targetUserID).Return(nil, errors.New("error in provenance db"))
+				db.On("GetValuesReadByUser", submittingUserName, targetUserID).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedErr:        "error while processing 'GET " + url + "' because error in provenance db",
 		},
+		{
+			name:    "permission error",
+			request: req,
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetValuesReadByUser", submittingUserName, targetUserID).Return(nil, &interrors.PermissionErr{
+					ErrMsg: "the user [alice] has no permission to read the provenance of user [" + targetUserID + "]",
+				})
+				return db
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "error while processing 'GET " + url + "' because the user [alice] has no permission to read the provenance of user [" + targetUserID + "]",
+		},
 		constructTestCaseForSigVerificationFailure(t, url, submittingUserName),
 	}
 
@@ -478,7 +607,7 @@ func TestGetDataWrittenBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValuesWrittenByUser", targetUserID).Return(genericResponse, nil)
+				db.On("GetValuesWrittenByUser", submittingUserName, targetUserID).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -490,7 +619,7 @@ func TestGetDataWrittenBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValuesWrittenByUser", targetUserID).Return(nil, errors.New("error in provenance db"))
+				db.On("GetValuesWrittenByUser", submittingUserName, targetUserID).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -547,7 +676,7 @@ func TestGetDataDeletedBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValuesDeletedByUser", targetUserID).Return(genericResponse, nil)
+				db.On("GetValuesDeletedByUser", submittingUserName, targetUserID).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -559,7 +688,7 @@ func TestGetDataDeletedBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetValuesDeletedByUser", targetUserID).Return(nil, errors.New("error in provenance db"))
+				db.On("GetValuesDeletedByUser", submittingUserName, targetUserID).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -611,7 +740,7 @@ func TestGetTxIDsSubmittedBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetTxIDsSubmittedByUser", targetUserID).Return(genericResponse, nil)
+				db.On("GetTxIDsSubmittedByUser", submittingUserName, targetUserID).Return(genericResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -623,7 +752,7 @@ func TestGetTxIDsSubmittedBy(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetTxIDsSubmittedByUser", targetUserID).Return(nil, errors.New("error in provenance db"))
+				db.On("GetTxIDsSubmittedByUser", submittingUserName, targetUserID).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -639,6 +768,124 @@ func TestGetTxIDsSubmittedBy(t *testing.T) {
 	}
 }
 
+func TestGetTxsByAnnotation(t *testing.T) {
+	t.Parallel()
+
+	submittingUserName := "alice"
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	genericResponse := &types.GetTxsByAnnotationResponseEnvelope{
+		Response: &types.GetTxsByAnnotationResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "testNodeID",
+			},
+			Txs: []*types.AnnotatedTx{
+				{
+					TxId:        "tx1",
+					BlockNumber: 2,
+					TxIndex:     1,
+					Timestamp:   1001,
+				},
+			},
+		},
+	}
+
+	url := constants.URLForGetTxsByAnnotation("correlation id", "c1&c2")
+	req := constructRequestForTestCase(
+		t,
+		url,
+		&types.GetTxsByAnnotationQuery{
+			UserId:          submittingUserName,
+			AnnotationKey:   "correlation id",
+			AnnotationValue: "c1&c2",
+		},
+		aliceSigner,
+		submittingUserName,
+	)
+
+	pageReq := constructRequestForTestCase(
+		t,
+		constants.URLForGetTxsByAnnotationPage("correlation id", "c1&c2", 10, 5),
+		&types.GetTxsByAnnotationQuery{
+			UserId:          submittingUserName,
+			AnnotationKey:   "correlation id",
+			AnnotationValue: "c1&c2",
+			Offset:          10,
+			Limit:           5,
+		},
+		aliceSigner,
+		submittingUserName,
+	)
+
+	noKeyURL := constants.URLForGetTxsByAnnotation("", "c1")
+	noKeyReq := constructRequestForTestCase(
+		t,
+		noKeyURL,
+		&types.GetTxsByAnnotationQuery{
+			UserId:          submittingUserName,
+			AnnotationValue: "c1",
+		},
+		aliceSigner,
+		submittingUserName,
+	)
+
+	testCases := []testCase{
+		{
+			name:    "valid",
+			request: req,
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxsByAnnotation", submittingUserName, "correlation id", "c1&c2", uint64(0), uint64(0)).Return(genericResponse, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   genericResponse,
+		},
+		{
+			name:    "valid page",
+			request: pageReq,
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxsByAnnotation", submittingUserName, "correlation id", "c1&c2", uint64(10), uint64(5)).Return(genericResponse, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   genericResponse,
+		},
+		{
+			name:    "annotation key is not set",
+			request: noKeyReq,
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the annotation key must be set",
+		},
+		{
+			name:    "internal server error",
+			request: req,
+			dbMockFactory: func(response interface{}) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetTxsByAnnotation", submittingUserName, "correlation id", "c1&c2", uint64(0), uint64(0)).Return(nil, errors.New("error in provenance db"))
+				return db
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'GET " + url + "' because error in provenance db",
+		},
+		constructTestCaseForSigVerificationFailure(t, url, submittingUserName),
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assertTestCase(t, tt, &types.GetTxsByAnnotationResponseEnvelope{})
+		})
+	}
+}
+
 func TestGetMostRecentNodeOrUser(t *testing.T) {
 	t.Parallel()
 
@@ -700,7 +947,7 @@ func TestGetMostRecentNodeOrUser(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetMostRecentValueAtOrBelow", worldstate.ConfigDBName, "node1", sampleVer).Return(nodeResponse, nil)
+				db.On("GetMostRecentValueAtOrBelow", submittingUserName, worldstate.ConfigDBName, "node1", sampleVer).Return(nodeResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -723,7 +970,7 @@ func TestGetMostRecentNodeOrUser(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetMostRecentValueAtOrBelow", worldstate.UsersDBName, "user1", sampleVer).Return(userResponse, nil)
+				db.On("GetMostRecentValueAtOrBelow", submittingUserName, worldstate.UsersDBName, "user1", sampleVer).Return(userResponse, nil)
 				return db
 			},
 			expectedStatusCode: http.StatusOK,
@@ -746,7 +993,7 @@ func TestGetMostRecentNodeOrUser(t *testing.T) {
 			dbMockFactory: func(response interface{}) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
-				db.On("GetMostRecentValueAtOrBelow", worldstate.UsersDBName, "user1", sampleVer).Return(nil, errors.New("error in provenance db"))
+				db.On("GetMostRecentValueAtOrBelow", submittingUserName, worldstate.UsersDBName, "user1", sampleVer).Return(nil, errors.New("error in provenance db"))
 				return db
 			},
 			expectedStatusCode: http.StatusInternalServerError,
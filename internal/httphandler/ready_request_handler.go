@@ -0,0 +1,45 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package httphandler
+
+import (
+	"net/http"
+
+	"github.com/hyperledger-labs/orion-server/internal/bcdb"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// readyRequestHandler serves the readiness of the node. The requests are not authenticated, such that the readiness
+// probes of an orchestrator can reach it.
+type readyRequestHandler struct {
+	db     bcdb.DB
+	logger *logger.SugarLogger
+}
+
+// NewReadyRequestHandler returns the handler of the readiness endpoint, which answers 200 while the node commits
+// blocks, and 503, along with the reason, once it halted the commit of blocks, e.g., on a quarantined block or on a
+// failure of the state trie.
+func NewReadyRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handler {
+	return &readyRequestHandler{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (h *readyRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.SendHTTPResponse(w, http.StatusMethodNotAllowed, &types.HttpResponseErr{ErrMsg: "the method " + r.Method + " is not allowed"})
+		return
+	}
+
+	if err := h.db.Ready(); err != nil {
+		h.logger.Debugf("the node is not ready: %s", err)
+		utils.SendHTTPResponse(w, http.StatusServiceUnavailable, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
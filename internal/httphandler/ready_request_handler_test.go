@@ -0,0 +1,49 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
+	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyRequestHandler(t *testing.T) {
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	t.Run("ready", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("Ready").Return(nil)
+
+		rr := httptest.NewRecorder()
+		NewReadyRequestHandler(db, logger).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, constants.ReadyEndpoint, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("the commit of blocks is halted", func(t *testing.T) {
+		db := &mocks.DB{}
+		db.On("Ready").Return(&interrors.UnavailableError{ErrMsg: "the node halted the commit of blocks until it restarts: trie store I/O error"})
+
+		rr := httptest.NewRecorder()
+		NewReadyRequestHandler(db, logger).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, constants.ReadyEndpoint, nil))
+		require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		respErr := &types.HttpResponseErr{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+		require.Equal(t, "the node halted the commit of blocks until it restarts: trie store I/O error", respErr.ErrMsg)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		NewReadyRequestHandler(&mocks.DB{}, logger).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, constants.ReadyEndpoint, nil))
+		require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
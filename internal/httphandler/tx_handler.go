@@ -27,8 +27,12 @@ func (t *txHandler) handleTransaction(w http.ResponseWriter, request *http.Reque
 			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
 		case *internalerror.DuplicateTxIDError:
 			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		case *internalerror.TxTooLargeError:
+			utils.SendHTTPResponse(w, http.StatusRequestEntityTooLarge, &types.HttpResponseErr{ErrMsg: err.Error()})
 		case *internalerror.TimeoutErr:
 			utils.SendHTTPResponse(w, http.StatusAccepted, &types.HttpResponseErr{ErrMsg: "Transaction processing timeout"})
+		case *internalerror.UnavailableError:
+			utils.SendHTTPResponse(w, http.StatusServiceUnavailable, &types.HttpResponseErr{ErrMsg: err.Error()})
 		case *internalerror.NotLeaderError:
 			leaderErr := err.(*internalerror.NotLeaderError)
 			if leaderErr.GetLeaderID() == 0 {
@@ -43,3 +47,10 @@ func (t *txHandler) handleTransaction(w http.ResponseWriter, request *http.Reque
 	}
 	utils.SendHTTPResponse(w, http.StatusOK, resp)
 }
+
+// rejectTransaction responds to a transaction that is rejected before it is submitted, and records the rejection in
+// the dead-letter queue of the node.
+func (t *txHandler) rejectTransaction(w http.ResponseWriter, status int, txID, userID string, category types.RejectedTx_Category, errMsg string) {
+	t.db.RecordRejectedTx(txID, userID, category, errMsg)
+	utils.SendHTTPResponse(w, status, &types.HttpResponseErr{ErrMsg: errMsg})
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb"
@@ -39,10 +41,14 @@ func NewUsersRequestHandler(db bcdb.DB, logger *logger.SugarLogger) http.Handler
 		logger: logger,
 	}
 
+	// HTTP GET "/user/list" list the users readable by the querier
+	handler.router.HandleFunc(constants.GetUsers, handler.getUsers).Methods(http.MethodGet)
 	// HTTP GET "/user/{userid}" get user record with given userID
 	handler.router.HandleFunc(constants.GetUser, handler.getUser).Methods(http.MethodGet)
 	// HTTP POST "user/tx" submit user creation transaction
 	handler.router.HandleFunc(constants.PostUserTx, handler.userTransaction).Methods(http.MethodPost)
+	// HTTP POST "/user/session" log in and receive a session token
+	handler.router.HandleFunc(constants.PostUserSession, handler.login).Methods(http.MethodPost)
 
 	return handler
 }
@@ -52,7 +58,7 @@ func (u *usersRequestHandler) ServeHTTP(responseWriter http.ResponseWriter, requ
 }
 
 func (u *usersRequestHandler) getUser(response http.ResponseWriter, request *http.Request) {
-	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetUser, u.sigVerifier)
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetUser, u.sigVerifier, u.db)
 	if respondedErr {
 		return
 	}
@@ -81,10 +87,71 @@ func (u *usersRequestHandler) getUser(response http.ResponseWriter, request *htt
 	utils.SendHTTPResponse(response, http.StatusOK, user)
 }
 
+func (u *usersRequestHandler) getUsers(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.GetUsers, u.sigVerifier, u.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.GetUsersQuery)
+
+	users, err := u.db.GetUsers(query.UserId, query.Prefix, query.Offset, query.Limit)
+	if err != nil {
+		utils.SendHTTPResponse(
+			response,
+			http.StatusInternalServerError,
+			&types.HttpResponseErr{ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error()},
+		)
+		u.logger.Errorf("failed to process request, due to %s", err.Error())
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, users)
+}
+
+func (u *usersRequestHandler) login(response http.ResponseWriter, request *http.Request) {
+	payload, respondedErr := extractVerifiedQueryPayload(response, request, constants.PostUserSession, u.sigVerifier, u.db)
+	if respondedErr {
+		return
+	}
+	query := payload.(*types.SessionLoginQuery)
+
+	var ttl time.Duration
+	if query.Ttl != "" {
+		var err error
+		if ttl, err = time.ParseDuration(query.Ttl); err != nil || ttl <= 0 {
+			utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{
+				ErrMsg: "the TTL of a session token must be a positive duration " + strconv.Quote(query.Ttl),
+			})
+			return
+		}
+	}
+
+	token, err := u.db.CreateSessionToken(query.UserId, query.Timestamp, ttl)
+	if err != nil {
+		var status int
+
+		switch err.(type) {
+		case *errors.BadRequestError:
+			status = http.StatusBadRequest
+		default:
+			status = http.StatusInternalServerError
+		}
+
+		utils.SendHTTPResponse(
+			response,
+			status,
+			&types.HttpResponseErr{ErrMsg: "error while processing '" + request.Method + " " + request.URL.String() + "' because " + err.Error()},
+		)
+		return
+	}
+
+	utils.SendHTTPResponse(response, http.StatusOK, token)
+}
+
 func (u *usersRequestHandler) userTransaction(response http.ResponseWriter, request *http.Request) {
 	timeout, err := validateAndParseTxPostHeader(&request.Header)
 	if err != nil {
-		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		u.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED, err.Error())
 		return
 	}
 
@@ -94,33 +161,41 @@ func (u *usersRequestHandler) userTransaction(response http.ResponseWriter, requ
 	txEnv := &types.UserAdministrationTxEnvelope{}
 	if err := d.Decode(txEnv); err != nil {
 		u.logger.Errorf(err.Error())
-		utils.SendHTTPResponse(response, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+		u.txHandler.rejectTransaction(response, decodeErrorStatus(err), "", "", types.RejectedTx_MALFORMED, err.Error())
 		return
 	}
 
 	if txEnv.Payload == nil {
 		u.logger.Errorf(fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload))
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload)})
+		u.txHandler.rejectTransaction(response, http.StatusBadRequest, "", "", types.RejectedTx_MALFORMED,
+			fmt.Sprintf("missing transaction envelope payload (%T)", txEnv.Payload))
 		return
 	}
 
+	txID := txEnv.Payload.TxId
 	if txEnv.Payload.UserId == "" {
 		u.logger.Errorf(fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload))
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload)})
+		u.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, "", types.RejectedTx_MALFORMED,
+			fmt.Sprintf("missing UserID in transaction envelope payload (%T)", txEnv.Payload))
 		return
 	}
 
 	if len(txEnv.Signature) == 0 {
 		u.logger.Errorf(fmt.Sprintf("missing Signature in transaction envelope payload (%T)", txEnv.Payload))
-		utils.SendHTTPResponse(response, http.StatusBadRequest,
-			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("missing Signature in transaction envelope payload (%T)", txEnv.Payload)})
+		u.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, txEnv.Payload.UserId, types.RejectedTx_UNAUTHENTICATED,
+			fmt.Sprintf("missing Signature in transaction envelope payload (%T)", txEnv.Payload))
+		return
+	}
+
+	if txEnv.Payload.CertificateRenewal != nil && len(txEnv.RenewalSignature) == 0 {
+		u.logger.Errorf(fmt.Sprintf("missing RenewalSignature in transaction envelope payload (%T)", txEnv.Payload))
+		u.txHandler.rejectTransaction(response, http.StatusBadRequest, txID, txEnv.Payload.UserId, types.RejectedTx_UNAUTHENTICATED,
+			fmt.Sprintf("missing RenewalSignature in transaction envelope payload (%T)", txEnv.Payload))
 		return
 	}
 
 	if err, code := VerifyRequestSignature(u.sigVerifier, txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload); err != nil {
-		utils.SendHTTPResponse(response, code, &types.HttpResponseErr{ErrMsg: err.Error()})
+		u.txHandler.rejectTransaction(response, code, txID, txEnv.Payload.UserId, types.RejectedTx_UNAUTHENTICATED, err.Error())
 		return
 	}
 
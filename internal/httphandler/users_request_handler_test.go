@@ -10,13 +10,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/hyperledger-labs/orion-server/internal/bcdb"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/mock"
@@ -180,6 +183,74 @@ func TestUsersRequestHandler_GetUser(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedErr:        "error while processing 'GET /user/targetUserID' because failed to retrieve user record",
 		},
+		{
+			name: "valid get user data request with a session token",
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetUser(targetUserID), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.SessionTokenHeader, "alice-token")
+
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetUserResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("VerifySessionToken", "alice-token").Return(submittingUserName, nil)
+				db.On("GetUser", submittingUserName, targetUserID).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetUserResponseEnvelope{
+				Response: &types.GetUserResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "invalid get user request, session token of another user",
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetUser(targetUserID), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.UserHeader, "bob")
+				req.Header.Set(constants.SessionTokenHeader, "alice-token")
+
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetUserResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("VerifySessionToken", "alice-token").Return(submittingUserName, nil)
+				return db
+			},
+			expectedResponse:   nil,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "the session token is not issued to the user [bob]",
+		},
+		{
+			name: "invalid get user request, expired session token",
+			requestFactory: func() (*http.Request, error) {
+				req, err := http.NewRequest(http.MethodGet, constants.URLForGetUser(targetUserID), nil)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set(constants.SessionTokenHeader, "alice-token")
+
+				return req, nil
+			},
+			dbMockFactory: func(response *types.GetUserResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("VerifySessionToken", "alice-token").Return("", &interrors.PermissionErr{ErrMsg: "the session token of the user [alice] has expired"})
+				return db
+			},
+			expectedResponse:   nil,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "the session token of the user [alice] has expired",
+		},
 	}
 
 	logger, err := createLogger("debug")
@@ -217,6 +288,385 @@ func TestUsersRequestHandler_GetUser(t *testing.T) {
 	}
 }
 
+func TestUsersRequestHandler_GetUserWithNonce(t *testing.T) {
+	submittingUserName := "alice"
+	targetUserID := "targetUserID"
+	timestamp := time.Now().UnixNano()
+
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	query := &types.GetUserQuery{UserId: submittingUserName, TargetUserId: targetUserID}
+
+	newRequest := func(nonce string, timestamp string, sig []byte) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, constants.URLForGetUser(targetUserID), nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, submittingUserName)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		if nonce != "" {
+			req.Header.Set(constants.NonceHeader, nonce)
+		}
+		if timestamp != "" {
+			req.Header.Set(constants.TimestampHeader, timestamp)
+		}
+		return req
+	}
+	guardedSig := func(nonce string) []byte {
+		sig, err := cryptoservice.SignReplayProtectedQuery(aliceSigner, query, nonce, timestamp)
+		require.NoError(t, err)
+		return sig
+	}
+	timestampStr := strconv.FormatInt(timestamp, 10)
+
+	testCases := []struct {
+		name               string
+		required           bool
+		requestFactory     func() *http.Request
+		dbMockFactory      func() *mocks.DB
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name: "valid get user request with a nonce",
+			requestFactory: func() *http.Request {
+				return newRequest("n1", timestampStr, guardedSig("n1"))
+			},
+			dbMockFactory: func() *mocks.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("CheckQueryNonce", submittingUserName, "n1", timestamp).Return(nil)
+				db.On("GetUser", submittingUserName, targetUserID).Return(&types.GetUserResponseEnvelope{}, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "invalid get user request, replayed nonce",
+			requestFactory: func() *http.Request {
+				return newRequest("n1", timestampStr, guardedSig("n1"))
+			},
+			dbMockFactory: func() *mocks.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("CheckQueryNonce", submittingUserName, "n1", timestamp).Return(
+					&interrors.PermissionErr{ErrMsg: "the nonce [n1] was already used by the user [alice]"})
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "the nonce [n1] was already used by the user [alice]",
+		},
+		{
+			name: "invalid get user request, too many nonces",
+			requestFactory: func() *http.Request {
+				return newRequest("n1", timestampStr, guardedSig("n1"))
+			},
+			dbMockFactory: func() *mocks.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("CheckQueryNonce", submittingUserName, "n1", timestamp).Return(
+					&interrors.ResourceExhaustedError{ErrMsg: "the number of recent query nonces reached the limit of 10"})
+				return db
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedErr:        "the number of recent query nonces reached the limit of 10",
+		},
+		{
+			name: "invalid get user request, the nonce is not signed",
+			requestFactory: func() *http.Request {
+				return newRequest("n2", timestampStr, guardedSig("n1"))
+			},
+			dbMockFactory: func() *mocks.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "signature verification failed",
+		},
+		{
+			name: "invalid get user request, nonce without a timestamp",
+			requestFactory: func() *http.Request {
+				return newRequest("n1", "", guardedSig("n1"))
+			},
+			dbMockFactory: func() *mocks.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "Nonce and Timestamp must be set together in the http request header",
+		},
+		{
+			name: "invalid get user request, timestamp is not an integer",
+			requestFactory: func() *http.Request {
+				return newRequest("n1", "now", guardedSig("n1"))
+			},
+			dbMockFactory: func() *mocks.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "Timestamp must be an integer \"now\"",
+		},
+		{
+			name:     "invalid get user request, nonce is required",
+			required: true,
+			requestFactory: func() *http.Request {
+				return newRequest("", "", testutils.SignatureFromQuery(t, aliceSigner, query))
+			},
+			dbMockFactory: func() *mocks.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "Nonce and Timestamp must be set in the http request header",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ConfigureQueryReplayProtection(tt.required)
+			defer ConfigureQueryReplayProtection(false)
+
+			db := tt.dbMockFactory()
+			handler := NewUsersRequestHandler(db, logger)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, tt.requestFactory())
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+			db.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUsersRequestHandler_GetUsers(t *testing.T) {
+	submittingUserName := "alice"
+
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	testCases := []struct {
+		name               string
+		url                string
+		query              *types.GetUsersQuery
+		dbMockFactory      func(response *types.GetUsersResponseEnvelope) bcdb.DB
+		expectedResponse   *types.GetUsersResponseEnvelope
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:  "list a page of users",
+			url:   constants.URLForGetUsersPage("b", 0, 1),
+			query: &types.GetUsersQuery{UserId: submittingUserName, Prefix: "b", Limit: 1},
+			dbMockFactory: func(response *types.GetUsersResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetUsers", submittingUserName, "b", uint64(0), uint64(1)).Return(response, nil)
+				return db
+			},
+			expectedResponse: &types.GetUsersResponseEnvelope{
+				Response: &types.GetUsersResponse{
+					Header: &types.ResponseHeader{
+						NodeId: "testNodeID",
+					},
+					Users: []*types.UserInfo{
+						{
+							Id:      "bob",
+							Version: &types.Version{BlockNum: 2},
+							Privilege: &types.Privilege{
+								DbPermission: map[string]types.Privilege_Access{"db1": types.Privilege_ReadWrite},
+							},
+						},
+					},
+					HasMore: true,
+				},
+				Signature: []byte{0, 0, 0},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "invalid offset",
+			url:   constants.GetUsers + "?offset=abc",
+			query: &types.GetUsersQuery{UserId: submittingUserName},
+			dbMockFactory: func(response *types.GetUsersResponseEnvelope) bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the offset parameter must be a non-negative integer \"abc\"",
+		},
+		{
+			name:  "failed to list users",
+			url:   constants.URLForGetUsers(""),
+			query: &types.GetUsersQuery{UserId: submittingUserName},
+			dbMockFactory: func(response *types.GetUsersResponseEnvelope) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", submittingUserName).Return(aliceCert, nil)
+				db.On("GetUsers", submittingUserName, "", uint64(0), uint64(0)).Return(nil, errors.New("failed to list users"))
+				return db
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "error while processing 'GET /user/list' because failed to list users",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			req.Header.Set(constants.UserHeader, submittingUserName)
+			sig := testutils.SignatureFromQuery(t, aliceSigner, tt.query)
+			req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+
+			db := tt.dbMockFactory(tt.expectedResponse)
+			handler := NewUsersRequestHandler(db, logger)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				err := json.NewDecoder(rr.Body).Decode(respErr)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+			}
+
+			if tt.expectedResponse != nil {
+				res := &types.GetUsersResponseEnvelope{}
+				err := json.NewDecoder(rr.Body).Decode(res)
+				require.NoError(t, err)
+
+				require.Equal(t, tt.expectedResponse, res)
+			}
+		})
+	}
+}
+
+func TestUsersRequestHandler_Login(t *testing.T) {
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	now := time.Now()
+	signedLogin := func(ttl time.Duration) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, constants.URLForPostUserSession(now, ttl), nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, "alice")
+		query := &types.SessionLoginQuery{UserId: "alice", Timestamp: now.UnixNano()}
+		if ttl != 0 {
+			query.Ttl = ttl.String()
+		}
+		sig := testutils.SignatureFromQuery(t, aliceSigner, query)
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	tokenResponse := &types.SessionTokenResponseEnvelope{
+		Response: &types.SessionTokenResponse{
+			Header:    &types.ResponseHeader{NodeId: "testNodeID"},
+			Token:     "alice-token",
+			UserId:    "alice",
+			ExpiresAt: now.Add(time.Minute).UnixNano(),
+		},
+		Signature: []byte{0, 0, 0},
+	}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		dbMockFactory      func() bcdb.DB
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			name:    "valid login",
+			request: signedLogin(time.Minute),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", "alice").Return(aliceCert, nil)
+				db.On("CreateSessionToken", "alice", now.UnixNano(), time.Minute).Return(tokenResponse, nil)
+				return db
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "invalid login, the timestamp is not set",
+			request: func() *http.Request {
+				req := signedLogin(0)
+				req.URL.RawQuery = ""
+				return req
+			}(),
+			dbMockFactory: func() bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr:        "the timestamp parameter must be an integer \"\"",
+		},
+		{
+			name: "invalid login, only a session token",
+			request: func() *http.Request {
+				req := signedLogin(0)
+				req.Header.Del(constants.UserHeader)
+				req.Header.Del(constants.SignatureHeader)
+				req.Header.Set(constants.SessionTokenHeader, "alice-token")
+				return req
+			}(),
+			dbMockFactory: func() bcdb.DB {
+				return &mocks.DB{}
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedErr:        "a session token is not accepted by " + constants.PostUserSession + ", the query must be signed",
+		},
+		{
+			name:    "invalid login, the timestamp is too old",
+			request: signedLogin(0),
+			dbMockFactory: func() bcdb.DB {
+				db := &mocks.DB{}
+				db.On("GetCertificate", "alice").Return(aliceCert, nil)
+				db.On("CreateSessionToken", "alice", now.UnixNano(), time.Duration(0)).Return(nil,
+					&interrors.BadRequestError{ErrMsg: "the timestamp of the login differs from the clock of the server by more than 5m0s"})
+				return db
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedErr: "error while processing 'POST " + constants.URLForPostUserSession(now, 0) +
+				"' because the timestamp of the login differs from the clock of the server by more than 5m0s",
+		},
+	}
+
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewUsersRequestHandler(tt.dbMockFactory(), logger)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, tt.request)
+
+			require.Equal(t, tt.expectedStatusCode, rr.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				respErr := &types.HttpResponseErr{}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+				require.Equal(t, tt.expectedErr, respErr.ErrMsg)
+				return
+			}
+
+			res := &types.SessionTokenResponseEnvelope{}
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(res))
+			require.Equal(t, tokenResponse, res)
+		})
+	}
+}
+
 func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 	userID := "testUserID"
 	userToDelete := "userToDelete"
@@ -322,6 +772,7 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "time: invalid duration \"asdf\"").Return()
 				return db
 			},
 			timeoutStr:   "asdf",
@@ -341,6 +792,7 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dbTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "timeout can't be negative \"-2s\"").Return()
 				return db
 			},
 			timeoutStr:   "-2s",
@@ -357,6 +809,7 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing transaction envelope payload (*types.UserAdministrationTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -375,6 +828,7 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_MALFORMED, "missing UserID in transaction envelope payload (*types.UserAdministrationTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
@@ -390,11 +844,33 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			},
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "missing Signature in transaction envelope payload (*types.UserAdministrationTx)").Return()
 				return db
 			},
 			expectedCode: http.StatusBadRequest,
 			expectedErr:  "missing Signature in transaction envelope payload (*types.UserAdministrationTx)",
 		},
+		{
+			name: "submit certificate renewal tx with missing renewal signature",
+			txEnvFactory: func() *types.UserAdministrationTxEnvelope {
+				tx := &types.UserAdministrationTx{
+					UserId:             "alice",
+					TxId:               "tx1",
+					CertificateRenewal: &types.UserCertificateRenewal{Certificate: []byte("new-cert")},
+				}
+				return &types.UserAdministrationTxEnvelope{Payload: tx, Signature: aliceSig}
+			},
+			txRespFactory: func() *types.TxReceiptResponseEnvelope {
+				return nil
+			},
+			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
+				db := &mocks.DB{}
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "missing RenewalSignature in transaction envelope payload (*types.UserAdministrationTx)").Return()
+				return db
+			},
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  "missing RenewalSignature in transaction envelope payload (*types.UserAdministrationTx)",
+		},
 		{
 			name: "bad signature",
 			txEnvFactory: func() *types.UserAdministrationTxEnvelope {
@@ -409,6 +885,7 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", userID).Return(aliceCert, nil)
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
@@ -432,6 +909,7 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 			createMockAndInstrument: func(t *testing.T, dataTxEnv interface{}, txRespEnv interface{}, timeout time.Duration) bcdb.DB {
 				db := &mocks.DB{}
 				db.On("GetCertificate", "not-alice").Return(nil, errors.New("no such user"))
+				db.On("RecordRejectedTx", mock.Anything, mock.Anything, types.RejectedTx_UNAUTHENTICATED, "signature verification failed").Return()
 
 				return db
 			},
@@ -545,3 +1023,30 @@ func TestUsersRequestHandler_SubmitUserTx(t *testing.T) {
 		})
 	}
 }
+
+func TestUsersRequestHandler_SubmitUserTxBodyTooLarge(t *testing.T) {
+	logger, err := createLogger("debug")
+	require.NoError(t, err)
+
+	txBytes, err := json.Marshal(&types.UserAdministrationTxEnvelope{
+		Payload: &types.UserAdministrationTx{TxId: "1", UserId: "alice"},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, constants.PostUserTx, bytes.NewReader(txBytes))
+	require.NoError(t, err)
+	// the body is streamed, hence its size is detected while it is decoded
+	req.ContentLength = -1
+	rr := httptest.NewRecorder()
+	require.False(t, utils.LimitRequestBody(rr, req, int64(len(txBytes)-1)))
+
+	db := &mocks.DB{}
+	db.On("RecordRejectedTx", "", "", types.RejectedTx_MALFORMED, "http: request body too large").Return()
+	handler := NewUsersRequestHandler(db, logger)
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	respErr := &types.HttpResponseErr{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+	require.Equal(t, "http: request body too large", respErr.ErrMsg)
+}
@@ -10,21 +10,117 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 )
 
-func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryType string, signVerifier *cryptoservice.SignatureVerifier) (interface{}, bool) {
-	querierUserID, signature, err := validateAndParseHeader(&r.Header)
-	if err != nil {
-		utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+// MaxQueryNonceLength is the maximal length of the nonce of a query
+const MaxQueryNonceLength = 128
+
+// queryNonceRequired is set when every signed query must carry a nonce and a timestamp
+var queryNonceRequired uint32
+
+// ConfigureQueryReplayProtection sets whether every signed query must carry the Nonce and Timestamp headers. It is
+// called once when the server starts.
+func ConfigureQueryReplayProtection(required bool) {
+	var v uint32
+	if required {
+		v = 1
+	}
+	atomic.StoreUint32(&queryNonceRequired, v)
+}
+
+// queryAuthenticator authenticates the queries that are not verified by their signature alone
+type queryAuthenticator interface {
+	// VerifySessionToken returns the user of a session token
+	VerifySessionToken(token string) (string, error)
+	// CheckQueryNonce records the nonce of a signed query of a user, and returns an error if the query is a replay
+	CheckQueryNonce(userID, nonce string, timestamp int64) error
+}
+
+// sessionQueryTypes are the read queries that a session token is accepted on. Every other query, e.g., a login, an
+// administration or a diagnostics query, must be signed.
+var sessionQueryTypes = map[string]bool{
+	constants.GetData:                 true,
+	constants.GetDataKeys:             true,
+	constants.GetDataCursorPage:       true,
+	constants.PostDataCursor:          true,
+	constants.PostDataCursorClose:     true,
+	constants.PostDataQuery:           true,
+	constants.PostDataQueryExplain:    true,
+	constants.PostDataSQLQuery:        true,
+	constants.GetPendingDataTx:        true,
+	constants.GetPendingDataTxs:       true,
+	constants.GetDBStatus:             true,
+	constants.GetDBs:                  true,
+	constants.GetUser:                 true,
+	constants.GetUsers:                true,
+	constants.GetConfig:               true,
+	constants.GetNodeConfig:           true,
+	constants.GetLastConfigBlock:      true,
+	constants.GetClusterStatus:        true,
+	constants.GetBlockHeader:          true,
+	constants.GetLastBlockHeader:      true,
+	constants.GetPath:                 true,
+	constants.GetTxProof:              true,
+	constants.GetDataProof:            true,
+	constants.GetDBStateRoot:          true,
+	constants.GetTxReceipt:            true,
+	constants.GetTxReceipts:           true,
+	constants.GetTxResourceUsage:      true,
+	constants.GetHistoricalData:       true,
+	constants.GetDataReaders:          true,
+	constants.GetDataWriters:          true,
+	constants.GetDataReadBy:           true,
+	constants.GetDataWrittenBy:        true,
+	constants.GetDataDeletedBy:        true,
+	constants.GetTxIDsSubmittedBy:     true,
+	constants.GetTxsByAnnotation:      true,
+	constants.GetMostRecentUserOrNode: true,
+}
+
+// extractVerifiedQueryPayload constructs the payload of a query and authenticates the querier, either by the signature
+// on the payload, or, for the read queries of sessionQueryTypes, by a session token. A signed query that carries a
+// nonce and a timestamp is signed together with them, and is rejected if it is a replay.
+func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryType string, signVerifier *cryptoservice.SignatureVerifier, auth queryAuthenticator) (interface{}, bool) {
+	var querierUserID string
+	var signature []byte
+	var nonce string
+	var timestamp int64
+	var err error
+
+	token := r.Header.Get(constants.SessionTokenHeader)
+	bySession := token != ""
+	if bySession && !sessionQueryTypes[queryType] {
+		utils.SendHTTPResponse(w, http.StatusUnauthorized, &types.HttpResponseErr{
+			ErrMsg: "a session token is not accepted by " + queryType + ", the query must be signed",
+		})
 		return nil, true
 	}
+	if bySession {
+		if querierUserID, err = verifySessionToken(&r.Header, token, auth); err != nil {
+			utils.SendHTTPResponse(w, http.StatusUnauthorized, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+	} else {
+		querierUserID, signature, err = validateAndParseHeader(&r.Header)
+		if err == nil {
+			nonce, timestamp, err = validateAndParseNonceHeaders(&r.Header)
+		}
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+	}
 
 	var payload interface{}
 	params := mux.Vars(r)
@@ -36,6 +132,97 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			DbName: params["dbname"],
 			Key:    params["key"],
 		}
+	case constants.GetDataKeys:
+		countOnly := false
+		if value := r.URL.Query().Get("countOnly"); value != "" {
+			countOnly, err = strconv.ParseBool(value)
+			if err != nil {
+				utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "countOnly must be a boolean, but it is [" + value + "]"})
+				return nil, true
+			}
+		}
+
+		limit, err := parseLimitParam(r)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
+		payload = &types.GetDataKeysQuery{
+			UserId:    querierUserID,
+			DbName:    params["dbname"],
+			Prefix:    r.URL.Query().Get("prefix"),
+			CountOnly: countOnly,
+			StartKey:  r.URL.Query().Get("startKey"),
+			Limit:     limit,
+		}
+	case constants.GetPendingDataTx:
+		payload = &types.GetPendingDataTxQuery{
+			UserId: querierUserID,
+			TxId:   params["txId"],
+		}
+	case constants.GetPendingDataTxs:
+		payload = &types.GetPendingDataTxsQuery{
+			UserId: querierUserID,
+		}
+	case constants.PostDataCursor:
+		dbName := r.URL.Query().Get("db")
+		if dbName == "" {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "the database of the cursor must be set"})
+			return nil, true
+		}
+
+		payload = &types.OpenDataCursorQuery{
+			UserId: querierUserID,
+			DbName: dbName,
+			Prefix: r.URL.Query().Get("prefix"),
+			Ttl:    r.URL.Query().Get("ttl"),
+		}
+	case constants.GetDataCursorPage:
+		var limit uint64
+		if value := r.URL.Query().Get("limit"); value != "" {
+			if limit, err = strconv.ParseUint(value, 10, 64); err != nil {
+				utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "the limit parameter must be a non-negative integer " + strconv.Quote(value)})
+				return nil, true
+			}
+		}
+
+		payload = &types.GetDataCursorPageQuery{
+			UserId:   querierUserID,
+			CursorId: params["cursorId"],
+			After:    r.URL.Query().Get("after"),
+			Limit:    limit,
+		}
+	case constants.PostDataCursorClose:
+		payload = &types.CloseDataCursorQuery{
+			UserId:   querierUserID,
+			CursorId: params["cursorId"],
+		}
+	case constants.GetUsers:
+		offset, limit, err := parsePagingParams(r)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
+		payload = &types.GetUsersQuery{
+			UserId: querierUserID,
+			Prefix: r.URL.Query().Get("prefix"),
+			Offset: offset,
+			Limit:  limit,
+		}
+	case constants.PostUserSession:
+		timestamp, err := strconv.ParseInt(r.URL.Query().Get("timestamp"), 10, 64)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "the timestamp parameter must be an integer " + strconv.Quote(r.URL.Query().Get("timestamp"))})
+			return nil, true
+		}
+
+		payload = &types.SessionLoginQuery{
+			UserId:    querierUserID,
+			Timestamp: timestamp,
+			Ttl:       r.URL.Query().Get("ttl"),
+		}
 	case constants.GetUser:
 		payload = &types.GetUserQuery{
 			UserId:       querierUserID,
@@ -46,6 +233,24 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			UserId: querierUserID,
 			DbName: params["dbname"],
 		}
+	case constants.GetDBs:
+		offset, limit, err := parsePagingParams(r)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
+		payload = &types.GetDBsQuery{
+			UserId: querierUserID,
+			Prefix: r.URL.Query().Get("prefix"),
+			Offset: offset,
+			Limit:  limit,
+		}
+	case constants.GetMigrations:
+		payload = &types.GetMigrationsQuery{
+			UserId: querierUserID,
+			Name:   r.URL.Query().Get("name"),
+		}
 	case constants.GetConfig:
 		payload = &types.GetConfigQuery{
 			UserId: querierUserID,
@@ -60,6 +265,27 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			UserId:      querierUserID,
 			BlockNumber: 0, // 0 means get last, as first block is 1
 		}
+	case constants.GetConfigHistory:
+		payload = &types.GetConfigHistoryQuery{
+			UserId: querierUserID,
+		}
+	case constants.GetConfigDiff:
+		fromBlockNum, err := utils.GetUintParam("fromBlockId", params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+		toBlockNum, err := utils.GetUintParam("toBlockId", params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+
+		payload = &types.GetConfigDiffQuery{
+			UserId:          querierUserID,
+			FromBlockNumber: fromBlockNum,
+			ToBlockNumber:   toBlockNum,
+		}
 	case constants.GetClusterStatus:
 		noCertificates := false
 		if value, ok := params["noCertificates"]; ok {
@@ -74,6 +300,79 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			UserId:         querierUserID,
 			NoCertificates: noCertificates,
 		}
+	case constants.PostTransferLeadership:
+		payload = &types.TransferLeadershipQuery{
+			UserId:       querierUserID,
+			TargetNodeId: params["nodeId"],
+		}
+	case constants.GetConsensusDiag:
+		payload = &types.GetConsensusDiagnosticsQuery{
+			UserId: querierUserID,
+		}
+	case constants.GetStorageReport:
+		var top uint64
+		if value := r.URL.Query().Get("top"); value != "" {
+			top, err = strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "the top parameter must be a non-negative integer " + strconv.Quote(value)})
+				return nil, true
+			}
+		}
+
+		payload = &types.GetStorageReportQuery{
+			UserId:          querierUserID,
+			Top:             uint32(top),
+			PrefixDelimiter: r.URL.Query().Get("delimiter"),
+		}
+	case constants.GetStateHash:
+		payload = &types.GetStateHashQuery{
+			UserId: querierUserID,
+			DbName: params["dbname"],
+		}
+	case constants.PostCompactIndex:
+		payload = &types.CompactIndexQuery{
+			UserId: querierUserID,
+			DbName: params["dbname"],
+		}
+	case constants.GetQuarantine:
+		payload = &types.GetQuarantinedBlockQuery{
+			UserId: querierUserID,
+		}
+	case constants.GetRejectedTxs:
+		payload = &types.GetRejectedTxsQuery{
+			UserId: querierUserID,
+		}
+	case constants.GetSlowQueries:
+		payload = &types.GetSlowQueriesQuery{
+			UserId: querierUserID,
+		}
+	case constants.GetWitnessStatus:
+		payload = &types.GetWitnessStatusQuery{
+			UserId: querierUserID,
+		}
+	case constants.GetIndexUsage:
+		unusedOnly := false
+		if value := r.URL.Query().Get("unusedOnly"); value != "" {
+			unusedOnly, err = strconv.ParseBool(value)
+			if err != nil {
+				utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "unusedOnly must be a boolean, but it is [" + value + "]"})
+				return nil, true
+			}
+		}
+
+		payload = &types.GetIndexUsageQuery{
+			UserId:     querierUserID,
+			UnusedOnly: unusedOnly,
+		}
+	case constants.DiagnosticsEndpoint:
+		payload = &types.GetDiagnosticsQuery{
+			UserId: querierUserID,
+			Path:   r.URL.Path,
+		}
+	case constants.PostSnapshot:
+		payload = &types.TriggerSnapshotQuery{
+			UserId: querierUserID,
+		}
 	case constants.GetBlockHeader:
 		blockNum, err := utils.GetBlockNum(params)
 		if err != nil {
@@ -146,11 +445,42 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			Key:         params["key"],
 			IsDeleted:   deleted,
 		}
+	case constants.GetDBStateRoot:
+		blockNum, err := utils.GetBlockNum(params)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+
+		payload = &types.GetDBStateRootQuery{
+			UserId:      querierUserID,
+			BlockNumber: blockNum,
+			DbName:      params["dbname"],
+		}
 	case constants.GetTxReceipt:
 		payload = &types.GetTxReceiptQuery{
 			UserId: querierUserID,
 			TxId:   params["txId"],
 		}
+	case constants.GetTxReceipts:
+		var txIDs []string
+		if value := r.URL.Query().Get("txIds"); value != "" {
+			txIDs = strings.Split(value, ",")
+		}
+		if len(txIDs) == 0 {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "the IDs of the transactions must be set"})
+			return nil, true
+		}
+
+		payload = &types.GetTxReceiptsQuery{
+			UserId: querierUserID,
+			TxIds:  txIDs,
+		}
+	case constants.GetTxResourceUsage:
+		payload = &types.GetTxResourceUsageQuery{
+			UserId: querierUserID,
+			TxId:   params["txId"],
+		}
 	case constants.GetHistoricalData:
 		version, err := utils.GetVersion(params)
 		if err != nil {
@@ -168,6 +498,18 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 
 		_, isMostRecentSet := params["mostrecent"]
 
+		offset, limit, err := parsePagingParams(r)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+		if offset > 0 && version != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{
+				ErrMsg: "the offset parameter can be set only when fetching all values of a key",
+			})
+			return nil, true
+		}
+
 		payload = &types.GetHistoricalDataQuery{
 			UserId:      querierUserID,
 			DbName:      params["dbname"],
@@ -176,6 +518,8 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			Direction:   params["direction"],
 			OnlyDeletes: isOnlyDeletesSet,
 			MostRecent:  isMostRecentSet,
+			Offset:      offset,
+			Limit:       limit,
 		}
 	case constants.GetDataReaders:
 		payload = &types.GetDataReadersQuery{
@@ -209,6 +553,26 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			UserId:       querierUserID,
 			TargetUserId: params["userId"],
 		}
+	case constants.GetTxsByAnnotation:
+		key := r.URL.Query().Get("key")
+		if key == "" {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "the annotation key must be set"})
+			return nil, true
+		}
+
+		offset, limit, err := parsePagingParams(r)
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
+		payload = &types.GetTxsByAnnotationQuery{
+			UserId:          querierUserID,
+			AnnotationKey:   key,
+			AnnotationValue: r.URL.Query().Get("value"),
+			Offset:          offset,
+			Limit:           limit,
+		}
 	case constants.GetMostRecentUserOrNode:
 		version, err := utils.GetVersion(params)
 		if err != nil {
@@ -237,7 +601,7 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 
 		b, err := io.ReadAll(r.Body)
 		if err != nil {
-			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			utils.SendHTTPResponse(w, decodeErrorStatus(err), &types.HttpResponseErr{ErrMsg: err.Error()})
 			return nil, true
 		}
 
@@ -251,17 +615,111 @@ func extractVerifiedQueryPayload(w http.ResponseWriter, r *http.Request, queryTy
 			DbName: params["dbname"],
 			Query:  q,
 		}
+	case constants.PostDataQueryExplain:
+		if r.Body == nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "query is empty"})
+			return nil, true
+		}
+
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.SendHTTPResponse(w, decodeErrorStatus(err), &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
+		q, err := strconv.Unquote(string(b))
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+		payload = &types.ExplainJSONQuery{
+			UserId:         querierUserID,
+			DbName:         params["dbname"],
+			ExplainedQuery: q,
+		}
+	case constants.PostDataSQLQuery:
+		if r.Body == nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, &types.HttpResponseErr{ErrMsg: "query is empty"})
+			return nil, true
+		}
+
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.SendHTTPResponse(w, decodeErrorStatus(err), &types.HttpResponseErr{ErrMsg: err.Error()})
+			return nil, true
+		}
+
+		q, err := strconv.Unquote(string(b))
+		if err != nil {
+			utils.SendHTTPResponse(w, http.StatusBadRequest, err)
+			return nil, true
+		}
+		payload = &types.DataSQLQuery{
+			UserId: querierUserID,
+			Query:  q,
+		}
+	}
+
+	if bySession {
+		return payload, false
 	}
 
-	err, status := VerifyRequestSignature(signVerifier, querierUserID, signature, payload)
+	if nonce == "" {
+		err, status := VerifyRequestSignature(signVerifier, querierUserID, signature, payload)
+		if err != nil {
+			utils.SendHTTPResponse(w, status, err)
+			return nil, true
+		}
+		return payload, false
+	}
+
+	guard, err := cryptoservice.NewQueryReplayGuard(payload, nonce, timestamp)
 	if err != nil {
+		utils.SendHTTPResponse(w, http.StatusInternalServerError, &types.HttpResponseErr{ErrMsg: "failure during json.Marshal: " + err.Error()})
+		return nil, true
+	}
+	if err, status := VerifyRequestSignature(signVerifier, querierUserID, signature, guard); err != nil {
 		utils.SendHTTPResponse(w, status, err)
 		return nil, true
 	}
+	if err := auth.CheckQueryNonce(querierUserID, nonce, timestamp); err != nil {
+		status := http.StatusUnauthorized
+		if _, ok := err.(*ierrors.ResourceExhaustedError); ok {
+			status = http.StatusServiceUnavailable
+		}
+		utils.SendHTTPResponse(w, status, &types.HttpResponseErr{ErrMsg: err.Error()})
+		return nil, true
+	}
 
 	return payload, false
 }
 
+// verifySessionToken returns the user of a session token. The UserID header may be omitted, but if it is set, it must
+// name the user of the token.
+func verifySessionToken(h *http.Header, token string, auth queryAuthenticator) (string, error) {
+	userID, err := auth.VerifySessionToken(token)
+	if err != nil {
+		return "", err
+	}
+	if headerUserID := h.Get(constants.UserHeader); headerUserID != "" && headerUserID != userID {
+		return "", errors.New("the session token is not issued to the user [" + headerUserID + "]")
+	}
+
+	return userID, nil
+}
+
+// etagMatches returns true if the value of an If-None-Match header lists the given entity tag or is "*". As required
+// for If-None-Match, the comparison is weak, i.e., the W/ prefix of a weak entity tag is ignored.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func VerifyRequestSignature(
 	sigVerifier *cryptoservice.SignatureVerifier,
 	user string,
@@ -273,7 +731,15 @@ func VerifyRequestSignature(
 		return &types.HttpResponseErr{ErrMsg: "failure during json.Marshal: " + err.Error()}, http.StatusInternalServerError
 	}
 
-	err = sigVerifier.Verify(user, signature, requestBytes)
+	return verifySignature(sigVerifier, user, signature, requestBytes)
+}
+
+// verifySignature verifies the signature of a user on the given bytes
+func verifySignature(sigVerifier *cryptoservice.SignatureVerifier, user string, signature, signed []byte) (error, int) {
+	err := sigVerifier.Verify(user, signature, signed)
+	if _, ok := err.(*identity.DisabledErr); ok {
+		return &types.HttpResponseErr{ErrMsg: err.Error()}, http.StatusUnauthorized
+	}
 	if err != nil {
 		return &types.HttpResponseErr{ErrMsg: "signature verification failed"}, http.StatusUnauthorized
 	}
@@ -299,6 +765,81 @@ func validateAndParseHeader(h *http.Header) (string, []byte, error) {
 	return userID, signatureBytes, nil
 }
 
+// decodeErrorStatus returns the status of a failure to read or decode the body of a request
+func decodeErrorStatus(err error) int {
+	if utils.IsRequestBodyTooLarge(err) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// validateAndParseNonceHeaders parses the optional Nonce and Timestamp headers, which must be set together. They must be
+// set if the replay protection of queries is required.
+func validateAndParseNonceHeaders(h *http.Header) (string, int64, error) {
+	nonce := h.Get(constants.NonceHeader)
+	timestampStr := h.Get(constants.TimestampHeader)
+	if nonce == "" && timestampStr == "" {
+		if atomic.LoadUint32(&queryNonceRequired) == 1 {
+			return "", 0, errors.New(constants.NonceHeader + " and " + constants.TimestampHeader + " must be set in the http request header")
+		}
+		return "", 0, nil
+	}
+
+	if nonce == "" || timestampStr == "" {
+		return "", 0, errors.New(constants.NonceHeader + " and " + constants.TimestampHeader + " must be set together in the http request header")
+	}
+	if len(nonce) > MaxQueryNonceLength {
+		return "", 0, errors.New(constants.NonceHeader + " must be up to " + strconv.Itoa(MaxQueryNonceLength) + " characters long")
+	}
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		return "", 0, errors.New(constants.TimestampHeader + " must be an integer " + strconv.Quote(timestampStr))
+	}
+
+	return nonce, timestamp, nil
+}
+
+// parsePagingParams parses the optional offset and limit query parameters, which are zero when not set
+func parsePagingParams(r *http.Request) (offset, limit uint64, err error) {
+	query := r.URL.Query()
+	if v := query.Get("offset"); v != "" {
+		if offset, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return 0, 0, errors.New("the offset parameter must be a non-negative integer " + strconv.Quote(v))
+		}
+	}
+	if limit, err = parseLimitParam(r); err != nil {
+		return 0, 0, err
+	}
+	return offset, limit, nil
+}
+
+// parseLimitParam parses the optional limit query parameter, which is zero when not set
+func parseLimitParam(r *http.Request) (limit uint64, err error) {
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return 0, errors.New("the limit parameter must be a non-negative integer " + strconv.Quote(v))
+		}
+	}
+	return limit, nil
+}
+
+func validateAndParseExpiryHeader(h *http.Header) (time.Duration, error) {
+	expiryStr := h.Get(constants.ExpiryHeader)
+	if len(expiryStr) == 0 {
+		return 0, nil
+	}
+
+	expiry, err := time.ParseDuration(expiryStr)
+	if err != nil {
+		return 0, err
+	}
+
+	if expiry <= 0 {
+		return 0, errors.New("expiry must be positive " + strconv.Quote(expiryStr))
+	}
+	return expiry, nil
+}
+
 func validateAndParseTxPostHeader(h *http.Header) (time.Duration, error) {
 	timeoutStr := h.Get(constants.TimeoutHeader)
 	if len(timeoutStr) == 0 {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger-labs/orion-server/internal/bcdb/mocks"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
@@ -47,6 +48,16 @@ func TestVerifyRequestSignature(t *testing.T) {
 		require.Equal(t, http.StatusUnauthorized, code)
 	})
 
+	t.Run("disabled user", func(t *testing.T) {
+		db := &mocks.DB{}
+		verifier := cryptoservice.NewVerifier(db, lg)
+		db.On("GetCertificate", "alice").Return(nil, identity.NewDisabledErr("alice"))
+		payload := &types.UserAdministrationTx{UserId: "alice", TxId: "xxx"}
+		err, code := VerifyRequestSignature(verifier, "alice", testutils.SignatureFromTx(t, aliceSigner, payload), payload)
+		require.EqualError(t, err, "the user [alice] is disabled")
+		require.Equal(t, http.StatusUnauthorized, code)
+	})
+
 	t.Run("bad sig", func(t *testing.T) {
 		db := &mocks.DB{}
 		verifier := cryptoservice.NewVerifier(db, lg)
@@ -86,3 +97,12 @@ func init() {
 		},
 	}
 }
+
+func TestETagMatches(t *testing.T) {
+	require.True(t, etagMatches(`"5-2"`, `"5-2"`))
+	require.True(t, etagMatches(`"4-0", "5-2"`, `"5-2"`))
+	require.True(t, etagMatches(`W/"5-2"`, `"5-2"`))
+	require.True(t, etagMatches(`*`, `"5-2"`))
+	require.False(t, etagMatches(`"5-1"`, `"5-2"`))
+	require.False(t, etagMatches(`5-2`, `"5-2"`))
+}
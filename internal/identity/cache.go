@@ -0,0 +1,184 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package identity
+
+import (
+	"crypto/x509"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheLookupsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "identity",
+		Name:      "cache_lookups_total",
+		Help:      "The number of lookups of user and node records in the identity cache, by result: hit or miss.",
+	}, []string{"node", "result"})
+
+	cacheInvalidationsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "identity",
+		Name:      "cache_invalidations_total",
+		Help:      "The number of user and node records invalidated in the identity cache by committed blocks.",
+	}, []string{"node"})
+
+	cacheEntriesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "identity",
+		Name:      "cache_entries",
+		Help:      "The number of user and node records held by the identity cache.",
+	}, []string{"node"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		cacheLookupsCounter,
+		cacheInvalidationsCounter,
+		cacheEntriesGauge,
+	)
+}
+
+// Cache holds the user and node records read by the queriers that share it, so that the access checks of every
+// request do not read and unmarshal the records, and parse the certificates, from the state database. The committer
+// invalidates the records that a block writes or deletes, once the block is committed to the state database.
+//
+// Every invalidation advances the version of the cache, and a record read from the state database is cached only if
+// the version did not advance during the read. Hence, a record read before a commit is never cached after the commit
+// invalidated it. When the cache is full, an arbitrary record is evicted.
+type Cache struct {
+	nodeID     string
+	maxEntries int
+
+	lock    sync.Mutex
+	version uint64
+	entries map[cacheKey]*cachedRecord
+}
+
+type cacheKey struct {
+	dbName string
+	key    string
+}
+
+// cachedRecord is a user or node record, or the absence of the record. The record is shared by the lookups, and must
+// not be modified.
+type cachedRecord struct {
+	user     *types.User
+	node     *types.NodeConfig
+	metadata *types.Metadata
+
+	// the certificate of the user is parsed once, on the first lookup that needs it
+	certOnce sync.Once
+	cert     *x509.Certificate
+	certErr  error
+}
+
+func (r *cachedRecord) certificate() (*x509.Certificate, error) {
+	r.certOnce.Do(func() {
+		r.cert, r.certErr = x509.ParseCertificate(r.user.GetCertificate())
+	})
+	return r.cert, r.certErr
+}
+
+// NewCache returns a cache of at most maxEntries user and node records, or nil if maxEntries is zero, as a nil cache
+// caches nothing.
+func NewCache(nodeID string, maxEntries uint32) *Cache {
+	if maxEntries == 0 {
+		return nil
+	}
+
+	return &Cache{
+		nodeID:     nodeID,
+		maxEntries: int(maxEntries),
+		entries:    make(map[cacheKey]*cachedRecord),
+	}
+}
+
+// Invalidate drops the user and node records written or deleted by the updates of a block, which were committed to
+// the state database.
+func (c *Cache) Invalidate(dbsUpdates map[string]*worldstate.DBUpdates) {
+	if c == nil {
+		return
+	}
+
+	var keys []cacheKey
+	collect := func(dbName, key string, namespace []byte) {
+		if strings.HasPrefix(key, string(namespace)) {
+			keys = append(keys, cacheKey{dbName: dbName, key: key})
+		}
+	}
+	for _, dbName := range []string{worldstate.UsersDBName, worldstate.ConfigDBName} {
+		updates, ok := dbsUpdates[dbName]
+		if !ok {
+			continue
+		}
+		namespace := UserNamespace
+		if dbName == worldstate.ConfigDBName {
+			namespace = NodeNamespace
+		}
+		for _, w := range updates.Writes {
+			collect(dbName, w.Key, namespace)
+		}
+		for _, key := range updates.Deletes {
+			collect(dbName, key, namespace)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.version++
+	for _, k := range keys {
+		delete(c.entries, k)
+	}
+	cacheInvalidationsCounter.WithLabelValues(c.nodeID).Add(float64(len(keys)))
+	cacheEntriesGauge.WithLabelValues(c.nodeID).Set(float64(len(c.entries)))
+}
+
+// lookup returns the cached record of a key, or loads the record from the state database and caches it
+func (c *Cache) lookup(k cacheKey, load func() (*cachedRecord, error)) (*cachedRecord, error) {
+	if c == nil {
+		return load()
+	}
+
+	c.lock.Lock()
+	r, ok := c.entries[k]
+	version := c.version
+	c.lock.Unlock()
+
+	if ok {
+		cacheLookupsCounter.WithLabelValues(c.nodeID, "hit").Inc()
+		return r, nil
+	}
+	cacheLookupsCounter.WithLabelValues(c.nodeID, "miss").Inc()
+
+	r, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.version != version {
+		// a block committed during the load may have updated the record
+		return r, nil
+	}
+	if len(c.entries) >= c.maxEntries {
+		for evicted := range c.entries {
+			delete(c.entries, evicted)
+			break
+		}
+	}
+	c.entries[k] = r
+	cacheEntriesGauge.WithLabelValues(c.nodeID).Set(float64(len(c.entries)))
+
+	return r, nil
+}
@@ -0,0 +1,170 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package identity
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedQuerier(t *testing.T) {
+	lookups := func(nodeID, result string) float64 {
+		return testutil.ToFloat64(cacheLookupsCounter.WithLabelValues(nodeID, result))
+	}
+
+	caCert, caKey, err := testutils.GenerateRootCA("root", "127.0.0.1")
+	require.NoError(t, err)
+	keyPair, err := tls.X509KeyPair(caCert, caKey)
+	require.NoError(t, err)
+	cert, _, err := testutils.IssueCertificate("alice", "127.0.0.1", keyPair)
+	require.NoError(t, err)
+	bl, _ := pem.Decode(cert)
+	require.NotNil(t, bl)
+
+	// commit writes the records to the db and invalidates them in the cache, as the committer does
+	commit := func(env *testEnv, cache *Cache, blockNum uint64, users []*types.User, nodes []*types.NodeConfig, deletes ...string) {
+		dbsUpdates := map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName:  {Deletes: deletes},
+			worldstate.ConfigDBName: {},
+		}
+		metadata := &types.Metadata{Version: &types.Version{BlockNum: blockNum}}
+		for _, u := range users {
+			value, err := proto.Marshal(u)
+			require.NoError(t, err)
+			dbsUpdates[worldstate.UsersDBName].Writes = append(dbsUpdates[worldstate.UsersDBName].Writes,
+				&worldstate.KVWithMetadata{Key: string(UserNamespace) + u.Id, Value: value, Metadata: metadata})
+		}
+		for _, n := range nodes {
+			value, err := proto.Marshal(n)
+			require.NoError(t, err)
+			dbsUpdates[worldstate.ConfigDBName].Writes = append(dbsUpdates[worldstate.ConfigDBName].Writes,
+				&worldstate.KVWithMetadata{Key: string(NodeNamespace) + n.Id, Value: value, Metadata: metadata})
+		}
+		require.NoError(t, env.db.Commit(dbsUpdates, blockNum))
+		cache.Invalidate(dbsUpdates)
+	}
+
+	t.Run("user records are cached until invalidated", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+		cache := NewCache("cache-users", 10)
+		q := NewCachedQuerier(env.db, cache)
+
+		commit(env, cache, 1, []*types.User{{Id: "alice", Certificate: bl.Bytes}}, nil)
+		hits, misses := lookups("cache-users", "hit"), lookups("cache-users", "miss")
+
+		user, metadata, err := q.GetUser("alice")
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), metadata.GetVersion().GetBlockNum())
+		exist, err := q.DoesUserExist("alice")
+		require.NoError(t, err)
+		require.True(t, exist)
+		require.Equal(t, misses+1, lookups("cache-users", "miss"))
+		require.Equal(t, hits+1, lookups("cache-users", "hit"))
+
+		// the cached record is not modified through the returned copy
+		user.Disabled = true
+		user, _, err = q.GetUser("alice")
+		require.NoError(t, err)
+		require.False(t, user.Disabled)
+
+		// the certificate is parsed once
+		cert1, err := q.GetCertificate("alice")
+		require.NoError(t, err)
+		cert2, err := q.GetCertificate("alice")
+		require.NoError(t, err)
+		require.Same(t, cert1, cert2)
+
+		// an update of the db that does not invalidate the cache is not seen
+		value, err := proto.Marshal(&types.User{Id: "alice", Disabled: true})
+		require.NoError(t, err)
+		require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: string(UserNamespace) + "alice", Value: value}},
+			},
+		}, 2))
+		_, err = q.GetCertificate("alice")
+		require.NoError(t, err)
+
+		invalidations := testutil.ToFloat64(cacheInvalidationsCounter.WithLabelValues("cache-users"))
+		commit(env, cache, 3, []*types.User{{Id: "alice", Certificate: bl.Bytes, Disabled: true}}, nil)
+		require.Equal(t, invalidations+1, testutil.ToFloat64(cacheInvalidationsCounter.WithLabelValues("cache-users")))
+		_, err = q.GetCertificate("alice")
+		require.EqualError(t, err, "the user [alice] is disabled")
+
+		commit(env, cache, 4, nil, nil, string(UserNamespace)+"alice")
+		_, _, err = q.GetUser("alice")
+		require.EqualError(t, err, "the user [alice] does not exist")
+	})
+
+	t.Run("missing records are cached until invalidated", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+		cache := NewCache("cache-missing", 10)
+		q := NewCachedQuerier(env.db, cache)
+
+		exist, err := q.DoesUserExist("bob")
+		require.NoError(t, err)
+		require.False(t, exist)
+		_, _, err = q.GetNode("node1")
+		require.EqualError(t, err, "the user [node1] does not exist")
+		require.Equal(t, float64(2), testutil.ToFloat64(cacheEntriesGauge.WithLabelValues("cache-missing")))
+
+		commit(env, cache, 1, []*types.User{{Id: "bob"}}, []*types.NodeConfig{{Id: "node1", Address: "127.0.0.1"}})
+		exist, err = q.DoesUserExist("bob")
+		require.NoError(t, err)
+		require.True(t, exist)
+		node, _, err := q.GetNode("node1")
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1", node.Address)
+	})
+
+	t.Run("a record loaded during an invalidation is not cached", func(t *testing.T) {
+		cache := NewCache("cache-stale", 10)
+		k := cacheKey{dbName: worldstate.UsersDBName, key: string(UserNamespace) + "carol"}
+
+		r, err := cache.lookup(k, func() (*cachedRecord, error) {
+			cache.Invalidate(map[string]*worldstate.DBUpdates{
+				worldstate.UsersDBName: {Deletes: []string{k.key}},
+			})
+			return &cachedRecord{}, nil
+		})
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		require.Empty(t, cache.entries)
+	})
+
+	t.Run("a full cache evicts a record", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+		cache := NewCache("cache-full", 2)
+		q := NewCachedQuerier(env.db, cache)
+
+		for _, userID := range []string{"u1", "u2", "u3"} {
+			_, err := q.DoesUserExist(userID)
+			require.NoError(t, err)
+		}
+		require.Len(t, cache.entries, 2)
+	})
+
+	t.Run("disabled cache", func(t *testing.T) {
+		require.Nil(t, NewCache("cache-disabled", 0))
+
+		env := newTestEnv(t)
+		defer env.cleanup()
+		q := NewCachedQuerier(env.db, nil)
+
+		commit(env, nil, 1, []*types.User{{Id: "dave"}}, nil)
+		exist, err := q.DoesUserExist("dave")
+		require.NoError(t, err)
+		require.True(t, exist)
+	})
+}
@@ -19,6 +19,33 @@ var (
 	NodeNamespace = []byte{0}
 )
 
+// ResolveCertificateRenewal returns the user administration transaction that is equivalent to a certificate renewal
+// transaction: a write of the submitting user that replaces its certificate and keeps its privileges, signing keys,
+// and access control. A transaction that does not renew a certificate is returned as is.
+func ResolveCertificateRenewal(tx *types.UserAdministrationTx, db worldstate.DB) (*types.UserAdministrationTx, error) {
+	if tx.CertificateRenewal == nil {
+		return tx, nil
+	}
+
+	user, metadata, err := NewQuerier(db).GetUser(tx.UserId)
+	if err != nil {
+		return nil, err
+	}
+	renewed := proto.Clone(user).(*types.User)
+	renewed.Certificate = tx.CertificateRenewal.Certificate
+
+	return &types.UserAdministrationTx{
+		UserId: tx.UserId,
+		TxId:   tx.TxId,
+		UserWrites: []*types.UserWrite{
+			{
+				User: renewed,
+				Acl:  metadata.GetAccessControl(),
+			},
+		},
+	}, nil
+}
+
 // ConstructDBEntriesForUserAdminTx constructs database entries for the transaction that manipulates
 // user information
 func ConstructDBEntriesForUserAdminTx(tx *types.UserAdministrationTx, version *types.Version) (*worldstate.DBUpdates, error) {
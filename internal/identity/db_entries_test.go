@@ -333,6 +333,90 @@ func TestConstructDBEntriesForClusterAdmins(t *testing.T) {
 	}
 }
 
+func TestResolveCertificateRenewal(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	user1 := &types.User{
+		Id:          "user1",
+		Certificate: []byte("rawcert"),
+		Privilege: &types.Privilege{
+			DbPermission: map[string]types.Privilege_Access{
+				"db1": types.Privilege_Read,
+			},
+		},
+		SigningKeys: []*types.SigningKey{
+			{
+				KeyId:     "key1",
+				PublicKey: []byte("publickey"),
+			},
+		},
+	}
+	user1Serialized, err := proto.Marshal(user1)
+	require.NoError(t, err)
+	acl := &types.AccessControl{
+		ReadUsers: map[string]bool{
+			"user2": true,
+		},
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:   string(UserNamespace) + "user1",
+					Value: user1Serialized,
+					Metadata: &types.Metadata{
+						Version:       &types.Version{BlockNum: 1},
+						AccessControl: acl,
+					},
+				},
+			},
+		},
+	}, 1))
+
+	tx := &types.UserAdministrationTx{
+		UserId: "user1",
+		TxId:   "tx1",
+		UserDeletes: []*types.UserDelete{
+			{UserId: "user2"},
+		},
+	}
+	resolved, err := ResolveCertificateRenewal(tx, env.db)
+	require.NoError(t, err)
+	require.Same(t, tx, resolved)
+
+	tx = &types.UserAdministrationTx{
+		UserId: "user1",
+		TxId:   "tx2",
+		CertificateRenewal: &types.UserCertificateRenewal{
+			Certificate: []byte("newrawcert"),
+		},
+	}
+	resolved, err = ResolveCertificateRenewal(tx, env.db)
+	require.NoError(t, err)
+
+	renewedUser1 := proto.Clone(user1).(*types.User)
+	renewedUser1.Certificate = []byte("newrawcert")
+	expected := &types.UserAdministrationTx{
+		UserId: "user1",
+		TxId:   "tx2",
+		UserWrites: []*types.UserWrite{
+			{
+				User: renewedUser1,
+				Acl:  acl,
+			},
+		},
+	}
+	require.True(t, proto.Equal(expected, resolved))
+
+	tx.UserId = "user3"
+	resolved, err = ResolveCertificateRenewal(tx, env.db)
+	require.EqualError(t, err, "the user [user3] does not exist")
+	require.Nil(t, resolved)
+}
+
 func TestConstructProvenanceEntriesForUserAdminTx(t *testing.T) {
 	t.Parallel()
 
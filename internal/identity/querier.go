@@ -3,8 +3,10 @@
 package identity
 
 import (
+	"bytes"
 	"crypto/x509"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -15,50 +17,121 @@ import (
 // Querier provides method to query both user and
 // admin information
 type Querier struct {
-	db worldstate.DB
-	// TODO: cache to reduce the number of DB access
-	// a listener to invalidate committed entries
+	db    worldstate.DB
+	cache *Cache
 }
 
 // NewQuerier returns a querier to fetch identity
 // and related credentials
 func NewQuerier(db worldstate.DB) *Querier {
+	return NewCachedQuerier(db, nil)
+}
+
+// NewCachedQuerier returns a querier to fetch identity and related
+// credentials, which caches the user and node records in the given
+// cache. The cache must be invalidated by the committer of the db
+func NewCachedQuerier(db worldstate.DB, cache *Cache) *Querier {
 	return &Querier{
-		db: db,
+		db:    db,
+		cache: cache,
 	}
 }
 
 // DoesUserExist returns true if the given user exist. Otherwise, it
 // return false
 func (q *Querier) DoesUserExist(userID string) (bool, error) {
-	exist, err := q.db.Has(worldstate.UsersDBName, string(UserNamespace)+userID)
+	if q.cache == nil {
+		exist, err := q.db.Has(worldstate.UsersDBName, string(UserNamespace)+userID)
+		if err != nil {
+			return false, errors.Wrapf(err, "error while checking the existance of the userID [%s]", userID)
+		}
+
+		return exist, nil
+	}
+
+	r, err := q.userRecord(userID)
 	if err != nil {
-		return false, errors.Wrapf(err, "error while checking the existance of the userID [%s]", userID)
+		return false, err
 	}
 
-	return exist, nil
+	return r.user != nil, nil
 }
 
 // GetUser returns the credentials associated with the given
 // non-admin userID
 func (q *Querier) GetUser(userID string) (*types.User, *types.Metadata, error) {
-	val, meta, err := q.db.Get(worldstate.UsersDBName, string(UserNamespace)+userID)
+	r, err := q.userRecord(userID)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "error while fetching userID [%s]", userID)
+		return nil, nil, err
 	}
 
-	if val == nil {
+	if r.user == nil {
 		return nil, nil, &NotFoundErr{
 			id: userID,
 		}
 	}
 
-	user := &types.User{}
-	if err := proto.Unmarshal(val, user); err != nil {
-		return nil, nil, errors.Wrapf(err, "error while unmarshaling persisted value of userID [%s]", userID)
+	if q.cache == nil {
+		return r.user, r.metadata, nil
 	}
+	// the cached record is shared, hence the caller gets a copy
+	return proto.Clone(r.user).(*types.User), cloneMetadata(r.metadata), nil
+}
+
+func (q *Querier) userRecord(userID string) (*cachedRecord, error) {
+	key := string(UserNamespace) + userID
+	return q.cache.lookup(cacheKey{dbName: worldstate.UsersDBName, key: key}, func() (*cachedRecord, error) {
+		val, meta, err := q.db.Get(worldstate.UsersDBName, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while fetching userID [%s]", userID)
+		}
 
-	return user, meta, nil
+		if val == nil {
+			return &cachedRecord{}, nil
+		}
+
+		user := &types.User{}
+		if err := proto.Unmarshal(val, user); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling persisted value of userID [%s]", userID)
+		}
+
+		return &cachedRecord{user: user, metadata: meta}, nil
+	})
+}
+
+// GetUsers returns all users, ordered by their userID, along with their metadata
+func (q *Querier) GetUsers() ([]*types.User, []*types.Metadata, error) {
+	itr, err := q.db.GetIterator(worldstate.UsersDBName, string(UserNamespace), "")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error while iterating over users")
+	}
+	defer itr.Release()
+
+	var users []*types.User
+	var metadata []*types.Metadata
+	for itr.Next() {
+		if !bytes.HasPrefix(itr.Key(), UserNamespace) {
+			break
+		}
+
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, nil, errors.Wrapf(err, "error while unmarshaling persisted value of key [%s]", itr.Key())
+		}
+
+		user := &types.User{}
+		if err := proto.Unmarshal(persisted.Value, user); err != nil {
+			return nil, nil, errors.Wrapf(err, "error while unmarshaling persisted value of key [%s]", itr.Key())
+		}
+
+		users = append(users, user)
+		metadata = append(metadata, persisted.Metadata)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, nil, errors.Wrap(err, "error while iterating over users")
+	}
+
+	return users, metadata, nil
 }
 
 // GetAccessControl returns the ACL defined on the userID
@@ -71,22 +144,42 @@ func (q *Querier) GetAccessControl(userID string) (*types.AccessControl, error)
 	return metadata.GetAccessControl(), nil
 }
 
-//TODO keep a cache of user and parsed certificates to avoid going to the DB and parsing the certificate
-// on every TX. Provide a mechanism to invalidate the cache when the user database changes.
-
-// GetCertificate returns the current certificate associated with a given userID
+// GetCertificate returns the current certificate associated with a given userID.
+// As the certificate is used to authenticate the user, a DisabledErr is returned
+// if the user is disabled.
 func (q *Querier) GetCertificate(userID string) (*x509.Certificate, error) {
-	user, _, err := q.GetUser(userID)
+	r, err := q.userRecord(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	cert, err := x509.ParseCertificate(user.Certificate)
+	if r.user == nil {
+		return nil, &NotFoundErr{
+			id: userID,
+		}
+	}
+
+	if r.user.GetDisabled() {
+		return nil, NewDisabledErr(userID)
+	}
+
+	return r.certificate()
+}
+
+// GetSigningKeys returns the API signing keys registered to a given userID,
+// including revoked and expired keys. As the keys are used to authenticate
+// the user, a DisabledErr is returned if the user is disabled.
+func (q *Querier) GetSigningKeys(userID string) ([]*types.SigningKey, error) {
+	user, _, err := q.GetUser(userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return cert, nil
+	if user.GetDisabled() {
+		return nil, NewDisabledErr(userID)
+	}
+
+	return user.GetSigningKeys(), nil
 }
 
 // GetUserVersion returns the current version of a given userID
@@ -122,6 +215,41 @@ func (q *Querier) HasAdministrationPrivilege(userID string) (bool, error) {
 	return user.GetPrivilege().GetAdmin(), nil
 }
 
+// HasDBAdministrationPrivilege returns true if the given userID has privilege to create,
+// delete, or index the given dbName. An admin has this privilege on every database while
+// a non-admin user has it only on the databases whose name path starts with one of the
+// user's db administration prefixes, as whole path segments, i.e., the prefix "team1"
+// covers "team1" and "team1/db1" but not "team10".
+func (q *Querier) HasDBAdministrationPrivilege(userID, dbName string) (bool, error) {
+	user, _, err := q.GetUser(userID)
+	if err != nil {
+		return false, err
+	}
+
+	if user.GetPrivilege().GetAdmin() {
+		return true, nil
+	}
+
+	for _, prefix := range user.GetPrivilege().GetDbAdministrationPrefixes() {
+		if prefix != "" && (dbName == prefix || strings.HasPrefix(dbName, prefix+"/")) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// HasProvenanceReadPrivilege returns true if the given userID has privilege to query
+// the provenance of other users. An admin has this privilege implicitly
+func (q *Querier) HasProvenanceReadPrivilege(userID string) (bool, error) {
+	user, _, err := q.GetUser(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return user.GetPrivilege().GetAdmin() || user.GetPrivilege().GetProvenanceRead(), nil
+}
+
 // HasReadAccessOnTargetUser returns true if the srcUser can read the targetUser
 func (q *Querier) HasReadAccessOnTargetUser(srcUser, targetUser string) (bool, error) {
 	acl, err := q.GetAccessControl(targetUser)
@@ -151,26 +279,89 @@ func (q *Querier) HasLedgerAccess(userID string) (bool, error) {
 	return q.DoesUserExist(userID)
 }
 
+// DefaultEndpointPrivileges holds the privilege required to query each group of endpoints, unless the access policy
+// of the cluster config requires another privilege
+var DefaultEndpointPrivileges = map[types.EndpointPolicy_Group]types.EndpointPolicy_Privilege{
+	types.EndpointPolicy_LEDGER:         types.EndpointPolicy_AUTHENTICATED,
+	types.EndpointPolicy_PROVENANCE:     types.EndpointPolicy_AUTHENTICATED,
+	types.EndpointPolicy_CONFIG_HISTORY: types.EndpointPolicy_ADMIN,
+	types.EndpointPolicy_OPERATIONS:     types.EndpointPolicy_ADMIN,
+}
+
+// HasEndpointAccess returns true if the given userID has the privilege that the access policy
+// of the cluster config requires to query the given group of endpoints
+func (q *Querier) HasEndpointAccess(userID string, group types.EndpointPolicy_Group) (bool, error) {
+	privilege, err := q.requiredEndpointPrivilege(group)
+	if err != nil {
+		return false, err
+	}
+
+	switch privilege {
+	case types.EndpointPolicy_AUTHENTICATED:
+		return q.DoesUserExist(userID)
+	case types.EndpointPolicy_PROVENANCE_READ:
+		return q.HasProvenanceReadPrivilege(userID)
+	case types.EndpointPolicy_ADMIN:
+		return q.HasAdministrationPrivilege(userID)
+	default:
+		return false, errors.Errorf("unknown privilege [%s] required to query the endpoints of group [%s]", privilege, group)
+	}
+}
+
+func (q *Querier) requiredEndpointPrivilege(group types.EndpointPolicy_Group) (types.EndpointPolicy_Privilege, error) {
+	config, _, err := q.db.GetConfig()
+	if err != nil {
+		return 0, errors.WithMessage(err, "error while fetching the access policy")
+	}
+
+	for _, p := range config.GetAccessPolicy().GetEndpoints() {
+		if p.Group == group {
+			return p.Privilege, nil
+		}
+	}
+
+	privilege, ok := DefaultEndpointPrivileges[group]
+	if !ok {
+		return 0, errors.Errorf("unknown group of endpoints [%s]", group)
+	}
+	return privilege, nil
+}
+
 // GetNode returns the credentials associated with the given
 // node ID
 func (q *Querier) GetNode(nodeID string) (*types.NodeConfig, *types.Metadata, error) {
-	val, meta, err := q.db.Get(worldstate.ConfigDBName, string(NodeNamespace)+nodeID)
+	key := string(NodeNamespace) + nodeID
+	r, err := q.cache.lookup(cacheKey{dbName: worldstate.ConfigDBName, key: key}, func() (*cachedRecord, error) {
+		val, meta, err := q.db.Get(worldstate.ConfigDBName, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while fetching nodeID [%s]", nodeID)
+		}
+
+		if val == nil {
+			return &cachedRecord{}, nil
+		}
+
+		node := &types.NodeConfig{}
+		if err := proto.Unmarshal(val, node); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling persisted value of nodeID [%s]", nodeID)
+		}
+
+		return &cachedRecord{node: node, metadata: meta}, nil
+	})
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "error while fetching nodeID [%s]", nodeID)
+		return nil, nil, err
 	}
 
-	if val == nil {
+	if r.node == nil {
 		return nil, nil, &NotFoundErr{
 			id: nodeID,
 		}
 	}
 
-	node := &types.NodeConfig{}
-	if err := proto.Unmarshal(val, node); err != nil {
-		return nil, nil, errors.Wrapf(err, "error while unmarshaling persisted value of nodeID [%s]", nodeID)
+	if q.cache == nil {
+		return r.node, r.metadata, nil
 	}
-
-	return node, meta, nil
+	return proto.Clone(r.node).(*types.NodeConfig), cloneMetadata(r.metadata), nil
 }
 
 // GetNodeVersion returns the current version of a given nodeID
@@ -183,6 +374,13 @@ func (q *Querier) GetNodeVersion(nodeID string) (*types.Version, error) {
 	return metadata.Version, nil
 }
 
+func cloneMetadata(metadata *types.Metadata) *types.Metadata {
+	if metadata == nil {
+		return nil
+	}
+	return proto.Clone(metadata).(*types.Metadata)
+}
+
 func (q *Querier) hasPrivilege(userID, dbName string, privilege types.Privilege_Access) (bool, error) {
 	user, _, err := q.GetUser(userID)
 	if err != nil {
@@ -214,3 +412,19 @@ type NotFoundErr struct {
 func (e *NotFoundErr) Error() string {
 	return fmt.Sprintf("the user [%s] does not exist", e.id)
 }
+
+// DisabledErr denotes that the user exists in the worldstate but is disabled
+type DisabledErr struct {
+	id string
+}
+
+// NewDisabledErr returns a DisabledErr for the given userID
+func NewDisabledErr(userID string) *DisabledErr {
+	return &DisabledErr{
+		id: userID,
+	}
+}
+
+func (e *DisabledErr) Error() string {
+	return fmt.Sprintf("the user [%s] is disabled", e.id)
+}
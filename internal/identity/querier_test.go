@@ -293,6 +293,161 @@ func TestQuerier(t *testing.T) {
 		require.Contains(t, err.Error(), "asn1: structure error: tags don't match")
 		require.Nil(t, cert)
 	})
+
+	t.Run("disabled user", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+
+		user := &types.User{
+			Id:          "disabledUser",
+			Certificate: certRaw,
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{
+					"db1": types.Privilege_Read,
+				},
+			},
+			Disabled: true,
+		}
+		setup(env.db, user)
+
+		cert, err := env.q.GetCertificate(user.Id)
+		require.EqualError(t, err, "the user [disabledUser] is disabled")
+		require.IsType(t, &DisabledErr{}, err)
+		require.Nil(t, cert)
+
+		exist, err := env.q.DoesUserExist(user.Id)
+		require.NoError(t, err)
+		require.True(t, exist)
+
+		persistedUser, _, err := env.q.GetUser(user.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(user, persistedUser))
+	})
+
+	t.Run("signing keys", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+
+		user := &types.User{
+			Id:          "userWithKeys",
+			Certificate: certRaw,
+			SigningKeys: []*types.SigningKey{
+				{KeyId: "key1", PublicKey: []byte("key1")},
+				{KeyId: "key2", PublicKey: []byte("key2"), Revoked: true},
+			},
+		}
+		setup(env.db, user)
+
+		keys, err := env.q.GetSigningKeys(user.Id)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		require.True(t, proto.Equal(user.SigningKeys[0], keys[0]))
+		require.True(t, proto.Equal(user.SigningKeys[1], keys[1]))
+
+		user.Disabled = true
+		setup(env.db, user)
+		keys, err = env.q.GetSigningKeys(user.Id)
+		require.EqualError(t, err, "the user [userWithKeys] is disabled")
+		require.Nil(t, keys)
+
+		keys, err = env.q.GetSigningKeys("nouser")
+		require.EqualError(t, err, "the user [nouser] does not exist")
+		require.Nil(t, keys)
+	})
+
+	t.Run("db administration prefixes", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+
+		user := &types.User{
+			Id:          "teamAdmin",
+			Certificate: certRaw,
+			Privilege: &types.Privilege{
+				DbAdministrationPrefixes: []string{"team1", "team2/apps"},
+			},
+		}
+		setup(env.db, user)
+
+		for _, dbName := range []string{"team1", "team1/db1", "team2/apps/db1"} {
+			perm, err := env.q.HasDBAdministrationPrivilege(user.Id, dbName)
+			require.NoError(t, err)
+			require.True(t, perm, dbName)
+		}
+
+		// the prefixes match whole segments of the database name path
+		for _, dbName := range []string{"team10", "team1-db1", "team2/apps1", "team2/db1", "team3/db1"} {
+			perm, err := env.q.HasDBAdministrationPrivilege(user.Id, dbName)
+			require.NoError(t, err)
+			require.False(t, perm, dbName)
+		}
+
+		perm, err := env.q.HasAdministrationPrivilege(user.Id)
+		require.NoError(t, err)
+		require.False(t, perm)
+
+		user.Privilege = &types.Privilege{Admin: true}
+		setup(env.db, user)
+		perm, err = env.q.HasDBAdministrationPrivilege(user.Id, "team3/db1")
+		require.NoError(t, err)
+		require.True(t, perm)
+
+		perm, err = env.q.HasDBAdministrationPrivilege("nouser", "team1/db1")
+		require.EqualError(t, err, "the user [nouser] does not exist")
+		require.False(t, perm)
+	})
+
+	t.Run("provenance read privilege", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+
+		user := &types.User{
+			Id:          "auditor",
+			Certificate: certRaw,
+		}
+		setup(env.db, user)
+
+		perm, err := env.q.HasProvenanceReadPrivilege(user.Id)
+		require.NoError(t, err)
+		require.False(t, perm)
+
+		user.Privilege = &types.Privilege{ProvenanceRead: true}
+		setup(env.db, user)
+		perm, err = env.q.HasProvenanceReadPrivilege(user.Id)
+		require.NoError(t, err)
+		require.True(t, perm)
+
+		user.Privilege = &types.Privilege{Admin: true}
+		setup(env.db, user)
+		perm, err = env.q.HasProvenanceReadPrivilege(user.Id)
+		require.NoError(t, err)
+		require.True(t, perm)
+	})
+
+	t.Run("GetUsers", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+
+		users, metadata, err := env.q.GetUsers()
+		require.NoError(t, err)
+		require.Empty(t, users)
+		require.Empty(t, metadata)
+
+		for _, userID := range []string{"carol", "alice", "bob"} {
+			setup(env.db, &types.User{
+				Id:          userID,
+				Certificate: certRaw,
+			})
+		}
+
+		users, metadata, err = env.q.GetUsers()
+		require.NoError(t, err)
+		require.Len(t, users, 3)
+		require.Len(t, metadata, 3)
+		for i, userID := range []string{"alice", "bob", "carol"} {
+			require.Equal(t, userID, users[i].Id)
+			require.True(t, proto.Equal(sampleMetadata, metadata[i]))
+		}
+	})
 }
 
 func TestQuerierNonExistingUser(t *testing.T) {
@@ -362,3 +517,76 @@ func TestQuerierNonExistingUser(t *testing.T) {
 		require.False(t, perm)
 	})
 }
+
+func TestHasEndpointAccess(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	users := []*types.User{
+		{Id: "user"},
+		{Id: "auditor", Privilege: &types.Privilege{ProvenanceRead: true}},
+		{Id: "admin", Privilege: &types.Privilege{Admin: true}},
+	}
+	userUpdates := &worldstate.DBUpdates{}
+	for _, u := range users {
+		user, err := proto.Marshal(u)
+		require.NoError(t, err)
+		userUpdates.Writes = append(userUpdates.Writes, &worldstate.KVWithMetadata{
+			Key:      string(UserNamespace) + u.Id,
+			Value:    user,
+			Metadata: &types.Metadata{Version: &types.Version{BlockNum: 1}},
+		})
+	}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{worldstate.UsersDBName: userUpdates}, 1))
+
+	requireAccess := func(group types.EndpointPolicy_Group, expected map[string]bool) {
+		for userID, expectedAccess := range expected {
+			hasAccess, err := env.q.HasEndpointAccess(userID, group)
+			require.NoError(t, err)
+			require.Equal(t, expectedAccess, hasAccess, "user [%s], group [%s]", userID, group)
+		}
+	}
+
+	t.Run("default policy", func(t *testing.T) {
+		all := map[string]bool{"user": true, "auditor": true, "admin": true, "unknown": false}
+		requireAccess(types.EndpointPolicy_LEDGER, all)
+		requireAccess(types.EndpointPolicy_PROVENANCE, all)
+		adminOnly := map[string]bool{"user": false, "auditor": false, "admin": true}
+		requireAccess(types.EndpointPolicy_CONFIG_HISTORY, adminOnly)
+		requireAccess(types.EndpointPolicy_OPERATIONS, adminOnly)
+	})
+
+	t.Run("configured policy", func(t *testing.T) {
+		config, err := proto.Marshal(&types.ClusterConfig{
+			AccessPolicy: &types.AccessPolicy{
+				Endpoints: []*types.EndpointPolicy{
+					{Group: types.EndpointPolicy_PROVENANCE, Privilege: types.EndpointPolicy_PROVENANCE_READ},
+					{Group: types.EndpointPolicy_CONFIG_HISTORY, Privilege: types.EndpointPolicy_AUTHENTICATED},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      worldstate.ConfigKey,
+						Value:    config,
+						Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}},
+					},
+				},
+			},
+		}, 2))
+
+		requireAccess(types.EndpointPolicy_LEDGER, map[string]bool{"user": true, "auditor": true, "admin": true})
+		requireAccess(types.EndpointPolicy_PROVENANCE, map[string]bool{"user": false, "auditor": true, "admin": true})
+		requireAccess(types.EndpointPolicy_CONFIG_HISTORY, map[string]bool{"user": true, "auditor": true, "admin": true})
+		requireAccess(types.EndpointPolicy_OPERATIONS, map[string]bool{"user": false, "auditor": false, "admin": true})
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		hasAccess, err := env.q.HasEndpointAccess("admin", types.EndpointPolicy_Group(10))
+		require.EqualError(t, err, "unknown group of endpoints [10]")
+		require.False(t, hasAccess)
+	})
+}
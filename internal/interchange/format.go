@@ -0,0 +1,237 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package interchange defines the ledger interchange format, a versioned, self-describing representation of a ledger
+// that does not depend on the on-disk format of the block store. It is used to migrate a ledger between storage
+// backends, and to archive a ledger for the long term.
+//
+// An archive is a UTF-8 text stream of newline-terminated JSON records. The first record is the Header, which
+// identifies the format and its version, and describes the ledger: its height, the hashes of its first and last
+// blocks, and the CA material of the cluster config in effect at its last block. Each following record holds one
+// block, in the canonical JSON mapping of protocol buffers, starting with the genesis block and in ascending order of
+// block numbers. The blocks are chained by the hash of the base header of their predecessor, which the Reader
+// verifies, such that a truncated, reordered, or modified archive is detected.
+package interchange
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Format identifies a ledger interchange archive.
+	Format = "orion-ledger"
+	// Version is the version of the format written by the Writer. A Reader reads every version up to Version.
+	Version uint32 = 1
+)
+
+// Header is the first record of an archive.
+type Header struct {
+	// Format is always the Format constant.
+	Format string `json:"format"`
+	// Version is the version of the format of the archive.
+	Version uint32 `json:"version"`
+	// Height is the number of blocks in the archive.
+	Height uint64 `json:"height"`
+	// GenesisHash is the hash of the genesis block.
+	GenesisHash []byte `json:"genesis_hash"`
+	// LastBlockHash is the hash of the last block.
+	LastBlockHash []byte `json:"last_block_hash"`
+	// CAConfig holds the certificates of the root and intermediate CAs of the cluster config in effect at the last
+	// block, with which the signatures of the transactions of the recent blocks can be verified without a node.
+	CAConfig *types.CAConfig `json:"ca_config"`
+	// ExportedBy is the ID of the node that exported the ledger.
+	ExportedBy string `json:"exported_by,omitempty"`
+	// ExportedAt is the time of the export, in Unix nanoseconds.
+	ExportedAt int64 `json:"exported_at,omitempty"`
+}
+
+// Writer writes an archive.
+type Writer struct {
+	w         *bufio.Writer
+	header    *Header
+	marshaler *jsonpb.Marshaler
+	written   uint64
+}
+
+// NewWriter writes the header of an archive and returns a Writer for its blocks. The Format and Version of the header
+// are set by the Writer.
+func NewWriter(w io.Writer, header *Header) (*Writer, error) {
+	header.Format = Format
+	header.Version = Version
+
+	bw := bufio.NewWriter(w)
+	record, err := json.Marshal(header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the archive header")
+	}
+	if err = writeRecord(bw, record); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		w:         bw,
+		header:    header,
+		marshaler: &jsonpb.Marshaler{},
+	}, nil
+}
+
+// WriteBlock writes the next block, which must follow the previous one.
+func (w *Writer) WriteBlock(block *types.Block) error {
+	number := block.GetHeader().GetBaseHeader().GetNumber()
+	if number != w.written+1 {
+		return errors.Errorf("block [%d] is written while expecting block [%d]", number, w.written+1)
+	}
+	if number > w.header.Height {
+		return errors.Errorf("block [%d] is beyond the height of the archive [%d]", number, w.header.Height)
+	}
+
+	var record bytes.Buffer
+	if err := w.marshaler.Marshal(&record, block); err != nil {
+		return errors.Wrapf(err, "failed to marshal block [%d]", number)
+	}
+	if err := writeRecord(w.w, record.Bytes()); err != nil {
+		return err
+	}
+	w.written = number
+
+	return nil
+}
+
+// Close flushes the archive, and returns an error if fewer blocks than the height of the archive were written.
+func (w *Writer) Close() error {
+	if err := w.w.Flush(); err != nil {
+		return errors.Wrap(err, "failed to flush the archive")
+	}
+	if w.written != w.header.Height {
+		return errors.Errorf("the archive holds [%d] blocks, while its height is [%d]", w.written, w.header.Height)
+	}
+
+	return nil
+}
+
+func writeRecord(w *bufio.Writer, record []byte) error {
+	if _, err := w.Write(record); err != nil {
+		return errors.Wrap(err, "failed to write to the archive")
+	}
+	if err := w.WriteByte('\n'); err != nil {
+		return errors.Wrap(err, "failed to write to the archive")
+	}
+	return nil
+}
+
+// Reader reads an archive, and verifies that its blocks are consecutive and chained.
+type Reader struct {
+	r            *bufio.Reader
+	header       *Header
+	unmarshaler  *jsonpb.Unmarshaler
+	read         uint64
+	prevBaseHash []byte
+}
+
+// NewReader reads the header of an archive and returns a Reader for its blocks.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	record, err := readRecord(br)
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("the archive is empty")
+		}
+		return nil, err
+	}
+
+	header := &Header{}
+	if err = json.Unmarshal(record, header); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the archive header")
+	}
+	if header.Format != Format {
+		return nil, errors.Errorf("the format of the archive is [%s], expected [%s]", header.Format, Format)
+	}
+	if header.Version == 0 || header.Version > Version {
+		return nil, errors.Errorf("the version of the archive is [%d], while versions up to [%d] are supported", header.Version, Version)
+	}
+
+	return &Reader{
+		r:           br,
+		header:      header,
+		unmarshaler: &jsonpb.Unmarshaler{},
+	}, nil
+}
+
+// Header returns the header of the archive.
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+// Next returns the next block, or io.EOF after the last block. An error is returned if the block does not follow the
+// previous one, or if the hash of the genesis block or of the last block does not match the header.
+func (r *Reader) Next() (*types.Block, error) {
+	if r.read == r.header.Height {
+		return nil, io.EOF
+	}
+
+	record, err := readRecord(r.r)
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.Errorf("the archive is truncated after block [%d], while its height is [%d]", r.read, r.header.Height)
+		}
+		return nil, err
+	}
+
+	expected := r.read + 1
+	block := &types.Block{}
+	if err = r.unmarshaler.Unmarshal(bytes.NewReader(record), block); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal block [%d]", expected)
+	}
+
+	baseHeader := block.GetHeader().GetBaseHeader()
+	if baseHeader.GetNumber() != expected {
+		return nil, errors.Errorf("the archive holds block [%d] while expecting block [%d]", baseHeader.GetNumber(), expected)
+	}
+	if expected > 1 && !bytes.Equal(baseHeader.GetPreviousBaseHeaderHash(), r.prevBaseHash) {
+		return nil, errors.Errorf("the previous base header hash of block [%d] does not match the base header hash of block [%d]", expected, expected-1)
+	}
+
+	if expected == 1 || expected == r.header.Height {
+		hash, err := blockstore.ComputeBlockHash(block)
+		if err != nil {
+			return nil, err
+		}
+		if expected == 1 && !bytes.Equal(hash, r.header.GenesisHash) {
+			return nil, errors.New("the hash of the genesis block does not match the header of the archive")
+		}
+		if expected == r.header.Height && !bytes.Equal(hash, r.header.LastBlockHash) {
+			return nil, errors.Errorf("the hash of the last block [%d] does not match the header of the archive", expected)
+		}
+	}
+
+	if r.prevBaseHash, err = blockstore.ComputeBlockBaseHash(block); err != nil {
+		return nil, err
+	}
+	r.read = expected
+
+	return block, nil
+}
+
+// readRecord reads a newline-terminated record, of any length.
+func readRecord(r *bufio.Reader) ([]byte, error) {
+	record, err := r.ReadBytes('\n')
+	if err != nil {
+		if err == io.EOF && len(record) > 0 {
+			return nil, errors.New("the last record of the archive is not terminated")
+		}
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, errors.Wrap(err, "failed to read from the archive")
+	}
+
+	return record[:len(record)-1], nil
+}
@@ -0,0 +1,208 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package interchange
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain returns a chain of blocks, each linked to its predecessor by the hash of its base header.
+func testChain(t *testing.T, n uint64) []*types.Block {
+	var blocks []*types.Block
+	var prevBaseHash, prevHash []byte
+	for number := uint64(1); number <= n; number++ {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                 number,
+					PreviousBaseHeaderHash: prevBaseHash,
+					LastCommittedBlockHash: prevHash,
+					LastCommittedBlockNum:  number - 1,
+				},
+				ValidationInfo: []*types.ValidationInfo{{Flag: types.Flag_VALID}},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"alice"},
+								TxId:            "tx" + string(rune('0'+number)),
+								DbOperations: []*types.DBOperation{
+									{
+										DbName:     "bdb",
+										DataWrites: []*types.DataWrite{{Key: "key", Value: []byte{byte(number)}}},
+									},
+								},
+							},
+							Signatures: map[string][]byte{"alice": []byte("sig")},
+						},
+					},
+				},
+			},
+		}
+
+		var err error
+		prevBaseHash, err = blockstore.ComputeBlockBaseHash(block)
+		require.NoError(t, err)
+		prevHash, err = blockstore.ComputeBlockHash(block)
+		require.NoError(t, err)
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func writeArchive(t *testing.T, blocks []*types.Block) []byte {
+	genesisHash, err := blockstore.ComputeBlockHash(blocks[0])
+	require.NoError(t, err)
+	lastBlockHash, err := blockstore.ComputeBlockHash(blocks[len(blocks)-1])
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	w, err := NewWriter(&archive, &Header{
+		Height:        uint64(len(blocks)),
+		GenesisHash:   genesisHash,
+		LastBlockHash: lastBlockHash,
+		CAConfig:      &types.CAConfig{Roots: [][]byte{[]byte("root-ca")}},
+		ExportedBy:    "node1",
+	})
+	require.NoError(t, err)
+	for _, block := range blocks {
+		require.NoError(t, w.WriteBlock(block))
+	}
+	require.NoError(t, w.Close())
+
+	return archive.Bytes()
+}
+
+func readArchive(archive []byte) ([]*types.Block, error) {
+	r, err := NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*types.Block
+	for {
+		block, err := r.Next()
+		if err == io.EOF {
+			return blocks, nil
+		}
+		if err != nil {
+			return blocks, err
+		}
+		blocks = append(blocks, block)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	blocks := testChain(t, 5)
+	archive := writeArchive(t, blocks)
+	require.Equal(t, 6, bytes.Count(archive, []byte("\n")))
+
+	r, err := NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	header := r.Header()
+	require.Equal(t, Format, header.Format)
+	require.Equal(t, Version, header.Version)
+	require.Equal(t, uint64(5), header.Height)
+	require.Equal(t, "node1", header.ExportedBy)
+	require.True(t, proto.Equal(&types.CAConfig{Roots: [][]byte{[]byte("root-ca")}}, header.CAConfig))
+
+	read, err := readArchive(archive)
+	require.NoError(t, err)
+	require.Len(t, read, 5)
+	for i := range blocks {
+		require.True(t, proto.Equal(blocks[i], read[i]), "block %d", i+1)
+	}
+}
+
+func TestWriter_Errors(t *testing.T) {
+	blocks := testChain(t, 3)
+
+	t.Run("block out of order", func(t *testing.T) {
+		w, err := NewWriter(&bytes.Buffer{}, &Header{Height: 3})
+		require.NoError(t, err)
+		require.EqualError(t, w.WriteBlock(blocks[1]), "block [2] is written while expecting block [1]")
+	})
+
+	t.Run("block beyond the height", func(t *testing.T) {
+		w, err := NewWriter(&bytes.Buffer{}, &Header{Height: 1})
+		require.NoError(t, err)
+		require.NoError(t, w.WriteBlock(blocks[0]))
+		require.EqualError(t, w.WriteBlock(blocks[1]), "block [2] is beyond the height of the archive [1]")
+	})
+
+	t.Run("missing blocks", func(t *testing.T) {
+		w, err := NewWriter(&bytes.Buffer{}, &Header{Height: 3})
+		require.NoError(t, err)
+		require.NoError(t, w.WriteBlock(blocks[0]))
+		require.EqualError(t, w.Close(), "the archive holds [1] blocks, while its height is [3]")
+	})
+}
+
+func TestReader_Errors(t *testing.T) {
+	blocks := testChain(t, 3)
+	archive := writeArchive(t, blocks)
+	records := strings.SplitAfter(string(archive), "\n")
+	require.Len(t, records, 5) // the header, 3 blocks, and the empty string after the last newline
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := NewReader(strings.NewReader(""))
+		require.EqualError(t, err, "the archive is empty")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewReader(strings.NewReader(`{"format":"other","version":1}` + "\n"))
+		require.EqualError(t, err, "the format of the archive is [other], expected [orion-ledger]")
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := NewReader(strings.NewReader(`{"format":"orion-ledger","version":2}` + "\n"))
+		require.EqualError(t, err, "the version of the archive is [2], while versions up to [1] are supported")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		read, err := readArchive([]byte(strings.Join(records[:3], "")))
+		require.EqualError(t, err, "the archive is truncated after block [2], while its height is [3]")
+		require.Len(t, read, 2)
+	})
+
+	t.Run("unterminated record", func(t *testing.T) {
+		truncated := strings.Join(records[:4], "")
+		_, err := readArchive([]byte(truncated[:len(truncated)-1]))
+		require.EqualError(t, err, "the last record of the archive is not terminated")
+	})
+
+	t.Run("reordered", func(t *testing.T) {
+		reordered := records[0] + records[2] + records[1] + records[3]
+		_, err := readArchive([]byte(reordered))
+		require.EqualError(t, err, "the archive holds block [2] while expecting block [1]")
+	})
+
+	t.Run("broken chain", func(t *testing.T) {
+		modified := proto.Clone(blocks[1]).(*types.Block)
+		modified.Header.BaseHeader.LastCommittedBlockNum = 0
+		record, err := (&jsonpb.Marshaler{}).MarshalToString(modified)
+		require.NoError(t, err)
+
+		_, err = readArchive([]byte(records[0] + records[1] + record + "\n" + records[3]))
+		require.EqualError(t, err, "the previous base header hash of block [3] does not match the base header hash of block [2]")
+	})
+
+	t.Run("genesis hash mismatch", func(t *testing.T) {
+		r, err := NewReader(strings.NewReader(strings.Replace(records[0], `"genesis_hash":"`, `"genesis_hash":"AAAA`, 1) + records[1]))
+		require.NoError(t, err)
+		_, err = r.Next()
+		require.EqualError(t, err, "the hash of the genesis block does not match the header of the archive")
+	})
+}
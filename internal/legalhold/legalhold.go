@@ -0,0 +1,136 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package legalhold
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// keySeparator separates the database name from the key in the keys of the legal holds database. A database name
+// cannot hold it, hence the holds of a database are stored in a contiguous range.
+const keySeparator = "\x00"
+
+// Key returns the key of the legal hold on a key of a database, or on the database as a whole if the key is empty
+func Key(dbName, key string) string {
+	return dbName + keySeparator + key
+}
+
+// Target describes the key or the database a legal hold is placed on
+func Target(h *types.LegalHold) string {
+	if h.Key == "" {
+		return "the database [" + h.DbName + "]"
+	}
+	return "the key [" + h.Key + "] of the database [" + h.DbName + "]"
+}
+
+// LogTarget describes the key or the database a legal hold is placed on as it may be logged, i.e., with the key
+// masked by the logger
+func LogTarget(lg *logger.SugarLogger, h *types.LegalHold) string {
+	return Target(&types.LegalHold{DbName: h.DbName, Key: lg.MaskKey(h.Key)})
+}
+
+// Get returns the legal hold placed on a key of a database, or on the database as a whole if the key is empty. It
+// returns nil if there is no such hold.
+func Get(db worldstate.DB, dbName, key string) (*types.LegalHold, error) {
+	val, _, err := db.Get(worldstate.LegalHoldsDBName, Key(dbName, key))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while fetching the legal hold on key [%s] of database [%s]", key, dbName)
+	}
+	if val == nil {
+		return nil, nil
+	}
+
+	hold := &types.LegalHold{}
+	if err := proto.Unmarshal(val, hold); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the legal hold on key [%s] of database [%s]", key, dbName)
+	}
+	return hold, nil
+}
+
+// Holding returns the legal hold that preserves a key of a database, i.e., the hold on the key or else, the hold on
+// the database. It returns nil if the key is not under a legal hold.
+func Holding(db worldstate.DB, dbName, key string) (*types.LegalHold, error) {
+	hold, err := Get(db, dbName, key)
+	if err != nil || hold != nil {
+		return hold, err
+	}
+
+	return Get(db, dbName, "")
+}
+
+// DBHolds returns the legal holds placed on a database and its keys, ordered by key. The hold on the database as a
+// whole, if any, comes first.
+func DBHolds(db worldstate.DB, dbName string) ([]*types.LegalHold, error) {
+	itr, err := db.GetIterator(worldstate.LegalHoldsDBName, dbName+keySeparator, "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while iterating over legal holds")
+	}
+	defer itr.Release()
+
+	var holds []*types.LegalHold
+	for itr.Next() {
+		if !strings.HasPrefix(string(itr.Key()), dbName+keySeparator) {
+			break
+		}
+
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling persisted value of key [%s]", itr.Key())
+		}
+
+		hold := &types.LegalHold{}
+		if err := proto.Unmarshal(persisted.Value, hold); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling the legal hold of key [%s]", itr.Key())
+		}
+		holds = append(holds, hold)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over legal holds")
+	}
+
+	return holds, nil
+}
+
+// ConstructDBEntriesForDBAdminTx constructs the entries of the legal holds database for the holds placed and
+// released by a database administration transaction. It returns nil if the transaction neither places nor releases
+// a hold.
+func ConstructDBEntriesForDBAdminTx(tx *types.DBAdministrationTx, version *types.Version) (*worldstate.DBUpdates, error) {
+	if len(tx.PlaceLegalHolds) == 0 && len(tx.ReleaseLegalHolds) == 0 {
+		return nil, nil
+	}
+
+	updates := &worldstate.DBUpdates{}
+	for _, h := range tx.PlaceLegalHolds {
+		hold := &types.LegalHold{
+			DbName:   h.DbName,
+			Key:      h.Key,
+			Reason:   h.Reason,
+			PlacedBy: tx.UserId,
+		}
+		holdSerialized, err := proto.Marshal(hold)
+		if err != nil {
+			return nil, errors.Wrap(err, "error while marshaling legal hold")
+		}
+
+		updates.Writes = append(updates.Writes, &worldstate.KVWithMetadata{
+			Key:   Key(h.DbName, h.Key),
+			Value: holdSerialized,
+			Metadata: &types.Metadata{
+				Version: version,
+			},
+		})
+	}
+
+	for _, h := range tx.ReleaseLegalHolds {
+		updates.Deletes = append(updates.Deletes, Key(h.DbName, h.Key))
+	}
+
+	return updates, nil
+}
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package legalhold
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) worldstate.DB {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("/tmp", "legalhold")
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(&leveldb.Config{
+		DBRootDir: filepath.Join(dir, "leveldb"),
+		Logger:    lg,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close the db instance, %v", err)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("failed to remove directory %s, %v", dir, err)
+		}
+	})
+	return db
+}
+
+func TestLegalHolds(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	updates, err := ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{CreateDbs: []string{"db1"}}, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.Nil(t, updates)
+
+	tx := &types.DBAdministrationTx{
+		UserId: "admin",
+		PlaceLegalHolds: []*types.LegalHold{
+			{DbName: "db1", Key: "key1", Reason: "case 1"},
+			{DbName: "db1", Key: "key2", Reason: "case 2"},
+			{DbName: "db2", Reason: "case 3"},
+			{DbName: "db10", Key: "key1", Reason: "case 4"},
+		},
+	}
+	updates, err = ConstructDBEntriesForDBAdminTx(tx, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.LegalHoldsDBName: updates}, 1))
+
+	hold, err := Get(db, "db1", "key1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.LegalHold{DbName: "db1", Key: "key1", Reason: "case 1", PlacedBy: "admin"}, hold))
+	hold, err = Get(db, "db1", "")
+	require.NoError(t, err)
+	require.Nil(t, hold)
+
+	hold, err = Holding(db, "db1", "key3")
+	require.NoError(t, err)
+	require.Nil(t, hold)
+	hold, err = Holding(db, "db2", "key3")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&types.LegalHold{DbName: "db2", Reason: "case 3", PlacedBy: "admin"}, hold))
+
+	holds, err := DBHolds(db, "db1")
+	require.NoError(t, err)
+	require.Len(t, holds, 2)
+	require.Equal(t, "key1", holds[0].Key)
+	require.Equal(t, "key2", holds[1].Key)
+
+	tx = &types.DBAdministrationTx{
+		UserId:            "admin",
+		ReleaseLegalHolds: []*types.LegalHold{{DbName: "db1", Key: "key1"}, {DbName: "db2"}},
+	}
+	updates, err = ConstructDBEntriesForDBAdminTx(tx, &types.Version{BlockNum: 2})
+	require.NoError(t, err)
+	require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.LegalHoldsDBName: updates}, 2))
+
+	holds, err = DBHolds(db, "db1")
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	require.Equal(t, "key2", holds[0].Key)
+	holds, err = DBHolds(db, "db2")
+	require.NoError(t, err)
+	require.Empty(t, holds)
+	holds, err = DBHolds(db, "db10")
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+}
+
+func TestTarget(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "the database [db1]", Target(&types.LegalHold{DbName: "db1"}))
+	require.Equal(t, "the key [key1] of the database [db1]", Target(&types.LegalHold{DbName: "db1", Key: "key1"}))
+}
@@ -0,0 +1,206 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package membudget
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+)
+
+// Kind identifies the kind of work that holds memory accounted by the budget.
+type Kind string
+
+const (
+	// KindSnapshot is the memory pinned by a DB snapshot held by a query.
+	KindSnapshot Kind = "snapshot"
+	// KindQueryResult is the memory of the result set of an in-flight query.
+	KindQueryResult Kind = "query_result"
+	// KindBlockAssembly is the memory of the data transactions batched into the next block.
+	KindBlockAssembly Kind = "block_assembly"
+)
+
+// Accountant limits the memory held concurrently by DB snapshots, in-flight query result sets, and block assembly
+// buffers to a global budget. Work that does not fit in the budget waits until memory is released, or is rejected.
+//
+// A nil Accountant, or one with a zero limit, accounts nothing and never blocks.
+type Accountant struct {
+	limit      uint64
+	used       uint64
+	usedByKind map[Kind]uint64
+	// released is closed, and replaced, every time memory is released, to wake up the waiters
+	released chan struct{}
+	mu       sync.Mutex
+	logger   *logger.SugarLogger
+}
+
+// Config holds the configuration of the memory accountant
+type Config struct {
+	// LimitBytes is the total number of bytes that may be held concurrently; zero means no limit.
+	LimitBytes uint64
+	Logger     *logger.SugarLogger
+}
+
+// Usage is a point-in-time view of the memory held by every kind of work
+type Usage struct {
+	LimitBytes uint64
+	UsedBytes  uint64
+	UsedByKind map[Kind]uint64
+}
+
+// New creates a memory accountant
+func New(conf *Config) *Accountant {
+	return &Accountant{
+		limit:      conf.LimitBytes,
+		usedByKind: make(map[Kind]uint64),
+		released:   make(chan struct{}),
+		logger:     conf.Logger,
+	}
+}
+
+// Enabled returns true if the accountant limits memory
+func (a *Accountant) Enabled() bool {
+	return a != nil && a.limit > 0
+}
+
+// TryAcquire acquires n bytes for the given kind of work if they fit in the budget, without waiting.
+func (a *Accountant) TryAcquire(kind Kind, n uint64) bool {
+	if !a.Enabled() {
+		return true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.tryAcquire(kind, n)
+}
+
+// Acquire acquires n bytes for the given kind of work, waiting until they fit in the budget. It returns a
+// *errors.ResourceExhaustedError if n exceeds the whole budget, or if the context is done before the bytes are
+// acquired.
+func (a *Accountant) Acquire(ctx context.Context, kind Kind, n uint64) error {
+	if !a.Enabled() {
+		return nil
+	}
+
+	if n > a.limit {
+		return &ierrors.ResourceExhaustedError{
+			ErrMsg: fmt.Sprintf("%s of %d bytes exceeds the memory budget of %d bytes", kind, n, a.limit),
+		}
+	}
+
+	for {
+		a.mu.Lock()
+		if a.tryAcquire(kind, n) {
+			a.mu.Unlock()
+			return nil
+		}
+		released := a.released
+		used := a.used
+		a.mu.Unlock()
+
+		a.logger.Debugf("waiting for %d bytes of the memory budget for %s, used [%d] out of [%d] bytes", n, kind, used, a.limit)
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return &ierrors.ResourceExhaustedError{
+				ErrMsg: fmt.Sprintf("the memory budget is exhausted: %s requires %d bytes, used [%d] out of [%d] bytes", kind, n, used, a.limit),
+			}
+		}
+	}
+}
+
+// Release releases n bytes previously acquired for the given kind of work
+func (a *Accountant) Release(kind Kind, n uint64) {
+	if !a.Enabled() || n == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if n > a.usedByKind[kind] {
+		a.logger.Panicf("releasing %d bytes for %s, but only %d bytes are held", n, kind, a.usedByKind[kind])
+	}
+	a.used -= n
+	a.usedByKind[kind] -= n
+
+	close(a.released)
+	a.released = make(chan struct{})
+}
+
+// Usage returns the memory held by every kind of work
+func (a *Accountant) Usage() *Usage {
+	usage := &Usage{UsedByKind: make(map[Kind]uint64)}
+	if !a.Enabled() {
+		return usage
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	usage.LimitBytes = a.limit
+	usage.UsedBytes = a.used
+	for kind, n := range a.usedByKind {
+		usage.UsedByKind[kind] = n
+	}
+	return usage
+}
+
+func (a *Accountant) tryAcquire(kind Kind, n uint64) bool {
+	if a.used+n > a.limit {
+		return false
+	}
+	a.used += n
+	a.usedByKind[kind] += n
+	return true
+}
+
+// Reservation accumulates the memory acquired by a single unit of work, e.g., the result set of a query that grows
+// as the query proceeds, so that it can be released at once.
+type Reservation struct {
+	accountant *Accountant
+	kind       Kind
+	bytes      uint64
+}
+
+// NewReservation returns an empty reservation of the given kind of work
+func (a *Accountant) NewReservation(kind Kind) *Reservation {
+	return &Reservation{
+		accountant: a,
+		kind:       kind,
+	}
+}
+
+// Grow acquires n more bytes for the reservation, waiting until they fit in the budget
+func (r *Reservation) Grow(ctx context.Context, n uint64) error {
+	if err := r.accountant.Acquire(ctx, r.kind, n); err != nil {
+		return err
+	}
+	r.bytes += n
+	return nil
+}
+
+// TryGrow acquires n more bytes for the reservation if they fit in the budget, without waiting
+func (r *Reservation) TryGrow(n uint64) bool {
+	if !r.accountant.TryAcquire(r.kind, n) {
+		return false
+	}
+	r.bytes += n
+	return true
+}
+
+// Bytes returns the number of bytes held by the reservation
+func (r *Reservation) Bytes() uint64 {
+	return r.bytes
+}
+
+// Release releases all the bytes held by the reservation
+func (r *Reservation) Release() {
+	r.accountant.Release(r.kind, r.bytes)
+	r.bytes = 0
+}
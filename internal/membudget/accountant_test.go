@@ -0,0 +1,132 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package membudget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccountantForTest(t *testing.T, limit uint64) *Accountant {
+	c := &logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(c)
+	require.NoError(t, err)
+
+	return New(&Config{
+		LimitBytes: limit,
+		Logger:     logger,
+	})
+}
+
+func TestAccountant(t *testing.T) {
+	t.Run("acquire and release", func(t *testing.T) {
+		a := newAccountantForTest(t, 100)
+		require.True(t, a.Enabled())
+
+		require.True(t, a.TryAcquire(KindSnapshot, 40))
+		require.NoError(t, a.Acquire(context.Background(), KindQueryResult, 50))
+		require.False(t, a.TryAcquire(KindBlockAssembly, 20))
+		require.Equal(t, &Usage{
+			LimitBytes: 100,
+			UsedBytes:  90,
+			UsedByKind: map[Kind]uint64{KindSnapshot: 40, KindQueryResult: 50},
+		}, a.Usage())
+
+		a.Release(KindSnapshot, 40)
+		require.True(t, a.TryAcquire(KindBlockAssembly, 20))
+		require.Equal(t, uint64(70), a.Usage().UsedBytes)
+
+		a.Release(KindQueryResult, 50)
+		a.Release(KindBlockAssembly, 20)
+		require.Equal(t, uint64(0), a.Usage().UsedBytes)
+
+		require.Panics(t, func() { a.Release(KindSnapshot, 1) })
+	})
+
+	t.Run("acquire waits for a release", func(t *testing.T) {
+		a := newAccountantForTest(t, 100)
+		require.True(t, a.TryAcquire(KindSnapshot, 80))
+
+		acquired := make(chan error, 1)
+		go func() {
+			acquired <- a.Acquire(context.Background(), KindQueryResult, 50)
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("acquired memory beyond the budget")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		a.Release(KindSnapshot, 80)
+		require.NoError(t, <-acquired)
+		require.Equal(t, map[Kind]uint64{KindSnapshot: 0, KindQueryResult: 50}, a.Usage().UsedByKind)
+	})
+
+	t.Run("acquire times out", func(t *testing.T) {
+		a := newAccountantForTest(t, 100)
+		require.True(t, a.TryAcquire(KindSnapshot, 80))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := a.Acquire(ctx, KindQueryResult, 50)
+		require.EqualError(t, err, "the memory budget is exhausted: query_result requires 50 bytes, used [80] out of [100] bytes")
+		require.IsType(t, &ierrors.ResourceExhaustedError{}, err)
+		require.Equal(t, uint64(80), a.Usage().UsedBytes)
+	})
+
+	t.Run("acquire more than the budget", func(t *testing.T) {
+		a := newAccountantForTest(t, 100)
+
+		err := a.Acquire(context.Background(), KindQueryResult, 101)
+		require.EqualError(t, err, "query_result of 101 bytes exceeds the memory budget of 100 bytes")
+		require.IsType(t, &ierrors.ResourceExhaustedError{}, err)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		for _, a := range []*Accountant{nil, newAccountantForTest(t, 0)} {
+			require.False(t, a.Enabled())
+			require.True(t, a.TryAcquire(KindSnapshot, 1<<40))
+			require.NoError(t, a.Acquire(context.Background(), KindSnapshot, 1<<40))
+			a.Release(KindSnapshot, 1<<40)
+			require.Equal(t, &Usage{UsedByKind: map[Kind]uint64{}}, a.Usage())
+		}
+	})
+}
+
+func TestReservation(t *testing.T) {
+	a := newAccountantForTest(t, 100)
+
+	r := a.NewReservation(KindQueryResult)
+	require.NoError(t, r.Grow(context.Background(), 30))
+	require.True(t, r.TryGrow(30))
+	require.False(t, r.TryGrow(50))
+	require.Equal(t, uint64(60), r.Bytes())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, r.Grow(ctx, 50))
+	require.Equal(t, uint64(60), r.Bytes())
+	require.Equal(t, uint64(60), a.Usage().UsedBytes)
+
+	r.Release()
+	require.Equal(t, uint64(0), r.Bytes())
+	require.Equal(t, uint64(0), a.Usage().UsedBytes)
+
+	var disabled *Accountant
+	r = disabled.NewReservation(KindQueryResult)
+	require.True(t, r.TryGrow(1<<40))
+	r.Release()
+	require.Equal(t, uint64(0), r.Bytes())
+}
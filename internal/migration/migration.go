@@ -0,0 +1,114 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Get returns the state of a migration. It returns nil if no version of the migration was ever applied.
+func Get(db worldstate.DB, name string) (*types.MigrationState, error) {
+	val, _, err := db.Get(worldstate.MigrationsDBName, name)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while fetching the state of migration [%s]", name)
+	}
+	if val == nil {
+		return nil, nil
+	}
+
+	state := &types.MigrationState{}
+	if err := proto.Unmarshal(val, state); err != nil {
+		return nil, errors.Wrapf(err, "error while unmarshaling the state of migration [%s]", name)
+	}
+	return state, nil
+}
+
+// List returns the states of all migrations, sorted by name
+func List(db worldstate.DB) ([]*types.MigrationState, error) {
+	itr, err := db.GetIterator(worldstate.MigrationsDBName, "", "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while iterating over migrations")
+	}
+	defer itr.Release()
+
+	var states []*types.MigrationState
+	for itr.Next() {
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling persisted value of key [%s]", itr.Key())
+		}
+
+		state := &types.MigrationState{}
+		if err := proto.Unmarshal(persisted.Value, state); err != nil {
+			return nil, errors.Wrapf(err, "error while unmarshaling the state of migration [%s]", itr.Key())
+		}
+		states = append(states, state)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrap(err, "error while iterating over migrations")
+	}
+
+	return states, nil
+}
+
+// NextVersion returns the version that a migration must carry to be applied or reverted, given the state of the
+// migration: the version that follows the applied version when it is applied, or the applied version when it is
+// reverted. It returns 0 when a migration at no version is reverted, as there is nothing to revert.
+func NextVersion(state *types.MigrationState, direction types.Migration_Direction) uint64 {
+	if direction == types.Migration_DOWN {
+		return state.GetVersion()
+	}
+	return state.GetVersion() + 1
+}
+
+// ConstructDBEntriesForDBAdminTx constructs the entry of the migrations database for the migration applied or
+// reverted by a database administration transaction. The state of the migration records its new version and the
+// transaction in its history. It returns nil if the transaction carries no migration.
+func ConstructDBEntriesForDBAdminTx(db worldstate.DB, tx *types.DBAdministrationTx, version *types.Version) (*worldstate.DBUpdates, error) {
+	m := tx.GetMigration()
+	if m == nil {
+		return nil, nil
+	}
+
+	state, err := Get(db, m.Name)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state = &types.MigrationState{Name: m.Name}
+	}
+
+	state.Version = m.Version
+	if m.Direction == types.Migration_DOWN {
+		state.Version = m.Version - 1
+	}
+	state.History = append(state.History, &types.MigrationStep{
+		Version:     m.Version,
+		Direction:   m.Direction,
+		Description: m.Description,
+		TxId:        tx.TxId,
+		UserId:      tx.UserId,
+		BlockNumber: version.GetBlockNum(),
+	})
+
+	stateSerialized, err := proto.Marshal(state)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshaling migration state")
+	}
+
+	return &worldstate.DBUpdates{
+		Writes: []*worldstate.KVWithMetadata{
+			{
+				Key:   m.Name,
+				Value: stateSerialized,
+				Metadata: &types.Metadata{
+					Version: version,
+				},
+			},
+		},
+	}, nil
+}
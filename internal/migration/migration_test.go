@@ -0,0 +1,120 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package migration
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) worldstate.DB {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("/tmp", "migration")
+	require.NoError(t, err)
+
+	db, err := leveldb.Open(&leveldb.Config{
+		DBRootDir: filepath.Join(dir, "leveldb"),
+		Logger:    lg,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close the db instance, %v", err)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			t.Errorf("failed to remove directory %s, %v", dir, err)
+		}
+	})
+	return db
+}
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+
+	updates, err := ConstructDBEntriesForDBAdminTx(db, &types.DBAdministrationTx{CreateDbs: []string{"db1"}}, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.Nil(t, updates)
+
+	state, err := Get(db, "schema")
+	require.NoError(t, err)
+	require.Nil(t, state)
+	require.Equal(t, uint64(1), NextVersion(state, types.Migration_UP))
+	require.Equal(t, uint64(0), NextVersion(state, types.Migration_DOWN))
+
+	commit := func(tx *types.DBAdministrationTx, blockNum uint64) {
+		updates, err := ConstructDBEntriesForDBAdminTx(db, tx, &types.Version{BlockNum: blockNum})
+		require.NoError(t, err)
+		require.Len(t, updates.Writes, 1)
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.MigrationsDBName: updates}, blockNum))
+	}
+
+	commit(&types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx1",
+		Migration: &types.Migration{Name: "schema", Version: 1, Description: "create orders"},
+	}, 2)
+	commit(&types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx2",
+		Migration: &types.Migration{Name: "schema", Version: 2, Description: "index orders"},
+	}, 3)
+	commit(&types.DBAdministrationTx{
+		UserId:    "admin2",
+		TxId:      "tx3",
+		Migration: &types.Migration{Name: "schema", Version: 2, Direction: types.Migration_DOWN, Description: "drop the index of orders"},
+	}, 4)
+	commit(&types.DBAdministrationTx{
+		UserId:    "admin",
+		TxId:      "tx4",
+		Migration: &types.Migration{Name: "accounts", Version: 1},
+	}, 5)
+
+	expectedSchema := &types.MigrationState{
+		Name:    "schema",
+		Version: 1,
+		History: []*types.MigrationStep{
+			{Version: 1, Description: "create orders", TxId: "tx1", UserId: "admin", BlockNumber: 2},
+			{Version: 2, Description: "index orders", TxId: "tx2", UserId: "admin", BlockNumber: 3},
+			{Version: 2, Direction: types.Migration_DOWN, Description: "drop the index of orders", TxId: "tx3", UserId: "admin2", BlockNumber: 4},
+		},
+	}
+	expectedAccounts := &types.MigrationState{
+		Name:    "accounts",
+		Version: 1,
+		History: []*types.MigrationStep{
+			{Version: 1, TxId: "tx4", UserId: "admin", BlockNumber: 5},
+		},
+	}
+
+	state, err = Get(db, "schema")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expectedSchema, state))
+	require.Equal(t, uint64(2), NextVersion(state, types.Migration_UP))
+	require.Equal(t, uint64(1), NextVersion(state, types.Migration_DOWN))
+
+	states, err := List(db)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	require.True(t, proto.Equal(expectedAccounts, states[0]))
+	require.True(t, proto.Equal(expectedSchema, states[1]))
+}
@@ -0,0 +1,265 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mptrie
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// parallelUpdateThreshold is the minimal number of updates below a branch node for which the sub-tries of
+// the branch node are updated in parallel. Fewer updates are applied sequentially, as the gain does not cover
+// the cost of the go-routines.
+const parallelUpdateThreshold = 64
+
+// KeyUpdate is an update of a single key applied by UpdateBatch. When Delete is true, the key is deleted
+// and Value is ignored. When ValueHash is set, the key refers to the value by its hash, and the value, which
+// is empty as it was erased, is not stored.
+type KeyUpdate struct {
+	Key       []byte
+	Value     []byte
+	ValueHash []byte
+	Delete    bool
+}
+
+type pendingUpdate struct {
+	hexKey    []byte
+	valuePtr  []byte
+	isDeleted bool
+}
+
+// UpdateBatch applies the updates to the trie, and results in the same trie as applying them one by one, in
+// order, with Update and Delete.
+//
+// The updates are sharded by key prefix: the updates below a branch node are grouped by the child they go to,
+// and the sub-tries of the children are updated in parallel, as they are disjoint. The branch node is then
+// updated with the pointers to the new children, hence the root hash is computed in a final reduction step.
+// The updates of each sub-trie are applied in their original order, so that a key updated more than once ends
+// with its last update.
+func (t *MPTrie) UpdateBatch(updates []*KeyUpdate) error {
+	// the number of go-routines updating sub-tries in parallel is bounded by the number of cores
+	return t.updateBatchWithLimit(updates, make(chan struct{}, runtime.NumCPU()))
+}
+
+// updateBatchWithLimit applies the updates as UpdateBatch does, where sem bounds the number of go-routines, possibly
+// shared with other tries that are updated at the same time
+func (t *MPTrie) updateBatchWithLimit(updates []*KeyUpdate, sem chan struct{}) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	pending, err := t.prepareUpdates(updates)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	t.rootHash = nil
+	root, rootPtr, err := t.updateBranchNodeBatch(t.root.(*BranchNode), pending, sem)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	t.releaseNode(rootPtr)
+
+	return nil
+}
+
+// prepareUpdates computes the value pointers of the updates and stores the values. A delete refers to the
+// value that the key holds at that point of the batch, and a delete of a key that does not exist is dropped,
+// as Delete does.
+func (t *MPTrie) prepareUpdates(updates []*KeyUpdate) ([]*pendingUpdate, error) {
+	var pending []*pendingUpdate
+	latestValuePtr := make(map[string][]byte)
+
+	for _, u := range updates {
+		if len(u.Key) == 0 {
+			return nil, errors.New("can't update element with empty key")
+		}
+		hexKey := convertByteToHex(u.Key)
+
+		if !u.Delete {
+			var valuePtr []byte
+			var err error
+			if u.ValueHash != nil {
+				valuePtr, err = state.CalculateKeyValueHashOfValueHash(u.Key, u.ValueHash)
+			} else {
+				valuePtr, err = state.CalculateKeyValueHash(u.Key, u.Value)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if u.ValueHash == nil {
+				if err := t.store.PutValue(valuePtr, u.Value); err != nil {
+					return nil, err
+				}
+			}
+
+			latestValuePtr[string(hexKey)] = valuePtr
+			pending = append(pending, &pendingUpdate{hexKey: hexKey, valuePtr: valuePtr})
+			continue
+		}
+
+		valuePtr, ok := latestValuePtr[string(hexKey)]
+		if !ok {
+			node, err := t.getNode(hexKey)
+			if err != nil {
+				return nil, err
+			}
+			if node == nil {
+				continue
+			}
+			valuePtr = node.getValuePtr()
+			latestValuePtr[string(hexKey)] = valuePtr
+		}
+		pending = append(pending, &pendingUpdate{hexKey: hexKey, valuePtr: valuePtr, isDeleted: true})
+	}
+
+	return pending, nil
+}
+
+func (t *MPTrie) updateBatch(node TrieNode, updates []*pendingUpdate, sem chan struct{}) (TrieNode, []byte, error) {
+	switch n := node.(type) {
+	case *BranchNode:
+		return t.updateBranchNodeBatch(n, updates, sem)
+
+	case *ExtensionNode:
+		// the child of the extension node can be updated in a batch only if the structure of the extension
+		// node does not change, i.e., if all the keys pass through it
+		passThrough := true
+		for _, u := range updates {
+			if !bytes.HasPrefix(u.hexKey, n.Key) {
+				passThrough = false
+				break
+			}
+		}
+		if !passThrough {
+			return t.updateSequentially(node, updates)
+		}
+
+		childNode, err := t.loadNode(n.Child)
+		if err != nil {
+			return nil, nil, err
+		}
+		childBranch, ok := childNode.(*BranchNode)
+		if !ok {
+			return nil, nil, errors.New("impossible state - extension node must be followed by a branch node")
+		}
+
+		childUpdates := make([]*pendingUpdate, len(updates))
+		for i, u := range updates {
+			childUpdates[i] = &pendingUpdate{hexKey: u.hexKey[len(n.Key):], valuePtr: u.valuePtr, isDeleted: u.isDeleted}
+		}
+		_, childPtr, err := t.updateBranchNodeBatch(childBranch, childUpdates, sem)
+		if err != nil {
+			return nil, nil, err
+		}
+		t.releaseNode(n.Child)
+		n.Child = childPtr
+
+		nodePtr, err := t.saveNode(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		return n, nodePtr, nil
+
+	default:
+		return t.updateSequentially(node, updates)
+	}
+}
+
+func (t *MPTrie) updateBranchNodeBatch(node *BranchNode, updates []*pendingUpdate, sem chan struct{}) (TrieNode, []byte, error) {
+	// the updates that end at the branch node update its value, and the others are grouped by child
+	var childUpdates [16][]*pendingUpdate
+	for _, u := range updates {
+		if len(u.hexKey) == 0 {
+			node.setValuePtr(u.valuePtr)
+			node.Deleted = u.isDeleted
+			continue
+		}
+		childUpdates[u.hexKey[0]] = append(childUpdates[u.hexKey[0]], &pendingUpdate{
+			hexKey:    u.hexKey[1:],
+			valuePtr:  u.valuePtr,
+			isDeleted: u.isDeleted,
+		})
+	}
+
+	parallel := len(updates) >= parallelUpdateThreshold
+	newChildPtrs := make([][]byte, 16)
+	errs := make([]error, 16)
+	var wg sync.WaitGroup
+
+	for i := range childUpdates {
+		if len(childUpdates[i]) == 0 {
+			continue
+		}
+
+		updateChild := func(i int) {
+			var childNode TrieNode = &EmptyNode{}
+			if node.Children[i] != nil {
+				childNode, errs[i] = t.loadNode(node.Children[i])
+				if errs[i] != nil {
+					return
+				}
+			}
+			_, newChildPtrs[i], errs[i] = t.updateBatch(childNode, childUpdates[i], sem)
+		}
+
+		if !parallel {
+			updateChild(i)
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+			wg.Add(1)
+			go func(i int) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				updateChild(i)
+			}(i)
+		default:
+			// all the cores are busy, so the sub-trie is updated by the current go-routine
+			updateChild(i)
+		}
+	}
+	wg.Wait()
+
+	for i := range childUpdates {
+		if errs[i] != nil {
+			return nil, nil, errs[i]
+		}
+		if len(childUpdates[i]) > 0 {
+			t.releaseNode(node.Children[i])
+			node.Children[i] = newChildPtrs[i]
+		}
+	}
+
+	nodePtr, err := t.saveNode(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	return node, nodePtr, nil
+}
+
+func (t *MPTrie) updateSequentially(node TrieNode, updates []*pendingUpdate) (TrieNode, []byte, error) {
+	var nodePtr []byte
+	var err error
+	for _, u := range updates {
+		prevNodePtr := nodePtr
+		node, nodePtr, err = t.update(node, u.hexKey, u.valuePtr, u.isDeleted)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the intermediate versions of the node are not referred by any other node
+		t.releaseNode(prevNodePtr)
+	}
+	return node, nodePtr, nil
+}
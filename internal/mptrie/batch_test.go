@@ -0,0 +1,125 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mptrie
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateBatch(t *testing.T) {
+	// keys share a long prefix, as the composite keys of the state trie do, such that the sub-tries are
+	// sharded below an extension node
+	compositeKey := func(db string, i int) []byte {
+		dbHash := sha256.Sum256([]byte(db))
+		keyHash := sha256.Sum256([]byte(fmt.Sprintf("key-%d", i)))
+		return append(dbHash[:], keyHash[:]...)
+	}
+
+	var initial []*KeyUpdate
+	for i := 0; i < 200; i++ {
+		initial = append(initial, &KeyUpdate{Key: compositeKey("db1", i), Value: []byte(fmt.Sprintf("value-%d", i))})
+	}
+
+	var block []*KeyUpdate
+	for i := 100; i < 400; i++ {
+		block = append(block, &KeyUpdate{Key: compositeKey("db1", i), Value: []byte(fmt.Sprintf("new-value-%d", i))})
+		block = append(block, &KeyUpdate{Key: compositeKey("db2", i), Value: []byte(fmt.Sprintf("db2-value-%d", i))})
+	}
+	block = append(block,
+		// delete of an existing key, of a key written in the batch, and of a key that does not exist
+		&KeyUpdate{Key: compositeKey("db1", 5), Delete: true},
+		&KeyUpdate{Key: compositeKey("db1", 300), Delete: true},
+		&KeyUpdate{Key: compositeKey("db1", 1000), Delete: true},
+		// a key written after it was deleted, and a key written twice
+		&KeyUpdate{Key: compositeKey("db1", 300), Value: []byte("rewritten")},
+		&KeyUpdate{Key: compositeKey("db2", 100), Value: []byte("last")},
+		// short keys end at branch nodes
+		&KeyUpdate{Key: []byte{0x12}, Value: []byte("short")},
+		&KeyUpdate{Key: []byte{0x12, 0x34}, Value: []byte("longer")},
+	)
+
+	applySequentially := func(trie *MPTrie, updates []*KeyUpdate) {
+		for _, u := range updates {
+			if u.Delete {
+				_, err := trie.Delete(u.Key)
+				require.NoError(t, err)
+				continue
+			}
+			require.NoError(t, trie.Update(u.Key, u.Value))
+		}
+	}
+
+	sequentialTrie, err := NewTrie(nil, newMockStore())
+	require.NoError(t, err)
+	applySequentially(sequentialTrie, initial)
+	require.NoError(t, sequentialTrie.Commit(1))
+	applySequentially(sequentialTrie, block)
+	expectedHash, err := sequentialTrie.Hash()
+	require.NoError(t, err)
+
+	batchTrie, err := NewTrie(nil, newMockStore())
+	require.NoError(t, err)
+	require.NoError(t, batchTrie.UpdateBatch(initial))
+	require.NoError(t, batchTrie.Commit(1))
+	require.NoError(t, batchTrie.UpdateBatch(block))
+	hash, err := batchTrie.Hash()
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+
+	require.NoError(t, batchTrie.Commit(2))
+	hash, err = batchTrie.Hash()
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+
+	value, err := batchTrie.Get(compositeKey("db1", 5))
+	require.NoError(t, err)
+	require.Nil(t, value)
+	value, err = batchTrie.Get(compositeKey("db1", 300))
+	require.NoError(t, err)
+	require.Equal(t, []byte("rewritten"), value)
+	value, err = batchTrie.Get(compositeKey("db2", 100))
+	require.NoError(t, err)
+	require.Equal(t, []byte("last"), value)
+	value, err = batchTrie.Get(compositeKey("db1", 150))
+	require.NoError(t, err)
+	require.Equal(t, []byte("new-value-150"), value)
+	value, err = batchTrie.Get([]byte{0x12})
+	require.NoError(t, err)
+	require.Equal(t, []byte("short"), value)
+
+	require.EqualError(t, batchTrie.UpdateBatch([]*KeyUpdate{{Key: nil, Value: []byte("v")}}), "can't update element with empty key")
+}
+
+func TestUpdateBatchWithValueHash(t *testing.T) {
+	updates := []*KeyUpdate{
+		{Key: []byte("key1"), Value: []byte("value1")},
+		{Key: []byte("key2"), Value: []byte("value2")},
+	}
+	trie, err := NewTrie(nil, newMockStore())
+	require.NoError(t, err)
+	require.NoError(t, trie.UpdateBatch(updates))
+	expectedHash, err := trie.Hash()
+	require.NoError(t, err)
+
+	// the erased value of key1 is referred to by its hash, and is not stored
+	valueHash := sha256.Sum256([]byte("value1"))
+	updates[0] = &KeyUpdate{Key: []byte("key1"), ValueHash: valueHash[:]}
+	trie, err = NewTrie(nil, newMockStore())
+	require.NoError(t, err)
+	require.NoError(t, trie.UpdateBatch(updates))
+	hash, err := trie.Hash()
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+
+	require.NoError(t, trie.Commit(1))
+	value, err := trie.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+	value, err = trie.Get([]byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), value)
+}
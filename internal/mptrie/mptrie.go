@@ -4,7 +4,9 @@ package mptrie
 
 import (
 	"bytes"
+	"encoding/binary"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hyperledger-labs/orion-server/pkg/state"
 
@@ -36,26 +38,50 @@ type Store interface {
 	// underlying database. Operation can cause to current MPTrie become invalid, so always reload trie
 	// after the call
 	RollbackChanges() error
+	// FlatLayout returns true if the store holds the state in a single trie, as the stores of the ledgers created
+	// before the state trie was split into a trie per database do, see StateTrie
+	FlatLayout() (bool, error)
+	// SetFlatLayout records that the store holds the state in a single trie
+	SetFlatLayout() error
 }
 
+const (
+	// dirtyNodePtrMarker is the first byte of a temporary pointer to a dirty node. The length of a temporary pointer
+	// differs from the length of a node hash, so that the two never collide.
+	dirtyNodePtrMarker = 0xff
+	dirtyNodePtrLen    = 9
+)
+
 // Merkle-Patricia Trie implementation. No node/value data stored inside trie, but in associated TrieStore
+//
+// Node hashes are calculated lazily. A node changed by Update or Delete is kept in memory as a dirty node, referred by
+// a temporary pointer, and the hashes of all the dirty nodes are calculated once, bottom up, when the hash of the trie
+// is required, i.e., by Hash, GetProof and Commit. Hence, the nodes on the path shared by many updates of a block are
+// hashed once per block rather than once per update.
 type MPTrie struct {
 	root  TrieNode
 	store Store
 	lock  sync.RWMutex
+	// rootHash is the hash of the root node, or nil if the trie changed since the hash was last calculated
+	rootHash []byte
+	// dirtyNodes holds the nodes changed since the hash was last calculated, by their temporary pointers
+	dirtyNodes   map[string]TrieNode
+	dirtyLock    sync.RWMutex
+	nextDirtyPtr uint64
 }
 
 // NewTrie creates new Merkle-Patricia Trie, with backend store.
 // If root node Hash is not nil, root node loaded from store, otherwise, empty trie is created
 func NewTrie(rootHash []byte, store Store) (*MPTrie, error) {
 	res := &MPTrie{
-		store: store,
+		store:      store,
+		dirtyNodes: make(map[string]TrieNode),
 	}
 	var err error
 	if rootHash == nil {
 		// To simplify things, root node is always full branch node, even if trie doesn't contains any data. Its valuePrt is always nil.
 		res.root = &BranchNode{Children: make([][]byte, 16)}
-		_, err = res.saveNode(res.root)
+		res.rootHash, err = res.storeNode(res.root)
 		if err != nil {
 			return nil, err
 		}
@@ -67,14 +93,81 @@ func NewTrie(rootHash []byte, store Store) (*MPTrie, error) {
 		if res.root == nil {
 			return nil, errors.New("invalid root Hash provided, not found in store")
 		}
+		res.rootHash = rootHash
 	}
 	return res, nil
 }
 
 func (t *MPTrie) Hash() ([]byte, error) {
-	t.lock.RLock()
-	defer t.lock.RUnlock()
-	return t.root.hash()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.hashRoot()
+}
+
+// hashRoot calculates the hashes of the dirty nodes and of the root node, and stores the nodes in the trie store.
+// The sub-tries of the children of the root are hashed in parallel.
+func (t *MPTrie) hashRoot() ([]byte, error) {
+	if t.rootHash != nil {
+		return t.rootHash, nil
+	}
+
+	root := t.root.(*BranchNode)
+	errs := make([]error, len(root.Children))
+	var wg sync.WaitGroup
+	for i, childPtr := range root.Children {
+		if !isDirtyNodePtr(childPtr) {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, childPtr []byte) {
+			defer wg.Done()
+			root.Children[i], errs[i] = t.hashDirtyNode(childPtr)
+		}(i, childPtr)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rootHash, err := t.storeNode(root)
+	if err != nil {
+		return nil, err
+	}
+
+	t.dirtyNodes = make(map[string]TrieNode)
+	t.rootHash = rootHash
+	return rootHash, nil
+}
+
+// hashDirtyNode replaces the temporary pointers of the children of a dirty node by their hashes, and then stores the
+// node in the trie store and returns its hash
+func (t *MPTrie) hashDirtyNode(nodePtr []byte) ([]byte, error) {
+	node, err := t.loadNode(nodePtr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n := node.(type) {
+	case *BranchNode:
+		for i, childPtr := range n.Children {
+			if !isDirtyNodePtr(childPtr) {
+				continue
+			}
+			if n.Children[i], err = t.hashDirtyNode(childPtr); err != nil {
+				return nil, err
+			}
+		}
+	case *ExtensionNode:
+		if isDirtyNodePtr(n.Child) {
+			if n.Child, err = t.hashDirtyNode(n.Child); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return t.storeNode(node)
 }
 
 func (t *MPTrie) Get(key []byte) ([]byte, error) {
@@ -110,13 +203,36 @@ func (t *MPTrie) Update(key, value []byte) error {
 	}
 	node := t.root
 	hexKey := convertByteToHex(key)
-	t.root, _, err = t.updateBranchNode(node.(*BranchNode), hexKey, valuePtr, false)
+	t.rootHash = nil
+	var rootPtr []byte
+	t.root, rootPtr, err = t.updateBranchNode(node.(*BranchNode), hexKey, valuePtr, false)
 	if err != nil {
 		return err
 	}
+	// the root is held by the trie, and not referred by a pointer
+	t.releaseNode(rootPtr)
 	return t.store.PutValue(valuePtr, value)
 }
 
+// updateSubtrieRoot sets the value of the key to the root hash of a sub-trie. Unlike Update, the root hash itself is
+// the value pointer, such that a proof in the sub-trie, which ends at its root, continues with a proof in this trie.
+func (t *MPTrie) updateSubtrieRoot(key, rootHash []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(key) == 0 {
+		return errors.New("can't update element with empty key")
+	}
+	t.rootHash = nil
+	root, rootPtr, err := t.updateBranchNode(t.root.(*BranchNode), convertByteToHex(key), rootHash, false)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	t.releaseNode(rootPtr)
+	return t.store.PutValue(rootHash, rootHash)
+}
+
 func (t *MPTrie) update(node TrieNode, hexKey, valuePtr []byte, isDeleted bool) (TrieNode, []byte, error) {
 	switch node := node.(type) {
 	case *BranchNode:
@@ -142,7 +258,7 @@ func (t *MPTrie) updateBranchNode(node *BranchNode, hexKey, valuePtr []byte, isD
 		var childNode TrieNode
 		childNode = &EmptyNode{}
 		if childPtr != nil {
-			childNode, err = t.store.GetNode(childPtr)
+			childNode, err = t.loadNode(childPtr)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -151,6 +267,7 @@ func (t *MPTrie) updateBranchNode(node *BranchNode, hexKey, valuePtr []byte, isD
 		if err != nil {
 			return nil, nil, err
 		}
+		t.releaseNode(childPtr)
 		node.Children[hexKey[0]] = updateChildNodePtr
 	}
 
@@ -312,7 +429,7 @@ func (t *MPTrie) updateExtensionNode(node *ExtensionNode, hexKey, valuePtr []byt
 
 	if len(valueKeyLeft) == 0 && len(nodeKeyLeft) == 0 {
 		// Exactly same node, just update value in following branch node
-		branchNode, err := t.store.GetNode(node.Child)
+		branchNode, err := t.loadNode(node.Child)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -320,6 +437,7 @@ func (t *MPTrie) updateExtensionNode(node *ExtensionNode, hexKey, valuePtr []byt
 		if err != nil {
 			return nil, nil, err
 		}
+		t.releaseNode(node.Child)
 		node.Child = childPtr
 		newNode = node
 	} else {
@@ -361,11 +479,15 @@ func (t *MPTrie) updateExtensionNode(node *ExtensionNode, hexKey, valuePtr []byt
 			}
 		} else if len(nodeKeyLeft) == 0 && len(valueKeyLeft) > 0 {
 			// pass value to child node update, just update valuePtr for current node
-			childNode, err := t.store.GetNode(node.Child)
+			childNode, err := t.loadNode(node.Child)
 			if err != nil {
 				return nil, nil, err
 			}
 			_, newChildPtr, err := t.update(childNode, hexKey[len(commonPrefix):], valuePtr, isDeleted)
+			if err != nil {
+				return nil, nil, err
+			}
+			t.releaseNode(node.Child)
 			node.Child = newChildPtr
 			newNode = node
 		} else {
@@ -443,27 +565,36 @@ func (t *MPTrie) Delete(key []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	t.root, _, err = t.updateBranchNode(t.root.(*BranchNode), hexKey, valuePtr, true)
+	t.rootHash = nil
+	var rootPtr []byte
+	t.root, rootPtr, err = t.updateBranchNode(t.root.(*BranchNode), hexKey, valuePtr, true)
 	if err != nil {
 		return nil, err
 	}
+	t.releaseNode(rootPtr)
 
 	return value, nil
 }
 
 func (t *MPTrie) Commit(blockNum uint64) error {
-	t.lock.RLock()
-	defer t.lock.RUnlock()
-
-	rootHash, err := t.root.hash()
-	if err != nil {
+	if err := t.persist(); err != nil {
 		return err
 	}
+	return t.store.CommitChanges(blockNum)
+}
+
+// persist marks the nodes and values of the trie changed since the last commit to be stored by the next call to
+// CommitChanges of the trie store
+func (t *MPTrie) persist() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
 
-	if err := t.persistSubtrie(rootHash); err != nil {
+	rootHash, err := t.hashRoot()
+	if err != nil {
 		return err
 	}
-	return t.store.CommitChanges(blockNum)
+
+	return t.persistSubtrie(rootHash)
 }
 
 func (t *MPTrie) persistSubtrie(nodePtr []byte) error {
@@ -510,6 +641,19 @@ func (t *MPTrie) persistSubtrie(nodePtr []byte) error {
 	return err
 }
 
+// getSubtrieRoot returns the root hash of the sub-trie set by updateSubtrieRoot for the key, or nil if the key does
+// not exist
+func (t *MPTrie) getSubtrieRoot(key []byte) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	node, err := t.getNode(convertByteToHex(key))
+	if err != nil || node == nil {
+		return nil, err
+	}
+	return node.getValuePtr(), nil
+}
+
 func (t *MPTrie) getNode(hexKey []byte) (TrieNodeWithValue, error) {
 	_, node, err := t.getPath(hexKey)
 	return node, err
@@ -534,7 +678,7 @@ func (t *MPTrie) getPath(hexKey []byte) ([]TrieNode, TrieNodeWithValue, error) {
 			}
 			hexKey = hexKey[1:]
 			var err error
-			node, err = t.store.GetNode(childPtr)
+			node, err = t.loadNode(childPtr)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -553,7 +697,7 @@ func (t *MPTrie) getPath(hexKey []byte) ([]TrieNode, TrieNodeWithValue, error) {
 				return nil, nil, errors.New("impossible state - extension node can't exist without following branch node")
 			}
 			var err error
-			node, err = t.store.GetNode(childPtr)
+			node, err = t.loadNode(childPtr)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -569,7 +713,21 @@ func (t *MPTrie) getPath(hexKey []byte) ([]TrieNode, TrieNodeWithValue, error) {
 	}
 }
 
+// saveNode keeps a copy of the node as a dirty node, and returns its temporary pointer. The hash of the node is
+// calculated by hashRoot.
 func (t *MPTrie) saveNode(node TrieNode) ([]byte, error) {
+	nodePtr := make([]byte, dirtyNodePtrLen)
+	nodePtr[0] = dirtyNodePtrMarker
+	binary.BigEndian.PutUint64(nodePtr[1:], atomic.AddUint64(&t.nextDirtyPtr, 1))
+
+	t.dirtyLock.Lock()
+	defer t.dirtyLock.Unlock()
+	t.dirtyNodes[string(nodePtr)] = cloneNode(node)
+	return nodePtr, nil
+}
+
+// storeNode calculates the hash of the node and stores the node in the trie store
+func (t *MPTrie) storeNode(node TrieNode) ([]byte, error) {
 	nodePtr, err := node.hash()
 	if err != nil {
 		return nil, err
@@ -581,6 +739,36 @@ func (t *MPTrie) saveNode(node TrieNode) ([]byte, error) {
 	return nodePtr, nil
 }
 
+// loadNode returns the node by either its temporary pointer or its hash
+func (t *MPTrie) loadNode(nodePtr []byte) (TrieNode, error) {
+	if !isDirtyNodePtr(nodePtr) {
+		return t.store.GetNode(nodePtr)
+	}
+
+	t.dirtyLock.RLock()
+	defer t.dirtyLock.RUnlock()
+	node, ok := t.dirtyNodes[string(nodePtr)]
+	if !ok {
+		return nil, errors.New("impossible state - dirty node not found")
+	}
+	return node, nil
+}
+
+// releaseNode drops a dirty node that is no longer referred, as it was replaced by a newer version
+func (t *MPTrie) releaseNode(nodePtr []byte) {
+	if !isDirtyNodePtr(nodePtr) {
+		return
+	}
+
+	t.dirtyLock.Lock()
+	defer t.dirtyLock.Unlock()
+	delete(t.dirtyNodes, string(nodePtr))
+}
+
+func isDirtyNodePtr(nodePtr []byte) bool {
+	return len(nodePtr) == dirtyNodePtrLen && nodePtr[0] == dirtyNodePtrMarker
+}
+
 func (t *MPTrie) persistNode(nodePtr []byte) (bool, error) {
 	isChanged, err := t.store.PersistNode(nodePtr)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -320,7 +321,7 @@ func TestUpdateTrieStructure(t *testing.T) {
 				tt.data.trieStat.extensionNodesNum,
 				tt.data.trieStat.valueNodesNum,
 				tt.data.trieStat.valuesNum)
-			validateTrie(t, trie.store, trie.root, true)
+			validateTrie(t, trie, trie.root, true)
 			keys := make([][]byte, 0)
 			values := make([][]byte, 0)
 			for keyStr, v := range resKeyValue {
@@ -665,7 +666,7 @@ func TestTrieCommit(t *testing.T) {
 				},
 			},
 			afterUpdateStoreStat: &storeStatistic{
-				inMemoryNodes:  6,
+				inMemoryNodes:  0,
 				persistNodes:   2,
 				inMemoryValues: 3,
 				persistValues:  1,
@@ -706,7 +707,7 @@ func TestTrieCommit(t *testing.T) {
 				},
 			},
 			afterUpdateStoreStat: &storeStatistic{
-				inMemoryNodes:  9,
+				inMemoryNodes:  0,
 				persistNodes:   2,
 				inMemoryValues: 2,
 				persistValues:  1,
@@ -780,6 +781,56 @@ func TestTrieCommit(t *testing.T) {
 	}
 }
 
+func TestLazyHashing(t *testing.T) {
+	var keys, values [][]byte
+	for i := 0; i < 100; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("key-%d", i)))
+		values = append(values, []byte(fmt.Sprintf("value-%d", i)))
+	}
+
+	// the hashes of the eager trie are calculated after every update
+	eagerTrie, err := NewTrie(nil, newMockStore())
+	require.NoError(t, err)
+	for i, key := range keys {
+		require.NoError(t, eagerTrie.Update(key, values[i]))
+		_, err = eagerTrie.Hash()
+		require.NoError(t, err)
+	}
+	_, err = eagerTrie.Delete(keys[10])
+	require.NoError(t, err)
+	expectedHash, err := eagerTrie.Hash()
+	require.NoError(t, err)
+
+	store := newMockStore()
+	lazyTrie, err := NewTrie(nil, store)
+	require.NoError(t, err)
+	require.NoError(t, lazyTrie.Commit(1))
+	for i, key := range keys {
+		require.NoError(t, lazyTrie.Update(key, values[i]))
+	}
+	_, err = lazyTrie.Delete(keys[10])
+	require.NoError(t, err)
+
+	// no node is stored before the hash is calculated, and replaced versions of nodes are dropped
+	inMemoryNodes, _, _, _ := store.(*trieStoreMock).storeStatistic()
+	require.Equal(t, 0, inMemoryNodes)
+	branchNodes, extensionNodes, valueNodes, _, err := lazyTrie.getStatistic(lazyTrie.root)
+	require.NoError(t, err)
+	require.Len(t, lazyTrie.dirtyNodes, branchNodes+extensionNodes+valueNodes-1)
+
+	hash, err := lazyTrie.Hash()
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+	require.Empty(t, lazyTrie.dirtyNodes)
+	inMemoryNodes, _, _, _ = store.(*trieStoreMock).storeStatistic()
+	require.Equal(t, branchNodes+extensionNodes+valueNodes, inMemoryNodes)
+
+	validateValues(t, lazyTrie, keys[11:], values[11:])
+	value, err := lazyTrie.Get(keys[10])
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
 func validateValues(t *testing.T, trie *MPTrie, keys [][]byte, values [][]byte) {
 	for i := range keys {
 		k := keys[i]
@@ -794,21 +845,21 @@ func validateValues(t *testing.T, trie *MPTrie, keys [][]byte, values [][]byte)
 	}
 }
 
-func validateTrie(t *testing.T, store Store, root TrieNode, isRoot bool) {
+func validateTrie(t *testing.T, trie *MPTrie, root TrieNode, isRoot bool) {
 	switch root.(type) {
 	case *BranchNode:
 		var childrenCount int
 		for _, childPtr := range root.(*BranchNode).Children {
 			if childPtr != nil {
 				childrenCount++
-				childNode, err := store.GetNode(childPtr)
+				childNode, err := trie.loadNode(childPtr)
 				require.NoError(t, err)
 				require.NotNil(t, childNode)
-				validateTrie(t, store, childNode, false)
+				validateTrie(t, trie, childNode, false)
 			}
 		}
 		if root.(*BranchNode).ValuePtr != nil {
-			val, err := store.GetValue(root.(*BranchNode).ValuePtr)
+			val, err := trie.store.GetValue(root.(*BranchNode).ValuePtr)
 			require.NoError(t, err)
 			require.NotNil(t, val)
 			require.GreaterOrEqual(t, childrenCount, 1)
@@ -819,13 +870,13 @@ func validateTrie(t *testing.T, store Store, root TrieNode, isRoot bool) {
 		require.NotNil(t, root.(*ExtensionNode).Child)
 		require.NotNil(t, root.(*ExtensionNode).Key)
 		childPtr := root.(*ExtensionNode).Child
-		childNode, err := store.GetNode(childPtr)
+		childNode, err := trie.loadNode(childPtr)
 		require.NoError(t, err)
 		require.NotNil(t, childNode)
-		validateTrie(t, store, childNode, false)
+		validateTrie(t, trie, childNode, false)
 	case *ValueNode:
 		require.NotNil(t, root.(*ValueNode).ValuePtr)
-		val, err := store.GetValue(root.(*ValueNode).ValuePtr)
+		val, err := trie.store.GetValue(root.(*ValueNode).ValuePtr)
 		require.NoError(t, err)
 		require.NotNil(t, val)
 	default:
@@ -844,11 +895,14 @@ func validateTrieStatistic(t *testing.T, trie *MPTrie, branchNodesNum, extension
 }
 
 type trieStoreMock struct {
+	// the trie updates sub-tries in parallel in UpdateBatch
+	mu             sync.Mutex
 	inMemoryNodes  map[string][]byte
 	inMemoryValues map[string][]byte
 	persistNodes   map[string][]byte
 	persistValues  map[string][]byte
 	lastBlock      uint64
+	flatLayout     bool
 }
 
 type nodeBytesWithType struct {
@@ -866,6 +920,9 @@ func newMockStore() Store {
 }
 
 func (s *trieStoreMock) GetNode(nodePtr []byte) (TrieNode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	key := base64.StdEncoding.EncodeToString(nodePtr)
 	nodeBytes, ok := s.persistNodes[key]
 	if !ok {
@@ -911,6 +968,9 @@ func (s *trieStoreMock) GetNode(nodePtr []byte) (TrieNode, error) {
 }
 
 func (s *trieStoreMock) GetValue(valuePtr []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	key := base64.StdEncoding.EncodeToString(valuePtr)
 	valueBytes, ok := s.persistValues[key]
 	if !ok {
@@ -920,6 +980,9 @@ func (s *trieStoreMock) GetValue(valuePtr []byte) ([]byte, error) {
 }
 
 func (s *trieStoreMock) PutNode(nodePtr []byte, node TrieNode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	key := base64.StdEncoding.EncodeToString(nodePtr)
 	var nb []byte
 	var err error
@@ -949,12 +1012,18 @@ func (s *trieStoreMock) PutNode(nodePtr []byte, node TrieNode) error {
 }
 
 func (s *trieStoreMock) PutValue(valuePtr, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	key := base64.StdEncoding.EncodeToString(valuePtr)
 	s.inMemoryValues[key] = value
 	return nil
 }
 
 func (s *trieStoreMock) PersistNode(nodePtr []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	key := base64.StdEncoding.EncodeToString(nodePtr)
 	nb, ok := s.inMemoryNodes[key]
 	if ok {
@@ -965,6 +1034,9 @@ func (s *trieStoreMock) PersistNode(nodePtr []byte) (bool, error) {
 }
 
 func (s *trieStoreMock) PersistValue(valuePtr []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	key := base64.StdEncoding.EncodeToString(valuePtr)
 	vb, ok := s.inMemoryValues[key]
 	if ok {
@@ -975,6 +1047,9 @@ func (s *trieStoreMock) PersistValue(valuePtr []byte) (bool, error) {
 }
 
 func (s *trieStoreMock) CommitChanges(blockNum uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.lastBlock = blockNum
 	s.inMemoryNodes = make(map[string][]byte)
 	s.inMemoryValues = make(map[string][]byte)
@@ -985,6 +1060,15 @@ func (s *trieStoreMock) RollbackChanges() error {
 	return nil
 }
 
+func (s *trieStoreMock) FlatLayout() (bool, error) {
+	return s.flatLayout, nil
+}
+
+func (s *trieStoreMock) SetFlatLayout() error {
+	s.flatLayout = true
+	return nil
+}
+
 func (s *trieStoreMock) Height() (uint64, error) {
 	return s.lastBlock, nil
 }
@@ -1011,7 +1095,7 @@ func (t *MPTrie) getStatistic(n TrieNode) (branchNodeNum int, extensionNodeNum i
 		}
 		for _, childPtr := range n.(*BranchNode).Children {
 			if childPtr != nil {
-				child, err := t.store.GetNode(childPtr)
+				child, err := t.loadNode(childPtr)
 				if err != nil {
 					return 0, 0, 0, 0, err
 				}
@@ -1024,7 +1108,7 @@ func (t *MPTrie) getStatistic(n TrieNode) (branchNodeNum int, extensionNodeNum i
 		}
 		return branchNodeNum, extensionNodeNum, valueNodeNum, valueNum, nil
 	case *ExtensionNode:
-		child, err := t.store.GetNode(n.(*ExtensionNode).Child)
+		child, err := t.loadNode(n.(*ExtensionNode).Child)
 		if err != nil {
 			return 0, 0, 0, 0, err
 		}
@@ -1083,7 +1167,7 @@ func (t *MPTrie) printTrie(n TrieNode, depth, ident int) error {
 
 		for _, childPtr := range n.(*BranchNode).Children {
 			if childPtr != nil {
-				child, err := t.store.GetNode(childPtr)
+				child, err := t.loadNode(childPtr)
 				if err != nil {
 					return err
 				}
@@ -1094,7 +1178,7 @@ func (t *MPTrie) printTrie(n TrieNode, depth, ident int) error {
 			}
 		}
 	case *ExtensionNode:
-		child, err := t.store.GetNode(n.(*ExtensionNode).Child)
+		child, err := t.loadNode(n.(*ExtensionNode).Child)
 		if err != nil {
 			return err
 		}
@@ -1179,7 +1263,7 @@ func (t *MPTrie) printTrie(n TrieNode, depth, ident int) error {
 
 func checkTrie(t *testing.T, trie *MPTrie, branchNodesNum, extensionNodesNum, valueNodesNum, valuesNum int, keysAndValues map[string][]byte) {
 	validateTrieStatistic(t, trie, branchNodesNum, extensionNodesNum, valueNodesNum, valuesNum)
-	validateTrie(t, trie.store, trie.root, true)
+	validateTrie(t, trie, trie.root, true)
 	keys := make([][]byte, 0)
 	values := make([][]byte, 0)
 	for keyStr, v := range keysAndValues {
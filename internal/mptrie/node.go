@@ -99,3 +99,19 @@ func (m *EmptyNode) hash() ([]byte, error) {
 func (m *EmptyNode) bytes() [][]byte {
 	panic("can't hash empty node")
 }
+
+// cloneNode returns a copy of the node, so that the node can be changed without affecting the copy
+func cloneNode(node TrieNode) TrieNode {
+	switch n := node.(type) {
+	case *BranchNode:
+		children := make([][]byte, len(n.Children))
+		copy(children, n.Children)
+		return &BranchNode{Children: children, ValuePtr: n.ValuePtr, Deleted: n.Deleted}
+	case *ExtensionNode:
+		return &ExtensionNode{Key: n.Key, Child: n.Child}
+	case *ValueNode:
+		return &ValueNode{Key: n.Key, ValuePtr: n.ValuePtr, Deleted: n.Deleted}
+	default:
+		return node
+	}
+}
@@ -9,6 +9,14 @@ import (
 // for given key and delete flag, i.e. if value was deleted, but delete flag id false,
 // no proof will be calculated
 func (t *MPTrie) GetProof(key []byte, isDeleted bool) (*state.Proof, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// the proof holds hashes, so the hashes of the dirty nodes must be calculated first
+	if _, err := t.hashRoot(); err != nil {
+		return nil, err
+	}
+
 	hexKey := convertByteToHex(key)
 	path, node, err := t.getPath(hexKey)
 
@@ -0,0 +1,290 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mptrie
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/pkg/state"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// ErrFlatLayout is returned for the database root hashes of a state held in a single trie
+var ErrFlatLayout = errors.New("the state of the ledger is held in a single trie, which has no database root hashes")
+
+// StateTrie is the system wide state trie, which consists of a trie per database and a top level trie. The key of a
+// value in the trie of a database is the composite key of the database name and the key of the value, as constructed
+// by state.ConstructCompositeKey. The top level trie holds the root hashes of the database tries, keyed by the
+// composite key of the database name only, and its root hash is the state root hash stored in the block header.
+//
+// The root hash of a database trie is the value pointer in the top level trie, hence, the proof of a value is the
+// proof in the database trie followed by the proof of the database root hash in the top level trie, and it is verified
+// against the state root hash with state.Proof, as the proof of a single trie is. The first part of the proof can be
+// verified on its own against the root hash of the database, e.g., by a tenant who only tracks its database.
+//
+// The ledgers created before the state was split into a trie per database keep the state in a single trie, which holds
+// the values of all the databases keyed by their composite keys, as the state roots of their committed blocks are the
+// roots of that trie. The state of such a ledger has no database root hashes.
+type StateTrie struct {
+	lock  sync.Mutex
+	store Store
+	// flat is set if the state is held in a single trie, i.e., in top
+	flat bool
+	// top holds the root hashes of the database tries
+	top *MPTrie
+	// dbTries holds the database tries loaded so far
+	dbTries map[string]*MPTrie
+	// updatedDBs holds the databases updated since the last commit
+	updatedDBs map[string]struct{}
+}
+
+// NewStateTrie creates the system wide state trie with the given root hash. If the root hash is nil, an empty trie
+// is created.
+func NewStateTrie(rootHash []byte, store Store) (*StateTrie, error) {
+	flat, err := store.FlatLayout()
+	if err != nil {
+		return nil, err
+	}
+	top, err := NewTrie(rootHash, store)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateTrie{
+		store:      store,
+		flat:       flat,
+		top:        top,
+		dbTries:    make(map[string]*MPTrie),
+		updatedDBs: make(map[string]struct{}),
+	}, nil
+}
+
+// Hash returns the root hash of the state trie
+func (s *StateTrie) Hash() ([]byte, error) {
+	return s.top.Hash()
+}
+
+// ApplyUpdates applies the updates of each database to its trie, where the keys of the updates are the keys of the
+// values in the database. The database tries are updated in parallel, and their root hashes are then set in the top
+// level trie.
+func (s *StateTrie) ApplyUpdates(dbsUpdates map[string][]*KeyUpdate) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var dbNames []string
+	trieUpdates := make(map[string][]*KeyUpdate)
+	for dbName, updates := range dbsUpdates {
+		if len(updates) == 0 {
+			continue
+		}
+
+		for _, u := range updates {
+			compositeKey, err := state.ConstructCompositeKey(dbName, string(u.Key))
+			if err != nil {
+				return err
+			}
+			trieUpdates[dbName] = append(trieUpdates[dbName], &KeyUpdate{Key: compositeKey, Value: u.Value, ValueHash: u.ValueHash, Delete: u.Delete})
+		}
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	// the go-routines updating and hashing the database tries and their sub-tries are bounded by the number of cores
+	sem := make(chan struct{}, runtime.NumCPU())
+	if s.flat {
+		var updates []*KeyUpdate
+		for _, dbName := range dbNames {
+			updates = append(updates, trieUpdates[dbName]...)
+		}
+		return s.top.updateBatchWithLimit(updates, sem)
+	}
+
+	tries := make([]*MPTrie, len(dbNames))
+	for i, dbName := range dbNames {
+		var err error
+		if tries[i], err = s.dbTrie(dbName); err != nil {
+			return err
+		}
+	}
+
+	rootHashes := make([][]byte, len(dbNames))
+	errs := make([]error, len(dbNames))
+	updateTrie := func(i int, dbName string) {
+		if errs[i] = tries[i].updateBatchWithLimit(trieUpdates[dbName], sem); errs[i] != nil {
+			return
+		}
+		rootHashes[i], errs[i] = tries[i].Hash()
+	}
+
+	var wg sync.WaitGroup
+	for i, dbName := range dbNames {
+		select {
+		case sem <- struct{}{}:
+			wg.Add(1)
+			go func(i int, dbName string) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				updateTrie(i, dbName)
+			}(i, dbName)
+		default:
+			updateTrie(i, dbName)
+		}
+	}
+	wg.Wait()
+
+	for i, dbName := range dbNames {
+		if errs[i] != nil {
+			return errors.WithMessagef(errs[i], "error while updating the state trie of database [%s]", dbName)
+		}
+
+		rootHash := rootHashes[i]
+		dbKey, err := state.ConstructCompositeKey(dbName, "")
+		if err != nil {
+			return err
+		}
+		if err := s.top.updateSubtrieRoot(dbKey, rootHash); err != nil {
+			return err
+		}
+		s.updatedDBs[dbName] = struct{}{}
+	}
+
+	return nil
+}
+
+// UpdatedDBRootHashes returns the root hashes of the databases updated since the last commit, sorted by database name
+func (s *StateTrie) UpdatedDBRootHashes() ([]*types.DBStateRootHash, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var dbNames []string
+	for dbName := range s.updatedDBs {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	var rootHashes []*types.DBStateRootHash
+	for _, dbName := range dbNames {
+		rootHash, err := s.dbTries[dbName].Hash()
+		if err != nil {
+			return nil, err
+		}
+		rootHashes = append(rootHashes, &types.DBStateRootHash{
+			DbName:   dbName,
+			RootHash: rootHash,
+		})
+	}
+
+	return rootHashes, nil
+}
+
+// GetDBRootHash returns the root hash of the trie of a database, or nil if the database holds no state
+func (s *StateTrie) GetDBRootHash(dbName string) ([]byte, error) {
+	if s.flat {
+		return nil, ErrFlatLayout
+	}
+	dbKey, err := state.ConstructCompositeKey(dbName, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.top.getSubtrieRoot(dbKey)
+}
+
+// Get returns the value of a key in a database
+func (s *StateTrie) Get(dbName, key string) ([]byte, error) {
+	s.lock.Lock()
+	trie, err := s.dbTrie(dbName)
+	s.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	compositeKey, err := state.ConstructCompositeKey(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+	return trie.Get(compositeKey)
+}
+
+// GetProof calculates the proof (path) from the node that contains the value of a key in a database to the root of
+// the state trie, for the given delete flag, as MPTrie.GetProof does. It returns nil if no proof exists.
+func (s *StateTrie) GetProof(dbName, key string, isDeleted bool) (*state.Proof, error) {
+	s.lock.Lock()
+	trie, err := s.dbTrie(dbName)
+	s.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	compositeKey, err := state.ConstructCompositeKey(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+	dbProof, err := trie.GetProof(compositeKey, isDeleted)
+	if err != nil || dbProof == nil || s.flat {
+		return dbProof, err
+	}
+
+	rootProof, err := s.GetDBRootProof(dbName)
+	if err != nil || rootProof == nil {
+		return nil, err
+	}
+
+	return state.NewProof(append(dbProof.GetPath(), rootProof.GetPath()...)), nil
+}
+
+// GetDBRootProof calculates the proof (path) from the node that holds the root hash of the trie of a database to the
+// root of the state trie. It returns nil if the database holds no state.
+func (s *StateTrie) GetDBRootProof(dbName string) (*state.Proof, error) {
+	if s.flat {
+		return nil, ErrFlatLayout
+	}
+	dbKey, err := state.ConstructCompositeKey(dbName, "")
+	if err != nil {
+		return nil, err
+	}
+	return s.top.GetProof(dbKey, false)
+}
+
+// Commit stores the changes of the database tries and of the top level trie in the trie store
+func (s *StateTrie) Commit(blockNum uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for dbName := range s.updatedDBs {
+		if err := s.dbTries[dbName].persist(); err != nil {
+			return err
+		}
+	}
+	if err := s.top.Commit(blockNum); err != nil {
+		return err
+	}
+
+	s.updatedDBs = make(map[string]struct{})
+	return nil
+}
+
+func (s *StateTrie) dbTrie(dbName string) (*MPTrie, error) {
+	if s.flat {
+		return s.top, nil
+	}
+	if trie, ok := s.dbTries[dbName]; ok {
+		return trie, nil
+	}
+
+	rootHash, err := s.GetDBRootHash(dbName)
+	if err != nil {
+		return nil, err
+	}
+	trie, err := NewTrie(rootHash, s.store)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while loading the state trie of database [%s]", dbName)
+	}
+
+	s.dbTries[dbName] = trie
+	return trie, nil
+}
@@ -0,0 +1,186 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package mptrie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/pkg/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateTrie(t *testing.T) {
+	store := newMockStore()
+	trie, err := NewStateTrie(nil, store)
+	require.NoError(t, err)
+
+	dbsUpdates := map[string][]*KeyUpdate{
+		"db1": nil,
+		"db2": nil,
+	}
+	for i := 0; i < 100; i++ {
+		dbsUpdates["db1"] = append(dbsUpdates["db1"], &KeyUpdate{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("db1-value%d", i))})
+	}
+	for i := 0; i < 10; i++ {
+		dbsUpdates["db2"] = append(dbsUpdates["db2"], &KeyUpdate{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("db2-value%d", i))})
+	}
+	require.NoError(t, trie.ApplyUpdates(dbsUpdates))
+
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+
+	dbRoots, err := trie.UpdatedDBRootHashes()
+	require.NoError(t, err)
+	require.Len(t, dbRoots, 2)
+	require.Equal(t, "db1", dbRoots[0].DbName)
+	require.Equal(t, "db2", dbRoots[1].DbName)
+
+	verifyValue := func(trie *StateTrie, rootHash []byte, dbName, key string, value []byte, isDeleted bool) {
+		compositeKey, err := state.ConstructCompositeKey(dbName, key)
+		require.NoError(t, err)
+		kvHash, err := state.CalculateKeyValueHash(compositeKey, value)
+		require.NoError(t, err)
+
+		proof, err := trie.GetProof(dbName, key, isDeleted)
+		require.NoError(t, err)
+		require.NotNil(t, proof)
+		isValid, err := proof.Verify(kvHash, rootHash, isDeleted)
+		require.NoError(t, err)
+		require.True(t, isValid)
+
+		// the proof starts with the path in the trie of the database, which can be verified against the root of the
+		// database, and continues with the path of the root of the database in the top level trie
+		dbRootHash, err := trie.GetDBRootHash(dbName)
+		require.NoError(t, err)
+		dbRootProof, err := trie.GetDBRootProof(dbName)
+		require.NoError(t, err)
+		isValid, err = dbRootProof.Verify(dbRootHash, rootHash, false)
+		require.NoError(t, err)
+		require.True(t, isValid)
+
+		dbPathLen := len(proof.GetPath()) - len(dbRootProof.GetPath())
+		require.Equal(t, dbRootProof.GetPath(), proof.GetPath()[dbPathLen:])
+		isValid, err = state.NewProof(proof.GetPath()[:dbPathLen]).Verify(kvHash, dbRootHash, isDeleted)
+		require.NoError(t, err)
+		require.True(t, isValid)
+	}
+
+	for _, dbRoot := range dbRoots {
+		dbRootHash, err := trie.GetDBRootHash(dbRoot.DbName)
+		require.NoError(t, err)
+		require.Equal(t, dbRoot.RootHash, dbRootHash)
+	}
+	for i := 0; i < 10; i++ {
+		value, err := trie.Get("db2", fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("db2-value%d", i)), value)
+		verifyValue(trie, rootHash, "db2", fmt.Sprintf("key%d", i), value, false)
+	}
+	verifyValue(trie, rootHash, "db1", "key50", []byte("db1-value50"), false)
+
+	// a database without state has no root and no proof
+	dbRootHash, err := trie.GetDBRootHash("db3")
+	require.NoError(t, err)
+	require.Nil(t, dbRootHash)
+	proof, err := trie.GetProof("db3", "key1", false)
+	require.NoError(t, err)
+	require.Nil(t, proof)
+
+	require.NoError(t, trie.Commit(1))
+	dbRoots, err = trie.UpdatedDBRootHashes()
+	require.NoError(t, err)
+	require.Empty(t, dbRoots)
+
+	// the root of a database does not change by the updates of other databases
+	trie, err = NewStateTrie(rootHash, store)
+	require.NoError(t, err)
+	db1RootHash, err := trie.GetDBRootHash("db1")
+	require.NoError(t, err)
+
+	require.NoError(t, trie.ApplyUpdates(map[string][]*KeyUpdate{
+		"db2": {
+			{Key: []byte("key1"), Delete: true},
+			{Key: []byte("key2"), Value: []byte("new-value")},
+		},
+	}))
+	newRootHash, err := trie.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, rootHash, newRootHash)
+
+	dbRoots, err = trie.UpdatedDBRootHashes()
+	require.NoError(t, err)
+	require.Len(t, dbRoots, 1)
+	require.Equal(t, "db2", dbRoots[0].DbName)
+	dbRootHash, err = trie.GetDBRootHash("db1")
+	require.NoError(t, err)
+	require.Equal(t, db1RootHash, dbRootHash)
+
+	require.NoError(t, trie.Commit(2))
+	trie, err = NewStateTrie(newRootHash, store)
+	require.NoError(t, err)
+
+	value, err := trie.Get("db2", "key1")
+	require.NoError(t, err)
+	require.Nil(t, value)
+	verifyValue(trie, newRootHash, "db2", "key1", []byte("db2-value1"), true)
+	verifyValue(trie, newRootHash, "db2", "key2", []byte("new-value"), false)
+	verifyValue(trie, newRootHash, "db1", "key99", []byte("db1-value99"), false)
+}
+
+func TestStateTrie_SingleTrieLayout(t *testing.T) {
+	store := newMockStore()
+	require.NoError(t, store.SetFlatLayout())
+	trie, err := NewStateTrie(nil, store)
+	require.NoError(t, err)
+
+	// the state is held in a single trie, as in the ledgers created before the split, such that the state root is
+	// the root of the trie of all the composite keys
+	legacyTrie, err := NewTrie(nil, newMockStore())
+	require.NoError(t, err)
+	dbsUpdates := map[string][]*KeyUpdate{}
+	for _, dbName := range []string{"db1", "db2"} {
+		for i := 0; i < 10; i++ {
+			key := fmt.Sprintf("key%d", i)
+			value := []byte(fmt.Sprintf("%s-value%d", dbName, i))
+			dbsUpdates[dbName] = append(dbsUpdates[dbName], &KeyUpdate{Key: []byte(key), Value: value})
+
+			compositeKey, err := state.ConstructCompositeKey(dbName, key)
+			require.NoError(t, err)
+			require.NoError(t, legacyTrie.Update(compositeKey, value))
+		}
+	}
+	require.NoError(t, trie.ApplyUpdates(dbsUpdates))
+
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+	legacyRootHash, err := legacyTrie.Hash()
+	require.NoError(t, err)
+	require.Equal(t, legacyRootHash, rootHash)
+
+	dbRoots, err := trie.UpdatedDBRootHashes()
+	require.NoError(t, err)
+	require.Empty(t, dbRoots)
+	_, err = trie.GetDBRootHash("db1")
+	require.Equal(t, ErrFlatLayout, err)
+	_, err = trie.GetDBRootProof("db1")
+	require.Equal(t, ErrFlatLayout, err)
+
+	require.NoError(t, trie.Commit(1))
+	trie, err = NewStateTrie(rootHash, store)
+	require.NoError(t, err)
+
+	value, err := trie.Get("db2", "key3")
+	require.NoError(t, err)
+	require.Equal(t, []byte("db2-value3"), value)
+
+	compositeKey, err := state.ConstructCompositeKey("db2", "key3")
+	require.NoError(t, err)
+	kvHash, err := state.CalculateKeyValueHash(compositeKey, value)
+	require.NoError(t, err)
+	proof, err := trie.GetProof("db2", "key3", false)
+	require.NoError(t, err)
+	isValid, err := proof.Verify(kvHash, rootHash, false)
+	require.NoError(t, err)
+	require.True(t, isValid)
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/storeformat"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -32,8 +33,27 @@ var (
 	trieValueNs = []byte{1}
 	// last block stored
 	lastBlockNs = []byte{2}
+	// flatLayoutKey marks a store that holds the state in a single trie, see mptrie.StateTrie
+	flatLayoutKey = []byte{3}
 )
 
+// format is the on-disk format of the state trie store. A change of the encoding of the trie nodes or values
+// increments the version, and registers the migration from the previous version.
+var format = &storeformat.Format{
+	Store:   "state trie store",
+	Version: 3,
+	Migrations: map[uint32]*storeformat.Migration{
+		1: {
+			Description: "keep the single state trie of the store, as the state roots of the committed blocks are its roots",
+			Migrate:     markFlatLayout,
+		},
+		2: {
+			Description: "a value may be the marshaled blob manifest carried by a data write in place of the value",
+			Migrate:     keepValues,
+		},
+	},
+}
+
 // Store maintains MPTrie nodes and values in backend store
 type Store struct {
 	trieDataDB      *leveldb.DB
@@ -79,6 +99,9 @@ func Open(c *Config) (*Store, error) {
 
 		return openNewStore(c)
 	default:
+		if err := format.Upgrade(c.StoreDir, c.Logger); err != nil {
+			return nil, err
+		}
 		return openExistingStore(c)
 	}
 }
@@ -109,6 +132,10 @@ func openNewStore(c *Config) (*Store, error) {
 		return nil, errors.WithMessage(err, "error while creating an trie data database")
 	}
 
+	if err := format.Init(c.StoreDir); err != nil {
+		return nil, err
+	}
+
 	if err := fileops.Remove(underCreationFlagPath); err != nil {
 		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
 	}
@@ -153,3 +180,28 @@ func (s *Store) Close() error {
 	}
 	return nil
 }
+
+// markFlatLayout marks a store created before the state trie was split into a trie per database, which holds the
+// state in a single trie. The store keeps the single trie, such that the state roots it calculates for the next blocks
+// are those calculated by the other nodes of the ledger, and the proofs of the committed blocks are still served.
+// keepValues migrates the trie store to the format in which a trie value may hold the blob manifest of a data write.
+// The values of the blocks committed so far are the values written by their transactions, which predate the blob
+// manifests, hence none of them needs to change. The version still changes, so that a node that predates the blob
+// manifests does not open a store whose state roots it cannot recompute.
+func keepValues(_ string, _ *logger.SugarLogger) error {
+	return nil
+}
+
+func markFlatLayout(storeDir string, _ *logger.SugarLogger) error {
+	trieDataDBPath := filepath.Join(storeDir, trieDataDBName)
+	trieDataDB, err := leveldb.OpenFile(trieDataDBPath, &opt.Options{ErrorIfMissing: true})
+	if err != nil {
+		return errors.WithMessage(err, "error while opening the existing leveldb file for the trie data")
+	}
+
+	if err = trieDataDB.Put(flatLayoutKey, []byte{1}, &opt.WriteOptions{Sync: true}); err != nil {
+		trieDataDB.Close()
+		return errors.Wrap(err, "error while marking the single trie layout")
+	}
+	return trieDataDB.Close()
+}
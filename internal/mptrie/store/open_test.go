@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/storeformat"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/stretchr/testify/require"
 )
@@ -147,6 +148,40 @@ func TestOpen(t *testing.T) {
 		lastBlock, err := s.Height()
 		require.NoError(t, err)
 		require.Equal(t, uint64(999), lastBlock)
+		flat, err := s.FlatLayout()
+		require.NoError(t, err)
+		require.False(t, flat)
+	})
+
+	t.Run("reopen a store created before the format version", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir(".", "open_test")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		storeDir := filepath.Join(testDir, "reopen-legacy-store")
+		c := &Config{
+			StoreDir: storeDir,
+			Logger:   logger,
+		}
+		s, err := Open(c)
+		require.NoError(t, err)
+		pointers := fillStore(t, s, true, 0, uint64(99))
+		require.NoError(t, s.Close())
+
+		// the stores created before the format version hold the state in a single trie, which is kept
+		require.NoError(t, os.Remove(filepath.Join(storeDir, "formatversion")))
+		s, err = Open(c)
+		require.NoError(t, err)
+
+		checkStoreContent(t, s, pointers, true, true, 0)
+		flat, err := s.FlatLayout()
+		require.NoError(t, err)
+		require.True(t, flat)
+		version, err := storeformat.ReadVersion(storeDir)
+		require.NoError(t, err)
+		require.Equal(t, format.Version, version)
 	})
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"errors"
 
+	"github.com/hyperledger-labs/orion-server/internal/failpoint"
 	"github.com/hyperledger-labs/orion-server/internal/mptrie"
 	"github.com/golang/protobuf/proto"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -155,6 +156,10 @@ func (s *Store) Height() (uint64, error) {
 }
 
 func (s *Store) CommitChanges(blockNum uint64) error {
+	if err := failpoint.Inject(failpoint.TrieStoreCommit); err != nil {
+		return err
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -191,3 +196,15 @@ func (s *Store) RollbackChanges() error {
 	s.inMemoryValues = make(map[string][]byte)
 	return nil
 }
+
+func (s *Store) FlatLayout() (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.trieDataDB.Has(flatLayoutKey, &opt.ReadOptions{})
+}
+
+func (s *Store) SetFlatLayout() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.trieDataDB.Put(flatLayoutKey, []byte{1}, &opt.WriteOptions{Sync: true})
+}
@@ -11,12 +11,29 @@ import (
 	"github.com/pkg/errors"
 )
 
+// CalculateBlockTxHashes returns the hashes of the transactions of a block, along with their validation info, i.e., the
+// leaves of the tx Merkle tree of the block
+func CalculateBlockTxHashes(block *types.Block) ([][]byte, error) {
+	return calculateBlockTxHashes(block)
+}
+
 func calculateBlockTxHashes(block *types.Block) ([][]byte, error) {
 	hashes := make([][]byte, 0)
 
 	switch block.Payload.(type) {
 	case *types.Block_DataTxEnvelopes:
+		sealedTxHashes := make(map[uint64][]byte, len(block.GetSealedTxHashes()))
+		for _, h := range block.GetSealedTxHashes() {
+			sealedTxHashes[h.GetTxIndex()] = h.GetHash()
+		}
+
 		for i, tx := range block.GetDataTxEnvelopes().GetEnvelopes() {
+			// a transaction whose values were erased is hashed as it was committed
+			if h, ok := sealedTxHashes[uint64(i)]; ok && hasErasedWrites(tx) {
+				hashes = append(hashes, h)
+				continue
+			}
+
 			h, err := calculateTxHash(tx, block.GetHeader().GetValidationInfo()[i])
 			if err != nil {
 				return nil, errors.Wrapf(err, "can't calculate msg hash %v", tx.GetPayload())
@@ -51,6 +68,17 @@ func calculateBlockTxHashes(block *types.Block) ([][]byte, error) {
 
 }
 
+func hasErasedWrites(tx *types.DataTxEnvelope) bool {
+	for _, ops := range tx.GetPayload().GetDbOperations() {
+		for _, w := range ops.GetDataWrites() {
+			if w.GetErased() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func calculateTxHash(msg proto.Message, valInfo proto.Message) ([]byte, error) {
 	payloadBytes, err := json.Marshal(msg)
 	if err != nil {
@@ -0,0 +1,68 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ordering abstracts the source of the ordered blocks that the block processor commits. By default the blocks
+// are ordered by the built-in Raft consensus of the replication package. Alternatively, the blocks may be ordered by an
+// external ordering service, e.g., an existing Kafka-based orderer, which is selected by the consensus algorithm of the
+// ClusterConfig.
+package ordering
+
+import (
+	"time"
+
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// RaftAlgorithm is the consensus algorithm of the built-in Raft consensus.
+const RaftAlgorithm = "raft"
+
+// Dispatcher accepts the block proposals of the block creator, orders them, and dispatches the ordered blocks to the
+// block processor, through the block one-queue-barrier.
+type Dispatcher interface {
+	// Submit a block proposal for ordering.
+	Submit(block *types.Block) error
+	// Start the internal go-routines that order and dispatch the blocks.
+	Start()
+	// Close stops the internal go-routines, and returns an error if the dispatcher is already closed.
+	Close() error
+	// IsLeader returns nil if the local node accepts transactions, or an error that points to the node that does.
+	IsLeader() *ierrors.NotLeaderError
+	// GetClusterStatus returns the Raft ID of the leader, and the active consensus members.
+	GetClusterStatus() (leaderID uint64, activePeers map[string]*types.PeerConfig)
+	// TriggerSnapshot takes a consensus snapshot.
+	TriggerSnapshot() (*replication.SnapshotInfo, error)
+	// TransferLeadership transfers the leadership from the local node to the target node.
+	TransferLeadership(targetNodeID string, timeout time.Duration) (string, error)
+	// Diagnostics describes the state of consensus on the local node.
+	Diagnostics() *replication.Diagnostics
+	// VerifyQuorumAfterReConfig checks that a new cluster config leaves the cluster able to order blocks.
+	VerifyQuorumAfterReConfig(newClusterConfig *types.ClusterConfig) error
+	// DescribeReConfig reports the changes that a new cluster config makes to the current cluster config.
+	DescribeReConfig(newClusterConfig *types.ClusterConfig) *replication.ClusterReConfigChanges
+}
+
+var (
+	_ Dispatcher = (*replication.BlockReplicator)(nil)
+	_ Dispatcher = (*ExternalDispatcher)(nil)
+)
+
+// Service is a client of an external ordering service, which totally orders the proposals broadcast by all the nodes
+// of the cluster into a single stream, e.g., a Kafka topic with a single partition. The entries of the stream are
+// numbered by consecutive offsets, starting at 0, and the stream must retain all of them, as a node that starts with
+// an empty ledger consumes the stream from the start.
+type Service interface {
+	// Broadcast appends a proposal to the stream.
+	Broadcast(proposal []byte) error
+	// Deliver opens the stream at an offset.
+	Deliver(offset uint64) (Stream, error)
+}
+
+// Stream delivers the entries of the stream of an ordering service, in order.
+type Stream interface {
+	// Recv blocks until the next entry is available and returns it, or returns an error if the stream is closed.
+	Recv() ([]byte, error)
+	// Close the stream, and unblock a pending Recv.
+	Close() error
+}
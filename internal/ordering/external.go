@@ -0,0 +1,396 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ordering
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultReconnectInterval is the time the external dispatcher waits before it re-opens a stream that failed.
+const DefaultReconnectInterval = time.Second
+
+// ExternalConfig holds the configuration information required to initialize the external dispatcher.
+type ExternalConfig struct {
+	NodeID               string
+	ClusterConfig        *types.ClusterConfig
+	Service              Service
+	LedgerReader         replication.BlockLedgerReader
+	BlockOneQueueBarrier *queue.OneQueueBarrier
+	PendingTxs           replication.PendingTxsReleaser
+	// ReconnectInterval is the time to wait before re-opening a stream that failed. If zero,
+	// DefaultReconnectInterval is used.
+	ReconnectInterval time.Duration
+	Logger            *logger.SugarLogger
+}
+
+// ExternalDispatcher orders the blocks with an external ordering service. Every node broadcasts the block proposals
+// it creates to the service, and consumes the stream of proposals ordered by the service. A proposal carries only the
+// payload of the block and the time it was proposed; the consuming node assigns the rest of the header, which depends
+// only on the stream and on the previous blocks, hence all the nodes assemble the same chain.
+//
+// The genesis block is not ordered by the service, so the block number N is the entry at offset N-2 of the stream.
+//
+// Every node accepts transactions, and there is no leader. The uniqueness of the TxID is checked by the node that
+// accepts the transaction, against the transactions it knows of.
+type ExternalDispatcher struct {
+	nodeID            string
+	raftID            uint64
+	algorithm         string
+	service           Service
+	oneQueueBarrier   *queue.OneQueueBarrier
+	pendingTxs        replication.PendingTxsReleaser
+	reconnectInterval time.Duration
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+
+	mutex              sync.Mutex
+	clusterConfig      *types.ClusterConfig
+	lastCommittedBlock *types.Block
+	stream             Stream
+
+	lg *logger.SugarLogger
+}
+
+// NewExternalDispatcher creates a new ExternalDispatcher. The ledger must hold at least the genesis block, as a node
+// cannot join a cluster that orders its blocks with an external ordering service with a join block.
+func NewExternalDispatcher(conf *ExternalConfig) (*ExternalDispatcher, error) {
+	raftID, err := comm.MemberRaftID(conf.NodeID, conf.ClusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = comm.VerifyProtocolVersionSupported(conf.ClusterConfig); err != nil {
+		return nil, errors.WithMessage(err, "the server must be upgraded")
+	}
+
+	height, err := conf.LedgerReader.Height()
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return nil, errors.New("the ledger is empty, a node that orders blocks with an external ordering service must start from the genesis block")
+	}
+	lastCommittedBlock, err := conf.LedgerReader.Get(height)
+	if err != nil {
+		return nil, err
+	}
+
+	reconnectInterval := conf.ReconnectInterval
+	if reconnectInterval == 0 {
+		reconnectInterval = DefaultReconnectInterval
+	}
+
+	algorithm := conf.ClusterConfig.GetConsensusConfig().GetAlgorithm()
+	return &ExternalDispatcher{
+		nodeID:             conf.NodeID,
+		raftID:             raftID,
+		algorithm:          algorithm,
+		service:            conf.Service,
+		oneQueueBarrier:    conf.BlockOneQueueBarrier,
+		pendingTxs:         conf.PendingTxs,
+		reconnectInterval:  reconnectInterval,
+		stopCh:             make(chan struct{}),
+		doneCh:             make(chan struct{}),
+		clusterConfig:      conf.ClusterConfig,
+		lastCommittedBlock: lastCommittedBlock,
+		lg:                 conf.Logger.With("nodeID", conf.NodeID, "ordering", algorithm),
+	}, nil
+}
+
+// Submit broadcasts a block proposal to the ordering service.
+//
+// If the broadcast fails, the transactions in the block are released with an error, and the block is dropped.
+// Returns an error if the component is already closed.
+func (d *ExternalDispatcher) Submit(block *types.Block) error {
+	select {
+	case <-d.stopCh:
+		return &ierrors.ClosedError{ErrMsg: "ordering dispatcher closed"}
+	default:
+	}
+
+	proposalNum := block.GetHeader().GetBaseHeader().GetNumber()
+	proposal := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Timestamp: time.Now().UnixNano(),
+			},
+		},
+		Payload: block.Payload,
+	}
+	proposalBytes, err := proto.Marshal(proposal)
+	if err == nil {
+		err = d.service.Broadcast(proposalBytes)
+	}
+	if err != nil {
+		d.lg.Errorf("Failed to broadcast block proposal [%d] to the ordering service: %s", proposalNum, err)
+		if txIDs, errID := utils.BlockPayloadToTxIDs(block.Payload); errID == nil {
+			d.pendingTxs.ReleaseWithError(txIDs, errors.WithMessage(err, "failed to broadcast the block to the ordering service"))
+		} else {
+			d.lg.Errorf("failed to extract TXIDs from block: %s", errID)
+		}
+		return nil
+	}
+
+	d.lg.Debugf("Broadcast block proposal [%d]", proposalNum)
+	return nil
+}
+
+// Start the internal go-routine that consumes the stream of the ordering service.
+func (d *ExternalDispatcher) Start() {
+	go d.run()
+}
+
+func (d *ExternalDispatcher) run() {
+	defer close(d.doneCh)
+
+	d.lg.Info("Starting to consume the ordering service")
+	var stream Stream
+	for {
+		if stream == nil {
+			d.mutex.Lock()
+			lastCommittedBlockNum := d.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber()
+			d.mutex.Unlock()
+
+			var err error
+			if stream, err = d.openStream(lastCommittedBlockNum - 1); err != nil {
+				if _, ok := err.(*ierrors.ClosedError); ok {
+					return
+				}
+				d.lg.Warnf("Failed to open the stream of the ordering service at block [%d]: %s", lastCommittedBlockNum+1, err)
+				if !d.waitReconnect() {
+					return
+				}
+				continue
+			}
+		}
+
+		entry, err := stream.Recv()
+		if err != nil {
+			select {
+			case <-d.stopCh:
+				return
+			default:
+			}
+			d.lg.Warnf("Failed to receive from the stream of the ordering service: %s", err)
+			if errClose := stream.Close(); errClose != nil {
+				d.lg.Debugf("Failed to close the stream of the ordering service: %s", errClose)
+			}
+			stream = nil
+			if !d.waitReconnect() {
+				return
+			}
+			continue
+		}
+
+		if err = d.commitBlock(d.assembleBlock(entry)); err != nil {
+			d.lg.Infof("Stopping to consume the ordering service: %s", err)
+			return
+		}
+	}
+}
+
+// openStream opens the stream at an offset, unless the component is closed.
+func (d *ExternalDispatcher) openStream(offset uint64) (Stream, error) {
+	stream, err := d.service.Deliver(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	select {
+	case <-d.stopCh:
+		_ = stream.Close()
+		return nil, &ierrors.ClosedError{ErrMsg: "ordering dispatcher closed"}
+	default:
+	}
+	d.stream = stream
+	return stream, nil
+}
+
+func (d *ExternalDispatcher) waitReconnect() bool {
+	select {
+	case <-d.stopCh:
+		return false
+	case <-time.After(d.reconnectInterval):
+		return true
+	}
+}
+
+// assembleBlock assigns the header of the block that follows the last committed block to a proposal. The timestamp
+// of the proposal never decreases along the chain, even if the clocks of the nodes that proposed the blocks differ.
+func (d *ExternalDispatcher) assembleBlock(entry []byte) *types.Block {
+	d.mutex.Lock()
+	lastCommittedBlock := d.lastCommittedBlock
+	d.mutex.Unlock()
+
+	lastCommittedBaseHeader := lastCommittedBlock.GetHeader().GetBaseHeader()
+	blockNum := lastCommittedBaseHeader.GetNumber() + 1
+
+	proposal := &types.Block{}
+	if err := proto.Unmarshal(entry, proposal); err != nil || proposal.Payload == nil {
+		d.lg.Panicf("Error unmarshaling the entry of block [%d] from the ordering service, error: %v", blockNum, err)
+	}
+
+	lastCommittedBlockHash, err := blockstore.ComputeBlockHash(lastCommittedBlock)
+	if err != nil {
+		d.lg.Panicf("Error while creating block header for block: %d; error: %s", blockNum, err)
+	}
+	lastCommittedBlockBaseHash, err := blockstore.ComputeBlockBaseHash(lastCommittedBlock)
+	if err != nil {
+		d.lg.Panicf("Error while creating block header for block: %d; error: %s", blockNum, err)
+	}
+
+	timestamp := proposal.GetHeader().GetBaseHeader().GetTimestamp()
+	if timestamp < lastCommittedBaseHeader.GetTimestamp() {
+		timestamp = lastCommittedBaseHeader.GetTimestamp()
+	}
+
+	return &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:                 blockNum,
+				PreviousBaseHeaderHash: lastCommittedBlockBaseHash,
+				LastCommittedBlockHash: lastCommittedBlockHash,
+				LastCommittedBlockNum:  lastCommittedBaseHeader.GetNumber(),
+				Timestamp:              timestamp,
+			},
+		},
+		Payload: proposal.Payload,
+	}
+}
+
+// commitBlock enqueues the block for commit and waits for it to be committed. If the block is a config block, the
+// cluster config is updated.
+func (d *ExternalDispatcher) commitBlock(block *types.Block) error {
+	blockNumber := block.GetHeader().GetBaseHeader().GetNumber()
+	d.lg.Debugf("Enqueue for commit block [%d]", blockNumber)
+
+	reConfig, err := d.oneQueueBarrier.EnqueueWait(block)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.lastCommittedBlock = block
+	if reConfig != nil {
+		d.clusterConfig = reConfig.(*types.ClusterConfig)
+		d.lg.Infof("Updated the cluster config: block number [%d]", blockNumber)
+	}
+
+	return nil
+}
+
+// Close signals the internal go-routine to stop and waits for it to exit.
+// If the component is already closed, and error is returned.
+func (d *ExternalDispatcher) Close() (err error) {
+	err = &ierrors.ClosedError{ErrMsg: "ordering dispatcher already closed"}
+	d.stopOnce.Do(func() {
+		d.lg.Info("closing ordering dispatcher")
+
+		d.mutex.Lock()
+		close(d.stopCh)
+		if d.stream != nil {
+			if errClose := d.stream.Close(); errClose != nil {
+				d.lg.Debugf("Failed to close the stream of the ordering service: %s", errClose)
+			}
+		}
+		d.mutex.Unlock()
+
+		if errQB := d.oneQueueBarrier.Close(); errQB != nil {
+			d.lg.Debugf("OneQueueBarrier error: %s", errQB)
+		}
+		<-d.doneCh
+
+		err = nil
+	})
+
+	return err
+}
+
+// IsLeader always returns nil, as every node accepts transactions.
+func (d *ExternalDispatcher) IsLeader() *ierrors.NotLeaderError {
+	return nil
+}
+
+// GetClusterStatus reports the local node as the leader, as every node accepts transactions, and as the only active
+// member, as the nodes do not communicate with each other.
+func (d *ExternalDispatcher) GetClusterStatus() (leaderID uint64, activePeers map[string]*types.PeerConfig) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	activePeers = make(map[string]*types.PeerConfig)
+	for _, m := range d.clusterConfig.GetConsensusConfig().GetMembers() {
+		if m.NodeId == d.nodeID {
+			activePeers[m.NodeId] = m
+		}
+	}
+
+	return d.raftID, activePeers
+}
+
+func (d *ExternalDispatcher) TriggerSnapshot() (*replication.SnapshotInfo, error) {
+	return nil, errors.Errorf("consensus snapshots are not supported when blocks are ordered by the ordering service [%s]", d.algorithm)
+}
+
+func (d *ExternalDispatcher) TransferLeadership(_ string, _ time.Duration) (string, error) {
+	return "", errors.Errorf("leadership transfer is not supported when blocks are ordered by the ordering service [%s]", d.algorithm)
+}
+
+// Diagnostics describes the local node as the leader, with the name of the ordering service as its state.
+func (d *ExternalDispatcher) Diagnostics() *replication.Diagnostics {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	diag := &replication.Diagnostics{
+		RaftID:                   d.raftID,
+		RaftState:                d.algorithm,
+		LeaderID:                 d.raftID,
+		LastCommittedBlockNumber: d.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber(),
+	}
+	for _, m := range d.clusterConfig.GetConsensusConfig().GetMembers() {
+		diag.Peers = append(diag.Peers, &replication.PeerDiagnostics{
+			NodeID: m.NodeId,
+			RaftID: m.RaftId,
+			Active: m.NodeId == d.nodeID,
+		})
+	}
+
+	return diag
+}
+
+// VerifyQuorumAfterReConfig only checks that the new cluster config has a consensus config, as the availability of
+// the ordering service does not depend on the members of the cluster.
+func (d *ExternalDispatcher) VerifyQuorumAfterReConfig(newClusterConfig *types.ClusterConfig) error {
+	if newClusterConfig.GetConsensusConfig() == nil {
+		return errors.New("the new cluster config has no consensus config")
+	}
+	return nil
+}
+
+// DescribeReConfig reports the changes that a new cluster config makes to the current cluster config.
+func (d *ExternalDispatcher) DescribeReConfig(newClusterConfig *types.ClusterConfig) *replication.ClusterReConfigChanges {
+	d.mutex.Lock()
+	currentConfig := d.clusterConfig
+	d.mutex.Unlock()
+
+	return replication.DescribeClusterReConfig(currentConfig, newClusterConfig)
+}
@@ -0,0 +1,358 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ordering
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/replication/mocks"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// memService is an in-memory ordering service
+type memService struct {
+	mutex        sync.Mutex
+	cond         *sync.Cond
+	entries      [][]byte
+	broadcastErr error
+}
+
+func newMemService() *memService {
+	s := &memService{}
+	s.cond = sync.NewCond(&s.mutex)
+	return s
+}
+
+func (s *memService) Broadcast(proposal []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.broadcastErr != nil {
+		return s.broadcastErr
+	}
+	s.entries = append(s.entries, proposal)
+	s.cond.Broadcast()
+	return nil
+}
+
+func (s *memService) Deliver(offset uint64) (Stream, error) {
+	return &memStream{service: s, offset: offset}, nil
+}
+
+type memStream struct {
+	service *memService
+	offset  uint64
+	closed  bool
+}
+
+func (m *memStream) Recv() ([]byte, error) {
+	s := m.service
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for !m.closed && uint64(len(s.entries)) <= m.offset {
+		s.cond.Wait()
+	}
+	if m.closed {
+		return nil, errors.New("stream closed")
+	}
+	entry := s.entries[m.offset]
+	m.offset++
+	return entry, nil
+}
+
+func (m *memStream) Close() error {
+	m.service.mutex.Lock()
+	defer m.service.mutex.Unlock()
+
+	m.closed = true
+	m.service.cond.Broadcast()
+	return nil
+}
+
+// memLedger is an in-memory ledger, committed to by a consumer of the block one-queue-barrier
+type memLedger struct {
+	mutex  sync.Mutex
+	blocks []*types.Block
+}
+
+func (l *memLedger) Height() (uint64, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return uint64(len(l.blocks)), nil
+}
+
+func (l *memLedger) Get(blockNumber uint64) (*types.Block, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.blocks[blockNumber-1], nil
+}
+
+func (l *memLedger) commitFrom(barrier *queue.OneQueueBarrier) {
+	for {
+		entry, err := barrier.Dequeue()
+		if err != nil {
+			return
+		}
+		block := entry.(*types.Block)
+		l.mutex.Lock()
+		l.blocks = append(l.blocks, block)
+		l.mutex.Unlock()
+
+		var reply interface{}
+		if configTx := block.GetConfigTxEnvelope(); configTx != nil {
+			reply = configTx.GetPayload().GetNewConfig()
+		}
+		if err = barrier.Reply(reply); err != nil {
+			return
+		}
+	}
+}
+
+func testClusterConfig() *types.ClusterConfig {
+	return &types.ClusterConfig{
+		ConsensusConfig: &types.ConsensusConfig{
+			Algorithm: "kafka",
+			Members: []*types.PeerConfig{
+				{NodeId: "node1", RaftId: 1, PeerHost: "127.0.0.1", PeerPort: 7091},
+				{NodeId: "node2", RaftId: 2, PeerHost: "127.0.0.1", PeerPort: 7092},
+			},
+		},
+	}
+}
+
+func genesisBlock(clusterConfig *types.ClusterConfig) *types.Block {
+	return &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{Number: 1, Timestamp: time.Now().UnixNano()},
+		},
+		Payload: &types.Block_ConfigTxEnvelope{
+			ConfigTxEnvelope: &types.ConfigTxEnvelope{
+				Payload: &types.ConfigTx{TxId: "genesis", NewConfig: clusterConfig},
+			},
+		},
+	}
+}
+
+func dataBlock(txIDs ...string) *types.Block {
+	envs := &types.DataTxEnvelopes{}
+	for _, txID := range txIDs {
+		envs.Envelopes = append(envs.Envelopes, &types.DataTxEnvelope{Payload: &types.DataTx{TxId: txID}})
+	}
+	return &types.Block{
+		Header:  &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 1}},
+		Payload: &types.Block_DataTxEnvelopes{DataTxEnvelopes: envs},
+	}
+}
+
+type testNode struct {
+	ledger     *memLedger
+	pendingTxs *mocks.PendingTxsReleaser
+	dispatcher *ExternalDispatcher
+}
+
+func startTestNode(t *testing.T, nodeID string, svc Service, ledger *memLedger) *testNode {
+	lg, err := logger.New(&logger.Config{
+		Level:         "info",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	barrier := queue.NewOneQueueBarrier(lg)
+	go ledger.commitFrom(barrier)
+
+	n := &testNode{ledger: ledger, pendingTxs: &mocks.PendingTxsReleaser{}}
+	n.dispatcher, err = NewExternalDispatcher(&ExternalConfig{
+		NodeID:               nodeID,
+		ClusterConfig:        ledger.blocks[0].GetConfigTxEnvelope().GetPayload().GetNewConfig(),
+		Service:              svc,
+		LedgerReader:         ledger,
+		BlockOneQueueBarrier: barrier,
+		PendingTxs:           n.pendingTxs,
+		ReconnectInterval:    10 * time.Millisecond,
+		Logger:               lg,
+	})
+	require.NoError(t, err)
+	n.dispatcher.Start()
+
+	return n
+}
+
+func requireHeight(t *testing.T, height uint64, nodes ...*testNode) {
+	require.Eventually(t, func() bool {
+		for _, n := range nodes {
+			if h, _ := n.ledger.Height(); h != height {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestExternalDispatcher(t *testing.T) {
+	t.Run("the nodes assemble the same chain", func(t *testing.T) {
+		svc := newMemService()
+		genesis := genesisBlock(testClusterConfig())
+		node1 := startTestNode(t, "node1", svc, &memLedger{blocks: []*types.Block{genesis}})
+		defer node1.dispatcher.Close()
+		node2 := startTestNode(t, "node2", svc, &memLedger{blocks: []*types.Block{genesis}})
+		defer node2.dispatcher.Close()
+
+		require.NoError(t, node1.dispatcher.Submit(dataBlock("tx1")))
+		require.NoError(t, node2.dispatcher.Submit(dataBlock("tx2", "tx3")))
+		// a proposal from a node whose clock is behind
+		late, err := proto.Marshal(&types.Block{
+			Header:  &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Timestamp: 1}},
+			Payload: dataBlock("tx4").Payload,
+		})
+		require.NoError(t, err)
+		require.NoError(t, svc.Broadcast(late))
+		requireHeight(t, 4, node1, node2)
+
+		for n := uint64(2); n <= 4; n++ {
+			block1, _ := node1.ledger.Get(n)
+			block2, _ := node2.ledger.Get(n)
+			require.True(t, proto.Equal(block1, block2), "block [%d] differs", n)
+
+			prev, _ := node1.ledger.Get(n - 1)
+			prevHash, err := blockstore.ComputeBlockHash(prev)
+			require.NoError(t, err)
+			prevBaseHash, err := blockstore.ComputeBlockBaseHash(prev)
+			require.NoError(t, err)
+
+			header := block1.GetHeader().GetBaseHeader()
+			require.Equal(t, n, header.Number)
+			require.Equal(t, n-1, header.LastCommittedBlockNum)
+			require.Equal(t, prevHash, header.LastCommittedBlockHash)
+			require.Equal(t, prevBaseHash, header.PreviousBaseHeaderHash)
+			require.GreaterOrEqual(t, header.Timestamp, prev.GetHeader().GetBaseHeader().GetTimestamp())
+		}
+		block3, _ := node1.ledger.Get(3)
+		block4, _ := node1.ledger.Get(4)
+		require.Equal(t, []string{"tx2", "tx3"}, []string{
+			block3.GetDataTxEnvelopes().Envelopes[0].Payload.TxId,
+			block3.GetDataTxEnvelopes().Envelopes[1].Payload.TxId,
+		})
+		require.Equal(t, block3.GetHeader().GetBaseHeader().GetTimestamp(), block4.GetHeader().GetBaseHeader().GetTimestamp())
+	})
+
+	t.Run("a restarted node resumes from its ledger", func(t *testing.T) {
+		svc := newMemService()
+		genesis := genesisBlock(testClusterConfig())
+		ledger1 := &memLedger{blocks: []*types.Block{genesis}}
+		node1 := startTestNode(t, "node1", svc, ledger1)
+		node2 := startTestNode(t, "node2", svc, &memLedger{blocks: []*types.Block{genesis}})
+		defer node2.dispatcher.Close()
+
+		require.NoError(t, node2.dispatcher.Submit(dataBlock("tx1")))
+		requireHeight(t, 2, node1, node2)
+		require.NoError(t, node1.dispatcher.Close())
+		require.EqualError(t, node1.dispatcher.Close(), "ordering dispatcher already closed")
+		require.EqualError(t, node1.dispatcher.Submit(dataBlock("tx2")), "ordering dispatcher closed")
+
+		require.NoError(t, node2.dispatcher.Submit(dataBlock("tx2")))
+		requireHeight(t, 3, node2)
+		requireHeight(t, 2, node1)
+
+		node1 = startTestNode(t, "node1", svc, ledger1)
+		defer node1.dispatcher.Close()
+		requireHeight(t, 3, node1, node2)
+
+		block1, _ := node1.ledger.Get(3)
+		block2, _ := node2.ledger.Get(3)
+		require.True(t, proto.Equal(block1, block2))
+	})
+
+	t.Run("a config block updates the cluster config", func(t *testing.T) {
+		svc := newMemService()
+		node1 := startTestNode(t, "node1", svc, &memLedger{blocks: []*types.Block{genesisBlock(testClusterConfig())}})
+		defer node1.dispatcher.Close()
+
+		newConfig := testClusterConfig()
+		newConfig.ConsensusConfig.Members = append(newConfig.ConsensusConfig.Members,
+			&types.PeerConfig{NodeId: "node3", RaftId: 3, PeerHost: "127.0.0.1", PeerPort: 7093})
+		require.True(t, node1.dispatcher.DescribeReConfig(newConfig).Consensus)
+
+		require.NoError(t, node1.dispatcher.Submit(&types.Block{
+			Payload: &types.Block_ConfigTxEnvelope{
+				ConfigTxEnvelope: &types.ConfigTxEnvelope{
+					Payload: &types.ConfigTx{TxId: "config", NewConfig: newConfig},
+				},
+			},
+		}))
+		requireHeight(t, 2, node1)
+
+		require.Eventually(t, func() bool {
+			return !node1.dispatcher.DescribeReConfig(newConfig).Consensus
+		}, 10*time.Second, 10*time.Millisecond)
+		require.Len(t, node1.dispatcher.Diagnostics().Peers, 3)
+	})
+
+	t.Run("a failed broadcast releases the transactions", func(t *testing.T) {
+		svc := newMemService()
+		svc.broadcastErr = errors.New("broker unavailable")
+		node1 := startTestNode(t, "node1", svc, &memLedger{blocks: []*types.Block{genesisBlock(testClusterConfig())}})
+		defer node1.dispatcher.Close()
+
+		require.NoError(t, node1.dispatcher.Submit(dataBlock("tx1", "tx2")))
+		require.Equal(t, 1, node1.pendingTxs.ReleaseWithErrorCallCount())
+		txIDs, err := node1.pendingTxs.ReleaseWithErrorArgsForCall(0)
+		require.Equal(t, []string{"tx1", "tx2"}, txIDs)
+		require.EqualError(t, err, "failed to broadcast the block to the ordering service: broker unavailable")
+	})
+
+	t.Run("every node accepts transactions", func(t *testing.T) {
+		svc := newMemService()
+		node2 := startTestNode(t, "node2", svc, &memLedger{blocks: []*types.Block{genesisBlock(testClusterConfig())}})
+		defer node2.dispatcher.Close()
+
+		require.Nil(t, node2.dispatcher.IsLeader())
+		leaderID, activePeers := node2.dispatcher.GetClusterStatus()
+		require.Equal(t, uint64(2), leaderID)
+		require.Len(t, activePeers, 1)
+		require.Contains(t, activePeers, "node2")
+		require.NoError(t, node2.dispatcher.VerifyQuorumAfterReConfig(testClusterConfig()))
+
+		diag := node2.dispatcher.Diagnostics()
+		require.Equal(t, "kafka", diag.RaftState)
+		require.Equal(t, uint64(2), diag.LeaderID)
+		require.Equal(t, uint64(1), diag.LastCommittedBlockNumber)
+
+		_, err := node2.dispatcher.TriggerSnapshot()
+		require.EqualError(t, err, "consensus snapshots are not supported when blocks are ordered by the ordering service [kafka]")
+		_, err = node2.dispatcher.TransferLeadership("node1", time.Second)
+		require.EqualError(t, err, "leadership transfer is not supported when blocks are ordered by the ordering service [kafka]")
+	})
+
+	t.Run("the ledger is empty", func(t *testing.T) {
+		_, err := NewExternalDispatcher(&ExternalConfig{
+			NodeID:        "node1",
+			ClusterConfig: testClusterConfig(),
+			LedgerReader:  &memLedger{},
+		})
+		require.EqualError(t, err, "the ledger is empty, a node that orders blocks with an external ordering service must start from the genesis block")
+	})
+
+	t.Run("the node is not a member", func(t *testing.T) {
+		_, err := NewExternalDispatcher(&ExternalConfig{
+			NodeID:        "node3",
+			ClusterConfig: testClusterConfig(),
+			LedgerReader:  &memLedger{},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "node ID 'node3' is not in Consensus members")
+	})
+}
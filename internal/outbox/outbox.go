@@ -0,0 +1,216 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultRetryInterval is the time a delivery worker waits before re-publishing an entry that failed.
+	defaultRetryInterval = time.Second
+	// deliveryBatchSize is the maximal number of pending entries a delivery worker reads from the store at once.
+	deliveryBatchSize = 100
+)
+
+// Subsystem produces external side effects of committed blocks, e.g., change-data-capture events, webhooks, or
+// anchoring of the ledger in an external system, and publishes them.
+//
+// Entries is called by the committer with every committed block, and must be deterministic and free of side effects:
+// after a crash, a block may be passed again in order to record the entries that were lost. Publish is called by a
+// delivery worker of the subsystem, with every entry recorded, in the order they were recorded. An entry is
+// re-published until Publish succeeds, also across restarts; hence, delivery is at-least-once, and the external system
+// achieves exactly-once processing by discarding entries whose key it has already seen.
+type Subsystem interface {
+	// Name identifies the subsystem. It must be unique, and must not change across restarts, as the recorded entries
+	// are delivered by name.
+	Name() string
+	// Entries returns the payloads of the external side effects of a committed block, if any.
+	Entries(block *types.Block) ([][]byte, error)
+	// Publish delivers an entry to the external system.
+	Publish(entry *Entry) error
+}
+
+// Outbox records the external side effects of every committed block, and delivers them with a worker go-routine per
+// subsystem.
+//
+// The entries of a block are recorded, together with the block number, in a single write to the outbox store, after
+// the block is committed to the block store and the state database. When the server crashes between the commit of a
+// block and its recording, the block processor records it during recovery, from the block store; an entry is deleted
+// only after it is published. Therefore, no side effect is lost, and none is recorded twice.
+type Outbox struct {
+	store         *store
+	subsystems    map[string]Subsystem
+	retryInterval time.Duration
+
+	started  bool
+	notifyCh map[string]chan struct{}
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+	mutex    sync.Mutex
+
+	logger *logger.SugarLogger
+}
+
+// Config holds the configuration of the outbox.
+type Config struct {
+	StoreDir string
+	// RetryInterval is the time to wait before re-publishing an entry that failed; if zero, a default is used.
+	RetryInterval time.Duration
+	Logger        *logger.SugarLogger
+}
+
+// New opens the outbox store and creates an Outbox. Subsystems must be registered before it is started.
+func New(conf *Config) (*Outbox, error) {
+	s, err := openStore(conf.StoreDir, conf.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &Outbox{
+		store:         s,
+		subsystems:    make(map[string]Subsystem),
+		retryInterval: defaultRetryInterval,
+		notifyCh:      make(map[string]chan struct{}),
+		stopCh:        make(chan struct{}),
+		logger:        conf.Logger,
+	}
+	if conf.RetryInterval > 0 {
+		o.retryInterval = conf.RetryInterval
+	}
+
+	return o, nil
+}
+
+// Register adds a subsystem. It must be called before Start.
+func (o *Outbox) Register(subsystem Subsystem) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	name := subsystem.Name()
+	switch {
+	case o.started:
+		return errors.Errorf("cannot register subsystem [%s], the outbox is already started", name)
+	case name == "" || strings.ContainsRune(name, 0):
+		return errors.Errorf("subsystem name [%s] is not allowed", name)
+	}
+	if _, ok := o.subsystems[name]; ok {
+		return errors.Errorf("the subsystem [%s] is already registered", name)
+	}
+
+	o.logger.Infof("Registering outbox subsystem [%s]", name)
+	o.subsystems[name] = subsystem
+	o.notifyCh[name] = make(chan struct{}, 1)
+	return nil
+}
+
+// Height returns the number of the last block recorded in the outbox, or 0 if no block was recorded.
+func (o *Outbox) Height() (uint64, error) {
+	return o.store.height()
+}
+
+// RecordBlock records the entries of all the subsystems for a committed block, and notifies the delivery workers.
+// A block that was already recorded is ignored.
+func (o *Outbox) RecordBlock(block *types.Block) error {
+	blockNumber := block.GetHeader().GetBaseHeader().GetNumber()
+
+	var entries []*Entry
+	for name, subsystem := range o.subsystems {
+		payloads, err := subsystem.Entries(block)
+		if err != nil {
+			return errors.WithMessagef(err, "error while computing the outbox entries of subsystem [%s] for block [%d]", name, blockNumber)
+		}
+		for i, p := range payloads {
+			entries = append(entries, &Entry{
+				Subsystem:   name,
+				BlockNumber: blockNumber,
+				Index:       uint32(i),
+				Payload:     p,
+			})
+		}
+	}
+
+	if err := o.store.record(blockNumber, entries); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		select {
+		case o.notifyCh[e.Subsystem] <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Start starts a delivery worker for every registered subsystem. Entries recorded before a restart are delivered
+// first.
+func (o *Outbox) Start() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.started = true
+	for name, subsystem := range o.subsystems {
+		o.wg.Add(1)
+		go o.deliver(subsystem, o.notifyCh[name])
+	}
+}
+
+// Close stops the delivery workers, waits for them to exit, and closes the outbox store.
+func (o *Outbox) Close() error {
+	close(o.stopCh)
+	o.wg.Wait()
+
+	return o.store.close()
+}
+
+func (o *Outbox) deliver(subsystem Subsystem, notifyCh chan struct{}) {
+	defer o.wg.Done()
+
+	name := subsystem.Name()
+	o.logger.Infof("Starting the outbox delivery worker of subsystem [%s]", name)
+	defer o.logger.Infof("Exiting the outbox delivery worker of subsystem [%s]", name)
+
+	for {
+		entries, err := o.store.pending(name, deliveryBatchSize)
+		if err != nil {
+			o.logger.Panicf("Failed to read the pending entries of subsystem [%s]: %s", name, err)
+		}
+
+		if len(entries) == 0 {
+			select {
+			case <-notifyCh:
+				continue
+			case <-o.stopCh:
+				return
+			}
+		}
+
+		for _, e := range entries {
+			for {
+				err := subsystem.Publish(e)
+				if err == nil {
+					break
+				}
+
+				o.logger.Warnf("Failed to publish outbox entry [%s], retrying in %s: %s", e.Key(), o.retryInterval, err)
+				select {
+				case <-time.After(o.retryInterval):
+				case <-o.stopCh:
+					return
+				}
+			}
+
+			if err := o.store.remove(e); err != nil {
+				o.logger.Panicf("Failed to remove a published entry: %s", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// testSubsystem produces an entry per data tx in a block, and records the keys it published.
+type testSubsystem struct {
+	name string
+
+	mutex     sync.Mutex
+	failures  int // number of times Publish fails before it succeeds
+	published []string
+	payloads  [][]byte
+}
+
+func (s *testSubsystem) Name() string {
+	return s.name
+}
+
+func (s *testSubsystem) Entries(block *types.Block) ([][]byte, error) {
+	var payloads [][]byte
+	for _, env := range block.GetDataTxEnvelopes().GetEnvelopes() {
+		payloads = append(payloads, []byte(s.name+":"+env.GetPayload().GetTxId()))
+	}
+	return payloads, nil
+}
+
+func (s *testSubsystem) Publish(entry *Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("external system is down")
+	}
+	s.published = append(s.published, entry.Key())
+	s.payloads = append(s.payloads, entry.Payload)
+	return nil
+}
+
+func (s *testSubsystem) publishedKeys() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string(nil), s.published...)
+}
+
+func newTestLogger(t *testing.T) *logger.SugarLogger {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+	return lg
+}
+
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "outbox")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func dataBlock(number uint64, txIDs ...string) *types.Block {
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number: number,
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{},
+		},
+	}
+	for _, txID := range txIDs {
+		block.GetDataTxEnvelopes().Envelopes = append(block.GetDataTxEnvelopes().Envelopes,
+			&types.DataTxEnvelope{Payload: &types.DataTx{TxId: txID}})
+	}
+	return block
+}
+
+func TestStore(t *testing.T) {
+	lg := newTestLogger(t)
+	storeDir := filepath.Join(newTestDir(t), "outbox")
+
+	s, err := openStore(storeDir, lg)
+	require.NoError(t, err)
+
+	h, err := s.height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), h)
+
+	require.NoError(t, s.record(2, []*Entry{
+		{Subsystem: "cdc", BlockNumber: 2, Index: 0, Payload: []byte("a")},
+		{Subsystem: "cdc", BlockNumber: 2, Index: 1, Payload: []byte("b")},
+		{Subsystem: "anchor", BlockNumber: 2, Index: 0, Payload: []byte("c")},
+	}))
+	require.NoError(t, s.record(3, nil))
+	require.NoError(t, s.record(10, []*Entry{
+		{Subsystem: "cdc", BlockNumber: 10, Index: 0, Payload: []byte("d")},
+	}))
+
+	h, err = s.height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), h)
+
+	entries, err := s.pending("cdc", 10)
+	require.NoError(t, err)
+	require.Equal(t, []*Entry{
+		{Subsystem: "cdc", BlockNumber: 2, Index: 0, Payload: []byte("a")},
+		{Subsystem: "cdc", BlockNumber: 2, Index: 1, Payload: []byte("b")},
+		{Subsystem: "cdc", BlockNumber: 10, Index: 0, Payload: []byte("d")},
+	}, entries)
+	require.Equal(t, "cdc/10/0", entries[2].Key())
+
+	entries, err = s.pending("cdc", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.NoError(t, s.remove(entries[0]))
+
+	// a block that was already recorded is ignored, so a removed entry is not recorded again
+	require.NoError(t, s.record(2, []*Entry{
+		{Subsystem: "cdc", BlockNumber: 2, Index: 0, Payload: []byte("a")},
+	}))
+
+	// the pending entries survive a restart
+	require.NoError(t, s.close())
+	s, err = openStore(storeDir, lg)
+	require.NoError(t, err)
+	defer s.close()
+
+	h, err = s.height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), h)
+
+	entries, err = s.pending("cdc", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "cdc/2/1", entries[0].Key())
+	require.Equal(t, "cdc/10/0", entries[1].Key())
+
+	entries, err = s.pending("anchor", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "anchor/2/0", entries[0].Key())
+}
+
+func TestOutbox_Register(t *testing.T) {
+	o, err := New(&Config{StoreDir: filepath.Join(newTestDir(t), "outbox"), Logger: newTestLogger(t)})
+	require.NoError(t, err)
+
+	require.NoError(t, o.Register(&testSubsystem{name: "cdc"}))
+	require.EqualError(t, o.Register(&testSubsystem{name: "cdc"}), "the subsystem [cdc] is already registered")
+	require.EqualError(t, o.Register(&testSubsystem{name: ""}), "subsystem name [] is not allowed")
+
+	o.Start()
+	require.EqualError(t, o.Register(&testSubsystem{name: "anchor"}), "cannot register subsystem [anchor], the outbox is already started")
+	require.NoError(t, o.Close())
+}
+
+func TestOutbox_Delivery(t *testing.T) {
+	lg := newTestLogger(t)
+	storeDir := filepath.Join(newTestDir(t), "outbox")
+
+	cdc := &testSubsystem{name: "cdc", failures: 2}
+	anchor := &testSubsystem{name: "anchor"}
+
+	o, err := New(&Config{StoreDir: storeDir, RetryInterval: 10 * time.Millisecond, Logger: lg})
+	require.NoError(t, err)
+	require.NoError(t, o.Register(cdc))
+	require.NoError(t, o.Register(anchor))
+	o.Start()
+
+	require.NoError(t, o.RecordBlock(dataBlock(1)))
+	require.NoError(t, o.RecordBlock(dataBlock(2, "tx1", "tx2")))
+	require.NoError(t, o.RecordBlock(dataBlock(3, "tx3")))
+
+	expected := func(name string) []string {
+		return []string{name + "/2/0", name + "/2/1", name + "/3/0"}
+	}
+	require.Eventually(t, func() bool { return len(cdc.publishedKeys()) == 3 }, 10*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return len(anchor.publishedKeys()) == 3 }, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, expected("cdc"), cdc.publishedKeys())
+	require.Equal(t, expected("anchor"), anchor.publishedKeys())
+	require.Equal(t, [][]byte{[]byte("cdc:tx1"), []byte("cdc:tx2"), []byte("cdc:tx3")}, cdc.payloads)
+
+	h, err := o.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), h)
+	require.NoError(t, o.Close())
+}
+
+func TestOutbox_DeliveryAcrossRestart(t *testing.T) {
+	lg := newTestLogger(t)
+	storeDir := filepath.Join(newTestDir(t), "outbox")
+
+	// the external system is down, so nothing is delivered before the outbox is closed
+	cdc := &testSubsystem{name: "cdc", failures: 1000000}
+	o, err := New(&Config{StoreDir: storeDir, RetryInterval: 10 * time.Millisecond, Logger: lg})
+	require.NoError(t, err)
+	require.NoError(t, o.Register(cdc))
+	o.Start()
+
+	for n := uint64(1); n <= 5; n++ {
+		require.NoError(t, o.RecordBlock(dataBlock(n, fmt.Sprintf("tx%d", n))))
+	}
+	require.NoError(t, o.Close())
+	require.Empty(t, cdc.publishedKeys())
+
+	// after a restart, the entries recorded before are delivered, and a block passed again is not recorded twice
+	cdc = &testSubsystem{name: "cdc"}
+	o, err = New(&Config{StoreDir: storeDir, RetryInterval: 10 * time.Millisecond, Logger: lg})
+	require.NoError(t, err)
+	require.NoError(t, o.Register(cdc))
+	require.NoError(t, o.RecordBlock(dataBlock(5, "tx5")))
+	require.NoError(t, o.RecordBlock(dataBlock(6, "tx6")))
+	o.Start()
+
+	require.Eventually(t, func() bool { return len(cdc.publishedKeys()) == 6 }, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"cdc/1/0", "cdc/2/0", "cdc/3/0", "cdc/4/0", "cdc/5/0", "cdc/6/0"}, cdc.publishedKeys())
+	require.NoError(t, o.Close())
+}
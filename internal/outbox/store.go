@@ -0,0 +1,208 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package outbox
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var (
+	// outboxDBName holds the entries that were recorded but not yet delivered
+	outboxDBName = "outbox"
+
+	// underCreationFlag is used to mark that the store
+	// is being created. If a failure happens during the
+	// creation, the retry logic will use this file to
+	// detect the partially created store and do cleanup
+	// before creating a new store
+	underCreationFlag = "undercreation"
+
+	// Namespaces for different data types:
+	// pending entries, keyed by subsystem, block number and index
+	entryNs = []byte{0}
+	// last block recorded
+	lastBlockNs = []byte{1}
+)
+
+// Entry is an external side effect of a committed block, recorded in the outbox until it is delivered.
+type Entry struct {
+	Subsystem   string
+	BlockNumber uint64
+	Index       uint32
+	Payload     []byte
+}
+
+// Key uniquely identifies the entry. The key is derived from the subsystem, the block number, and the index of the
+// entry among the entries of the subsystem in that block; hence, it is the same on every node and across
+// re-deliveries, and an external system can use it to discard duplicates.
+func (e *Entry) Key() string {
+	return fmt.Sprintf("%s/%d/%d", e.Subsystem, e.BlockNumber, e.Index)
+}
+
+// store maintains the pending entries in a leveldb database, together with the number of the last block recorded.
+type store struct {
+	db     *leveldb.DB
+	mutex  sync.Mutex
+	logger *logger.SugarLogger
+}
+
+func openStore(storeDir string, lg *logger.SugarLogger) (*store, error) {
+	exist, err := fileops.Exists(storeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		partial, err := isExistingStoreCreatedPartially(storeDir)
+		if err != nil {
+			return nil, err
+		}
+		if !partial {
+			db, err := leveldb.OpenFile(filepath.Join(storeDir, outboxDBName), &opt.Options{ErrorIfMissing: true})
+			if err != nil {
+				return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the outbox")
+			}
+			return &store{db: db, logger: lg}, nil
+		}
+
+		if err := fileops.RemoveAll(storeDir); err != nil {
+			return nil, errors.Wrap(err, "error while removing the existing partially created store")
+		}
+	}
+
+	if err := fileops.CreateDir(storeDir); err != nil {
+		return nil, errors.WithMessagef(err, "error while creating directory [%s]", storeDir)
+	}
+
+	underCreationFlagPath := filepath.Join(storeDir, underCreationFlag)
+	if err := fileops.CreateFile(underCreationFlagPath); err != nil {
+		return nil, err
+	}
+
+	db, err := leveldb.OpenFile(filepath.Join(storeDir, outboxDBName), &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the outbox database")
+	}
+
+	if err := fileops.Remove(underCreationFlagPath); err != nil {
+		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
+	}
+
+	return &store{db: db, logger: lg}, nil
+}
+
+func isExistingStoreCreatedPartially(storeDir string) (bool, error) {
+	empty, err := fileops.IsDirEmpty(storeDir)
+	if err != nil || empty {
+		return true, err
+	}
+
+	return fileops.Exists(filepath.Join(storeDir, underCreationFlag))
+}
+
+func (s *store) close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.db.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the outbox database")
+	}
+	return nil
+}
+
+// height returns the number of the last block recorded, or 0 if no block was recorded.
+func (s *store) height() (uint64, error) {
+	val, err := s.db.Get(lastBlockNs, nil)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "error while reading the outbox height")
+	}
+
+	return binary.BigEndian.Uint64(val), nil
+}
+
+// record atomically stores the entries of a block and advances the height. A block that was already recorded is
+// ignored, so that entries that were delivered and deleted are not recorded again.
+func (s *store) record(blockNumber uint64, entries []*Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	h, err := s.height()
+	if err != nil {
+		return err
+	}
+	if blockNumber <= h {
+		s.logger.Debugf("block [%d] was already recorded in the outbox, height is [%d]", blockNumber, h)
+		return nil
+	}
+
+	batch := &leveldb.Batch{}
+	for _, e := range entries {
+		batch.Put(entryKey(e.Subsystem, e.BlockNumber, e.Index), e.Payload)
+	}
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, blockNumber)
+	batch.Put(lastBlockNs, heightBytes)
+
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrapf(err, "error while recording block [%d] in the outbox", blockNumber)
+	}
+	return nil
+}
+
+// pending returns up to `limit` pending entries of a subsystem, in the order they were recorded.
+func (s *store) pending(subsystem string, limit int) ([]*Entry, error) {
+	prefix := subsystemPrefix(subsystem)
+	itr := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer itr.Release()
+
+	var entries []*Entry
+	for len(entries) < limit && itr.Next() {
+		key := itr.Key()[len(prefix):]
+		payload := make([]byte, len(itr.Value()))
+		copy(payload, itr.Value())
+		entries = append(entries, &Entry{
+			Subsystem:   subsystem,
+			BlockNumber: binary.BigEndian.Uint64(key[:8]),
+			Index:       binary.BigEndian.Uint32(key[8:12]),
+			Payload:     payload,
+		})
+	}
+
+	return entries, errors.Wrap(itr.Error(), "error while iterating over the outbox")
+}
+
+// remove deletes a delivered entry.
+func (s *store) remove(e *Entry) error {
+	if err := s.db.Delete(entryKey(e.Subsystem, e.BlockNumber, e.Index), &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrapf(err, "error while removing entry [%s] from the outbox", e.Key())
+	}
+	return nil
+}
+
+func subsystemPrefix(subsystem string) []byte {
+	prefix := append([]byte{}, entryNs...)
+	prefix = append(prefix, subsystem...)
+	return append(prefix, 0)
+}
+
+func entryKey(subsystem string, blockNumber uint64, index uint32) []byte {
+	key := subsystemPrefix(subsystem)
+	key = append(key, make([]byte, 12)...)
+	binary.BigEndian.PutUint64(key[len(key)-12:], blockNumber)
+	binary.BigEndian.PutUint32(key[len(key)-4:], index)
+	return key
+}
@@ -4,12 +4,15 @@ package provenance
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	interrors "github.com/hyperledger-labs/orion-server/internal/errors"
+	"github.com/hyperledger-labs/orion-server/internal/failpoint"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/cayleygraph/cayley"
 	"github.com/cayleygraph/cayley/graph"
@@ -40,6 +43,23 @@ const (
 	// PREVIOUS edge from one to another
 	// denotes that the previous version of the value
 	PREVIOUS = "p"
+	// ANNOTATED edge from txID to an annotation
+	// denotes that the txID carries the annotation
+	ANNOTATED = "a"
+	// CONTENT edge from a value to its content
+	// denotes that the value holds the content. As the graph
+	// database stores each distinct vertex once, the content
+	// is stored once for all values holding the same bytes
+	CONTENT = "c"
+
+	// contentPrefix distinguishes the content vertices from
+	// all other vertices of the graph
+	contentPrefix = "content:"
+	// sealedContentPrefix distinguishes the content vertices
+	// that hold a value of an erasable database, sealed by the
+	// erasure key of its key. As a sealed value is encrypted
+	// with a random nonce, it is stored once per write.
+	sealedContentPrefix = "sealed:"
 )
 
 // TxDataForProvenance holds the transaction data that is
@@ -53,6 +73,11 @@ type TxDataForProvenance struct {
 	Writes             []*types.KVWithMetadata
 	Deletes            map[string]*types.Version
 	OldVersionOfWrites map[string]*types.Version
+	Annotations        map[string]string
+	// ErasedWrites holds the written keys whose values were
+	// erased before the transaction was committed to this node,
+	// i.e., when the block was committed from another node
+	ErasedWrites map[string]bool
 }
 
 // KeyWithVersion holds a key and a version
@@ -61,11 +86,25 @@ type KeyWithVersion struct {
 	Version *types.Version
 }
 
+// KVWithDBName holds a key-value pair along with the
+// name of the database the key belongs to
+type KVWithDBName struct {
+	DBName string
+	*types.KVWithMetadata
+}
+
+// Annotation holds a single annotation of a transaction
+type Annotation struct {
+	Key   string `json:"annotation_key"`
+	Value string `json:"annotation_value"`
+}
+
 // TxIDLocation refers to the location of a TxID
-// in the block
+// in the block, along with the block timestamp
 type TxIDLocation struct {
-	BlockNum uint64 `json:"block_num"`
-	TxIndex  int    `json:"tx_index"`
+	BlockNum  uint64 `json:"block_num"`
+	TxIndex   int    `json:"tx_index"`
+	BlockTime int64  `json:"block_time,omitempty"`
 }
 
 // Commit commits the txsData to a graph database. The following relationships are stored
@@ -77,19 +116,37 @@ type TxIDLocation struct {
 //  6. key--(version)-->value
 //  7. value<--(previous)--value
 //  8. value--(next)-->value
-func (s *Store) Commit(blockNum uint64, txsData []*TxDataForProvenance) error {
+//  9. txID--(annotated)-->annotation
+//  10. value--(content)-->content
+//
+// The value vertex holds the key and the metadata, while the value bytes are held by a content vertex, which is
+// content-addressed, i.e., identical value bytes written repeatedly are stored once. Value vertices that were committed
+// before the content vertices were introduced hold the value bytes themselves, and are read as before.
+//
+// The blockTime is the timestamp of the block, in nanoseconds since the Unix epoch, and is recorded
+// along with the location of each transaction.
+func (s *levelDBStore) Commit(blockNum uint64, blockTime int64, txsData []*TxDataForProvenance) error {
+	if err := failpoint.Inject(failpoint.ProvenanceCommit); err != nil {
+		return err
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	batch := graph.NewWriter(s.cayleyGraph.QuadWriter)
 	for txNum, tx := range txsData {
-		loc, err := json.Marshal(&TxIDLocation{blockNum, txNum})
+		loc, err := json.Marshal(&TxIDLocation{BlockNum: blockNum, TxIndex: txNum, BlockTime: blockTime})
 		if err != nil {
 			return errors.WithMessage(err, "error while marshaling txID location")
 		}
 		s.logger.Debugf("loc[%s]]---(includes)--->txID[%s]", loc, tx.TxID)
 		batch.WriteQuad(quad.Make(string(loc), INCLUDES, tx.TxID, ""))
 
+		// annotations are recorded for invalid transactions as well, so that applications can correlate them
+		if err := s.addAnnotations(tx, batch); err != nil {
+			return err
+		}
+
 		if !tx.IsValid {
 			s.logger.Debugf("as txID [%s] is invalid, we created vertex and edge to represent only the relation [location--(includes)-->txID]", tx.TxID)
 			// if needed in the future, we can store more details about the invalid transactions.
@@ -115,25 +172,44 @@ func (s *Store) Commit(blockNum uint64, txsData []*TxDataForProvenance) error {
 	return batch.Close()
 }
 
-func (s *Store) addReads(tx *TxDataForProvenance, batch graph.BatchWriter) error {
+func (s *levelDBStore) addAnnotations(tx *TxDataForProvenance, batch graph.BatchWriter) error {
+	for k, v := range tx.Annotations {
+		annotation, err := json.Marshal(&Annotation{Key: k, Value: v})
+		if err != nil {
+			return errors.WithMessage(err, "error while marshaling annotation")
+		}
+
+		s.logger.Debugf("txID[%s]---(annotated)--->annotation[%s: %s]", tx.TxID, k, s.logger.MaskValue([]byte(v)))
+		batch.WriteQuad(quad.Make(tx.TxID, ANNOTATED, string(annotation), ""))
+	}
+
+	return nil
+}
+
+func (s *levelDBStore) addReads(tx *TxDataForProvenance, batch graph.BatchWriter) error {
 	for _, read := range tx.Reads {
 		value, err := s.getValueVertex(tx.DBName, read.Key, read.Version)
 		if err != nil {
 			return err
 		}
 
-		s.logger.Debugf("txID[%s]---(reads)--->value[%s]", tx.TxID, quad.NativeOf(value))
+		s.logger.Debugf("txID[%s]---(reads)--->value[%s]", tx.TxID, s.maskedVertex(read.Key, value))
 		batch.WriteQuad(quad.Make(tx.TxID, READS, value, ""))
 	}
 
 	return nil
 }
 
-func (s *Store) addWrites(tx *TxDataForProvenance, batch graph.BatchWriter) error {
+func (s *levelDBStore) addWrites(tx *TxDataForProvenance, batch graph.BatchWriter) error {
 	for _, write := range tx.Writes {
 		actualKey := write.Key
 		write.Key = constructCompositeKey(tx.DBName, write.Key)
-		newValue, err := json.Marshal(write)
+		// the value bytes are held by the content vertex, while the chunks of a blob are held by the blob store
+		newValue, err := json.Marshal(&types.KVWithMetadata{
+			Key:          write.Key,
+			Metadata:     write.Metadata,
+			BlobManifest: write.BlobManifest,
+		})
 		if err != nil {
 			return err
 		}
@@ -142,23 +218,37 @@ func (s *Store) addWrites(tx *TxDataForProvenance, batch graph.BatchWriter) erro
 		if err != nil {
 			return err
 		}
-		s.logger.Debugf("key[%s]---(version[%s])--->value[%s]", write.Key, string(newVersion), string(newValue))
+		s.logger.Debugf("key[%s]---(version[%s])--->value[%s]", s.logger.MaskKey(actualKey), string(newVersion), s.maskedVertex(actualKey, quad.String(newValue)))
 		batch.WriteQuad(quad.Make(write.Key, string(newVersion), string(newValue), ""))
 
-		s.logger.Debugf("txID[%s]---(writes)--->value[%s]", tx.TxID, string(newValue))
+		if tx.ErasedWrites[actualKey] {
+			s.logger.Debugf("value[%s]---(content)--->erased", s.maskedVertex(actualKey, quad.String(newValue)))
+			batch.WriteQuad(quad.Make(string(newValue), CONTENT, erasedContentVertex(), ""))
+		} else if len(write.Value) > 0 {
+			content, err := s.newContentVertex(tx.DBName, actualKey, quad.String(newValue), write.Value)
+			if err != nil {
+				return err
+			}
+			if content != nil {
+				s.logger.Debugf("value[%s]---(content)--->content", s.maskedVertex(actualKey, quad.String(newValue)))
+				batch.WriteQuad(quad.Make(string(newValue), CONTENT, content, ""))
+			}
+		}
+
+		s.logger.Debugf("txID[%s]---(writes)--->value[%s]", tx.TxID, s.maskedVertex(actualKey, quad.String(newValue)))
 		batch.WriteQuad(quad.Make(tx.TxID, WRITES, string(newValue), ""))
 
 		oldVersion, ok := tx.OldVersionOfWrites[actualKey]
 		if !ok {
 			// old version would not have been passed if it was deleted in the worldstate database already
 			// but we can find the old version from the provenance store even if it was deleted already
-			s.logger.Debug("fetching last deleted version of key [" + actualKey + "] from db [" + tx.DBName + "]")
+			s.logger.Debug("fetching last deleted version of key [" + s.logger.MaskKey(actualKey) + "] from db [" + tx.DBName + "]")
 			lastVer, err := s.getLastDeletedVersion(tx.DBName, write.Key)
 			if err != nil {
 				return err
 			}
 			if lastVer == nil {
-				s.logger.Debug("previous version of key [" + actualKey + "] does not exist in db [" + tx.DBName + "]")
+				s.logger.Debug("previous version of key [" + s.logger.MaskKey(actualKey) + "] does not exist in db [" + tx.DBName + "]")
 				continue
 			}
 
@@ -171,23 +261,33 @@ func (s *Store) addWrites(tx *TxDataForProvenance, batch graph.BatchWriter) erro
 		}
 
 		if oldValue == nil {
-			s.logger.Debugf("key [%s] version [%d,%d] for which oldValue is not found", actualKey, oldVersion.BlockNum, oldVersion.TxNum)
+			s.logger.Debugf("key [%s] version [%d,%d] for which oldValue is not found", s.logger.MaskKey(actualKey), oldVersion.BlockNum, oldVersion.TxNum)
 			return errors.Errorf("error while finding the previous version of the key[%s]", write.Key)
 		}
 
-		s.logger.Debugf("oldValue[%s]<---(previous)---newValue[%s]", quad.NativeOf(oldValue), string(newValue))
+		s.logger.Debugf("oldValue[%s]<---(previous)---newValue[%s]", s.maskedVertex(actualKey, oldValue), s.maskedVertex(actualKey, quad.String(newValue)))
 		batch.WriteQuad(quad.Make(string(newValue), PREVIOUS, oldValue, ""))
 
-		s.logger.Debugf("oldValue[%s]---(next)--->newValue[%s]", quad.NativeOf(oldValue), string(newValue))
+		s.logger.Debugf("oldValue[%s]---(next)--->newValue[%s]", s.maskedVertex(actualKey, oldValue), s.maskedVertex(actualKey, quad.String(newValue)))
 		batch.WriteQuad(quad.Make(oldValue, NEXT, string(newValue), ""))
 	}
 
 	return nil
 }
 
-func (s *Store) addDeletes(tx *TxDataForProvenance, batch graph.BatchWriter) error {
+// maskedVertex returns a value vertex as it may be logged, i.e., with its key masked by the logger. The vertex holds
+// the key and the metadata of a value, but not the value itself.
+func (s *levelDBStore) maskedVertex(key string, vertex quad.Value) string {
+	v := fmt.Sprint(quad.NativeOf(vertex))
+	if masked := s.logger.MaskKey(key); masked != key {
+		v = strings.ReplaceAll(v, key, masked)
+	}
+	return v
+}
+
+func (s *levelDBStore) addDeletes(tx *TxDataForProvenance, batch graph.BatchWriter) error {
 	for k, v := range tx.Deletes {
-		s.logger.Debugf("fetch value of key [%s] at version (%d, %d)", k, v.BlockNum, v.TxNum)
+		s.logger.Debugf("fetch value of key [%s] at version (%d, %d)", s.logger.MaskKey(k), v.BlockNum, v.TxNum)
 		value, err := s.getValueVertex(tx.DBName, k, v)
 		if err != nil {
 			return err
@@ -197,18 +297,18 @@ func (s *Store) addDeletes(tx *TxDataForProvenance, batch graph.BatchWriter) err
 			// no such value exist and the delete of non-existing value is a non-op
 			continue
 		}
-		s.logger.Debugf("txID[%s]---(deletes)--->value[%s]", tx.TxID, quad.NativeOf(value))
+		s.logger.Debugf("txID[%s]---(deletes)--->value[%s]", tx.TxID, s.maskedVertex(k, value))
 		batch.WriteQuad(quad.Make(tx.TxID, DELETES, value, ""))
 	}
 	return nil
 }
 
 // GetValues returns all values associated with a given key
-func (s *Store) GetValues(dbName, key string) ([]*types.ValueWithMetadata, error) {
+func (s *levelDBStore) GetValues(dbName, key string) ([]*types.ValueWithMetadata, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	s.logger.Debugf("fetch all historical values associated with the key [%s] in db [%s]", key, dbName)
+	s.logger.Debugf("fetch all historical values associated with the key [%s] in db [%s]", s.logger.MaskKey(key), dbName)
 	cKey := constructCompositeKey(dbName, key)
 	p := cayley.StartPath(s.cayleyGraph, quad.String(cKey)).Out()
 
@@ -217,12 +317,12 @@ func (s *Store) GetValues(dbName, key string) ([]*types.ValueWithMetadata, error
 		return nil, err
 	}
 
-	return verticesToValues(valueVertices)
+	return s.verticesToValues(valueVertices)
 }
 
 // GetPreviousValues returns previous values of a given key and a version. The number of records returned would be limited
 // by the limit parameters.
-func (s *Store) GetPreviousValues(dbName, key string, version *types.Version, limit int) ([]*types.ValueWithMetadata, error) {
+func (s *levelDBStore) GetPreviousValues(dbName, key string, version *types.Version, limit int) ([]*types.ValueWithMetadata, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -231,19 +331,50 @@ func (s *Store) GetPreviousValues(dbName, key string, version *types.Version, li
 
 // GetNextValues returns next values of a given key and a version. The number of records returned would be limited
 // by the limit parameters.
-func (s *Store) GetNextValues(dbName, key string, version *types.Version, limit int) ([]*types.ValueWithMetadata, error) {
+func (s *levelDBStore) GetNextValues(dbName, key string, version *types.Version, limit int) ([]*types.ValueWithMetadata, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	return s.getValuesRecursively(dbName, key, version, NEXT, limit)
 }
 
+// IterateValues calls visit with the values associated with a given key, skipping the first offset values, and up to
+// the limit, if the limit is positive. Unlike GetValues, the values are not collected in memory.
+func (s *levelDBStore) IterateValues(dbName, key string, offset, limit int, visit func(*types.ValueWithMetadata) error) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	s.logger.Debugf("iterate over historical values associated with the key [%s] in db [%s], offset [%d], limit [%d]", s.logger.MaskKey(key), dbName, offset, limit)
+	cKey := constructCompositeKey(dbName, key)
+	p := cayley.StartPath(s.cayleyGraph, quad.String(cKey)).Out()
+
+	return s.iterateValues(p, offset, limit, visit)
+}
+
+// IteratePreviousValues calls visit with the previous values of a given key and a version, up to the limit, if the
+// limit is positive
+func (s *levelDBStore) IteratePreviousValues(dbName, key string, version *types.Version, limit int, visit func(*types.ValueWithMetadata) error) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.iterateValuesRecursively(dbName, key, version, PREVIOUS, limit, visit)
+}
+
+// IterateNextValues calls visit with the next values of a given key and a version, up to the limit, if the limit is
+// positive
+func (s *levelDBStore) IterateNextValues(dbName, key string, version *types.Version, limit int, visit func(*types.ValueWithMetadata) error) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.iterateValuesRecursively(dbName, key, version, NEXT, limit, visit)
+}
+
 // GetValueAt returns the value of a given key at a particular version
-func (s *Store) GetValueAt(dbName, key string, version *types.Version) (*types.ValueWithMetadata, error) {
+func (s *levelDBStore) GetValueAt(dbName, key string, version *types.Version) (*types.ValueWithMetadata, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	s.logger.Debugf("fetch value of key [%s] at version (%d, %d)", key, version.BlockNum, version.TxNum)
+	s.logger.Debugf("fetch value of key [%s] at version (%d, %d)", s.logger.MaskKey(key), version.BlockNum, version.TxNum)
 	valueVertex, err := s.getValueVertex(dbName, key, version)
 	if err != nil {
 		return nil, err
@@ -253,11 +384,11 @@ func (s *Store) GetValueAt(dbName, key string, version *types.Version) (*types.V
 		return nil, nil
 	}
 
-	return vertexToValue(valueVertex)
+	return s.vertexToValue(valueVertex)
 }
 
 // GetValuesReadByUser returns all values read by a given user
-func (s *Store) GetValuesReadByUser(userID string) ([]*types.KVWithMetadata, error) {
+func (s *levelDBStore) GetValuesReadByUser(userID string) ([]*KVWithDBName, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -270,7 +401,7 @@ func (s *Store) GetValuesReadByUser(userID string) ([]*types.KVWithMetadata, err
 }
 
 // GetValuesWrittenByUser returns all values written by a given user
-func (s *Store) GetValuesWrittenByUser(userID string) ([]*types.KVWithMetadata, error) {
+func (s *levelDBStore) GetValuesWrittenByUser(userID string) ([]*KVWithDBName, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -283,7 +414,7 @@ func (s *Store) GetValuesWrittenByUser(userID string) ([]*types.KVWithMetadata,
 }
 
 // GetValuesDeletedByUser returns all values deleted by a given user
-func (s *Store) GetValuesDeletedByUser(userID string) ([]*types.KVWithMetadata, error) {
+func (s *levelDBStore) GetValuesDeletedByUser(userID string) ([]*KVWithDBName, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -297,15 +428,15 @@ func (s *Store) GetValuesDeletedByUser(userID string) ([]*types.KVWithMetadata,
 
 // GetDeletedValues returns all deleted values associated with a given key present in the
 // given database name
-func (s *Store) GetDeletedValues(dbName, key string) ([]*types.ValueWithMetadata, error) {
+func (s *levelDBStore) GetDeletedValues(dbName, key string) ([]*types.ValueWithMetadata, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	return s.getDeletedValuesWithoutLock(dbName, key)
 }
 
-func (s *Store) getDeletedValuesWithoutLock(dbName, key string) ([]*types.ValueWithMetadata, error) {
-	s.logger.Debugf("fetch all historical deleted values associated with the key [%s] in db [%s]", key, dbName)
+func (s *levelDBStore) getDeletedValuesWithoutLock(dbName, key string) ([]*types.ValueWithMetadata, error) {
+	s.logger.Debugf("fetch all historical deleted values associated with the key [%s] in db [%s]", s.logger.MaskKey(key), dbName)
 	cKey := constructCompositeKey(dbName, key)
 	p := cayley.StartPath(s.cayleyGraph, quad.String(cKey)).Out().Tag("deleted_value").In(quad.String(DELETES)).Back("deleted_value")
 
@@ -314,11 +445,11 @@ func (s *Store) getDeletedValuesWithoutLock(dbName, key string) ([]*types.ValueW
 		return nil, err
 	}
 
-	return verticesToValues(valueVertices)
+	return s.verticesToValues(valueVertices)
 }
 
 // GetReaders returns all userIDs who have accessed a given key as well as the access frequency
-func (s *Store) GetReaders(dbName, key string) (map[string]uint32, error) {
+func (s *levelDBStore) GetReaders(dbName, key string) (map[string]uint32, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -338,7 +469,7 @@ func (s *Store) GetReaders(dbName, key string) (map[string]uint32, error) {
 }
 
 // GetWriters returns all userIDs who have modified a given key as well as the modifcation frequency
-func (s *Store) GetWriters(dbName, key string) (map[string]uint32, error) {
+func (s *levelDBStore) GetWriters(dbName, key string) (map[string]uint32, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -358,7 +489,7 @@ func (s *Store) GetWriters(dbName, key string) (map[string]uint32, error) {
 }
 
 // GetTxIDsSubmittedByUser returns all ids of all transactions submitted by a given user
-func (s *Store) GetTxIDsSubmittedByUser(userID string) ([]string, error) {
+func (s *levelDBStore) GetTxIDsSubmittedByUser(userID string) ([]string, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -377,8 +508,8 @@ func (s *Store) GetTxIDsSubmittedByUser(userID string) ([]string, error) {
 	return txIDs, err
 }
 
-// GetTxIDLocation returns the location, i.e, block number and the tx index, of a given txID
-func (s *Store) GetTxIDLocation(txID string) (*TxIDLocation, error) {
+// GetTxIDLocation returns the location, i.e, block number and the tx index, of a given txID, along with the block timestamp
+func (s *levelDBStore) GetTxIDLocation(txID string) (*TxIDLocation, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -405,8 +536,66 @@ func (s *Store) GetTxIDLocation(txID string) (*TxIDLocation, error) {
 	return loc, nil
 }
 
+// GetTxAnnotations returns the annotations of a given txID. A nil map is returned if the transaction
+// carries no annotations or does not exist
+func (s *levelDBStore) GetTxAnnotations(txID string) (map[string]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	p := cayley.StartPath(s.cayleyGraph, quad.String(txID)).Out(quad.String(ANNOTATED))
+
+	vertices, err := p.Iterate(context.Background()).AllValues(s.cayleyGraph)
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations map[string]string
+	for _, qv := range vertices {
+		annotation := &Annotation{}
+		if err := json.Unmarshal([]byte(quad.ToString(qv)), annotation); err != nil {
+			return nil, err
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[annotation.Key] = annotation.Value
+	}
+
+	return annotations, nil
+}
+
+// GetTxIDsByAnnotation returns the ids of all transactions that carry a given annotation. As the annotation
+// vertices are shared by the transactions that carry the same annotation, the search does not scan the transactions.
+// If the submitter is set, only the transactions it submitted are returned, without fetching all of them.
+func (s *levelDBStore) GetTxIDsByAnnotation(key, value, submitterID string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	annotation, err := json.Marshal(&Annotation{Key: key, Value: value})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while marshaling annotation")
+	}
+
+	p := cayley.StartPath(s.cayleyGraph, quad.String(annotation)).In(quad.String(ANNOTATED))
+	if submitterID != "" {
+		p = p.HasReverse(quad.String(SUBMITTED), quad.String(submitterID))
+	}
+
+	vertices, err := p.Iterate(context.Background()).AllValues(s.cayleyGraph)
+	if err != nil {
+		return nil, err
+	}
+
+	var txIDs []string
+	for _, qv := range vertices {
+		txIDs = append(txIDs, quad.ToString(qv))
+	}
+
+	return txIDs, nil
+}
+
 // GetMostRecentValueAtOrBelow returns the most recent value hold by the given key at or below a given version
-func (s *Store) GetMostRecentValueAtOrBelow(dbName, key string, version *types.Version) (*types.ValueWithMetadata, error) {
+func (s *levelDBStore) GetMostRecentValueAtOrBelow(dbName, key string, version *types.Version) (*types.ValueWithMetadata, error) {
 	values, err := s.GetValues(dbName, key)
 	if err != nil {
 		return nil, err
@@ -440,7 +629,7 @@ func (s *Store) GetMostRecentValueAtOrBelow(dbName, key string, version *types.V
 	return nil, nil
 }
 
-func (s *Store) getLastDeletedVersion(dbName, key string) (*types.Version, error) {
+func (s *levelDBStore) getLastDeletedVersion(dbName, key string) (*types.Version, error) {
 	valuesWithMetadata, err := s.getDeletedValuesWithoutLock(dbName, key)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error finding the last deleted version")
@@ -461,7 +650,7 @@ func (s *Store) getLastDeletedVersion(dbName, key string) (*types.Version, error
 	return lastVer, nil
 }
 
-func (s *Store) getValuesRecursively(dbName, key string, version *types.Version, predicate string, limit int) ([]*types.ValueWithMetadata, error) {
+func (s *levelDBStore) getValuesRecursively(dbName, key string, version *types.Version, predicate string, limit int) ([]*types.ValueWithMetadata, error) {
 	valueVertex, err := s.getValueVertex(dbName, key, version)
 	if err != nil {
 		return nil, err
@@ -473,10 +662,63 @@ func (s *Store) getValuesRecursively(dbName, key string, version *types.Version,
 		return nil, err
 	}
 
-	return verticesToValues(valueVertices)
+	return s.verticesToValues(valueVertices)
+}
+
+func (s *levelDBStore) iterateValuesRecursively(dbName, key string, version *types.Version, predicate string, limit int, visit func(*types.ValueWithMetadata) error) error {
+	valueVertex, err := s.getValueVertex(dbName, key, version)
+	if err != nil {
+		return err
+	}
+
+	maxDepth := limit
+	if maxDepth <= 0 {
+		maxDepth = -1
+	}
+	p := cayley.StartPath(s.cayleyGraph, valueVertex).FollowRecursive(quad.String(predicate), maxDepth, nil)
+
+	return s.iterateValues(p, 0, limit, visit)
+}
+
+// iterateValues calls visit with the value vertices of the path, one at a time, skipping the first offset vertices
+// and up to the limit, if the limit is positive
+func (s *levelDBStore) iterateValues(p *cayley.Path, offset, limit int, visit func(*types.ValueWithMetadata) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	it := p.Iterate(ctx)
+	if limit > 0 {
+		it = it.Limit(offset + limit)
+	}
+
+	var visitErr error
+	err := it.EachValue(s.cayleyGraph, func(qv quad.Value) {
+		if visitErr != nil {
+			return
+		}
+		if offset > 0 {
+			offset--
+			return
+		}
+
+		v, err := s.vertexToValue(qv)
+		if err == nil {
+			err = visit(v)
+		}
+		if err != nil {
+			// stops the iteration
+			visitErr = err
+			cancel()
+		}
+	})
+	if visitErr != nil {
+		return visitErr
+	}
+
+	return err
 }
 
-func (s *Store) getValueVertex(dbName, key string, version *types.Version) (quad.Value, error) {
+func (s *levelDBStore) getValueVertex(dbName, key string, version *types.Version) (quad.Value, error) {
 	cKey := constructCompositeKey(dbName, key)
 	ver, err := json.Marshal(version)
 	if err != nil {
@@ -488,11 +730,11 @@ func (s *Store) getValueVertex(dbName, key string, version *types.Version) (quad
 	return p.Iterate(context.Background()).FirstValue(s.cayleyGraph)
 }
 
-func (s *Store) outEdgesFrom(verticies []string, predicate string) ([]*types.KVWithMetadata, error) {
+func (s *levelDBStore) outEdgesFrom(verticies []string, predicate string) ([]*KVWithDBName, error) {
 	// TODO: convert the array to map to include counts for each value. For now, the returned array
 	// might contain duplicate entries if more than two vertices connects to the same vertex with an
 	// edge for a given predicate
-	var values []*types.KVWithMetadata
+	var values []*KVWithDBName
 
 	for _, vertex := range verticies {
 		s.logger.Debugf("finding all out edges from vertex [%s] with predicate [%s]", vertex, predicate)
@@ -503,7 +745,7 @@ func (s *Store) outEdgesFrom(verticies []string, predicate string) ([]*types.KVW
 			return nil, err
 		}
 
-		kvs, err := verticesToKVs(vertices)
+		kvs, err := s.verticesToKVs(vertices)
 		if err != nil {
 			return nil, err
 		}
@@ -513,27 +755,31 @@ func (s *Store) outEdgesFrom(verticies []string, predicate string) ([]*types.KVW
 	return values, nil
 }
 
-func verticesToKVs(qvs []quad.Value) ([]*types.KVWithMetadata, error) {
-	var KVs []*types.KVWithMetadata
+func (s *levelDBStore) verticesToKVs(qvs []quad.Value) ([]*KVWithDBName, error) {
+	var KVs []*KVWithDBName
 
 	for _, qv := range qvs {
-		kv := &types.KVWithMetadata{}
-		if err := json.Unmarshal([]byte(quad.ToString(qv)), kv); err != nil {
+		kv, err := s.vertexToKV(qv)
+		if err != nil {
 			return nil, err
 		}
-		_, kv.Key = splitCompositeKey(kv.Key)
+		dbName, key := splitCompositeKey(kv.Key)
+		kv.Key = key
 
-		KVs = append(KVs, kv)
+		KVs = append(KVs, &KVWithDBName{
+			DBName:         dbName,
+			KVWithMetadata: kv,
+		})
 	}
 
 	return KVs, nil
 }
 
-func verticesToValues(qvs []quad.Value) ([]*types.ValueWithMetadata, error) {
+func (s *levelDBStore) verticesToValues(qvs []quad.Value) ([]*types.ValueWithMetadata, error) {
 	var values []*types.ValueWithMetadata
 
 	for _, qv := range qvs {
-		v, err := vertexToValue(qv)
+		v, err := s.vertexToValue(qv)
 		if err != nil {
 			return nil, err
 		}
@@ -544,18 +790,120 @@ func verticesToValues(qvs []quad.Value) ([]*types.ValueWithMetadata, error) {
 	return values, nil
 }
 
-func vertexToValue(qv quad.Value) (*types.ValueWithMetadata, error) {
-	kv := &types.KVWithMetadata{}
-	if err := json.Unmarshal([]byte(quad.ToString(qv)), kv); err != nil {
+// vertexToValue decodes a value vertex. The value of a key that was erased since it was written is returned sealed.
+func (s *levelDBStore) vertexToValue(qv quad.Value) (*types.ValueWithMetadata, error) {
+	kv, erased, err := s.decodeValueVertex(qv)
+	if err != nil {
 		return nil, err
 	}
 
 	return &types.ValueWithMetadata{
-		Value:    kv.Value,
-		Metadata: kv.Metadata,
+		Value:          kv.Value,
+		Metadata:       kv.Metadata,
+		EncryptedValue: erased,
+		BlobManifest:   kv.BlobManifest,
 	}, nil
 }
 
+// vertexToKV decodes a value vertex, along with the value bytes held by its content vertex. The value of a key that
+// was erased since it was written is empty.
+func (s *levelDBStore) vertexToKV(qv quad.Value) (*types.KVWithMetadata, error) {
+	kv, _, err := s.decodeValueVertex(qv)
+	return kv, err
+}
+
+// decodeValueVertex decodes a value vertex, along with the value bytes held by its content vertex. If the value was
+// sealed by an erasure key that was destroyed, the value is empty and the sealed value is returned.
+func (s *levelDBStore) decodeValueVertex(qv quad.Value) (*types.KVWithMetadata, *types.EncryptedValue, error) {
+	kv := &types.KVWithMetadata{}
+	if err := json.Unmarshal([]byte(quad.ToString(qv)), kv); err != nil {
+		return nil, nil, err
+	}
+	if kv.Value != nil {
+		// the value vertex was committed before the content vertices were introduced
+		return kv, nil, nil
+	}
+
+	content, err := cayley.StartPath(s.cayleyGraph, qv).Out(quad.String(CONTENT)).Iterate(context.Background()).FirstValue(s.cayleyGraph)
+	if err != nil {
+		return nil, nil, err
+	}
+	if content == nil {
+		// the value is empty
+		return kv, nil, nil
+	}
+
+	contentStr := quad.ToString(content)
+	if !strings.HasPrefix(contentStr, sealedContentPrefix) {
+		if kv.Value, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(contentStr, contentPrefix)); err != nil {
+			return nil, nil, errors.Wrap(err, "error while decoding the content of a value")
+		}
+		return kv, nil, nil
+	}
+
+	marshaled, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(contentStr, sealedContentPrefix))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error while decoding the sealed content of a value")
+	}
+	sealed := &types.EncryptedValue{}
+	if err := proto.Unmarshal(marshaled, sealed); err != nil {
+		return nil, nil, errors.Wrap(err, "error while unmarshaling the sealed content of a value")
+	}
+
+	dbName, key := splitCompositeKey(kv.Key)
+	value, ok, err := s.erasure.Unseal(dbName, key, sealed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return kv, sealed, nil
+	}
+
+	kv.Value = value
+	return kv, nil, nil
+}
+
+// contentVertex returns the content vertex of the value bytes
+func contentVertex(value []byte) quad.Value {
+	return quad.String(contentPrefix + base64.StdEncoding.EncodeToString(value))
+}
+
+// newContentVertex returns the content vertex of the value bytes of a value vertex. The value bytes of a key of an
+// erasable database are sealed with a random nonce, hence nil is returned if the value vertex already holds its sealed
+// content, e.g., when a block is committed again as it is replayed on recovery, so that the value holds a single content.
+func (s *levelDBStore) newContentVertex(dbName, key string, value quad.Value, valueBytes []byte) (quad.Value, error) {
+	if !s.erasure.Erasable(dbName) {
+		return contentVertex(valueBytes), nil
+	}
+
+	content, err := cayley.StartPath(s.cayleyGraph, value).Out(quad.String(CONTENT)).Iterate(context.Background()).FirstValue(s.cayleyGraph)
+	if err != nil || content != nil {
+		return nil, err
+	}
+
+	return s.sealedContentVertex(dbName, key, valueBytes)
+}
+
+// sealedContentVertex returns the content vertex of the value bytes of a key of an erasable database
+func (s *levelDBStore) sealedContentVertex(dbName, key string, value []byte) (quad.Value, error) {
+	sealed, err := s.erasure.Seal(dbName, key, value)
+	if err != nil {
+		return nil, err
+	}
+	marshaled, err := proto.Marshal(sealed)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshaling the sealed content of a value")
+	}
+
+	return quad.String(sealedContentPrefix + base64.StdEncoding.EncodeToString(marshaled)), nil
+}
+
+// erasedContentVertex returns the content vertex of a value that was erased before it was committed to this node. It
+// holds an empty sealed value, which refers to no erasure key, hence it is decoded as an erased value.
+func erasedContentVertex() quad.Value {
+	return quad.String(sealedContentPrefix)
+}
+
 func vertexToTxIDLocation(qv quad.Value) (*TxIDLocation, error) {
 	loc := &TxIDLocation{}
 	if err := json.Unmarshal([]byte(quad.ToString(qv)), loc); err != nil {
@@ -3,18 +3,26 @@
 package provenance
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/cayleygraph/cayley"
+	"github.com/cayleygraph/quad"
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
 type testEnv struct {
 	storeDir string
-	s        *Store
+	s        *levelDBStore
 	cleanup  func()
 }
 
@@ -36,7 +44,7 @@ func newTestEnv(t *testing.T) *testEnv {
 		Logger:   lggr,
 	}
 
-	store, err := Open(c)
+	store, err := openLevelDBStore(c)
 	if err != nil {
 		if rmErr := os.RemoveAll(storeDir); rmErr != nil {
 			t.Errorf("error while removing directory %s, %v", storeDir, rmErr)
@@ -60,7 +68,7 @@ func newTestEnv(t *testing.T) *testEnv {
 	}
 }
 
-func setup(t *testing.T, s *Store) {
+func setup(t *testing.T, s *levelDBStore) {
 	block1TxsData := []*TxDataForProvenance{
 		{
 			IsValid: true,
@@ -270,12 +278,12 @@ func setup(t *testing.T, s *Store) {
 		},
 	}
 
-	require.NoError(t, s.Commit(1, block1TxsData))
-	require.NoError(t, s.Commit(2, block2TxsData))
-	require.NoError(t, s.Commit(3, block3TxsData))
-	require.NoError(t, s.Commit(4, block4TxsData))
-	require.NoError(t, s.Commit(5, block5TxsData))
-	require.NoError(t, s.Commit(6, block6TxsData))
+	require.NoError(t, s.Commit(1, 1001, block1TxsData))
+	require.NoError(t, s.Commit(2, 1002, block2TxsData))
+	require.NoError(t, s.Commit(3, 1003, block3TxsData))
+	require.NoError(t, s.Commit(4, 1004, block4TxsData))
+	require.NoError(t, s.Commit(5, 1005, block5TxsData))
+	require.NoError(t, s.Commit(6, 1006, block6TxsData))
 }
 
 func TestGetValueAt(t *testing.T) {
@@ -467,6 +475,125 @@ func TestGetValues(t *testing.T) {
 	}
 }
 
+func TestIterateValues(t *testing.T) {
+	t.Parallel()
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	setup(t, env.s)
+
+	value := func(v string, blockNum uint64) *types.ValueWithMetadata {
+		return &types.ValueWithMetadata{
+			Value: []byte(v),
+			Metadata: &types.Metadata{
+				Version: &types.Version{
+					BlockNum: blockNum,
+					TxNum:    0,
+				},
+			},
+		}
+	}
+
+	collect := func(values *[]*types.ValueWithMetadata) func(*types.ValueWithMetadata) error {
+		return func(v *types.ValueWithMetadata) error {
+			*values = append(*values, v)
+			return nil
+		}
+	}
+
+	t.Run("all values of key1 in pages", func(t *testing.T) {
+		allValues, err := env.s.GetValues("db1", "key1")
+		require.NoError(t, err)
+		require.Len(t, allValues, 4)
+
+		var firstPage, secondPage, thirdPage []*types.ValueWithMetadata
+		require.NoError(t, env.s.IterateValues("db1", "key1", 0, 3, collect(&firstPage)))
+		require.Len(t, firstPage, 3)
+		require.NoError(t, env.s.IterateValues("db1", "key1", 3, 3, collect(&secondPage)))
+		require.Len(t, secondPage, 1)
+		require.NoError(t, env.s.IterateValues("db1", "key1", 4, 3, collect(&thirdPage)))
+		require.Empty(t, thirdPage)
+
+		require.Equal(t, allValues, append(firstPage, secondPage...))
+	})
+
+	t.Run("all values of key1 without limit", func(t *testing.T) {
+		var values []*types.ValueWithMetadata
+		require.NoError(t, env.s.IterateValues("db1", "key1", 0, 0, collect(&values)))
+		require.ElementsMatch(t, []*types.ValueWithMetadata{
+			value("value1", 1),
+			value("value2", 2),
+			value("value4", 3),
+			value("value5", 4),
+		}, values)
+	})
+
+	t.Run("previous and next values of key1 up to the limit", func(t *testing.T) {
+		var values []*types.ValueWithMetadata
+		require.NoError(t, env.s.IteratePreviousValues("db1", "key1", &types.Version{BlockNum: 4, TxNum: 0}, 2, collect(&values)))
+		require.ElementsMatch(t, []*types.ValueWithMetadata{value("value4", 3), value("value2", 2)}, values)
+
+		values = nil
+		require.NoError(t, env.s.IterateNextValues("db1", "key1", &types.Version{BlockNum: 1, TxNum: 0}, 2, collect(&values)))
+		require.ElementsMatch(t, []*types.ValueWithMetadata{value("value2", 2), value("value4", 3)}, values)
+	})
+
+	t.Run("iteration stops on error", func(t *testing.T) {
+		visited := 0
+		err := env.s.IterateValues("db1", "key1", 0, 0, func(v *types.ValueWithMetadata) error {
+			visited++
+			return errors.New("client disconnected")
+		})
+		require.EqualError(t, err, "client disconnected")
+		require.Equal(t, 1, visited)
+	})
+}
+
+func TestValueContentDeduplication(t *testing.T) {
+	t.Parallel()
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	setup(t, env.s)
+
+	t.Run("identical values share the content vertex", func(t *testing.T) {
+		// key1 and key2 are written with the same value in block 1
+		valueVertex1, err := env.s.getValueVertex("db1", "key1", &types.Version{BlockNum: 1, TxNum: 0})
+		require.NoError(t, err)
+		valueVertex2, err := env.s.getValueVertex("db1", "key2", &types.Version{BlockNum: 1, TxNum: 1})
+		require.NoError(t, err)
+		require.NotEqual(t, valueVertex1, valueVertex2)
+
+		content := contentVertex([]byte("value1"))
+		require.NotContains(t, quad.ToString(valueVertex1), strings.TrimPrefix(quad.ToString(content), contentPrefix))
+
+		for _, v := range []quad.Value{valueVertex1, valueVertex2} {
+			c, err := cayley.StartPath(env.s.cayleyGraph, v).Out(quad.String(CONTENT)).Iterate(context.Background()).FirstValue(env.s.cayleyGraph)
+			require.NoError(t, err)
+			require.Equal(t, content, c)
+		}
+
+		values, err := cayley.StartPath(env.s.cayleyGraph, content).In(quad.String(CONTENT)).Iterate(context.Background()).AllValues(env.s.cayleyGraph)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []quad.Value{valueVertex1, valueVertex2}, values)
+	})
+
+	t.Run("value vertex holding the value bytes", func(t *testing.T) {
+		version := &types.Version{BlockNum: 1, TxNum: 0}
+		metadata := &types.Metadata{Version: version}
+		cKey := constructCompositeKey("db2", "key1")
+		ver, err := json.Marshal(version)
+		require.NoError(t, err)
+		value, err := json.Marshal(&types.KVWithMetadata{Key: cKey, Value: []byte("value1"), Metadata: metadata})
+		require.NoError(t, err)
+		require.NoError(t, env.s.cayleyGraph.AddQuad(quad.Make(cKey, string(ver), string(value), "")))
+
+		v, err := env.s.GetValueAt("db2", "key1", version)
+		require.NoError(t, err)
+		require.Equal(t, &types.ValueWithMetadata{Value: []byte("value1"), Metadata: metadata}, v)
+	})
+}
+
 func TestGetTxSubmittedByUser(t *testing.T) {
 	t.Parallel()
 	env := newTestEnv(t)
@@ -613,19 +740,22 @@ func TestGetValuesReadByUser(t *testing.T) {
 	tests := []struct {
 		name          string
 		userID        string
-		expectedReads []*types.KVWithMetadata
+		expectedReads []*KVWithDBName
 	}{
 		{
 			name:   "fetch all values read by user1",
 			userID: "user1",
-			expectedReads: []*types.KVWithMetadata{
+			expectedReads: []*KVWithDBName{
 				{
-					Key:   "key1",
-					Value: []byte("value1"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 1,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value1"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 1,
+								TxNum:    0,
+							},
 						},
 					},
 				},
@@ -634,30 +764,36 @@ func TestGetValuesReadByUser(t *testing.T) {
 		{
 			name:   "fetch all values read by user2",
 			userID: "user2",
-			expectedReads: []*types.KVWithMetadata{
+			expectedReads: []*KVWithDBName{
 				{
-					Key:   "key2",
-					Value: []byte("value1"),
-					Metadata: &types.Metadata{
-						AccessControl: &types.AccessControl{
-							ReadWriteUsers: map[string]bool{
-								"user1": true,
-								"user2": true,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key2",
+						Value: []byte("value1"),
+						Metadata: &types.Metadata{
+							AccessControl: &types.AccessControl{
+								ReadWriteUsers: map[string]bool{
+									"user1": true,
+									"user2": true,
+								},
+							},
+							Version: &types.Version{
+								BlockNum: 1,
+								TxNum:    1,
 							},
-						},
-						Version: &types.Version{
-							BlockNum: 1,
-							TxNum:    1,
 						},
 					},
 				},
 				{
-					Key:   "key1",
-					Value: []byte("value2"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 2,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value2"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 2,
+								TxNum:    0,
+							},
 						},
 					},
 				},
@@ -690,45 +826,54 @@ func TestGetValuesWrittenByUser(t *testing.T) {
 	tests := []struct {
 		name           string
 		userID         string
-		expectedWrites []*types.KVWithMetadata
+		expectedWrites []*KVWithDBName
 	}{
 		{
 			name:   "fetch all values written by user1",
 			userID: "user1",
-			expectedWrites: []*types.KVWithMetadata{
+			expectedWrites: []*KVWithDBName{
 				{
-					Key:   "key1",
-					Value: []byte("value1"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 1,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value1"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 1,
+								TxNum:    0,
+							},
 						},
 					},
 				},
 				{
-					Key:   "key2",
-					Value: []byte("value1"),
-					Metadata: &types.Metadata{
-						AccessControl: &types.AccessControl{
-							ReadWriteUsers: map[string]bool{
-								"user1": true,
-								"user2": true,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key2",
+						Value: []byte("value1"),
+						Metadata: &types.Metadata{
+							AccessControl: &types.AccessControl{
+								ReadWriteUsers: map[string]bool{
+									"user1": true,
+									"user2": true,
+								},
+							},
+							Version: &types.Version{
+								BlockNum: 1,
+								TxNum:    1,
 							},
-						},
-						Version: &types.Version{
-							BlockNum: 1,
-							TxNum:    1,
 						},
 					},
 				},
 				{
-					Key:   "key1",
-					Value: []byte("value2"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 2,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value2"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 2,
+								TxNum:    0,
+							},
 						},
 					},
 				},
@@ -737,40 +882,49 @@ func TestGetValuesWrittenByUser(t *testing.T) {
 		{
 			name:   "fetch all values written by user2",
 			userID: "user2",
-			expectedWrites: []*types.KVWithMetadata{
+			expectedWrites: []*KVWithDBName{
 				{
-					Key:   "key1",
-					Value: []byte("value4"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 3,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value4"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 3,
+								TxNum:    0,
+							},
 						},
 					},
 				},
 				{
-					Key:   "key1",
-					Value: []byte("value5"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 4,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value5"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 4,
+								TxNum:    0,
+							},
 						},
 					},
 				},
 				{
-					Key:   "key2",
-					Value: []byte("value2"),
-					Metadata: &types.Metadata{
-						AccessControl: &types.AccessControl{
-							ReadWriteUsers: map[string]bool{
-								"user1": true,
-								"user2": true,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key2",
+						Value: []byte("value2"),
+						Metadata: &types.Metadata{
+							AccessControl: &types.AccessControl{
+								ReadWriteUsers: map[string]bool{
+									"user1": true,
+									"user2": true,
+								},
+							},
+							Version: &types.Version{
+								BlockNum: 3,
+								TxNum:    0,
 							},
-						},
-						Version: &types.Version{
-							BlockNum: 3,
-							TxNum:    0,
 						},
 					},
 				},
@@ -1194,29 +1348,35 @@ func TestGetValuesDeletedByUser(t *testing.T) {
 	tests := []struct {
 		name           string
 		userID         string
-		expectedWrites []*types.KVWithMetadata
+		expectedWrites []*KVWithDBName
 	}{
 		{
 			name:   "fetch all values deleted by user2",
 			userID: "user2",
-			expectedWrites: []*types.KVWithMetadata{
+			expectedWrites: []*KVWithDBName{
 				{
-					Key:   "key1",
-					Value: []byte("value4"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 3,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value4"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 3,
+								TxNum:    0,
+							},
 						},
 					},
 				},
 				{
-					Key:   "key1",
-					Value: []byte("value5"),
-					Metadata: &types.Metadata{
-						Version: &types.Version{
-							BlockNum: 4,
-							TxNum:    0,
+					DBName: "db1",
+					KVWithMetadata: &types.KVWithMetadata{
+						Key:   "key1",
+						Value: []byte("value5"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 4,
+								TxNum:    0,
+							},
 						},
 					},
 				},
@@ -1254,15 +1414,17 @@ func TestGetTxIDLocation(t *testing.T) {
 		{
 			txID: "tx2",
 			expected: &TxIDLocation{
-				BlockNum: 1,
-				TxIndex:  1,
+				BlockNum:  1,
+				TxIndex:   1,
+				BlockTime: 1001,
 			},
 		},
 		{
 			txID: "tx10",
 			expected: &TxIDLocation{
-				BlockNum: 1,
-				TxIndex:  2,
+				BlockNum:  1,
+				TxIndex:   2,
+				BlockTime: 1001,
 			},
 		},
 		{
@@ -1434,3 +1596,339 @@ func TestGetMostRecentValueAtOrBelow(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTxAnnotations(t *testing.T) {
+	t.Parallel()
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	txsData := []*TxDataForProvenance{
+		{
+			IsValid: true,
+			DBName:  "db1",
+			UserID:  "user1",
+			TxID:    "tx1",
+			Writes: []*types.KVWithMetadata{
+				{
+					Key:   "key1",
+					Value: []byte("value1"),
+					Metadata: &types.Metadata{
+						Version: &types.Version{
+							BlockNum: 1,
+							TxNum:    0,
+						},
+					},
+				},
+			},
+			OldVersionOfWrites: make(map[string]*types.Version),
+			Annotations: map[string]string{
+				"correlation-id": "c1",
+				"order":          "o1",
+			},
+		},
+		{
+			IsValid: false,
+			TxID:    "tx2",
+			Annotations: map[string]string{
+				"correlation-id": "c2",
+			},
+		},
+		{
+			IsValid:            true,
+			DBName:             "db1",
+			UserID:             "user1",
+			TxID:               "tx3",
+			OldVersionOfWrites: make(map[string]*types.Version),
+		},
+	}
+	require.NoError(t, env.s.Commit(1, 1001, txsData))
+
+	tests := []struct {
+		txID     string
+		expected map[string]string
+	}{
+		{
+			txID: "tx1",
+			expected: map[string]string{
+				"correlation-id": "c1",
+				"order":          "o1",
+			},
+		},
+		{
+			txID: "tx2",
+			expected: map[string]string{
+				"correlation-id": "c2",
+			},
+		},
+		{
+			txID:     "tx3",
+			expected: nil,
+		},
+		{
+			txID:     "tx-not-found",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.txID, func(t *testing.T) {
+			annotations, err := env.s.GetTxAnnotations(tt.txID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, annotations)
+		})
+	}
+}
+
+func TestGetTxIDsByAnnotation(t *testing.T) {
+	t.Parallel()
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	block1TxsData := []*TxDataForProvenance{
+		{
+			IsValid:            true,
+			DBName:             "db1",
+			UserID:             "user1",
+			TxID:               "tx1",
+			OldVersionOfWrites: make(map[string]*types.Version),
+			Annotations: map[string]string{
+				"correlation-id": "c1",
+				"order":          "o1",
+			},
+		},
+		{
+			IsValid: false,
+			TxID:    "tx2",
+			Annotations: map[string]string{
+				"correlation-id": "c1",
+			},
+		},
+	}
+	require.NoError(t, env.s.Commit(1, 1001, block1TxsData))
+
+	block2TxsData := []*TxDataForProvenance{
+		{
+			IsValid:            true,
+			DBName:             "db1",
+			UserID:             "user2",
+			TxID:               "tx3",
+			OldVersionOfWrites: make(map[string]*types.Version),
+			Annotations: map[string]string{
+				"correlation-id": "c2",
+				"order":          "o1",
+			},
+		},
+	}
+	require.NoError(t, env.s.Commit(2, 1002, block2TxsData))
+
+	tests := []struct {
+		name      string
+		key       string
+		value     string
+		submitter string
+		expected  []string
+	}{
+		{
+			name:     "valid and invalid txs",
+			key:      "correlation-id",
+			value:    "c1",
+			expected: []string{"tx1", "tx2"},
+		},
+		{
+			name:     "txs of different blocks",
+			key:      "order",
+			value:    "o1",
+			expected: []string{"tx1", "tx3"},
+		},
+		{
+			name:     "single tx",
+			key:      "correlation-id",
+			value:    "c2",
+			expected: []string{"tx3"},
+		},
+		{
+			name:     "value not found",
+			key:      "correlation-id",
+			value:    "c3",
+			expected: nil,
+		},
+		{
+			name:     "key not found",
+			key:      "customer",
+			value:    "c1",
+			expected: nil,
+		},
+		{
+			name:      "txs of a submitter",
+			key:       "order",
+			value:     "o1",
+			submitter: "user2",
+			expected:  []string{"tx3"},
+		},
+		{
+			name:      "no tx of a submitter",
+			key:       "correlation-id",
+			value:     "c1",
+			submitter: "user2",
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txIDs, err := env.s.GetTxIDsByAnnotation(tt.key, tt.value, tt.submitter)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.expected, txIDs)
+		})
+	}
+}
+
+func TestErasableValues(t *testing.T) {
+	t.Parallel()
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	erasureDir, err := ioutil.TempDir("", "erasure")
+	require.NoError(t, err)
+	defer os.RemoveAll(erasureDir)
+
+	erasureStore, err := erasure.Open(&erasure.Config{StoreDir: erasureDir, Logger: env.s.logger})
+	require.NoError(t, err)
+	defer erasureStore.Close()
+	erasureStore.SetErasableDBs([]string{"db1"})
+	env.s.erasure = erasureStore
+
+	metadata := func(blockNum uint64) *types.Metadata {
+		return &types.Metadata{Version: &types.Version{BlockNum: blockNum}}
+	}
+	for blockNum := uint64(1); blockNum <= 2; blockNum++ {
+		require.NoError(t, env.s.Commit(blockNum, 0, []*TxDataForProvenance{
+			{
+				IsValid: true,
+				DBName:  "db1",
+				UserID:  "user1",
+				TxID:    fmt.Sprintf("tx%d", blockNum),
+				Writes: []*types.KVWithMetadata{
+					{Key: "key1", Value: []byte("value1"), Metadata: metadata(blockNum)},
+					{Key: "key2", Value: []byte("value2"), Metadata: metadata(blockNum)},
+				},
+				OldVersionOfWrites: make(map[string]*types.Version),
+			},
+		}))
+	}
+
+	// a sealed value is not deduplicated, and it does not share the content vertex of the plain value
+	content, err := cayley.StartPath(env.s.cayleyGraph, contentVertex([]byte("value1"))).In(quad.String(CONTENT)).Iterate(context.Background()).AllValues(env.s.cayleyGraph)
+	require.NoError(t, err)
+	require.Empty(t, content)
+
+	values, err := env.s.GetValues("db1", "key1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*types.ValueWithMetadata{
+		{Value: []byte("value1"), Metadata: metadata(1)},
+		{Value: []byte("value1"), Metadata: metadata(2)},
+	}, values)
+
+	require.NoError(t, erasureStore.Erase([]*types.KeyErasure{{DbName: "db1", Key: "key1"}}))
+
+	values, err = env.s.GetValues("db1", "key1")
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	for _, v := range values {
+		require.Empty(t, v.Value)
+		require.NotNil(t, v.EncryptedValue)
+	}
+
+	v, err := env.s.GetValueAt("db1", "key2", metadata(2).Version)
+	require.NoError(t, err)
+	require.Equal(t, &types.ValueWithMetadata{Value: []byte("value2"), Metadata: metadata(2)}, v)
+
+	kvs, err := env.s.GetValuesWrittenByUser("user1")
+	require.NoError(t, err)
+	require.Len(t, kvs, 4)
+	for _, kv := range kvs {
+		if kv.Key == "key1" {
+			require.Empty(t, kv.Value)
+		} else {
+			require.Equal(t, []byte("value2"), kv.Value)
+		}
+	}
+}
+
+func TestCommitReplayedBlocks(t *testing.T) {
+	t.Parallel()
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	erasureDir, err := ioutil.TempDir("", "erasure")
+	require.NoError(t, err)
+	defer os.RemoveAll(erasureDir)
+
+	erasureStore, err := erasure.Open(&erasure.Config{StoreDir: erasureDir, Logger: env.s.logger})
+	require.NoError(t, err)
+	defer erasureStore.Close()
+	erasureStore.SetErasableDBs([]string{"db1"})
+	env.s.erasure = erasureStore
+
+	metadata := func(blockNum uint64) *types.Metadata {
+		return &types.Metadata{Version: &types.Version{BlockNum: blockNum}}
+	}
+	// the txs data is constructed anew for every commit, as it is when a block is replayed
+	commit := func(blockNum uint64) {
+		oldVersionOfWrites := make(map[string]*types.Version)
+		if blockNum > 1 {
+			oldVersionOfWrites["key1"] = metadata(blockNum - 1).Version
+		}
+		require.NoError(t, env.s.Commit(blockNum, 0, []*TxDataForProvenance{
+			{
+				IsValid: true,
+				DBName:  "db1",
+				UserID:  "user1",
+				TxID:    fmt.Sprintf("tx%d", blockNum),
+				Writes: []*types.KVWithMetadata{
+					{Key: "key1", Value: []byte(fmt.Sprintf("value%d", blockNum)), Metadata: metadata(blockNum)},
+				},
+				OldVersionOfWrites: oldVersionOfWrites,
+				Annotations:        map[string]string{"order": "o1"},
+			},
+			{
+				IsValid: true,
+				DBName:  "db2",
+				UserID:  "user1",
+				TxID:    fmt.Sprintf("tx%d-db2", blockNum),
+				Writes: []*types.KVWithMetadata{
+					{Key: fmt.Sprintf("key%d", blockNum), Value: []byte("value"), Metadata: metadata(blockNum)},
+				},
+				OldVersionOfWrites: make(map[string]*types.Version),
+			},
+		}))
+	}
+	quads := func() int64 {
+		stats, err := env.s.cayleyGraph.Stats(context.Background(), true)
+		require.NoError(t, err)
+		return stats.Quads.Size
+	}
+
+	for blockNum := uint64(1); blockNum <= 3; blockNum++ {
+		commit(blockNum)
+	}
+	expectedQuads := quads()
+	expectedValues, err := env.s.GetValues("db1", "key1")
+	require.NoError(t, err)
+	require.Len(t, expectedValues, 3)
+
+	for blockNum := uint64(2); blockNum <= 3; blockNum++ {
+		commit(blockNum)
+	}
+	require.Equal(t, expectedQuads, quads())
+
+	values, err := env.s.GetValues("db1", "key1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, expectedValues, values)
+	next, err := env.s.GetNextValues("db1", "key1", metadata(1).Version, -1)
+	require.NoError(t, err)
+	require.Len(t, next, 2)
+	txIDs, err := env.s.GetTxIDsByAnnotation("order", "o1", "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"tx1", "tx2", "tx3"}, txIDs)
+}
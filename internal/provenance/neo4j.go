@@ -0,0 +1,369 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package provenance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Neo4jExportSubsystemName is the name of the outbox subsystem that exports the lineage to Neo4j
+	Neo4jExportSubsystemName = "provenance-neo4j"
+
+	defaultNeo4jDatabase = "neo4j"
+	defaultNeo4jTimeout  = 30 * time.Second
+)
+
+// Neo4jConfig holds the connection parameters of a Neo4j database
+type Neo4jConfig struct {
+	// URL of the HTTP endpoint of the Neo4j server, e.g., http://localhost:7474
+	URL string
+	// Database is the name of the database. If empty, "neo4j" is used.
+	Database string
+	Username string
+	Password string
+	// Timeout of a request to the Neo4j server. If zero, a default is used.
+	Timeout time.Duration
+}
+
+// neo4jExport is an outbox subsystem that exports the lineage of every committed block to a Neo4j database, over the
+// transactional HTTP endpoint of Neo4j.
+//
+// The lineage of a block is derived from the block alone, and is recorded in the outbox as a single entry, which is
+// exported in a single Neo4j transaction. As the outbox delivers the entries in the order of the blocks, and
+// re-publishes an entry until it is exported, the version that a write replaces, and the version that a delete
+// removes, are resolved in Neo4j as the latest version of the key exported before the transaction. The statements are
+// MERGE statements only; hence, exporting a block again is harmless. The commit of a block never waits for, or fails
+// on, the export. The export contains the keys and versions of the values, but not the values themselves.
+//
+// The exported graph consists of Block, Tx, User, Value and Annotation nodes, where a Value is identified by its
+// database, key and version, and the relationships (Block)-[:INCLUDES]->(Tx), (User)-[:SUBMITTED]->(Tx),
+// (Tx)-[:READS|WRITES|DELETES]->(Value), (Value)-[:PREVIOUS]->(Value), and (Tx)-[:ANNOTATED]->(Annotation). The
+// values of data transactions and user administration transactions, and the cluster configuration written by config
+// transactions, are exported; database administration transactions are not.
+type neo4jExport struct {
+	commitURL     string
+	username      string
+	password      string
+	client        *http.Client
+	schemaCreated bool
+	logger        *logger.SugarLogger
+}
+
+type neo4jStatement struct {
+	Statement  string                 `json:"statement"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+type neo4jRequest struct {
+	Statements []*neo4jStatement `json:"statements"`
+}
+
+type neo4jResponse struct {
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// neo4jSchema makes the MERGE statements of the export use an index rather than a label scan
+var neo4jSchema = []string{
+	"CREATE CONSTRAINT IF NOT EXISTS FOR (b:Block) REQUIRE b.number IS UNIQUE",
+	"CREATE CONSTRAINT IF NOT EXISTS FOR (t:Tx) REQUIRE t.id IS UNIQUE",
+	"CREATE CONSTRAINT IF NOT EXISTS FOR (u:User) REQUIRE u.id IS UNIQUE",
+	"CREATE INDEX IF NOT EXISTS FOR (v:Value) ON (v.db, v.key, v.block, v.tx)",
+}
+
+// lineageTx holds the lineage of the operations of a transaction on a single database
+type lineageTx struct {
+	txID        string
+	index       int
+	valid       bool
+	userID      string
+	dbName      string
+	annotations map[string]string
+	reads       []*KeyWithVersion
+	writes      []string
+	deletes     []string
+}
+
+// NewNeo4jExport creates an outbox subsystem that exports the lineage of every committed block to Neo4j. The Neo4j
+// database need not be reachable, as the schema is created with the first export.
+func NewNeo4jExport(conf *Neo4jConfig, lg *logger.SugarLogger) (outbox.Subsystem, error) {
+	if conf == nil || conf.URL == "" {
+		return nil, errors.New("the URL of the Neo4j database must be set when the provenance store backend is [" + Neo4jBackend + "]")
+	}
+
+	database := conf.Database
+	if database == "" {
+		database = defaultNeo4jDatabase
+	}
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = defaultNeo4jTimeout
+	}
+
+	return &neo4jExport{
+		commitURL: strings.TrimSuffix(conf.URL, "/") + "/db/" + database + "/tx/commit",
+		username:  conf.Username,
+		password:  conf.Password,
+		client:    &http.Client{Timeout: timeout},
+		logger:    lg,
+	}, nil
+}
+
+func (e *neo4jExport) Name() string {
+	return Neo4jExportSubsystemName
+}
+
+// Entries returns the statements that export the lineage of the block
+func (e *neo4jExport) Entries(block *types.Block) ([][]byte, error) {
+	blockNum := block.GetHeader().GetBaseHeader().GetNumber()
+	statements := lineageStatements(blockNum, block.GetHeader().GetBaseHeader().GetTimestamp(), blockLineage(block))
+
+	entry, err := json.Marshal(&neo4jRequest{Statements: statements})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while marshaling the lineage of block [%d]", blockNum)
+	}
+	return [][]byte{entry}, nil
+}
+
+// Publish exports the lineage of a block, after creating the schema, if it was not created since the node started
+func (e *neo4jExport) Publish(entry *outbox.Entry) error {
+	if !e.schemaCreated {
+		schema := &neo4jRequest{}
+		for _, stmt := range neo4jSchema {
+			schema.Statements = append(schema.Statements, &neo4jStatement{Statement: stmt})
+		}
+		body, err := json.Marshal(schema)
+		if err != nil {
+			return errors.Wrap(err, "error while marshaling the Neo4j request")
+		}
+		if err := e.send(body); err != nil {
+			return errors.WithMessage(err, "error while creating the schema of the Neo4j provenance database")
+		}
+		e.schemaCreated = true
+	}
+
+	if err := e.send(entry.Payload); err != nil {
+		return errors.WithMessagef(err, "error while exporting the lineage of block [%d] to Neo4j", entry.BlockNumber)
+	}
+
+	e.logger.Debugf("Exported the lineage of block [%d] to Neo4j", entry.BlockNumber)
+	return nil
+}
+
+// blockLineage returns the lineage of the transactions of a block
+func blockLineage(block *types.Block) []*lineageTx {
+	validationInfo := block.GetHeader().GetValidationInfo()
+	isValid := func(txNum int) bool {
+		return txNum < len(validationInfo) && validationInfo[txNum].GetFlag() == types.Flag_VALID
+	}
+
+	var txs []*lineageTx
+	switch block.Payload.(type) {
+	case *types.Block_DataTxEnvelopes:
+		for txNum, env := range block.GetDataTxEnvelopes().GetEnvelopes() {
+			tx := env.GetPayload()
+			if !isValid(txNum) {
+				txs = append(txs, &lineageTx{txID: tx.GetTxId(), index: txNum, annotations: tx.GetAnnotations()})
+				continue
+			}
+
+			for _, ops := range tx.GetDbOperations() {
+				ltx := &lineageTx{
+					txID:        tx.GetTxId(),
+					index:       txNum,
+					valid:       true,
+					userID:      tx.GetMustSignUserIds()[0],
+					dbName:      ops.GetDbName(),
+					annotations: tx.GetAnnotations(),
+				}
+				for _, r := range ops.GetDataReads() {
+					ltx.reads = append(ltx.reads, &KeyWithVersion{Key: r.GetKey(), Version: r.GetVersion()})
+				}
+				for _, w := range ops.GetDataWrites() {
+					ltx.writes = append(ltx.writes, w.GetKey())
+				}
+				for _, d := range ops.GetDataDeletes() {
+					ltx.deletes = append(ltx.deletes, d.GetKey())
+				}
+				txs = append(txs, ltx)
+			}
+		}
+
+	case *types.Block_UserAdministrationTxEnvelope:
+		tx := block.GetUserAdministrationTxEnvelope().GetPayload()
+		ltx := &lineageTx{txID: tx.GetTxId(), valid: isValid(0)}
+		if ltx.valid {
+			ltx.userID = tx.GetUserId()
+			ltx.dbName = worldstate.UsersDBName
+			for _, r := range tx.GetUserReads() {
+				ltx.reads = append(ltx.reads, &KeyWithVersion{Key: r.GetUserId(), Version: r.GetVersion()})
+			}
+			for _, w := range tx.GetUserWrites() {
+				ltx.writes = append(ltx.writes, w.GetUser().GetId())
+			}
+			for _, d := range tx.GetUserDeletes() {
+				ltx.deletes = append(ltx.deletes, d.GetUserId())
+			}
+			if tx.GetCertificateRenewal() != nil {
+				ltx.writes = append(ltx.writes, tx.GetUserId())
+			}
+		}
+		txs = append(txs, ltx)
+
+	case *types.Block_ConfigTxEnvelope:
+		tx := block.GetConfigTxEnvelope().GetPayload()
+		ltx := &lineageTx{txID: tx.GetTxId(), valid: isValid(0)}
+		if ltx.valid {
+			ltx.userID = tx.GetUserId()
+			ltx.dbName = worldstate.ConfigDBName
+			ltx.writes = []string{worldstate.ConfigKey}
+		}
+		txs = append(txs, ltx)
+	}
+
+	return txs
+}
+
+func lineageStatements(blockNum uint64, blockTime int64, txs []*lineageTx) []*neo4jStatement {
+	statements := []*neo4jStatement{
+		{
+			Statement:  "MERGE (b:Block {number: $block}) SET b.time = $time",
+			Parameters: map[string]interface{}{"block": blockNum, "time": blockTime},
+		},
+	}
+
+	for _, tx := range txs {
+		txParams := map[string]interface{}{
+			"block": blockNum,
+			"index": tx.index,
+			"txID":  tx.txID,
+			"valid": tx.valid,
+			"user":  tx.userID,
+			"db":    tx.dbName,
+		}
+
+		statements = append(statements, &neo4jStatement{
+			Statement: "MATCH (b:Block {number: $block}) " +
+				"MERGE (t:Tx {id: $txID}) SET t.block = $block, t.index = $index, t.valid = $valid " +
+				"MERGE (b)-[:INCLUDES]->(t)",
+			Parameters: txParams,
+		})
+
+		if len(tx.annotations) > 0 {
+			var annotations []map[string]interface{}
+			for k, v := range tx.annotations {
+				annotations = append(annotations, map[string]interface{}{"key": k, "value": v})
+			}
+			sort.Slice(annotations, func(i, j int) bool {
+				return annotations[i]["key"].(string) < annotations[j]["key"].(string)
+			})
+			statements = append(statements, &neo4jStatement{
+				Statement: "MATCH (t:Tx {id: $txID}) UNWIND $annotations AS a " +
+					"MERGE (n:Annotation {key: a.key, value: a.value}) MERGE (t)-[:ANNOTATED]->(n)",
+				Parameters: map[string]interface{}{"txID": tx.txID, "annotations": annotations},
+			})
+		}
+
+		if !tx.valid {
+			continue
+		}
+
+		statements = append(statements, &neo4jStatement{
+			Statement:  "MATCH (t:Tx {id: $txID}) MERGE (u:User {id: $user}) MERGE (u)-[:SUBMITTED]->(t)",
+			Parameters: txParams,
+		})
+
+		if len(tx.reads) > 0 {
+			var reads []map[string]interface{}
+			for _, r := range tx.reads {
+				reads = append(reads, map[string]interface{}{
+					"key":   r.Key,
+					"block": r.Version.GetBlockNum(),
+					"tx":    r.Version.GetTxNum(),
+				})
+			}
+			statements = append(statements, &neo4jStatement{
+				Statement: "MATCH (t:Tx {id: $txID}) UNWIND $values AS v " +
+					"MERGE (n:Value {db: $db, key: v.key, block: v.block, tx: v.tx}) MERGE (t)-[:READS]->(n)",
+				Parameters: map[string]interface{}{"txID": tx.txID, "db": tx.dbName, "values": reads},
+			})
+		}
+
+		// the latest version of a key exported before the transaction is the version that a write replaces, or that a
+		// delete removes
+		latestVersion := "OPTIONAL MATCH (p:Value {db: $db, key: key}) " +
+			"WHERE p.block < $block OR (p.block = $block AND p.tx < $index) " +
+			"WITH t, key, p ORDER BY p.block DESC, p.tx DESC " +
+			"WITH t, key, collect(p)[0] AS latest "
+
+		if len(tx.writes) > 0 {
+			statements = append(statements, &neo4jStatement{
+				Statement: "MATCH (t:Tx {id: $txID}) UNWIND $keys AS key " + latestVersion +
+					"MERGE (n:Value {db: $db, key: key, block: $block, tx: $index}) MERGE (t)-[:WRITES]->(n) " +
+					"FOREACH (p IN CASE WHEN latest IS NULL THEN [] ELSE [latest] END | MERGE (n)-[:PREVIOUS]->(p))",
+				Parameters: map[string]interface{}{"txID": tx.txID, "db": tx.dbName, "block": blockNum, "index": tx.index, "keys": tx.writes},
+			})
+		}
+
+		if len(tx.deletes) > 0 {
+			statements = append(statements, &neo4jStatement{
+				Statement: "MATCH (t:Tx {id: $txID}) UNWIND $keys AS key " + latestVersion +
+					"FOREACH (p IN CASE WHEN latest IS NULL THEN [] ELSE [latest] END | MERGE (t)-[:DELETES]->(p))",
+				Parameters: map[string]interface{}{"txID": tx.txID, "db": tx.dbName, "block": blockNum, "index": tx.index, "keys": tx.deletes},
+			})
+		}
+	}
+
+	return statements
+}
+
+func (e *neo4jExport) send(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, e.commitURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "error while creating the Neo4j request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if e.username != "" {
+		req.SetBasicAuth(e.username, e.password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error while sending the request to Neo4j")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "error while reading the response of Neo4j")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Neo4j responded with status [%s]: %s", resp.Status, string(respBody))
+	}
+
+	neo4jResp := &neo4jResponse{}
+	if err := json.Unmarshal(respBody, neo4jResp); err != nil {
+		return errors.Wrap(err, "error while unmarshaling the response of Neo4j")
+	}
+	if len(neo4jResp.Errors) > 0 {
+		return errors.Errorf("Neo4j failed to execute the statements: %s: %s", neo4jResp.Errors[0].Code, neo4jResp.Errors[0].Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package provenance
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNeo4j records the statements it receives on the transactional endpoint, and fails the requests while
+// failures is positive.
+type fakeNeo4j struct {
+	mutex      sync.Mutex
+	requests   []*neo4jRequest
+	failures   int
+	authHeader string
+}
+
+func (f *fakeNeo4j) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.URL.Path != "/db/lineage/tx/commit" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	f.authHeader = r.Header.Get("Authorization")
+
+	if f.failures > 0 {
+		f.failures--
+		w.Write([]byte(`{"results":[],"errors":[{"code":"Neo.TransientError.General.DatabaseUnavailable","message":"unavailable"}]}`))
+		return
+	}
+
+	req := &neo4jRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.requests = append(f.requests, req)
+	w.Write([]byte(`{"results":[],"errors":[]}`))
+}
+
+func (f *fakeNeo4j) statements() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var stmts []string
+	for _, req := range f.requests {
+		for _, s := range req.Statements {
+			stmts = append(stmts, s.Statement)
+		}
+	}
+	return stmts
+}
+
+func TestNeo4jExport(t *testing.T) {
+	lggr, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	fake := &fakeNeo4j{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	e, err := NewNeo4jExport(&Neo4jConfig{
+		URL:      server.URL,
+		Database: "lineage",
+		Username: "neo4j",
+		Password: "secret",
+	}, lggr)
+	require.NoError(t, err)
+	require.Equal(t, Neo4jExportSubsystemName, e.Name())
+	// Neo4j is not contacted until the first export
+	require.Empty(t, fake.requests)
+
+	block1 := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{Number: 1, Timestamp: 100},
+			ValidationInfo: []*types.ValidationInfo{
+				{Flag: types.Flag_VALID},
+				{Flag: types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE},
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: []*types.DataTxEnvelope{
+					{
+						Payload: &types.DataTx{
+							MustSignUserIds: []string{"user1"},
+							TxId:            "tx1",
+							DbOperations: []*types.DBOperation{
+								{
+									DbName:     "db1",
+									DataWrites: []*types.DataWrite{{Key: "key1", Value: []byte("value1")}},
+								},
+							},
+							Annotations: map[string]string{"purpose": "test"},
+						},
+					},
+					{
+						Payload: &types.DataTx{
+							MustSignUserIds: []string{"user2"},
+							TxId:            "tx2",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	entries, err := e.Entries(block1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	// the entries are derived from the block alone
+	again, err := e.Entries(block1)
+	require.NoError(t, err)
+	require.Equal(t, entries, again)
+
+	require.NoError(t, e.Publish(&outbox.Entry{Subsystem: Neo4jExportSubsystemName, BlockNumber: 1, Payload: entries[0]}))
+	require.True(t, strings.HasPrefix(fake.authHeader, "Basic "))
+	require.Len(t, fake.requests, 2)
+	require.Len(t, fake.requests[0].Statements, len(neo4jSchema))
+
+	exported := fake.requests[1].Statements
+	// block, tx1, its annotations, its submitter, and its writes; tx2 is invalid, so only the tx is exported
+	require.Len(t, exported, 6)
+	require.Contains(t, exported[0].Statement, "MERGE (b:Block")
+	require.Contains(t, exported[4].Statement, "[:WRITES]")
+	require.Contains(t, exported[4].Statement, "[:PREVIOUS]")
+	require.Equal(t, []interface{}{"key1"}, exported[4].Parameters["keys"])
+	require.Equal(t, "tx2", exported[5].Parameters["txID"])
+	require.Equal(t, false, exported[5].Parameters["valid"])
+
+	block2 := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader:     &types.BlockHeaderBase{Number: 2, Timestamp: 200},
+			ValidationInfo: []*types.ValidationInfo{{Flag: types.Flag_VALID}},
+		},
+		Payload: &types.Block_UserAdministrationTxEnvelope{
+			UserAdministrationTxEnvelope: &types.UserAdministrationTxEnvelope{
+				Payload: &types.UserAdministrationTx{
+					UserId:      "admin",
+					TxId:        "tx3",
+					UserReads:   []*types.UserRead{{UserId: "user1", Version: &types.Version{BlockNum: 1, TxNum: 0}}},
+					UserDeletes: []*types.UserDelete{{UserId: "user1"}},
+				},
+			},
+		},
+	}
+
+	entries, err = e.Entries(block2)
+	require.NoError(t, err)
+
+	// a failed export is reported to the outbox, which re-publishes the entry; the commit is not affected
+	fake.mutex.Lock()
+	fake.failures = 1
+	fake.mutex.Unlock()
+	err = e.Publish(&outbox.Entry{Subsystem: Neo4jExportSubsystemName, BlockNumber: 2, Payload: entries[0]})
+	require.EqualError(t, err, "error while exporting the lineage of block [2] to Neo4j: Neo4j failed to execute the statements: "+
+		"Neo.TransientError.General.DatabaseUnavailable: unavailable")
+	require.NoError(t, e.Publish(&outbox.Entry{Subsystem: Neo4jExportSubsystemName, BlockNumber: 2, Payload: entries[0]}))
+
+	require.Len(t, fake.requests, 3)
+	exported = fake.requests[2].Statements
+	require.Len(t, exported, 5)
+	require.Equal(t, "_users", exported[1].Parameters["db"])
+	require.Contains(t, exported[3].Statement, "[:READS]")
+	require.Equal(t, []interface{}{map[string]interface{}{"key": "user1", "block": float64(1), "tx": float64(0)}},
+		exported[3].Parameters["values"])
+	require.Contains(t, exported[4].Statement, "[:DELETES]")
+	require.Equal(t, []interface{}{"user1"}, exported[4].Parameters["keys"])
+
+	_, err = NewNeo4jExport(&Neo4jConfig{}, lggr)
+	require.EqualError(t, err, "the URL of the Neo4j database must be set when the provenance store backend is [neo4j]")
+}
+
+func TestOpenBackend(t *testing.T) {
+	lggr, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	storeDir, err := ioutil.TempDir("", "provenance")
+	require.NoError(t, err)
+	defer os.RemoveAll(storeDir)
+
+	s, err := Open(&Config{StoreDir: storeDir, Logger: lggr})
+	require.NoError(t, err)
+	require.IsType(t, &levelDBStore{}, s)
+	require.NoError(t, s.Close())
+
+	s, err = Open(&Config{StoreDir: storeDir, Backend: "bolt", Logger: lggr})
+	require.EqualError(t, err, "unsupported provenance store backend [bolt]")
+	require.Nil(t, s)
+
+	// the lineage is exported by an outbox subsystem, see NewNeo4jExport
+	s, err = Open(&Config{StoreDir: storeDir, Backend: Neo4jBackend, Logger: lggr})
+	require.NoError(t, err)
+	require.IsType(t, &levelDBStore{}, s)
+	require.NoError(t, s.Close())
+}
@@ -6,7 +6,9 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/storeformat"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/cayleygraph/cayley"
 	"github.com/cayleygraph/cayley/graph"
@@ -31,26 +33,27 @@ var (
 	// detect the partially created store and do cleanup
 	// before creating a new levelDB instance
 	underCreationFlag = "undercreation"
+
+	// format is the on-disk format of the provenance store. A change of the quads of the graph increments the
+	// version, and registers the migration from the previous version.
+	format = &storeformat.Format{
+		Store:   "provenance store",
+		Version: 1,
+	}
 )
 
-// Store holds information about the provenance store, i.e., a
-// graph database
-type Store struct {
+// levelDBStore holds information about the provenance store, i.e., a
+// graph database persisted in leveldb
+type levelDBStore struct {
 	rootDir     string
 	cayleyGraph *cayley.Handle
+	erasure     *erasure.Store
 	mutex       sync.RWMutex
 	logger      *logger.SugarLogger
 }
 
-// Config holds the configuration parameter of the
-// provenance store
-type Config struct {
-	StoreDir string
-	Logger   *logger.SugarLogger
-}
-
-// Open opens a provenance store to maintain historical values of each state
-func Open(conf *Config) (*Store, error) {
+// openLevelDBStore opens a graph database persisted in leveldb to maintain historical values of each state
+func openLevelDBStore(conf *Config) (*levelDBStore, error) {
 	exist, err := fileops.Exists(conf.StoreDir)
 	if err != nil {
 		return nil, err
@@ -71,6 +74,9 @@ func Open(conf *Config) (*Store, error) {
 		return openNewProvenanceStore(conf)
 	}
 
+	if err := format.Upgrade(conf.StoreDir, conf.Logger); err != nil {
+		return nil, err
+	}
 	return openExistingLevelDBInstance(conf)
 }
 
@@ -87,7 +93,7 @@ func isExistingProvenanceStoreCreatedPartially(dbPath string) (bool, error) {
 	return fileops.Exists(filepath.Join(dbPath, underCreationFlag))
 }
 
-func openNewProvenanceStore(c *Config) (*Store, error) {
+func openNewProvenanceStore(c *Config) (*levelDBStore, error) {
 	if err := fileops.CreateDir(c.StoreDir); err != nil {
 		return nil, errors.WithMessagef(err, "failed to create director %s", c.StoreDir)
 	}
@@ -106,32 +112,38 @@ func openNewProvenanceStore(c *Config) (*Store, error) {
 		return nil, err
 	}
 
+	if err := format.Init(c.StoreDir); err != nil {
+		return nil, err
+	}
+
 	if err := fileops.Remove(underCreationFlagPath); err != nil {
 		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
 	}
 
-	return &Store{
+	return &levelDBStore{
 		rootDir:     c.StoreDir,
 		cayleyGraph: cayleyGraph,
+		erasure:     c.Erasure,
 		logger:      c.Logger,
 	}, nil
 }
 
-func openExistingLevelDBInstance(c *Config) (*Store, error) {
+func openExistingLevelDBInstance(c *Config) (*levelDBStore, error) {
 	cayleyGraph, err := cayley.NewGraph(leveldb.Name, c.StoreDir, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Store{
+	return &levelDBStore{
 		rootDir:     c.StoreDir,
 		cayleyGraph: cayleyGraph,
+		erasure:     c.Erasure,
 		logger:      c.Logger,
 	}, nil
 }
 
 // Close closes the database instance by closing all leveldb databases
-func (s *Store) Close() error {
+func (s *levelDBStore) Close() error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
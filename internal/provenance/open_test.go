@@ -21,7 +21,7 @@ import (
 func TestOpenStore(t *testing.T) {
 	t.Parallel()
 
-	assertStore := func(t *testing.T, storeDir string, s *Store) {
+	assertStore := func(t *testing.T, storeDir string, s *levelDBStore) {
 		require.Equal(t, storeDir, s.rootDir)
 		require.NotNil(t, s.cayleyGraph)
 		p := cayley.StartPath(s.cayleyGraph).Out()
@@ -51,7 +51,7 @@ func TestOpenStore(t *testing.T) {
 			StoreDir: storeDir,
 			Logger:   logger,
 		}
-		s, err := Open(c)
+		s, err := openLevelDBStore(c)
 		defer func() {
 			if err := s.Close(); err != nil {
 				t.Errorf("error wile closing the store: %s", err.Error())
@@ -79,7 +79,7 @@ func TestOpenStore(t *testing.T) {
 			StoreDir: storeDir,
 			Logger:   logger,
 		}
-		s, err := Open(c)
+		s, err := openLevelDBStore(c)
 		defer func() {
 			if err := s.Close(); err != nil {
 				t.Errorf("error wile closing the store: %s", err.Error())
@@ -117,7 +117,7 @@ func TestOpenStore(t *testing.T) {
 			StoreDir: storeDir,
 			Logger:   logger,
 		}
-		s, err := Open(c)
+		s, err := openLevelDBStore(c)
 		defer func() {
 			if err := s.Close(); err != nil {
 				t.Errorf("error wile closing the store: %s", err.Error())
@@ -144,7 +144,7 @@ func TestOpenStore(t *testing.T) {
 			StoreDir: storeDir,
 			Logger:   logger,
 		}
-		s, err := Open(c)
+		s, err := openLevelDBStore(c)
 		defer os.RemoveAll(storeDir)
 		require.NoError(t, err)
 		s.Close()
@@ -153,7 +153,7 @@ func TestOpenStore(t *testing.T) {
 
 		// close and reopen the store
 		require.NoError(t, s.Close())
-		s, err = Open(c)
+		s, err = openLevelDBStore(c)
 		defer func() {
 			if err := s.Close(); err != nil {
 				t.Errorf("error wile closing the store: %s", err.Error())
@@ -179,7 +179,7 @@ func TestOpenStore(t *testing.T) {
 			StoreDir: storeDir,
 			Logger:   logger,
 		}
-		s, err := Open(c)
+		s, err := openLevelDBStore(c)
 		defer os.RemoveAll(storeDir)
 		require.NoError(t, err)
 
@@ -191,7 +191,7 @@ func TestOpenStore(t *testing.T) {
 
 		// close and reopen the store
 		require.NoError(t, s.Close())
-		s, err = Open(c)
+		s, err = openLevelDBStore(c)
 		defer func() {
 			if err := s.Close(); err != nil {
 				t.Errorf("error wile closing the store: %s", err.Error())
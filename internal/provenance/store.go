@@ -0,0 +1,97 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package provenance
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/erasure"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LevelDBBackend maintains the provenance graph in an embedded graph database persisted in leveldb.
+	// It is the default backend.
+	LevelDBBackend = "leveldb"
+	// Neo4jBackend maintains the provenance graph in leveldb, as LevelDBBackend does, and exports the
+	// lineage of every committed block to a Neo4j database, where multi-hop lineage queries, e.g.,
+	// value -> tx -> user -> other txs, can be executed efficiently. The export is an outbox subsystem,
+	// see NewNeo4jExport.
+	Neo4jBackend = "neo4j"
+)
+
+// Store maintains the historical values of each state, along with the lineage of the values, i.e., the
+// transactions that read, wrote, and deleted them, and the users who submitted these transactions.
+type Store interface {
+	// Commit commits the provenance data of the transactions of a block
+	Commit(blockNum uint64, blockTime int64, txsData []*TxDataForProvenance) error
+	// GetValues returns all values associated with a given key
+	GetValues(dbName, key string) ([]*types.ValueWithMetadata, error)
+	// GetPreviousValues returns previous values of a given key and a version, up to the limit
+	GetPreviousValues(dbName, key string, version *types.Version, limit int) ([]*types.ValueWithMetadata, error)
+	// GetNextValues returns next values of a given key and a version, up to the limit
+	GetNextValues(dbName, key string, version *types.Version, limit int) ([]*types.ValueWithMetadata, error)
+	// IterateValues calls visit with the values associated with a given key, skipping the first offset values, and up to
+	// the limit, if the limit is positive. The iteration stops at the first error returned by visit.
+	IterateValues(dbName, key string, offset, limit int, visit func(*types.ValueWithMetadata) error) error
+	// IteratePreviousValues calls visit with the previous values of a given key and a version, up to the limit, if the
+	// limit is positive. The iteration stops at the first error returned by visit.
+	IteratePreviousValues(dbName, key string, version *types.Version, limit int, visit func(*types.ValueWithMetadata) error) error
+	// IterateNextValues calls visit with the next values of a given key and a version, up to the limit, if the limit is
+	// positive. The iteration stops at the first error returned by visit.
+	IterateNextValues(dbName, key string, version *types.Version, limit int, visit func(*types.ValueWithMetadata) error) error
+	// GetValueAt returns the value of a given key at a particular version
+	GetValueAt(dbName, key string, version *types.Version) (*types.ValueWithMetadata, error)
+	// GetMostRecentValueAtOrBelow returns the most recent value hold by the given key at or below a given version
+	GetMostRecentValueAtOrBelow(dbName, key string, version *types.Version) (*types.ValueWithMetadata, error)
+	// GetDeletedValues returns all deleted values associated with a given key
+	GetDeletedValues(dbName, key string) ([]*types.ValueWithMetadata, error)
+	// GetValuesReadByUser returns all values read by a given user
+	GetValuesReadByUser(userID string) ([]*KVWithDBName, error)
+	// GetValuesWrittenByUser returns all values written by a given user
+	GetValuesWrittenByUser(userID string) ([]*KVWithDBName, error)
+	// GetValuesDeletedByUser returns all values deleted by a given user
+	GetValuesDeletedByUser(userID string) ([]*KVWithDBName, error)
+	// GetReaders returns all userIDs who have accessed a given key as well as the access frequency
+	GetReaders(dbName, key string) (map[string]uint32, error)
+	// GetWriters returns all userIDs who have modified a given key as well as the modification frequency
+	GetWriters(dbName, key string) (map[string]uint32, error)
+	// GetTxIDsSubmittedByUser returns all ids of all transactions submitted by a given user
+	GetTxIDsSubmittedByUser(userID string) ([]string, error)
+	// GetTxIDLocation returns the location of a given txID, along with the block timestamp
+	GetTxIDLocation(txID string) (*TxIDLocation, error)
+	// GetTxAnnotations returns the annotations of a given txID
+	GetTxAnnotations(txID string) (map[string]string, error)
+	// GetTxIDsByAnnotation returns the ids of all transactions that carry a given annotation. If the submitter is set,
+	// only the transactions it submitted are returned.
+	GetTxIDsByAnnotation(key, value, submitterID string) ([]string, error)
+	// Close closes the store
+	Close() error
+}
+
+// Config holds the configuration parameter of the
+// provenance store
+type Config struct {
+	StoreDir string
+	// Backend selects the backend of the store. If empty, LevelDBBackend is used.
+	Backend string
+	// Erasure seals the values written to the erasable databases. If nil, no value is sealed. The values are never
+	// exported to Neo4j.
+	Erasure *erasure.Store
+	Logger  *logger.SugarLogger
+}
+
+// Open opens a provenance store to maintain historical values of each state
+func Open(conf *Config) (Store, error) {
+	switch conf.Backend {
+	case "", LevelDBBackend, Neo4jBackend:
+		s, err := openLevelDBStore(conf)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+
+	default:
+		return nil, errors.Errorf("unsupported provenance store backend [%s]", conf.Backend)
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"sync"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/redaction"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
 )
 
 func (e *WorldStateJSONQueryExecutor) executeAND(ctx context.Context, dbName string, attrsConds attributeToConditions) (map[string]bool, error) {
@@ -149,6 +153,7 @@ func (e *WorldStateJSONQueryExecutor) executeAllConditions(ctx context.Context,
 }
 
 func (e *WorldStateJSONQueryExecutor) execute(ctx context.Context, dbName string, attribute string, conds *attributeTypeAndConditions) (map[string]bool, error) {
+	scan := newIndexScan(attribute, conds)
 	plan, err := createQueryPlan(attribute, conds)
 	if err != nil {
 		return nil, err
@@ -181,6 +186,7 @@ func (e *WorldStateJSONQueryExecutor) execute(ctx context.Context, dbName string
 			if iter.Error() != nil {
 				return nil, err
 			}
+			scan.EntriesScanned++
 
 			indexEntry := &stateindex.IndexEntry{}
 			if err := indexEntry.Load(iter.Key()); err != nil {
@@ -188,14 +194,18 @@ func (e *WorldStateJSONQueryExecutor) execute(ctx context.Context, dbName string
 			}
 
 			if len(plan.excludeKeys) == 0 {
-				keys[indexEntry.Key] = true
+				if err := e.addIfReadable(keys, indexEntry.Key, iter.Value()); err != nil {
+					return nil, err
+				}
 				continue
 			}
 
 			// we may need to skip entries continously
 			for {
 				if _, ok := plan.excludeKeys[indexEntry.Value]; !ok {
-					keys[indexEntry.Key] = true
+					if err := e.addIfReadable(keys, indexEntry.Key, iter.Value()); err != nil {
+						return nil, err
+					}
 					break
 				}
 
@@ -207,9 +217,11 @@ func (e *WorldStateJSONQueryExecutor) execute(ctx context.Context, dbName string
 				e.logger.Debug("skipping to the next entry of [" + key + "]")
 
 				itemExist := iter.Seek([]byte(key))
+				scan.Seeks++
 				if !itemExist {
 					break
 				}
+				scan.EntriesScanned++
 
 				delete(plan.excludeKeys, indexEntry.Value)
 
@@ -221,5 +233,26 @@ func (e *WorldStateJSONQueryExecutor) execute(ctx context.Context, dbName string
 		}
 	}
 
+	e.planLock.Lock()
+	e.plan.Scans = append(e.plan.Scans, scan)
+	e.planLock.Unlock()
+
 	return keys, nil
 }
+
+// addIfReadable adds the key of an index entry to the keys, unless the access control of the key, which the index entry
+// holds, does not allow the reader of the results to read the key
+func (e *WorldStateJSONQueryExecutor) addIfReadable(keys map[string]bool, key string, entryValue []byte) error {
+	if e.readerID != "" && len(entryValue) > 0 {
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(entryValue, persisted); err != nil {
+			return errors.Wrapf(err, "error while unmarshaling the index entry of key [%s]", key)
+		}
+		if _, canRead := redaction.ReadAccess(persisted.GetMetadata().GetAccessControl(), e.readerID); !canRead {
+			return nil
+		}
+	}
+
+	keys[key] = true
+	return nil
+}
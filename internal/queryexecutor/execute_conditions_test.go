@@ -287,7 +287,7 @@ func TestExecuteAND(t *testing.T) {
 		},
 	}
 
-	snapshots, err := env.db.GetDBsSnapshot([]string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
+	snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
 	require.NoError(t, err)
 	defer snapshots.Release()
 
@@ -457,7 +457,7 @@ func TestExecuteOR(t *testing.T) {
 		},
 	}
 
-	snapshots, err := env.db.GetDBsSnapshot([]string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
+	snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
 	require.NoError(t, err)
 	defer snapshots.Release()
 
@@ -1367,7 +1367,7 @@ func TestExecuteOnly(t *testing.T) {
 		},
 	}
 
-	snapshots, err := env.db.GetDBsSnapshot([]string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
+	snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
 	require.NoError(t, err)
 	defer snapshots.Release()
 
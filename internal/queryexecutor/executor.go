@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
@@ -21,21 +23,81 @@ import (
 type WorldStateJSONQueryExecutor struct {
 	db     worldstate.DBsSnapshot
 	logger *logger.SugarLogger
+	// readerID, if set, is the user who reads the results of the queries, whose access control is checked on the
+	// index entries
+	readerID string
+	// plan is the plan of the last executed query, with the statistics of its index scans
+	plan     *types.QueryPlan
+	planLock sync.Mutex
 }
 
 func NewWorldStateJSONQueryExecutor(db worldstate.DBsSnapshot, l *logger.SugarLogger) *WorldStateJSONQueryExecutor {
 	return &WorldStateJSONQueryExecutor{
 		db:     db,
 		logger: l,
+		plan:   &types.QueryPlan{},
 	}
 }
 
+// FilterReadableBy makes the executor return only the keys that the given user can read, in full or redacted, as the
+// index entries of a key hold its access control. The keys indexed before their index entries held the access control
+// are returned, and their access control must still be checked on their values.
+func (e *WorldStateJSONQueryExecutor) FilterReadableBy(userID string) *WorldStateJSONQueryExecutor {
+	e.readerID = userID
+	return e
+}
+
 func (e *WorldStateJSONQueryExecutor) ExecuteQuery(ctx context.Context, dbName string, selector []byte) (map[string]bool, error) {
+	operator, disectedConditions, err := e.parseQuery(dbName, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	e.plan = &types.QueryPlan{
+		Operator: operator,
+	}
+
+	var keys map[string]bool
+	switch operator {
+	case constants.QueryOpAnd:
+		keys, err = e.executeAND(ctx, dbName, disectedConditions)
+	case constants.QueryOpOr:
+		keys, err = e.executeOR(ctx, dbName, disectedConditions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sortScans(e.plan)
+	return keys, nil
+}
+
+// ExplainQuery returns the plan that ExecuteQuery would execute for the given query, without executing it. The query
+// is validated against the index definition of the database as it is when executed.
+func (e *WorldStateJSONQueryExecutor) ExplainQuery(dbName string, selector []byte) (*types.QueryPlan, error) {
+	operator, disectedConditions, err := e.parseQuery(dbName, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &types.QueryPlan{
+		Operator: operator,
+	}
+	for attr, conds := range disectedConditions {
+		plan.Scans = append(plan.Scans, newIndexScan(attr, conds))
+	}
+
+	sortScans(plan)
+	return plan, nil
+}
+
+// parseQuery decodes a query, and returns its combination operator and its conditions, disected per attribute
+func (e *WorldStateJSONQueryExecutor) parseQuery(dbName string, selector []byte) (string, attributeToConditions, error) {
 	query := make(map[string]interface{})
 	decoder := json.NewDecoder(bytes.NewBuffer(selector))
 	decoder.UseNumber()
 	if err := decoder.Decode(&query); err != nil {
-		return nil, errors.Wrap(err, "error decoding the query")
+		return "", nil, errors.Wrap(err, "error decoding the query")
 	}
 
 	// only the following query semantics are allowed for now
@@ -46,64 +108,87 @@ func (e *WorldStateJSONQueryExecutor) ExecuteQuery(ctx context.Context, dbName s
 	// in the future, we will allow nested "$and", "$or" semantics
 
 	if _, ok := query[constants.QueryFieldSelector]; !ok {
-		return nil, errors.New("selector field is missing in the query")
+		return "", nil, errors.New("selector field is missing in the query")
 	}
 	query, ok := query[constants.QueryFieldSelector].(map[string]interface{})
 	if !ok {
-		return nil, errors.New("query syntax error near " + constants.QueryFieldSelector)
+		return "", nil, errors.New("query syntax error near " + constants.QueryFieldSelector)
 	}
 
 	if len(query) == 0 {
-		return nil, errors.New("query conditions cannot be empty")
+		return "", nil, errors.New("query conditions cannot be empty")
 	}
 
 	_, and := query[constants.QueryOpAnd]
 	_, or := query[constants.QueryOpOr]
 
-	var keys map[string]bool
+	operator := constants.QueryOpAnd
+	conditions := query
 
 	switch {
 	case !and && !or:
 		// default is $and
-		disectedConditions, err := e.validateAndDisectConditions(dbName, query)
-		if err != nil {
-			return nil, err
-		}
-		if keys, err = e.executeAND(ctx, dbName, disectedConditions); err != nil {
-			return nil, err
-		}
 	case and && or:
 		// not supported yet
-		return nil, errors.New("there must be a single upper level combination operator")
+		return "", nil, errors.New("there must be a single upper level combination operator")
 	case and:
-		c, ok := query[constants.QueryOpAnd].(map[string]interface{})
-		if !ok {
-			return nil, errors.New("query syntax error near $and")
-		}
-
-		disectedConditions, err := e.validateAndDisectConditions(dbName, c)
-		if err != nil {
-			return nil, err
-		}
-		if keys, err = e.executeAND(ctx, dbName, disectedConditions); err != nil {
-			return nil, err
+		if conditions, ok = query[constants.QueryOpAnd].(map[string]interface{}); !ok {
+			return "", nil, errors.New("query syntax error near $and")
 		}
 	case or:
-		c, ok := query[constants.QueryOpOr].(map[string]interface{})
-		if !ok {
-			return nil, errors.New("query syntax error near $or")
+		if conditions, ok = query[constants.QueryOpOr].(map[string]interface{}); !ok {
+			return "", nil, errors.New("query syntax error near $or")
 		}
+		operator = constants.QueryOpOr
+	}
 
-		disectedConditions, err := e.validateAndDisectConditions(dbName, c)
-		if err != nil {
-			return nil, err
-		}
-		if keys, err = e.executeOR(ctx, dbName, disectedConditions); err != nil {
-			return nil, err
+	disectedConditions, err := e.validateAndDisectConditions(dbName, conditions)
+	if err != nil {
+		return "", nil, err
+	}
+	return operator, disectedConditions, nil
+}
+
+func sortScans(plan *types.QueryPlan) {
+	sort.Slice(plan.Scans, func(i, j int) bool {
+		return plan.Scans[i].Attribute < plan.Scans[j].Attribute
+	})
+}
+
+// Plan returns the plan of the last executed query, with the number of index entries scanned for every attribute
+func (e *WorldStateJSONQueryExecutor) Plan() *types.QueryPlan {
+	return e.plan
+}
+
+// QueryAttributes returns the attributes that the conditions of a query refer to, sorted by name
+func QueryAttributes(selector []byte) ([]string, error) {
+	query := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewBuffer(selector))
+	decoder.UseNumber()
+	if err := decoder.Decode(&query); err != nil {
+		return nil, errors.Wrap(err, "error decoding the query")
+	}
+
+	conditions, ok := query[constants.QueryFieldSelector].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("query syntax error near " + constants.QueryFieldSelector)
+	}
+	for _, op := range []string{constants.QueryOpAnd, constants.QueryOpOr} {
+		if c, ok := conditions[op]; ok {
+			if conditions, ok = c.(map[string]interface{}); !ok {
+				return nil, errors.New("query syntax error near " + op)
+			}
+			break
 		}
 	}
 
-	return keys, nil
+	attrs := make([]string, 0, len(conditions))
+	for attr := range conditions {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	return attrs, nil
 }
 
 type attributeToConditions map[string]*attributeTypeAndConditions
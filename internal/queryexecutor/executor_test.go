@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
@@ -221,7 +222,7 @@ func TestExecuteJSONQuery(t *testing.T) {
 		},
 	}
 
-	snapshots, err := env.db.GetDBsSnapshot([]string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
+	snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
 	require.NoError(t, err)
 	defer snapshots.Release()
 
@@ -242,6 +243,239 @@ func TestExecuteJSONQuery(t *testing.T) {
 	}
 }
 
+func TestExecuteJSONQueryPlan(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	dbName := "testdb"
+	setupDBForTestingExecutes(t, env.db, dbName)
+
+	snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
+	require.NoError(t, err)
+	defer snapshots.Release()
+
+	tests := []struct {
+		name         string
+		query        []byte
+		expectedPlan *types.QueryPlan
+	}{
+		{
+			name: "and is set",
+			query: []byte(
+				`{
+					"selector": {
+						"attr1": {
+							"$eq": "a"
+						},
+						"attr2": {
+							"$neq": [true]
+						},
+						"attr4": {
+							"$gt": -50,
+							"$lt": 5
+						}
+					}
+				}`,
+			),
+			expectedPlan: &types.QueryPlan{
+				Operator: constants.QueryOpAnd,
+				Scans: []*types.IndexScan{
+					{
+						Attribute:      "attr1",
+						Type:           types.IndexAttributeType_STRING,
+						Kind:           types.IndexScan_POINT,
+						Operators:      []string{constants.QueryOpEqual},
+						EntriesScanned: 3,
+					},
+					{
+						Attribute:      "attr2",
+						Type:           types.IndexAttributeType_BOOLEAN,
+						Kind:           types.IndexScan_FULL,
+						Operators:      []string{constants.QueryOpNotEqual},
+						EntriesScanned: 5,
+						Seeks:          1,
+					},
+					{
+						Attribute:      "attr4",
+						Type:           types.IndexAttributeType_NUMBER,
+						Kind:           types.IndexScan_RANGE,
+						Operators:      []string{constants.QueryOpGreaterThan, constants.QueryOpLesserThan},
+						EntriesScanned: 5,
+					},
+				},
+			},
+		},
+		{
+			name: "or is set",
+			query: []byte(
+				`{
+					"selector": {
+						"$or": {
+							"attr3": {
+								"$gte": "a2"
+							},
+							"attr1": {
+								"$lte": "b",
+								"$neq": ["a"]
+							}
+						}
+					}
+				}`,
+			),
+			expectedPlan: &types.QueryPlan{
+				Operator: constants.QueryOpOr,
+				Scans: []*types.IndexScan{
+					{
+						Attribute:      "attr1",
+						Type:           types.IndexAttributeType_STRING,
+						Kind:           types.IndexScan_RANGE,
+						Operators:      []string{constants.QueryOpLesserThanOrEqual, constants.QueryOpNotEqual},
+						EntriesScanned: 3,
+						Seeks:          1,
+					},
+					{
+						Attribute:      "attr3",
+						Type:           types.IndexAttributeType_STRING,
+						Kind:           types.IndexScan_RANGE,
+						Operators:      []string{constants.QueryOpGreaterThanOrEqual},
+						EntriesScanned: 3,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			qExecutor := NewWorldStateJSONQueryExecutor(snapshots, env.l)
+			_, err := qExecutor.ExecuteQuery(context.Background(), dbName, tt.query)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedPlan, qExecutor.Plan()), "expected: %v, actual: %v", tt.expectedPlan, qExecutor.Plan())
+		})
+	}
+}
+
+func TestExplainJSONQuery(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	dbName := "testdb"
+	setupDBForTestingExecutes(t, env.db, dbName)
+
+	snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName})
+	require.NoError(t, err)
+	defer snapshots.Release()
+
+	tests := []struct {
+		name         string
+		query        []byte
+		expectedPlan *types.QueryPlan
+		expectedErr  string
+	}{
+		{
+			name: "and is set",
+			query: []byte(
+				`{
+					"selector": {
+						"$and": {
+							"attr4": {
+								"$gte": -50,
+								"$neq": [0]
+							},
+							"attr2": {
+								"$eq": true
+							}
+						}
+					}
+				}`,
+			),
+			expectedPlan: &types.QueryPlan{
+				Operator: constants.QueryOpAnd,
+				Scans: []*types.IndexScan{
+					{
+						Attribute: "attr2",
+						Type:      types.IndexAttributeType_BOOLEAN,
+						Kind:      types.IndexScan_POINT,
+						Operators: []string{constants.QueryOpEqual},
+					},
+					{
+						Attribute: "attr4",
+						Type:      types.IndexAttributeType_NUMBER,
+						Kind:      types.IndexScan_RANGE,
+						Operators: []string{constants.QueryOpGreaterThanOrEqual, constants.QueryOpNotEqual},
+					},
+				},
+			},
+		},
+		{
+			name: "or is set",
+			query: []byte(
+				`{
+					"selector": {
+						"$or": {
+							"attr1": {
+								"$neq": ["a", "b"]
+							}
+						}
+					}
+				}`,
+			),
+			expectedPlan: &types.QueryPlan{
+				Operator: constants.QueryOpOr,
+				Scans: []*types.IndexScan{
+					{
+						Attribute: "attr1",
+						Type:      types.IndexAttributeType_STRING,
+						Kind:      types.IndexScan_FULL,
+						Operators: []string{constants.QueryOpNotEqual},
+					},
+				},
+			},
+		},
+		{
+			name: "attribute is not indexed",
+			query: []byte(
+				`{
+					"selector": {
+						"attr5": {
+							"$eq": "a"
+						}
+					}
+				}`,
+			),
+			expectedErr: "attribute [attr5] given in the query condition is not indexed",
+		},
+		{
+			name: "both and and or are set",
+			query: []byte(
+				`{
+					"selector": {
+						"$and": {},
+						"$or": {}
+					}
+				}`,
+			),
+			expectedErr: "there must be a single upper level combination operator",
+		},
+	}
+
+	qExecutor := NewWorldStateJSONQueryExecutor(snapshots, env.l)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := qExecutor.ExplainQuery(dbName, tt.query)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				require.Nil(t, plan)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedPlan, plan), "expected: %v, actual: %v", tt.expectedPlan, plan)
+		})
+	}
+}
+
 func TestExecuteJSONQueryErrorCases(t *testing.T) {
 	env := newTestEnv(t)
 	defer env.cleanup()
@@ -430,7 +664,7 @@ func TestExecuteJSONQueryErrorCases(t *testing.T) {
 		},
 	}
 
-	snapshots, err := env.db.GetDBsSnapshot([]string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
+	snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
 	require.NoError(t, err)
 	defer snapshots.Release()
 
@@ -741,7 +975,7 @@ func TestValidateAndDisectConditions(t *testing.T) {
 
 			tt.setup(t, env.db)
 
-			snapshots, err := env.db.GetDBsSnapshot([]string{worldstate.DatabasesDBName, stateindex.IndexDB(tt.dbName)})
+			snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(tt.dbName)})
 			require.NoError(t, err)
 			defer snapshots.Release()
 			qExecutor := NewWorldStateJSONQueryExecutor(snapshots, env.l)
@@ -967,7 +1201,7 @@ func TestValidateAndDisectConditionsErrorCases(t *testing.T) {
 
 			tt.setup(t, env.db)
 
-			snapshots, err := env.db.GetDBsSnapshot([]string{worldstate.DatabasesDBName, stateindex.IndexDB(tt.dbName)})
+			snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(tt.dbName)})
 			require.NoError(t, err)
 			defer snapshots.Release()
 
@@ -1102,3 +1336,162 @@ func TestValidateAttrConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedAttrs []string
+		expectedErr   string
+	}{
+		{
+			name:          "conditions on a single attribute",
+			query:         `{"selector":{"age":{"$gt":20}}}`,
+			expectedAttrs: []string{"age"},
+		},
+		{
+			name:          "$and of conditions",
+			query:         `{"selector":{"$and":{"name":{"$eq":"a"},"age":{"$gt":20}}}}`,
+			expectedAttrs: []string{"age", "name"},
+		},
+		{
+			name:          "$or of conditions",
+			query:         `{"selector":{"$or":{"salary":{"$lt":10},"name":{"$neq":["b"]}}}}`,
+			expectedAttrs: []string{"name", "salary"},
+		},
+		{
+			name:        "not JSON",
+			query:       `selector`,
+			expectedErr: "error decoding the query",
+		},
+		{
+			name:        "no selector",
+			query:       `{"age":{"$gt":20}}`,
+			expectedErr: "query syntax error near selector",
+		},
+		{
+			name:        "malformed $and",
+			query:       `{"selector":{"$and":["age"]}}`,
+			expectedErr: "query syntax error near $and",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attrs, err := QueryAttributes([]byte(tt.query))
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedAttrs, attrs)
+		})
+	}
+}
+
+func TestExecuteJSONQueryFilterReadableBy(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	dbName := "acldb"
+	indexDef, err := json.Marshal(map[string]types.IndexAttributeType{"color": types.IndexAttributeType_STRING})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: dbName, Value: indexDef},
+				{Key: stateindex.IndexDB(dbName)},
+			},
+		},
+	}, 1))
+
+	// commit writes the values and their index entries, as the committer does
+	commit := func(blockNum uint64, writes ...*worldstate.KVWithMetadata) {
+		dbsUpdates := map[string]*worldstate.DBUpdates{
+			dbName: {Writes: writes},
+		}
+		indexUpdates, err := stateindex.ConstructIndexEntries(dbsUpdates, env.db)
+		require.NoError(t, err)
+		for indexDB, updates := range indexUpdates {
+			dbsUpdates[indexDB] = updates
+		}
+		require.NoError(t, env.db.Commit(dbsUpdates, blockNum))
+	}
+	red := []byte(`{"color":"red"}`)
+	commit(2,
+		&worldstate.KVWithMetadata{Key: "open", Value: red},
+		&worldstate.KVWithMetadata{Key: "blue", Value: []byte(`{"color":"blue"}`)},
+		&worldstate.KVWithMetadata{Key: "alice", Value: red, Metadata: &types.Metadata{
+			AccessControl: &types.AccessControl{ReadUsers: map[string]bool{"alice": true}},
+		}},
+		&worldstate.KVWithMetadata{Key: "bob", Value: red, Metadata: &types.Metadata{
+			AccessControl: &types.AccessControl{ReadWriteUsers: map[string]bool{"bob": true}},
+		}},
+		&worldstate.KVWithMetadata{Key: "redacted", Value: red, Metadata: &types.Metadata{
+			AccessControl: &types.AccessControl{
+				ReadWriteUsers:    map[string]bool{"bob": true},
+				RedactedReadUsers: map[string]*types.RedactionPolicy{"alice": {StrippedFields: []string{"color"}}},
+			},
+		}},
+	)
+
+	// an index entry written before the index entries held the access control is not filtered
+	legacyEntry, err := (&stateindex.IndexEntry{
+		Attribute:     "color",
+		Type:          types.IndexAttributeType_STRING,
+		ValuePosition: stateindex.Existing,
+		Value:         "red",
+		KeyPosition:   stateindex.Existing,
+		Key:           "legacy",
+	}).String()
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		dbName: {
+			Writes: []*worldstate.KVWithMetadata{{Key: "legacy", Value: red, Metadata: &types.Metadata{
+				AccessControl: &types.AccessControl{ReadUsers: map[string]bool{"bob": true}},
+			}}},
+		},
+		stateindex.IndexDB(dbName): {
+			Writes: []*worldstate.KVWithMetadata{{Key: legacyEntry}},
+		},
+	}, 3))
+
+	execute := func(readerID string, query string) map[string]bool {
+		snapshots, err := env.db.GetDBsSnapshot("test", []string{worldstate.DatabasesDBName, stateindex.IndexDB(dbName)})
+		require.NoError(t, err)
+		defer snapshots.Release()
+
+		e := NewWorldStateJSONQueryExecutor(snapshots, env.l)
+		if readerID != "" {
+			e.FilterReadableBy(readerID)
+		}
+		keys, err := e.ExecuteQuery(context.Background(), dbName, []byte(query))
+		require.NoError(t, err)
+		return keys
+	}
+	isRed := `{"selector":{"color":{"$eq":"red"}}}`
+	isNotBlue := `{"selector":{"color":{"$neq":["blue"]}}}`
+
+	for _, query := range []string{isRed, isNotBlue} {
+		require.Equal(t, map[string]bool{"open": true, "alice": true, "bob": true, "redacted": true, "legacy": true}, execute("", query))
+		require.Equal(t, map[string]bool{"open": true, "alice": true, "redacted": true, "legacy": true}, execute("alice", query))
+		require.Equal(t, map[string]bool{"open": true, "bob": true, "redacted": true, "legacy": true}, execute("bob", query))
+		require.Equal(t, map[string]bool{"open": true, "legacy": true}, execute("carol", query))
+	}
+
+	// an update of the access control alone updates the index entries
+	commit(4,
+		&worldstate.KVWithMetadata{Key: "alice", Value: red, Metadata: &types.Metadata{
+			AccessControl: &types.AccessControl{ReadUsers: map[string]bool{"carol": true}},
+		}},
+		&worldstate.KVWithMetadata{Key: "bob", Value: red},
+	)
+	require.Equal(t, map[string]bool{"open": true, "bob": true, "redacted": true, "legacy": true}, execute("alice", isRed))
+	require.Equal(t, map[string]bool{"open": true, "alice": true, "bob": true, "legacy": true}, execute("carol", isRed))
+}
@@ -0,0 +1,76 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package queryexecutor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// IndexUsage tracks the number of executed queries that scanned the index of every attribute of every database, and
+// the time the index was last scanned. As every index slows down the commit of the writes to its database, the indexes
+// that no query scans are candidates to be dropped. The usage is held in memory, and is lost when the node restarts.
+type IndexUsage struct {
+	lock  sync.Mutex
+	usage map[string]map[string]*attributeUsage
+	since time.Time
+	nowFn func() time.Time
+}
+
+type attributeUsage struct {
+	hits     uint64
+	lastUsed time.Time
+}
+
+// NewIndexUsage returns an index usage tracker, which tracks the usage from now on
+func NewIndexUsage() *IndexUsage {
+	return &IndexUsage{
+		usage: make(map[string]map[string]*attributeUsage),
+		since: time.Now(),
+		nowFn: time.Now,
+	}
+}
+
+// Record records the index scans of the plan of a query executed on a database
+func (u *IndexUsage) Record(dbName string, plan *types.QueryPlan) {
+	now := u.nowFn()
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	attrs, ok := u.usage[dbName]
+	if !ok {
+		attrs = make(map[string]*attributeUsage)
+		u.usage[dbName] = attrs
+	}
+	for _, scan := range plan.GetScans() {
+		a, ok := attrs[scan.Attribute]
+		if !ok {
+			a = &attributeUsage{}
+			attrs[scan.Attribute] = a
+		}
+		a.hits++
+		a.lastUsed = now
+	}
+}
+
+// Get returns the number of queries that scanned the index of an attribute of a database, and the time it was last
+// scanned, which is the zero time if no query scanned it
+func (u *IndexUsage) Get(dbName, attribute string) (uint64, time.Time) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	a, ok := u.usage[dbName][attribute]
+	if !ok {
+		return 0, time.Time{}
+	}
+	return a.hits, a.lastUsed
+}
+
+// Since returns the time from which the usage is tracked
+func (u *IndexUsage) Since() time.Time {
+	return u.since
+}
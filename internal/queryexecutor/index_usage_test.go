@@ -0,0 +1,49 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package queryexecutor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexUsage(t *testing.T) {
+	u := NewIndexUsage()
+	now := time.Unix(1000, 0)
+	u.nowFn = func() time.Time { return now }
+
+	hits, lastUsed := u.Get("db1", "attr1")
+	require.Equal(t, uint64(0), hits)
+	require.True(t, lastUsed.IsZero())
+
+	plan := &types.QueryPlan{
+		Scans: []*types.IndexScan{
+			{Attribute: "attr1"},
+			{Attribute: "attr2"},
+		},
+	}
+	u.Record("db1", plan)
+	now = now.Add(time.Second)
+	u.Record("db1", &types.QueryPlan{Scans: []*types.IndexScan{{Attribute: "attr1"}}})
+	u.Record("db2", plan)
+
+	hits, lastUsed = u.Get("db1", "attr1")
+	require.Equal(t, uint64(2), hits)
+	require.Equal(t, time.Unix(1001, 0), lastUsed)
+
+	hits, lastUsed = u.Get("db1", "attr2")
+	require.Equal(t, uint64(1), hits)
+	require.Equal(t, time.Unix(1000, 0), lastUsed)
+
+	hits, lastUsed = u.Get("db2", "attr2")
+	require.Equal(t, uint64(1), hits)
+	require.Equal(t, time.Unix(1001, 0), lastUsed)
+
+	hits, _ = u.Get("db2", "attr3")
+	require.Equal(t, uint64(0), hits)
+	require.False(t, u.Since().IsZero())
+}
@@ -1,6 +1,8 @@
 package queryexecutor
 
 import (
+	"sort"
+
 	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -16,6 +18,31 @@ type toSeek interface {
 	Seek(key []byte) bool
 }
 
+// newIndexScan returns the scan of the index of an attribute that executes the given conditions. It must be called
+// before the range query plan of the conditions is created, as the latter consumes the $neq condition.
+func newIndexScan(attribute string, conds *attributeTypeAndConditions) *types.IndexScan {
+	scan := &types.IndexScan{
+		Attribute: attribute,
+		Type:      conds.valueType,
+		Kind:      types.IndexScan_RANGE,
+	}
+	for c := range conds.conditions {
+		scan.Operators = append(scan.Operators, c)
+	}
+	sort.Strings(scan.Operators)
+
+	_, eq := conds.conditions[constants.QueryOpEqual]
+	_, neq := conds.conditions[constants.QueryOpNotEqual]
+	switch {
+	case eq:
+		scan.Kind = types.IndexScan_POINT
+	case neq && len(conds.conditions) == 1:
+		scan.Kind = types.IndexScan_FULL
+	}
+
+	return scan
+}
+
 func createQueryPlan(attribute string, conds *attributeTypeAndConditions) (*rangeQueryPlan, error) {
 	// we assume this function to get only valid conditions
 	//   - eq and no other conditions
@@ -0,0 +1,347 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package queryexecutor
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/pkg/errors"
+)
+
+// SQLQuery is a read-only query of the SQL dialect, compiled to a JSON query on the indexed attributes of a database.
+// The dialect supports a single form of statement:
+//
+//	SELECT * FROM <db> WHERE <attr> <op> <value> [AND|OR <attr> <op> <value> ...] [ORDER BY <attr> [ASC|DESC]] [LIMIT <n>]
+//
+// where <op> is one of =, !=, <>, <, <=, > and >=, and <value> is an integer, a single-quoted string, TRUE or FALSE.
+// As in the JSON query, the predicates are combined either all with AND or all with OR, and refer to indexed
+// attributes only. The keywords are case-insensitive, and a name that is not a plain identifier, such as an attribute
+// named as a keyword, can be double-quoted.
+type SQLQuery struct {
+	// DBName is the database the query reads
+	DBName string
+	// Selector is the JSON query the predicates are compiled to
+	Selector []byte
+	// OrderBy is the attribute the results are ordered by, or empty if the results are ordered by key
+	OrderBy string
+	// Descending orders the results in descending order
+	Descending bool
+	// Limit bounds the number of results, or is 0 if the number of results is not bounded
+	Limit uint64
+}
+
+var sqlOperators = map[string]string{
+	"=":  constants.QueryOpEqual,
+	"!=": constants.QueryOpNotEqual,
+	"<>": constants.QueryOpNotEqual,
+	"<":  constants.QueryOpLesserThan,
+	"<=": constants.QueryOpLesserThanOrEqual,
+	">":  constants.QueryOpGreaterThan,
+	">=": constants.QueryOpGreaterThanOrEqual,
+}
+
+// CompileSQL parses a query of the SQL dialect and compiles its predicates to a JSON query
+func CompileSQL(sql string) (*SQLQuery, error) {
+	tokens, err := tokenizeSQL(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &sqlParser{tokens: tokens}
+	q, err := p.parse()
+	if err != nil {
+		return nil, errors.WithMessage(err, "query syntax error")
+	}
+	return q, nil
+}
+
+type sqlTokenKind int
+
+const (
+	sqlIdent sqlTokenKind = iota
+	sqlQuotedIdent
+	sqlString
+	sqlNumber
+	sqlSymbol
+	sqlEOF
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+func (t sqlToken) String() string {
+	if t.kind == sqlEOF {
+		return "the end of the query"
+	}
+	return "[" + t.text + "]"
+}
+
+// isKeyword returns true if the token is the given keyword. A quoted identifier is never a keyword.
+func (t sqlToken) isKeyword(keyword string) bool {
+	return t.kind == sqlIdent && strings.EqualFold(t.text, keyword)
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isIdentPart allows the characters of a hierarchical database name, such as org1/app-2/db.v1, in an identifier
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || r == '-' || r == '.' || r == '/'
+}
+
+func tokenizeSQL(sql string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	runes := []rune(sql)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case isIdentStart(r):
+			start := i
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlIdent, text: string(runes[start:i])})
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlNumber, text: string(runes[start:i])})
+
+		case r == '\'' || r == '"':
+			// a quote is escaped by doubling it
+			var text strings.Builder
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						text.WriteRune(r)
+						i++
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				text.WriteRune(runes[i])
+			}
+			if !closed {
+				return nil, errors.Errorf("query syntax error: unterminated quote [%c]", r)
+			}
+
+			kind := sqlString
+			if r == '"' {
+				kind = sqlQuotedIdent
+			}
+			tokens = append(tokens, sqlToken{kind: kind, text: text.String()})
+
+		case strings.ContainsRune("!<>", r) && i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')):
+			tokens = append(tokens, sqlToken{kind: sqlSymbol, text: string(runes[i : i+2])})
+			i += 2
+
+		case strings.ContainsRune("*=<>;", r):
+			tokens = append(tokens, sqlToken{kind: sqlSymbol, text: string(r)})
+			i++
+
+		default:
+			return nil, errors.Errorf("query syntax error: unexpected character [%c]", r)
+		}
+	}
+
+	return append(tokens, sqlToken{kind: sqlEOF}), nil
+}
+
+type sqlParser struct {
+	tokens []sqlToken
+	pos    int
+}
+
+func (p *sqlParser) peek() sqlToken {
+	return p.tokens[p.pos]
+}
+
+func (p *sqlParser) next() sqlToken {
+	t := p.tokens[p.pos]
+	if t.kind != sqlEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *sqlParser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.isKeyword(keyword) {
+		return errors.Errorf("expected [%s] but found %s", keyword, t)
+	}
+	return nil
+}
+
+func (p *sqlParser) expectName(what string) (string, error) {
+	t := p.next()
+	if (t.kind != sqlIdent && t.kind != sqlQuotedIdent) || t.text == "" {
+		return "", errors.Errorf("expected %s but found %s", what, t)
+	}
+	return t.text, nil
+}
+
+func (p *sqlParser) parse() (*SQLQuery, error) {
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+	if t := p.next(); t.kind != sqlSymbol || t.text != "*" {
+		return nil, errors.Errorf("only [*] can be selected but found %s", t)
+	}
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+
+	q := &SQLQuery{}
+	var err error
+	if q.DBName, err = p.expectName("a database name"); err != nil {
+		return nil, err
+	}
+
+	if err := p.expectKeyword("WHERE"); err != nil {
+		return nil, err
+	}
+	if q.Selector, err = p.parsePredicates(); err != nil {
+		return nil, err
+	}
+
+	if p.peek().isKeyword("ORDER") {
+		p.next()
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		if q.OrderBy, err = p.expectName("an attribute"); err != nil {
+			return nil, err
+		}
+		switch {
+		case p.peek().isKeyword("ASC"):
+			p.next()
+		case p.peek().isKeyword("DESC"):
+			p.next()
+			q.Descending = true
+		}
+	}
+
+	if p.peek().isKeyword("LIMIT") {
+		p.next()
+		t := p.next()
+		limit, err := strconv.ParseUint(t.text, 10, 64)
+		if t.kind != sqlNumber || err != nil || limit == 0 {
+			return nil, errors.Errorf("the limit must be a positive integer but found %s", t)
+		}
+		q.Limit = limit
+	}
+
+	if t := p.peek(); t.kind == sqlSymbol && t.text == ";" {
+		p.next()
+	}
+	if t := p.next(); t.kind != sqlEOF {
+		return nil, errors.Errorf("unexpected %s", t)
+	}
+
+	return q, nil
+}
+
+// parsePredicates parses the predicates of the WHERE clause into the selector of a JSON query. As the conditions on an
+// attribute are all met by a matching value, an attribute can be compared more than once only if the predicates are
+// combined with AND.
+func (p *sqlParser) parsePredicates() ([]byte, error) {
+	conditions := make(map[string]interface{})
+	combination := ""
+	repeatedAttr := ""
+
+	for {
+		attr, opr, value, err := p.parsePredicate()
+		if err != nil {
+			return nil, err
+		}
+
+		attrConds, ok := conditions[attr].(map[string]interface{})
+		if !ok {
+			attrConds = make(map[string]interface{})
+			conditions[attr] = attrConds
+		} else if repeatedAttr == "" {
+			repeatedAttr = attr
+		}
+		if opr == constants.QueryOpNotEqual {
+			values, _ := attrConds[opr].([]interface{})
+			attrConds[opr] = append(values, value)
+		} else {
+			if _, ok := attrConds[opr]; ok {
+				return nil, errors.Errorf("the attribute [%s] has more than one [%s] condition", attr, opr)
+			}
+			attrConds[opr] = value
+		}
+
+		var op string
+		switch t := p.peek(); {
+		case t.isKeyword("AND"):
+			op = constants.QueryOpAnd
+		case t.isKeyword("OR"):
+			op = constants.QueryOpOr
+		default:
+			if combination == "" {
+				combination = constants.QueryOpAnd
+			}
+			if combination == constants.QueryOpOr && repeatedAttr != "" {
+				return nil, errors.Errorf("the attribute [%s] can be compared only once when the predicates are combined with OR", repeatedAttr)
+			}
+			return json.Marshal(map[string]interface{}{
+				constants.QueryFieldSelector: map[string]interface{}{
+					combination: conditions,
+				},
+			})
+		}
+		if combination != "" && combination != op {
+			return nil, errors.New("the predicates must be combined either all with AND or all with OR")
+		}
+		combination = op
+		p.next()
+	}
+}
+
+func (p *sqlParser) parsePredicate() (string, string, interface{}, error) {
+	attr, err := p.expectName("an attribute")
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	t := p.next()
+	opr, ok := sqlOperators[t.text]
+	if t.kind != sqlSymbol || !ok {
+		return "", "", nil, errors.Errorf("expected a comparison operator after the attribute [%s] but found %s", attr, t)
+	}
+
+	t = p.next()
+	switch {
+	case t.kind == sqlString:
+		return attr, opr, t.text, nil
+	case t.kind == sqlNumber:
+		if _, err := strconv.ParseInt(t.text, 10, 64); err != nil {
+			return "", "", nil, errors.Errorf("the number %s compared with the attribute [%s] is out of range", t, attr)
+		}
+		return attr, opr, json.Number(t.text), nil
+	case t.isKeyword("TRUE"):
+		return attr, opr, true, nil
+	case t.isKeyword("FALSE"):
+		return attr, opr, false, nil
+	default:
+		return "", "", nil, errors.Errorf("expected a value to compare with the attribute [%s] but found %s", attr, t)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package queryexecutor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileSQL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		sql              string
+		expectedDBName   string
+		expectedSelector string
+		expectedOrderBy  string
+		expectedDesc     bool
+		expectedLimit    uint64
+	}{
+		{
+			name:             "single predicate",
+			sql:              "SELECT * FROM db1 WHERE attr1 = 'a'",
+			expectedDBName:   "db1",
+			expectedSelector: `{"selector":{"$and":{"attr1":{"$eq":"a"}}}}`,
+		},
+		{
+			name:             "keywords are case-insensitive",
+			sql:              "select * from db1 where attr1 = 'a' and attr2 = TRUE order by attr3 desc limit 5;",
+			expectedDBName:   "db1",
+			expectedSelector: `{"selector":{"$and":{"attr1":{"$eq":"a"},"attr2":{"$eq":true}}}}`,
+			expectedOrderBy:  "attr3",
+			expectedDesc:     true,
+			expectedLimit:    5,
+		},
+		{
+			name:             "range on a single attribute",
+			sql:              "SELECT * FROM db1 WHERE attr4 > -10 AND attr4 <= 20 ORDER BY attr4 ASC",
+			expectedDBName:   "db1",
+			expectedSelector: `{"selector":{"$and":{"attr4":{"$gt":-10,"$lte":20}}}}`,
+			expectedOrderBy:  "attr4",
+		},
+		{
+			name:             "not equal values are collected",
+			sql:              "SELECT * FROM db1 WHERE attr1 != 'a' AND attr1 <> 'b' AND attr2 >= 'c' AND attr2 < 'd'",
+			expectedDBName:   "db1",
+			expectedSelector: `{"selector":{"$and":{"attr1":{"$neq":["a","b"]},"attr2":{"$gte":"c","$lt":"d"}}}}`,
+		},
+		{
+			name:             "predicates combined with OR",
+			sql:              "SELECT * FROM db1 WHERE attr1 = 'a' OR attr2 = false LIMIT 10",
+			expectedDBName:   "db1",
+			expectedSelector: `{"selector":{"$or":{"attr1":{"$eq":"a"},"attr2":{"$eq":false}}}}`,
+			expectedLimit:    10,
+		},
+		{
+			name:             "hierarchical database name and quoted names",
+			sql:              `SELECT * FROM org1/app-2/db.v1 WHERE "order" = 'it''s' ORDER BY "limit"`,
+			expectedDBName:   "org1/app-2/db.v1",
+			expectedSelector: `{"selector":{"$and":{"order":{"$eq":"it's"}}}}`,
+			expectedOrderBy:  "limit",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := CompileSQL(tt.sql)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedDBName, q.DBName)
+			require.JSONEq(t, tt.expectedSelector, string(q.Selector))
+			require.Equal(t, tt.expectedOrderBy, q.OrderBy)
+			require.Equal(t, tt.expectedDesc, q.Descending)
+			require.Equal(t, tt.expectedLimit, q.Limit)
+		})
+	}
+}
+
+func TestCompileSQLErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		sql         string
+		expectedErr string
+	}{
+		{
+			name:        "empty query",
+			sql:         "",
+			expectedErr: "query syntax error: expected [SELECT] but found the end of the query",
+		},
+		{
+			name:        "projection",
+			sql:         "SELECT attr1 FROM db1 WHERE attr1 = 'a'",
+			expectedErr: "query syntax error: only [*] can be selected but found [attr1]",
+		},
+		{
+			name:        "missing database",
+			sql:         "SELECT * FROM WHERE attr1 = 'a'",
+			expectedErr: "query syntax error: expected [WHERE] but found [attr1]",
+		},
+		{
+			name:        "missing where clause",
+			sql:         "SELECT * FROM db1",
+			expectedErr: "query syntax error: expected [WHERE] but found the end of the query",
+		},
+		{
+			name:        "missing operator",
+			sql:         "SELECT * FROM db1 WHERE attr1 'a'",
+			expectedErr: "query syntax error: expected a comparison operator after the attribute [attr1] but found [a]",
+		},
+		{
+			name:        "missing value",
+			sql:         "SELECT * FROM db1 WHERE attr1 = attr2",
+			expectedErr: "query syntax error: expected a value to compare with the attribute [attr1] but found [attr2]",
+		},
+		{
+			name:        "number out of range",
+			sql:         "SELECT * FROM db1 WHERE attr1 = 99999999999999999999",
+			expectedErr: "query syntax error: the number [99999999999999999999] compared with the attribute [attr1] is out of range",
+		},
+		{
+			name:        "mixed combination operators",
+			sql:         "SELECT * FROM db1 WHERE attr1 = 'a' AND attr2 = 'b' OR attr3 = 'c'",
+			expectedErr: "query syntax error: the predicates must be combined either all with AND or all with OR",
+		},
+		{
+			name:        "repeated attribute with OR",
+			sql:         "SELECT * FROM db1 WHERE attr1 < 'a' OR attr1 > 'z'",
+			expectedErr: "query syntax error: the attribute [attr1] can be compared only once when the predicates are combined with OR",
+		},
+		{
+			name:        "repeated condition",
+			sql:         "SELECT * FROM db1 WHERE attr1 > 'a' AND attr1 > 'b'",
+			expectedErr: "query syntax error: the attribute [attr1] has more than one [$gt] condition",
+		},
+		{
+			name:        "missing order attribute",
+			sql:         "SELECT * FROM db1 WHERE attr1 = 'a' ORDER BY",
+			expectedErr: "query syntax error: expected an attribute but found the end of the query",
+		},
+		{
+			name:        "zero limit",
+			sql:         "SELECT * FROM db1 WHERE attr1 = 'a' LIMIT 0",
+			expectedErr: "query syntax error: the limit must be a positive integer but found [0]",
+		},
+		{
+			name:        "negative limit",
+			sql:         "SELECT * FROM db1 WHERE attr1 = 'a' LIMIT -1",
+			expectedErr: "query syntax error: the limit must be a positive integer but found [-1]",
+		},
+		{
+			name:        "trailing tokens",
+			sql:         "SELECT * FROM db1 WHERE attr1 = 'a' LIMIT 1 OFFSET 2",
+			expectedErr: "query syntax error: unexpected [OFFSET]",
+		},
+		{
+			name:        "unterminated string",
+			sql:         "SELECT * FROM db1 WHERE attr1 = 'a",
+			expectedErr: "query syntax error: unterminated quote [']",
+		},
+		{
+			name:        "unexpected character",
+			sql:         "SELECT * FROM db1 WHERE attr1 = ('a')",
+			expectedErr: "query syntax error: unexpected character [(]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := CompileSQL(tt.sql)
+			require.EqualError(t, err, tt.expectedErr)
+			require.Nil(t, q)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package queue_test
 import (
 	"sync"
 	"testing"
+	"time"
 
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
@@ -152,6 +153,41 @@ func TestOneQueueBarrier_Dequeue(t *testing.T) {
 	})
 }
 
+func TestOneQueueBarrier_DequeueWithWaitLimit(t *testing.T) {
+	t.Run("returns nil when nothing is enqueued in time", func(t *testing.T) {
+		qb := queue.NewOneQueueBarrier(testLogger(t, "debug"))
+		defer qb.Close()
+
+		entry, err := qb.DequeueWithWaitLimit(10 * time.Millisecond)
+		require.NoError(t, err)
+		require.Nil(t, entry)
+	})
+
+	t.Run("Enqueue releases consuming go-routine, reply releases producing", func(t *testing.T) {
+		qb := queue.NewOneQueueBarrier(testLogger(t, "debug"))
+		defer qb.Close()
+
+		wgP := &sync.WaitGroup{}
+		wgP.Add(1)
+		go testEnqueueFunc(t, qb, wgP, 1, 2, nil)
+
+		entry, err := qb.DequeueWithWaitLimit(time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, 1, entry)
+		require.NoError(t, qb.Reply(2))
+		wgP.Wait()
+	})
+
+	t.Run("Close releases consuming go-routine", func(t *testing.T) {
+		qb := queue.NewOneQueueBarrier(testLogger(t, "debug"))
+		require.NoError(t, qb.Close())
+
+		entry, err := qb.DequeueWithWaitLimit(time.Minute)
+		require.EqualError(t, err, "closed")
+		require.Nil(t, entry)
+	})
+}
+
 func testEnqueueFunc(t *testing.T, qb *queue.OneQueueBarrier, wg *sync.WaitGroup, entry interface{}, expectReply interface{}, expectErr error) {
 	reply, err := qb.EnqueueWait(entry)
 	if expectErr == nil {
@@ -5,6 +5,7 @@ package queue
 
 import (
 	"sync"
+	"time"
 
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
@@ -69,6 +70,24 @@ func (qb *OneQueueBarrier) Dequeue() (interface{}, error) {
 	}
 }
 
+// DequeueWithWaitLimit waits at most d for an entry to be consumed, and returns a nil entry if none is enqueued in
+// time. After consuming the entry is done, the producing go-routine must be release by invoking Reply().
+// An error is returned if the OneQueueBarrier was closed.
+func (qb *OneQueueBarrier) DequeueWithWaitLimit(d time.Duration) (interface{}, error) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-qb.stopCh:
+		qb.logger.Debug("stopped before dequeue")
+		return nil, &ierrors.ClosedError{ErrMsg: "closed"}
+	case entry := <-qb.entryCh:
+		return entry, nil
+	case <-timer.C:
+		return nil, nil
+	}
+}
+
 // Reply sends a reply to the waiting producing go-routine, thus releasing it.
 // The reply can be nil or an object.
 // An error is returned if the OneQueueBarrier was closed.
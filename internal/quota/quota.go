@@ -0,0 +1,35 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// ByDBName returns the quotas of the cluster configuration, by database name
+func ByDBName(config *types.ClusterConfig) map[string]*types.DatabaseQuota {
+	quotas := make(map[string]*types.DatabaseQuota, len(config.GetDbQuotas()))
+	for _, q := range config.GetDbQuotas() {
+		quotas[q.DbName] = q
+	}
+
+	return quotas
+}
+
+// MinuteStart returns the start of the minute of a block timestamp, in nanoseconds since the Unix epoch. The
+// transaction rate of a database is limited per such minute.
+func MinuteStart(timestamp int64) int64 {
+	return timestamp - timestamp%int64(time.Minute)
+}
+
+// MinuteTxs returns the number of transactions accounted in the usage for the minute that starts at minuteStart
+func MinuteTxs(usage *types.DBUsage, minuteStart int64) uint64 {
+	if usage.GetMinuteStart() != minuteStart {
+		return 0
+	}
+
+	return usage.GetMinuteTxs()
+}
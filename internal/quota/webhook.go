@@ -0,0 +1,127 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AlertsSubsystemName is the name of the outbox subsystem that publishes the quota alerts
+	AlertsSubsystemName = "quota-alerts"
+	// IdempotencyKeyHeader carries the key of an alert, with which the receiver discards the alerts it has already
+	// seen, as an alert is delivered at least once by every node
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	defaultWebhookTimeout = 10 * time.Second
+)
+
+// AlertsReader provides the quota alerts raised by a committed block
+type AlertsReader interface {
+	GetQuotaAlerts(blockNum uint64) ([]*types.QuotaAlert, error)
+}
+
+// WebhookConfig holds the configuration of the quota alerts webhook
+type WebhookConfig struct {
+	// URL receives an HTTP POST request with a JSON body for every alert
+	URL string
+	// Timeout of a request to the webhook; if zero, a default is used
+	Timeout time.Duration
+}
+
+// WebhookAlert is the JSON body of the request posted to the webhook for an alert
+type WebhookAlert struct {
+	DBName    string `json:"db_name"`
+	BlockNum  uint64 `json:"block_num"`
+	Resource  string `json:"resource"`
+	Usage     uint64 `json:"usage"`
+	SoftLimit uint64 `json:"soft_limit"`
+	HardLimit uint64 `json:"hard_limit"`
+}
+
+// alertsWebhook is an outbox subsystem that posts the quota alerts raised by the committed blocks to a webhook
+type alertsWebhook struct {
+	alerts AlertsReader
+	url    string
+	client *http.Client
+	logger *logger.SugarLogger
+}
+
+// NewAlertsWebhook creates an outbox subsystem that posts the quota alerts to the webhook
+func NewAlertsWebhook(alerts AlertsReader, conf *WebhookConfig, lg *logger.SugarLogger) outbox.Subsystem {
+	timeout := conf.Timeout
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &alertsWebhook{
+		alerts: alerts,
+		url:    conf.URL,
+		client: &http.Client{Timeout: timeout},
+		logger: lg,
+	}
+}
+
+func (w *alertsWebhook) Name() string {
+	return AlertsSubsystemName
+}
+
+// Entries returns the alerts raised by the block, which are stored by the committer before the block is recorded in
+// the outbox
+func (w *alertsWebhook) Entries(block *types.Block) ([][]byte, error) {
+	alerts, err := w.alerts.GetQuotaAlerts(block.GetHeader().GetBaseHeader().GetNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	var entries [][]byte
+	for _, a := range alerts {
+		entry, err := json.Marshal(&WebhookAlert{
+			DBName:    a.DbName,
+			BlockNum:  a.BlockNum,
+			Resource:  a.Resource.String(),
+			Usage:     a.Usage,
+			SoftLimit: a.SoftLimit,
+			HardLimit: a.HardLimit,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "error while marshaling a quota alert")
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (w *alertsWebhook) Publish(e *outbox.Entry) error {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(e.Payload))
+	if err != nil {
+		return errors.Wrap(err, "error while creating the quota webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, e.Key())
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "error while posting a quota alert to the webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("the quota webhook responded with status [%s]: %s", resp.Status, string(body))
+	}
+
+	w.logger.Debugf("Published quota alert [%s]", e.Key())
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package quota
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+type alertsByBlock map[uint64][]*types.QuotaAlert
+
+func (a alertsByBlock) GetQuotaAlerts(blockNum uint64) ([]*types.QuotaAlert, error) {
+	return a[blockNum], nil
+}
+
+func TestAlertsWebhook(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	alerts := alertsByBlock{
+		5: {
+			{DbName: "db1", BlockNum: 5, Resource: types.QuotaAlert_STORAGE, Usage: 110, SoftLimit: 100, HardLimit: 200},
+			{DbName: "db2", BlockNum: 5, Resource: types.QuotaAlert_TX_RATE, Usage: 10, SoftLimit: 10},
+		},
+	}
+
+	var received []*WebhookAlert
+	var keys []string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		alert := &WebhookAlert{}
+		require.NoError(t, json.Unmarshal(body, alert))
+		received = append(received, alert)
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	webhook := NewAlertsWebhook(alerts, &WebhookConfig{URL: srv.URL}, lg)
+	require.Equal(t, AlertsSubsystemName, webhook.Name())
+
+	entries, err := webhook.Entries(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 4}}})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	entries, err = webhook.Entries(&types.Block{Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 5}}})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	for i, payload := range entries {
+		require.NoError(t, webhook.Publish(&outbox.Entry{
+			Subsystem:   AlertsSubsystemName,
+			BlockNumber: 5,
+			Index:       uint32(i),
+			Payload:     payload,
+		}))
+	}
+	require.Equal(t, []*WebhookAlert{
+		{DBName: "db1", BlockNum: 5, Resource: "STORAGE", Usage: 110, SoftLimit: 100, HardLimit: 200},
+		{DBName: "db2", BlockNum: 5, Resource: "TX_RATE", Usage: 10, SoftLimit: 10},
+	}, received)
+	require.Equal(t, []string{"quota-alerts/5/0", "quota-alerts/5/1"}, keys)
+
+	status = http.StatusInternalServerError
+	err = webhook.Publish(&outbox.Entry{Subsystem: AlertsSubsystemName, BlockNumber: 5, Payload: entries[0]})
+	require.EqualError(t, err, "the quota webhook responded with status [500 Internal Server Error]: ")
+}
+
+func TestMinuteTxs(t *testing.T) {
+	minute := int64(time.Minute)
+	require.Equal(t, 3*minute, MinuteStart(3*minute))
+	require.Equal(t, 3*minute, MinuteStart(3*minute+int64(59*time.Second)))
+
+	usage := &types.DBUsage{MinuteStart: 3 * minute, MinuteTxs: 7}
+	require.Equal(t, uint64(7), MinuteTxs(usage, 3*minute))
+	require.Equal(t, uint64(0), MinuteTxs(usage, 4*minute))
+	require.Equal(t, uint64(0), MinuteTxs(nil, 4*minute))
+}
@@ -0,0 +1,121 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package redaction
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// fieldSeparator separates the member names in the path of a field
+const fieldSeparator = "."
+
+// ReadAccess returns whether a user can read a value with the given access control and, if the user can read only a
+// redacted view of the value, the redaction policy of the user. A value without access control is read in full by
+// all users.
+func ReadAccess(acl *types.AccessControl, userID string) (*types.RedactionPolicy, bool) {
+	if acl == nil || acl.ReadUsers[userID] || acl.ReadWriteUsers[userID] {
+		return nil, true
+	}
+
+	policy, ok := acl.RedactedReadUsers[userID]
+	return policy, ok
+}
+
+// Redact returns the JSON object with the fields of the policy stripped or hashed. The fields that the object does not
+// hold are skipped. The members of the returned object are sorted by name.
+func Redact(value []byte, policy *types.RedactionPolicy) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	obj := make(map[string]interface{})
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, errors.Wrap(err, "the value is not a JSON object")
+	}
+
+	for _, field := range policy.GetStrippedFields() {
+		parent, name := lookupParent(obj, field)
+		if parent != nil {
+			delete(parent, name)
+		}
+	}
+
+	for _, field := range policy.GetHashedFields() {
+		parent, name := lookupParent(obj, field)
+		if parent == nil {
+			continue
+		}
+		fieldValue, ok := parent[name]
+		if !ok {
+			continue
+		}
+
+		encoded, err := json.Marshal(fieldValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while encoding the field [%s]", field)
+		}
+		hash := sha256.Sum256(encoded)
+		parent[name] = hex.EncodeToString(hash[:])
+	}
+
+	return json.Marshal(obj)
+}
+
+// lookupParent returns the object that holds the last member of the path of a field, and the name of that member, or
+// nil if the path does not lead to an object
+func lookupParent(obj map[string]interface{}, field string) (map[string]interface{}, string) {
+	names := strings.Split(field, fieldSeparator)
+	for _, name := range names[:len(names)-1] {
+		child, ok := obj[name].(map[string]interface{})
+		if !ok {
+			return nil, ""
+		}
+		obj = child
+	}
+
+	return obj, names[len(names)-1]
+}
+
+// RedactsAttribute returns true if the policy redacts the top-level member of a JSON object with the given name, or a
+// field nested in it
+func RedactsAttribute(policy *types.RedactionPolicy, attr string) bool {
+	for _, fields := range [][]string{policy.GetStrippedFields(), policy.GetHashedFields()} {
+		for _, field := range fields {
+			if field == attr || strings.HasPrefix(field, attr+fieldSeparator) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ValidatePolicy returns an error if a field of the policy has an empty member name, or is listed more than once
+func ValidatePolicy(policy *types.RedactionPolicy) error {
+	if policy == nil {
+		return errors.New("the redaction policy is empty")
+	}
+
+	fields := make(map[string]bool)
+	for _, list := range [][]string{policy.StrippedFields, policy.HashedFields} {
+		for _, field := range list {
+			for _, name := range strings.Split(field, fieldSeparator) {
+				if name == "" {
+					return errors.Errorf("the field [%s] has an empty member name", field)
+				}
+			}
+			if fields[field] {
+				return errors.Errorf("the field [%s] is listed more than once", field)
+			}
+			fields[field] = true
+		}
+	}
+
+	return nil
+}
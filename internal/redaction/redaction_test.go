@@ -0,0 +1,131 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package redaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func TestReadAccess(t *testing.T) {
+	t.Parallel()
+
+	policy := &types.RedactionPolicy{StrippedFields: []string{"salary"}}
+	acl := &types.AccessControl{
+		ReadUsers:      map[string]bool{"alice": true},
+		ReadWriteUsers: map[string]bool{"bob": true},
+		RedactedReadUsers: map[string]*types.RedactionPolicy{
+			"alice":   policy,
+			"charlie": policy,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		acl            *types.AccessControl
+		user           string
+		expectedPolicy *types.RedactionPolicy
+		expectedRead   bool
+	}{
+		{name: "no access control", acl: nil, user: "dave", expectedRead: true},
+		{name: "full read takes precedence", acl: acl, user: "alice", expectedRead: true},
+		{name: "read-write user", acl: acl, user: "bob", expectedRead: true},
+		{name: "redacted read", acl: acl, user: "charlie", expectedPolicy: policy, expectedRead: true},
+		{name: "no read", acl: acl, user: "dave"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			policy, canRead := ReadAccess(tt.acl, tt.user)
+			require.Equal(t, tt.expectedRead, canRead)
+			require.Equal(t, tt.expectedPolicy, policy)
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	value := []byte(`{"name":"alice","salary":1000.50,"ssn":"123-45-6789","address":{"city":"Haifa","street":"Herzl 1"},"tags":["a","b"],"manager":{"id":7,"name":"bob"}}`)
+
+	t.Run("strip and hash", func(t *testing.T) {
+		redacted, err := Redact(value, &types.RedactionPolicy{
+			StrippedFields: []string{"salary", "address.street", "missing", "name.first"},
+			HashedFields:   []string{"ssn", "manager", "tags", "address.zip"},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"name": "alice",
+			"ssn": "`+sha256Hex(`"123-45-6789"`)+`",
+			"address": {"city": "Haifa"},
+			"tags": "`+sha256Hex(`["a","b"]`)+`",
+			"manager": "`+sha256Hex(`{"id":7,"name":"bob"}`)+`"
+		}`, string(redacted))
+	})
+
+	t.Run("the hash of an object does not depend on the order of its members", func(t *testing.T) {
+		policy := &types.RedactionPolicy{HashedFields: []string{"manager"}}
+		redacted1, err := Redact([]byte(`{"manager":{"id":7,"name":"bob"}}`), policy)
+		require.NoError(t, err)
+		redacted2, err := Redact([]byte(`{"manager":{"name":"bob","id":7}}`), policy)
+		require.NoError(t, err)
+		require.Equal(t, redacted1, redacted2)
+	})
+
+	t.Run("numbers are kept as written", func(t *testing.T) {
+		redacted, err := Redact([]byte(`{"big":12345678901234567890,"ssn":1}`), &types.RedactionPolicy{StrippedFields: []string{"ssn"}})
+		require.NoError(t, err)
+		require.Equal(t, `{"big":12345678901234567890}`, string(redacted))
+	})
+
+	t.Run("not a JSON object", func(t *testing.T) {
+		for _, v := range []string{`not json`, `["a"]`, `"a"`} {
+			redacted, err := Redact([]byte(v), &types.RedactionPolicy{StrippedFields: []string{"a"}})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "the value is not a JSON object")
+			require.Nil(t, redacted)
+		}
+	})
+}
+
+func TestRedactsAttribute(t *testing.T) {
+	t.Parallel()
+
+	policy := &types.RedactionPolicy{
+		StrippedFields: []string{"salary"},
+		HashedFields:   []string{"address.street"},
+	}
+	require.True(t, RedactsAttribute(policy, "salary"))
+	require.True(t, RedactsAttribute(policy, "address"))
+	require.False(t, RedactsAttribute(policy, "addr"))
+	require.False(t, RedactsAttribute(policy, "name"))
+}
+
+func TestValidatePolicy(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidatePolicy(&types.RedactionPolicy{
+		StrippedFields: []string{"salary", "address.street"},
+		HashedFields:   []string{"ssn"},
+	}))
+	require.NoError(t, ValidatePolicy(&types.RedactionPolicy{}))
+
+	require.EqualError(t, ValidatePolicy(nil), "the redaction policy is empty")
+	require.EqualError(t, ValidatePolicy(&types.RedactionPolicy{StrippedFields: []string{""}}), "the field [] has an empty member name")
+	require.EqualError(t, ValidatePolicy(&types.RedactionPolicy{HashedFields: []string{"address..street"}}), "the field [address..street] has an empty member name")
+	require.EqualError(t, ValidatePolicy(&types.RedactionPolicy{
+		StrippedFields: []string{"ssn"},
+		HashedFields:   []string{"ssn"},
+	}), "the field [ssn] is listed more than once")
+}
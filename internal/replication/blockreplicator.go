@@ -32,6 +32,22 @@ const (
 	// to preserve in memory when a snapshot is taken. This is for
 	// slow followers to catch up.
 	DefaultSnapshotCatchUpEntries = uint64(4)
+
+	// DefaultLeadershipTransferTimeout is the default time to wait for in-flight blocks to drain and for the
+	// leadership to be assumed by the transferee.
+	DefaultLeadershipTransferTimeout = 30 * time.Second
+
+	// leadershipTransferPollInterval is the interval at which a leadership transfer checks its progress.
+	leadershipTransferPollInterval = 10 * time.Millisecond
+
+	// MaxBlockTimestampSkew bounds the difference between the timestamp the leader puts in a block and the clock of a
+	// member that commits it. A member flags a block whose timestamp is out of bound, but commits it: a committed Raft
+	// entry must be applied by all members, whatever their clocks are.
+	MaxBlockTimestampSkew = 30 * time.Second
+
+	// protocolVersionCheckTimeout is the time the leader waits for the members to report the protocol versions they
+	// support, before proposing a config that activates a new protocol version.
+	protocolVersionCheckTimeout = 5 * time.Second
 )
 
 type BlockLedgerReader interface {
@@ -82,20 +98,79 @@ type BlockReplicator struct {
 	cancelProposeContext            func() // cancels the propose-context if leadership is lost
 	lastProposedBlockNumber         uint64
 	lastProposedBlockHeaderBaseHash []byte
+	lastProposedBlockTimestamp      int64
 	lastCommittedBlock              *types.Block
 	numInFlightBlocks               uint32 // number of in-flight blocks
 	inFlightConfigBlockNumber       uint64 // the block number of the in-flight config, if any; 0 if none
 	condTooManyInFlightBlocks       *sync.Cond
+	transferringLeadership          bool                 // the leader declines new blocks while leadership is transferred
+	proposalTimes                   map[uint64]time.Time // the time each in-flight block was proposed, on the leader
+	numLeaderChanges                uint64
+	numElections                    uint64
+	lastLeaderChangeTime            time.Time
 
 	appliedIndex uint64
 
 	// needed by snapshotting
-	sizeLimit        uint64 // SnapshotIntervalSize in bytes
-	accDataSize      uint64 // accumulative data size since last snapshot
-	lastSnapBlockNum uint64
-	confState        raftpb.ConfState // Etcdraft requires ConfState to be persisted within snapshot
+	sizeLimit          uint64 // SnapshotIntervalSize in bytes
+	blockIntervalLimit uint64 // SnapshotIntervalBlocks, 0 if disabled
+	accDataSize        uint64 // accumulative data size since last snapshot
+	lastSnapBlockNum   uint64
+	confState          raftpb.ConfState      // Etcdraft requires ConfState to be persisted within snapshot
+	snapshotRequestCh  chan *snapshotRequest // snapshots requested by an admin, served by the event-loop go-routine
+
+	// needed by the compression of the proposed blocks
+	entryCodec    string // the codec of the proposed blocks, empty if they are not compressed
+	minEntryBytes uint64 // the size of a marshaled block below which it is not compressed
+	maxEntryBytes uint64 // the size above which a compressed block of a committed entry is rejected
+
+	clock   Clock
+	metrics *consensusMetrics
+	lg      *logger.SugarLogger
+}
+
+// SnapshotInfo describes a raft snapshot taken by the block replicator.
+type SnapshotInfo struct {
+	// BlockNumber is the number of the last block included in the snapshot.
+	BlockNumber uint64
+	// RaftIndex is the raft index at which the snapshot was taken.
+	RaftIndex uint64
+}
 
-	lg *logger.SugarLogger
+// Diagnostics describes the state of consensus on the local node.
+type Diagnostics struct {
+	RaftID      uint64
+	RaftState   string
+	Term        uint64
+	LeaderID    uint64
+	CommitIndex uint64
+	// AppliedIndex is the last index raft delivered to the replicator.
+	AppliedIndex             uint64
+	NumLeaderChanges         uint64
+	NumElections             uint64
+	LastLeaderChangeTime     time.Time
+	InFlightBlocks           uint32
+	LastProposedBlockNumber  uint64
+	LastCommittedBlockNumber uint64
+	Peers                    []*PeerDiagnostics
+}
+
+// PeerDiagnostics describes the state of a remote consensus member, as seen by the local node.
+type PeerDiagnostics struct {
+	NodeID string
+	RaftID uint64
+	Active bool
+	// MatchIndex, NextIndex and ProgressState describe the replication progress of the peer, and are known only to
+	// the leader.
+	MatchIndex    uint64
+	NextIndex     uint64
+	ProgressState string
+}
+
+type snapshotRequest struct {
+	info *SnapshotInfo
+	err  error
+	done chan struct{}
 }
 
 // Config holds the configuration information required to initialize the block replicator.
@@ -108,6 +183,7 @@ type Config struct {
 	BlockOneQueueBarrier *queue.OneQueueBarrier
 	PendingTxs           PendingTxsReleaser
 	ConfigValidator      ConfigTxValidator
+	Clock                Clock // optional, the system clock is used if nil
 	Logger               *logger.SugarLogger
 }
 
@@ -118,6 +194,15 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		return nil, err
 	}
 
+	if err = comm.VerifyProtocolVersionSupported(conf.ClusterConfig); err != nil {
+		return nil, errors.WithMessage(err, "the server must be upgraded")
+	}
+
+	maxBlockSize, err := conf.LocalConf.BlockCreation.MaxBlockSizeInBytes()
+	if err != nil {
+		return nil, err
+	}
+
 	lg := conf.Logger.With("nodeID", conf.LocalConf.Server.Identity.ID, "raftID", raftID)
 
 	haveWAL := wal.Exist(conf.LocalConf.Replication.WALDir)
@@ -126,6 +211,12 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		return nil, errors.Errorf("failed to restore persisted raft data: %s", err)
 	}
 	storage.SnapshotCatchUpEntries = DefaultSnapshotCatchUpEntries
+	if catchUpEntries := conf.LocalConf.Replication.SnapshotCatchUpEntries; catchUpEntries > 0 {
+		storage.SnapshotCatchUpEntries = catchUpEntries
+	}
+	if maxSnapFiles := conf.LocalConf.Replication.MaxSnapshotFiles; maxSnapFiles > 0 {
+		storage.MaxSnapshotFiles = int(maxSnapFiles)
+	}
 
 	var snapBlkNum uint64
 	var confState raftpb.ConfState
@@ -158,11 +249,27 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		clusterConfig:        conf.ClusterConfig,
 		cancelProposeContext: func() {}, //NOOP
 		sizeLimit:            conf.ClusterConfig.ConsensusConfig.RaftConfig.SnapshotIntervalSize,
+		blockIntervalLimit:   conf.LocalConf.Replication.SnapshotIntervalBlocks,
 		lastSnapBlockNum:     snapBlkNum,
 		confState:            confState,
+		snapshotRequestCh:    make(chan *snapshotRequest),
+		proposalTimes:        make(map[uint64]time.Time),
+		minEntryBytes:        conf.LocalConf.Replication.Compression.MinEntryBytes,
+		maxEntryBytes:        comm.MaxDecompressedBlockBytes(maxBlockSize),
+		clock:                conf.Clock,
+		metrics:              newConsensusMetrics(conf.LocalConf.Server.Identity.ID),
 		lg:                   lg,
 	}
+	if br.clock == nil {
+		br.clock = systemClock{}
+	}
 	br.condTooManyInFlightBlocks = sync.NewCond(&br.mutex)
+	if codecs := conf.LocalConf.Replication.Compression.Codecs; len(codecs) > 0 {
+		br.entryCodec = codecs[0]
+	}
+	if br.minEntryBytes == 0 {
+		br.minEntryBytes = DefaultMinCompressedEntryBytes
+	}
 
 	height, err := br.ledgerReader.Height()
 	if err != nil {
@@ -175,6 +282,7 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 			br.lg.Panicf("Failed to read last block: %s", err)
 		}
 		br.lastProposedBlockNumber = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber()
+		br.lastProposedBlockTimestamp = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetTimestamp()
 		if baseHash, err := blockstore.ComputeBlockBaseHash(br.lastCommittedBlock); err == nil {
 			br.lastProposedBlockHeaderBaseHash = baseHash
 		} else {
@@ -193,7 +301,7 @@ func NewBlockReplicator(conf *Config) (*BlockReplicator, error) {
 		ID:              raftID,
 		ElectionTick:    int(br.clusterConfig.ConsensusConfig.RaftConfig.ElectionTicks),
 		HeartbeatTick:   int(br.clusterConfig.ConsensusConfig.RaftConfig.HeartbeatTicks),
-		MaxSizePerMsg:   br.localConf.BlockCreation.MaxBlockSize,
+		MaxSizePerMsg:   maxBlockSize,
 		MaxInflightMsgs: int(br.clusterConfig.ConsensusConfig.RaftConfig.MaxInflightBlocks),
 		Logger:          lg,
 		Storage:         br.raftStorage.MemoryStorage,
@@ -358,6 +466,7 @@ func (br *BlockReplicator) runRaftEventLoop(readyCh chan<- struct{}) {
 	// TODO proactive campaign to speed up leader election on a new cluster
 
 	var raftStatusStr string
+	var raftState raft.StateType
 Event_Loop:
 	for {
 		select {
@@ -374,10 +483,15 @@ Event_Loop:
 				br.lg.Panicf("Failed to persist etcd/raft data: %s", err)
 			}
 			duration := time.Since(startStoring).Seconds()
+			br.metrics.walSyncDuration.Observe(duration)
 			if duration > halfElectionTimeout {
 				br.lg.Warningf("WAL sync took %v seconds and the network is configured to start elections after %v seconds. Your disk is too slow and may cause loss of quorum and trigger leadership election.", duration, electionTimeout)
 			}
 
+			if !raft.IsEmptyHardState(rd.HardState) {
+				br.metrics.term.Set(float64(rd.HardState.Term))
+			}
+
 			if !raft.IsEmptySnap(rd.Snapshot) {
 				if err := br.catchUp(rd.Snapshot); err != nil {
 					br.lg.Panicf("Failed to catch-up to snapshot: %+v", rd.Snapshot)
@@ -400,11 +514,21 @@ Event_Loop:
 					br.lg.Debug("No leader")
 				}
 
+				// with PreVote, only a pre-candidate that won the pre-vote becomes a candidate and starts an election
+				if rd.SoftState.RaftState == raft.StateCandidate && raftState != raft.StateCandidate {
+					br.processElectionStart()
+				}
+				raftState = rd.SoftState.RaftState
+
 				br.processLeaderChanges(leader)
 			}
 
 			br.raftNode.Advance()
 
+		case req := <-br.snapshotRequestCh:
+			req.info, req.err = br.takeRequestedSnapshot()
+			close(req.done)
+
 		case <-br.stopCh:
 			br.lg.Info("Stopping block replicator")
 			break Event_Loop
@@ -419,6 +543,7 @@ Event_Loop:
 	br.inFlightConfigBlockNumber = 0 // stop waiting for config block to commit
 	br.condTooManyInFlightBlocks.Broadcast()
 	br.mutex.Unlock()
+	br.metrics.isLeader.Set(0)
 
 	raftTicker.Stop()
 	br.raftNode.Stop()
@@ -439,6 +564,15 @@ func (br *BlockReplicator) processLeaderChanges(leader uint64) {
 		lostLeadership := br.lastKnownLeader == br.raftID
 		assumedLeadership := leader == br.raftID
 
+		br.numLeaderChanges++
+		br.lastLeaderChangeTime = time.Now()
+		br.metrics.leaderChanges.Inc()
+		if assumedLeadership {
+			br.metrics.isLeader.Set(1)
+		} else {
+			br.metrics.isLeader.Set(0)
+		}
+
 		if lostLeadership {
 			br.lg.Info("Lost leadership")
 			// cancel the current proposal to free the propose-loop go-routine, as it might block for a long time.
@@ -451,6 +585,7 @@ func (br *BlockReplicator) processLeaderChanges(leader uint64) {
 		if lostLeadership || assumedLeadership {
 			var err error
 			br.lastProposedBlockNumber = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber()
+			br.lastProposedBlockTimestamp = br.lastCommittedBlock.GetHeader().GetBaseHeader().GetTimestamp()
 			br.lastProposedBlockHeaderBaseHash, err = blockstore.ComputeBlockBaseHash(br.lastCommittedBlock)
 			if err != nil {
 				br.lg.Panicf("Error computing base header hash of last commited block: %+v; error: %s",
@@ -458,6 +593,7 @@ func (br *BlockReplicator) processLeaderChanges(leader uint64) {
 			}
 			br.numInFlightBlocks = 0
 			br.inFlightConfigBlockNumber = 0
+			br.proposalTimes = make(map[uint64]time.Time)
 			br.condTooManyInFlightBlocks.Broadcast()
 		}
 
@@ -466,6 +602,15 @@ func (br *BlockReplicator) processLeaderChanges(leader uint64) {
 	}
 }
 
+func (br *BlockReplicator) processElectionStart() {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	br.lg.Infof("Starting an election, last known leader: %d", br.lastKnownLeader)
+	br.numElections++
+	br.metrics.elections.Inc()
+}
+
 // When a node lags behind the cluster more than the last checkpoint of the leader, the leader will send a snapshot to
 // it. A snapshot is a block with some raft information. A received snapshot serves as a trigger for the node to
 // perform catch-up, or state transfer. It will contact one of the active members of the cluster (preferably the
@@ -582,6 +727,14 @@ func (br *BlockReplicator) catchUpToBlock(initBlockNumber, targetBlockNumber uin
 
 			br.lg.Infof("Going to commit [%d] blocks", len(blocks)) //Not necessarily the entire range requested!
 
+			// `PullBlocks` verifies the links between the blocks it returns, the link to the ledger is verified here
+			if lastBlock := br.lastCommittedBlock; lastBlock != nil && len(blocks) > 0 &&
+				lastBlock.GetHeader().GetBaseHeader().GetNumber()+1 == blocks[0].GetHeader().GetBaseHeader().GetNumber() {
+				if err := comm.VerifyBlockLink(lastBlock, blocks[0]); err != nil {
+					return errors.WithMessage(err, "pulled blocks do not extend the ledger")
+				}
+			}
+
 			for _, blockToCommit := range blocks {
 				br.lg.Infof("enqueue for commit block [%d], ConsensusMetadata: [%+v]",
 					blockToCommit.GetHeader().GetBaseHeader().GetNumber(),
@@ -616,9 +769,35 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 	}
 
 	var position int
+	var hasData bool
+	// Consecutive data blocks are committed as a group, so that their block store writes are group-committed. The
+	// applied index is updated once the group is committed, which takes place before any other entry is applied.
+	var group []*types.Block
+	var groupLastIndex uint64
+	commitGroup := func() bool {
+		if len(group) == 0 {
+			return true
+		}
+		if err := br.commitBlocks(group); err != nil {
+			br.lg.Errorf("commit block error: %s, stopping block replicator", err.Error())
+			return false
+		}
+		if br.appliedIndex < groupLastIndex {
+			br.appliedIndex = groupLastIndex
+		}
+		group = nil
+		return true
+	}
+
 	for i := range committedEntries {
 		br.lg.Debugf("processing commited entry [%d]: %s", i, raftEntryString(committedEntries[i]))
 
+		if committedEntries[i].Type != raftpb.EntryNormal || len(committedEntries[i].Data) == 0 {
+			if !commitGroup() {
+				return false
+			}
+		}
+
 		switch committedEntries[i].Type {
 		case raftpb.EntryNormal:
 			if len(committedEntries[i].Data) == 0 {
@@ -627,6 +806,7 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 			}
 
 			position = i
+			hasData = true
 			br.accDataSize += uint64(len(committedEntries[i].Data))
 
 			// We need to strictly avoid re-applying normal entries,
@@ -636,16 +816,34 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 				break
 			}
 
+			blockBytes, err := decodeBlockEntry(committedEntries[i].Data, br.maxEntryBytes)
+			if err != nil {
+				br.lg.Panicf("Error decoding entry [#%d], entry: %+v, error: %s", i, committedEntries[i], err)
+			}
 			var block = &types.Block{}
-			if err := proto.Unmarshal(committedEntries[i].Data, block); err != nil {
+			if err := proto.Unmarshal(blockBytes, block); err != nil {
 				br.lg.Panicf("Error unmarshaling entry [#%d], entry: %+v, error: %s", i, committedEntries[i], err)
 			}
 			block.ConsensusMetadata = &types.ConsensusMetadata{
 				RaftTerm:  committedEntries[i].Term,
 				RaftIndex: committedEntries[i].Index,
 			}
+			previous := br.getLastCommittedBlock()
+			if len(group) > 0 {
+				previous = group[len(group)-1]
+			}
+			br.checkBlockTimestamp(block, previous)
+
+			if block.GetDataTxEnvelopes() != nil {
+				group = append(group, block)
+				groupLastIndex = committedEntries[i].Index
+				continue
+			}
+			if !commitGroup() {
+				return false
+			}
 
-			err := br.commitBlock(block, true)
+			err = br.commitBlock(block, true)
 			if err != nil {
 				br.lg.Errorf("commit block error: %s, stopping block replicator", err.Error())
 				return false
@@ -730,16 +928,27 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 		}
 	}
 
-	// Take a snapshot if in-memory storage size exceeds the limit
-	if br.accDataSize >= br.sizeLimit {
+	if !commitGroup() {
+		return false
+	}
+
+	// Take a snapshot if in-memory storage size exceeds the limit, or if enough blocks were committed since the last
+	// snapshot.
+	blockIntervalReached := br.blockIntervalLimit > 0 && hasData &&
+		br.getLastCommittedBlockNumber() >= br.lastSnapBlockNum+br.blockIntervalLimit
+	if br.accDataSize >= br.sizeLimit || blockIntervalReached {
 		var snapBlock = &types.Block{}
 		var snapData []byte
 		switch committedEntries[position].Type {
 		case raftpb.EntryNormal:
-			if err := proto.Unmarshal(committedEntries[position].Data, snapBlock); err != nil {
+			blockBytes, err := decodeBlockEntry(committedEntries[position].Data, br.maxEntryBytes)
+			if err != nil {
+				br.lg.Panicf("Error decoding Normal entry [#%d], entry: %+v, error: %s", position, committedEntries[position], err)
+			}
+			if err := proto.Unmarshal(blockBytes, snapBlock); err != nil {
 				br.lg.Panicf("Error unmarshaling Normal entry [#%d], entry: %+v, error: %s", position, committedEntries[position], err)
 			}
-			snapData = committedEntries[position].Data
+			snapData = blockBytes
 
 		case raftpb.EntryConfChangeV2:
 			var ccV2 raftpb.ConfChangeV2
@@ -758,9 +967,9 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 			br.lg.Fatalf("Failed to create snapshot at index %d: %s", br.appliedIndex, err)
 		}
 
-		br.lg.Infof("Accumulated %d bytes since last snapshot, exceeding size limit (%d bytes), "+
+		br.lg.Infof("Accumulated %d bytes since last snapshot (size limit: %d bytes, block interval: %d), "+
 			"taking snapshot at block [%d] (index: %d), last snapshotted block number is %d, current voters: %+v",
-			br.accDataSize, br.sizeLimit, snapBlock.GetHeader().GetBaseHeader().GetNumber(), br.appliedIndex, br.lastSnapBlockNum, br.confState.Voters)
+			br.accDataSize, br.sizeLimit, br.blockIntervalLimit, snapBlock.GetHeader().GetBaseHeader().GetNumber(), br.appliedIndex, br.lastSnapBlockNum, br.confState.Voters)
 
 		br.accDataSize = 0
 		br.lastSnapBlockNum = snapBlock.GetHeader().GetBaseHeader().GetNumber()
@@ -769,6 +978,64 @@ func (br *BlockReplicator) deliverEntries(committedEntries []raftpb.Entry) bool
 	return true
 }
 
+// TriggerSnapshot takes a raft snapshot at the last applied index, regardless of the snapshot intervals, and purges
+// the WAL and snapshot files that are no longer retained. It blocks until the snapshot is persisted.
+func (br *BlockReplicator) TriggerSnapshot() (*SnapshotInfo, error) {
+	br.mutex.Lock()
+	onBoarding := br.isOnBoarding()
+	br.mutex.Unlock()
+	if onBoarding {
+		return nil, errors.New("cannot take a snapshot while the node is on-boarding")
+	}
+
+	req := &snapshotRequest{done: make(chan struct{})}
+	select {
+	case br.snapshotRequestCh <- req:
+	case <-br.stopCh:
+		return nil, &ierrors.ClosedError{ErrMsg: "block replicator closed"}
+	}
+
+	select {
+	case <-req.done:
+		return req.info, req.err
+	case <-br.stopCh:
+		return nil, &ierrors.ClosedError{ErrMsg: "block replicator closed"}
+	}
+}
+
+// takeRequestedSnapshot is called from the event-loop go-routine, hence it may access the applied index and conf state.
+func (br *BlockReplicator) takeRequestedSnapshot() (*SnapshotInfo, error) {
+	if lastSnapIndex := br.raftStorage.LastSnapshotIndex(); br.appliedIndex <= lastSnapIndex {
+		return nil, errors.Errorf("no entries were applied since the last snapshot, applied index: %d, last snapshot index: %d",
+			br.appliedIndex, lastSnapIndex)
+	}
+
+	br.mutex.Lock()
+	snapBlock := br.lastCommittedBlock
+	br.mutex.Unlock()
+
+	snapData, err := proto.Marshal(snapBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal snapshot block")
+	}
+
+	if err := br.raftStorage.TakeSnapshot(br.appliedIndex, br.confState, snapData); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot at index %d", br.appliedIndex)
+	}
+
+	snapBlockNum := snapBlock.GetHeader().GetBaseHeader().GetNumber()
+	br.lg.Infof("Took a requested snapshot at block [%d] (index: %d), last snapshotted block number is %d, current voters: %+v",
+		snapBlockNum, br.appliedIndex, br.lastSnapBlockNum, br.confState.Voters)
+
+	br.accDataSize = 0
+	br.lastSnapBlockNum = snapBlockNum
+
+	return &SnapshotInfo{
+		BlockNumber: snapBlockNum,
+		RaftIndex:   br.appliedIndex,
+	}, nil
+}
+
 func (br *BlockReplicator) runProposeLoop(readyCh chan<- struct{}) {
 	defer close(br.doneProposeCh)
 
@@ -802,6 +1069,12 @@ Propose_Loop:
 				}
 
 				newClusterConfig := configTxEnv.GetPayload().GetNewConfig()
+				if errVer := br.verifyProtocolVersionUpgrade(newClusterConfig); errVer != nil {
+					br.releasePendingTXs(blockToPropose, "Declined to propose block, protocol version not supported by all members",
+						&ierrors.BadRequestError{ErrMsg: errVer.Error()})
+					continue Propose_Loop
+				}
+
 				_, consensus, _, _ := ClassifyClusterReConfig(br.clusterConfig, newClusterConfig)
 				if consensus {
 					var errDetect error
@@ -812,6 +1085,12 @@ Propose_Loop:
 					}
 					isMembershipConfig = len(addedPeers)+len(removedPeers) > 0
 				}
+
+				if errQuorum := br.verifyQuorumAfterRemoval(removedPeers, newClusterConfig.GetConsensusConfig()); errQuorum != nil {
+					br.releasePendingTXs(blockToPropose, "Declined to propose block, the remaining members cannot form a quorum",
+						&ierrors.BadRequestError{ErrMsg: errQuorum.Error()})
+					continue Propose_Loop
+				}
 			}
 
 			if !isMembershipConfig {
@@ -837,6 +1116,63 @@ Propose_Loop:
 	br.lg.Info("Exiting the block replicator propose loop")
 }
 
+// verifyProtocolVersionUpgrade checks that all the consensus members support the protocol version of a new config,
+// if it activates a version that is higher than the current one. This check is done by the leader before proposing,
+// because it depends on the binaries the members are running, and therefore cannot be part of the deterministic
+// validation of the config tx.
+func (br *BlockReplicator) verifyProtocolVersionUpgrade(newClusterConfig *types.ClusterConfig) error {
+	current := comm.ClusterProtocolVersion(br.clusterConfig)
+	updated := comm.ClusterProtocolVersion(newClusterConfig)
+	if updated <= current {
+		return nil
+	}
+
+	br.lg.Infof("Config activates protocol version [%d], current version is [%d]; checking all members support it", updated, current)
+	ctx, cancel := context.WithTimeout(context.Background(), protocolVersionCheckTimeout)
+	defer cancel()
+
+	return br.transport.VerifyPeersSupportProtocolVersion(ctx, updated)
+}
+
+// verifyQuorumAfterRemoval checks that the consensus members that remain after removing peers can form a quorum with
+// the members that are currently active. Like the protocol version check, it depends on the liveness of the members,
+// and therefore cannot be part of the deterministic validation of the config tx.
+func (br *BlockReplicator) verifyQuorumAfterRemoval(removedPeers []*types.PeerConfig, updatedConfig *types.ConsensusConfig) error {
+	if len(removedPeers) == 0 {
+		return nil
+	}
+
+	return verifyQuorumViability(updatedConfig, br.transport.ActivePeers(0, true))
+}
+
+// VerifyQuorumAfterReConfig checks that a new cluster config that removes a peer leaves enough active members to form
+// a quorum. This is done before a config tx is submitted, and again by the leader before proposing it.
+func (br *BlockReplicator) VerifyQuorumAfterReConfig(newClusterConfig *types.ClusterConfig) error {
+	br.mutex.Lock()
+	currentConsensus := br.clusterConfig.GetConsensusConfig()
+	br.mutex.Unlock()
+
+	if newClusterConfig.GetConsensusConfig() == nil {
+		return errors.New("the new cluster config has no consensus config")
+	}
+
+	_, removedPeers, _, err := detectPeerConfigChanges(currentConsensus, newClusterConfig.GetConsensusConfig())
+	if err != nil {
+		return err
+	}
+
+	return br.verifyQuorumAfterRemoval(removedPeers, newClusterConfig.GetConsensusConfig())
+}
+
+// DescribeReConfig reports the changes that a new cluster config makes to the current cluster config.
+func (br *BlockReplicator) DescribeReConfig(newClusterConfig *types.ClusterConfig) *ClusterReConfigChanges {
+	br.mutex.Lock()
+	currentConfig := br.clusterConfig
+	br.mutex.Unlock()
+
+	return DescribeClusterReConfig(currentConfig, newClusterConfig)
+}
+
 // proposeRegular proposes the block to Raft as a regular message.
 func (br *BlockReplicator) proposeRegular(blockToPropose *types.Block) bool {
 	ctx, blockBytes, doPropose := br.prepareProposal(blockToPropose)
@@ -844,9 +1180,20 @@ func (br *BlockReplicator) proposeRegular(blockToPropose *types.Block) bool {
 		return false
 	}
 
+	// The block is compressed only once all the members can decompress it.
+	entryData := blockBytes
+	if br.ProtocolVersion() >= comm.ProtocolVersionCompressedEntries {
+		var err error
+		if entryData, err = encodeBlockEntry(blockBytes, br.entryCodec, br.minEntryBytes); err != nil {
+			br.lg.Panicf("Error compressing a block: %s", err)
+		}
+	}
+	br.metrics.proposedBlockBytes.Add(float64(len(blockBytes)))
+	br.metrics.proposedEntryBytes.Add(float64(len(entryData)))
+
 	// Propose to raft: the call to raft.Node.Propose() may block when a leader loses its leadership and has no quorum.
 	// It is cancelled when the node loses leadership, by the event-loop go-routine.
-	err := br.raftNode.Propose(ctx, blockBytes)
+	err := br.raftNode.Propose(ctx, entryData)
 	if err != nil {
 		br.releasePendingTXs(blockToPropose, "Failed to propose block", err)
 		return false
@@ -920,8 +1267,17 @@ func (br *BlockReplicator) prepareProposal(blockToPropose *types.Block) (ctx con
 		return nil, nil, false //skip proposing
 	}
 
+	if br.transferringLeadership {
+		br.mutex.Unlock() //do not call the pendingTxs component with a mutex locked
+
+		br.releasePendingTXs(blockToPropose, "Declined to propose block, transferring leadership", &ierrors.NotLeaderError{})
+
+		return nil, nil, false //skip proposing
+	}
+
 	// number the block and set the base header hash
 	br.insertBlockBaseHeader(blockToPropose)
+	br.proposalTimes[blockToPropose.GetHeader().GetBaseHeader().GetNumber()] = time.Now()
 
 	var err error
 	blockBytes, err = proto.Marshal(blockToPropose)
@@ -946,6 +1302,7 @@ func (br *BlockReplicator) updateLastProposal(lastBlockProposed *types.Block) {
 
 	if br.isLeader() == nil {
 		br.lastProposedBlockNumber = lastBlockProposed.GetHeader().GetBaseHeader().GetNumber()
+		br.lastProposedBlockTimestamp = lastBlockProposed.GetHeader().GetBaseHeader().GetTimestamp()
 		if baseHash, err := blockstore.ComputeBlockBaseHash(lastBlockProposed); err == nil {
 			br.lastProposedBlockHeaderBaseHash = baseHash
 		} else {
@@ -1022,6 +1379,12 @@ func (br *BlockReplicator) IsLeader() *ierrors.NotLeaderError {
 	br.mutex.Lock()
 	defer br.mutex.Unlock()
 
+	// While leadership is transferred the node is still the Raft leader, but it does not accept new blocks, and
+	// the new leader is not yet known.
+	if br.transferringLeadership {
+		return &ierrors.NotLeaderError{}
+	}
+
 	return br.isLeader()
 }
 
@@ -1034,6 +1397,120 @@ func (br *BlockReplicator) isLeader() *ierrors.NotLeaderError {
 		LeaderID: br.lastKnownLeader, LeaderHostPort: br.lastKnownLeaderHost}
 }
 
+// TransferLeadership gracefully transfers the leadership from this node to the target node, in preparation for a
+// planned shutdown of this node. If the target node ID is empty, the transferee is chosen among the active peers.
+//
+// Once the transfer starts the node declines new blocks, waits for the in-flight blocks to commit, and then asks
+// Raft to transfer the leadership to the transferee. The call returns the node ID of the new leader, or an error if
+// the leadership was not assumed by the transferee within the timeout. If the timeout is zero,
+// DefaultLeadershipTransferTimeout is used.
+func (br *BlockReplicator) TransferLeadership(targetNodeID string, timeout time.Duration) (string, error) {
+	if timeout == 0 {
+		timeout = DefaultLeadershipTransferTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	br.mutex.Lock()
+	if br.isOnBoarding() {
+		br.mutex.Unlock()
+		return "", errors.New("cannot transfer leadership while the node is on-boarding")
+	}
+	if err := br.isLeader(); err != nil {
+		br.mutex.Unlock()
+		return "", err
+	}
+	if br.transferringLeadership {
+		br.mutex.Unlock()
+		return "", &ierrors.BadRequestError{ErrMsg: "a leadership transfer is already in progress"}
+	}
+	transferee, err := br.transfereeFromNodeID(targetNodeID)
+	if err != nil {
+		br.mutex.Unlock()
+		return "", err
+	}
+	br.transferringLeadership = true
+	br.mutex.Unlock()
+
+	defer func() {
+		br.mutex.Lock()
+		br.transferringLeadership = false
+		br.mutex.Unlock()
+	}()
+
+	br.lg.Infof("Transferring leadership to node: %s, RaftID: %d; draining in-flight blocks", transferee.NodeId, transferee.RaftId)
+
+	// No new blocks are proposed, wait for the blocks that were already proposed to commit.
+	if err := br.waitForLeadershipTransferCondition(deadline, "in-flight blocks did not drain", func() bool {
+		return br.numInFlightBlocks == 0 || br.isLeader() != nil
+	}); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	br.raftNode.TransferLeadership(ctx, br.raftID, transferee.RaftId)
+
+	if err := br.waitForLeadershipTransferCondition(deadline, "leadership was not assumed by node: "+transferee.NodeId, func() bool {
+		return br.lastKnownLeader == transferee.RaftId
+	}); err != nil {
+		return "", err
+	}
+
+	br.lg.Infof("Leadership transferred to node: %s, RaftID: %d", transferee.NodeId, transferee.RaftId)
+	return transferee.NodeId, nil
+}
+
+// transfereeFromNodeID finds the consensus member to transfer leadership to. Must be called with the mutex locked.
+func (br *BlockReplicator) transfereeFromNodeID(targetNodeID string) (*types.PeerConfig, error) {
+	if targetNodeID == "" {
+		var transferee *types.PeerConfig
+		for _, peer := range br.transport.ActivePeers(500*time.Millisecond, false) {
+			if transferee == nil || peer.RaftId < transferee.RaftId {
+				transferee = peer
+			}
+		}
+		if transferee == nil {
+			return nil, &ierrors.BadRequestError{ErrMsg: "there are no active peers to transfer leadership to"}
+		}
+		return transferee, nil
+	}
+
+	for _, peer := range br.clusterConfig.ConsensusConfig.Members {
+		if peer.NodeId != targetNodeID {
+			continue
+		}
+		if peer.RaftId == br.raftID {
+			return nil, &ierrors.BadRequestError{ErrMsg: "node [" + targetNodeID + "] is already the leader"}
+		}
+		return peer, nil
+	}
+
+	return nil, &ierrors.BadRequestError{ErrMsg: "node [" + targetNodeID + "] is not a consensus member"}
+}
+
+// waitForLeadershipTransferCondition polls the condition, which is evaluated with the mutex locked, until it holds
+// or the deadline expires.
+func (br *BlockReplicator) waitForLeadershipTransferCondition(deadline time.Time, reason string, cond func() bool) error {
+	for {
+		br.mutex.Lock()
+		done := cond()
+		br.mutex.Unlock()
+		if done {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return &ierrors.TimeoutErr{ErrMsg: "timeout has occurred while transferring leadership, " + reason}
+		}
+
+		select {
+		case <-br.stopCh:
+			return &ierrors.ClosedError{ErrMsg: "block replicator closed"}
+		case <-time.After(leadershipTransferPollInterval):
+		}
+	}
+}
+
 func (br *BlockReplicator) GetLeaderID() uint64 {
 	br.mutex.Lock()
 	defer br.mutex.Unlock()
@@ -1041,6 +1518,15 @@ func (br *BlockReplicator) GetLeaderID() uint64 {
 	return br.lastKnownLeader
 }
 
+// ProtocolVersion returns the protocol version that is active in the cluster. Features that were introduced in a
+// higher protocol version must not be used, as some members may not support them yet.
+func (br *BlockReplicator) ProtocolVersion() uint32 {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	return comm.ClusterProtocolVersion(br.clusterConfig)
+}
+
 func (br *BlockReplicator) GetClusterStatus() (leaderID uint64, activePeers map[string]*types.PeerConfig) {
 	br.mutex.Lock()
 	defer br.mutex.Unlock()
@@ -1051,6 +1537,57 @@ func (br *BlockReplicator) GetClusterStatus() (leaderID uint64, activePeers map[
 	return
 }
 
+// Diagnostics returns the state of consensus on the local node.
+func (br *BlockReplicator) Diagnostics() *Diagnostics {
+	br.mutex.Lock()
+	diag := &Diagnostics{
+		RaftID:                   br.raftID,
+		LeaderID:                 br.lastKnownLeader,
+		NumLeaderChanges:         br.numLeaderChanges,
+		NumElections:             br.numElections,
+		LastLeaderChangeTime:     br.lastLeaderChangeTime,
+		InFlightBlocks:           br.numInFlightBlocks,
+		LastProposedBlockNumber:  br.lastProposedBlockNumber,
+		LastCommittedBlockNumber: br.lastCommittedBlock.GetHeader().GetBaseHeader().GetNumber(),
+	}
+	members := br.clusterConfig.GetConsensusConfig().GetMembers()
+	onBoarding := br.isOnBoarding()
+	raftNode := br.raftNode
+	br.mutex.Unlock()
+
+	if onBoarding {
+		diag.RaftState = "OnBoarding"
+		return diag
+	}
+
+	// Status is served by the raft node go-routine, and must not be called with the mutex locked.
+	status := raftNode.Status()
+	diag.RaftState = status.RaftState.String()
+	diag.Term = status.Term
+	diag.CommitIndex = status.Commit
+	diag.AppliedIndex = status.Applied
+
+	activePeers := br.transport.ActivePeers(500*time.Millisecond, false)
+	for _, m := range members {
+		if m.RaftId == br.raftID {
+			continue
+		}
+		peer := &PeerDiagnostics{
+			NodeID: m.NodeId,
+			RaftID: m.RaftId,
+		}
+		_, peer.Active = activePeers[m.NodeId]
+		if pr, ok := status.Progress[m.RaftId]; ok { // progress is tracked only by the leader
+			peer.MatchIndex = pr.Match
+			peer.NextIndex = pr.Next
+			peer.ProgressState = pr.State.String()
+		}
+		diag.Peers = append(diag.Peers, peer)
+	}
+
+	return diag
+}
+
 // Commit the block to the ledger and DB.
 //
 // If the block is a config block, update the cluster config if `updateConfig` is true.
@@ -1062,10 +1599,12 @@ func (br *BlockReplicator) commitBlock(block *types.Block, updateConfig bool) er
 		blockNumber, block.GetConsensusMetadata())
 
 	// we can only get a valid config transaction
+	startCommit := time.Now()
 	reConfig, err := br.oneQueueBarrier.EnqueueWait(block)
 	if err != nil {
 		return err
 	}
+	br.metrics.blockCommitDuration.Observe(time.Since(startCommit).Seconds())
 
 	br.setLastCommittedBlock(block)
 
@@ -1086,12 +1625,58 @@ func (br *BlockReplicator) commitBlock(block *types.Block, updateConfig bool) er
 	return nil
 }
 
+// commitBlocks commits a group of consecutive data blocks to the ledger and DB. The block processor group-commits the
+// block store writes of the blocks, which are all committed once it is released.
+func (br *BlockReplicator) commitBlocks(blocks []*types.Block) error {
+	if len(blocks) == 1 {
+		return br.commitBlock(blocks[0], true)
+	}
+
+	br.lg.Infof("Enqueue for commit blocks [%d] to [%d]",
+		blocks[0].GetHeader().GetBaseHeader().GetNumber(), blocks[len(blocks)-1].GetHeader().GetBaseHeader().GetNumber())
+
+	startCommit := time.Now()
+	if _, err := br.oneQueueBarrier.EnqueueWait(blocks); err != nil {
+		return err
+	}
+	br.metrics.blockCommitDuration.Observe(time.Since(startCommit).Seconds())
+
+	for _, block := range blocks {
+		br.setLastCommittedBlock(block)
+	}
+
+	return nil
+}
+
+// checkBlockTimestamp flags a block whose timestamp precedes the timestamp of the previous block, or deviates from
+// the local clock by more than MaxBlockTimestampSkew: it is counted by the block timestamps out of bound metric, and
+// logged as an error. The block is committed regardless, as it was already agreed upon by consensus, and members with
+// different clocks must still commit the same blocks.
+func (br *BlockReplicator) checkBlockTimestamp(block, previous *types.Block) {
+	timestamp := block.GetHeader().GetBaseHeader().GetTimestamp()
+	if timestamp == 0 {
+		return // blocks proposed before timestamps were introduced
+	}
+
+	previousTimestamp := previous.GetHeader().GetBaseHeader().GetTimestamp()
+	if err := verifyBlockTimestamp(timestamp, previousTimestamp, br.clock.Now(), MaxBlockTimestampSkew); err != nil {
+		br.metrics.blockTimestampsOutOfBound.Inc()
+		br.lg.Errorf("Block [%d] is flagged, its %s; the clocks of the cluster members may be out of sync",
+			block.GetHeader().GetBaseHeader().GetNumber(), err)
+	}
+}
+
 func (br *BlockReplicator) setLastCommittedBlock(block *types.Block) {
 	br.mutex.Lock()
 	defer br.mutex.Unlock()
 
 	br.lastCommittedBlock = block
 
+	if proposedAt, ok := br.proposalTimes[block.GetHeader().GetBaseHeader().GetNumber()]; ok { // only on the leader
+		br.metrics.proposalCommitLatency.Observe(time.Since(proposedAt).Seconds())
+		delete(br.proposalTimes, block.GetHeader().GetBaseHeader().GetNumber())
+	}
+
 	var doBroadcast bool
 
 	if br.numInFlightBlocks > 0 { // only reduce on the leader
@@ -1114,6 +1699,13 @@ func (br *BlockReplicator) setLastCommittedBlock(block *types.Block) {
 	}
 }
 
+func (br *BlockReplicator) getLastCommittedBlock() *types.Block {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	return br.lastCommittedBlock
+}
+
 func (br *BlockReplicator) getLastCommittedBlockNumber() uint64 {
 	br.mutex.Lock()
 	defer br.mutex.Unlock()
@@ -1124,6 +1716,11 @@ func (br *BlockReplicator) getLastCommittedBlockNumber() uint64 {
 func (br *BlockReplicator) updateClusterConfig(clusterConfig *types.ClusterConfig) error {
 	br.lg.Infof("New cluster config committed, going to apply to block replicator: %+v", clusterConfig)
 
+	// A member that does not support the protocol version cannot go on, it must be upgraded first.
+	if err := comm.VerifyProtocolVersionSupported(clusterConfig); err != nil {
+		return errors.WithMessage(err, "the server must be upgraded")
+	}
+
 	br.mutex.Lock()
 	defer br.mutex.Unlock()
 
@@ -1232,6 +1829,7 @@ func (br *BlockReplicator) ReportUnreachable(id uint64) {
 	}
 
 	br.lg.Debugf("ReportUnreachable: %d", id)
+	br.metrics.unreachableReports.Inc()
 	br.raftNode.ReportUnreachable(id)
 }
 
@@ -1245,15 +1843,25 @@ func (br *BlockReplicator) ReportSnapshot(id uint64, status raft.SnapshotStatus)
 	}
 
 	br.lg.Debugf("ReportSnapshot: %d, %v", id, status)
+	if status == raft.SnapshotFailure {
+		br.metrics.snapshotSendFailures.Inc()
+	}
 	br.raftNode.ReportSnapshot(id, status)
 }
 
 // called inside a br.mutex.Lock()
 func (br *BlockReplicator) insertBlockBaseHeader(proposedBlock *types.Block) {
 	blockNum := br.lastProposedBlockNumber + 1
+	// The timestamp never decreases along the chain, even if the clock of the leader goes back, or if the clock
+	// of a newly elected leader is behind the clock of the previous one.
+	timestamp := br.clock.Now().UnixNano()
+	if timestamp < br.lastProposedBlockTimestamp {
+		timestamp = br.lastProposedBlockTimestamp
+	}
 	baseHeader := &types.BlockHeaderBase{
 		Number:                 blockNum,
 		PreviousBaseHeaderHash: br.lastProposedBlockHeaderBaseHash,
+		Timestamp:              timestamp,
 	}
 
 	if blockNum > 1 {
@@ -748,3 +748,176 @@ func TestBlockReplicator_3Node_InFlightBlocks(t *testing.T) {
 
 	require.True(t, isCountOver(4))
 }
+
+// Scenario:
+// - Start 3 nodes together, wait for leader, submit blocks;
+// - transfer leadership from a follower, and to the leader itself, both fail;
+// - transfer leadership to a chosen follower, wait for all ledgers to get the blocks;
+// - transfer leadership again, letting the leader choose the transferee;
+// - submit blocks to the new leader, wait for all ledgers to get them.
+func TestBlockReplicator_3Node_TransferLeadership(t *testing.T) {
+	env := createClusterEnv(t, 3, nil, "info")
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 3, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	assert.Eventually(t, func() bool { return env.ExistsAgreedLeader() }, 30*time.Second, 100*time.Millisecond)
+	assert.Eventually(t, func() bool { return env.SymmetricConnectivity() }, 30*time.Second, 100*time.Millisecond)
+
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:                1,
+				LastCommittedBlockNum: 1,
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{},
+	}
+
+	submitBlocks := func(leaderIdx int, numBlocks int) {
+		for i := 0; i < numBlocks; i++ {
+			err := env.nodes[leaderIdx].blockReplicator.Submit(proto.Clone(block).(*types.Block))
+			require.NoError(t, err)
+		}
+	}
+
+	leaderIdx := env.AgreedLeaderIndex()
+	follower1 := (leaderIdx + 1) % 3
+	submitBlocks(leaderIdx, 10)
+	// blocks that are not yet proposed when the transfer starts are declined, wait for all of them to commit
+	assert.Eventually(t, func() bool { return env.AssertEqualHeight(11) }, 30*time.Second, 100*time.Millisecond)
+
+	_, err := env.nodes[follower1].blockReplicator.TransferLeadership("node3", time.Second)
+	require.Error(t, err)
+	require.IsType(t, &interrors.NotLeaderError{}, err)
+
+	leaderNodeID := fmt.Sprintf("node%d", leaderIdx+1)
+	_, err = env.nodes[leaderIdx].blockReplicator.TransferLeadership(leaderNodeID, time.Second)
+	require.EqualError(t, err, "node ["+leaderNodeID+"] is already the leader")
+
+	_, err = env.nodes[leaderIdx].blockReplicator.TransferLeadership("node7", time.Second)
+	require.EqualError(t, err, "node [node7] is not a consensus member")
+
+	targetNodeID := fmt.Sprintf("node%d", follower1+1)
+	newLeaderID, err := env.nodes[leaderIdx].blockReplicator.TransferLeadership(targetNodeID, 0)
+	require.NoError(t, err)
+	require.Equal(t, targetNodeID, newLeaderID)
+	assert.Eventually(t, func() bool { return env.AgreedLeaderIndex() == follower1 }, 30*time.Second, 100*time.Millisecond)
+	assert.Eventually(t, func() bool { return env.AssertEqualHeight(11) }, 30*time.Second, 100*time.Millisecond)
+
+	newLeaderID, err = env.nodes[follower1].blockReplicator.TransferLeadership("", 0)
+	require.NoError(t, err)
+	require.NotEqual(t, targetNodeID, newLeaderID)
+	leaderIdx = -1
+	assert.Eventually(t, func() bool {
+		leaderIdx = env.AgreedLeaderIndex()
+		return leaderIdx >= 0 && fmt.Sprintf("node%d", leaderIdx+1) == newLeaderID
+	}, 30*time.Second, 100*time.Millisecond)
+
+	submitBlocks(leaderIdx, 10)
+	assert.Eventually(t, func() bool { return env.AssertEqualHeight(21) }, 30*time.Second, 100*time.Millisecond)
+
+	for _, node := range env.nodes {
+		err := node.Close()
+		require.NoError(t, err)
+	}
+}
+
+// Scenario:
+// - Start 3 nodes together, wait for leader, submit some blocks,
+// - check the consensus diagnostics of the leader and the followers,
+// - stop the leader, wait for a new leader, and check that the leader change is counted.
+func TestBlockReplicator_3Node_Diagnostics(t *testing.T) {
+	env := createClusterEnv(t, 3, nil, "info")
+	defer os.RemoveAll(env.testDir)
+	require.Equal(t, 3, len(env.nodes))
+
+	for _, node := range env.nodes {
+		err := node.Start()
+		require.NoError(t, err)
+	}
+
+	assert.Eventually(t, func() bool { return env.ExistsAgreedLeader() }, 30*time.Second, 100*time.Millisecond)
+	assert.Eventually(t, func() bool { return env.SymmetricConnectivity() }, 30*time.Second, 100*time.Millisecond)
+
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:                1,
+				LastCommittedBlockNum: 1,
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{},
+	}
+
+	leaderIdx := env.AgreedLeaderIndex()
+	for i := 0; i < 10; i++ {
+		err := env.nodes[leaderIdx].blockReplicator.Submit(proto.Clone(block).(*types.Block))
+		require.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return env.AssertEqualHeight(11) }, 30*time.Second, 100*time.Millisecond)
+
+	leaderRaftID := uint64(leaderIdx + 1)
+	assert.Eventually(t, func() bool {
+		diag := env.nodes[leaderIdx].blockReplicator.Diagnostics()
+		if len(diag.Peers) != 2 {
+			return false
+		}
+		for _, peer := range diag.Peers {
+			if !peer.Active || peer.MatchIndex != diag.CommitIndex || peer.ProgressState != "StateReplicate" {
+				return false
+			}
+		}
+		return diag.RaftState == "StateLeader" && diag.LeaderID == leaderRaftID && diag.LastCommittedBlockNumber == 11
+	}, 30*time.Second, 100*time.Millisecond)
+
+	leaderDiag := env.nodes[leaderIdx].blockReplicator.Diagnostics()
+	require.Equal(t, leaderRaftID, leaderDiag.RaftID)
+	require.True(t, leaderDiag.Term > 0)
+	require.True(t, leaderDiag.NumLeaderChanges >= 1)
+	require.True(t, leaderDiag.NumElections >= 1)
+	require.False(t, leaderDiag.LastLeaderChangeTime.IsZero())
+	require.Equal(t, uint32(0), leaderDiag.InFlightBlocks)
+	require.Equal(t, uint64(11), leaderDiag.LastProposedBlockNumber)
+
+	for i, node := range env.nodes {
+		if i == leaderIdx {
+			continue
+		}
+		diag := node.blockReplicator.Diagnostics()
+		require.Equal(t, "StateFollower", diag.RaftState)
+		require.Equal(t, leaderRaftID, diag.LeaderID)
+		require.Equal(t, leaderDiag.Term, diag.Term)
+		require.Equal(t, uint64(11), diag.LastCommittedBlockNumber)
+		require.Len(t, diag.Peers, 2)
+		for _, peer := range diag.Peers {
+			require.Equal(t, uint64(0), peer.MatchIndex) // progress is tracked only by the leader
+			require.Equal(t, "", peer.ProgressState)
+		}
+	}
+
+	err := env.nodes[leaderIdx].Close()
+	require.NoError(t, err)
+
+	follower1 := (leaderIdx + 1) % 3
+	assert.Eventually(t, func() bool {
+		diag := env.nodes[follower1].blockReplicator.Diagnostics()
+		return diag.LeaderID != 0 && diag.LeaderID != leaderRaftID
+	}, 30*time.Second, 100*time.Millisecond)
+
+	diag := env.nodes[follower1].blockReplicator.Diagnostics()
+	require.True(t, diag.NumLeaderChanges >= 2)
+	require.True(t, diag.Term > leaderDiag.Term)
+
+	for i, node := range env.nodes {
+		if i == leaderIdx {
+			continue
+		}
+		err := node.Close()
+		require.NoError(t, err)
+	}
+}
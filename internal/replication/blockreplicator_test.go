@@ -11,10 +11,12 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/config"
 	"github.com/hyperledger-labs/orion-server/internal/comm"
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
 	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -153,6 +155,8 @@ func TestBlockReplicator_Submit(t *testing.T) {
 		require.NotNil(t, block2commit.(*types.Block).GetConsensusMetadata())
 		raftIndex := block2commit.(*types.Block).GetConsensusMetadata().GetRaftIndex()
 		require.True(t, raftIndex > 0)
+		timestamp := block2commit.(*types.Block).GetHeader().GetBaseHeader().GetTimestamp()
+		require.True(t, timestamp > 0)
 		err = env.conf.BlockOneQueueBarrier.Reply(nil)
 		require.NoError(t, err)
 
@@ -172,6 +176,7 @@ func TestBlockReplicator_Submit(t *testing.T) {
 		require.True(t, proto.Equal(proposeBlock.GetHeader(), block2commit.(*types.Block).GetHeader()), "in: %+v, out: %+v", proposeBlock, block2commit)
 		require.NotNil(t, block2commit.(*types.Block).GetConsensusMetadata())
 		require.True(t, block2commit.(*types.Block).GetConsensusMetadata().GetRaftIndex() > raftIndex)
+		require.True(t, block2commit.(*types.Block).GetHeader().GetBaseHeader().GetTimestamp() >= timestamp)
 		err = env.conf.BlockOneQueueBarrier.Reply(nil)
 		require.NoError(t, err)
 
@@ -480,6 +485,81 @@ func TestBlockReplicator_ReConfig(t *testing.T) {
 	})
 }
 
+// Scenario:
+// - configure the cluster at the protocol version of compressed entries, and the node to compress blocks with snappy;
+// - submit large blocks that compress well, and verify they commit uncompressed;
+// - verify snapshots are taken according to the size of the compressed entries;
+// - close the node and restart it, the node is expected to recover from the last snapshot and the following entries;
+// - submit more blocks, and make sure they commit, then close.
+func TestBlockReplicator_CompressedEntries(t *testing.T) {
+	lg := testLogger(t, "info")
+	testDir, err := ioutil.TempDir("", "replication-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	block, blockLen := testDataBlock(10000)
+	compressed, err := comm.Compress(comm.CodecSnappy, utils.MarshalOrPanic(block))
+	require.NoError(t, err)
+	entryLen := uint64(len(compressed) + 2)
+	require.Less(t, entryLen, blockLen)
+
+	clusterConfig := proto.Clone(clusterConfig1node).(*types.ClusterConfig)
+	clusterConfig.ProtocolVersion = comm.ProtocolVersionCompressedEntries
+	clusterConfig.ConsensusConfig.RaftConfig.SnapshotIntervalSize = 2 * entryLen // take a snapshot every 2 blocks
+	env, err := newNodeEnvWithCompression(1, testDir, lg, clusterConfig, config.ReplicationCompressionConf{
+		Codecs:        []string{comm.CodecSnappy},
+		MinEntryBytes: 1,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, env)
+
+	err = env.Start()
+	require.NoError(t, err)
+
+	isLeaderCond := func() bool {
+		return env.blockReplicator.IsLeader() == nil
+	}
+	assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+	submitBlocks := func(numBlocks uint64) {
+		heightBefore, err := env.ledger.Height()
+		require.NoError(t, err)
+		for i := uint64(0); i < numBlocks; i++ {
+			b := proto.Clone(block).(*types.Block)
+			b.Header.BaseHeader.Number = heightBefore + 1 + i
+			err := env.blockReplicator.Submit(b)
+			require.NoError(t, err)
+		}
+		assert.Eventually(t, func() bool {
+			h, err := env.ledger.Height()
+			return err == nil && h == heightBefore+numBlocks
+		}, 30*time.Second, 100*time.Millisecond)
+
+		for n := heightBefore + 1; n <= heightBefore+numBlocks; n++ {
+			b, err := env.ledger.Get(n)
+			require.NoError(t, err)
+			require.Equal(t, n, b.GetHeader().GetBaseHeader().GetNumber())
+			require.Equal(t, 10000, len(b.GetDataTxEnvelopes().Envelopes[0].Payload.DbOperations[0].DataWrites[0].Value))
+		}
+	}
+
+	submitBlocks(7)
+
+	err = env.Close()
+	require.NoError(t, err)
+	snapList := replication.ListSnapshots(env.conf.Logger, env.conf.LocalConf.Replication.SnapDir)
+	require.Equal(t, 3, len(snapList))
+
+	err = env.Restart()
+	require.NoError(t, err)
+	assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+	submitBlocks(3)
+
+	err = env.Close()
+	require.NoError(t, err)
+}
+
 // Scenario: check that snapshots are taken as expected, and can be recovered from.
 func TestBlockReplicator_Snapshots(t *testing.T) {
 	// Scenario:
@@ -672,4 +752,243 @@ func TestBlockReplicator_Snapshots(t *testing.T) {
 		err = env.Close()
 		require.NoError(t, err)
 	})
+
+	// Scenario:
+	// - never take snapshots by size;
+	// - submit blocks, trigger a snapshot, and verify it is taken at the last block;
+	// - trigger again without new blocks, and verify it fails;
+	// - restart with a block interval of 2, submit blocks, and verify snapshots are taken.
+	t.Run("trigger a snapshot and take snapshots by block interval", func(t *testing.T) {
+		lg := testLogger(t, "info")
+		testDir, err := ioutil.TempDir("", "replication-test")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                1,
+					LastCommittedBlockNum: 1,
+				},
+			},
+			Payload: &types.Block_DataTxEnvelopes{},
+		}
+
+		env, err := newNodeEnv(1, testDir, lg, clusterConfig1node)
+		require.NoError(t, err)
+		require.NotNil(t, env)
+
+		err = env.Start()
+		require.NoError(t, err)
+
+		isLeaderCond := func() bool {
+			return env.blockReplicator.IsLeader() == nil
+		}
+		assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+		submitBlocks := func(from, to uint64) {
+			for i := from; i <= to; i++ {
+				b := proto.Clone(block).(*types.Block)
+				b.Header.BaseHeader.Number = i
+				err := env.blockReplicator.Submit(b)
+				require.NoError(t, err)
+			}
+			assert.Eventually(t, func() bool {
+				h, err := env.ledger.Height()
+				return err == nil && h == to
+			}, 30*time.Second, 100*time.Millisecond)
+		}
+
+		submitBlocks(2, 6)
+
+		info, err := env.blockReplicator.TriggerSnapshot()
+		require.NoError(t, err)
+		require.Equal(t, uint64(6), info.BlockNumber)
+		require.True(t, info.RaftIndex > 0)
+
+		info, err = env.blockReplicator.TriggerSnapshot()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no entries were applied since the last snapshot")
+		require.Nil(t, info)
+
+		err = env.Close()
+		require.NoError(t, err)
+
+		snapList := replication.ListSnapshots(env.conf.Logger, env.conf.LocalConf.Replication.SnapDir)
+		require.Equal(t, 1, len(snapList))
+
+		env.conf.LocalConf.Replication.SnapshotIntervalBlocks = 2
+		err = env.Restart()
+		require.NoError(t, err)
+		assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+		submitBlocks(7, 10)
+
+		err = env.Close()
+		require.NoError(t, err)
+
+		snapList = replication.ListSnapshots(env.conf.Logger, env.conf.LocalConf.Replication.SnapDir)
+		require.True(t, len(snapList) >= 2, "snapshots: %v", snapList)
+	})
+}
+
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = now
+}
+
+// Scenario: the leader assigns the timestamps of the blocks from the configured clock, and the timestamps never go
+// backwards, even when the clock does.
+func TestBlockReplicator_Timestamps(t *testing.T) {
+	env := createNodeEnv(t, "info")
+	require.NotNil(t, env)
+	defer os.RemoveAll(env.testDir)
+
+	err := env.conf.Transport.Start()
+	require.NoError(t, err)
+	env.blockReplicator.Start()
+
+	isLeaderCond := func() bool {
+		return env.blockReplicator.IsLeader() == nil
+	}
+	assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+	err = env.blockReplicator.Close()
+	require.NoError(t, err)
+	env.conf.Transport.Close()
+
+	// recreate with a clock
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: now}
+	env.conf.Clock = clock
+	env.conf.Transport, _ = comm.NewHTTPTransport(&comm.Config{LocalConf: env.conf.LocalConf, Logger: env.conf.Logger})
+	env.conf.BlockOneQueueBarrier = queue.NewOneQueueBarrier(env.conf.Logger)
+	env.blockReplicator, err = replication.NewBlockReplicator(env.conf)
+	require.NoError(t, err)
+	err = env.conf.Transport.SetConsensusListener(env.blockReplicator)
+	require.NoError(t, err)
+	err = env.conf.Transport.SetClusterConfig(env.conf.ClusterConfig)
+	require.NoError(t, err)
+
+	err = env.conf.Transport.Start()
+	require.NoError(t, err)
+	env.blockReplicator.Start()
+	defer func() {
+		require.NoError(t, env.blockReplicator.Close())
+		env.conf.Transport.Close()
+	}()
+
+	assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+	submitAndCommit := func(number uint64) int64 {
+		err := env.blockReplicator.Submit(&types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                number,
+					LastCommittedBlockNum: number - 1,
+				},
+			},
+		})
+		require.NoError(t, err)
+		block2commit, err := env.conf.BlockOneQueueBarrier.Dequeue()
+		require.NoError(t, err)
+		require.NoError(t, env.conf.BlockOneQueueBarrier.Reply(nil))
+		return block2commit.(*types.Block).GetHeader().GetBaseHeader().GetTimestamp()
+	}
+
+	require.Equal(t, now.UnixNano(), submitAndCommit(1))
+
+	clock.Set(now.Add(time.Second))
+	require.Equal(t, now.Add(time.Second).UnixNano(), submitAndCommit(2))
+
+	// the clock goes back, the timestamp does not
+	clock.Set(now.Add(-time.Hour))
+	require.Equal(t, now.Add(time.Second).UnixNano(), submitAndCommit(3))
+}
+
+// Scenario: data blocks that are committed together by raft are enqueued for commit as a group, in order, while other
+// blocks are enqueued one by one.
+func TestBlockReplicator_GroupsDataBlocks(t *testing.T) {
+	lg := testLogger(t, "info")
+	testDir, err := ioutil.TempDir("", "replication-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	// raft delivers a single committed entry at a time when the size of a block is not limited
+	env, err := newNodeEnvWithConf(1, testDir, lg, clusterConfig1node, config.ReplicationCompressionConf{},
+		config.BlockCreationConf{MaxBlockSizeBytes: 1024 * 1024})
+	require.NoError(t, err)
+
+	err = env.conf.Transport.Start()
+	require.NoError(t, err)
+	env.blockReplicator.Start()
+	defer func() {
+		require.NoError(t, env.blockReplicator.Close())
+		env.conf.Transport.Close()
+	}()
+
+	isLeaderCond := func() bool {
+		return env.blockReplicator.IsLeader() == nil
+	}
+	assert.Eventually(t, isLeaderCond, 30*time.Second, 100*time.Millisecond)
+
+	submit := func(number uint64, dataBlock bool) {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                number,
+					LastCommittedBlockNum: number - 1,
+				},
+			},
+		}
+		if dataBlock {
+			block.Payload = &types.Block_DataTxEnvelopes{DataTxEnvelopes: &types.DataTxEnvelopes{}}
+		}
+		require.NoError(t, env.blockReplicator.Submit(block))
+	}
+
+	// the replicator is blocked until the first block is committed, while the following blocks are submitted
+	submit(1, true)
+	first, err := env.conf.BlockOneQueueBarrier.Dequeue()
+	require.NoError(t, err)
+	firstNumber := first.(*types.Block).GetHeader().GetBaseHeader().GetNumber()
+	for number := uint64(2); number <= 5; number++ {
+		submit(number, true)
+	}
+	submit(6, false)
+	time.Sleep(500 * time.Millisecond)
+	require.NoError(t, env.conf.BlockOneQueueBarrier.Reply(nil))
+
+	var numbers []uint64
+	var groups int
+	for len(numbers) < 5 {
+		entry, err := env.conf.BlockOneQueueBarrier.Dequeue()
+		require.NoError(t, err)
+		switch blocks := entry.(type) {
+		case []*types.Block:
+			require.Greater(t, len(blocks), 1)
+			groups++
+			for _, block := range blocks {
+				require.NotNil(t, block.GetDataTxEnvelopes())
+				numbers = append(numbers, block.GetHeader().GetBaseHeader().GetNumber())
+			}
+		case *types.Block:
+			numbers = append(numbers, blocks.GetHeader().GetBaseHeader().GetNumber())
+		}
+		require.NoError(t, env.conf.BlockOneQueueBarrier.Reply(nil))
+	}
+	require.Equal(t, []uint64{firstNumber + 1, firstNumber + 2, firstNumber + 3, firstNumber + 4, firstNumber + 5}, numbers)
+	require.Greater(t, groups, 0)
 }
@@ -0,0 +1,43 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Clock tells the block replicator the time, from which the leader assigns the timestamps of the blocks it proposes,
+// and against which the members check the timestamps of the blocks they commit.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the Clock used when none is configured
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// verifyBlockTimestamp checks that the timestamp of a block does not precede the timestamp of the previous block,
+// and that it deviates from the local time by at most maxSkew.
+func verifyBlockTimestamp(timestamp, previous int64, now time.Time, maxSkew time.Duration) error {
+	if timestamp < previous {
+		return errors.Errorf("timestamp [%s] precedes the timestamp of the previous block [%s]",
+			time.Unix(0, timestamp).UTC(), time.Unix(0, previous).UTC())
+	}
+
+	skew := now.Sub(time.Unix(0, timestamp))
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > maxSkew {
+		return errors.Errorf("timestamp [%s] deviates from the local clock by %s, which exceeds the bound of %s",
+			time.Unix(0, timestamp).UTC(), skew, maxSkew)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBlockTimestamp(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	previous := now.Add(-time.Minute).UnixNano()
+
+	require.NoError(t, verifyBlockTimestamp(now.UnixNano(), previous, now, MaxBlockTimestampSkew))
+	require.NoError(t, verifyBlockTimestamp(previous, previous, now.Add(-30*time.Second), MaxBlockTimestampSkew))
+	require.NoError(t, verifyBlockTimestamp(now.Add(MaxBlockTimestampSkew).UnixNano(), previous, now, MaxBlockTimestampSkew))
+
+	err := verifyBlockTimestamp(previous-1, previous, now, MaxBlockTimestampSkew)
+	require.EqualError(t, err, "timestamp [2021-06-01 11:58:59.999999999 +0000 UTC] precedes the timestamp of the previous block [2021-06-01 11:59:00 +0000 UTC]")
+
+	err = verifyBlockTimestamp(now.Add(time.Hour).UnixNano(), previous, now, MaxBlockTimestampSkew)
+	require.EqualError(t, err, "timestamp [2021-06-01 13:00:00 +0000 UTC] deviates from the local clock by 1h0m0s, which exceeds the bound of 30s")
+
+	err = verifyBlockTimestamp(previous, previous, now, MaxBlockTimestampSkew)
+	require.EqualError(t, err, "timestamp [2021-06-01 11:59:00 +0000 UTC] deviates from the local clock by 1m0s, which exceeds the bound of 30s")
+}
@@ -0,0 +1,73 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/pkg/errors"
+)
+
+// DefaultMinCompressedEntryBytes is the size of a marshaled block below which it is not compressed in the Raft log,
+// when none is configured
+const DefaultMinCompressedEntryBytes = 1024
+
+// The data of a normal entry of the Raft log is a marshaled block. From protocol version
+// comm.ProtocolVersionCompressedEntries, it may instead be a compressed block: the compressedEntryMarker, the ID of the
+// codec, and the compressed marshaled block. A marshaled block never starts with the marker, as zero is not a valid
+// protobuf field tag. The snapshots and the ledger always hold uncompressed blocks.
+const compressedEntryMarker byte = 0
+
+var (
+	entryCodecIDs = map[string]byte{
+		comm.CodecSnappy: 1,
+		comm.CodecGzip:   2,
+	}
+	entryCodecNames = map[byte]string{
+		1: comm.CodecSnappy,
+		2: comm.CodecGzip,
+	}
+)
+
+// encodeBlockEntry returns the data of the entry of a marshaled block. The block is compressed with the codec, unless
+// the codec is empty, the block is smaller than minBytes, or the compression does not make it smaller.
+func encodeBlockEntry(blockBytes []byte, codec string, minBytes uint64) ([]byte, error) {
+	if codec == "" || uint64(len(blockBytes)) < minBytes {
+		return blockBytes, nil
+	}
+
+	id, ok := entryCodecIDs[codec]
+	if !ok {
+		return nil, errors.Errorf("compression codec [%s] is not supported", codec)
+	}
+
+	compressed, err := comm.Compress(codec, blockBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed)+2 >= len(blockBytes) {
+		return blockBytes, nil
+	}
+
+	data := make([]byte, 0, len(compressed)+2)
+	data = append(data, compressedEntryMarker, id)
+	return append(data, compressed...), nil
+}
+
+// decodeBlockEntry returns the marshaled block held by the data of a normal entry. A compressed block that is larger
+// than maxBytes once decompressed is rejected.
+func decodeBlockEntry(data []byte, maxBytes uint64) ([]byte, error) {
+	if len(data) == 0 || data[0] != compressedEntryMarker {
+		return data, nil
+	}
+
+	if len(data) < 2 {
+		return nil, errors.New("compressed entry is truncated")
+	}
+	codec, ok := entryCodecNames[data[1]]
+	if !ok {
+		return nil, errors.Errorf("compression codec ID [%d] of entry is not supported", data[1])
+	}
+
+	return comm.Decompress(codec, data[2:], maxBytes)
+}
@@ -0,0 +1,99 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockEntry(t *testing.T) {
+	block := &types.Block{
+		Header: &types.BlockHeader{BaseHeader: &types.BlockHeaderBase{Number: 7}},
+		Payload: &types.Block_DataTxEnvelopes{DataTxEnvelopes: &types.DataTxEnvelopes{
+			Envelopes: []*types.DataTxEnvelope{
+				{
+					Payload: &types.DataTx{
+						MustSignUserIds: []string{"alice"},
+						TxId:            "txid",
+						DbOperations: []*types.DBOperation{
+							{
+								DbName: "bdb",
+								DataWrites: []*types.DataWrite{
+									{Key: "key", Value: bytes.Repeat([]byte("value"), 1000)},
+								},
+							},
+						},
+					},
+				},
+			},
+		}},
+	}
+	blockBytes, err := proto.Marshal(block)
+	require.NoError(t, err)
+
+	for _, codec := range []string{comm.CodecSnappy, comm.CodecGzip} {
+		t.Run("compressed: "+codec, func(t *testing.T) {
+			data, err := encodeBlockEntry(blockBytes, codec, DefaultMinCompressedEntryBytes)
+			require.NoError(t, err)
+			require.Equal(t, compressedEntryMarker, data[0])
+			require.Equal(t, entryCodecIDs[codec], data[1])
+			require.Less(t, len(data), len(blockBytes))
+
+			decoded, err := decodeBlockEntry(data, uint64(len(blockBytes)))
+			require.NoError(t, err)
+			require.Equal(t, blockBytes, decoded)
+
+			decoded, err = decodeBlockEntry(data, uint64(len(blockBytes)-1))
+			require.EqualError(t, err, fmt.Sprintf("failed to decompress with codec [%s]: the payload decompressed with codec [%s] is larger than %d bytes", codec, codec, len(blockBytes)-1))
+			require.Nil(t, decoded)
+		})
+	}
+
+	t.Run("not compressed", func(t *testing.T) {
+		data, err := encodeBlockEntry(blockBytes, "", DefaultMinCompressedEntryBytes)
+		require.NoError(t, err)
+		require.Equal(t, blockBytes, data)
+
+		data, err = encodeBlockEntry(blockBytes, comm.CodecSnappy, uint64(len(blockBytes)+1))
+		require.NoError(t, err)
+		require.Equal(t, blockBytes, data)
+
+		random := make([]byte, 4096)
+		_, err = rand.Read(random)
+		require.NoError(t, err)
+		random[0] = 0x0a
+		data, err = encodeBlockEntry(random, comm.CodecGzip, DefaultMinCompressedEntryBytes)
+		require.NoError(t, err)
+		require.Equal(t, random, data)
+
+		decoded, err := decodeBlockEntry(blockBytes, uint64(len(blockBytes)))
+		require.NoError(t, err)
+		require.Equal(t, blockBytes, decoded)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		data, err := encodeBlockEntry(blockBytes, "br", DefaultMinCompressedEntryBytes)
+		require.EqualError(t, err, "compression codec [br] is not supported")
+		require.Nil(t, data)
+
+		data, err = decodeBlockEntry([]byte{compressedEntryMarker}, comm.DefaultMaxDecompressedBlockBytes)
+		require.EqualError(t, err, "compressed entry is truncated")
+		require.Nil(t, data)
+
+		data, err = decodeBlockEntry([]byte{compressedEntryMarker, 9, 1, 2, 3}, comm.DefaultMaxDecompressedBlockBytes)
+		require.EqualError(t, err, "compression codec ID [9] of entry is not supported")
+		require.Nil(t, data)
+
+		_, err = decodeBlockEntry([]byte{compressedEntryMarker, entryCodecIDs[comm.CodecGzip], 1, 2, 3}, comm.DefaultMaxDecompressedBlockBytes)
+		require.Error(t, err)
+	})
+}
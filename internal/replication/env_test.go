@@ -13,7 +13,9 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
 	"github.com/hyperledger-labs/orion-server/internal/comm"
+	"github.com/hyperledger-labs/orion-server/internal/mtree"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
 	"github.com/hyperledger-labs/orion-server/internal/replication"
 	"github.com/hyperledger-labs/orion-server/internal/replication/mocks"
@@ -165,12 +167,18 @@ func (n *nodeEnv) ServeCommit() {
 				lg.Errorf("Stopping to serve commit loop, error: %s", err)
 				return
 			}
-			block2commit := b.(*types.Block)
-			err = n.ledger.Append(block2commit)
-			if err != nil {
-				lg.Panicf("Stopping to serve commit loop, error: %s", err)
-				return
+			// a group of consecutive data blocks is committed together
+			blocks, ok := b.([]*types.Block)
+			if !ok {
+				blocks = []*types.Block{b.(*types.Block)}
+			}
+			for _, block := range blocks {
+				if err = n.ledger.Append(block); err != nil {
+					lg.Panicf("Stopping to serve commit loop, error: %s", err)
+					return
+				}
 			}
+			block2commit := blocks[len(blocks)-1]
 			switch block2commit.Payload.(type) {
 			case *types.Block_ConfigTxEnvelope:
 				clusterConfig := block2commit.GetConfigTxEnvelope().GetPayload().GetNewConfig()
@@ -256,6 +264,14 @@ func createClusterEnv(t *testing.T, nNodes int, raftConf *types.RaftConfig, logL
 
 // create a BlockReplicator environment with a genesis block
 func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig) (*nodeEnv, error) {
+	return newNodeEnvWithCompression(n, testDir, lg, clusterConfig, config.ReplicationCompressionConf{})
+}
+
+func newNodeEnvWithCompression(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig, compression config.ReplicationCompressionConf) (*nodeEnv, error) {
+	return newNodeEnvWithConf(n, testDir, lg, clusterConfig, compression, config.BlockCreationConf{})
+}
+
+func newNodeEnvWithConf(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig *types.ClusterConfig, compression config.ReplicationCompressionConf, blockCreation config.BlockCreationConf) (*nodeEnv, error) {
 	nodeID := fmt.Sprintf("node%d", n)
 	localTestDir := path.Join(testDir, nodeID)
 
@@ -275,7 +291,9 @@ func newNodeEnv(n uint32, testDir string, lg *logger.SugarLogger, clusterConfig
 			TLS: config.TLSConf{
 				Enabled: false,
 			},
+			Compression: compression,
 		},
+		BlockCreation: blockCreation,
 	}
 
 	qBarrier := queue.NewOneQueueBarrier(lg)
@@ -658,10 +676,48 @@ func (l *memLedger) Append(block *types.Block) error {
 			block.GetHeader().GetBaseHeader().Number)
 	}
 
+	if err := l.completeHeader(block); err != nil {
+		return err
+	}
+
 	l.ledger = append(l.ledger, block)
 	return nil
 }
 
+// completeHeader fills the validation info, the tx Merkle tree root and the link to the previous block in the header,
+// the way the block processor does before the block is committed, so that catch-up can verify the blocks it pulls.
+func (l *memLedger) completeHeader(block *types.Block) error {
+	if h := len(l.ledger); h > 0 {
+		prevHash, err := blockstore.ComputeBlockHash(l.ledger[h-1])
+		if err != nil {
+			return err
+		}
+		block.Header.SkipchainHashes = [][]byte{prevHash}
+	}
+
+	if block.GetPayload() == nil {
+		return nil
+	}
+
+	txs := 1
+	if envelopes := block.GetDataTxEnvelopes(); envelopes != nil {
+		txs = len(envelopes.GetEnvelopes())
+	}
+	if len(block.GetHeader().GetValidationInfo()) != txs {
+		block.Header.ValidationInfo = nil
+		for i := 0; i < txs; i++ {
+			block.Header.ValidationInfo = append(block.Header.ValidationInfo, &types.ValidationInfo{Flag: types.Flag_VALID})
+		}
+	}
+
+	root, err := mtree.BuildTreeForBlockTx(block)
+	if err != nil {
+		return err
+	}
+	block.Header.TxMerkelTreeRootHash = root.Hash()
+	return nil
+}
+
 func (l *memLedger) Get(blockNum uint64) (*types.Block, error) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
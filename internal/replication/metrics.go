@@ -0,0 +1,165 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package replication
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The consensus metrics are registered with the default Prometheus registry, together with the network metrics of the
+// etcd/raft HTTP transport (e.g., etcd_network_peer_round_trip_time_seconds, etcd_network_peer_sent_failures_total),
+// which cover the heartbeat round-trip times and the messages dropped when sending to a peer fails.
+//
+// The proposal commit latency is measured by the leader, from the moment a block is proposed until it is committed,
+// whereas the block commit duration measures only the time the block processor takes to validate and commit a block
+// delivered by consensus. Comparing the two separates a stall in consensus from a stall in the committer.
+
+const (
+	metricsNamespace = "orion"
+	metricsSubsystem = "consensus"
+	metricsNodeLabel = "node"
+)
+
+var (
+	termGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "term",
+		Help:      "The current Raft term.",
+	}, []string{metricsNodeLabel})
+
+	isLeaderGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "is_leader",
+		Help:      "Whether the node is the Raft leader (1) or not (0).",
+	}, []string{metricsNodeLabel})
+
+	leaderChangesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "leader_changes_total",
+		Help:      "The number of leader changes observed by the node, including the loss of a leader.",
+	}, []string{metricsNodeLabel})
+
+	electionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "elections_total",
+		Help:      "The number of elections the node started as a candidate.",
+	}, []string{metricsNodeLabel})
+
+	unreachableReportsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "unreachable_reports_total",
+		Help:      "The number of times a peer was reported unreachable by the transport, after failing to send it a message.",
+	}, []string{metricsNodeLabel})
+
+	snapshotSendFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "snapshot_send_failures_total",
+		Help:      "The number of snapshots that failed to be sent to a peer.",
+	}, []string{metricsNodeLabel})
+
+	walSyncDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "wal_sync_duration_seconds",
+		Help:      "The time it takes to persist Raft entries and state to the WAL.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{metricsNodeLabel})
+
+	proposalCommitLatencyHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "proposal_commit_latency_seconds",
+		Help:      "The time from proposing a block until it is committed, measured on the leader.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{metricsNodeLabel})
+
+	blockCommitDurationHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "block_commit_duration_seconds",
+		Help:      "The time the block processor takes to validate and commit a block delivered by consensus.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{metricsNodeLabel})
+
+	proposedBlockBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "proposed_block_bytes_total",
+		Help:      "The size of the marshaled blocks proposed by the leader, before compression.",
+	}, []string{metricsNodeLabel})
+
+	proposedEntryBytesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "proposed_entry_bytes_total",
+		Help:      "The size of the Raft entries of the blocks proposed by the leader, after compression.",
+	}, []string{metricsNodeLabel})
+
+	blockTimestampsOutOfBoundCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "block_timestamps_out_of_bound_total",
+		Help:      "The number of committed blocks flagged for a timestamp that precedes the previous block, or deviates from the local clock by more than the allowed skew.",
+	}, []string{metricsNodeLabel})
+)
+
+func init() {
+	prometheus.MustRegister(
+		termGauge,
+		isLeaderGauge,
+		leaderChangesCounter,
+		electionsCounter,
+		unreachableReportsCounter,
+		snapshotSendFailuresCounter,
+		walSyncDurationHistogram,
+		proposalCommitLatencyHistogram,
+		blockCommitDurationHistogram,
+		proposedBlockBytesCounter,
+		proposedEntryBytesCounter,
+		blockTimestampsOutOfBoundCounter,
+	)
+}
+
+// consensusMetrics holds the metrics of a single block replicator, labeled by the node ID.
+type consensusMetrics struct {
+	term                  prometheus.Gauge
+	isLeader              prometheus.Gauge
+	leaderChanges         prometheus.Counter
+	elections             prometheus.Counter
+	unreachableReports    prometheus.Counter
+	snapshotSendFailures  prometheus.Counter
+	walSyncDuration       prometheus.Observer
+	proposalCommitLatency prometheus.Observer
+	blockCommitDuration   prometheus.Observer
+	proposedBlockBytes    prometheus.Counter
+	proposedEntryBytes    prometheus.Counter
+
+	blockTimestampsOutOfBound prometheus.Counter
+}
+
+func newConsensusMetrics(nodeID string) *consensusMetrics {
+	labels := prometheus.Labels{metricsNodeLabel: nodeID}
+
+	return &consensusMetrics{
+		term:                  termGauge.With(labels),
+		isLeader:              isLeaderGauge.With(labels),
+		leaderChanges:         leaderChangesCounter.With(labels),
+		elections:             electionsCounter.With(labels),
+		unreachableReports:    unreachableReportsCounter.With(labels),
+		snapshotSendFailures:  snapshotSendFailuresCounter.With(labels),
+		walSyncDuration:       walSyncDurationHistogram.With(labels),
+		proposalCommitLatency: proposalCommitLatencyHistogram.With(labels),
+		blockCommitDuration:   blockCommitDurationHistogram.With(labels),
+		proposedBlockBytes:    proposedBlockBytesCounter.With(labels),
+		proposedEntryBytes:    proposedEntryBytesCounter.With(labels),
+
+		blockTimestampsOutOfBound: blockTimestampsOutOfBoundCounter.With(labels),
+	}
+}
@@ -24,16 +24,20 @@ import (
 	"go.etcd.io/etcd/wal/walpb"
 )
 
-// MaxSnapshotFiles defines max number of etcd/raft snapshot files to retain
-// on filesystem. Snapshot files are read from newest to oldest, until first
-// intact file is found. The more snapshot files we keep around, the more we
-// mitigate the impact of a corrupted snapshots. This is exported for testing
+// MaxSnapshotFiles defines the default max number of etcd/raft snapshot files
+// to retain on filesystem. Snapshot files are read from newest to oldest, until
+// first intact file is found. The more snapshot files we keep around, the more
+// we mitigate the impact of a corrupted snapshots. This is exported for testing
 // purpose. This MUST be greater equal than 1.
 var MaxSnapshotFiles = 4
 
 // RaftStorage encapsulates storages needed for etcd/raft data, i.e. memory, wal
 type RaftStorage struct {
 	SnapshotCatchUpEntries uint64
+	// MaxSnapshotFiles is the number of snapshot files retained on disk; WAL files
+	// older than the oldest retained snapshot are purged. It defaults to the
+	// package level MaxSnapshotFiles.
+	MaxSnapshotFiles int
 
 	walDir  string
 	snapDir string
@@ -90,13 +94,14 @@ func CreateStorage(lg *logger.SugarLogger, walDir string, snapDir string) (*Raft
 	ms.Append(ents) // MemoryStorage.Append always return nil
 
 	return &RaftStorage{
-		lg:            lg,
-		MemoryStorage: ms,
-		wal:           w,
-		snap:          sn,
-		walDir:        walDir,
-		snapDir:       snapDir,
-		snapshotIndex: ListSnapshots(lg, snapDir),
+		MaxSnapshotFiles: MaxSnapshotFiles,
+		lg:               lg,
+		MemoryStorage:    ms,
+		wal:              w,
+		snap:             sn,
+		walDir:           walDir,
+		snapDir:          snapDir,
+		snapshotIndex:    ListSnapshots(lg, snapDir),
 	}, nil
 }
 
@@ -278,6 +283,11 @@ func (rs *RaftStorage) saveSnap(snap raftpb.Snapshot) error {
 	return nil
 }
 
+// LastSnapshotIndex returns the raft index of the latest snapshot stored in memory, or 0 if there is none.
+func (rs *RaftStorage) LastSnapshotIndex() uint64 {
+	return rs.Snapshot().Metadata.Index
+}
+
 // TakeSnapshot takes a snapshot at index i from MemoryStorage, and persists it to wal and disk.
 func (rs *RaftStorage) TakeSnapshot(i uint64, cs raftpb.ConfState, data []byte) error {
 	rs.lg.Debugf("Creating snapshot at index %d from MemoryStorage", i)
@@ -313,13 +323,13 @@ func (rs *RaftStorage) TakeSnapshot(i uint64, cs raftpb.ConfState, data []byte)
 
 // gc collects etcd/raft garbage files, namely wal and snapshot files
 func (rs *RaftStorage) gc() {
-	if len(rs.snapshotIndex) < MaxSnapshotFiles {
+	if len(rs.snapshotIndex) < rs.MaxSnapshotFiles {
 		rs.lg.Debugf("Snapshots on disk (%d) < limit (%d), no need to purge wal/snapshot",
-			len(rs.snapshotIndex), MaxSnapshotFiles)
+			len(rs.snapshotIndex), rs.MaxSnapshotFiles)
 		return
 	}
 
-	rs.snapshotIndex = rs.snapshotIndex[len(rs.snapshotIndex)-MaxSnapshotFiles:]
+	rs.snapshotIndex = rs.snapshotIndex[len(rs.snapshotIndex)-rs.MaxSnapshotFiles:]
 
 	rs.purgeWAL()
 	rs.purgeSnap()
@@ -383,11 +393,11 @@ func (rs *RaftStorage) purgeSnap() {
 	}
 
 	l := len(files)
-	if l <= MaxSnapshotFiles {
+	if l <= rs.MaxSnapshotFiles {
 		return
 	}
 
-	rs.purge(files[:l-MaxSnapshotFiles]) // retain last MaxSnapshotFiles snapshot files
+	rs.purge(files[:l-rs.MaxSnapshotFiles]) // retain last MaxSnapshotFiles snapshot files
 }
 
 func (rs *RaftStorage) purge(files []string) {
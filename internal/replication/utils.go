@@ -8,6 +8,7 @@ import (
 	"hash/crc64"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -39,10 +40,15 @@ func raftEntryString(e raftpb.Entry) string {
 // - Members' endpoints can be updated one at a time
 // - An existing member cannot change its Raft ID (it must be removed from the cluster and added again as a new member)
 // - The Raft ID of a new member must be unique - therefore it must be larger than MaxRaftId
+// - The consensus algorithm cannot be changed
 //
 // We assume that both the current and updated ClusterConfig are internally consistent, specifically, that the Nodes
 // and the ConsensusConfig.Members arrays match by NodeId in each.
 func VerifyConsensusReConfig(currentConfig, updatedConfig *types.ConsensusConfig, lg *logger.SugarLogger) error {
+	if currentConfig.GetAlgorithm() != updatedConfig.GetAlgorithm() {
+		return errors.Errorf("cannot change the consensus algorithm from [%s] to [%s]", currentConfig.GetAlgorithm(), updatedConfig.GetAlgorithm())
+	}
+
 	addedPeers, removedPeers, changedPeers, err := detectPeerConfigChanges(currentConfig, updatedConfig)
 	if err != nil {
 		return err
@@ -63,6 +69,19 @@ func VerifyConsensusReConfig(currentConfig, updatedConfig *types.ConsensusConfig
 	return nil
 }
 
+// VerifyConsensusPromotion checks the changes in types.ConsensusConfig made by the promotion of a standby cluster to
+// active. The members of the standby cluster replace the members of the primary cluster at once, hence the rules of
+// VerifyConsensusReConfig do not apply, except that the consensus algorithm cannot be changed. The Raft ID of a new
+// member must still be larger than MaxRaftId, so that it is not confused with a member of the primary cluster.
+func VerifyConsensusPromotion(currentConfig, updatedConfig *types.ConsensusConfig) error {
+	if currentConfig.GetAlgorithm() != updatedConfig.GetAlgorithm() {
+		return errors.Errorf("cannot change the consensus algorithm from [%s] to [%s]", currentConfig.GetAlgorithm(), updatedConfig.GetAlgorithm())
+	}
+
+	_, _, _, err := detectPeerConfigChanges(currentConfig, updatedConfig)
+	return err
+}
+
 func detectPeerConfigChanges(currentConfig, updatedConfig *types.ConsensusConfig) (addedPeers, removedPeers, changedPeers []*types.PeerConfig, err error) {
 	currPeers := make(map[string]*types.PeerConfig)
 	for _, m := range currentConfig.Members {
@@ -84,10 +103,10 @@ func detectPeerConfigChanges(currentConfig, updatedConfig *types.ConsensusConfig
 			}
 		} else {
 			// added peer
-			if updtMember.RaftId <= currentConfig.RaftConfig.MaxRaftId {
+			if updtMember.RaftId <= currentConfig.GetRaftConfig().GetMaxRaftId() {
 				return nil, nil, nil,
 					errors.Errorf("the RaftId of a new peer must be unique,  > MaxRaftId [%d]; but: NodeId=%s, RaftID=%d",
-						currentConfig.RaftConfig.MaxRaftId, updtMember.NodeId, updtMember.RaftId)
+						currentConfig.GetRaftConfig().GetMaxRaftId(), updtMember.NodeId, updtMember.RaftId)
 			}
 			addedPeers = append(addedPeers, updtMember)
 		}
@@ -122,6 +141,63 @@ func ClassifyClusterReConfig(currentConfig, updatedConfig *types.ClusterConfig)
 	return nodes, consensus, ca, admins
 }
 
+// ClusterReConfigChanges describes the changes that an updated ClusterConfig makes to the current one.
+type ClusterReConfigChanges struct {
+	Nodes     bool
+	Consensus bool
+	CA        bool
+	Admins    bool
+
+	// The consensus members that are added, removed, or whose endpoints are updated.
+	AddedPeers   []*types.PeerConfig
+	RemovedPeers []*types.PeerConfig
+	ChangedPeers []*types.PeerConfig
+
+	CurrentProtocolVersion uint32
+	UpdatedProtocolVersion uint32
+}
+
+// DescribeClusterReConfig reports the changes that happened in the ClusterConfig, including the membership changes.
+// Unlike ClassifyClusterReConfig, the updated config need not be valid; membership changes that cannot be applied,
+// e.g. a change of the RaftId of an existing peer, are not reported.
+func DescribeClusterReConfig(currentConfig, updatedConfig *types.ClusterConfig) *ClusterReConfigChanges {
+	changes := &ClusterReConfigChanges{
+		CurrentProtocolVersion: comm.ClusterProtocolVersion(currentConfig),
+		UpdatedProtocolVersion: comm.ClusterProtocolVersion(updatedConfig),
+	}
+	changes.Nodes, changes.Consensus, changes.CA, changes.Admins = ClassifyClusterReConfig(currentConfig, updatedConfig)
+
+	if changes.Consensus && currentConfig.GetConsensusConfig() != nil && updatedConfig.GetConsensusConfig() != nil {
+		added, removed, changed, err := detectPeerConfigChanges(currentConfig.GetConsensusConfig(), updatedConfig.GetConsensusConfig())
+		if err == nil {
+			changes.AddedPeers, changes.RemovedPeers, changes.ChangedPeers = added, removed, changed
+		}
+	}
+
+	return changes
+}
+
+// verifyQuorumViability checks that the members of the updated ConsensusConfig that are currently active can form a
+// quorum. Removing a peer while too few of the remaining members are active would leave the cluster without a quorum,
+// something that is very difficult to recover from.
+func verifyQuorumViability(updatedConfig *types.ConsensusConfig, activePeers map[string]*types.PeerConfig) error {
+	members := updatedConfig.GetMembers()
+	numActive := 0
+	for _, m := range members {
+		if _, ok := activePeers[m.NodeId]; ok {
+			numActive++
+		}
+	}
+
+	quorum := len(members)/2 + 1
+	if numActive < quorum {
+		return errors.Errorf("the updated config has %d members of which %d are active, less than the quorum of %d",
+			len(members), numActive, quorum)
+	}
+
+	return nil
+}
+
 func changedAdmins(curAdmins []*types.Admin, updAdmins []*types.Admin) bool {
 	if len(curAdmins) != len(updAdmins) {
 		return true
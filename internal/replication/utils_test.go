@@ -111,6 +111,13 @@ func TestVerifyConsensusReConfig(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("invalid: change algorithm", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Algorithm = "kafka"
+		err := VerifyConsensusReConfig(clusterConfig.ConsensusConfig, updateConfig, lg)
+		require.EqualError(t, err, "cannot change the consensus algorithm from [raft] to [kafka]")
+	})
+
 	t.Run("invalid: too many adds", func(t *testing.T) {
 		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
 		updateConfig.Members = append(updateConfig.Members, &types.PeerConfig{
@@ -162,6 +169,143 @@ func TestVerifyConsensusReConfig(t *testing.T) {
 	})
 }
 
+func TestVerifyConsensusPromotion(t *testing.T) {
+	clusterConfig := testClusterConfig()
+
+	standbyMembers := func(firstRaftID uint64) []*types.PeerConfig {
+		var members []*types.PeerConfig
+		for i := uint64(0); i < 3; i++ {
+			members = append(members, &types.PeerConfig{
+				NodeId:   fmt.Sprintf("standby%d", i+1),
+				RaftId:   firstRaftID + i,
+				PeerHost: "127.0.0.1",
+				PeerPort: uint32(8090 + i),
+			})
+		}
+		return members
+	}
+
+	t.Run("valid: replace all the peers", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Members = standbyMembers(6)
+		err := VerifyConsensusPromotion(clusterConfig.ConsensusConfig, updateConfig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid: change algorithm", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Members = standbyMembers(6)
+		updateConfig.Algorithm = "kafka"
+		err := VerifyConsensusPromotion(clusterConfig.ConsensusConfig, updateConfig)
+		require.EqualError(t, err, "cannot change the consensus algorithm from [raft] to [kafka]")
+	})
+
+	t.Run("invalid: add a peer with non-unique RaftID", func(t *testing.T) {
+		updateConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updateConfig.Members = standbyMembers(5)
+		err := VerifyConsensusPromotion(clusterConfig.ConsensusConfig, updateConfig)
+		require.EqualError(t, err, "the RaftId of a new peer must be unique,  > MaxRaftId [5]; but: NodeId=standby1, RaftID=5")
+	})
+}
+
+func TestDescribeClusterReConfig(t *testing.T) {
+	clusterConfig := testClusterConfig()
+
+	t.Run("no change", func(t *testing.T) {
+		changes := DescribeClusterReConfig(clusterConfig, proto.Clone(clusterConfig).(*types.ClusterConfig))
+		require.Equal(t, &ClusterReConfigChanges{CurrentProtocolVersion: 1, UpdatedProtocolVersion: 1}, changes)
+	})
+
+	t.Run("remove a peer", func(t *testing.T) {
+		updatedConfig := proto.Clone(clusterConfig).(*types.ClusterConfig)
+		updatedConfig.Nodes = updatedConfig.Nodes[0:2]
+		updatedConfig.ConsensusConfig.Members = updatedConfig.ConsensusConfig.Members[0:2]
+
+		changes := DescribeClusterReConfig(clusterConfig, updatedConfig)
+		require.True(t, changes.Nodes)
+		require.True(t, changes.Consensus)
+		require.False(t, changes.CA)
+		require.False(t, changes.Admins)
+		require.Len(t, changes.AddedPeers, 0)
+		require.Len(t, changes.RemovedPeers, 1)
+		require.True(t, proto.Equal(clusterConfig.ConsensusConfig.Members[2], changes.RemovedPeers[0]))
+		require.Len(t, changes.ChangedPeers, 0)
+	})
+
+	t.Run("add a peer and upgrade the protocol version", func(t *testing.T) {
+		updatedConfig := proto.Clone(clusterConfig).(*types.ClusterConfig)
+		updatedConfig.ProtocolVersion = 2
+		updatedConfig.ConsensusConfig.Members = append(updatedConfig.ConsensusConfig.Members, &types.PeerConfig{
+			NodeId:   "node4",
+			RaftId:   6,
+			PeerHost: "127.0.0.1",
+			PeerPort: 7094,
+		})
+
+		changes := DescribeClusterReConfig(clusterConfig, updatedConfig)
+		require.True(t, changes.Consensus)
+		require.Len(t, changes.AddedPeers, 1)
+		require.Equal(t, "node4", changes.AddedPeers[0].NodeId)
+		require.Len(t, changes.RemovedPeers, 0)
+		require.Equal(t, uint32(1), changes.CurrentProtocolVersion)
+		require.Equal(t, uint32(2), changes.UpdatedProtocolVersion)
+	})
+
+	t.Run("change the RaftId of a peer", func(t *testing.T) {
+		updatedConfig := proto.Clone(clusterConfig).(*types.ClusterConfig)
+		updatedConfig.ConsensusConfig.Members[0].RaftId = 8
+
+		changes := DescribeClusterReConfig(clusterConfig, updatedConfig)
+		require.True(t, changes.Consensus)
+		require.Len(t, changes.AddedPeers, 0)
+		require.Len(t, changes.RemovedPeers, 0)
+		require.Len(t, changes.ChangedPeers, 0)
+	})
+
+	t.Run("empty consensus config", func(t *testing.T) {
+		updatedConfig := proto.Clone(clusterConfig).(*types.ClusterConfig)
+		updatedConfig.ConsensusConfig = nil
+
+		changes := DescribeClusterReConfig(clusterConfig, updatedConfig)
+		require.True(t, changes.Consensus)
+		require.Len(t, changes.RemovedPeers, 0)
+	})
+}
+
+func TestVerifyQuorumViability(t *testing.T) {
+	clusterConfig := testClusterConfig()
+	members := clusterConfig.ConsensusConfig.Members
+
+	activePeers := func(peers ...*types.PeerConfig) map[string]*types.PeerConfig {
+		active := make(map[string]*types.PeerConfig)
+		for _, p := range peers {
+			active[p.NodeId] = p
+		}
+		return active
+	}
+
+	t.Run("valid: all remaining members are active", func(t *testing.T) {
+		updatedConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updatedConfig.Members = updatedConfig.Members[0:2]
+		err := verifyQuorumViability(updatedConfig, activePeers(members[0], members[1]))
+		require.NoError(t, err)
+	})
+
+	t.Run("valid: the removed member is down", func(t *testing.T) {
+		updatedConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updatedConfig.Members = updatedConfig.Members[1:3]
+		err := verifyQuorumViability(updatedConfig, activePeers(members[1], members[2]))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid: a remaining member is down", func(t *testing.T) {
+		updatedConfig := proto.Clone(clusterConfig.ConsensusConfig).(*types.ConsensusConfig)
+		updatedConfig.Members = updatedConfig.Members[0:2]
+		err := verifyQuorumViability(updatedConfig, activePeers(members[0], members[2]))
+		require.EqualError(t, err, "the updated config has 2 members of which 1 are active, less than the quorum of 2")
+	})
+}
+
 func testClusterConfig() *types.ClusterConfig {
 	clusterConfig := &types.ClusterConfig{
 		Admins: []*types.Admin{
@@ -0,0 +1,242 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package standby supports a standby cluster: a passive copy of a primary cluster in another region, for disaster
+// recovery. Every node of the standby cluster follows the ledger of the primary cluster asynchronously, by pulling the
+// committed blocks from the primary members, and re-validating them like a witness. The LagMonitor reports how far
+// behind the primary cluster the node is.
+package standby
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultLagProbeInterval is the interval at which the height of the primary cluster is probed when none is configured
+const DefaultLagProbeInterval = 10 * time.Second
+
+var (
+	primaryHeightGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "standby",
+		Name:      "primary_height",
+		Help:      "The highest ledger height reported by the members of the primary cluster.",
+	}, []string{"node"})
+
+	lagBlocksGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "standby",
+		Name:      "lag_blocks",
+		Help:      "The number of blocks committed by the primary cluster and not yet by the standby node.",
+	}, []string{"node"})
+
+	lagSecondsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "standby",
+		Name:      "lag_seconds",
+		Help:      "The age of the last block committed by the standby node while it lags behind the primary cluster, zero when it does not.",
+	}, []string{"node"})
+
+	probeFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "standby",
+		Name:      "probe_failures_total",
+		Help:      "The number of probes in which no member of the primary cluster reported its ledger height.",
+	}, []string{"node"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		primaryHeightGauge,
+		lagBlocksGauge,
+		lagSecondsGauge,
+		probeFailuresCounter,
+	)
+}
+
+type LedgerReader interface {
+	Height() (uint64, error)
+	Get(blockNumber uint64) (*types.Block, error)
+}
+
+// HeightReader reads the ledger height of a member of the primary cluster.
+type HeightReader interface {
+	GetHeight(ctx context.Context, targetID uint64) (uint64, error)
+}
+
+// ConfigReader reads the cluster config committed to the local ledger, which holds the members of the primary cluster.
+type ConfigReader interface {
+	GetConfig() (*types.ClusterConfig, *types.Metadata, error)
+}
+
+// Lag describes how far behind the primary cluster a standby node is.
+type Lag struct {
+	// PrimaryHeight is the highest ledger height reported by the members of the primary cluster in the last
+	// successful probe.
+	PrimaryHeight uint64
+	// LocalHeight is the height of the local ledger.
+	LocalHeight uint64
+	// Blocks is the number of blocks the local ledger lags behind the primary cluster.
+	Blocks uint64
+	// Duration is the age of the last block committed locally, if the local ledger lags behind, and zero otherwise.
+	Duration time.Duration
+	// LastProbe is the time of the last successful probe, zero if no probe succeeded.
+	LastProbe time.Time
+}
+
+// LagMonitor periodically probes the ledger height of the members of the primary cluster, and compares it to the
+// height of the local ledger.
+type LagMonitor struct {
+	nodeID       string
+	interval     time.Duration
+	ledgerReader LedgerReader
+	heightReader HeightReader
+	configReader ConfigReader
+	nowFn        func() time.Time
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+
+	mutex sync.Mutex
+	lag   Lag
+
+	lg *logger.SugarLogger
+}
+
+// LagMonitorConfig holds the configuration information required to initialize the lag monitor.
+type LagMonitorConfig struct {
+	NodeID string
+	// Interval is the interval between probes; if zero, DefaultLagProbeInterval is used.
+	Interval     time.Duration
+	LedgerReader LedgerReader
+	HeightReader HeightReader
+	ConfigReader ConfigReader
+	Logger       *logger.SugarLogger
+}
+
+// NewLagMonitor creates a new LagMonitor.
+func NewLagMonitor(conf *LagMonitorConfig) *LagMonitor {
+	interval := conf.Interval
+	if interval == 0 {
+		interval = DefaultLagProbeInterval
+	}
+
+	return &LagMonitor{
+		nodeID:       conf.NodeID,
+		interval:     interval,
+		ledgerReader: conf.LedgerReader,
+		heightReader: conf.HeightReader,
+		configReader: conf.ConfigReader,
+		nowFn:        time.Now,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+		lg:           conf.Logger,
+	}
+}
+
+// Start starts probing in a go-routine.
+func (m *LagMonitor) Start() {
+	go m.run()
+}
+
+// Stop stops probing and waits for the go-routine to exit.
+func (m *LagMonitor) Stop() {
+	m.stopOnce.Do(func() { close(m.stopCh) })
+	<-m.doneCh
+}
+
+// Lag returns the lag computed by the last probe.
+func (m *LagMonitor) Lag() Lag {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.lag
+}
+
+func (m *LagMonitor) run() {
+	defer close(m.doneCh)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.probe()
+
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe asks every member of the primary cluster for its ledger height, and updates the lag with the highest one
+func (m *LagMonitor) probe() {
+	labels := prometheus.Labels{"node": m.nodeID}
+
+	clusterConfig, _, err := m.configReader.GetConfig()
+	if err != nil {
+		m.lg.Errorf("Failed to read the cluster config: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.interval)
+	defer cancel()
+
+	var primaryHeight uint64
+	var reported bool
+	for _, member := range clusterConfig.GetConsensusConfig().GetMembers() {
+		height, err := m.heightReader.GetHeight(ctx, member.RaftId)
+		if err != nil {
+			m.lg.Debugf("Failed to read the ledger height of primary member [%s]: %s", member.NodeId, err)
+			continue
+		}
+		reported = true
+		if height > primaryHeight {
+			primaryHeight = height
+		}
+	}
+	if !reported {
+		m.lg.Warnf("No member of the primary cluster reported its ledger height")
+		probeFailuresCounter.With(labels).Inc()
+		return
+	}
+
+	localHeight, err := m.ledgerReader.Height()
+	if err != nil {
+		m.lg.Errorf("Failed to read the ledger height: %s", err)
+		return
+	}
+
+	now := m.nowFn()
+	lag := Lag{
+		PrimaryHeight: primaryHeight,
+		LocalHeight:   localHeight,
+		LastProbe:     now,
+	}
+	if primaryHeight > localHeight {
+		lag.Blocks = primaryHeight - localHeight
+		if localHeight > 0 {
+			block, err := m.ledgerReader.Get(localHeight)
+			if err != nil {
+				m.lg.Errorf("Failed to read block [%d]: %s", localHeight, err)
+				return
+			}
+			lag.Duration = now.Sub(time.Unix(0, block.GetHeader().GetBaseHeader().GetTimestamp()))
+		}
+	}
+
+	m.mutex.Lock()
+	m.lag = lag
+	m.mutex.Unlock()
+
+	primaryHeightGauge.With(labels).Set(float64(lag.PrimaryHeight))
+	lagBlocksGauge.With(labels).Set(float64(lag.Blocks))
+	lagSecondsGauge.With(labels).Set(lag.Duration.Seconds())
+}
@@ -0,0 +1,145 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package standby
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type memLedger struct {
+	blocks []*types.Block
+}
+
+func (l *memLedger) Height() (uint64, error) {
+	return uint64(len(l.blocks)), nil
+}
+
+func (l *memLedger) Get(blockNumber uint64) (*types.Block, error) {
+	if blockNumber == 0 || blockNumber > uint64(len(l.blocks)) {
+		return nil, errors.Errorf("block [%d] not found", blockNumber)
+	}
+	return l.blocks[blockNumber-1], nil
+}
+
+type memHeights struct {
+	mutex   sync.Mutex
+	heights map[uint64]uint64
+}
+
+func (h *memHeights) GetHeight(_ context.Context, targetID uint64) (uint64, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	height, ok := h.heights[targetID]
+	if !ok {
+		return 0, errors.Errorf("member [%d] unreachable", targetID)
+	}
+	return height, nil
+}
+
+type memConfig struct {
+	config *types.ClusterConfig
+}
+
+func (c *memConfig) GetConfig() (*types.ClusterConfig, *types.Metadata, error) {
+	return c.config, nil, nil
+}
+
+func testLogger(t *testing.T) *logger.SugarLogger {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+		Name:          "standby",
+	})
+	require.NoError(t, err)
+	return lg
+}
+
+func newTestLagMonitor(t *testing.T, heights map[uint64]uint64, localHeight uint64, now time.Time) *LagMonitor {
+	ledger := &memLedger{}
+	for n := uint64(1); n <= localHeight; n++ {
+		ledger.blocks = append(ledger.blocks, &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:    n,
+					Timestamp: now.Add(-time.Duration(localHeight-n+1) * time.Minute).UnixNano(),
+				},
+			},
+		})
+	}
+
+	m := NewLagMonitor(&LagMonitorConfig{
+		NodeID:       "standby1",
+		LedgerReader: ledger,
+		HeightReader: &memHeights{heights: heights},
+		ConfigReader: &memConfig{
+			config: &types.ClusterConfig{
+				ConsensusConfig: &types.ConsensusConfig{
+					Members: []*types.PeerConfig{
+						{NodeId: "node1", RaftId: 1},
+						{NodeId: "node2", RaftId: 2},
+						{NodeId: "node3", RaftId: 3},
+					},
+				},
+			},
+		},
+		Logger: testLogger(t),
+	})
+	m.nowFn = func() time.Time { return now }
+	return m
+}
+
+func TestLagMonitor_Probe(t *testing.T) {
+	now := time.Now()
+
+	t.Run("lagging", func(t *testing.T) {
+		m := newTestLagMonitor(t, map[uint64]uint64{1: 8, 2: 10}, 6, now)
+		m.probe()
+
+		lag := m.Lag()
+		require.Equal(t, uint64(10), lag.PrimaryHeight)
+		require.Equal(t, uint64(6), lag.LocalHeight)
+		require.Equal(t, uint64(4), lag.Blocks)
+		require.Equal(t, time.Minute, lag.Duration)
+		require.Equal(t, now, lag.LastProbe)
+	})
+
+	t.Run("in sync", func(t *testing.T) {
+		m := newTestLagMonitor(t, map[uint64]uint64{1: 6, 2: 6, 3: 5}, 6, now)
+		m.probe()
+
+		lag := m.Lag()
+		require.Equal(t, uint64(6), lag.PrimaryHeight)
+		require.Equal(t, uint64(0), lag.Blocks)
+		require.Equal(t, time.Duration(0), lag.Duration)
+	})
+
+	t.Run("primary unreachable", func(t *testing.T) {
+		m := newTestLagMonitor(t, map[uint64]uint64{}, 6, now)
+		m.probe()
+
+		require.Equal(t, Lag{}, m.Lag())
+	})
+}
+
+func TestLagMonitor_StartStop(t *testing.T) {
+	m := newTestLagMonitor(t, map[uint64]uint64{1: 3}, 1, time.Now())
+	m.interval = 10 * time.Millisecond
+	m.Start()
+
+	require.Eventually(t, func() bool { return m.Lag().Blocks == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	m.Stop()
+	m.Stop()
+}
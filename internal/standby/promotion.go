@@ -0,0 +1,45 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package standby
+
+import (
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// PromotionBlock creates the block that commits the config tx that promotes the standby cluster to active, on top of
+// the last block of the local ledger. The block depends only on the config tx and on the last block, hence every node
+// of the standby cluster whose ledger is at the height of the promotion creates the same block. The block carries no
+// consensus metadata, as the consensus of the standby cluster starts from scratch.
+func PromotionBlock(txEnv *types.ConfigTxEnvelope, lastBlock *types.Block) (*types.Block, error) {
+	lastBaseHeader := lastBlock.GetHeader().GetBaseHeader()
+	if height := txEnv.GetPayload().GetStandbyPromotion().GetLedgerHeight(); lastBaseHeader.GetNumber() != height {
+		return nil, errors.Errorf("the last block is [%d], while the promotion is at ledger height [%d]", lastBaseHeader.GetNumber(), height)
+	}
+
+	lastBlockHash, err := blockstore.ComputeBlockHash(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	lastBlockBaseHash, err := blockstore.ComputeBlockBaseHash(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:                 lastBaseHeader.GetNumber() + 1,
+				PreviousBaseHeaderHash: lastBlockBaseHash,
+				LastCommittedBlockHash: lastBlockHash,
+				LastCommittedBlockNum:  lastBaseHeader.GetNumber(),
+				Timestamp:              lastBaseHeader.GetTimestamp(),
+			},
+		},
+		Payload: &types.Block_ConfigTxEnvelope{
+			ConfigTxEnvelope: txEnv,
+		},
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package standby
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotionBlock(t *testing.T) {
+	lastBlock := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:                 5,
+				PreviousBaseHeaderHash: []byte("base-hash-4"),
+				LastCommittedBlockHash: []byte("hash-4"),
+				LastCommittedBlockNum:  4,
+				Timestamp:              1000,
+			},
+		},
+	}
+	txEnv := &types.ConfigTxEnvelope{
+		Payload: &types.ConfigTx{
+			TxId:             "promotion",
+			StandbyPromotion: &types.StandbyPromotion{LedgerHeight: 5},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		block, err := PromotionBlock(txEnv, lastBlock)
+		require.NoError(t, err)
+
+		lastBlockHash, err := blockstore.ComputeBlockHash(lastBlock)
+		require.NoError(t, err)
+		lastBlockBaseHash, err := blockstore.ComputeBlockBaseHash(lastBlock)
+		require.NoError(t, err)
+
+		baseHeader := block.GetHeader().GetBaseHeader()
+		require.Equal(t, uint64(6), baseHeader.Number)
+		require.Equal(t, lastBlockBaseHash, baseHeader.PreviousBaseHeaderHash)
+		require.Equal(t, lastBlockHash, baseHeader.LastCommittedBlockHash)
+		require.Equal(t, uint64(5), baseHeader.LastCommittedBlockNum)
+		require.Equal(t, int64(1000), baseHeader.Timestamp)
+		require.Nil(t, block.GetConsensusMetadata())
+		require.Equal(t, txEnv, block.GetConfigTxEnvelope())
+
+		// every standby node creates the same block
+		again, err := PromotionBlock(txEnv, lastBlock)
+		require.NoError(t, err)
+		require.Equal(t, block, again)
+	})
+
+	t.Run("height mismatch", func(t *testing.T) {
+		behind := &types.ConfigTxEnvelope{
+			Payload: &types.ConfigTx{
+				TxId:             "promotion",
+				StandbyPromotion: &types.StandbyPromotion{LedgerHeight: 7},
+			},
+		}
+		block, err := PromotionBlock(behind, lastBlock)
+		require.EqualError(t, err, "the last block is [5], while the promotion is at ledger height [7]")
+		require.Nil(t, block)
+	})
+}
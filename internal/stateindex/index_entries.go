@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 )
@@ -25,25 +27,22 @@ const (
 	Ending
 )
 
-// ConstructIndexEntries constructs index entries for the supplied the world state updates
+// ConstructIndexEntries constructs index entries for the supplied the world state updates. The metadata of the index
+// entries of a key holds the access control of the key, if any, so that the query results can be filtered by the
+// access control without reading the values of the keys.
 func ConstructIndexEntries(updates map[string]*worldstate.DBUpdates, db worldstate.DB) (map[string]*worldstate.DBUpdates, error) {
 	indexEntries := make(map[string]*worldstate.DBUpdates)
 
 	for dbName, update := range updates {
-		indexDef, _, err := db.GetIndexDefinition(dbName)
+		index, err := Index(db, dbName)
 		if err != nil {
 			return nil, err
 		}
 
-		if indexDef == nil {
+		if index == nil {
 			continue
 		}
 
-		index := map[string]types.IndexAttributeType{}
-		if err := json.Unmarshal(indexDef, &index); err != nil {
-			return nil, err
-		}
-
 		newIndexToBeCreated, oldIndexToBeDeleted, err := indexEntriesForWrites(update.Writes, index, db, dbName)
 		if err != nil {
 			return nil, err
@@ -55,11 +54,8 @@ func ConstructIndexEntries(updates map[string]*worldstate.DBUpdates, db worldsta
 		}
 		oldIndexToBeDeleted = append(oldIndexToBeDeleted, toBeDeletedIndexEntries...)
 
-		dbUpdates := &worldstate.DBUpdates{}
-		for _, ind := range newIndexToBeCreated {
-			dbUpdates.Writes = append(dbUpdates.Writes, &worldstate.KVWithMetadata{
-				Key: ind,
-			})
+		dbUpdates := &worldstate.DBUpdates{
+			Writes: newIndexToBeCreated,
 		}
 		dbUpdates.Deletes = append(dbUpdates.Deletes, oldIndexToBeDeleted...)
 
@@ -71,22 +67,101 @@ func ConstructIndexEntries(updates map[string]*worldstate.DBUpdates, db worldsta
 	return indexEntries, nil
 }
 
+// ConstructIndexEntriesForStoredValues constructs the index entries of the values stored in a database for the given
+// index, e.g., when an index is defined on a database that already holds values. As for the written values, the
+// metadata of the index entries of a key holds the access control of the key, if any. It returns nil if no stored
+// value has an index entry.
+func ConstructIndexEntriesForStoredValues(db worldstate.DB, dbName string, index map[string]types.IndexAttributeType) (*worldstate.DBUpdates, error) {
+	itr, err := db.GetIterator(dbName, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	// the values are read through the database rather than the iterator, as a value may be encrypted or stored in the
+	// blob store
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()))
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+
+	updates := &worldstate.DBUpdates{}
+	for _, k := range keys {
+		v, metadata, err := db.Get(dbName, k)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := toStrings(decodeJSONAndConstructIndexEntries(k, v, index))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			w := &worldstate.KVWithMetadata{
+				Key: e,
+			}
+			if acl := metadata.GetAccessControl(); acl != nil {
+				w.Metadata = &types.Metadata{
+					AccessControl: acl,
+				}
+			}
+			updates.Writes = append(updates.Writes, w)
+		}
+	}
+
+	if len(updates.Writes) == 0 {
+		return nil, nil
+	}
+	return updates, nil
+}
+
+// Index returns the index defined on the database, i.e., the type of each indexed attribute, or nil if the database is
+// not indexed
+func Index(db worldstate.DB, dbName string) (map[string]types.IndexAttributeType, error) {
+	indexDef, _, err := db.GetIndexDefinition(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	if indexDef == nil {
+		return nil, nil
+	}
+
+	index := map[string]types.IndexAttributeType{}
+	if err := json.Unmarshal(indexDef, &index); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// CountIndexEntries returns the number of index entries constructed for a value written to a database with the given
+// index. A value that is not a JSON object has no index entries.
+func CountIndexEntries(key string, value []byte, index map[string]types.IndexAttributeType) int {
+	return len(decodeJSONAndConstructIndexEntries(key, value, index))
+}
+
 func indexEntriesForWrites(
 	writes []*worldstate.KVWithMetadata,
 	index map[string]types.IndexAttributeType,
 	db worldstate.DB,
 	dbName string,
-) ([]string, []string, error) {
+) ([]*worldstate.KVWithMetadata, []string, error) {
 	newIndexEntries, err := indexEntriesForNewValues(writes, index)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	var keysUpdated []string
+	acls := make(map[string]*types.AccessControl)
 	for _, w := range writes {
 		keysUpdated = append(keysUpdated, w.Key)
+		acls[w.Key] = w.Metadata.GetAccessControl()
 	}
-	existingIndexEntries, err := indexEntriesOfExistingValue(keysUpdated, index, db, dbName)
+	existingIndexEntries, existingACLs, err := indexEntriesOfExistingValue(keysUpdated, index, db, dbName)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -102,11 +177,40 @@ func indexEntriesForWrites(
 	}
 
 	newIndexToBeCreated, oldIndexToBeDeleted := removeDuplicateIndexEntries(newEntries, existingEntries)
-	return newIndexToBeCreated, oldIndexToBeDeleted, nil
+
+	// the index entries of a key whose access control is updated are rewritten even if they are not updated, so that
+	// they hold the updated access control
+	toBeCreated := make(map[string]bool)
+	for _, e := range newIndexToBeCreated {
+		toBeCreated[e] = true
+	}
+	entryKeys := make(map[string]string)
+	for i, e := range newIndexEntries {
+		entryKeys[newEntries[i]] = e.Key
+		if !toBeCreated[newEntries[i]] && !proto.Equal(acls[e.Key], existingACLs[e.Key]) {
+			newIndexToBeCreated = append(newIndexToBeCreated, newEntries[i])
+			toBeCreated[newEntries[i]] = true
+		}
+	}
+
+	var indexWrites []*worldstate.KVWithMetadata
+	for _, e := range newIndexToBeCreated {
+		w := &worldstate.KVWithMetadata{
+			Key: e,
+		}
+		if acl := acls[entryKeys[e]]; acl != nil {
+			w.Metadata = &types.Metadata{
+				AccessControl: acl,
+			}
+		}
+		indexWrites = append(indexWrites, w)
+	}
+
+	return indexWrites, oldIndexToBeDeleted, nil
 }
 
 func indexEntriesForDeletes(deletes []string, index map[string]types.IndexAttributeType, db worldstate.DB, dbName string) ([]string, error) {
-	existingIndexOfDeletedValues, err := indexEntriesOfExistingValue(deletes, index, db, dbName)
+	existingIndexOfDeletedValues, _, err := indexEntriesOfExistingValue(deletes, index, db, dbName)
 	if err != nil {
 		return nil, err
 	}
@@ -152,22 +256,33 @@ func indexEntriesForNewValues(kvs []*worldstate.KVWithMetadata, index map[string
 	return indexEntriesToBeCreated, nil
 }
 
-func indexEntriesOfExistingValue(deletes []string, index map[string]types.IndexAttributeType, db worldstate.DB, dbName string) ([]*IndexEntry, error) {
-	var indexEntriesToBeDeleted []*IndexEntry
+// indexEntriesOfExistingValue returns the index entries of the existing values of the given keys, and the access
+// control of the keys that have one
+func indexEntriesOfExistingValue(
+	keys []string,
+	index map[string]types.IndexAttributeType,
+	db worldstate.DB,
+	dbName string,
+) ([]*IndexEntry, map[string]*types.AccessControl, error) {
+	var existingIndexEntries []*IndexEntry
+	acls := make(map[string]*types.AccessControl)
 
-	for _, k := range deletes {
-		v, _, err := db.Get(dbName, k)
+	for _, k := range keys {
+		v, metadata, err := db.Get(dbName, k)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if acl := metadata.GetAccessControl(); acl != nil {
+			acls[k] = acl
 		}
 
-		indexEntriesToBeDeleted = append(
-			indexEntriesToBeDeleted,
+		existingIndexEntries = append(
+			existingIndexEntries,
 			decodeJSONAndConstructIndexEntries(k, v, index)...,
 		)
 	}
 
-	return indexEntriesToBeDeleted, nil
+	return existingIndexEntries, acls, nil
 }
 
 func decodeJSONAndConstructIndexEntries(key string, value []byte, index map[string]types.IndexAttributeType) []*IndexEntry {
@@ -343,3 +458,8 @@ func toStrings(indexEntries []*IndexEntry) ([]string, error) {
 func IndexDB(dbName string) string {
 	return indexDBPrefix + dbName
 }
+
+// IsIndexDB returns true if the given db is the index database of a user database
+func IsIndexDB(dbName string) bool {
+	return strings.HasPrefix(dbName, indexDBPrefix)
+}
@@ -12,6 +12,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate/leveldb"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
@@ -343,6 +344,137 @@ func TestConstructIndexEntries(t *testing.T) {
 	}
 }
 
+func TestConstructIndexEntriesWithAccessControl(t *testing.T) {
+	env := newIndexTestEnv(t)
+	defer env.cleanup()
+
+	indexDef, err := json.Marshal(map[string]types.IndexAttributeType{"a1": types.IndexAttributeType_STRING})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1", Value: indexDef},
+				{Key: IndexDB("db1")},
+			},
+		},
+	}, 1))
+
+	aclAlice := &types.AccessControl{ReadUsers: map[string]bool{"alice": true}}
+	aclBob := &types.AccessControl{ReadWriteUsers: map[string]bool{"bob": true}}
+	entryOne := `{"a":"a1","t":1,"vp":2,"v":"one","kp":2,"k":"key1"}`
+	entryTwo := `{"a":"a1","t":1,"vp":2,"v":"two","kp":2,"k":"key1"}`
+
+	commit := func(blockNum uint64, value string, acl *types.AccessControl) *worldstate.DBUpdates {
+		updates := map[string]*worldstate.DBUpdates{
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:      "key1",
+						Value:    []byte(value),
+						Metadata: &types.Metadata{AccessControl: acl},
+					},
+				},
+			},
+		}
+		indexEntries, err := ConstructIndexEntries(updates, env.db)
+		require.NoError(t, err)
+		dbIndexEntries, ok := indexEntries[IndexDB("db1")]
+		if ok {
+			updates[IndexDB("db1")] = dbIndexEntries
+		}
+		require.NoError(t, env.db.Commit(updates, blockNum))
+		return dbIndexEntries
+	}
+	requireWrites := func(expected, actual []*worldstate.KVWithMetadata) {
+		require.Len(t, actual, len(expected))
+		for i := range expected {
+			require.Equal(t, expected[i].Key, actual[i].Key)
+			require.True(t, proto.Equal(expected[i].Metadata, actual[i].Metadata))
+		}
+	}
+
+	t.Run("new value", func(t *testing.T) {
+		indexEntries := commit(2, `{"a1":"one"}`, aclAlice)
+		requireWrites([]*worldstate.KVWithMetadata{
+			{Key: entryOne, Metadata: &types.Metadata{AccessControl: aclAlice}},
+		}, indexEntries.Writes)
+		require.Empty(t, indexEntries.Deletes)
+	})
+
+	t.Run("same value and access control", func(t *testing.T) {
+		require.Nil(t, commit(3, `{"a1":"one"}`, aclAlice))
+	})
+
+	t.Run("updated access control", func(t *testing.T) {
+		indexEntries := commit(4, `{"a1":"one"}`, aclBob)
+		requireWrites([]*worldstate.KVWithMetadata{
+			{Key: entryOne, Metadata: &types.Metadata{AccessControl: aclBob}},
+		}, indexEntries.Writes)
+		require.Empty(t, indexEntries.Deletes)
+
+		_, metadata, err := env.db.Get(IndexDB("db1"), entryOne)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(aclBob, metadata.GetAccessControl()))
+	})
+
+	t.Run("removed access control and updated value", func(t *testing.T) {
+		indexEntries := commit(5, `{"a1":"two"}`, nil)
+		requireWrites([]*worldstate.KVWithMetadata{{Key: entryTwo}}, indexEntries.Writes)
+		require.Equal(t, []string{entryOne}, indexEntries.Deletes)
+	})
+}
+
+func TestConstructIndexEntriesForStoredValues(t *testing.T) {
+	env := newIndexTestEnv(t)
+	defer env.cleanup()
+
+	aclAlice := &types.AccessControl{ReadUsers: map[string]bool{"alice": true}}
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{{Key: "db1"}, {Key: "db2"}},
+		},
+	}, 1))
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "key1", Value: []byte(`{"a1":"one","a2":true}`), Metadata: &types.Metadata{AccessControl: aclAlice}},
+				{Key: "key2", Value: []byte(`{"a1":"two"}`)},
+				{Key: "key3", Value: []byte(`not a json object`)},
+			},
+		},
+	}, 2))
+
+	index := map[string]types.IndexAttributeType{
+		"a1": types.IndexAttributeType_STRING,
+		"a2": types.IndexAttributeType_BOOLEAN,
+	}
+
+	t.Run("stored values are indexed", func(t *testing.T) {
+		updates, err := ConstructIndexEntriesForStoredValues(env.db, "db1", index)
+		require.NoError(t, err)
+		require.Empty(t, updates.Deletes)
+
+		expected := map[string]*types.Metadata{
+			`{"a":"a1","t":1,"vp":2,"v":"one","kp":2,"k":"key1"}`: {AccessControl: aclAlice},
+			`{"a":"a2","t":2,"vp":2,"v":true,"kp":2,"k":"key1"}`:  {AccessControl: aclAlice},
+			`{"a":"a1","t":1,"vp":2,"v":"two","kp":2,"k":"key2"}`: nil,
+		}
+		require.Len(t, updates.Writes, len(expected))
+		for _, w := range updates.Writes {
+			metadata, ok := expected[w.Key]
+			require.True(t, ok, "unexpected index entry %s", w.Key)
+			require.True(t, proto.Equal(metadata, w.Metadata))
+			require.Nil(t, w.Value)
+		}
+	})
+
+	t.Run("database without values", func(t *testing.T) {
+		updates, err := ConstructIndexEntriesForStoredValues(env.db, "db2", index)
+		require.NoError(t, err)
+		require.Nil(t, updates)
+	})
+}
+
 func TestIndexEntriesForNewValues(t *testing.T) {
 	indexDef := map[string]types.IndexAttributeType{
 		"age": types.IndexAttributeType_NUMBER,
@@ -559,7 +691,7 @@ func TestIndexEntriesOfExistingValues(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			env := newIndexTestEnv(t)
 			tt.setup(env.db)
-			indexEntries, err := indexEntriesOfExistingValue(tt.deletedKeys, indexDef, env.db, tt.dbName)
+			indexEntries, _, err := indexEntriesOfExistingValue(tt.deletedKeys, indexDef, env.db, tt.dbName)
 			require.NoError(t, err)
 			require.ElementsMatch(t, tt.expectedIndexEntries, indexEntries)
 		})
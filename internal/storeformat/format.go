@@ -0,0 +1,132 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package storeformat records the version of the on-disk format of a store in the directory of the store, and
+// upgrades a store written in an older format when it is opened, by running the migrations from its version to the
+// current version, one version at a time. A store that was created before the version was recorded is at
+// LegacyVersion. A store written in a newer format than the current one is rejected, as the format cannot be
+// downgraded.
+package storeformat
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// LegacyVersion is the version of the format of a store that was created before the version was recorded.
+	LegacyVersion uint32 = 1
+
+	versionFileName = "formatversion"
+)
+
+// Migration converts a store from one version of its format to the next one.
+type Migration struct {
+	// Description describes the change of the format, and is logged when the migration runs.
+	Description string
+	// Migrate converts the store in the directory, which is not open. As the new version is recorded only after
+	// Migrate returns, a migration that is interrupted runs again when the store is opened next, and must therefore
+	// be idempotent.
+	Migrate func(storeDir string, logger *logger.SugarLogger) error
+}
+
+// Format describes the current on-disk format of a store.
+type Format struct {
+	// Store names the store in the logs and errors.
+	Store string
+	// Version is the current version of the format.
+	Version uint32
+	// Migrations holds the migrations to the current version, keyed by the version each one converts from.
+	Migrations map[uint32]*Migration
+}
+
+// Init records the current version in the directory of a store that is being created.
+func (f *Format) Init(storeDir string) error {
+	return writeVersion(storeDir, f.Version)
+}
+
+// Upgrade runs the migrations of an existing store, from the version recorded in its directory to the current
+// version, and records the version reached after each migration.
+func (f *Format) Upgrade(storeDir string, logger *logger.SugarLogger) error {
+	version, err := ReadVersion(storeDir)
+	if err != nil {
+		return err
+	}
+
+	if version > f.Version {
+		return errors.Errorf("the %s in [%s] is in format version [%d], which is newer than the supported version [%d]",
+			f.Store, storeDir, version, f.Version)
+	}
+	for v := version; v < f.Version; v++ {
+		if f.Migrations[v] == nil {
+			return errors.Errorf("no migration of the %s format from version [%d] to [%d]", f.Store, v, v+1)
+		}
+	}
+
+	for ; version < f.Version; version++ {
+		migration := f.Migrations[version]
+		logger.Infof("Migrating the %s in [%s] from format version [%d] to [%d]: %s",
+			f.Store, storeDir, version, version+1, migration.Description)
+		if err = migration.Migrate(storeDir, logger); err != nil {
+			return errors.WithMessagef(err, "failed to migrate the %s from format version [%d] to [%d]", f.Store, version, version+1)
+		}
+		if err = writeVersion(storeDir, version+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadVersion reads the version of the format recorded in the directory of a store, or LegacyVersion if none is.
+func ReadVersion(storeDir string) (uint32, error) {
+	versionFilePath := filepath.Join(storeDir, versionFileName)
+	exist, err := fileops.Exists(versionFilePath)
+	if err != nil {
+		return 0, err
+	}
+	if !exist {
+		return LegacyVersion, nil
+	}
+
+	content, err := ioutil.ReadFile(versionFilePath)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error while reading the format version file [%s]", versionFilePath)
+	}
+	version, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 32)
+	if err != nil || version == 0 {
+		return 0, errors.Errorf("the format version file [%s] holds an invalid version [%s]", versionFilePath, content)
+	}
+
+	return uint32(version), nil
+}
+
+// writeVersion replaces the version file atomically, such that a crash leaves either the old or the new version.
+func writeVersion(storeDir string, version uint32) error {
+	versionFilePath := filepath.Join(storeDir, versionFileName)
+	tmpFilePath := versionFilePath + ".tmp"
+
+	f, err := os.OpenFile(tmpFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "error while creating the format version file [%s]", tmpFilePath)
+	}
+	if _, err = fileops.Write(f, []byte(strconv.FormatUint(uint64(version), 10)+"\n")); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrapf(err, "error while closing the format version file [%s]", tmpFilePath)
+	}
+
+	if err = os.Rename(tmpFilePath, versionFilePath); err != nil {
+		return errors.Wrapf(err, "error while replacing the format version file [%s]", versionFilePath)
+	}
+	return fileops.SyncDir(storeDir)
+}
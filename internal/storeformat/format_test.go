@@ -0,0 +1,172 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storeformat
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testLogger(t *testing.T) *logger.SugarLogger {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+	return lg
+}
+
+// appendMigration returns a migration that appends its name to a file in the store, such that the tests can check
+// which migrations ran, and in which order.
+func appendMigration(name string) *Migration {
+	return &Migration{
+		Description: "append " + name,
+		Migrate: func(storeDir string, _ *logger.SugarLogger) error {
+			path := filepath.Join(storeDir, "log")
+			content, err := ioutil.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return ioutil.WriteFile(path, append(content, []byte(name)...), 0644)
+		},
+	}
+}
+
+func migrationLog(t *testing.T, storeDir string) string {
+	content, err := ioutil.ReadFile(filepath.Join(storeDir, "log"))
+	if os.IsNotExist(err) {
+		return ""
+	}
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestFormat(t *testing.T) {
+	lg := testLogger(t)
+
+	format := &Format{
+		Store:   "test store",
+		Version: 3,
+		Migrations: map[uint32]*Migration{
+			1: appendMigration("a"),
+			2: appendMigration("b"),
+		},
+	}
+
+	t.Run("new store", func(t *testing.T) {
+		storeDir, err := ioutil.TempDir("", "storeformat")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		require.NoError(t, format.Init(storeDir))
+		version, err := ReadVersion(storeDir)
+		require.NoError(t, err)
+		require.Equal(t, uint32(3), version)
+
+		require.NoError(t, format.Upgrade(storeDir, lg))
+		require.Equal(t, "", migrationLog(t, storeDir))
+	})
+
+	t.Run("legacy store", func(t *testing.T) {
+		storeDir, err := ioutil.TempDir("", "storeformat")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		version, err := ReadVersion(storeDir)
+		require.NoError(t, err)
+		require.Equal(t, LegacyVersion, version)
+
+		require.NoError(t, format.Upgrade(storeDir, lg))
+		require.Equal(t, "ab", migrationLog(t, storeDir))
+		version, err = ReadVersion(storeDir)
+		require.NoError(t, err)
+		require.Equal(t, uint32(3), version)
+
+		require.NoError(t, format.Upgrade(storeDir, lg))
+		require.Equal(t, "ab", migrationLog(t, storeDir))
+	})
+
+	t.Run("store in an intermediate version", func(t *testing.T) {
+		storeDir, err := ioutil.TempDir("", "storeformat")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		require.NoError(t, writeVersion(storeDir, 2))
+		require.NoError(t, format.Upgrade(storeDir, lg))
+		require.Equal(t, "b", migrationLog(t, storeDir))
+	})
+
+	t.Run("store in a newer version", func(t *testing.T) {
+		storeDir, err := ioutil.TempDir("", "storeformat")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		require.NoError(t, writeVersion(storeDir, 4))
+		err = format.Upgrade(storeDir, lg)
+		require.EqualError(t, err, "the test store in ["+storeDir+"] is in format version [4], which is newer than the supported version [3]")
+	})
+
+	t.Run("failed migration", func(t *testing.T) {
+		storeDir, err := ioutil.TempDir("", "storeformat")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		failing := &Format{
+			Store:   "test store",
+			Version: 3,
+			Migrations: map[uint32]*Migration{
+				1: appendMigration("a"),
+				2: {
+					Description: "fail",
+					Migrate: func(string, *logger.SugarLogger) error {
+						return errors.New("disk full")
+					},
+				},
+			},
+		}
+		err = failing.Upgrade(storeDir, lg)
+		require.EqualError(t, err, "failed to migrate the test store from format version [2] to [3]: disk full")
+
+		// the version reached by the migrations that succeeded is recorded, so they do not run again
+		version, err := ReadVersion(storeDir)
+		require.NoError(t, err)
+		require.Equal(t, uint32(2), version)
+		require.NoError(t, format.Upgrade(storeDir, lg))
+		require.Equal(t, "ab", migrationLog(t, storeDir))
+	})
+
+	t.Run("missing migration", func(t *testing.T) {
+		storeDir, err := ioutil.TempDir("", "storeformat")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		incomplete := &Format{
+			Store:      "test store",
+			Version:    3,
+			Migrations: map[uint32]*Migration{1: appendMigration("a")},
+		}
+		err = incomplete.Upgrade(storeDir, lg)
+		require.EqualError(t, err, "no migration of the test store format from version [2] to [3]")
+		require.Equal(t, "", migrationLog(t, storeDir))
+	})
+
+	t.Run("invalid version file", func(t *testing.T) {
+		storeDir, err := ioutil.TempDir("", "storeformat")
+		require.NoError(t, err)
+		defer os.RemoveAll(storeDir)
+
+		versionFilePath := filepath.Join(storeDir, versionFileName)
+		require.NoError(t, ioutil.WriteFile(versionFilePath, []byte("x"), 0644))
+		_, err = ReadVersion(storeDir)
+		require.EqualError(t, err, "the format version file ["+versionFilePath+"] holds an invalid version [x]")
+	})
+}
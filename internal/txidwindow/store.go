@@ -0,0 +1,225 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txidwindow
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+const (
+	// DefaultWindow is the time the TxId of an accepted transaction is remembered when no window is configured
+	DefaultWindow = 10 * time.Minute
+)
+
+var (
+	// txIDsDBName holds the expiry of the TxId of every transaction that was accepted for ordering, and is not yet
+	// committed
+	txIDsDBName = "txids"
+
+	// underCreationFlag is used to mark that the store
+	// is being created. If a failure happens during the
+	// creation, the retry logic will use this file to
+	// detect the partially created store and do cleanup
+	// before creating a new store
+	underCreationFlag = "undercreation"
+)
+
+// Store persists the TxIDs of the transactions that the node accepted for ordering, until they are committed or their
+// window elapses, so that a transaction that is submitted again with the same TxId is rejected as a duplicate, also
+// after the node restarts. The TxIDs of committed transactions are found in the block store, and are removed from the
+// window.
+//
+// The TxIDs are added without syncing the writes, as the submission of every transaction would otherwise wait for the
+// disk: the window survives a restart of the node, but a crash of the machine may lose the TxIDs added last.
+type Store struct {
+	db        *leveldb.DB
+	window    time.Duration
+	lastPurge time.Time
+	nowFn     func() time.Time
+	mutex     sync.Mutex
+	logger    *logger.SugarLogger
+}
+
+// Config holds the configuration of the TxID window store
+type Config struct {
+	StoreDir string
+	// Window is the time a TxId is remembered if its transaction is not committed; if zero, DefaultWindow is used.
+	Window time.Duration
+	Logger *logger.SugarLogger
+}
+
+// Open opens the TxID window store, and purges the expired TxIDs
+func Open(conf *Config) (*Store, error) {
+	exist, err := fileops.Exists(conf.StoreDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		partial, err := isExistingStoreCreatedPartially(conf.StoreDir)
+		if err != nil {
+			return nil, err
+		}
+		if !partial {
+			db, err := leveldb.OpenFile(filepath.Join(conf.StoreDir, txIDsDBName), &opt.Options{ErrorIfMissing: true})
+			if err != nil {
+				return nil, errors.WithMessage(err, "error while opening the existing leveldb file for the TxID window")
+			}
+			s := newStore(db, conf)
+			if err := s.purgeExpired(); err != nil {
+				db.Close()
+				return nil, err
+			}
+			return s, nil
+		}
+
+		if err := fileops.RemoveAll(conf.StoreDir); err != nil {
+			return nil, errors.Wrap(err, "error while removing the existing partially created store")
+		}
+	}
+
+	if err := fileops.CreateDir(conf.StoreDir); err != nil {
+		return nil, errors.WithMessagef(err, "error while creating directory [%s]", conf.StoreDir)
+	}
+
+	underCreationFlagPath := filepath.Join(conf.StoreDir, underCreationFlag)
+	if err := fileops.CreateFile(underCreationFlagPath); err != nil {
+		return nil, err
+	}
+
+	db, err := leveldb.OpenFile(filepath.Join(conf.StoreDir, txIDsDBName), &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while creating the TxID window database")
+	}
+
+	if err := fileops.Remove(underCreationFlagPath); err != nil {
+		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
+	}
+
+	return newStore(db, conf), nil
+}
+
+func newStore(db *leveldb.DB, conf *Config) *Store {
+	window := conf.Window
+	if window == 0 {
+		window = DefaultWindow
+	}
+
+	return &Store{
+		db:        db,
+		window:    window,
+		lastPurge: time.Now(),
+		nowFn:     time.Now,
+		logger:    conf.Logger,
+	}
+}
+
+func isExistingStoreCreatedPartially(storeDir string) (bool, error) {
+	empty, err := fileops.IsDirEmpty(storeDir)
+	if err != nil || empty {
+		return true, err
+	}
+
+	return fileops.Exists(filepath.Join(storeDir, underCreationFlag))
+}
+
+// Close closes the store
+func (s *Store) Close() error {
+	if err := s.db.Close(); err != nil {
+		return errors.WithMessage(err, "error while closing the TxID window database")
+	}
+	return nil
+}
+
+// Add adds the TxId of a transaction that is accepted for ordering to the window. The expired TxIDs are purged at
+// most once per window. The TxId is synced to the disk before Add returns, such that a crash cannot drop it and
+// let a replay of the transaction through after a restart.
+func (s *Store) Add(txID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.nowFn()
+	if now.Sub(s.lastPurge) >= s.window {
+		if err := s.purgeExpired(); err != nil {
+			return err
+		}
+	}
+
+	expiry := make([]byte, 8)
+	binary.BigEndian.PutUint64(expiry, uint64(now.Add(s.window).UnixNano()))
+	if err := s.db.Put([]byte(txID), expiry, &opt.WriteOptions{Sync: true}); err != nil {
+		return errors.Wrapf(err, "error while adding the TxId [%s] to the TxID window", txID)
+	}
+	return nil
+}
+
+// Has returns true if the TxId is in the window, and has not expired
+func (s *Store) Has(txID string) (bool, error) {
+	expiry, err := s.db.Get([]byte(txID), nil)
+	if err == leveldb.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error while looking up the TxId [%s] in the TxID window", txID)
+	}
+
+	return !expired(expiry, s.nowFn()), nil
+}
+
+// Remove removes the TxIDs of committed transactions from the window
+func (s *Store) Remove(txIDs []string) error {
+	if len(txIDs) == 0 {
+		return nil
+	}
+
+	batch := &leveldb.Batch{}
+	for _, txID := range txIDs {
+		batch.Delete([]byte(txID))
+	}
+	if err := s.db.Write(batch, &opt.WriteOptions{Sync: false}); err != nil {
+		return errors.Wrap(err, "error while removing committed TxIDs from the TxID window")
+	}
+	return nil
+}
+
+// purgeExpired removes the expired TxIDs, i.e., those of the transactions that were accepted but not committed within
+// the window, e.g., because they were lost when the node restarted before ordering them.
+func (s *Store) purgeExpired() error {
+	now := s.nowFn()
+	batch := &leveldb.Batch{}
+
+	itr := s.db.NewIterator(nil, nil)
+	for itr.Next() {
+		if expired(itr.Value(), now) {
+			batch.Delete(append([]byte(nil), itr.Key()...))
+		}
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over the TxID window")
+	}
+
+	if batch.Len() > 0 {
+		if err := s.db.Write(batch, &opt.WriteOptions{Sync: false}); err != nil {
+			return errors.Wrap(err, "error while purging the expired TxIDs from the TxID window")
+		}
+		s.logger.Debugf("purged %d expired TxIDs from the TxID window", batch.Len())
+	}
+	s.lastPurge = now
+
+	return nil
+}
+
+func expired(expiry []byte, now time.Time) bool {
+	return len(expiry) != 8 || int64(binary.BigEndian.Uint64(expiry)) <= now.UnixNano()
+}
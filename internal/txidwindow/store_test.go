@@ -0,0 +1,129 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txidwindow
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) (*Store, *Config) {
+	storeDir, err := ioutil.TempDir("/tmp", "txidwindow")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := os.RemoveAll(storeDir); err != nil {
+			t.Errorf("error while removing directory %s, %v", storeDir, err)
+		}
+	})
+
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	conf := &Config{StoreDir: storeDir, Window: time.Minute, Logger: lg}
+	s, err := Open(conf)
+	require.NoError(t, err)
+	return s, conf
+}
+
+func TestAddAndRemove(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newTestStore(t)
+	defer s.Close()
+
+	require.NoError(t, s.Add("tx1"))
+	require.NoError(t, s.Add("tx2"))
+
+	for _, txID := range []string{"tx1", "tx2"} {
+		has, err := s.Has(txID)
+		require.NoError(t, err)
+		require.True(t, has)
+	}
+	has, err := s.Has("tx3")
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, s.Remove([]string{"tx1", "tx3"}))
+	has, err = s.Has("tx1")
+	require.NoError(t, err)
+	require.False(t, has)
+	has, err = s.Has("tx2")
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
+func TestExpiry(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newTestStore(t)
+	defer s.Close()
+
+	now := time.Unix(1000, 0)
+	s.nowFn = func() time.Time { return now }
+	s.lastPurge = now
+
+	require.NoError(t, s.Add("tx1"))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, s.Add("tx2"))
+
+	now = now.Add(31 * time.Second)
+	has, err := s.Has("tx1")
+	require.NoError(t, err)
+	require.False(t, has)
+	has, err = s.Has("tx2")
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// the expired TxIDs are purged once per window, when a TxId is added
+	require.NoError(t, s.Add("tx3"))
+	_, err = s.db.Get([]byte("tx1"), nil)
+	require.Error(t, err)
+	_, err = s.db.Get([]byte("tx2"), nil)
+	require.NoError(t, err)
+}
+
+func TestReopen(t *testing.T) {
+	t.Parallel()
+
+	s, conf := newTestStore(t)
+	require.NoError(t, s.Add("tx1"))
+	require.NoError(t, s.Close())
+
+	s, err := Open(conf)
+	require.NoError(t, err)
+	defer s.Close()
+
+	has, err := s.Has("tx1")
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
+func TestOpenPartiallyCreatedStore(t *testing.T) {
+	t.Parallel()
+
+	s, conf := newTestStore(t)
+	require.NoError(t, s.Add("tx1"))
+	require.NoError(t, s.Close())
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(conf.StoreDir, underCreationFlag), nil, 0644))
+
+	s, err := Open(conf)
+	require.NoError(t, err)
+	defer s.Close()
+
+	has, err := s.Has("tx1")
+	require.NoError(t, err)
+	require.False(t, has)
+}
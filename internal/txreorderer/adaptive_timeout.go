@@ -0,0 +1,106 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txreorderer
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// lowLoadFill is the fill of a batch cut by the timeout below which the load is considered low, and the timeout is
+	// shortened, as waiting longer adds latency to the few pending transactions without batching many more.
+	lowLoadFill = 0.25
+	// highLoadFill is the fill of a batch cut by the timeout above which the load is considered high, and the timeout
+	// is lengthened, so that fuller blocks are created.
+	highLoadFill = 0.5
+)
+
+var (
+	batchTimeoutGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "txreorderer",
+		Name:      "batch_timeout_seconds",
+		Help:      "The timeout after which the pending data transactions are cut into a block.",
+	}, []string{"node"})
+
+	batchTimeoutAdjustmentsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "txreorderer",
+		Name:      "batch_timeout_adjustments_total",
+		Help:      "The number of times the adaptive batch timeout was shortened or lengthened, by direction.",
+	}, []string{"node", "direction"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		batchTimeoutGauge,
+		batchTimeoutAdjustmentsCounter,
+	)
+}
+
+// AdaptiveTimeoutConfig holds the bounds of the adaptive batch timeout.
+type AdaptiveTimeoutConfig struct {
+	MinBatchTimeout time.Duration
+	MaxBatchTimeout time.Duration
+}
+
+// adaptiveTimeout adapts the batch timeout to the load, which is measured by the fill of the batches, i.e., the
+// fraction of the transaction count or size limit of a block that a batch reaches. When a batch is cut by the timeout
+// while less than a quarter full, the timeout is halved, down to the minimum; when a batch is cut by the timeout while
+// more than half full, or is cut by a limit, the timeout is doubled, up to the maximum.
+type adaptiveTimeout struct {
+	nodeID  string
+	timeout time.Duration
+	min     time.Duration
+	max     time.Duration
+}
+
+func newAdaptiveTimeout(nodeID string, initial time.Duration, conf *AdaptiveTimeoutConfig) *adaptiveTimeout {
+	a := &adaptiveTimeout{
+		nodeID:  nodeID,
+		timeout: initial,
+		min:     conf.MinBatchTimeout,
+		max:     conf.MaxBatchTimeout,
+	}
+	if a.timeout < a.min {
+		a.timeout = a.min
+	}
+	if a.timeout > a.max {
+		a.timeout = a.max
+	}
+	return a
+}
+
+// next returns the timeout of the next batch, given the fill of the batch that was cut, and whether it was cut by the
+// timeout or by a limit.
+func (a *adaptiveTimeout) next(fill float64, timedOut bool) time.Duration {
+	switch {
+	case timedOut && fill < lowLoadFill:
+		if shorter := a.timeout / 2; shorter > a.min {
+			a.adjust(shorter, "shorten")
+		} else {
+			a.adjust(a.min, "shorten")
+		}
+	case !timedOut || fill > highLoadFill:
+		if longer := a.timeout * 2; longer < a.max {
+			a.adjust(longer, "lengthen")
+		} else {
+			a.adjust(a.max, "lengthen")
+		}
+	}
+
+	return a.timeout
+}
+
+func (a *adaptiveTimeout) adjust(timeout time.Duration, direction string) {
+	if timeout == a.timeout {
+		return
+	}
+
+	a.timeout = timeout
+	batchTimeoutAdjustmentsCounter.WithLabelValues(a.nodeID, direction).Inc()
+	batchTimeoutGauge.WithLabelValues(a.nodeID).Set(timeout.Seconds())
+}
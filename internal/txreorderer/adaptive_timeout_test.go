@@ -0,0 +1,100 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txreorderer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveTimeout(t *testing.T) {
+	adjustments := func(nodeID, direction string) float64 {
+		return testutil.ToFloat64(batchTimeoutAdjustmentsCounter.WithLabelValues(nodeID, direction))
+	}
+
+	conf := &AdaptiveTimeoutConfig{
+		MinBatchTimeout: 10 * time.Millisecond,
+		MaxBatchTimeout: 100 * time.Millisecond,
+	}
+
+	t.Run("initial timeout within bounds", func(t *testing.T) {
+		require.Equal(t, 10*time.Millisecond, newAdaptiveTimeout("node1", time.Millisecond, conf).timeout)
+		require.Equal(t, 50*time.Millisecond, newAdaptiveTimeout("node1", 50*time.Millisecond, conf).timeout)
+		require.Equal(t, 100*time.Millisecond, newAdaptiveTimeout("node1", time.Second, conf).timeout)
+	})
+
+	t.Run("low load shortens", func(t *testing.T) {
+		a := newAdaptiveTimeout("adaptive-low", 50*time.Millisecond, conf)
+		shortened := adjustments("adaptive-low", "shorten")
+		require.Equal(t, 25*time.Millisecond, a.next(0.1, true))
+		require.Equal(t, 12500*time.Microsecond, a.next(0, true))
+		require.Equal(t, 10*time.Millisecond, a.next(0, true))
+		require.Equal(t, 10*time.Millisecond, a.next(0, true))
+
+		require.Equal(t, shortened+3, adjustments("adaptive-low", "shorten"))
+		require.Equal(t, 0.01, testutil.ToFloat64(batchTimeoutGauge.WithLabelValues("adaptive-low")))
+	})
+
+	t.Run("high load lengthens", func(t *testing.T) {
+		a := newAdaptiveTimeout("adaptive-high", 30*time.Millisecond, conf)
+		lengthened := adjustments("adaptive-high", "lengthen")
+		require.Equal(t, 60*time.Millisecond, a.next(0.75, true))
+		require.Equal(t, 100*time.Millisecond, a.next(1, false))
+		require.Equal(t, 100*time.Millisecond, a.next(1, false))
+
+		require.Equal(t, lengthened+2, adjustments("adaptive-high", "lengthen"))
+		require.Equal(t, 0.1, testutil.ToFloat64(batchTimeoutGauge.WithLabelValues("adaptive-high")))
+	})
+
+	t.Run("moderate load keeps", func(t *testing.T) {
+		a := newAdaptiveTimeout("adaptive-moderate", 30*time.Millisecond, conf)
+		require.Equal(t, 30*time.Millisecond, a.next(lowLoadFill, true))
+		require.Equal(t, 30*time.Millisecond, a.next(highLoadFill, true))
+	})
+}
+
+func TestTxReordererAdaptiveTimeout(t *testing.T) {
+	logger, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	r := New(&Config{
+		NodeID:             "adaptive-reorderer",
+		TxQueue:            queue.New(10),
+		TxBatchQueue:       queue.New(20),
+		MaxTxCountPerBatch: 1,
+		BatchTimeout:       80 * time.Millisecond,
+		AdaptiveTimeout: &AdaptiveTimeoutConfig{
+			MinBatchTimeout: 10 * time.Millisecond,
+			MaxBatchTimeout: 160 * time.Millisecond,
+		},
+		Logger: logger,
+	})
+	go r.Start()
+	r.WaitTillStart()
+	defer r.Stop()
+
+	timeout := func() float64 {
+		return testutil.ToFloat64(batchTimeoutGauge.WithLabelValues("adaptive-reorderer"))
+	}
+
+	// without load, the timeout is shortened down to the minimum
+	require.Eventually(t, func() bool { return timeout() == 0.01 }, 5*time.Second, 10*time.Millisecond)
+
+	// batches cut by the count limit lengthen the timeout up to the maximum
+	for i := 0; i < 10; i++ {
+		r.txQueue.Enqueue(&types.DataTxEnvelope{Payload: &types.DataTx{TxId: "tx"}})
+	}
+	require.Eventually(t, func() bool { return timeout() == 0.16 }, 5*time.Second, time.Millisecond)
+}
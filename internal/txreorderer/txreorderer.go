@@ -3,26 +3,50 @@
 package txreorderer
 
 import (
+	"context"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 )
 
+// BlockHeaderSizeReserve is the number of bytes, out of the maximal block size, that are reserved for the block header
+// and the consensus metadata, which are added to the block after the transactions are batched. The validation info of
+// each transaction, which is added to the block header as well, is reserved on top of it, see
+// txvalidation.ValidationInfoSizeReserve.
+const BlockHeaderSizeReserve = 1024
+
+// MinBlockSize is the maximal block size up to which no transaction fits in a block
+const MinBlockSize = BlockHeaderSizeReserve + txvalidation.ValidationInfoSizeReserve
+
 // TxReorderer holds queue and other components needed to reorder
 // transactions before creating a next batch of transactions to be
 // included in the block
 type TxReorderer struct {
+	nodeID             string
 	txQueue            *queue.Queue
 	txBatchQueue       *queue.Queue
 	maxTxCountPerBatch uint32
+	maxBatchSizeBytes  uint64
 	batchTimeout       time.Duration
-	started            chan struct{}
-	stop               chan struct{}
-	stopped            chan struct{}
-	pendingDataTxs     *types.DataTxEnvelopes
-	logger             *logger.SugarLogger
+	// adaptiveTimeout adapts the batch timeout to the load; nil means the batch timeout is fixed
+	adaptiveTimeout *adaptiveTimeout
+	started         chan struct{}
+	stop            chan struct{}
+	stopped         chan struct{}
+	pendingDataTxs  *types.DataTxEnvelopes
+	// pendingDataTxsSize is the size of the pending data transactions in the block, including their validation info
+	pendingDataTxsSize uint64
+	pendingReservation *membudget.Reservation
+	memBudget          *membudget.Accountant
+	// stopCtx is cancelled when the reorderer is stopped, to abort waiting for the memory budget
+	stopCtx    context.Context
+	cancelStop context.CancelFunc
+	logger     *logger.SugarLogger
 	// TODO:
 	// tx merkle tree
 	// dependency graph
@@ -32,20 +56,51 @@ type TxReorderer struct {
 // Config holds the configuration information need to start the transaction
 // reorderer
 type Config struct {
+	NodeID             string
 	TxQueue            *queue.Queue
 	TxBatchQueue       *queue.Queue
 	MaxTxCountPerBatch uint32
-	BatchTimeout       time.Duration
-	Logger             *logger.SugarLogger
+	// MaxBlockSizeBytes limits the serialized size of a block; data transactions are batched such that the
+	// serialized batch, along with the validation info of its transactions, does not exceed
+	// MaxBlockSizeBytes - BlockHeaderSizeReserve. Zero means no limit.
+	MaxBlockSizeBytes uint64
+	BatchTimeout      time.Duration
+	// AdaptiveTimeout bounds the batch timeout when it is adapted to the load, starting from BatchTimeout; nil means
+	// the batch timeout is fixed.
+	AdaptiveTimeout *AdaptiveTimeoutConfig
+	// MemBudget limits the memory held by the pending batch of data transactions; nil means no limit.
+	MemBudget *membudget.Accountant
+	Logger    *logger.SugarLogger
 }
 
 // New creates a transaction reorderer
 func New(conf *Config) *TxReorderer {
+	var maxBatchSizeBytes uint64
+	if conf.MaxBlockSizeBytes > 0 {
+		maxBatchSizeBytes = maxBatchSize(conf.MaxBlockSizeBytes)
+	}
+
+	batchTimeout := conf.BatchTimeout
+	var adaptive *adaptiveTimeout
+	if conf.AdaptiveTimeout != nil {
+		adaptive = newAdaptiveTimeout(conf.NodeID, conf.BatchTimeout, conf.AdaptiveTimeout)
+		batchTimeout = adaptive.timeout
+	}
+
+	stopCtx, cancelStop := context.WithCancel(context.Background())
+
 	return &TxReorderer{
+		nodeID:             conf.NodeID,
 		txQueue:            conf.TxQueue,
 		txBatchQueue:       conf.TxBatchQueue,
 		maxTxCountPerBatch: conf.MaxTxCountPerBatch,
-		batchTimeout:       conf.BatchTimeout,
+		maxBatchSizeBytes:  maxBatchSizeBytes,
+		batchTimeout:       batchTimeout,
+		adaptiveTimeout:    adaptive,
+		pendingReservation: conf.MemBudget.NewReservation(membudget.KindBlockAssembly),
+		memBudget:          conf.MemBudget,
+		stopCtx:            stopCtx,
+		cancelStop:         cancelStop,
 		started:            make(chan struct{}),
 		stop:               make(chan struct{}),
 		stopped:            make(chan struct{}),
@@ -59,6 +114,7 @@ func (r *TxReorderer) Start() {
 	r.logger.Info("starting the transactions reorderer")
 	close(r.started)
 
+	batchTimeoutGauge.WithLabelValues(r.nodeID).Set(r.batchTimeout.Seconds())
 	ticker := time.NewTicker(r.batchTimeout)
 	defer ticker.Stop()
 
@@ -72,7 +128,11 @@ func (r *TxReorderer) Start() {
 
 		case <-ticker.C:
 			r.logger.Debug("block timeout has occurred")
+			fill := r.pendingFill()
 			r.enqueueAndResetPendingDataTxBatch()
+			if r.adaptBatchTimeout(fill, true) {
+				ticker.Reset(r.batchTimeout)
+			}
 
 		default:
 			tx := r.txQueue.DequeueWithWaitLimit(r.batchTimeout)
@@ -82,10 +142,34 @@ func (r *TxReorderer) Start() {
 
 			switch env := tx.(type) {
 			case *types.DataTxEnvelope:
+				txSize := encodedEnvelopeSize(env)
+				sizeInBlock := txSize + txvalidation.ValidationInfoSizeReserve
+				if r.maxBatchSizeBytes > 0 && r.pendingDataTxsSize+sizeInBlock > r.maxBatchSizeBytes {
+					r.logger.Debugf("block size limit reached, pending batch size [%d], tx size in block [%d], limit [%d]",
+						r.pendingDataTxsSize, sizeInBlock, r.maxBatchSizeBytes)
+					r.enqueueAndResetPendingDataTxBatch()
+					r.adaptBatchTimeout(1, false)
+					ticker.Reset(r.batchTimeout)
+				}
+
+				if !r.pendingReservation.TryGrow(txSize) {
+					// the pending batch is cut short to return its memory to the budget, and the reorderer waits for
+					// memory to be released, which throttles the intake of transactions
+					r.logger.Debugf("memory budget exhausted, pending batch size [%d], tx size [%d]", r.pendingDataTxsSize, txSize)
+					r.enqueueAndResetPendingDataTxBatch()
+					ticker.Reset(r.batchTimeout)
+					if err := r.pendingReservation.Grow(r.stopCtx, txSize); err != nil {
+						r.logger.Warnf("the transaction [%s] is batched without charging the memory budget: %s",
+							env.GetPayload().GetTxId(), err)
+					}
+				}
+
 				r.pendingDataTxs.Envelopes = append(r.pendingDataTxs.Envelopes, env)
+				r.pendingDataTxsSize += sizeInBlock
 
 				if uint32(len(r.pendingDataTxs.Envelopes)) == r.maxTxCountPerBatch {
 					r.enqueueAndResetPendingDataTxBatch()
+					r.adaptBatchTimeout(1, false)
 					ticker.Reset(r.batchTimeout)
 				}
 
@@ -134,8 +218,10 @@ func (r *TxReorderer) WaitTillStart() {
 // Stop stops the transaction reorderer
 func (r *TxReorderer) Stop() {
 	r.txQueue.Close()
+	r.cancelStop()
 	close(r.stop)
 	<-r.stopped
+	r.pendingReservation.Release()
 }
 
 func (r *TxReorderer) enqueueAndResetPendingDataTxBatch() {
@@ -151,4 +237,64 @@ func (r *TxReorderer) enqueueAndResetPendingDataTxBatch() {
 	)
 
 	r.pendingDataTxs = &types.DataTxEnvelopes{}
+	r.pendingDataTxsSize = 0
+	// the enqueued batches are bounded by the length of the batch queue, hence only the pending batch is charged
+	r.pendingReservation.Release()
+}
+
+// pendingFill returns the fraction of the transaction count or size limit of a batch, whichever is larger, that the
+// pending data transactions reach
+func (r *TxReorderer) pendingFill() float64 {
+	var fill float64
+	if r.maxTxCountPerBatch > 0 {
+		fill = float64(len(r.pendingDataTxs.Envelopes)) / float64(r.maxTxCountPerBatch)
+	}
+	if r.maxBatchSizeBytes > 0 {
+		if sizeFill := float64(r.pendingDataTxsSize) / float64(r.maxBatchSizeBytes); sizeFill > fill {
+			fill = sizeFill
+		}
+	}
+	return fill
+}
+
+// adaptBatchTimeout adapts the batch timeout to the fill of the batch that was cut, if the timeout is adaptive, and
+// returns true if the timeout changed
+func (r *TxReorderer) adaptBatchTimeout(fill float64, timedOut bool) bool {
+	if r.adaptiveTimeout == nil {
+		return false
+	}
+
+	timeout := r.adaptiveTimeout.next(fill, timedOut)
+	if timeout == r.batchTimeout {
+		return false
+	}
+
+	r.logger.Debugf("batch timeout changed from %s to %s, fill of the last batch [%.2f]", r.batchTimeout, timeout, fill)
+	r.batchTimeout = timeout
+	return true
+}
+
+// MaxTxSize returns the maximal serialized size of a transaction that fits in a block of the given maximal size, along
+// with its validation info.
+func MaxTxSize(maxBlockSizeBytes uint64) uint64 {
+	if maxBlockSizeBytes <= MinBlockSize {
+		return 0
+	}
+	return maxBlockSizeBytes - MinBlockSize
+}
+
+// maxBatchSize returns the maximal size of a batch of data transactions, along with their validation info, in a block
+// of the given maximal size.
+func maxBatchSize(maxBlockSizeBytes uint64) uint64 {
+	if maxBlockSizeBytes <= BlockHeaderSizeReserve {
+		return 0
+	}
+	return maxBlockSizeBytes - BlockHeaderSizeReserve
+}
+
+// encodedEnvelopeSize returns the number of bytes the envelope adds to the serialized DataTxEnvelopes,
+// i.e., the field tag, the length prefix, and the envelope itself.
+func encodedEnvelopeSize(env *types.DataTxEnvelope) uint64 {
+	size := proto.Size(env)
+	return uint64(1 + proto.SizeVarint(uint64(size)) + size)
 }
@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger-labs/orion-server/internal/membudget"
 	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/internal/txvalidation"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
@@ -177,6 +179,7 @@ func TestTxReorderer(t *testing.T) {
 	tests := []struct {
 		name               string
 		maxTxCountPerBatch uint32
+		maxBatchSizeBytes  uint64
 		timeout            time.Duration
 		txs                []interface{}
 		expectedTxBatches  []interface{}
@@ -271,6 +274,34 @@ func TestTxReorderer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:               "batch size reached",
+			maxTxCountPerBatch: 1000,
+			maxBatchSizeBytes:  encodedEnvelopeSize(dataTx1) + encodedEnvelopeSize(dataTx2) + 2*txvalidation.ValidationInfoSizeReserve,
+			timeout:            500 * time.Millisecond,
+			txs: []interface{}{
+				dataTx1,
+				dataTx2,
+				dataTx3,
+			},
+			expectedTxBatches: []interface{}{
+				&types.Block_DataTxEnvelopes{
+					DataTxEnvelopes: &types.DataTxEnvelopes{
+						Envelopes: []*types.DataTxEnvelope{
+							dataTx1,
+							dataTx2,
+						},
+					},
+				},
+				&types.Block_DataTxEnvelopes{
+					DataTxEnvelopes: &types.DataTxEnvelopes{
+						Envelopes: []*types.DataTxEnvelope{
+							dataTx3,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -281,6 +312,7 @@ func TestTxReorderer(t *testing.T) {
 			defer r.Stop()
 
 			r.maxTxCountPerBatch = tt.maxTxCountPerBatch
+			r.maxBatchSizeBytes = tt.maxBatchSizeBytes
 			for _, tx := range tt.txs {
 				r.txQueue.Enqueue(tx)
 			}
@@ -297,3 +329,67 @@ func TestTxReorderer(t *testing.T) {
 		})
 	}
 }
+
+func TestTxReordererMemoryBudget(t *testing.T) {
+	c := &logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	}
+	logger, err := logger.New(c)
+	require.NoError(t, err)
+
+	var dataTxs []*types.DataTxEnvelope
+	for _, key := range []string{"key1", "key2", "key3"} {
+		dataTxs = append(dataTxs, &types.DataTxEnvelope{
+			Payload: &types.DataTx{
+				MustSignUserIds: []string{"user1"},
+				DbOperations: []*types.DBOperation{
+					{
+						DbName:      "db1",
+						DataDeletes: []*types.DataDelete{{Key: key}},
+					},
+				},
+			},
+		})
+	}
+	txSize := encodedEnvelopeSize(dataTxs[0])
+
+	// the budget fits two transactions, hence the third transaction cuts the batch
+	memBudget := membudget.New(&membudget.Config{LimitBytes: 2*txSize + 1, Logger: logger})
+	r := New(&Config{
+		TxQueue:            queue.New(10),
+		TxBatchQueue:       queue.New(10),
+		MaxTxCountPerBatch: 10,
+		BatchTimeout:       time.Minute,
+		MemBudget:          memBudget,
+		Logger:             logger,
+	})
+	go r.Start()
+	r.WaitTillStart()
+
+	for _, tx := range dataTxs {
+		r.txQueue.Enqueue(tx)
+	}
+
+	require.Eventually(t, func() bool { return r.txBatchQueue.Size() == 1 }, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, &types.Block_DataTxEnvelopes{
+		DataTxEnvelopes: &types.DataTxEnvelopes{
+			Envelopes: dataTxs[:2],
+		},
+	}, r.txBatchQueue.Dequeue())
+	require.Eventually(t, func() bool {
+		return memBudget.Usage().UsedByKind[membudget.KindBlockAssembly] == txSize
+	}, 2*time.Second, 10*time.Millisecond)
+
+	r.Stop()
+	require.Equal(t, uint64(0), memBudget.Usage().UsedBytes)
+}
+
+func TestMaxTxSize(t *testing.T) {
+	require.Equal(t, uint64(0), MaxTxSize(0))
+	require.Equal(t, uint64(0), MaxTxSize(MinBlockSize))
+	require.Equal(t, uint64(1), MaxTxSize(MinBlockSize+1))
+	require.Equal(t, uint64(1024*1024-BlockHeaderSizeReserve-txvalidation.ValidationInfoSizeReserve), MaxTxSize(1024*1024))
+}
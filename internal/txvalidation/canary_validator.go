@@ -0,0 +1,91 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// IsCanaryTx returns whether a data transaction operates on the canary database. A canary transaction is submitted by
+// a node to check its own health: it is signed by the node, rather than by users, and may only write the canary key of
+// the node, which is the node ID.
+func IsCanaryTx(tx *types.DataTx) bool {
+	for _, ops := range tx.GetDbOperations() {
+		if ops.DbName == worldstate.CanaryDBName {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCanarySignature checks that a canary transaction is signed by a single node of the cluster, and returns the
+// ID of the node
+func (v *dataTxValidator) validateCanarySignature(txEnv *types.DataTxEnvelope) ([]string, *types.ValidationInfo, error) {
+	mustSign := txEnv.Payload.MustSignUserIds
+	if len(mustSign) != 1 || len(txEnv.Signatures) != 1 || txEnv.Signatures[mustSign[0]] == nil {
+		return nil, &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_UNAUTHORISED,
+			ReasonIfInvalid: "a canary transaction must be signed by a single node, which must sign",
+		}, nil
+	}
+	nodeID := mustSign[0]
+
+	node, _, err := v.identityQuerier.GetNode(nodeID)
+	if err != nil {
+		if _, ok := err.(*identity.NotFoundErr); ok {
+			return nil, &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "the signer [" + nodeID + "] of the canary transaction is not a node of the cluster",
+			}, nil
+		}
+		return nil, nil, err
+	}
+
+	payloadBytes, err := json.Marshal(txEnv.Payload)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to json.Marshal the canary transaction")
+	}
+	verifier, err := crypto.NewVerifier(node.Certificate)
+	if err == nil {
+		err = verifier.Verify(payloadBytes, txEnv.Signatures[nodeID])
+	}
+	if err != nil {
+		return nil, &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_UNAUTHORISED,
+			ReasonIfInvalid: "signature of the node [" + nodeID + "] on the canary transaction is not valid: " + err.Error(),
+		}, nil
+	}
+
+	return []string{nodeID}, &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+}
+
+// validateCanaryOps checks that a canary transaction only writes the canary key of the node that signed it
+func (v *dataTxValidator) validateCanaryOps(tx *types.DataTx, nodeIDs []string) *types.ValidationInfo {
+	invalid := func(reason string) *types.ValidationInfo {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: reason,
+			FailedOperation: &types.DBOperationFailure{DbName: worldstate.CanaryDBName},
+		}
+	}
+
+	if len(tx.DbOperations) != 1 {
+		return invalid("a canary transaction must operate only on the database [" + worldstate.CanaryDBName + "]")
+	}
+	ops := tx.DbOperations[0]
+	if len(ops.DataReads) != 0 || len(ops.DataDeletes) != 0 || len(ops.AclWrites) != 0 || len(ops.DataWrites) != 1 {
+		return invalid("a canary transaction must hold a single write and no other operation")
+	}
+	if w := ops.DataWrites[0]; len(nodeIDs) != 1 || w.Key != nodeIDs[0] || w.Acl != nil {
+		return invalid("a canary transaction may only write the canary key of the node that signs it, without an ACL")
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}
+}
@@ -0,0 +1,162 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCanaryTx(t *testing.T) {
+	t.Parallel()
+
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node1", "node2", "alice"})
+	node1Cert, node1Signer := testutils.LoadTestClientCrypto(t, cryptoDir, "node1")
+	_, node2Signer := testutils.LoadTestClientCrypto(t, cryptoDir, "node2")
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+
+	setup := func(db worldstate.DB) {
+		node, err := proto.Marshal(&types.NodeConfig{Id: "node1", Certificate: node1Cert.Raw})
+		require.NoError(t, err)
+		alice, err := proto.Marshal(&types.User{
+			Id:          "alice",
+			Certificate: aliceCert.Raw,
+			Privilege: &types.Privilege{
+				DbPermission: map[string]types.Privilege_Access{worldstate.CanaryDBName: types.Privilege_ReadWrite},
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: string(identity.NodeNamespace) + "node1", Value: node}},
+			},
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: string(identity.UserNamespace) + "alice", Value: alice}},
+			},
+		}, 1))
+	}
+
+	canaryTx := func(signer string, ops ...*types.DBOperation) *types.DataTx {
+		if len(ops) == 0 {
+			ops = []*types.DBOperation{
+				{
+					DbName:     worldstate.CanaryDBName,
+					DataWrites: []*types.DataWrite{{Key: signer, Value: []byte("1")}},
+				},
+			}
+		}
+		return &types.DataTx{
+			MustSignUserIds: []string{signer},
+			TxId:            "canary-" + signer,
+			DbOperations:    ops,
+		}
+	}
+	incorrectEntries := func(reason string) *types.ValidationInfo {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: reason,
+			FailedOperation: &types.DBOperationFailure{DbName: worldstate.CanaryDBName},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		txEnv          *types.DataTxEnvelope
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:           "valid: the node writes its canary key",
+			txEnv:          testutils.SignedDataTxEnvelope(t, []crypto.Signer{node1Signer}, canaryTx("node1")),
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name:  "invalid: signed by a user",
+			txEnv: testutils.SignedDataTxEnvelope(t, []crypto.Signer{aliceSigner}, canaryTx("alice")),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "the signer [alice] of the canary transaction is not a node of the cluster",
+			},
+		},
+		{
+			name: "invalid: signed by another key",
+			txEnv: &types.DataTxEnvelope{
+				Payload:    canaryTx("node1"),
+				Signatures: map[string][]byte{"node1": testutils.SignatureFromTx(t, node2Signer, canaryTx("node1"))},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "signature of the node [node1] on the canary transaction is not valid: x509: ECDSA verification failure",
+			},
+		},
+		{
+			name: "invalid: signed by more than one node",
+			txEnv: testutils.SignedDataTxEnvelope(t, []crypto.Signer{node1Signer, node2Signer}, &types.DataTx{
+				MustSignUserIds: []string{"node1", "node2"},
+				DbOperations:    canaryTx("node1").DbOperations,
+			}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "a canary transaction must be signed by a single node, which must sign",
+			},
+		},
+		{
+			name: "invalid: operates on another database",
+			txEnv: testutils.SignedDataTxEnvelope(t, []crypto.Signer{node1Signer}, canaryTx("node1",
+				canaryTx("node1").DbOperations[0],
+				&types.DBOperation{DbName: worldstate.DefaultDBName},
+			)),
+			expectedResult: incorrectEntries("a canary transaction must operate only on the database [_canary]"),
+		},
+		{
+			name: "invalid: reads the canary key",
+			txEnv: testutils.SignedDataTxEnvelope(t, []crypto.Signer{node1Signer}, canaryTx("node1", &types.DBOperation{
+				DbName:     worldstate.CanaryDBName,
+				DataReads:  []*types.DataRead{{Key: "node1"}},
+				DataWrites: []*types.DataWrite{{Key: "node1", Value: []byte("1")}},
+			})),
+			expectedResult: incorrectEntries("a canary transaction must hold a single write and no other operation"),
+		},
+		{
+			name: "invalid: writes the canary key of another node",
+			txEnv: testutils.SignedDataTxEnvelope(t, []crypto.Signer{node1Signer}, canaryTx("node1", &types.DBOperation{
+				DbName:     worldstate.CanaryDBName,
+				DataWrites: []*types.DataWrite{{Key: "node2", Value: []byte("1")}},
+			})),
+			expectedResult: incorrectEntries("a canary transaction may only write the canary key of the node that signs it, without an ACL"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+
+			setup(env.db)
+
+			require.True(t, IsCanaryTx(tt.txEnv.Payload))
+			usersWithValidSignTx, valInfo, err := env.validator.dataTxValidator.validateSignatures(tt.txEnv, time.Now().UnixNano())
+			require.NoError(t, err)
+			if valInfo.Flag != types.Flag_VALID {
+				require.Equal(t, tt.expectedResult, valInfo)
+				return
+			}
+
+			result, err := env.validator.dataTxValidator.validate(tt.txEnv, usersWithValidSignTx, newPendingOperations())
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
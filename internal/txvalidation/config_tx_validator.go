@@ -8,11 +8,15 @@ import (
 	"hash/crc32"
 	"net"
 	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/comm"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/replication"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
@@ -21,14 +25,27 @@ import (
 )
 
 type ConfigTxValidator struct {
-	db              worldstate.DB
-	identityQuerier *identity.Querier
-	sigValidator    *txSigValidator
-	logger          *logger.SugarLogger
+	db                  worldstate.DB
+	identityQuerier     *identity.Querier
+	sigValidator        *txSigValidator
+	consensusAlgorithms map[string]bool
+	logger              *logger.SugarLogger
 }
 
+// Validate validates a configuration transaction before it is ordered, assuming that it is committed in the block that
+// follows the last committed block, at the current time
 func (v *ConfigTxValidator) Validate(txEnv *types.ConfigTxEnvelope) (*types.ValidationInfo, error) {
-	valInfo, err := v.sigValidator.validate(txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload)
+	height, err := v.db.Height()
+	if err != nil {
+		return nil, err
+	}
+
+	return v.validate(txEnv, height+1, time.Now().UnixNano())
+}
+
+// validate validates a configuration transaction committed in the given block, with the given block timestamp
+func (v *ConfigTxValidator) validate(txEnv *types.ConfigTxEnvelope, blockNum uint64, blockTimestamp int64) (*types.ValidationInfo, error) {
+	valInfo, err := v.sigValidator.validate(txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload, blockTimestamp)
 	if err != nil || valInfo.Flag != types.Flag_VALID {
 		return valInfo, err
 	}
@@ -52,7 +69,7 @@ func (v *ConfigTxValidator) Validate(txEnv *types.ConfigTxEnvelope) (*types.Vali
 		}, nil
 	}
 
-	vi := validateConfig(tx.NewConfig)
+	vi := validateConfig(tx.NewConfig, blockNum, v.consensusAlgorithms)
 	if vi.Flag != types.Flag_VALID {
 		return vi, nil
 	}
@@ -70,13 +87,13 @@ func (v *ConfigTxValidator) Validate(txEnv *types.ConfigTxEnvelope) (*types.Vali
 		return vi, nil
 	}
 
-	return v.validateConfigTransitionRules(clusterConfig, tx.NewConfig)
+	return v.validateConfigTransitionRules(clusterConfig, tx.NewConfig, blockNum, tx.StandbyPromotion != nil)
 }
 
 func (v *ConfigTxValidator) validateGenesis(txEnv *types.ConfigTxEnvelope) ([]*types.ValidationInfo, error) {
 	configTx := txEnv.Payload
 
-	vi := validateConfig(configTx.NewConfig)
+	vi := validateConfig(configTx.NewConfig, 1, v.consensusAlgorithms)
 	if vi.Flag != types.Flag_VALID {
 		return nil, errors.Errorf("genesis block cannot be invalid: reason for invalidation [%s]", vi.ReasonIfInvalid)
 	}
@@ -84,12 +101,26 @@ func (v *ConfigTxValidator) validateGenesis(txEnv *types.ConfigTxEnvelope) ([]*t
 	return []*types.ValidationInfo{{Flag: types.Flag_VALID}}, nil
 }
 
-func validateConfig(config *types.ClusterConfig) *types.ValidationInfo {
+func validateConfig(config *types.ClusterConfig, blockNum uint64, consensusAlgorithms map[string]bool) *types.ValidationInfo {
 	vi, caCertCollection := validateCAConfig(config.CertAuthConfig)
 	if vi.Flag != types.Flag_VALID {
 		return vi
 	}
 
+	if len(config.CertAuthConfig.Retiring) > 0 {
+		// the certificates of the nodes and admins may be issued by the retiring CAs during their overlap period
+		if vi, caCertCollection = validateRetiringCAs(config.CertAuthConfig, blockNum); vi.Flag != types.Flag_VALID {
+			return vi
+		}
+	}
+
+	if len(config.CertAuthConfig.Scopes) > 0 {
+		if vi = validateCAScopes(config.CertAuthConfig); vi.Flag != types.Flag_VALID {
+			return vi
+		}
+		caCertCollection.SetScopes(config.CertAuthConfig.Scopes)
+	}
+
 	if vi = validateNodeConfig(config.Nodes, caCertCollection); vi.Flag != types.Flag_VALID {
 		return vi
 	}
@@ -98,7 +129,7 @@ func validateConfig(config *types.ClusterConfig) *types.ValidationInfo {
 		return vi
 	}
 
-	if vi = validateConsensusConfig(config.ConsensusConfig); vi.Flag != types.Flag_VALID {
+	if vi = validateConsensusConfig(config.ConsensusConfig, consensusAlgorithms); vi.Flag != types.Flag_VALID {
 		return vi
 	}
 
@@ -106,9 +137,119 @@ func validateConfig(config *types.ClusterConfig) *types.ValidationInfo {
 		return vi
 	}
 
+	if vi = validateDBQuotas(config.DbQuotas); vi.Flag != types.Flag_VALID {
+		return vi
+	}
+
+	if vi = validateErasableDBs(config.ErasableDbs); vi.Flag != types.Flag_VALID {
+		return vi
+	}
+
+	if vi = validateAccessPolicy(config.AccessPolicy); vi.Flag != types.Flag_VALID {
+		return vi
+	}
+
 	return vi
 }
 
+var dbNameRegexp = regexp.MustCompile(worldstate.AllowedCharsInDBName)
+
+// validateDBQuotas checks that every quota limits a distinct data database, and that a soft limit does not exceed the
+// hard limit it warns about. A quota may refer to a database that does not exist yet.
+func validateDBQuotas(quotas []*types.DatabaseQuota) *types.ValidationInfo {
+	dbNames := make(map[string]bool)
+	for _, q := range quotas {
+		switch {
+		case q == nil:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Database quota is empty.",
+			}
+		case !dbNameRegexp.MatchString(q.DbName) || worldstate.IsSystemDB(q.DbName) || stateindex.IsIndexDB(q.DbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Database quota has an invalid database name: '%s'.", q.DbName),
+			}
+		case dbNames[q.DbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("There are two quotas of the same database: '%s'.", q.DbName),
+			}
+		case q.HardStorageBytes > 0 && q.SoftStorageBytes > q.HardStorageBytes:
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Database quota of '%s': the soft storage limit %d exceeds the hard storage limit %d.",
+					q.DbName, q.SoftStorageBytes, q.HardStorageBytes),
+			}
+		case q.HardTxsPerMinute > 0 && q.SoftTxsPerMinute > q.HardTxsPerMinute:
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Database quota of '%s': the soft transaction rate limit %d exceeds the hard transaction rate limit %d.",
+					q.DbName, q.SoftTxsPerMinute, q.HardTxsPerMinute),
+			}
+		}
+		dbNames[q.DbName] = true
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}
+}
+
+// validateErasableDBs checks that every erasable database is a distinct data database. An erasable database may not
+// exist yet.
+func validateErasableDBs(dbNames []string) *types.ValidationInfo {
+	erasable := make(map[string]bool)
+	for _, dbName := range dbNames {
+		switch {
+		case !dbNameRegexp.MatchString(dbName) || worldstate.IsSystemDB(dbName) || stateindex.IsIndexDB(dbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Erasable databases have an invalid database name: '%s'.", dbName),
+			}
+		case erasable[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Erasable databases have a duplicate database name: '%s'.", dbName),
+			}
+		}
+		erasable[dbName] = true
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}
+}
+
+// validateAccessPolicy checks that the access policy sets a known privilege for known groups of endpoints, each at
+// most once. A nil policy requires the default privileges.
+func validateAccessPolicy(policy *types.AccessPolicy) *types.ValidationInfo {
+	groups := make(map[types.EndpointPolicy_Group]bool)
+	for i, p := range policy.GetEndpoints() {
+		switch {
+		case p == nil:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Access policy of endpoints [%d] is empty.", i),
+			}
+		case types.EndpointPolicy_Group_name[int32(p.Group)] == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Access policy has an unknown group of endpoints: %d.", p.Group),
+			}
+		case types.EndpointPolicy_Privilege_name[int32(p.Privilege)] == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Access policy of the %s endpoints has an unknown privilege: %d.", p.Group, p.Privilege),
+			}
+		case groups[p.Group]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("Access policy has two policies of the %s endpoints.", p.Group),
+			}
+		}
+		groups[p.Group] = true
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}
+}
+
 func validateCAConfig(caConfig *types.CAConfig) (*types.ValidationInfo, *certificateauthority.CACertCollection) {
 	if caConfig == nil {
 		return &types.ValidationInfo{
@@ -144,6 +285,145 @@ func validateCAConfig(caConfig *types.CAConfig) (*types.ValidationInfo, *certifi
 	}, caCertCollection
 }
 
+// validateRetiringCAs checks that every set of retiring CAs has certificates and an end to its overlap period, and that
+// the retiring CAs form valid chains along with the current CAs. It returns the collection of the CAs that are
+// trusted in the given block.
+func validateRetiringCAs(caConfig *types.CAConfig, blockNum uint64) (*types.ValidationInfo, *certificateauthority.CACertCollection) {
+	for i, retiring := range caConfig.Retiring {
+		switch {
+		case retiring == nil || len(retiring.Roots)+len(retiring.Intermediates) == 0:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("CA config retiring CAs [%d] have no certificate", i),
+			}, nil
+		case retiring.TrustedUntilBlock == 0:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("CA config retiring CAs [%d] have no end of their overlap period", i),
+			}, nil
+		}
+	}
+
+	allCerts, err := certificateauthority.NewCACertCollection(certificateauthority.AllCAs(caConfig))
+	if err != nil {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("CA certificate collection with the retiring CAs cannot be created: %s", err.Error()),
+		}, nil
+	}
+	if err = allCerts.VerifyCollection(); err != nil {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("CA certificate collection with the retiring CAs is invalid: %s", err.Error()),
+		}, nil
+	}
+
+	trustedCerts, err := certificateauthority.NewCACertCollection(certificateauthority.TrustedCAs(caConfig, blockNum))
+	if err != nil {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("CA certificate collection with the retiring CAs cannot be created: %s", err.Error()),
+		}, nil
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, trustedCerts
+}
+
+// validateCAScopes checks that every organization has a distinct name, CAs that are among the CA certificates and
+// that no other organization has, and a scope that does not overlap the scope of any other organization, so that the
+// certificate of every ID can be issued by a single organization.
+func validateCAScopes(caConfig *types.CAConfig) *types.ValidationInfo {
+	roots, intermediates := certificateauthority.AllCAs(caConfig)
+	knownCAs := make(map[string]bool)
+	for _, ca := range append(roots, intermediates...) {
+		knownCAs[string(ca)] = true
+	}
+
+	orgs := make(map[string]bool)
+	caOrgs := make(map[string]string)
+	nodeOrgs := make(map[string]string)
+	var prefixes []string
+	prefixOrgs := make(map[string]string)
+
+	for i, scope := range caConfig.Scopes {
+		switch {
+		case scope == nil || scope.Organization == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("CA config scope [%d] has no organization", i),
+			}
+		case orgs[scope.Organization]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "CA config has two scopes of the same organization [" + scope.Organization + "]",
+			}
+		case len(scope.Cas) == 0:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the CA scope of the organization [" + scope.Organization + "] has no CA certificate",
+			}
+		case len(scope.UserIdPrefixes)+len(scope.NodeIds) == 0:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the CA scope of the organization [" + scope.Organization + "] includes neither users nor nodes",
+			}
+		}
+		orgs[scope.Organization] = true
+
+		for _, ca := range scope.Cas {
+			if !knownCAs[string(ca)] {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the CA scope of the organization [" + scope.Organization + "] has a CA certificate that is not among the CA certificates",
+				}
+			}
+			if org, ok := caOrgs[string(ca)]; ok && org != scope.Organization {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the organizations [" + org + "] and [" + scope.Organization + "] have the same CA certificate",
+				}
+			}
+			caOrgs[string(ca)] = scope.Organization
+		}
+
+		for _, id := range scope.NodeIds {
+			if org, ok := nodeOrgs[id]; ok {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the node [" + id + "] is in the scopes of both organizations [" + org + "] and [" + scope.Organization + "]",
+				}
+			}
+			nodeOrgs[id] = scope.Organization
+		}
+
+		for _, prefix := range scope.UserIdPrefixes {
+			if prefix == "" {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the CA scope of the organization [" + scope.Organization + "] has an empty user ID prefix",
+				}
+			}
+			for _, other := range prefixes {
+				if strings.HasPrefix(prefix, other) || strings.HasPrefix(other, prefix) {
+					return &types.ValidationInfo{
+						Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+						ReasonIfInvalid: "the user ID prefix [" + prefix + "] of the organization [" + scope.Organization +
+							"] overlaps the user ID prefix [" + other + "] of the organization [" + prefixOrgs[other] + "]",
+					}
+				}
+			}
+			prefixes = append(prefixes, prefix)
+			prefixOrgs[prefix] = scope.Organization
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
 func validateNodeConfig(nodes []*types.NodeConfig, caCertCollection *certificateauthority.CACertCollection) *types.ValidationInfo {
 	if len(nodes) == 0 {
 		return &types.ValidationInfo{
@@ -188,8 +468,14 @@ func validateNodeConfig(nodes []*types.NodeConfig, caCertCollection *certificate
 				ReasonIfInvalid: fmt.Sprintf("the node [%s] has an invalid port number [%d]", n.Id, n.Port),
 			}
 
+		case n.Zone != "" && n.Region == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the node [" + n.Id + "] has a zone [" + n.Zone + "] but no region",
+			}
+
 		default:
-			if err := caCertCollection.VerifyLeafCert(n.Certificate); err != nil {
+			if err := caCertCollection.VerifyNodeCert(n.Certificate, n.Id); err != nil {
 				return &types.ValidationInfo{
 					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 					ReasonIfInvalid: "the node [" + n.Id + "] has an invalid certificate: " + err.Error(),
@@ -246,7 +532,7 @@ func validateAdminConfig(admins []*types.Admin, caCertCollection *certificateaut
 				ReasonIfInvalid: "there is an admin in the admin config with an empty ID. A valid adminID must be an non-empty string",
 			}
 		default:
-			if err := caCertCollection.VerifyLeafCert(a.Certificate); err != nil {
+			if err := caCertCollection.VerifyUserCert(a.Certificate, a.Id); err != nil {
 				return &types.ValidationInfo{
 					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 					ReasonIfInvalid: "the admin [" + a.Id + "] has an invalid certificate: " + err.Error(),
@@ -268,8 +554,9 @@ func validateAdminConfig(admins []*types.Admin, caCertCollection *certificateaut
 	}
 }
 
-// validate the internal consistency of the ConsensusConfig
-func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.ValidationInfo {
+// validate the internal consistency of the ConsensusConfig. The RaftConfig is validated only if the algorithm is
+// "raft", as the other algorithms order the blocks with an external ordering service.
+func validateConsensusConfig(consensusConf *types.ConsensusConfig, consensusAlgorithms map[string]bool) *types.ValidationInfo {
 	switch {
 	case consensusConf == nil:
 		return &types.ValidationInfo{
@@ -277,7 +564,7 @@ func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.Valida
 			ReasonIfInvalid: "Consensus config is empty.",
 		}
 
-	case consensusConf.Algorithm != "raft":
+	case !consensusAlgorithms[consensusConf.Algorithm]:
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 			ReasonIfInvalid: fmt.Sprintf("Consensus config Algorithm '%s' is not supported.", consensusConf.Algorithm),
@@ -399,6 +686,12 @@ func validateConsensusConfig(consensusConf *types.ConsensusConfig) *types.Valida
 		hostPortSet[hostPort] = true
 	}
 
+	if consensusConf.Algorithm != "raft" {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}
+	}
+
 	switch {
 	case consensusConf.RaftConfig == nil:
 		return &types.ValidationInfo{
@@ -518,17 +811,33 @@ func validateHostPort(host string, port uint32) error {
 	return nil
 }
 
-// validate whether the transition from currentConfig to updatedConfig is valid and safe.
-func (v *ConfigTxValidator) validateConfigTransitionRules(currentConfig, updatedConfig *types.ClusterConfig) (*types.ValidationInfo, error) {
+// validate whether the transition from currentConfig to updatedConfig is valid and safe. The promotion of a standby
+// cluster may replace all the consensus members at once, as the current members no longer take part in consensus.
+func (v *ConfigTxValidator) validateConfigTransitionRules(currentConfig, updatedConfig *types.ClusterConfig, blockNum uint64, standbyPromotion bool) (*types.ValidationInfo, error) {
 	nodes, consensus, ca, admins := replication.ClassifyClusterReConfig(currentConfig, updatedConfig)
 
+	currentVersion := comm.ClusterProtocolVersion(currentConfig)
+	updatedVersion := comm.ClusterProtocolVersion(updatedConfig)
+	if updatedVersion < currentVersion {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("the protocol version cannot be decreased from [%d] to [%d]", currentVersion, updatedVersion),
+		}, nil
+	}
+	if updatedVersion != currentVersion {
+		v.logger.Infof("ClusterConfig ProtocolVersion changed: current: %d; updated: %d", currentVersion, updatedVersion)
+	}
+
 	if nodes {
 		v.logger.Debugf("ClusterConfig Nodes changed: current: %s; updated: %s", nodeConfigSliceToString(currentConfig.Nodes), nodeConfigSliceToString(updatedConfig.Nodes))
 		// TODO add rules for nodes re-config safety
 	}
 	if ca {
 		v.logger.Debugf("ClusterConfig CA changed: current: %v; updated: %v", currentConfig.CertAuthConfig, updatedConfig.CertAuthConfig)
-		// TODO add rules for CA re-config safety: https://github.com/hyperledger-labs/orion-server/issues/154
+		if vi := validateCARetirement(currentConfig.CertAuthConfig, updatedConfig.CertAuthConfig, blockNum); vi.Flag != types.Flag_VALID {
+			return vi, nil
+		}
+		// TODO add more rules for CA re-config safety: https://github.com/hyperledger-labs/orion-server/issues/154
 	}
 
 	if admins {
@@ -537,7 +846,12 @@ func (v *ConfigTxValidator) validateConfigTransitionRules(currentConfig, updated
 	}
 
 	if consensus {
-		err := replication.VerifyConsensusReConfig(currentConfig.GetConsensusConfig(), updatedConfig.GetConsensusConfig(), v.logger)
+		var err error
+		if standbyPromotion {
+			err = replication.VerifyConsensusPromotion(currentConfig.GetConsensusConfig(), updatedConfig.GetConsensusConfig())
+		} else {
+			err = replication.VerifyConsensusReConfig(currentConfig.GetConsensusConfig(), updatedConfig.GetConsensusConfig(), v.logger)
+		}
 		if err != nil {
 			v.logger.Errorf("ClusterConfig ConsensusConfig validation failed: error: %s", err)
 			v.logger.Debugf("ClusterConfig ConsensusConfig rejected change request: current: %v; updated: %v", currentConfig.ConsensusConfig, updatedConfig.ConsensusConfig)
@@ -554,6 +868,50 @@ func (v *ConfigTxValidator) validateConfigTransitionRules(currentConfig, updated
 	}, nil
 }
 
+// validateCARetirement checks the retiring CAs that are added by a configuration transaction committed in the given
+// block: the retiring CAs must be trusted by the current configuration, and their overlap period must not have ended.
+// The retiring CAs that are kept as they are remain valid, even once their overlap period has ended.
+func validateCARetirement(currentCAs, updatedCAs *types.CAConfig, blockNum uint64) *types.ValidationInfo {
+	trusted := make(map[string]bool)
+	roots, intermediates := certificateauthority.TrustedCAs(currentCAs, blockNum)
+	for _, cert := range append(roots, intermediates...) {
+		trusted[string(cert)] = true
+	}
+
+	for i, retiring := range updatedCAs.GetRetiring() {
+		kept := false
+		for _, current := range currentCAs.GetRetiring() {
+			if proto.Equal(current, retiring) {
+				kept = true
+				break
+			}
+		}
+		if kept {
+			continue
+		}
+
+		if retiring.TrustedUntilBlock < blockNum {
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("CA config retiring CAs [%d] are trusted until block [%d], which precedes the block [%d] of the transaction",
+					i, retiring.TrustedUntilBlock, blockNum),
+			}
+		}
+		for _, cert := range append(append([][]byte{}, retiring.Roots...), retiring.Intermediates...) {
+			if !trusted[string(cert)] {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: fmt.Sprintf("CA config retiring CAs [%d] hold a certificate that is not trusted by the current configuration", i),
+				}
+			}
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
 func nodeConfigToString(n *types.NodeConfig) string {
 	return fmt.Sprintf("Id: %s, Address: %s, Port: %d, Cert-hash: %x", n.Id, n.Address, n.Port, crc32.ChecksumIEEE(n.Certificate))
 }
@@ -4,6 +4,7 @@
 package txvalidation
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -598,6 +599,118 @@ func TestValidateConfigTx(t *testing.T) {
 	}
 }
 
+func TestValidateConfigTransitionRules_ProtocolVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		currentVersion uint32
+		updatedVersion uint32
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:           "valid: unset is equivalent to version 1",
+			currentVersion: 0,
+			updatedVersion: 1,
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name:           "valid: version unchanged",
+			currentVersion: 2,
+			updatedVersion: 2,
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name:           "valid: version increased",
+			currentVersion: 1,
+			updatedVersion: 2,
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name:           "invalid: version decreased",
+			currentVersion: 2,
+			updatedVersion: 1,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the protocol version cannot be decreased from [2] to [1]",
+			},
+		},
+		{
+			name:           "invalid: version decreased to unset",
+			currentVersion: 2,
+			updatedVersion: 0,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the protocol version cannot be decreased from [2] to [1]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+
+			result, err := env.validator.configTxValidator.validateConfigTransitionRules(
+				&types.ClusterConfig{ProtocolVersion: tt.currentVersion},
+				&types.ClusterConfig{ProtocolVersion: tt.updatedVersion},
+				2,
+				false,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestValidateConfigTransitionRules_StandbyPromotion(t *testing.T) {
+	t.Parallel()
+
+	members := func(prefix string, firstRaftID uint64) []*types.PeerConfig {
+		var peers []*types.PeerConfig
+		for i := uint64(0); i < 3; i++ {
+			peers = append(peers, &types.PeerConfig{
+				NodeId:   fmt.Sprintf("%s%d", prefix, i+1),
+				RaftId:   firstRaftID + i,
+				PeerHost: "127.0.0.1",
+				PeerPort: uint32(7090 + firstRaftID + i),
+			})
+		}
+		return peers
+	}
+	currentConfig := &types.ClusterConfig{
+		ConsensusConfig: &types.ConsensusConfig{
+			Algorithm:  "raft",
+			Members:    members("node", 1),
+			RaftConfig: &types.RaftConfig{MaxRaftId: 3},
+		},
+	}
+	updatedConfig := &types.ClusterConfig{
+		ConsensusConfig: &types.ConsensusConfig{
+			Algorithm:  "raft",
+			Members:    members("standby", 4),
+			RaftConfig: &types.RaftConfig{MaxRaftId: 3},
+		},
+	}
+
+	env := newValidatorTestEnv(t)
+	defer env.cleanup()
+
+	result, err := env.validator.configTxValidator.validateConfigTransitionRules(currentConfig, updatedConfig, 2, true)
+	require.NoError(t, err)
+	require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, result)
+
+	result, err = env.validator.configTxValidator.validateConfigTransitionRules(currentConfig, updatedConfig, 2, false)
+	require.NoError(t, err)
+	require.Equal(t, &types.ValidationInfo{
+		Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+		ReasonIfInvalid: "error in ConsensusConfig: cannot make more than one membership change at a time: 3 added, 3 removed",
+	}, result)
+}
+
 func TestValidateCAConfig(t *testing.T) {
 	t.Parallel()
 
@@ -682,6 +795,286 @@ func TestValidateCAConfig(t *testing.T) {
 	}
 }
 
+func TestValidateRetiringCAs(t *testing.T) {
+	t.Parallel()
+
+	oldCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node"})
+	oldNodeCert, _ := testutils.LoadTestClientCrypto(t, oldCryptoDir, "node")
+	oldCACert, _ := testutils.LoadTestClientCA(t, oldCryptoDir, testutils.RootCAFileName)
+	newCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node"})
+	newNodeCert, _ := testutils.LoadTestClientCrypto(t, newCryptoDir, "node")
+	newCACert, _ := testutils.LoadTestClientCA(t, newCryptoDir, testutils.RootCAFileName)
+
+	t.Run("trusted during the overlap period", func(t *testing.T) {
+		caConfig := &types.CAConfig{
+			Roots:    [][]byte{newCACert.Raw},
+			Retiring: []*types.RetiringCAs{{Roots: [][]byte{oldCACert.Raw}, TrustedUntilBlock: 10}},
+		}
+
+		for _, blockNum := range []uint64{5, 10} {
+			result, caCertCollection := validateRetiringCAs(caConfig, blockNum)
+			require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, result)
+			require.NoError(t, caCertCollection.VerifyLeafCert(newNodeCert.Raw))
+			require.NoError(t, caCertCollection.VerifyLeafCert(oldNodeCert.Raw))
+		}
+
+		result, caCertCollection := validateRetiringCAs(caConfig, 11)
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, result)
+		require.NoError(t, caCertCollection.VerifyLeafCert(newNodeCert.Raw))
+		require.Error(t, caCertCollection.VerifyLeafCert(oldNodeCert.Raw))
+	})
+
+	tests := []struct {
+		name           string
+		retiring       []*types.RetiringCAs
+		expectedReason string
+	}{
+		{
+			name:           "invalid: no certificate",
+			retiring:       []*types.RetiringCAs{{TrustedUntilBlock: 10}},
+			expectedReason: "CA config retiring CAs [0] have no certificate",
+		},
+		{
+			name:           "invalid: empty entry",
+			retiring:       []*types.RetiringCAs{{Roots: [][]byte{oldCACert.Raw}, TrustedUntilBlock: 10}, nil},
+			expectedReason: "CA config retiring CAs [1] have no certificate",
+		},
+		{
+			name:           "invalid: no end of the overlap period",
+			retiring:       []*types.RetiringCAs{{Roots: [][]byte{oldCACert.Raw}}},
+			expectedReason: "CA config retiring CAs [0] have no end of their overlap period",
+		},
+		{
+			name:           "invalid: not a CA certificate",
+			retiring:       []*types.RetiringCAs{{Roots: [][]byte{oldNodeCert.Raw}, TrustedUntilBlock: 10}},
+			expectedReason: "CA certificate collection with the retiring CAs cannot be created: certificate is missing the CA property, SN:",
+		},
+		{
+			name:           "invalid: intermediate without a root",
+			retiring:       []*types.RetiringCAs{{Intermediates: [][]byte{oldCACert.Raw}, TrustedUntilBlock: 10}},
+			expectedReason: "CA certificate collection with the retiring CAs is invalid: error verifying CA certificate against trusted certificate authority (CA), SN:",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, caCertCollection := validateRetiringCAs(&types.CAConfig{Roots: [][]byte{newCACert.Raw}, Retiring: tt.retiring}, 5)
+			require.Equal(t, types.Flag_INVALID_INCORRECT_ENTRIES, result.Flag)
+			require.True(t, strings.HasPrefix(result.ReasonIfInvalid, tt.expectedReason), result.ReasonIfInvalid)
+			require.Nil(t, caCertCollection)
+		})
+	}
+}
+
+func TestValidateCARetirement(t *testing.T) {
+	t.Parallel()
+
+	oldCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node"})
+	oldCACert, _ := testutils.LoadTestClientCA(t, oldCryptoDir, testutils.RootCAFileName)
+	newCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node"})
+	newCACert, _ := testutils.LoadTestClientCA(t, newCryptoDir, testutils.RootCAFileName)
+	otherCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node"})
+	otherCACert, _ := testutils.LoadTestClientCA(t, otherCryptoDir, testutils.RootCAFileName)
+
+	current := &types.CAConfig{Roots: [][]byte{oldCACert.Raw}}
+	rotated := &types.CAConfig{
+		Roots:    [][]byte{newCACert.Raw},
+		Retiring: []*types.RetiringCAs{{Roots: [][]byte{oldCACert.Raw}, TrustedUntilBlock: 10}},
+	}
+
+	t.Run("valid: rotation with an overlap period", func(t *testing.T) {
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, validateCARetirement(current, rotated, 5))
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, validateCARetirement(current, rotated, 10))
+	})
+
+	t.Run("valid: retiring CAs are kept after their overlap period", func(t *testing.T) {
+		updated := proto.Clone(rotated).(*types.CAConfig)
+		updated.Roots = append(updated.Roots, otherCACert.Raw)
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, validateCARetirement(rotated, updated, 20))
+	})
+
+	t.Run("valid: the overlap period of retiring CAs is extended", func(t *testing.T) {
+		updated := proto.Clone(rotated).(*types.CAConfig)
+		updated.Retiring[0].TrustedUntilBlock = 20
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, validateCARetirement(rotated, updated, 8))
+	})
+
+	t.Run("invalid: the overlap period has ended", func(t *testing.T) {
+		require.Equal(t, &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "CA config retiring CAs [0] are trusted until block [10], which precedes the block [11] of the transaction",
+		}, validateCARetirement(current, rotated, 11))
+	})
+
+	t.Run("invalid: the retiring CAs are not trusted", func(t *testing.T) {
+		updated := proto.Clone(rotated).(*types.CAConfig)
+		updated.Retiring[0].Roots = append(updated.Retiring[0].Roots, otherCACert.Raw)
+		require.Equal(t, &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "CA config retiring CAs [0] hold a certificate that is not trusted by the current configuration",
+		}, validateCARetirement(current, updated, 5))
+
+		// the overlap period of the retiring CAs cannot be extended once it has ended
+		updated = proto.Clone(rotated).(*types.CAConfig)
+		updated.Retiring[0].TrustedUntilBlock = 20
+		require.Equal(t, &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "CA config retiring CAs [0] hold a certificate that is not trusted by the current configuration",
+		}, validateCARetirement(rotated, updated, 11))
+	})
+}
+
+func TestValidateCAScopes(t *testing.T) {
+	t.Parallel()
+
+	org1CryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node1", "org1.admin"})
+	org1CACert, _ := testutils.LoadTestClientCA(t, org1CryptoDir, testutils.RootCAFileName)
+	node1Cert, _ := testutils.LoadTestClientCrypto(t, org1CryptoDir, "node1")
+	org1AdminCert, _ := testutils.LoadTestClientCrypto(t, org1CryptoDir, "org1.admin")
+	org2CryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node2"})
+	org2CACert, _ := testutils.LoadTestClientCA(t, org2CryptoDir, testutils.RootCAFileName)
+	otherCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node"})
+	otherCACert, _ := testutils.LoadTestClientCA(t, otherCryptoDir, testutils.RootCAFileName)
+
+	org1 := func() *types.CAScope {
+		return &types.CAScope{Organization: "org1", Cas: [][]byte{org1CACert.Raw}, UserIdPrefixes: []string{"org1."}, NodeIds: []string{"node1"}}
+	}
+	org2 := func() *types.CAScope {
+		return &types.CAScope{Organization: "org2", Cas: [][]byte{org2CACert.Raw}, UserIdPrefixes: []string{"org2."}, NodeIds: []string{"node2"}}
+	}
+
+	t.Run("valid: the certificates are issued in scope", func(t *testing.T) {
+		caConfig := &types.CAConfig{
+			Roots:  [][]byte{org1CACert.Raw, org2CACert.Raw},
+			Scopes: []*types.CAScope{org1(), org2()},
+		}
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, validateCAScopes(caConfig))
+
+		result, caCertCollection := validateCAConfig(caConfig)
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, result)
+		caCertCollection.SetScopes(caConfig.Scopes)
+
+		nodes := []*types.NodeConfig{{Id: "node1", Address: "127.0.0.1", Port: 6090, Certificate: node1Cert.Raw}}
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, validateNodeConfig(nodes, caCertCollection))
+		admins := []*types.Admin{{Id: "org1.admin", Certificate: org1AdminCert.Raw}}
+		require.Equal(t, &types.ValidationInfo{Flag: types.Flag_VALID}, validateAdminConfig(admins, caCertCollection))
+
+		nodes = []*types.NodeConfig{{Id: "node2", Address: "127.0.0.1", Port: 6090, Certificate: node1Cert.Raw}}
+		require.Equal(t, &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the node [node2] has an invalid certificate: the certificate is issued by the organization [org1], whose scope does not include the node [node2]",
+		}, validateNodeConfig(nodes, caCertCollection))
+		admins = []*types.Admin{{Id: "org2.admin", Certificate: org1AdminCert.Raw}}
+		require.Equal(t, &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the admin [org2.admin] has an invalid certificate: the certificate is issued by the organization [org1], whose scope does not include the user [org2.admin]",
+		}, validateAdminConfig(admins, caCertCollection))
+	})
+
+	tests := []struct {
+		name           string
+		scopes         func() []*types.CAScope
+		expectedReason string
+	}{
+		{
+			name:           "invalid: empty entry",
+			scopes:         func() []*types.CAScope { return []*types.CAScope{org1(), nil} },
+			expectedReason: "CA config scope [1] has no organization",
+		},
+		{
+			name: "invalid: same organization",
+			scopes: func() []*types.CAScope {
+				s := org2()
+				s.Organization = "org1"
+				return []*types.CAScope{org1(), s}
+			},
+			expectedReason: "CA config has two scopes of the same organization [org1]",
+		},
+		{
+			name: "invalid: no CA",
+			scopes: func() []*types.CAScope {
+				s := org1()
+				s.Cas = nil
+				return []*types.CAScope{s}
+			},
+			expectedReason: "the CA scope of the organization [org1] has no CA certificate",
+		},
+		{
+			name: "invalid: no ID",
+			scopes: func() []*types.CAScope {
+				s := org1()
+				s.UserIdPrefixes, s.NodeIds = nil, nil
+				return []*types.CAScope{s}
+			},
+			expectedReason: "the CA scope of the organization [org1] includes neither users nor nodes",
+		},
+		{
+			name: "invalid: unknown CA",
+			scopes: func() []*types.CAScope {
+				s := org1()
+				s.Cas = append(s.Cas, otherCACert.Raw)
+				return []*types.CAScope{s}
+			},
+			expectedReason: "the CA scope of the organization [org1] has a CA certificate that is not among the CA certificates",
+		},
+		{
+			name: "invalid: shared CA",
+			scopes: func() []*types.CAScope {
+				s := org2()
+				s.Cas = append(s.Cas, org1CACert.Raw)
+				return []*types.CAScope{org1(), s}
+			},
+			expectedReason: "the organizations [org1] and [org2] have the same CA certificate",
+		},
+		{
+			name: "invalid: shared node",
+			scopes: func() []*types.CAScope {
+				s := org2()
+				s.NodeIds = append(s.NodeIds, "node1")
+				return []*types.CAScope{org1(), s}
+			},
+			expectedReason: "the node [node1] is in the scopes of both organizations [org1] and [org2]",
+		},
+		{
+			name: "invalid: empty prefix",
+			scopes: func() []*types.CAScope {
+				s := org1()
+				s.UserIdPrefixes = append(s.UserIdPrefixes, "")
+				return []*types.CAScope{s}
+			},
+			expectedReason: "the CA scope of the organization [org1] has an empty user ID prefix",
+		},
+		{
+			name: "invalid: overlapping prefixes",
+			scopes: func() []*types.CAScope {
+				s := org2()
+				s.UserIdPrefixes = append(s.UserIdPrefixes, "org1.admins.")
+				return []*types.CAScope{org1(), s}
+			},
+			expectedReason: "the user ID prefix [org1.admins.] of the organization [org2] overlaps the user ID prefix [org1.] of the organization [org1]",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			caConfig := &types.CAConfig{
+				Roots:  [][]byte{org1CACert.Raw, org2CACert.Raw},
+				Scopes: tt.scopes(),
+			}
+			require.Equal(t, &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: tt.expectedReason,
+			}, validateCAScopes(caConfig))
+		})
+	}
+}
+
 func TestValidateNodeConfig(t *testing.T) {
 	t.Parallel()
 
@@ -809,6 +1202,22 @@ func TestValidateNodeConfig(t *testing.T) {
 				ReasonIfInvalid: "there are two nodes with the same Host:Port [127.0.0.1:6090] in the node config. Endpoints must be unique",
 			},
 		},
+		{
+			name: "invalid: zone without a region",
+			nodes: []*types.NodeConfig{
+				{
+					Id:          "node1",
+					Address:     "127.0.0.1",
+					Port:        6090,
+					Certificate: nodeCert.Raw,
+					Zone:        "us-east-1a",
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the node [node1] has a zone [us-east-1a] but no region",
+			},
+		},
 		{
 			name: "valid",
 			nodes: []*types.NodeConfig{
@@ -823,6 +1232,29 @@ func TestValidateNodeConfig(t *testing.T) {
 				Flag: types.Flag_VALID,
 			},
 		},
+		{
+			name: "valid: with locality labels",
+			nodes: []*types.NodeConfig{
+				{
+					Id:          "node1",
+					Address:     "127.0.0.1",
+					Port:        6090,
+					Certificate: nodeCert.Raw,
+					Region:      "us-east",
+					Zone:        "us-east-1a",
+				},
+				{
+					Id:          "node2",
+					Address:     "127.0.0.1",
+					Port:        6091,
+					Certificate: nodeCert.Raw,
+					Region:      "eu-west",
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -977,6 +1409,26 @@ func TestValidateConsensusConfig(t *testing.T) {
 				ReasonIfInvalid: "Consensus config Algorithm 'solo' is not supported.",
 			},
 		},
+		{
+			name: "invalid: external algorithm with no members",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "kafka",
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Consensus config has no member peers. At least one member peer is required.",
+			},
+		},
+		{
+			name: "valid: external algorithm without raft config",
+			consensusConfig: &types.ConsensusConfig{
+				Algorithm: "kafka",
+				Members:   []*types.PeerConfig{peer1},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
 		{
 			name: "invalid: no members",
 			consensusConfig: &types.ConsensusConfig{
@@ -1386,7 +1838,7 @@ func TestValidateConsensusConfig(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := validateConsensusConfig(tt.consensusConfig)
+			result := validateConsensusConfig(tt.consensusConfig, map[string]bool{"raft": true, "kafka": true})
 			require.Equal(t, tt.expectedResult, result)
 		})
 	}
@@ -1483,6 +1935,210 @@ func TestValidateMembersNodesMatch(t *testing.T) {
 	}
 }
 
+func TestValidateDBQuotas(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		quotas         []*types.DatabaseQuota
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid",
+			quotas: []*types.DatabaseQuota{
+				{DbName: "db1", SoftStorageBytes: 10, HardStorageBytes: 20, SoftTxsPerMinute: 5, HardTxsPerMinute: 5},
+				{DbName: "db2", SoftStorageBytes: 10},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:   "invalid: empty quota",
+			quotas: []*types.DatabaseQuota{nil},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Database quota is empty.",
+			},
+		},
+		{
+			name:   "invalid: system database",
+			quotas: []*types.DatabaseQuota{{DbName: worldstate.UsersDBName, HardStorageBytes: 20}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Database quota has an invalid database name: '" + worldstate.UsersDBName + "'.",
+			},
+		},
+		{
+			name:   "invalid: database name",
+			quotas: []*types.DatabaseQuota{{DbName: "db 1", HardStorageBytes: 20}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Database quota has an invalid database name: 'db 1'.",
+			},
+		},
+		{
+			name:   "invalid: duplicate database",
+			quotas: []*types.DatabaseQuota{{DbName: "db1"}, {DbName: "db1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "There are two quotas of the same database: 'db1'.",
+			},
+		},
+		{
+			name:   "invalid: soft storage limit exceeds hard limit",
+			quotas: []*types.DatabaseQuota{{DbName: "db1", SoftStorageBytes: 30, HardStorageBytes: 20}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Database quota of 'db1': the soft storage limit 30 exceeds the hard storage limit 20.",
+			},
+		},
+		{
+			name:   "invalid: soft transaction rate limit exceeds hard limit",
+			quotas: []*types.DatabaseQuota{{DbName: "db1", SoftTxsPerMinute: 6, HardTxsPerMinute: 5}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Database quota of 'db1': the soft transaction rate limit 6 exceeds the hard transaction rate limit 5.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validateDBQuotas(tt.quotas)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestValidateErasableDBs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		dbNames        []string
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:    "valid",
+			dbNames: []string{"db1", "db2"},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:    "invalid: system database",
+			dbNames: []string{worldstate.UsersDBName},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Erasable databases have an invalid database name: '" + worldstate.UsersDBName + "'.",
+			},
+		},
+		{
+			name:    "invalid: database name",
+			dbNames: []string{"db 1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Erasable databases have an invalid database name: 'db 1'.",
+			},
+		},
+		{
+			name:    "invalid: duplicate database",
+			dbNames: []string{"db1", "db2", "db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Erasable databases have a duplicate database name: 'db1'.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validateErasableDBs(tt.dbNames)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestValidateAccessPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		policy         *types.AccessPolicy
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:           "valid: no policy",
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name: "valid: policies of distinct groups",
+			policy: &types.AccessPolicy{
+				Endpoints: []*types.EndpointPolicy{
+					{Group: types.EndpointPolicy_PROVENANCE, Privilege: types.EndpointPolicy_PROVENANCE_READ},
+					{Group: types.EndpointPolicy_LEDGER, Privilege: types.EndpointPolicy_AUTHENTICATED},
+					{Group: types.EndpointPolicy_OPERATIONS, Privilege: types.EndpointPolicy_ADMIN},
+				},
+			},
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name:   "invalid: empty entry",
+			policy: &types.AccessPolicy{Endpoints: []*types.EndpointPolicy{{}, nil}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Access policy of endpoints [1] is empty.",
+			},
+		},
+		{
+			name:   "invalid: unknown group",
+			policy: &types.AccessPolicy{Endpoints: []*types.EndpointPolicy{{Group: 10}}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Access policy has an unknown group of endpoints: 10.",
+			},
+		},
+		{
+			name:   "invalid: unknown privilege",
+			policy: &types.AccessPolicy{Endpoints: []*types.EndpointPolicy{{Group: types.EndpointPolicy_LEDGER, Privilege: 10}}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Access policy of the LEDGER endpoints has an unknown privilege: 10.",
+			},
+		},
+		{
+			name: "invalid: duplicate group",
+			policy: &types.AccessPolicy{
+				Endpoints: []*types.EndpointPolicy{
+					{Group: types.EndpointPolicy_PROVENANCE, Privilege: types.EndpointPolicy_PROVENANCE_READ},
+					{Group: types.EndpointPolicy_PROVENANCE, Privilege: types.EndpointPolicy_ADMIN},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "Access policy has two policies of the PROVENANCE endpoints.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validateAccessPolicy(tt.policy)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
 func TestMVCCOnConfigTx(t *testing.T) {
 	t.Parallel()
 
@@ -4,17 +4,31 @@
 package txvalidation
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/constraints"
+	"github.com/hyperledger-labs/orion-server/internal/dbfreeze"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/legalhold"
+	"github.com/hyperledger-labs/orion-server/internal/redaction"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
 )
 
+const (
+	// MaxDataTxAnnotations is the maximal number of annotations a data transaction may carry
+	MaxDataTxAnnotations = 32
+	// MaxDataTxAnnotationsSize is the maximal total size, in bytes, of the keys and values of the
+	// annotations of a data transaction
+	MaxDataTxAnnotationsSize = 4096
+)
+
 type dataTxValidator struct {
 	db              worldstate.DB
 	identityQuerier *identity.Querier
@@ -23,6 +37,13 @@ type dataTxValidator struct {
 }
 
 func (v *dataTxValidator) validate(txEnv *types.DataTxEnvelope, userIDsWithValidSign []string, pendingOps *pendingOperations) (*types.ValidationInfo, error) {
+	if valRes := ValidateDataTxAnnotations(txEnv.Payload.Annotations); valRes.Flag != types.Flag_VALID {
+		return valRes, nil
+	}
+	if IsCanaryTx(txEnv.Payload) {
+		return v.validateCanaryOps(txEnv.Payload, userIDsWithValidSign), nil
+	}
+
 	dbs := make(map[string]bool)
 	for _, ops := range txEnv.Payload.DbOperations {
 		if !dbs[ops.DbName] {
@@ -33,6 +54,7 @@ func (v *dataTxValidator) validate(txEnv *types.DataTxEnvelope, userIDsWithValid
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 			ReasonIfInvalid: "the database [" + ops.DbName + "] occurs more than once in the operations. The database present in the operations should be unique",
+			FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Check: types.DBOperationCheck_DB_CHECK},
 		}, nil
 	}
 
@@ -42,6 +64,7 @@ func (v *dataTxValidator) validate(txEnv *types.DataTxEnvelope, userIDsWithValid
 			return nil, err
 		}
 		if valRes.Flag != types.Flag_VALID {
+			valRes.FailedOperation = &types.DBOperationFailure{DbName: ops.DbName, Check: types.DBOperationCheck_DB_CHECK}
 			return valRes, nil
 		}
 
@@ -67,25 +90,87 @@ func (v *dataTxValidator) validate(txEnv *types.DataTxEnvelope, userIDsWithValid
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [" + strings.Join(userIDsWithValidSign, ", ") + "] has read-write permission on the database [" + ops.DbName + "]",
+				FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Check: types.DBOperationCheck_DB_PERMISSION_CHECK},
 			}, nil
 		}
 
+		valRes, err = v.validateDBNotFrozen(ops)
+		if err != nil {
+			return nil, err
+		}
+		if valRes.Flag != types.Flag_VALID {
+			return valRes, nil
+		}
+
 		valRes, err = v.validateOps(usersWithDBAccess, ops, pendingOps)
-		if err != nil || valRes.Flag != types.Flag_VALID {
-			return valRes, err
+		if err != nil {
+			return nil, err
+		}
+		if valRes.Flag != types.Flag_VALID {
+			// the checks of the operations detail the key and the check at fault, but not the database
+			if valRes.FailedOperation == nil {
+				valRes.FailedOperation = &types.DBOperationFailure{}
+			}
+			valRes.FailedOperation.DbName = ops.DbName
+			return valRes, nil
 		}
 	}
 
-	return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+	valRes, err := v.validateSchemas(txEnv.Payload)
+	if err != nil || valRes.Flag != types.Flag_VALID {
+		return valRes, err
+	}
+
+	return v.validateReferences(txEnv.Payload, pendingOps)
 }
 
-func (v *dataTxValidator) validateSignatures(txEnv *types.DataTxEnvelope) ([]string, *types.ValidationInfo, error) {
+// ValidateDataTxAnnotations checks that the annotations of a data transaction do not exceed the
+// MaxDataTxAnnotations and MaxDataTxAnnotationsSize limits, and that no annotation has an empty key
+func ValidateDataTxAnnotations(annotations map[string]string) *types.ValidationInfo {
+	if len(annotations) > MaxDataTxAnnotations {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("the number of annotations [%d] exceeds the limit of %d", len(annotations), MaxDataTxAnnotations),
+		}
+	}
+
+	size := 0
+	for k, v := range annotations {
+		if k == "" {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "an annotation has an empty key",
+			}
+		}
+		size += len(k) + len(v)
+	}
+
+	if size > MaxDataTxAnnotationsSize {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("the total size of the annotations [%d bytes] exceeds the limit of %d bytes", size, MaxDataTxAnnotationsSize),
+		}
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}
+}
+
+func (v *dataTxValidator) validateSignatures(txEnv *types.DataTxEnvelope, blockTimestamp int64) ([]string, *types.ValidationInfo, error) {
+	if IsCanaryTx(txEnv.Payload) {
+		return v.validateCanarySignature(txEnv)
+	}
+
 	var userIDsWithValidSign []string
 	for userID, signature := range txEnv.Signatures {
-		valRes, err := v.sigValidator.validate(userID, signature, txEnv.Payload)
+		valRes, err := v.sigValidator.validate(userID, signature, txEnv.Payload, blockTimestamp)
 		if err != nil {
 			return nil, nil, err
 		}
+		if valRes.Flag == types.Flag_INVALID_USER_DISABLED {
+			// unlike an invalid signature, which is ignored unless the user must sign, a signature of a
+			// disabled user invalidates the transaction
+			return nil, valRes, nil
+		}
 		if valRes.Flag != types.Flag_VALID {
 			for _, mustSignUserID := range txEnv.Payload.MustSignUserIds {
 				if userID == mustSignUserID {
@@ -132,6 +217,32 @@ func (v *dataTxValidator) validateDBName(dbName string) (*types.ValidationInfo,
 	}, nil
 }
 
+// validateDBNotFrozen checks that the operations do not write to a frozen database, i.e., that they neither write nor
+// delete a key, nor change the access control of a key, of a frozen database. Reading from a frozen database is allowed.
+func (v *dataTxValidator) validateDBNotFrozen(ops *types.DBOperation) (*types.ValidationInfo, error) {
+	if len(ops.DataWrites) == 0 && len(ops.DataDeletes) == 0 && len(ops.AclWrites) == 0 {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}, nil
+	}
+
+	freeze, err := dbfreeze.Get(v.db, ops.DbName)
+	if err != nil {
+		return nil, err
+	}
+	if freeze != nil {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_DATABASE_FROZEN,
+			ReasonIfInvalid: "the database [" + ops.DbName + "] is frozen for the reason [" + freeze.Reason + "] and hence, it cannot be written to",
+			FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Check: types.DBOperationCheck_FREEZE_CHECK},
+		}, nil
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
 func (v *dataTxValidator) validateOps(
 	userIDs []string,
 	txOps *types.DBOperation,
@@ -155,7 +266,15 @@ func (v *dataTxValidator) validateOps(
 		return r, nil
 	}
 
-	r = validateUniquenessInDataWritesAndDeletes(txOps.DataWrites, txOps.DataDeletes)
+	r, err = v.validateFieldsInAclWrites(txOps.DbName, txOps.AclWrites, pendingOps)
+	if err != nil {
+		return nil, err
+	}
+	if r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	r = validateUniquenessInDataWritesAndDeletes(txOps.DataWrites, txOps.DataDeletes, txOps.AclWrites)
 	if r.Flag != types.Flag_VALID {
 		return r, nil
 	}
@@ -184,6 +303,22 @@ func (v *dataTxValidator) validateOps(
 		return r, nil
 	}
 
+	r, err = v.validateLegalHoldsOnDataDeletes(userIDs, dbName, txOps.DataDeletes)
+	if err != nil {
+		return nil, err
+	}
+	if r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	r, err = v.validateACLOnAclWrites(userIDs, dbName, txOps.AclWrites)
+	if err != nil {
+		return nil, err
+	}
+	if r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
 	return v.mvccValidation(dbName, txOps, pendingOps)
 }
 
@@ -195,44 +330,182 @@ func (v *dataTxValidator) validateFieldsInDataWrites(DataWrites []*types.DataWri
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "there is an empty entry in the write list",
+				FailedOperation: &types.DBOperationFailure{Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}, nil
 		}
 
-		if w.Acl == nil {
-			continue
+		if w.EncryptedValue != nil || w.Erased || w.ValueHash != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [" + w.Key + "] is written with a sealed or an erased value, which only the block store sets",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+			}, nil
 		}
 
-		userToCheck := make(map[string]struct{})
+		if r := validateBlobManifest(w); r.Flag != types.Flag_VALID {
+			return r, nil
+		}
 
-		for user := range w.Acl.ReadUsers {
-			if existingUser[user] {
-				continue
-			}
-			userToCheck[user] = struct{}{}
+		if r := validateSignPolicyInACL(w.Key, w.Acl); r.Flag != types.Flag_VALID {
+			return r, nil
 		}
 
-		for user := range w.Acl.ReadWriteUsers {
-			if existingUser[user] {
-				continue
-			}
-			userToCheck[user] = struct{}{}
+		if r := validateRedactionsInACL(w.Key, w.Acl); r.Flag != types.Flag_VALID {
+			return r, nil
 		}
 
-		for user := range userToCheck {
-			exist, err := v.identityQuerier.DoesUserExist(user)
-			if err != nil {
-				return nil, errors.WithMessagef(err, "error while validating access control definition")
+		r, err := v.validateUsersInACL(w.Key, w.Acl, existingUser)
+		if err != nil {
+			return nil, err
+		}
+		if r.Flag != types.Flag_VALID {
+			return r, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateBlobManifest checks that a write of a blob carries a well-formed manifest in place of its value.
+func validateBlobManifest(w *types.DataWrite) *types.ValidationInfo {
+	if w.BlobManifest == nil {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}
+	}
+
+	reason := ""
+	if len(w.Value) != 0 || w.EncryptedValue != nil {
+		reason = "the key [" + w.Key + "] is written by a blob manifest, hence its value must be empty"
+	} else if err := blobstore.ValidateManifest(w.BlobManifest); err != nil {
+		reason = "the blob manifest of the key [" + w.Key + "] is invalid: " + err.Error()
+	}
+	if reason != "" {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: reason,
+			FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+// validateSignPolicyInACL checks that a threshold is set only for the THRESHOLD write policy, and that it can be met
+// by the users who have a write permission on the key.
+func validateSignPolicyInACL(key string, acl *types.AccessControl) *types.ValidationInfo {
+	if acl == nil {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}
+	}
+
+	switch acl.SignPolicyForWrite {
+	case types.AccessControl_ANY, types.AccessControl_ALL:
+		if acl.SignThresholdForWrite != 0 {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the sign threshold for write of the key [" + key + "] can be set only with the " + types.AccessControl_THRESHOLD.String() + " sign policy",
+				FailedOperation: &types.DBOperationFailure{Key: key, Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}
+		}
+	case types.AccessControl_THRESHOLD:
+		if acl.SignThresholdForWrite == 0 || int(acl.SignThresholdForWrite) > len(acl.ReadWriteUsers) {
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("the sign threshold for write of the key [%s] must be between 1 and the number of read-write users [%d], but it is [%d]",
+					key, len(acl.ReadWriteUsers), acl.SignThresholdForWrite),
+				FailedOperation: &types.DBOperationFailure{Key: key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+			}
+		}
+	default:
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the sign policy for write [" + acl.SignPolicyForWrite.String() + "] of the key [" + key + "] is unknown",
+			FailedOperation: &types.DBOperationFailure{Key: key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+		}
+	}
 
-			if !exist {
-				return &types.ValidationInfo{
-					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-					ReasonIfInvalid: "the user [" + user + "] defined in the access control for the key [" + w.Key + "] does not exist",
-				}, nil
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+// validateRedactionsInACL checks the redaction policies of the users who have a redacted read permission on the key.
+// The users are checked in order, so that the reason of an invalid transaction is the same on all nodes.
+func validateRedactionsInACL(key string, acl *types.AccessControl) *types.ValidationInfo {
+	users := make([]string, 0, len(acl.GetRedactedReadUsers()))
+	for user := range acl.GetRedactedReadUsers() {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+
+	for _, user := range users {
+		if err := redaction.ValidatePolicy(acl.RedactedReadUsers[user]); err != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the redaction policy of the user [" + user + "] for the key [" + key + "] is invalid: " + err.Error(),
+				FailedOperation: &types.DBOperationFailure{Key: key, Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+// validateUsersInACL checks that all users in the access control of the given key exist. The users already known to
+// exist are skipped, and the users found to exist are added to existingUser.
+func (v *dataTxValidator) validateUsersInACL(key string, acl *types.AccessControl, existingUser map[string]bool) (*types.ValidationInfo, error) {
+	if acl == nil {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}, nil
+	}
+
+	userToCheck := make(map[string]struct{})
+
+	for user := range acl.ReadUsers {
+		if existingUser[user] {
+			continue
+		}
+		userToCheck[user] = struct{}{}
+	}
 
-			existingUser[user] = true
+	for user := range acl.ReadWriteUsers {
+		if existingUser[user] {
+			continue
 		}
+		userToCheck[user] = struct{}{}
+	}
+
+	for user := range acl.RedactedReadUsers {
+		if existingUser[user] {
+			continue
+		}
+		userToCheck[user] = struct{}{}
+	}
+
+	for user := range userToCheck {
+		exist, err := v.identityQuerier.DoesUserExist(user)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while validating access control definition")
+		}
+
+		if !exist {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [" + user + "] defined in the access control for the key [" + key + "] does not exist",
+				FailedOperation: &types.DBOperationFailure{Key: key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+			}, nil
+		}
+
+		existingUser[user] = true
 	}
 
 	return &types.ValidationInfo{
@@ -250,6 +523,7 @@ func (v *dataTxValidator) validateFieldsInDataDeletes(
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "there is an empty entry in the delete list",
+				FailedOperation: &types.DBOperationFailure{Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}, nil
 		}
 
@@ -261,6 +535,7 @@ func (v *dataTxValidator) validateFieldsInDataDeletes(
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "the key [" + d.Key + "] is already deleted by some previous transaction in the block",
+				FailedOperation: &types.DBOperationFailure{Key: d.Key, Check: types.DBOperationCheck_MVCC_CHECK},
 			}, nil
 		}
 
@@ -272,6 +547,7 @@ func (v *dataTxValidator) validateFieldsInDataDeletes(
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [" + d.Key + "] does not exist in the database and hence, it cannot be deleted",
+				FailedOperation: &types.DBOperationFailure{Key: d.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}, nil
 		}
 	}
@@ -281,15 +557,81 @@ func (v *dataTxValidator) validateFieldsInDataDeletes(
 	}, nil
 }
 
-func validateUniquenessInDataWritesAndDeletes(dataWrites []*types.DataWrite, dataDeletes []*types.DataDelete) *types.ValidationInfo {
+func (v *dataTxValidator) validateFieldsInAclWrites(
+	dbName string,
+	aclWrites []*types.AclWrite,
+	pendingOps *pendingOperations,
+) (*types.ValidationInfo, error) {
+	existingUser := make(map[string]bool)
+
+	for _, w := range aclWrites {
+		if w == nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the acl write list",
+				FailedOperation: &types.DBOperationFailure{Check: types.DBOperationCheck_ENTRIES_CHECK},
+			}, nil
+		}
+
+		// an acl write keeps the committed value of the key, and hence, the key must exist. A key deleted by a
+		// previous transaction in the block is reported as an mvcc conflict, as done for deletes.
+		if pendingOps.existDelete(dbName, w.Key) {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
+				ReasonIfInvalid: "the key [" + w.Key + "] is already deleted by some previous transaction in the block",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_MVCC_CHECK},
+			}, nil
+		}
+
+		val, metadata, err := v.db.Get(dbName, w.Key)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error while validating acl write entries")
+		}
+		if val == nil && metadata == nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [" + w.Key + "] does not exist in the database and hence, its access control cannot be updated",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+			}, nil
+		}
+
+		if r := validateSignPolicyInACL(w.Key, w.NewAcl); r.Flag != types.Flag_VALID {
+			return r, nil
+		}
+
+		if r := validateRedactionsInACL(w.Key, w.NewAcl); r.Flag != types.Flag_VALID {
+			return r, nil
+		}
+
+		r, err := v.validateUsersInACL(w.Key, w.NewAcl, existingUser)
+		if err != nil {
+			return nil, err
+		}
+		if r.Flag != types.Flag_VALID {
+			return r, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+func validateUniquenessInDataWritesAndDeletes(
+	dataWrites []*types.DataWrite,
+	dataDeletes []*types.DataDelete,
+	aclWrites []*types.AclWrite,
+) *types.ValidationInfo {
 	writeKeys := make(map[string]bool)
 	deleteKeys := make(map[string]bool)
+	aclWriteKeys := make(map[string]bool)
 
 	for _, w := range dataWrites {
 		if writeKeys[w.Key] {
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [" + w.Key + "] is duplicated in the write list. The keys in the write list must be unique",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}
 		}
 		writeKeys[w.Key] = true
@@ -301,18 +643,40 @@ func validateUniquenessInDataWritesAndDeletes(dataWrites []*types.DataWrite, dat
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [" + d.Key + "] is duplicated in the delete list. The keys in the delete list must be unique",
+				FailedOperation: &types.DBOperationFailure{Key: d.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}
 
 		case writeKeys[d.Key]:
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [" + d.Key + "] is being updated as well as deleted. Only one operation per key is allowed within a transaction",
+				FailedOperation: &types.DBOperationFailure{Key: d.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
 			}
 		}
 
 		deleteKeys[d.Key] = true
 	}
 
+	for _, w := range aclWrites {
+		switch {
+		case aclWriteKeys[w.Key]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [" + w.Key + "] is duplicated in the acl write list. The keys in the acl write list must be unique",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+			}
+
+		case writeKeys[w.Key] || deleteKeys[w.Key]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the access control of the key [" + w.Key + "] is being updated while the key is also written or deleted. Only one operation per key is allowed within a transaction",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_ENTRIES_CHECK},
+			}
+		}
+
+		aclWriteKeys[w.Key] = true
+	}
+
 	return &types.ValidationInfo{
 		Flag: types.Flag_VALID,
 	}
@@ -344,6 +708,7 @@ func (v *dataTxValidator) validateACLOnDataReads(userIDs []string, dbName string
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_NO_PERMISSION,
 			ReasonIfInvalid: "none of the user in [" + strings.Join(userIDs, ",") + "] has a read permission on key [" + r.Key + "] present in the database [" + dbName + "]",
+			FailedOperation: &types.DBOperationFailure{Key: r.Key, Check: types.DBOperationCheck_READ_ACL_CHECK},
 		}, nil
 	}
 
@@ -363,6 +728,7 @@ func (v *dataTxValidator) validateACLOnDataWrites(userIDs []string, dbName strin
 		}
 
 		if valRes.Flag != types.Flag_VALID {
+			valRes.FailedOperation = &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_WRITE_ACL_CHECK}
 			return valRes, nil
 		}
 	}
@@ -383,6 +749,159 @@ func (v *dataTxValidator) validateACLOnDataDeletes(userIDs []string, dbName stri
 		}
 
 		if valRes.Flag != types.Flag_VALID {
+			valRes.FailedOperation = &types.DBOperationFailure{Key: d.Key, Check: types.DBOperationCheck_DELETE_ACL_CHECK}
+			return valRes, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateACLOnAclWrites checks that the users have write permission on the keys, according to their committed
+// access control, as changing the access control of a key is a write
+// validateLegalHoldsOnDataDeletes checks that no deleted key is under a legal hold, either placed on the key itself
+// or on its database
+func (v *dataTxValidator) validateLegalHoldsOnDataDeletes(userIDs []string, dbName string, deletes []*types.DataDelete) (*types.ValidationInfo, error) {
+	for _, d := range deletes {
+		hold, err := legalhold.Holding(v.db, dbName, d.Key)
+		if err != nil {
+			return nil, err
+		}
+		if hold == nil {
+			continue
+		}
+
+		v.logger.Infof("legal hold audit: users [%s] attempted to delete key [%s] of database [%s] while %s is under a legal hold",
+			strings.Join(userIDs, ", "), v.logger.MaskKey(d.Key), dbName, legalhold.LogTarget(v.logger, hold))
+		reason := "the key [" + d.Key + "] is under a legal hold and hence, it cannot be deleted"
+		if hold.Key == "" {
+			reason = "the database [" + dbName + "] is under a legal hold and hence, the key [" + d.Key + "] cannot be deleted"
+		}
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_LEGAL_HOLD,
+			ReasonIfInvalid: reason,
+			FailedOperation: &types.DBOperationFailure{Key: d.Key, Check: types.DBOperationCheck_LEGAL_HOLD_CHECK},
+		}, nil
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateSchemas checks that the documents written to a database that has a schema conform to it
+func (v *dataTxValidator) validateSchemas(tx *types.DataTx) (*types.ValidationInfo, error) {
+	for _, ops := range tx.DbOperations {
+		if len(ops.DataWrites) == 0 {
+			continue
+		}
+
+		dbConstraints, err := constraints.Get(v.db, ops.DbName)
+		if err != nil {
+			return nil, err
+		}
+		if dbConstraints.GetSchema() == nil {
+			continue
+		}
+
+		for _, w := range ops.DataWrites {
+			if err := constraints.ValidateDocument(dbConstraints.Schema, w.Value); err != nil {
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+					ReasonIfInvalid: "the value of the key [" + w.Key + "] does not conform to the schema of database [" + ops.DbName + "]: " + err.Error(),
+					FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Key: w.Key, Check: types.DBOperationCheck_SCHEMA_CHECK},
+				}, nil
+			}
+		}
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+}
+
+// validateReferences checks that the documents written to a database that has reference constraints refer to existing
+// keys. A referenced key exists if it is written by the transaction, or by a previous transaction in the block, or if
+// it is committed, unless it is deleted by the transaction or by a previous transaction in the block. Deleting a
+// referenced key is not restricted.
+func (v *dataTxValidator) validateReferences(tx *types.DataTx, pendingOps *pendingOperations) (*types.ValidationInfo, error) {
+	txOps := newPendingOperations()
+	for _, ops := range tx.DbOperations {
+		for _, w := range ops.DataWrites {
+			txOps.addWrite(ops.DbName, w.Key)
+		}
+		for _, d := range ops.DataDeletes {
+			txOps.addDelete(ops.DbName, d.Key)
+		}
+	}
+
+	for _, ops := range tx.DbOperations {
+		if len(ops.DataWrites) == 0 {
+			continue
+		}
+
+		dbConstraints, err := constraints.Get(v.db, ops.DbName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range ops.DataWrites {
+			for _, r := range dbConstraints.GetReferences() {
+				refKey, refers, err := constraints.ReferencedKey(w.Value, r.Attribute)
+				if err != nil {
+					return &types.ValidationInfo{
+						Flag:            types.Flag_INVALID_DANGLING_REFERENCE,
+						ReasonIfInvalid: "the key [" + w.Key + "] cannot refer to a key of database [" + r.ReferencedDb + "] as " + err.Error(),
+						FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Key: w.Key, Check: types.DBOperationCheck_REFERENCE_CHECK},
+					}, nil
+				}
+				if !refers {
+					continue
+				}
+
+				exist, err := v.referencedKeyExists(r.ReferencedDb, refKey, txOps, pendingOps)
+				if err != nil {
+					return nil, err
+				}
+				if !exist {
+					return &types.ValidationInfo{
+						Flag: types.Flag_INVALID_DANGLING_REFERENCE,
+						ReasonIfInvalid: "the attribute [" + r.Attribute + "] of the key [" + w.Key + "] refers to the key [" + refKey +
+							"], which does not exist in database [" + r.ReferencedDb + "]",
+						FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Key: w.Key, Check: types.DBOperationCheck_REFERENCE_CHECK},
+					}, nil
+				}
+			}
+		}
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+}
+
+func (v *dataTxValidator) referencedKeyExists(dbName, key string, txOps, pendingOps *pendingOperations) (bool, error) {
+	switch {
+	case txOps.existDelete(dbName, key) || pendingOps.existDelete(dbName, key):
+		return false, nil
+	case txOps.exist(dbName, key) || pendingOps.exist(dbName, key):
+		return true, nil
+	}
+
+	val, metadata, err := v.db.Get(dbName, key)
+	if err != nil {
+		return false, errors.WithMessage(err, "error while validating references")
+	}
+	return val != nil || metadata != nil, nil
+}
+
+func (v *dataTxValidator) validateACLOnAclWrites(userIDs []string, dbName string, aclWrites []*types.AclWrite) (*types.ValidationInfo, error) {
+	for _, w := range aclWrites {
+		valRes, err := v.validateACLForWriteOrDelete(userIDs, dbName, w.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		if valRes.Flag != types.Flag_VALID {
+			valRes.FailedOperation = &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_WRITE_ACL_CHECK}
 			return valRes, nil
 		}
 	}
@@ -453,6 +972,30 @@ func (v *dataTxValidator) validateACLForWriteOrDelete(userIDs []string, dbName,
 				}, nil
 			}
 		}
+
+	case types.AccessControl_THRESHOLD:
+		// at least the threshold number of users present in the ACL list must be
+		// included in the userIDs
+		signed := 0
+		for _, userID := range userIDs {
+			if acl.ReadWriteUsers[userID] {
+				signed++
+			}
+		}
+
+		if signed < int(acl.SignThresholdForWrite) {
+			var targetUserIDs []string
+			for userID := range acl.ReadWriteUsers {
+				targetUserIDs = append(targetUserIDs, userID)
+			}
+
+			sort.Strings(targetUserIDs)
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: fmt.Sprintf("only %d out of the required %d users in [%s] have signed the transaction to write/delete key [%s] present in the database [%s]",
+					signed, acl.SignThresholdForWrite, strings.Join(targetUserIDs, ","), key, dbName),
+			}, nil
+		}
 	}
 
 	return &types.ValidationInfo{
@@ -466,6 +1009,7 @@ func (v *dataTxValidator) mvccValidation(dbName string, txOps *types.DBOperation
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [" + r.Key + "] in database [" + dbName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: r.Key, Check: types.DBOperationCheck_MVCC_CHECK},
 			}, nil
 		}
 
@@ -480,6 +1024,7 @@ func (v *dataTxValidator) mvccValidation(dbName string, txOps *types.DBOperation
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE,
 			ReasonIfInvalid: "mvcc conflict has occurred as the committed state for the key [" + r.Key + "] in database [" + dbName + "] changed",
+			FailedOperation: &types.DBOperationFailure{Key: r.Key, Check: types.DBOperationCheck_MVCC_CHECK},
 		}, nil
 	}
 
@@ -492,6 +1037,7 @@ func (v *dataTxValidator) mvccValidation(dbName string, txOps *types.DBOperation
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [" + w.Key + "] in database [" + dbName + "]. Within a block, a key can be modified only once",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_MVCC_CHECK},
 			}, nil
 		}
 	}
@@ -500,6 +1046,16 @@ func (v *dataTxValidator) mvccValidation(dbName string, txOps *types.DBOperation
 			return &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [" + d.Key + "] in database [" + dbName + "]. Within a block, a key can be modified only once",
+				FailedOperation: &types.DBOperationFailure{Key: d.Key, Check: types.DBOperationCheck_MVCC_CHECK},
+			}, nil
+		}
+	}
+	for _, w := range txOps.AclWrites {
+		if pendingOps.exist(dbName, w.Key) {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
+				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [" + w.Key + "] in database [" + dbName + "]. Within a block, a key can be modified only once",
+				FailedOperation: &types.DBOperationFailure{Key: w.Key, Check: types.DBOperationCheck_MVCC_CHECK},
 			}, nil
 		}
 	}
@@ -4,10 +4,17 @@
 package txvalidation
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/constraints"
+	"github.com/hyperledger-labs/orion-server/internal/dbfreeze"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/legalhold"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
@@ -86,7 +93,7 @@ func TestValidateDataTx(t *testing.T) {
 				MustSignUserIds: []string{alice},
 				DbOperations: []*types.DBOperation{
 					{
-						DbName: "db1/name",
+						DbName: "db1/name$",
 						DataWrites: []*types.DataWrite{
 							{
 								Key: "key1",
@@ -106,7 +113,8 @@ func TestValidateDataTx(t *testing.T) {
 			pendingOps: newPendingOperations(),
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-				ReasonIfInvalid: "the database name [db1/name] is not valid",
+				ReasonIfInvalid: "the database name [db1/name$] is not valid",
+				FailedOperation: &types.DBOperationFailure{DbName: "db1/name$", Check: types.DBOperationCheck_DB_CHECK},
 			},
 		},
 		{
@@ -134,6 +142,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_DATABASE_DOES_NOT_EXIST,
 				ReasonIfInvalid: "the database [db1] does not exist in the cluster",
+				FailedOperation: &types.DBOperationFailure{DbName: "db1", Check: types.DBOperationCheck_DB_CHECK},
 			},
 		},
 		{
@@ -161,6 +170,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "the database [" + worldstate.ConfigDBName + "] is a system database and no user can write to a system database via data transaction. Use appropriate transaction type to modify the system database",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.ConfigDBName, Check: types.DBOperationCheck_DB_CHECK},
 			},
 		},
 		{
@@ -199,6 +209,44 @@ func TestValidateDataTx(t *testing.T) {
 				ReasonIfInvalid: "signature of the must sign user [" + alice + "] is not valid (maybe the certificate got changed)",
 			},
 		},
+		{
+			name: "invalid: signature of a disabled user",
+			setup: func(db worldstate.DB) {
+				bobEntry := constructUserForTest(t, bob, bobCert.Raw, nil, nil, nil)
+				disabledBob := &types.User{
+					Id:          bob,
+					Certificate: bobCert.Raw,
+					Disabled:    true,
+				}
+				var err error
+				bobEntry.Value, err = proto.Marshal(disabledBob)
+				require.NoError(t, err)
+
+				user := map[string]*worldstate.DBUpdates{
+					worldstate.UsersDBName: {
+						Writes: []*worldstate.KVWithMetadata{
+							constructUserForTest(t, alice, aliceCert.Raw, nil, nil, nil),
+							bobEntry,
+						},
+					},
+				}
+
+				require.NoError(t, db.Commit(user, 1))
+			},
+			txEnv: testutils.SignedDataTxEnvelope(t, []crypto.Signer{aliceSigner, bobSigner}, &types.DataTx{
+				MustSignUserIds: []string{alice},
+				DbOperations: []*types.DBOperation{
+					{
+						DbName: worldstate.DefaultDBName,
+					},
+				},
+			}),
+			pendingOps: newPendingOperations(),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_USER_DISABLED,
+				ReasonIfInvalid: "the user [" + bob + "] is disabled",
+			},
+		},
 		{
 			name: "Invalid signature from non-must sign user and bob does not have rw access on the db",
 			setup: func(db worldstate.DB) {
@@ -225,6 +273,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [bob] has read-write permission on the database [bdb]",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Check: types.DBOperationCheck_DB_PERMISSION_CHECK},
 			},
 		},
 		{
@@ -297,6 +346,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the database [" + worldstate.DefaultDBName + "] occurs more than once in the operations. The database present in the operations should be unique",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Check: types.DBOperationCheck_DB_CHECK},
 			},
 		},
 		{
@@ -330,6 +380,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [alice, bob] has read-write permission on the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Check: types.DBOperationCheck_DB_PERMISSION_CHECK},
 			},
 		},
 		{
@@ -359,6 +410,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the user [user1] defined in the access control for the key [key1] does not exist",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -388,6 +440,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [key2] does not exist in the database and hence, it cannot be deleted",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key2", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -435,6 +488,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [key1] is being updated as well as deleted. Only one operation per key is allowed within a transaction",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -478,6 +532,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [alice] has a read permission on key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_READ_ACL_CHECK},
 			},
 		},
 		{
@@ -521,6 +576,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [alice] has a write/delete permission on key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_WRITE_ACL_CHECK},
 			},
 		},
 		{
@@ -564,6 +620,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [alice] has a write/delete permission on key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_DELETE_ACL_CHECK},
 			},
 		},
 		{
@@ -597,6 +654,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -619,6 +677,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "the database [" + worldstate.UsersDBName + "] is a system database and no user can write to a system database via data transaction. Use appropriate transaction type to modify the system database",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.UsersDBName, Check: types.DBOperationCheck_DB_CHECK},
 			},
 		},
 		{
@@ -978,6 +1037,7 @@ func TestValidateDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "not all required users in [alice,bob] have signed the transaction to write/delete key [key1] present in the database [bdb]",
+				FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_WRITE_ACL_CHECK},
 			},
 		},
 		{
@@ -1010,7 +1070,7 @@ func TestValidateDataTx(t *testing.T) {
 
 			tt.setup(env.db)
 
-			usersWithValidSignTx, valInfo, err := env.validator.dataTxValidator.validateSignatures(tt.txEnv)
+			usersWithValidSignTx, valInfo, err := env.validator.dataTxValidator.validateSignatures(tt.txEnv, time.Now().UnixNano())
 			require.NoError(t, err)
 			if valInfo.Flag != types.Flag_VALID {
 				require.Equal(t, tt.expectedResult, valInfo)
@@ -1042,6 +1102,101 @@ func TestValidateFieldsInDataWrites(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "there is an empty entry in the write list",
+				FailedOperation: &types.DBOperationFailure{Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: a key is written with a sealed value",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key:            "key1",
+					EncryptedValue: &types.EncryptedValue{KeyId: "key-1", Ciphertext: []byte("sealed")},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] is written with a sealed or an erased value, which only the block store sets",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: a key is written as erased",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key:    "key1",
+					Erased: true,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] is written with a sealed or an erased value, which only the block store sets",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: a key is written with the hash of a sealed value",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key:       "key1",
+					Value:     []byte("value1"),
+					ValueHash: []byte("hash"),
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] is written with a sealed or an erased value, which only the block store sets",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: a key written by a blob manifest has a value",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key:          "key1",
+					Value:        []byte("value1"),
+					BlobManifest: blobstore.NewManifest([]byte("0123456789"), 4),
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] is written by a blob manifest, hence its value must be empty",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: a blob manifest lists fewer chunks than the size of the blob",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+					BlobManifest: &types.BlobManifest{
+						Size:        10,
+						ChunkSize:   4,
+						ChunkHashes: blobstore.NewManifest([]byte("01234567"), 4).ChunkHashes,
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the blob manifest of the key [key1] is invalid: a blob of size [10] in chunks of size [4] has [3] chunks, but the manifest lists [2]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "valid: a key written by a blob manifest",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key:          "key1",
+					BlobManifest: blobstore.NewManifest([]byte("0123456789"), 4),
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
 			},
 		},
 		{
@@ -1060,6 +1215,7 @@ func TestValidateFieldsInDataWrites(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the user [user1] defined in the access control for the key [key1] does not exist",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -1078,6 +1234,128 @@ func TestValidateFieldsInDataWrites(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the user [user1] defined in the access control for the key [key1] does not exist",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: threshold set with the ALL write policy",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+					Acl: &types.AccessControl{
+						ReadWriteUsers: map[string]bool{
+							"user1": true,
+						},
+						SignPolicyForWrite:    types.AccessControl_ALL,
+						SignThresholdForWrite: 1,
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the sign threshold for write of the key [key1] can be set only with the THRESHOLD sign policy",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: threshold is larger than the number of read-write users",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+					Acl: &types.AccessControl{
+						ReadWriteUsers: map[string]bool{
+							"user1": true,
+							"user2": true,
+						},
+						SignPolicyForWrite:    types.AccessControl_THRESHOLD,
+						SignThresholdForWrite: 3,
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the sign threshold for write of the key [key1] must be between 1 and the number of read-write users [2], but it is [3]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: threshold is not set with the THRESHOLD write policy",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+					Acl: &types.AccessControl{
+						ReadWriteUsers: map[string]bool{
+							"user1": true,
+						},
+						SignPolicyForWrite: types.AccessControl_THRESHOLD,
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the sign threshold for write of the key [key1] must be between 1 and the number of read-write users [1], but it is [0]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: user defined in the redacted read acl does not exist",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+					Acl: &types.AccessControl{
+						RedactedReadUsers: map[string]*types.RedactionPolicy{
+							"user1": {StrippedFields: []string{"salary"}},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [user1] defined in the access control for the key [key1] does not exist",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: redaction policy lists a field twice",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+					Acl: &types.AccessControl{
+						RedactedReadUsers: map[string]*types.RedactionPolicy{
+							"user2": {StrippedFields: []string{"salary"}},
+							"user1": {StrippedFields: []string{"salary"}, HashedFields: []string{"salary"}},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the redaction policy of the user [user1] for the key [key1] is invalid: the field [salary] is listed more than once",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: redaction policy has an empty field",
+			setup: func(db worldstate.DB) {},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+					Acl: &types.AccessControl{
+						RedactedReadUsers: map[string]*types.RedactionPolicy{
+							"user1": {HashedFields: []string{"address."}},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the redaction policy of the user [user1] for the key [key1] is invalid: the field [address.] has an empty member name",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -1088,6 +1366,7 @@ func TestValidateFieldsInDataWrites(t *testing.T) {
 						Writes: []*worldstate.KVWithMetadata{
 							constructUserForTest(t, "user1", nil, nil, nil, nil),
 							constructUserForTest(t, "user2", nil, nil, nil, nil),
+							constructUserForTest(t, "user3", nil, nil, nil, nil),
 						},
 					},
 				}
@@ -1106,6 +1385,17 @@ func TestValidateFieldsInDataWrites(t *testing.T) {
 						},
 					},
 				},
+				{
+					Key: "key3",
+					Acl: &types.AccessControl{
+						ReadWriteUsers: map[string]bool{
+							"user1": true,
+							"user2": true,
+						},
+						SignPolicyForWrite:    types.AccessControl_THRESHOLD,
+						SignThresholdForWrite: 1,
+					},
+				},
 				{
 					Key: "key2",
 					Acl: &types.AccessControl{
@@ -1115,11 +1405,419 @@ func TestValidateFieldsInDataWrites(t *testing.T) {
 						ReadWriteUsers: map[string]bool{
 							"user2": true,
 						},
+						RedactedReadUsers: map[string]*types.RedactionPolicy{
+							"user3": {StrippedFields: []string{"salary"}, HashedFields: []string{"address.street"}},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+
+			tt.setup(env.db)
+
+			result, err := env.validator.dataTxValidator.validateFieldsInDataWrites(tt.dataWrites)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestValidateFieldsInDataDeletes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		setup          func(db worldstate.DB)
+		dataDeletes    []*types.DataDelete
+		pendingOps     *pendingOperations
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:  "invalid: an empty entry in the delete",
+			setup: func(db worldstate.DB) {},
+			dataDeletes: []*types.DataDelete{
+				nil,
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the delete list",
+				FailedOperation: &types.DBOperationFailure{Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "invalid: entry to be deleted is already deleted by some previous transaction",
+			setup: func(db worldstate.DB) {},
+			dataDeletes: []*types.DataDelete{
+				{
+					Key: "key1",
+				},
+			},
+			pendingOps: &pendingOperations{
+				pendingWrites: map[string]bool{},
+				pendingDeletes: map[string]bool{
+					constructCompositeKey(worldstate.DefaultDBName, "key1"): true,
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
+				ReasonIfInvalid: "the key [key1] is already deleted by some previous transaction in the block",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
+			},
+		},
+		{
+			name:  "invalid: entry to be deleted does not exist",
+			setup: func(db worldstate.DB) {},
+			dataDeletes: []*types.DataDelete{
+				{
+					Key: "key1",
+				},
+			},
+			pendingOps: newPendingOperations(),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] does not exist in the database and hence, it cannot be deleted",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name: "valid",
+			setup: func(db worldstate.DB) {
+				data := map[string]*worldstate.DBUpdates{
+					worldstate.DefaultDBName: {
+						Writes: []*worldstate.KVWithMetadata{
+							{
+								Key: "key1",
+								Metadata: &types.Metadata{
+									Version: &types.Version{
+										BlockNum: 1,
+										TxNum:    1,
+									},
+								},
+							},
+						},
+					},
+				}
+
+				require.NoError(t, db.Commit(data, 1))
+			},
+			dataDeletes: []*types.DataDelete{
+				{
+					Key: "key1",
+				},
+			},
+			pendingOps: newPendingOperations(),
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			tt.setup(env.db)
+
+			result, err := env.validator.dataTxValidator.validateFieldsInDataDeletes(worldstate.DefaultDBName, tt.dataDeletes, tt.pendingOps)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestValidateLegalHoldsOnDataDeletes(t *testing.T) {
+	t.Parallel()
+
+	placeHolds := func(t *testing.T, db worldstate.DB, holds ...*types.LegalHold) {
+		updates, err := legalhold.ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{UserId: "admin", PlaceLegalHolds: holds}, &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.LegalHoldsDBName: updates}, 1))
+	}
+
+	tests := []struct {
+		name           string
+		holds          []*types.LegalHold
+		dataDeletes    []*types.DataDelete
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:        "valid: no legal hold",
+			dataDeletes: []*types.DataDelete{{Key: "key1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: legal holds on other keys and databases",
+			holds: []*types.LegalHold{
+				{DbName: worldstate.DefaultDBName, Key: "key2", Reason: "case 1"},
+				{DbName: "db1", Reason: "case 2"},
+			},
+			dataDeletes: []*types.DataDelete{{Key: "key1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:        "invalid: key is under a legal hold",
+			holds:       []*types.LegalHold{{DbName: worldstate.DefaultDBName, Key: "key2", Reason: "case 1"}},
+			dataDeletes: []*types.DataDelete{{Key: "key1"}, {Key: "key2"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: "the key [key2] is under a legal hold and hence, it cannot be deleted",
+				FailedOperation: &types.DBOperationFailure{Key: "key2", Check: types.DBOperationCheck_LEGAL_HOLD_CHECK},
+			},
+		},
+		{
+			name:        "invalid: database is under a legal hold",
+			holds:       []*types.LegalHold{{DbName: worldstate.DefaultDBName, Reason: "case 1"}},
+			dataDeletes: []*types.DataDelete{{Key: "key1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: "the database [" + worldstate.DefaultDBName + "] is under a legal hold and hence, the key [key1] cannot be deleted",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_LEGAL_HOLD_CHECK},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			if len(tt.holds) > 0 {
+				placeHolds(t, env.db, tt.holds...)
+			}
+
+			result, err := env.validator.dataTxValidator.validateLegalHoldsOnDataDeletes([]string{"user1"}, worldstate.DefaultDBName, tt.dataDeletes)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		updates, err := constraints.ConstructDBEntriesForDBAdminTx(db, &types.DBAdministrationTx{
+			UserId: "admin",
+			DbsConstraints: map[string]*types.DBConstraints{
+				"orders": {
+					References: []*types.ReferenceConstraint{
+						{Attribute: "customer", ReferencedDb: "customers"},
+					},
+				},
+			},
+		}, &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "orders"}, {Key: "customers"}},
+			},
+			worldstate.ConstraintsDBName: updates,
+		}, 1))
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			"customers": {
+				Writes: []*worldstate.KVWithMetadata{
+					{Key: "c1", Value: []byte(`{"name":"alice"}`), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}}},
+					{Key: "c2", Value: []byte(`{"name":"bob"}`), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}}},
+				},
+			},
+		}, 2))
+	}
+
+	writeOrder := func(key, value string) *types.DBOperation {
+		return &types.DBOperation{
+			DbName:     "orders",
+			DataWrites: []*types.DataWrite{{Key: key, Value: []byte(value)}},
+		}
+	}
+	pendingDelete := func(dbName, key string) *pendingOperations {
+		p := newPendingOperations()
+		p.addDelete(dbName, key)
+		return p
+	}
+	pendingWrite := func(dbName, key string) *pendingOperations {
+		p := newPendingOperations()
+		p.addWrite(dbName, key)
+		return p
+	}
+
+	tests := []struct {
+		name           string
+		ops            []*types.DBOperation
+		pendingOps     *pendingOperations
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid: the referenced key is committed",
+			ops:  []*types.DBOperation{writeOrder("o1", `{"customer":"c1"}`)},
+		},
+		{
+			name: "valid: the document does not refer to a key",
+			ops: []*types.DBOperation{
+				writeOrder("o1", `{"customer":null}`),
+				{DbName: "customers", DataWrites: []*types.DataWrite{{Key: "c3", Value: []byte(`{"customer":"none"}`)}}},
+			},
+		},
+		{
+			name: "valid: the referenced key is written by the transaction",
+			ops: []*types.DBOperation{
+				writeOrder("o1", `{"customer":"c3"}`),
+				{DbName: "customers", DataWrites: []*types.DataWrite{{Key: "c3", Value: []byte(`{"name":"charlie"}`)}}},
+			},
+		},
+		{
+			name:       "valid: the referenced key is written by a previous transaction in the block",
+			ops:        []*types.DBOperation{writeOrder("o1", `{"customer":"c3"}`)},
+			pendingOps: pendingWrite("customers", "c3"),
+		},
+		{
+			name: "invalid: the referenced key does not exist",
+			ops:  []*types.DBOperation{writeOrder("o1", `{"customer":"c3"}`)},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DANGLING_REFERENCE,
+				ReasonIfInvalid: "the attribute [customer] of the key [o1] refers to the key [c3], which does not exist in database [customers]",
+				FailedOperation: &types.DBOperationFailure{DbName: "orders", Key: "o1", Check: types.DBOperationCheck_REFERENCE_CHECK},
+			},
+		},
+		{
+			name: "invalid: the referenced key is deleted by the transaction",
+			ops: []*types.DBOperation{
+				writeOrder("o1", `{"customer":"c1"}`),
+				{DbName: "customers", DataDeletes: []*types.DataDelete{{Key: "c1"}}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DANGLING_REFERENCE,
+				ReasonIfInvalid: "the attribute [customer] of the key [o1] refers to the key [c1], which does not exist in database [customers]",
+				FailedOperation: &types.DBOperationFailure{DbName: "orders", Key: "o1", Check: types.DBOperationCheck_REFERENCE_CHECK},
+			},
+		},
+		{
+			name:       "invalid: the referenced key is deleted by a previous transaction in the block",
+			ops:        []*types.DBOperation{writeOrder("o1", `{"customer":"c2"}`)},
+			pendingOps: pendingDelete("customers", "c2"),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DANGLING_REFERENCE,
+				ReasonIfInvalid: "the attribute [customer] of the key [o1] refers to the key [c2], which does not exist in database [customers]",
+				FailedOperation: &types.DBOperationFailure{DbName: "orders", Key: "o1", Check: types.DBOperationCheck_REFERENCE_CHECK},
+			},
+		},
+		{
+			name: "invalid: the referring attribute is not a string",
+			ops:  []*types.DBOperation{writeOrder("o1", `{"customer":1}`)},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DANGLING_REFERENCE,
+				ReasonIfInvalid: "the key [o1] cannot refer to a key of database [customers] as the attribute [customer] holds 1, which is not a string",
+				FailedOperation: &types.DBOperationFailure{DbName: "orders", Key: "o1", Check: types.DBOperationCheck_REFERENCE_CHECK},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			pendingOps := tt.pendingOps
+			if pendingOps == nil {
+				pendingOps = newPendingOperations()
+			}
+			expectedResult := tt.expectedResult
+			if expectedResult == nil {
+				expectedResult = &types.ValidationInfo{Flag: types.Flag_VALID}
+			}
+
+			result, err := env.validator.dataTxValidator.validateReferences(&types.DataTx{DbOperations: tt.ops}, pendingOps)
+			require.NoError(t, err)
+			require.Equal(t, expectedResult, result)
+		})
+	}
+}
+
+func TestValidateSchemas(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		updates, err := constraints.ConstructDBEntriesForDBAdminTx(db, &types.DBAdministrationTx{
+			UserId: "admin",
+			DbsConstraints: map[string]*types.DBConstraints{
+				"orders": {
+					Schema: &types.DocumentSchema{
+						AttributeAndType:   map[string]types.IndexAttributeType{"customer": types.IndexAttributeType_STRING, "amount": types.IndexAttributeType_NUMBER},
+						RequiredAttributes: []string{"customer"},
 					},
 				},
 			},
+		}, &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "orders"}, {Key: "customers"}},
+			},
+			worldstate.ConstraintsDBName: updates,
+		}, 1))
+	}
+
+	tests := []struct {
+		name           string
+		ops            []*types.DBOperation
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid: the documents conform to the schema",
+			ops: []*types.DBOperation{
+				{DbName: "orders", DataWrites: []*types.DataWrite{{Key: "o1", Value: []byte(`{"customer":"c1","amount":10,"note":1}`)}}},
+				{DbName: "customers", DataWrites: []*types.DataWrite{{Key: "c1", Value: []byte(`binary value`)}}},
+			},
+		},
+		{
+			name: "invalid: the required attribute is absent",
+			ops: []*types.DBOperation{
+				{DbName: "orders", DataWrites: []*types.DataWrite{
+					{Key: "o1", Value: []byte(`{"customer":"c1"}`)},
+					{Key: "o2", Value: []byte(`{"amount":10}`)},
+				}},
+			},
 			expectedResult: &types.ValidationInfo{
-				Flag: types.Flag_VALID,
+				Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+				ReasonIfInvalid: "the value of the key [o2] does not conform to the schema of database [orders]: the required attribute [customer] is absent or null",
+				FailedOperation: &types.DBOperationFailure{DbName: "orders", Key: "o2", Check: types.DBOperationCheck_SCHEMA_CHECK},
+			},
+		},
+		{
+			name: "invalid: the attribute has another type",
+			ops: []*types.DBOperation{
+				{DbName: "orders", DataWrites: []*types.DataWrite{{Key: "o1", Value: []byte(`{"customer":"c1","amount":"10"}`)}}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_SCHEMA_VIOLATION,
+				ReasonIfInvalid: `the value of the key [o1] does not conform to the schema of database [orders]: the attribute [amount] holds "10", which is not of type NUMBER`,
+				FailedOperation: &types.DBOperationFailure{DbName: "orders", Key: "o1", Check: types.DBOperationCheck_SCHEMA_CHECK},
 			},
 		},
 	}
@@ -1131,41 +1829,68 @@ func TestValidateFieldsInDataWrites(t *testing.T) {
 
 			env := newValidatorTestEnv(t)
 			defer env.cleanup()
+			setup(t, env.db)
 
-			tt.setup(env.db)
+			expectedResult := tt.expectedResult
+			if expectedResult == nil {
+				expectedResult = &types.ValidationInfo{Flag: types.Flag_VALID}
+			}
 
-			result, err := env.validator.dataTxValidator.validateFieldsInDataWrites(tt.dataWrites)
+			result, err := env.validator.dataTxValidator.validateSchemas(&types.DataTx{DbOperations: tt.ops})
 			require.NoError(t, err)
-			require.Equal(t, tt.expectedResult, result)
+			require.Equal(t, expectedResult, result)
 		})
 	}
 }
 
-func TestValidateFieldsInDataDeletes(t *testing.T) {
+func TestValidateFieldsInAclWrites(t *testing.T) {
 	t.Parallel()
 
+	setupKey1 := func(db worldstate.DB) {
+		data := map[string]*worldstate.DBUpdates{
+			worldstate.DefaultDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   "key1",
+						Value: []byte("value1"),
+						Metadata: &types.Metadata{
+							Version: &types.Version{
+								BlockNum: 1,
+								TxNum:    1,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		require.NoError(t, db.Commit(data, 1))
+	}
+
 	tests := []struct {
 		name           string
 		setup          func(db worldstate.DB)
-		dataDeletes    []*types.DataDelete
+		aclWrites      []*types.AclWrite
 		pendingOps     *pendingOperations
 		expectedResult *types.ValidationInfo
 	}{
 		{
-			name:  "invalid: an empty entry in the delete",
+			name:  "invalid: an empty entry in the acl writes",
 			setup: func(db worldstate.DB) {},
-			dataDeletes: []*types.DataDelete{
+			aclWrites: []*types.AclWrite{
 				nil,
 			},
+			pendingOps: newPendingOperations(),
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-				ReasonIfInvalid: "there is an empty entry in the delete list",
+				ReasonIfInvalid: "there is an empty entry in the acl write list",
+				FailedOperation: &types.DBOperationFailure{Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
-			name:  "invalid: entry to be deleted is already deleted by some previous transaction",
-			setup: func(db worldstate.DB) {},
-			dataDeletes: []*types.DataDelete{
+			name:  "invalid: key is already deleted by some previous transaction",
+			setup: setupKey1,
+			aclWrites: []*types.AclWrite{
 				{
 					Key: "key1",
 				},
@@ -1179,12 +1904,13 @@ func TestValidateFieldsInDataDeletes(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "the key [key1] is already deleted by some previous transaction in the block",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
-			name:  "invalid: entry to be deleted does not exist",
+			name:  "invalid: key does not exist",
 			setup: func(db worldstate.DB) {},
-			dataDeletes: []*types.DataDelete{
+			aclWrites: []*types.AclWrite{
 				{
 					Key: "key1",
 				},
@@ -1192,31 +1918,34 @@ func TestValidateFieldsInDataDeletes(t *testing.T) {
 			pendingOps: newPendingOperations(),
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-				ReasonIfInvalid: "the key [key1] does not exist in the database and hence, it cannot be deleted",
+				ReasonIfInvalid: "the key [key1] does not exist in the database and hence, its access control cannot be updated",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
-			name: "valid",
-			setup: func(db worldstate.DB) {
-				data := map[string]*worldstate.DBUpdates{
-					worldstate.DefaultDBName: {
-						Writes: []*worldstate.KVWithMetadata{
-							{
-								Key: "key1",
-								Metadata: &types.Metadata{
-									Version: &types.Version{
-										BlockNum: 1,
-										TxNum:    1,
-									},
-								},
-							},
+			name:  "invalid: user in the new acl does not exist",
+			setup: setupKey1,
+			aclWrites: []*types.AclWrite{
+				{
+					Key: "key1",
+					NewAcl: &types.AccessControl{
+						ReadUsers: map[string]bool{
+							"user1": true,
 						},
 					},
-				}
-
-				require.NoError(t, db.Commit(data, 1))
+				},
 			},
-			dataDeletes: []*types.DataDelete{
+			pendingOps: newPendingOperations(),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [user1] defined in the access control for the key [key1] does not exist",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name:  "valid: the acl is removed",
+			setup: setupKey1,
+			aclWrites: []*types.AclWrite{
 				{
 					Key: "key1",
 				},
@@ -1237,7 +1966,7 @@ func TestValidateFieldsInDataDeletes(t *testing.T) {
 			defer env.cleanup()
 			tt.setup(env.db)
 
-			result, err := env.validator.dataTxValidator.validateFieldsInDataDeletes(worldstate.DefaultDBName, tt.dataDeletes, tt.pendingOps)
+			result, err := env.validator.dataTxValidator.validateFieldsInAclWrites(worldstate.DefaultDBName, tt.aclWrites, tt.pendingOps)
 			require.NoError(t, err)
 			require.Equal(t, tt.expectedResult, result)
 		})
@@ -1251,6 +1980,7 @@ func TestValidateUniquenessInDataWritesAndDeletes(t *testing.T) {
 		name           string
 		dataWrites     []*types.DataWrite
 		dataDeletes    []*types.DataDelete
+		aclWrites      []*types.AclWrite
 		expectedResult *types.ValidationInfo
 	}{
 		{
@@ -1266,6 +1996,7 @@ func TestValidateUniquenessInDataWritesAndDeletes(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [key1] is duplicated in the write list. The keys in the write list must be unique",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -1281,6 +2012,7 @@ func TestValidateUniquenessInDataWritesAndDeletes(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [key1] is duplicated in the delete list. The keys in the delete list must be unique",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -1298,6 +2030,41 @@ func TestValidateUniquenessInDataWritesAndDeletes(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 				ReasonIfInvalid: "the key [key1] is being updated as well as deleted. Only one operation per key is allowed within a transaction",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name: "invalid: duplicate entry in the acl writes",
+			aclWrites: []*types.AclWrite{
+				{
+					Key: "key1",
+				},
+				{
+					Key: "key1",
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] is duplicated in the acl write list. The keys in the acl write list must be unique",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
+			},
+		},
+		{
+			name: "invalid: the same entry is present in both write and acl write list",
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+				},
+			},
+			aclWrites: []*types.AclWrite{
+				{
+					Key: "key1",
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the access control of the key [key1] is being updated while the key is also written or deleted. Only one operation per key is allowed within a transaction",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_ENTRIES_CHECK},
 			},
 		},
 		{
@@ -1312,6 +2079,11 @@ func TestValidateUniquenessInDataWritesAndDeletes(t *testing.T) {
 					Key: "key2",
 				},
 			},
+			aclWrites: []*types.AclWrite{
+				{
+					Key: "key3",
+				},
+			},
 			expectedResult: &types.ValidationInfo{
 				Flag: types.Flag_VALID,
 			},
@@ -1326,7 +2098,7 @@ func TestValidateUniquenessInDataWritesAndDeletes(t *testing.T) {
 			env := newValidatorTestEnv(t)
 			defer env.cleanup()
 
-			result := validateUniquenessInDataWritesAndDeletes(tt.dataWrites, tt.dataDeletes)
+			result := validateUniquenessInDataWritesAndDeletes(tt.dataWrites, tt.dataDeletes, tt.aclWrites)
 			require.Equal(t, tt.expectedResult, result)
 		})
 	}
@@ -1377,6 +2149,7 @@ func TestValidateAClOnDataReads(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [operatingUser,anotherUser] has a read permission on key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_READ_ACL_CHECK},
 			},
 		},
 		{
@@ -1549,6 +2322,7 @@ func TestValidateAClOnDataWrites(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [operatingUser,anotherUser] has a write/delete permission on key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_WRITE_ACL_CHECK},
 			},
 		},
 		{
@@ -1586,6 +2360,7 @@ func TestValidateAClOnDataWrites(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "not all required users in [user1,user2,user3] have signed the transaction to write/delete key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_WRITE_ACL_CHECK},
 			},
 		},
 		{
@@ -1620,6 +2395,7 @@ func TestValidateAClOnDataWrites(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "no user can write or delete the key [key1]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_WRITE_ACL_CHECK},
 			},
 		},
 		{
@@ -1692,6 +2468,82 @@ func TestValidateAClOnDataWrites(t *testing.T) {
 				Flag: types.Flag_VALID,
 			},
 		},
+		{
+			name: "invalid: not enough users have signed - THRESHOLD write policy",
+			setup: func(db worldstate.DB) {
+				data := map[string]*worldstate.DBUpdates{
+					worldstate.DefaultDBName: {
+						Writes: []*worldstate.KVWithMetadata{
+							{
+								Key: "key1",
+								Metadata: &types.Metadata{
+									Version: sampleVersion,
+									AccessControl: &types.AccessControl{
+										ReadWriteUsers: map[string]bool{
+											"user1": true,
+											"user2": true,
+											"user3": true,
+										},
+										SignPolicyForWrite:    types.AccessControl_THRESHOLD,
+										SignThresholdForWrite: 2,
+									},
+								},
+							},
+						},
+					},
+				}
+
+				require.NoError(t, db.Commit(data, 1))
+			},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+				},
+			},
+			operatingUser: []string{"user1", "anotherUser"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: "only 1 out of the required 2 users in [user1,user2,user3] have signed the transaction to write/delete key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_WRITE_ACL_CHECK},
+			},
+		},
+		{
+			name: "valid: acl check passes - THRESHOLD write policy",
+			setup: func(db worldstate.DB) {
+				data := map[string]*worldstate.DBUpdates{
+					worldstate.DefaultDBName: {
+						Writes: []*worldstate.KVWithMetadata{
+							{
+								Key: "key1",
+								Metadata: &types.Metadata{
+									Version: sampleVersion,
+									AccessControl: &types.AccessControl{
+										ReadWriteUsers: map[string]bool{
+											"user1": true,
+											"user2": true,
+											"user3": true,
+										},
+										SignPolicyForWrite:    types.AccessControl_THRESHOLD,
+										SignThresholdForWrite: 2,
+									},
+								},
+							},
+						},
+					},
+				}
+
+				require.NoError(t, db.Commit(data, 1))
+			},
+			dataWrites: []*types.DataWrite{
+				{
+					Key: "key1",
+				},
+			},
+			operatingUser: []string{"anotherUser", "user1", "user3"},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
 		{
 			name: "valid: no acl",
 			setup: func(db worldstate.DB) {
@@ -1796,6 +2648,7 @@ func TestValidateAClOnDataDeletes(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "none of the user in [operatingUser,anotherUser] has a write/delete permission on key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_DELETE_ACL_CHECK},
 			},
 		},
 		{
@@ -1833,6 +2686,7 @@ func TestValidateAClOnDataDeletes(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "not all required users in [user1,user2,user3] have signed the transaction to write/delete key [key1] present in the database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_DELETE_ACL_CHECK},
 			},
 		},
 		{
@@ -1867,6 +2721,7 @@ func TestValidateAClOnDataDeletes(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_NO_PERMISSION,
 				ReasonIfInvalid: "no user can write or delete the key [key1]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_DELETE_ACL_CHECK},
 			},
 		},
 		{
@@ -2043,6 +2898,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2065,6 +2921,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2087,6 +2944,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]. Within a block, a key can be modified only once",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2109,6 +2967,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]. Within a block, a key can be modified only once",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2130,6 +2989,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]. Within a block, a key can be modified only once",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2151,6 +3011,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 				ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]. Within a block, a key can be modified only once",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2168,6 +3029,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE,
 				ReasonIfInvalid: "mvcc conflict has occurred as the committed state for the key [key1] in database [" + worldstate.DefaultDBName + "] changed",
+				FailedOperation: &types.DBOperationFailure{Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2210,6 +3072,7 @@ func TestMVCCOnDataTx(t *testing.T) {
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE,
 				ReasonIfInvalid: "mvcc conflict has occurred as the committed state for the key [key2] in database [" + worldstate.DefaultDBName + "] changed",
+				FailedOperation: &types.DBOperationFailure{Key: "key2", Check: types.DBOperationCheck_MVCC_CHECK},
 			},
 		},
 		{
@@ -2275,3 +3138,148 @@ func TestMVCCOnDataTx(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDataTxAnnotations(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxDataTxAnnotations; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:           "valid: no annotations",
+			annotations:    nil,
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name: "valid: annotations within limits",
+			annotations: map[string]string{
+				"correlation-id": "1234",
+				"order":          "po-5678",
+			},
+			expectedResult: &types.ValidationInfo{Flag: types.Flag_VALID},
+		},
+		{
+			name:        "invalid: too many annotations",
+			annotations: tooMany,
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("the number of annotations [%d] exceeds the limit of %d", MaxDataTxAnnotations+1, MaxDataTxAnnotations),
+			},
+		},
+		{
+			name: "invalid: annotations too large",
+			annotations: map[string]string{
+				"key": strings.Repeat("v", MaxDataTxAnnotationsSize),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("the total size of the annotations [%d bytes] exceeds the limit of %d bytes", MaxDataTxAnnotationsSize+3, MaxDataTxAnnotationsSize),
+			},
+		},
+		{
+			name: "invalid: empty key",
+			annotations: map[string]string{
+				"": "value",
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "an annotation has an empty key",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.True(t, proto.Equal(tt.expectedResult, ValidateDataTxAnnotations(tt.annotations)))
+		})
+	}
+}
+
+func TestValidateDBNotFrozen(t *testing.T) {
+	t.Parallel()
+
+	env := newValidatorTestEnv(t)
+	defer env.cleanup()
+
+	freezes, err := dbfreeze.ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{
+		UserId:    "admin",
+		FreezeDbs: []*types.DatabaseFreeze{{DbName: "db1", Reason: "cutover"}},
+	}, &types.Version{BlockNum: 1})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{worldstate.FrozenDBsDBName: freezes}, 1))
+
+	frozenResult := &types.ValidationInfo{
+		Flag:            types.Flag_INVALID_DATABASE_FROZEN,
+		ReasonIfInvalid: "the database [db1] is frozen for the reason [cutover] and hence, it cannot be written to",
+		FailedOperation: &types.DBOperationFailure{DbName: "db1", Check: types.DBOperationCheck_FREEZE_CHECK},
+	}
+
+	tests := []struct {
+		name           string
+		ops            *types.DBOperation
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid: the database is not frozen",
+			ops: &types.DBOperation{
+				DbName:     "db2",
+				DataWrites: []*types.DataWrite{{Key: "key1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: reads from a frozen database",
+			ops: &types.DBOperation{
+				DbName:    "db1",
+				DataReads: []*types.DataRead{{Key: "key1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "invalid: writes to a frozen database",
+			ops: &types.DBOperation{
+				DbName:     "db1",
+				DataWrites: []*types.DataWrite{{Key: "key1"}},
+			},
+			expectedResult: frozenResult,
+		},
+		{
+			name: "invalid: deletes from a frozen database",
+			ops: &types.DBOperation{
+				DbName:      "db1",
+				DataDeletes: []*types.DataDelete{{Key: "key1"}},
+			},
+			expectedResult: frozenResult,
+		},
+		{
+			name: "invalid: changes the access control of a key of a frozen database",
+			ops: &types.DBOperation{
+				DbName:    "db1",
+				AclWrites: []*types.AclWrite{{Key: "key1"}},
+			},
+			expectedResult: frozenResult,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := env.validator.dataTxValidator.validateDBNotFrozen(tt.ops)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedResult, result))
+		})
+	}
+}
@@ -4,7 +4,16 @@
 package txvalidation
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger-labs/orion-server/internal/constraints"
+	"github.com/hyperledger-labs/orion-server/internal/dbfreeze"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/legalhold"
+	"github.com/hyperledger-labs/orion-server/internal/migration"
+	"github.com/hyperledger-labs/orion-server/internal/redaction"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -18,22 +27,19 @@ type dbAdminTxValidator struct {
 	logger          *logger.SugarLogger
 }
 
-func (v *dbAdminTxValidator) validate(txEnv *types.DBAdministrationTxEnvelope) (*types.ValidationInfo, error) {
-	valInfo, err := v.sigValidator.validate(txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload)
+func (v *dbAdminTxValidator) validate(txEnv *types.DBAdministrationTxEnvelope, blockTimestamp int64) (*types.ValidationInfo, error) {
+	valInfo, err := v.sigValidator.validate(txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload, blockTimestamp)
 	if err != nil || valInfo.Flag != types.Flag_VALID {
 		return valInfo, err
 	}
 
 	tx := txEnv.Payload
-	hasPerm, err := v.identityQuerier.HasAdministrationPrivilege(tx.UserId)
+	r, err := v.validatePrivilege(tx)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "error while checking database administrative privilege for user [%s]", tx.UserId)
 	}
-	if !hasPerm {
-		return &types.ValidationInfo{
-			Flag:            types.Flag_INVALID_NO_PERMISSION,
-			ReasonIfInvalid: "the user [" + tx.UserId + "] has no privilege to perform database administrative operations",
-		}, nil
+	if r.Flag != types.Flag_VALID {
+		return r, nil
 	}
 
 	if r := v.validateCreateDBEntries(tx.CreateDbs); r.Flag != types.Flag_VALID {
@@ -44,7 +50,105 @@ func (v *dbAdminTxValidator) validate(txEnv *types.DBAdministrationTxEnvelope) (
 		return r, nil
 	}
 
-	return v.validateIndexEntries(tx.DbsIndex, tx.CreateDbs, tx.DeleteDbs), nil
+	if r := v.validateIndexEntries(tx.DbsIndex, tx.CreateDbs, tx.DeleteDbs); r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	r, err = v.validateEraseEntries(tx.EraseKeys, tx.DeleteDbs)
+	if err != nil || r.Flag != types.Flag_VALID {
+		return r, err
+	}
+
+	if r := v.validateLegalHoldEntries(tx.PlaceLegalHolds, tx.ReleaseLegalHolds, tx.DeleteDbs); r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	r, err = v.validateConstraintEntries(tx.DbsConstraints, tx.CreateDbs, tx.DeleteDbs)
+	if err != nil || r.Flag != types.Flag_VALID {
+		return r, err
+	}
+
+	if r := v.validateFreezeEntries(tx.FreezeDbs, tx.ThawDbs, tx.DeleteDbs); r.Flag != types.Flag_VALID {
+		return r, nil
+	}
+
+	r, err = v.validateFreezes(tx)
+	if err != nil || r.Flag != types.Flag_VALID {
+		return r, err
+	}
+
+	r, err = v.validateMigration(tx)
+	if err != nil || r.Flag != types.Flag_VALID {
+		return r, err
+	}
+
+	return v.validateLegalHolds(tx)
+}
+
+// validatePrivilege checks whether the submitting user is an admin or, otherwise, holds the
+// db administration privilege on every database touched by the transaction
+func (v *dbAdminTxValidator) validatePrivilege(tx *types.DBAdministrationTx) (*types.ValidationInfo, error) {
+	user, _, err := v.identityQuerier.GetUser(tx.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.GetPrivilege().GetAdmin() {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}, nil
+	}
+
+	if len(user.GetPrivilege().GetDbAdministrationPrefixes()) == 0 {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_NO_PERMISSION,
+			ReasonIfInvalid: "the user [" + tx.UserId + "] has no privilege to perform database administrative operations",
+		}, nil
+	}
+
+	var dbNames []string
+	dbNames = append(dbNames, tx.CreateDbs...)
+	dbNames = append(dbNames, tx.DeleteDbs...)
+	var indexDBNames []string
+	for dbName := range tx.DbsIndex {
+		indexDBNames = append(indexDBNames, dbName)
+	}
+	sort.Strings(indexDBNames)
+	dbNames = append(dbNames, indexDBNames...)
+	for _, k := range tx.EraseKeys {
+		dbNames = append(dbNames, k.GetDbName())
+	}
+	for _, h := range append(tx.PlaceLegalHolds, tx.ReleaseLegalHolds...) {
+		dbNames = append(dbNames, h.GetDbName())
+	}
+	var constrainedDBNames []string
+	for dbName := range tx.DbsConstraints {
+		constrainedDBNames = append(constrainedDBNames, dbName)
+	}
+	sort.Strings(constrainedDBNames)
+	dbNames = append(dbNames, constrainedDBNames...)
+	for _, f := range tx.FreezeDbs {
+		dbNames = append(dbNames, f.GetDbName())
+	}
+	dbNames = append(dbNames, tx.ThawDbs...)
+	dbNames = append(dbNames, tx.GetMigration().GetBackfillIndexDbs()...)
+
+	for _, dbName := range dbNames {
+		hasPerm, err := v.identityQuerier.HasDBAdministrationPrivilege(tx.UserId, dbName)
+		if err != nil {
+			return nil, err
+		}
+		if !hasPerm {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: "the user [" + tx.UserId + "] has no privilege to perform database administrative operations on the database [" + dbName + "]",
+			}, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
 }
 
 func (v *dbAdminTxValidator) validateCreateDBEntries(toCreateDBs []string) *types.ValidationInfo {
@@ -198,3 +302,699 @@ func (v *dbAdminTxValidator) validateIndexEntries(dbsIndex map[string]*types.DBI
 		Flag: types.Flag_VALID,
 	}
 }
+
+// validateEraseEntries checks that every key to be erased belongs to an existing erasable database, is erased once,
+// and does not exist, i.e., it was deleted, so that its current value is not kept in the state database
+func (v *dbAdminTxValidator) validateEraseEntries(eraseKeys []*types.KeyErasure, toDeleteDBs []string) (*types.ValidationInfo, error) {
+	if len(eraseKeys) == 0 {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}, nil
+	}
+
+	config, _, err := v.db.GetConfig()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while fetching the cluster configuration")
+	}
+	erasableDBs := make(map[string]bool)
+	for _, dbName := range config.GetErasableDbs() {
+		erasableDBs[dbName] = true
+	}
+	toDeleteDBsLookup := make(map[string]bool)
+	for _, dbName := range toDeleteDBs {
+		toDeleteDBsLookup[dbName] = true
+	}
+
+	erased := make(map[string]map[string]bool)
+	for _, k := range eraseKeys {
+		switch {
+		case k == nil:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the erase list",
+			}, nil
+
+		case !erasableDBs[k.DbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + k.DbName + "] is not erasable and hence, its keys cannot be erased",
+			}, nil
+
+		case !v.db.Exist(k.DbName) || toDeleteDBsLookup[k.DbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + k.DbName + "] does not exist in the cluster or is deleted, and hence, its keys cannot be erased",
+			}, nil
+
+		case k.Key == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key to be erased from the database [" + k.DbName + "] cannot be empty",
+			}, nil
+
+		case erased[k.DbName][k.Key]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [" + k.Key + "] of the database [" + k.DbName + "] is duplicated in the erase list",
+			}, nil
+		}
+
+		val, metadata, err := v.db.Get(k.DbName, k.Key)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error while validating erase entries")
+		}
+		if val != nil || metadata != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [" + k.Key + "] exists in the database [" + k.DbName + "] and hence, it must be deleted before it is erased",
+			}, nil
+		}
+
+		if erased[k.DbName] == nil {
+			erased[k.DbName] = make(map[string]bool)
+		}
+		erased[k.DbName][k.Key] = true
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateConstraintEntries checks that the constraints are set on existing or created data databases that are not
+// deleted, and that every reference constraint names an attribute once and refers to an existing or created data
+// database that is not deleted. The schema and the default access control of a database are checked as well. As a
+// deleted database would leave the documents that refer to it dangling, a database
+// that is referred to by the committed constraints of another database cannot be deleted, unless the other database
+// is deleted as well or its constraints are replaced.
+func (v *dbAdminTxValidator) validateConstraintEntries(dbsConstraints map[string]*types.DBConstraints, toCreateDBs, toDeleteDBs []string) (*types.ValidationInfo, error) {
+	toCreateDBsLookup := make(map[string]bool)
+	for _, dbName := range toCreateDBs {
+		toCreateDBsLookup[dbName] = true
+	}
+	toDeleteDBsLookup := make(map[string]bool)
+	for _, dbName := range toDeleteDBs {
+		toDeleteDBsLookup[dbName] = true
+	}
+	isDataDB := func(dbName string) bool {
+		return !worldstate.IsSystemDB(dbName) && !stateindex.IsIndexDB(dbName) &&
+			(v.db.Exist(dbName) || toCreateDBsLookup[dbName]) && !toDeleteDBsLookup[dbName]
+	}
+
+	var dbNames []string
+	for dbName := range dbsConstraints {
+		dbNames = append(dbNames, dbName)
+	}
+	sort.Strings(dbNames)
+
+	for _, dbName := range dbNames {
+		if !isDataDB(dbName) {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "constraints provided for database [" + dbName + "] cannot be processed as the database is a system database, or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			}, nil
+		}
+
+		attributes := make(map[string]bool)
+		for _, r := range dbsConstraints[dbName].GetReferences() {
+			switch {
+			case r == nil:
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "there is an empty reference constraint for database [" + dbName + "]",
+				}, nil
+
+			case r.Attribute == "":
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the attribute of a reference constraint for database [" + dbName + "] cannot be empty",
+				}, nil
+
+			case attributes[r.Attribute]:
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the attribute [" + r.Attribute + "] has more than one reference constraint for database [" + dbName + "]",
+				}, nil
+
+			case !isDataDB(r.ReferencedDb):
+				return &types.ValidationInfo{
+					Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+					ReasonIfInvalid: "the attribute [" + r.Attribute + "] of database [" + dbName + "] cannot refer to database [" + r.ReferencedDb +
+						"] as it is a system database, or it neither exists nor is in the create DB list, or it is in the delete DB list",
+				}, nil
+			}
+			attributes[r.Attribute] = true
+		}
+
+		if r := validateSchema(dbName, dbsConstraints[dbName].GetSchema()); r.Flag != types.Flag_VALID {
+			return r, nil
+		}
+
+		r, err := v.validateDefaultACL(dbName, dbsConstraints[dbName].GetDefaultAcl())
+		if err != nil || r.Flag != types.Flag_VALID {
+			return r, err
+		}
+	}
+
+	for _, dbName := range toDeleteDBs {
+		referencing, err := constraints.ReferencingDBs(v.db, dbName)
+		if err != nil {
+			return nil, err
+		}
+
+		var referencingDBNames []string
+		for referencingDB := range referencing {
+			if _, replaced := dbsConstraints[referencingDB]; !replaced && !toDeleteDBsLookup[referencingDB] {
+				referencingDBNames = append(referencingDBNames, referencingDB)
+			}
+		}
+		if len(referencingDBNames) == 0 {
+			continue
+		}
+
+		sort.Strings(referencingDBNames)
+		return &types.ValidationInfo{
+			Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the database [" + dbName + "] is referred to by the attribute [" + referencing[referencingDBNames[0]][0] +
+				"] of database [" + referencingDBNames[0] + "] and hence, it cannot be deleted",
+		}, nil
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateSchema checks that the attributes of a schema are named and have a known type, and that its required
+// attributes are named and listed once
+func validateSchema(dbName string, schema *types.DocumentSchema) *types.ValidationInfo {
+	if schema == nil {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}
+	}
+
+	var attrs []string
+	for attr := range schema.AttributeAndType {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	for _, attr := range attrs {
+		if attr == "" {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the name of an attribute in the schema of database [" + dbName + "] cannot be empty",
+			}
+		}
+
+		switch ty := schema.AttributeAndType[attr]; ty {
+		case types.IndexAttributeType_NUMBER, types.IndexAttributeType_STRING, types.IndexAttributeType_BOOLEAN:
+		default:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "invalid type provided for the attribute [" + attr + "] in the schema of database [" + dbName + "]",
+			}
+		}
+	}
+
+	required := make(map[string]bool)
+	for _, attr := range schema.RequiredAttributes {
+		switch {
+		case attr == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the name of a required attribute in the schema of database [" + dbName + "] cannot be empty",
+			}
+
+		case required[attr]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the attribute [" + attr + "] is required more than once in the schema of database [" + dbName + "]",
+			}
+		}
+		required[attr] = true
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+// validateDefaultACL checks the default access control of a database as the access control of a written key is
+// checked: its sign policy can be met, the redaction policies are valid, and its users exist
+func (v *dbAdminTxValidator) validateDefaultACL(dbName string, acl *types.AccessControl) (*types.ValidationInfo, error) {
+	if acl == nil {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}, nil
+	}
+
+	switch acl.SignPolicyForWrite {
+	case types.AccessControl_ANY, types.AccessControl_ALL:
+		if acl.SignThresholdForWrite != 0 {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the sign threshold for write of the default access control of database [" + dbName + "] can be set only with the " + types.AccessControl_THRESHOLD.String() + " sign policy",
+			}, nil
+		}
+	case types.AccessControl_THRESHOLD:
+		if acl.SignThresholdForWrite == 0 || int(acl.SignThresholdForWrite) > len(acl.ReadWriteUsers) {
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("the sign threshold for write of the default access control of database [%s] must be between 1 and the number of read-write users [%d], but it is [%d]",
+					dbName, len(acl.ReadWriteUsers), acl.SignThresholdForWrite),
+			}, nil
+		}
+	default:
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the sign policy for write [" + acl.SignPolicyForWrite.String() + "] of the default access control of database [" + dbName + "] is unknown",
+		}, nil
+	}
+
+	users := make(map[string]struct{})
+	for user := range acl.ReadUsers {
+		users[user] = struct{}{}
+	}
+	for user := range acl.ReadWriteUsers {
+		users[user] = struct{}{}
+	}
+	for user := range acl.RedactedReadUsers {
+		users[user] = struct{}{}
+	}
+
+	sortedUsers := make([]string, 0, len(users))
+	for user := range users {
+		sortedUsers = append(sortedUsers, user)
+	}
+	sort.Strings(sortedUsers)
+
+	for _, user := range sortedUsers {
+		policy, ok := acl.RedactedReadUsers[user]
+		if !ok {
+			continue
+		}
+		if err := redaction.ValidatePolicy(policy); err != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the redaction policy of the user [" + user + "] in the default access control of database [" + dbName + "] is invalid: " + err.Error(),
+			}, nil
+		}
+	}
+
+	for _, user := range sortedUsers {
+		exist, err := v.identityQuerier.DoesUserExist(user)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "error while validating the default access control of database [%s]", dbName)
+		}
+		if !exist {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [" + user + "] defined in the default access control of database [" + dbName + "] does not exist",
+			}, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateLegalHoldEntries checks that every legal hold to be placed refers to an existing data database that is not
+// deleted, and has a reason, and that every legal hold to be released is placed. A hold is placed or released once.
+func (v *dbAdminTxValidator) validateLegalHoldEntries(toPlace, toRelease []*types.LegalHold, toDeleteDBs []string) *types.ValidationInfo {
+	toDeleteDBsLookup := make(map[string]bool)
+	for _, dbName := range toDeleteDBs {
+		toDeleteDBsLookup[dbName] = true
+	}
+
+	placed := make(map[string]bool)
+	for _, h := range toPlace {
+		switch {
+		case h == nil:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the legal hold place list",
+			}
+
+		case worldstate.IsSystemDB(h.DbName) || stateindex.IsIndexDB(h.DbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + h.DbName + "] is a system database and hence, no legal hold can be placed on it",
+			}
+
+		case !v.db.Exist(h.DbName) || toDeleteDBsLookup[h.DbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + h.DbName + "] does not exist in the cluster or is deleted, and hence, no legal hold can be placed on it",
+			}
+
+		case h.Reason == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on " + legalhold.Target(h) + " has no reason",
+			}
+
+		case placed[legalhold.Key(h.DbName, h.Key)]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on " + legalhold.Target(h) + " is duplicated in the place list",
+			}
+		}
+		placed[legalhold.Key(h.DbName, h.Key)] = true
+	}
+
+	released := make(map[string]bool)
+	for _, h := range toRelease {
+		switch {
+		case h == nil:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the legal hold release list",
+			}
+
+		case placed[legalhold.Key(h.DbName, h.Key)]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on " + legalhold.Target(h) + " is both placed and released",
+			}
+
+		case released[legalhold.Key(h.DbName, h.Key)]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on " + legalhold.Target(h) + " is duplicated in the release list",
+			}
+		}
+		released[legalhold.Key(h.DbName, h.Key)] = true
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+// validateLegalHolds checks the transaction against the committed legal holds: a hold to be placed must not be
+// placed already, a hold to be released must be placed, and no key erased or database deleted by the transaction may
+// be under a legal hold once the holds of the transaction are placed and released
+func (v *dbAdminTxValidator) validateLegalHolds(tx *types.DBAdministrationTx) (*types.ValidationInfo, error) {
+	placed := make(map[string]bool)
+	for _, h := range tx.PlaceLegalHolds {
+		hold, err := legalhold.Get(v.db, h.DbName, h.Key)
+		if err != nil {
+			return nil, err
+		}
+		if hold != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: legalhold.Target(h) + " is already under a legal hold",
+			}, nil
+		}
+		placed[legalhold.Key(h.DbName, h.Key)] = true
+	}
+
+	released := make(map[string]bool)
+	for _, h := range tx.ReleaseLegalHolds {
+		hold, err := legalhold.Get(v.db, h.DbName, h.Key)
+		if err != nil {
+			return nil, err
+		}
+		if hold == nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: legalhold.Target(h) + " is not under a legal hold",
+			}, nil
+		}
+		released[legalhold.Key(h.DbName, h.Key)] = true
+	}
+
+	for _, k := range tx.EraseKeys {
+		for _, holdKey := range []string{k.Key, ""} {
+			held := placed[legalhold.Key(k.DbName, holdKey)]
+			if !held && !released[legalhold.Key(k.DbName, holdKey)] {
+				hold, err := legalhold.Get(v.db, k.DbName, holdKey)
+				if err != nil {
+					return nil, err
+				}
+				held = hold != nil
+			}
+			if held {
+				v.logger.Infof("legal hold audit: user [%s] attempted to erase key [%s] of database [%s] under a legal hold in transaction [%s]", tx.UserId, v.logger.MaskKey(k.Key), k.DbName, tx.TxId)
+				return &types.ValidationInfo{
+					Flag:            types.Flag_INVALID_LEGAL_HOLD,
+					ReasonIfInvalid: "the key [" + k.Key + "] of the database [" + k.DbName + "] is under a legal hold and hence, it cannot be erased",
+				}, nil
+			}
+		}
+	}
+
+	for _, dbName := range tx.DeleteDbs {
+		holds, err := legalhold.DBHolds(v.db, dbName)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range holds {
+			if released[legalhold.Key(h.DbName, h.Key)] {
+				continue
+			}
+
+			v.logger.Infof("legal hold audit: user [%s] attempted to delete database [%s] while %s is under a legal hold in transaction [%s]", tx.UserId, dbName, legalhold.LogTarget(v.logger, h), tx.TxId)
+			reason := "the database [" + dbName + "] is under a legal hold and hence, it cannot be deleted"
+			if h.Key != "" {
+				reason = "the database [" + dbName + "] holds the key [" + h.Key + "] under a legal hold and hence, it cannot be deleted"
+			}
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: reason,
+			}, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateFreezeEntries checks that every database to be frozen is an existing data database that is not deleted, and
+// has a reason. A database is frozen or thawed once.
+func (v *dbAdminTxValidator) validateFreezeEntries(toFreeze []*types.DatabaseFreeze, toThaw, toDeleteDBs []string) *types.ValidationInfo {
+	toDeleteDBsLookup := make(map[string]bool)
+	for _, dbName := range toDeleteDBs {
+		toDeleteDBsLookup[dbName] = true
+	}
+
+	frozen := make(map[string]bool)
+	for _, f := range toFreeze {
+		switch {
+		case f == nil:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the freeze DB list",
+			}
+
+		case worldstate.IsSystemDB(f.DbName) || stateindex.IsIndexDB(f.DbName):
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + f.DbName + "] is a system database and hence, it cannot be frozen",
+			}
+
+		case !v.db.Exist(f.DbName) || toDeleteDBsLookup[f.DbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + f.DbName + "] does not exist in the cluster or is deleted, and hence, it cannot be frozen",
+			}
+
+		case f.Reason == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the freeze of the database [" + f.DbName + "] has no reason",
+			}
+
+		case frozen[f.DbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + f.DbName + "] is duplicated in the freeze DB list",
+			}
+		}
+		frozen[f.DbName] = true
+	}
+
+	thawed := make(map[string]bool)
+	for _, dbName := range toThaw {
+		switch {
+		case frozen[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + dbName + "] is both frozen and thawed",
+			}
+
+		case thawed[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + dbName + "] is duplicated in the thaw DB list",
+			}
+		}
+		thawed[dbName] = true
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
+// validateFreezes checks the transaction against the committed freezes: a database to be frozen must not be frozen
+// already, a database to be thawed must be frozen, and no database deleted by the transaction may be frozen, unless
+// it is thawed by the transaction
+func (v *dbAdminTxValidator) validateFreezes(tx *types.DBAdministrationTx) (*types.ValidationInfo, error) {
+	for _, f := range tx.FreezeDbs {
+		freeze, err := dbfreeze.Get(v.db, f.DbName)
+		if err != nil {
+			return nil, err
+		}
+		if freeze != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + f.DbName + "] is already frozen",
+			}, nil
+		}
+	}
+
+	thawed := make(map[string]bool)
+	for _, dbName := range tx.ThawDbs {
+		freeze, err := dbfreeze.Get(v.db, dbName)
+		if err != nil {
+			return nil, err
+		}
+		if freeze == nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + dbName + "] is not frozen",
+			}, nil
+		}
+		thawed[dbName] = true
+	}
+
+	for _, dbName := range tx.DeleteDbs {
+		if thawed[dbName] {
+			continue
+		}
+
+		freeze, err := dbfreeze.Get(v.db, dbName)
+		if err != nil {
+			return nil, err
+		}
+		if freeze != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DATABASE_FROZEN,
+				ReasonIfInvalid: "the database [" + dbName + "] is frozen and hence, it cannot be deleted",
+			}, nil
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// validateMigration checks that the migration carried by the transaction, if any, is named, and that its version
+// follows the applied version of the migration when it is applied, or is the applied version when it is reverted, so
+// that the versions of a migration are applied and reverted in order. Every database whose stored values are indexed
+// must be a data database that exists or is created, is not deleted, has no index, and whose index is defined by the
+// transaction.
+func (v *dbAdminTxValidator) validateMigration(tx *types.DBAdministrationTx) (*types.ValidationInfo, error) {
+	m := tx.GetMigration()
+	if m == nil {
+		return &types.ValidationInfo{
+			Flag: types.Flag_VALID,
+		}, nil
+	}
+
+	if m.Name == "" {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the name of the migration cannot be empty",
+		}, nil
+	}
+
+	if _, ok := types.Migration_Direction_name[int32(m.Direction)]; !ok {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the direction [" + m.Direction.String() + "] of the migration [" + m.Name + "] is unknown",
+		}, nil
+	}
+
+	state, err := migration.Get(v.db, m.Name)
+	if err != nil {
+		return nil, err
+	}
+	expected := migration.NextVersion(state, m.Direction)
+
+	switch {
+	case m.Direction == types.Migration_DOWN && expected == 0:
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the migration [" + m.Name + "] has no applied version to revert",
+		}, nil
+
+	case m.Direction == types.Migration_DOWN && m.Version != expected:
+		return &types.ValidationInfo{
+			Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("the version [%d] of the migration [%s] cannot be reverted as the applied version is [%d]",
+				m.Version, m.Name, expected),
+		}, nil
+
+	case m.Direction == types.Migration_UP && m.Version != expected:
+		return &types.ValidationInfo{
+			Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: fmt.Sprintf("the version [%d] of the migration [%s] cannot be applied as the applied version is [%d], hence the next version is [%d]",
+				m.Version, m.Name, expected-1, expected),
+		}, nil
+	}
+
+	toCreateDBsLookup := make(map[string]bool)
+	for _, dbName := range tx.CreateDbs {
+		toCreateDBsLookup[dbName] = true
+	}
+	toDeleteDBsLookup := make(map[string]bool)
+	for _, dbName := range tx.DeleteDbs {
+		toDeleteDBsLookup[dbName] = true
+	}
+
+	backfilled := make(map[string]bool)
+	for _, dbName := range m.BackfillIndexDbs {
+		switch {
+		case backfilled[dbName]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [" + dbName + "] are indexed more than once by the migration [" + m.Name + "]",
+			}, nil
+
+		case worldstate.IsSystemDB(dbName) || stateindex.IsIndexDB(dbName) ||
+			!(v.db.Exist(dbName) || toCreateDBsLookup[dbName]) || toDeleteDBsLookup[dbName]:
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [" + dbName + "] cannot be indexed by the migration [" + m.Name +
+					"] as the database is a system database, or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			}, nil
+
+		case len(tx.DbsIndex[dbName].GetAttributeAndType()) == 0:
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [" + dbName + "] cannot be indexed by the migration [" + m.Name +
+					"] as the transaction does not define the index of the database",
+			}, nil
+
+		case v.db.Exist(stateindex.IndexDB(dbName)):
+			return &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [" + dbName + "] cannot be indexed by the migration [" + m.Name +
+					"] as the database already has an index, which must be removed by an earlier transaction",
+			}, nil
+		}
+		backfilled[dbName] = true
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
@@ -5,9 +5,15 @@ package txvalidation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/constraints"
+	"github.com/hyperledger-labs/orion-server/internal/dbfreeze"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
+	"github.com/hyperledger-labs/orion-server/internal/legalhold"
+	"github.com/hyperledger-labs/orion-server/internal/migration"
+	"github.com/hyperledger-labs/orion-server/internal/stateindex"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -69,6 +75,28 @@ func TestValidateDBAdminTx(t *testing.T) {
 		},
 	}
 
+	userWithDBAdminPrivilege := &types.User{
+		Id:          "userWithDBAdminPrivilege",
+		Certificate: nonAdminCert.Raw,
+		Privilege: &types.Privilege{
+			DbAdministrationPrefixes: []string{"team1"},
+		},
+	}
+	userWithDBAdminPrivilegeSerialized, err := proto.Marshal(userWithDBAdminPrivilege)
+	require.NoError(t, err)
+
+	dbAdminUser := map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:      string(identity.UserNamespace) + "userWithDBAdminPrivilege",
+					Value:    userWithDBAdminPrivilegeSerialized,
+					Metadata: sampleMetadataData,
+				},
+			},
+		},
+	}
+
 	tests := []struct {
 		name           string
 		setup          func(db worldstate.DB)
@@ -105,6 +133,75 @@ func TestValidateDBAdminTx(t *testing.T) {
 				ReasonIfInvalid: "the user [userWithLessPrivilege] has no privilege to perform database administrative operations",
 			},
 		},
+		{
+			name: "invalid: user with db admin privilege creates a database outside its prefixes",
+			setup: func(db worldstate.DB) {
+				require.NoError(t, db.Commit(dbAdminUser, 1))
+			},
+			txEnv: testutils.SignedDBAdministrationTxEnvelope(t, nonAdminSigner,
+				&types.DBAdministrationTx{
+					UserId:    "userWithDBAdminPrivilege",
+					CreateDbs: []string{"team1/db1", "team10/db1"},
+				}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: "the user [userWithDBAdminPrivilege] has no privilege to perform database administrative operations on the database [team10/db1]",
+			},
+		},
+		{
+			name: "invalid: user with db admin privilege indexes a database outside its prefixes",
+			setup: func(db worldstate.DB) {
+				require.NoError(t, db.Commit(dbAdminUser, 1))
+			},
+			txEnv: testutils.SignedDBAdministrationTxEnvelope(t, nonAdminSigner,
+				&types.DBAdministrationTx{
+					UserId: "userWithDBAdminPrivilege",
+					DbsIndex: map[string]*types.DBIndex{
+						"bdb": {
+							AttributeAndType: map[string]types.IndexAttributeType{
+								"attr1": types.IndexAttributeType_STRING,
+							},
+						},
+					},
+				}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: "the user [userWithDBAdminPrivilege] has no privilege to perform database administrative operations on the database [bdb]",
+			},
+		},
+		{
+			name: "valid: user with db admin privilege manages databases under its prefixes",
+			setup: func(db worldstate.DB) {
+				require.NoError(t, db.Commit(dbAdminUser, 1))
+
+				createDB := map[string]*worldstate.DBUpdates{
+					worldstate.DatabasesDBName: {
+						Writes: []*worldstate.KVWithMetadata{
+							{
+								Key: "team1/db3",
+							},
+						},
+					},
+				}
+				require.NoError(t, db.Commit(createDB, 1))
+			},
+			txEnv: testutils.SignedDBAdministrationTxEnvelope(t, nonAdminSigner,
+				&types.DBAdministrationTx{
+					UserId:    "userWithDBAdminPrivilege",
+					CreateDbs: []string{"team1/db1"},
+					DeleteDbs: []string{"team1/db3"},
+					DbsIndex: map[string]*types.DBIndex{
+						"team1/db1": {
+							AttributeAndType: map[string]types.IndexAttributeType{
+								"attr1": types.IndexAttributeType_STRING,
+							},
+						},
+					},
+				}),
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
 		{
 			name: "invalid: createDBs list has duplicate",
 			setup: func(db worldstate.DB) {
@@ -142,11 +239,11 @@ func TestValidateDBAdminTx(t *testing.T) {
 			txEnv: testutils.SignedDBAdministrationTxEnvelope(t, adminSigner,
 				&types.DBAdministrationTx{
 					UserId:    "userWithMorePrivilege",
-					CreateDbs: []string{"db1", "db1/abc"},
+					CreateDbs: []string{"db1", "db1//abc"},
 				}),
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-				ReasonIfInvalid: "the database name [db1/abc] is not valid",
+				ReasonIfInvalid: "the database name [db1//abc] is not valid",
 			},
 		},
 		{
@@ -156,11 +253,11 @@ func TestValidateDBAdminTx(t *testing.T) {
 			},
 			txEnv: testutils.SignedDBAdministrationTxEnvelope(t, adminSigner, &types.DBAdministrationTx{
 				UserId:    "userWithMorePrivilege",
-				DeleteDbs: []string{"db1/abc/def", "db1"},
+				DeleteDbs: []string{"db1/../def", "db1"},
 			}),
 			expectedResult: &types.ValidationInfo{
 				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
-				ReasonIfInvalid: "the database name [db1/abc/def] is not valid",
+				ReasonIfInvalid: "the database name [db1/../def] is not valid",
 			},
 		},
 		{
@@ -230,7 +327,7 @@ func TestValidateDBAdminTx(t *testing.T) {
 
 			tt.setup(env.db)
 
-			result, err := env.validator.dbAdminTxValidator.validate(tt.txEnv)
+			result, err := env.validator.dbAdminTxValidator.validate(tt.txEnv, time.Now().UnixNano())
 			require.NoError(t, err)
 			require.Equal(t, tt.expectedResult, result)
 		})
@@ -532,3 +629,1048 @@ func TestValidateIndexDBEntries(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEraseEntries(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		config, err := proto.Marshal(&types.ClusterConfig{ErasableDbs: []string{"db1", "db3"}})
+		require.NoError(t, err)
+
+		updates := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key: "db1",
+					},
+					{
+						Key: "db2",
+					},
+				},
+			},
+			worldstate.ConfigDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   worldstate.ConfigKey,
+						Value: config,
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(updates, 1))
+
+		data := map[string]*worldstate.DBUpdates{
+			"db1": {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key:   "key1",
+						Value: []byte("value1"),
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(data, 2))
+	}
+
+	tests := []struct {
+		name           string
+		eraseKeys      []*types.KeyErasure
+		toDeleteDBs    []string
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:      "valid",
+			eraseKeys: []*types.KeyErasure{{DbName: "db1", Key: "key2"}, {DbName: "db1", Key: "key3"}},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:      "invalid: empty entry",
+			eraseKeys: []*types.KeyErasure{{DbName: "db1", Key: "key2"}, nil},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the erase list",
+			},
+		},
+		{
+			name:      "invalid: db is not erasable",
+			eraseKeys: []*types.KeyErasure{{DbName: "db2", Key: "key2"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db2] is not erasable and hence, its keys cannot be erased",
+			},
+		},
+		{
+			name:      "invalid: db does not exist",
+			eraseKeys: []*types.KeyErasure{{DbName: "db3", Key: "key2"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db3] does not exist in the cluster or is deleted, and hence, its keys cannot be erased",
+			},
+		},
+		{
+			name:        "invalid: db is deleted",
+			eraseKeys:   []*types.KeyErasure{{DbName: "db1", Key: "key2"}},
+			toDeleteDBs: []string{"db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db1] does not exist in the cluster or is deleted, and hence, its keys cannot be erased",
+			},
+		},
+		{
+			name:      "invalid: empty key",
+			eraseKeys: []*types.KeyErasure{{DbName: "db1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key to be erased from the database [db1] cannot be empty",
+			},
+		},
+		{
+			name:      "invalid: duplicate key",
+			eraseKeys: []*types.KeyErasure{{DbName: "db1", Key: "key2"}, {DbName: "db1", Key: "key2"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key2] of the database [db1] is duplicated in the erase list",
+			},
+		},
+		{
+			name:      "invalid: key exists",
+			eraseKeys: []*types.KeyErasure{{DbName: "db1", Key: "key1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] exists in the database [db1] and hence, it must be deleted before it is erased",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			result, err := env.validator.dbAdminTxValidator.validateEraseEntries(tt.eraseKeys, tt.toDeleteDBs)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedResult, result))
+		})
+	}
+}
+
+func TestValidateLegalHoldEntries(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		createDBs := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key: "db1",
+					},
+					{
+						Key: "db2",
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(createDBs, 1))
+	}
+
+	tests := []struct {
+		name           string
+		toPlace        []*types.LegalHold
+		toRelease      []*types.LegalHold
+		toDeleteDBs    []string
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:      "valid",
+			toPlace:   []*types.LegalHold{{DbName: "db1", Reason: "case 1"}, {DbName: "db1", Key: "key1", Reason: "case 2"}},
+			toRelease: []*types.LegalHold{{DbName: "db2"}, {DbName: "db1", Key: "key2"}},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:    "invalid: empty entry in the place list",
+			toPlace: []*types.LegalHold{nil},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the legal hold place list",
+			},
+		},
+		{
+			name:    "invalid: system database",
+			toPlace: []*types.LegalHold{{DbName: worldstate.UsersDBName, Reason: "case 1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + worldstate.UsersDBName + "] is a system database and hence, no legal hold can be placed on it",
+			},
+		},
+		{
+			name:    "invalid: database does not exist",
+			toPlace: []*types.LegalHold{{DbName: "db3", Reason: "case 1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db3] does not exist in the cluster or is deleted, and hence, no legal hold can be placed on it",
+			},
+		},
+		{
+			name:        "invalid: database is deleted",
+			toPlace:     []*types.LegalHold{{DbName: "db1", Key: "key1", Reason: "case 1"}},
+			toDeleteDBs: []string{"db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db1] does not exist in the cluster or is deleted, and hence, no legal hold can be placed on it",
+			},
+		},
+		{
+			name:    "invalid: no reason",
+			toPlace: []*types.LegalHold{{DbName: "db1", Key: "key1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on the key [key1] of the database [db1] has no reason",
+			},
+		},
+		{
+			name:    "invalid: duplicate hold in the place list",
+			toPlace: []*types.LegalHold{{DbName: "db1", Reason: "case 1"}, {DbName: "db1", Reason: "case 2"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on the database [db1] is duplicated in the place list",
+			},
+		},
+		{
+			name:      "invalid: empty entry in the release list",
+			toRelease: []*types.LegalHold{nil},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the legal hold release list",
+			},
+		},
+		{
+			name:      "invalid: hold is both placed and released",
+			toPlace:   []*types.LegalHold{{DbName: "db1", Key: "key1", Reason: "case 1"}},
+			toRelease: []*types.LegalHold{{DbName: "db1", Key: "key1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on the key [key1] of the database [db1] is both placed and released",
+			},
+		},
+		{
+			name:      "invalid: duplicate hold in the release list",
+			toRelease: []*types.LegalHold{{DbName: "db1"}, {DbName: "db1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the legal hold on the database [db1] is duplicated in the release list",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			result := env.validator.dbAdminTxValidator.validateLegalHoldEntries(tt.toPlace, tt.toRelease, tt.toDeleteDBs)
+			require.True(t, proto.Equal(tt.expectedResult, result))
+		})
+	}
+}
+
+func TestValidateLegalHolds(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		holds, err := legalhold.ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{
+			UserId: "admin",
+			PlaceLegalHolds: []*types.LegalHold{
+				{DbName: "db1", Key: "key1", Reason: "case 1"},
+				{DbName: "db2", Reason: "case 2"},
+			},
+		}, &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.LegalHoldsDBName: holds}, 1))
+	}
+
+	tests := []struct {
+		name           string
+		tx             *types.DBAdministrationTx
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid",
+			tx: &types.DBAdministrationTx{
+				PlaceLegalHolds:   []*types.LegalHold{{DbName: "db1", Key: "key2", Reason: "case 3"}},
+				ReleaseLegalHolds: []*types.LegalHold{{DbName: "db2"}},
+				EraseKeys:         []*types.KeyErasure{{DbName: "db1", Key: "key3"}, {DbName: "db2", Key: "key1"}},
+				DeleteDbs:         []string{"db3"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: the legal holds of the deleted database are released",
+			tx: &types.DBAdministrationTx{
+				ReleaseLegalHolds: []*types.LegalHold{{DbName: "db2"}},
+				DeleteDbs:         []string{"db2"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "invalid: hold is already placed",
+			tx: &types.DBAdministrationTx{
+				PlaceLegalHolds: []*types.LegalHold{{DbName: "db1", Key: "key1", Reason: "case 3"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the key [key1] of the database [db1] is already under a legal hold",
+			},
+		},
+		{
+			name: "invalid: hold is not placed",
+			tx: &types.DBAdministrationTx{
+				ReleaseLegalHolds: []*types.LegalHold{{DbName: "db1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db1] is not under a legal hold",
+			},
+		},
+		{
+			name: "invalid: erased key is under a legal hold",
+			tx: &types.DBAdministrationTx{
+				EraseKeys: []*types.KeyErasure{{DbName: "db1", Key: "key1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: "the key [key1] of the database [db1] is under a legal hold and hence, it cannot be erased",
+			},
+		},
+		{
+			name: "invalid: erased key is under a legal hold placed by the transaction",
+			tx: &types.DBAdministrationTx{
+				PlaceLegalHolds: []*types.LegalHold{{DbName: "db1", Reason: "case 3"}},
+				EraseKeys:       []*types.KeyErasure{{DbName: "db1", Key: "key2"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: "the key [key2] of the database [db1] is under a legal hold and hence, it cannot be erased",
+			},
+		},
+		{
+			name: "invalid: database of the erased key is under a legal hold",
+			tx: &types.DBAdministrationTx{
+				EraseKeys: []*types.KeyErasure{{DbName: "db2", Key: "key1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: "the key [key1] of the database [db2] is under a legal hold and hence, it cannot be erased",
+			},
+		},
+		{
+			name: "invalid: deleted database is under a legal hold",
+			tx: &types.DBAdministrationTx{
+				DeleteDbs: []string{"db2"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: "the database [db2] is under a legal hold and hence, it cannot be deleted",
+			},
+		},
+		{
+			name: "invalid: deleted database holds a key under a legal hold",
+			tx: &types.DBAdministrationTx{
+				DeleteDbs: []string{"db1"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_LEGAL_HOLD,
+				ReasonIfInvalid: "the database [db1] holds the key [key1] under a legal hold and hence, it cannot be deleted",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			result, err := env.validator.dbAdminTxValidator.validateLegalHolds(tt.tx)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedResult, result))
+		})
+	}
+}
+
+func TestValidateConstraintEntries(t *testing.T) {
+	t.Parallel()
+
+	references := func(refs ...string) *types.DBConstraints {
+		c := &types.DBConstraints{}
+		for i := 0; i < len(refs); i += 2 {
+			c.References = append(c.References, &types.ReferenceConstraint{Attribute: refs[i], ReferencedDb: refs[i+1]})
+		}
+		return c
+	}
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		updates, err := constraints.ConstructDBEntriesForDBAdminTx(db, &types.DBAdministrationTx{
+			UserId: "admin",
+			DbsConstraints: map[string]*types.DBConstraints{
+				"orders": references("customer", "customers"),
+			},
+		}, &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+		alice, err := proto.Marshal(&types.User{Id: "alice"})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "orders"}, {Key: "customers"}, {Key: "products"}},
+			},
+			worldstate.ConstraintsDBName: updates,
+			worldstate.UsersDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: string(identity.UserNamespace) + "alice", Value: alice}},
+			},
+		}, 1))
+	}
+
+	tests := []struct {
+		name           string
+		dbsConstraints map[string]*types.DBConstraints
+		toCreateDBs    []string
+		toDeleteDBs    []string
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders":   references("customer", "customers", "product", "products"),
+				"invoices": references("order", "orders"),
+				"products": {},
+			},
+			toCreateDBs: []string{"invoices"},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:        "valid: the referring database is deleted",
+			toDeleteDBs: []string{"customers", "orders"},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: the constraints of the referring database are removed",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": {},
+			},
+			toDeleteDBs: []string{"customers"},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: schema and default access control of a created database",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"invoices": {
+					References: references("order", "orders").References,
+					Schema: &types.DocumentSchema{
+						AttributeAndType:   map[string]types.IndexAttributeType{"order": types.IndexAttributeType_STRING, "amount": types.IndexAttributeType_NUMBER},
+						RequiredAttributes: []string{"order", "amount"},
+					},
+					DefaultAcl: &types.AccessControl{
+						ReadWriteUsers:        map[string]bool{"alice": true},
+						SignPolicyForWrite:    types.AccessControl_THRESHOLD,
+						SignThresholdForWrite: 1,
+					},
+				},
+			},
+			toCreateDBs: []string{"invoices"},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "invalid: empty attribute in the schema",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": {Schema: &types.DocumentSchema{AttributeAndType: map[string]types.IndexAttributeType{"": types.IndexAttributeType_STRING}}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the name of an attribute in the schema of database [orders] cannot be empty",
+			},
+		},
+		{
+			name: "invalid: unknown type in the schema",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": {Schema: &types.DocumentSchema{AttributeAndType: map[string]types.IndexAttributeType{"amount": 10}}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "invalid type provided for the attribute [amount] in the schema of database [orders]",
+			},
+		},
+		{
+			name: "invalid: attribute required twice in the schema",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": {Schema: &types.DocumentSchema{RequiredAttributes: []string{"customer", "customer"}}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the attribute [customer] is required more than once in the schema of database [orders]",
+			},
+		},
+		{
+			name: "invalid: sign threshold of the default access control cannot be met",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": {
+					DefaultAcl: &types.AccessControl{
+						ReadWriteUsers:        map[string]bool{"alice": true},
+						SignPolicyForWrite:    types.AccessControl_THRESHOLD,
+						SignThresholdForWrite: 2,
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the sign threshold for write of the default access control of database [orders] must be between 1 and the number of read-write users [1], but it is [2]",
+			},
+		},
+		{
+			name: "invalid: invalid redaction policy in the default access control",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": {
+					DefaultAcl: &types.AccessControl{
+						RedactedReadUsers: map[string]*types.RedactionPolicy{"alice": {StrippedFields: []string{"a..b"}}},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the redaction policy of the user [alice] in the default access control of database [orders] is invalid: the field [a..b] has an empty member name",
+			},
+		},
+		{
+			name: "invalid: user of the default access control does not exist",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": {
+					DefaultAcl: &types.AccessControl{
+						ReadUsers:      map[string]bool{"bob": true},
+						ReadWriteUsers: map[string]bool{"alice": true},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [bob] defined in the default access control of database [orders] does not exist",
+			},
+		},
+		{
+			name: "invalid: the constrained database does not exist",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"invoices": references("order", "orders"),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "constraints provided for database [invoices] cannot be processed as the database is a system database, or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			},
+		},
+		{
+			name: "invalid: the constrained database is a system database",
+			dbsConstraints: map[string]*types.DBConstraints{
+				worldstate.UsersDBName: references("order", "orders"),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "constraints provided for database [_users] cannot be processed as the database is a system database, or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			},
+		},
+		{
+			name: "invalid: empty attribute",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": references("", "customers"),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the attribute of a reference constraint for database [orders] cannot be empty",
+			},
+		},
+		{
+			name: "invalid: duplicated attribute",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": references("customer", "customers", "customer", "products"),
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the attribute [customer] has more than one reference constraint for database [orders]",
+			},
+		},
+		{
+			name: "invalid: the referenced database is deleted",
+			dbsConstraints: map[string]*types.DBConstraints{
+				"orders": references("product", "products"),
+			},
+			toDeleteDBs: []string{"products"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the attribute [product] of database [orders] cannot refer to database [products] as it is a system database, or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			},
+		},
+		{
+			name:        "invalid: the deleted database is referred to",
+			toDeleteDBs: []string{"customers"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [customers] is referred to by the attribute [customer] of database [orders] and hence, it cannot be deleted",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			result, err := env.validator.dbAdminTxValidator.validateConstraintEntries(tt.dbsConstraints, tt.toCreateDBs, tt.toDeleteDBs)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedResult, result))
+		})
+	}
+}
+
+func TestValidateFreezeEntries(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		createDBs := map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{
+					{
+						Key: "db1",
+					},
+					{
+						Key: "db2",
+					},
+				},
+			},
+		}
+		require.NoError(t, db.Commit(createDBs, 1))
+	}
+
+	tests := []struct {
+		name           string
+		toFreeze       []*types.DatabaseFreeze
+		toThaw         []string
+		toDeleteDBs    []string
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:     "valid",
+			toFreeze: []*types.DatabaseFreeze{{DbName: "db1", Reason: "cutover"}},
+			toThaw:   []string{"db2"},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:     "invalid: empty entry in the freeze list",
+			toFreeze: []*types.DatabaseFreeze{nil},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "there is an empty entry in the freeze DB list",
+			},
+		},
+		{
+			name:     "invalid: system database",
+			toFreeze: []*types.DatabaseFreeze{{DbName: worldstate.UsersDBName, Reason: "cutover"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [" + worldstate.UsersDBName + "] is a system database and hence, it cannot be frozen",
+			},
+		},
+		{
+			name:     "invalid: database does not exist",
+			toFreeze: []*types.DatabaseFreeze{{DbName: "db3", Reason: "cutover"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db3] does not exist in the cluster or is deleted, and hence, it cannot be frozen",
+			},
+		},
+		{
+			name:        "invalid: database is deleted",
+			toFreeze:    []*types.DatabaseFreeze{{DbName: "db1", Reason: "cutover"}},
+			toDeleteDBs: []string{"db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db1] does not exist in the cluster or is deleted, and hence, it cannot be frozen",
+			},
+		},
+		{
+			name:     "invalid: no reason",
+			toFreeze: []*types.DatabaseFreeze{{DbName: "db1"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the freeze of the database [db1] has no reason",
+			},
+		},
+		{
+			name:     "invalid: duplicate database in the freeze list",
+			toFreeze: []*types.DatabaseFreeze{{DbName: "db1", Reason: "cutover"}, {DbName: "db1", Reason: "investigation"}},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db1] is duplicated in the freeze DB list",
+			},
+		},
+		{
+			name:     "invalid: database is both frozen and thawed",
+			toFreeze: []*types.DatabaseFreeze{{DbName: "db1", Reason: "cutover"}},
+			toThaw:   []string{"db1"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db1] is both frozen and thawed",
+			},
+		},
+		{
+			name:   "invalid: duplicate database in the thaw list",
+			toThaw: []string{"db2", "db2"},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db2] is duplicated in the thaw DB list",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			result := env.validator.dbAdminTxValidator.validateFreezeEntries(tt.toFreeze, tt.toThaw, tt.toDeleteDBs)
+			require.True(t, proto.Equal(tt.expectedResult, result))
+		})
+	}
+}
+
+func TestValidateFreezes(t *testing.T) {
+	t.Parallel()
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		freezes, err := dbfreeze.ConstructDBEntriesForDBAdminTx(&types.DBAdministrationTx{
+			UserId:    "admin",
+			FreezeDbs: []*types.DatabaseFreeze{{DbName: "db1", Reason: "cutover"}},
+		}, &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{worldstate.FrozenDBsDBName: freezes}, 1))
+	}
+
+	tests := []struct {
+		name           string
+		tx             *types.DBAdministrationTx
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid",
+			tx: &types.DBAdministrationTx{
+				FreezeDbs: []*types.DatabaseFreeze{{DbName: "db2", Reason: "investigation"}},
+				ThawDbs:   []string{"db1"},
+				DeleteDbs: []string{"db3"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: the deleted database is thawed",
+			tx: &types.DBAdministrationTx{
+				ThawDbs:   []string{"db1"},
+				DeleteDbs: []string{"db1"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "invalid: database is already frozen",
+			tx: &types.DBAdministrationTx{
+				FreezeDbs: []*types.DatabaseFreeze{{DbName: "db1", Reason: "investigation"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db1] is already frozen",
+			},
+		},
+		{
+			name: "invalid: database is not frozen",
+			tx: &types.DBAdministrationTx{
+				ThawDbs: []string{"db2"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the database [db2] is not frozen",
+			},
+		},
+		{
+			name: "invalid: deleted database is frozen",
+			tx: &types.DBAdministrationTx{
+				DeleteDbs: []string{"db1"},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_DATABASE_FROZEN,
+				ReasonIfInvalid: "the database [db1] is frozen and hence, it cannot be deleted",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			result, err := env.validator.dbAdminTxValidator.validateFreezes(tt.tx)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedResult, result))
+		})
+	}
+}
+
+func TestValidateMigration(t *testing.T) {
+	t.Parallel()
+
+	index := map[string]*types.DBIndex{
+		"db1": {AttributeAndType: map[string]types.IndexAttributeType{"a1": types.IndexAttributeType_STRING}},
+		"db2": {AttributeAndType: map[string]types.IndexAttributeType{"a1": types.IndexAttributeType_STRING}},
+		"db3": {AttributeAndType: map[string]types.IndexAttributeType{"a1": types.IndexAttributeType_STRING}},
+	}
+
+	setup := func(t *testing.T, db worldstate.DB) {
+		updates, err := migration.ConstructDBEntriesForDBAdminTx(db, &types.DBAdministrationTx{
+			UserId:    "admin",
+			TxId:      "tx1",
+			Migration: &types.Migration{Name: "schema", Version: 1},
+		}, &types.Version{BlockNum: 1})
+		require.NoError(t, err)
+
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "db1"}, {Key: "db2"}, {Key: stateindex.IndexDB("db2")}},
+			},
+			worldstate.MigrationsDBName: updates,
+		}, 1))
+	}
+
+	tests := []struct {
+		name           string
+		tx             *types.DBAdministrationTx
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name: "valid: no migration",
+			tx:   &types.DBAdministrationTx{},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: next version is applied with backfilled indexes",
+			tx: &types.DBAdministrationTx{
+				CreateDbs: []string{"db3"},
+				DbsIndex:  index,
+				Migration: &types.Migration{Name: "schema", Version: 2, BackfillIndexDbs: []string{"db1", "db3"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: applied version is reverted",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "schema", Version: 1, Direction: types.Migration_DOWN},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "valid: first version of a new migration",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "accounts", Version: 1},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name: "invalid: empty name",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Version: 1},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the name of the migration cannot be empty",
+			},
+		},
+		{
+			name: "invalid: unknown direction",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "schema", Version: 2, Direction: 5},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the direction [5] of the migration [schema] is unknown",
+			},
+		},
+		{
+			name: "invalid: version is skipped",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "schema", Version: 3},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the version [3] of the migration [schema] cannot be applied as the applied version is [1], hence the next version is [2]",
+			},
+		},
+		{
+			name: "invalid: version is applied again",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "schema", Version: 1},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the version [1] of the migration [schema] cannot be applied as the applied version is [1], hence the next version is [2]",
+			},
+		},
+		{
+			name: "invalid: reverted version is not the applied version",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "schema", Version: 2, Direction: types.Migration_DOWN},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the version [2] of the migration [schema] cannot be reverted as the applied version is [1]",
+			},
+		},
+		{
+			name: "invalid: nothing to revert",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "accounts", Version: 1, Direction: types.Migration_DOWN},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the migration [accounts] has no applied version to revert",
+			},
+		},
+		{
+			name: "invalid: database is backfilled twice",
+			tx: &types.DBAdministrationTx{
+				DbsIndex:  index,
+				Migration: &types.Migration{Name: "schema", Version: 2, BackfillIndexDbs: []string{"db1", "db1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [db1] are indexed more than once by the migration [schema]",
+			},
+		},
+		{
+			name: "invalid: backfilled database does not exist",
+			tx: &types.DBAdministrationTx{
+				DbsIndex:  index,
+				Migration: &types.Migration{Name: "schema", Version: 2, BackfillIndexDbs: []string{"db3"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [db3] cannot be indexed by the migration [schema] as the database is a system database, " +
+					"or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			},
+		},
+		{
+			name: "invalid: backfilled database is deleted",
+			tx: &types.DBAdministrationTx{
+				DeleteDbs: []string{"db1"},
+				DbsIndex:  index,
+				Migration: &types.Migration{Name: "schema", Version: 2, BackfillIndexDbs: []string{"db1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [db1] cannot be indexed by the migration [schema] as the database is a system database, " +
+					"or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			},
+		},
+		{
+			name: "invalid: backfilled database is a system database",
+			tx: &types.DBAdministrationTx{
+				DbsIndex:  index,
+				Migration: &types.Migration{Name: "schema", Version: 2, BackfillIndexDbs: []string{worldstate.UsersDBName}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [" + worldstate.UsersDBName + "] cannot be indexed by the migration [schema] as the database is a system database, " +
+					"or it neither exists nor is in the create DB list, or it is in the delete DB list",
+			},
+		},
+		{
+			name: "invalid: index of the backfilled database is not defined",
+			tx: &types.DBAdministrationTx{
+				Migration: &types.Migration{Name: "schema", Version: 2, BackfillIndexDbs: []string{"db1"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [db1] cannot be indexed by the migration [schema] as the transaction does not define the index of the database",
+			},
+		},
+		{
+			name: "invalid: backfilled database already has an index",
+			tx: &types.DBAdministrationTx{
+				DbsIndex:  index,
+				Migration: &types.Migration{Name: "schema", Version: 2, BackfillIndexDbs: []string{"db2"}},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the stored values of database [db2] cannot be indexed by the migration [schema] as the database already has an index, " +
+					"which must be removed by an earlier transaction",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setup(t, env.db)
+
+			result, err := env.validator.dbAdminTxValidator.validateMigration(tt.tx)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expectedResult, result), "expected: %v, actual: %v", tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// maskedPayload returns the payload of a transaction as it may be logged: the keys and values of the users held by a
+// data or a database administration transaction are masked by the logger. The payload itself is not modified.
+func maskedPayload(lg *logger.SugarLogger, payload interface{}) interface{} {
+	switch tx := payload.(type) {
+	case *types.DataTx:
+		return maskedDataTx(lg, tx)
+	case *types.DBAdministrationTx:
+		return maskedDBAdminTx(lg, tx)
+	default:
+		return payload
+	}
+}
+
+// maskedDataTx returns a copy of a data transaction, whose keys, values, and annotation values are masked by the logger
+func maskedDataTx(lg *logger.SugarLogger, tx *types.DataTx) *types.DataTx {
+	if tx == nil {
+		return nil
+	}
+
+	masked := proto.Clone(tx).(*types.DataTx)
+	for k, v := range masked.Annotations {
+		masked.Annotations[k] = lg.MaskValue([]byte(v))
+	}
+	for _, ops := range masked.DbOperations {
+		for _, r := range ops.GetDataReads() {
+			r.Key = lg.MaskKey(r.Key)
+		}
+		for _, w := range ops.GetDataWrites() {
+			w.Key = lg.MaskKey(w.Key)
+			w.Value = []byte(lg.MaskValue(w.Value))
+		}
+		for _, d := range ops.GetDataDeletes() {
+			d.Key = lg.MaskKey(d.Key)
+		}
+		for _, w := range ops.GetAclWrites() {
+			w.Key = lg.MaskKey(w.Key)
+		}
+	}
+	return masked
+}
+
+// maskedDBAdminTx returns a copy of a database administration transaction, whose erased keys and keys under legal
+// holds are masked by the logger
+func maskedDBAdminTx(lg *logger.SugarLogger, tx *types.DBAdministrationTx) *types.DBAdministrationTx {
+	if tx == nil {
+		return nil
+	}
+
+	masked := proto.Clone(tx).(*types.DBAdministrationTx)
+	for _, k := range masked.EraseKeys {
+		k.Key = lg.MaskKey(k.Key)
+	}
+	for _, h := range append(masked.PlaceLegalHolds, masked.ReleaseLegalHolds...) {
+		h.Key = lg.MaskKey(h.Key)
+	}
+	return masked
+}
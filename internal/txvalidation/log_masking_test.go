@@ -0,0 +1,85 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskedPayload(t *testing.T) {
+	t.Parallel()
+
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+		Masking: &logger.MaskingConfig{
+			Mode:           logger.MaskingModeTruncate,
+			TruncateLength: 4,
+			RedactKeys:     []string{"^ssn-"},
+		},
+	})
+	require.NoError(t, err)
+	redacted := lg.MaskKey("ssn-alice")
+	require.NotEqual(t, "ssn-alice", redacted)
+
+	t.Run("data transaction", func(t *testing.T) {
+		tx := &types.DataTx{
+			MustSignUserIds: []string{"alice"},
+			TxId:            "tx1",
+			DbOperations: []*types.DBOperation{
+				{
+					DbName:      "db1",
+					DataReads:   []*types.DataRead{{Key: "ssn-alice"}, {Key: "key1"}},
+					DataWrites:  []*types.DataWrite{{Key: "ssn-alice", Value: []byte("123-45-6789")}},
+					DataDeletes: []*types.DataDelete{{Key: "ssn-alice"}},
+					AclWrites:   []*types.AclWrite{{Key: "ssn-alice"}},
+				},
+			},
+			Annotations: map[string]string{"ref": "order-12345"},
+		}
+		original := proto.Clone(tx)
+
+		masked := maskedPayload(lg, tx).(*types.DataTx)
+		require.True(t, proto.Equal(&types.DataTx{
+			MustSignUserIds: []string{"alice"},
+			TxId:            "tx1",
+			DbOperations: []*types.DBOperation{
+				{
+					DbName:      "db1",
+					DataReads:   []*types.DataRead{{Key: redacted}, {Key: "key1"}},
+					DataWrites:  []*types.DataWrite{{Key: redacted, Value: []byte("123-...(+7 bytes)")}},
+					DataDeletes: []*types.DataDelete{{Key: redacted}},
+					AclWrites:   []*types.AclWrite{{Key: redacted}},
+				},
+			},
+			Annotations: map[string]string{"ref": "orde...(+7 bytes)"},
+		}, masked), "masked: %v", masked)
+		require.True(t, proto.Equal(original, tx))
+	})
+
+	t.Run("db administration transaction", func(t *testing.T) {
+		tx := &types.DBAdministrationTx{
+			UserId:          "admin",
+			EraseKeys:       []*types.KeyErasure{{DbName: "db1", Key: "ssn-alice"}},
+			PlaceLegalHolds: []*types.LegalHold{{DbName: "db1", Key: "ssn-alice", Reason: "case 1"}},
+		}
+
+		masked := maskedPayload(lg, tx).(*types.DBAdministrationTx)
+		require.Equal(t, redacted, masked.EraseKeys[0].Key)
+		require.Equal(t, redacted, masked.PlaceLegalHolds[0].Key)
+		require.Equal(t, "ssn-alice", tx.EraseKeys[0].Key)
+	})
+
+	t.Run("other payloads", func(t *testing.T) {
+		tx := &types.UserAdministrationTx{UserId: "admin"}
+		require.Same(t, tx, maskedPayload(lg, tx))
+	})
+}
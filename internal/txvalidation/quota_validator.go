@@ -0,0 +1,140 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/orion-server/internal/quota"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// DBUsageReader provides the usage of the databases with a quota, as accounted by the committer up to the last
+// committed block
+type DBUsageReader interface {
+	// GetDBUsage returns the usage of a database, or nil if its usage is not accounted
+	GetDBUsage(dbName string) (*types.DBUsage, error)
+}
+
+// quotaValidator enforces the hard limits of the database quotas on the data transactions of a block. The usage of a
+// database is the usage accounted up to the last committed block, together with the usage of the transactions of the
+// block found valid so far. A database whose usage is not yet accounted is not limited.
+type quotaValidator struct {
+	quotas      map[string]*types.DatabaseQuota
+	dbUsage     DBUsageReader
+	minuteStart int64
+	usages      map[string]*types.DBUsage
+	// pending holds the storage written, and the number of transactions, of the valid transactions of the block
+	pending map[string]*pendingDBUsage
+}
+
+type pendingDBUsage struct {
+	storage uint64
+	txs     uint64
+}
+
+func newQuotaValidator(config *types.ClusterConfig, dbUsage DBUsageReader, blockTimestamp int64) *quotaValidator {
+	return &quotaValidator{
+		quotas:      quota.ByDBName(config),
+		dbUsage:     dbUsage,
+		minuteStart: quota.MinuteStart(blockTimestamp),
+		usages:      make(map[string]*types.DBUsage),
+		pending:     make(map[string]*pendingDBUsage),
+	}
+}
+
+// validate checks that the transaction does not make a database it operates on exceed the hard limits of its quota.
+// The values the transaction writes are counted in full, as the sizes of the values they overwrite are not known.
+func (q *quotaValidator) validate(tx *types.DataTx) (*types.ValidationInfo, error) {
+	if q.dbUsage == nil || len(q.quotas) == 0 {
+		return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+	}
+
+	for _, ops := range tx.DbOperations {
+		dbQuota := q.quotas[ops.DbName]
+		if dbQuota == nil {
+			continue
+		}
+
+		usage, err := q.usage(ops.DbName)
+		if err != nil {
+			return nil, err
+		}
+		if usage == nil {
+			continue
+		}
+		pending := q.pendingUsage(ops.DbName)
+
+		if written := writtenBytes(ops); dbQuota.HardStorageBytes > 0 && written > 0 {
+			if storage := usage.StorageBytes + pending.storage + written; storage > dbQuota.HardStorageBytes {
+				return &types.ValidationInfo{
+					Flag: types.Flag_INVALID_QUOTA_EXCEEDED,
+					ReasonIfInvalid: fmt.Sprintf("the transaction would make the storage of the database [%s] reach %d bytes, "+
+						"which exceeds the hard limit of %d bytes", ops.DbName, storage, dbQuota.HardStorageBytes),
+					FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Check: types.DBOperationCheck_QUOTA_CHECK},
+				}, nil
+			}
+		}
+
+		if dbQuota.HardTxsPerMinute > 0 {
+			if txs := quota.MinuteTxs(usage, q.minuteStart) + pending.txs + 1; txs > dbQuota.HardTxsPerMinute {
+				return &types.ValidationInfo{
+					Flag: types.Flag_INVALID_QUOTA_EXCEEDED,
+					ReasonIfInvalid: fmt.Sprintf("the transaction would make the number of transactions on the database [%s] "+
+						"in a minute reach %d, which exceeds the hard limit of %d", ops.DbName, txs, dbQuota.HardTxsPerMinute),
+					FailedOperation: &types.DBOperationFailure{DbName: ops.DbName, Check: types.DBOperationCheck_QUOTA_CHECK},
+				}, nil
+			}
+		}
+	}
+
+	return &types.ValidationInfo{Flag: types.Flag_VALID}, nil
+}
+
+// add adds the usage of a valid transaction to the usage of the block
+func (q *quotaValidator) add(tx *types.DataTx) {
+	if q.dbUsage == nil || len(q.quotas) == 0 {
+		return
+	}
+
+	for _, ops := range tx.DbOperations {
+		if q.quotas[ops.DbName] == nil {
+			continue
+		}
+
+		pending := q.pendingUsage(ops.DbName)
+		pending.storage += writtenBytes(ops)
+		pending.txs++
+	}
+}
+
+func (q *quotaValidator) usage(dbName string) (*types.DBUsage, error) {
+	if usage, ok := q.usages[dbName]; ok {
+		return usage, nil
+	}
+
+	usage, err := q.dbUsage.GetDBUsage(dbName)
+	if err != nil {
+		return nil, err
+	}
+	q.usages[dbName] = usage
+	return usage, nil
+}
+
+func (q *quotaValidator) pendingUsage(dbName string) *pendingDBUsage {
+	pending, ok := q.pending[dbName]
+	if !ok {
+		pending = &pendingDBUsage{}
+		q.pending[dbName] = pending
+	}
+	return pending
+}
+
+func writtenBytes(ops *types.DBOperation) uint64 {
+	var written uint64
+	for _, w := range ops.DataWrites {
+		written += uint64(len(w.Key)+len(w.Value)) + w.GetBlobManifest().GetSize()
+	}
+	return written
+}
@@ -6,7 +6,9 @@ package txvalidation
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -18,21 +20,31 @@ type txSigValidator struct {
 	logger      *logger.SugarLogger
 }
 
+// validate validates the signature of a user on a transaction committed in a block with the given timestamp, in
+// nanoseconds since the Unix epoch, at which the expiry of the signing keys of the user is checked
 func (s *txSigValidator) validate(
 	user string,
 	signature []byte,
 	txPayload interface{},
+	blockTimestamp int64,
 ) (*types.ValidationInfo, error) {
 	requestBytes, err := json.Marshal(txPayload)
 	if err != nil {
-		s.logger.Errorf("Error during json.Marshal Tx: %s, error: %s", txPayload, err)
+		s.logger.Errorf("Error during json.Marshal Tx: %s, error: %s", maskedPayload(s.logger, txPayload), err)
 		return nil, errors.Wrapf(err, "failed to json.Marshal Tx: %s", txPayload)
 	}
 
-	err = s.sigVerifier.Verify(user, signature, requestBytes)
+	err = s.sigVerifier.VerifyAt(user, signature, requestBytes, time.Unix(0, blockTimestamp))
+	if _, ok := err.(*identity.DisabledErr); ok {
+		s.logger.Debugf("Failed to verify Tx (Flag_INVALID_USER_DISABLED): user: %s, payload: %s", user, maskedPayload(s.logger, txPayload))
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_USER_DISABLED,
+			ReasonIfInvalid: err.Error(),
+		}, nil
+	}
 	if err != nil {
 		s.logger.Debugf("Failed to verify Tx (Flag_INVALID_UNAUTHORISED): user: %s, sig: %x, payload: %s, error: %s",
-			user, signature, txPayload, err)
+			user, signature, maskedPayload(s.logger, txPayload), err)
 		return &types.ValidationInfo{
 			Flag:            types.Flag_INVALID_UNAUTHORISED,
 			ReasonIfInvalid: fmt.Sprintf("signature verification failed: %s", err.Error()),
@@ -4,10 +4,19 @@
 package txvalidation
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/certificateauthority"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -20,13 +29,18 @@ type userAdminTxValidator struct {
 	logger          *logger.SugarLogger
 }
 
-func (v *userAdminTxValidator) validate(txEnv *types.UserAdministrationTxEnvelope) (*types.ValidationInfo, error) {
-	valInfo, err := v.sigValidator.validate(txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload)
+// validate validates a user administration transaction committed in the given block
+func (v *userAdminTxValidator) validate(txEnv *types.UserAdministrationTxEnvelope, blockNum uint64, blockTimestamp int64) (*types.ValidationInfo, error) {
+	valInfo, err := v.sigValidator.validate(txEnv.Payload.UserId, txEnv.Signature, txEnv.Payload, blockTimestamp)
 	if err != nil || valInfo.Flag != types.Flag_VALID {
 		return valInfo, err
 	}
 
 	tx := txEnv.Payload
+	if tx.CertificateRenewal != nil {
+		return v.validateCertificateRenewal(txEnv, blockNum)
+	}
+
 	hasPerm, err := v.identityQuerier.HasAdministrationPrivilege(tx.UserId)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "error while checking user administrative privilege for user [%s]", tx.UserId)
@@ -38,7 +52,7 @@ func (v *userAdminTxValidator) validate(txEnv *types.UserAdministrationTxEnvelop
 		}, nil
 	}
 
-	r, err := v.validateFieldsInUserWrites(tx.UserWrites)
+	r, err := v.validateFieldsInUserWrites(tx.UserWrites, blockNum)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "error while validating fields in user writes")
 	}
@@ -81,7 +95,82 @@ func (v *userAdminTxValidator) validate(txEnv *types.UserAdministrationTxEnvelop
 	return v.mvccValidation(tx.UserReads)
 }
 
-func (v *userAdminTxValidator) validateFieldsInUserWrites(userWrites []*types.UserWrite) (*types.ValidationInfo, error) {
+// validateCertificateRenewal validates a transaction by which a user replaces its own certificate. The payload must
+// be signed by the key of the current certificate, which is checked by the caller, and by the key of the new
+// certificate, so that the user proves the possession of both keys.
+func (v *userAdminTxValidator) validateCertificateRenewal(txEnv *types.UserAdministrationTxEnvelope, blockNum uint64) (*types.ValidationInfo, error) {
+	tx := txEnv.Payload
+	if len(tx.UserReads) > 0 || len(tx.UserWrites) > 0 || len(tx.UserDeletes) > 0 {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "a certificate renewal transaction cannot carry user reads, writes, or deletes",
+		}, nil
+	}
+
+	admin, err := v.identityQuerier.HasAdministrationPrivilege(tx.UserId)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while checking user administrative privilege for user [%s]", tx.UserId)
+	}
+	if admin {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_NO_PERMISSION,
+			ReasonIfInvalid: "the user [" + tx.UserId + "] is an admin user. Only via a cluster configuration transaction, the certificate of [" + tx.UserId + "] can be renewed",
+		}, nil
+	}
+
+	user, _, err := v.identityQuerier.GetUser(tx.UserId)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while fetching user [%s]", tx.UserId)
+	}
+	newCert := tx.CertificateRenewal.Certificate
+	if bytes.Equal(user.Certificate, newCert) {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the new certificate of the user [" + tx.UserId + "] is the same as its current certificate",
+		}, nil
+	}
+
+	caCertCollection, err := v.caCertCollection(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if err := caCertCollection.VerifyUserCert(newCert, tx.UserId); err != nil {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+			ReasonIfInvalid: "the new certificate of the user [" + tx.UserId + "] is invalid: Error = " + err.Error(),
+		}, nil
+	}
+
+	if len(txEnv.RenewalSignature) == 0 {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_MISSING_SIGNATURE,
+			ReasonIfInvalid: "the certificate renewal of the user [" + tx.UserId + "] is not signed by the key of the new certificate",
+		}, nil
+	}
+	cert, err := x509.ParseCertificate(newCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while parsing the new certificate")
+	}
+	payloadBytes, err := json.Marshal(tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while marshaling the transaction payload")
+	}
+	verifier := crypto.Verifier{Certificate: cert}
+	if err := verifier.Verify(payloadBytes, txEnv.RenewalSignature); err != nil {
+		return &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_UNAUTHORISED,
+			ReasonIfInvalid: "the signature by the key of the new certificate of the user [" + tx.UserId + "] is invalid: " + err.Error(),
+		}, nil
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}, nil
+}
+
+// caCertCollection returns the collection of the CAs that are trusted in the given block, including the retiring CAs
+// whose overlap period includes the block, restricted by the scopes of the CAs of the organizations
+func (v *userAdminTxValidator) caCertCollection(blockNum uint64) (*certificateauthority.CACertCollection, error) {
 	config, _, err := v.db.GetConfig()
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot get config")
@@ -89,10 +178,19 @@ func (v *userAdminTxValidator) validateFieldsInUserWrites(userWrites []*types.Us
 	if config == nil {
 		return nil, errors.New("config is nil")
 	}
-	caCertCollection, err := certificateauthority.NewCACertCollection(config.CertAuthConfig.Roots, config.CertAuthConfig.Intermediates)
+	caCertCollection, err := certificateauthority.NewCACertCollection(certificateauthority.TrustedCAs(config.CertAuthConfig, blockNum))
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot build CA certificate collection")
 	}
+	caCertCollection.SetScopes(config.CertAuthConfig.Scopes)
+	return caCertCollection, nil
+}
+
+func (v *userAdminTxValidator) validateFieldsInUserWrites(userWrites []*types.UserWrite, blockNum uint64) (*types.ValidationInfo, error) {
+	caCertCollection, err := v.caCertCollection(blockNum)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, w := range userWrites {
 		switch {
@@ -123,6 +221,15 @@ func (v *userAdminTxValidator) validateFieldsInUserWrites(userWrites []*types.Us
 					}, nil
 				}
 
+				for _, prefix := range w.User.Privilege.DbAdministrationPrefixes {
+					if prefix == "" || strings.HasPrefix(prefix, "_") || strings.HasPrefix(prefix, "/") || strings.HasSuffix(prefix, "/") {
+						return &types.ValidationInfo{
+							Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+							ReasonIfInvalid: "the user [" + w.User.Id + "] has an invalid db administration prefix [" + prefix + "]. A prefix must be a non-empty database name path, which cannot refer to system databases",
+						}, nil
+					}
+				}
+
 				dbPerm := w.User.Privilege.DbPermission
 				for dbName := range dbPerm {
 					if v.db.Exist(dbName) {
@@ -135,13 +242,17 @@ func (v *userAdminTxValidator) validateFieldsInUserWrites(userWrites []*types.Us
 				}
 			}
 
-			err = caCertCollection.VerifyLeafCert(w.User.Certificate)
+			err = caCertCollection.VerifyUserCert(w.User.Certificate, w.User.Id)
 			if err != nil {
 				return &types.ValidationInfo{
 					Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
 					ReasonIfInvalid: "the user [" + w.User.Id + "] in the write list has an invalid certificate: Error = " + err.Error(),
 				}, nil
 			}
+
+			if r := validateSigningKeys(w.User); r.Flag != types.Flag_VALID {
+				return r, nil
+			}
 		}
 	}
 
@@ -150,6 +261,52 @@ func (v *userAdminTxValidator) validateFieldsInUserWrites(userWrites []*types.Us
 	}, nil
 }
 
+func validateSigningKeys(user *types.User) *types.ValidationInfo {
+	keyIDs := make(map[string]bool)
+	for _, k := range user.SigningKeys {
+		switch {
+		case k == nil:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [" + user.Id + "] in the write list has an empty signing key",
+			}
+
+		case k.KeyId == "":
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [" + user.Id + "] in the write list has a signing key with an empty key ID",
+			}
+
+		case keyIDs[k.KeyId]:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [" + user.Id + "] in the write list has two signing keys with the same key ID [" + k.KeyId + "]",
+			}
+		}
+		keyIDs[k.KeyId] = true
+
+		publicKey, err := x509.ParsePKIXPublicKey(k.PublicKey)
+		if err != nil {
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the signing key [" + k.KeyId + "] of the user [" + user.Id + "] in the write list is invalid: Error = " + err.Error(),
+			}
+		}
+		switch publicKey.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey:
+		default:
+			return &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: fmt.Sprintf("the signing key [%s] of the user [%s] in the write list is of an unsupported type: %T", k.KeyId, user.Id, publicKey),
+			}
+		}
+	}
+
+	return &types.ValidationInfo{
+		Flag: types.Flag_VALID,
+	}
+}
+
 func validateFieldsInUserDeletes(userDeletes []*types.UserDelete) *types.ValidationInfo {
 	for _, d := range userDeletes {
 		switch {
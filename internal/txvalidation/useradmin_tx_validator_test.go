@@ -4,12 +4,16 @@
 package txvalidation
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
@@ -394,13 +398,158 @@ func TestValidateUsedAdminTx(t *testing.T) {
 			setupClusterConfigCA(t, env, caCert)
 			tt.setup(env.db)
 
-			result, err := env.validator.userAdminTxValidator.validate(tt.txEnv)
+			result, err := env.validator.userAdminTxValidator.validate(tt.txEnv, 2, time.Now().UnixNano())
 			require.NoError(t, err)
 			require.Equal(t, tt.expectedResult, result)
 		})
 	}
 }
 
+func TestValidateCertificateRenewal(t *testing.T) {
+	t.Parallel()
+
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"adminUser", "alice", "aliceRenewed"})
+	adminCert, adminSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "adminUser")
+	aliceCert, aliceSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	renewedCert, renewedSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "aliceRenewed")
+	caCert, _ := testutils.LoadTestClientCA(t, cryptoDir, testutils.RootCAFileName)
+
+	untrustedCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"aliceRenewed"})
+	untrustedCert, untrustedSigner := testutils.LoadTestClientCrypto(t, untrustedCryptoDir, "aliceRenewed")
+
+	setup := func(db worldstate.DB) {
+		var writes []*worldstate.KVWithMetadata
+		for _, user := range []*types.User{
+			{
+				Id:          "adminUser",
+				Certificate: adminCert.Raw,
+				Privilege: &types.Privilege{
+					Admin: true,
+				},
+			},
+			{
+				Id:          "alice",
+				Certificate: aliceCert.Raw,
+			},
+		} {
+			u, err := proto.Marshal(user)
+			require.NoError(t, err)
+			writes = append(writes, &worldstate.KVWithMetadata{
+				Key:   string(identity.UserNamespace) + user.Id,
+				Value: u,
+			})
+		}
+		require.NoError(t, db.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.UsersDBName: {
+				Writes: writes,
+			},
+		}, 1))
+	}
+
+	renewal := func(userID string, cert []byte, oldSigner, newSigner crypto.Signer, tx *types.UserAdministrationTx) *types.UserAdministrationTxEnvelope {
+		tx.UserId = userID
+		tx.CertificateRenewal = &types.UserCertificateRenewal{Certificate: cert}
+		txEnv := testutils.SignedUserAdministrationTxEnvelope(t, oldSigner, tx)
+		if newSigner != nil {
+			txEnv.RenewalSignature = testutils.SignatureFromTx(t, newSigner, tx)
+		}
+		return txEnv
+	}
+
+	tests := []struct {
+		name           string
+		txEnv          *types.UserAdministrationTxEnvelope
+		expectedResult *types.ValidationInfo
+	}{
+		{
+			name:  "valid",
+			txEnv: renewal("alice", renewedCert.Raw, aliceSigner, renewedSigner, &types.UserAdministrationTx{}),
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
+		{
+			name:  "invalid: not signed by the key of the current certificate",
+			txEnv: renewal("alice", renewedCert.Raw, renewedSigner, renewedSigner, &types.UserAdministrationTx{}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "signature verification failed: x509: ECDSA verification failure",
+			},
+		},
+		{
+			name: "invalid: the transaction carries user writes",
+			txEnv: renewal("alice", renewedCert.Raw, aliceSigner, renewedSigner, &types.UserAdministrationTx{
+				UserWrites: []*types.UserWrite{
+					{
+						User: &types.User{Id: "bob"},
+					},
+				},
+			}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "a certificate renewal transaction cannot carry user reads, writes, or deletes",
+			},
+		},
+		{
+			name:  "invalid: the user is an admin",
+			txEnv: renewal("adminUser", renewedCert.Raw, adminSigner, renewedSigner, &types.UserAdministrationTx{}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_NO_PERMISSION,
+				ReasonIfInvalid: "the user [adminUser] is an admin user. Only via a cluster configuration transaction, the certificate of [adminUser] can be renewed",
+			},
+		},
+		{
+			name:  "invalid: the new certificate is the current certificate",
+			txEnv: renewal("alice", aliceCert.Raw, aliceSigner, aliceSigner, &types.UserAdministrationTx{}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the new certificate of the user [alice] is the same as its current certificate",
+			},
+		},
+		{
+			name:  "invalid: the new certificate is not issued by a CA of the cluster",
+			txEnv: renewal("alice", untrustedCert.Raw, aliceSigner, untrustedSigner, &types.UserAdministrationTx{}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the new certificate of the user [alice] is invalid: Error = error verifying certificate against trusted certificate authority (CA)",
+			},
+		},
+		{
+			name:  "invalid: not signed by the key of the new certificate",
+			txEnv: renewal("alice", renewedCert.Raw, aliceSigner, nil, &types.UserAdministrationTx{}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_MISSING_SIGNATURE,
+				ReasonIfInvalid: "the certificate renewal of the user [alice] is not signed by the key of the new certificate",
+			},
+		},
+		{
+			name:  "invalid: signed by a key other than the key of the new certificate",
+			txEnv: renewal("alice", renewedCert.Raw, aliceSigner, aliceSigner, &types.UserAdministrationTx{}),
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_UNAUTHORISED,
+				ReasonIfInvalid: "the signature by the key of the new certificate of the user [alice] is invalid: x509: ECDSA verification failure",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := newValidatorTestEnv(t)
+			defer env.cleanup()
+			setupClusterConfigCA(t, env, caCert)
+			setup(env.db)
+
+			result, err := env.validator.userAdminTxValidator.validate(tt.txEnv, 2, time.Now().UnixNano())
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedResult.Flag, result.Flag)
+			require.Contains(t, result.ReasonIfInvalid, tt.expectedResult.ReasonIfInvalid)
+		})
+	}
+}
+
 func TestValidateEntryFieldsInWrites(t *testing.T) {
 	t.Parallel()
 
@@ -412,6 +561,13 @@ func TestValidateEntryFieldsInWrites(t *testing.T) {
 	untrustedCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"})
 	untrustedAliceCert, _ := testutils.LoadTestClientCrypto(t, untrustedCryptoDir, "alice")
 
+	ecdsaPublicKey, err := x509.MarshalPKIXPublicKey(aliceCert.PublicKey)
+	require.NoError(t, err)
+	rsaPrivateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	rsaPublicKey, err := x509.MarshalPKIXPublicKey(&rsaPrivateKey.PublicKey)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name           string
 		userWrites     []*types.UserWrite
@@ -468,6 +624,57 @@ func TestValidateEntryFieldsInWrites(t *testing.T) {
 				ReasonIfInvalid: "the user [user1] is marked as admin user. Only via a cluster configuration transaction, the [user1] can be added as admin",
 			},
 		},
+		{
+			name: "invalid: the user has an empty db administration prefix",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id: "user1",
+						Privilege: &types.Privilege{
+							DbAdministrationPrefixes: []string{"team1", ""},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [user1] has an invalid db administration prefix []. A prefix must be a non-empty database name path, which cannot refer to system databases",
+			},
+		},
+		{
+			name: "invalid: the user has a db administration prefix ending with a slash",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id: "user1",
+						Privilege: &types.Privilege{
+							DbAdministrationPrefixes: []string{"team1/"},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [user1] has an invalid db administration prefix [team1/]. A prefix must be a non-empty database name path, which cannot refer to system databases",
+			},
+		},
+		{
+			name: "invalid: the user has a db administration prefix covering system databases",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id: "user1",
+						Privilege: &types.Privilege{
+							DbAdministrationPrefixes: []string{"_"},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [user1] has an invalid db administration prefix [_]. A prefix must be a non-empty database name path, which cannot refer to system databases",
+			},
+		},
 		{
 			name: "invalid: db present in the premission list does not exist",
 			userWrites: []*types.UserWrite{
@@ -531,6 +738,97 @@ func TestValidateEntryFieldsInWrites(t *testing.T) {
 				Flag: types.Flag_VALID,
 			},
 		},
+		{
+			name: "invalid: signing key with an empty key ID",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id:          userID,
+						Certificate: aliceCert.Raw,
+						SigningKeys: []*types.SigningKey{
+							{PublicKey: ecdsaPublicKey},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [alice] in the write list has a signing key with an empty key ID",
+			},
+		},
+		{
+			name: "invalid: two signing keys with the same key ID",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id:          userID,
+						Certificate: aliceCert.Raw,
+						SigningKeys: []*types.SigningKey{
+							{KeyId: "key1", PublicKey: ecdsaPublicKey},
+							{KeyId: "key1", PublicKey: ecdsaPublicKey, Revoked: true},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the user [alice] in the write list has two signing keys with the same key ID [key1]",
+			},
+		},
+		{
+			name: "invalid: signing key cannot be parsed",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id:          userID,
+						Certificate: aliceCert.Raw,
+						SigningKeys: []*types.SigningKey{
+							{KeyId: "key1", PublicKey: []byte("bogus-key")},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the signing key [key1] of the user [alice] in the write list is invalid: Error = asn1: structure error: tags don't match (16 vs {class:1 tag:2 length:111 isCompound:true}) {optional:false explicit:false application:false private:false defaultValue:<nil> tag:<nil> stringType:0 timeType:0 set:false omitEmpty:false} publicKeyInfo @2",
+			},
+		},
+		{
+			name: "invalid: signing key of an unsupported type",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id:          userID,
+						Certificate: aliceCert.Raw,
+						SigningKeys: []*types.SigningKey{
+							{KeyId: "key1", PublicKey: rsaPublicKey},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag:            types.Flag_INVALID_INCORRECT_ENTRIES,
+				ReasonIfInvalid: "the signing key [key1] of the user [alice] in the write list is of an unsupported type: *rsa.PublicKey",
+			},
+		},
+		{
+			name: "valid: entries with signing keys are correct",
+			userWrites: []*types.UserWrite{
+				{
+					User: &types.User{
+						Id:          userID,
+						Certificate: aliceCert.Raw,
+						SigningKeys: []*types.SigningKey{
+							{KeyId: "key1", PublicKey: ecdsaPublicKey, ExpiresAt: 1700000000},
+							{KeyId: "key2", PublicKey: ecdsaPublicKey, Revoked: true},
+						},
+					},
+				},
+			},
+			expectedResult: &types.ValidationInfo{
+				Flag: types.Flag_VALID,
+			},
+		},
 		{
 			name: "valid: entries are correct and db exist too",
 			userWrites: []*types.UserWrite{
@@ -569,7 +867,7 @@ func TestValidateEntryFieldsInWrites(t *testing.T) {
 			defer env.cleanup()
 			setupClusterConfigCA(t, env, caCert)
 
-			result, err := env.validator.userAdminTxValidator.validateFieldsInUserWrites(tt.userWrites)
+			result, err := env.validator.userAdminTxValidator.validateFieldsInUserWrites(tt.userWrites, 2)
 			require.NoError(t, err)
 			require.Equal(t, tt.expectedResult, result)
 		})
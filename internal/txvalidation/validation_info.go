@@ -0,0 +1,45 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"unicode/utf8"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+const (
+	// MaxReasonIfInvalidBytes bounds the reason of an invalid transaction recorded in the block header
+	MaxReasonIfInvalidBytes = 256
+	// MaxFailedOperationFieldBytes bounds the database name and the key of the failed operation of an invalid
+	// transaction recorded in the block header
+	MaxFailedOperationFieldBytes = 64
+
+	// ValidationInfoSizeReserve is the number of bytes, out of the maximal block size, that are reserved per
+	// transaction for its validation info, which is added to the block header after the transactions are batched. It
+	// bounds the serialized size of a validation info bounded by boundValidationInfo, within the block header.
+	ValidationInfoSizeReserve = 512
+)
+
+// boundValidationInfo truncates the strings of a validation info, such that its serialized size in the block header
+// does not exceed ValidationInfoSizeReserve, whatever the reason of the invalidation is.
+func boundValidationInfo(info *types.ValidationInfo) {
+	info.ReasonIfInvalid = truncateUTF8(info.ReasonIfInvalid, MaxReasonIfInvalidBytes)
+	if op := info.GetFailedOperation(); op != nil {
+		op.DbName = truncateUTF8(op.DbName, MaxFailedOperationFieldBytes)
+		op.Key = truncateUTF8(op.Key, MaxFailedOperationFieldBytes)
+	}
+}
+
+// truncateUTF8 truncates a string to at most maxBytes bytes, without splitting a multi-byte character
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	n := maxBytes
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,62 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package txvalidation
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundValidationInfo(t *testing.T) {
+	t.Run("short strings are kept", func(t *testing.T) {
+		info := &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_NO_PERMISSION,
+			ReasonIfInvalid: "no permission",
+			FailedOperation: &types.DBOperationFailure{DbName: "db1", Key: "key1", Check: types.DBOperationCheck_DB_PERMISSION_CHECK},
+		}
+		expected := proto.Clone(info)
+		boundValidationInfo(info)
+		require.True(t, proto.Equal(expected, info))
+	})
+
+	t.Run("long strings are truncated on a character boundary", func(t *testing.T) {
+		info := &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
+			ReasonIfInvalid: "r" + strings.Repeat("é", MaxReasonIfInvalidBytes),
+			FailedOperation: &types.DBOperationFailure{
+				DbName: strings.Repeat("d", 2*MaxFailedOperationFieldBytes),
+				Key:    "k" + strings.Repeat("界", MaxFailedOperationFieldBytes),
+				Check:  types.DBOperationCheck_MVCC_CHECK,
+			},
+		}
+		boundValidationInfo(info)
+		require.Equal(t, "r"+strings.Repeat("é", MaxReasonIfInvalidBytes/2-1), info.ReasonIfInvalid)
+		require.Equal(t, strings.Repeat("d", MaxFailedOperationFieldBytes), info.FailedOperation.DbName)
+		require.Equal(t, "k"+strings.Repeat("界", (MaxFailedOperationFieldBytes-1)/3), info.FailedOperation.Key)
+		require.True(t, utf8.ValidString(info.ReasonIfInvalid))
+		require.True(t, utf8.ValidString(info.FailedOperation.Key))
+	})
+
+	t.Run("the bounded validation info fits in the reserve", func(t *testing.T) {
+		info := &types.ValidationInfo{
+			Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE,
+			ReasonIfInvalid: strings.Repeat("r", 10*MaxReasonIfInvalidBytes),
+			FailedOperation: &types.DBOperationFailure{
+				DbName: strings.Repeat("d", 10*MaxFailedOperationFieldBytes),
+				Key:    strings.Repeat("k", 10*MaxFailedOperationFieldBytes),
+				Check:  types.DBOperationCheck_MVCC_CHECK,
+			},
+		}
+		boundValidationInfo(info)
+
+		empty := &types.BlockHeader{}
+		header := &types.BlockHeader{ValidationInfo: []*types.ValidationInfo{info}}
+		require.LessOrEqual(t, proto.Size(header)-proto.Size(empty), ValidationInfoSizeReserve)
+	})
+}
@@ -23,12 +23,20 @@ type Validator struct {
 	userAdminTxValidator *userAdminTxValidator
 	dataTxValidator      *dataTxValidator
 	signValidator        *txSigValidator
+	db                   worldstate.DB
+	dbUsage              DBUsageReader
 	logger               *logger.SugarLogger
 }
 
 type Config struct {
-	DB     worldstate.DB
-	Logger *logger.SugarLogger
+	DB worldstate.DB
+	// DBUsage provides the usage of the databases against which the hard limits of their quotas are enforced. If nil,
+	// the quotas are not enforced.
+	DBUsage DBUsageReader
+	// ConsensusAlgorithms are the consensus algorithms that the cluster config may select. If empty, only "raft" may
+	// be selected.
+	ConsensusAlgorithms []string
+	Logger              *logger.SugarLogger
 }
 
 // NewValidator creates a new Validator
@@ -39,12 +47,18 @@ func NewValidator(conf *Config) *Validator {
 		logger:      conf.Logger,
 	}
 
+	consensusAlgorithms := map[string]bool{"raft": true}
+	for _, algorithm := range conf.ConsensusAlgorithms {
+		consensusAlgorithms[algorithm] = true
+	}
+
 	return &Validator{
 		configTxValidator: &ConfigTxValidator{
-			db:              conf.DB,
-			identityQuerier: idQuerier,
-			sigValidator:    txSigValidator,
-			logger:          conf.Logger,
+			db:                  conf.DB,
+			identityQuerier:     idQuerier,
+			sigValidator:        txSigValidator,
+			consensusAlgorithms: consensusAlgorithms,
+			logger:              conf.Logger,
 		},
 
 		dbAdminTxValidator: &dbAdminTxValidator{
@@ -70,23 +84,45 @@ func NewValidator(conf *Config) *Validator {
 
 		signValidator: txSigValidator,
 
-		logger: conf.Logger,
+		db:      conf.DB,
+		dbUsage: conf.DBUsage,
+		logger:  conf.Logger,
 	}
 }
 
 // ValidateBlock validates each transaction present in the block to ensure
-// the request isolation level
+// the request isolation level. The strings of the validation info are bounded, so that the validation info of each
+// transaction fits in the ValidationInfoSizeReserve of the block header.
 func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo, error) {
+	valInfoArray, err := v.validateBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, valInfo := range valInfoArray {
+		boundValidationInfo(valInfo)
+	}
+	return valInfoArray, nil
+}
+
+func (v *Validator) validateBlock(block *types.Block) ([]*types.ValidationInfo, error) {
 	if block.Header.BaseHeader.Number == 1 {
 		// for the genesis block, which is created by the node itself, we cannot
 		// do a regular validation, but we still need to validate the entries.
 		return v.configTxValidator.validateGenesis(block.GetConfigTxEnvelope())
 	}
 
+	// the expiry of the signing keys is checked at the block timestamp, which is the same on all the nodes
+	blockTimestamp := block.GetHeader().GetBaseHeader().GetTimestamp()
 	switch block.Payload.(type) {
 	case *types.Block_DataTxEnvelopes:
 		dataTxEnvs := block.GetDataTxEnvelopes().Envelopes
-		valInfoArray, usersWithValidSigPerTX, err := v.parallelSigValidation(dataTxEnvs)
+		valInfoArray, usersWithValidSigPerTX, err := v.parallelSigValidation(dataTxEnvs, blockTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		quotas, err := v.newQuotaValidator(block)
 		if err != nil {
 			return nil, err
 		}
@@ -101,13 +137,19 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 			if err != nil {
 				return nil, errors.WithMessage(err, "error while validating data transaction")
 			}
+			if valRes.Flag == types.Flag_VALID {
+				if valRes, err = quotas.validate(txEnv.Payload); err != nil {
+					return nil, errors.WithMessage(err, "error while validating the quotas of data transaction")
+				}
+			}
 
 			valInfoArray[txNum] = valRes
 			if valRes.Flag != types.Flag_VALID {
-				v.logger.Debugf("data transaction [%v] is invalid due to [%s]", txEnv.Payload, valRes.ReasonIfInvalid)
+				v.logger.Debugf("data transaction [%v] is invalid due to [%s]", maskedDataTx(v.logger, txEnv.Payload), valRes.ReasonIfInvalid)
 				continue
 			}
 
+			quotas.add(txEnv.Payload)
 			for _, ops := range txEnv.Payload.DbOperations {
 				for _, w := range ops.DataWrites {
 					pendingOps.addWrite(ops.DbName, w.Key)
@@ -116,6 +158,10 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 				for _, d := range ops.DataDeletes {
 					pendingOps.addDelete(ops.DbName, d.Key)
 				}
+
+				for _, w := range ops.AclWrites {
+					pendingOps.addWrite(ops.DbName, w.Key)
+				}
 			}
 		}
 
@@ -123,7 +169,7 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 
 	case *types.Block_UserAdministrationTxEnvelope:
 		userTxEnv := block.GetUserAdministrationTxEnvelope()
-		valRes, err := v.userAdminTxValidator.validate(userTxEnv)
+		valRes, err := v.userAdminTxValidator.validate(userTxEnv, block.Header.BaseHeader.Number, blockTimestamp)
 		if err != nil {
 			return nil, errors.WithMessage(err, "error while validating user administrative transaction")
 		}
@@ -138,13 +184,13 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 
 	case *types.Block_DbAdministrationTxEnvelope:
 		dbTxEnv := block.GetDbAdministrationTxEnvelope()
-		valRes, err := v.dbAdminTxValidator.validate(dbTxEnv)
+		valRes, err := v.dbAdminTxValidator.validate(dbTxEnv, blockTimestamp)
 		if err != nil {
 			return nil, errors.WithMessage(err, "error while validating db administrative transaction")
 		}
 
 		if valRes.Flag != types.Flag_VALID {
-			v.logger.Debugf("database administration transaction [%v] is invalid due to [%s]", dbTxEnv.Payload, valRes.ReasonIfInvalid)
+			v.logger.Debugf("database administration transaction [%v] is invalid due to [%s]", maskedDBAdminTx(v.logger, dbTxEnv.Payload), valRes.ReasonIfInvalid)
 		}
 
 		return []*types.ValidationInfo{
@@ -153,7 +199,7 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 
 	case *types.Block_ConfigTxEnvelope:
 		configTxEnv := block.GetConfigTxEnvelope()
-		valRes, err := v.configTxValidator.Validate(configTxEnv)
+		valRes, err := v.configTxValidator.validate(configTxEnv, block.Header.BaseHeader.Number, blockTimestamp)
 		if err != nil {
 			return nil, errors.WithMessage(err, "error while validating config transaction")
 		}
@@ -171,12 +217,27 @@ func (v *Validator) ValidateBlock(block *types.Block) ([]*types.ValidationInfo,
 	}
 }
 
+// newQuotaValidator creates the validator of the database quotas for a data block, with the quotas of the committed
+// cluster configuration
+func (v *Validator) newQuotaValidator(block *types.Block) (*quotaValidator, error) {
+	if v.dbUsage == nil {
+		return newQuotaValidator(nil, nil, 0), nil
+	}
+
+	config, _, err := v.db.GetConfig()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while fetching the cluster configuration")
+	}
+
+	return newQuotaValidator(config, v.dbUsage, block.GetHeader().GetBaseHeader().GetTimestamp()), nil
+}
+
 // ConfigValidator provides a pointer to the internal validator that verifies config transactions.
 func (v *Validator) ConfigValidator() *ConfigTxValidator {
 	return v.configTxValidator
 }
 
-func (v *Validator) parallelSigValidation(dataTxEnvs []*types.DataTxEnvelope) ([]*types.ValidationInfo, [][]string, error) {
+func (v *Validator) parallelSigValidation(dataTxEnvs []*types.DataTxEnvelope, blockTimestamp int64) ([]*types.ValidationInfo, [][]string, error) {
 	valInfoPerTx := make([]*types.ValidationInfo, len(dataTxEnvs))
 	usersWithValidSigPerTX := make([][]string, len(dataTxEnvs))
 	errorPerTx := make([]error, len(dataTxEnvs))
@@ -188,7 +249,7 @@ func (v *Validator) parallelSigValidation(dataTxEnvs []*types.DataTxEnvelope) ([
 		go func(txEnv *types.DataTxEnvelope, txNum int) {
 			defer wg.Done()
 
-			usersWithValidSignTx, vInfo, vErr := v.dataTxValidator.validateSignatures(txEnv)
+			usersWithValidSignTx, vInfo, vErr := v.dataTxValidator.validateSignatures(txEnv, blockTimestamp)
 			if vErr != nil {
 				errorPerTx[txNum] = vErr
 				return
@@ -197,7 +258,7 @@ func (v *Validator) parallelSigValidation(dataTxEnvs []*types.DataTxEnvelope) ([
 			usersWithValidSigPerTX[txNum] = usersWithValidSignTx
 			valInfoPerTx[txNum] = vInfo
 			if vInfo.Flag != types.Flag_VALID {
-				v.logger.Debugf("data transaction [%v] is invalid due to [%s]", txEnv.Payload, vInfo.ReasonIfInvalid)
+				v.logger.Debugf("data transaction [%v] is invalid due to [%s]", maskedDataTx(v.logger, txEnv.Payload), vInfo.ReasonIfInvalid)
 			}
 		}(txEnvelope, txNumber)
 	}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger-labs/orion-server/internal/identity"
@@ -616,22 +617,27 @@ func TestValidateDataBlock(t *testing.T) {
 				{
 					Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 					ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]",
+					FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 				},
 				{
 					Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 					ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key2] in database [" + worldstate.DefaultDBName + "]",
+					FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key2", Check: types.DBOperationCheck_MVCC_CHECK},
 				},
 				{
 					Flag:            types.Flag_INVALID_DATABASE_DOES_NOT_EXIST,
 					ReasonIfInvalid: "the database [db2] does not exist in the cluster",
+					FailedOperation: &types.DBOperationFailure{DbName: "db2", Check: types.DBOperationCheck_DB_CHECK},
 				},
 				{
 					Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 					ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [" + worldstate.DefaultDBName + "]. Within a block, a key can be modified only once",
+					FailedOperation: &types.DBOperationFailure{DbName: worldstate.DefaultDBName, Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 				},
 				{
 					Flag:            types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK,
 					ReasonIfInvalid: "mvcc conflict has occurred within the block for the key [key1] in database [db1]. Within a block, a key can be modified only once",
+					FailedOperation: &types.DBOperationFailure{DbName: "db1", Key: "key1", Check: types.DBOperationCheck_MVCC_CHECK},
 				},
 			},
 		},
@@ -654,6 +660,134 @@ func TestValidateDataBlock(t *testing.T) {
 	}
 }
 
+type dbUsages map[string]*types.DBUsage
+
+func (u dbUsages) GetDBUsage(dbName string) (*types.DBUsage, error) {
+	return u[dbName], nil
+}
+
+func TestValidateDataBlockWithQuotas(t *testing.T) {
+	t.Parallel()
+
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"operatingUser"})
+	userCert, userSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "operatingUser")
+
+	env := newValidatorTestEnv(t)
+	defer env.cleanup()
+
+	user, err := proto.Marshal(&types.User{
+		Id:          "operatingUser",
+		Certificate: userCert.Raw,
+		Privilege: &types.Privilege{
+			DbPermission: map[string]types.Privilege_Access{
+				"db1": types.Privilege_ReadWrite,
+				"db2": types.Privilege_ReadWrite,
+			},
+		},
+	})
+	require.NoError(t, err)
+	config, err := proto.Marshal(&types.ClusterConfig{
+		DbQuotas: []*types.DatabaseQuota{
+			{DbName: "db1", HardStorageBytes: 50, HardTxsPerMinute: 3},
+			{DbName: "db2", HardStorageBytes: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, env.db.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.UsersDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: string(identity.UserNamespace) + "operatingUser", Value: user},
+			},
+		},
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "db1"},
+				{Key: "db2"},
+			},
+		},
+		worldstate.ConfigDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: worldstate.ConfigKey, Value: config},
+			},
+		},
+	}, 1))
+
+	timestamp := int64(10*time.Minute + 30*time.Second)
+	// the usage of db2 is not accounted yet, hence it is not limited
+	env.validator.dbUsage = dbUsages{
+		"db1": {DbName: "db1", BlockNum: 1, StorageBytes: 30, MinuteStart: int64(10 * time.Minute), MinuteTxs: 1},
+	}
+
+	dataTx := func(ops ...*types.DBOperation) *types.DataTxEnvelope {
+		return testutils.SignedDataTxEnvelope(t, []crypto.Signer{userSigner}, &types.DataTx{
+			MustSignUserIds: []string{"operatingUser"},
+			DbOperations:    ops,
+		})
+	}
+	block := &types.Block{
+		Header: &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{
+				Number:    2,
+				Timestamp: timestamp,
+			},
+		},
+		Payload: &types.Block_DataTxEnvelopes{
+			DataTxEnvelopes: &types.DataTxEnvelopes{
+				Envelopes: []*types.DataTxEnvelope{
+					dataTx(&types.DBOperation{
+						DbName:     "db1",
+						DataWrites: []*types.DataWrite{{Key: "k1", Value: []byte("value1")}},
+					}),
+					dataTx(&types.DBOperation{
+						DbName:     "db1",
+						DataWrites: []*types.DataWrite{{Key: "k2", Value: []byte("a-longer-value")}},
+					}),
+					dataTx(&types.DBOperation{
+						DbName:     "db2",
+						DataWrites: []*types.DataWrite{{Key: "k1", Value: []byte("value1")}},
+					}),
+					dataTx(&types.DBOperation{DbName: "db1"}),
+					dataTx(&types.DBOperation{DbName: "db1"}),
+				},
+			},
+		},
+	}
+
+	results, err := env.validator.ValidateBlock(block)
+	require.NoError(t, err)
+	require.Equal(t, []*types.ValidationInfo{
+		{
+			Flag: types.Flag_VALID,
+		},
+		{
+			Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+			ReasonIfInvalid: "the transaction would make the storage of the database [db1] reach 54 bytes, which exceeds the hard limit of 50 bytes",
+			FailedOperation: &types.DBOperationFailure{DbName: "db1", Check: types.DBOperationCheck_QUOTA_CHECK},
+		},
+		{
+			Flag: types.Flag_VALID,
+		},
+		{
+			Flag: types.Flag_VALID,
+		},
+		{
+			Flag:            types.Flag_INVALID_QUOTA_EXCEEDED,
+			ReasonIfInvalid: "the transaction would make the number of transactions on the database [db1] in a minute reach 4, which exceeds the hard limit of 3",
+			FailedOperation: &types.DBOperationFailure{DbName: "db1", Check: types.DBOperationCheck_QUOTA_CHECK},
+		},
+	}, results)
+
+	// the transactions accounted in a previous minute do not count
+	env.validator.dbUsage = dbUsages{
+		"db1": {DbName: "db1", BlockNum: 1, StorageBytes: 30, MinuteStart: int64(9 * time.Minute), MinuteTxs: 3},
+	}
+	results, err = env.validator.ValidateBlock(block)
+	require.NoError(t, err)
+	require.Equal(t, types.Flag_VALID, results[0].Flag)
+	require.Equal(t, types.Flag_VALID, results[3].Flag)
+	require.Equal(t, types.Flag_VALID, results[4].Flag)
+}
+
 func TestValidateUserBlock(t *testing.T) {
 	t.Parallel()
 
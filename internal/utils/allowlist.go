@@ -0,0 +1,84 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+// ParseCIDRs parses a list of networks in CIDR notation
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid network [%s]", cidr)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// IsAllowedAddr returns whether the IP of an address is in one of the allowed networks
+func IsAllowedAddr(addr net.Addr, allowed []*net.IPNet) bool {
+	var ip net.IP
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ip = a.IP
+	default:
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return false
+		}
+		ip = net.ParseIP(host)
+	}
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range allowed {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAllowListListener wraps a listener so that the connections from addresses outside of the allowed networks are
+// closed as soon as they are accepted. If no network is allowed, the listener is returned as is, i.e., all networks are
+// allowed.
+func NewAllowListListener(l net.Listener, allowed []*net.IPNet, lg *logger.SugarLogger) net.Listener {
+	if len(allowed) == 0 {
+		return l
+	}
+	return &allowListListener{
+		Listener: l,
+		allowed:  allowed,
+		logger:   lg,
+	}
+}
+
+type allowListListener struct {
+	net.Listener
+	allowed []*net.IPNet
+	logger  *logger.SugarLogger
+}
+
+func (l *allowListListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if IsAllowedAddr(conn.RemoteAddr(), l.allowed) {
+			return conn, nil
+		}
+
+		l.logger.Warnf("Rejected a connection from [%s] to [%s], which is not in the allowed networks", conn.RemoteAddr(), l.Addr())
+		conn.Close()
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package utils
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCIDRs(t *testing.T) {
+	nets, err := ParseCIDRs([]string{"10.0.0.0/8", "::1/128"})
+	require.NoError(t, err)
+	require.Len(t, nets, 2)
+
+	require.True(t, IsAllowedAddr(&net.TCPAddr{IP: net.ParseIP("10.1.2.3")}, nets))
+	require.True(t, IsAllowedAddr(&net.TCPAddr{IP: net.ParseIP("::1")}, nets))
+	require.False(t, IsAllowedAddr(&net.TCPAddr{IP: net.ParseIP("192.168.0.1")}, nets))
+	require.True(t, IsAllowedAddr(&net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 80}, nets))
+
+	nets, err = ParseCIDRs([]string{"10.0.0.0/8", "10.0.0.1"})
+	require.EqualError(t, err, "invalid network [10.0.0.1]: invalid CIDR address: 10.0.0.1")
+	require.Nil(t, nets)
+}
+
+func TestAllowListListener(t *testing.T) {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+		Name:          "test",
+	})
+	require.NoError(t, err)
+
+	accept := func(allowed []string) net.Conn {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer l.Close()
+
+		nets, err := ParseCIDRs(allowed)
+		require.NoError(t, err)
+		l = NewAllowListListener(l, nets, lg)
+
+		conn, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		accepted := make(chan net.Conn, 1)
+		go func() {
+			c, err := l.Accept()
+			if err == nil {
+				accepted <- c
+			}
+		}()
+
+		select {
+		case c := <-accepted:
+			return c
+		case <-time.After(500 * time.Millisecond):
+			return nil
+		}
+	}
+
+	conn := accept(nil)
+	require.NotNil(t, conn)
+	conn.Close()
+
+	conn = accept([]string{"127.0.0.0/8"})
+	require.NotNil(t, conn)
+	conn.Close()
+
+	conn = accept([]string{"10.0.0.0/8"})
+	require.Nil(t, conn)
+}
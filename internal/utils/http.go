@@ -10,18 +10,50 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 )
 
 const MultiPartFormData = "multipart/form-data"
 
-// SendHTTPResponse writes HTTP response back including HTTP code number and encode payload
+// SendHTTPResponse writes HTTP response back including HTTP code number and encode payload.
+// The JSON encoding of the payload is buffered in a pooled buffer and sent with a Content-Length header, unless it is
+// large enough to be streamed as it is encoded, see ResponseEncodingConfig.
 func SendHTTPResponse(w http.ResponseWriter, code int, payload interface{}) {
-	response, _ := json.Marshal(payload)
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	if _, err := w.Write(response); err != nil {
+
+	buf := getEncodingBuffer()
+	defer putEncodingBuffer(buf)
+
+	sw := &spillWriter{
+		w:         w,
+		code:      code,
+		buf:       buf,
+		threshold: atomic.LoadUint64(&streamThresholdBytes),
+	}
+	err := streamJSON(sw, payload)
+	if err == nil {
+		// the encoding is terminated by a newline, as json.Encoder does
+		_, err = sw.Write([]byte{'\n'})
+	}
+	if err != nil {
+		// a response that is streamed already cannot be replaced by an error
+		if sw.streaming {
+			log.Printf("Warning: failed to stream response [%v] to the response writer: %s\n", w, err)
+			return
+		}
+		sw.code = http.StatusInternalServerError
+		buf.Reset()
+		_ = json.NewEncoder(buf).Encode(&types.HttpResponseErr{ErrMsg: "failed to encode the response: " + err.Error()})
+	}
+	if sw.streaming {
+		return
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(sw.code)
+	if _, err := w.Write(buf.Bytes()); err != nil {
 		log.Printf("Warning: failed to write response [%v] to the response writer\n", w)
 	}
 }
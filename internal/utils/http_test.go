@@ -4,14 +4,17 @@ package utils
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
 
-	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,6 +57,153 @@ func TestSendHTTPResponse(t *testing.T) {
 	})
 }
 
+func TestSendHTTPResponseEncoding(t *testing.T) {
+	defer ConfigureResponseEncoding(&ResponseEncodingConfig{})
+
+	block := &types.GetBlockResponseEnvelope{
+		Response: &types.GetBlockResponse{
+			Header: &types.ResponseHeader{
+				NodeId: "testID",
+			},
+			BlockHeader: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                 10,
+					PreviousBaseHeaderHash: bytes.Repeat([]byte{1}, 64),
+				},
+			},
+		},
+		Signature: []byte("<html>"),
+	}
+	expected, err := json.Marshal(block)
+	require.NoError(t, err)
+
+	t.Run("buffered", func(t *testing.T) {
+		ConfigureResponseEncoding(&ResponseEncodingConfig{})
+
+		w := httptest.NewRecorder()
+		SendHTTPResponse(w, http.StatusOK, block)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, strconv.Itoa(len(expected)+1), w.Header().Get("Content-Length"))
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, append(expected, '\n'), w.Body.Bytes())
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		ConfigureResponseEncoding(&ResponseEncodingConfig{StreamThresholdBytes: 64})
+
+		w := httptest.NewRecorder()
+		SendHTTPResponse(w, http.StatusOK, block)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Header().Get("Content-Length"))
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, append(expected, '\n'), w.Body.Bytes())
+	})
+
+	t.Run("encoding failure", func(t *testing.T) {
+		ConfigureResponseEncoding(&ResponseEncodingConfig{})
+
+		w := httptest.NewRecorder()
+		SendHTTPResponse(w, http.StatusOK, map[string]interface{}{"ch": make(chan int)})
+
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		actualErr := &types.HttpResponseErr{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), actualErr))
+		require.Equal(t, "failed to encode the response: json: unsupported type: chan int", actualErr.ErrMsg)
+	})
+
+	t.Run("encode JSON", func(t *testing.T) {
+		ConfigureResponseEncoding(&ResponseEncodingConfig{MaxPooledBufferBytes: 16})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, EncodeJSON(block, func(encoded []byte) error {
+				require.Equal(t, expected, encoded)
+				return nil
+			}))
+		}
+
+		err := EncodeJSON(block, func(encoded []byte) error {
+			return errors.New("sign failed")
+		})
+		require.EqualError(t, err, "sign failed")
+	})
+}
+
+type pointerMarshaler struct {
+	Value string
+}
+
+func (p *pointerMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`"custom"`), nil
+}
+
+type embedding struct {
+	pointerMarshaler
+	Other int `json:"other"`
+}
+
+func TestStreamJSON(t *testing.T) {
+	values := []interface{}{
+		nil,
+		&types.GetBlockResponseEnvelope{},
+		&types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader:     &types.BlockHeaderBase{Number: 10},
+				ValidationInfo: []*types.ValidationInfo{{Flag: types.Flag_VALID}, {Flag: types.Flag_INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE, ReasonIfInvalid: "<conflict> & more"}},
+			},
+			Payload: &types.Block_DataTxEnvelopes{
+				DataTxEnvelopes: &types.DataTxEnvelopes{
+					Envelopes: []*types.DataTxEnvelope{
+						{
+							Payload: &types.DataTx{
+								MustSignUserIds: []string{"alice", "bob"},
+								TxId:            "tx1",
+								DbOperations: []*types.DBOperation{
+									{
+										DbName:     "db1",
+										DataWrites: []*types.DataWrite{{Key: "key1", Value: []byte{0, 1, 0xff}, Acl: &types.AccessControl{ReadUsers: map[string]bool{"carol": true, "bob": false}}}},
+									},
+								},
+							},
+							Signatures: map[string][]byte{"bob": []byte("sig2"), "alice": []byte("sig1")},
+						},
+					},
+				},
+			},
+		},
+		map[string]interface{}{"b": []int{1, 2}, "a": nil, "c": map[int]string{2: "x", 1: "y"}},
+		[]interface{}{pointerMarshaler{Value: "v"}, &pointerMarshaler{}, []pointerMarshaler{{}}, [2]byte{1, 2}, 1.5, "\u2028"},
+		struct {
+			Named   string `json:"named,omitempty"`
+			Empty   string `json:",omitempty"`
+			Skipped int    `json:"-"`
+			hidden  int
+		}{Named: "n"},
+		embedding{Other: 1},
+		struct {
+			Quoted int64 `json:"quoted,string"`
+		}{Quoted: 7},
+	}
+
+	for _, v := range values {
+		expected, err := json.Marshal(v)
+		require.NoError(t, err)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, streamJSON(buf, v))
+		require.Equal(t, string(expected), buf.String())
+
+		hash, err := HashJSON(v)
+		require.NoError(t, err)
+		expectedHash := sha256.Sum256(expected)
+		require.Equal(t, expectedHash[:], hash)
+	}
+
+	_, err := HashJSON([]interface{}{1, make(chan int)})
+	require.EqualError(t, err, "json: unsupported type: chan int")
+}
+
 func TestSendHTTPRedirectServer(t *testing.T) {
 	w := httptest.NewRecorder()
 
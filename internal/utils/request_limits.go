@@ -0,0 +1,37 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+// errRequestBodyTooLarge is the message of the error returned by the reader of http.MaxBytesReader once the limit is
+// exceeded
+const errRequestBodyTooLarge = "http: request body too large"
+
+// LimitRequestBody limits the body of a request to maxBytes. A request whose Content-Length exceeds the limit is
+// rejected with 413 before its body is read, in which case it returns true. Otherwise, reading the body beyond the
+// limit fails, see IsRequestBodyTooLarge.
+func LimitRequestBody(w http.ResponseWriter, r *http.Request, maxBytes int64) bool {
+	if r.ContentLength > maxBytes {
+		SendHTTPResponse(w, http.StatusRequestEntityTooLarge,
+			&types.HttpResponseErr{ErrMsg: fmt.Sprintf("the body of the request is larger than the limit of %d bytes", maxBytes)})
+		return true
+	}
+
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	}
+	return false
+}
+
+// IsRequestBodyTooLarge returns whether reading or decoding a request body failed because the body exceeds the limit
+// set by LimitRequestBody
+func IsRequestBodyTooLarge(err error) bool {
+	return err != nil && err.Error() == errRequestBodyTooLarge
+}
@@ -0,0 +1,394 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+const (
+	// DefaultStreamThresholdBytes is the default size of the JSON encoding of a response above which the response is
+	// streamed to the client rather than buffered.
+	DefaultStreamThresholdBytes = 1024 * 1024
+	// DefaultMaxPooledBufferBytes is the default capacity of the largest encoding buffer that is returned to the pool.
+	DefaultMaxPooledBufferBytes = 256 * 1024
+)
+
+// ResponseEncodingConfig holds the parameters of the JSON encoding of responses
+type ResponseEncodingConfig struct {
+	// StreamThresholdBytes is the size of the JSON encoding of a response, e.g., a block or the result of a query,
+	// above which the encoding is streamed to the client without copying it into a buffer and without a
+	// Content-Length header. Smaller responses are encoded into a pooled buffer. If zero, a default is used.
+	StreamThresholdBytes uint64
+	// MaxPooledBufferBytes is the capacity of the largest encoding buffer that is returned to the pool, so that the
+	// pool does not pin the memory of an occasional large response. If zero, a default is used.
+	MaxPooledBufferBytes uint64
+}
+
+var (
+	streamThresholdBytes uint64 = DefaultStreamThresholdBytes
+	maxPooledBufferBytes uint64 = DefaultMaxPooledBufferBytes
+
+	encodingBufferPool = sync.Pool{
+		New: func() interface{} {
+			return &bytes.Buffer{}
+		},
+	}
+)
+
+// ConfigureResponseEncoding sets the parameters of the JSON encoding of all responses. It is called once when the
+// server starts, and zero parameters are replaced by their defaults.
+func ConfigureResponseEncoding(conf *ResponseEncodingConfig) {
+	threshold := conf.StreamThresholdBytes
+	if threshold == 0 {
+		threshold = DefaultStreamThresholdBytes
+	}
+	maxPooled := conf.MaxPooledBufferBytes
+	if maxPooled == 0 {
+		maxPooled = DefaultMaxPooledBufferBytes
+	}
+
+	atomic.StoreUint64(&streamThresholdBytes, threshold)
+	atomic.StoreUint64(&maxPooledBufferBytes, maxPooled)
+}
+
+// EncodeJSON encodes v into a pooled buffer and calls fn with the encoding, which is identical to the output of
+// json.Marshal. The encoding is valid only until fn returns, as the buffer is then returned to the pool.
+func EncodeJSON(v interface{}, fn func(encoded []byte) error) error {
+	buf := getEncodingBuffer()
+	defer putEncodingBuffer(buf)
+
+	if err := json.NewEncoder(buf).Encode(v); err != nil {
+		return err
+	}
+
+	// json.Encoder terminates every value with a newline, which json.Marshal does not
+	return fn(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+}
+
+// HashJSON returns the SHA-256 hash of the JSON encoding of v, which is identical to the output of json.Marshal. The
+// encoding is hashed as it is produced, hence a large response is signed without holding its encoding in memory.
+func HashJSON(v interface{}) ([]byte, error) {
+	h := sha256.New()
+	if err := streamJSON(h, v); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// spillWriter buffers the JSON encoding of a response, and switches to writing directly to the response writer once
+// the encoding exceeds the stream threshold. As streamJSON writes the encoding as it is produced, a large response is
+// never held in memory as a whole.
+type spillWriter struct {
+	w         http.ResponseWriter
+	code      int
+	buf       *bytes.Buffer
+	threshold uint64
+	streaming bool
+}
+
+func (s *spillWriter) Write(p []byte) (int, error) {
+	if !s.streaming && uint64(s.buf.Len()+len(p)) <= s.threshold {
+		return s.buf.Write(p)
+	}
+
+	if !s.streaming {
+		s.streaming = true
+		s.w.WriteHeader(s.code)
+		if s.buf.Len() > 0 {
+			if _, err := s.w.Write(s.buf.Bytes()); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return s.w.Write(p)
+}
+
+var (
+	jsonNull = []byte("null")
+
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// jsonStructFields caches the fields of the struct types walked by streamJSON, keyed by their type
+	jsonStructFields sync.Map
+)
+
+// jsonField is a field of a struct as it is encoded by encoding/json
+type jsonField struct {
+	index     int
+	name      []byte // the encoded name followed by a colon
+	omitEmpty bool
+}
+
+// jsonStructEncoding holds the fields of a struct type, or walk=false if the struct is encoded by json.Marshal as a
+// whole, since its fields are resolved by rules that streamJSON does not replicate, such as embedded structs
+type jsonStructEncoding struct {
+	walk   bool
+	fields []jsonField
+}
+
+// streamJSON writes the JSON encoding of v to w as it is produced. The output is identical to that of json.Marshal:
+// structs, slices, arrays, maps with string keys, pointers and interfaces are walked, while any other value, as well
+// as any value that customizes its encoding, is encoded by json.Marshal. The writes are small, hence w is expected to
+// buffer them.
+func streamJSON(w io.Writer, v interface{}) error {
+	s := &jsonStreamer{w: w}
+	s.value(reflect.ValueOf(v))
+	return s.err
+}
+
+type jsonStreamer struct {
+	w   io.Writer
+	err error
+}
+
+func (s *jsonStreamer) write(p []byte) {
+	if s.err == nil {
+		_, s.err = s.w.Write(p)
+	}
+}
+
+func (s *jsonStreamer) value(v reflect.Value) {
+	if s.err != nil {
+		return
+	}
+	if !v.IsValid() {
+		s.write(jsonNull)
+		return
+	}
+
+	if customJSONEncoding(v.Type()) {
+		s.marshal(v)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			s.write(jsonNull)
+			return
+		}
+		s.value(v.Elem())
+	case reflect.Struct:
+		s.structValue(v)
+	case reflect.Slice:
+		// a byte slice is encoded in base64 by json.Marshal
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			s.marshal(v)
+			return
+		}
+		s.array(v)
+	case reflect.Array:
+		s.array(v)
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			s.marshal(v)
+			return
+		}
+		s.mapValue(v)
+	default:
+		s.marshal(v)
+	}
+}
+
+// marshal encodes a value by json.Marshal, which calls the pointer receiver methods of an addressable value
+func (s *jsonStreamer) marshal(v reflect.Value) {
+	i := v.Interface()
+	if v.CanAddr() && v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
+		i = v.Addr().Interface()
+	}
+
+	encoded, err := json.Marshal(i)
+	if err != nil {
+		s.err = err
+		return
+	}
+	s.write(encoded)
+}
+
+func (s *jsonStreamer) structValue(v reflect.Value) {
+	enc := structEncoding(v.Type())
+	if !enc.walk {
+		s.marshal(v)
+		return
+	}
+
+	s.write([]byte{'{'})
+	first := true
+	for _, f := range enc.fields {
+		fv := v.Field(f.index)
+		if f.omitEmpty && isEmptyJSONValue(fv) {
+			continue
+		}
+		if !first {
+			s.write([]byte{','})
+		}
+		first = false
+		s.write(f.name)
+		s.value(fv)
+	}
+	s.write([]byte{'}'})
+}
+
+func (s *jsonStreamer) array(v reflect.Value) {
+	s.write([]byte{'['})
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			s.write([]byte{','})
+		}
+		s.value(v.Index(i))
+	}
+	s.write([]byte{']'})
+}
+
+// mapValue encodes a map with string keys, which json.Marshal sorts
+func (s *jsonStreamer) mapValue(v reflect.Value) {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	s.write([]byte{'{'})
+	for i, k := range keys {
+		if i > 0 {
+			s.write([]byte{','})
+		}
+		name, err := json.Marshal(k.String())
+		if err != nil {
+			s.err = err
+			return
+		}
+		s.write(name)
+		s.write([]byte{':'})
+		s.value(v.MapIndex(k))
+	}
+	s.write([]byte{'}'})
+}
+
+func customJSONEncoding(t reflect.Type) bool {
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return true
+	}
+	if t.Kind() != reflect.Ptr {
+		pt := reflect.PtrTo(t)
+		return pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType)
+	}
+	return false
+}
+
+func structEncoding(t reflect.Type) *jsonStructEncoding {
+	if enc, ok := jsonStructFields.Load(t); ok {
+		return enc.(*jsonStructEncoding)
+	}
+
+	enc := &jsonStructEncoding{walk: true}
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous {
+			enc = &jsonStructEncoding{}
+			break
+		}
+		if sf.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx != -1 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		if !isValidJSONTag(name) {
+			name = sf.Name
+		}
+		omitEmpty := false
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "omitempty":
+				omitEmpty = true
+			case "string":
+				enc = &jsonStructEncoding{}
+			}
+		}
+		if !enc.walk || names[name] {
+			enc = &jsonStructEncoding{}
+			break
+		}
+		names[name] = true
+
+		encodedName, err := json.Marshal(name)
+		if err != nil {
+			enc = &jsonStructEncoding{}
+			break
+		}
+		enc.fields = append(enc.fields, jsonField{
+			index:     i,
+			name:      append(encodedName, ':'),
+			omitEmpty: omitEmpty,
+		})
+	}
+
+	actual, _ := jsonStructFields.LoadOrStore(t, enc)
+	return actual.(*jsonStructEncoding)
+}
+
+// isValidJSONTag reports whether encoding/json accepts a tag as the name of a field
+func isValidJSONTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyJSONValue reports whether a field with the omitempty option is omitted by encoding/json
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+func getEncodingBuffer() *bytes.Buffer {
+	return encodingBufferPool.Get().(*bytes.Buffer)
+}
+
+func putEncodingBuffer(buf *bytes.Buffer) {
+	if uint64(buf.Cap()) > atomic.LoadUint64(&maxPooledBufferBytes) {
+		return
+	}
+	buf.Reset()
+	encodingBufferPool.Put(buf)
+}
@@ -0,0 +1,322 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package witness
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+)
+
+const (
+	// defaultPullBatchSize is the maximal number of blocks requested from a member in a single pull.
+	defaultPullBatchSize = 100
+	// maxRetainedAlerts is the number of most recent alerts retained in memory.
+	maxRetainedAlerts = 1000
+)
+
+type LedgerReader interface {
+	Height() (uint64, error)
+	Get(blockNumber uint64) (*types.Block, error)
+}
+
+// BlockPuller pulls blocks from the consensus members.
+type BlockPuller interface {
+	PullBlocks(ctx context.Context, start, end, leaderHint uint64) ([]*types.Block, error)
+	UpdateMembers(memberList []*types.PeerConfig) error
+}
+
+// Alert describes a discrepancy between a block received from the consensus members and the same block as
+// re-validated by the witness.
+type Alert struct {
+	BlockNumber uint64
+	Reason      string
+	Time        time.Time
+}
+
+func (a *Alert) String() string {
+	return fmt.Sprintf("block [%d]: %s", a.BlockNumber, a.Reason)
+}
+
+// Witness follows the ledger of the cluster without taking part in consensus. It pulls blocks from the consensus
+// members and commits them through the local block processor, which re-validates the transactions and re-computes the
+// trie roots. The outcome is compared to the header of the block that was received, and every discrepancy raises an
+// alert.
+//
+// A Witness is created with New and starts following the ledger with Start. The block processor must be started as
+// well, as blocks are committed through the OneQueueBarrier. To stop the Witness call Close.
+type Witness struct {
+	ledgerReader      LedgerReader
+	oneQueueBarrier   *queue.OneQueueBarrier
+	puller            BlockPuller
+	pullBatchSize     uint64
+	haltOnDiscrepancy bool
+	alertHandler      func(alert *Alert)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	mutex          sync.Mutex
+	alerts         []*Alert
+	verifiedHeight uint64
+	halted         bool
+
+	lg *logger.SugarLogger
+}
+
+// Config holds the configuration information required to initialize the witness.
+type Config struct {
+	LedgerReader         LedgerReader
+	BlockOneQueueBarrier *queue.OneQueueBarrier
+	BlockPuller          BlockPuller
+	// PullBatchSize is the maximal number of blocks requested in a single pull; if zero, a default is used.
+	PullBatchSize uint64
+	// HaltOnDiscrepancy stops following the ledger after the first discrepancy.
+	HaltOnDiscrepancy bool
+	// AlertHandler, if not nil, is called with every alert raised, in addition to the alert being logged.
+	AlertHandler func(alert *Alert)
+	Logger       *logger.SugarLogger
+}
+
+// New creates a new Witness.
+func New(conf *Config) *Witness {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w := &Witness{
+		ledgerReader:      conf.LedgerReader,
+		oneQueueBarrier:   conf.BlockOneQueueBarrier,
+		puller:            conf.BlockPuller,
+		pullBatchSize:     defaultPullBatchSize,
+		haltOnDiscrepancy: conf.HaltOnDiscrepancy,
+		alertHandler:      conf.AlertHandler,
+		ctx:               ctx,
+		cancel:            cancel,
+		doneCh:            make(chan struct{}),
+		lg:                conf.Logger,
+	}
+
+	if conf.PullBatchSize > 0 {
+		w.pullBatchSize = conf.PullBatchSize
+	}
+
+	return w
+}
+
+// Start starts following the ledger in a go-routine.
+func (w *Witness) Start() {
+	go w.run()
+}
+
+// Close stops following the ledger and waits for the go-routine to exit.
+func (w *Witness) Close() {
+	w.cancel()
+	<-w.doneCh
+}
+
+// Alerts returns the most recent alerts, oldest first.
+func (w *Witness) Alerts() []*Alert {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	alerts := make([]*Alert, len(w.alerts))
+	copy(alerts, w.alerts)
+	return alerts
+}
+
+// Status returns the height up to which the ledger was verified, and whether the witness stopped following the
+// ledger due to a discrepancy.
+func (w *Witness) Status() (verifiedHeight uint64, halted bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.verifiedHeight, w.halted
+}
+
+func (w *Witness) run() {
+	defer close(w.doneCh)
+
+	w.lg.Info("Starting the witness")
+	for {
+		height, err := w.ledgerReader.Height()
+		if err != nil {
+			w.lg.Panicf("Failed to read the ledger height: %s", err)
+		}
+
+		w.mutex.Lock()
+		if w.verifiedHeight == 0 {
+			w.verifiedHeight = height
+		}
+		w.mutex.Unlock()
+
+		// PullBlocks retries with back-off until some blocks are available, and returns an error only when canceled.
+		blocks, err := w.puller.PullBlocks(w.ctx, height+1, height+w.pullBatchSize, 0)
+		if err != nil {
+			select {
+			case <-w.ctx.Done():
+				w.lg.Info("Stopping the witness")
+				return
+			default:
+				w.lg.Warnf("Failed to pull blocks starting from [%d]: %s", height+1, err)
+				continue
+			}
+		}
+
+		for i, block := range blocks {
+			if verified := w.verifyAndCommit(block, height+1+uint64(i)); !verified {
+				if w.ctx.Err() != nil {
+					w.lg.Info("Stopping the witness")
+					return
+				}
+				if w.haltOnDiscrepancy {
+					w.halt()
+					return
+				}
+				break // pull again from the current height of the ledger
+			}
+		}
+	}
+}
+
+// verifyAndCommit verifies that a block extends the local ledger, commits it through the block processor, and
+// compares the outcome of the local validation to the header of the block received. Every discrepancy raises an
+// alert, and false is returned. A block that does not extend the local ledger is not committed. A block whose
+// validation outcome differs is committed nonetheless, with the outcome computed by the witness.
+func (w *Witness) verifyAndCommit(block *types.Block, expectedNumber uint64) bool {
+	number := block.GetHeader().GetBaseHeader().GetNumber()
+	if number != expectedNumber {
+		w.raiseAlert(number, fmt.Sprintf("received block number [%d] while expecting block number [%d]", number, expectedNumber))
+		return false
+	}
+
+	if number > 1 {
+		prevBlock, err := w.ledgerReader.Get(number - 1)
+		if err != nil {
+			w.lg.Panicf("Failed to read block [%d] from the ledger: %s", number-1, err)
+		}
+		prevBaseHash, err := blockstore.ComputeBlockBaseHash(prevBlock)
+		if err != nil {
+			w.lg.Panicf("Failed to compute the base hash of block [%d]: %s", number-1, err)
+		}
+		if !bytes.Equal(prevBaseHash, block.GetHeader().GetBaseHeader().GetPreviousBaseHeaderHash()) {
+			w.raiseAlert(number, fmt.Sprintf("previous base header hash [%x] does not match the base header hash of block [%d] in the local ledger [%x]",
+				block.GetHeader().GetBaseHeader().GetPreviousBaseHeaderHash(), number-1, prevBaseHash))
+			return false
+		}
+	}
+
+	// The block processor overwrites the validation info and the roots with the ones it computes
+	received := proto.Clone(block.GetHeader()).(*types.BlockHeader)
+
+	reConfig, err := w.oneQueueBarrier.EnqueueWait(block)
+	if err != nil {
+		w.lg.Warnf("Failed to commit block [%d]: %s", number, err)
+		return false
+	}
+
+	if reConfig != nil {
+		clusterConfig := reConfig.(*types.ClusterConfig)
+		w.lg.Infof("New cluster config committed in block [%d], updating the members to pull blocks from", number)
+		if err := w.puller.UpdateMembers(clusterConfig.GetConsensusConfig().GetMembers()); err != nil {
+			w.lg.Errorf("Failed to update the members to pull blocks from: %s", err)
+		}
+	}
+
+	reasons := compareHeaders(received, block.GetHeader())
+	for _, reason := range reasons {
+		w.raiseAlert(number, reason)
+	}
+	if len(reasons) > 0 {
+		return false
+	}
+
+	w.mutex.Lock()
+	w.verifiedHeight = number
+	w.mutex.Unlock()
+
+	return true
+}
+
+// compareHeaders returns a description of every difference between the validation outcome in the received header and
+// the one computed by the witness.
+func compareHeaders(received, computed *types.BlockHeader) []string {
+	var reasons []string
+
+	receivedInfo := received.GetValidationInfo()
+	computedInfo := computed.GetValidationInfo()
+	if len(receivedInfo) != len(computedInfo) {
+		reasons = append(reasons, fmt.Sprintf("validation info has [%d] entries, re-validation produced [%d] entries",
+			len(receivedInfo), len(computedInfo)))
+	} else {
+		for i := range receivedInfo {
+			if !proto.Equal(receivedInfo[i], computedInfo[i]) {
+				reasons = append(reasons, fmt.Sprintf("tx [%d] validation info is [%s], re-validation produced [%s]",
+					i, receivedInfo[i].String(), computedInfo[i].String()))
+			}
+		}
+	}
+
+	if !bytes.Equal(received.GetTxMerkelTreeRootHash(), computed.GetTxMerkelTreeRootHash()) {
+		reasons = append(reasons, fmt.Sprintf("tx merkle tree root hash is [%x], re-computed [%x]",
+			received.GetTxMerkelTreeRootHash(), computed.GetTxMerkelTreeRootHash()))
+	}
+
+	if !bytes.Equal(received.GetStateMerkelTreeRootHash(), computed.GetStateMerkelTreeRootHash()) {
+		reasons = append(reasons, fmt.Sprintf("state merkle-patricia trie root hash is [%x], re-computed [%x]",
+			received.GetStateMerkelTreeRootHash(), computed.GetStateMerkelTreeRootHash()))
+	}
+
+	receivedDBRoots := received.GetDbStateRootHashes()
+	computedDBRoots := computed.GetDbStateRootHashes()
+	if len(receivedDBRoots) != len(computedDBRoots) {
+		reasons = append(reasons, fmt.Sprintf("state trie root hashes of [%d] databases, re-computed [%d] databases",
+			len(receivedDBRoots), len(computedDBRoots)))
+	} else {
+		for i := range receivedDBRoots {
+			if !proto.Equal(receivedDBRoots[i], computedDBRoots[i]) {
+				reasons = append(reasons, fmt.Sprintf("state trie root hash of database [%s] is [%x], re-computed [%s] with [%x]",
+					receivedDBRoots[i].GetDbName(), receivedDBRoots[i].GetRootHash(),
+					computedDBRoots[i].GetDbName(), computedDBRoots[i].GetRootHash()))
+			}
+		}
+	}
+
+	return reasons
+}
+
+func (w *Witness) raiseAlert(blockNumber uint64, reason string) {
+	alert := &Alert{
+		BlockNumber: blockNumber,
+		Reason:      reason,
+		Time:        time.Now(),
+	}
+	w.lg.Errorf("Witness alert: %s", alert)
+
+	w.mutex.Lock()
+	w.alerts = append(w.alerts, alert)
+	if len(w.alerts) > maxRetainedAlerts {
+		w.alerts = w.alerts[len(w.alerts)-maxRetainedAlerts:]
+	}
+	w.mutex.Unlock()
+
+	if w.alertHandler != nil {
+		w.alertHandler(alert)
+	}
+}
+
+func (w *Witness) halt() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.halted = true
+	w.lg.Errorf("Witness halted at verified height [%d], following the ledger stopped due to a discrepancy", w.verifiedHeight)
+}
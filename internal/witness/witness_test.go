@@ -0,0 +1,247 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package witness
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/internal/queue"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type memLedger struct {
+	mutex  sync.Mutex
+	blocks []*types.Block
+}
+
+func (l *memLedger) Height() (uint64, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return uint64(len(l.blocks)), nil
+}
+
+func (l *memLedger) Get(blockNumber uint64) (*types.Block, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if blockNumber == 0 || blockNumber > uint64(len(l.blocks)) {
+		return nil, errors.Errorf("block [%d] not found", blockNumber)
+	}
+	return l.blocks[blockNumber-1], nil
+}
+
+func (l *memLedger) append(block *types.Block) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.blocks = append(l.blocks, block)
+}
+
+// chainPuller serves blocks from a chain, and blocks until canceled when no blocks are available.
+type chainPuller struct {
+	chain []*types.Block
+}
+
+func (p *chainPuller) PullBlocks(ctx context.Context, start, end, _ uint64) ([]*types.Block, error) {
+	if start > uint64(len(p.chain)) {
+		<-ctx.Done()
+		return nil, errors.WithMessage(ctx.Err(), "PullBlocks canceled")
+	}
+	if end > uint64(len(p.chain)) {
+		end = uint64(len(p.chain))
+	}
+
+	var blocks []*types.Block
+	for n := start; n <= end; n++ {
+		blocks = append(blocks, proto.Clone(p.chain[n-1]).(*types.Block))
+	}
+	return blocks, nil
+}
+
+func (p *chainPuller) UpdateMembers(_ []*types.PeerConfig) error {
+	return nil
+}
+
+// newChain creates a chain of blocks with valid base header links, carrying the validation outcome the fake block
+// processor computes.
+func newChain(t *testing.T, numBlocks uint64) []*types.Block {
+	var chain []*types.Block
+	var prevBaseHash []byte
+	for n := uint64(1); n <= numBlocks; n++ {
+		block := &types.Block{
+			Header: &types.BlockHeader{
+				BaseHeader: &types.BlockHeaderBase{
+					Number:                 n,
+					PreviousBaseHeaderHash: prevBaseHash,
+				},
+			},
+		}
+		computeHeader(block)
+		chain = append(chain, block)
+
+		var err error
+		prevBaseHash, err = blockstore.ComputeBlockBaseHash(block)
+		require.NoError(t, err)
+	}
+	return chain
+}
+
+func computeHeader(block *types.Block) {
+	n := block.GetHeader().GetBaseHeader().GetNumber()
+	block.Header.ValidationInfo = []*types.ValidationInfo{{Flag: types.Flag_VALID}}
+	block.Header.TxMerkelTreeRootHash = []byte(fmt.Sprintf("tx-root-%d", n))
+	block.Header.StateMerkelTreeRootHash = []byte(fmt.Sprintf("state-root-%d", n))
+	block.Header.DbStateRootHashes = []*types.DBStateRootHash{
+		{DbName: "bdb", RootHash: []byte(fmt.Sprintf("bdb-state-root-%d", n))},
+	}
+}
+
+type witnessTestEnv struct {
+	ledger  *memLedger
+	barrier *queue.OneQueueBarrier
+	witness *Witness
+	doneCh  chan struct{}
+}
+
+// newWitnessTestEnv starts a witness over the chain, with a fake block processor that re-computes the header of every
+// block it commits.
+func newWitnessTestEnv(t *testing.T, chain []*types.Block, haltOnDiscrepancy bool) *witnessTestEnv {
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	env := &witnessTestEnv{
+		ledger:  &memLedger{},
+		barrier: queue.NewOneQueueBarrier(lg),
+		doneCh:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(env.doneCh)
+		for {
+			entry, err := env.barrier.Dequeue()
+			if err != nil {
+				return
+			}
+			block := entry.(*types.Block)
+			computeHeader(block)
+			env.ledger.append(block)
+			if err = env.barrier.Reply(nil); err != nil {
+				return
+			}
+		}
+	}()
+
+	env.witness = New(&Config{
+		LedgerReader:         env.ledger,
+		BlockOneQueueBarrier: env.barrier,
+		BlockPuller:          &chainPuller{chain: chain},
+		PullBatchSize:        3,
+		HaltOnDiscrepancy:    haltOnDiscrepancy,
+		Logger:               lg,
+	})
+	env.witness.Start()
+
+	t.Cleanup(func() {
+		env.witness.Close()
+		require.NoError(t, env.barrier.Close())
+		<-env.doneCh
+	})
+
+	return env
+}
+
+func TestWitness_Verified(t *testing.T) {
+	chain := newChain(t, 10)
+	env := newWitnessTestEnv(t, chain, false)
+
+	require.Eventually(t, func() bool {
+		h, halted := env.witness.Status()
+		return h == 10 && !halted
+	}, 10*time.Second, 10*time.Millisecond)
+
+	height, err := env.ledger.Height()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), height)
+	require.Empty(t, env.witness.Alerts())
+}
+
+func TestWitness_Discrepancy(t *testing.T) {
+	t.Run("state root and validation mismatch, keep following", func(t *testing.T) {
+		chain := newChain(t, 10)
+		chain[4].Header.StateMerkelTreeRootHash = []byte("bogus")
+		chain[6].Header.ValidationInfo[0].Flag = types.Flag_INVALID_MVCC_CONFLICT_WITHIN_BLOCK
+		chain[7].Header.DbStateRootHashes[0].RootHash = []byte("bogus")
+		env := newWitnessTestEnv(t, chain, false)
+
+		require.Eventually(t, func() bool {
+			height, _ := env.ledger.Height()
+			return height == 10 && len(env.witness.Alerts()) == 3
+		}, 10*time.Second, 10*time.Millisecond)
+
+		alerts := env.witness.Alerts()
+		require.Equal(t, uint64(5), alerts[0].BlockNumber)
+		require.Equal(t, fmt.Sprintf("state merkle-patricia trie root hash is [%x], re-computed [%x]", []byte("bogus"), []byte("state-root-5")), alerts[0].Reason)
+		require.Equal(t, uint64(7), alerts[1].BlockNumber)
+		require.Contains(t, alerts[1].Reason, "tx [0] validation info is [")
+		require.Contains(t, alerts[1].Reason, "INVALID_MVCC_CONFLICT_WITHIN_BLOCK")
+		require.Equal(t, uint64(8), alerts[2].BlockNumber)
+		require.Equal(t, fmt.Sprintf("state trie root hash of database [bdb] is [%x], re-computed [bdb] with [%x]", []byte("bogus"), []byte("bdb-state-root-8")), alerts[2].Reason)
+
+		h, halted := env.witness.Status()
+		require.Equal(t, uint64(10), h)
+		require.False(t, halted)
+	})
+
+	t.Run("tx root mismatch, halt", func(t *testing.T) {
+		chain := newChain(t, 10)
+		chain[4].Header.TxMerkelTreeRootHash = []byte("bogus")
+		env := newWitnessTestEnv(t, chain, true)
+
+		require.Eventually(t, func() bool {
+			_, halted := env.witness.Status()
+			return halted
+		}, 10*time.Second, 10*time.Millisecond)
+
+		h, _ := env.witness.Status()
+		require.Equal(t, uint64(4), h)
+		alerts := env.witness.Alerts()
+		require.Len(t, alerts, 1)
+		require.Equal(t, uint64(5), alerts[0].BlockNumber)
+		require.Equal(t, fmt.Sprintf("tx merkle tree root hash is [%x], re-computed [%x]", []byte("bogus"), []byte("tx-root-5")), alerts[0].Reason)
+	})
+
+	t.Run("broken chain, block not committed", func(t *testing.T) {
+		chain := newChain(t, 10)
+		chain[4].Header.BaseHeader.PreviousBaseHeaderHash = []byte("bogus")
+		env := newWitnessTestEnv(t, chain, true)
+
+		require.Eventually(t, func() bool {
+			_, halted := env.witness.Status()
+			return halted
+		}, 10*time.Second, 10*time.Millisecond)
+
+		height, err := env.ledger.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), height)
+		alerts := env.witness.Alerts()
+		require.Len(t, alerts, 1)
+		require.Equal(t, uint64(5), alerts[0].BlockNumber)
+		require.Contains(t, alerts[0].Reason, "does not match the base header hash of block [4] in the local ledger")
+	})
+}
@@ -3,6 +3,8 @@
 package worldstate
 
 import (
+	"time"
+
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 )
 
@@ -17,6 +19,21 @@ const (
 	// MetadataDBName holds the name of the database that holds
 	// the metadata about the worldstate database
 	MetadataDBName = "_metadata"
+	// LegalHoldsDBName holds the name of the database that holds
+	// the legal holds placed on keys and databases
+	LegalHoldsDBName = "_legalholds"
+	// ConstraintsDBName holds the name of the database that holds
+	// the constraints on the documents of the databases
+	ConstraintsDBName = "_constraints"
+	// FrozenDBsDBName holds the name of the database that holds
+	// the freezes of the frozen databases
+	FrozenDBsDBName = "_frozendbs"
+	// MigrationsDBName holds the name of the database that holds
+	// the applied versions of the migrations
+	MigrationsDBName = "_migrations"
+	// CanaryDBName holds the name of the database that holds
+	// the canary key written by every node to check its health
+	CanaryDBName = "_canary"
 	// DefaultDBName is the default database created during
 	// node bootstrap
 	DefaultDBName = "bdb"
@@ -24,8 +41,9 @@ const (
 	// stores the cluster configuration
 	ConfigKey = "config"
 	// AllowedCharsInDBName holds the regexp for allowed characters
-	// in a database name
-	AllowedCharsInDBName = `^[0-9a-zA-Z_-.]+$`
+	// in a database name. A name can be a hierarchical path of
+	// segments separated by '/'
+	AllowedCharsInDBName = `^[0-9a-zA-Z_\-\.]+(/[0-9a-zA-Z_\-\.]+)*$`
 )
 
 // DB provides method to create and access states stored in
@@ -39,9 +57,17 @@ type DB interface {
 	// Get returns the value of the key present in the
 	// database
 	Get(dbName, key string) ([]byte, *types.Metadata, error)
+	// GetBlobManifest returns the manifest of the value of
+	// the key when the value is stored in the blob store,
+	// and nil otherwise
+	GetBlobManifest(dbName, key string) (*types.BlobManifest, error)
 	// GetVersion returns the version of the key present
 	// in the database
 	GetVersion(dbName, key string) (*types.Version, error)
+	// GetVersions returns the versions of the given keys
+	// present in the database. Keys that do not exist are
+	// not included in the returned map
+	GetVersions(dbName string, keys []string) (map[string]*types.Version, error)
 	// GetACL returns the access control rule for the given
 	// key
 	GetACL(dbName, key string) (*types.AccessControl, error)
@@ -60,9 +86,34 @@ type DB interface {
 	// A snapshot is a frozen snapshot of a DB state at a particular point in time.
 	// The content of snapshot are guaranteed to be consistent.
 	// The snapshot must be released after use, by calling Release method on the DBSnapshot.
-	GetDBsSnapshot(dbNames []string) (DBsSnapshot, error)
-	// Commit commits the updates to each database
+	// The owner names the component that takes the snapshot, and is reported with the open snapshots.
+	GetDBsSnapshot(owner string, dbNames []string) (DBsSnapshot, error)
+	// OpenSnapshots returns the snapshots that are not yet released, oldest first
+	OpenSnapshots() []*SnapshotInfo
+	// CompactDB compacts the storage of a database, which drops the tombstones of the deleted keys and the
+	// overwritten values
+	CompactDB(dbName string) error
+	// DBStats returns the statistics of the storage of a database
+	DBStats(dbName string) (*DBStats, error)
+	// Commit commits the updates to each database. The deferred updates
+	// of earlier blocks are written first
 	Commit(dbsUpdates map[string]*DBUpdates, blockNumber uint64) error
+	// CommitDeferred applies the updates of a block to the state seen by
+	// the point reads of the database, i.e., Get, GetVersion, GetVersions,
+	// GetACL, and Has, but defers writing them, so that the updates of
+	// several consecutive blocks are written in a single batch per
+	// database by FlushDeferred or by the next Commit. The updates must
+	// not create or delete databases
+	CommitDeferred(dbsUpdates map[string]*DBUpdates, blockNumber uint64) error
+	// FlushDeferred writes the deferred updates to the databases
+	FlushDeferred() error
+	// DeferredBlocks returns the number of blocks whose updates are
+	// deferred
+	DeferredBlocks() int
+	// SetBeforeFlushDeferred sets a function that is called before the
+	// deferred updates are written, e.g., to write first the blocks
+	// whose updates they are
+	SetBeforeFlushDeferred(beforeFlush func() error)
 	// Height returns the state database block height. In other
 	// words, it returns the last committed block number
 	Height() (uint64, error)
@@ -84,6 +135,9 @@ type DBsSnapshot interface {
 	// the caller wants from the first key in the database (lexicographic order). An empty
 	// endKey (i.e., "") denotes that the caller wants till the last key in the database (lexicographic order).
 	GetIterator(dbName string, startKey, endKey string) (Iterator, error)
+	// Height returns the height of the state database when the snapshot
+	// was taken, i.e., the last block whose updates it holds
+	Height() uint64
 	// Release releases the snapshot. This will not release any returned
 	// iterators, the iterators would still be valid until released or the
 	// underlying DB is closed.
@@ -91,11 +145,39 @@ type DBsSnapshot interface {
 	Release()
 }
 
+// SnapshotInfo describes an open snapshot
+type SnapshotInfo struct {
+	ID uint64
+	// Owner names the component that took the snapshot
+	Owner   string
+	DBNames []string
+	// Height is the height of the state database when the snapshot was taken
+	Height   uint64
+	Acquired time.Time
+	// LastRead is the time of the last read of the snapshot, or the time it was taken if it was not read
+	LastRead time.Time
+}
+
+// DBStats holds the statistics of the storage of a database
+type DBStats struct {
+	// SizeBytes is the size of the files of the database on disk
+	SizeBytes uint64
+	// Deletes is the number of keys deleted since the node started or the database was last compacted
+	Deletes uint64
+}
+
 // KVWithMetadata holds a key and value pair
 type KVWithMetadata struct {
 	Key      string
 	Value    []byte
 	Metadata *types.Metadata
+	// BlobManifest, when set, describes the chunks of a blob held
+	// by the blob store, and is stored in place of the value
+	BlobManifest *types.BlobManifest
+	// ErasedValueHash, when set, is the hash of a value that was
+	// erased before the write was committed. The value is empty,
+	// and the state trie refers to the value by its hash.
+	ErasedValueHash []byte
 }
 
 // DBUpdates holds writes of KV pairs and deletes of
@@ -138,7 +220,12 @@ func IsSystemDB(dbName string) bool {
 	return dbName == UsersDBName ||
 		dbName == DatabasesDBName ||
 		dbName == ConfigDBName ||
-		dbName == MetadataDBName
+		dbName == MetadataDBName ||
+		dbName == LegalHoldsDBName ||
+		dbName == ConstraintsDBName ||
+		dbName == FrozenDBsDBName ||
+		dbName == MigrationsDBName ||
+		dbName == CanaryDBName
 }
 
 // IsDefaultWorldStateDB returns true if the given db is the default
@@ -154,5 +241,10 @@ func SystemDBs() []string {
 		DatabasesDBName,
 		ConfigDBName,
 		MetadataDBName,
+		LegalHoldsDBName,
+		ConstraintsDBName,
+		FrozenDBsDBName,
+		MigrationsDBName,
+		CanaryDBName,
 	}
 }
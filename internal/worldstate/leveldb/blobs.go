@@ -0,0 +1,55 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// ReferencedChunks returns the hashes of the chunks of the blobs referenced by the databases, for the garbage
+// collection of the blob store. A blob that is committed while the databases are scanned may be missed, which the
+// grace period of the garbage collection makes harmless.
+func (l *LevelDB) ReferencedChunks() (map[string]struct{}, error) {
+	// the databases are scanned by iterators, hence the deferred updates must be written first
+	if err := l.flushDeferredBeforeRead(); err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]struct{})
+	for _, dbName := range l.ListDBs() {
+		if worldstate.IsSystemDB(dbName) {
+			continue
+		}
+
+		l.dbsList.RLock()
+		db := l.dbs[dbName]
+		l.dbsList.RUnlock()
+		if db == nil {
+			// the database was deleted since it was listed
+			continue
+		}
+
+		itr := db.file.NewIterator(nil, &opt.ReadOptions{})
+		for itr.Next() {
+			persisted := &types.ValueWithMetadata{}
+			if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+				itr.Release()
+				return nil, errors.Wrapf(err, "failed to unmarshal the value of key [%s] in database %s", itr.Key(), dbName)
+			}
+			for _, hash := range persisted.GetBlobManifest().GetChunkHashes() {
+				referenced[string(hash)] = struct{}{}
+			}
+		}
+		itr.Release()
+		if err := itr.Error(); err != nil {
+			return nil, errors.Wrapf(err, "error while scanning database %s for the referenced chunks", dbName)
+		}
+	}
+
+	return referenced, nil
+}
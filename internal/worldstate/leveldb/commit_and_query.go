@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -54,8 +56,15 @@ func (l *LevelDB) ListDBs() []string {
 }
 
 // Height returns the block height of the state database. In other words, it
-// returns the last committed block number
+// returns the last committed block number, including the deferred blocks
 func (l *LevelDB) Height() (uint64, error) {
+	l.deferred.mu.RLock()
+	deferredHeight := l.deferred.height
+	l.deferred.mu.RUnlock()
+	if deferredHeight > 0 {
+		return deferredHeight, nil
+	}
+
 	l.dbsList.RLock()
 	defer l.dbsList.RUnlock()
 
@@ -84,14 +93,55 @@ func (l *LevelDB) Height() (uint64, error) {
 	return blockNumberDec, nil
 }
 
-// Get returns the value of the key present in the database.
+// Get returns the value of the key present in the database. A value that is stored in the blob store is assembled
+// from its chunks.
 func (l *LevelDB) Get(dbName string, key string) ([]byte, *types.Metadata, error) {
+	persisted, err := l.getPersisted(dbName, key)
+	if err != nil || persisted == nil {
+		return nil, nil, err
+	}
+
+	if err := decryptPersisted(l.encryption, dbName, key, persisted); err != nil {
+		return nil, nil, err
+	}
+	value, err := resolveBlob(l.blobs, persisted)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "failed to retrieve the value of key [%s] from database %s", key, dbName)
+	}
+
+	return value, persisted.Metadata, nil
+}
+
+// GetBlobManifest returns the manifest of the value of the key when the value is stored in the blob store, and nil
+// otherwise
+func (l *LevelDB) GetBlobManifest(dbName string, key string) (*types.BlobManifest, error) {
+	persisted, err := l.getPersisted(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return persisted.GetBlobManifest(), nil
+}
+
+// GetVersion returns the version of the key present in the database
+func (l *LevelDB) GetVersion(dbName string, key string) (*types.Version, error) {
+	persisted, err := l.getPersisted(dbName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return persisted.GetMetadata().GetVersion(), nil
+}
+
+// GetVersions returns the versions of the given keys present in the database. The database lock is acquired once
+// for all keys, and keys that do not exist are not included in the returned map.
+func (l *LevelDB) GetVersions(dbName string, keys []string) (map[string]*types.Version, error) {
 	l.dbsList.RLock()
 	defer l.dbsList.RUnlock()
 
 	db, ok := l.dbs[dbName]
 	if !ok {
-		return nil, nil, &DBNotFoundErr{
+		return nil, &DBNotFoundErr{
 			dbName: dbName,
 		}
 	}
@@ -99,40 +149,100 @@ func (l *LevelDB) Get(dbName string, key string) ([]byte, *types.Metadata, error
 	db.mu.RLock()
 	defer db.mu.RUnlock()
 
-	dbval, err := db.file.Get([]byte(key), db.readOpts)
-	if err == leveldb.ErrNotFound {
-		return nil, nil, nil
+	versions := make(map[string]*types.Version, len(keys))
+	persisted := &types.ValueWithMetadata{}
+	for _, key := range keys {
+		dbval, err := l.getDBValue(db, key)
+		if err != nil {
+			return nil, err
+		}
+		if dbval == nil {
+			continue
+		}
+
+		persisted.Reset()
+		if err := proto.Unmarshal(dbval, persisted); err != nil {
+			return nil, err
+		}
+		if v := persisted.GetMetadata().GetVersion(); v != nil {
+			versions[key] = v
+		}
 	}
+
+	return versions, nil
+}
+
+// GetACL returns the access control rule for the given key present in the database
+func (l *LevelDB) GetACL(dbName, key string) (*types.AccessControl, error) {
+	persisted, err := l.getPersisted(dbName, key)
 	if err != nil {
-		return nil, nil, errors.WithMessagef(err, "failed to retrieve leveldb key [%s] from database %s", key, dbName)
+		return nil, err
+	}
+
+	return persisted.GetMetadata().GetAccessControl(), nil
+}
+
+// getPersisted returns the persisted value and metadata of the key, without assembling a value that is stored in the
+// blob store, or nil if the key does not exist
+func (l *LevelDB) getPersisted(dbName string, key string) (*types.ValueWithMetadata, error) {
+	l.dbsList.RLock()
+	defer l.dbsList.RUnlock()
+
+	db, ok := l.dbs[dbName]
+	if !ok {
+		return nil, &DBNotFoundErr{
+			dbName: dbName,
+		}
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	dbval, err := l.getDBValue(db, key)
+	if err != nil || dbval == nil {
+		return nil, err
 	}
 
 	persisted := &types.ValueWithMetadata{}
 	if err := proto.Unmarshal(dbval, persisted); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return persisted.Value, persisted.Metadata, nil
+	return persisted, nil
 }
 
-// GetVersion returns the version of the key present in the database
-func (l *LevelDB) GetVersion(dbName string, key string) (*types.Version, error) {
-	_, metadata, err := l.Get(dbName, key)
+// getDBValue returns the persisted bytes of the key, either from the deferred updates or from the database, or nil if
+// the key does not exist. The caller must hold the read lock of the database.
+func (l *LevelDB) getDBValue(db *db, key string) ([]byte, error) {
+	if v, ok := l.deferred.get(db.name, key); ok {
+		if v.deleted {
+			return nil, nil
+		}
+		return v.dbval, nil
+	}
+
+	dbval, err := db.file.Get([]byte(key), db.readOpts)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, err
+		return nil, errors.WithMessagef(err, "failed to retrieve leveldb key [%s] from database %s", key, db.name)
 	}
 
-	return metadata.GetVersion(), nil
+	return dbval, nil
 }
 
-// GetACL returns the access control rule for the given key present in the database
-func (l *LevelDB) GetACL(dbName, key string) (*types.AccessControl, error) {
-	_, metadata, err := l.Get(dbName, key)
-	if err != nil {
-		return nil, err
+// resolveBlob returns the value of a persisted entry, assembling it from the blob store when the entry holds a blob
+// manifest
+func resolveBlob(blobs *blobstore.Store, persisted *types.ValueWithMetadata) ([]byte, error) {
+	if persisted.BlobManifest == nil {
+		return persisted.Value, nil
+	}
+	if blobs == nil {
+		return nil, errors.New("the value is stored in the blob store but no blob store is configured")
 	}
 
-	return metadata.GetAccessControl(), nil
+	return blobs.GetValue(persisted.BlobManifest)
 }
 
 // Has returns true if the key exist in the database
@@ -141,6 +251,10 @@ func (l *LevelDB) Has(dbName, key string) (bool, error) {
 	db := l.dbs[dbName]
 	l.dbsList.RUnlock()
 
+	if v, ok := l.deferred.get(dbName, key); ok {
+		return !v.deleted, nil
+	}
+
 	return db.file.Has([]byte(key), nil)
 }
 
@@ -169,6 +283,11 @@ func (l *LevelDB) GetIndexDefinition(dbName string) ([]byte, *types.Metadata, er
 // the caller wants from the first key in the database (lexicographic order). An empty
 // endKey (i.e., "") denotes that the caller wants till the last key in the database (lexicographic order).
 func (l *LevelDB) GetIterator(dbName string, startKey, endKey string) (worldstate.Iterator, error) {
+	// an iterator reads the database itself, hence it requires the deferred updates to be written
+	if err := l.flushDeferredBeforeRead(); err != nil {
+		return nil, err
+	}
+
 	l.dbsList.RLock()
 	db := l.dbs[dbName]
 	l.dbsList.RUnlock()
@@ -191,12 +310,32 @@ func (l *LevelDB) GetIterator(dbName string, startKey, endKey string) (worldstat
 		r.Limit = []byte(endKey)
 	}
 
-	return db.file.NewIterator(r, &opt.ReadOptions{}), nil
+	return newDecryptingIterator(db.file.NewIterator(r, &opt.ReadOptions{}), dbName, l.encryption), nil
 }
 
-// Commit commits the updates to the database
+// Commit commits the updates to the database. The deferred updates of earlier blocks are written first.
 func (l *LevelDB) Commit(dbsUpdates map[string]*worldstate.DBUpdates, blockNumber uint64) error {
-	for dbName, updates := range dbsUpdates {
+	l.commitMu.Lock()
+	defer l.commitMu.Unlock()
+
+	if err := l.flushDeferred(); err != nil {
+		return err
+	}
+
+	// the databases database is committed first, so that the databases it creates can be written by the same commit,
+	// e.g., the index database created along with the index entries of the values already stored
+	dbNames := make([]string, 0, len(dbsUpdates))
+	if _, ok := dbsUpdates[worldstate.DatabasesDBName]; ok {
+		dbNames = append(dbNames, worldstate.DatabasesDBName)
+	}
+	for dbName := range dbsUpdates {
+		if dbName != worldstate.DatabasesDBName {
+			dbNames = append(dbNames, dbName)
+		}
+	}
+
+	for _, dbName := range dbNames {
+		updates := dbsUpdates[dbName]
 		l.dbsList.RLock()
 		db := l.dbs[dbName]
 		l.dbsList.RUnlock()
@@ -213,6 +352,11 @@ func (l *LevelDB) Commit(dbsUpdates map[string]*worldstate.DBUpdates, blockNumbe
 		l.logger.Debugf("changes committed to the database %s, took %d ms, available dbs are [%s]", dbName, time.Since(start).Milliseconds(), l.dbs)
 	}
 
+	return l.commitHeight(blockNumber)
+}
+
+// commitHeight stores the number of the last committed block in the metadata database
+func (l *LevelDB) commitHeight(blockNumber uint64) error {
 	l.dbsList.RLock()
 	db, exists := l.dbs[worldstate.MetadataDBName]
 	l.dbsList.RUnlock()
@@ -237,14 +381,9 @@ func (l *LevelDB) commitToDB(dbName string, db *db, updates *worldstate.DBUpdate
 	batch := &leveldb.Batch{}
 
 	for _, kv := range updates.Writes {
-		dbval, err := proto.Marshal(
-			&types.ValueWithMetadata{
-				Value:    kv.Value,
-				Metadata: kv.Metadata,
-			},
-		)
+		dbval, err := l.marshalPersisted(dbName, kv)
 		if err != nil {
-			return errors.WithMessagef(err, "failed to marshal the constructed dbValue [%v]", kv.Value)
+			return err
 		}
 
 		batch.Put([]byte(kv.Key), dbval)
@@ -260,6 +399,7 @@ func (l *LevelDB) commitToDB(dbName string, db *db, updates *worldstate.DBUpdate
 	if err := db.file.Write(batch, db.writeOpts); err != nil {
 		return errors.Wrapf(err, "error while writing an update batch to database [%s]", db.name)
 	}
+	atomic.AddUint64(&db.deletes, uint64(len(updates.Deletes)))
 
 	if dbName != worldstate.DatabasesDBName {
 		return nil
@@ -292,6 +432,31 @@ func (l *LevelDB) commitToDB(dbName string, db *db, updates *worldstate.DBUpdate
 	return nil
 }
 
+// marshalPersisted returns the persisted bytes of a write. A write of a blob persists its manifest, as the chunks of
+// the blob are already held by the blob store. Any other value of an encrypted database is encrypted.
+func (l *LevelDB) marshalPersisted(dbName string, kv *worldstate.KVWithMetadata) ([]byte, error) {
+	persisted := &types.ValueWithMetadata{
+		Value:    kv.Value,
+		Metadata: kv.Metadata,
+	}
+
+	if kv.BlobManifest != nil {
+		persisted = &types.ValueWithMetadata{
+			Metadata:     kv.Metadata,
+			BlobManifest: kv.BlobManifest,
+		}
+	} else if err := encryptPersisted(l.encryption, dbName, kv.Key, persisted); err != nil {
+		return nil, err
+	}
+
+	dbval, err := proto.Marshal(persisted)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to marshal the constructed dbValue [%v]", kv.Value)
+	}
+
+	return dbval, nil
+}
+
 // create creates a database. It does not return an error when the database already exist.
 func (l *LevelDB) create(dbName string) error {
 	l.dbsList.Lock()
@@ -302,7 +467,7 @@ func (l *LevelDB) create(dbName string) error {
 		return nil
 	}
 
-	file, err := leveldb.OpenFile(filepath.Join(l.dbRootDir, dbName), &opt.Options{})
+	file, err := leveldb.OpenFile(filepath.Join(l.dbRootDir, dbDir(dbName)), &opt.Options{})
 	if err != nil {
 		return errors.WithMessagef(err, "failed to open leveldb file for database %s", dbName)
 	}
@@ -338,7 +503,7 @@ func (l *LevelDB) delete(dbName string) error {
 
 	delete(l.dbs, dbName)
 
-	if err := os.RemoveAll(filepath.Join(l.dbRootDir, dbName)); err != nil {
+	if err := os.RemoveAll(filepath.Join(l.dbRootDir, dbDir(dbName))); err != nil {
 		return errors.Wrapf(err, "error while deleting database [%s]", dbName)
 	}
 
@@ -4,11 +4,13 @@
 package leveldb
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
@@ -39,8 +41,15 @@ func newTestEnv(t *testing.T) *testEnv {
 	logger, err := logger.New(c)
 	require.NoError(t, err)
 
+	blobs, err := blobstore.Open(&blobstore.Config{
+		StoreDir: filepath.Join(dir, "blobstore"),
+		Logger:   logger,
+	})
+	require.NoError(t, err)
+
 	conf := &Config{
 		DBRootDir: path,
+		BlobStore: blobs,
 		Logger:    logger,
 	}
 	l, err := Open(conf)
@@ -56,6 +65,10 @@ func newTestEnv(t *testing.T) *testEnv {
 			t.Errorf("failed to close the database instance, %v", err)
 		}
 
+		if err := blobs.Close(); err != nil {
+			t.Errorf("failed to close the blob store, %v", err)
+		}
+
 		if err := os.RemoveAll(dir); err != nil {
 			t.Errorf("failed to remove %s, %v", dir, err)
 		}
@@ -155,6 +168,43 @@ func verifyDBExistance(t *testing.T, l *LevelDB, dbName string, expected bool) {
 	require.Equal(t, expected, exist)
 }
 
+func TestKeyLayout(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	l := env.l
+
+	dbName := "org1/db1"
+	require.NoError(t, l.create(dbName))
+	require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{
+		dbName: {
+			Writes: []*worldstate.KVWithMetadata{{Key: "key1", Value: []byte("value1")}},
+		},
+	}, 1))
+
+	// the key is stored as it is, in the LevelDB instance of its database
+	db := l.dbs[dbName]
+	require.Equal(t, "org1%2Fdb1", dbDir(dbName))
+	found, err := db.file.Has([]byte("key1"), nil)
+	require.NoError(t, err)
+	require.True(t, found)
+
+	it := db.file.NewIterator(nil, nil)
+	defer it.Release()
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(t, it.Error())
+	require.Equal(t, []string{"key1"}, keys)
+
+	// the keys of the other databases are not affected
+	found, err = l.dbs[worldstate.DefaultDBName].file.Has([]byte("key1"), nil)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
 func TestListDBsAndExist(t *testing.T) {
 	t.Parallel()
 
@@ -376,6 +426,33 @@ func TestCommitAndQuery(t *testing.T) {
 		}
 	})
 
+	t.Run("GetVersions() on non-empty databases", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t)
+		defer env.cleanup()
+		l := env.l
+		db1KVs, db2KVs := setupWithData(l)
+
+		for dbName, kvs := range map[string]map[string]*types.ValueWithMetadata{"db1": db1KVs, "db2": db2KVs} {
+			keys := []string{dbName + "-key0"}
+			for key := range kvs {
+				keys = append(keys, key)
+			}
+
+			versions, err := l.GetVersions(dbName, keys)
+			require.NoError(t, err)
+			require.Len(t, versions, len(kvs))
+			for key, expectedValAndMetadata := range kvs {
+				require.True(t, proto.Equal(expectedValAndMetadata.GetMetadata().GetVersion(), versions[key]))
+			}
+			require.NotContains(t, versions, dbName+"-key0")
+		}
+
+		versions, err := l.GetVersions("db3", []string{"db3-key1"})
+		require.EqualError(t, err, "database db3 does not exist")
+		require.Nil(t, versions)
+	})
+
 	t.Run("GetIterator() on non-empty databases", func(t *testing.T) {
 		t.Parallel()
 		env := newTestEnv(t)
@@ -601,6 +678,71 @@ func TestCommitAndQuery(t *testing.T) {
 	})
 }
 
+func TestCommitAndQueryBlobs(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	// the chunks of a blob are uploaded before the manifest is committed
+	largeValue := []byte("a large value that is stored in chunks")
+	manifest := blobstore.NewManifest(largeValue, 8)
+	require.NoError(t, env.l.blobs.PutValue(manifest, largeValue))
+	metadata := &types.Metadata{
+		Version: &types.Version{
+			BlockNum: 1,
+			TxNum:    1,
+		},
+	}
+
+	require.NoError(t, env.l.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DefaultDBName: {
+			Writes: []*worldstate.KVWithMetadata{
+				{
+					Key:          "large",
+					Metadata:     metadata,
+					BlobManifest: manifest,
+				},
+				{
+					Key:      "small",
+					Value:    []byte("small value"),
+					Metadata: metadata,
+				},
+			},
+		},
+	}, 1))
+
+	value, actualMetadata, err := env.l.Get(worldstate.DefaultDBName, "large")
+	require.NoError(t, err)
+	require.Equal(t, largeValue, value)
+	require.True(t, proto.Equal(metadata, actualMetadata))
+
+	actualManifest, err := env.l.GetBlobManifest(worldstate.DefaultDBName, "large")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(manifest, actualManifest))
+
+	actualManifest, err = env.l.GetBlobManifest(worldstate.DefaultDBName, "small")
+	require.NoError(t, err)
+	require.Nil(t, actualManifest)
+
+	version, err := env.l.GetVersion(worldstate.DefaultDBName, "large")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(metadata.Version, version))
+
+	// the database holds the manifest in place of the value
+	persisted, err := env.l.getPersisted(worldstate.DefaultDBName, "large")
+	require.NoError(t, err)
+	require.Nil(t, persisted.Value)
+
+	snap, err := env.l.GetDBsSnapshot("test", []string{worldstate.DefaultDBName})
+	require.NoError(t, err)
+	defer snap.Release()
+
+	value, _, err = snap.Get(worldstate.DefaultDBName, "large")
+	require.NoError(t, err)
+	require.Equal(t, largeValue, value)
+}
+
 func TestCommitWithDBManagement(t *testing.T) {
 	t.Parallel()
 
@@ -714,6 +856,31 @@ func TestCommitWithDBManagement(t *testing.T) {
 	}
 }
 
+func TestCommitToCreatedDB(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	l := env.l
+
+	// the created database is written by the same commit, whatever the order of the map
+	for i := uint64(1); i <= 10; i++ {
+		dbName := fmt.Sprintf("db%d", i)
+		require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: dbName}},
+			},
+			dbName: {
+				Writes: []*worldstate.KVWithMetadata{{Key: "key1", Value: []byte("value1")}},
+			},
+		}, i))
+
+		val, _, err := l.Get(dbName, "key1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), val)
+	}
+}
+
 func TestGetConfig(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// CompactDB compacts the whole key range of a database. The compaction does not hold the lock of the database, so
+// that the commits to the database proceed meanwhile; a database that is deleted during its compaction fails it.
+func (l *LevelDB) CompactDB(dbName string) error {
+	db, err := l.getDB(dbName)
+	if err != nil {
+		return err
+	}
+
+	// the keys deleted during the compaction count towards the next one
+	atomic.StoreUint64(&db.deletes, 0)
+	if err := db.file.CompactRange(util.Range{}); err != nil {
+		return errors.Wrapf(err, "error while compacting database [%s]", dbName)
+	}
+
+	return nil
+}
+
+// DBStats returns the size of the files of a database on disk, and the number of keys deleted from it since the node
+// started or the database was last compacted
+func (l *LevelDB) DBStats(dbName string) (*worldstate.DBStats, error) {
+	db, err := l.getDB(dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the files of a database are held in a single directory
+	files, err := ioutil.ReadDir(filepath.Join(l.dbRootDir, dbDir(dbName)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while computing the size of database [%s]", dbName)
+	}
+	var size uint64
+	for _, f := range files {
+		if f.Mode().IsRegular() {
+			size += uint64(f.Size())
+		}
+	}
+
+	return &worldstate.DBStats{
+		SizeBytes: size,
+		Deletes:   atomic.LoadUint64(&db.deletes),
+	}, nil
+}
+
+func (l *LevelDB) getDB(dbName string) (*db, error) {
+	l.dbsList.RLock()
+	defer l.dbsList.RUnlock()
+
+	db, ok := l.dbs[dbName]
+	if !ok {
+		return nil, &DBNotFoundErr{
+			dbName: dbName,
+		}
+	}
+
+	return db, nil
+}
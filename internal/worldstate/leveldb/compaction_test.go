@@ -0,0 +1,62 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactDB(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	l := env.l
+	require.NoError(t, l.create("db1"))
+
+	var writes []*worldstate.KVWithMetadata
+	var deletes []string
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key%04d", i)
+		// random values, which are not compressed by LevelDB
+		value := make([]byte, 1024)
+		_, err := rand.Read(value)
+		require.NoError(t, err)
+		writes = append(writes, &worldstate.KVWithMetadata{Key: key, Value: value})
+		deletes = append(deletes, key)
+	}
+	require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{"db1": {Writes: writes}}, 1))
+	require.NoError(t, l.CompactDB("db1"))
+
+	stats, err := l.DBStats("db1")
+	require.NoError(t, err)
+	require.Zero(t, stats.Deletes)
+	sizeWithValues := stats.SizeBytes
+	require.True(t, sizeWithValues > 1000*1024/2)
+
+	// the deletes are counted whether they are committed directly or deferred
+	require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{"db1": {Deletes: deletes[:600]}}, 2))
+	require.NoError(t, l.CommitDeferred(map[string]*worldstate.DBUpdates{"db1": {Deletes: deletes[600:]}}, 3))
+	stats, err = l.DBStats("db1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(600), stats.Deletes)
+	require.NoError(t, l.FlushDeferred())
+	stats, err = l.DBStats("db1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), stats.Deletes)
+
+	require.NoError(t, l.CompactDB("db1"))
+	stats, err = l.DBStats("db1")
+	require.NoError(t, err)
+	require.Zero(t, stats.Deletes)
+	require.Less(t, stats.SizeBytes, sizeWithValues/10)
+
+	err = l.CompactDB("db2")
+	require.EqualError(t, err, "database db2 does not exist")
+	_, err = l.DBStats("db2")
+	require.EqualError(t, err, "database db2 does not exist")
+}
@@ -0,0 +1,192 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// deferredUpdates holds the updates of the blocks committed with CommitDeferred that are not yet written to the
+// databases. As a key written by several deferred blocks holds the value of the last of them, the updates of all the
+// deferred blocks are written in a single batch per database.
+type deferredUpdates struct {
+	// values holds, per database, the persisted value of every key written by the deferred blocks, or its deletion.
+	// The values are modified only by the committing go-routine, while holding both commitMu of the LevelDB and mu.
+	values map[string]map[string]deferredValue
+	height uint64
+	blocks int
+	mu     sync.RWMutex
+}
+
+type deferredValue struct {
+	dbval   []byte
+	deleted bool
+}
+
+func newDeferredUpdates() *deferredUpdates {
+	return &deferredUpdates{
+		values: make(map[string]map[string]deferredValue),
+	}
+}
+
+// get returns the deferred value of the key, and false if the key was not updated by the deferred blocks
+func (d *deferredUpdates) get(dbName, key string) (deferredValue, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	v, ok := d.values[dbName][key]
+	return v, ok
+}
+
+// CommitDeferred applies the updates of the block to the state seen by the point reads of the database, and defers
+// writing them until FlushDeferred or Commit is called. The updates must not create or delete databases.
+func (l *LevelDB) CommitDeferred(dbsUpdates map[string]*worldstate.DBUpdates, blockNumber uint64) error {
+	l.commitMu.Lock()
+	defer l.commitMu.Unlock()
+
+	// the values are marshaled, and the blobs are stored, before the deferred updates are locked, so that the point
+	// reads are not held back
+	values := make(map[string]map[string]deferredValue, len(dbsUpdates))
+	for dbName, updates := range dbsUpdates {
+		if dbName == worldstate.DatabasesDBName {
+			return errors.Errorf("the updates of database %s cannot be deferred", dbName)
+		}
+		if !l.Exist(dbName) {
+			l.logger.Errorf("database %s does not exist", dbName)
+			return errors.Errorf("database %s does not exist", dbName)
+		}
+
+		dbValues := make(map[string]deferredValue, len(updates.Writes)+len(updates.Deletes))
+		for _, kv := range updates.Writes {
+			dbval, err := l.marshalPersisted(dbName, kv)
+			if err != nil {
+				return err
+			}
+			dbValues[kv.Key] = deferredValue{dbval: dbval}
+		}
+		for _, key := range updates.Deletes {
+			dbValues[key] = deferredValue{deleted: true}
+		}
+		values[dbName] = dbValues
+	}
+
+	l.deferred.mu.Lock()
+	defer l.deferred.mu.Unlock()
+
+	for dbName, dbValues := range values {
+		deferredDBValues, ok := l.deferred.values[dbName]
+		if !ok {
+			l.deferred.values[dbName] = dbValues
+			continue
+		}
+		for key, v := range dbValues {
+			deferredDBValues[key] = v
+		}
+	}
+	l.deferred.height = blockNumber
+	l.deferred.blocks++
+
+	return nil
+}
+
+// FlushDeferred writes the updates of the deferred blocks to the databases, in a single batch per database, and
+// stores the number of the last deferred block as the height of the state database
+func (l *LevelDB) FlushDeferred() error {
+	l.commitMu.Lock()
+	defer l.commitMu.Unlock()
+
+	return l.flushDeferred()
+}
+
+// SetBeforeFlushDeferred sets a function that is called before the deferred updates are written, which must not
+// write to the database
+func (l *LevelDB) SetBeforeFlushDeferred(beforeFlush func() error) {
+	l.commitMu.Lock()
+	defer l.commitMu.Unlock()
+
+	l.beforeFlushDeferred = beforeFlush
+}
+
+// DeferredBlocks returns the number of blocks whose updates are deferred
+func (l *LevelDB) DeferredBlocks() int {
+	l.deferred.mu.RLock()
+	defer l.deferred.mu.RUnlock()
+
+	return l.deferred.blocks
+}
+
+// flushDeferredBeforeRead writes the deferred updates before a read that is not served from them, i.e., an iterator.
+// A snapshot writes them itself, see GetDBsSnapshot.
+func (l *LevelDB) flushDeferredBeforeRead() error {
+	if l.DeferredBlocks() == 0 {
+		return nil
+	}
+
+	return l.FlushDeferred()
+}
+
+// flushDeferred must be called while holding commitMu. The deferred updates are read without their lock, as they are
+// modified only while holding commitMu.
+func (l *LevelDB) flushDeferred() error {
+	if l.deferred.blocks == 0 {
+		return nil
+	}
+
+	if l.beforeFlushDeferred != nil {
+		if err := l.beforeFlushDeferred(); err != nil {
+			return errors.WithMessage(err, "error before writing the deferred updates")
+		}
+	}
+
+	for dbName, dbValues := range l.deferred.values {
+		l.dbsList.RLock()
+		db := l.dbs[dbName]
+		l.dbsList.RUnlock()
+
+		if db == nil {
+			l.logger.Errorf("database %s does not exist", dbName)
+			return errors.Errorf("database %s does not exist", dbName)
+		}
+
+		batch := &leveldb.Batch{}
+		deletes := uint64(0)
+		for key, v := range dbValues {
+			if v.deleted {
+				batch.Delete([]byte(key))
+				deletes++
+				continue
+			}
+			batch.Put([]byte(key), v.dbval)
+		}
+
+		db.mu.Lock()
+		err := db.file.Write(batch, db.writeOpts)
+		db.mu.Unlock()
+		if err != nil {
+			return errors.Wrapf(err, "error while writing the deferred update batch to database [%s]", dbName)
+		}
+		atomic.AddUint64(&db.deletes, deletes)
+	}
+
+	if err := l.commitHeight(l.deferred.height); err != nil {
+		return err
+	}
+	l.logger.Debugf("wrote the deferred updates of %d blocks, up to block %d", l.deferred.blocks, l.deferred.height)
+
+	// the values are removed only once they are written, so that a point read finds the updates either in the deferred
+	// values or in the databases
+	l.deferred.mu.Lock()
+	defer l.deferred.mu.Unlock()
+
+	l.deferred.values = make(map[string]map[string]deferredValue)
+	l.deferred.height = 0
+	l.deferred.blocks = 0
+
+	return nil
+}
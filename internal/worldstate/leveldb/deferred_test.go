@@ -0,0 +1,199 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitDeferred(t *testing.T) {
+	t.Parallel()
+
+	kv := func(key, value string, blockNum uint64) *worldstate.KVWithMetadata {
+		return &worldstate.KVWithMetadata{
+			Key:   key,
+			Value: []byte(value),
+			Metadata: &types.Metadata{
+				Version: &types.Version{BlockNum: blockNum},
+			},
+		}
+	}
+
+	setup := func(t *testing.T, l *LevelDB) {
+		require.NoError(t, l.create("db1"))
+		require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{
+			"db1": {Writes: []*worldstate.KVWithMetadata{kv("key1", "value1", 1), kv("key2", "value2", 1)}},
+		}, 1))
+
+		require.NoError(t, l.CommitDeferred(map[string]*worldstate.DBUpdates{
+			"db1": {Writes: []*worldstate.KVWithMetadata{kv("key1", "value1-2", 2), kv("key3", "value3", 2)}},
+		}, 2))
+		require.NoError(t, l.CommitDeferred(map[string]*worldstate.DBUpdates{
+			"db1": {
+				Writes:  []*worldstate.KVWithMetadata{kv("key3", "value3-3", 3)},
+				Deletes: []string{"key2"},
+			},
+		}, 3))
+	}
+
+	assertState := func(t *testing.T, l *LevelDB) {
+		height, err := l.Height()
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), height)
+
+		expected := map[string]*types.ValueWithMetadata{
+			"key1": {Value: []byte("value1-2"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 2}}},
+			"key3": {Value: []byte("value3-3"), Metadata: &types.Metadata{Version: &types.Version{BlockNum: 3}}},
+		}
+		for key, expectedValue := range expected {
+			value, metadata, err := l.Get("db1", key)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expectedValue, &types.ValueWithMetadata{Value: value, Metadata: metadata}))
+
+			exist, err := l.Has("db1", key)
+			require.NoError(t, err)
+			require.True(t, exist)
+		}
+
+		value, metadata, err := l.Get("db1", "key2")
+		require.NoError(t, err)
+		require.Nil(t, value)
+		require.Nil(t, metadata)
+		exist, err := l.Has("db1", "key2")
+		require.NoError(t, err)
+		require.False(t, exist)
+
+		versions, err := l.GetVersions("db1", []string{"key1", "key2", "key3"})
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.True(t, proto.Equal(&types.Version{BlockNum: 2}, versions["key1"]))
+		require.True(t, proto.Equal(&types.Version{BlockNum: 3}, versions["key3"]))
+	}
+
+	persistedHeight := func(t *testing.T, l *LevelDB) uint64 {
+		heightEnc, err := l.dbs[worldstate.MetadataDBName].file.Get(lastCommittedBlockNumberKey, nil)
+		require.NoError(t, err)
+		height, err := binary.ReadUvarint(bytes.NewBuffer(heightEnc))
+		require.NoError(t, err)
+		return height
+	}
+
+	t.Run("reads see the deferred updates, which are written by FlushDeferred", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t)
+		defer env.cleanup()
+		l := env.l
+		setup(t, l)
+
+		require.Equal(t, 2, l.DeferredBlocks())
+		require.Equal(t, uint64(1), persistedHeight(t, l))
+		dbval, err := l.dbs["db1"].file.Get([]byte("key3"), nil)
+		require.EqualError(t, err, "leveldb: not found")
+		require.Nil(t, dbval)
+		assertState(t, l)
+
+		require.NoError(t, l.FlushDeferred())
+		require.Equal(t, 0, l.DeferredBlocks())
+		require.Equal(t, uint64(3), persistedHeight(t, l))
+		assertState(t, l)
+	})
+
+	t.Run("an iterator writes the deferred updates", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t)
+		defer env.cleanup()
+		l := env.l
+		setup(t, l)
+
+		itr, err := l.GetIterator("db1", "", "")
+		require.NoError(t, err)
+		defer itr.Release()
+
+		var keys []string
+		for itr.Next() {
+			keys = append(keys, string(itr.Key()))
+		}
+		require.Equal(t, []string{"key1", "key3"}, keys)
+		require.Equal(t, 0, l.DeferredBlocks())
+		assertState(t, l)
+	})
+
+	t.Run("commit writes the deferred updates first", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t)
+		defer env.cleanup()
+		l := env.l
+		setup(t, l)
+
+		require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{
+			"db1": {Writes: []*worldstate.KVWithMetadata{kv("key4", "value4", 4)}},
+		}, 4))
+		require.Equal(t, 0, l.DeferredBlocks())
+		require.Equal(t, uint64(4), persistedHeight(t, l))
+
+		dbval, err := l.dbs["db1"].file.Get([]byte("key3"), nil)
+		require.NoError(t, err)
+		require.NotNil(t, dbval)
+		value, _, err := l.Get("db1", "key4")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value4"), value)
+	})
+
+	t.Run("the function set to be called before the deferred updates are written", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t)
+		defer env.cleanup()
+		l := env.l
+		setup(t, l)
+
+		calls := 0
+		l.SetBeforeFlushDeferred(func() error {
+			calls++
+			if calls == 1 {
+				return errors.New("block store failure")
+			}
+			require.Equal(t, uint64(1), persistedHeight(t, l))
+			return nil
+		})
+
+		require.EqualError(t, l.FlushDeferred(), "error before writing the deferred updates: block store failure")
+		require.Equal(t, 2, l.DeferredBlocks())
+		require.Equal(t, uint64(1), persistedHeight(t, l))
+
+		require.NoError(t, l.FlushDeferred())
+		require.Equal(t, 0, l.DeferredBlocks())
+		require.Equal(t, uint64(3), persistedHeight(t, l))
+		assertState(t, l)
+
+		// nothing is written without deferred updates
+		require.NoError(t, l.FlushDeferred())
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("database management cannot be deferred", func(t *testing.T) {
+		t.Parallel()
+		env := newTestEnv(t)
+		defer env.cleanup()
+		l := env.l
+
+		err := l.CommitDeferred(map[string]*worldstate.DBUpdates{
+			worldstate.DatabasesDBName: {Writes: []*worldstate.KVWithMetadata{kv("db2", "", 2)}},
+		}, 2)
+		require.EqualError(t, err, "the updates of database _dbs cannot be deferred")
+
+		err = l.CommitDeferred(map[string]*worldstate.DBUpdates{
+			"db2": {Writes: []*worldstate.KVWithMetadata{kv("key1", "value1", 2)}},
+		}, 2)
+		require.EqualError(t, err, "database db2 does not exist")
+		require.Equal(t, 0, l.DeferredBlocks())
+	})
+}
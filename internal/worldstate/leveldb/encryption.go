@@ -0,0 +1,273 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/encryption"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// reencryptBatchSize is the number of keys read by a batch of the re-encryption, which holds the commit lock
+const reencryptBatchSize = 1000
+
+// encryptPersisted encrypts the value of the persisted entry when its database is encrypted
+func encryptPersisted(keys *encryption.Keys, dbName, key string, persisted *types.ValueWithMetadata) error {
+	encrypted, err := keys.Encrypt(dbName, key, persisted.Value)
+	if err != nil || encrypted == nil {
+		return err
+	}
+
+	persisted.Value = nil
+	persisted.EncryptedValue = encrypted
+	return nil
+}
+
+// decryptPersisted decrypts the value of the persisted entry when it is encrypted
+func decryptPersisted(keys *encryption.Keys, dbName, key string, persisted *types.ValueWithMetadata) error {
+	if persisted.EncryptedValue == nil {
+		return nil
+	}
+
+	value, err := keys.Decrypt(dbName, key, persisted.EncryptedValue)
+	if err != nil {
+		return err
+	}
+
+	persisted.Value = value
+	persisted.EncryptedValue = nil
+	return nil
+}
+
+// decryptingIterator returns the persisted entries with their values decrypted. An entry that cannot be decrypted
+// ends the iteration, and its error is returned by Error.
+type decryptingIterator struct {
+	worldstate.Iterator
+	dbName string
+	keys   *encryption.Keys
+	value  []byte
+	err    error
+}
+
+func newDecryptingIterator(itr worldstate.Iterator, dbName string, keys *encryption.Keys) worldstate.Iterator {
+	if keys == nil {
+		return itr
+	}
+
+	return &decryptingIterator{
+		Iterator: itr,
+		dbName:   dbName,
+		keys:     keys,
+	}
+}
+
+func (i *decryptingIterator) Next() bool {
+	return i.err == nil && i.Iterator.Next() && i.decrypt()
+}
+
+func (i *decryptingIterator) Seek(key []byte) bool {
+	return i.err == nil && i.Iterator.Seek(key) && i.decrypt()
+}
+
+func (i *decryptingIterator) Value() []byte {
+	return i.value
+}
+
+func (i *decryptingIterator) Error() error {
+	if i.err != nil {
+		return i.err
+	}
+
+	return i.Iterator.Error()
+}
+
+func (i *decryptingIterator) decrypt() bool {
+	i.value = nil
+
+	dbval := i.Iterator.Value()
+	persisted := &types.ValueWithMetadata{}
+	if err := proto.Unmarshal(dbval, persisted); err != nil {
+		i.err = errors.Wrapf(err, "failed to unmarshal the value of key [%s] of database %s", i.Iterator.Key(), i.dbName)
+		return false
+	}
+	if persisted.EncryptedValue == nil {
+		i.value = dbval
+		return true
+	}
+
+	if err := decryptPersisted(i.keys, i.dbName, string(i.Iterator.Key()), persisted); err != nil {
+		i.err = err
+		return false
+	}
+
+	value, err := proto.Marshal(persisted)
+	if err != nil {
+		i.err = errors.Wrapf(err, "failed to marshal the decrypted value of key [%s] of database %s", i.Iterator.Key(), i.dbName)
+		return false
+	}
+
+	i.value = value
+	return true
+}
+
+// reencryption rewrites, in the background, the values that are not encrypted with the current key of their
+// database, i.e., the values of a database whose key was rotated, or whose encryption was turned on or off. The
+// values that are committed meanwhile are encrypted with the current key.
+type reencryption struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+func (l *LevelDB) startReencryption() {
+	if l.encryption == nil {
+		return
+	}
+
+	l.reencryption = &reencryption{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go l.reencrypt()
+}
+
+func (l *LevelDB) stopReencryption() {
+	if l.reencryption == nil {
+		return
+	}
+
+	select {
+	case <-l.reencryption.stop:
+	default:
+		close(l.reencryption.stop)
+	}
+	<-l.reencryption.done
+}
+
+func (r *reencryption) stopped() bool {
+	select {
+	case <-r.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *LevelDB) reencrypt() {
+	defer close(l.reencryption.done)
+
+	l.dbsList.RLock()
+	var dbNames []string
+	for dbName := range l.dbs {
+		if !worldstate.IsSystemDB(dbName) {
+			dbNames = append(dbNames, dbName)
+		}
+	}
+	l.dbsList.RUnlock()
+	sort.Strings(dbNames)
+
+	for _, dbName := range dbNames {
+		rewritten, err := l.reencryptDB(dbName)
+		if err != nil {
+			l.logger.Errorf("failed to re-encrypt the values of database %s: %s", dbName, err)
+			continue
+		}
+		if rewritten > 0 {
+			l.logger.Infof("re-encrypted %d values of database %s with key [%s]", rewritten, dbName, l.encryption.DBKeyID(dbName))
+		}
+		if l.reencryption.stopped() {
+			return
+		}
+	}
+}
+
+// reencryptDB re-encrypts the values of a database, in batches, and returns the number of values re-encrypted
+func (l *LevelDB) reencryptDB(dbName string) (int, error) {
+	var start []byte
+	total := 0
+	for !l.reencryption.stopped() {
+		next, rewritten, err := l.reencryptBatch(dbName, start)
+		if err != nil {
+			return total, err
+		}
+		total += rewritten
+		if next == nil {
+			break
+		}
+		start = next
+	}
+
+	return total, nil
+}
+
+// reencryptBatch re-encrypts the values of a batch of keys, starting at a given key, and returns the key the next
+// batch starts at, or nil if the batch reached the end of the database. The batch holds the commit lock, so that a
+// value committed concurrently is not overwritten. A key whose deferred update is not yet written is rewritten in the
+// database, and is then overwritten when the deferred updates are written.
+func (l *LevelDB) reencryptBatch(dbName string, start []byte) ([]byte, int, error) {
+	l.commitMu.Lock()
+	defer l.commitMu.Unlock()
+
+	l.dbsList.RLock()
+	db := l.dbs[dbName]
+	l.dbsList.RUnlock()
+	if db == nil {
+		// the database was deleted
+		return nil, 0, nil
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	itr := db.file.NewIterator(&util.Range{Start: start}, &opt.ReadOptions{})
+	defer itr.Release()
+
+	keyID := l.encryption.DBKeyID(dbName)
+	batch := &leveldb.Batch{}
+	var next []byte
+	for scanned := 0; itr.Next(); scanned++ {
+		if scanned == reencryptBatchSize {
+			next = append([]byte{}, itr.Key()...)
+			break
+		}
+
+		persisted := &types.ValueWithMetadata{}
+		if err := proto.Unmarshal(itr.Value(), persisted); err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to unmarshal the value of key [%s]", itr.Key())
+		}
+		if persisted.BlobManifest != nil || persisted.EncryptedValue.GetKeyId() == keyID {
+			continue
+		}
+
+		key := string(itr.Key())
+		if err := decryptPersisted(l.encryption, dbName, key, persisted); err != nil {
+			return nil, 0, err
+		}
+		if err := encryptPersisted(l.encryption, dbName, key, persisted); err != nil {
+			return nil, 0, err
+		}
+		dbval, err := proto.Marshal(persisted)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to marshal the re-encrypted value of key [%s]", key)
+		}
+		batch.Put([]byte(key), dbval)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to read database %s", dbName)
+	}
+
+	if batch.Len() > 0 {
+		if err := db.file.Write(batch, db.writeOpts); err != nil {
+			return nil, 0, errors.Wrapf(err, "error while writing a re-encrypted batch to database [%s]", dbName)
+		}
+	}
+
+	return next, batch.Len(), nil
+}
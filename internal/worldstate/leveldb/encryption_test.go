@@ -0,0 +1,214 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/encryption"
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryption(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("/tmp", "encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lg, err := logger.New(&logger.Config{
+		Level:         "debug",
+		OutputPath:    []string{"stdout"},
+		ErrOutputPath: []string{"stderr"},
+		Encoding:      "console",
+	})
+	require.NoError(t, err)
+
+	writeKeys := func(keysDir string, keyIDs ...string) {
+		require.NoError(t, os.MkdirAll(keysDir, 0755))
+		for i, keyID := range keyIDs {
+			key := hex.EncodeToString(bytes.Repeat([]byte{byte(i + 1)}, encryption.KeySize))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(keysDir, keyID+encryption.KeyFileExtension), []byte(key), 0600))
+		}
+	}
+	keysDir := filepath.Join(dir, "keys")
+	writeKeys(keysDir, "key1", "key2")
+
+	// open opens the state database, and waits for the re-encryption to end
+	open := func(keysDir string, dbKeys map[string]string) *LevelDB {
+		keys, err := encryption.Load(&encryption.Config{KeysDir: keysDir, DBKeys: dbKeys})
+		require.NoError(t, err)
+		l, err := Open(&Config{
+			DBRootDir:  filepath.Join(dir, "leveldb"),
+			Encryption: keys,
+			Logger:     lg,
+		})
+		require.NoError(t, err)
+		<-l.reencryption.done
+		return l
+	}
+
+	// stored returns the value of a key as stored in the database file
+	stored := func(l *LevelDB, dbName, key string) *types.ValueWithMetadata {
+		dbval, err := l.dbs[dbName].file.Get([]byte(key), nil)
+		require.NoError(t, err)
+		persisted := &types.ValueWithMetadata{}
+		require.NoError(t, proto.Unmarshal(dbval, persisted))
+		return persisted
+	}
+
+	requireValue := func(l *LevelDB, dbName, key, expected string) {
+		value, metadata, err := l.Get(dbName, key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(expected), value)
+		require.Equal(t, uint64(2), metadata.GetVersion().GetBlockNum())
+	}
+
+	metadata := &types.Metadata{Version: &types.Version{BlockNum: 2}}
+	l := open(keysDir, map[string]string{"db1": "key1"})
+	require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{
+		worldstate.DatabasesDBName: {
+			Writes: []*worldstate.KVWithMetadata{{Key: "db1"}, {Key: "db2"}},
+		},
+	}, 1))
+	require.NoError(t, l.Commit(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "k1", Value: []byte("value1"), Metadata: metadata},
+			},
+		},
+		"db2": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "k1", Value: []byte("value1"), Metadata: metadata},
+			},
+		},
+	}, 2))
+	require.NoError(t, l.CommitDeferred(map[string]*worldstate.DBUpdates{
+		"db1": {
+			Writes: []*worldstate.KVWithMetadata{
+				{Key: "k2", Value: []byte("value2"), Metadata: metadata},
+			},
+		},
+	}, 3))
+	requireValue(l, "db1", "k2", "value2")
+	require.NoError(t, l.FlushDeferred())
+
+	// only the values of db1 are encrypted, and their metadata is kept in clear
+	for _, key := range []string{"k1", "k2"} {
+		persisted := stored(l, "db1", key)
+		require.Nil(t, persisted.Value)
+		require.Equal(t, "key1", persisted.EncryptedValue.KeyId)
+		require.True(t, proto.Equal(metadata, persisted.Metadata))
+	}
+	require.Equal(t, []byte("value1"), stored(l, "db2", "k1").Value)
+
+	requireValue(l, "db1", "k1", "value1")
+	requireValue(l, "db1", "k2", "value2")
+	requireValue(l, "db2", "k1", "value1")
+
+	itr, err := l.GetIterator("db1", "", "")
+	require.NoError(t, err)
+	var values []string
+	for itr.Next() {
+		persisted := &types.ValueWithMetadata{}
+		require.NoError(t, proto.Unmarshal(itr.Value(), persisted))
+		values = append(values, string(itr.Key())+"="+string(persisted.Value))
+	}
+	require.NoError(t, itr.Error())
+	itr.Release()
+	require.Equal(t, []string{"k1=value1", "k2=value2"}, values)
+
+	snap, err := l.GetDBsSnapshot("test", []string{"db1"})
+	require.NoError(t, err)
+	value, _, err := snap.Get("db1", "k1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), value)
+	snapItr, err := snap.GetIterator("db1", "k2", "")
+	require.NoError(t, err)
+	require.True(t, snapItr.Next())
+	persisted := &types.ValueWithMetadata{}
+	require.NoError(t, proto.Unmarshal(snapItr.Value(), persisted))
+	require.Equal(t, []byte("value2"), persisted.Value)
+	snapItr.Release()
+	snap.Release()
+	require.NoError(t, l.Close())
+
+	// the rotated key of db1, and the new key of db2, are applied to the stored values
+	l = open(keysDir, map[string]string{"db1": "key2", "db2": "key1"})
+	require.Equal(t, "key2", stored(l, "db1", "k1").EncryptedValue.KeyId)
+	require.Equal(t, "key2", stored(l, "db1", "k2").EncryptedValue.KeyId)
+	require.Equal(t, "key1", stored(l, "db2", "k1").EncryptedValue.KeyId)
+	requireValue(l, "db1", "k1", "value1")
+	requireValue(l, "db1", "k2", "value2")
+	requireValue(l, "db2", "k1", "value1")
+	require.NoError(t, l.Close())
+
+	// a value encrypted with a key that is not found cannot be read
+	onlyKey1Dir := filepath.Join(dir, "only-key1")
+	writeKeys(onlyKey1Dir, "key1")
+	l = open(onlyKey1Dir, nil)
+	_, _, err = l.Get("db1", "k1")
+	require.EqualError(t, err, "the value of key [k1] of database [db1] is encrypted with key [key2], which is not found")
+	itr, err = l.GetIterator("db1", "", "")
+	require.NoError(t, err)
+	require.False(t, itr.Next())
+	require.EqualError(t, itr.Error(), "the value of key [k1] of database [db1] is encrypted with key [key2], which is not found")
+	itr.Release()
+	// whereas the values of db2 were decrypted
+	require.Equal(t, []byte("value1"), stored(l, "db2", "k1").Value)
+	require.NoError(t, l.Close())
+
+	// the values of the databases whose encryption is turned off are decrypted
+	l = open(keysDir, nil)
+	require.Equal(t, []byte("value1"), stored(l, "db1", "k1").Value)
+	require.Nil(t, stored(l, "db1", "k1").EncryptedValue)
+	require.Equal(t, []byte("value2"), stored(l, "db1", "k2").Value)
+	requireValue(l, "db1", "k1", "value1")
+	require.NoError(t, l.Close())
+}
+
+func TestReencryptInBatches(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	keysDir, err := ioutil.TempDir("/tmp", "keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(keysDir)
+	key := hex.EncodeToString(bytes.Repeat([]byte{1}, encryption.KeySize))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(keysDir, "key1.key"), []byte(key), 0600))
+
+	var writes []*worldstate.KVWithMetadata
+	for i := 0; i < 2*reencryptBatchSize+1; i++ {
+		writes = append(writes, &worldstate.KVWithMetadata{Key: fmt.Sprintf("key%04d", i), Value: []byte("value")})
+	}
+	require.NoError(t, env.l.Commit(map[string]*worldstate.DBUpdates{worldstate.DefaultDBName: {Writes: writes}}, 1))
+
+	env.l.encryption, err = encryption.Load(&encryption.Config{KeysDir: keysDir, DBKeys: map[string]string{worldstate.DefaultDBName: "key1"}})
+	require.NoError(t, err)
+	env.l.startReencryption()
+	<-env.l.reencryption.done
+
+	itr := env.l.dbs[worldstate.DefaultDBName].file.NewIterator(nil, nil)
+	defer itr.Release()
+	count := 0
+	for itr.Next() {
+		persisted := &types.ValueWithMetadata{}
+		require.NoError(t, proto.Unmarshal(itr.Value(), persisted))
+		require.Equal(t, "key1", persisted.EncryptedValue.GetKeyId())
+		count++
+	}
+	require.Equal(t, len(writes), count)
+}
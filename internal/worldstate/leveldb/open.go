@@ -1,13 +1,24 @@
 // Copyright IBM Corp. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
+
+// Package leveldb implements the state database with LevelDB. Every database, including the system databases, is
+// stored in its own LevelDB instance, in a directory named by the escaped name of the database under the root
+// directory. The keys of a database are stored as they are, without a prefix that holds the name of the database,
+// such that a database is compacted on its own, and is deleted by removing its directory.
 package leveldb
 
 import (
+	"net/url"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/encryption"
 	"github.com/hyperledger-labs/orion-server/internal/fileops"
+	"github.com/hyperledger-labs/orion-server/internal/storeformat"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
 	"github.com/pkg/errors"
@@ -23,10 +34,19 @@ var (
 	// before creating a new levelDB instance
 	underCreationFlag = "undercreation"
 	// allowedCharsInDBName holds the regexp for allowed characters
-	// in a database name
-	allowedCharsInDBName = `^[0-9a-zA-Z_\-\.]+$`
+	// in a database name. A name can be a hierarchical path of
+	// segments separated by '/', such as org1/app2/db
+	allowedCharsInDBName = `^[0-9a-zA-Z_\-\.]+(/[0-9a-zA-Z_\-\.]+)*$`
 )
 
+// format is the on-disk format of the state database. Version 1 is the layout of one LevelDB instance per database.
+// A change of the layout of the databases or of the encoding of the values and metadata increments the version, and
+// registers the migration from the previous version.
+var format = &storeformat.Format{
+	Store:   "state database",
+	Version: 1,
+}
+
 // LevelDB holds information about all created database
 type LevelDB struct {
 	dbRootDir   string
@@ -34,6 +54,19 @@ type LevelDB struct {
 	logger      *logger.SugarLogger
 	dbsList     sync.RWMutex
 	dbNameRegex *regexp.Regexp
+	blobs       *blobstore.Store
+	encryption  *encryption.Keys
+	// reencryption re-encrypts, in the background, the values that are not encrypted with the current key of their
+	// database
+	reencryption *reencryption
+	// deferred holds the updates of the blocks committed with CommitDeferred that are not yet written
+	deferred *deferredUpdates
+	// beforeFlushDeferred, if not nil, is called before the deferred updates are written
+	beforeFlushDeferred func() error
+	// commitMu serializes the writes of updates to the databases
+	commitMu sync.Mutex
+	// snapshots tracks the snapshots that are not yet released
+	snapshots *snapshotTracker
 }
 
 // db - a wrapper on an actual store
@@ -43,6 +76,9 @@ type db struct {
 	mu        sync.RWMutex
 	readOpts  *opt.ReadOptions
 	writeOpts *opt.WriteOptions
+	// deletes is the number of keys deleted since the database was opened or last compacted. It is accessed
+	// atomically, as a compaction does not hold the lock of the database.
+	deletes uint64
 }
 
 var (
@@ -54,7 +90,16 @@ var (
 
 type Config struct {
 	DBRootDir string
-	Logger    *logger.SugarLogger
+	// BlobStore holds the chunks of the values that are committed with a blob manifest. It may be nil when no
+	// such value is committed.
+	BlobStore *blobstore.Store
+	// Encryption holds the keys with which the values of the databases are encrypted in the world state. It may be nil
+	// when no database is encrypted.
+	Encryption *encryption.Keys
+	// SnapshotLeakTimeout is the time after which a snapshot that is not read is released by force; zero disables
+	// the forced release.
+	SnapshotLeakTimeout time.Duration
+	Logger              *logger.SugarLogger
 }
 
 // Open opens a leveldb instance to maintain world state
@@ -64,7 +109,7 @@ func Open(conf *Config) (*LevelDB, error) {
 		return nil, err
 	}
 	if !exist {
-		return openNewLevelDBInstance(conf)
+		return started(openNewLevelDBInstance(conf))
 	}
 
 	partialInstanceExist, err := isExistingLevelDBInstanceCreatedPartially(conf.DBRootDir)
@@ -78,10 +123,25 @@ func Open(conf *Config) (*LevelDB, error) {
 			return nil, errors.Wrap(err, "error while removing the existing partially created levelDB instance")
 		}
 
-		return openNewLevelDBInstance(conf)
+		return started(openNewLevelDBInstance(conf))
 	default:
-		return openExistingLevelDBInstance(conf)
+		if err := format.Upgrade(conf.DBRootDir, conf.Logger); err != nil {
+			return nil, err
+		}
+		return started(openExistingLevelDBInstance(conf))
+	}
+}
+
+// started starts the background tasks of an opened instance: the check of the open snapshots, and the re-encryption
+// of the values, when the values of its databases are encrypted
+func started(l *LevelDB, err error) (*LevelDB, error) {
+	if err != nil {
+		return nil, err
 	}
+
+	l.snapshots.start()
+	l.startReencryption()
+	return l, nil
 }
 
 func isExistingLevelDBInstanceCreatedPartially(dbPath string) (bool, error) {
@@ -112,6 +172,10 @@ func openNewLevelDBInstance(c *Config) (*LevelDB, error) {
 		dbs:         make(map[string]*db),
 		logger:      c.Logger,
 		dbNameRegex: regexp.MustCompile(allowedCharsInDBName),
+		blobs:       c.BlobStore,
+		encryption:  c.Encryption,
+		deferred:    newDeferredUpdates(),
+		snapshots:   newSnapshotTracker(c.SnapshotLeakTimeout, c.Logger),
 	}
 
 	for _, dbName := range preCreateDBs {
@@ -120,6 +184,10 @@ func openNewLevelDBInstance(c *Config) (*LevelDB, error) {
 		}
 	}
 
+	if err := format.Init(c.DBRootDir); err != nil {
+		return nil, err
+	}
+
 	if err := fileops.Remove(underCreationFlagPath); err != nil {
 		return nil, errors.WithMessagef(err, "error while removing the under creation flag [%s]", underCreationFlagPath)
 	}
@@ -133,16 +201,25 @@ func openExistingLevelDBInstance(c *Config) (*LevelDB, error) {
 		dbs:         make(map[string]*db),
 		logger:      c.Logger,
 		dbNameRegex: regexp.MustCompile(allowedCharsInDBName),
+		blobs:       c.BlobStore,
+		encryption:  c.Encryption,
+		deferred:    newDeferredUpdates(),
+		snapshots:   newSnapshotTracker(c.SnapshotLeakTimeout, c.Logger),
 	}
 
-	dbNames, err := fileops.ListSubdirs(c.DBRootDir)
+	dirNames, err := fileops.ListSubdirs(c.DBRootDir)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to retrieve existing level dbs from %s", c.DBRootDir)
 	}
 
-	for _, dbName := range dbNames {
+	for _, dirName := range dirNames {
+		dbName, err := dbNameFromDir(dirName)
+		if err != nil {
+			return nil, err
+		}
+
 		file, err := leveldb.OpenFile(
-			filepath.Join(l.dbRootDir, dbName),
+			filepath.Join(l.dbRootDir, dirName),
 			&opt.Options{ErrorIfMissing: false},
 		)
 		if err != nil {
@@ -157,11 +234,27 @@ func openExistingLevelDBInstance(c *Config) (*LevelDB, error) {
 		}
 	}
 
+	// the system databases introduced after the instance was created are created on open
+	for _, dbName := range worldstate.SystemDBs() {
+		if err := l.create(dbName); err != nil {
+			return nil, err
+		}
+	}
+
 	return l, nil
 }
 
 // Close closes the database instance by closing all leveldb databases
 func (l *LevelDB) Close() error {
+	l.stopReencryption()
+	l.snapshots.stopChecks()
+
+	// the deferred updates are also replayed from the block store during recovery, hence a failure to write them is
+	// not fatal
+	if err := l.FlushDeferred(); err != nil {
+		l.logger.Warnf("failed to write the deferred updates before closing: %s", err)
+	}
+
 	l.dbsList.Lock()
 	defer l.dbsList.Unlock()
 
@@ -181,5 +274,32 @@ func (l *LevelDB) Close() error {
 
 // ValidDBName returns true if the given dbName is valid
 func (l *LevelDB) ValidDBName(dbName string) bool {
-	return l.dbNameRegex.MatchString(dbName)
+	if !l.dbNameRegex.MatchString(dbName) {
+		return false
+	}
+
+	for _, segment := range strings.Split(dbName, "/") {
+		if segment == "." || segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dbDir returns the directory name of the given database. As a hierarchical
+// database name holds '/', the name is escaped so that all databases are
+// stored as flat directories under the root directory
+func dbDir(dbName string) string {
+	return url.QueryEscape(dbName)
+}
+
+// dbNameFromDir returns the database name stored in the given directory
+func dbNameFromDir(dirName string) (string, error) {
+	dbName, err := url.QueryUnescape(dirName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to decode the database name from the directory %s", dirName)
+	}
+
+	return dbName, nil
 }
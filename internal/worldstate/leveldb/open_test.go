@@ -25,8 +25,9 @@ func TestOpenLevelDBInstance(t *testing.T) {
 			require.NotNil(t, l.dbs[dbName])
 		}
 
-		require.False(t, l.ValidDBName("db1/name"))
+		require.False(t, l.ValidDBName("db1//name"))
 		require.True(t, l.ValidDBName("db_2"))
+		require.True(t, l.ValidDBName("db1/name"))
 	}
 
 	c := &logger.Config{
@@ -177,6 +178,68 @@ func TestOpenLevelDBInstance(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, []byte("value1"), actualValue)
 	})
+
+	t.Run("reopen leveldb with a hierarchical database name", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir("", "opentest")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		dbRootDir := filepath.Join(testDir, "reopen-hierarchical-store")
+		conf := &Config{
+			DBRootDir: dbRootDir,
+			Logger:    logger,
+		}
+		l, err := Open(conf)
+		require.NoError(t, err)
+
+		dbName := "org1/app2/db"
+		require.NoError(t, l.create(dbName))
+		require.DirExists(t, filepath.Join(dbRootDir, "org1%2Fapp2%2Fdb"))
+		require.NoDirExists(t, filepath.Join(dbRootDir, "org1"))
+
+		// close and reopen the store
+		require.NoError(t, l.Close())
+		l, err = Open(conf)
+		defer func() {
+			require.NoError(t, l.Close())
+		}()
+		require.NoError(t, err)
+
+		require.True(t, l.Exist(dbName))
+		require.Equal(t, []string{dbName}, l.ListDBs())
+
+		require.NoError(t, l.delete(dbName))
+		require.NoDirExists(t, filepath.Join(dbRootDir, "org1%2Fapp2%2Fdb"))
+	})
+
+	t.Run("reopen leveldb created before a system database was introduced", func(t *testing.T) {
+		t.Parallel()
+
+		testDir, err := ioutil.TempDir("", "opentest")
+		require.NoError(t, err)
+		defer os.RemoveAll(testDir)
+
+		dbRootDir := filepath.Join(testDir, "reopen-old-store")
+		conf := &Config{
+			DBRootDir: dbRootDir,
+			Logger:    logger,
+		}
+		l, err := Open(conf)
+		require.NoError(t, err)
+
+		require.NoError(t, l.Close())
+		require.NoError(t, os.RemoveAll(filepath.Join(dbRootDir, dbDir(worldstate.LegalHoldsDBName))))
+
+		l, err = Open(conf)
+		defer func() {
+			require.NoError(t, l.Close())
+		}()
+		require.NoError(t, err)
+
+		assertDBInstance(dbRootDir, l)
+	})
 }
 
 func TestValidDBName(t *testing.T) {
@@ -190,11 +253,31 @@ func TestValidDBName(t *testing.T) {
 			dbName:         "db1DZ0-_.",
 			expectedResult: true,
 		},
+		{
+			name:           "valid hierarchical db name",
+			dbName:         "org1/app-2/db_3",
+			expectedResult: true,
+		},
 		{
 			name:           "invalid db name",
 			dbName:         "/db1DZ0/-_.",
 			expectedResult: false,
 		},
+		{
+			name:           "invalid db name with an empty segment",
+			dbName:         "org1//db",
+			expectedResult: false,
+		},
+		{
+			name:           "invalid db name with a trailing slash",
+			dbName:         "org1/db/",
+			expectedResult: false,
+		},
+		{
+			name:           "invalid db name with a relative path segment",
+			dbName:         "org1/../db",
+			expectedResult: false,
+		},
 		{
 			name:           "invalid db name",
 			dbName:         "$p",
@@ -1,9 +1,14 @@
 package leveldb
 
 import (
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blobstore"
+	"github.com/hyperledger-labs/orion-server/internal/encryption"
 	"github.com/hyperledger-labs/orion-server/internal/worldstate"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -13,17 +18,51 @@ import (
 )
 
 type Snapshots struct {
-	dbSnap map[string]*leveldb.Snapshot
+	dbSnap     map[string]*leveldb.Snapshot
+	height     uint64
+	blobs      *blobstore.Store
+	encryption *encryption.Keys
 	sync.RWMutex
+
+	// id, owner, acquired, and names describe the snapshot to the tracker of the open snapshots
+	id       uint64
+	owner    string
+	acquired time.Time
+	names    []string
+	tracker  *snapshotTracker
+	// lastRead holds the time of the last read of the snapshot, in nanoseconds since the epoch. It is updated by the
+	// concurrent reads, hence it is accessed atomically.
+	lastRead int64
+	// forced is set when the snapshot was released by the tracker, as it was not read for longer than the leak
+	// timeout
+	forced bool
 }
 
-func (l *LevelDB) GetDBsSnapshot(dbNames []string) (worldstate.DBsSnapshot, error) {
+func (l *LevelDB) GetDBsSnapshot(owner string, dbNames []string) (worldstate.DBsSnapshot, error) {
+	// the snapshots are taken between commits, so that they hold the databases at a single height. A snapshot reads
+	// the databases themselves, hence it requires the deferred updates to be written.
+	l.commitMu.Lock()
+	defer l.commitMu.Unlock()
+	if err := l.flushDeferred(); err != nil {
+		return nil, err
+	}
+	height, err := l.Height()
+	if err != nil {
+		return nil, err
+	}
+
 	l.dbsList.RLock()
 	defer l.dbsList.RUnlock()
 
 	snap := &Snapshots{
-		dbSnap: make(map[string]*leveldb.Snapshot),
+		dbSnap:     make(map[string]*leveldb.Snapshot),
+		height:     height,
+		blobs:      l.blobs,
+		encryption: l.encryption,
+		owner:      owner,
+		names:      append([]string(nil), dbNames...),
 	}
+	sort.Strings(snap.names)
 
 	for _, dbName := range dbNames {
 		db, ok := l.dbs[dbName]
@@ -44,13 +83,23 @@ func (l *LevelDB) GetDBsSnapshot(dbNames []string) (worldstate.DBsSnapshot, erro
 		snap.dbSnap[dbName] = s
 	}
 
+	l.snapshots.track(snap)
+
 	return snap, nil
 }
 
+// OpenSnapshots returns the snapshots that are not yet released, oldest first
+func (l *LevelDB) OpenSnapshots() []*worldstate.SnapshotInfo {
+	return l.snapshots.list()
+}
+
 func (s *Snapshots) Get(dbName, key string) ([]byte, *types.Metadata, error) {
 	s.RLock()
 	defer s.RUnlock()
 
+	if err := s.read(); err != nil {
+		return nil, nil, err
+	}
 	lSnap, ok := s.dbSnap[dbName]
 	if !ok {
 		return nil, nil, errors.New(dbName + " is needed to fetch the index definiton and is not snapshotted")
@@ -69,7 +118,15 @@ func (s *Snapshots) Get(dbName, key string) ([]byte, *types.Metadata, error) {
 		return nil, nil, err
 	}
 
-	return persisted.Value, persisted.Metadata, nil
+	if err := decryptPersisted(s.encryption, dbName, key, persisted); err != nil {
+		return nil, nil, err
+	}
+	value, err := resolveBlob(s.blobs, persisted)
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "failed to retrieve the value of key [%s] from the snapshot of database [%s]", key, dbName)
+	}
+
+	return value, persisted.Metadata, nil
 }
 
 func (s *Snapshots) GetIndexDefinition(dbName string) ([]byte, *types.Metadata, error) {
@@ -80,6 +137,9 @@ func (s *Snapshots) GetIterator(dbName string, startKey, endKey string) (worldst
 	s.RLock()
 	defer s.RUnlock()
 
+	if err := s.read(); err != nil {
+		return nil, err
+	}
 	lSnap, ok := s.dbSnap[dbName]
 	if !ok {
 		return nil, errors.New(dbName + " database is not snapshotted")
@@ -98,16 +158,72 @@ func (s *Snapshots) GetIterator(dbName string, startKey, endKey string) (worldst
 		r.Limit = []byte(endKey)
 	}
 
-	return lSnap.NewIterator(r, &opt.ReadOptions{}), nil
+	return newDecryptingIterator(lSnap.NewIterator(r, &opt.ReadOptions{}), dbName, s.encryption), nil
+}
+
+// Height returns the height of the state database when the snapshots were taken
+func (s *Snapshots) Height() uint64 {
+	return s.height
 }
 
 func (s *Snapshots) Release() {
 	s.Lock()
 	defer s.Unlock()
 
+	s.release()
+}
+
+// forceRelease releases the snapshot on behalf of its owner, and returns false if the owner released it meanwhile
+func (s *Snapshots) forceRelease() bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.dbSnap == nil {
+		return false
+	}
+	s.release()
+	s.forced = true
+	return true
+}
+
+func (s *Snapshots) release() {
 	for _, lSnap := range s.dbSnap {
 		lSnap.Release()
 	}
 
 	s.dbSnap = nil
+	if s.tracker != nil {
+		s.tracker.untrack(s)
+	}
+}
+
+// read records a read of the snapshot, or returns an error if the snapshot was released by force, so that its owner
+// is told why the read fails
+func (s *Snapshots) read() error {
+	if s.forced {
+		return errors.Errorf("snapshot [%d] taken by [%s] was released, as it was not read for longer than the leak timeout of %s",
+			s.id, s.owner, s.tracker.leakTimeout)
+	}
+
+	atomic.StoreInt64(&s.lastRead, time.Now().UnixNano())
+	return nil
+}
+
+// lastReadTime returns the time of the last read of the snapshot, or the time it was taken if it was not read
+func (s *Snapshots) lastReadTime() time.Time {
+	if lastRead := atomic.LoadInt64(&s.lastRead); lastRead != 0 {
+		return time.Unix(0, lastRead)
+	}
+	return s.acquired
+}
+
+func (s *Snapshots) info() *worldstate.SnapshotInfo {
+	return &worldstate.SnapshotInfo{
+		ID:       s.id,
+		Owner:    s.owner,
+		DBNames:  s.names,
+		Height:   s.height,
+		Acquired: s.acquired,
+		LastRead: s.lastReadTime(),
+	}
 }
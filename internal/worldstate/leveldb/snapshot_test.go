@@ -19,7 +19,7 @@ func TestSnapshots(t *testing.T) {
 	require.NoError(t, env.l.create(db1))
 	require.NoError(t, env.l.create(db2))
 
-	s0, err := env.l.GetDBsSnapshot([]string{db1, db2})
+	s0, err := env.l.GetDBsSnapshot("test", []string{db1, db2})
 	require.NoError(t, err)
 	defer s0.Release()
 
@@ -40,7 +40,7 @@ func TestSnapshots(t *testing.T) {
 	// as a result, the new snapshot s1 should return
 	// some kv pairs while the old snapshot s0 should
 	// not return any kv pairs
-	s1, err := env.l.GetDBsSnapshot([]string{db1, db2})
+	s1, err := env.l.GetDBsSnapshot("test", []string{db1, db2})
 	require.NoError(t, err)
 	defer s1.Release()
 
@@ -58,13 +58,18 @@ func TestSnapshots(t *testing.T) {
 
 	// acquire a new snapshot s2. The snapshot s2 should
 	// not return any kv pairs while s1 should
-	s2, err := env.l.GetDBsSnapshot([]string{db1, db2})
+	s2, err := env.l.GetDBsSnapshot("test", []string{db1, db2})
 	require.NoError(t, err)
 	defer s2.Release()
 
 	verifyEmptiness(t, s2)
 	verifyNonEmptiness(t, s1)
 	verifyEmptiness(t, s0)
+
+	// every snapshot holds the height it was taken at
+	require.Equal(t, uint64(0), s0.Height())
+	require.Equal(t, uint64(1), s1.Height())
+	require.Equal(t, uint64(2), s2.Height())
 }
 
 func verifyEmptiness(t *testing.T, db snapshotTestAPIs) {
@@ -0,0 +1,195 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxSnapshotCheckInterval is the maximal interval at which the ages of the open snapshots are checked
+const maxSnapshotCheckInterval = 10 * time.Second
+
+var (
+	openSnapshotsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "worldstate",
+		Name:      "open_snapshots",
+		Help:      "The number of open snapshots of the state database, by owner.",
+	}, []string{"owner"})
+
+	oldestSnapshotAgeGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "orion",
+		Subsystem: "worldstate",
+		Name:      "oldest_snapshot_age_seconds",
+		Help:      "The age of the oldest open snapshot of the state database, by owner, as of the last check.",
+	}, []string{"owner"})
+
+	leakedSnapshotsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "orion",
+		Subsystem: "worldstate",
+		Name:      "leaked_snapshots_total",
+		Help:      "The number of snapshots of the state database released by force, as they were not read for longer than the leak timeout, by owner.",
+	}, []string{"owner"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		openSnapshotsGauge,
+		oldestSnapshotAgeGauge,
+		leakedSnapshotsCounter,
+	)
+}
+
+// snapshotTracker tracks the lifetimes of the open snapshots. A snapshot pins the memtables and the table files of
+// LevelDB until it is released, hence a snapshot that is never released, or is held for long, makes the memory and
+// the disk usage of the node grow without a visible cause. The tracker exposes the open snapshots with their owners
+// and ages, and, if a leak timeout is set, considers a snapshot that is not read for longer than the timeout as
+// leaked, and releases it by force. The later reads of the snapshot fail, whereas its open iterators remain valid.
+type snapshotTracker struct {
+	leakTimeout time.Duration
+	logger      *logger.SugarLogger
+
+	lock   sync.Mutex
+	nextID uint64
+	open   map[uint64]*Snapshots
+	// owners holds every owner that took a snapshot, so that the age of the oldest snapshot of an owner is reset once
+	// the owner holds no snapshot
+	owners map[string]struct{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newSnapshotTracker(leakTimeout time.Duration, logger *logger.SugarLogger) *snapshotTracker {
+	return &snapshotTracker{
+		leakTimeout: leakTimeout,
+		logger:      logger,
+		open:        make(map[uint64]*Snapshots),
+		owners:      make(map[string]struct{}),
+	}
+}
+
+// track assigns an ID to a snapshot and tracks it until it is released
+func (t *snapshotTracker) track(s *Snapshots) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.nextID++
+	s.id = t.nextID
+	s.acquired = time.Now()
+	s.tracker = t
+	t.open[s.id] = s
+	t.owners[s.owner] = struct{}{}
+	openSnapshotsGauge.WithLabelValues(s.owner).Inc()
+}
+
+func (t *snapshotTracker) untrack(s *Snapshots) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.open[s.id]; !ok {
+		return
+	}
+	delete(t.open, s.id)
+	openSnapshotsGauge.WithLabelValues(s.owner).Dec()
+}
+
+// list returns the open snapshots, ordered by ID, i.e., oldest first
+func (t *snapshotTracker) list() []*worldstate.SnapshotInfo {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	infos := make([]*worldstate.SnapshotInfo, 0, len(t.open))
+	for _, s := range t.open {
+		infos = append(infos, s.info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
+
+// check updates the ages of the oldest snapshots of the owners, and releases the snapshots that were not read for
+// longer than the leak timeout
+func (t *snapshotTracker) check(now time.Time) {
+	oldest := make(map[string]time.Duration)
+	var leaked []*Snapshots
+
+	t.lock.Lock()
+	for owner := range t.owners {
+		oldest[owner] = 0
+	}
+	for _, s := range t.open {
+		age := now.Sub(s.acquired)
+		if age > oldest[s.owner] {
+			oldest[s.owner] = age
+		}
+		if t.leakTimeout > 0 && now.Sub(s.lastReadTime()) > t.leakTimeout {
+			leaked = append(leaked, s)
+		}
+	}
+	t.lock.Unlock()
+
+	for owner, age := range oldest {
+		oldestSnapshotAgeGauge.WithLabelValues(owner).Set(age.Seconds())
+	}
+
+	// a snapshot is released outside the lock of the tracker, as the release waits for the reads of the snapshot
+	sort.Slice(leaked, func(i, j int) bool {
+		return leaked[i].id < leaked[j].id
+	})
+	for _, s := range leaked {
+		if !s.forceRelease() {
+			continue
+		}
+		leakedSnapshotsCounter.WithLabelValues(s.owner).Inc()
+		t.logger.Warnf("released snapshot [%d] of the databases %v, taken by [%s] at height %d %s ago, as it was not "+
+			"read for longer than the leak timeout of %s", s.id, s.names, s.owner, s.height, now.Sub(s.acquired), t.leakTimeout)
+	}
+}
+
+// start checks the open snapshots periodically until the tracker is stopped
+func (t *snapshotTracker) start() {
+	interval := maxSnapshotCheckInterval
+	if half := t.leakTimeout / 2; half > 0 && half < interval {
+		interval = half
+	}
+
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+	go func() {
+		defer close(t.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-t.stop:
+				return
+			case now := <-ticker.C:
+				t.check(now)
+			}
+		}
+	}()
+}
+
+func (t *snapshotTracker) stopChecks() {
+	if t.stop == nil {
+		return
+	}
+
+	select {
+	case <-t.stop:
+	default:
+		close(t.stop)
+	}
+	<-t.done
+}
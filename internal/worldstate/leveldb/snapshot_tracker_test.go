@@ -0,0 +1,126 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package leveldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/internal/worldstate"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotTracker(t *testing.T) {
+	open := func(owner string) float64 {
+		return testutil.ToFloat64(openSnapshotsGauge.WithLabelValues(owner))
+	}
+	leaked := func(owner string) float64 {
+		return testutil.ToFloat64(leakedSnapshotsCounter.WithLabelValues(owner))
+	}
+	owners := func(infos []*worldstate.SnapshotInfo) []string {
+		var owners []string
+		for _, info := range infos {
+			owners = append(owners, info.Owner)
+		}
+		return owners
+	}
+
+	t.Run("open snapshots are listed until released", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+		require.NoError(t, env.l.create("db1"))
+		openQueries, openCursors := open("tracker query"), open("tracker cursor")
+
+		s1, err := env.l.GetDBsSnapshot("tracker query", []string{worldstate.DatabasesDBName, "db1"})
+		require.NoError(t, err)
+		s2, err := env.l.GetDBsSnapshot("tracker cursor", []string{"db1"})
+		require.NoError(t, err)
+		require.Equal(t, openQueries+1, open("tracker query"))
+		require.Equal(t, openCursors+1, open("tracker cursor"))
+
+		infos := env.l.OpenSnapshots()
+		require.Equal(t, []string{"tracker query", "tracker cursor"}, owners(infos))
+		require.Less(t, infos[0].ID, infos[1].ID)
+		require.Equal(t, []string{worldstate.DatabasesDBName, "db1"}, infos[0].DBNames)
+		require.Equal(t, uint64(0), infos[0].Height)
+		require.Equal(t, infos[0].Acquired, infos[0].LastRead)
+
+		_, _, err = s1.Get("db1", "key1")
+		require.NoError(t, err)
+		require.True(t, env.l.OpenSnapshots()[0].LastRead.After(infos[0].Acquired))
+
+		s1.Release()
+		s1.Release()
+		require.Equal(t, openQueries, open("tracker query"))
+		require.Equal(t, []string{"tracker cursor"}, owners(env.l.OpenSnapshots()))
+
+		s2.Release()
+		require.Empty(t, env.l.OpenSnapshots())
+		require.Equal(t, openCursors, open("tracker cursor"))
+	})
+
+	t.Run("snapshots that are not read are released by force", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+		require.NoError(t, env.l.create("db1"))
+		env.l.snapshots.leakTimeout = time.Minute
+		leakedBefore := leaked("tracker leak")
+
+		idle, err := env.l.GetDBsSnapshot("tracker leak", []string{"db1"})
+		require.NoError(t, err)
+		itr, err := idle.GetIterator("db1", "", "")
+		require.NoError(t, err)
+		defer itr.Release()
+		active, err := env.l.GetDBsSnapshot("tracker leak", []string{"db1"})
+		require.NoError(t, err)
+		defer active.Release()
+
+		// the snapshots are not released before the leak timeout
+		env.l.snapshots.check(time.Now().Add(30 * time.Second))
+		require.Len(t, env.l.OpenSnapshots(), 2)
+		require.Equal(t, leakedBefore, leaked("tracker leak"))
+		require.InDelta(t, 30, testutil.ToFloat64(oldestSnapshotAgeGauge.WithLabelValues("tracker leak")), 1)
+
+		// the active snapshot is read after the idle one was last read
+		idle.(*Snapshots).lastRead = time.Now().Add(-time.Minute).UnixNano()
+		_, _, err = active.Get("db1", "key1")
+		require.NoError(t, err)
+
+		env.l.snapshots.check(time.Now().Add(30 * time.Second))
+		require.Equal(t, leakedBefore+1, leaked("tracker leak"))
+		infos := env.l.OpenSnapshots()
+		require.Len(t, infos, 1)
+		require.Equal(t, active.(*Snapshots).id, infos[0].ID)
+
+		_, _, err = idle.Get("db1", "key1")
+		require.EqualError(t, err, "snapshot [1] taken by [tracker leak] was released, as it was not read for longer "+
+			"than the leak timeout of 1m0s")
+		_, err = idle.GetIterator("db1", "", "")
+		require.Error(t, err)
+
+		// the open iterator of the released snapshot remains valid
+		require.False(t, itr.Next())
+		require.NoError(t, itr.Error())
+
+		// the release by the owner is a no-op
+		idle.Release()
+		require.Len(t, env.l.OpenSnapshots(), 1)
+	})
+
+	t.Run("no forced release without a leak timeout", func(t *testing.T) {
+		env := newTestEnv(t)
+		defer env.cleanup()
+		require.NoError(t, env.l.create("db1"))
+
+		s, err := env.l.GetDBsSnapshot("tracker no leak", []string{"db1"})
+		require.NoError(t, err)
+		defer s.Release()
+
+		env.l.snapshots.check(time.Now().Add(24 * time.Hour))
+		require.Len(t, env.l.OpenSnapshots(), 1)
+		_, _, err = s.Get("db1", "key1")
+		require.NoError(t, err)
+	})
+}
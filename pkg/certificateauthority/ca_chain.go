@@ -3,9 +3,11 @@
 package certificateauthority
 
 import (
+	"bytes"
 	"crypto/x509"
 	"encoding/pem"
 	"io/ioutil"
+	"strings"
 
 	"github.com/hyperledger-labs/orion-server/config"
 	"github.com/hyperledger-labs/orion-server/pkg/types"
@@ -16,6 +18,7 @@ type CACertCollection struct {
 	roots         []*x509.Certificate
 	intermediates []*x509.Certificate
 	opts          x509.VerifyOptions
+	scopes        []*types.CAScope
 }
 
 // NewCACertCollection creates a new  CACertCollection from a set of root CAs and
@@ -65,6 +68,122 @@ func (c *CACertCollection) VerifyLeafCert(asn1Data []byte) error {
 	return nil
 }
 
+// SetScopes sets the scopes of the CAs of the organizations, which restrict the IDs of the users and nodes whose
+// certificates are verified by VerifyUserCert and VerifyNodeCert.
+func (c *CACertCollection) SetScopes(scopes []*types.CAScope) {
+	c.scopes = scopes
+}
+
+// VerifyUserCert verifies the given certificate of a user, or of an admin, against the CA certificates in the
+// collection, and against the scopes of the CAs.
+func (c *CACertCollection) VerifyUserCert(asn1Data []byte, userID string) error {
+	return c.verifyScopedLeafCert(asn1Data, "user", userID, func(scope *types.CAScope) bool {
+		for _, prefix := range scope.UserIdPrefixes {
+			if strings.HasPrefix(userID, prefix) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// VerifyNodeCert verifies the given certificate of a node against the CA certificates in the collection, and against
+// the scopes of the CAs.
+func (c *CACertCollection) VerifyNodeCert(asn1Data []byte, nodeID string) error {
+	return c.verifyScopedLeafCert(asn1Data, "node", nodeID, func(scope *types.CAScope) bool {
+		for _, id := range scope.NodeIds {
+			if id == nodeID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// verifyScopedLeafCert verifies a leaf certificate, and that, if the certificate is issued by some organizations, or
+// if the ID is in the scope of some organizations, the certificate is issued by an organization whose scope includes
+// the ID.
+func (c *CACertCollection) verifyScopedLeafCert(asn1Data []byte, kind, id string, inScope func(scope *types.CAScope) bool) error {
+	if len(c.scopes) == 0 {
+		return c.VerifyLeafCert(asn1Data)
+	}
+
+	cas, err := c.GetLeafCertChainCAs(asn1Data)
+	if err != nil {
+		return err
+	}
+	chain := make(map[string]bool)
+	for _, ca := range cas {
+		chain[string(ca)] = true
+	}
+
+	var issuers, owners []string
+	for _, scope := range c.scopes {
+		issued := false
+		for _, ca := range scope.Cas {
+			if chain[string(ca)] {
+				issued = true
+				break
+			}
+		}
+		owned := inScope(scope)
+
+		switch {
+		case issued && owned:
+			return nil
+		case issued:
+			issuers = append(issuers, scope.Organization)
+		case owned:
+			owners = append(owners, scope.Organization)
+		}
+	}
+
+	switch {
+	case len(issuers) > 0:
+		return errors.Errorf("the certificate is issued by the organization [%s], whose scope does not include the %s [%s]",
+			strings.Join(issuers, ", "), kind, id)
+	case len(owners) > 0:
+		return errors.Errorf("the %s [%s] is in the scope of the organization [%s], which did not issue the certificate",
+			kind, id, strings.Join(owners, ", "))
+	default:
+		return nil
+	}
+}
+
+// GetLeafCertChainCAs returns the CA certificates, in raw format, that appear in any of the chains of the given leaf
+// certificate, without duplicates. The chains are built from the issuer names and signatures only, regardless of the
+// validity periods of the certificates, such that the result does not depend on the time at which it is computed.
+func (c *CACertCollection) GetLeafCertChainCAs(asn1Data []byte) ([][]byte, error) {
+	cert, err := x509.ParseCertificate(asn1Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing certificate")
+	}
+
+	candidates := append(append([]*x509.Certificate{}, c.intermediates...), c.roots...)
+	var cas [][]byte
+	seen := make(map[string]bool)
+	issued := []*x509.Certificate{cert}
+	for len(issued) > 0 {
+		child := issued[0]
+		issued = issued[1:]
+		for i, caCert := range candidates {
+			if seen[string(caCert.Raw)] || !bytes.Equal(child.RawIssuer, caCert.RawSubject) || child.CheckSignatureFrom(caCert) != nil {
+				continue
+			}
+			seen[string(caCert.Raw)] = true
+			cas = append(cas, caCert.Raw)
+			if i < len(c.intermediates) {
+				issued = append(issued, caCert)
+			}
+		}
+	}
+	if len(cas) == 0 {
+		return nil, errors.Errorf("certificate is not issued by a trusted certificate authority (CA), SN: %v", cert.SerialNumber)
+	}
+
+	return cas, nil
+}
+
 // VerifyCollection verifies each CA certificate in the collection, to make sure each one is part of a valid chain.
 func (c *CACertCollection) VerifyCollection() error {
 	//Make sure each root CA is self-signed
@@ -102,6 +221,37 @@ func (c *CACertCollection) GetCertPool() *x509.CertPool {
 	return pool
 }
 
+// TrustedCAs returns the root and intermediate CA certificates of a CA configuration that are trusted in a block: the
+// current CAs, and the retiring CAs whose overlap period includes the block.
+func TrustedCAs(caConfig *types.CAConfig, blockNum uint64) (roots [][]byte, intermediates [][]byte) {
+	roots = append(roots, caConfig.GetRoots()...)
+	intermediates = append(intermediates, caConfig.GetIntermediates()...)
+
+	for _, retiring := range caConfig.GetRetiring() {
+		if blockNum > retiring.GetTrustedUntilBlock() {
+			continue
+		}
+		roots = append(roots, retiring.GetRoots()...)
+		intermediates = append(intermediates, retiring.GetIntermediates()...)
+	}
+
+	return roots, intermediates
+}
+
+// AllCAs returns the root and intermediate CA certificates of a CA configuration, including all the retiring CAs,
+// whether their overlap period has ended or not.
+func AllCAs(caConfig *types.CAConfig) (roots [][]byte, intermediates [][]byte) {
+	roots = append(roots, caConfig.GetRoots()...)
+	intermediates = append(intermediates, caConfig.GetIntermediates()...)
+
+	for _, retiring := range caConfig.GetRetiring() {
+		roots = append(roots, retiring.GetRoots()...)
+		intermediates = append(intermediates, retiring.GetIntermediates()...)
+	}
+
+	return roots, intermediates
+}
+
 // LoadCAConfig loads the Root CA and Intermediate CA certificates defined in the configuration.
 func LoadCAConfig(caConfiguration *config.CAConfiguration) (*types.CAConfig, error) {
 	if len(caConfiguration.RootCACertsPath) == 0 {
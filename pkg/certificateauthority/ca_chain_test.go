@@ -3,6 +3,17 @@
 package certificateauthority
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
 	"github.com/hyperledger-labs/orion-server/config"
 	"path"
 	"testing"
@@ -10,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -192,6 +204,83 @@ func TestCACertCollection_VerifyCollection(t *testing.T) {
 	})
 }
 
+func TestCACertCollection_GetLeafCertChainCAs(t *testing.T) {
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"alice"}, true)
+	aliceCert, _ := testutils.LoadTestClientCrypto(t, cryptoDir, "alice")
+	rootCACert, _ := testutils.LoadTestClientCA(t, cryptoDir, testutils.RootCAFileName)
+	midCACert, midCAKey := testutils.LoadTestClientCA(t, cryptoDir, testutils.IntermediateCAFileName)
+
+	cryptoDir2 := testutils.GenerateTestClientCrypto(t, []string{"bob"}, true)
+	bobCert, _ := testutils.LoadTestClientCrypto(t, cryptoDir2, "bob")
+	rootCACert2, _ := testutils.LoadTestClientCA(t, cryptoDir2, testutils.RootCAFileName)
+	midCACert2, _ := testutils.LoadTestClientCA(t, cryptoDir2, testutils.IntermediateCAFileName)
+
+	caCertCollection, err := NewCACertCollection([][]byte{rootCACert.Raw, rootCACert2.Raw}, [][]byte{midCACert.Raw, midCACert2.Raw})
+	require.NoError(t, err)
+
+	cas, err := caCertCollection.GetLeafCertChainCAs(aliceCert.Raw)
+	require.NoError(t, err)
+	require.ElementsMatch(t, [][]byte{midCACert.Raw, rootCACert.Raw}, cas)
+
+	cas, err = caCertCollection.GetLeafCertChainCAs(bobCert.Raw)
+	require.NoError(t, err)
+	require.ElementsMatch(t, [][]byte{midCACert2.Raw, rootCACert2.Raw}, cas)
+
+	// the chain of an expired certificate is found as well
+	midCAKeyPair, err := tls.X509KeyPair(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: midCACert.Raw}), midCAKey)
+	require.NoError(t, err)
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	expiredCert, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(7),
+		Subject:      pkix.Name{CommonName: "expired"},
+		NotBefore:    time.Now().Add(-2 * time.Hour),
+		NotAfter:     time.Now().Add(-time.Hour),
+	}, midCACert, &leafKey.PublicKey, midCAKeyPair.PrivateKey)
+	require.NoError(t, err)
+	cas, err = caCertCollection.GetLeafCertChainCAs(expiredCert)
+	require.NoError(t, err)
+	require.ElementsMatch(t, [][]byte{midCACert.Raw, rootCACert.Raw}, cas)
+
+	caCertCollection, err = NewCACertCollection([][]byte{rootCACert.Raw}, [][]byte{midCACert.Raw})
+	require.NoError(t, err)
+	cas, err = caCertCollection.GetLeafCertChainCAs(bobCert.Raw)
+	require.EqualError(t, err, fmt.Sprintf("certificate is not issued by a trusted certificate authority (CA), SN: %v", bobCert.SerialNumber))
+	require.Nil(t, cas)
+
+	cas, err = caCertCollection.GetLeafCertChainCAs([]byte("bogus"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error parsing certificate")
+	require.Nil(t, cas)
+}
+
+func TestTrustedCAs(t *testing.T) {
+	caConfig := &types.CAConfig{
+		Roots:         [][]byte{[]byte("root")},
+		Intermediates: [][]byte{[]byte("intermediate")},
+		Retiring: []*types.RetiringCAs{
+			{Roots: [][]byte{[]byte("old-root")}, TrustedUntilBlock: 10},
+			{Intermediates: [][]byte{[]byte("old-intermediate")}, TrustedUntilBlock: 20},
+		},
+	}
+
+	roots, intermediates := TrustedCAs(caConfig, 10)
+	require.Equal(t, [][]byte{[]byte("root"), []byte("old-root")}, roots)
+	require.Equal(t, [][]byte{[]byte("intermediate"), []byte("old-intermediate")}, intermediates)
+
+	roots, intermediates = TrustedCAs(caConfig, 11)
+	require.Equal(t, [][]byte{[]byte("root")}, roots)
+	require.Equal(t, [][]byte{[]byte("intermediate"), []byte("old-intermediate")}, intermediates)
+
+	roots, intermediates = TrustedCAs(caConfig, 21)
+	require.Equal(t, [][]byte{[]byte("root")}, roots)
+	require.Equal(t, [][]byte{[]byte("intermediate")}, intermediates)
+
+	roots, intermediates = AllCAs(caConfig)
+	require.Equal(t, [][]byte{[]byte("root"), []byte("old-root")}, roots)
+	require.Equal(t, [][]byte{[]byte("intermediate"), []byte("old-intermediate")}, intermediates)
+}
+
 func TestLoadCAConfig(t *testing.T) {
 	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"user", "node"}, true)
 
@@ -209,4 +298,62 @@ func TestLoadCAConfig(t *testing.T) {
 	caColl, err := NewCACertCollection(caConfig.GetRoots(), caConfig.GetIntermediates())
 	require.NoError(t, err)
 	require.NotNil(t, caColl)
-}
\ No newline at end of file
+}
+func TestCACertCollection_VerifyScopedCerts(t *testing.T) {
+	rootPem, rootKeyPem, err := testutils.GenerateRootCA("Consortium RootCA", "127.0.0.1")
+	require.NoError(t, err)
+	rootKeyPair, err := tls.X509KeyPair(rootPem, rootKeyPem)
+	require.NoError(t, err)
+
+	issue := func(subjectCN string, caKeyPair tls.Certificate, isCA bool) ([]byte, tls.Certificate) {
+		issueFn := testutils.IssueCertificate
+		if isCA {
+			issueFn = testutils.GenerateIntermediateCA
+		}
+		certPem, keyPem, err := issueFn(subjectCN, "127.0.0.1", caKeyPair)
+		require.NoError(t, err)
+		keyPair, err := tls.X509KeyPair(certPem, keyPem)
+		require.NoError(t, err)
+		return keyPair.Certificate[0], keyPair
+	}
+
+	org1CA, org1KeyPair := issue("Org1 IntermediateCA", rootKeyPair, true)
+	org2CA, org2KeyPair := issue("Org2 IntermediateCA", rootKeyPair, true)
+	org1User, _ := issue("org1.alice", org1KeyPair, false)
+	org1Node, _ := issue("node1", org1KeyPair, false)
+	org2User, _ := issue("org2.bob", org2KeyPair, false)
+	rootUser, _ := issue("charlie", rootKeyPair, false)
+
+	caCertCollection, err := NewCACertCollection([][]byte{rootKeyPair.Certificate[0]}, [][]byte{org1CA, org2CA})
+	require.NoError(t, err)
+
+	// without scopes, every CA issues every ID
+	require.NoError(t, caCertCollection.VerifyUserCert(org2User, "org1.alice"))
+	require.NoError(t, caCertCollection.VerifyNodeCert(org1User, "node2"))
+
+	caCertCollection.SetScopes([]*types.CAScope{
+		{Organization: "org1", Cas: [][]byte{org1CA}, UserIdPrefixes: []string{"org1."}, NodeIds: []string{"node1"}},
+		{Organization: "org2", Cas: [][]byte{org2CA}, UserIdPrefixes: []string{"org2."}, NodeIds: []string{"node2"}},
+	})
+
+	require.NoError(t, caCertCollection.VerifyUserCert(org1User, "org1.alice"))
+	require.NoError(t, caCertCollection.VerifyUserCert(org2User, "org2.bob"))
+	require.NoError(t, caCertCollection.VerifyNodeCert(org1Node, "node1"))
+	// an ID out of every scope with a certificate issued by no organization
+	require.NoError(t, caCertCollection.VerifyUserCert(rootUser, "charlie"))
+
+	require.EqualError(t, caCertCollection.VerifyUserCert(org2User, "org1.bob"),
+		"the certificate is issued by the organization [org2], whose scope does not include the user [org1.bob]")
+	require.EqualError(t, caCertCollection.VerifyUserCert(org1User, "alice"),
+		"the certificate is issued by the organization [org1], whose scope does not include the user [alice]")
+	require.EqualError(t, caCertCollection.VerifyNodeCert(org1Node, "node2"),
+		"the certificate is issued by the organization [org1], whose scope does not include the node [node2]")
+	require.EqualError(t, caCertCollection.VerifyUserCert(rootUser, "org2.charlie"),
+		"the user [org2.charlie] is in the scope of the organization [org2], which did not issue the certificate")
+	require.EqualError(t, caCertCollection.VerifyNodeCert(rootUser, "node1"),
+		"the node [node1] is in the scope of the organization [org1], which did not issue the certificate")
+
+	err = caCertCollection.VerifyUserCert([]byte("bogus"), "org1.alice")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error parsing certificate")
+}
@@ -5,8 +5,12 @@ package constants
 
 import (
 	"fmt"
+	"net/url"
 	"path"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
@@ -28,50 +32,148 @@ func init() {
 	validURLSegmentNZ = regexp.MustCompile(`^(` + unReserved + `|` + pctEncoded + `|` + subDelim + `|` + plus + `)+$`)
 }
 
+// dbNamePattern matches a database name in a URL path. A name can be a
+// hierarchical path, such as org1/app2/db, hence it may contain '/'
+const dbNamePattern = `[0-9a-zA-Z_\-\.]+(?:/[0-9a-zA-Z_\-\.]+)*`
+
 const (
 	UserHeader      = "UserID"
 	SignatureHeader = "Signature"
 	TimeoutHeader   = "TxTimeout"
+	// ExpiryHeader sets the time a pending data transaction awaits the signatures of its must sign users
+	ExpiryHeader = "TxExpiry"
+	// ETagHeader carries the version of a value returned by a data read
+	ETagHeader = "ETag"
+	// IfNoneMatchHeader makes a data read conditional on the version of the value having changed
+	IfNoneMatchHeader = "If-None-Match"
+	// MetadataHeader carries the JSON encoded metadata of a value that is returned as an octet stream
+	MetadataHeader = "Metadata"
+	// NodeIDHeader carries the ID of the node that signed a value that is returned as an octet stream
+	NodeIDHeader = "NodeID"
+	// NonceHeader carries the nonce of a query that is signed together with its nonce and timestamp
+	NonceHeader = "Nonce"
+	// TimestampHeader carries the timestamp, in unix nanoseconds, of a query that is signed together with its nonce
+	// and timestamp
+	TimestampHeader = "Timestamp"
+	// SessionTokenHeader carries a session token, which authenticates a query in place of the UserID and Signature
+	// headers
+	SessionTokenHeader = "SessionToken"
+	// OctetStreamContentType is accepted by a data read to return the value as is rather than wrapped in JSON, and is
+	// the content type of a data write that carries the value as is
+	OctetStreamContentType = "application/octet-stream"
+	// TxIDHeader carries the ID of a data transaction that writes a value sent as an octet stream
+	TxIDHeader = "TxID"
+	// ACLHeader carries the JSON encoded access control of a value sent as an octet stream
+	ACLHeader = "ACL"
+	// ArrowStreamContentType is accepted by a JSON or SQL query to return the result as an Apache Arrow IPC stream
+	ArrowStreamContentType = "application/vnd.apache.arrow.stream"
+	// NDJSONContentType is accepted by a JSON query to stream the result as newline-delimited JSON, one key-value pair
+	// per line, as the key-value pairs are read, followed by a signed trailer
+	NDJSONContentType = "application/x-ndjson"
+
+	// MetricsEndpoint serves the Prometheus metrics of the server
+	MetricsEndpoint = "/metrics"
+
+	// ReadyEndpoint serves the readiness of the node, without authentication, e.g., to the readiness probes of an
+	// orchestrator. It answers 503 once the node halted the commit of blocks.
+	ReadyEndpoint = "/ready"
+
+	// DiagnosticsEndpoint serves the runtime diagnostics of the server on the diagnostics port, to admins only
+	DiagnosticsEndpoint      = "/debug/"
+	GetDiagnosticsPprof      = "/debug/pprof/"
+	GetDiagnosticsGoroutines = "/debug/goroutines"
+	GetDiagnosticsGCStats    = "/debug/gcstats"
+	GetDiagnosticsSnapshots  = "/debug/snapshots"
+	// The failpoints can be enabled only in builds with the tag [failpoints], see package internal/failpoint
+	GetDiagnosticsFailpoints   = "/debug/failpoints"
+	PutDiagnosticsFailpoint    = "/debug/failpoints/{name}/{action}"
+	DeleteDiagnosticsFailpoint = "/debug/failpoints/{name}"
 
 	UserEndpoint = "/user/"
+	GetUsers     = "/user/list"
 	GetUser      = "/user/{userid}"
 	PostUserTx   = "/user/tx"
-
-	DataEndpoint  = "/data/"
-	GetData       = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/{key}"
-	PostDataTx    = "/data/tx"
-	PostDataQuery = "/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/jsonquery"
+	// PostUserSession logs a user in and returns a session token
+	PostUserSession = "/user/session"
+
+	DataEndpoint = "/data/"
+	GetData      = "/data/{dbname:" + dbNamePattern + "}/{key}"
+	PutData      = "/data/{dbname:" + dbNamePattern + "}/{key}"
+	GetDataKeys  = "/data/{dbname:" + dbNamePattern + "}/keys"
+	PostDataTx   = "/data/tx"
+	// PostBlob uploads a value sent as an octet stream to the blob store, and returns the manifest with which a data
+	// write refers to it
+	PostBlob      = "/data/blob"
+	PostDataQuery = "/data/{dbname:" + dbNamePattern + "}/jsonquery"
+	// PostDataQueryExplain returns the execution plan of a JSON query, without executing it
+	PostDataQueryExplain = "/data/{dbname:" + dbNamePattern + "}/query/explain"
+	// PostDataSQLQuery executes a read-only query of the SQL dialect, which names the database it reads
+	PostDataSQLQuery = "/data/sql"
+
+	PostPendingDataTx          = "/data/pending/tx"
+	GetPendingDataTx           = "/data/pending/tx/{txId}"
+	PostPendingDataTxSignature = "/data/pending/tx/{txId}/sign"
+	GetPendingDataTxs          = "/data/pending"
+
+	// PostDataCursor opens a cursor over the keys of a database, which is read page by page across requests
+	PostDataCursor      = "/data/cursor"
+	GetDataCursorPage   = "/data/cursor/{cursorId:[0-9a-f]{32}}"
+	PostDataCursorClose = "/data/cursor/{cursorId:[0-9a-f]{32}}/close"
 
 	DBEndpoint  = "/db/"
-	GetDBStatus = "/db/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}"
+	GetDBs      = "/db/"
+	GetDBStatus = "/db/{dbname:" + dbNamePattern + "}"
 	PostDBTx    = "/db/tx"
+	// GetMigrations returns the applied versions of the migrations
+	GetMigrations = "/db/migrations"
 
 	ConfigEndpoint     = "/config/"
 	PostConfigTx       = "/config/tx"
+	PostConfigTxDryRun = "/config/tx/dryrun"
 	GetConfig          = "/config/tx"
 	GetNodeConfigPath  = "/config/node"
 	GetNodeConfig      = "/config/node/{nodeId}"
 	GetLastConfigBlock = "/config/block/last"
+	GetConfigHistory   = "/config/history"
+	GetConfigDiff      = "/config/diff/{fromBlockId:[0-9]+}/{toBlockId:[0-9]+}"
 	GetClusterStatus   = "/config/cluster"
-
-	LedgerEndpoint     = "/ledger/"
-	GetBlockHeader     = "/ledger/block/{blockId:[0-9]+}"
-	GetLastBlockHeader = "/ledger/block/last"
-	GetPath            = "/ledger/path"
-	GetTxProofPrefix   = "/ledger/proof/tx"
-	GetTxProof         = "/ledger/proof/tx/{blockId:[0-9]+}"
-	GetDataProofPrefix = "/ledger/proof/data"
-	GetDataProof       = "/ledger/proof/data/{dbname:" + `[0-9a-zA-Z_\-\.]+` + "}/{key}"
-	GetTxReceipt       = "/ledger/tx/receipt/{txId}"
+	PostSnapshot       = "/config/snapshot"
+	GetConsensusDiag   = "/config/consensus/diagnostics"
+	GetStorageReport   = "/config/storage/report"
+	GetStateHash       = "/config/state/hash/{dbname:" + dbNamePattern + "}"
+	PostCompactIndex   = "/config/index/compact/{dbname:" + dbNamePattern + "}"
+	GetQuarantine      = "/config/quarantine"
+	GetRejectedTxs     = "/config/rejectedtxs"
+	GetSlowQueries     = "/config/slowqueries"
+	GetWitnessStatus   = "/config/witness"
+	GetIndexUsage      = "/config/indexusage"
+
+	PostTransferLeadershipPrefix = "/config/leader/transfer"
+	PostTransferLeadership       = "/config/leader/transfer/{nodeId}"
+
+	LedgerEndpoint       = "/ledger/"
+	GetBlockHeader       = "/ledger/block/{blockId:[0-9]+}"
+	GetLastBlockHeader   = "/ledger/block/last"
+	GetPath              = "/ledger/path"
+	GetTxProofPrefix     = "/ledger/proof/tx"
+	GetTxProof           = "/ledger/proof/tx/{blockId:[0-9]+}"
+	GetDataProofPrefix   = "/ledger/proof/data"
+	GetDataProof         = "/ledger/proof/data/{dbname:" + dbNamePattern + "}/{key}"
+	GetDBStateRootPrefix = "/ledger/state/root"
+	GetDBStateRoot       = "/ledger/state/root/{dbname:" + dbNamePattern + "}"
+	GetTxReceipt         = "/ledger/tx/receipt/{txId}"
+	GetTxReceipts        = "/ledger/tx/receipts"
+	GetTxResourceUsage   = "/ledger/tx/usage/{txId}"
 
 	ProvenanceEndpoint      = "/provenance/"
-	GetHistoricalData       = "/provenance/data/history/{dbname}/{key}"
-	GetDataReaders          = "/provenance/data/readers/{dbname}/{key}"
-	GetDataWriters          = "/provenance/data/writers/{dbname}/{key}"
+	GetHistoricalData       = "/provenance/data/history/{dbname:" + dbNamePattern + "}/{key}"
+	GetDataReaders          = "/provenance/data/readers/{dbname:" + dbNamePattern + "}/{key}"
+	GetDataWriters          = "/provenance/data/writers/{dbname:" + dbNamePattern + "}/{key}"
 	GetDataReadBy           = "/provenance/data/read/{userId}"
 	GetDataWrittenBy        = "/provenance/data/written/{userId}"
 	GetDataDeletedBy        = "/provenance/data/deleted/{userId}"
 	GetTxIDsSubmittedBy     = "/provenance/data/tx/{userId}"
+	GetTxsByAnnotation      = "/provenance/tx/annotation"
 	GetMostRecentUserOrNode = "/provenance/{type:user|node}/{id}"
 )
 
@@ -81,6 +183,39 @@ func URLForGetData(dbName, key string) string {
 	return DataEndpoint + path.Join(dbName, key)
 }
 
+// URLForGetDataKeys returns url for GET request to retrieve
+// the keys present in the dbName that start with the prefix,
+// or only their count when countOnly is set
+func URLForGetDataKeys(dbName, prefix string, countOnly bool) string {
+	return URLForGetDataKeysPage(dbName, prefix, "", 0, countOnly)
+}
+
+// URLForGetDataKeysPage returns url for GET request to retrieve
+// the keys present in the dbName that start with the prefix,
+// from the startKey and up to the limit, or only their count
+// when countOnly is set
+func URLForGetDataKeysPage(dbName, prefix, startKey string, limit uint64, countOnly bool) string {
+	params := url.Values{}
+	if prefix != "" {
+		params.Set("prefix", prefix)
+	}
+	if countOnly {
+		params.Set("countOnly", "true")
+	}
+	if startKey != "" {
+		params.Set("startKey", startKey)
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", limit))
+	}
+
+	u := DataEndpoint + path.Join(dbName, "keys")
+	if len(params) == 0 {
+		return u
+	}
+	return u + "?" + params.Encode()
+}
+
 // URLForJSONQuery returns url for GET request to retrieve
 // key-value pairs present in the dbName which are matching the
 // given JSON query criteria
@@ -88,6 +223,64 @@ func URLForJSONQuery(dbName string) string {
 	return DataEndpoint + path.Join(dbName, "jsonquery")
 }
 
+// URLForJSONQueryExplain returns url for POST request to retrieve
+// the execution plan of the given JSON query on the dbName
+func URLForJSONQueryExplain(dbName string) string {
+	return DataEndpoint + path.Join(dbName, "query", "explain")
+}
+
+// URLForGetPendingDataTx returns url for GET request to retrieve
+// a pending data transaction
+func URLForGetPendingDataTx(txID string) string {
+	return DataEndpoint + path.Join("pending", "tx", txID)
+}
+
+// URLForPostPendingDataTxSignature returns url for POST request to add
+// a signature to a pending data transaction
+func URLForPostPendingDataTxSignature(txID string) string {
+	return DataEndpoint + path.Join("pending", "tx", txID, "sign")
+}
+
+// URLForPostDataCursor returns url for POST request to open
+// a cursor over the keys present in the dbName that start with
+// the prefix, which is held for the ttl when it is not read
+func URLForPostDataCursor(dbName, prefix string, ttl time.Duration) string {
+	params := url.Values{}
+	params.Set("db", dbName)
+	if prefix != "" {
+		params.Set("prefix", prefix)
+	}
+	if ttl != 0 {
+		params.Set("ttl", ttl.String())
+	}
+
+	return DataEndpoint + "cursor?" + params.Encode()
+}
+
+// URLForGetDataCursorPage returns url for GET request to retrieve
+// the page of a cursor that starts after the given key
+func URLForGetDataCursorPage(cursorID, after string, limit uint64) string {
+	params := url.Values{}
+	if after != "" {
+		params.Set("after", after)
+	}
+	if limit != 0 {
+		params.Set("limit", strconv.FormatUint(limit, 10))
+	}
+
+	u := DataEndpoint + path.Join("cursor", cursorID)
+	if len(params) == 0 {
+		return u
+	}
+	return u + "?" + params.Encode()
+}
+
+// URLForPostDataCursorClose returns url for POST request to close
+// a cursor
+func URLForPostDataCursorClose(cursorID string) string {
+	return DataEndpoint + path.Join("cursor", cursorID, "close")
+}
+
 // URLForGetUser returns url for GET request to retrieve
 // a user information
 func URLForGetUser(userID string) string {
@@ -100,6 +293,75 @@ func URLForGetDBStatus(dbName string) string {
 	return DBEndpoint + dbName
 }
 
+// URLForGetDBs returns url for GET request to list the
+// databases within the namespace denoted by the prefix
+func URLForGetDBs(prefix string) string {
+	return GetDBs + listQuery(prefix, 0, 0)
+}
+
+// URLForGetDBsPage returns url for GET request to list the
+// databases within the namespace denoted by the prefix,
+// skipping the first offset databases and up to the limit
+func URLForGetDBsPage(prefix string, offset, limit uint64) string {
+	return GetDBs + listQuery(prefix, offset, limit)
+}
+
+// URLForGetMigrations returns url for GET request to retrieve
+// the state of a migration or, if the name is empty, of all
+// migrations
+func URLForGetMigrations(name string) string {
+	if name == "" {
+		return GetMigrations
+	}
+
+	params := url.Values{}
+	params.Set("name", name)
+	return GetMigrations + "?" + params.Encode()
+}
+
+// URLForPostUserSession returns url for POST request to log
+// in at the given time and receive a session token that
+// expires after the ttl
+func URLForPostUserSession(timestamp time.Time, ttl time.Duration) string {
+	params := url.Values{}
+	params.Set("timestamp", strconv.FormatInt(timestamp.UnixNano(), 10))
+	if ttl != 0 {
+		params.Set("ttl", ttl.String())
+	}
+
+	return PostUserSession + "?" + params.Encode()
+}
+
+// URLForGetUsers returns url for GET request to list the
+// users whose ID starts with the prefix
+func URLForGetUsers(prefix string) string {
+	return GetUsers + listQuery(prefix, 0, 0)
+}
+
+// URLForGetUsersPage returns url for GET request to list the
+// users whose ID starts with the prefix, skipping the first
+// offset users and up to the limit
+func URLForGetUsersPage(prefix string, offset, limit uint64) string {
+	return GetUsers + listQuery(prefix, offset, limit)
+}
+
+func listQuery(prefix string, offset, limit uint64) string {
+	params := url.Values{}
+	if prefix != "" {
+		params.Set("prefix", prefix)
+	}
+	if offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", offset))
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", limit))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + params.Encode()
+}
+
 // URLForGetConfig returns url for GET request to retrieve
 // the cluster configuration
 func URLForGetConfig() string {
@@ -132,16 +394,80 @@ func URLDataProof(blockNum uint64, dbname, key string, deleted bool) string {
 	return LedgerEndpoint + fmt.Sprintf("proof/data/%s/%s?block=%d", dbname, key, blockNum)
 }
 
+func URLDBStateRoot(blockNum uint64, dbname string) string {
+	return LedgerEndpoint + fmt.Sprintf("state/root/%s?block=%d", dbname, blockNum)
+}
+
 func URLForNodeConfigPath(nodeID string) string {
 	return path.Join(GetNodeConfigPath, nodeID)
 }
 
+// URLForGetConfigHistory returns url for GET request to retrieve
+// the list of committed config blocks
+func URLForGetConfigHistory() string {
+	return GetConfigHistory
+}
+
+// URLForGetConfigDiff returns url for GET request to retrieve
+// the changes between the configs committed in two config blocks
+func URLForGetConfigDiff(fromBlockNum, toBlockNum uint64) string {
+	return ConfigEndpoint + fmt.Sprintf("diff/%d/%d", fromBlockNum, toBlockNum)
+}
+
+// URLForGetStorageReport returns url for GET request to retrieve
+// the storage report of the data databases, with up to top entries
+// in each list, and key prefixes that end at the delimiter
+func URLForGetStorageReport(top uint32, delimiter string) string {
+	params := url.Values{}
+	if top > 0 {
+		params.Set("top", fmt.Sprintf("%d", top))
+	}
+	if delimiter != "" {
+		params.Set("delimiter", delimiter)
+	}
+
+	if len(params) == 0 {
+		return GetStorageReport
+	}
+	return GetStorageReport + "?" + params.Encode()
+}
+
+// URLForGetIndexUsage returns url for GET request to retrieve
+// the usage of the indexes by queries, or only the unused
+// indexes if unusedOnly is set
+func URLForGetIndexUsage(unusedOnly bool) string {
+	if unusedOnly {
+		return GetIndexUsage + "?unusedOnly=true"
+	}
+	return GetIndexUsage
+}
+
+// URLForGetStateHash returns url for GET request to compute the
+// canonical hash of the full contents of a database
+func URLForGetStateHash(dbName string) string {
+	return ConfigEndpoint + "state/hash/" + dbName
+}
+
+// URLForPostCompactIndex returns url for POST request to compact
+// the index of a database
+func URLForPostCompactIndex(dbName string) string {
+	return ConfigEndpoint + "index/compact/" + dbName
+}
+
 // URLForGetHistoricalData returns url for GET request to
 // retrieve all values associated with a given key on a database
 func URLForGetHistoricalData(dbName, key string) string {
 	return ProvenanceEndpoint + path.Join("data", "history", dbName, key)
 }
 
+// URLForGetHistoricalDataPage returns url for GET request to
+// retrieve a page of the values associated with a given key on a database,
+// skipping the first offset values and up to the limit
+func URLForGetHistoricalDataPage(dbName, key string, offset, limit uint64) string {
+	return ProvenanceEndpoint + path.Join("data", "history", dbName, key) +
+		fmt.Sprintf("?offset=%d&limit=%d", offset, limit)
+}
+
 // URLForGetHistoricalDeletedData returns url for GET request to
 // retrieve all deleted values associated with a given key on a database
 func URLForGetHistoricalDeletedData(dbName, key string) string {
@@ -179,6 +505,18 @@ func URLForGetNextHistoricalData(dbName, key string, version *types.Version) str
 		"&direction=next"
 }
 
+// URLForGetPreviousHistoricalDataPage returns url for GET request to
+// retrieve up to the limit previous values for a given key on a database from a particular version
+func URLForGetPreviousHistoricalDataPage(dbName, key string, version *types.Version, limit uint64) string {
+	return URLForGetPreviousHistoricalData(dbName, key, version) + fmt.Sprintf("&limit=%d", limit)
+}
+
+// URLForGetNextHistoricalDataPage returns url for GET request to
+// retrieve up to the limit next values for a given key on a database from a particular version
+func URLForGetNextHistoricalDataPage(dbName, key string, version *types.Version, limit uint64) string {
+	return URLForGetNextHistoricalData(dbName, key, version) + fmt.Sprintf("&limit=%d", limit)
+}
+
 // URLForGetDataReaders returns url for GET request to
 // retrive all users who have read a given key from a database
 func URLForGetDataReaders(dbName, key string) string {
@@ -215,10 +553,45 @@ func URLForGetTxIDsSubmittedBy(userID string) string {
 	return ProvenanceEndpoint + path.Join("data", "tx", userID)
 }
 
+// URLForGetTxsByAnnotation returns url for GET request to
+// retrieve the transactions that carry a given annotation
+func URLForGetTxsByAnnotation(key, value string) string {
+	params := url.Values{}
+	params.Set("key", key)
+	params.Set("value", value)
+	return ProvenanceEndpoint + "tx/annotation?" + params.Encode()
+}
+
+// URLForGetTxsByAnnotationPage returns url for GET request to
+// retrieve a page of the transactions that carry a given annotation,
+// skipping the first offset transactions and up to the limit
+func URLForGetTxsByAnnotationPage(key, value string, offset, limit uint64) string {
+	params := url.Values{}
+	params.Set("key", key)
+	params.Set("value", value)
+	params.Set("offset", strconv.FormatUint(offset, 10))
+	params.Set("limit", strconv.FormatUint(limit, 10))
+	return ProvenanceEndpoint + "tx/annotation?" + params.Encode()
+}
+
 func URLForGetTransactionReceipt(txId string) string {
 	return LedgerEndpoint + path.Join("tx", "receipt", txId)
 }
 
+// URLForGetTxReceipts returns url for GET request to retrieve
+// the receipts of many transactions at once
+func URLForGetTxReceipts(txIds []string) string {
+	params := url.Values{}
+	params.Set("txIds", strings.Join(txIds, ","))
+	return LedgerEndpoint + "tx/receipts?" + params.Encode()
+}
+
+// URLForGetTxResourceUsage returns url for GET request to retrieve
+// the resource usage of a committed transaction
+func URLForGetTxResourceUsage(txId string) string {
+	return LedgerEndpoint + path.Join("tx", "usage", txId)
+}
+
 func URLForGetMostRecentUserInfo(userID string, version *types.Version) string {
 	return ProvenanceEndpoint + path.Join("user", userID) +
 		fmt.Sprintf("?blocknumber=%d&transactionnumber=%d", version.BlockNum, version.TxNum)
@@ -229,6 +602,16 @@ func URLForGetMostRecentNodeConfig(nodeID string, version *types.Version) string
 		fmt.Sprintf("?blocknumber=%d&transactionnumber=%d", version.BlockNum, version.TxNum)
 }
 
+// ETagForVersion returns the entity tag of a value at the given version,
+// which is returned in the ETag header of a data read and can be sent in
+// the If-None-Match header of a later read. A nil version has no entity tag.
+func ETagForVersion(version *types.Version) string {
+	if version == nil {
+		return ""
+	}
+	return fmt.Sprintf("\"%d-%d\"", version.BlockNum, version.TxNum)
+}
+
 // SafeURLSegmentNZ checks that the string `s` is safe to use as a URL segment-nz.
 // For example: `http://example.com:8080/tx/my-id`, for s="my-id".
 // See: `https://www.ietf.org/rfc/rfc3986.txt`.
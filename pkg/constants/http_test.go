@@ -27,6 +27,41 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/data/db1/key1",
 		},
+		{
+			name: "GetDataKeys",
+			execute: func() string {
+				return URLForGetDataKeys("db1", "", false)
+			},
+			expectedURL: "/data/db1/keys",
+		},
+		{
+			name: "GetDataKeys with prefix and count only",
+			execute: func() string {
+				return URLForGetDataKeys("org1/db1", "user/", true)
+			},
+			expectedURL: "/data/org1/db1/keys?countOnly=true&prefix=user%2F",
+		},
+		{
+			name: "GetDataKeysPage",
+			execute: func() string {
+				return URLForGetDataKeysPage("db1", "user/", "user/k2", 10, false)
+			},
+			expectedURL: "/data/db1/keys?limit=10&prefix=user%2F&startKey=user%2Fk2",
+		},
+		{
+			name: "GetStorageReport",
+			execute: func() string {
+				return URLForGetStorageReport(0, "")
+			},
+			expectedURL: "/config/storage/report",
+		},
+		{
+			name: "GetStorageReport with top and delimiter",
+			execute: func() string {
+				return URLForGetStorageReport(20, ":")
+			},
+			expectedURL: "/config/storage/report?delimiter=%3A&top=20",
+		},
 		{
 			name: "JSONQuery",
 			execute: func() string {
@@ -34,6 +69,20 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/data/db1/jsonquery",
 		},
+		{
+			name: "GetPendingDataTx",
+			execute: func() string {
+				return URLForGetPendingDataTx("tx1")
+			},
+			expectedURL: "/data/pending/tx/tx1",
+		},
+		{
+			name: "PostPendingDataTxSignature",
+			execute: func() string {
+				return URLForPostPendingDataTxSignature("tx1")
+			},
+			expectedURL: "/data/pending/tx/tx1/sign",
+		},
 		{
 			name: "GetUser",
 			execute: func() string {
@@ -48,6 +97,62 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/db/db1",
 		},
+		{
+			name: "GetDBs",
+			execute: func() string {
+				return URLForGetDBs("")
+			},
+			expectedURL: "/db/",
+		},
+		{
+			name: "GetDBs with prefix",
+			execute: func() string {
+				return URLForGetDBs("org1/app2")
+			},
+			expectedURL: "/db/?prefix=org1%2Fapp2",
+		},
+		{
+			name: "GetDBsPage",
+			execute: func() string {
+				return URLForGetDBsPage("org1", 10, 5)
+			},
+			expectedURL: "/db/?limit=5&offset=10&prefix=org1",
+		},
+		{
+			name: "GetMigrations",
+			execute: func() string {
+				return URLForGetMigrations("")
+			},
+			expectedURL: "/db/migrations",
+		},
+		{
+			name: "GetMigrations by name",
+			execute: func() string {
+				return URLForGetMigrations("schema v2")
+			},
+			expectedURL: "/db/migrations?name=schema+v2",
+		},
+		{
+			name: "GetUsers",
+			execute: func() string {
+				return URLForGetUsers("")
+			},
+			expectedURL: "/user/list",
+		},
+		{
+			name: "GetUsersPage",
+			execute: func() string {
+				return URLForGetUsersPage("", 0, 20)
+			},
+			expectedURL: "/user/list?limit=20",
+		},
+		{
+			name: "GetDBStatus of a hierarchical database",
+			execute: func() string {
+				return URLForGetDBStatus("org1/app2/db1")
+			},
+			expectedURL: "/db/org1/app2/db1",
+		},
 		{
 			name: "URLForGetConfig",
 			execute: func() string {
@@ -111,6 +216,13 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/ledger/proof/data/db1/key?block=1&deleted=true",
 		},
+		{
+			name: "URLDBStateRoot",
+			execute: func() string {
+				return URLDBStateRoot(1, "db1")
+			},
+			expectedURL: "/ledger/state/root/db1?block=1",
+		},
 		{
 			name: "URLForGetHistoricalData",
 			execute: func() string {
@@ -118,6 +230,13 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/provenance/data/history/db1/key1",
 		},
+		{
+			name: "URLForGetHistoricalDataPage",
+			execute: func() string {
+				return URLForGetHistoricalDataPage("db1", "key1", 20, 10)
+			},
+			expectedURL: "/provenance/data/history/db1/key1?offset=20&limit=10",
+		},
 		{
 			name: "URLForGetHistoricalDeletedData",
 			execute: func() string {
@@ -165,6 +284,26 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/provenance/data/history/db4/key4?blocknumber=22&transactionnumber=16&direction=next",
 		},
+		{
+			name: "URLForGetPreviousHistoricalDataPage",
+			execute: func() string {
+				return URLForGetPreviousHistoricalDataPage("db4", "key4", &types.Version{
+					BlockNum: 22,
+					TxNum:    16,
+				}, 5)
+			},
+			expectedURL: "/provenance/data/history/db4/key4?blocknumber=22&transactionnumber=16&direction=previous&limit=5",
+		},
+		{
+			name: "URLForGetNextHistoricalDataPage",
+			execute: func() string {
+				return URLForGetNextHistoricalDataPage("db4", "key4", &types.Version{
+					BlockNum: 22,
+					TxNum:    16,
+				}, 5)
+			},
+			expectedURL: "/provenance/data/history/db4/key4?blocknumber=22&transactionnumber=16&direction=next&limit=5",
+		},
 		{
 			name: "URLForGetDataReaders",
 			execute: func() string {
@@ -200,6 +339,13 @@ func TestURLConstruction(t *testing.T) {
 			},
 			expectedURL: "/ledger/tx/receipt/tx1",
 		},
+		{
+			name: "URLForGetTxReceipts",
+			execute: func() string {
+				return URLForGetTxReceipts([]string{"tx1", "tx2"})
+			},
+			expectedURL: "/ledger/tx/receipts?txIds=tx1%2Ctx2",
+		},
 		{
 			name: "URLForGetMostRecentNodeInfo",
 			execute: func() string {
@@ -239,6 +385,12 @@ func TestURLConstruction(t *testing.T) {
 	}
 }
 
+func TestETagForVersion(t *testing.T) {
+	require.Equal(t, `"5-2"`, ETagForVersion(&types.Version{BlockNum: 5, TxNum: 2}))
+	require.Equal(t, `"0-0"`, ETagForVersion(&types.Version{}))
+	require.Equal(t, "", ETagForVersion(nil))
+}
+
 func TestSafeURLSegmentNZ(t *testing.T) {
 	type testCase struct {
 		name string
@@ -45,3 +45,26 @@ func (_m *Signer) Sign(msgBytes []byte) ([]byte, error) {
 
 	return r0, r1
 }
+
+// SignHash provides a mock function with given fields: hash
+func (_m *Signer) SignHash(hash []byte) ([]byte, error) {
+	ret := _m.Called(hash)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte) []byte); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -24,6 +24,9 @@ type SignerOptions struct {
 // Signer is cryptographic primitive used only to sign messages. Each entity usually access single Signer
 type Signer interface {
 	Sign(msgBytes []byte) ([]byte, error)
+	// SignHash signs the SHA-256 hash of a message, which the caller computes, e.g., as the message is produced. The
+	// signature is the same as that of Sign on the message.
+	SignHash(hash []byte) ([]byte, error)
 	Identity() string
 }
 
@@ -93,7 +96,15 @@ func (s *signer) Sign(msgBytes []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	return s.singer.Sign(rand.Reader, h, crypto.SHA256)
+	return s.SignHash(h)
+}
+
+func (s *signer) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != crypto.SHA256.Size() {
+		return nil, fmt.Errorf("the hash is %d bytes, while a SHA-256 hash is %d bytes", len(hash), crypto.SHA256.Size())
+	}
+
+	return s.singer.Sign(rand.Reader, hash, crypto.SHA256)
 }
 
 func (s *signer) Identity() string {
@@ -4,6 +4,7 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -90,6 +91,54 @@ func TestSignAndVerify(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("Sign the hash of the message", func(t *testing.T) {
+		_, rawCert := createTestData(t)
+		msgBytes := []byte("Test message bytes")
+		userSideVerifier, nodeSideSigner := loadUserSideVerifierAndNodeSideSigner(t, rawCert, createSignerOptions())
+
+		hash, err := ComputeSHA256Hash(msgBytes)
+		require.NoError(t, err)
+		signature, err := nodeSideSigner.SignHash(hash)
+		require.NoError(t, err)
+		require.NoError(t, userSideVerifier.Verify(msgBytes, signature))
+
+		_, err = nodeSideSigner.SignHash(msgBytes)
+		require.EqualError(t, err, "the hash is 18 bytes, while a SHA-256 hash is 32 bytes")
+	})
+}
+
+func TestVerifyWithPublicKey(t *testing.T) {
+	msgBytes := []byte("Test message bytes")
+
+	t.Run("ECDSA key of the Signer", func(t *testing.T) {
+		_, rawCert := createTestData(t)
+		userSideVerifier, nodeSideSigner := loadUserSideVerifierAndNodeSideSigner(t, rawCert, createSignerOptions())
+
+		signature, err := nodeSideSigner.Sign(msgBytes)
+		require.NoError(t, err)
+
+		pkBytes, err := x509.MarshalPKIXPublicKey(userSideVerifier.Certificate.PublicKey)
+		require.NoError(t, err)
+		pk, err := x509.ParsePKIXPublicKey(pkBytes)
+		require.NoError(t, err)
+
+		require.NoError(t, VerifyWithPublicKey(pk, msgBytes, signature))
+		require.EqualError(t, VerifyWithPublicKey(pk, []byte("Another message"), signature), "ECDSA verification failure")
+	})
+
+	t.Run("ed25519 key", func(t *testing.T) {
+		pk, sk, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		signature := ed25519.Sign(sk, msgBytes)
+		require.NoError(t, VerifyWithPublicKey(pk, msgBytes, signature))
+		require.EqualError(t, VerifyWithPublicKey(pk, []byte("Another message"), signature), "ed25519 verification failure")
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		err := VerifyWithPublicKey("not a key", msgBytes, []byte("signature"))
+		require.EqualError(t, err, "unsupported public key type: string")
+	})
 }
 
 type pkcs8Key struct {
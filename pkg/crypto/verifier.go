@@ -3,7 +3,12 @@
 package crypto
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
 	"crypto/x509"
+
+	"github.com/pkg/errors"
 )
 
 // Verifier is cryptographic primitive used only to validate message signature, each node usually access multiple Verifiers.
@@ -27,3 +32,23 @@ func NewVerifier(rawCert []byte) (*Verifier, error) {
 func (v *Verifier) Verify(msgBytes []byte, signature []byte) error {
 	return v.Certificate.CheckSignature(v.Certificate.SignatureAlgorithm, msgBytes, signature)
 }
+
+// VerifyWithPublicKey verifies a signature made by the private key that corresponds to a public key, as returned by
+// x509.ParsePKIXPublicKey. Like the Signer, an ECDSA key is expected to sign the SHA-256 digest of the message.
+func VerifyWithPublicKey(publicKey interface{}, msgBytes []byte, signature []byte) error {
+	switch pk := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(msgBytes)
+		if !ecdsa.VerifyASN1(pk, digest[:], signature) {
+			return errors.New("ECDSA verification failure")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pk, msgBytes, signature) {
+			return errors.New("ed25519 verification failure")
+		}
+	default:
+		return errors.Errorf("unsupported public key type: %T", publicKey)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cryptoservice
+
+import (
+	"crypto/x509"
+	"encoding/json"
+
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// VerifyAttestedResponse authenticates a response signed by a node that attests its identity, e.g., the cluster status
+// or a node config, before the client trusts the nodes it lists: the certificate of the node must be issued through
+// the attested CA chain by one of the trusted root CAs, and must verify the signature of the response. It returns the
+// certificate of the node.
+func VerifyAttestedResponse(trustedRoots [][]byte, attestation *types.NodeAttestation, response interface{}, signature []byte) (*x509.Certificate, error) {
+	if attestation == nil || len(attestation.Certificate) == 0 {
+		return nil, errors.New("the response has no attestation of the node identity")
+	}
+
+	roots := x509.NewCertPool()
+	for _, rawRoot := range trustedRoots {
+		root, err := x509.ParseCertificate(rawRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing a trusted root CA certificate")
+		}
+		roots.AddCert(root)
+	}
+	intermediates := x509.NewCertPool()
+	for _, rawCA := range attestation.CaChain {
+		ca, err := x509.ParseCertificate(rawCA)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing a CA certificate of the attested chain")
+		}
+		intermediates.AddCert(ca)
+	}
+
+	verifier, err := crypto.NewVerifier(attestation.Certificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing the attested node certificate")
+	}
+	if _, err = verifier.Certificate.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, errors.Wrap(err, "the attested node certificate is not issued by a trusted CA")
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling the response")
+	}
+	if err = verifier.Verify(responseBytes, signature); err != nil {
+		return nil, errors.Wrap(err, "the signature of the response does not match the attested node certificate")
+	}
+
+	return verifier.Certificate, nil
+}
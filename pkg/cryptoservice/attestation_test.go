@@ -0,0 +1,81 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package cryptoservice_test
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/server/testutils"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAttestedResponse(t *testing.T) {
+	cryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node1"}, true)
+	nodeCert, nodeSigner := testutils.LoadTestClientCrypto(t, cryptoDir, "node1")
+	rootCACert, _ := testutils.LoadTestClientCA(t, cryptoDir, testutils.RootCAFileName)
+	midCACert, _ := testutils.LoadTestClientCA(t, cryptoDir, testutils.IntermediateCAFileName)
+
+	otherCryptoDir := testutils.GenerateTestClientCrypto(t, []string{"node2"})
+	otherCert, _ := testutils.LoadTestClientCrypto(t, otherCryptoDir, "node2")
+	otherRootCACert, _ := testutils.LoadTestClientCA(t, otherCryptoDir, testutils.RootCAFileName)
+
+	response := &types.GetClusterStatusResponse{
+		Header: &types.ResponseHeader{NodeId: "node1"},
+		Leader: "node1",
+		Attestation: &types.NodeAttestation{
+			Certificate: nodeCert.Raw,
+			CaChain:     [][]byte{midCACert.Raw, rootCACert.Raw},
+		},
+	}
+	sig, err := cryptoservice.SignPayload(nodeSigner, response)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		cert, err := cryptoservice.VerifyAttestedResponse([][]byte{otherRootCACert.Raw, rootCACert.Raw}, response.Attestation, response, sig)
+		require.NoError(t, err)
+		require.Equal(t, nodeCert.Raw, cert.Raw)
+	})
+
+	t.Run("no attestation", func(t *testing.T) {
+		cert, err := cryptoservice.VerifyAttestedResponse([][]byte{rootCACert.Raw}, nil, response, sig)
+		require.EqualError(t, err, "the response has no attestation of the node identity")
+		require.Nil(t, cert)
+	})
+
+	t.Run("untrusted root", func(t *testing.T) {
+		cert, err := cryptoservice.VerifyAttestedResponse([][]byte{otherRootCACert.Raw}, response.Attestation, response, sig)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the attested node certificate is not issued by a trusted CA")
+		require.Nil(t, cert)
+	})
+
+	t.Run("incomplete chain", func(t *testing.T) {
+		attestation := &types.NodeAttestation{Certificate: nodeCert.Raw}
+		cert, err := cryptoservice.VerifyAttestedResponse([][]byte{rootCACert.Raw}, attestation, response, sig)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the attested node certificate is not issued by a trusted CA")
+		require.Nil(t, cert)
+	})
+
+	t.Run("tampered response", func(t *testing.T) {
+		tampered := &types.GetClusterStatusResponse{
+			Header:      response.Header,
+			Leader:      "node2",
+			Attestation: response.Attestation,
+		}
+		cert, err := cryptoservice.VerifyAttestedResponse([][]byte{rootCACert.Raw}, response.Attestation, tampered, sig)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the signature of the response does not match the attested node certificate")
+		require.Nil(t, cert)
+	})
+
+	t.Run("certificate of another node", func(t *testing.T) {
+		attestation := &types.NodeAttestation{Certificate: otherCert.Raw}
+		cert, err := cryptoservice.VerifyAttestedResponse([][]byte{otherRootCACert.Raw}, attestation, response, sig)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the signature of the response does not match the attested node certificate")
+		require.Nil(t, cert)
+	})
+}
@@ -14,16 +14,41 @@ func SignQuery(querySigner crypto.Signer, query interface{}) ([]byte, error) {
 	switch v := query.(type) {
 	case *types.GetConfigQuery:
 	case *types.GetConfigBlockQuery:
+	case *types.GetConfigHistoryQuery:
+	case *types.GetConfigDiffQuery:
 	case *types.GetClusterStatusQuery:
+	case *types.TriggerSnapshotQuery:
+	case *types.TransferLeadershipQuery:
+	case *types.GetConsensusDiagnosticsQuery:
+	case *types.GetStorageReportQuery:
+	case *types.GetStateHashQuery:
+	case *types.CompactIndexQuery:
+	case *types.GetQuarantinedBlockQuery:
+	case *types.GetRejectedTxsQuery:
+	case *types.GetSlowQueriesQuery:
+	case *types.GetWitnessStatusQuery:
+	case *types.GetIndexUsageQuery:
+	case *types.GetDiagnosticsQuery:
 	case *types.GetDataQuery:
+	case *types.GetDataKeysQuery:
+	case *types.GetPendingDataTxQuery:
+	case *types.GetPendingDataTxsQuery:
+	case *types.OpenDataCursorQuery:
+	case *types.GetDataCursorPageQuery:
+	case *types.CloseDataCursorQuery:
 	case *types.GetDBStatusQuery:
+	case *types.GetDBsQuery:
+	case *types.GetMigrationsQuery:
 	case *types.GetUserQuery:
+	case *types.GetUsersQuery:
 	case *types.GetBlockQuery:
 	case *types.GetLastBlockQuery:
 	case *types.GetLedgerPathQuery:
 	case *types.GetNodeConfigQuery:
 	case *types.GetTxProofQuery:
 	case *types.GetTxReceiptQuery:
+	case *types.GetTxReceiptsQuery:
+	case *types.GetTxResourceUsageQuery:
 	case *types.GetHistoricalDataQuery:
 	case *types.GetDataReadersQuery:
 	case *types.GetDataWritersQuery:
@@ -31,9 +56,14 @@ func SignQuery(querySigner crypto.Signer, query interface{}) ([]byte, error) {
 	case *types.GetDataWrittenByQuery:
 	case *types.GetDataDeletedByQuery:
 	case *types.GetTxIDsSubmittedByQuery:
+	case *types.GetTxsByAnnotationQuery:
 	case *types.GetMostRecentUserOrNodeQuery:
 	case *types.GetDataProofQuery:
+	case *types.GetDBStateRootQuery:
 	case *types.DataJSONQuery:
+	case *types.ExplainJSONQuery:
+	case *types.DataSQLQuery:
+	case *types.SessionLoginQuery:
 
 	default:
 		return nil, errors.Errorf("unknown query type: %T", v)
@@ -42,6 +72,31 @@ func SignQuery(querySigner crypto.Signer, query interface{}) ([]byte, error) {
 	return SignPayload(querySigner, query)
 }
 
+// SignReplayProtectedQuery signs a query together with a nonce and a timestamp, in unix nanoseconds, which are sent in
+// the Nonce and Timestamp headers of the query
+func SignReplayProtectedQuery(querySigner crypto.Signer, query interface{}, nonce string, timestamp int64) ([]byte, error) {
+	guard, err := NewQueryReplayGuard(query, nonce, timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return SignPayload(querySigner, guard)
+}
+
+// NewQueryReplayGuard binds a query to a nonce and a timestamp
+func NewQueryReplayGuard(query interface{}, nonce string, timestamp int64) (*types.QueryReplayGuard, error) {
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryReplayGuard{
+		Query:     queryBytes,
+		Nonce:     nonce,
+		Timestamp: timestamp,
+	}, nil
+}
+
 func SignTx(txSigner crypto.Signer, tx interface{}) ([]byte, error) {
 	switch v := tx.(type) {
 	case *types.ConfigTx:
@@ -4,8 +4,10 @@ package cryptoservice
 
 import (
 	"crypto/x509"
+	"time"
 
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 )
@@ -16,6 +18,13 @@ type UserDBQuerier interface {
 	GetCertificate(userID string) (*x509.Certificate, error)
 }
 
+// SigningKeysQuerier is implemented by a UserDBQuerier that provides, in addition to the certificate, the API signing
+// keys registered to a user. The SignatureVerifier then accepts a signature by any key of the user that is neither
+// revoked nor expired.
+type SigningKeysQuerier interface {
+	GetSigningKeys(userID string) ([]*types.SigningKey, error)
+}
+
 func NewVerifier(userQuerier UserDBQuerier, logger *logger.SugarLogger) *SignatureVerifier {
 	return &SignatureVerifier{
 		userDBQuerier: userQuerier,
@@ -31,7 +40,16 @@ type SignatureVerifier struct {
 	logger        *logger.SugarLogger
 }
 
+// Verify verifies the signature of the user on the body, where the expiry of the signing keys of the user is checked
+// against the local clock, e.g., when a request is submitted.
 func (sv *SignatureVerifier) Verify(userID string, signature, body []byte) error {
+	return sv.VerifyAt(userID, signature, body, time.Now())
+}
+
+// VerifyAt verifies the signature as Verify does, where the expiry of the signing keys of the user is checked at the
+// given time. The validation of a block checks the expiry at the block timestamp, such that all the nodes reach the
+// same result.
+func (sv *SignatureVerifier) VerifyAt(userID string, signature, body []byte, at time.Time) error {
 	cert, err := sv.userDBQuerier.GetCertificate(userID)
 	if err != nil {
 		sv.logger.Debugf("Error during GetCertificate: userID: %s, error: %s", userID, err)
@@ -39,8 +57,40 @@ func (sv *SignatureVerifier) Verify(userID string, signature, body []byte) error
 	}
 	verifier := crypto.Verifier{Certificate: cert}
 	if err = verifier.Verify(body, signature); err != nil {
+		if keysQuerier, ok := sv.userDBQuerier.(SigningKeysQuerier); ok && sv.verifyWithSigningKeys(keysQuerier, userID, signature, body, at) {
+			return nil
+		}
 		sv.logger.Debugf("Failed to verify signature: userID: %s, error: %s", userID, err)
 		return err
 	}
 	return err
 }
+
+// verifyWithSigningKeys returns true if the signature is made by one of the signing keys of the user that are active at
+// the given time.
+func (sv *SignatureVerifier) verifyWithSigningKeys(keysQuerier SigningKeysQuerier, userID string, signature, body []byte, at time.Time) bool {
+	keys, err := keysQuerier.GetSigningKeys(userID)
+	if err != nil {
+		sv.logger.Debugf("Error during GetSigningKeys: userID: %s, error: %s", userID, err)
+		return false
+	}
+
+	now := at.Unix()
+	for _, k := range keys {
+		if k.GetRevoked() || (k.GetExpiresAt() != 0 && now >= k.GetExpiresAt()) {
+			continue
+		}
+
+		publicKey, err := x509.ParsePKIXPublicKey(k.GetPublicKey())
+		if err != nil {
+			sv.logger.Debugf("Failed to parse signing key: userID: %s, keyID: %s, error: %s", userID, k.GetKeyId(), err)
+			continue
+		}
+		if crypto.VerifyWithPublicKey(publicKey, body, signature) == nil {
+			sv.logger.Debugf("Verified signature with signing key: userID: %s, keyID: %s", userID, k.GetKeyId())
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,14 +3,18 @@
 package cryptoservice_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"io/ioutil"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 
 	"github.com/hyperledger-labs/orion-server/pkg/crypto"
 	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
@@ -90,6 +94,93 @@ func TestSignatureVerifier_Verify(t *testing.T) {
 	})
 }
 
+type userDBWithSigningKeys struct {
+	*mocks.UserDBQuerier
+	keys map[string][]*types.SigningKey
+}
+
+func (u *userDBWithSigningKeys) GetSigningKeys(userID string) ([]*types.SigningKey, error) {
+	return u.keys[userID], nil
+}
+
+func TestSignatureVerifier_VerifyWithSigningKeys(t *testing.T) {
+	setup(t)
+	userData := generateUserData(t)
+	userDB := &mocks.UserDBQuerier{}
+	userDB.GetCertificateCalls(
+		func(userID string) (*x509.Certificate, error) {
+			cert, ok := userData[userID]
+			if ok {
+				return cert, nil
+			}
+			return nil, errors.New("user not found")
+		},
+	)
+
+	publicKeyOf := func(name string) []byte {
+		pk, err := x509.MarshalPKIXPublicKey(userData[name].PublicKey)
+		require.NoError(t, err)
+		return pk
+	}
+	edPublicKey, edPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edPublicKeyBytes, err := x509.MarshalPKIXPublicKey(edPublicKey)
+	require.NoError(t, err)
+
+	verifier := cryptoservice.NewVerifier(&userDBWithSigningKeys{
+		UserDBQuerier: userDB,
+		keys: map[string][]*types.SigningKey{
+			"alice": {
+				{KeyId: "service1", PublicKey: publicKeyOf("bob")},
+				{KeyId: "service2", PublicKey: publicKeyOf("noca_alice"), Revoked: true},
+				{KeyId: "service3", PublicKey: publicKeyOf("noca_bob"), ExpiresAt: time.Now().Add(-time.Hour).Unix()},
+				{KeyId: "service4", PublicKey: edPublicKeyBytes, ExpiresAt: time.Now().Add(time.Hour).Unix()},
+			},
+		},
+	}, lg)
+
+	signWith := func(name string, msgBytes []byte) []byte {
+		signer, err := crypto.NewSigner(&crypto.SignerOptions{KeyFilePath: path.Join("testdata", name+".key")})
+		require.NoError(t, err)
+		sig, err := signer.Sign(msgBytes)
+		require.NoError(t, err)
+		return sig
+	}
+
+	msgBytes := []byte("alice is the queen")
+
+	t.Run("certificate is still accepted", func(t *testing.T) {
+		require.NoError(t, verifier.Verify("alice", signWith("alice", msgBytes), msgBytes))
+	})
+
+	t.Run("active signing keys are accepted", func(t *testing.T) {
+		require.NoError(t, verifier.Verify("alice", signWith("bob", msgBytes), msgBytes))
+		require.NoError(t, verifier.Verify("alice", ed25519.Sign(edPrivateKey, msgBytes), msgBytes))
+	})
+
+	t.Run("revoked signing key is rejected", func(t *testing.T) {
+		err := verifier.Verify("alice", signWith("noca_alice", msgBytes), msgBytes)
+		require.EqualError(t, err, "x509: ECDSA verification failure")
+	})
+
+	t.Run("expired signing key is rejected", func(t *testing.T) {
+		err := verifier.Verify("alice", signWith("noca_bob", msgBytes), msgBytes)
+		require.EqualError(t, err, "x509: ECDSA verification failure")
+	})
+
+	t.Run("expiry is checked at the given time", func(t *testing.T) {
+		require.NoError(t, verifier.VerifyAt("alice", signWith("noca_bob", msgBytes), msgBytes, time.Now().Add(-2*time.Hour)))
+
+		err := verifier.VerifyAt("alice", ed25519.Sign(edPrivateKey, msgBytes), msgBytes, time.Now().Add(2*time.Hour))
+		require.Error(t, err)
+	})
+
+	t.Run("signing key of another user is rejected", func(t *testing.T) {
+		err := verifier.Verify("bob", ed25519.Sign(edPrivateKey, msgBytes), msgBytes)
+		require.Error(t, err)
+	})
+}
+
 func generateUserData(t *testing.T) map[string]*x509.Certificate {
 	userData := make(map[string]*x509.Certificate)
 
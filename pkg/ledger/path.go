@@ -0,0 +1,81 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// BlockHeaderHash returns the hash of a block header, which is the hash of the block that is linked to by the skip
+// list hashes of later blocks
+func BlockHeaderHash(header *types.BlockHeader) ([]byte, error) {
+	headerBytes, err := proto.Marshal(header)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal block header")
+	}
+	return crypto.ComputeSHA256Hash(headerBytes)
+}
+
+// VerifyPath validates that the block headers, as returned by the ledger path query, form a path in the skip list
+// chain of the ledger from block end back to block start, which proves that block start is an ancestor of block end.
+// The headers are ordered from end to start, and every header must hold the hash of the header that follows it among
+// its skip list hashes. If endBlockHash is not nil, the path is also anchored to it, i.e., the hash of the header of
+// block end, e.g., taken from a trusted receipt or from the last block header, must be equal to it.
+//
+// An error is returned if the path is malformed, and false if the hashes do not match.
+func VerifyPath(headers []*types.BlockHeader, start, end uint64, endBlockHash []byte) (bool, error) {
+	if len(headers) == 0 {
+		return false, errors.New("path can't be empty")
+	}
+	if start > end {
+		return false, errors.Errorf("start block [%d] is after end block [%d]", start, end)
+	}
+
+	if number := headers[0].GetBaseHeader().GetNumber(); number != end {
+		return false, errors.Errorf("path begins at block [%d], expected end block [%d]", number, end)
+	}
+	if number := headers[len(headers)-1].GetBaseHeader().GetNumber(); number != start {
+		return false, errors.Errorf("path ends at block [%d], expected start block [%d]", number, start)
+	}
+
+	hash, err := BlockHeaderHash(headers[0])
+	if err != nil {
+		return false, err
+	}
+	if endBlockHash != nil && !bytes.Equal(endBlockHash, hash) {
+		return false, nil
+	}
+
+	// every header in the path is linked to by the header before it, starting from block end
+	for i := 1; i < len(headers); i++ {
+		prevNumber := headers[i-1].GetBaseHeader().GetNumber()
+		number := headers[i].GetBaseHeader().GetNumber()
+		if number >= prevNumber {
+			return false, errors.Errorf("block [%d] follows block [%d] in the path, block numbers must decrease", number, prevNumber)
+		}
+
+		hash, err = BlockHeaderHash(headers[i])
+		if err != nil {
+			return false, err
+		}
+
+		linked := false
+		for _, skipHash := range headers[i-1].GetSkipchainHashes() {
+			if bytes.Equal(skipHash, hash) {
+				linked = true
+				break
+			}
+		}
+		if !linked {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,92 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ledger
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger-labs/orion-server/internal/blockstore"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPath(t *testing.T) {
+	t.Parallel()
+
+	// headers[n-1] is the header of block n, linked by skip list hashes as the block store links them
+	var headers []*types.BlockHeader
+	var hashes [][]byte
+	for n := uint64(1); n <= 20; n++ {
+		header := &types.BlockHeader{
+			BaseHeader: &types.BlockHeaderBase{Number: n},
+		}
+		for _, linked := range blockstore.CalculateSkipListLinks(n) {
+			header.SkipchainHashes = append(header.SkipchainHashes, hashes[linked-1])
+		}
+		hash, err := BlockHeaderHash(header)
+		require.NoError(t, err)
+		headers = append(headers, header)
+		hashes = append(hashes, hash)
+	}
+
+	path := []*types.BlockHeader{headers[16], headers[8], headers[4], headers[2], headers[1]}
+
+	t.Run("valid path", func(t *testing.T) {
+		valid, err := VerifyPath(path, 2, 17, nil)
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		valid, err = VerifyPath(path, 2, 17, hashes[16])
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		valid, err = VerifyPath([]*types.BlockHeader{headers[16], headers[0]}, 1, 17, hashes[16])
+		require.NoError(t, err)
+		require.True(t, valid)
+	})
+
+	t.Run("path not anchored to the end block hash", func(t *testing.T) {
+		valid, err := VerifyPath(path, 2, 17, hashes[15])
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		tampered := proto.Clone(headers[4]).(*types.BlockHeader)
+		tampered.TxMerkelTreeRootHash = []byte("tampered")
+
+		valid, err := VerifyPath([]*types.BlockHeader{headers[16], headers[8], tampered, headers[2], headers[1]}, 2, 17, nil)
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("header skipped", func(t *testing.T) {
+		valid, err := VerifyPath([]*types.BlockHeader{headers[16], headers[4], headers[2], headers[1]}, 2, 17, nil)
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	t.Run("malformed path", func(t *testing.T) {
+		valid, err := VerifyPath(nil, 2, 17, nil)
+		require.EqualError(t, err, "path can't be empty")
+		require.False(t, valid)
+
+		valid, err = VerifyPath(path, 17, 2, nil)
+		require.EqualError(t, err, "start block [17] is after end block [2]")
+		require.False(t, valid)
+
+		valid, err = VerifyPath(path, 2, 18, nil)
+		require.EqualError(t, err, "path begins at block [17], expected end block [18]")
+		require.False(t, valid)
+
+		valid, err = VerifyPath(path, 1, 17, nil)
+		require.EqualError(t, err, "path ends at block [2], expected start block [1]")
+		require.False(t, valid)
+
+		valid, err = VerifyPath([]*types.BlockHeader{headers[16], headers[16], headers[1]}, 2, 17, nil)
+		require.EqualError(t, err, "block [17] follows block [17] in the path, block numbers must decrease")
+		require.False(t, valid)
+	})
+}
@@ -12,8 +12,9 @@ import (
 
 type SugarLogger struct {
 	*zap.SugaredLogger
-	conf  zap.Config
-	mutex sync.RWMutex
+	conf   zap.Config
+	mutex  sync.RWMutex
+	masker *masker
 }
 
 type Config struct {
@@ -22,6 +23,8 @@ type Config struct {
 	ErrOutputPath []string
 	Encoding      string
 	Name          string
+	// Masking masks the values and keys of the users that are logged; nil means they are logged as they are.
+	Masking *MaskingConfig
 }
 
 func New(c *Config, opts ...zap.Option) (*SugarLogger, error) {
@@ -30,6 +33,11 @@ func New(c *Config, opts ...zap.Option) (*SugarLogger, error) {
 		return nil, err
 	}
 
+	m, err := newMasker(c.Masking)
+	if err != nil {
+		return nil, err
+	}
+
 	logCfg := zap.Config{
 		Encoding:         c.Encoding,
 		Level:            zap.NewAtomicLevelAt(logLevel),
@@ -67,12 +75,14 @@ func New(c *Config, opts ...zap.Option) (*SugarLogger, error) {
 	return &SugarLogger{
 		SugaredLogger: l.Named(c.Name).Sugar(),
 		conf:          logCfg,
+		masker:        m,
 	}, nil
 }
 
 func (l *SugarLogger) With(args ...interface{}) *SugarLogger {
 	return &SugarLogger{
 		SugaredLogger: l.SugaredLogger.With(args...),
+		masker:        l.masker,
 	}
 }
 
@@ -0,0 +1,117 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaskingModeHash replaces the logged values with a prefix of their SHA-256 hash and their length
+	MaskingModeHash = "hash"
+	// MaskingModeTruncate logs the leading bytes of the values and the number of bytes cut off
+	MaskingModeTruncate = "truncate"
+	// MaskingModeNone logs the values as they are
+	MaskingModeNone = "none"
+
+	// DefaultMaskingTruncateLength is the number of leading bytes of a value that are logged in truncate mode, unless
+	// configured otherwise
+	DefaultMaskingTruncateLength = 8
+)
+
+// MaskingConfig holds the masking of the values and keys of the users that are logged, e.g., the values written by a
+// transaction, which are logged at debug level, so that they do not leak into the log aggregation.
+type MaskingConfig struct {
+	// Mode is "hash", "truncate", or "none"; if empty, the values are hashed.
+	Mode string
+	// TruncateLength is the number of leading bytes of a value that are logged in truncate mode; if zero, a default
+	// is used.
+	TruncateLength int
+	// RedactKeys are regular expressions matching the keys that are replaced with their hash.
+	RedactKeys []string
+}
+
+// masker masks the values and keys that are logged. A nil masker logs them as they are.
+type masker struct {
+	mode           string
+	truncateLength int
+	redactKeys     []*regexp.Regexp
+}
+
+func newMasker(c *MaskingConfig) (*masker, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	m := &masker{
+		mode:           c.Mode,
+		truncateLength: c.TruncateLength,
+	}
+	switch m.mode {
+	case "":
+		m.mode = MaskingModeHash
+	case MaskingModeHash, MaskingModeTruncate, MaskingModeNone:
+	default:
+		return nil, errors.Errorf("unrecognized masking mode [%s]. Only hash, truncate, and none are supported", c.Mode)
+	}
+	if m.truncateLength <= 0 {
+		m.truncateLength = DefaultMaskingTruncateLength
+	}
+
+	for _, expr := range c.RedactKeys {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid expression [%s] of the keys to redact", expr)
+		}
+		m.redactKeys = append(m.redactKeys, re)
+	}
+
+	return m, nil
+}
+
+// MaskValue returns a value of a user as it may be logged, according to the masking mode
+func (l *SugarLogger) MaskValue(value []byte) string {
+	m := l.masker
+	if m == nil {
+		return string(value)
+	}
+
+	switch m.mode {
+	case MaskingModeHash:
+		return maskedHash(value) + fmt.Sprintf("(%d bytes)", len(value))
+	case MaskingModeTruncate:
+		if len(value) <= m.truncateLength {
+			return string(value)
+		}
+		return fmt.Sprintf("%s...(+%d bytes)", value[:m.truncateLength], len(value)-m.truncateLength)
+	default:
+		return string(value)
+	}
+}
+
+// MaskKey returns a key of a user as it may be logged: a key that matches one of the keys to redact is replaced with
+// its hash
+func (l *SugarLogger) MaskKey(key string) string {
+	if l.masker == nil {
+		return key
+	}
+
+	for _, re := range l.masker.redactKeys {
+		if re.MatchString(key) {
+			return maskedHash([]byte(key))
+		}
+	}
+	return key
+}
+
+// maskedHash returns a prefix of the SHA-256 hash of a value, which is enough to correlate the occurrences of a
+// value in the log
+func maskedHash(value []byte) string {
+	h := sha256.Sum256(value)
+	return "sha256:" + hex.EncodeToString(h[:8])
+}
@@ -0,0 +1,75 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMasking(t *testing.T) {
+	t.Parallel()
+
+	newLogger := func(t *testing.T, masking *MaskingConfig) *SugarLogger {
+		l, err := New(&Config{
+			Level:         "debug",
+			OutputPath:    []string{"stdout"},
+			ErrOutputPath: []string{"stderr"},
+			Encoding:      "console",
+			Masking:       masking,
+		})
+		require.NoError(t, err)
+		return l
+	}
+
+	value := []byte(`{"name":"alice","ssn":"123-45-6789"}`)
+
+	t.Run("no masking", func(t *testing.T) {
+		l := newLogger(t, nil)
+		require.Equal(t, string(value), l.MaskValue(value))
+		require.Equal(t, "ssn-alice", l.MaskKey("ssn-alice"))
+	})
+
+	t.Run("hash by default", func(t *testing.T) {
+		l := newLogger(t, &MaskingConfig{})
+		masked := l.MaskValue(value)
+		require.Regexp(t, `^sha256:[0-9a-f]{16}\(36 bytes\)$`, masked)
+		require.Equal(t, masked, l.MaskValue(value))
+		require.NotEqual(t, masked, l.MaskValue([]byte("bob")))
+		require.Equal(t, "ssn-alice", l.MaskKey("ssn-alice"))
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		l := newLogger(t, &MaskingConfig{Mode: MaskingModeTruncate})
+		require.Equal(t, `{"name":...(+28 bytes)`, l.MaskValue(value))
+		require.Equal(t, "short", l.MaskValue([]byte("short")))
+
+		l = newLogger(t, &MaskingConfig{Mode: MaskingModeTruncate, TruncateLength: 15})
+		require.Equal(t, `{"name":"alice"...(+21 bytes)`, l.MaskValue(value))
+	})
+
+	t.Run("none", func(t *testing.T) {
+		l := newLogger(t, &MaskingConfig{Mode: MaskingModeNone})
+		require.Equal(t, string(value), l.MaskValue(value))
+	})
+
+	t.Run("redacted keys", func(t *testing.T) {
+		l := newLogger(t, &MaskingConfig{Mode: MaskingModeNone, RedactKeys: []string{"^ssn-", "secret"}})
+		require.Regexp(t, `^sha256:[0-9a-f]{16}$`, l.MaskKey("ssn-alice"))
+		require.Regexp(t, `^sha256:[0-9a-f]{16}$`, l.MaskKey("my-secret-key"))
+		require.Equal(t, "alice-ssn", l.MaskKey("alice-ssn"))
+
+		// the masking is inherited by the derived loggers
+		require.Regexp(t, `^sha256:[0-9a-f]{16}$`, l.With("component", "test").MaskKey("ssn-alice"))
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := New(&Config{Level: "debug", Encoding: "console", Masking: &MaskingConfig{Mode: "encrypt"}})
+		require.EqualError(t, err, "unrecognized masking mode [encrypt]. Only hash, truncate, and none are supported")
+
+		_, err = New(&Config{Level: "debug", Encoding: "console", Masking: &MaskingConfig{RedactKeys: []string{"("}}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid expression [(] of the keys to redact")
+	})
+}
@@ -0,0 +1,171 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// The groups of endpoints that may be bound to different listeners, see config.ExposureConf
+const (
+	// EndpointGroupTx is the submission of data transactions
+	EndpointGroupTx = "tx"
+	// EndpointGroupQuery is all the requests that are neither transactions nor admin requests
+	EndpointGroupQuery = "query"
+	// EndpointGroupAdmin is the configuration, user and database administration transactions, the reads of the
+	// configuration and the state of the node that are limited to admins, and the metrics
+	EndpointGroupAdmin = "admin"
+)
+
+// The maximal sizes of the bodies of requests when none is configured, see config.RequestLimitsConf
+const (
+	DefaultMaxTxBodyBytes    = 16 * 1024 * 1024
+	DefaultMaxQueryBodyBytes = 1024 * 1024
+	DefaultMaxAdminBodyBytes = 16 * 1024 * 1024
+)
+
+var endpointGroups = []string{EndpointGroupTx, EndpointGroupQuery, EndpointGroupAdmin}
+
+// adminQueryPaths are the paths of the GET requests that read the configuration and the state of the node, which are
+// limited to admins by default and are therefore served along with the admin transactions
+var adminQueryPaths = map[string]bool{
+	constants.GetConfig:          true,
+	constants.GetLastConfigBlock: true,
+	constants.GetConfigHistory:   true,
+	constants.GetConsensusDiag:   true,
+	constants.GetStorageReport:   true,
+	constants.GetQuarantine:      true,
+	constants.GetRejectedTxs:     true,
+	constants.GetSlowQueries:     true,
+	constants.GetWitnessStatus:   true,
+	constants.GetIndexUsage:      true,
+}
+
+// adminQueryPathPrefixes are the prefixes of the paths of the admin GET requests that carry path parameters
+var adminQueryPathPrefixes = []string{
+	constants.ConfigEndpoint + "diff/",
+	constants.ConfigEndpoint + "state/hash/",
+}
+
+// endpointGroupOf returns the endpoint group of a request
+func endpointGroupOf(r *http.Request) string {
+	p := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		if strings.HasPrefix(p, constants.DataEndpoint) {
+			return EndpointGroupTx
+		}
+	case http.MethodGet:
+		if isAdminQuery(p) {
+			return EndpointGroupAdmin
+		}
+	case http.MethodPost:
+		switch {
+		case p == constants.PostUserTx, p == constants.PostDBTx, strings.HasPrefix(p, constants.ConfigEndpoint):
+			return EndpointGroupAdmin
+		case p == constants.PostDataTx, p == constants.PostBlob, strings.HasPrefix(p, constants.PostPendingDataTx):
+			return EndpointGroupTx
+		}
+	}
+
+	return EndpointGroupQuery
+}
+
+func isAdminQuery(path string) bool {
+	if adminQueryPaths[path] {
+		return true
+	}
+	for _, prefix := range adminQueryPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointGroupFilter serves only the requests of a set of endpoint groups, and limits the size of their bodies
+type endpointGroupFilter struct {
+	groups       map[string]bool
+	maxBodyBytes map[string]int64
+	next         http.Handler
+}
+
+func (f *endpointGroupFilter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	group := endpointGroupOf(r)
+	if !f.groups[group] {
+		utils.SendHTTPResponse(w, http.StatusNotFound,
+			&types.HttpResponseErr{ErrMsg: "the endpoint group [" + group + "] is not served on this listener"})
+		return
+	}
+	if utils.LimitRequestBody(w, r, f.maxBodyBytes[group]) {
+		return
+	}
+
+	f.next.ServeHTTP(w, r)
+}
+
+// maxBodyBytes returns the maximal size of the bodies of the requests of every endpoint group
+func maxBodyBytes(conf *config.RequestLimitsConf) map[string]int64 {
+	limit := func(configured uint64, defaultLimit int64) int64 {
+		if configured == 0 {
+			return defaultLimit
+		}
+		return int64(configured)
+	}
+
+	return map[string]int64{
+		EndpointGroupTx:    limit(conf.MaxTxBodyBytes, DefaultMaxTxBodyBytes),
+		EndpointGroupQuery: limit(conf.MaxQueryBodyBytes, DefaultMaxQueryBodyBytes),
+		EndpointGroupAdmin: limit(conf.MaxAdminBodyBytes, DefaultMaxAdminBodyBytes),
+	}
+}
+
+// exposedGroups returns the endpoint groups served by each of the listeners of the exposure configuration, followed by
+// the groups served by the listener that serves clients, which are the groups not bound to any other listener
+func exposedGroups(conf *config.ExposureConf) ([]map[string]bool, map[string]bool, error) {
+	bound := make(map[string]bool)
+	var listenerGroups []map[string]bool
+	for _, l := range conf.Listeners {
+		if len(l.EndpointGroups) == 0 {
+			return nil, nil, errors.Errorf("the listener on port [%d] serves no endpoint group", l.Network.Port)
+		}
+
+		groups := make(map[string]bool)
+		for _, g := range l.EndpointGroups {
+			if !isEndpointGroup(g) {
+				return nil, nil, errors.Errorf("unknown endpoint group [%s], expected one of %v", g, endpointGroups)
+			}
+			if bound[g] {
+				return nil, nil, errors.Errorf("the endpoint group [%s] is bound to more than one listener", g)
+			}
+			bound[g] = true
+			groups[g] = true
+		}
+		listenerGroups = append(listenerGroups, groups)
+	}
+
+	clientGroups := make(map[string]bool)
+	for _, g := range endpointGroups {
+		if !bound[g] {
+			clientGroups[g] = true
+		}
+	}
+
+	return listenerGroups, clientGroups, nil
+}
+
+func isEndpointGroup(group string) bool {
+	for _, g := range endpointGroups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
+	"github.com/hyperledger-labs/orion-server/pkg/constants"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointGroupOf(t *testing.T) {
+	testCases := []struct {
+		method string
+		path   string
+		group  string
+	}{
+		{method: http.MethodPost, path: constants.PostDataTx, group: EndpointGroupTx},
+		{method: http.MethodPost, path: constants.PostBlob, group: EndpointGroupTx},
+		{method: http.MethodPost, path: constants.PostPendingDataTx, group: EndpointGroupTx},
+		{method: http.MethodPost, path: constants.URLForPostPendingDataTxSignature("tx1"), group: EndpointGroupTx},
+		{method: http.MethodPut, path: constants.URLForGetData("db1", "key1"), group: EndpointGroupTx},
+		{method: http.MethodPost, path: constants.PostUserTx, group: EndpointGroupAdmin},
+		{method: http.MethodPost, path: constants.PostDBTx, group: EndpointGroupAdmin},
+		{method: http.MethodPost, path: constants.PostConfigTx, group: EndpointGroupAdmin},
+		{method: http.MethodPost, path: constants.PostSnapshot, group: EndpointGroupAdmin},
+		{method: http.MethodGet, path: constants.GetConfig, group: EndpointGroupAdmin},
+		{method: http.MethodGet, path: constants.URLForGetStateHash("db1"), group: EndpointGroupAdmin},
+		{method: http.MethodGet, path: constants.URLForGetConfigDiff(1, 5), group: EndpointGroupAdmin},
+		{method: http.MethodGet, path: constants.URLForGetStorageReport(10, "/"), group: EndpointGroupAdmin},
+		{method: http.MethodGet, path: constants.URLForNodeConfigPath("node1"), group: EndpointGroupQuery},
+		{method: http.MethodGet, path: constants.GetClusterStatus, group: EndpointGroupQuery},
+		{method: http.MethodGet, path: constants.URLForGetUser("alice"), group: EndpointGroupQuery},
+		{method: http.MethodPost, path: constants.PostDataSQLQuery, group: EndpointGroupQuery},
+		{method: http.MethodPost, path: constants.PostUserSession, group: EndpointGroupQuery},
+	}
+
+	for _, tt := range testCases {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		require.Equal(t, tt.group, endpointGroupOf(req), "%s %s", tt.method, tt.path)
+	}
+}
+
+func TestExposedGroups(t *testing.T) {
+	t.Run("all groups are served to clients by default", func(t *testing.T) {
+		listenerGroups, clientGroups, err := exposedGroups(&config.ExposureConf{})
+		require.NoError(t, err)
+		require.Empty(t, listenerGroups)
+		require.Equal(t, map[string]bool{EndpointGroupTx: true, EndpointGroupQuery: true, EndpointGroupAdmin: true}, clientGroups)
+	})
+
+	t.Run("groups bound to listeners", func(t *testing.T) {
+		listenerGroups, clientGroups, err := exposedGroups(&config.ExposureConf{
+			Listeners: []config.ListenerConf{
+				{EndpointGroups: []string{EndpointGroupAdmin}},
+				{EndpointGroups: []string{EndpointGroupTx, EndpointGroupQuery}},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []map[string]bool{
+			{EndpointGroupAdmin: true},
+			{EndpointGroupTx: true, EndpointGroupQuery: true},
+		}, listenerGroups)
+		require.Empty(t, clientGroups)
+	})
+
+	t.Run("invalid configurations", func(t *testing.T) {
+		for conf, expectedErr := range map[*config.ExposureConf]string{
+			{Listeners: []config.ListenerConf{{Network: config.NetworkConf{Port: 6201}}}}: "the listener on port [6201] serves no endpoint group",
+			{Listeners: []config.ListenerConf{{EndpointGroups: []string{"ops"}}}}:         "unknown endpoint group [ops], expected one of [tx query admin]",
+			{Listeners: []config.ListenerConf{
+				{EndpointGroups: []string{EndpointGroupAdmin}},
+				{EndpointGroups: []string{EndpointGroupAdmin}},
+			}}: "the endpoint group [admin] is bound to more than one listener",
+		} {
+			_, _, err := exposedGroups(conf)
+			require.EqualError(t, err, expectedErr)
+		}
+	})
+}
+
+func TestEndpointGroupFilter(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	f := &endpointGroupFilter{
+		groups:       map[string]bool{EndpointGroupAdmin: true},
+		maxBodyBytes: maxBodyBytes(&config.RequestLimitsConf{}),
+		next:         next,
+	}
+
+	rr := httptest.NewRecorder()
+	f.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, constants.PostUserTx, nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	f.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, constants.PostDataTx, nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	respErr := &types.HttpResponseErr{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+	require.Equal(t, "the endpoint group [tx] is not served on this listener", respErr.ErrMsg)
+}
+
+func TestAdminQueriesOnQueryListener(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	f := &endpointGroupFilter{
+		groups:       map[string]bool{EndpointGroupQuery: true},
+		maxBodyBytes: maxBodyBytes(&config.RequestLimitsConf{}),
+		next:         next,
+	}
+
+	for _, path := range []string{
+		constants.GetConfig,
+		constants.GetLastConfigBlock,
+		constants.GetConfigHistory,
+		constants.URLForGetConfigDiff(1, 5),
+		constants.GetConsensusDiag,
+		constants.URLForGetStorageReport(0, ""),
+		constants.URLForGetStateHash("db1"),
+		constants.GetQuarantine,
+		constants.GetRejectedTxs,
+		constants.GetSlowQueries,
+		constants.GetWitnessStatus,
+		constants.URLForGetIndexUsage(true),
+	} {
+		rr := httptest.NewRecorder()
+		f.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusNotFound, rr.Code, path)
+		respErr := &types.HttpResponseErr{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+		require.Equal(t, "the endpoint group [admin] is not served on this listener", respErr.ErrMsg)
+	}
+
+	for _, path := range []string{
+		constants.URLForNodeConfigPath("node1"),
+		constants.GetClusterStatus,
+		constants.URLForGetData("db1", "key1"),
+	} {
+		rr := httptest.NewRecorder()
+		f.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rr.Code, path)
+	}
+}
+
+func TestEndpointGroupFilterBodyLimits(t *testing.T) {
+	var readErr error
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, readErr = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	})
+	f := &endpointGroupFilter{
+		groups:       map[string]bool{EndpointGroupTx: true, EndpointGroupQuery: true, EndpointGroupAdmin: true},
+		maxBodyBytes: maxBodyBytes(&config.RequestLimitsConf{MaxTxBodyBytes: 10}),
+		next:         next,
+	}
+	require.Equal(t, int64(DefaultMaxQueryBodyBytes), f.maxBodyBytes[EndpointGroupQuery])
+	require.Equal(t, int64(DefaultMaxAdminBodyBytes), f.maxBodyBytes[EndpointGroupAdmin])
+
+	t.Run("within the limit", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		f.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, constants.PostDataTx, strings.NewReader("0123456789")))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NoError(t, readErr)
+	})
+
+	t.Run("the content length exceeds the limit", func(t *testing.T) {
+		readErr = nil
+		rr := httptest.NewRecorder()
+		f.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, constants.PostDataTx, strings.NewReader("0123456789a")))
+		require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+		respErr := &types.HttpResponseErr{}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(respErr))
+		require.Equal(t, "the body of the request is larger than the limit of 10 bytes", respErr.ErrMsg)
+	})
+
+	t.Run("the body exceeds the limit without a content length", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, constants.PostDataTx, strings.NewReader("0123456789a"))
+		req.ContentLength = -1
+		rr := httptest.NewRecorder()
+		f.ServeHTTP(rr, req)
+		require.True(t, utils.IsRequestBodyTooLarge(readErr))
+	})
+
+	t.Run("other groups have their own limits", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		f.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, constants.PostDataSQLQuery, strings.NewReader("0123456789a")))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.NoError(t, readErr)
+	})
+}
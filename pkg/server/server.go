@@ -6,14 +6,21 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/internal/accesslog"
 	"github.com/hyperledger-labs/orion-server/internal/bcdb"
 	ierrors "github.com/hyperledger-labs/orion-server/internal/errors"
 	"github.com/hyperledger-labs/orion-server/internal/httphandler"
+	"github.com/hyperledger-labs/orion-server/internal/ordering"
+	"github.com/hyperledger-labs/orion-server/internal/outbox"
+	"github.com/hyperledger-labs/orion-server/internal/utils"
 	"github.com/hyperledger-labs/orion-server/pkg/constants"
 	"github.com/hyperledger-labs/orion-server/pkg/logger"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // BCDBHTTPServer holds the database and http server objects
@@ -22,39 +29,242 @@ type BCDBHTTPServer struct {
 	handler http.Handler
 	listen  net.Listener
 	server  *http.Server
-	conf    *config.Configurations
-	logger  *logger.SugarLogger
+	// diagnosticsListen and diagnosticsServer are nil unless the diagnostics endpoints are enabled
+	diagnosticsListen net.Listener
+	diagnosticsServer *http.Server
+	// metricsListen and metricsServer are nil unless the metrics endpoint is enabled
+	metricsListen net.Listener
+	metricsServer *http.Server
+	// groupListeners are the additional listeners that serve groups of endpoints, see config.ExposureConf
+	groupListeners []*groupListener
+	// accessLog is nil unless the access log is enabled
+	accessLog *accesslog.Logger
+	conf      *config.Configurations
+	logger    *logger.SugarLogger
+}
+
+// groupListener is a listener that serves a set of endpoint groups
+type groupListener struct {
+	listen net.Listener
+	server *http.Server
+	groups map[string]bool
+}
+
+// CommitListener is notified after every block is committed by the server. See bcdb.CommitListener.
+type CommitListener = bcdb.CommitListener
+
+// OutboxSubsystem produces external side effects of committed blocks, which are recorded in the outbox and published
+// at-least-once. See outbox.Subsystem.
+type OutboxSubsystem = outbox.Subsystem
+
+// OutboxEntry is an external side effect recorded in the outbox. Its key allows to discard duplicates.
+type OutboxEntry = outbox.Entry
+
+// OrderingService is a client of an external ordering service, which orders the blocks of the cluster instead of the
+// built-in Raft consensus. See ordering.Service.
+type OrderingService = ordering.Service
+
+// OrderingStream delivers the entries ordered by an external ordering service. See ordering.Stream.
+type OrderingStream = ordering.Stream
+
+// Option configures a BCDBHTTPServer at construction.
+type Option func(o *options)
+
+type options struct {
+	extensions bcdb.Extensions
+}
+
+// WithCommitListener registers a commit listener under a unique name. Listeners are registered before the server
+// starts to commit blocks, so that no block is missed.
+func WithCommitListener(name string, listener CommitListener) Option {
+	return func(o *options) {
+		if o.extensions.CommitListeners == nil {
+			o.extensions.CommitListeners = make(map[string]CommitListener)
+		}
+		o.extensions.CommitListeners[name] = listener
+	}
+}
+
+// WithOutboxSubsystem registers an outbox subsystem, whose entries are recorded with every committed block and are
+// published by a dedicated worker.
+func WithOutboxSubsystem(subsystem OutboxSubsystem) Option {
+	return func(o *options) {
+		o.extensions.OutboxSubsystems = append(o.extensions.OutboxSubsystems, subsystem)
+	}
+}
+
+// WithOrderingService registers an external ordering service under a consensus algorithm. The service orders the
+// blocks if the ConsensusConfig of the cluster selects that algorithm, which cannot be changed once the cluster is
+// bootstrapped.
+func WithOrderingService(algorithm string, service OrderingService) Option {
+	return func(o *options) {
+		if o.extensions.OrderingServices == nil {
+			o.extensions.OrderingServices = make(map[string]ordering.Service)
+		}
+		o.extensions.OrderingServices[algorithm] = service
+	}
 }
 
 // New creates a object of BCDBHTTPServer
-func New(conf *config.Configurations) (*BCDBHTTPServer, error) {
+func New(conf *config.Configurations, opts ...Option) (*BCDBHTTPServer, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	c := &logger.Config{
 		Level:         conf.LocalConfig.Server.LogLevel,
 		OutputPath:    []string{"stdout"},
 		ErrOutputPath: []string{"stderr"},
 		Encoding:      "console",
 		Name:          conf.LocalConfig.Server.Identity.ID,
+		Masking: &logger.MaskingConfig{
+			Mode:           conf.LocalConfig.Server.LogMasking.Mode,
+			TruncateLength: conf.LocalConfig.Server.LogMasking.TruncateLength,
+			RedactKeys:     conf.LocalConfig.Server.LogMasking.RedactKeys,
+		},
 	}
 	lg, err := logger.New(c)
 	if err != nil {
 		return nil, err
 	}
 
-	db, err := bcdb.NewDB(conf, lg)
+	exposureConf := conf.LocalConfig.Server.Exposure
+	listenerGroups, clientGroups, err := exposedGroups(&exposureConf)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error in the exposure configuration")
+	}
+
+	db, err := bcdb.NewDB(conf, lg, &o.extensions)
 	if err != nil {
 		return nil, errors.Wrap(err, "error while creating the database object")
 	}
 
+	encodingConf := conf.LocalConfig.Server.ResponseEncoding
+	utils.ConfigureResponseEncoding(&utils.ResponseEncodingConfig{
+		StreamThresholdBytes: encodingConf.StreamThresholdBytes,
+		MaxPooledBufferBytes: encodingConf.MaxPooledBufferBytes,
+	})
+	httphandler.ConfigureQueryReplayProtection(conf.LocalConfig.Server.QueryReplayProtection.Required)
+
 	mux := http.NewServeMux()
-	mux.Handle(constants.UserEndpoint, httphandler.NewUsersRequestHandler(db, lg))
-	mux.Handle(constants.DataEndpoint, httphandler.NewDataRequestHandler(db, lg))
-	mux.Handle(constants.DBEndpoint, httphandler.NewDBRequestHandler(db, lg))
-	mux.Handle(constants.ConfigEndpoint, httphandler.NewConfigRequestHandler(db, lg))
-	mux.Handle(constants.LedgerEndpoint, httphandler.NewLedgerRequestHandler(db, lg))
-	mux.Handle(constants.ProvenanceEndpoint, httphandler.NewProvenanceRequestHandler(db, lg))
+	mux.Handle(constants.ReadyEndpoint, httphandler.NewReadyRequestHandler(db, lg))
+	if conf.LocalConfig.Witness.Enabled {
+		// a witness does not serve client requests
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			utils.SendHTTPResponse(w, http.StatusServiceUnavailable,
+				&types.HttpResponseErr{ErrMsg: "node [" + conf.LocalConfig.Server.Identity.ID + "] is a witness and does not serve client requests"})
+		})
+	} else {
+		mux.Handle(constants.UserEndpoint, httphandler.NewUsersRequestHandler(db, lg))
+		mux.Handle(constants.DataEndpoint, httphandler.NewDataRequestHandler(db, lg))
+		mux.Handle(constants.DBEndpoint, httphandler.NewDBRequestHandler(db, lg))
+		mux.Handle(constants.ConfigEndpoint, httphandler.NewConfigRequestHandler(db, lg))
+		mux.Handle(constants.LedgerEndpoint, httphandler.NewLedgerRequestHandler(db, lg))
+		mux.Handle(constants.ProvenanceEndpoint, httphandler.NewProvenanceRequestHandler(db, lg))
+	}
 
 	netConf := conf.LocalConfig.Server.Network
 	addr := fmt.Sprintf("%s:%d", netConf.Address, netConf.Port)
+	netListener, err := newAllowListListener(addr, exposureConf.AllowedCIDRs, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	var accessLog *accesslog.Logger
+	if accessLogConf := conf.LocalConfig.Server.AccessLog; accessLogConf.Enabled {
+		if accessLog, err = accesslog.New(&accessLogConf, endpointGroupOf); err != nil {
+			netListener.Close()
+			return nil, err
+		}
+	}
+	bodyLimits := maxBodyBytes(&conf.LocalConfig.Server.RequestLimits)
+	serveGroups := func(groups map[string]bool) http.Handler {
+		var h http.Handler = &endpointGroupFilter{groups: groups, maxBodyBytes: bodyLimits, next: mux}
+		if accessLog != nil {
+			h = accessLog.Handler(h)
+		}
+		return h
+	}
+
+	s := &BCDBHTTPServer{
+		db:        db,
+		handler:   mux,
+		listen:    netListener,
+		server:    &http.Server{Handler: serveGroups(clientGroups)},
+		accessLog: accessLog,
+		conf:      conf,
+		logger:    lg,
+	}
+
+	for i, listenerConf := range exposureConf.Listeners {
+		if listenerConf.Network.Port != 0 && listenerConf.Network.Port == netConf.Port {
+			s.closeListeners()
+			return nil, errors.Errorf("the port [%d] of the listener of the endpoint groups %v must differ from the port that serves clients",
+				listenerConf.Network.Port, listenerConf.EndpointGroups)
+		}
+
+		groupAddr := fmt.Sprintf("%s:%d", listenerConf.Network.Address, listenerConf.Network.Port)
+		l, err := newAllowListListener(groupAddr, listenerConf.AllowedCIDRs, lg)
+		if err != nil {
+			s.closeListeners()
+			return nil, err
+		}
+		s.groupListeners = append(s.groupListeners, &groupListener{
+			listen: l,
+			server: &http.Server{Handler: serveGroups(listenerGroups[i])},
+			groups: listenerGroups[i],
+		})
+	}
+
+	diagConf := conf.LocalConfig.Server.Diagnostics
+	if diagConf.Enabled {
+		if diagConf.Network.Port != 0 && diagConf.Network.Port == netConf.Port {
+			s.closeListeners()
+			return nil, errors.Errorf("the diagnostics port [%d] must differ from the port that serves clients", diagConf.Network.Port)
+		}
+
+		diagAddr := fmt.Sprintf("%s:%d", diagConf.Network.Address, diagConf.Network.Port)
+		s.diagnosticsListen, err = net.Listen("tcp", diagAddr)
+		if err != nil {
+			s.closeListeners()
+			lg.Errorf("Failed to create a tcp listener on: %s, error: %s", diagAddr, err)
+			return nil, errors.Wrapf(err, "error while creating a tcp listener for the diagnostics endpoints on: %s", diagAddr)
+		}
+
+		diagMux := http.NewServeMux()
+		diagMux.Handle(constants.DiagnosticsEndpoint, httphandler.NewDiagnosticsRequestHandler(db, lg))
+		s.diagnosticsServer = &http.Server{Handler: diagMux}
+	}
+
+	metricsConf := conf.LocalConfig.Server.Metrics
+	if metricsConf.Enabled {
+		if metricsConf.Network.Port != 0 && metricsConf.Network.Port == netConf.Port {
+			s.closeListeners()
+			return nil, errors.Errorf("the metrics port [%d] must differ from the port that serves clients", metricsConf.Network.Port)
+		}
+
+		metricsAddr := fmt.Sprintf("%s:%d", metricsConf.Network.Address, metricsConf.Network.Port)
+		s.metricsListen, err = newAllowListListener(metricsAddr, metricsConf.AllowedCIDRs, lg)
+		if err != nil {
+			s.closeListeners()
+			return nil, err
+		}
+
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle(constants.MetricsEndpoint, promhttp.Handler())
+		s.metricsServer = &http.Server{Handler: metricsMux}
+	}
+
+	return s, nil
+}
+
+// newAllowListListener creates a tcp listener that accepts connections only from the allowed networks
+func newAllowListListener(addr string, allowedCIDRs []string, lg *logger.SugarLogger) (net.Listener, error) {
+	allowed, err := utils.ParseCIDRs(allowedCIDRs)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error in the allowed networks of the listener on: %s", addr)
+	}
 
 	netListener, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -62,16 +272,18 @@ func New(conf *config.Configurations) (*BCDBHTTPServer, error) {
 		return nil, errors.Wrapf(err, "error while creating a tcp listener on: %s", addr)
 	}
 
-	server := &http.Server{Handler: mux}
+	return utils.NewAllowListListener(netListener, allowed, lg), nil
+}
 
-	return &BCDBHTTPServer{
-		db:      db,
-		handler: mux,
-		listen:  netListener,
-		server:  server,
-		conf:    conf,
-		logger:  lg,
-	}, nil
+// closeListeners closes the listeners of a server that failed to be created
+func (s *BCDBHTTPServer) closeListeners() {
+	s.listen.Close()
+	for _, l := range s.groupListeners {
+		l.listen.Close()
+	}
+	if s.diagnosticsListen != nil {
+		s.diagnosticsListen.Close()
+	}
 }
 
 // Start starts the server
@@ -85,6 +297,15 @@ func (s *BCDBHTTPServer) Start() error {
 	}
 
 	go s.serveRequests(s.listen)
+	for _, l := range s.groupListeners {
+		go s.serveGroup(l)
+	}
+	if s.diagnosticsServer != nil {
+		go s.serveDiagnostics()
+	}
+	if s.metricsServer != nil {
+		go s.serveMetrics()
+	}
 
 	return nil
 }
@@ -103,6 +324,36 @@ func (s *BCDBHTTPServer) serveRequests(l net.Listener) {
 	s.logger.Infof("Finished serving requests on: %s", s.listen.Addr().String())
 }
 
+func (s *BCDBHTTPServer) serveGroup(l *groupListener) {
+	s.logger.Infof("Starting to serve the endpoint groups %v on: %s", l.groups, l.listen.Addr().String())
+
+	if err := l.server.Serve(l.listen); err != nil && err != http.ErrServerClosed {
+		s.logger.Errorf("the endpoint groups %v stopped unexpectedly, %v", l.groups, err)
+	}
+
+	s.logger.Infof("Finished serving the endpoint groups %v on: %s", l.groups, l.listen.Addr().String())
+}
+
+func (s *BCDBHTTPServer) serveDiagnostics() {
+	s.logger.Infof("Starting to serve the diagnostics endpoints on: %s", s.diagnosticsListen.Addr().String())
+
+	if err := s.diagnosticsServer.Serve(s.diagnosticsListen); err != nil && err != http.ErrServerClosed {
+		s.logger.Errorf("the diagnostics endpoints stopped unexpectedly, %v", err)
+	}
+
+	s.logger.Infof("Finished serving the diagnostics endpoints on: %s", s.diagnosticsListen.Addr().String())
+}
+
+func (s *BCDBHTTPServer) serveMetrics() {
+	s.logger.Infof("Starting to serve the metrics endpoint on: %s", s.metricsListen.Addr().String())
+
+	if err := s.metricsServer.Serve(s.metricsListen); err != nil && err != http.ErrServerClosed {
+		s.logger.Errorf("the metrics endpoint stopped unexpectedly, %v", err)
+	}
+
+	s.logger.Infof("Finished serving the metrics endpoint on: %s", s.metricsListen.Addr().String())
+}
+
 // Stop stops the server
 func (s *BCDBHTTPServer) Stop() error {
 	if s == nil || s.listen == nil || s.server == nil {
@@ -117,10 +368,38 @@ func (s *BCDBHTTPServer) Stop() error {
 		errR = err
 	}
 
+	for _, l := range s.groupListeners {
+		if err := l.server.Close(); err != nil {
+			s.logger.Errorf("Failure while closing the http server of the endpoint groups %v: %s", l.groups, err)
+			errR = err
+		}
+	}
+
+	if s.diagnosticsServer != nil {
+		if err := s.diagnosticsServer.Close(); err != nil {
+			s.logger.Errorf("Failure while closing the diagnostics http server: %s", err)
+			errR = err
+		}
+	}
+
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Close(); err != nil {
+			s.logger.Errorf("Failure while closing the metrics http server: %s", err)
+			errR = err
+		}
+	}
+
 	if err := s.db.Close(); err != nil {
 		s.logger.Errorf("Failure while closing the database: %s", err)
 		errR = err
 	}
+
+	if s.accessLog != nil {
+		if err := s.accessLog.Close(); err != nil {
+			s.logger.Errorf("Failure while closing the access log: %s", err)
+			errR = err
+		}
+	}
 	return errR
 }
 
@@ -130,6 +409,68 @@ func (s *BCDBHTTPServer) Port() (port string, err error) {
 	return
 }
 
+// EndpointGroupPort returns the port number of the listener that serves an endpoint group, see config.ExposureConf
+func (s *BCDBHTTPServer) EndpointGroupPort(group string) (port string, err error) {
+	for _, l := range s.groupListeners {
+		if l.groups[group] {
+			_, port, err = net.SplitHostPort(l.listen.Addr().String())
+			return
+		}
+	}
+	return s.Port()
+}
+
+// DiagnosticsPort returns the port number of the diagnostics endpoints, or an empty string if they are disabled
+func (s *BCDBHTTPServer) DiagnosticsPort() (port string, err error) {
+	if s.diagnosticsListen == nil {
+		return "", nil
+	}
+	_, port, err = net.SplitHostPort(s.diagnosticsListen.Addr().String())
+	return
+}
+
+// MetricsPort returns the port number of the metrics endpoint, or an empty string if it is disabled
+func (s *BCDBHTTPServer) MetricsPort() (port string, err error) {
+	if s.metricsListen == nil {
+		return "", nil
+	}
+	_, port, err = net.SplitHostPort(s.metricsListen.Addr().String())
+	return
+}
+
 func (s *BCDBHTTPServer) IsLeader() *ierrors.NotLeaderError {
 	return s.db.IsLeader()
 }
+
+// SubmitTransaction submits a transaction envelope directly to the database, bypassing the HTTP layer.
+// The envelope must be one of the *types.XXXTxEnvelope types and carry valid signatures, as it goes through
+// the same validation as a transaction submitted over HTTP. A non-zero timeout makes the submission
+// synchronous, i.e., the call returns only after the transaction is committed or the timeout expires.
+func (s *BCDBHTTPServer) SubmitTransaction(tx interface{}, timeout time.Duration) (*types.TxReceiptResponseEnvelope, error) {
+	return s.db.SubmitTransaction(tx, timeout)
+}
+
+// GetTxReceipt returns the receipt of a committed transaction, bypassing the HTTP layer.
+func (s *BCDBHTTPServer) GetTxReceipt(userID, txID string) (*types.TxReceiptResponseEnvelope, error) {
+	return s.db.GetTxReceipt(userID, txID)
+}
+
+// GetTxReceipts returns the receipts of many committed transactions, bypassing the HTTP layer.
+func (s *BCDBHTTPServer) GetTxReceipts(userID string, txIDs []string) (*types.GetTxReceiptsResponseEnvelope, error) {
+	return s.db.GetTxReceipts(userID, txIDs)
+}
+
+// GetTxResourceUsage returns the resource usage of a committed transaction, bypassing the HTTP layer.
+func (s *BCDBHTTPServer) GetTxResourceUsage(userID, txID string) (*types.GetTxResourceUsageResponseEnvelope, error) {
+	return s.db.GetTxResourceUsage(userID, txID)
+}
+
+// GetData returns the value of a key, bypassing the HTTP layer.
+func (s *BCDBHTTPServer) GetData(dbName, querierUserID, key string) (*types.GetDataResponseEnvelope, error) {
+	return s.db.GetData(dbName, querierUserID, key)
+}
+
+// LedgerHeight returns the current height of the ledger.
+func (s *BCDBHTTPServer) LedgerHeight() (uint64, error) {
+	return s.db.LedgerHeight()
+}
@@ -5,11 +5,13 @@ package server
 import (
 	"bytes"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"math"
+	"net/http"
 	"os"
 	"path"
 	"runtime"
@@ -188,12 +190,26 @@ func newServerTestEnv(t *testing.T) *serverTestEnv {
 					Transaction:               1,
 					ReorderedTransactionBatch: 1,
 				},
+				Diagnostics: config.DiagnosticsConf{
+					Enabled: true,
+					Network: config.NetworkConf{
+						Address: "127.0.0.1",
+						Port:    0,
+					},
+				},
+				Metrics: config.MetricsConf{
+					Enabled: true,
+					Network: config.NetworkConf{
+						Address: "127.0.0.1",
+						Port:    0,
+					},
+				},
 
 				LogLevel: "debug",
 			},
 			BlockCreation: config.BlockCreationConf{
 				BlockTimeout:                500 * time.Millisecond,
-				MaxBlockSize:                1,
+				MaxBlockSizeBytes:           1024 * 1024,
 				MaxTransactionCountPerBlock: 1,
 			},
 			Replication: config.ReplicationConf{
@@ -563,6 +579,64 @@ func TestServerWithConfigRequest(t *testing.T) {
 	// TODO cover config transactions and requests, see: https://github.com/hyperledger-labs/orion-server/issues/306
 }
 
+func TestServerWithDiagnostics(t *testing.T) {
+	env := newServerTestEnv(t)
+	defer env.cleanup(t)
+
+	port, err := env.bcdbHTTPServer.DiagnosticsPort()
+	require.NoError(t, err)
+	require.NotEmpty(t, port)
+	clientPort, err := env.bcdbHTTPServer.Port()
+	require.NoError(t, err)
+	require.NotEqual(t, clientPort, port)
+
+	newRequest := func(host, path string) *http.Request {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%s%s", host, path), nil)
+		require.NoError(t, err)
+		req.Header.Set(constants.UserHeader, "admin")
+		sig := testutils.SignatureFromQuery(t, env.adminSigner, &types.GetDiagnosticsQuery{UserId: "admin", Path: path})
+		req.Header.Set(constants.SignatureHeader, base64.StdEncoding.EncodeToString(sig))
+		return req
+	}
+
+	resp, err := http.DefaultClient.Do(newRequest(port, constants.GetDiagnosticsGCStats))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	stats := &types.GCStats{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(stats))
+	require.True(t, stats.Goroutines > 0)
+
+	// the diagnostics endpoints are not served on the port of the clients
+	resp, err = http.DefaultClient.Do(newRequest(clientPort, constants.GetDiagnosticsGCStats))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServerWithMetrics(t *testing.T) {
+	env := newServerTestEnv(t)
+	defer env.cleanup(t)
+
+	port, err := env.bcdbHTTPServer.MetricsPort()
+	require.NoError(t, err)
+	require.NotEmpty(t, port)
+	clientPort, err := env.bcdbHTTPServer.Port()
+	require.NoError(t, err)
+	require.NotEqual(t, clientPort, port)
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%s%s", port, constants.MetricsEndpoint))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the metrics are not served on the port of the clients
+	resp, err = http.Get(fmt.Sprintf("http://127.0.0.1:%s%s", clientPort, constants.MetricsEndpoint))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestServerWithFailureScenarios(t *testing.T) {
 	testCases := []struct {
 		testName         string
@@ -0,0 +1,213 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testutil provides a single-node database server that can be embedded in a Go program, e.g., an SDK test
+// suite. Transactions are submitted programmatically, without going through HTTP, and the submitting call blocks
+// until the transaction is committed. The HTTP endpoint of the embedded server is still available for clients that
+// want to use it.
+package testutil
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/hyperledger-labs/orion-server/config"
+	"github.com/hyperledger-labs/orion-server/pkg/crypto"
+	"github.com/hyperledger-labs/orion-server/pkg/cryptoservice"
+	"github.com/hyperledger-labs/orion-server/pkg/server"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultCommitTimeout is the time SubmitAndWait waits for a transaction to be committed.
+	DefaultCommitTimeout = 30 * time.Second
+
+	leaderWaitTimeout  = 30 * time.Second
+	leaderPollInterval = 100 * time.Millisecond
+)
+
+// Server is an embedded single-node database server.
+type Server struct {
+	dir         string
+	conf        *config.Configurations
+	server      *server.BCDBHTTPServer
+	adminSigner crypto.Signer
+}
+
+// NewServer creates an embedded server that keeps its crypto material and ledger in `dir`.
+// The crypto material of the CA, the node, and the admin is generated on first use, and reused afterwards,
+// so that a server can be stopped and re-created over the same directory. The client and peer listen ports
+// are allocated by the operating system, so that several embedded servers may run side by side.
+func NewServer(dir string) (*Server, error) {
+	conf, err := config.DevConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	conf.LocalConfig.Server.Network.Port = 0
+	conf.LocalConfig.Replication.Network.Port = 0
+
+	adminSigner, err := crypto.NewSigner(&crypto.SignerOptions{
+		Identity:    config.DevAdminID,
+		KeyFilePath: path.Join(dir, "crypto", config.DevAdminID+".key"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while loading the admin signer")
+	}
+
+	srv, err := server.New(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		dir:         dir,
+		conf:        conf,
+		server:      srv,
+		adminSigner: adminSigner,
+	}, nil
+}
+
+// Start starts the server and waits until it becomes the leader of its single-node cluster,
+// i.e., until it is ready to accept transactions.
+func (s *Server) Start() error {
+	if err := s.server.Start(); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(leaderWaitTimeout)
+	for s.server.IsLeader() != nil {
+		if time.Now().After(deadline) {
+			return errors.Errorf("server did not become a leader within %s", leaderWaitTimeout)
+		}
+		time.Sleep(leaderPollInterval)
+	}
+
+	return nil
+}
+
+// Stop stops the server and closes the database. The ledger and crypto material are kept in the directory.
+func (s *Server) Stop() error {
+	return s.server.Stop()
+}
+
+// URL returns the address of the HTTP endpoint of the server.
+func (s *Server) URL() (string, error) {
+	port, err := s.server.Port()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("http://%s:%s", s.conf.LocalConfig.Server.Network.Address, port), nil
+}
+
+// Config returns the configuration the server was started with.
+func (s *Server) Config() *config.Configurations {
+	return s.conf
+}
+
+// AdminID returns the ID of the cluster admin.
+func (s *Server) AdminID() string {
+	return config.DevAdminID
+}
+
+// AdminSigner returns the signer of the cluster admin.
+func (s *Server) AdminSigner() crypto.Signer {
+	return s.adminSigner
+}
+
+// AdminCertificatePath returns the path of the certificate of the cluster admin.
+func (s *Server) AdminCertificatePath() string {
+	return s.conf.SharedConfig.Admin.CertificatePath
+}
+
+// RootCACertificatePath returns the path of the root CA certificate, which issued the node and admin certificates.
+func (s *Server) RootCACertificatePath() string {
+	return s.conf.SharedConfig.CAConfig.RootCACertsPath[0]
+}
+
+// SubmitAndWait submits a signed transaction envelope and blocks until it is committed, or until the timeout
+// expires. If the timeout is zero, DefaultCommitTimeout is used. The envelope must be one of
+// *types.DataTxEnvelope, *types.UserAdministrationTxEnvelope, *types.DBAdministrationTxEnvelope, or
+// *types.ConfigTxEnvelope. A transaction that was committed but marked invalid returns the receipt along with
+// an error that carries the validation flag and reason.
+func (s *Server) SubmitAndWait(txEnv interface{}, timeout time.Duration) (*types.TxReceipt, error) {
+	if timeout == 0 {
+		timeout = DefaultCommitTimeout
+	}
+
+	resp, err := s.server.SubmitTransaction(txEnv, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt := resp.GetResponse().GetReceipt()
+	validationInfo := receipt.GetHeader().GetValidationInfo()
+	if receipt.GetTxIndex() >= uint64(len(validationInfo)) {
+		return receipt, errors.Errorf("receipt carries no validation info for tx index %d", receipt.GetTxIndex())
+	}
+	if info := validationInfo[receipt.GetTxIndex()]; info.GetFlag() != types.Flag_VALID {
+		return receipt, errors.Errorf("transaction is invalid, flag: %s, reason: %s", info.GetFlag(), info.GetReasonIfInvalid())
+	}
+
+	return receipt, nil
+}
+
+// GetData returns the value and metadata of a key, as seen by the given user.
+func (s *Server) GetData(dbName, userID, key string) (*types.GetDataResponse, error) {
+	resp, err := s.server.GetData(dbName, userID, key)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetResponse(), nil
+}
+
+// LedgerHeight returns the current height of the ledger.
+func (s *Server) LedgerHeight() (uint64, error) {
+	return s.server.LedgerHeight()
+}
+
+// SignedDataTxEnvelope signs a data transaction with each of the signers.
+func SignedDataTxEnvelope(tx *types.DataTx, signers ...crypto.Signer) (*types.DataTxEnvelope, error) {
+	env := &types.DataTxEnvelope{
+		Payload:    tx,
+		Signatures: make(map[string][]byte),
+	}
+
+	for _, signer := range signers {
+		sig, err := cryptoservice.SignTx(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		env.Signatures[signer.Identity()] = sig
+	}
+
+	return env, nil
+}
+
+// SignedUserAdministrationTxEnvelope signs a user administration transaction.
+func SignedUserAdministrationTxEnvelope(tx *types.UserAdministrationTx, signer crypto.Signer) (*types.UserAdministrationTxEnvelope, error) {
+	sig, err := cryptoservice.SignTx(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &types.UserAdministrationTxEnvelope{Payload: tx, Signature: sig}, nil
+}
+
+// SignedDBAdministrationTxEnvelope signs a database administration transaction.
+func SignedDBAdministrationTxEnvelope(tx *types.DBAdministrationTx, signer crypto.Signer) (*types.DBAdministrationTxEnvelope, error) {
+	sig, err := cryptoservice.SignTx(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &types.DBAdministrationTxEnvelope{Payload: tx, Signature: sig}, nil
+}
+
+// SignedConfigTxEnvelope signs a cluster configuration transaction.
+func SignedConfigTxEnvelope(tx *types.ConfigTx, signer crypto.Signer) (*types.ConfigTxEnvelope, error) {
+	sig, err := cryptoservice.SignTx(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &types.ConfigTxEnvelope{Payload: tx, Signature: sig}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+package testutil
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger-labs/orion-server/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "embeddedServer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	srv, err := NewServer(dir)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start())
+
+	dbTx := &types.DBAdministrationTx{
+		TxId:      uuid.New().String(),
+		UserId:    srv.AdminID(),
+		CreateDbs: []string{"testDB"},
+	}
+	dbEnv, err := SignedDBAdministrationTxEnvelope(dbTx, srv.AdminSigner())
+	require.NoError(t, err)
+	receipt, err := srv.SubmitAndWait(dbEnv, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), receipt.GetHeader().GetBaseHeader().GetNumber())
+
+	dataTx := &types.DataTx{
+		MustSignUserIds: []string{srv.AdminID()},
+		TxId:            uuid.New().String(),
+		DbOperations: []*types.DBOperation{
+			{
+				DbName: "testDB",
+				DataWrites: []*types.DataWrite{
+					{
+						Key:   "foo",
+						Value: []byte("bar"),
+					},
+				},
+			},
+		},
+	}
+	dataEnv, err := SignedDataTxEnvelope(dataTx, srv.AdminSigner())
+	require.NoError(t, err)
+	receipt, err = srv.SubmitAndWait(dataEnv, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), receipt.GetHeader().GetBaseHeader().GetNumber())
+
+	data, err := srv.GetData("testDB", srv.AdminID(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, []byte("bar"), data.GetValue())
+
+	// a transaction on a non-existing database is committed as invalid
+	dataTx.TxId = uuid.New().String()
+	dataTx.DbOperations[0].DbName = "no-such-db"
+	dataEnv, err = SignedDataTxEnvelope(dataTx, srv.AdminSigner())
+	require.NoError(t, err)
+	receipt, err = srv.SubmitAndWait(dataEnv, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "transaction is invalid")
+	require.NotNil(t, receipt)
+
+	// restart over the same directory
+	require.NoError(t, srv.Stop())
+	srv, err = NewServer(dir)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	height, err := srv.LedgerHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), height)
+
+	data, err = srv.GetData("testDB", srv.AdminID(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, []byte("bar"), data.GetValue())
+}
@@ -105,16 +105,23 @@ func ConstructCompositeKey(dbName, key string) ([]byte, error) {
 }
 
 func CalculateKeyValueHash(key, value []byte) ([]byte, error) {
-	bytesToHash := make([]byte, 0)
-	if len(key) > 0 {
-		bytesToHash = append(bytesToHash, key...)
-	}
+	var valHash []byte
 	if len(value) > 0 {
-		valHash, err := crypto.ComputeSHA256Hash(value)
-		if err != nil {
+		var err error
+		if valHash, err = crypto.ComputeSHA256Hash(value); err != nil {
 			return nil, err
 		}
-		bytesToHash = append(bytesToHash, valHash...)
 	}
+	return CalculateKeyValueHashOfValueHash(key, valHash)
+}
+
+// CalculateKeyValueHashOfValueHash calculates the same hash as CalculateKeyValueHash, given the hash of the value
+// rather than the value, e.g., when the value was erased
+func CalculateKeyValueHashOfValueHash(key, valHash []byte) ([]byte, error) {
+	bytesToHash := make([]byte, 0)
+	if len(key) > 0 {
+		bytesToHash = append(bytesToHash, key...)
+	}
+	bytesToHash = append(bytesToHash, valHash...)
 	return crypto.ComputeSHA256Hash(bytesToHash)
 }
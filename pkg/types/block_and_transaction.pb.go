@@ -31,17 +31,29 @@ const (
 	Flag_INVALID_INCORRECT_ENTRIES                  Flag = 5
 	Flag_INVALID_UNAUTHORISED                       Flag = 6
 	Flag_INVALID_MISSING_SIGNATURE                  Flag = 7
+	Flag_INVALID_USER_DISABLED                      Flag = 8
+	Flag_INVALID_QUOTA_EXCEEDED                     Flag = 9
+	Flag_INVALID_LEGAL_HOLD                         Flag = 10
+	Flag_INVALID_DANGLING_REFERENCE                 Flag = 11
+	Flag_INVALID_DATABASE_FROZEN                    Flag = 12
+	Flag_INVALID_SCHEMA_VIOLATION                   Flag = 13
 )
 
 var Flag_name = map[int32]string{
-	0: "VALID",
-	1: "INVALID_MVCC_CONFLICT_WITHIN_BLOCK",
-	2: "INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE",
-	3: "INVALID_DATABASE_DOES_NOT_EXIST",
-	4: "INVALID_NO_PERMISSION",
-	5: "INVALID_INCORRECT_ENTRIES",
-	6: "INVALID_UNAUTHORISED",
-	7: "INVALID_MISSING_SIGNATURE",
+	0:  "VALID",
+	1:  "INVALID_MVCC_CONFLICT_WITHIN_BLOCK",
+	2:  "INVALID_MVCC_CONFLICT_WITH_COMMITTED_STATE",
+	3:  "INVALID_DATABASE_DOES_NOT_EXIST",
+	4:  "INVALID_NO_PERMISSION",
+	5:  "INVALID_INCORRECT_ENTRIES",
+	6:  "INVALID_UNAUTHORISED",
+	7:  "INVALID_MISSING_SIGNATURE",
+	8:  "INVALID_USER_DISABLED",
+	9:  "INVALID_QUOTA_EXCEEDED",
+	10: "INVALID_LEGAL_HOLD",
+	11: "INVALID_DANGLING_REFERENCE",
+	12: "INVALID_DATABASE_FROZEN",
+	13: "INVALID_SCHEMA_VIOLATION",
 }
 
 var Flag_value = map[string]int32{
@@ -53,6 +65,12 @@ var Flag_value = map[string]int32{
 	"INVALID_INCORRECT_ENTRIES":                  5,
 	"INVALID_UNAUTHORISED":                       6,
 	"INVALID_MISSING_SIGNATURE":                  7,
+	"INVALID_USER_DISABLED":                      8,
+	"INVALID_QUOTA_EXCEEDED":                     9,
+	"INVALID_LEGAL_HOLD":                         10,
+	"INVALID_DANGLING_REFERENCE":                 11,
+	"INVALID_DATABASE_FROZEN":                    12,
+	"INVALID_SCHEMA_VIOLATION":                   13,
 }
 
 func (x Flag) String() string {
@@ -63,6 +81,77 @@ func (Flag) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_8098d268f52aac08, []int{0}
 }
 
+// DBOperationCheck is a validation check performed on a database operation of a data transaction
+type DBOperationCheck int32
+
+const (
+	DBOperationCheck_NO_CHECK DBOperationCheck = 0
+	// the database is valid, exists, and is not a system database
+	DBOperationCheck_DB_CHECK DBOperationCheck = 1
+	// a signer has read-write permission on the database
+	DBOperationCheck_DB_PERMISSION_CHECK DBOperationCheck = 2
+	// the entries of the writes and deletes are well formed, unique, and refer to existing keys and users
+	DBOperationCheck_ENTRIES_CHECK DBOperationCheck = 3
+	// a signer has read permission on a read key
+	DBOperationCheck_READ_ACL_CHECK DBOperationCheck = 4
+	// the signers satisfy the write access control of a written key
+	DBOperationCheck_WRITE_ACL_CHECK DBOperationCheck = 5
+	// the signers satisfy the write access control of a deleted key
+	DBOperationCheck_DELETE_ACL_CHECK DBOperationCheck = 6
+	// the read versions match the committed state, and no key is modified twice in a block
+	DBOperationCheck_MVCC_CHECK DBOperationCheck = 7
+	// the transaction does not make the database exceed the hard limits of its quota
+	DBOperationCheck_QUOTA_CHECK DBOperationCheck = 8
+	// no deleted key is under a legal hold
+	DBOperationCheck_LEGAL_HOLD_CHECK DBOperationCheck = 9
+	// the written documents refer to existing keys, as required by the reference constraints of the database
+	DBOperationCheck_REFERENCE_CHECK DBOperationCheck = 10
+	// the database is not frozen, if the operation writes to it
+	DBOperationCheck_FREEZE_CHECK DBOperationCheck = 11
+	// the written documents conform to the schema of the database
+	DBOperationCheck_SCHEMA_CHECK DBOperationCheck = 12
+)
+
+var DBOperationCheck_name = map[int32]string{
+	0:  "NO_CHECK",
+	1:  "DB_CHECK",
+	2:  "DB_PERMISSION_CHECK",
+	3:  "ENTRIES_CHECK",
+	4:  "READ_ACL_CHECK",
+	5:  "WRITE_ACL_CHECK",
+	6:  "DELETE_ACL_CHECK",
+	7:  "MVCC_CHECK",
+	8:  "QUOTA_CHECK",
+	9:  "LEGAL_HOLD_CHECK",
+	10: "REFERENCE_CHECK",
+	11: "FREEZE_CHECK",
+	12: "SCHEMA_CHECK",
+}
+
+var DBOperationCheck_value = map[string]int32{
+	"NO_CHECK":            0,
+	"DB_CHECK":            1,
+	"DB_PERMISSION_CHECK": 2,
+	"ENTRIES_CHECK":       3,
+	"READ_ACL_CHECK":      4,
+	"WRITE_ACL_CHECK":     5,
+	"DELETE_ACL_CHECK":    6,
+	"MVCC_CHECK":          7,
+	"QUOTA_CHECK":         8,
+	"LEGAL_HOLD_CHECK":    9,
+	"REFERENCE_CHECK":     10,
+	"FREEZE_CHECK":        11,
+	"SCHEMA_CHECK":        12,
+}
+
+func (x DBOperationCheck) String() string {
+	return proto.EnumName(DBOperationCheck_name, int32(x))
+}
+
+func (DBOperationCheck) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{1}
+}
+
 type IndexAttributeType int32
 
 const (
@@ -88,7 +177,32 @@ func (x IndexAttributeType) String() string {
 }
 
 func (IndexAttributeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{1}
+	return fileDescriptor_8098d268f52aac08, []int{2}
+}
+
+type Migration_Direction int32
+
+const (
+	Migration_UP   Migration_Direction = 0
+	Migration_DOWN Migration_Direction = 1
+)
+
+var Migration_Direction_name = map[int32]string{
+	0: "UP",
+	1: "DOWN",
+}
+
+var Migration_Direction_value = map[string]int32{
+	"UP":   0,
+	"DOWN": 1,
+}
+
+func (x Migration_Direction) String() string {
+	return proto.EnumName(Migration_Direction_name, int32(x))
+}
+
+func (Migration_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{21, 0}
 }
 
 type AccessControlWritePolicy int32
@@ -96,16 +210,20 @@ type AccessControlWritePolicy int32
 const (
 	AccessControl_ANY AccessControlWritePolicy = 0
 	AccessControl_ALL AccessControlWritePolicy = 1
+	// at least sign_threshold_for_write of the read_write_users must sign
+	AccessControl_THRESHOLD AccessControlWritePolicy = 2
 )
 
 var AccessControlWritePolicy_name = map[int32]string{
 	0: "ANY",
 	1: "ALL",
+	2: "THRESHOLD",
 }
 
 var AccessControlWritePolicy_value = map[string]int32{
-	"ANY": 0,
-	"ALL": 1,
+	"ANY":       0,
+	"ALL":       1,
+	"THRESHOLD": 2,
 }
 
 func (x AccessControlWritePolicy) String() string {
@@ -113,7 +231,32 @@ func (x AccessControlWritePolicy) String() string {
 }
 
 func (AccessControlWritePolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{22, 0}
+	return fileDescriptor_8098d268f52aac08, []int{38, 0}
+}
+
+type QuotaAlert_Resource int32
+
+const (
+	QuotaAlert_STORAGE QuotaAlert_Resource = 0
+	QuotaAlert_TX_RATE QuotaAlert_Resource = 1
+)
+
+var QuotaAlert_Resource_name = map[int32]string{
+	0: "STORAGE",
+	1: "TX_RATE",
+}
+
+var QuotaAlert_Resource_value = map[string]int32{
+	"STORAGE": 0,
+	"TX_RATE": 1,
+}
+
+func (x QuotaAlert_Resource) String() string {
+	return proto.EnumName(QuotaAlert_Resource_name, int32(x))
+}
+
+func (QuotaAlert_Resource) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{53, 0}
 }
 
 // Block holds the chain information and transactions
@@ -126,10 +269,15 @@ type Block struct {
 	//	*Block_UserAdministrationTxEnvelope
 	Payload isBlock_Payload `protobuf_oneof:"Payload"`
 	// Consensus protocol metadata
-	ConsensusMetadata    *ConsensusMetadata `protobuf:"bytes,6,opt,name=consensus_metadata,json=consensusMetadata,proto3" json:"consensus_metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	ConsensusMetadata *ConsensusMetadata `protobuf:"bytes,6,opt,name=consensus_metadata,json=consensusMetadata,proto3" json:"consensus_metadata,omitempty"`
+	// set only in the blocks held by the block store, and in the blocks read from it after keys were erased: the hashes
+	// of the transactions that write values to erasable databases, as committed. A transaction whose values were erased
+	// no longer hashes to the leaf of the tx Merkle tree it was committed with, hence the tree is built with its
+	// committed hash in its place. A block read from the block store holds the hashes of such transactions only.
+	SealedTxHashes       []*TxHash `protobuf:"bytes,7,rep,name=sealed_tx_hashes,json=sealedTxHashes,proto3" json:"sealed_tx_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *Block) Reset()         { *m = Block{} }
@@ -234,6 +382,13 @@ func (m *Block) GetConsensusMetadata() *ConsensusMetadata {
 	return nil
 }
 
+func (m *Block) GetSealedTxHashes() []*TxHash {
+	if m != nil {
+		return m.SealedTxHashes
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Block) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -244,6 +399,54 @@ func (*Block) XXX_OneofWrappers() []interface{} {
 	}
 }
 
+// TxHash holds the hash of a transaction of a block, along with its validation info, i.e., a leaf of the tx Merkle tree
+type TxHash struct {
+	TxIndex              uint64   `protobuf:"varint,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxHash) Reset()         { *m = TxHash{} }
+func (m *TxHash) String() string { return proto.CompactTextString(m) }
+func (*TxHash) ProtoMessage()    {}
+func (*TxHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{1}
+}
+
+func (m *TxHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxHash.Unmarshal(m, b)
+}
+func (m *TxHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxHash.Marshal(b, m, deterministic)
+}
+func (m *TxHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxHash.Merge(m, src)
+}
+func (m *TxHash) XXX_Size() int {
+	return xxx_messageInfo_TxHash.Size(m)
+}
+func (m *TxHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxHash proto.InternalMessageInfo
+
+func (m *TxHash) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *TxHash) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
 // BlockHeaderBase holds the block metadata and the chain information
 // that computed before transaction validation
 type BlockHeaderBase struct {
@@ -253,17 +456,21 @@ type BlockHeaderBase struct {
 	// Hash of BlockHeader of last block already committed to ledger
 	LastCommittedBlockHash []byte `protobuf:"bytes,3,opt,name=last_committed_block_hash,json=lastCommittedBlockHash,proto3" json:"last_committed_block_hash,omitempty"`
 	// Number of last block already committed to ledger
-	LastCommittedBlockNum uint64   `protobuf:"varint,4,opt,name=last_committed_block_num,json=lastCommittedBlockNum,proto3" json:"last_committed_block_num,omitempty"`
-	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
-	XXX_unrecognized      []byte   `json:"-"`
-	XXX_sizecache         int32    `json:"-"`
+	LastCommittedBlockNum uint64 `protobuf:"varint,4,opt,name=last_committed_block_num,json=lastCommittedBlockNum,proto3" json:"last_committed_block_num,omitempty"`
+	// Block creation time, in nanoseconds since the Unix epoch, as set by the leader when the block is proposed.
+	// Block timestamps never decrease along the chain. Followers report a block timestamp that deviates from their
+	// local clock by more than the allowed clock skew.
+	Timestamp            int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *BlockHeaderBase) Reset()         { *m = BlockHeaderBase{} }
 func (m *BlockHeaderBase) String() string { return proto.CompactTextString(m) }
 func (*BlockHeaderBase) ProtoMessage()    {}
 func (*BlockHeaderBase) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{1}
+	return fileDescriptor_8098d268f52aac08, []int{2}
 }
 
 func (m *BlockHeaderBase) XXX_Unmarshal(b []byte) error {
@@ -312,6 +519,13 @@ func (m *BlockHeaderBase) GetLastCommittedBlockNum() uint64 {
 	return 0
 }
 
+func (m *BlockHeaderBase) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
 // BlockHeader holds, in addition to base header, additional chain integrity information that is computed after transactions validation,
 // including the state and transaction Merkle trees roots, skip-chain hashes, and transaction validation information.
 type BlockHeader struct {
@@ -323,17 +537,20 @@ type BlockHeader struct {
 	// Root hash of system wide state merkle-particia tree
 	StateMerkelTreeRootHash []byte `protobuf:"bytes,4,opt,name=state_merkel_tree_root_hash,json=stateMerkelTreeRootHash,proto3" json:"state_merkel_tree_root_hash,omitempty"`
 	// Validation info for transactions in block.
-	ValidationInfo       []*ValidationInfo `protobuf:"bytes,5,rep,name=validation_info,json=validationInfo,proto3" json:"validation_info,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	ValidationInfo []*ValidationInfo `protobuf:"bytes,5,rep,name=validation_info,json=validationInfo,proto3" json:"validation_info,omitempty"`
+	// Root hashes of the state tries of the databases updated by the block, sorted by database name. The root of each
+	// database state trie is a value in the system wide state trie, keyed by the database name.
+	DbStateRootHashes    []*DBStateRootHash `protobuf:"bytes,6,rep,name=db_state_root_hashes,json=dbStateRootHashes,proto3" json:"db_state_root_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{2}
+	return fileDescriptor_8098d268f52aac08, []int{3}
 }
 
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
@@ -389,6 +606,61 @@ func (m *BlockHeader) GetValidationInfo() []*ValidationInfo {
 	return nil
 }
 
+func (m *BlockHeader) GetDbStateRootHashes() []*DBStateRootHash {
+	if m != nil {
+		return m.DbStateRootHashes
+	}
+	return nil
+}
+
+// DBStateRootHash holds the root hash of the state merkle-patricia tree of a single database
+type DBStateRootHash struct {
+	DbName               string   `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	RootHash             []byte   `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DBStateRootHash) Reset()         { *m = DBStateRootHash{} }
+func (m *DBStateRootHash) String() string { return proto.CompactTextString(m) }
+func (*DBStateRootHash) ProtoMessage()    {}
+func (*DBStateRootHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{4}
+}
+
+func (m *DBStateRootHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBStateRootHash.Unmarshal(m, b)
+}
+func (m *DBStateRootHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBStateRootHash.Marshal(b, m, deterministic)
+}
+func (m *DBStateRootHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBStateRootHash.Merge(m, src)
+}
+func (m *DBStateRootHash) XXX_Size() int {
+	return xxx_messageInfo_DBStateRootHash.Size(m)
+}
+func (m *DBStateRootHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBStateRootHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBStateRootHash proto.InternalMessageInfo
+
+func (m *DBStateRootHash) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *DBStateRootHash) GetRootHash() []byte {
+	if m != nil {
+		return m.RootHash
+	}
+	return nil
+}
+
 type DataTxEnvelopes struct {
 	Envelopes            []*DataTxEnvelope `protobuf:"bytes,1,rep,name=envelopes,proto3" json:"envelopes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -400,7 +672,7 @@ func (m *DataTxEnvelopes) Reset()         { *m = DataTxEnvelopes{} }
 func (m *DataTxEnvelopes) String() string { return proto.CompactTextString(m) }
 func (*DataTxEnvelopes) ProtoMessage()    {}
 func (*DataTxEnvelopes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{3}
+	return fileDescriptor_8098d268f52aac08, []int{5}
 }
 
 func (m *DataTxEnvelopes) XXX_Unmarshal(b []byte) error {
@@ -440,7 +712,7 @@ func (m *DataTxEnvelope) Reset()         { *m = DataTxEnvelope{} }
 func (m *DataTxEnvelope) String() string { return proto.CompactTextString(m) }
 func (*DataTxEnvelope) ProtoMessage()    {}
 func (*DataTxEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{4}
+	return fileDescriptor_8098d268f52aac08, []int{6}
 }
 
 func (m *DataTxEnvelope) XXX_Unmarshal(b []byte) error {
@@ -475,6 +747,121 @@ func (m *DataTxEnvelope) GetSignatures() map[string][]byte {
 	return nil
 }
 
+// PendingDataTx is a data transaction that awaits the signatures of some of its must sign users. It is held by the
+// leader until all must sign users sign it, at which point it is submitted, or until it expires.
+type PendingDataTx struct {
+	Envelope *DataTxEnvelope `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	// The must sign users that have not signed the transaction yet
+	PendingSignerIds []string `protobuf:"bytes,2,rep,name=pending_signer_ids,json=pendingSignerIds,proto3" json:"pending_signer_ids,omitempty"`
+	// The expiry time, in nanoseconds since the Unix epoch, after which the transaction is dropped
+	ExpiresAt            int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PendingDataTx) Reset()         { *m = PendingDataTx{} }
+func (m *PendingDataTx) String() string { return proto.CompactTextString(m) }
+func (*PendingDataTx) ProtoMessage()    {}
+func (*PendingDataTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{7}
+}
+
+func (m *PendingDataTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingDataTx.Unmarshal(m, b)
+}
+func (m *PendingDataTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingDataTx.Marshal(b, m, deterministic)
+}
+func (m *PendingDataTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingDataTx.Merge(m, src)
+}
+func (m *PendingDataTx) XXX_Size() int {
+	return xxx_messageInfo_PendingDataTx.Size(m)
+}
+func (m *PendingDataTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingDataTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingDataTx proto.InternalMessageInfo
+
+func (m *PendingDataTx) GetEnvelope() *DataTxEnvelope {
+	if m != nil {
+		return m.Envelope
+	}
+	return nil
+}
+
+func (m *PendingDataTx) GetPendingSignerIds() []string {
+	if m != nil {
+		return m.PendingSignerIds
+	}
+	return nil
+}
+
+func (m *PendingDataTx) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// PendingDataTxSignature adds the signature of a must sign user on the payload of a pending data transaction
+type PendingDataTxSignature struct {
+	TxId                 string   `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	UserId               string   `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Signature            []byte   `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PendingDataTxSignature) Reset()         { *m = PendingDataTxSignature{} }
+func (m *PendingDataTxSignature) String() string { return proto.CompactTextString(m) }
+func (*PendingDataTxSignature) ProtoMessage()    {}
+func (*PendingDataTxSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{8}
+}
+
+func (m *PendingDataTxSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingDataTxSignature.Unmarshal(m, b)
+}
+func (m *PendingDataTxSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingDataTxSignature.Marshal(b, m, deterministic)
+}
+func (m *PendingDataTxSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingDataTxSignature.Merge(m, src)
+}
+func (m *PendingDataTxSignature) XXX_Size() int {
+	return xxx_messageInfo_PendingDataTxSignature.Size(m)
+}
+func (m *PendingDataTxSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingDataTxSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingDataTxSignature proto.InternalMessageInfo
+
+func (m *PendingDataTxSignature) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *PendingDataTxSignature) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *PendingDataTxSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type ConfigTxEnvelope struct {
 	Payload              *ConfigTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
 	Signature            []byte    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
@@ -487,7 +874,7 @@ func (m *ConfigTxEnvelope) Reset()         { *m = ConfigTxEnvelope{} }
 func (m *ConfigTxEnvelope) String() string { return proto.CompactTextString(m) }
 func (*ConfigTxEnvelope) ProtoMessage()    {}
 func (*ConfigTxEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{5}
+	return fileDescriptor_8098d268f52aac08, []int{9}
 }
 
 func (m *ConfigTxEnvelope) XXX_Unmarshal(b []byte) error {
@@ -534,7 +921,7 @@ func (m *DBAdministrationTxEnvelope) Reset()         { *m = DBAdministrationTxEn
 func (m *DBAdministrationTxEnvelope) String() string { return proto.CompactTextString(m) }
 func (*DBAdministrationTxEnvelope) ProtoMessage()    {}
 func (*DBAdministrationTxEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{6}
+	return fileDescriptor_8098d268f52aac08, []int{10}
 }
 
 func (m *DBAdministrationTxEnvelope) XXX_Unmarshal(b []byte) error {
@@ -570,18 +957,20 @@ func (m *DBAdministrationTxEnvelope) GetSignature() []byte {
 }
 
 type UserAdministrationTxEnvelope struct {
-	Payload              *UserAdministrationTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Payload   *UserAdministrationTx `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	// renewal_signature is the signature on the payload by the key of the new certificate of a certificate renewal
+	RenewalSignature     []byte   `protobuf:"bytes,3,opt,name=renewal_signature,json=renewalSignature,proto3" json:"renewal_signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *UserAdministrationTxEnvelope) Reset()         { *m = UserAdministrationTxEnvelope{} }
 func (m *UserAdministrationTxEnvelope) String() string { return proto.CompactTextString(m) }
 func (*UserAdministrationTxEnvelope) ProtoMessage()    {}
 func (*UserAdministrationTxEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{7}
+	return fileDescriptor_8098d268f52aac08, []int{11}
 }
 
 func (m *UserAdministrationTxEnvelope) XXX_Unmarshal(b []byte) error {
@@ -616,20 +1005,30 @@ func (m *UserAdministrationTxEnvelope) GetSignature() []byte {
 	return nil
 }
 
+func (m *UserAdministrationTxEnvelope) GetRenewalSignature() []byte {
+	if m != nil {
+		return m.RenewalSignature
+	}
+	return nil
+}
+
 type DataTx struct {
-	MustSignUserIds      []string       `protobuf:"bytes,1,rep,name=must_sign_user_ids,json=mustSignUserIds,proto3" json:"must_sign_user_ids,omitempty"`
-	TxId                 string         `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	DbOperations         []*DBOperation `protobuf:"bytes,3,rep,name=db_operations,json=dbOperations,proto3" json:"db_operations,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	MustSignUserIds []string       `protobuf:"bytes,1,rep,name=must_sign_user_ids,json=mustSignUserIds,proto3" json:"must_sign_user_ids,omitempty"`
+	TxId            string         `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	DbOperations    []*DBOperation `protobuf:"bytes,3,rep,name=db_operations,json=dbOperations,proto3" json:"db_operations,omitempty"`
+	// Opaque application annotations, e.g., correlation IDs and business references. Annotations are recorded in the
+	// provenance store and returned with the transaction receipt. Their number and total size are limited.
+	Annotations          map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *DataTx) Reset()         { *m = DataTx{} }
 func (m *DataTx) String() string { return proto.CompactTextString(m) }
 func (*DataTx) ProtoMessage()    {}
 func (*DataTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{8}
+	return fileDescriptor_8098d268f52aac08, []int{12}
 }
 
 func (m *DataTx) XXX_Unmarshal(b []byte) error {
@@ -671,11 +1070,19 @@ func (m *DataTx) GetDbOperations() []*DBOperation {
 	return nil
 }
 
+func (m *DataTx) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
 type DBOperation struct {
 	DbName               string        `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
 	DataReads            []*DataRead   `protobuf:"bytes,4,rep,name=data_reads,json=dataReads,proto3" json:"data_reads,omitempty"`
 	DataWrites           []*DataWrite  `protobuf:"bytes,5,rep,name=data_writes,json=dataWrites,proto3" json:"data_writes,omitempty"`
 	DataDeletes          []*DataDelete `protobuf:"bytes,6,rep,name=data_deletes,json=dataDeletes,proto3" json:"data_deletes,omitempty"`
+	AclWrites            []*AclWrite   `protobuf:"bytes,7,rep,name=acl_writes,json=aclWrites,proto3" json:"acl_writes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
 	XXX_unrecognized     []byte        `json:"-"`
 	XXX_sizecache        int32         `json:"-"`
@@ -685,7 +1092,7 @@ func (m *DBOperation) Reset()         { *m = DBOperation{} }
 func (m *DBOperation) String() string { return proto.CompactTextString(m) }
 func (*DBOperation) ProtoMessage()    {}
 func (*DBOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{9}
+	return fileDescriptor_8098d268f52aac08, []int{13}
 }
 
 func (m *DBOperation) XXX_Unmarshal(b []byte) error {
@@ -734,6 +1141,13 @@ func (m *DBOperation) GetDataDeletes() []*DataDelete {
 	return nil
 }
 
+func (m *DBOperation) GetAclWrites() []*AclWrite {
+	if m != nil {
+		return m.AclWrites
+	}
+	return nil
+}
+
 // DataRead hold a read key and its version
 type DataRead struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -747,7 +1161,7 @@ func (m *DataRead) Reset()         { *m = DataRead{} }
 func (m *DataRead) String() string { return proto.CompactTextString(m) }
 func (*DataRead) ProtoMessage()    {}
 func (*DataRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{10}
+	return fileDescriptor_8098d268f52aac08, []int{14}
 }
 
 func (m *DataRead) XXX_Unmarshal(b []byte) error {
@@ -784,19 +1198,32 @@ func (m *DataRead) GetVersion() *Version {
 
 // DataWrite hold a write including a delete
 type DataWrite struct {
-	Key                  string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte         `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Acl                  *AccessControl `protobuf:"bytes,3,opt,name=acl,proto3" json:"acl,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Key   string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte         `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Acl   *AccessControl `protobuf:"bytes,3,opt,name=acl,proto3" json:"acl,omitempty"`
+	// set only in the blocks held by the block store, when the value is written to an erasable database. The value is
+	// then sealed with the erasure key of the key, and the value field is empty. A block read from the block store
+	// holds the value again, unless the key was erased.
+	EncryptedValue *EncryptedValue `protobuf:"bytes,4,opt,name=encrypted_value,json=encryptedValue,proto3" json:"encrypted_value,omitempty"`
+	// set when the value is a blob that was uploaded to the blob store before the transaction was submitted. The value
+	// field is then empty, and the transaction, and hence the block, carries only the manifest of the blob.
+	BlobManifest *BlobManifest `protobuf:"bytes,5,opt,name=blob_manifest,json=blobManifest,proto3" json:"blob_manifest,omitempty"`
+	// set only in the blocks read from the block store, when the key was erased since the value was written. The value
+	// field is then empty, on every node alike.
+	Erased bool `protobuf:"varint,6,opt,name=erased,proto3" json:"erased,omitempty"`
+	// set along with encrypted_value: the hash of the value, to which the state trie refers. It is kept once the key is
+	// erased, such that a node that commits the block from another node updates its state trie alike.
+	ValueHash            []byte   `protobuf:"bytes,7,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *DataWrite) Reset()         { *m = DataWrite{} }
 func (m *DataWrite) String() string { return proto.CompactTextString(m) }
 func (*DataWrite) ProtoMessage()    {}
 func (*DataWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{11}
+	return fileDescriptor_8098d268f52aac08, []int{15}
 }
 
 func (m *DataWrite) XXX_Unmarshal(b []byte) error {
@@ -838,6 +1265,34 @@ func (m *DataWrite) GetAcl() *AccessControl {
 	return nil
 }
 
+func (m *DataWrite) GetEncryptedValue() *EncryptedValue {
+	if m != nil {
+		return m.EncryptedValue
+	}
+	return nil
+}
+
+func (m *DataWrite) GetBlobManifest() *BlobManifest {
+	if m != nil {
+		return m.BlobManifest
+	}
+	return nil
+}
+
+func (m *DataWrite) GetErased() bool {
+	if m != nil {
+		return m.Erased
+	}
+	return false
+}
+
+func (m *DataWrite) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
 type DataDelete struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -849,7 +1304,7 @@ func (m *DataDelete) Reset()         { *m = DataDelete{} }
 func (m *DataDelete) String() string { return proto.CompactTextString(m) }
 func (*DataDelete) ProtoMessage()    {}
 func (*DataDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{12}
+	return fileDescriptor_8098d268f52aac08, []int{16}
 }
 
 func (m *DataDelete) XXX_Unmarshal(b []byte) error {
@@ -877,21 +1332,74 @@ func (m *DataDelete) GetKey() string {
 	return ""
 }
 
-type ConfigTx struct {
-	UserId               string         `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TxId                 string         `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	ReadOldConfigVersion *Version       `protobuf:"bytes,3,opt,name=read_old_config_version,json=readOldConfigVersion,proto3" json:"read_old_config_version,omitempty"`
-	NewConfig            *ClusterConfig `protobuf:"bytes,4,opt,name=new_config,json=newConfig,proto3" json:"new_config,omitempty"`
+// AclWrite replaces the access control of an existing key without rewriting its value. Like a DataWrite, it creates
+// a new version of the key that is tracked in the provenance store, but the value itself is not resubmitted.
+type AclWrite struct {
+	Key                  string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	NewAcl               *AccessControl `protobuf:"bytes,2,opt,name=new_acl,json=newAcl,proto3" json:"new_acl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
 }
 
+func (m *AclWrite) Reset()         { *m = AclWrite{} }
+func (m *AclWrite) String() string { return proto.CompactTextString(m) }
+func (*AclWrite) ProtoMessage()    {}
+func (*AclWrite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{17}
+}
+
+func (m *AclWrite) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AclWrite.Unmarshal(m, b)
+}
+func (m *AclWrite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AclWrite.Marshal(b, m, deterministic)
+}
+func (m *AclWrite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AclWrite.Merge(m, src)
+}
+func (m *AclWrite) XXX_Size() int {
+	return xxx_messageInfo_AclWrite.Size(m)
+}
+func (m *AclWrite) XXX_DiscardUnknown() {
+	xxx_messageInfo_AclWrite.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AclWrite proto.InternalMessageInfo
+
+func (m *AclWrite) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *AclWrite) GetNewAcl() *AccessControl {
+	if m != nil {
+		return m.NewAcl
+	}
+	return nil
+}
+
+type ConfigTx struct {
+	UserId               string         `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TxId                 string         `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	ReadOldConfigVersion *Version       `protobuf:"bytes,3,opt,name=read_old_config_version,json=readOldConfigVersion,proto3" json:"read_old_config_version,omitempty"`
+	NewConfig            *ClusterConfig `protobuf:"bytes,4,opt,name=new_config,json=newConfig,proto3" json:"new_config,omitempty"`
+	// marks the config tx that promotes a standby cluster to active. It is submitted to the nodes of the standby
+	// cluster, and may replace all the consensus members at once, as the members of the primary cluster no longer take
+	// part in consensus.
+	StandbyPromotion     *StandbyPromotion `protobuf:"bytes,5,opt,name=standby_promotion,json=standbyPromotion,proto3" json:"standby_promotion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
 func (m *ConfigTx) Reset()         { *m = ConfigTx{} }
 func (m *ConfigTx) String() string { return proto.CompactTextString(m) }
 func (*ConfigTx) ProtoMessage()    {}
 func (*ConfigTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{13}
+	return fileDescriptor_8098d268f52aac08, []int{18}
 }
 
 func (m *ConfigTx) XXX_Unmarshal(b []byte) error {
@@ -933,139 +1441,806 @@ func (m *ConfigTx) GetReadOldConfigVersion() *Version {
 	return nil
 }
 
-func (m *ConfigTx) GetNewConfig() *ClusterConfig {
+func (m *ConfigTx) GetNewConfig() *ClusterConfig {
+	if m != nil {
+		return m.NewConfig
+	}
+	return nil
+}
+
+func (m *ConfigTx) GetStandbyPromotion() *StandbyPromotion {
+	if m != nil {
+		return m.StandbyPromotion
+	}
+	return nil
+}
+
+// StandbyPromotion describes the promotion of a standby cluster to active.
+type StandbyPromotion struct {
+	// the height of the ledger of every standby node at promotion; the promotion is committed in the next block.
+	LedgerHeight         uint64   `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight,proto3" json:"ledger_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StandbyPromotion) Reset()         { *m = StandbyPromotion{} }
+func (m *StandbyPromotion) String() string { return proto.CompactTextString(m) }
+func (*StandbyPromotion) ProtoMessage()    {}
+func (*StandbyPromotion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{19}
+}
+
+func (m *StandbyPromotion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StandbyPromotion.Unmarshal(m, b)
+}
+func (m *StandbyPromotion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StandbyPromotion.Marshal(b, m, deterministic)
+}
+func (m *StandbyPromotion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StandbyPromotion.Merge(m, src)
+}
+func (m *StandbyPromotion) XXX_Size() int {
+	return xxx_messageInfo_StandbyPromotion.Size(m)
+}
+func (m *StandbyPromotion) XXX_DiscardUnknown() {
+	xxx_messageInfo_StandbyPromotion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StandbyPromotion proto.InternalMessageInfo
+
+func (m *StandbyPromotion) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+type DBAdministrationTx struct {
+	UserId    string              `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TxId      string              `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	CreateDbs []string            `protobuf:"bytes,3,rep,name=create_dbs,json=createDbs,proto3" json:"create_dbs,omitempty"`
+	DeleteDbs []string            `protobuf:"bytes,4,rep,name=delete_dbs,json=deleteDbs,proto3" json:"delete_dbs,omitempty"`
+	DbsIndex  map[string]*DBIndex `protobuf:"bytes,5,rep,name=dbs_index,json=dbsIndex,proto3" json:"dbs_index,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the keys whose historical values are crypto-erased, i.e., whose erasure keys are destroyed, such that the
+	// values they held in an erasable database can no longer be read from the block store and the provenance store.
+	// The values remain in the raft log of the nodes until it is purged, i.e., until enough snapshots are taken after
+	// the values were written.
+	EraseKeys []*KeyErasure `protobuf:"bytes,6,rep,name=erase_keys,json=eraseKeys,proto3" json:"erase_keys,omitempty"`
+	// the legal holds placed on keys or databases. A key under a legal hold cannot be deleted or erased, and a
+	// database under a legal hold, or holding a key under a legal hold, cannot be deleted.
+	PlaceLegalHolds []*LegalHold `protobuf:"bytes,7,rep,name=place_legal_holds,json=placeLegalHolds,proto3" json:"place_legal_holds,omitempty"`
+	// the legal holds released. Only the database name and the key of a released hold are considered.
+	ReleaseLegalHolds []*LegalHold `protobuf:"bytes,8,rep,name=release_legal_holds,json=releaseLegalHolds,proto3" json:"release_legal_holds,omitempty"`
+	// the constraints of databases, which replace their existing constraints. A database whose entry holds no
+	// constraint has its constraints removed. Along with create_dbs and dbs_index, they let a single transaction
+	// set up the databases of an application, which are all created or none is.
+	DbsConstraints map[string]*DBConstraints `protobuf:"bytes,9,rep,name=dbs_constraints,json=dbsConstraints,proto3" json:"dbs_constraints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the databases frozen. No data transaction can write to a frozen database, nor can it be deleted, while it can
+	// still be read, until it is thawed.
+	FreezeDbs []*DatabaseFreeze `protobuf:"bytes,10,rep,name=freeze_dbs,json=freezeDbs,proto3" json:"freeze_dbs,omitempty"`
+	// the names of the databases thawed
+	ThawDbs []string `protobuf:"bytes,11,rep,name=thaw_dbs,json=thawDbs,proto3" json:"thaw_dbs,omitempty"`
+	// the migration applied or reverted by the transaction, whose steps are the other entries of the transaction
+	Migration            *Migration `protobuf:"bytes,12,opt,name=migration,proto3" json:"migration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *DBAdministrationTx) Reset()         { *m = DBAdministrationTx{} }
+func (m *DBAdministrationTx) String() string { return proto.CompactTextString(m) }
+func (*DBAdministrationTx) ProtoMessage()    {}
+func (*DBAdministrationTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{20}
+}
+
+func (m *DBAdministrationTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBAdministrationTx.Unmarshal(m, b)
+}
+func (m *DBAdministrationTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBAdministrationTx.Marshal(b, m, deterministic)
+}
+func (m *DBAdministrationTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBAdministrationTx.Merge(m, src)
+}
+func (m *DBAdministrationTx) XXX_Size() int {
+	return xxx_messageInfo_DBAdministrationTx.Size(m)
+}
+func (m *DBAdministrationTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBAdministrationTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBAdministrationTx proto.InternalMessageInfo
+
+func (m *DBAdministrationTx) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *DBAdministrationTx) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *DBAdministrationTx) GetCreateDbs() []string {
+	if m != nil {
+		return m.CreateDbs
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetDeleteDbs() []string {
+	if m != nil {
+		return m.DeleteDbs
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetDbsIndex() map[string]*DBIndex {
+	if m != nil {
+		return m.DbsIndex
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetEraseKeys() []*KeyErasure {
+	if m != nil {
+		return m.EraseKeys
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetPlaceLegalHolds() []*LegalHold {
+	if m != nil {
+		return m.PlaceLegalHolds
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetReleaseLegalHolds() []*LegalHold {
+	if m != nil {
+		return m.ReleaseLegalHolds
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetDbsConstraints() map[string]*DBConstraints {
+	if m != nil {
+		return m.DbsConstraints
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetFreezeDbs() []*DatabaseFreeze {
+	if m != nil {
+		return m.FreezeDbs
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetThawDbs() []string {
+	if m != nil {
+		return m.ThawDbs
+	}
+	return nil
+}
+
+func (m *DBAdministrationTx) GetMigration() *Migration {
+	if m != nil {
+		return m.Migration
+	}
+	return nil
+}
+
+// Migration names and versions the schema changes of the databases of an application, e.g., the databases created
+// and the indexes defined, which are made by a database administration transaction. The applied version of every
+// migration is tracked, so that an environment is brought to a target version by applying the missing versions in
+// order, and back by reverting them in reverse order.
+type Migration struct {
+	// the name of the migration, e.g., the name of the application whose databases are migrated
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the version applied, which must follow the applied version, or the version reverted, which must be the applied
+	// version
+	Version     uint64              `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Direction   Migration_Direction `protobuf:"varint,3,opt,name=direction,proto3,enum=types.Migration_Direction" json:"direction,omitempty"`
+	Description string              `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// the databases whose stored values are indexed when the transaction is committed. The transaction must define
+	// the index of each of them, and none of them may have an index already.
+	BackfillIndexDbs     []string `protobuf:"bytes,5,rep,name=backfill_index_dbs,json=backfillIndexDbs,proto3" json:"backfill_index_dbs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Migration) Reset()         { *m = Migration{} }
+func (m *Migration) String() string { return proto.CompactTextString(m) }
+func (*Migration) ProtoMessage()    {}
+func (*Migration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{21}
+}
+
+func (m *Migration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Migration.Unmarshal(m, b)
+}
+func (m *Migration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Migration.Marshal(b, m, deterministic)
+}
+func (m *Migration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Migration.Merge(m, src)
+}
+func (m *Migration) XXX_Size() int {
+	return xxx_messageInfo_Migration.Size(m)
+}
+func (m *Migration) XXX_DiscardUnknown() {
+	xxx_messageInfo_Migration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Migration proto.InternalMessageInfo
+
+func (m *Migration) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Migration) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *Migration) GetDirection() Migration_Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return Migration_UP
+}
+
+func (m *Migration) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Migration) GetBackfillIndexDbs() []string {
+	if m != nil {
+		return m.BackfillIndexDbs
+	}
+	return nil
+}
+
+// MigrationState is the applied version of a migration, along with the history of the versions applied and reverted
+type MigrationState struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the applied version, where zero means that no version is applied
+	Version              uint64           `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	History              []*MigrationStep `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *MigrationState) Reset()         { *m = MigrationState{} }
+func (m *MigrationState) String() string { return proto.CompactTextString(m) }
+func (*MigrationState) ProtoMessage()    {}
+func (*MigrationState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{22}
+}
+
+func (m *MigrationState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrationState.Unmarshal(m, b)
+}
+func (m *MigrationState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrationState.Marshal(b, m, deterministic)
+}
+func (m *MigrationState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrationState.Merge(m, src)
+}
+func (m *MigrationState) XXX_Size() int {
+	return xxx_messageInfo_MigrationState.Size(m)
+}
+func (m *MigrationState) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrationState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrationState proto.InternalMessageInfo
+
+func (m *MigrationState) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MigrationState) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *MigrationState) GetHistory() []*MigrationStep {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+// MigrationStep is a version of a migration applied or reverted by a transaction
+type MigrationStep struct {
+	Version              uint64              `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Direction            Migration_Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=types.Migration_Direction" json:"direction,omitempty"`
+	Description          string              `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	TxId                 string              `protobuf:"bytes,4,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	UserId               string              `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockNumber          uint64              `protobuf:"varint,6,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *MigrationStep) Reset()         { *m = MigrationStep{} }
+func (m *MigrationStep) String() string { return proto.CompactTextString(m) }
+func (*MigrationStep) ProtoMessage()    {}
+func (*MigrationStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{23}
+}
+
+func (m *MigrationStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigrationStep.Unmarshal(m, b)
+}
+func (m *MigrationStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigrationStep.Marshal(b, m, deterministic)
+}
+func (m *MigrationStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrationStep.Merge(m, src)
+}
+func (m *MigrationStep) XXX_Size() int {
+	return xxx_messageInfo_MigrationStep.Size(m)
+}
+func (m *MigrationStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrationStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrationStep proto.InternalMessageInfo
+
+func (m *MigrationStep) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *MigrationStep) GetDirection() Migration_Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return Migration_UP
+}
+
+func (m *MigrationStep) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *MigrationStep) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *MigrationStep) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *MigrationStep) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+// DatabaseFreeze refers to a frozen database
+type DatabaseFreeze struct {
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	// the reason of the freeze, e.g., a cutover or an investigation, which is required when the database is frozen
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// the user who froze the database, which is set when the freeze is committed
+	FrozenBy             string   `protobuf:"bytes,3,opt,name=frozen_by,json=frozenBy,proto3" json:"frozen_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatabaseFreeze) Reset()         { *m = DatabaseFreeze{} }
+func (m *DatabaseFreeze) String() string { return proto.CompactTextString(m) }
+func (*DatabaseFreeze) ProtoMessage()    {}
+func (*DatabaseFreeze) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{24}
+}
+
+func (m *DatabaseFreeze) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatabaseFreeze.Unmarshal(m, b)
+}
+func (m *DatabaseFreeze) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatabaseFreeze.Marshal(b, m, deterministic)
+}
+func (m *DatabaseFreeze) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatabaseFreeze.Merge(m, src)
+}
+func (m *DatabaseFreeze) XXX_Size() int {
+	return xxx_messageInfo_DatabaseFreeze.Size(m)
+}
+func (m *DatabaseFreeze) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatabaseFreeze.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatabaseFreeze proto.InternalMessageInfo
+
+func (m *DatabaseFreeze) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *DatabaseFreeze) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *DatabaseFreeze) GetFrozenBy() string {
+	if m != nil {
+		return m.FrozenBy
+	}
+	return ""
+}
+
+// KeyErasure refers to a key of an erasable database. The key must be deleted before it is erased.
+type KeyErasure struct {
+	DbName               string   `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyErasure) Reset()         { *m = KeyErasure{} }
+func (m *KeyErasure) String() string { return proto.CompactTextString(m) }
+func (*KeyErasure) ProtoMessage()    {}
+func (*KeyErasure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{25}
+}
+
+func (m *KeyErasure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyErasure.Unmarshal(m, b)
+}
+func (m *KeyErasure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyErasure.Marshal(b, m, deterministic)
+}
+func (m *KeyErasure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyErasure.Merge(m, src)
+}
+func (m *KeyErasure) XXX_Size() int {
+	return xxx_messageInfo_KeyErasure.Size(m)
+}
+func (m *KeyErasure) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyErasure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyErasure proto.InternalMessageInfo
+
+func (m *KeyErasure) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *KeyErasure) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+// LegalHold refers to a key of a database or, if the key is empty, to the database as a whole, which is preserved
+// until the hold is released
+type LegalHold struct {
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// the reason of the hold, e.g., the reference of the litigation, which is required when the hold is placed
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// the user who placed the hold, which is set when the hold is committed
+	PlacedBy             string   `protobuf:"bytes,4,opt,name=placed_by,json=placedBy,proto3" json:"placed_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LegalHold) Reset()         { *m = LegalHold{} }
+func (m *LegalHold) String() string { return proto.CompactTextString(m) }
+func (*LegalHold) ProtoMessage()    {}
+func (*LegalHold) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{26}
+}
+
+func (m *LegalHold) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LegalHold.Unmarshal(m, b)
+}
+func (m *LegalHold) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LegalHold.Marshal(b, m, deterministic)
+}
+func (m *LegalHold) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LegalHold.Merge(m, src)
+}
+func (m *LegalHold) XXX_Size() int {
+	return xxx_messageInfo_LegalHold.Size(m)
+}
+func (m *LegalHold) XXX_DiscardUnknown() {
+	xxx_messageInfo_LegalHold.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LegalHold proto.InternalMessageInfo
+
+func (m *LegalHold) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *LegalHold) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LegalHold) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *LegalHold) GetPlacedBy() string {
+	if m != nil {
+		return m.PlacedBy
+	}
+	return ""
+}
+
+type DBIndex struct {
+	AttributeAndType     map[string]IndexAttributeType `protobuf:"bytes,1,rep,name=attribute_and_type,json=attributeAndType,proto3" json:"attribute_and_type,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=types.IndexAttributeType"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *DBIndex) Reset()         { *m = DBIndex{} }
+func (m *DBIndex) String() string { return proto.CompactTextString(m) }
+func (*DBIndex) ProtoMessage()    {}
+func (*DBIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{27}
+}
+
+func (m *DBIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBIndex.Unmarshal(m, b)
+}
+func (m *DBIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBIndex.Marshal(b, m, deterministic)
+}
+func (m *DBIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBIndex.Merge(m, src)
+}
+func (m *DBIndex) XXX_Size() int {
+	return xxx_messageInfo_DBIndex.Size(m)
+}
+func (m *DBIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBIndex proto.InternalMessageInfo
+
+func (m *DBIndex) GetAttributeAndType() map[string]IndexAttributeType {
 	if m != nil {
-		return m.NewConfig
+		return m.AttributeAndType
 	}
 	return nil
 }
 
-type DBAdministrationTx struct {
-	UserId               string              `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TxId                 string              `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	CreateDbs            []string            `protobuf:"bytes,3,rep,name=create_dbs,json=createDbs,proto3" json:"create_dbs,omitempty"`
-	DeleteDbs            []string            `protobuf:"bytes,4,rep,name=delete_dbs,json=deleteDbs,proto3" json:"delete_dbs,omitempty"`
-	DbsIndex             map[string]*DBIndex `protobuf:"bytes,5,rep,name=dbs_index,json=dbsIndex,proto3" json:"dbs_index,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+// DBConstraints holds the constraints on the JSON documents written to a database
+type DBConstraints struct {
+	References []*ReferenceConstraint `protobuf:"bytes,1,rep,name=references,proto3" json:"references,omitempty"`
+	// the schema of the JSON documents written to the database
+	Schema *DocumentSchema `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	// the access control of the keys written to the database without one
+	DefaultAcl           *AccessControl `protobuf:"bytes,3,opt,name=default_acl,json=defaultAcl,proto3" json:"default_acl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *DBAdministrationTx) Reset()         { *m = DBAdministrationTx{} }
-func (m *DBAdministrationTx) String() string { return proto.CompactTextString(m) }
-func (*DBAdministrationTx) ProtoMessage()    {}
-func (*DBAdministrationTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{14}
+func (m *DBConstraints) Reset()         { *m = DBConstraints{} }
+func (m *DBConstraints) String() string { return proto.CompactTextString(m) }
+func (*DBConstraints) ProtoMessage()    {}
+func (*DBConstraints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{28}
 }
 
-func (m *DBAdministrationTx) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DBAdministrationTx.Unmarshal(m, b)
+func (m *DBConstraints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBConstraints.Unmarshal(m, b)
 }
-func (m *DBAdministrationTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DBAdministrationTx.Marshal(b, m, deterministic)
+func (m *DBConstraints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBConstraints.Marshal(b, m, deterministic)
 }
-func (m *DBAdministrationTx) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DBAdministrationTx.Merge(m, src)
+func (m *DBConstraints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBConstraints.Merge(m, src)
 }
-func (m *DBAdministrationTx) XXX_Size() int {
-	return xxx_messageInfo_DBAdministrationTx.Size(m)
+func (m *DBConstraints) XXX_Size() int {
+	return xxx_messageInfo_DBConstraints.Size(m)
 }
-func (m *DBAdministrationTx) XXX_DiscardUnknown() {
-	xxx_messageInfo_DBAdministrationTx.DiscardUnknown(m)
+func (m *DBConstraints) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBConstraints.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DBAdministrationTx proto.InternalMessageInfo
+var xxx_messageInfo_DBConstraints proto.InternalMessageInfo
 
-func (m *DBAdministrationTx) GetUserId() string {
+func (m *DBConstraints) GetReferences() []*ReferenceConstraint {
 	if m != nil {
-		return m.UserId
+		return m.References
 	}
-	return ""
+	return nil
 }
 
-func (m *DBAdministrationTx) GetTxId() string {
+func (m *DBConstraints) GetSchema() *DocumentSchema {
 	if m != nil {
-		return m.TxId
+		return m.Schema
 	}
-	return ""
+	return nil
 }
 
-func (m *DBAdministrationTx) GetCreateDbs() []string {
+func (m *DBConstraints) GetDefaultAcl() *AccessControl {
 	if m != nil {
-		return m.CreateDbs
+		return m.DefaultAcl
 	}
 	return nil
 }
 
-func (m *DBAdministrationTx) GetDeleteDbs() []string {
+// DocumentSchema requires that the values written to a database are JSON objects whose top-level attributes have
+// the declared types, when they are present and not null, and whose required attributes are present and not null
+type DocumentSchema struct {
+	AttributeAndType     map[string]IndexAttributeType `protobuf:"bytes,1,rep,name=attribute_and_type,json=attributeAndType,proto3" json:"attribute_and_type,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=types.IndexAttributeType"`
+	RequiredAttributes   []string                      `protobuf:"bytes,2,rep,name=required_attributes,json=requiredAttributes,proto3" json:"required_attributes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *DocumentSchema) Reset()         { *m = DocumentSchema{} }
+func (m *DocumentSchema) String() string { return proto.CompactTextString(m) }
+func (*DocumentSchema) ProtoMessage()    {}
+func (*DocumentSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{29}
+}
+
+func (m *DocumentSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DocumentSchema.Unmarshal(m, b)
+}
+func (m *DocumentSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DocumentSchema.Marshal(b, m, deterministic)
+}
+func (m *DocumentSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DocumentSchema.Merge(m, src)
+}
+func (m *DocumentSchema) XXX_Size() int {
+	return xxx_messageInfo_DocumentSchema.Size(m)
+}
+func (m *DocumentSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_DocumentSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DocumentSchema proto.InternalMessageInfo
+
+func (m *DocumentSchema) GetAttributeAndType() map[string]IndexAttributeType {
 	if m != nil {
-		return m.DeleteDbs
+		return m.AttributeAndType
 	}
 	return nil
 }
 
-func (m *DBAdministrationTx) GetDbsIndex() map[string]*DBIndex {
+func (m *DocumentSchema) GetRequiredAttributes() []string {
 	if m != nil {
-		return m.DbsIndex
+		return m.RequiredAttributes
 	}
 	return nil
 }
 
-type DBIndex struct {
-	AttributeAndType     map[string]IndexAttributeType `protobuf:"bytes,1,rep,name=attribute_and_type,json=attributeAndType,proto3" json:"attribute_and_type,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=types.IndexAttributeType"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+// ReferenceConstraint requires that a top-level attribute of the JSON documents written to a database, when it is
+// present and not null, is a string that names an existing key of the referenced database
+type ReferenceConstraint struct {
+	Attribute            string   `protobuf:"bytes,1,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	ReferencedDb         string   `protobuf:"bytes,2,opt,name=referenced_db,json=referencedDb,proto3" json:"referenced_db,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DBIndex) Reset()         { *m = DBIndex{} }
-func (m *DBIndex) String() string { return proto.CompactTextString(m) }
-func (*DBIndex) ProtoMessage()    {}
-func (*DBIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{15}
+func (m *ReferenceConstraint) Reset()         { *m = ReferenceConstraint{} }
+func (m *ReferenceConstraint) String() string { return proto.CompactTextString(m) }
+func (*ReferenceConstraint) ProtoMessage()    {}
+func (*ReferenceConstraint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{30}
 }
 
-func (m *DBIndex) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DBIndex.Unmarshal(m, b)
+func (m *ReferenceConstraint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReferenceConstraint.Unmarshal(m, b)
 }
-func (m *DBIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DBIndex.Marshal(b, m, deterministic)
+func (m *ReferenceConstraint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReferenceConstraint.Marshal(b, m, deterministic)
 }
-func (m *DBIndex) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DBIndex.Merge(m, src)
+func (m *ReferenceConstraint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReferenceConstraint.Merge(m, src)
 }
-func (m *DBIndex) XXX_Size() int {
-	return xxx_messageInfo_DBIndex.Size(m)
+func (m *ReferenceConstraint) XXX_Size() int {
+	return xxx_messageInfo_ReferenceConstraint.Size(m)
 }
-func (m *DBIndex) XXX_DiscardUnknown() {
-	xxx_messageInfo_DBIndex.DiscardUnknown(m)
+func (m *ReferenceConstraint) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReferenceConstraint.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DBIndex proto.InternalMessageInfo
+var xxx_messageInfo_ReferenceConstraint proto.InternalMessageInfo
 
-func (m *DBIndex) GetAttributeAndType() map[string]IndexAttributeType {
+func (m *ReferenceConstraint) GetAttribute() string {
 	if m != nil {
-		return m.AttributeAndType
+		return m.Attribute
 	}
-	return nil
+	return ""
+}
+
+func (m *ReferenceConstraint) GetReferencedDb() string {
+	if m != nil {
+		return m.ReferencedDb
+	}
+	return ""
 }
 
 type UserAdministrationTx struct {
-	UserId               string        `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TxId                 string        `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
-	UserReads            []*UserRead   `protobuf:"bytes,3,rep,name=user_reads,json=userReads,proto3" json:"user_reads,omitempty"`
-	UserWrites           []*UserWrite  `protobuf:"bytes,4,rep,name=user_writes,json=userWrites,proto3" json:"user_writes,omitempty"`
-	UserDeletes          []*UserDelete `protobuf:"bytes,5,rep,name=user_deletes,json=userDeletes,proto3" json:"user_deletes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	UserId      string        `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TxId        string        `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	UserReads   []*UserRead   `protobuf:"bytes,3,rep,name=user_reads,json=userReads,proto3" json:"user_reads,omitempty"`
+	UserWrites  []*UserWrite  `protobuf:"bytes,4,rep,name=user_writes,json=userWrites,proto3" json:"user_writes,omitempty"`
+	UserDeletes []*UserDelete `protobuf:"bytes,5,rep,name=user_deletes,json=userDeletes,proto3" json:"user_deletes,omitempty"`
+	// certificate_renewal replaces the certificate of the submitting user. A transaction that renews a certificate
+	// carries no user reads, writes, or deletes, and does not require administrative privilege.
+	CertificateRenewal   *UserCertificateRenewal `protobuf:"bytes,6,opt,name=certificate_renewal,json=certificateRenewal,proto3" json:"certificate_renewal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
 func (m *UserAdministrationTx) Reset()         { *m = UserAdministrationTx{} }
 func (m *UserAdministrationTx) String() string { return proto.CompactTextString(m) }
 func (*UserAdministrationTx) ProtoMessage()    {}
 func (*UserAdministrationTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{16}
+	return fileDescriptor_8098d268f52aac08, []int{31}
 }
 
 func (m *UserAdministrationTx) XXX_Unmarshal(b []byte) error {
@@ -1121,6 +2296,52 @@ func (m *UserAdministrationTx) GetUserDeletes() []*UserDelete {
 	return nil
 }
 
+func (m *UserAdministrationTx) GetCertificateRenewal() *UserCertificateRenewal {
+	if m != nil {
+		return m.CertificateRenewal
+	}
+	return nil
+}
+
+type UserCertificateRenewal struct {
+	Certificate          []byte   `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserCertificateRenewal) Reset()         { *m = UserCertificateRenewal{} }
+func (m *UserCertificateRenewal) String() string { return proto.CompactTextString(m) }
+func (*UserCertificateRenewal) ProtoMessage()    {}
+func (*UserCertificateRenewal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{32}
+}
+
+func (m *UserCertificateRenewal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserCertificateRenewal.Unmarshal(m, b)
+}
+func (m *UserCertificateRenewal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserCertificateRenewal.Marshal(b, m, deterministic)
+}
+func (m *UserCertificateRenewal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserCertificateRenewal.Merge(m, src)
+}
+func (m *UserCertificateRenewal) XXX_Size() int {
+	return xxx_messageInfo_UserCertificateRenewal.Size(m)
+}
+func (m *UserCertificateRenewal) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserCertificateRenewal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserCertificateRenewal proto.InternalMessageInfo
+
+func (m *UserCertificateRenewal) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
 type UserRead struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Version              *Version `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
@@ -1133,7 +2354,7 @@ func (m *UserRead) Reset()         { *m = UserRead{} }
 func (m *UserRead) String() string { return proto.CompactTextString(m) }
 func (*UserRead) ProtoMessage()    {}
 func (*UserRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{17}
+	return fileDescriptor_8098d268f52aac08, []int{33}
 }
 
 func (m *UserRead) XXX_Unmarshal(b []byte) error {
@@ -1180,7 +2401,7 @@ func (m *UserWrite) Reset()         { *m = UserWrite{} }
 func (m *UserWrite) String() string { return proto.CompactTextString(m) }
 func (*UserWrite) ProtoMessage()    {}
 func (*UserWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{18}
+	return fileDescriptor_8098d268f52aac08, []int{34}
 }
 
 func (m *UserWrite) XXX_Unmarshal(b []byte) error {
@@ -1226,7 +2447,7 @@ func (m *UserDelete) Reset()         { *m = UserDelete{} }
 func (m *UserDelete) String() string { return proto.CompactTextString(m) }
 func (*UserDelete) ProtoMessage()    {}
 func (*UserDelete) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{19}
+	return fileDescriptor_8098d268f52aac08, []int{35}
 }
 
 func (m *UserDelete) XXX_Unmarshal(b []byte) error {
@@ -1266,7 +2487,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{20}
+	return fileDescriptor_8098d268f52aac08, []int{36}
 }
 
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
@@ -1313,7 +2534,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{21}
+	return fileDescriptor_8098d268f52aac08, []int{37}
 }
 
 func (m *Version) XXX_Unmarshal(b []byte) error {
@@ -1349,19 +2570,24 @@ func (m *Version) GetTxNum() uint64 {
 }
 
 type AccessControl struct {
-	ReadUsers            map[string]bool          `protobuf:"bytes,1,rep,name=read_users,json=readUsers,proto3" json:"read_users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	ReadWriteUsers       map[string]bool          `protobuf:"bytes,2,rep,name=read_write_users,json=readWriteUsers,proto3" json:"read_write_users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	SignPolicyForWrite   AccessControlWritePolicy `protobuf:"varint,3,opt,name=sign_policy_for_write,json=signPolicyForWrite,proto3,enum=types.AccessControlWritePolicy" json:"sign_policy_for_write,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
-	XXX_unrecognized     []byte                   `json:"-"`
-	XXX_sizecache        int32                    `json:"-"`
+	ReadUsers          map[string]bool          `protobuf:"bytes,1,rep,name=read_users,json=readUsers,proto3" json:"read_users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ReadWriteUsers     map[string]bool          `protobuf:"bytes,2,rep,name=read_write_users,json=readWriteUsers,proto3" json:"read_write_users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	SignPolicyForWrite AccessControlWritePolicy `protobuf:"varint,3,opt,name=sign_policy_for_write,json=signPolicyForWrite,proto3,enum=types.AccessControlWritePolicy" json:"sign_policy_for_write,omitempty"`
+	// the number of read_write_users that must sign a write or a delete when the policy is THRESHOLD
+	SignThresholdForWrite uint32 `protobuf:"varint,4,opt,name=sign_threshold_for_write,json=signThresholdForWrite,proto3" json:"sign_threshold_for_write,omitempty"`
+	// redacted_read_users can read the value, which must be a JSON object, only with the fields of their redaction
+	// policy stripped or hashed. A user who is also listed in read_users or read_write_users reads the value in full.
+	RedactedReadUsers    map[string]*RedactionPolicy `protobuf:"bytes,5,rep,name=redacted_read_users,json=redactedReadUsers,proto3" json:"redacted_read_users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
 func (m *AccessControl) Reset()         { *m = AccessControl{} }
 func (m *AccessControl) String() string { return proto.CompactTextString(m) }
 func (*AccessControl) ProtoMessage()    {}
 func (*AccessControl) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{22}
+	return fileDescriptor_8098d268f52aac08, []int{38}
 }
 
 func (m *AccessControl) XXX_Unmarshal(b []byte) error {
@@ -1403,20 +2629,88 @@ func (m *AccessControl) GetSignPolicyForWrite() AccessControlWritePolicy {
 	return AccessControl_ANY
 }
 
+func (m *AccessControl) GetSignThresholdForWrite() uint32 {
+	if m != nil {
+		return m.SignThresholdForWrite
+	}
+	return 0
+}
+
+func (m *AccessControl) GetRedactedReadUsers() map[string]*RedactionPolicy {
+	if m != nil {
+		return m.RedactedReadUsers
+	}
+	return nil
+}
+
+// RedactionPolicy lists the fields of a JSON object that are redacted when it is read. A field is denoted by the
+// path of member names that leads to it, separated by '.', e.g., address.street.
+type RedactionPolicy struct {
+	// the fields removed from the value
+	StrippedFields []string `protobuf:"bytes,1,rep,name=stripped_fields,json=strippedFields,proto3" json:"stripped_fields,omitempty"`
+	// the fields whose value is replaced by the hex-encoded SHA-256 hash of its JSON encoding, in which the members of
+	// an object are sorted by name, so that equal values can be matched without being disclosed
+	HashedFields         []string `protobuf:"bytes,2,rep,name=hashed_fields,json=hashedFields,proto3" json:"hashed_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedactionPolicy) Reset()         { *m = RedactionPolicy{} }
+func (m *RedactionPolicy) String() string { return proto.CompactTextString(m) }
+func (*RedactionPolicy) ProtoMessage()    {}
+func (*RedactionPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{39}
+}
+
+func (m *RedactionPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedactionPolicy.Unmarshal(m, b)
+}
+func (m *RedactionPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedactionPolicy.Marshal(b, m, deterministic)
+}
+func (m *RedactionPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedactionPolicy.Merge(m, src)
+}
+func (m *RedactionPolicy) XXX_Size() int {
+	return xxx_messageInfo_RedactionPolicy.Size(m)
+}
+func (m *RedactionPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedactionPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedactionPolicy proto.InternalMessageInfo
+
+func (m *RedactionPolicy) GetStrippedFields() []string {
+	if m != nil {
+		return m.StrippedFields
+	}
+	return nil
+}
+
+func (m *RedactionPolicy) GetHashedFields() []string {
+	if m != nil {
+		return m.HashedFields
+	}
+	return nil
+}
+
 type KVWithMetadata struct {
-	Key                  string    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                []byte    `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Metadata             *Metadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Key      string    `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value    []byte    `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Metadata *Metadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// set in place of the value when the value is a blob held by the blob store
+	BlobManifest         *BlobManifest `protobuf:"bytes,4,opt,name=blob_manifest,json=blobManifest,proto3" json:"blob_manifest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *KVWithMetadata) Reset()         { *m = KVWithMetadata{} }
 func (m *KVWithMetadata) String() string { return proto.CompactTextString(m) }
 func (*KVWithMetadata) ProtoMessage()    {}
 func (*KVWithMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{23}
+	return fileDescriptor_8098d268f52aac08, []int{40}
 }
 
 func (m *KVWithMetadata) XXX_Unmarshal(b []byte) error {
@@ -1458,49 +2752,193 @@ func (m *KVWithMetadata) GetMetadata() *Metadata {
 	return nil
 }
 
+func (m *KVWithMetadata) GetBlobManifest() *BlobManifest {
+	if m != nil {
+		return m.BlobManifest
+	}
+	return nil
+}
+
 type ValueWithMetadata struct {
-	Value                []byte    `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Metadata             *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Value    []byte    `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Metadata *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// when set, the value is stored as content-addressed chunks in the blob store and the value field is empty
+	BlobManifest *BlobManifest `protobuf:"bytes,3,opt,name=blob_manifest,json=blobManifest,proto3" json:"blob_manifest,omitempty"`
+	// when set, the value is encrypted in the world state with a key of the database and the value field is empty
+	EncryptedValue       *EncryptedValue `protobuf:"bytes,4,opt,name=encrypted_value,json=encryptedValue,proto3" json:"encrypted_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
 func (m *ValueWithMetadata) Reset()         { *m = ValueWithMetadata{} }
 func (m *ValueWithMetadata) String() string { return proto.CompactTextString(m) }
 func (*ValueWithMetadata) ProtoMessage()    {}
 func (*ValueWithMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{24}
+	return fileDescriptor_8098d268f52aac08, []int{41}
+}
+
+func (m *ValueWithMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValueWithMetadata.Unmarshal(m, b)
+}
+func (m *ValueWithMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValueWithMetadata.Marshal(b, m, deterministic)
+}
+func (m *ValueWithMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValueWithMetadata.Merge(m, src)
+}
+func (m *ValueWithMetadata) XXX_Size() int {
+	return xxx_messageInfo_ValueWithMetadata.Size(m)
+}
+func (m *ValueWithMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValueWithMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValueWithMetadata proto.InternalMessageInfo
+
+func (m *ValueWithMetadata) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ValueWithMetadata) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ValueWithMetadata) GetBlobManifest() *BlobManifest {
+	if m != nil {
+		return m.BlobManifest
+	}
+	return nil
+}
+
+func (m *ValueWithMetadata) GetEncryptedValue() *EncryptedValue {
+	if m != nil {
+		return m.EncryptedValue
+	}
+	return nil
+}
+
+// EncryptedValue holds a value encrypted with AES-256-GCM by a key of its database. The key is referenced by its ID,
+// so that the values encrypted with a previous key of the database can be read until they are re-encrypted. A value
+// of an erasable database is sealed the same way by the erasure key of its key.
+type EncryptedValue struct {
+	KeyId                string   `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Nonce                []byte   `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Ciphertext           []byte   `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EncryptedValue) Reset()         { *m = EncryptedValue{} }
+func (m *EncryptedValue) String() string { return proto.CompactTextString(m) }
+func (*EncryptedValue) ProtoMessage()    {}
+func (*EncryptedValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{42}
+}
+
+func (m *EncryptedValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EncryptedValue.Unmarshal(m, b)
+}
+func (m *EncryptedValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EncryptedValue.Marshal(b, m, deterministic)
+}
+func (m *EncryptedValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EncryptedValue.Merge(m, src)
+}
+func (m *EncryptedValue) XXX_Size() int {
+	return xxx_messageInfo_EncryptedValue.Size(m)
+}
+func (m *EncryptedValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_EncryptedValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EncryptedValue proto.InternalMessageInfo
+
+func (m *EncryptedValue) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *EncryptedValue) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *EncryptedValue) GetCiphertext() []byte {
+	if m != nil {
+		return m.Ciphertext
+	}
+	return nil
+}
+
+// BlobManifest describes a large value that is stored as a sequence of chunks, each addressed by its SHA-256 hash.
+// A data write carries the manifest of a blob uploaded beforehand, in place of the value. The state trie holds the
+// marshaled manifest in place of the value, hence a proof of such a value covers the manifest.
+type BlobManifest struct {
+	// the size of the value in bytes
+	Size uint64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// the size of every chunk but the last one
+	ChunkSize uint64 `protobuf:"varint,2,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// the SHA-256 hashes of the chunks, in the order they form the value
+	ChunkHashes          [][]byte `protobuf:"bytes,3,rep,name=chunk_hashes,json=chunkHashes,proto3" json:"chunk_hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ValueWithMetadata) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ValueWithMetadata.Unmarshal(m, b)
+func (m *BlobManifest) Reset()         { *m = BlobManifest{} }
+func (m *BlobManifest) String() string { return proto.CompactTextString(m) }
+func (*BlobManifest) ProtoMessage()    {}
+func (*BlobManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{43}
 }
-func (m *ValueWithMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ValueWithMetadata.Marshal(b, m, deterministic)
+
+func (m *BlobManifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlobManifest.Unmarshal(m, b)
 }
-func (m *ValueWithMetadata) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ValueWithMetadata.Merge(m, src)
+func (m *BlobManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlobManifest.Marshal(b, m, deterministic)
 }
-func (m *ValueWithMetadata) XXX_Size() int {
-	return xxx_messageInfo_ValueWithMetadata.Size(m)
+func (m *BlobManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlobManifest.Merge(m, src)
 }
-func (m *ValueWithMetadata) XXX_DiscardUnknown() {
-	xxx_messageInfo_ValueWithMetadata.DiscardUnknown(m)
+func (m *BlobManifest) XXX_Size() int {
+	return xxx_messageInfo_BlobManifest.Size(m)
+}
+func (m *BlobManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlobManifest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ValueWithMetadata proto.InternalMessageInfo
+var xxx_messageInfo_BlobManifest proto.InternalMessageInfo
 
-func (m *ValueWithMetadata) GetValue() []byte {
+func (m *BlobManifest) GetSize() uint64 {
 	if m != nil {
-		return m.Value
+		return m.Size
 	}
-	return nil
+	return 0
 }
 
-func (m *ValueWithMetadata) GetMetadata() *Metadata {
+func (m *BlobManifest) GetChunkSize() uint64 {
 	if m != nil {
-		return m.Metadata
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *BlobManifest) GetChunkHashes() [][]byte {
+	if m != nil {
+		return m.ChunkHashes
 	}
 	return nil
 }
@@ -1519,7 +2957,7 @@ func (m *Digest) Reset()         { *m = Digest{} }
 func (m *Digest) String() string { return proto.CompactTextString(m) }
 func (*Digest) ProtoMessage()    {}
 func (*Digest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{25}
+	return fileDescriptor_8098d268f52aac08, []int{44}
 }
 
 func (m *Digest) XXX_Unmarshal(b []byte) error {
@@ -1555,18 +2993,20 @@ func (m *Digest) GetHeight() uint64 {
 }
 
 type ValidationInfo struct {
-	Flag                 Flag     `protobuf:"varint,1,opt,name=flag,proto3,enum=types.Flag" json:"flag,omitempty"`
-	ReasonIfInvalid      string   `protobuf:"bytes,2,opt,name=reason_if_invalid,json=reasonIfInvalid,proto3" json:"reason_if_invalid,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Flag            Flag   `protobuf:"varint,1,opt,name=flag,proto3,enum=types.Flag" json:"flag,omitempty"`
+	ReasonIfInvalid string `protobuf:"bytes,2,opt,name=reason_if_invalid,json=reasonIfInvalid,proto3" json:"reason_if_invalid,omitempty"`
+	// failed_operation details the database operation that invalidated a data transaction, if any
+	FailedOperation      *DBOperationFailure `protobuf:"bytes,3,opt,name=failed_operation,json=failedOperation,proto3" json:"failed_operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *ValidationInfo) Reset()         { *m = ValidationInfo{} }
 func (m *ValidationInfo) String() string { return proto.CompactTextString(m) }
 func (*ValidationInfo) ProtoMessage()    {}
 func (*ValidationInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{26}
+	return fileDescriptor_8098d268f52aac08, []int{45}
 }
 
 func (m *ValidationInfo) XXX_Unmarshal(b []byte) error {
@@ -1601,6 +3041,72 @@ func (m *ValidationInfo) GetReasonIfInvalid() string {
 	return ""
 }
 
+func (m *ValidationInfo) GetFailedOperation() *DBOperationFailure {
+	if m != nil {
+		return m.FailedOperation
+	}
+	return nil
+}
+
+// DBOperationFailure details why a database operation of a data transaction is invalid
+type DBOperationFailure struct {
+	// db_name is the database of the failed operation
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	// key is the key at fault, if the failure is specific to a key
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// check is the validation check that failed
+	Check                DBOperationCheck `protobuf:"varint,3,opt,name=check,proto3,enum=types.DBOperationCheck" json:"check,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DBOperationFailure) Reset()         { *m = DBOperationFailure{} }
+func (m *DBOperationFailure) String() string { return proto.CompactTextString(m) }
+func (*DBOperationFailure) ProtoMessage()    {}
+func (*DBOperationFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{46}
+}
+
+func (m *DBOperationFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBOperationFailure.Unmarshal(m, b)
+}
+func (m *DBOperationFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBOperationFailure.Marshal(b, m, deterministic)
+}
+func (m *DBOperationFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBOperationFailure.Merge(m, src)
+}
+func (m *DBOperationFailure) XXX_Size() int {
+	return xxx_messageInfo_DBOperationFailure.Size(m)
+}
+func (m *DBOperationFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBOperationFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBOperationFailure proto.InternalMessageInfo
+
+func (m *DBOperationFailure) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *DBOperationFailure) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *DBOperationFailure) GetCheck() DBOperationCheck {
+	if m != nil {
+		return m.Check
+	}
+	return DBOperationCheck_NO_CHECK
+}
+
 type TxProof struct {
 	Header               *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Path                 [][]byte     `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
@@ -1613,7 +3119,7 @@ func (m *TxProof) Reset()         { *m = TxProof{} }
 func (m *TxProof) String() string { return proto.CompactTextString(m) }
 func (*TxProof) ProtoMessage()    {}
 func (*TxProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{27}
+	return fileDescriptor_8098d268f52aac08, []int{47}
 }
 
 func (m *TxProof) XXX_Unmarshal(b []byte) error {
@@ -1660,7 +3166,7 @@ func (m *BlockProof) Reset()         { *m = BlockProof{} }
 func (m *BlockProof) String() string { return proto.CompactTextString(m) }
 func (*BlockProof) ProtoMessage()    {}
 func (*BlockProof) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{28}
+	return fileDescriptor_8098d268f52aac08, []int{48}
 }
 
 func (m *BlockProof) XXX_Unmarshal(b []byte) error {
@@ -1696,18 +3202,20 @@ func (m *BlockProof) GetPath() []*BlockHeader {
 }
 
 type TxReceipt struct {
-	Header               *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	TxIndex              uint64       `protobuf:"varint,2,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Header  *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	TxIndex uint64       `protobuf:"varint,2,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	// The annotations of a data transaction, if any
+	Annotations          map[string]string `protobuf:"bytes,3,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *TxReceipt) Reset()         { *m = TxReceipt{} }
 func (m *TxReceipt) String() string { return proto.CompactTextString(m) }
 func (*TxReceipt) ProtoMessage()    {}
 func (*TxReceipt) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{29}
+	return fileDescriptor_8098d268f52aac08, []int{49}
 }
 
 func (m *TxReceipt) XXX_Unmarshal(b []byte) error {
@@ -1742,6 +3250,326 @@ func (m *TxReceipt) GetTxIndex() uint64 {
 	return 0
 }
 
+func (m *TxReceipt) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+// TxResourceUsage is the accounting of the resources consumed by a valid data transaction, computed when the block
+// that holds the transaction is committed. It serves to meter the usage per user and per database.
+type TxResourceUsage struct {
+	TxId     string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	BlockNum uint64 `protobuf:"varint,2,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxIndex  uint64 `protobuf:"varint,3,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	// The users that signed the transaction
+	UserIds []string `protobuf:"bytes,4,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	// The usage of each database operated upon by the transaction, in the order of the operations
+	DbUsages             []*DBResourceUsage `protobuf:"bytes,5,rep,name=db_usages,json=dbUsages,proto3" json:"db_usages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *TxResourceUsage) Reset()         { *m = TxResourceUsage{} }
+func (m *TxResourceUsage) String() string { return proto.CompactTextString(m) }
+func (*TxResourceUsage) ProtoMessage()    {}
+func (*TxResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{50}
+}
+
+func (m *TxResourceUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxResourceUsage.Unmarshal(m, b)
+}
+func (m *TxResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxResourceUsage.Marshal(b, m, deterministic)
+}
+func (m *TxResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxResourceUsage.Merge(m, src)
+}
+func (m *TxResourceUsage) XXX_Size() int {
+	return xxx_messageInfo_TxResourceUsage.Size(m)
+}
+func (m *TxResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxResourceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxResourceUsage proto.InternalMessageInfo
+
+func (m *TxResourceUsage) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TxResourceUsage) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *TxResourceUsage) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *TxResourceUsage) GetUserIds() []string {
+	if m != nil {
+		return m.UserIds
+	}
+	return nil
+}
+
+func (m *TxResourceUsage) GetDbUsages() []*DBResourceUsage {
+	if m != nil {
+		return m.DbUsages
+	}
+	return nil
+}
+
+type DBResourceUsage struct {
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	// The total size of the keys and values written, including the written ACLs
+	BytesWritten uint64 `protobuf:"varint,2,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	KeysRead     uint64 `protobuf:"varint,3,opt,name=keys_read,json=keysRead,proto3" json:"keys_read,omitempty"`
+	KeysWritten  uint64 `protobuf:"varint,4,opt,name=keys_written,json=keysWritten,proto3" json:"keys_written,omitempty"`
+	KeysDeleted  uint64 `protobuf:"varint,5,opt,name=keys_deleted,json=keysDeleted,proto3" json:"keys_deleted,omitempty"`
+	// The number of index entries generated for the written values, if the database is indexed
+	IndexEntries         uint64   `protobuf:"varint,6,opt,name=index_entries,json=indexEntries,proto3" json:"index_entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DBResourceUsage) Reset()         { *m = DBResourceUsage{} }
+func (m *DBResourceUsage) String() string { return proto.CompactTextString(m) }
+func (*DBResourceUsage) ProtoMessage()    {}
+func (*DBResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{51}
+}
+
+func (m *DBResourceUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBResourceUsage.Unmarshal(m, b)
+}
+func (m *DBResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBResourceUsage.Marshal(b, m, deterministic)
+}
+func (m *DBResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBResourceUsage.Merge(m, src)
+}
+func (m *DBResourceUsage) XXX_Size() int {
+	return xxx_messageInfo_DBResourceUsage.Size(m)
+}
+func (m *DBResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBResourceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBResourceUsage proto.InternalMessageInfo
+
+func (m *DBResourceUsage) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *DBResourceUsage) GetBytesWritten() uint64 {
+	if m != nil {
+		return m.BytesWritten
+	}
+	return 0
+}
+
+func (m *DBResourceUsage) GetKeysRead() uint64 {
+	if m != nil {
+		return m.KeysRead
+	}
+	return 0
+}
+
+func (m *DBResourceUsage) GetKeysWritten() uint64 {
+	if m != nil {
+		return m.KeysWritten
+	}
+	return 0
+}
+
+func (m *DBResourceUsage) GetKeysDeleted() uint64 {
+	if m != nil {
+		return m.KeysDeleted
+	}
+	return 0
+}
+
+func (m *DBResourceUsage) GetIndexEntries() uint64 {
+	if m != nil {
+		return m.IndexEntries
+	}
+	return 0
+}
+
+// DBUsage is the usage of a data database with a quota, as accounted by the committer up to a block
+type DBUsage struct {
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	// The number of the last block accounted
+	BlockNum uint64 `protobuf:"varint,2,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	// The total size of the keys and values stored in the database
+	StorageBytes uint64 `protobuf:"varint,3,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes,omitempty"`
+	// The start of the minute of the last block accounted, in nanoseconds since the Unix epoch
+	MinuteStart int64 `protobuf:"varint,4,opt,name=minute_start,json=minuteStart,proto3" json:"minute_start,omitempty"`
+	// The number of valid transactions that operated on the database in that minute
+	MinuteTxs            uint64   `protobuf:"varint,5,opt,name=minute_txs,json=minuteTxs,proto3" json:"minute_txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DBUsage) Reset()         { *m = DBUsage{} }
+func (m *DBUsage) String() string { return proto.CompactTextString(m) }
+func (*DBUsage) ProtoMessage()    {}
+func (*DBUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{52}
+}
+
+func (m *DBUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBUsage.Unmarshal(m, b)
+}
+func (m *DBUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBUsage.Marshal(b, m, deterministic)
+}
+func (m *DBUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBUsage.Merge(m, src)
+}
+func (m *DBUsage) XXX_Size() int {
+	return xxx_messageInfo_DBUsage.Size(m)
+}
+func (m *DBUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBUsage proto.InternalMessageInfo
+
+func (m *DBUsage) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *DBUsage) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *DBUsage) GetStorageBytes() uint64 {
+	if m != nil {
+		return m.StorageBytes
+	}
+	return 0
+}
+
+func (m *DBUsage) GetMinuteStart() int64 {
+	if m != nil {
+		return m.MinuteStart
+	}
+	return 0
+}
+
+func (m *DBUsage) GetMinuteTxs() uint64 {
+	if m != nil {
+		return m.MinuteTxs
+	}
+	return 0
+}
+
+// QuotaAlert warns that a block made the usage of a database reach a soft limit of its quota
+type QuotaAlert struct {
+	DbName   string              `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	BlockNum uint64              `protobuf:"varint,2,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	Resource QuotaAlert_Resource `protobuf:"varint,3,opt,name=resource,proto3,enum=types.QuotaAlert_Resource" json:"resource,omitempty"`
+	// The usage after the block, i.e., the bytes stored or the transactions in the minute of the block
+	Usage                uint64   `protobuf:"varint,4,opt,name=usage,proto3" json:"usage,omitempty"`
+	SoftLimit            uint64   `protobuf:"varint,5,opt,name=soft_limit,json=softLimit,proto3" json:"soft_limit,omitempty"`
+	HardLimit            uint64   `protobuf:"varint,6,opt,name=hard_limit,json=hardLimit,proto3" json:"hard_limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuotaAlert) Reset()         { *m = QuotaAlert{} }
+func (m *QuotaAlert) String() string { return proto.CompactTextString(m) }
+func (*QuotaAlert) ProtoMessage()    {}
+func (*QuotaAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8098d268f52aac08, []int{53}
+}
+
+func (m *QuotaAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuotaAlert.Unmarshal(m, b)
+}
+func (m *QuotaAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuotaAlert.Marshal(b, m, deterministic)
+}
+func (m *QuotaAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuotaAlert.Merge(m, src)
+}
+func (m *QuotaAlert) XXX_Size() int {
+	return xxx_messageInfo_QuotaAlert.Size(m)
+}
+func (m *QuotaAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuotaAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuotaAlert proto.InternalMessageInfo
+
+func (m *QuotaAlert) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *QuotaAlert) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *QuotaAlert) GetResource() QuotaAlert_Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return QuotaAlert_STORAGE
+}
+
+func (m *QuotaAlert) GetUsage() uint64 {
+	if m != nil {
+		return m.Usage
+	}
+	return 0
+}
+
+func (m *QuotaAlert) GetSoftLimit() uint64 {
+	if m != nil {
+		return m.SoftLimit
+	}
+	return 0
+}
+
+func (m *QuotaAlert) GetHardLimit() uint64 {
+	if m != nil {
+		return m.HardLimit
+	}
+	return 0
+}
+
 // ConsensusMetadata holds data specific to the consensus protocol ordering the block.
 // The field prefix indicated the protocil used, e.g. "raft_*".
 type ConsensusMetadata struct {
@@ -1758,7 +3586,7 @@ func (m *ConsensusMetadata) Reset()         { *m = ConsensusMetadata{} }
 func (m *ConsensusMetadata) String() string { return proto.CompactTextString(m) }
 func (*ConsensusMetadata) ProtoMessage()    {}
 func (*ConsensusMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{30}
+	return fileDescriptor_8098d268f52aac08, []int{54}
 }
 
 func (m *ConsensusMetadata) XXX_Unmarshal(b []byte) error {
@@ -1805,7 +3633,7 @@ func (m *AugmentedBlockHeader) Reset()         { *m = AugmentedBlockHeader{} }
 func (m *AugmentedBlockHeader) String() string { return proto.CompactTextString(m) }
 func (*AugmentedBlockHeader) ProtoMessage()    {}
 func (*AugmentedBlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8098d268f52aac08, []int{31}
+	return fileDescriptor_8098d268f52aac08, []int{55}
 }
 
 func (m *AugmentedBlockHeader) XXX_Unmarshal(b []byte) error {
@@ -1842,28 +3670,50 @@ func (m *AugmentedBlockHeader) GetTxIds() []string {
 
 func init() {
 	proto.RegisterEnum("types.Flag", Flag_name, Flag_value)
+	proto.RegisterEnum("types.DBOperationCheck", DBOperationCheck_name, DBOperationCheck_value)
 	proto.RegisterEnum("types.IndexAttributeType", IndexAttributeType_name, IndexAttributeType_value)
+	proto.RegisterEnum("types.Migration_Direction", Migration_Direction_name, Migration_Direction_value)
 	proto.RegisterEnum("types.AccessControlWritePolicy", AccessControlWritePolicy_name, AccessControlWritePolicy_value)
+	proto.RegisterEnum("types.QuotaAlert_Resource", QuotaAlert_Resource_name, QuotaAlert_Resource_value)
 	proto.RegisterType((*Block)(nil), "types.Block")
+	proto.RegisterType((*TxHash)(nil), "types.TxHash")
 	proto.RegisterType((*BlockHeaderBase)(nil), "types.BlockHeaderBase")
 	proto.RegisterType((*BlockHeader)(nil), "types.BlockHeader")
+	proto.RegisterType((*DBStateRootHash)(nil), "types.DBStateRootHash")
 	proto.RegisterType((*DataTxEnvelopes)(nil), "types.DataTxEnvelopes")
 	proto.RegisterType((*DataTxEnvelope)(nil), "types.DataTxEnvelope")
 	proto.RegisterMapType((map[string][]byte)(nil), "types.DataTxEnvelope.SignaturesEntry")
+	proto.RegisterType((*PendingDataTx)(nil), "types.PendingDataTx")
+	proto.RegisterType((*PendingDataTxSignature)(nil), "types.PendingDataTxSignature")
 	proto.RegisterType((*ConfigTxEnvelope)(nil), "types.ConfigTxEnvelope")
 	proto.RegisterType((*DBAdministrationTxEnvelope)(nil), "types.DBAdministrationTxEnvelope")
 	proto.RegisterType((*UserAdministrationTxEnvelope)(nil), "types.UserAdministrationTxEnvelope")
 	proto.RegisterType((*DataTx)(nil), "types.DataTx")
+	proto.RegisterMapType((map[string]string)(nil), "types.DataTx.AnnotationsEntry")
 	proto.RegisterType((*DBOperation)(nil), "types.DBOperation")
 	proto.RegisterType((*DataRead)(nil), "types.DataRead")
 	proto.RegisterType((*DataWrite)(nil), "types.DataWrite")
 	proto.RegisterType((*DataDelete)(nil), "types.DataDelete")
+	proto.RegisterType((*AclWrite)(nil), "types.AclWrite")
 	proto.RegisterType((*ConfigTx)(nil), "types.ConfigTx")
+	proto.RegisterType((*StandbyPromotion)(nil), "types.StandbyPromotion")
 	proto.RegisterType((*DBAdministrationTx)(nil), "types.DBAdministrationTx")
+	proto.RegisterMapType((map[string]*DBConstraints)(nil), "types.DBAdministrationTx.DbsConstraintsEntry")
 	proto.RegisterMapType((map[string]*DBIndex)(nil), "types.DBAdministrationTx.DbsIndexEntry")
+	proto.RegisterType((*Migration)(nil), "types.Migration")
+	proto.RegisterType((*MigrationState)(nil), "types.MigrationState")
+	proto.RegisterType((*MigrationStep)(nil), "types.MigrationStep")
+	proto.RegisterType((*DatabaseFreeze)(nil), "types.DatabaseFreeze")
+	proto.RegisterType((*KeyErasure)(nil), "types.KeyErasure")
+	proto.RegisterType((*LegalHold)(nil), "types.LegalHold")
 	proto.RegisterType((*DBIndex)(nil), "types.DBIndex")
 	proto.RegisterMapType((map[string]IndexAttributeType)(nil), "types.DBIndex.AttributeAndTypeEntry")
+	proto.RegisterType((*DBConstraints)(nil), "types.DBConstraints")
+	proto.RegisterType((*DocumentSchema)(nil), "types.DocumentSchema")
+	proto.RegisterMapType((map[string]IndexAttributeType)(nil), "types.DocumentSchema.AttributeAndTypeEntry")
+	proto.RegisterType((*ReferenceConstraint)(nil), "types.ReferenceConstraint")
 	proto.RegisterType((*UserAdministrationTx)(nil), "types.UserAdministrationTx")
+	proto.RegisterType((*UserCertificateRenewal)(nil), "types.UserCertificateRenewal")
 	proto.RegisterType((*UserRead)(nil), "types.UserRead")
 	proto.RegisterType((*UserWrite)(nil), "types.UserWrite")
 	proto.RegisterType((*UserDelete)(nil), "types.UserDelete")
@@ -1872,13 +3722,23 @@ func init() {
 	proto.RegisterType((*AccessControl)(nil), "types.AccessControl")
 	proto.RegisterMapType((map[string]bool)(nil), "types.AccessControl.ReadUsersEntry")
 	proto.RegisterMapType((map[string]bool)(nil), "types.AccessControl.ReadWriteUsersEntry")
+	proto.RegisterMapType((map[string]*RedactionPolicy)(nil), "types.AccessControl.RedactedReadUsersEntry")
+	proto.RegisterType((*RedactionPolicy)(nil), "types.RedactionPolicy")
 	proto.RegisterType((*KVWithMetadata)(nil), "types.KVWithMetadata")
 	proto.RegisterType((*ValueWithMetadata)(nil), "types.ValueWithMetadata")
+	proto.RegisterType((*EncryptedValue)(nil), "types.EncryptedValue")
+	proto.RegisterType((*BlobManifest)(nil), "types.BlobManifest")
 	proto.RegisterType((*Digest)(nil), "types.Digest")
 	proto.RegisterType((*ValidationInfo)(nil), "types.ValidationInfo")
+	proto.RegisterType((*DBOperationFailure)(nil), "types.DBOperationFailure")
 	proto.RegisterType((*TxProof)(nil), "types.TxProof")
 	proto.RegisterType((*BlockProof)(nil), "types.BlockProof")
 	proto.RegisterType((*TxReceipt)(nil), "types.TxReceipt")
+	proto.RegisterMapType((map[string]string)(nil), "types.TxReceipt.AnnotationsEntry")
+	proto.RegisterType((*TxResourceUsage)(nil), "types.TxResourceUsage")
+	proto.RegisterType((*DBResourceUsage)(nil), "types.DBResourceUsage")
+	proto.RegisterType((*DBUsage)(nil), "types.DBUsage")
+	proto.RegisterType((*QuotaAlert)(nil), "types.QuotaAlert")
 	proto.RegisterType((*ConsensusMetadata)(nil), "types.ConsensusMetadata")
 	proto.RegisterType((*AugmentedBlockHeader)(nil), "types.AugmentedBlockHeader")
 }
@@ -1886,125 +3746,240 @@ func init() {
 func init() { proto.RegisterFile("block_and_transaction.proto", fileDescriptor_8098d268f52aac08) }
 
 var fileDescriptor_8098d268f52aac08 = []byte{
-	// 1910 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x58, 0xdd, 0x72, 0xdb, 0xb8,
-	0x15, 0x8e, 0xfe, 0xad, 0x23, 0x47, 0xa2, 0x11, 0x3b, 0x51, 0x9c, 0xa4, 0xc9, 0x32, 0xfb, 0x93,
-	0xcd, 0xce, 0x2a, 0xd3, 0x64, 0xdb, 0x74, 0xdb, 0x4d, 0x67, 0xf4, 0x97, 0x98, 0x13, 0x5b, 0xca,
-	0x40, 0x8c, 0xd3, 0xed, 0x4e, 0xcb, 0x21, 0x45, 0x48, 0xe2, 0x44, 0x22, 0x55, 0x02, 0x72, 0xe4,
-	0xcb, 0x4e, 0x1f, 0xa1, 0x2f, 0xd0, 0xbb, 0xbe, 0x40, 0x6f, 0x3b, 0x7d, 0x8d, 0xde, 0xf4, 0x0d,
-	0xfa, 0x10, 0x3b, 0xf8, 0x21, 0x45, 0xca, 0x92, 0x13, 0xdf, 0x81, 0xf8, 0xce, 0xf9, 0xce, 0x01,
-	0x70, 0xf0, 0x01, 0x20, 0xdc, 0x71, 0xa6, 0xc1, 0xf0, 0xbd, 0x65, 0xfb, 0xae, 0xc5, 0x42, 0xdb,
-	0xa7, 0xf6, 0x90, 0x79, 0x81, 0xdf, 0x98, 0x87, 0x01, 0x0b, 0x50, 0x81, 0x9d, 0xcf, 0x09, 0x3d,
-	0xbc, 0x31, 0x0c, 0xfc, 0x91, 0x37, 0x5e, 0x84, 0xf6, 0x0a, 0xd3, 0xff, 0x9f, 0x83, 0x42, 0x8b,
-	0xfb, 0xa2, 0xc7, 0x50, 0x9c, 0x10, 0xdb, 0x25, 0x61, 0x3d, 0xf3, 0x20, 0xf3, 0xa8, 0xf2, 0x14,
-	0x35, 0x84, 0x5b, 0x43, 0xa0, 0x47, 0x02, 0xc1, 0xca, 0x02, 0x75, 0x60, 0xcf, 0xb5, 0x99, 0x6d,
-	0xb1, 0xa5, 0x45, 0xfc, 0x33, 0x32, 0x0d, 0xe6, 0x84, 0xd6, 0xb3, 0xc2, 0xed, 0xa6, 0x72, 0xeb,
-	0xd8, 0xcc, 0x36, 0x97, 0xdd, 0x08, 0x3d, 0xba, 0x86, 0x6b, 0x6e, 0xba, 0x0b, 0xbd, 0x02, 0x24,
-	0x53, 0x4a, 0xf2, 0xd4, 0x73, 0x82, 0xe6, 0x96, 0xa2, 0x69, 0x0b, 0x83, 0x95, 0xd7, 0xd1, 0x35,
-	0xac, 0x0d, 0xd7, 0xfa, 0xd0, 0x08, 0xee, 0xb9, 0x8e, 0x65, 0xbb, 0x33, 0xcf, 0xf7, 0x28, 0x93,
-	0xe3, 0x4b, 0x71, 0xe6, 0x05, 0xe7, 0x67, 0x51, 0x6a, 0xad, 0x66, 0xca, 0x34, 0xc5, 0x7e, 0xe8,
-	0x3a, 0xdb, 0x50, 0x34, 0x85, 0xfb, 0x0b, 0x4a, 0xc2, 0xcb, 0x22, 0x15, 0x44, 0xa4, 0x87, 0x2a,
-	0xd2, 0x5b, 0x4a, 0xc2, 0x4b, 0x62, 0xdd, 0x5d, 0x5c, 0x82, 0xab, 0xe9, 0xa1, 0xc4, 0xa7, 0x0b,
-	0x6a, 0xcd, 0x08, 0xb3, 0xf9, 0xfc, 0xd5, 0x8b, 0x22, 0x40, 0x7d, 0x35, 0x3d, 0xd2, 0xe0, 0x44,
-	0xe1, 0x78, 0x6f, 0xb8, 0xde, 0xd5, 0x2a, 0x43, 0xe9, 0x8d, 0x7d, 0x3e, 0x0d, 0x6c, 0x57, 0xff,
-	0x6f, 0x06, 0x6a, 0x89, 0x05, 0x6d, 0xd9, 0x94, 0xa0, 0x9b, 0x50, 0xf4, 0x17, 0x33, 0x47, 0x2d,
-	0x7c, 0x1e, 0xab, 0x2f, 0xf4, 0x3d, 0xdc, 0x9e, 0x87, 0xe4, 0xcc, 0x0b, 0x16, 0xd4, 0x72, 0x6c,
-	0x4a, 0x2c, 0xb9, 0xf8, 0xd6, 0xc4, 0xa6, 0x13, 0xb1, 0xd8, 0xbb, 0xf8, 0x66, 0x64, 0xc0, 0x89,
-	0x24, 0xe5, 0x91, 0x4d, 0x27, 0xdc, 0x75, 0x6a, 0x53, 0x66, 0x0d, 0x83, 0xd9, 0xcc, 0x63, 0x8c,
-	0xb8, 0x96, 0xac, 0x4f, 0xe1, 0x9a, 0x93, 0xae, 0xdc, 0xa0, 0x1d, 0xe1, 0x32, 0x27, 0xee, 0xfa,
-	0x1c, 0xea, 0x1b, 0x5d, 0xfd, 0xc5, 0x4c, 0x2c, 0x63, 0x1e, 0x1f, 0x5c, 0xf4, 0xec, 0x2d, 0x66,
-	0xfa, 0x3f, 0xb3, 0x50, 0x49, 0x0c, 0x0d, 0x3d, 0x87, 0x4a, 0x22, 0x6b, 0x55, 0xd4, 0x37, 0x2f,
-	0x16, 0x35, 0x4f, 0x1d, 0x83, 0x13, 0x0f, 0x00, 0x7d, 0x0d, 0x1a, 0x7d, 0xef, 0xcd, 0x87, 0x13,
-	0xdb, 0xf3, 0x45, 0xc6, 0xa2, 0xb6, 0x73, 0x8f, 0x76, 0x71, 0x2d, 0xee, 0x3f, 0x12, 0xdd, 0xe8,
-	0xd7, 0x50, 0x67, 0x4b, 0x6b, 0x46, 0xc2, 0xf7, 0x64, 0x6a, 0xb1, 0x90, 0x10, 0x2b, 0x0c, 0x02,
-	0x96, 0x1c, 0xe6, 0x3e, 0x5b, 0x9e, 0x08, 0xd8, 0x0c, 0x09, 0xc1, 0x41, 0xc0, 0xc4, 0x20, 0x7f,
-	0x80, 0x3b, 0x94, 0xd9, 0x8c, 0x6c, 0x71, 0xcd, 0x0b, 0xd7, 0x5b, 0xc2, 0x64, 0x83, 0xf7, 0xef,
-	0xa1, 0x76, 0x66, 0x4f, 0x3d, 0x57, 0x56, 0x9f, 0xe7, 0x8f, 0x82, 0x7a, 0xe1, 0x41, 0xee, 0x51,
-	0xe5, 0xe9, 0x81, 0x1a, 0xdd, 0x69, 0x8c, 0x1a, 0xfe, 0x28, 0xc0, 0xd5, 0xb3, 0xd4, 0xb7, 0xfe,
-	0x12, 0x6a, 0x6b, 0xbb, 0x13, 0x3d, 0x83, 0xf2, 0x6a, 0x23, 0x67, 0x52, 0x64, 0x69, 0x53, 0xbc,
-	0xb2, 0xd3, 0xff, 0x93, 0x81, 0x6a, 0x1a, 0x45, 0x5f, 0x41, 0x69, 0x2e, 0x4b, 0x4d, 0x4d, 0xf8,
-	0xf5, 0x14, 0x0b, 0x8e, 0x50, 0xd4, 0x05, 0xa0, 0xde, 0xd8, 0xb7, 0xd9, 0x22, 0x54, 0xd3, 0x5b,
-	0x79, 0xfa, 0xc5, 0xc6, 0x88, 0x8d, 0x41, 0x6c, 0xd7, 0xf5, 0x59, 0x78, 0x8e, 0x13, 0x8e, 0x87,
-	0x2f, 0xa0, 0xb6, 0x06, 0x23, 0x0d, 0x72, 0xef, 0xc9, 0xb9, 0x08, 0x5f, 0xc6, 0xbc, 0x89, 0xf6,
-	0xa1, 0x70, 0x66, 0x4f, 0x17, 0x44, 0x15, 0xad, 0xfc, 0xf8, 0x6d, 0xf6, 0x37, 0x19, 0xfd, 0x27,
-	0xd0, 0xd6, 0x05, 0x06, 0x7d, 0xbd, 0x3e, 0x84, 0xda, 0x9a, 0x14, 0xad, 0x06, 0x71, 0x17, 0xca,
-	0x71, 0x2e, 0x8a, 0x7c, 0xd5, 0xa1, 0x07, 0x70, 0xb8, 0x5d, 0x69, 0xd0, 0xb3, 0xf5, 0x30, 0xb7,
-	0xb7, 0xaa, 0xd3, 0xa7, 0x06, 0xa4, 0x70, 0xf7, 0x32, 0xc1, 0x41, 0xbf, 0x5a, 0x0f, 0x79, 0xe7,
-	0x12, 0x99, 0xfa, 0xd4, 0xa0, 0x7f, 0xcb, 0x40, 0x51, 0x2e, 0x18, 0xfa, 0x06, 0xd0, 0x6c, 0x41,
-	0x99, 0xc5, 0x41, 0x4b, 0x08, 0xa5, 0xe7, 0xca, 0x6a, 0x2a, 0xe3, 0x1a, 0x47, 0xf8, 0x52, 0xf1,
-	0x58, 0x86, 0x4b, 0xd1, 0x0d, 0x28, 0xb0, 0xa5, 0xe5, 0xb9, 0x82, 0xb1, 0x8c, 0xf3, 0x6c, 0x69,
-	0xb8, 0xe8, 0x39, 0x5c, 0x77, 0x1d, 0x2b, 0x98, 0x13, 0x99, 0x05, 0xad, 0xe7, 0x44, 0x61, 0xa0,
-	0x78, 0x6a, 0xfa, 0x11, 0x84, 0x77, 0x5d, 0x27, 0xfe, 0x10, 0xa5, 0x58, 0x49, 0xa0, 0xe8, 0x16,
-	0x94, 0x5c, 0xc7, 0xf2, 0xed, 0x99, 0x3c, 0x4f, 0xca, 0xb8, 0xe8, 0x3a, 0x3d, 0x7b, 0x46, 0x50,
-	0x03, 0x40, 0x9c, 0x5c, 0x21, 0xb1, 0x5d, 0x5a, 0xcf, 0x0b, 0xfa, 0x5a, 0xa2, 0xee, 0x30, 0xb1,
-	0x5d, 0x5c, 0x76, 0x55, 0x8b, 0xa2, 0x5f, 0x42, 0x45, 0xd8, 0x7f, 0x08, 0x3d, 0x46, 0xa8, 0xda,
-	0x67, 0x5a, 0xc2, 0xe1, 0x1d, 0x07, 0xb0, 0x20, 0x15, 0x4d, 0x8a, 0xbe, 0x83, 0x5d, 0xe1, 0xe2,
-	0x92, 0x29, 0xe1, 0x3e, 0x45, 0xe1, 0xb3, 0x97, 0xf0, 0xe9, 0x08, 0x04, 0x0b, 0x66, 0xd9, 0xa6,
-	0xfa, 0x4b, 0xd8, 0x89, 0xe2, 0x6f, 0x28, 0xe1, 0x47, 0x50, 0x3a, 0x23, 0x21, 0xf5, 0x02, 0x5f,
-	0x1d, 0xb3, 0xd5, 0x68, 0xab, 0xcb, 0x5e, 0x1c, 0xc1, 0xfa, 0x4f, 0x50, 0x8e, 0xd3, 0xfa, 0xd4,
-	0xbd, 0x80, 0xbe, 0x84, 0x9c, 0x3d, 0x9c, 0xaa, 0xa3, 0x77, 0x5f, 0x51, 0x37, 0x87, 0x43, 0x42,
-	0x69, 0x3b, 0xf0, 0x59, 0x18, 0x4c, 0x31, 0x37, 0xd0, 0x7f, 0x01, 0xb0, 0xca, 0xff, 0x22, 0xbb,
-	0xfe, 0xaf, 0x0c, 0xec, 0x44, 0xdb, 0x84, 0xaf, 0x81, 0x2a, 0x02, 0x65, 0x52, 0x5c, 0x88, 0xb5,
-	0xdf, 0xbc, 0xf4, 0x5d, 0xb8, 0xc5, 0xd7, 0xc4, 0x0a, 0xa6, 0xae, 0xa5, 0x6e, 0x05, 0xd1, 0x88,
-	0x73, 0x1b, 0x47, 0xbc, 0xcf, 0xcd, 0xfb, 0x53, 0x57, 0xc6, 0x53, 0xbd, 0xe8, 0x19, 0x80, 0x4f,
-	0x3e, 0x28, 0x06, 0x75, 0xee, 0x47, 0x03, 0x6a, 0x4f, 0x17, 0x94, 0x91, 0x50, 0x3a, 0xe0, 0xb2,
-	0x4f, 0x3e, 0xc8, 0xa6, 0xfe, 0xf7, 0x2c, 0xa0, 0x8b, 0xdb, 0xee, 0x8a, 0x03, 0xb8, 0x07, 0x30,
-	0x0c, 0x09, 0x17, 0x75, 0xd7, 0x91, 0x85, 0x5b, 0xc6, 0x65, 0xd9, 0xd3, 0x71, 0x28, 0x87, 0x65,
-	0x41, 0x08, 0x38, 0x2f, 0x61, 0xd9, 0xc3, 0xe1, 0x0e, 0x94, 0x5d, 0x87, 0x5a, 0x9e, 0xef, 0x92,
-	0xa5, 0xaa, 0xb2, 0xaf, 0xb6, 0x0a, 0x42, 0xa3, 0xe3, 0x50, 0x83, 0x5b, 0x4a, 0x41, 0xdc, 0x71,
-	0xd5, 0xe7, 0xe1, 0x6b, 0xb8, 0x9e, 0x82, 0x36, 0x14, 0xc0, 0xe7, 0xc9, 0x02, 0x58, 0xcd, 0x6a,
-	0xa7, 0x25, 0xbc, 0x92, 0xe2, 0xf8, 0xef, 0x0c, 0x94, 0x54, 0x37, 0xc2, 0x80, 0x6c, 0xc6, 0x42,
-	0xcf, 0x59, 0x30, 0x22, 0x6f, 0x99, 0xe7, 0x73, 0xa2, 0x0e, 0x8a, 0xcf, 0xd3, 0x14, 0x8d, 0x66,
-	0x64, 0xd8, 0xf4, 0x5d, 0xf3, 0x7c, 0x4e, 0x64, 0x92, 0x9a, 0xbd, 0xd6, 0x7d, 0xf8, 0x67, 0x38,
-	0xd8, 0x68, 0xba, 0x21, 0xe9, 0x27, 0xc9, 0xa4, 0xab, 0xb1, 0x54, 0x8a, 0x78, 0x31, 0x07, 0x27,
-	0x48, 0xe6, 0xff, 0xbf, 0x0c, 0xec, 0x6f, 0x52, 0xb6, 0x2b, 0xae, 0x6b, 0x03, 0x40, 0x58, 0x4b,
-	0xc5, 0xc8, 0xa5, 0x14, 0x83, 0xd3, 0x4b, 0xc5, 0x58, 0xa8, 0x96, 0x50, 0x0c, 0x61, 0xaf, 0x14,
-	0x23, 0x9f, 0x52, 0x0c, 0xee, 0xa0, 0x14, 0x63, 0x11, 0x35, 0x85, 0x62, 0x08, 0x97, 0x48, 0x31,
-	0x0a, 0x29, 0xc5, 0xe0, 0x3e, 0x91, 0x62, 0x2c, 0xe2, 0x36, 0xd5, 0x4f, 0x60, 0x27, 0x8a, 0xbf,
-	0x7d, 0x48, 0x9f, 0x2e, 0x1c, 0x26, 0x94, 0xe3, 0xec, 0xd0, 0x7d, 0xc8, 0x73, 0x02, 0x75, 0x4e,
-	0x54, 0x92, 0xc3, 0x15, 0x40, 0xa4, 0x18, 0xd9, 0x8f, 0x29, 0xc6, 0x17, 0x00, 0xab, 0xfc, 0xb7,
-	0xa6, 0xa9, 0xff, 0x05, 0x76, 0xa2, 0xeb, 0x6a, 0x32, 0xe5, 0xcc, 0xa5, 0x29, 0xa3, 0xdf, 0x41,
-	0xd5, 0x16, 0x21, 0xf9, 0x7e, 0xe7, 0x31, 0x2f, 0xcd, 0xe7, 0xba, 0x9d, 0xfc, 0xd4, 0x5f, 0x40,
-	0x29, 0x12, 0x8d, 0x3b, 0x50, 0x5e, 0x5d, 0x32, 0xe5, 0x25, 0x78, 0xc7, 0x51, 0xf7, 0x4a, 0x74,
-	0x00, 0x45, 0xb6, 0x14, 0x48, 0x56, 0x20, 0x05, 0xb6, 0xe4, 0xd7, 0xcd, 0x7f, 0xe4, 0xe0, 0x7a,
-	0x8a, 0x1f, 0xb5, 0x00, 0x84, 0x82, 0xf1, 0x21, 0x45, 0x97, 0xa8, 0x87, 0x9b, 0x32, 0x69, 0xf0,
-	0x25, 0xe3, 0xb3, 0xa2, 0x2e, 0x34, 0xe5, 0x30, 0xfa, 0x46, 0x18, 0x34, 0xc1, 0x21, 0x8a, 0x47,
-	0x31, 0xc9, 0xcb, 0xd1, 0xa3, 0xad, 0x4c, 0x62, 0xc5, 0x12, 0x74, 0xd5, 0x30, 0xd5, 0x89, 0x4c,
-	0x38, 0x10, 0x27, 0xf2, 0x3c, 0x98, 0x7a, 0xc3, 0x73, 0x6b, 0x14, 0xa8, 0xda, 0x14, 0xba, 0x5a,
-	0x8d, 0x5f, 0x45, 0x69, 0x62, 0x99, 0x80, 0x74, 0xc1, 0x88, 0xfb, 0xbf, 0x11, 0xed, 0x97, 0x81,
-	0xac, 0x90, 0xc3, 0x1f, 0xa0, 0x9a, 0x1e, 0xc6, 0xc7, 0x0e, 0x9b, 0x9d, 0xc4, 0xde, 0x3c, 0x6c,
-	0xc2, 0x8d, 0x0d, 0xa9, 0x5f, 0x85, 0x42, 0x7f, 0x00, 0xbb, 0xc9, 0x24, 0x51, 0x09, 0x72, 0xcd,
-	0xde, 0x8f, 0xda, 0x35, 0xd1, 0x38, 0x3e, 0xd6, 0x32, 0x3a, 0x81, 0xea, 0xeb, 0xd3, 0x77, 0x1e,
-	0x9b, 0xc4, 0xa5, 0xf5, 0xa9, 0xe7, 0xe1, 0x37, 0xb0, 0x13, 0x3f, 0xb8, 0x72, 0xa9, 0x4b, 0x60,
-	0xfc, 0xce, 0x8a, 0x0d, 0xf4, 0x53, 0xd8, 0x3b, 0xe5, 0x5e, 0xa9, 0x48, 0x31, 0x6f, 0x66, 0x1b,
-	0x6f, 0xf6, 0x63, 0xbc, 0x2f, 0xa0, 0xd8, 0xf1, 0xc6, 0x84, 0x32, 0x5e, 0x9f, 0xab, 0xc7, 0x81,
-	0x24, 0xdc, 0x09, 0xa3, 0xd7, 0xc0, 0x4d, 0xfe, 0x6e, 0xf7, 0xc6, 0x13, 0xa6, 0xea, 0x53, 0x7d,
-	0xe9, 0x7f, 0x82, 0x6a, 0xfa, 0x1d, 0xc0, 0x37, 0xf5, 0x68, 0x6a, 0x8f, 0x05, 0x43, 0x35, 0xde,
-	0xd4, 0x2f, 0xa7, 0xf6, 0x18, 0x0b, 0x00, 0x3d, 0x86, 0xbd, 0x90, 0xd8, 0x94, 0x3f, 0x2a, 0x46,
-	0x96, 0xe7, 0x8b, 0x67, 0x83, 0xd2, 0xc2, 0x9a, 0x04, 0x8c, 0x91, 0x21, 0xbb, 0x75, 0x03, 0x4a,
-	0xe6, 0xf2, 0x4d, 0x18, 0x04, 0xa3, 0x2b, 0xfd, 0x39, 0x40, 0x90, 0x9f, 0xdb, 0x6c, 0xa2, 0x1e,
-	0x54, 0xa2, 0xad, 0xbf, 0x03, 0x10, 0xa6, 0x92, 0xed, 0x33, 0xd8, 0x8d, 0x37, 0xe3, 0xea, 0x51,
-	0x5a, 0x89, 0xf6, 0xa3, 0x23, 0xc4, 0x67, 0x45, 0xb2, 0x39, 0x9c, 0x24, 0xc6, 0x50, 0x36, 0x97,
-	0x98, 0x0c, 0x89, 0x37, 0x67, 0x57, 0xca, 0xf2, 0x36, 0xec, 0xf0, 0x83, 0x40, 0x1c, 0xc6, 0x72,
-	0x56, 0x4b, 0x6c, 0x29, 0x4e, 0x1b, 0xbd, 0x0f, 0x7b, 0x17, 0x1e, 0xdd, 0x62, 0x81, 0xec, 0x11,
-	0xb3, 0x18, 0x09, 0x63, 0x01, 0xe1, 0x1d, 0x26, 0x09, 0x67, 0xfc, 0xe4, 0x17, 0x60, 0x92, 0x4e,
-	0x98, 0x4b, 0xc2, 0x1f, 0x61, 0xbf, 0xb9, 0x18, 0xcf, 0x88, 0x1f, 0x3f, 0x83, 0x65, 0x0e, 0x57,
-	0xc9, 0x57, 0x6a, 0x14, 0xbf, 0x6d, 0x67, 0xc5, 0xc5, 0xa2, 0xc0, 0x4f, 0x2e, 0xfa, 0xf8, 0xaf,
-	0x59, 0xc8, 0xf3, 0xe5, 0x45, 0x65, 0x28, 0x9c, 0x36, 0x8f, 0x8d, 0x8e, 0x76, 0x0d, 0x7d, 0x09,
-	0xba, 0xd1, 0x13, 0x1f, 0xd6, 0xc9, 0x69, 0xbb, 0x6d, 0xb5, 0xfb, 0xbd, 0x97, 0xc7, 0x46, 0xdb,
-	0xb4, 0xde, 0x19, 0xe6, 0x91, 0xd1, 0xb3, 0x5a, 0xc7, 0xfd, 0xf6, 0x6b, 0x2d, 0x83, 0x1a, 0xf0,
-	0x78, 0xbb, 0x9d, 0xd5, 0xee, 0x9f, 0x9c, 0x18, 0xa6, 0xd9, 0xed, 0x58, 0x03, 0xb3, 0x69, 0x76,
-	0xb5, 0x2c, 0x7a, 0x08, 0xf7, 0x23, 0xfb, 0x4e, 0xd3, 0x6c, 0xb6, 0x9a, 0x83, 0xae, 0xd5, 0xe9,
-	0x77, 0x07, 0x56, 0xaf, 0x6f, 0x5a, 0xdd, 0x3f, 0x18, 0x03, 0x53, 0xcb, 0xa1, 0xdb, 0x70, 0x10,
-	0x19, 0xf5, 0xfa, 0xd6, 0x9b, 0x2e, 0x3e, 0x31, 0x06, 0x03, 0xa3, 0xdf, 0xd3, 0xf2, 0xe8, 0x1e,
-	0xdc, 0x8e, 0x20, 0xa3, 0xd7, 0xee, 0x63, 0xdc, 0x6d, 0x9b, 0x56, 0xb7, 0x67, 0x62, 0xa3, 0x3b,
-	0xd0, 0x0a, 0xa8, 0x0e, 0xfb, 0x11, 0xfc, 0xb6, 0xd7, 0x7c, 0x6b, 0x1e, 0xf5, 0xb1, 0x31, 0xe8,
-	0x76, 0xb4, 0x62, 0xd2, 0x51, 0xb0, 0xf5, 0x5e, 0x59, 0x03, 0xe3, 0x55, 0xaf, 0x69, 0xbe, 0xc5,
-	0x5d, 0xad, 0xf4, 0xf8, 0x7b, 0x40, 0x17, 0xaf, 0x09, 0x08, 0xa0, 0xd8, 0x7b, 0x7b, 0xd2, 0xea,
-	0x62, 0xed, 0x1a, 0x6f, 0x0f, 0x4c, 0x6c, 0xf4, 0x5e, 0x69, 0x19, 0x54, 0x81, 0x52, 0xab, 0xdf,
-	0x3f, 0xee, 0x36, 0x7b, 0x5a, 0xb6, 0xf5, 0xdd, 0x1f, 0x9f, 0x8e, 0x3d, 0x36, 0x59, 0x38, 0x8d,
-	0x61, 0x30, 0x7b, 0x32, 0x39, 0x9f, 0x93, 0x70, 0x4a, 0xdc, 0x31, 0x09, 0xbf, 0x9d, 0xda, 0x0e,
-	0x7d, 0x12, 0x84, 0x5e, 0xe0, 0x7f, 0x4b, 0x49, 0x78, 0x46, 0xc2, 0x27, 0xf3, 0xf7, 0xe3, 0x27,
-	0x62, 0x7d, 0x9c, 0xa2, 0xf8, 0xb1, 0xf6, 0xec, 0xe7, 0x00, 0x00, 0x00, 0xff, 0xff, 0x94, 0x5e,
-	0xa4, 0xe4, 0x93, 0x13, 0x00, 0x00,
+	// 3745 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x4d, 0x6f, 0x1b, 0x49,
+	0x76, 0xe6, 0x37, 0xf9, 0x48, 0x91, 0xad, 0x92, 0x2c, 0xd3, 0xf2, 0x78, 0xc6, 0x6e, 0xcf, 0xce,
+	0x78, 0x3d, 0x6b, 0x39, 0x6b, 0xcf, 0xae, 0x67, 0x26, 0x33, 0x8b, 0xf0, 0xa3, 0x25, 0x11, 0x96,
+	0x48, 0x6f, 0x91, 0xb2, 0x67, 0x66, 0x93, 0x6d, 0x34, 0xd9, 0x45, 0xb1, 0xa1, 0x66, 0x37, 0xb7,
+	0xab, 0x68, 0x8b, 0x73, 0xcf, 0x21, 0x40, 0x80, 0xfc, 0x84, 0x04, 0x58, 0x24, 0x97, 0x20, 0x01,
+	0xf2, 0x03, 0x82, 0xfc, 0x85, 0x9c, 0x92, 0x7b, 0xfe, 0x40, 0x72, 0x08, 0x12, 0x20, 0xc8, 0x21,
+	0xa8, 0x8f, 0x6e, 0x76, 0x53, 0x94, 0x6c, 0x65, 0xb1, 0xb7, 0xae, 0xf7, 0x5d, 0xaf, 0xaa, 0x5e,
+	0xbd, 0xf7, 0xaa, 0xe1, 0xce, 0xd0, 0xf5, 0x47, 0x67, 0xa6, 0xe5, 0xd9, 0x26, 0x0b, 0x2c, 0x8f,
+	0x5a, 0x23, 0xe6, 0xf8, 0xde, 0xde, 0x2c, 0xf0, 0x99, 0x8f, 0x72, 0x6c, 0x31, 0x23, 0x74, 0x77,
+	0x6b, 0xe4, 0x7b, 0x63, 0xe7, 0x74, 0x1e, 0x58, 0x4b, 0x9c, 0xfe, 0xd7, 0x59, 0xc8, 0x35, 0x39,
+	0x2f, 0x7a, 0x04, 0xf9, 0x09, 0xb1, 0x6c, 0x12, 0xd4, 0x53, 0xf7, 0x52, 0x0f, 0xcb, 0x4f, 0xd1,
+	0x9e, 0x60, 0xdb, 0x13, 0xd8, 0x43, 0x81, 0xc1, 0x8a, 0x02, 0xb5, 0x61, 0xd3, 0xb6, 0x98, 0x65,
+	0xb2, 0x73, 0x93, 0x78, 0x6f, 0x88, 0xeb, 0xcf, 0x08, 0xad, 0xa7, 0x05, 0xdb, 0x8e, 0x62, 0x6b,
+	0x5b, 0xcc, 0x1a, 0x9c, 0x1b, 0x21, 0xf6, 0xf0, 0x06, 0xae, 0xd9, 0x49, 0x10, 0x3a, 0x00, 0x24,
+	0x4d, 0x8a, 0xcb, 0xa9, 0x67, 0x84, 0x98, 0x5b, 0x4a, 0x4c, 0x4b, 0x10, 0x2c, 0xb9, 0x0e, 0x6f,
+	0x60, 0x6d, 0xb4, 0x02, 0x43, 0x63, 0xb8, 0x6b, 0x0f, 0x4d, 0xcb, 0x9e, 0x3a, 0x9e, 0x43, 0x99,
+	0x9c, 0x5f, 0x42, 0x66, 0x56, 0xc8, 0xbc, 0x1f, 0x9a, 0xd6, 0x6c, 0x24, 0x48, 0x13, 0xd2, 0x77,
+	0xed, 0xe1, 0x65, 0x58, 0xe4, 0xc2, 0x47, 0x73, 0x4a, 0x82, 0xab, 0x34, 0xe5, 0x84, 0xa6, 0x07,
+	0x4a, 0xd3, 0x09, 0x25, 0xc1, 0x15, 0xba, 0x3e, 0x98, 0x5f, 0x81, 0x57, 0xee, 0xa1, 0xc4, 0xa3,
+	0x73, 0x6a, 0x4e, 0x09, 0xb3, 0xb8, 0xff, 0xea, 0x79, 0xa1, 0xa0, 0xbe, 0x74, 0x8f, 0x24, 0x38,
+	0x56, 0x78, 0xbc, 0x39, 0x5a, 0x05, 0xa1, 0xe7, 0xa0, 0x51, 0x62, 0xb9, 0xc4, 0xe6, 0x96, 0x4e,
+	0x2c, 0x3a, 0x21, 0xb4, 0x5e, 0xb8, 0x97, 0x79, 0x58, 0x7e, 0xba, 0xa1, 0xc4, 0x0c, 0xce, 0x0f,
+	0x2d, 0x3a, 0xc1, 0x55, 0x49, 0x26, 0x47, 0x84, 0x36, 0x4b, 0x50, 0x78, 0x69, 0x2d, 0x5c, 0xdf,
+	0xb2, 0xf5, 0xe7, 0x90, 0x97, 0x60, 0x74, 0x1b, 0x8a, 0xec, 0xdc, 0x74, 0x3c, 0x9b, 0x9c, 0x8b,
+	0x9d, 0x92, 0xc5, 0x05, 0x76, 0xde, 0xe1, 0x43, 0x84, 0x20, 0xcb, 0xc5, 0x8b, 0x9d, 0x50, 0xc1,
+	0xe2, 0x5b, 0xff, 0xaf, 0x14, 0xd4, 0x62, 0x5b, 0xa8, 0x69, 0x51, 0x82, 0x76, 0x20, 0xef, 0xcd,
+	0xa7, 0x43, 0xb5, 0xd5, 0xb2, 0x58, 0x8d, 0xd0, 0x97, 0x70, 0x7b, 0x16, 0x90, 0x37, 0x8e, 0x3f,
+	0xa7, 0xe6, 0xd0, 0xa2, 0xc4, 0x94, 0xdb, 0xcd, 0x8c, 0x09, 0xdd, 0x09, 0x09, 0xb8, 0x20, 0x29,
+	0x52, 0x58, 0xf5, 0x25, 0xdc, 0x76, 0x2d, 0xca, 0xcc, 0x91, 0x3f, 0x9d, 0x3a, 0x8c, 0x11, 0xdb,
+	0x94, 0x27, 0x42, 0xb0, 0x66, 0x24, 0x2b, 0x27, 0x68, 0x85, 0x78, 0x69, 0x13, 0x67, 0x7d, 0x0e,
+	0xf5, 0xb5, 0xac, 0xde, 0x7c, 0x2a, 0x36, 0x4e, 0x16, 0xdf, 0xbc, 0xc8, 0xd9, 0x9d, 0x4f, 0xd1,
+	0x07, 0x50, 0x62, 0xce, 0x94, 0x50, 0x66, 0x4d, 0x67, 0x62, 0xe1, 0x33, 0x78, 0x09, 0xd0, 0xff,
+	0x23, 0x0d, 0xe5, 0xd8, 0xc4, 0xd1, 0x73, 0x28, 0xc7, 0xe6, 0x54, 0x4f, 0x25, 0x4e, 0xcb, 0x8a,
+	0x87, 0x30, 0x0c, 0xa3, 0xe9, 0xa1, 0x1f, 0x83, 0x46, 0xcf, 0x9c, 0xd9, 0x68, 0x62, 0x39, 0x5e,
+	0xb8, 0x7c, 0xe9, 0x7b, 0x99, 0x87, 0x15, 0x5c, 0x8b, 0xe0, 0x72, 0xc1, 0xd0, 0xcf, 0xa1, 0xce,
+	0xce, 0xcd, 0x29, 0x09, 0xce, 0x88, 0x6b, 0xb2, 0x80, 0x10, 0x33, 0xf0, 0x7d, 0x16, 0x77, 0xc2,
+	0x36, 0x3b, 0x3f, 0x16, 0xe8, 0x41, 0x40, 0x08, 0xf6, 0x7d, 0x26, 0x5c, 0xf0, 0x35, 0xdc, 0xa1,
+	0xcc, 0x62, 0xe4, 0x12, 0xd6, 0xac, 0x60, 0xbd, 0x25, 0x48, 0xd6, 0x70, 0xff, 0x02, 0x6a, 0x6f,
+	0x2c, 0xd7, 0xb1, 0xe5, 0x69, 0x70, 0xbc, 0xb1, 0x5f, 0xcf, 0x89, 0xed, 0x75, 0x53, 0xcd, 0xee,
+	0x55, 0x84, 0xed, 0x78, 0x63, 0x1f, 0x57, 0xdf, 0x24, 0xc6, 0xe8, 0x00, 0xb6, 0xed, 0xa1, 0x29,
+	0x0d, 0x88, 0x94, 0x12, 0x5a, 0xcf, 0xdf, 0xcb, 0xc4, 0x5c, 0xd4, 0x6e, 0xf6, 0x39, 0x45, 0xa8,
+	0x15, 0x6f, 0xda, 0xc3, 0x04, 0x80, 0x50, 0xfd, 0x00, 0x6a, 0x2b, 0x54, 0xe8, 0x16, 0x14, 0xec,
+	0xa1, 0xe9, 0x59, 0x53, 0x22, 0x3c, 0x5e, 0xc2, 0x79, 0x7b, 0xd8, 0xb5, 0xa6, 0x04, 0xdd, 0x81,
+	0xd2, 0x72, 0x82, 0x72, 0x6f, 0x15, 0x03, 0xc5, 0xa5, 0xef, 0x43, 0x6d, 0x25, 0x7e, 0xa1, 0x67,
+	0x50, 0x5a, 0x86, 0xba, 0x54, 0x62, 0x7a, 0x49, 0x52, 0xbc, 0xa4, 0xd3, 0xff, 0x29, 0x05, 0xd5,
+	0x24, 0x16, 0x7d, 0x0a, 0x85, 0x99, 0x3c, 0x53, 0x6a, 0x0b, 0x6c, 0x24, 0xa4, 0xe0, 0x10, 0x8b,
+	0x0c, 0x00, 0xea, 0x9c, 0x7a, 0x16, 0x9b, 0x07, 0x6a, 0xc1, 0xcb, 0x4f, 0x7f, 0xb4, 0x56, 0xe3,
+	0x5e, 0x3f, 0xa2, 0x33, 0x3c, 0x16, 0x2c, 0x70, 0x8c, 0x71, 0xf7, 0x1b, 0xa8, 0xad, 0xa0, 0x91,
+	0x06, 0x99, 0x33, 0xb2, 0x50, 0xfe, 0xe0, 0x9f, 0x68, 0x1b, 0x72, 0x6f, 0x2c, 0x77, 0x4e, 0x94,
+	0x23, 0xe4, 0xe0, 0xab, 0xf4, 0x17, 0x29, 0xfd, 0x2f, 0x52, 0xb0, 0xf1, 0x92, 0x78, 0xb6, 0xe3,
+	0x9d, 0x4a, 0xa5, 0xe8, 0xa7, 0x50, 0x8c, 0xa2, 0x9d, 0x9c, 0xc1, 0x25, 0x7e, 0x88, 0xc8, 0xd0,
+	0x4f, 0x00, 0xcd, 0xa4, 0x0c, 0x93, 0x5b, 0x46, 0x02, 0xd3, 0xb1, 0xe5, 0x94, 0x4a, 0x58, 0x53,
+	0x98, 0xbe, 0x40, 0x74, 0x6c, 0x8a, 0xee, 0x02, 0x90, 0xf3, 0x99, 0x13, 0x10, 0x6a, 0x5a, 0x4c,
+	0x6c, 0xdb, 0x0c, 0x2e, 0x29, 0x48, 0x83, 0xe9, 0x36, 0xec, 0x24, 0x0c, 0x8a, 0x66, 0x87, 0xb6,
+	0x20, 0xc7, 0x23, 0x93, 0xad, 0x66, 0x96, 0x65, 0xe7, 0x1d, 0x9b, 0x6f, 0x00, 0x11, 0xb3, 0x1d,
+	0x5b, 0x4c, 0xae, 0x84, 0xf3, 0x7c, 0xd8, 0xb1, 0xf9, 0xe9, 0x8d, 0xdc, 0xa4, 0x0e, 0xc7, 0x12,
+	0xa0, 0xff, 0x0a, 0xb4, 0xd5, 0xab, 0x07, 0xfd, 0x78, 0x75, 0xe9, 0x6a, 0x2b, 0x97, 0xd4, 0x72,
+	0xf1, 0x12, 0xc2, 0xd3, 0xab, 0xc2, 0x7d, 0xd8, 0xbd, 0xfc, 0x0e, 0x42, 0xcf, 0x56, 0xd5, 0xdc,
+	0xbe, 0xf4, 0xde, 0x7a, 0x5f, 0x85, 0x7f, 0x95, 0x82, 0x0f, 0xae, 0xba, 0x8b, 0xd0, 0xcf, 0x56,
+	0x75, 0xde, 0xb9, 0xe2, 0x06, 0x7b, 0x4f, 0xad, 0xe8, 0x33, 0xd8, 0x0c, 0x88, 0x47, 0xde, 0x5a,
+	0xae, 0xb9, 0xea, 0x69, 0x4d, 0x21, 0xa2, 0xc5, 0xd3, 0xff, 0x34, 0x0d, 0x79, 0xb5, 0xc3, 0x3e,
+	0x03, 0x34, 0x9d, 0x53, 0x26, 0x98, 0x4c, 0xb5, 0x78, 0xf2, 0xcc, 0x95, 0x70, 0x8d, 0x63, 0x38,
+	0xd7, 0x09, 0x95, 0xbb, 0x25, 0x5a, 0xf4, 0x74, 0x6c, 0xd1, 0x9f, 0xc3, 0x86, 0x3d, 0x34, 0xfd,
+	0x19, 0x91, 0x26, 0xd3, 0x7a, 0xe6, 0x5e, 0x26, 0x96, 0xd2, 0xb4, 0x9b, 0xbd, 0x10, 0x85, 0x2b,
+	0xf6, 0x30, 0x1a, 0x50, 0xf4, 0x47, 0x50, 0xb6, 0x3c, 0xcf, 0x67, 0x8a, 0x2d, 0x2b, 0xd8, 0x3e,
+	0x4c, 0xec, 0xef, 0xbd, 0xc6, 0x92, 0x40, 0x1e, 0xb7, 0x38, 0xcb, 0xee, 0x2f, 0x40, 0x5b, 0x25,
+	0x78, 0xd7, 0x81, 0x2b, 0xc5, 0x0f, 0xdc, 0xbf, 0xa7, 0xa0, 0x1c, 0xb3, 0x2f, 0x1e, 0xc0, 0x32,
+	0x89, 0x00, 0xb6, 0x07, 0x20, 0x72, 0xb0, 0x80, 0x58, 0x76, 0x68, 0x69, 0x2d, 0x66, 0x29, 0x26,
+	0x96, 0x8d, 0x4b, 0xb6, 0xfa, 0xa2, 0xe8, 0xa7, 0x50, 0x16, 0xf4, 0x6f, 0x03, 0x87, 0x11, 0xaa,
+	0x22, 0xb4, 0x16, 0x63, 0x78, 0xcd, 0x11, 0x18, 0xec, 0xf0, 0x93, 0xa2, 0xcf, 0xa1, 0x22, 0x58,
+	0x6c, 0xe2, 0x12, 0x16, 0x05, 0xe4, 0xcd, 0x18, 0x4f, 0x5b, 0x60, 0x70, 0xd9, 0x8e, 0xbe, 0x29,
+	0x37, 0xcc, 0x1a, 0xb9, 0xa1, 0x9e, 0x42, 0xc2, 0xb0, 0xc6, 0xc8, 0x95, 0x6a, 0x4a, 0x96, 0xfa,
+	0xa2, 0xfa, 0x3e, 0x14, 0x43, 0x7b, 0xd7, 0x78, 0xea, 0x21, 0x14, 0xde, 0x90, 0x80, 0x3a, 0xbe,
+	0xa7, 0x12, 0xcc, 0x6a, 0x78, 0xa9, 0x48, 0x28, 0x0e, 0xd1, 0xfa, 0x9f, 0xa7, 0xa1, 0x14, 0xcd,
+	0xe3, 0x7d, 0x83, 0x1c, 0xfa, 0x04, 0x32, 0xd6, 0xc8, 0x55, 0x59, 0xe7, 0x76, 0x64, 0xe6, 0x88,
+	0x50, 0xda, 0xf2, 0x3d, 0x16, 0xf8, 0x2e, 0xe6, 0x04, 0xfc, 0x92, 0x23, 0xde, 0x28, 0x58, 0xcc,
+	0x78, 0x82, 0x20, 0xe5, 0x64, 0x13, 0xd1, 0xcf, 0x08, 0xb1, 0xaf, 0x38, 0x12, 0x57, 0x49, 0x62,
+	0x8c, 0xbe, 0x80, 0x8d, 0xa1, 0xeb, 0x0f, 0xcd, 0xa9, 0xe5, 0x39, 0x63, 0x42, 0x99, 0xca, 0x14,
+	0xb7, 0x96, 0x09, 0xc0, 0xf0, 0x58, 0xa1, 0x70, 0x65, 0x18, 0x1b, 0xf1, 0x6c, 0x89, 0x04, 0x16,
+	0x25, 0xb6, 0xc8, 0xfd, 0x8a, 0x58, 0x8d, 0x78, 0x9c, 0x14, 0x76, 0xc8, 0x2b, 0xac, 0x20, 0x4f,
+	0x9f, 0x80, 0x88, 0x3b, 0xec, 0x43, 0x80, 0xe5, 0x0a, 0x5d, 0x74, 0x87, 0xfe, 0x02, 0x8a, 0xe1,
+	0x6a, 0x5c, 0xc4, 0xa2, 0xc7, 0x50, 0xf0, 0xc8, 0x5b, 0x93, 0xbb, 0x26, 0x7d, 0x85, 0x6b, 0xf2,
+	0x1e, 0x79, 0xdb, 0x18, 0xb9, 0xfa, 0xff, 0xa4, 0xa0, 0x18, 0x46, 0xc1, 0x78, 0xc8, 0x4d, 0x25,
+	0x42, 0xee, 0xda, 0xb3, 0x6a, 0xc0, 0x2d, 0xbe, 0x85, 0x4d, 0xdf, 0xb5, 0x4d, 0x55, 0x0e, 0x84,
+	0x0b, 0x9e, 0x59, 0xbb, 0xe0, 0xdb, 0x9c, 0xbc, 0xe7, 0xda, 0x52, 0x9f, 0x82, 0xa2, 0x67, 0x00,
+	0xdc, 0x60, 0x29, 0xa1, 0x9e, 0x4d, 0xd8, 0xdc, 0x72, 0xe7, 0x94, 0x91, 0x40, 0x32, 0xe0, 0x92,
+	0x47, 0xde, 0xca, 0x4f, 0x5e, 0xc7, 0x50, 0x66, 0x79, 0xf6, 0x70, 0x61, 0xce, 0x02, 0x7f, 0xea,
+	0xf3, 0x13, 0x57, 0xcf, 0x25, 0x0a, 0x90, 0xbe, 0xc4, 0xbf, 0x0c, 0xd1, 0x58, 0xa3, 0x2b, 0x10,
+	0xfd, 0x39, 0x68, 0xab, 0x54, 0xe8, 0x01, 0x6c, 0xb8, 0xc4, 0x3e, 0xe5, 0xc9, 0x2b, 0x71, 0x4e,
+	0x27, 0x4c, 0x65, 0xba, 0x15, 0x09, 0x3c, 0x14, 0x30, 0xfd, 0xbf, 0x73, 0x80, 0x2e, 0x06, 0xf5,
+	0x6b, 0xfa, 0xef, 0x2e, 0xc0, 0x28, 0x20, 0x3c, 0x77, 0xb2, 0x87, 0x32, 0xd0, 0x95, 0x70, 0x49,
+	0x42, 0xda, 0x43, 0x71, 0x9b, 0xca, 0xe3, 0x2b, 0xd0, 0x59, 0x89, 0x96, 0x10, 0x8e, 0x6e, 0x43,
+	0xc9, 0x1e, 0x52, 0x95, 0xce, 0xcb, 0x98, 0xf0, 0xe9, 0xa5, 0xd7, 0xcd, 0x5e, 0x7b, 0x48, 0x45,
+	0xa6, 0x2f, 0xe3, 0x5e, 0xd1, 0x56, 0x43, 0xf4, 0x07, 0x00, 0x62, 0x53, 0x9a, 0x67, 0x64, 0xb1,
+	0x1a, 0x26, 0x5e, 0x90, 0x85, 0x11, 0x58, 0x74, 0x1e, 0xf0, 0xcc, 0x88, 0x13, 0xbd, 0x20, 0x0b,
+	0x8a, 0xbe, 0x86, 0xcd, 0x99, 0x6b, 0x8d, 0x88, 0xe9, 0x92, 0x53, 0xcb, 0x35, 0x27, 0xbe, 0x6b,
+	0x87, 0xb1, 0x22, 0x8c, 0x49, 0x47, 0x1c, 0x73, 0xe8, 0xbb, 0x36, 0xae, 0x09, 0xd2, 0x68, 0xcc,
+	0xc3, 0xf4, 0x56, 0x40, 0x5c, 0x62, 0xd1, 0x24, 0x7f, 0xf1, 0x12, 0xfe, 0x4d, 0x45, 0x1c, 0x93,
+	0xf0, 0x0a, 0x6a, 0x7c, 0xde, 0xbc, 0x58, 0x62, 0x81, 0xe5, 0x78, 0x8c, 0xd6, 0x4b, 0x82, 0xfb,
+	0xf1, 0x95, 0xb3, 0x6f, 0x2d, 0xe9, 0xa5, 0x0f, 0xaa, 0x76, 0x02, 0x88, 0x3e, 0x07, 0x18, 0x07,
+	0x84, 0xfc, 0x20, 0xdd, 0x0d, 0x17, 0xf2, 0x44, 0x9e, 0xd7, 0xef, 0x0b, 0x02, 0x5c, 0x92, 0x84,
+	0x7c, 0x15, 0x78, 0x4d, 0x35, 0xb1, 0xde, 0x0a, 0x9e, 0xb2, 0x58, 0xa2, 0x02, 0x1f, 0x73, 0xd4,
+	0x1e, 0x94, 0xa6, 0xce, 0xa9, 0xb4, 0xa1, 0x5e, 0xb9, 0x97, 0x8a, 0x4d, 0xf0, 0x38, 0x84, 0xe3,
+	0x25, 0xc9, 0xee, 0x0b, 0xd8, 0x48, 0xac, 0xd2, 0x9a, 0xb3, 0xfd, 0x71, 0x3c, 0x10, 0x2e, 0xcf,
+	0x57, 0xbb, 0x29, 0xb8, 0x62, 0x97, 0xd1, 0xee, 0x6b, 0xd8, 0x5a, 0x33, 0xe9, 0x35, 0x22, 0x1f,
+	0x25, 0x45, 0x6e, 0x47, 0x22, 0x63, 0xbc, 0xf1, 0x5b, 0xee, 0xdf, 0x52, 0x50, 0x8a, 0xcc, 0xe7,
+	0x75, 0x63, 0x2c, 0x43, 0x17, 0xdf, 0xa8, 0x9e, 0x8c, 0xfb, 0xd9, 0x28, 0xce, 0xa3, 0x2f, 0xa0,
+	0x64, 0x3b, 0x01, 0x19, 0xb1, 0x30, 0x44, 0x54, 0x9f, 0xee, 0xae, 0x7a, 0x64, 0xaf, 0x1d, 0x52,
+	0xe0, 0x25, 0x31, 0xba, 0x07, 0x65, 0x9b, 0xd0, 0x51, 0xe0, 0xcc, 0x04, 0x6f, 0x56, 0xa8, 0x8b,
+	0x83, 0x78, 0xa6, 0x3a, 0xb4, 0x46, 0x67, 0x63, 0xc7, 0x75, 0xe5, 0x99, 0x10, 0x4b, 0x92, 0x93,
+	0x99, 0x6a, 0x88, 0x11, 0x6e, 0x6a, 0x0f, 0xa9, 0x7e, 0x17, 0x4a, 0x91, 0x1e, 0x94, 0x87, 0xf4,
+	0xc9, 0x4b, 0xed, 0x06, 0x2a, 0x42, 0xb6, 0xdd, 0x7b, 0xdd, 0xd5, 0x52, 0xba, 0x07, 0xd5, 0xc8,
+	0x20, 0x51, 0x95, 0x5c, 0x73, 0xa2, 0x7b, 0x50, 0x98, 0x38, 0x94, 0xf9, 0xc1, 0x42, 0xe5, 0x2f,
+	0xdb, 0xab, 0xd3, 0xec, 0x33, 0x32, 0xc3, 0x21, 0x91, 0xfe, 0x2f, 0x29, 0xd8, 0x48, 0xa0, 0xe2,
+	0xb2, 0x53, 0x57, 0x38, 0x31, 0xfd, 0x3b, 0x38, 0x31, 0x73, 0xd1, 0x89, 0x51, 0x98, 0xca, 0xae,
+	0xcf, 0xc3, 0x73, 0x89, 0xa0, 0x76, 0x1f, 0x2a, 0x51, 0xbd, 0xcd, 0x5b, 0x02, 0x79, 0x61, 0x68,
+	0x79, 0xa8, 0xaa, 0xec, 0x21, 0x09, 0xf4, 0x5f, 0x43, 0x35, 0x79, 0x76, 0x2e, 0x2f, 0xeb, 0x76,
+	0x20, 0x1f, 0x10, 0x8b, 0xaa, 0x49, 0x95, 0xb0, 0x1a, 0xf1, 0x72, 0x6f, 0x1c, 0xf8, 0x3f, 0x10,
+	0xcf, 0x1c, 0x2e, 0x94, 0xcd, 0x45, 0x09, 0x68, 0x2e, 0xf4, 0xe7, 0x00, 0xcb, 0x28, 0x75, 0xb9,
+	0x6c, 0xb5, 0xed, 0xd3, 0xcb, 0x3b, 0xf4, 0x0c, 0x4a, 0x51, 0x4c, 0xb9, 0x06, 0x5f, 0xcc, 0xca,
+	0xcc, 0xaa, 0x95, 0x22, 0xd4, 0xd9, 0xdc, 0x4a, 0xe9, 0xbd, 0xa2, 0x04, 0x34, 0x17, 0xfa, 0x3f,
+	0xa6, 0xa0, 0xa0, 0xce, 0x28, 0xc2, 0x80, 0x2c, 0xc6, 0x02, 0x67, 0x38, 0x67, 0x44, 0x76, 0xfd,
+	0x16, 0xa2, 0x1c, 0xe3, 0xbb, 0xe4, 0xe3, 0xe4, 0x79, 0xde, 0x6b, 0x84, 0x84, 0x0d, 0xcf, 0x1e,
+	0x2c, 0x66, 0x44, 0x06, 0x2e, 0xcd, 0x5a, 0x01, 0xef, 0xfe, 0x1a, 0x6e, 0xae, 0x25, 0x5d, 0x73,
+	0xdc, 0x9f, 0xc4, 0x8f, 0x7b, 0x35, 0x2a, 0x50, 0x84, 0xbe, 0x48, 0x06, 0x17, 0x10, 0x3f, 0xf3,
+	0xff, 0x90, 0x82, 0x8d, 0x44, 0x40, 0x40, 0x5f, 0x01, 0x04, 0x64, 0x4c, 0x02, 0xe2, 0x8d, 0xa2,
+	0xa2, 0x3a, 0xdc, 0x85, 0x38, 0x44, 0x2c, 0x19, 0x70, 0x8c, 0x1a, 0x3d, 0x86, 0x3c, 0x1d, 0x4d,
+	0xc8, 0xd4, 0x52, 0x21, 0x27, 0x0a, 0xb2, 0xfe, 0x68, 0x3e, 0x25, 0x1e, 0xeb, 0x0b, 0x24, 0x56,
+	0x44, 0xe8, 0x67, 0x7c, 0xd7, 0x8e, 0xad, 0xb9, 0xcb, 0xcc, 0x77, 0xa5, 0x7b, 0xa0, 0x08, 0x79,
+	0x5e, 0xf3, 0xbf, 0xbc, 0x80, 0x4f, 0x48, 0x44, 0xdf, 0x5d, 0xe1, 0xfa, 0xcf, 0xd6, 0x1a, 0xf1,
+	0xbe, 0x2b, 0x80, 0x9e, 0xf0, 0x6b, 0xed, 0x37, 0x73, 0x27, 0x20, 0xb6, 0x19, 0x21, 0xc3, 0x42,
+	0x19, 0x85, 0xa8, 0x48, 0x1a, 0xfd, 0xbd, 0x2f, 0xd9, 0xb7, 0xb0, 0xb5, 0x66, 0x1d, 0x78, 0xd9,
+	0x17, 0x99, 0xa7, 0x74, 0x2c, 0x01, 0x3c, 0xf5, 0x89, 0xd6, 0xc9, 0x36, 0xed, 0xa1, 0xda, 0xf8,
+	0x95, 0x25, 0xb0, 0x3d, 0xd4, 0xff, 0x2e, 0x0d, 0xdb, 0xeb, 0x6a, 0xcb, 0x6b, 0x26, 0x3f, 0x7b,
+	0x00, 0x82, 0x5a, 0x16, 0x41, 0x99, 0x44, 0xad, 0xc1, 0xc5, 0xcb, 0x22, 0x68, 0xae, 0xbe, 0x44,
+	0x11, 0x24, 0xe8, 0x55, 0x71, 0x92, 0x4d, 0x24, 0x0c, 0x9c, 0x41, 0x15, 0x41, 0xf3, 0xf0, 0x53,
+	0x14, 0x41, 0x82, 0x25, 0x2c, 0x82, 0x72, 0x89, 0xec, 0x86, 0xf3, 0x84, 0x45, 0xd0, 0x3c, 0xfa,
+	0xa6, 0xa8, 0x0b, 0x5b, 0x23, 0x12, 0x30, 0x67, 0xec, 0x8c, 0x44, 0x5b, 0x4b, 0x96, 0xbb, 0xaa,
+	0x7b, 0x7b, 0x37, 0xc6, 0xdc, 0x5a, 0x52, 0x61, 0x49, 0x84, 0xd1, 0xe8, 0x02, 0x4c, 0xff, 0x0a,
+	0x76, 0xd6, 0x53, 0xf3, 0x78, 0x1c, 0xa3, 0x17, 0x4e, 0xab, 0xe0, 0x38, 0x48, 0x3f, 0x86, 0x62,
+	0xe8, 0x8b, 0xcb, 0xdd, 0xfb, 0xfe, 0x75, 0xd6, 0x00, 0x4a, 0x91, 0xa7, 0xd0, 0x47, 0x90, 0xe5,
+	0x02, 0x54, 0xd7, 0xa0, 0x1c, 0x77, 0xbd, 0x40, 0x84, 0xf5, 0x55, 0xfa, 0x1d, 0xf5, 0x95, 0xfe,
+	0x23, 0x80, 0xa5, 0x2f, 0x2f, 0x35, 0x53, 0xff, 0x0d, 0x14, 0xa3, 0xbe, 0xf6, 0xc3, 0xe4, 0xed,
+	0x76, 0xb9, 0xc9, 0xe8, 0x0f, 0xa1, 0x6a, 0x09, 0x95, 0xe6, 0x48, 0xea, 0xbc, 0xd2, 0x9e, 0x0d,
+	0x2b, 0x3e, 0xd4, 0xbf, 0x81, 0x82, 0x12, 0xc8, 0xe3, 0xf3, 0xb2, 0x37, 0x2c, 0x6f, 0xd4, 0x62,
+	0x78, 0x51, 0xa1, 0x9b, 0x90, 0x67, 0xe7, 0x02, 0x23, 0xef, 0xf1, 0x1c, 0x3b, 0xef, 0xce, 0xa7,
+	0xfa, 0x6f, 0x73, 0xb0, 0x91, 0x90, 0x8f, 0x9a, 0x3c, 0xec, 0x59, 0xb6, 0x68, 0x6d, 0x84, 0x61,
+	0xef, 0xc1, 0x3a, 0x4b, 0xf6, 0xf8, 0x92, 0x71, 0xaf, 0xa8, 0x64, 0xb3, 0x14, 0x84, 0x63, 0x84,
+	0x41, 0x13, 0x32, 0xc4, 0x46, 0x56, 0x92, 0x64, 0x8f, 0xf0, 0xe1, 0xa5, 0x92, 0xc4, 0x8a, 0xc5,
+	0xc4, 0x55, 0x83, 0x04, 0x10, 0x0d, 0xe0, 0xa6, 0x68, 0xb9, 0xcc, 0x7c, 0xd7, 0x19, 0x2d, 0xcc,
+	0xb1, 0xaf, 0xce, 0x89, 0x4a, 0xb2, 0xee, 0xaf, 0x15, 0x2c, 0x0d, 0x90, 0x2c, 0x18, 0x71, 0xfe,
+	0x97, 0xe2, 0x7b, 0xdf, 0x57, 0x3b, 0xe4, 0x39, 0xd4, 0x85, 0x54, 0x36, 0x09, 0x08, 0xe5, 0x79,
+	0x7a, 0x4c, 0x30, 0xbf, 0xe2, 0x36, 0xb0, 0xd0, 0x3a, 0x08, 0xd1, 0x11, 0xe3, 0xaf, 0x78, 0x34,
+	0xb4, 0xad, 0x11, 0x2f, 0xb8, 0x63, 0xfe, 0xca, 0x25, 0x22, 0xed, 0xea, 0x2c, 0x25, 0xfd, 0x8a,
+	0xdf, 0x36, 0x83, 0x55, 0xf8, 0xee, 0xd7, 0x50, 0x4d, 0x12, 0xbd, 0xab, 0x61, 0x50, 0x8c, 0xe7,
+	0xc5, 0x0d, 0x1e, 0x17, 0x2f, 0x38, 0xf4, 0x5a, 0x22, 0xfe, 0x18, 0x76, 0xd6, 0x5b, 0xbb, 0x46,
+	0xca, 0x4f, 0x92, 0xd9, 0xf5, 0x4e, 0x74, 0x45, 0xda, 0xf2, 0x9d, 0x4f, 0x7a, 0x3c, 0x1e, 0xb8,
+	0x9f, 0x40, 0x25, 0xbe, 0x30, 0xa8, 0x00, 0x99, 0x46, 0xf7, 0x3b, 0xed, 0x86, 0xf8, 0x38, 0x3a,
+	0xd2, 0x52, 0x68, 0x03, 0x4a, 0x83, 0x43, 0x6c, 0xf4, 0x0f, 0x7b, 0x47, 0x6d, 0x2d, 0xad, 0x9b,
+	0x50, 0x5b, 0x11, 0x87, 0x3e, 0x85, 0x1a, 0x65, 0x81, 0x33, 0x9b, 0x11, 0xdb, 0x1c, 0x3b, 0xc4,
+	0x8d, 0x7a, 0x70, 0xd5, 0x10, 0xbc, 0x2f, 0xa0, 0x3c, 0xe0, 0x8b, 0x8e, 0x7d, 0x44, 0x26, 0x2f,
+	0xac, 0x8a, 0x04, 0x4a, 0x22, 0xfd, 0x2f, 0x53, 0x50, 0x7d, 0xf1, 0xea, 0xb5, 0xc3, 0x26, 0xd1,
+	0xf9, 0x7d, 0xdf, 0x16, 0xcd, 0x67, 0x50, 0x8c, 0x9e, 0xbf, 0x32, 0x89, 0xc6, 0x6b, 0x28, 0x0a,
+	0x47, 0x04, 0x17, 0xfb, 0x2c, 0xd9, 0xf7, 0xec, 0xb3, 0xe8, 0xff, 0x9c, 0x82, 0x4d, 0xd1, 0xab,
+	0x49, 0x18, 0x19, 0x99, 0x94, 0xba, 0xcc, 0xa4, 0xf4, 0xb5, 0x4d, 0xca, 0xbc, 0xa7, 0x49, 0xbf,
+	0x6b, 0xd3, 0x49, 0xff, 0x13, 0xa8, 0x26, 0x29, 0x78, 0x90, 0x3a, 0x23, 0x8b, 0x65, 0x5c, 0xcd,
+	0x9d, 0x91, 0x45, 0xc7, 0xe6, 0xb3, 0xf4, 0x7c, 0x6f, 0x14, 0x39, 0x5e, 0x0c, 0xd0, 0x87, 0x00,
+	0x23, 0x67, 0x36, 0x21, 0x01, 0x23, 0xe7, 0x4c, 0x75, 0x6e, 0x63, 0x10, 0xdd, 0x86, 0x4a, 0xdc,
+	0x78, 0x5e, 0xde, 0x50, 0xe7, 0x07, 0xa2, 0x22, 0xa3, 0xf8, 0x16, 0xed, 0x89, 0xc9, 0xdc, 0x3b,
+	0x33, 0x05, 0x46, 0x46, 0xc6, 0x92, 0x80, 0xf4, 0x39, 0xfa, 0x3e, 0x54, 0x24, 0x5a, 0xbd, 0xf9,
+	0x64, 0xc4, 0xc3, 0x56, 0x59, 0xc0, 0xd4, 0xab, 0xce, 0x37, 0x90, 0x6f, 0x3b, 0xa7, 0x5c, 0x7e,
+	0xe2, 0xcd, 0x26, 0x95, 0x7c, 0xb3, 0xe1, 0x39, 0xb5, 0x6a, 0xb5, 0x48, 0x25, 0x6a, 0xa4, 0xff,
+	0x36, 0x05, 0xd5, 0xe4, 0x03, 0x14, 0xbf, 0xb4, 0xc6, 0xae, 0x75, 0x2a, 0x44, 0x54, 0xa3, 0x4b,
+	0x6b, 0xdf, 0xb5, 0x4e, 0xb1, 0x40, 0xa0, 0x47, 0xb0, 0x29, 0x33, 0x72, 0xd3, 0x19, 0x9b, 0x8e,
+	0x27, 0xde, 0xab, 0x54, 0xde, 0x51, 0x93, 0x88, 0xce, 0xb8, 0x23, 0xc1, 0xa8, 0x0d, 0xda, 0xd8,
+	0x72, 0xf8, 0xeb, 0x6a, 0xd4, 0x6f, 0x56, 0x0b, 0x7c, 0xfb, 0x62, 0xbb, 0x79, 0xdf, 0x72, 0x5c,
+	0xde, 0x09, 0xa9, 0x49, 0x96, 0x08, 0xae, 0x7b, 0xbc, 0x13, 0xb4, 0x4a, 0x76, 0x9d, 0x92, 0xe2,
+	0x31, 0xe4, 0x46, 0x13, 0x32, 0x3a, 0x53, 0xc1, 0xfa, 0xd6, 0x45, 0xdd, 0x2d, 0x8e, 0xc6, 0x92,
+	0x4a, 0xef, 0x40, 0x61, 0x70, 0xfe, 0x32, 0xf0, 0xfd, 0xf1, 0xb5, 0x1e, 0xfe, 0x11, 0x64, 0x67,
+	0x16, 0x9b, 0xa8, 0xf7, 0x47, 0xf1, 0xad, 0xbf, 0x06, 0x10, 0xa4, 0x52, 0xda, 0x6a, 0x39, 0x97,
+	0xba, 0x50, 0xce, 0xa1, 0x4f, 0x62, 0x42, 0xd6, 0xab, 0x93, 0x82, 0xff, 0x35, 0x05, 0xa5, 0xc1,
+	0x39, 0x26, 0x23, 0xe2, 0xcc, 0xd8, 0xb5, 0xcc, 0x8c, 0xbf, 0x51, 0xa7, 0x93, 0x6f, 0xd4, 0xad,
+	0x64, 0x87, 0x5f, 0xa6, 0x8c, 0xf7, 0xa3, 0x77, 0x70, 0xa5, 0xed, 0xf7, 0xdc, 0xe4, 0xff, 0xfb,
+	0x14, 0xd4, 0xb8, 0x2e, 0xea, 0xcf, 0x83, 0x11, 0x39, 0xa1, 0xd6, 0xe9, 0x25, 0xaf, 0x57, 0x89,
+	0x84, 0x23, 0xbd, 0x92, 0x70, 0xc4, 0x67, 0x99, 0x49, 0xce, 0xf2, 0x36, 0x14, 0xa3, 0x87, 0x13,
+	0xd9, 0xf3, 0x2b, 0xcc, 0xd5, 0x83, 0xc9, 0x33, 0xde, 0xf1, 0x33, 0xe7, 0x5c, 0x67, 0x78, 0x99,
+	0x2e, 0x9f, 0x58, 0x13, 0x26, 0xf1, 0x06, 0x9f, 0xf8, 0xa0, 0x7c, 0x29, 0x6a, 0x2b, 0xd8, 0xcb,
+	0x37, 0xe7, 0x03, 0xd8, 0x18, 0x2e, 0x18, 0xa1, 0xe2, 0x92, 0x67, 0x24, 0xec, 0x6b, 0x54, 0x04,
+	0xf0, 0xb5, 0x84, 0xf1, 0x99, 0xf1, 0x66, 0xa1, 0xb8, 0xd9, 0x95, 0xf5, 0x45, 0x0e, 0x10, 0x59,
+	0xea, 0x7d, 0xa8, 0x08, 0x64, 0x28, 0x40, 0x3e, 0xc3, 0x97, 0x39, 0x2c, 0xe4, 0x0f, 0x49, 0x64,
+	0x5a, 0x2e, 0x9b, 0x0a, 0x8a, 0x44, 0xe6, 0x90, 0x36, 0xb7, 0x43, 0xf6, 0x70, 0x88, 0xc7, 0x02,
+	0x47, 0xbc, 0x5f, 0x08, 0x3b, 0x9c, 0xb0, 0x39, 0xe6, 0x10, 0xaa, 0xff, 0x8d, 0xa8, 0xaa, 0xdf,
+	0x31, 0xa3, 0x2b, 0x97, 0xe1, 0x01, 0x6c, 0x50, 0xe6, 0x07, 0xd6, 0x29, 0x31, 0xc5, 0x0c, 0xd5,
+	0x6c, 0x2a, 0x0a, 0xd8, 0xe4, 0x30, 0x6e, 0xee, 0xd4, 0xf1, 0x78, 0xc9, 0x48, 0x99, 0x15, 0xc8,
+	0x5b, 0x29, 0x83, 0xcb, 0x12, 0xd6, 0xe7, 0x20, 0x1e, 0x29, 0x15, 0x09, 0x3b, 0xa7, 0x6a, 0x3e,
+	0x25, 0x09, 0x19, 0x9c, 0x53, 0xfd, 0x3f, 0x53, 0x00, 0xbf, 0x9c, 0xfb, 0xcc, 0x6a, 0xb8, 0x24,
+	0x60, 0xff, 0x4f, 0x5b, 0x7f, 0x0e, 0xc5, 0x40, 0x2d, 0xe2, 0x4a, 0xeb, 0x6c, 0x29, 0x7a, 0x2f,
+	0x5c, 0x66, 0x1c, 0xd1, 0xf2, 0xad, 0x2c, 0x76, 0x8c, 0x5a, 0x09, 0x39, 0xe0, 0x16, 0x53, 0x7f,
+	0xcc, 0x4c, 0xd7, 0x99, 0x3a, 0x2c, 0xb4, 0x98, 0x43, 0x8e, 0x38, 0x80, 0xa3, 0x27, 0x56, 0x60,
+	0x2b, 0xb4, 0x74, 0x7e, 0x89, 0x43, 0x04, 0x5a, 0xff, 0x18, 0x8a, 0xa1, 0x26, 0x54, 0x86, 0x42,
+	0x7f, 0xd0, 0xc3, 0x8d, 0x03, 0x43, 0xbb, 0xc1, 0x07, 0x83, 0x6f, 0x4d, 0xdc, 0x18, 0x18, 0x5a,
+	0x4a, 0xef, 0xc1, 0xe6, 0x85, 0x9f, 0x5c, 0xc4, 0x45, 0x60, 0x8d, 0x99, 0xc9, 0x48, 0x10, 0xe5,
+	0xe1, 0x1c, 0x30, 0x20, 0xc1, 0x94, 0xab, 0x15, 0xc8, 0xf8, 0xf1, 0x17, 0xe4, 0xe2, 0x68, 0xe8,
+	0xdf, 0xc1, 0x76, 0x63, 0x7e, 0xca, 0xab, 0xf3, 0xf0, 0x27, 0x10, 0x19, 0x33, 0xae, 0x13, 0x5f,
+	0x64, 0xaa, 0xbf, 0x7c, 0xc4, 0xce, 0xf1, 0xc3, 0x4a, 0x1f, 0xfd, 0x6d, 0x06, 0xb2, 0xfc, 0x16,
+	0x41, 0x25, 0xc8, 0xbd, 0x6a, 0x1c, 0x75, 0xda, 0xda, 0x0d, 0xf4, 0x09, 0xe8, 0x9d, 0xae, 0x18,
+	0x98, 0xc7, 0xaf, 0x5a, 0x2d, 0xb3, 0xd5, 0xeb, 0xee, 0x1f, 0x75, 0x5a, 0x03, 0xf3, 0x75, 0x67,
+	0x70, 0xd8, 0xe9, 0x9a, 0xcd, 0xa3, 0x5e, 0xeb, 0x85, 0x96, 0x42, 0x7b, 0xf0, 0xe8, 0x72, 0x3a,
+	0xb3, 0xd5, 0x3b, 0x3e, 0xee, 0x0c, 0x06, 0x46, 0xdb, 0xec, 0x0f, 0xb8, 0x5f, 0xd2, 0xe8, 0x01,
+	0x7c, 0x14, 0xd2, 0xb7, 0x1b, 0x83, 0x46, 0xb3, 0xd1, 0x37, 0xcc, 0x76, 0xcf, 0xe8, 0x9b, 0xdd,
+	0xde, 0xc0, 0x34, 0xbe, 0xed, 0xf4, 0x07, 0x5a, 0x06, 0xdd, 0x86, 0x9b, 0x21, 0x51, 0xb7, 0x67,
+	0xbe, 0x34, 0xf0, 0x71, 0xa7, 0xdf, 0xef, 0xf4, 0xba, 0x5a, 0x16, 0xdd, 0x85, 0xdb, 0x21, 0xaa,
+	0xd3, 0x6d, 0xf5, 0x30, 0x36, 0x5a, 0x03, 0xd3, 0xe8, 0x0e, 0x70, 0xc7, 0xe8, 0x6b, 0x39, 0x54,
+	0x87, 0xed, 0x10, 0x7d, 0xd2, 0x6d, 0x9c, 0x0c, 0x0e, 0x7b, 0xb8, 0xd3, 0x37, 0xda, 0x5a, 0x3e,
+	0xce, 0x28, 0xa4, 0x75, 0x0f, 0xcc, 0x7e, 0xe7, 0xa0, 0xdb, 0x18, 0x9c, 0x60, 0x43, 0x2b, 0xc4,
+	0x55, 0x9e, 0xf4, 0x0d, 0x6c, 0xb6, 0x3b, 0xfd, 0x46, 0xf3, 0xc8, 0x68, 0x6b, 0x45, 0xb4, 0x0b,
+	0x3b, 0x21, 0xea, 0x97, 0x27, 0xbd, 0x41, 0xc3, 0x34, 0xbe, 0x6d, 0x19, 0x46, 0xdb, 0x68, 0x6b,
+	0x25, 0xb4, 0x03, 0x28, 0xc4, 0x1d, 0x19, 0x07, 0x8d, 0x23, 0x53, 0xe4, 0xa5, 0x80, 0x3e, 0x84,
+	0xdd, 0xe5, 0x34, 0xbb, 0x07, 0x47, 0x5c, 0x1d, 0x36, 0xf6, 0x0d, 0x6c, 0x74, 0x5b, 0x86, 0x56,
+	0x46, 0x77, 0xe0, 0xd6, 0x05, 0x37, 0xec, 0xe3, 0xde, 0xf7, 0x46, 0x57, 0xab, 0xa0, 0x0f, 0xa0,
+	0x1e, 0x22, 0xfb, 0xad, 0x43, 0xe3, 0xb8, 0x61, 0xbe, 0xea, 0xf4, 0x8e, 0x1a, 0x03, 0xee, 0x81,
+	0x8d, 0x47, 0x7f, 0x96, 0x06, 0x6d, 0xf5, 0x7a, 0x44, 0x15, 0x28, 0x76, 0x7b, 0x66, 0xeb, 0xd0,
+	0x68, 0xbd, 0xd0, 0x6e, 0xf0, 0x51, 0xbb, 0xa9, 0x46, 0x29, 0x74, 0x0b, 0xb6, 0xda, 0xcd, 0x98,
+	0x17, 0x15, 0x22, 0x8d, 0x36, 0x61, 0x43, 0x79, 0x4e, 0x81, 0x32, 0x08, 0x41, 0x15, 0x1b, 0x8d,
+	0xb6, 0xd9, 0x68, 0x1d, 0x29, 0x58, 0x16, 0x6d, 0x41, 0xed, 0x35, 0xee, 0x0c, 0x8c, 0x18, 0x30,
+	0x87, 0xb6, 0x41, 0x6b, 0x1b, 0x47, 0x46, 0x02, 0x9a, 0x47, 0x55, 0x00, 0xb9, 0x0b, 0xc4, 0xb8,
+	0x80, 0x6a, 0x50, 0x96, 0x2e, 0x93, 0x80, 0x22, 0x67, 0x5b, 0xfa, 0x49, 0x41, 0x4b, 0x5c, 0x43,
+	0xe4, 0x1c, 0x05, 0x04, 0xa4, 0x41, 0x65, 0x1f, 0x1b, 0xc6, 0xf7, 0x21, 0xa4, 0xcc, 0x21, 0xca,
+	0x1f, 0x12, 0x52, 0x79, 0xf4, 0x25, 0xa0, 0x8b, 0x9d, 0x20, 0x04, 0x90, 0xef, 0x9e, 0x1c, 0x37,
+	0x0d, 0xac, 0xdd, 0xe0, 0xdf, 0xfd, 0x01, 0xee, 0x74, 0x0f, 0xb4, 0x14, 0x3f, 0xa0, 0xcd, 0x5e,
+	0xef, 0xc8, 0x68, 0x74, 0xb5, 0x74, 0xf3, 0xf3, 0xef, 0x9f, 0x9e, 0x3a, 0x6c, 0x32, 0x1f, 0xee,
+	0x8d, 0xfc, 0xe9, 0x93, 0xc9, 0x62, 0x46, 0x02, 0xf9, 0xc8, 0xf5, 0xd8, 0xb5, 0x86, 0xf4, 0x89,
+	0x1f, 0x38, 0xbe, 0xf7, 0x98, 0x92, 0xe0, 0x0d, 0x09, 0x9e, 0xcc, 0xce, 0x4e, 0x9f, 0x88, 0x53,
+	0x35, 0xcc, 0x8b, 0xdf, 0x0f, 0x9f, 0xfd, 0xdf, 0x00, 0x0f, 0xaf, 0xa1, 0x3d, 0xb9, 0x28, 0x00,
+	0x00,
 }
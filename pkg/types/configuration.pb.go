@@ -20,6 +20,76 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type EndpointPolicy_Group int32
+
+const (
+	// The blocks, the transaction receipts, and the proofs of transactions, data, and state. By default, every
+	// authenticated user may query them.
+	EndpointPolicy_LEDGER EndpointPolicy_Group = 0
+	// The provenance of the data and of the users. By default, every authenticated user may query them.
+	EndpointPolicy_PROVENANCE EndpointPolicy_Group = 1
+	// The committed config blocks, the config history, and the diff of configs. By default, only admins may query
+	// them.
+	EndpointPolicy_CONFIG_HISTORY EndpointPolicy_Group = 2
+	// The operations of the nodes: snapshots, leadership transfer, consensus diagnostics, storage reports, state
+	// hashes, the quarantined block, the rejected transactions, the slow queries, and the index usage. By default,
+	// only admins may query them.
+	EndpointPolicy_OPERATIONS EndpointPolicy_Group = 3
+)
+
+var EndpointPolicy_Group_name = map[int32]string{
+	0: "LEDGER",
+	1: "PROVENANCE",
+	2: "CONFIG_HISTORY",
+	3: "OPERATIONS",
+}
+
+var EndpointPolicy_Group_value = map[string]int32{
+	"LEDGER":         0,
+	"PROVENANCE":     1,
+	"CONFIG_HISTORY": 2,
+	"OPERATIONS":     3,
+}
+
+func (x EndpointPolicy_Group) String() string {
+	return proto.EnumName(EndpointPolicy_Group_name, int32(x))
+}
+
+func (EndpointPolicy_Group) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{2, 0}
+}
+
+type EndpointPolicy_Privilege int32
+
+const (
+	// Every authenticated user.
+	EndpointPolicy_AUTHENTICATED EndpointPolicy_Privilege = 0
+	// The users with the provenance read privilege, and the admins.
+	EndpointPolicy_PROVENANCE_READ EndpointPolicy_Privilege = 1
+	// The admins.
+	EndpointPolicy_ADMIN EndpointPolicy_Privilege = 2
+)
+
+var EndpointPolicy_Privilege_name = map[int32]string{
+	0: "AUTHENTICATED",
+	1: "PROVENANCE_READ",
+	2: "ADMIN",
+}
+
+var EndpointPolicy_Privilege_value = map[string]int32{
+	"AUTHENTICATED":   0,
+	"PROVENANCE_READ": 1,
+	"ADMIN":           2,
+}
+
+func (x EndpointPolicy_Privilege) String() string {
+	return proto.EnumName(EndpointPolicy_Privilege_name, int32(x))
+}
+
+func (EndpointPolicy_Privilege) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{2, 1}
+}
+
 type Privilege_Access int32
 
 const (
@@ -42,7 +112,7 @@ func (x Privilege_Access) String() string {
 }
 
 func (Privilege_Access) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{9, 0}
+	return fileDescriptor_415c9e57263f32ab, []int{15, 0}
 }
 
 // ClusterConfig holds the shared configuration of a blockchain database cluster.
@@ -64,10 +134,23 @@ type ClusterConfig struct {
 	// transactions and blocks.
 	CertAuthConfig *CAConfig `protobuf:"bytes,3,opt,name=cert_auth_config,json=certAuthConfig,proto3" json:"cert_auth_config,omitempty"`
 	// The consensus configuration.
-	ConsensusConfig      *ConsensusConfig `protobuf:"bytes,4,opt,name=consensus_config,json=consensusConfig,proto3" json:"consensus_config,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+	ConsensusConfig *ConsensusConfig `protobuf:"bytes,4,opt,name=consensus_config,json=consensusConfig,proto3" json:"consensus_config,omitempty"`
+	// The protocol version that is active in the cluster. A node uses the features introduced in a protocol version only
+	// once the cluster is configured with that version, which is allowed only when all the consensus members support it.
+	// This allows nodes to be upgraded one at a time. Zero is equivalent to version 1.
+	ProtocolVersion uint32 `protobuf:"varint,5,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// The quotas of the data databases. A database without a quota is not limited.
+	DbQuotas []*DatabaseQuota `protobuf:"bytes,6,rep,name=db_quotas,json=dbQuotas,proto3" json:"db_quotas,omitempty"`
+	// The data databases whose historical values can be crypto-erased. Every value written to an erasable database is
+	// sealed in the block store and the provenance store with an erasure key of its key, which is destroyed when the key
+	// is erased. The values written before a database is made erasable are not sealed. A block whose values were erased
+	// is served with the values empty, and can be verified, but not replayed, hence a node cannot catch up past it.
+	ErasableDbs []string `protobuf:"bytes,7,rep,name=erasable_dbs,json=erasableDbs,proto3" json:"erasable_dbs,omitempty"`
+	// The privileges that the users need to query the groups of endpoints.
+	AccessPolicy         *AccessPolicy `protobuf:"bytes,8,opt,name=access_policy,json=accessPolicy,proto3" json:"access_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *ClusterConfig) Reset()         { *m = ClusterConfig{} }
@@ -123,6 +206,206 @@ func (m *ClusterConfig) GetConsensusConfig() *ConsensusConfig {
 	return nil
 }
 
+func (m *ClusterConfig) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+func (m *ClusterConfig) GetDbQuotas() []*DatabaseQuota {
+	if m != nil {
+		return m.DbQuotas
+	}
+	return nil
+}
+
+func (m *ClusterConfig) GetErasableDbs() []string {
+	if m != nil {
+		return m.ErasableDbs
+	}
+	return nil
+}
+
+func (m *ClusterConfig) GetAccessPolicy() *AccessPolicy {
+	if m != nil {
+		return m.AccessPolicy
+	}
+	return nil
+}
+
+// AccessPolicy maps the groups of query endpoints to the privilege that a user needs to query them. A group that is
+// not listed requires its default privilege. The access control of the data itself, e.g., the read permission on a
+// database or the ACL of a key, applies in addition to the policy.
+type AccessPolicy struct {
+	Endpoints            []*EndpointPolicy `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *AccessPolicy) Reset()         { *m = AccessPolicy{} }
+func (m *AccessPolicy) String() string { return proto.CompactTextString(m) }
+func (*AccessPolicy) ProtoMessage()    {}
+func (*AccessPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{1}
+}
+
+func (m *AccessPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccessPolicy.Unmarshal(m, b)
+}
+func (m *AccessPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccessPolicy.Marshal(b, m, deterministic)
+}
+func (m *AccessPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccessPolicy.Merge(m, src)
+}
+func (m *AccessPolicy) XXX_Size() int {
+	return xxx_messageInfo_AccessPolicy.Size(m)
+}
+func (m *AccessPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccessPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccessPolicy proto.InternalMessageInfo
+
+func (m *AccessPolicy) GetEndpoints() []*EndpointPolicy {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+// EndpointPolicy sets the privilege that a user needs to query a group of endpoints.
+type EndpointPolicy struct {
+	Group                EndpointPolicy_Group     `protobuf:"varint,1,opt,name=group,proto3,enum=types.EndpointPolicy_Group" json:"group,omitempty"`
+	Privilege            EndpointPolicy_Privilege `protobuf:"varint,2,opt,name=privilege,proto3,enum=types.EndpointPolicy_Privilege" json:"privilege,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *EndpointPolicy) Reset()         { *m = EndpointPolicy{} }
+func (m *EndpointPolicy) String() string { return proto.CompactTextString(m) }
+func (*EndpointPolicy) ProtoMessage()    {}
+func (*EndpointPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{2}
+}
+
+func (m *EndpointPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointPolicy.Unmarshal(m, b)
+}
+func (m *EndpointPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointPolicy.Marshal(b, m, deterministic)
+}
+func (m *EndpointPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointPolicy.Merge(m, src)
+}
+func (m *EndpointPolicy) XXX_Size() int {
+	return xxx_messageInfo_EndpointPolicy.Size(m)
+}
+func (m *EndpointPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointPolicy proto.InternalMessageInfo
+
+func (m *EndpointPolicy) GetGroup() EndpointPolicy_Group {
+	if m != nil {
+		return m.Group
+	}
+	return EndpointPolicy_LEDGER
+}
+
+func (m *EndpointPolicy) GetPrivilege() EndpointPolicy_Privilege {
+	if m != nil {
+		return m.Privilege
+	}
+	return EndpointPolicy_AUTHENTICATED
+}
+
+// DatabaseQuota limits the storage and the transaction rate of a data database. A limit of zero is not enforced.
+//
+// A block that makes the usage reach a soft limit raises a warning, which is published to the quota webhook of each
+// node. A data transaction that would make the usage of a database exceed a hard limit is invalidated with
+// INVALID_QUOTA_EXCEEDED. The usage of a database is accounted from the first block committed after its quota is
+// configured.
+type DatabaseQuota struct {
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	// The soft and hard limits of the total size, in bytes, of the keys and values stored in the database. As the
+	// values a transaction overwrites are not known at validation time, the values it writes are counted in full
+	// against the hard limit; hence, deletes are always allowed.
+	SoftStorageBytes uint64 `protobuf:"varint,2,opt,name=soft_storage_bytes,json=softStorageBytes,proto3" json:"soft_storage_bytes,omitempty"`
+	HardStorageBytes uint64 `protobuf:"varint,3,opt,name=hard_storage_bytes,json=hardStorageBytes,proto3" json:"hard_storage_bytes,omitempty"`
+	// The soft and hard limits of the number of valid transactions that operate on the database per minute. The
+	// minutes are measured by the block timestamps.
+	SoftTxsPerMinute     uint64   `protobuf:"varint,4,opt,name=soft_txs_per_minute,json=softTxsPerMinute,proto3" json:"soft_txs_per_minute,omitempty"`
+	HardTxsPerMinute     uint64   `protobuf:"varint,5,opt,name=hard_txs_per_minute,json=hardTxsPerMinute,proto3" json:"hard_txs_per_minute,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DatabaseQuota) Reset()         { *m = DatabaseQuota{} }
+func (m *DatabaseQuota) String() string { return proto.CompactTextString(m) }
+func (*DatabaseQuota) ProtoMessage()    {}
+func (*DatabaseQuota) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{3}
+}
+
+func (m *DatabaseQuota) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DatabaseQuota.Unmarshal(m, b)
+}
+func (m *DatabaseQuota) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DatabaseQuota.Marshal(b, m, deterministic)
+}
+func (m *DatabaseQuota) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DatabaseQuota.Merge(m, src)
+}
+func (m *DatabaseQuota) XXX_Size() int {
+	return xxx_messageInfo_DatabaseQuota.Size(m)
+}
+func (m *DatabaseQuota) XXX_DiscardUnknown() {
+	xxx_messageInfo_DatabaseQuota.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DatabaseQuota proto.InternalMessageInfo
+
+func (m *DatabaseQuota) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *DatabaseQuota) GetSoftStorageBytes() uint64 {
+	if m != nil {
+		return m.SoftStorageBytes
+	}
+	return 0
+}
+
+func (m *DatabaseQuota) GetHardStorageBytes() uint64 {
+	if m != nil {
+		return m.HardStorageBytes
+	}
+	return 0
+}
+
+func (m *DatabaseQuota) GetSoftTxsPerMinute() uint64 {
+	if m != nil {
+		return m.SoftTxsPerMinute
+	}
+	return 0
+}
+
+func (m *DatabaseQuota) GetHardTxsPerMinute() uint64 {
+	if m != nil {
+		return m.HardTxsPerMinute
+	}
+	return 0
+}
+
 // NodeConfig holds the information about a database node in the cluster.
 // This information is exposed to the clients.
 // The address and port (see below) define the HTTP/REST endpoint that clients connect to,
@@ -139,7 +422,13 @@ type NodeConfig struct {
 	Port uint32 `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
 	// The x509 certificate used by this node to authenticate its communication with clients.
 	// This certificate corresponds to the private key the server uses to sign blocks and transaction responses.
-	Certificate          []byte   `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Certificate []byte `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// The region in which the node is deployed, e.g. "us-east". Optional.
+	// Together with the zone, it allows clients to route queries to nearby nodes.
+	Region string `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	// The availability zone, within the region, in which the node is deployed, e.g. "us-east-1a". Optional.
+	// A zone can be set only if the region is set.
+	Zone                 string   `protobuf:"bytes,6,opt,name=zone,proto3" json:"zone,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -149,7 +438,7 @@ func (m *NodeConfig) Reset()         { *m = NodeConfig{} }
 func (m *NodeConfig) String() string { return proto.CompactTextString(m) }
 func (*NodeConfig) ProtoMessage()    {}
 func (*NodeConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{1}
+	return fileDescriptor_415c9e57263f32ab, []int{4}
 }
 
 func (m *NodeConfig) XXX_Unmarshal(b []byte) error {
@@ -198,10 +487,30 @@ func (m *NodeConfig) GetCertificate() []byte {
 	return nil
 }
 
+func (m *NodeConfig) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *NodeConfig) GetZone() string {
+	if m != nil {
+		return m.Zone
+	}
+	return ""
+}
+
 // Admin holds the id and certificate of a cluster administrator.
 type Admin struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Certificate          []byte   `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Certificate []byte `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// The IDs of the nodes operated by the organization of this admin. When set, and the admin is not a super admin,
+	// reading the cluster config returns only the sections relevant to these nodes: the nodes themselves, their
+	// consensus peers, the admin itself, and the CAs that issued their certificates.
+	NodeIds []string `protobuf:"bytes,3,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
+	// A super admin can read the full cluster config regardless of node_ids.
+	SuperAdmin           bool     `protobuf:"varint,4,opt,name=super_admin,json=superAdmin,proto3" json:"super_admin,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -211,7 +520,7 @@ func (m *Admin) Reset()         { *m = Admin{} }
 func (m *Admin) String() string { return proto.CompactTextString(m) }
 func (*Admin) ProtoMessage()    {}
 func (*Admin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{2}
+	return fileDescriptor_415c9e57263f32ab, []int{5}
 }
 
 func (m *Admin) XXX_Unmarshal(b []byte) error {
@@ -246,19 +555,39 @@ func (m *Admin) GetCertificate() []byte {
 	return nil
 }
 
+func (m *Admin) GetNodeIds() []string {
+	if m != nil {
+		return m.NodeIds
+	}
+	return nil
+}
+
+func (m *Admin) GetSuperAdmin() bool {
+	if m != nil {
+		return m.SuperAdmin
+	}
+	return false
+}
+
 type CAConfig struct {
-	Roots                [][]byte `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
-	Intermediates        [][]byte `protobuf:"bytes,2,rep,name=intermediates,proto3" json:"intermediates,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Roots         [][]byte `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
+	Intermediates [][]byte `protobuf:"bytes,2,rep,name=intermediates,proto3" json:"intermediates,omitempty"`
+	// The CA certificates that were replaced by a rotation of the CAs, and that remain trusted during an overlap period,
+	// so that the identities they issued keep being accepted while they are re-issued by the new CAs.
+	Retiring []*RetiringCAs `protobuf:"bytes,3,rep,name=retiring,proto3" json:"retiring,omitempty"`
+	// The scopes of the CAs of the organizations of a consortium, which restrict the IDs of the users and nodes whose
+	// certificates each organization may issue. Without scopes, every CA may issue the certificates of every ID.
+	Scopes               []*CAScope `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *CAConfig) Reset()         { *m = CAConfig{} }
 func (m *CAConfig) String() string { return proto.CompactTextString(m) }
 func (*CAConfig) ProtoMessage()    {}
 func (*CAConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{3}
+	return fileDescriptor_415c9e57263f32ab, []int{6}
 }
 
 func (m *CAConfig) XXX_Unmarshal(b []byte) error {
@@ -293,9 +622,158 @@ func (m *CAConfig) GetIntermediates() [][]byte {
 	return nil
 }
 
+func (m *CAConfig) GetRetiring() []*RetiringCAs {
+	if m != nil {
+		return m.Retiring
+	}
+	return nil
+}
+
+func (m *CAConfig) GetScopes() []*CAScope {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+// CAScope restricts the identities that the CAs of an organization may issue, typically the intermediate CAs of the
+// organization under a root CA shared by the consortium. A certificate is issued by an organization if one of the CAs
+// of the organization is in the chain of the certificate. The certificate of a user or node whose ID is in the scope
+// of an organization must be issued by that organization, and a certificate issued by an organization may only be
+// held by a user or node whose ID is in its scope. Hence, the members of a consortium cannot issue identities for
+// each other. The admins are scoped by the prefixes of the IDs of the users.
+type CAScope struct {
+	// The name of the organization.
+	Organization string `protobuf:"bytes,1,opt,name=organization,proto3" json:"organization,omitempty"`
+	// The root or intermediate CA certificates of the organization, which must be among the CA certificates.
+	Cas [][]byte `protobuf:"bytes,2,rep,name=cas,proto3" json:"cas,omitempty"`
+	// The prefixes of the IDs of the users whose certificates the organization may issue.
+	UserIdPrefixes []string `protobuf:"bytes,3,rep,name=user_id_prefixes,json=userIdPrefixes,proto3" json:"user_id_prefixes,omitempty"`
+	// The IDs of the nodes whose certificates the organization may issue.
+	NodeIds              []string `protobuf:"bytes,4,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CAScope) Reset()         { *m = CAScope{} }
+func (m *CAScope) String() string { return proto.CompactTextString(m) }
+func (*CAScope) ProtoMessage()    {}
+func (*CAScope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{7}
+}
+
+func (m *CAScope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAScope.Unmarshal(m, b)
+}
+func (m *CAScope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAScope.Marshal(b, m, deterministic)
+}
+func (m *CAScope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAScope.Merge(m, src)
+}
+func (m *CAScope) XXX_Size() int {
+	return xxx_messageInfo_CAScope.Size(m)
+}
+func (m *CAScope) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAScope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAScope proto.InternalMessageInfo
+
+func (m *CAScope) GetOrganization() string {
+	if m != nil {
+		return m.Organization
+	}
+	return ""
+}
+
+func (m *CAScope) GetCas() [][]byte {
+	if m != nil {
+		return m.Cas
+	}
+	return nil
+}
+
+func (m *CAScope) GetUserIdPrefixes() []string {
+	if m != nil {
+		return m.UserIdPrefixes
+	}
+	return nil
+}
+
+func (m *CAScope) GetNodeIds() []string {
+	if m != nil {
+		return m.NodeIds
+	}
+	return nil
+}
+
+// RetiringCAs are root and intermediate CA certificates that are trusted up to, and including, a block. A certificate
+// must be trusted by the current configuration when it is added to the retiring CAs, i.e., a CA can only be retired
+// by the configuration transaction that replaces it, or while it is retiring. The certificates issued by the retiring
+// CAs are accepted by the validators of the transactions committed during the overlap period, after which the
+// certificates of the nodes and admins of a new configuration, and of the users written, must be issued by the
+// current CAs.
+type RetiringCAs struct {
+	Roots         [][]byte `protobuf:"bytes,1,rep,name=roots,proto3" json:"roots,omitempty"`
+	Intermediates [][]byte `protobuf:"bytes,2,rep,name=intermediates,proto3" json:"intermediates,omitempty"`
+	// The last block in which the retiring CAs are trusted.
+	TrustedUntilBlock    uint64   `protobuf:"varint,3,opt,name=trusted_until_block,json=trustedUntilBlock,proto3" json:"trusted_until_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetiringCAs) Reset()         { *m = RetiringCAs{} }
+func (m *RetiringCAs) String() string { return proto.CompactTextString(m) }
+func (*RetiringCAs) ProtoMessage()    {}
+func (*RetiringCAs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{8}
+}
+
+func (m *RetiringCAs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetiringCAs.Unmarshal(m, b)
+}
+func (m *RetiringCAs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetiringCAs.Marshal(b, m, deterministic)
+}
+func (m *RetiringCAs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetiringCAs.Merge(m, src)
+}
+func (m *RetiringCAs) XXX_Size() int {
+	return xxx_messageInfo_RetiringCAs.Size(m)
+}
+func (m *RetiringCAs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetiringCAs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetiringCAs proto.InternalMessageInfo
+
+func (m *RetiringCAs) GetRoots() [][]byte {
+	if m != nil {
+		return m.Roots
+	}
+	return nil
+}
+
+func (m *RetiringCAs) GetIntermediates() [][]byte {
+	if m != nil {
+		return m.Intermediates
+	}
+	return nil
+}
+
+func (m *RetiringCAs) GetTrustedUntilBlock() uint64 {
+	if m != nil {
+		return m.TrustedUntilBlock
+	}
+	return 0
+}
+
 // The definitions of the clustered consensus algorithm, members, and parameters.
 type ConsensusConfig struct {
-	// The consensus algorithm, currently only "raft" is supported.
+	// The consensus algorithm: "raft", or the algorithm of an external ordering service registered with the server.
 	Algorithm string `protobuf:"bytes,1,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
 	// Peers that take part in consensus.
 	Members []*PeerConfig `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
@@ -312,7 +790,7 @@ func (m *ConsensusConfig) Reset()         { *m = ConsensusConfig{} }
 func (m *ConsensusConfig) String() string { return proto.CompactTextString(m) }
 func (*ConsensusConfig) ProtoMessage()    {}
 func (*ConsensusConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{4}
+	return fileDescriptor_415c9e57263f32ab, []int{9}
 }
 
 func (m *ConsensusConfig) XXX_Unmarshal(b []byte) error {
@@ -380,7 +858,7 @@ func (m *PeerConfig) Reset()         { *m = PeerConfig{} }
 func (m *PeerConfig) String() string { return proto.CompactTextString(m) }
 func (*PeerConfig) ProtoMessage()    {}
 func (*PeerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{5}
+	return fileDescriptor_415c9e57263f32ab, []int{10}
 }
 
 func (m *PeerConfig) XXX_Unmarshal(b []byte) error {
@@ -464,7 +942,7 @@ func (m *RaftConfig) Reset()         { *m = RaftConfig{} }
 func (m *RaftConfig) String() string { return proto.CompactTextString(m) }
 func (*RaftConfig) ProtoMessage()    {}
 func (*RaftConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{6}
+	return fileDescriptor_415c9e57263f32ab, []int{11}
 }
 
 func (m *RaftConfig) XXX_Unmarshal(b []byte) error {
@@ -542,7 +1020,7 @@ func (m *DatabaseConfig) Reset()         { *m = DatabaseConfig{} }
 func (m *DatabaseConfig) String() string { return proto.CompactTextString(m) }
 func (*DatabaseConfig) ProtoMessage()    {}
 func (*DatabaseConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{7}
+	return fileDescriptor_415c9e57263f32ab, []int{12}
 }
 
 func (m *DatabaseConfig) XXX_Unmarshal(b []byte) error {
@@ -587,19 +1065,26 @@ func (m *DatabaseConfig) GetWriteAccessUsers() []string {
 // User holds userID, certificate, privilege the user has,
 // and groups the user belong to.
 type User struct {
-	Id                   string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Certificate          []byte     `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
-	Privilege            *Privilege `protobuf:"bytes,3,opt,name=privilege,proto3" json:"privilege,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Id          string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Certificate []byte     `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Privilege   *Privilege `protobuf:"bytes,3,opt,name=privilege,proto3" json:"privilege,omitempty"`
+	// A disabled user cannot authenticate nor sign transactions, but, unlike a deleted user, it keeps its history and
+	// the references to it in access control lists. A user is disabled or enabled by a user administration transaction.
+	Disabled bool `protobuf:"varint,4,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// API signing keys that the user can use to sign requests and transactions, in addition to the private key of its
+	// certificate. This allows each machine client acting on behalf of the user to hold its own key, which can expire
+	// or be revoked independently of the others. The keys are registered by a user administration transaction.
+	SigningKeys          []*SigningKey `protobuf:"bytes,5,rep,name=signing_keys,json=signingKeys,proto3" json:"signing_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *User) Reset()         { *m = User{} }
 func (m *User) String() string { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()    {}
 func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{8}
+	return fileDescriptor_415c9e57263f32ab, []int{13}
 }
 
 func (m *User) XXX_Unmarshal(b []byte) error {
@@ -641,6 +1126,90 @@ func (m *User) GetPrivilege() *Privilege {
 	return nil
 }
 
+func (m *User) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
+func (m *User) GetSigningKeys() []*SigningKey {
+	if m != nil {
+		return m.SigningKeys
+	}
+	return nil
+}
+
+// SigningKey is an API signing key registered to a user.
+type SigningKey struct {
+	// The key ID, unique among the keys of the user.
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// The public key, in PKIX, ASN.1 DER form. ECDSA keys, which sign the SHA-256 digest of the message, and ed25519
+	// keys are supported.
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// The expiry time, in seconds since the Unix epoch, after which signatures by the key are rejected. Zero means
+	// the key does not expire.
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Signatures by a revoked key are rejected. A key can be revoked, rather than removed, to keep a record of it.
+	Revoked              bool     `protobuf:"varint,4,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SigningKey) Reset()         { *m = SigningKey{} }
+func (m *SigningKey) String() string { return proto.CompactTextString(m) }
+func (*SigningKey) ProtoMessage()    {}
+func (*SigningKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_415c9e57263f32ab, []int{14}
+}
+
+func (m *SigningKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SigningKey.Unmarshal(m, b)
+}
+func (m *SigningKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SigningKey.Marshal(b, m, deterministic)
+}
+func (m *SigningKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SigningKey.Merge(m, src)
+}
+func (m *SigningKey) XXX_Size() int {
+	return xxx_messageInfo_SigningKey.Size(m)
+}
+func (m *SigningKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_SigningKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SigningKey proto.InternalMessageInfo
+
+func (m *SigningKey) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *SigningKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *SigningKey) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *SigningKey) GetRevoked() bool {
+	if m != nil {
+		return m.Revoked
+	}
+	return false
+}
+
 // Privilege holds user/group privilege information such as
 // a list of databases to which the read is allowed, a list of
 // databases to which the write is allowed, bools to indicate
@@ -654,7 +1223,19 @@ type Privilege struct {
 	// from any database provided that the state has no ACL defined. If
 	// a state has a read and write ACL, the admin can read or write to
 	// the state only if the admin is listed in the read or write ACL list.
-	Admin                bool     `protobuf:"varint,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	Admin bool `protobuf:"varint,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	// db_administration_prefixes allows a non-admin user to submit database
+	// administration transactions that create, delete, or index databases
+	// whose name path starts with one of the listed prefixes, as whole path
+	// segments, e.g., the prefix "team1" covers "team1/db1". This lets an
+	// application team manage its own databases without cluster admin rights.
+	DbAdministrationPrefixes []string `protobuf:"bytes,3,rep,name=db_administration_prefixes,json=dbAdministrationPrefixes,proto3" json:"db_administration_prefixes,omitempty"`
+	// provenance_read allows a non-admin user to query the provenance of
+	// other users, i.e., the values read, written, or deleted by them and
+	// the transactions submitted by them. A user can always query its own
+	// provenance. Values whose ACL excludes the querying user are never
+	// returned, irrespective of this privilege.
+	ProvenanceRead       bool     `protobuf:"varint,4,opt,name=provenance_read,json=provenanceRead,proto3" json:"provenance_read,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -664,7 +1245,7 @@ func (m *Privilege) Reset()         { *m = Privilege{} }
 func (m *Privilege) String() string { return proto.CompactTextString(m) }
 func (*Privilege) ProtoMessage()    {}
 func (*Privilege) Descriptor() ([]byte, []int) {
-	return fileDescriptor_415c9e57263f32ab, []int{9}
+	return fileDescriptor_415c9e57263f32ab, []int{15}
 }
 
 func (m *Privilege) XXX_Unmarshal(b []byte) error {
@@ -699,17 +1280,39 @@ func (m *Privilege) GetAdmin() bool {
 	return false
 }
 
+func (m *Privilege) GetDbAdministrationPrefixes() []string {
+	if m != nil {
+		return m.DbAdministrationPrefixes
+	}
+	return nil
+}
+
+func (m *Privilege) GetProvenanceRead() bool {
+	if m != nil {
+		return m.ProvenanceRead
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterEnum("types.EndpointPolicy_Group", EndpointPolicy_Group_name, EndpointPolicy_Group_value)
+	proto.RegisterEnum("types.EndpointPolicy_Privilege", EndpointPolicy_Privilege_name, EndpointPolicy_Privilege_value)
 	proto.RegisterEnum("types.Privilege_Access", Privilege_Access_name, Privilege_Access_value)
 	proto.RegisterType((*ClusterConfig)(nil), "types.ClusterConfig")
+	proto.RegisterType((*AccessPolicy)(nil), "types.AccessPolicy")
+	proto.RegisterType((*EndpointPolicy)(nil), "types.EndpointPolicy")
+	proto.RegisterType((*DatabaseQuota)(nil), "types.DatabaseQuota")
 	proto.RegisterType((*NodeConfig)(nil), "types.NodeConfig")
 	proto.RegisterType((*Admin)(nil), "types.Admin")
 	proto.RegisterType((*CAConfig)(nil), "types.CAConfig")
+	proto.RegisterType((*CAScope)(nil), "types.CAScope")
+	proto.RegisterType((*RetiringCAs)(nil), "types.RetiringCAs")
 	proto.RegisterType((*ConsensusConfig)(nil), "types.ConsensusConfig")
 	proto.RegisterType((*PeerConfig)(nil), "types.PeerConfig")
 	proto.RegisterType((*RaftConfig)(nil), "types.RaftConfig")
 	proto.RegisterType((*DatabaseConfig)(nil), "types.DatabaseConfig")
 	proto.RegisterType((*User)(nil), "types.User")
+	proto.RegisterType((*SigningKey)(nil), "types.SigningKey")
 	proto.RegisterType((*Privilege)(nil), "types.Privilege")
 	proto.RegisterMapType((map[string]Privilege_Access)(nil), "types.Privilege.DbPermissionEntry")
 }
@@ -717,56 +1320,99 @@ func init() {
 func init() { proto.RegisterFile("configuration.proto", fileDescriptor_415c9e57263f32ab) }
 
 var fileDescriptor_415c9e57263f32ab = []byte{
-	// 802 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x4d, 0x6f, 0xe4, 0x34,
-	0x18, 0x26, 0xf3, 0xd5, 0xe6, 0x9d, 0xcf, 0x7a, 0x57, 0xbb, 0x23, 0x40, 0xa8, 0x84, 0x45, 0x5b,
-	0x01, 0x9d, 0x91, 0x86, 0x3d, 0xb0, 0xdc, 0x66, 0xbb, 0x7c, 0xf4, 0x82, 0x2a, 0x03, 0x02, 0x71,
-	0x89, 0x9c, 0xe4, 0x9d, 0x89, 0xd5, 0x24, 0x8e, 0x6c, 0xa7, 0xb4, 0x7b, 0xe0, 0xca, 0xef, 0xe2,
-	0x7f, 0x70, 0xe7, 0x6f, 0x20, 0xdb, 0xf1, 0x4c, 0xdb, 0x11, 0x07, 0x6e, 0xf6, 0xf3, 0x3c, 0xaf,
-	0xfd, 0xf8, 0x79, 0xed, 0x04, 0x9e, 0xa4, 0xa2, 0xda, 0xf0, 0x6d, 0x23, 0x99, 0xe6, 0xa2, 0x5a,
-	0xd4, 0x52, 0x68, 0x41, 0xfa, 0xfa, 0xae, 0x46, 0x15, 0xfd, 0x1d, 0xc0, 0xf8, 0xa2, 0x68, 0x94,
-	0x46, 0x79, 0x61, 0x55, 0xe4, 0x25, 0xf4, 0x2b, 0x91, 0xa1, 0x9a, 0x07, 0xa7, 0xdd, 0xb3, 0xe1,
-	0xea, 0x64, 0x61, 0x85, 0x8b, 0x1f, 0x44, 0x86, 0x4e, 0x41, 0x1d, 0x4f, 0x5e, 0xc0, 0x80, 0x65,
-	0x25, 0xaf, 0xd4, 0xbc, 0x63, 0x95, 0xa3, 0x56, 0xb9, 0x36, 0x20, 0x6d, 0x39, 0xf2, 0x1a, 0x66,
-	0x29, 0x4a, 0x1d, 0xb3, 0x46, 0xe7, 0xb1, 0x33, 0x32, 0xef, 0x9e, 0x06, 0x67, 0xc3, 0xd5, 0xb4,
-	0xd5, 0x5f, 0xac, 0xdb, 0x75, 0x27, 0x46, 0xb8, 0x6e, 0x74, 0xde, 0x3a, 0x59, 0xc3, 0x2c, 0x15,
-	0x95, 0xc2, 0x4a, 0x35, 0xca, 0x97, 0xf6, 0x6c, 0xe9, 0x33, 0x5f, 0xea, 0xe9, 0x76, 0x85, 0x69,
-	0xfa, 0x10, 0x88, 0x0a, 0x80, 0xbd, 0x71, 0x32, 0x81, 0x0e, 0xcf, 0xe6, 0xc1, 0x69, 0x70, 0x16,
-	0xd2, 0x0e, 0xcf, 0xc8, 0x1c, 0x8e, 0x58, 0x96, 0x49, 0x54, 0xe6, 0x08, 0x06, 0xf4, 0x53, 0x42,
-	0xa0, 0x57, 0x0b, 0xa9, 0xad, 0xd3, 0x31, 0xb5, 0x63, 0x72, 0x0a, 0x43, 0x63, 0x90, 0x6f, 0x78,
-	0xca, 0x34, 0x5a, 0x27, 0x23, 0x7a, 0x1f, 0x8a, 0x5e, 0x43, 0xdf, 0x1e, 0xfe, 0x60, 0xa3, 0x47,
-	0xa5, 0x9d, 0xc3, 0xd2, 0x6f, 0xe1, 0xd8, 0xe7, 0x40, 0x9e, 0x42, 0x5f, 0x0a, 0xa1, 0x5d, 0x07,
-	0x46, 0xd4, 0x4d, 0xc8, 0x0b, 0x18, 0xf3, 0x4a, 0xa3, 0x2c, 0x31, 0xe3, 0x4c, 0xa3, 0x4b, 0x7d,
-	0x44, 0x1f, 0x82, 0xd1, 0x5f, 0x01, 0x4c, 0x1f, 0xa5, 0x42, 0x3e, 0x84, 0x90, 0x15, 0x5b, 0x21,
-	0xb9, 0xce, 0xcb, 0xd6, 0xd4, 0x1e, 0x20, 0x9f, 0xc3, 0x51, 0x89, 0x65, 0x82, 0xd2, 0xf7, 0xd1,
-	0x77, 0xfc, 0x0a, 0xfd, 0x9d, 0xa0, 0x5e, 0x41, 0x96, 0x10, 0x8a, 0x44, 0xa1, 0xbc, 0x31, 0xf2,
-	0xee, 0x7f, 0xc9, 0xf7, 0x1a, 0xb2, 0x82, 0xa1, 0x64, 0x1b, 0xfd, 0xb0, 0x7d, 0xbe, 0x84, 0xb2,
-	0x8d, 0x6e, 0x4b, 0x40, 0xee, 0xc6, 0xd1, 0x2d, 0xc0, 0x7e, 0x31, 0xf2, 0x1c, 0x8e, 0xcc, 0x7d,
-	0x8b, 0x77, 0x81, 0x0e, 0xcc, 0xf4, 0x32, 0x33, 0x84, 0x5d, 0x9a, 0x67, 0x36, 0xd0, 0x1e, 0x1d,
-	0x98, 0xe9, 0x65, 0x46, 0x3e, 0x80, 0xb0, 0x46, 0x94, 0x71, 0x2e, 0x94, 0xeb, 0x60, 0x48, 0x8f,
-	0x0d, 0xf0, 0xbd, 0x50, 0x7a, 0x47, 0xda, 0xf6, 0xf6, 0x6c, 0x7b, 0x2d, 0x79, 0x25, 0xa4, 0x8e,
-	0xfe, 0xec, 0x00, 0xec, 0x4d, 0x91, 0x4f, 0x60, 0xac, 0x79, 0x7a, 0x1d, 0xdb, 0x88, 0x6f, 0x58,
-	0xd1, 0x1a, 0x18, 0x19, 0xf0, 0xb2, 0xc5, 0xc8, 0xa7, 0x30, 0xc1, 0x02, 0x53, 0xf3, 0xb4, 0x62,
-	0x43, 0xb8, 0xbb, 0x34, 0xa6, 0x63, 0x8f, 0xfe, 0x64, 0x40, 0xf2, 0x12, 0xa6, 0x39, 0x32, 0xa9,
-	0x13, 0x64, 0xba, 0xd5, 0xb9, 0xcb, 0x35, 0xd9, 0xc1, 0x4e, 0xb8, 0x80, 0x27, 0x25, 0xbb, 0x8d,
-	0x79, 0xb5, 0x29, 0xf8, 0x36, 0xd7, 0x71, 0x52, 0x08, 0x23, 0x76, 0x56, 0x4f, 0x4a, 0x76, 0x7b,
-	0xd9, 0x32, 0x6f, 0x2c, 0x41, 0x5e, 0xc1, 0x33, 0x55, 0xb1, 0x5a, 0xe5, 0x42, 0xef, 0x8c, 0xc6,
-	0x8a, 0xbf, 0xc3, 0x79, 0xdf, 0xa6, 0xf2, 0xd4, 0xb3, 0xde, 0xf1, 0x8f, 0xfc, 0x1d, 0x92, 0x8f,
-	0x60, 0x68, 0x76, 0xf1, 0x01, 0x0e, 0xac, 0x34, 0x2c, 0xd9, 0x2d, 0xb5, 0x19, 0x46, 0x7f, 0xc0,
-	0xe4, 0x2d, 0xd3, 0x2c, 0x61, 0xca, 0x3f, 0x1e, 0x02, 0xbd, 0x8a, 0x95, 0xd8, 0x66, 0x60, 0xc7,
-	0xe4, 0x33, 0x38, 0x91, 0xc8, 0xb2, 0x98, 0xa5, 0x29, 0x2a, 0x15, 0x37, 0xca, 0xdf, 0xa2, 0x90,
-	0x4e, 0x0d, 0xb1, 0xb6, 0xf8, 0xcf, 0x06, 0x26, 0x5f, 0x00, 0xf9, 0x5d, 0x72, 0x8d, 0x0f, 0xc5,
-	0x5d, 0x2b, 0x9e, 0x59, 0xe6, 0x9e, 0x3a, 0xca, 0xa1, 0x67, 0x06, 0xff, 0xff, 0x25, 0x91, 0x05,
-	0x84, 0xb5, 0xe4, 0x37, 0xbc, 0xc0, 0x2d, 0xb6, 0x5f, 0x9a, 0x99, 0xbf, 0xa2, 0x1e, 0xa7, 0x7b,
-	0x49, 0xf4, 0x4f, 0x00, 0xe1, 0x8e, 0x20, 0xdf, 0xc1, 0x38, 0x4b, 0xe2, 0x1a, 0x65, 0xc9, 0x95,
-	0xe2, 0xa2, 0x6a, 0xbf, 0x82, 0xd1, 0xe3, 0x15, 0x16, 0x6f, 0x93, 0xab, 0x9d, 0xe8, 0x9b, 0x4a,
-	0xcb, 0x3b, 0x3a, 0xca, 0xee, 0x41, 0xe6, 0x11, 0xdb, 0x2f, 0xa0, 0xb5, 0x78, 0x4c, 0xdd, 0xe4,
-	0xfd, 0x5f, 0xe1, 0xe4, 0xa0, 0x90, 0xcc, 0xa0, 0x7b, 0x8d, 0x77, 0xed, 0x21, 0xcd, 0x90, 0x9c,
-	0x43, 0xff, 0x86, 0x15, 0x8d, 0x3b, 0xdf, 0x64, 0xf5, 0xfc, 0x60, 0x77, 0x17, 0x15, 0x75, 0xaa,
-	0xaf, 0x3b, 0x5f, 0x05, 0xd1, 0xc7, 0x30, 0x70, 0x20, 0x39, 0x86, 0x1e, 0x45, 0x96, 0xcd, 0xde,
-	0x23, 0x63, 0x08, 0xcd, 0xe8, 0x17, 0x13, 0xee, 0x2c, 0x78, 0xf3, 0xea, 0xb7, 0xd5, 0x96, 0xeb,
-	0xbc, 0x49, 0x16, 0xa9, 0x28, 0x97, 0xf9, 0x5d, 0x8d, 0xb2, 0xc0, 0x6c, 0x8b, 0xf2, 0xbc, 0x60,
-	0x89, 0x5a, 0x0a, 0xc9, 0x45, 0x75, 0xee, 0x1e, 0xee, 0xb2, 0xbe, 0xde, 0x2e, 0xed, 0xa6, 0xc9,
-	0xc0, 0xfe, 0x2f, 0xbe, 0xfc, 0x37, 0x00, 0x00, 0xff, 0xff, 0x43, 0x90, 0xf6, 0xbd, 0x46, 0x06,
-	0x00, 0x00,
+	// 1491 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcb, 0x72, 0x24, 0x39,
+	0x15, 0xed, 0x7a, 0xba, 0xf2, 0xd6, 0xc3, 0x69, 0xb9, 0xa7, 0x27, 0xe9, 0x61, 0x18, 0x4f, 0x32,
+	0xd0, 0xe6, 0xd1, 0xe5, 0x18, 0x4f, 0x2f, 0x06, 0x02, 0x88, 0xa8, 0xb6, 0x0b, 0x77, 0x05, 0x8c,
+	0x5d, 0xc8, 0xee, 0xe6, 0xb1, 0xc9, 0x50, 0xa6, 0xe4, 0x2a, 0x85, 0xab, 0x52, 0x89, 0xa4, 0x32,
+	0x55, 0x4d, 0x04, 0x2b, 0x22, 0x58, 0xf0, 0x01, 0xb0, 0xe2, 0x43, 0xd8, 0xf1, 0x03, 0x7c, 0x04,
+	0x5f, 0x42, 0xe8, 0x91, 0xf5, 0x70, 0x37, 0x1b, 0x66, 0x27, 0x9d, 0x73, 0x74, 0x75, 0x75, 0x53,
+	0x3a, 0x37, 0xe1, 0x30, 0x13, 0xf9, 0x2d, 0x9f, 0x2c, 0x24, 0xd1, 0x5c, 0xe4, 0xfd, 0x42, 0x0a,
+	0x2d, 0x50, 0x43, 0xaf, 0x0a, 0xa6, 0xe2, 0xbf, 0xd5, 0xa0, 0x7b, 0x36, 0x5b, 0x28, 0xcd, 0xe4,
+	0x99, 0x55, 0xa1, 0x67, 0xd0, 0xc8, 0x05, 0x65, 0x2a, 0xaa, 0x1c, 0xd5, 0x8e, 0xdb, 0xa7, 0x07,
+	0x7d, 0x2b, 0xec, 0x5f, 0x0a, 0xca, 0x9c, 0x02, 0x3b, 0x1e, 0x7d, 0x06, 0x4d, 0x42, 0xe7, 0x3c,
+	0x57, 0x51, 0xd5, 0x2a, 0x3b, 0x5e, 0x39, 0x30, 0x20, 0xf6, 0x1c, 0xfa, 0x11, 0x84, 0x19, 0x93,
+	0x3a, 0x21, 0x0b, 0x3d, 0x4d, 0x5c, 0x22, 0x51, 0xed, 0xa8, 0x72, 0xdc, 0x3e, 0xdd, 0xf7, 0xfa,
+	0xb3, 0x81, 0x8f, 0xdb, 0x33, 0xc2, 0xc1, 0x42, 0x4f, 0x7d, 0x26, 0x03, 0x08, 0x33, 0x91, 0x2b,
+	0x96, 0xab, 0x85, 0x2a, 0x97, 0xd6, 0xed, 0xd2, 0x27, 0xe5, 0xd2, 0x92, 0xf6, 0x11, 0xf6, 0xb3,
+	0x5d, 0x00, 0x7d, 0x0f, 0x42, 0x7b, 0xdc, 0x4c, 0xcc, 0x92, 0x7b, 0x26, 0x15, 0x17, 0x79, 0xd4,
+	0x38, 0xaa, 0x1c, 0x77, 0xf1, 0x7e, 0x89, 0xbf, 0x71, 0x30, 0xfa, 0x1c, 0x02, 0x9a, 0x26, 0xbf,
+	0x5f, 0x08, 0x4d, 0x54, 0xd4, 0xb4, 0x27, 0x7a, 0xec, 0xb7, 0x39, 0x27, 0x9a, 0xa4, 0x44, 0xb1,
+	0x5f, 0x19, 0x12, 0xb7, 0x68, 0x6a, 0x07, 0x0a, 0x7d, 0x0a, 0x1d, 0x26, 0x89, 0x22, 0xe9, 0x8c,
+	0x25, 0x34, 0x55, 0xd1, 0xde, 0x51, 0xed, 0x38, 0xc0, 0xed, 0x12, 0x3b, 0x4f, 0x15, 0xfa, 0x12,
+	0xba, 0x24, 0xcb, 0x98, 0x52, 0x49, 0x21, 0x66, 0x3c, 0x5b, 0x45, 0x2d, 0x7b, 0x80, 0xc3, 0xb2,
+	0x56, 0x96, 0x1b, 0x5b, 0x0a, 0x77, 0xc8, 0xd6, 0x2c, 0x3e, 0x83, 0xce, 0x36, 0x8b, 0xbe, 0x80,
+	0x80, 0xe5, 0xb4, 0x10, 0x3c, 0xd7, 0xe5, 0xb7, 0xf9, 0xc0, 0x47, 0x19, 0x7a, 0xdc, 0xc7, 0xd9,
+	0xe8, 0xe2, 0xbf, 0x56, 0xa1, 0xb7, 0xcb, 0xa2, 0xcf, 0xa1, 0x31, 0x91, 0x62, 0x51, 0x44, 0x95,
+	0xa3, 0xca, 0x71, 0xef, 0xf4, 0xa3, 0xf7, 0xc6, 0xe8, 0x5f, 0x18, 0x09, 0x76, 0x4a, 0xf4, 0x53,
+	0x08, 0x0a, 0xc9, 0xef, 0xf9, 0x8c, 0x4d, 0x58, 0x54, 0xb5, 0xcb, 0x3e, 0x79, 0xff, 0xb2, 0x71,
+	0x29, 0xc3, 0x9b, 0x15, 0xf1, 0x05, 0x34, 0x6c, 0x38, 0x04, 0xd0, 0xfc, 0xe5, 0xf0, 0xfc, 0x62,
+	0x88, 0xc3, 0x47, 0xa8, 0x07, 0x30, 0xc6, 0x57, 0x6f, 0x86, 0x97, 0x83, 0xcb, 0xb3, 0x61, 0x58,
+	0x41, 0x08, 0x7a, 0x67, 0x57, 0x97, 0x3f, 0x1f, 0x5d, 0x24, 0xaf, 0x46, 0xd7, 0x37, 0x57, 0xf8,
+	0xb7, 0x61, 0xd5, 0x68, 0xae, 0xc6, 0x43, 0x3c, 0xb8, 0x19, 0x5d, 0x5d, 0x5e, 0x87, 0xb5, 0xf8,
+	0x67, 0x10, 0xac, 0x37, 0x40, 0x07, 0xd0, 0x1d, 0xbc, 0xbe, 0x79, 0x35, 0xbc, 0xbc, 0x19, 0x9d,
+	0x0d, 0x6e, 0x86, 0xe7, 0xe1, 0x23, 0x74, 0x08, 0xfb, 0x9b, 0x98, 0x09, 0x1e, 0x0e, 0xce, 0xc3,
+	0x0a, 0x0a, 0xa0, 0x31, 0x38, 0xff, 0x6a, 0x74, 0x19, 0x56, 0xe3, 0xff, 0x54, 0xa0, 0xbb, 0xf3,
+	0x2d, 0xd1, 0x87, 0xb0, 0x47, 0xd3, 0x24, 0x27, 0x73, 0x66, 0xcb, 0x11, 0xe0, 0x26, 0x4d, 0x2f,
+	0xc9, 0x9c, 0xa1, 0x1f, 0x02, 0x52, 0xe2, 0x56, 0x27, 0x4a, 0x0b, 0x49, 0x26, 0x2c, 0x49, 0x57,
+	0x9a, 0x29, 0x7b, 0xf6, 0x3a, 0x0e, 0x0d, 0x73, 0xed, 0x88, 0x97, 0x06, 0x37, 0xea, 0x29, 0x91,
+	0xf4, 0x81, 0xba, 0xe6, 0xd4, 0x86, 0xd9, 0x51, 0x3f, 0x87, 0x43, 0x1b, 0x5b, 0x2f, 0x55, 0x52,
+	0x30, 0x99, 0xcc, 0x79, 0xbe, 0xd0, 0x2c, 0xaa, 0x6f, 0x82, 0xdf, 0x2c, 0xd5, 0x98, 0xc9, 0xaf,
+	0x2c, 0x6e, 0xe4, 0x36, 0xf8, 0x03, 0x79, 0x63, 0x13, 0x7d, 0x5b, 0x1e, 0xff, 0xbd, 0x02, 0xb0,
+	0x79, 0xac, 0xa8, 0x07, 0x55, 0x4e, 0xfd, 0xe1, 0xaa, 0x9c, 0xa2, 0x08, 0xf6, 0x08, 0xa5, 0x92,
+	0x29, 0x77, 0x9a, 0x00, 0x97, 0x53, 0x84, 0xa0, 0x5e, 0x08, 0xa9, 0x6d, 0xda, 0x5d, 0x6c, 0xc7,
+	0xe8, 0x08, 0xda, 0xe6, 0x51, 0xf2, 0x5b, 0x9e, 0x11, 0x9f, 0x62, 0x07, 0x6f, 0x43, 0xe8, 0x09,
+	0x34, 0x25, 0x9b, 0x94, 0xef, 0x2a, 0xc0, 0x7e, 0x66, 0xa2, 0xbd, 0x15, 0x39, 0x8b, 0x9a, 0x16,
+	0xb5, 0xe3, 0x78, 0x01, 0x0d, 0x6b, 0x0e, 0xef, 0x24, 0xf5, 0x60, 0x9b, 0xea, 0xbb, 0xdb, 0x7c,
+	0x03, 0x5a, 0xc6, 0x75, 0x12, 0x4e, 0x4d, 0x5d, 0xcd, 0x33, 0xdb, 0x33, 0xf3, 0x11, 0x55, 0xe8,
+	0x13, 0x68, 0xab, 0x85, 0x29, 0x8c, 0x75, 0x1c, 0x9b, 0x63, 0x0b, 0x83, 0x85, 0xec, 0x6e, 0xf1,
+	0x3f, 0x2a, 0xd0, 0x2a, 0x4d, 0x06, 0x3d, 0x86, 0x86, 0x14, 0xc2, 0x3f, 0xa1, 0x0e, 0x76, 0x13,
+	0xf4, 0x19, 0x74, 0x79, 0xae, 0x99, 0x9c, 0x33, 0xca, 0x89, 0xfb, 0xd2, 0x86, 0xdd, 0x05, 0x51,
+	0x1f, 0x5a, 0x92, 0x69, 0x2e, 0x79, 0x3e, 0xb1, 0x49, 0xb4, 0x4f, 0x91, 0x7f, 0x06, 0xd8, 0xc3,
+	0x67, 0x03, 0x85, 0xd7, 0x1a, 0xf4, 0x5d, 0x68, 0xaa, 0x4c, 0x14, 0x4c, 0x45, 0x75, 0xab, 0xee,
+	0xad, 0x1d, 0xef, 0xda, 0xc0, 0xd8, 0xb3, 0xf1, 0x9f, 0x2b, 0xb0, 0xe7, 0x31, 0x14, 0x43, 0x47,
+	0xc8, 0x09, 0xc9, 0xf9, 0x5b, 0xeb, 0xd6, 0xbe, 0x48, 0x3b, 0x18, 0x0a, 0xa1, 0x96, 0x91, 0x32,
+	0x47, 0x33, 0x44, 0xc7, 0x10, 0x2e, 0x14, 0x93, 0x09, 0xa7, 0x49, 0x21, 0xd9, 0x2d, 0x5f, 0xb2,
+	0xb2, 0x4c, 0x3d, 0x83, 0x8f, 0xe8, 0xd8, 0xa3, 0x3b, 0x85, 0xac, 0xef, 0x14, 0x32, 0x5e, 0x41,
+	0x7b, 0xeb, 0x1c, 0x5f, 0xb3, 0x52, 0x87, 0x5a, 0x9a, 0xae, 0x42, 0x93, 0x45, 0xae, 0xf9, 0x2c,
+	0x49, 0x67, 0x22, 0xbb, 0xf3, 0x2f, 0xe2, 0xc0, 0x53, 0xaf, 0x0d, 0xf3, 0xd2, 0x10, 0xf1, 0xbf,
+	0x2a, 0xb0, 0xff, 0xc0, 0xcc, 0xd1, 0x37, 0x21, 0x20, 0xb3, 0x89, 0x90, 0x5c, 0x4f, 0xe7, 0xbe,
+	0x0c, 0x1b, 0x00, 0xfd, 0x00, 0xf6, 0xe6, 0x6c, 0x9e, 0x32, 0x59, 0xb6, 0x9f, 0xb2, 0x51, 0x8d,
+	0x59, 0xd9, 0xca, 0x70, 0xa9, 0x40, 0x27, 0x10, 0x88, 0x54, 0x31, 0x69, 0x5a, 0x40, 0x54, 0xfb,
+	0x5f, 0xf2, 0x8d, 0x06, 0x9d, 0x42, 0x5b, 0x92, 0x5b, 0xbd, 0xdb, 0x75, 0xca, 0x25, 0x98, 0xdc,
+	0x6a, 0xbf, 0x04, 0xe4, 0x7a, 0x1c, 0x2f, 0x01, 0x36, 0xc1, 0x8c, 0xb3, 0xf8, 0x3a, 0x97, 0xce,
+	0xe2, 0xca, 0x6c, 0x08, 0x1b, 0x9a, 0x53, 0x6f, 0x27, 0x4d, 0x33, 0x1d, 0x51, 0xf4, 0x11, 0x04,
+	0x05, 0x63, 0x32, 0x99, 0x0a, 0xe5, 0x1e, 0x61, 0x80, 0x5b, 0x06, 0x78, 0x25, 0x94, 0x5e, 0x93,
+	0xf6, 0x85, 0xd6, 0xed, 0x0b, 0xb5, 0xe4, 0x58, 0x48, 0x1d, 0xff, 0xa5, 0x0a, 0xb0, 0x49, 0x0a,
+	0x7d, 0x1b, 0xba, 0x9a, 0x67, 0x77, 0x89, 0xfd, 0x24, 0xf7, 0x64, 0x56, 0xde, 0x21, 0x03, 0x8e,
+	0x3c, 0x86, 0xbe, 0x03, 0x3d, 0x36, 0x63, 0x99, 0xb9, 0x4f, 0x89, 0x21, 0x9c, 0x1d, 0x74, 0x71,
+	0xb7, 0x44, 0x6f, 0x0c, 0x88, 0x9e, 0xc1, 0xfe, 0x94, 0x11, 0xa9, 0x53, 0x46, 0xb4, 0xd7, 0x39,
+	0x7f, 0xe8, 0xad, 0x61, 0x27, 0xec, 0xc3, 0xe1, 0x9c, 0x2c, 0x13, 0x9e, 0xdf, 0xce, 0xf8, 0x64,
+	0xaa, 0xdd, 0x07, 0x57, 0x3e, 0xd5, 0x83, 0x39, 0x59, 0x8e, 0x3c, 0x63, 0x3f, 0xb8, 0x42, 0x2f,
+	0xe0, 0x89, 0xca, 0x49, 0xa1, 0xa6, 0x42, 0xaf, 0x13, 0x4d, 0x14, 0x7f, 0x5b, 0x1a, 0xdb, 0xe3,
+	0x92, 0x2d, 0x33, 0xbe, 0xe6, 0x6f, 0x19, 0xfa, 0x16, 0xb4, 0xcd, 0x2e, 0x65, 0x01, 0x9b, 0x56,
+	0x1a, 0xcc, 0xc9, 0x12, 0xdb, 0x1a, 0xc6, 0x7f, 0x82, 0x5e, 0x69, 0xf0, 0xbe, 0x18, 0x08, 0xea,
+	0x5b, 0xf6, 0x6e, 0xc7, 0xe8, 0xfb, 0x70, 0x20, 0x19, 0xa1, 0x89, 0xef, 0xcc, 0xe6, 0x85, 0xb8,
+	0x5b, 0x14, 0xe0, 0x7d, 0x43, 0xb8, 0xbe, 0xfb, 0xda, 0xc0, 0xc6, 0xda, 0xff, 0x20, 0xb9, 0x66,
+	0xbb, 0x62, 0xf7, 0xb6, 0x42, 0xcb, 0x6c, 0xa9, 0xe3, 0x7f, 0x56, 0xa0, 0x6e, 0x46, 0xff, 0x87,
+	0xc3, 0xf5, 0xb7, 0x9b, 0xac, 0xfb, 0x43, 0x0a, 0xcb, 0x3b, 0xfa, 0x9e, 0xae, 0x8a, 0x9e, 0x42,
+	0x8b, 0x72, 0xfb, 0x9f, 0x41, 0xbd, 0xe7, 0xad, 0xe7, 0xe8, 0x05, 0x74, 0x14, 0x9f, 0xe4, 0x3c,
+	0x9f, 0x24, 0x77, 0x6c, 0xa5, 0xa2, 0xc6, 0xce, 0x95, 0xbf, 0x76, 0xd4, 0x2f, 0xd8, 0x0a, 0xb7,
+	0xd5, 0x7a, 0xac, 0xe2, 0x3f, 0x02, 0x6c, 0x28, 0xf4, 0x01, 0x34, 0xef, 0xd8, 0x6a, 0x73, 0x7f,
+	0x1b, 0x77, 0x6c, 0x35, 0xa2, 0xe8, 0x63, 0x80, 0x62, 0x91, 0xce, 0x78, 0x66, 0x22, 0xfb, 0x73,
+	0x04, 0x0e, 0x31, 0xab, 0x3e, 0x06, 0x60, 0xcb, 0x82, 0x4b, 0xa6, 0x12, 0xe2, 0x6e, 0x71, 0x0d,
+	0x07, 0x1e, 0x19, 0x68, 0xd3, 0x7d, 0x24, 0xbb, 0x17, 0x77, 0xeb, 0x9c, 0xcb, 0x69, 0xfc, 0xef,
+	0xea, 0x76, 0x73, 0xbf, 0x80, 0x2e, 0x4d, 0x4d, 0xb7, 0x9b, 0x73, 0xa5, 0x9c, 0x0d, 0x9a, 0x13,
+	0xc4, 0x0f, 0x0b, 0xd2, 0x3f, 0x4f, 0xc7, 0x6b, 0xd1, 0x30, 0xd7, 0x72, 0x85, 0x3b, 0x74, 0x0b,
+	0x32, 0x26, 0xe6, 0xda, 0x42, 0xd5, 0x6e, 0xe7, 0x26, 0xe8, 0x27, 0xf0, 0x94, 0xa6, 0xae, 0x5f,
+	0x70, 0xa5, 0xdd, 0x7f, 0xf1, 0x43, 0xe3, 0x8c, 0x68, 0x3a, 0xd8, 0x11, 0xac, 0x2d, 0xf4, 0x19,
+	0x98, 0x9f, 0xc7, 0x7b, 0x96, 0x93, 0x3c, 0x63, 0x89, 0xb9, 0x30, 0xfe, 0x30, 0xbd, 0x0d, 0x8c,
+	0x19, 0xa1, 0x4f, 0x7f, 0x03, 0x07, 0xef, 0xe4, 0x67, 0xcc, 0xdb, 0x54, 0xce, 0x15, 0xd5, 0x0c,
+	0xd1, 0x73, 0x68, 0xdc, 0x93, 0xd9, 0xa2, 0xfc, 0xb5, 0xfa, 0xf0, 0x9d, 0x43, 0xba, 0x1b, 0x86,
+	0x9d, 0xea, 0xc7, 0xd5, 0x2f, 0x2b, 0xf1, 0xa7, 0xd0, 0x74, 0x20, 0x6a, 0x41, 0xdd, 0xec, 0x15,
+	0x3e, 0x42, 0x5d, 0x08, 0xcc, 0xe8, 0xd7, 0xe6, 0x4e, 0x86, 0x95, 0x97, 0x2f, 0x7e, 0x77, 0x3a,
+	0xe1, 0x7a, 0xba, 0x48, 0xfb, 0x99, 0x98, 0x9f, 0x4c, 0x57, 0x05, 0x93, 0x33, 0x46, 0x27, 0x4c,
+	0x3e, 0x9f, 0x91, 0x54, 0x9d, 0x08, 0xc9, 0x45, 0xfe, 0xdc, 0xf9, 0xdd, 0x49, 0x71, 0x37, 0x39,
+	0xb1, 0x9b, 0xa6, 0x4d, 0xfb, 0x5b, 0xfc, 0xc5, 0x7f, 0x07, 0x00, 0x92, 0xab, 0x82, 0x97, 0x34,
+	0x0c, 0x00, 0x00,
 }
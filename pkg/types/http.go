@@ -10,3 +10,58 @@ type HttpResponseErr struct {
 func (e *HttpResponseErr) Error() string {
 	return e.ErrMsg
 }
+
+// Failpoints holds the failpoints enabled on a node. It is used as the body of the response of the diagnostics endpoint
+// GET /debug/failpoints.
+type Failpoints struct {
+	// Enabled maps the name of every enabled failpoint to its action
+	Enabled map[string]string `json:"enabled"`
+}
+
+// GCStats holds the garbage collection and memory statistics of the Go runtime of a node. It is used as the body of
+// the response of the diagnostics endpoint GET /debug/gcstats.
+type GCStats struct {
+	Goroutines int `json:"goroutines"`
+	// NumGC is the number of completed GC cycles
+	NumGC int64 `json:"num_gc"`
+	// LastGC is the time of the last GC cycle, in RFC3339 format, or empty if no GC cycle has completed
+	LastGC string `json:"last_gc,omitempty"`
+	// PauseTotal is the total stop-the-world pause time of all GC cycles
+	PauseTotal string `json:"pause_total"`
+	// RecentPauses holds the pause times of the most recent GC cycles, most recent first
+	RecentPauses []string `json:"recent_pauses,omitempty"`
+	// GCCPUFraction is the fraction of the available CPU time used by the GC since the process started
+	GCCPUFraction float64 `json:"gc_cpu_fraction"`
+	HeapAlloc     uint64  `json:"heap_alloc_bytes"`
+	HeapInuse     uint64  `json:"heap_inuse_bytes"`
+	HeapIdle      uint64  `json:"heap_idle_bytes"`
+	HeapReleased  uint64  `json:"heap_released_bytes"`
+	HeapObjects   uint64  `json:"heap_objects"`
+	// NextGC is the target heap size of the next GC cycle
+	NextGC uint64 `json:"next_gc_bytes"`
+	// Sys is the total memory obtained from the OS
+	Sys uint64 `json:"sys_bytes"`
+}
+
+// OpenSnapshots holds the snapshots of the state database of a node that are not yet released. It is used as the body
+// of the response of the diagnostics endpoint GET /debug/snapshots.
+type OpenSnapshots struct {
+	// Snapshots holds the open snapshots, oldest first
+	Snapshots []*OpenSnapshot `json:"snapshots"`
+}
+
+// OpenSnapshot describes a snapshot of the state database that is not yet released
+type OpenSnapshot struct {
+	ID uint64 `json:"id"`
+	// Owner names the component of the node that took the snapshot, e.g., "JSON query" or "data cursor"
+	Owner string   `json:"owner"`
+	DBs   []string `json:"dbs"`
+	// Height is the height of the state database when the snapshot was taken
+	Height uint64 `json:"height"`
+	// Acquired is the time the snapshot was taken, in RFC3339 format
+	Acquired string `json:"acquired"`
+	// Age is the time the snapshot has been held for
+	Age string `json:"age"`
+	// Idle is the time since the last read of the snapshot
+	Idle string `json:"idle"`
+}
@@ -42,7 +42,7 @@ func (x GetMostRecentUserOrNodeQuery_Type) String() string {
 }
 
 func (GetMostRecentUserOrNodeQuery_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{40, 0}
+	return fileDescriptor_5c6ac9b241082464, []int{89, 0}
 }
 
 type GetDBStatusQueryEnvelope struct {
@@ -139,568 +139,2620 @@ func (m *GetDBStatusQuery) GetDbName() string {
 	return ""
 }
 
-type GetDataQueryEnvelope struct {
-	Payload              *GetDataQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte        `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+type GetDBsQueryEnvelope struct {
+	Payload              *GetDBsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte       `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *GetDataQueryEnvelope) Reset()         { *m = GetDataQueryEnvelope{} }
-func (m *GetDataQueryEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetDataQueryEnvelope) ProtoMessage()    {}
-func (*GetDataQueryEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetDBsQueryEnvelope) Reset()         { *m = GetDBsQueryEnvelope{} }
+func (m *GetDBsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDBsQueryEnvelope) ProtoMessage()    {}
+func (*GetDBsQueryEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{2}
 }
 
-func (m *GetDataQueryEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataQueryEnvelope.Unmarshal(m, b)
+func (m *GetDBsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBsQueryEnvelope.Unmarshal(m, b)
 }
-func (m *GetDataQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataQueryEnvelope.Marshal(b, m, deterministic)
+func (m *GetDBsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBsQueryEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetDataQueryEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataQueryEnvelope.Merge(m, src)
+func (m *GetDBsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBsQueryEnvelope.Merge(m, src)
 }
-func (m *GetDataQueryEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetDataQueryEnvelope.Size(m)
+func (m *GetDBsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDBsQueryEnvelope.Size(m)
 }
-func (m *GetDataQueryEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataQueryEnvelope.DiscardUnknown(m)
+func (m *GetDBsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBsQueryEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataQueryEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetDBsQueryEnvelope proto.InternalMessageInfo
 
-func (m *GetDataQueryEnvelope) GetPayload() *GetDataQuery {
+func (m *GetDBsQueryEnvelope) GetPayload() *GetDBsQuery {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (m *GetDataQueryEnvelope) GetSignature() []byte {
+func (m *GetDBsQueryEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetDataQuery struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+// GetDBsQuery lists the databases the user can access. An empty prefix lists
+// all databases while a prefix such as org1/app2 lists only the databases
+// within that hierarchical namespace
+type GetDBsQuery struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// offset is the number of databases skipped from the start of the list
+	Offset uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is the maximum number of databases returned, where zero means no limit
+	Limit                uint64   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetDataQuery) Reset()         { *m = GetDataQuery{} }
-func (m *GetDataQuery) String() string { return proto.CompactTextString(m) }
-func (*GetDataQuery) ProtoMessage()    {}
-func (*GetDataQuery) Descriptor() ([]byte, []int) {
+func (m *GetDBsQuery) Reset()         { *m = GetDBsQuery{} }
+func (m *GetDBsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetDBsQuery) ProtoMessage()    {}
+func (*GetDBsQuery) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{3}
 }
 
-func (m *GetDataQuery) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataQuery.Unmarshal(m, b)
+func (m *GetDBsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBsQuery.Unmarshal(m, b)
 }
-func (m *GetDataQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataQuery.Marshal(b, m, deterministic)
+func (m *GetDBsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBsQuery.Marshal(b, m, deterministic)
 }
-func (m *GetDataQuery) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataQuery.Merge(m, src)
+func (m *GetDBsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBsQuery.Merge(m, src)
 }
-func (m *GetDataQuery) XXX_Size() int {
-	return xxx_messageInfo_GetDataQuery.Size(m)
+func (m *GetDBsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetDBsQuery.Size(m)
 }
-func (m *GetDataQuery) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataQuery.DiscardUnknown(m)
+func (m *GetDBsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBsQuery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataQuery proto.InternalMessageInfo
+var xxx_messageInfo_GetDBsQuery proto.InternalMessageInfo
 
-func (m *GetDataQuery) GetUserId() string {
+func (m *GetDBsQuery) GetUserId() string {
 	if m != nil {
 		return m.UserId
 	}
 	return ""
 }
 
-func (m *GetDataQuery) GetDbName() string {
+func (m *GetDBsQuery) GetPrefix() string {
 	if m != nil {
-		return m.DbName
+		return m.Prefix
 	}
 	return ""
 }
 
-func (m *GetDataQuery) GetKey() string {
+func (m *GetDBsQuery) GetOffset() uint64 {
 	if m != nil {
-		return m.Key
+		return m.Offset
 	}
-	return ""
+	return 0
 }
 
-type GetUserQueryEnvelope struct {
-	Payload              *GetUserQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte        `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+func (m *GetDBsQuery) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
 }
 
-func (m *GetUserQueryEnvelope) Reset()         { *m = GetUserQueryEnvelope{} }
-func (m *GetUserQueryEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetUserQueryEnvelope) ProtoMessage()    {}
-func (*GetUserQueryEnvelope) Descriptor() ([]byte, []int) {
+type GetIndexUsageQueryEnvelope struct {
+	Payload              *GetIndexUsageQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetIndexUsageQueryEnvelope) Reset()         { *m = GetIndexUsageQueryEnvelope{} }
+func (m *GetIndexUsageQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetIndexUsageQueryEnvelope) ProtoMessage()    {}
+func (*GetIndexUsageQueryEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{4}
 }
 
-func (m *GetUserQueryEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetUserQueryEnvelope.Unmarshal(m, b)
+func (m *GetIndexUsageQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexUsageQueryEnvelope.Unmarshal(m, b)
 }
-func (m *GetUserQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetUserQueryEnvelope.Marshal(b, m, deterministic)
+func (m *GetIndexUsageQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexUsageQueryEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetUserQueryEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetUserQueryEnvelope.Merge(m, src)
+func (m *GetIndexUsageQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexUsageQueryEnvelope.Merge(m, src)
 }
-func (m *GetUserQueryEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetUserQueryEnvelope.Size(m)
+func (m *GetIndexUsageQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetIndexUsageQueryEnvelope.Size(m)
 }
-func (m *GetUserQueryEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetUserQueryEnvelope.DiscardUnknown(m)
+func (m *GetIndexUsageQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexUsageQueryEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetUserQueryEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetIndexUsageQueryEnvelope proto.InternalMessageInfo
 
-func (m *GetUserQueryEnvelope) GetPayload() *GetUserQuery {
+func (m *GetIndexUsageQueryEnvelope) GetPayload() *GetIndexUsageQuery {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (m *GetUserQueryEnvelope) GetSignature() []byte {
+func (m *GetIndexUsageQueryEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetUserQuery struct {
-	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TargetUserId         string   `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+// GetIndexUsageQuery requests the usage of the indexes of the data databases by the queries that the node executed.
+// Only admin users can get the index usage.
+type GetIndexUsageQuery struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// unused_only lists only the indexes that no query used
+	UnusedOnly           bool     `protobuf:"varint,2,opt,name=unused_only,json=unusedOnly,proto3" json:"unused_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetUserQuery) Reset()         { *m = GetUserQuery{} }
-func (m *GetUserQuery) String() string { return proto.CompactTextString(m) }
-func (*GetUserQuery) ProtoMessage()    {}
-func (*GetUserQuery) Descriptor() ([]byte, []int) {
+func (m *GetIndexUsageQuery) Reset()         { *m = GetIndexUsageQuery{} }
+func (m *GetIndexUsageQuery) String() string { return proto.CompactTextString(m) }
+func (*GetIndexUsageQuery) ProtoMessage()    {}
+func (*GetIndexUsageQuery) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{5}
 }
 
-func (m *GetUserQuery) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetUserQuery.Unmarshal(m, b)
+func (m *GetIndexUsageQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexUsageQuery.Unmarshal(m, b)
 }
-func (m *GetUserQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetUserQuery.Marshal(b, m, deterministic)
+func (m *GetIndexUsageQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexUsageQuery.Marshal(b, m, deterministic)
 }
-func (m *GetUserQuery) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetUserQuery.Merge(m, src)
+func (m *GetIndexUsageQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexUsageQuery.Merge(m, src)
 }
-func (m *GetUserQuery) XXX_Size() int {
-	return xxx_messageInfo_GetUserQuery.Size(m)
+func (m *GetIndexUsageQuery) XXX_Size() int {
+	return xxx_messageInfo_GetIndexUsageQuery.Size(m)
 }
-func (m *GetUserQuery) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetUserQuery.DiscardUnknown(m)
+func (m *GetIndexUsageQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexUsageQuery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetUserQuery proto.InternalMessageInfo
+var xxx_messageInfo_GetIndexUsageQuery proto.InternalMessageInfo
 
-func (m *GetUserQuery) GetUserId() string {
+func (m *GetIndexUsageQuery) GetUserId() string {
 	if m != nil {
 		return m.UserId
 	}
 	return ""
 }
 
-func (m *GetUserQuery) GetTargetUserId() string {
+func (m *GetIndexUsageQuery) GetUnusedOnly() bool {
 	if m != nil {
-		return m.TargetUserId
+		return m.UnusedOnly
 	}
-	return ""
+	return false
 }
 
-type GetConfigQueryEnvelope struct {
-	Payload              *GetConfigQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte          `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type GetMigrationsQueryEnvelope struct {
+	Payload              *GetMigrationsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *GetConfigQueryEnvelope) Reset()         { *m = GetConfigQueryEnvelope{} }
-func (m *GetConfigQueryEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetConfigQueryEnvelope) ProtoMessage()    {}
-func (*GetConfigQueryEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetMigrationsQueryEnvelope) Reset()         { *m = GetMigrationsQueryEnvelope{} }
+func (m *GetMigrationsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetMigrationsQueryEnvelope) ProtoMessage()    {}
+func (*GetMigrationsQueryEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{6}
 }
 
-func (m *GetConfigQueryEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetConfigQueryEnvelope.Unmarshal(m, b)
+func (m *GetMigrationsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMigrationsQueryEnvelope.Unmarshal(m, b)
 }
-func (m *GetConfigQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetConfigQueryEnvelope.Marshal(b, m, deterministic)
+func (m *GetMigrationsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMigrationsQueryEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetConfigQueryEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigQueryEnvelope.Merge(m, src)
+func (m *GetMigrationsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMigrationsQueryEnvelope.Merge(m, src)
 }
-func (m *GetConfigQueryEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetConfigQueryEnvelope.Size(m)
+func (m *GetMigrationsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetMigrationsQueryEnvelope.Size(m)
 }
-func (m *GetConfigQueryEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigQueryEnvelope.DiscardUnknown(m)
+func (m *GetMigrationsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMigrationsQueryEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigQueryEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetMigrationsQueryEnvelope proto.InternalMessageInfo
 
-func (m *GetConfigQueryEnvelope) GetPayload() *GetConfigQuery {
+func (m *GetMigrationsQueryEnvelope) GetPayload() *GetMigrationsQuery {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (m *GetConfigQueryEnvelope) GetSignature() []byte {
+func (m *GetMigrationsQueryEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetConfigQuery struct {
+// GetMigrationsQuery requests the state of a migration or, if the name is empty, of all migrations
+type GetMigrationsQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetConfigQuery) Reset()         { *m = GetConfigQuery{} }
-func (m *GetConfigQuery) String() string { return proto.CompactTextString(m) }
-func (*GetConfigQuery) ProtoMessage()    {}
-func (*GetConfigQuery) Descriptor() ([]byte, []int) {
+func (m *GetMigrationsQuery) Reset()         { *m = GetMigrationsQuery{} }
+func (m *GetMigrationsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetMigrationsQuery) ProtoMessage()    {}
+func (*GetMigrationsQuery) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{7}
 }
 
-func (m *GetConfigQuery) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetConfigQuery.Unmarshal(m, b)
+func (m *GetMigrationsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMigrationsQuery.Unmarshal(m, b)
 }
-func (m *GetConfigQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetConfigQuery.Marshal(b, m, deterministic)
+func (m *GetMigrationsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMigrationsQuery.Marshal(b, m, deterministic)
 }
-func (m *GetConfigQuery) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigQuery.Merge(m, src)
+func (m *GetMigrationsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMigrationsQuery.Merge(m, src)
 }
-func (m *GetConfigQuery) XXX_Size() int {
-	return xxx_messageInfo_GetConfigQuery.Size(m)
+func (m *GetMigrationsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetMigrationsQuery.Size(m)
 }
-func (m *GetConfigQuery) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigQuery.DiscardUnknown(m)
+func (m *GetMigrationsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMigrationsQuery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigQuery proto.InternalMessageInfo
+var xxx_messageInfo_GetMigrationsQuery proto.InternalMessageInfo
 
-func (m *GetConfigQuery) GetUserId() string {
+func (m *GetMigrationsQuery) GetUserId() string {
 	if m != nil {
 		return m.UserId
 	}
 	return ""
 }
 
-type GetNodeConfigQueryEnvelope struct {
-	Payload              *GetNodeConfigQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+func (m *GetMigrationsQuery) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func (m *GetNodeConfigQueryEnvelope) Reset()         { *m = GetNodeConfigQueryEnvelope{} }
-func (m *GetNodeConfigQueryEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetNodeConfigQueryEnvelope) ProtoMessage()    {}
-func (*GetNodeConfigQueryEnvelope) Descriptor() ([]byte, []int) {
+type GetDataQueryEnvelope struct {
+	Payload              *GetDataQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte        `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetDataQueryEnvelope) Reset()         { *m = GetDataQueryEnvelope{} }
+func (m *GetDataQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataQueryEnvelope) ProtoMessage()    {}
+func (*GetDataQueryEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{8}
 }
 
-func (m *GetNodeConfigQueryEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeConfigQueryEnvelope.Unmarshal(m, b)
+func (m *GetDataQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataQueryEnvelope.Unmarshal(m, b)
 }
-func (m *GetNodeConfigQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeConfigQueryEnvelope.Marshal(b, m, deterministic)
+func (m *GetDataQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataQueryEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetNodeConfigQueryEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeConfigQueryEnvelope.Merge(m, src)
+func (m *GetDataQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataQueryEnvelope.Merge(m, src)
 }
-func (m *GetNodeConfigQueryEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetNodeConfigQueryEnvelope.Size(m)
+func (m *GetDataQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataQueryEnvelope.Size(m)
 }
-func (m *GetNodeConfigQueryEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeConfigQueryEnvelope.DiscardUnknown(m)
+func (m *GetDataQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataQueryEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeConfigQueryEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetDataQueryEnvelope proto.InternalMessageInfo
 
-func (m *GetNodeConfigQueryEnvelope) GetPayload() *GetNodeConfigQuery {
+func (m *GetDataQueryEnvelope) GetPayload() *GetDataQuery {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (m *GetNodeConfigQueryEnvelope) GetSignature() []byte {
+func (m *GetDataQueryEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetNodeConfigQuery struct {
+type GetDataQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	NodeId               string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetNodeConfigQuery) Reset()         { *m = GetNodeConfigQuery{} }
-func (m *GetNodeConfigQuery) String() string { return proto.CompactTextString(m) }
-func (*GetNodeConfigQuery) ProtoMessage()    {}
-func (*GetNodeConfigQuery) Descriptor() ([]byte, []int) {
+func (m *GetDataQuery) Reset()         { *m = GetDataQuery{} }
+func (m *GetDataQuery) String() string { return proto.CompactTextString(m) }
+func (*GetDataQuery) ProtoMessage()    {}
+func (*GetDataQuery) Descriptor() ([]byte, []int) {
 	return fileDescriptor_5c6ac9b241082464, []int{9}
 }
 
-func (m *GetNodeConfigQuery) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeConfigQuery.Unmarshal(m, b)
+func (m *GetDataQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataQuery.Unmarshal(m, b)
 }
-func (m *GetNodeConfigQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeConfigQuery.Marshal(b, m, deterministic)
+func (m *GetDataQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataQuery.Marshal(b, m, deterministic)
 }
-func (m *GetNodeConfigQuery) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeConfigQuery.Merge(m, src)
+func (m *GetDataQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataQuery.Merge(m, src)
 }
-func (m *GetNodeConfigQuery) XXX_Size() int {
-	return xxx_messageInfo_GetNodeConfigQuery.Size(m)
+func (m *GetDataQuery) XXX_Size() int {
+	return xxx_messageInfo_GetDataQuery.Size(m)
 }
-func (m *GetNodeConfigQuery) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeConfigQuery.DiscardUnknown(m)
+func (m *GetDataQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataQuery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeConfigQuery proto.InternalMessageInfo
+var xxx_messageInfo_GetDataQuery proto.InternalMessageInfo
 
-func (m *GetNodeConfigQuery) GetUserId() string {
+func (m *GetDataQuery) GetUserId() string {
 	if m != nil {
 		return m.UserId
 	}
 	return ""
 }
 
-func (m *GetNodeConfigQuery) GetNodeId() string {
+func (m *GetDataQuery) GetDbName() string {
 	if m != nil {
-		return m.NodeId
+		return m.DbName
 	}
 	return ""
 }
 
-type GeConfigBlockQueryEnvelope struct {
-	Payload              *GetConfigBlockQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *GetDataQuery) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
 }
 
-func (m *GeConfigBlockQueryEnvelope) Reset()         { *m = GeConfigBlockQueryEnvelope{} }
-func (m *GeConfigBlockQueryEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GeConfigBlockQueryEnvelope) ProtoMessage()    {}
-func (*GeConfigBlockQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{10}
+type GetDataKeysQueryEnvelope struct {
+	Payload              *GetDataKeysQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte            `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetDataKeysQueryEnvelope) Reset()         { *m = GetDataKeysQueryEnvelope{} }
+func (m *GetDataKeysQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataKeysQueryEnvelope) ProtoMessage()    {}
+func (*GetDataKeysQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{10}
+}
+
+func (m *GetDataKeysQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataKeysQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetDataKeysQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataKeysQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDataKeysQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataKeysQueryEnvelope.Merge(m, src)
+}
+func (m *GetDataKeysQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataKeysQueryEnvelope.Size(m)
+}
+func (m *GetDataKeysQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataKeysQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataKeysQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetDataKeysQueryEnvelope) GetPayload() *GetDataKeysQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetDataKeysQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetDataKeysQuery fetches the keys of a database that start with the prefix, or only their count
+type GetDataKeysQuery struct {
+	UserId    string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName    string `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Prefix    string `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	CountOnly bool   `protobuf:"varint,4,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	// start_key is the key from which the keys are fetched, inclusive. An empty start_key fetches from the first key
+	StartKey string `protobuf:"bytes,5,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	// limit is the maximum number of keys fetched, where zero means no limit
+	Limit                uint64   `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDataKeysQuery) Reset()         { *m = GetDataKeysQuery{} }
+func (m *GetDataKeysQuery) String() string { return proto.CompactTextString(m) }
+func (*GetDataKeysQuery) ProtoMessage()    {}
+func (*GetDataKeysQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{11}
+}
+
+func (m *GetDataKeysQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataKeysQuery.Unmarshal(m, b)
+}
+func (m *GetDataKeysQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataKeysQuery.Marshal(b, m, deterministic)
+}
+func (m *GetDataKeysQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataKeysQuery.Merge(m, src)
+}
+func (m *GetDataKeysQuery) XXX_Size() int {
+	return xxx_messageInfo_GetDataKeysQuery.Size(m)
+}
+func (m *GetDataKeysQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataKeysQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataKeysQuery proto.InternalMessageInfo
+
+func (m *GetDataKeysQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetDataKeysQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *GetDataKeysQuery) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *GetDataKeysQuery) GetCountOnly() bool {
+	if m != nil {
+		return m.CountOnly
+	}
+	return false
+}
+
+func (m *GetDataKeysQuery) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *GetDataKeysQuery) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// OpenDataCursorQuery opens a cursor over the keys of a database that start with the prefix. The cursor reads a
+// snapshot of the database, and is closed when it is not read for the TTL, which is a duration such as "10m".
+type OpenDataCursorQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Prefix               string   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Ttl                  string   `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenDataCursorQuery) Reset()         { *m = OpenDataCursorQuery{} }
+func (m *OpenDataCursorQuery) String() string { return proto.CompactTextString(m) }
+func (*OpenDataCursorQuery) ProtoMessage()    {}
+func (*OpenDataCursorQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{12}
+}
+
+func (m *OpenDataCursorQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OpenDataCursorQuery.Unmarshal(m, b)
+}
+func (m *OpenDataCursorQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OpenDataCursorQuery.Marshal(b, m, deterministic)
+}
+func (m *OpenDataCursorQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenDataCursorQuery.Merge(m, src)
+}
+func (m *OpenDataCursorQuery) XXX_Size() int {
+	return xxx_messageInfo_OpenDataCursorQuery.Size(m)
+}
+func (m *OpenDataCursorQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenDataCursorQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenDataCursorQuery proto.InternalMessageInfo
+
+func (m *OpenDataCursorQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *OpenDataCursorQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *OpenDataCursorQuery) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *OpenDataCursorQuery) GetTtl() string {
+	if m != nil {
+		return m.Ttl
+	}
+	return ""
+}
+
+// GetDataCursorPageQuery fetches up to limit key-value pairs of a cursor, starting after the given key, or from the
+// first key of the cursor if after is empty
+type GetDataCursorPageQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CursorId             string   `protobuf:"bytes,2,opt,name=cursor_id,json=cursorId,proto3" json:"cursor_id,omitempty"`
+	After                string   `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	Limit                uint64   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDataCursorPageQuery) Reset()         { *m = GetDataCursorPageQuery{} }
+func (m *GetDataCursorPageQuery) String() string { return proto.CompactTextString(m) }
+func (*GetDataCursorPageQuery) ProtoMessage()    {}
+func (*GetDataCursorPageQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{13}
+}
+
+func (m *GetDataCursorPageQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataCursorPageQuery.Unmarshal(m, b)
+}
+func (m *GetDataCursorPageQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataCursorPageQuery.Marshal(b, m, deterministic)
+}
+func (m *GetDataCursorPageQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataCursorPageQuery.Merge(m, src)
+}
+func (m *GetDataCursorPageQuery) XXX_Size() int {
+	return xxx_messageInfo_GetDataCursorPageQuery.Size(m)
+}
+func (m *GetDataCursorPageQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataCursorPageQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataCursorPageQuery proto.InternalMessageInfo
+
+func (m *GetDataCursorPageQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetDataCursorPageQuery) GetCursorId() string {
+	if m != nil {
+		return m.CursorId
+	}
+	return ""
+}
+
+func (m *GetDataCursorPageQuery) GetAfter() string {
+	if m != nil {
+		return m.After
+	}
+	return ""
+}
+
+func (m *GetDataCursorPageQuery) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+// CloseDataCursorQuery closes a cursor and releases its snapshot
+type CloseDataCursorQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CursorId             string   `protobuf:"bytes,2,opt,name=cursor_id,json=cursorId,proto3" json:"cursor_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CloseDataCursorQuery) Reset()         { *m = CloseDataCursorQuery{} }
+func (m *CloseDataCursorQuery) String() string { return proto.CompactTextString(m) }
+func (*CloseDataCursorQuery) ProtoMessage()    {}
+func (*CloseDataCursorQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{14}
+}
+
+func (m *CloseDataCursorQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CloseDataCursorQuery.Unmarshal(m, b)
+}
+func (m *CloseDataCursorQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CloseDataCursorQuery.Marshal(b, m, deterministic)
+}
+func (m *CloseDataCursorQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CloseDataCursorQuery.Merge(m, src)
+}
+func (m *CloseDataCursorQuery) XXX_Size() int {
+	return xxx_messageInfo_CloseDataCursorQuery.Size(m)
+}
+func (m *CloseDataCursorQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_CloseDataCursorQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CloseDataCursorQuery proto.InternalMessageInfo
+
+func (m *CloseDataCursorQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *CloseDataCursorQuery) GetCursorId() string {
+	if m != nil {
+		return m.CursorId
+	}
+	return ""
+}
+
+// QueryReplayGuard binds the signature of a query to a nonce and a timestamp, in unix nanoseconds, so that a captured
+// query cannot be replayed. A query that carries the Nonce and Timestamp headers is signed by signing the JSON encoding
+// of its guard, which holds the JSON encoding of the query, in place of the query.
+type QueryReplayGuard struct {
+	Query                []byte   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Nonce                string   `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryReplayGuard) Reset()         { *m = QueryReplayGuard{} }
+func (m *QueryReplayGuard) String() string { return proto.CompactTextString(m) }
+func (*QueryReplayGuard) ProtoMessage()    {}
+func (*QueryReplayGuard) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{15}
+}
+
+func (m *QueryReplayGuard) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryReplayGuard.Unmarshal(m, b)
+}
+func (m *QueryReplayGuard) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryReplayGuard.Marshal(b, m, deterministic)
+}
+func (m *QueryReplayGuard) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryReplayGuard.Merge(m, src)
+}
+func (m *QueryReplayGuard) XXX_Size() int {
+	return xxx_messageInfo_QueryReplayGuard.Size(m)
+}
+func (m *QueryReplayGuard) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryReplayGuard.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryReplayGuard proto.InternalMessageInfo
+
+func (m *QueryReplayGuard) GetQuery() []byte {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (m *QueryReplayGuard) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func (m *QueryReplayGuard) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+// SessionLoginQuery requests a session token, which authenticates the queries of the user in place of their
+// signatures. The timestamp, in unix nanoseconds, must be close to the clock of the server so that a login cannot be
+// replayed later. The TTL is a duration such as "15m".
+type SessionLoginQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Timestamp            int64    `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Ttl                  string   `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionLoginQuery) Reset()         { *m = SessionLoginQuery{} }
+func (m *SessionLoginQuery) String() string { return proto.CompactTextString(m) }
+func (*SessionLoginQuery) ProtoMessage()    {}
+func (*SessionLoginQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{16}
+}
+
+func (m *SessionLoginQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionLoginQuery.Unmarshal(m, b)
+}
+func (m *SessionLoginQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionLoginQuery.Marshal(b, m, deterministic)
+}
+func (m *SessionLoginQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionLoginQuery.Merge(m, src)
+}
+func (m *SessionLoginQuery) XXX_Size() int {
+	return xxx_messageInfo_SessionLoginQuery.Size(m)
+}
+func (m *SessionLoginQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionLoginQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionLoginQuery proto.InternalMessageInfo
+
+func (m *SessionLoginQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *SessionLoginQuery) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *SessionLoginQuery) GetTtl() string {
+	if m != nil {
+		return m.Ttl
+	}
+	return ""
+}
+
+type GetUserQueryEnvelope struct {
+	Payload              *GetUserQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte        `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetUserQueryEnvelope) Reset()         { *m = GetUserQueryEnvelope{} }
+func (m *GetUserQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetUserQueryEnvelope) ProtoMessage()    {}
+func (*GetUserQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{17}
+}
+
+func (m *GetUserQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUserQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetUserQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUserQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetUserQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserQueryEnvelope.Merge(m, src)
+}
+func (m *GetUserQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetUserQueryEnvelope.Size(m)
+}
+func (m *GetUserQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUserQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetUserQueryEnvelope) GetPayload() *GetUserQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetUserQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetUserQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId         string   `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUserQuery) Reset()         { *m = GetUserQuery{} }
+func (m *GetUserQuery) String() string { return proto.CompactTextString(m) }
+func (*GetUserQuery) ProtoMessage()    {}
+func (*GetUserQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{18}
+}
+
+func (m *GetUserQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUserQuery.Unmarshal(m, b)
+}
+func (m *GetUserQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUserQuery.Marshal(b, m, deterministic)
+}
+func (m *GetUserQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserQuery.Merge(m, src)
+}
+func (m *GetUserQuery) XXX_Size() int {
+	return xxx_messageInfo_GetUserQuery.Size(m)
+}
+func (m *GetUserQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUserQuery proto.InternalMessageInfo
+
+func (m *GetUserQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetUserQuery) GetTargetUserId() string {
+	if m != nil {
+		return m.TargetUserId
+	}
+	return ""
+}
+
+type GetUsersQueryEnvelope struct {
+	Payload              *GetUsersQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte         `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetUsersQueryEnvelope) Reset()         { *m = GetUsersQueryEnvelope{} }
+func (m *GetUsersQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetUsersQueryEnvelope) ProtoMessage()    {}
+func (*GetUsersQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{19}
+}
+
+func (m *GetUsersQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsersQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetUsersQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsersQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetUsersQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsersQueryEnvelope.Merge(m, src)
+}
+func (m *GetUsersQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetUsersQueryEnvelope.Size(m)
+}
+func (m *GetUsersQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsersQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsersQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetUsersQueryEnvelope) GetPayload() *GetUsersQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetUsersQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetUsersQuery lists the users the querier can read. A non-empty prefix lists
+// only the users whose ID starts with the prefix
+type GetUsersQuery struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// offset is the number of users skipped from the start of the list
+	Offset uint64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is the maximum number of users returned, where zero means no limit
+	Limit                uint64   `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetUsersQuery) Reset()         { *m = GetUsersQuery{} }
+func (m *GetUsersQuery) String() string { return proto.CompactTextString(m) }
+func (*GetUsersQuery) ProtoMessage()    {}
+func (*GetUsersQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{20}
+}
+
+func (m *GetUsersQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsersQuery.Unmarshal(m, b)
+}
+func (m *GetUsersQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsersQuery.Marshal(b, m, deterministic)
+}
+func (m *GetUsersQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsersQuery.Merge(m, src)
+}
+func (m *GetUsersQuery) XXX_Size() int {
+	return xxx_messageInfo_GetUsersQuery.Size(m)
+}
+func (m *GetUsersQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsersQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsersQuery proto.InternalMessageInfo
+
+func (m *GetUsersQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetUsersQuery) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *GetUsersQuery) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetUsersQuery) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type GetConfigQueryEnvelope struct {
+	Payload              *GetConfigQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte          `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetConfigQueryEnvelope) Reset()         { *m = GetConfigQueryEnvelope{} }
+func (m *GetConfigQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigQueryEnvelope) ProtoMessage()    {}
+func (*GetConfigQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{21}
+}
+
+func (m *GetConfigQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigQueryEnvelope.Merge(m, src)
+}
+func (m *GetConfigQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigQueryEnvelope.Size(m)
+}
+func (m *GetConfigQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigQueryEnvelope) GetPayload() *GetConfigQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetConfigQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigQuery) Reset()         { *m = GetConfigQuery{} }
+func (m *GetConfigQuery) String() string { return proto.CompactTextString(m) }
+func (*GetConfigQuery) ProtoMessage()    {}
+func (*GetConfigQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{22}
+}
+
+func (m *GetConfigQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigQuery.Unmarshal(m, b)
+}
+func (m *GetConfigQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigQuery.Marshal(b, m, deterministic)
+}
+func (m *GetConfigQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigQuery.Merge(m, src)
+}
+func (m *GetConfigQuery) XXX_Size() int {
+	return xxx_messageInfo_GetConfigQuery.Size(m)
+}
+func (m *GetConfigQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigQuery proto.InternalMessageInfo
+
+func (m *GetConfigQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GetNodeConfigQueryEnvelope struct {
+	Payload              *GetNodeConfigQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetNodeConfigQueryEnvelope) Reset()         { *m = GetNodeConfigQueryEnvelope{} }
+func (m *GetNodeConfigQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetNodeConfigQueryEnvelope) ProtoMessage()    {}
+func (*GetNodeConfigQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{23}
+}
+
+func (m *GetNodeConfigQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeConfigQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetNodeConfigQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeConfigQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetNodeConfigQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeConfigQueryEnvelope.Merge(m, src)
+}
+func (m *GetNodeConfigQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetNodeConfigQueryEnvelope.Size(m)
+}
+func (m *GetNodeConfigQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeConfigQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeConfigQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetNodeConfigQueryEnvelope) GetPayload() *GetNodeConfigQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetNodeConfigQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetNodeConfigQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	NodeId               string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeConfigQuery) Reset()         { *m = GetNodeConfigQuery{} }
+func (m *GetNodeConfigQuery) String() string { return proto.CompactTextString(m) }
+func (*GetNodeConfigQuery) ProtoMessage()    {}
+func (*GetNodeConfigQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{24}
+}
+
+func (m *GetNodeConfigQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeConfigQuery.Unmarshal(m, b)
+}
+func (m *GetNodeConfigQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeConfigQuery.Marshal(b, m, deterministic)
+}
+func (m *GetNodeConfigQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeConfigQuery.Merge(m, src)
+}
+func (m *GetNodeConfigQuery) XXX_Size() int {
+	return xxx_messageInfo_GetNodeConfigQuery.Size(m)
+}
+func (m *GetNodeConfigQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeConfigQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeConfigQuery proto.InternalMessageInfo
+
+func (m *GetNodeConfigQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetNodeConfigQuery) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type GeConfigBlockQueryEnvelope struct {
+	Payload              *GetConfigBlockQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GeConfigBlockQueryEnvelope) Reset()         { *m = GeConfigBlockQueryEnvelope{} }
+func (m *GeConfigBlockQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GeConfigBlockQueryEnvelope) ProtoMessage()    {}
+func (*GeConfigBlockQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{25}
+}
+
+func (m *GeConfigBlockQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GeConfigBlockQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GeConfigBlockQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GeConfigBlockQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GeConfigBlockQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GeConfigBlockQueryEnvelope.Merge(m, src)
+}
+func (m *GeConfigBlockQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GeConfigBlockQueryEnvelope.Size(m)
+}
+func (m *GeConfigBlockQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GeConfigBlockQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GeConfigBlockQueryEnvelope proto.InternalMessageInfo
+
+func (m *GeConfigBlockQueryEnvelope) GetPayload() *GetConfigBlockQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GeConfigBlockQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigBlockQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigBlockQuery) Reset()         { *m = GetConfigBlockQuery{} }
+func (m *GetConfigBlockQuery) String() string { return proto.CompactTextString(m) }
+func (*GetConfigBlockQuery) ProtoMessage()    {}
+func (*GetConfigBlockQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{26}
+}
+
+func (m *GetConfigBlockQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigBlockQuery.Unmarshal(m, b)
+}
+func (m *GetConfigBlockQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigBlockQuery.Marshal(b, m, deterministic)
+}
+func (m *GetConfigBlockQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigBlockQuery.Merge(m, src)
+}
+func (m *GetConfigBlockQuery) XXX_Size() int {
+	return xxx_messageInfo_GetConfigBlockQuery.Size(m)
+}
+func (m *GetConfigBlockQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigBlockQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigBlockQuery proto.InternalMessageInfo
+
+func (m *GetConfigBlockQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetConfigBlockQuery) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+type GetClusterStatusQueryEnvelope struct {
+	Payload              *GetClusterStatusQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetClusterStatusQueryEnvelope) Reset()         { *m = GetClusterStatusQueryEnvelope{} }
+func (m *GetClusterStatusQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetClusterStatusQueryEnvelope) ProtoMessage()    {}
+func (*GetClusterStatusQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{27}
+}
+
+func (m *GetClusterStatusQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClusterStatusQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetClusterStatusQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClusterStatusQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetClusterStatusQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterStatusQueryEnvelope.Merge(m, src)
+}
+func (m *GetClusterStatusQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetClusterStatusQueryEnvelope.Size(m)
+}
+func (m *GetClusterStatusQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterStatusQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterStatusQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetClusterStatusQueryEnvelope) GetPayload() *GetClusterStatusQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetClusterStatusQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetClusterStatusQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	NoCertificates       bool     `protobuf:"varint,2,opt,name=noCertificates,proto3" json:"noCertificates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetClusterStatusQuery) Reset()         { *m = GetClusterStatusQuery{} }
+func (m *GetClusterStatusQuery) String() string { return proto.CompactTextString(m) }
+func (*GetClusterStatusQuery) ProtoMessage()    {}
+func (*GetClusterStatusQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{28}
+}
+
+func (m *GetClusterStatusQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClusterStatusQuery.Unmarshal(m, b)
+}
+func (m *GetClusterStatusQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClusterStatusQuery.Marshal(b, m, deterministic)
+}
+func (m *GetClusterStatusQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterStatusQuery.Merge(m, src)
+}
+func (m *GetClusterStatusQuery) XXX_Size() int {
+	return xxx_messageInfo_GetClusterStatusQuery.Size(m)
+}
+func (m *GetClusterStatusQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterStatusQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterStatusQuery proto.InternalMessageInfo
+
+func (m *GetClusterStatusQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetClusterStatusQuery) GetNoCertificates() bool {
+	if m != nil {
+		return m.NoCertificates
+	}
+	return false
+}
+
+type GetConfigHistoryQueryEnvelope struct {
+	Payload              *GetConfigHistoryQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetConfigHistoryQueryEnvelope) Reset()         { *m = GetConfigHistoryQueryEnvelope{} }
+func (m *GetConfigHistoryQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigHistoryQueryEnvelope) ProtoMessage()    {}
+func (*GetConfigHistoryQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{29}
+}
+
+func (m *GetConfigHistoryQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigHistoryQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigHistoryQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigHistoryQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigHistoryQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigHistoryQueryEnvelope.Merge(m, src)
+}
+func (m *GetConfigHistoryQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigHistoryQueryEnvelope.Size(m)
+}
+func (m *GetConfigHistoryQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigHistoryQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigHistoryQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigHistoryQueryEnvelope) GetPayload() *GetConfigHistoryQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetConfigHistoryQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigHistoryQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigHistoryQuery) Reset()         { *m = GetConfigHistoryQuery{} }
+func (m *GetConfigHistoryQuery) String() string { return proto.CompactTextString(m) }
+func (*GetConfigHistoryQuery) ProtoMessage()    {}
+func (*GetConfigHistoryQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{30}
+}
+
+func (m *GetConfigHistoryQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigHistoryQuery.Unmarshal(m, b)
+}
+func (m *GetConfigHistoryQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigHistoryQuery.Marshal(b, m, deterministic)
+}
+func (m *GetConfigHistoryQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigHistoryQuery.Merge(m, src)
+}
+func (m *GetConfigHistoryQuery) XXX_Size() int {
+	return xxx_messageInfo_GetConfigHistoryQuery.Size(m)
+}
+func (m *GetConfigHistoryQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigHistoryQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigHistoryQuery proto.InternalMessageInfo
+
+func (m *GetConfigHistoryQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GetConfigDiffQueryEnvelope struct {
+	Payload              *GetConfigDiffQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetConfigDiffQueryEnvelope) Reset()         { *m = GetConfigDiffQueryEnvelope{} }
+func (m *GetConfigDiffQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigDiffQueryEnvelope) ProtoMessage()    {}
+func (*GetConfigDiffQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{31}
+}
+
+func (m *GetConfigDiffQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigDiffQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigDiffQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigDiffQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigDiffQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigDiffQueryEnvelope.Merge(m, src)
+}
+func (m *GetConfigDiffQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigDiffQueryEnvelope.Size(m)
+}
+func (m *GetConfigDiffQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigDiffQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigDiffQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigDiffQueryEnvelope) GetPayload() *GetConfigDiffQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetConfigDiffQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigDiffQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FromBlockNumber      uint64   `protobuf:"varint,2,opt,name=from_block_number,json=fromBlockNumber,proto3" json:"from_block_number,omitempty"`
+	ToBlockNumber        uint64   `protobuf:"varint,3,opt,name=to_block_number,json=toBlockNumber,proto3" json:"to_block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigDiffQuery) Reset()         { *m = GetConfigDiffQuery{} }
+func (m *GetConfigDiffQuery) String() string { return proto.CompactTextString(m) }
+func (*GetConfigDiffQuery) ProtoMessage()    {}
+func (*GetConfigDiffQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{32}
+}
+
+func (m *GetConfigDiffQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigDiffQuery.Unmarshal(m, b)
+}
+func (m *GetConfigDiffQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigDiffQuery.Marshal(b, m, deterministic)
+}
+func (m *GetConfigDiffQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigDiffQuery.Merge(m, src)
+}
+func (m *GetConfigDiffQuery) XXX_Size() int {
+	return xxx_messageInfo_GetConfigDiffQuery.Size(m)
+}
+func (m *GetConfigDiffQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigDiffQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigDiffQuery proto.InternalMessageInfo
+
+func (m *GetConfigDiffQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetConfigDiffQuery) GetFromBlockNumber() uint64 {
+	if m != nil {
+		return m.FromBlockNumber
+	}
+	return 0
+}
+
+func (m *GetConfigDiffQuery) GetToBlockNumber() uint64 {
+	if m != nil {
+		return m.ToBlockNumber
+	}
+	return 0
+}
+
+type TriggerSnapshotQueryEnvelope struct {
+	Payload              *TriggerSnapshotQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *TriggerSnapshotQueryEnvelope) Reset()         { *m = TriggerSnapshotQueryEnvelope{} }
+func (m *TriggerSnapshotQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TriggerSnapshotQueryEnvelope) ProtoMessage()    {}
+func (*TriggerSnapshotQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{33}
+}
+
+func (m *TriggerSnapshotQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerSnapshotQueryEnvelope.Unmarshal(m, b)
+}
+func (m *TriggerSnapshotQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerSnapshotQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TriggerSnapshotQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerSnapshotQueryEnvelope.Merge(m, src)
+}
+func (m *TriggerSnapshotQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TriggerSnapshotQueryEnvelope.Size(m)
+}
+func (m *TriggerSnapshotQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerSnapshotQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerSnapshotQueryEnvelope proto.InternalMessageInfo
+
+func (m *TriggerSnapshotQueryEnvelope) GetPayload() *TriggerSnapshotQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *TriggerSnapshotQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// TriggerSnapshotQuery requests the node to take a consensus (Raft) snapshot, and compact its WAL.
+// Only admin users can trigger a snapshot.
+type TriggerSnapshotQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerSnapshotQuery) Reset()         { *m = TriggerSnapshotQuery{} }
+func (m *TriggerSnapshotQuery) String() string { return proto.CompactTextString(m) }
+func (*TriggerSnapshotQuery) ProtoMessage()    {}
+func (*TriggerSnapshotQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{34}
+}
+
+func (m *TriggerSnapshotQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerSnapshotQuery.Unmarshal(m, b)
+}
+func (m *TriggerSnapshotQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerSnapshotQuery.Marshal(b, m, deterministic)
+}
+func (m *TriggerSnapshotQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerSnapshotQuery.Merge(m, src)
+}
+func (m *TriggerSnapshotQuery) XXX_Size() int {
+	return xxx_messageInfo_TriggerSnapshotQuery.Size(m)
+}
+func (m *TriggerSnapshotQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerSnapshotQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerSnapshotQuery proto.InternalMessageInfo
+
+func (m *TriggerSnapshotQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type TransferLeadershipQueryEnvelope struct {
+	Payload              *TransferLeadershipQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *TransferLeadershipQueryEnvelope) Reset()         { *m = TransferLeadershipQueryEnvelope{} }
+func (m *TransferLeadershipQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipQueryEnvelope) ProtoMessage()    {}
+func (*TransferLeadershipQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{35}
+}
+
+func (m *TransferLeadershipQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipQueryEnvelope.Unmarshal(m, b)
+}
+func (m *TransferLeadershipQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipQueryEnvelope.Merge(m, src)
+}
+func (m *TransferLeadershipQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipQueryEnvelope.Size(m)
+}
+func (m *TransferLeadershipQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipQueryEnvelope proto.InternalMessageInfo
+
+func (m *TransferLeadershipQueryEnvelope) GetPayload() *TransferLeadershipQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *TransferLeadershipQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// TransferLeadershipQuery requests the leader to transfer the leadership to another node, e.g., before it is shut
+// down for maintenance. If target_node_id is empty, the leader chooses one of the active nodes.
+// Only admin users can transfer the leadership.
+type TransferLeadershipQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetNodeId         string   `protobuf:"bytes,2,opt,name=target_node_id,json=targetNodeId,proto3" json:"target_node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferLeadershipQuery) Reset()         { *m = TransferLeadershipQuery{} }
+func (m *TransferLeadershipQuery) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipQuery) ProtoMessage()    {}
+func (*TransferLeadershipQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{36}
+}
+
+func (m *TransferLeadershipQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipQuery.Unmarshal(m, b)
+}
+func (m *TransferLeadershipQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipQuery.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipQuery.Merge(m, src)
+}
+func (m *TransferLeadershipQuery) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipQuery.Size(m)
+}
+func (m *TransferLeadershipQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipQuery proto.InternalMessageInfo
+
+func (m *TransferLeadershipQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *TransferLeadershipQuery) GetTargetNodeId() string {
+	if m != nil {
+		return m.TargetNodeId
+	}
+	return ""
+}
+
+type GetConsensusDiagnosticsQueryEnvelope struct {
+	Payload              *GetConsensusDiagnosticsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                        `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *GetConsensusDiagnosticsQueryEnvelope) Reset()         { *m = GetConsensusDiagnosticsQueryEnvelope{} }
+func (m *GetConsensusDiagnosticsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusDiagnosticsQueryEnvelope) ProtoMessage()    {}
+func (*GetConsensusDiagnosticsQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{37}
+}
+
+func (m *GetConsensusDiagnosticsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusDiagnosticsQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetConsensusDiagnosticsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusDiagnosticsQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusDiagnosticsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusDiagnosticsQueryEnvelope.Merge(m, src)
+}
+func (m *GetConsensusDiagnosticsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusDiagnosticsQueryEnvelope.Size(m)
+}
+func (m *GetConsensusDiagnosticsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusDiagnosticsQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusDiagnosticsQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetConsensusDiagnosticsQueryEnvelope) GetPayload() *GetConsensusDiagnosticsQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetConsensusDiagnosticsQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetConsensusDiagnosticsQuery requests the state of consensus (Raft) on the node, in order to diagnose elections
+// and commit stalls. Only admin users can get the consensus diagnostics.
+type GetConsensusDiagnosticsQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConsensusDiagnosticsQuery) Reset()         { *m = GetConsensusDiagnosticsQuery{} }
+func (m *GetConsensusDiagnosticsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusDiagnosticsQuery) ProtoMessage()    {}
+func (*GetConsensusDiagnosticsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{38}
+}
+
+func (m *GetConsensusDiagnosticsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusDiagnosticsQuery.Unmarshal(m, b)
+}
+func (m *GetConsensusDiagnosticsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusDiagnosticsQuery.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusDiagnosticsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusDiagnosticsQuery.Merge(m, src)
+}
+func (m *GetConsensusDiagnosticsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusDiagnosticsQuery.Size(m)
+}
+func (m *GetConsensusDiagnosticsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusDiagnosticsQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusDiagnosticsQuery proto.InternalMessageInfo
+
+func (m *GetConsensusDiagnosticsQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GetStorageReportQueryEnvelope struct {
+	Payload              *GetStorageReportQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetStorageReportQueryEnvelope) Reset()         { *m = GetStorageReportQueryEnvelope{} }
+func (m *GetStorageReportQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetStorageReportQueryEnvelope) ProtoMessage()    {}
+func (*GetStorageReportQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{39}
+}
+
+func (m *GetStorageReportQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageReportQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetStorageReportQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageReportQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetStorageReportQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageReportQueryEnvelope.Merge(m, src)
+}
+func (m *GetStorageReportQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetStorageReportQueryEnvelope.Size(m)
+}
+func (m *GetStorageReportQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageReportQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageReportQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetStorageReportQueryEnvelope) GetPayload() *GetStorageReportQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetStorageReportQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetStorageReportQuery requests a report of the storage used by the data databases of the node: duplicate values,
+// the largest keys and key prefixes, and the keys with the most versions. Only admin users can get the report.
+type GetStorageReportQuery struct {
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The number of entries in each list of the report; a default is used when zero.
+	Top uint32 `protobuf:"varint,2,opt,name=top,proto3" json:"top,omitempty"`
+	// A key prefix ends at the first occurrence of the delimiter in the key; a default is used when empty.
+	PrefixDelimiter      string   `protobuf:"bytes,3,opt,name=prefix_delimiter,json=prefixDelimiter,proto3" json:"prefix_delimiter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStorageReportQuery) Reset()         { *m = GetStorageReportQuery{} }
+func (m *GetStorageReportQuery) String() string { return proto.CompactTextString(m) }
+func (*GetStorageReportQuery) ProtoMessage()    {}
+func (*GetStorageReportQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{40}
+}
+
+func (m *GetStorageReportQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageReportQuery.Unmarshal(m, b)
+}
+func (m *GetStorageReportQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageReportQuery.Marshal(b, m, deterministic)
+}
+func (m *GetStorageReportQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageReportQuery.Merge(m, src)
+}
+func (m *GetStorageReportQuery) XXX_Size() int {
+	return xxx_messageInfo_GetStorageReportQuery.Size(m)
+}
+func (m *GetStorageReportQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageReportQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageReportQuery proto.InternalMessageInfo
+
+func (m *GetStorageReportQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetStorageReportQuery) GetTop() uint32 {
+	if m != nil {
+		return m.Top
+	}
+	return 0
+}
+
+func (m *GetStorageReportQuery) GetPrefixDelimiter() string {
+	if m != nil {
+		return m.PrefixDelimiter
+	}
+	return ""
+}
+
+type GetStateHashQueryEnvelope struct {
+	Payload              *GetStateHashQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetStateHashQueryEnvelope) Reset()         { *m = GetStateHashQueryEnvelope{} }
+func (m *GetStateHashQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetStateHashQueryEnvelope) ProtoMessage()    {}
+func (*GetStateHashQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{41}
+}
+
+func (m *GetStateHashQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateHashQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetStateHashQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateHashQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetStateHashQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateHashQueryEnvelope.Merge(m, src)
+}
+func (m *GetStateHashQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetStateHashQueryEnvelope.Size(m)
+}
+func (m *GetStateHashQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateHashQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateHashQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetStateHashQueryEnvelope) GetPayload() *GetStateHashQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
 }
 
-func (m *GeConfigBlockQueryEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GeConfigBlockQueryEnvelope.Unmarshal(m, b)
+func (m *GetStateHashQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
 }
-func (m *GeConfigBlockQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GeConfigBlockQueryEnvelope.Marshal(b, m, deterministic)
+
+// GetStateHashQuery requests the canonical hash of the full contents of a database of the node, so that replicas can
+// be compared without relying on the state trie. Only admin users can get the hash.
+type GetStateHashQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *GeConfigBlockQueryEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GeConfigBlockQueryEnvelope.Merge(m, src)
+
+func (m *GetStateHashQuery) Reset()         { *m = GetStateHashQuery{} }
+func (m *GetStateHashQuery) String() string { return proto.CompactTextString(m) }
+func (*GetStateHashQuery) ProtoMessage()    {}
+func (*GetStateHashQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{42}
 }
-func (m *GeConfigBlockQueryEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GeConfigBlockQueryEnvelope.Size(m)
+
+func (m *GetStateHashQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateHashQuery.Unmarshal(m, b)
 }
-func (m *GeConfigBlockQueryEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GeConfigBlockQueryEnvelope.DiscardUnknown(m)
+func (m *GetStateHashQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateHashQuery.Marshal(b, m, deterministic)
+}
+func (m *GetStateHashQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateHashQuery.Merge(m, src)
+}
+func (m *GetStateHashQuery) XXX_Size() int {
+	return xxx_messageInfo_GetStateHashQuery.Size(m)
+}
+func (m *GetStateHashQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateHashQuery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GeConfigBlockQueryEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetStateHashQuery proto.InternalMessageInfo
+
+func (m *GetStateHashQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetStateHashQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+type CompactIndexQueryEnvelope struct {
+	Payload              *CompactIndexQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CompactIndexQueryEnvelope) Reset()         { *m = CompactIndexQueryEnvelope{} }
+func (m *CompactIndexQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*CompactIndexQueryEnvelope) ProtoMessage()    {}
+func (*CompactIndexQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{43}
+}
+
+func (m *CompactIndexQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactIndexQueryEnvelope.Unmarshal(m, b)
+}
+func (m *CompactIndexQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactIndexQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *CompactIndexQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactIndexQueryEnvelope.Merge(m, src)
+}
+func (m *CompactIndexQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_CompactIndexQueryEnvelope.Size(m)
+}
+func (m *CompactIndexQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactIndexQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactIndexQueryEnvelope proto.InternalMessageInfo
+
+func (m *CompactIndexQueryEnvelope) GetPayload() *CompactIndexQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *CompactIndexQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// CompactIndexQuery requests the node to compact the storage of the index of a database, which drops the tombstones of
+// the deleted index entries. Only admin users can compact an index.
+type CompactIndexQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompactIndexQuery) Reset()         { *m = CompactIndexQuery{} }
+func (m *CompactIndexQuery) String() string { return proto.CompactTextString(m) }
+func (*CompactIndexQuery) ProtoMessage()    {}
+func (*CompactIndexQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{44}
+}
+
+func (m *CompactIndexQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactIndexQuery.Unmarshal(m, b)
+}
+func (m *CompactIndexQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactIndexQuery.Marshal(b, m, deterministic)
+}
+func (m *CompactIndexQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactIndexQuery.Merge(m, src)
+}
+func (m *CompactIndexQuery) XXX_Size() int {
+	return xxx_messageInfo_CompactIndexQuery.Size(m)
+}
+func (m *CompactIndexQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactIndexQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactIndexQuery proto.InternalMessageInfo
+
+func (m *CompactIndexQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *CompactIndexQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+type GetQuarantinedBlockQueryEnvelope struct {
+	Payload              *GetQuarantinedBlockQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetQuarantinedBlockQueryEnvelope) Reset()         { *m = GetQuarantinedBlockQueryEnvelope{} }
+func (m *GetQuarantinedBlockQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetQuarantinedBlockQueryEnvelope) ProtoMessage()    {}
+func (*GetQuarantinedBlockQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{45}
+}
+
+func (m *GetQuarantinedBlockQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuarantinedBlockQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetQuarantinedBlockQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuarantinedBlockQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetQuarantinedBlockQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuarantinedBlockQueryEnvelope.Merge(m, src)
+}
+func (m *GetQuarantinedBlockQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetQuarantinedBlockQueryEnvelope.Size(m)
+}
+func (m *GetQuarantinedBlockQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuarantinedBlockQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuarantinedBlockQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetQuarantinedBlockQueryEnvelope) GetPayload() *GetQuarantinedBlockQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetQuarantinedBlockQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetQuarantinedBlockQuery requests the block that the node failed to validate or commit, and quarantined, if any.
+// Only admin users can get the quarantined block.
+type GetQuarantinedBlockQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetQuarantinedBlockQuery) Reset()         { *m = GetQuarantinedBlockQuery{} }
+func (m *GetQuarantinedBlockQuery) String() string { return proto.CompactTextString(m) }
+func (*GetQuarantinedBlockQuery) ProtoMessage()    {}
+func (*GetQuarantinedBlockQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{46}
+}
+
+func (m *GetQuarantinedBlockQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuarantinedBlockQuery.Unmarshal(m, b)
+}
+func (m *GetQuarantinedBlockQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuarantinedBlockQuery.Marshal(b, m, deterministic)
+}
+func (m *GetQuarantinedBlockQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuarantinedBlockQuery.Merge(m, src)
+}
+func (m *GetQuarantinedBlockQuery) XXX_Size() int {
+	return xxx_messageInfo_GetQuarantinedBlockQuery.Size(m)
+}
+func (m *GetQuarantinedBlockQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuarantinedBlockQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuarantinedBlockQuery proto.InternalMessageInfo
+
+func (m *GetQuarantinedBlockQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GetRejectedTxsQueryEnvelope struct {
+	Payload              *GetRejectedTxsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetRejectedTxsQueryEnvelope) Reset()         { *m = GetRejectedTxsQueryEnvelope{} }
+func (m *GetRejectedTxsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetRejectedTxsQueryEnvelope) ProtoMessage()    {}
+func (*GetRejectedTxsQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{47}
+}
+
+func (m *GetRejectedTxsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRejectedTxsQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetRejectedTxsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRejectedTxsQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetRejectedTxsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRejectedTxsQueryEnvelope.Merge(m, src)
+}
+func (m *GetRejectedTxsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetRejectedTxsQueryEnvelope.Size(m)
+}
+func (m *GetRejectedTxsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRejectedTxsQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRejectedTxsQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetRejectedTxsQueryEnvelope) GetPayload() *GetRejectedTxsQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetRejectedTxsQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetRejectedTxsQuery requests the transactions that the node most recently rejected before ordering, i.e., its
+// dead-letter queue. Only admin users can get the rejected transactions.
+type GetRejectedTxsQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRejectedTxsQuery) Reset()         { *m = GetRejectedTxsQuery{} }
+func (m *GetRejectedTxsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetRejectedTxsQuery) ProtoMessage()    {}
+func (*GetRejectedTxsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{48}
+}
+
+func (m *GetRejectedTxsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRejectedTxsQuery.Unmarshal(m, b)
+}
+func (m *GetRejectedTxsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRejectedTxsQuery.Marshal(b, m, deterministic)
+}
+func (m *GetRejectedTxsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRejectedTxsQuery.Merge(m, src)
+}
+func (m *GetRejectedTxsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetRejectedTxsQuery.Size(m)
+}
+func (m *GetRejectedTxsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRejectedTxsQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRejectedTxsQuery proto.InternalMessageInfo
+
+func (m *GetRejectedTxsQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GetSlowQueriesQueryEnvelope struct {
+	Payload              *GetSlowQueriesQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetSlowQueriesQueryEnvelope) Reset()         { *m = GetSlowQueriesQueryEnvelope{} }
+func (m *GetSlowQueriesQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetSlowQueriesQueryEnvelope) ProtoMessage()    {}
+func (*GetSlowQueriesQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{49}
+}
+
+func (m *GetSlowQueriesQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSlowQueriesQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetSlowQueriesQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSlowQueriesQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetSlowQueriesQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSlowQueriesQueryEnvelope.Merge(m, src)
+}
+func (m *GetSlowQueriesQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetSlowQueriesQueryEnvelope.Size(m)
+}
+func (m *GetSlowQueriesQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSlowQueriesQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSlowQueriesQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetSlowQueriesQueryEnvelope) GetPayload() *GetSlowQueriesQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetSlowQueriesQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetSlowQueriesQuery requests the data queries that the node most recently executed in more than the slow query
+// threshold. Only admin users can get the slow queries.
+type GetSlowQueriesQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSlowQueriesQuery) Reset()         { *m = GetSlowQueriesQuery{} }
+func (m *GetSlowQueriesQuery) String() string { return proto.CompactTextString(m) }
+func (*GetSlowQueriesQuery) ProtoMessage()    {}
+func (*GetSlowQueriesQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{50}
+}
+
+func (m *GetSlowQueriesQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSlowQueriesQuery.Unmarshal(m, b)
+}
+func (m *GetSlowQueriesQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSlowQueriesQuery.Marshal(b, m, deterministic)
+}
+func (m *GetSlowQueriesQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSlowQueriesQuery.Merge(m, src)
+}
+func (m *GetSlowQueriesQuery) XXX_Size() int {
+	return xxx_messageInfo_GetSlowQueriesQuery.Size(m)
+}
+func (m *GetSlowQueriesQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSlowQueriesQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSlowQueriesQuery proto.InternalMessageInfo
+
+func (m *GetSlowQueriesQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+type GetWitnessStatusQueryEnvelope struct {
+	Payload              *GetWitnessStatusQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetWitnessStatusQueryEnvelope) Reset()         { *m = GetWitnessStatusQueryEnvelope{} }
+func (m *GetWitnessStatusQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetWitnessStatusQueryEnvelope) ProtoMessage()    {}
+func (*GetWitnessStatusQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{51}
+}
+
+func (m *GetWitnessStatusQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWitnessStatusQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetWitnessStatusQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWitnessStatusQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetWitnessStatusQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWitnessStatusQueryEnvelope.Merge(m, src)
+}
+func (m *GetWitnessStatusQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetWitnessStatusQueryEnvelope.Size(m)
+}
+func (m *GetWitnessStatusQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWitnessStatusQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWitnessStatusQueryEnvelope proto.InternalMessageInfo
 
-func (m *GeConfigBlockQueryEnvelope) GetPayload() *GetConfigBlockQuery {
+func (m *GetWitnessStatusQueryEnvelope) GetPayload() *GetWitnessStatusQuery {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (m *GeConfigBlockQueryEnvelope) GetSignature() []byte {
+func (m *GetWitnessStatusQueryEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetConfigBlockQuery struct {
+// GetWitnessStatusQuery requests the verification status and the discrepancy alerts of a witness or standby node.
+// Only admin users can get the witness status.
+type GetWitnessStatusQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetConfigBlockQuery) Reset()         { *m = GetConfigBlockQuery{} }
-func (m *GetConfigBlockQuery) String() string { return proto.CompactTextString(m) }
-func (*GetConfigBlockQuery) ProtoMessage()    {}
-func (*GetConfigBlockQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{11}
+func (m *GetWitnessStatusQuery) Reset()         { *m = GetWitnessStatusQuery{} }
+func (m *GetWitnessStatusQuery) String() string { return proto.CompactTextString(m) }
+func (*GetWitnessStatusQuery) ProtoMessage()    {}
+func (*GetWitnessStatusQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{52}
 }
 
-func (m *GetConfigBlockQuery) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetConfigBlockQuery.Unmarshal(m, b)
+func (m *GetWitnessStatusQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWitnessStatusQuery.Unmarshal(m, b)
 }
-func (m *GetConfigBlockQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetConfigBlockQuery.Marshal(b, m, deterministic)
+func (m *GetWitnessStatusQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWitnessStatusQuery.Marshal(b, m, deterministic)
 }
-func (m *GetConfigBlockQuery) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigBlockQuery.Merge(m, src)
+func (m *GetWitnessStatusQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWitnessStatusQuery.Merge(m, src)
 }
-func (m *GetConfigBlockQuery) XXX_Size() int {
-	return xxx_messageInfo_GetConfigBlockQuery.Size(m)
+func (m *GetWitnessStatusQuery) XXX_Size() int {
+	return xxx_messageInfo_GetWitnessStatusQuery.Size(m)
 }
-func (m *GetConfigBlockQuery) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigBlockQuery.DiscardUnknown(m)
+func (m *GetWitnessStatusQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWitnessStatusQuery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigBlockQuery proto.InternalMessageInfo
+var xxx_messageInfo_GetWitnessStatusQuery proto.InternalMessageInfo
 
-func (m *GetConfigBlockQuery) GetUserId() string {
+func (m *GetWitnessStatusQuery) GetUserId() string {
 	if m != nil {
 		return m.UserId
 	}
 	return ""
 }
 
-func (m *GetConfigBlockQuery) GetBlockNumber() uint64 {
-	if m != nil {
-		return m.BlockNumber
-	}
-	return 0
-}
-
-type GetClusterStatusQueryEnvelope struct {
-	Payload              *GetClusterStatusQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+type GetDiagnosticsQueryEnvelope struct {
+	Payload              *GetDiagnosticsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetClusterStatusQueryEnvelope) Reset()         { *m = GetClusterStatusQueryEnvelope{} }
-func (m *GetClusterStatusQueryEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetClusterStatusQueryEnvelope) ProtoMessage()    {}
-func (*GetClusterStatusQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{12}
+func (m *GetDiagnosticsQueryEnvelope) Reset()         { *m = GetDiagnosticsQueryEnvelope{} }
+func (m *GetDiagnosticsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDiagnosticsQueryEnvelope) ProtoMessage()    {}
+func (*GetDiagnosticsQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{53}
 }
 
-func (m *GetClusterStatusQueryEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetClusterStatusQueryEnvelope.Unmarshal(m, b)
+func (m *GetDiagnosticsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDiagnosticsQueryEnvelope.Unmarshal(m, b)
 }
-func (m *GetClusterStatusQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetClusterStatusQueryEnvelope.Marshal(b, m, deterministic)
+func (m *GetDiagnosticsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDiagnosticsQueryEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetClusterStatusQueryEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetClusterStatusQueryEnvelope.Merge(m, src)
+func (m *GetDiagnosticsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDiagnosticsQueryEnvelope.Merge(m, src)
 }
-func (m *GetClusterStatusQueryEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetClusterStatusQueryEnvelope.Size(m)
+func (m *GetDiagnosticsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDiagnosticsQueryEnvelope.Size(m)
 }
-func (m *GetClusterStatusQueryEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetClusterStatusQueryEnvelope.DiscardUnknown(m)
+func (m *GetDiagnosticsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDiagnosticsQueryEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetClusterStatusQueryEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetDiagnosticsQueryEnvelope proto.InternalMessageInfo
 
-func (m *GetClusterStatusQueryEnvelope) GetPayload() *GetClusterStatusQuery {
+func (m *GetDiagnosticsQueryEnvelope) GetPayload() *GetDiagnosticsQuery {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (m *GetClusterStatusQueryEnvelope) GetSignature() []byte {
+func (m *GetDiagnosticsQueryEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetClusterStatusQuery struct {
+// GetDiagnosticsQuery requests a runtime profile, a goroutine dump, or the GC statistics of the node from the
+// diagnostics endpoints. The signature covers the path of the request, so that it cannot be used to access another
+// endpoint. Only admin users can access the diagnostics endpoints.
+type GetDiagnosticsQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	NoCertificates       bool     `protobuf:"varint,2,opt,name=noCertificates,proto3" json:"noCertificates,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetClusterStatusQuery) Reset()         { *m = GetClusterStatusQuery{} }
-func (m *GetClusterStatusQuery) String() string { return proto.CompactTextString(m) }
-func (*GetClusterStatusQuery) ProtoMessage()    {}
-func (*GetClusterStatusQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{13}
+func (m *GetDiagnosticsQuery) Reset()         { *m = GetDiagnosticsQuery{} }
+func (m *GetDiagnosticsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetDiagnosticsQuery) ProtoMessage()    {}
+func (*GetDiagnosticsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{54}
 }
 
-func (m *GetClusterStatusQuery) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetClusterStatusQuery.Unmarshal(m, b)
+func (m *GetDiagnosticsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDiagnosticsQuery.Unmarshal(m, b)
 }
-func (m *GetClusterStatusQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetClusterStatusQuery.Marshal(b, m, deterministic)
+func (m *GetDiagnosticsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDiagnosticsQuery.Marshal(b, m, deterministic)
 }
-func (m *GetClusterStatusQuery) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetClusterStatusQuery.Merge(m, src)
+func (m *GetDiagnosticsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDiagnosticsQuery.Merge(m, src)
 }
-func (m *GetClusterStatusQuery) XXX_Size() int {
-	return xxx_messageInfo_GetClusterStatusQuery.Size(m)
+func (m *GetDiagnosticsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetDiagnosticsQuery.Size(m)
 }
-func (m *GetClusterStatusQuery) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetClusterStatusQuery.DiscardUnknown(m)
+func (m *GetDiagnosticsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDiagnosticsQuery.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetClusterStatusQuery proto.InternalMessageInfo
+var xxx_messageInfo_GetDiagnosticsQuery proto.InternalMessageInfo
 
-func (m *GetClusterStatusQuery) GetUserId() string {
+func (m *GetDiagnosticsQuery) GetUserId() string {
 	if m != nil {
 		return m.UserId
 	}
 	return ""
 }
 
-func (m *GetClusterStatusQuery) GetNoCertificates() bool {
+func (m *GetDiagnosticsQuery) GetPath() string {
 	if m != nil {
-		return m.NoCertificates
+		return m.Path
 	}
-	return false
+	return ""
 }
 
 type GetBlockQuery struct {
@@ -716,7 +2768,7 @@ func (m *GetBlockQuery) Reset()         { *m = GetBlockQuery{} }
 func (m *GetBlockQuery) String() string { return proto.CompactTextString(m) }
 func (*GetBlockQuery) ProtoMessage()    {}
 func (*GetBlockQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{14}
+	return fileDescriptor_5c6ac9b241082464, []int{55}
 }
 
 func (m *GetBlockQuery) XXX_Unmarshal(b []byte) error {
@@ -770,7 +2822,7 @@ func (m *GetBlockQueryEnvelope) Reset()         { *m = GetBlockQueryEnvelope{} }
 func (m *GetBlockQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetBlockQueryEnvelope) ProtoMessage()    {}
 func (*GetBlockQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{15}
+	return fileDescriptor_5c6ac9b241082464, []int{56}
 }
 
 func (m *GetBlockQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -816,7 +2868,7 @@ func (m *GetLastBlockQuery) Reset()         { *m = GetLastBlockQuery{} }
 func (m *GetLastBlockQuery) String() string { return proto.CompactTextString(m) }
 func (*GetLastBlockQuery) ProtoMessage()    {}
 func (*GetLastBlockQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{16}
+	return fileDescriptor_5c6ac9b241082464, []int{57}
 }
 
 func (m *GetLastBlockQuery) XXX_Unmarshal(b []byte) error {
@@ -856,7 +2908,7 @@ func (m *GetLastBlockQueryEnvelope) Reset()         { *m = GetLastBlockQueryEnve
 func (m *GetLastBlockQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetLastBlockQueryEnvelope) ProtoMessage()    {}
 func (*GetLastBlockQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{17}
+	return fileDescriptor_5c6ac9b241082464, []int{58}
 }
 
 func (m *GetLastBlockQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -904,7 +2956,7 @@ func (m *GetLedgerPathQuery) Reset()         { *m = GetLedgerPathQuery{} }
 func (m *GetLedgerPathQuery) String() string { return proto.CompactTextString(m) }
 func (*GetLedgerPathQuery) ProtoMessage()    {}
 func (*GetLedgerPathQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{18}
+	return fileDescriptor_5c6ac9b241082464, []int{59}
 }
 
 func (m *GetLedgerPathQuery) XXX_Unmarshal(b []byte) error {
@@ -958,7 +3010,7 @@ func (m *GetLedgerPathQueryEnvelope) Reset()         { *m = GetLedgerPathQueryEn
 func (m *GetLedgerPathQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetLedgerPathQueryEnvelope) ProtoMessage()    {}
 func (*GetLedgerPathQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{19}
+	return fileDescriptor_5c6ac9b241082464, []int{60}
 }
 
 func (m *GetLedgerPathQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1006,7 +3058,7 @@ func (m *GetTxProofQuery) Reset()         { *m = GetTxProofQuery{} }
 func (m *GetTxProofQuery) String() string { return proto.CompactTextString(m) }
 func (*GetTxProofQuery) ProtoMessage()    {}
 func (*GetTxProofQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{20}
+	return fileDescriptor_5c6ac9b241082464, []int{61}
 }
 
 func (m *GetTxProofQuery) XXX_Unmarshal(b []byte) error {
@@ -1060,7 +3112,7 @@ func (m *GetTxProofQueryEnvelope) Reset()         { *m = GetTxProofQueryEnvelope
 func (m *GetTxProofQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetTxProofQueryEnvelope) ProtoMessage()    {}
 func (*GetTxProofQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{21}
+	return fileDescriptor_5c6ac9b241082464, []int{62}
 }
 
 func (m *GetTxProofQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1110,7 +3162,7 @@ func (m *GetDataProofQuery) Reset()         { *m = GetDataProofQuery{} }
 func (m *GetDataProofQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataProofQuery) ProtoMessage()    {}
 func (*GetDataProofQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{22}
+	return fileDescriptor_5c6ac9b241082464, []int{63}
 }
 
 func (m *GetDataProofQuery) XXX_Unmarshal(b []byte) error {
@@ -1178,7 +3230,7 @@ func (m *GetDataProofQueryEnvelope) Reset()         { *m = GetDataProofQueryEnve
 func (m *GetDataProofQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataProofQueryEnvelope) ProtoMessage()    {}
 func (*GetDataProofQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{23}
+	return fileDescriptor_5c6ac9b241082464, []int{64}
 }
 
 func (m *GetDataProofQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1213,14 +3265,120 @@ func (m *GetDataProofQueryEnvelope) GetSignature() []byte {
 	return nil
 }
 
-type GetHistoricalDataQuery struct {
+type GetDBStateRootQuery struct {
 	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
-	Key                  string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	Version              *Version `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
-	Direction            string   `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
-	OnlyDeletes          bool     `protobuf:"varint,6,opt,name=only_deletes,json=onlyDeletes,proto3" json:"only_deletes,omitempty"`
-	MostRecent           bool     `protobuf:"varint,7,opt,name=most_recent,json=mostRecent,proto3" json:"most_recent,omitempty"`
+	BlockNumber          uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	DbName               string   `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDBStateRootQuery) Reset()         { *m = GetDBStateRootQuery{} }
+func (m *GetDBStateRootQuery) String() string { return proto.CompactTextString(m) }
+func (*GetDBStateRootQuery) ProtoMessage()    {}
+func (*GetDBStateRootQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{65}
+}
+
+func (m *GetDBStateRootQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBStateRootQuery.Unmarshal(m, b)
+}
+func (m *GetDBStateRootQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBStateRootQuery.Marshal(b, m, deterministic)
+}
+func (m *GetDBStateRootQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBStateRootQuery.Merge(m, src)
+}
+func (m *GetDBStateRootQuery) XXX_Size() int {
+	return xxx_messageInfo_GetDBStateRootQuery.Size(m)
+}
+func (m *GetDBStateRootQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBStateRootQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDBStateRootQuery proto.InternalMessageInfo
+
+func (m *GetDBStateRootQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetDBStateRootQuery) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *GetDBStateRootQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+type GetDBStateRootQueryEnvelope struct {
+	Payload              *GetDBStateRootQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetDBStateRootQueryEnvelope) Reset()         { *m = GetDBStateRootQueryEnvelope{} }
+func (m *GetDBStateRootQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDBStateRootQueryEnvelope) ProtoMessage()    {}
+func (*GetDBStateRootQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{66}
+}
+
+func (m *GetDBStateRootQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBStateRootQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetDBStateRootQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBStateRootQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDBStateRootQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBStateRootQueryEnvelope.Merge(m, src)
+}
+func (m *GetDBStateRootQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDBStateRootQueryEnvelope.Size(m)
+}
+func (m *GetDBStateRootQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBStateRootQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDBStateRootQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetDBStateRootQueryEnvelope) GetPayload() *GetDBStateRootQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetDBStateRootQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetHistoricalDataQuery struct {
+	UserId      string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName      string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key         string   `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Version     *Version `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Direction   string   `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	OnlyDeletes bool     `protobuf:"varint,6,opt,name=only_deletes,json=onlyDeletes,proto3" json:"only_deletes,omitempty"`
+	MostRecent  bool     `protobuf:"varint,7,opt,name=most_recent,json=mostRecent,proto3" json:"most_recent,omitempty"`
+	// offset is the number of values skipped from the start of the history of all values of the key
+	Offset uint64 `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is the maximum number of values returned, where zero means no limit
+	Limit                uint64   `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1230,7 +3388,7 @@ func (m *GetHistoricalDataQuery) Reset()         { *m = GetHistoricalDataQuery{}
 func (m *GetHistoricalDataQuery) String() string { return proto.CompactTextString(m) }
 func (*GetHistoricalDataQuery) ProtoMessage()    {}
 func (*GetHistoricalDataQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{24}
+	return fileDescriptor_5c6ac9b241082464, []int{67}
 }
 
 func (m *GetHistoricalDataQuery) XXX_Unmarshal(b []byte) error {
@@ -1300,6 +3458,20 @@ func (m *GetHistoricalDataQuery) GetMostRecent() bool {
 	return false
 }
 
+func (m *GetHistoricalDataQuery) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetHistoricalDataQuery) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 type GetHistoricalDataQueryEnvelope struct {
 	Payload              *GetHistoricalDataQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
 	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
@@ -1312,7 +3484,7 @@ func (m *GetHistoricalDataQueryEnvelope) Reset()         { *m = GetHistoricalDat
 func (m *GetHistoricalDataQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetHistoricalDataQueryEnvelope) ProtoMessage()    {}
 func (*GetHistoricalDataQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{25}
+	return fileDescriptor_5c6ac9b241082464, []int{68}
 }
 
 func (m *GetHistoricalDataQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1360,7 +3532,7 @@ func (m *GetDataReadersQuery) Reset()         { *m = GetDataReadersQuery{} }
 func (m *GetDataReadersQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadersQuery) ProtoMessage()    {}
 func (*GetDataReadersQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{26}
+	return fileDescriptor_5c6ac9b241082464, []int{69}
 }
 
 func (m *GetDataReadersQuery) XXX_Unmarshal(b []byte) error {
@@ -1414,7 +3586,7 @@ func (m *GetDataReadersQueryEnvelope) Reset()         { *m = GetDataReadersQuery
 func (m *GetDataReadersQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadersQueryEnvelope) ProtoMessage()    {}
 func (*GetDataReadersQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{27}
+	return fileDescriptor_5c6ac9b241082464, []int{70}
 }
 
 func (m *GetDataReadersQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1462,7 +3634,7 @@ func (m *GetDataWritersQuery) Reset()         { *m = GetDataWritersQuery{} }
 func (m *GetDataWritersQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataWritersQuery) ProtoMessage()    {}
 func (*GetDataWritersQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{28}
+	return fileDescriptor_5c6ac9b241082464, []int{71}
 }
 
 func (m *GetDataWritersQuery) XXX_Unmarshal(b []byte) error {
@@ -1516,7 +3688,7 @@ func (m *GetDataWritersQueryEnvelope) Reset()         { *m = GetDataWritersQuery
 func (m *GetDataWritersQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataWritersQueryEnvelope) ProtoMessage()    {}
 func (*GetDataWritersQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{29}
+	return fileDescriptor_5c6ac9b241082464, []int{72}
 }
 
 func (m *GetDataWritersQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1563,7 +3735,7 @@ func (m *GetDataReadByQuery) Reset()         { *m = GetDataReadByQuery{} }
 func (m *GetDataReadByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadByQuery) ProtoMessage()    {}
 func (*GetDataReadByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{30}
+	return fileDescriptor_5c6ac9b241082464, []int{73}
 }
 
 func (m *GetDataReadByQuery) XXX_Unmarshal(b []byte) error {
@@ -1610,7 +3782,7 @@ func (m *GetDataReadByQueryEnvelope) Reset()         { *m = GetDataReadByQueryEn
 func (m *GetDataReadByQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataReadByQueryEnvelope) ProtoMessage()    {}
 func (*GetDataReadByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{31}
+	return fileDescriptor_5c6ac9b241082464, []int{74}
 }
 
 func (m *GetDataReadByQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1657,7 +3829,7 @@ func (m *GetDataWrittenByQuery) Reset()         { *m = GetDataWrittenByQuery{} }
 func (m *GetDataWrittenByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataWrittenByQuery) ProtoMessage()    {}
 func (*GetDataWrittenByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{32}
+	return fileDescriptor_5c6ac9b241082464, []int{75}
 }
 
 func (m *GetDataWrittenByQuery) XXX_Unmarshal(b []byte) error {
@@ -1704,7 +3876,7 @@ func (m *GetDataDeletedByQuery) Reset()         { *m = GetDataDeletedByQuery{} }
 func (m *GetDataDeletedByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetDataDeletedByQuery) ProtoMessage()    {}
 func (*GetDataDeletedByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{33}
+	return fileDescriptor_5c6ac9b241082464, []int{76}
 }
 
 func (m *GetDataDeletedByQuery) XXX_Unmarshal(b []byte) error {
@@ -1751,7 +3923,7 @@ func (m *GetDataDeletedByQueryEnvelope) Reset()         { *m = GetDataDeletedByQ
 func (m *GetDataDeletedByQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataDeletedByQueryEnvelope) ProtoMessage()    {}
 func (*GetDataDeletedByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{34}
+	return fileDescriptor_5c6ac9b241082464, []int{77}
 }
 
 func (m *GetDataDeletedByQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1798,7 +3970,7 @@ func (m *GetDataWrittenByQueryEnvelope) Reset()         { *m = GetDataWrittenByQ
 func (m *GetDataWrittenByQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetDataWrittenByQueryEnvelope) ProtoMessage()    {}
 func (*GetDataWrittenByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{35}
+	return fileDescriptor_5c6ac9b241082464, []int{78}
 }
 
 func (m *GetDataWrittenByQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1845,7 +4017,7 @@ func (m *GetTxIDsSubmittedByQuery) Reset()         { *m = GetTxIDsSubmittedByQue
 func (m *GetTxIDsSubmittedByQuery) String() string { return proto.CompactTextString(m) }
 func (*GetTxIDsSubmittedByQuery) ProtoMessage()    {}
 func (*GetTxIDsSubmittedByQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{36}
+	return fileDescriptor_5c6ac9b241082464, []int{79}
 }
 
 func (m *GetTxIDsSubmittedByQuery) XXX_Unmarshal(b []byte) error {
@@ -1866,61 +4038,182 @@ func (m *GetTxIDsSubmittedByQuery) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GetTxIDsSubmittedByQuery proto.InternalMessageInfo
 
-func (m *GetTxIDsSubmittedByQuery) GetUserId() string {
+func (m *GetTxIDsSubmittedByQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetTxIDsSubmittedByQuery) GetTargetUserId() string {
+	if m != nil {
+		return m.TargetUserId
+	}
+	return ""
+}
+
+type GetTxIDsSubmittedByQueryEnvelope struct {
+	Payload              *GetTxIDsSubmittedByQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetTxIDsSubmittedByQueryEnvelope) Reset()         { *m = GetTxIDsSubmittedByQueryEnvelope{} }
+func (m *GetTxIDsSubmittedByQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxIDsSubmittedByQueryEnvelope) ProtoMessage()    {}
+func (*GetTxIDsSubmittedByQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{80}
+}
+
+func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Merge(m, src)
+}
+func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Size(m)
+}
+func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetTxIDsSubmittedByQueryEnvelope) GetPayload() *GetTxIDsSubmittedByQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetTxIDsSubmittedByQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetTxsByAnnotationQuery searches the transactions that carry an annotation, e.g., a correlation ID
+type GetTxsByAnnotationQuery struct {
+	UserId          string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AnnotationKey   string `protobuf:"bytes,2,opt,name=annotation_key,json=annotationKey,proto3" json:"annotation_key,omitempty"`
+	AnnotationValue string `protobuf:"bytes,3,opt,name=annotation_value,json=annotationValue,proto3" json:"annotation_value,omitempty"`
+	// offset is the number of transactions skipped from the start of the transactions found, ordered by their location
+	Offset uint64 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit is the maximum number of transactions returned, where zero means no limit
+	Limit                uint64   `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxsByAnnotationQuery) Reset()         { *m = GetTxsByAnnotationQuery{} }
+func (m *GetTxsByAnnotationQuery) String() string { return proto.CompactTextString(m) }
+func (*GetTxsByAnnotationQuery) ProtoMessage()    {}
+func (*GetTxsByAnnotationQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{81}
+}
+
+func (m *GetTxsByAnnotationQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxsByAnnotationQuery.Unmarshal(m, b)
+}
+func (m *GetTxsByAnnotationQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxsByAnnotationQuery.Marshal(b, m, deterministic)
+}
+func (m *GetTxsByAnnotationQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxsByAnnotationQuery.Merge(m, src)
+}
+func (m *GetTxsByAnnotationQuery) XXX_Size() int {
+	return xxx_messageInfo_GetTxsByAnnotationQuery.Size(m)
+}
+func (m *GetTxsByAnnotationQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxsByAnnotationQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxsByAnnotationQuery proto.InternalMessageInfo
+
+func (m *GetTxsByAnnotationQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetTxsByAnnotationQuery) GetAnnotationKey() string {
 	if m != nil {
-		return m.UserId
+		return m.AnnotationKey
 	}
 	return ""
 }
 
-func (m *GetTxIDsSubmittedByQuery) GetTargetUserId() string {
+func (m *GetTxsByAnnotationQuery) GetAnnotationValue() string {
 	if m != nil {
-		return m.TargetUserId
+		return m.AnnotationValue
 	}
 	return ""
 }
 
-type GetTxIDsSubmittedByQueryEnvelope struct {
-	Payload              *GetTxIDsSubmittedByQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+func (m *GetTxsByAnnotationQuery) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
 }
 
-func (m *GetTxIDsSubmittedByQueryEnvelope) Reset()         { *m = GetTxIDsSubmittedByQueryEnvelope{} }
-func (m *GetTxIDsSubmittedByQueryEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetTxIDsSubmittedByQueryEnvelope) ProtoMessage()    {}
-func (*GetTxIDsSubmittedByQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{37}
+func (m *GetTxsByAnnotationQuery) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
 }
 
-func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Unmarshal(m, b)
+type GetTxsByAnnotationQueryEnvelope struct {
+	Payload              *GetTxsByAnnotationQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
 }
-func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Marshal(b, m, deterministic)
+
+func (m *GetTxsByAnnotationQueryEnvelope) Reset()         { *m = GetTxsByAnnotationQueryEnvelope{} }
+func (m *GetTxsByAnnotationQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxsByAnnotationQueryEnvelope) ProtoMessage()    {}
+func (*GetTxsByAnnotationQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{82}
 }
-func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Merge(m, src)
+
+func (m *GetTxsByAnnotationQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxsByAnnotationQueryEnvelope.Unmarshal(m, b)
 }
-func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.Size(m)
+func (m *GetTxsByAnnotationQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxsByAnnotationQueryEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetTxIDsSubmittedByQueryEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope.DiscardUnknown(m)
+func (m *GetTxsByAnnotationQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxsByAnnotationQueryEnvelope.Merge(m, src)
+}
+func (m *GetTxsByAnnotationQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxsByAnnotationQueryEnvelope.Size(m)
+}
+func (m *GetTxsByAnnotationQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxsByAnnotationQueryEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTxIDsSubmittedByQueryEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetTxsByAnnotationQueryEnvelope proto.InternalMessageInfo
 
-func (m *GetTxIDsSubmittedByQueryEnvelope) GetPayload() *GetTxIDsSubmittedByQuery {
+func (m *GetTxsByAnnotationQueryEnvelope) GetPayload() *GetTxsByAnnotationQuery {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (m *GetTxIDsSubmittedByQueryEnvelope) GetSignature() []byte {
+func (m *GetTxsByAnnotationQueryEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
@@ -1939,7 +4232,7 @@ func (m *GetTxReceiptQuery) Reset()         { *m = GetTxReceiptQuery{} }
 func (m *GetTxReceiptQuery) String() string { return proto.CompactTextString(m) }
 func (*GetTxReceiptQuery) ProtoMessage()    {}
 func (*GetTxReceiptQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{38}
+	return fileDescriptor_5c6ac9b241082464, []int{83}
 }
 
 func (m *GetTxReceiptQuery) XXX_Unmarshal(b []byte) error {
@@ -1986,7 +4279,7 @@ func (m *GetTxReceiptQueryEnvelope) Reset()         { *m = GetTxReceiptQueryEnve
 func (m *GetTxReceiptQueryEnvelope) String() string { return proto.CompactTextString(m) }
 func (*GetTxReceiptQueryEnvelope) ProtoMessage()    {}
 func (*GetTxReceiptQueryEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{39}
+	return fileDescriptor_5c6ac9b241082464, []int{84}
 }
 
 func (m *GetTxReceiptQueryEnvelope) XXX_Unmarshal(b []byte) error {
@@ -2021,6 +4314,196 @@ func (m *GetTxReceiptQueryEnvelope) GetSignature() []byte {
 	return nil
 }
 
+// GetTxReceiptsQuery gets the receipts of many transactions at once, e.g., to reconcile a batch of submitted
+// transactions with the ledger
+type GetTxReceiptsQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TxIds                []string `protobuf:"bytes,2,rep,name=tx_ids,json=txIds,proto3" json:"tx_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxReceiptsQuery) Reset()         { *m = GetTxReceiptsQuery{} }
+func (m *GetTxReceiptsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetTxReceiptsQuery) ProtoMessage()    {}
+func (*GetTxReceiptsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{85}
+}
+
+func (m *GetTxReceiptsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxReceiptsQuery.Unmarshal(m, b)
+}
+func (m *GetTxReceiptsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxReceiptsQuery.Marshal(b, m, deterministic)
+}
+func (m *GetTxReceiptsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxReceiptsQuery.Merge(m, src)
+}
+func (m *GetTxReceiptsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetTxReceiptsQuery.Size(m)
+}
+func (m *GetTxReceiptsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxReceiptsQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxReceiptsQuery proto.InternalMessageInfo
+
+func (m *GetTxReceiptsQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetTxReceiptsQuery) GetTxIds() []string {
+	if m != nil {
+		return m.TxIds
+	}
+	return nil
+}
+
+type GetTxReceiptsQueryEnvelope struct {
+	Payload              *GetTxReceiptsQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetTxReceiptsQueryEnvelope) Reset()         { *m = GetTxReceiptsQueryEnvelope{} }
+func (m *GetTxReceiptsQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxReceiptsQueryEnvelope) ProtoMessage()    {}
+func (*GetTxReceiptsQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{86}
+}
+
+func (m *GetTxReceiptsQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxReceiptsQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxReceiptsQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxReceiptsQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxReceiptsQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxReceiptsQueryEnvelope.Merge(m, src)
+}
+func (m *GetTxReceiptsQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxReceiptsQueryEnvelope.Size(m)
+}
+func (m *GetTxReceiptsQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxReceiptsQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxReceiptsQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetTxReceiptsQueryEnvelope) GetPayload() *GetTxReceiptsQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetTxReceiptsQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetTxResourceUsageQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TxId                 string   `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxResourceUsageQuery) Reset()         { *m = GetTxResourceUsageQuery{} }
+func (m *GetTxResourceUsageQuery) String() string { return proto.CompactTextString(m) }
+func (*GetTxResourceUsageQuery) ProtoMessage()    {}
+func (*GetTxResourceUsageQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{87}
+}
+
+func (m *GetTxResourceUsageQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxResourceUsageQuery.Unmarshal(m, b)
+}
+func (m *GetTxResourceUsageQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxResourceUsageQuery.Marshal(b, m, deterministic)
+}
+func (m *GetTxResourceUsageQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxResourceUsageQuery.Merge(m, src)
+}
+func (m *GetTxResourceUsageQuery) XXX_Size() int {
+	return xxx_messageInfo_GetTxResourceUsageQuery.Size(m)
+}
+func (m *GetTxResourceUsageQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxResourceUsageQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxResourceUsageQuery proto.InternalMessageInfo
+
+func (m *GetTxResourceUsageQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetTxResourceUsageQuery) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+type GetTxResourceUsageQueryEnvelope struct {
+	Payload              *GetTxResourceUsageQuery `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature            []byte                   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GetTxResourceUsageQueryEnvelope) Reset()         { *m = GetTxResourceUsageQueryEnvelope{} }
+func (m *GetTxResourceUsageQueryEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxResourceUsageQueryEnvelope) ProtoMessage()    {}
+func (*GetTxResourceUsageQueryEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{88}
+}
+
+func (m *GetTxResourceUsageQueryEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxResourceUsageQueryEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxResourceUsageQueryEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxResourceUsageQueryEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxResourceUsageQueryEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxResourceUsageQueryEnvelope.Merge(m, src)
+}
+func (m *GetTxResourceUsageQueryEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxResourceUsageQueryEnvelope.Size(m)
+}
+func (m *GetTxResourceUsageQueryEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxResourceUsageQueryEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxResourceUsageQueryEnvelope proto.InternalMessageInfo
+
+func (m *GetTxResourceUsageQueryEnvelope) GetPayload() *GetTxResourceUsageQuery {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *GetTxResourceUsageQueryEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
 type GetMostRecentUserOrNodeQuery struct {
 	Type                 GetMostRecentUserOrNodeQuery_Type `protobuf:"varint,1,opt,name=type,proto3,enum=types.GetMostRecentUserOrNodeQuery_Type" json:"type,omitempty"`
 	UserId               string                            `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -2035,7 +4518,7 @@ func (m *GetMostRecentUserOrNodeQuery) Reset()         { *m = GetMostRecentUserO
 func (m *GetMostRecentUserOrNodeQuery) String() string { return proto.CompactTextString(m) }
 func (*GetMostRecentUserOrNodeQuery) ProtoMessage()    {}
 func (*GetMostRecentUserOrNodeQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{40}
+	return fileDescriptor_5c6ac9b241082464, []int{89}
 }
 
 func (m *GetMostRecentUserOrNodeQuery) XXX_Unmarshal(b []byte) error {
@@ -2097,7 +4580,7 @@ func (m *DataJSONQuery) Reset()         { *m = DataJSONQuery{} }
 func (m *DataJSONQuery) String() string { return proto.CompactTextString(m) }
 func (*DataJSONQuery) ProtoMessage()    {}
 func (*DataJSONQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_5c6ac9b241082464, []int{41}
+	return fileDescriptor_5c6ac9b241082464, []int{90}
 }
 
 func (m *DataJSONQuery) XXX_Unmarshal(b []byte) error {
@@ -2139,14 +4622,222 @@ func (m *DataJSONQuery) GetQuery() string {
 	return ""
 }
 
+// ExplainJSONQuery requests the execution plan of a JSON query, without executing it. The query is held in
+// explained_query, rather than in query as in DataJSONQuery, so that the signature of an explain request cannot be
+// replayed to execute the query.
+type ExplainJSONQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName               string   `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	ExplainedQuery       string   `protobuf:"bytes,3,opt,name=explained_query,json=explainedQuery,proto3" json:"explained_query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExplainJSONQuery) Reset()         { *m = ExplainJSONQuery{} }
+func (m *ExplainJSONQuery) String() string { return proto.CompactTextString(m) }
+func (*ExplainJSONQuery) ProtoMessage()    {}
+func (*ExplainJSONQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{91}
+}
+
+func (m *ExplainJSONQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainJSONQuery.Unmarshal(m, b)
+}
+func (m *ExplainJSONQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainJSONQuery.Marshal(b, m, deterministic)
+}
+func (m *ExplainJSONQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainJSONQuery.Merge(m, src)
+}
+func (m *ExplainJSONQuery) XXX_Size() int {
+	return xxx_messageInfo_ExplainJSONQuery.Size(m)
+}
+func (m *ExplainJSONQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainJSONQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainJSONQuery proto.InternalMessageInfo
+
+func (m *ExplainJSONQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ExplainJSONQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *ExplainJSONQuery) GetExplainedQuery() string {
+	if m != nil {
+		return m.ExplainedQuery
+	}
+	return ""
+}
+
+// DataSQLQuery holds a read-only query of the SQL dialect, which names the database it reads
+type DataSQLQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Query                string   `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataSQLQuery) Reset()         { *m = DataSQLQuery{} }
+func (m *DataSQLQuery) String() string { return proto.CompactTextString(m) }
+func (*DataSQLQuery) ProtoMessage()    {}
+func (*DataSQLQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{92}
+}
+
+func (m *DataSQLQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataSQLQuery.Unmarshal(m, b)
+}
+func (m *DataSQLQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataSQLQuery.Marshal(b, m, deterministic)
+}
+func (m *DataSQLQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataSQLQuery.Merge(m, src)
+}
+func (m *DataSQLQuery) XXX_Size() int {
+	return xxx_messageInfo_DataSQLQuery.Size(m)
+}
+func (m *DataSQLQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataSQLQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataSQLQuery proto.InternalMessageInfo
+
+func (m *DataSQLQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *DataSQLQuery) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+type GetPendingDataTxQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TxId                 string   `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPendingDataTxQuery) Reset()         { *m = GetPendingDataTxQuery{} }
+func (m *GetPendingDataTxQuery) String() string { return proto.CompactTextString(m) }
+func (*GetPendingDataTxQuery) ProtoMessage()    {}
+func (*GetPendingDataTxQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{93}
+}
+
+func (m *GetPendingDataTxQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingDataTxQuery.Unmarshal(m, b)
+}
+func (m *GetPendingDataTxQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingDataTxQuery.Marshal(b, m, deterministic)
+}
+func (m *GetPendingDataTxQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingDataTxQuery.Merge(m, src)
+}
+func (m *GetPendingDataTxQuery) XXX_Size() int {
+	return xxx_messageInfo_GetPendingDataTxQuery.Size(m)
+}
+func (m *GetPendingDataTxQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingDataTxQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPendingDataTxQuery proto.InternalMessageInfo
+
+func (m *GetPendingDataTxQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *GetPendingDataTxQuery) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+// GetPendingDataTxsQuery fetches the pending data transactions that await the signature of the user
+type GetPendingDataTxsQuery struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPendingDataTxsQuery) Reset()         { *m = GetPendingDataTxsQuery{} }
+func (m *GetPendingDataTxsQuery) String() string { return proto.CompactTextString(m) }
+func (*GetPendingDataTxsQuery) ProtoMessage()    {}
+func (*GetPendingDataTxsQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5c6ac9b241082464, []int{94}
+}
+
+func (m *GetPendingDataTxsQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingDataTxsQuery.Unmarshal(m, b)
+}
+func (m *GetPendingDataTxsQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingDataTxsQuery.Marshal(b, m, deterministic)
+}
+func (m *GetPendingDataTxsQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingDataTxsQuery.Merge(m, src)
+}
+func (m *GetPendingDataTxsQuery) XXX_Size() int {
+	return xxx_messageInfo_GetPendingDataTxsQuery.Size(m)
+}
+func (m *GetPendingDataTxsQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingDataTxsQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPendingDataTxsQuery proto.InternalMessageInfo
+
+func (m *GetPendingDataTxsQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("types.GetMostRecentUserOrNodeQuery_Type", GetMostRecentUserOrNodeQuery_Type_name, GetMostRecentUserOrNodeQuery_Type_value)
 	proto.RegisterType((*GetDBStatusQueryEnvelope)(nil), "types.GetDBStatusQueryEnvelope")
 	proto.RegisterType((*GetDBStatusQuery)(nil), "types.GetDBStatusQuery")
+	proto.RegisterType((*GetDBsQueryEnvelope)(nil), "types.GetDBsQueryEnvelope")
+	proto.RegisterType((*GetDBsQuery)(nil), "types.GetDBsQuery")
+	proto.RegisterType((*GetIndexUsageQueryEnvelope)(nil), "types.GetIndexUsageQueryEnvelope")
+	proto.RegisterType((*GetIndexUsageQuery)(nil), "types.GetIndexUsageQuery")
+	proto.RegisterType((*GetMigrationsQueryEnvelope)(nil), "types.GetMigrationsQueryEnvelope")
+	proto.RegisterType((*GetMigrationsQuery)(nil), "types.GetMigrationsQuery")
 	proto.RegisterType((*GetDataQueryEnvelope)(nil), "types.GetDataQueryEnvelope")
 	proto.RegisterType((*GetDataQuery)(nil), "types.GetDataQuery")
+	proto.RegisterType((*GetDataKeysQueryEnvelope)(nil), "types.GetDataKeysQueryEnvelope")
+	proto.RegisterType((*GetDataKeysQuery)(nil), "types.GetDataKeysQuery")
+	proto.RegisterType((*OpenDataCursorQuery)(nil), "types.OpenDataCursorQuery")
+	proto.RegisterType((*GetDataCursorPageQuery)(nil), "types.GetDataCursorPageQuery")
+	proto.RegisterType((*CloseDataCursorQuery)(nil), "types.CloseDataCursorQuery")
+	proto.RegisterType((*QueryReplayGuard)(nil), "types.QueryReplayGuard")
+	proto.RegisterType((*SessionLoginQuery)(nil), "types.SessionLoginQuery")
 	proto.RegisterType((*GetUserQueryEnvelope)(nil), "types.GetUserQueryEnvelope")
 	proto.RegisterType((*GetUserQuery)(nil), "types.GetUserQuery")
+	proto.RegisterType((*GetUsersQueryEnvelope)(nil), "types.GetUsersQueryEnvelope")
+	proto.RegisterType((*GetUsersQuery)(nil), "types.GetUsersQuery")
 	proto.RegisterType((*GetConfigQueryEnvelope)(nil), "types.GetConfigQueryEnvelope")
 	proto.RegisterType((*GetConfigQuery)(nil), "types.GetConfigQuery")
 	proto.RegisterType((*GetNodeConfigQueryEnvelope)(nil), "types.GetNodeConfigQueryEnvelope")
@@ -2155,6 +4846,32 @@ func init() {
 	proto.RegisterType((*GetConfigBlockQuery)(nil), "types.GetConfigBlockQuery")
 	proto.RegisterType((*GetClusterStatusQueryEnvelope)(nil), "types.GetClusterStatusQueryEnvelope")
 	proto.RegisterType((*GetClusterStatusQuery)(nil), "types.GetClusterStatusQuery")
+	proto.RegisterType((*GetConfigHistoryQueryEnvelope)(nil), "types.GetConfigHistoryQueryEnvelope")
+	proto.RegisterType((*GetConfigHistoryQuery)(nil), "types.GetConfigHistoryQuery")
+	proto.RegisterType((*GetConfigDiffQueryEnvelope)(nil), "types.GetConfigDiffQueryEnvelope")
+	proto.RegisterType((*GetConfigDiffQuery)(nil), "types.GetConfigDiffQuery")
+	proto.RegisterType((*TriggerSnapshotQueryEnvelope)(nil), "types.TriggerSnapshotQueryEnvelope")
+	proto.RegisterType((*TriggerSnapshotQuery)(nil), "types.TriggerSnapshotQuery")
+	proto.RegisterType((*TransferLeadershipQueryEnvelope)(nil), "types.TransferLeadershipQueryEnvelope")
+	proto.RegisterType((*TransferLeadershipQuery)(nil), "types.TransferLeadershipQuery")
+	proto.RegisterType((*GetConsensusDiagnosticsQueryEnvelope)(nil), "types.GetConsensusDiagnosticsQueryEnvelope")
+	proto.RegisterType((*GetConsensusDiagnosticsQuery)(nil), "types.GetConsensusDiagnosticsQuery")
+	proto.RegisterType((*GetStorageReportQueryEnvelope)(nil), "types.GetStorageReportQueryEnvelope")
+	proto.RegisterType((*GetStorageReportQuery)(nil), "types.GetStorageReportQuery")
+	proto.RegisterType((*GetStateHashQueryEnvelope)(nil), "types.GetStateHashQueryEnvelope")
+	proto.RegisterType((*GetStateHashQuery)(nil), "types.GetStateHashQuery")
+	proto.RegisterType((*CompactIndexQueryEnvelope)(nil), "types.CompactIndexQueryEnvelope")
+	proto.RegisterType((*CompactIndexQuery)(nil), "types.CompactIndexQuery")
+	proto.RegisterType((*GetQuarantinedBlockQueryEnvelope)(nil), "types.GetQuarantinedBlockQueryEnvelope")
+	proto.RegisterType((*GetQuarantinedBlockQuery)(nil), "types.GetQuarantinedBlockQuery")
+	proto.RegisterType((*GetRejectedTxsQueryEnvelope)(nil), "types.GetRejectedTxsQueryEnvelope")
+	proto.RegisterType((*GetRejectedTxsQuery)(nil), "types.GetRejectedTxsQuery")
+	proto.RegisterType((*GetSlowQueriesQueryEnvelope)(nil), "types.GetSlowQueriesQueryEnvelope")
+	proto.RegisterType((*GetSlowQueriesQuery)(nil), "types.GetSlowQueriesQuery")
+	proto.RegisterType((*GetWitnessStatusQueryEnvelope)(nil), "types.GetWitnessStatusQueryEnvelope")
+	proto.RegisterType((*GetWitnessStatusQuery)(nil), "types.GetWitnessStatusQuery")
+	proto.RegisterType((*GetDiagnosticsQueryEnvelope)(nil), "types.GetDiagnosticsQueryEnvelope")
+	proto.RegisterType((*GetDiagnosticsQuery)(nil), "types.GetDiagnosticsQuery")
 	proto.RegisterType((*GetBlockQuery)(nil), "types.GetBlockQuery")
 	proto.RegisterType((*GetBlockQueryEnvelope)(nil), "types.GetBlockQueryEnvelope")
 	proto.RegisterType((*GetLastBlockQuery)(nil), "types.GetLastBlockQuery")
@@ -2165,6 +4882,8 @@ func init() {
 	proto.RegisterType((*GetTxProofQueryEnvelope)(nil), "types.GetTxProofQueryEnvelope")
 	proto.RegisterType((*GetDataProofQuery)(nil), "types.GetDataProofQuery")
 	proto.RegisterType((*GetDataProofQueryEnvelope)(nil), "types.GetDataProofQueryEnvelope")
+	proto.RegisterType((*GetDBStateRootQuery)(nil), "types.GetDBStateRootQuery")
+	proto.RegisterType((*GetDBStateRootQueryEnvelope)(nil), "types.GetDBStateRootQueryEnvelope")
 	proto.RegisterType((*GetHistoricalDataQuery)(nil), "types.GetHistoricalDataQuery")
 	proto.RegisterType((*GetHistoricalDataQueryEnvelope)(nil), "types.GetHistoricalDataQueryEnvelope")
 	proto.RegisterType((*GetDataReadersQuery)(nil), "types.GetDataReadersQuery")
@@ -2179,82 +4898,153 @@ func init() {
 	proto.RegisterType((*GetDataWrittenByQueryEnvelope)(nil), "types.GetDataWrittenByQueryEnvelope")
 	proto.RegisterType((*GetTxIDsSubmittedByQuery)(nil), "types.GetTxIDsSubmittedByQuery")
 	proto.RegisterType((*GetTxIDsSubmittedByQueryEnvelope)(nil), "types.GetTxIDsSubmittedByQueryEnvelope")
+	proto.RegisterType((*GetTxsByAnnotationQuery)(nil), "types.GetTxsByAnnotationQuery")
+	proto.RegisterType((*GetTxsByAnnotationQueryEnvelope)(nil), "types.GetTxsByAnnotationQueryEnvelope")
 	proto.RegisterType((*GetTxReceiptQuery)(nil), "types.GetTxReceiptQuery")
 	proto.RegisterType((*GetTxReceiptQueryEnvelope)(nil), "types.GetTxReceiptQueryEnvelope")
+	proto.RegisterType((*GetTxReceiptsQuery)(nil), "types.GetTxReceiptsQuery")
+	proto.RegisterType((*GetTxReceiptsQueryEnvelope)(nil), "types.GetTxReceiptsQueryEnvelope")
+	proto.RegisterType((*GetTxResourceUsageQuery)(nil), "types.GetTxResourceUsageQuery")
+	proto.RegisterType((*GetTxResourceUsageQueryEnvelope)(nil), "types.GetTxResourceUsageQueryEnvelope")
 	proto.RegisterType((*GetMostRecentUserOrNodeQuery)(nil), "types.GetMostRecentUserOrNodeQuery")
 	proto.RegisterType((*DataJSONQuery)(nil), "types.DataJSONQuery")
+	proto.RegisterType((*ExplainJSONQuery)(nil), "types.ExplainJSONQuery")
+	proto.RegisterType((*DataSQLQuery)(nil), "types.DataSQLQuery")
+	proto.RegisterType((*GetPendingDataTxQuery)(nil), "types.GetPendingDataTxQuery")
+	proto.RegisterType((*GetPendingDataTxsQuery)(nil), "types.GetPendingDataTxsQuery")
 }
 
 func init() { proto.RegisterFile("query.proto", fileDescriptor_5c6ac9b241082464) }
 
 var fileDescriptor_5c6ac9b241082464 = []byte{
-	// 1075 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xc6, 0x89, 0xf3, 0x77, 0x9c, 0x1a, 0xa3, 0xa6, 0x8d, 0x93, 0x26, 0x34, 0x68, 0x18, 0x26,
-	0xcc, 0x34, 0x0e, 0xa4, 0x1d, 0x18, 0x66, 0xb8, 0xc1, 0x75, 0x30, 0x61, 0x5a, 0xa7, 0x95, 0x93,
-	0x16, 0xb8, 0xf1, 0xac, 0xad, 0x13, 0x67, 0x27, 0xb6, 0xd6, 0xdd, 0x5d, 0x05, 0x7b, 0xb8, 0xe6,
-	0x21, 0x78, 0x26, 0x5e, 0x84, 0xc7, 0x60, 0x76, 0x65, 0x5b, 0xd2, 0x5a, 0xa6, 0x1b, 0x30, 0x77,
-	0xd1, 0xd1, 0xf9, 0xce, 0x7e, 0xdf, 0xa7, 0xdd, 0x73, 0x36, 0x86, 0xc2, 0xbb, 0x10, 0xf9, 0xa8,
-	0x32, 0xe0, 0x4c, 0x32, 0x67, 0x45, 0x8e, 0x06, 0x28, 0x76, 0x1f, 0xb5, 0x7b, 0xac, 0x73, 0xd3,
-	0x22, 0x81, 0xdf, 0x92, 0x9c, 0x04, 0x82, 0x74, 0x24, 0x65, 0x41, 0x94, 0xe3, 0xde, 0x40, 0xb9,
-	0x8e, 0xb2, 0x56, 0x6d, 0x4a, 0x22, 0x43, 0xf1, 0x5a, 0xa1, 0x4f, 0x83, 0x5b, 0xec, 0xb1, 0x01,
-	0x3a, 0x5f, 0xc2, 0xda, 0x80, 0x8c, 0x7a, 0x8c, 0xf8, 0xe5, 0xdc, 0x41, 0xee, 0xb0, 0x70, 0xb2,
-	0x5d, 0xd1, 0x15, 0x2b, 0x26, 0xc2, 0x9b, 0xe4, 0x39, 0x7b, 0xb0, 0x21, 0x68, 0x37, 0x20, 0x32,
-	0xe4, 0x58, 0x5e, 0x3a, 0xc8, 0x1d, 0x6e, 0x7a, 0x71, 0xc0, 0xad, 0x41, 0xc9, 0x84, 0x3a, 0xdb,
-	0xb0, 0x16, 0x0a, 0xe4, 0x2d, 0x1a, 0x2d, 0xb2, 0xe1, 0xad, 0xaa, 0xc7, 0x33, 0x5f, 0xbd, 0xf0,
-	0xdb, 0xad, 0x80, 0xf4, 0xa3, 0x42, 0x1b, 0xde, 0xaa, 0xdf, 0x6e, 0x90, 0x3e, 0xba, 0x1d, 0xd8,
-	0x52, 0x55, 0x88, 0x24, 0x69, 0xba, 0x47, 0x26, 0xdd, 0xfb, 0x09, 0xba, 0x93, 0x6c, 0x5b, 0xaa,
-	0x1e, 0x6c, 0x26, 0x61, 0x77, 0xa7, 0xe9, 0x94, 0x60, 0xf9, 0x06, 0x47, 0xe5, 0x65, 0x1d, 0x54,
-	0x7f, 0x8e, 0x89, 0x5f, 0x0a, 0xe4, 0xf6, 0xc4, 0xa7, 0xd9, 0xb6, 0xc4, 0x5f, 0x6a, 0xe2, 0x53,
-	0xd8, 0x7c, 0xe2, 0x9f, 0x42, 0x51, 0x12, 0xde, 0x45, 0xd9, 0x9a, 0xbc, 0x8f, 0xf8, 0x6f, 0x46,
-	0xd1, 0x4b, 0x9d, 0xe5, 0x76, 0xe1, 0x61, 0x1d, 0xe5, 0x73, 0x16, 0x5c, 0xd1, 0x6e, 0x9a, 0xf5,
-	0xb1, 0xc9, 0xfa, 0x41, 0xcc, 0x3a, 0x91, 0x6f, 0xcb, 0xfb, 0x73, 0x28, 0xa6, 0x81, 0x73, 0x99,
-	0xbb, 0x0c, 0x76, 0xeb, 0x28, 0x1b, 0xcc, 0xc7, 0x2c, 0x5e, 0x4f, 0x4d, 0x5e, 0x3b, 0x31, 0x2f,
-	0x03, 0x63, 0xcb, 0xed, 0x7b, 0x70, 0x66, 0xc1, 0xff, 0xb8, 0x25, 0x02, 0xe6, 0x63, 0x6c, 0xe9,
-	0xaa, 0x7a, 0x3c, 0xf3, 0xdd, 0x81, 0x22, 0x1e, 0x95, 0xa8, 0xaa, 0x33, 0x99, 0x26, 0xfe, 0xcc,
-	0x24, 0xbe, 0x6b, 0x1a, 0x1a, 0x83, 0x6c, 0x99, 0xbf, 0x86, 0xfb, 0x19, 0xe8, 0xf9, 0xd4, 0x3f,
-	0x81, 0xcd, 0xa8, 0x5b, 0x04, 0x61, 0xbf, 0x8d, 0x5c, 0x17, 0xcc, 0x7b, 0x05, 0x1d, 0x6b, 0xe8,
-	0x90, 0x1b, 0xc2, 0xbe, 0x2a, 0xd9, 0x0b, 0x85, 0x44, 0x9e, 0xd5, 0x36, 0xbe, 0x32, 0x75, 0xec,
-	0x25, 0x74, 0xcc, 0xc0, 0x6c, 0x95, 0xfc, 0x04, 0x0f, 0x32, 0xf1, 0xf3, 0xb5, 0x7c, 0x06, 0xc5,
-	0x80, 0x3d, 0x47, 0x2e, 0xe9, 0x15, 0xed, 0x10, 0x89, 0x42, 0x17, 0x5d, 0xf7, 0x8c, 0xa8, 0x4b,
-	0xe1, 0x5e, 0x1d, 0xe5, 0x62, 0xdc, 0x51, 0x22, 0x48, 0xd8, 0xed, 0x63, 0x20, 0xd1, 0xd7, 0x67,
-	0x7f, 0xdd, 0x8b, 0x03, 0x2e, 0x6a, 0x11, 0x19, 0xdf, 0xbe, 0x62, 0x7a, 0xb6, 0x15, 0x7b, 0x76,
-	0xf7, 0xaf, 0xfe, 0x04, 0x3e, 0xaa, 0xa3, 0x7c, 0x41, 0x84, 0x8d, 0x2a, 0xb7, 0x0f, 0x3b, 0x33,
-	0xd9, 0x53, 0x62, 0x27, 0x26, 0xb1, 0x72, 0x4c, 0x2c, 0x0d, 0xb1, 0x25, 0xf7, 0x7b, 0x4e, 0x9f,
-	0xa6, 0x17, 0xe8, 0x77, 0x91, 0xbf, 0x22, 0xf2, 0xfa, 0x3d, 0xa6, 0x3f, 0x01, 0x47, 0x48, 0xc2,
-	0x65, 0x2b, 0xc3, 0xfa, 0x92, 0x7e, 0x53, 0x4d, 0xf8, 0x7f, 0x08, 0x25, 0x0c, 0xfc, 0x74, 0xee,
-	0xb2, 0xce, 0x2d, 0x62, 0xe0, 0x27, 0x32, 0xc7, 0x5d, 0xc4, 0xa0, 0x61, 0xd5, 0x45, 0x0c, 0x8c,
-	0xad, 0xf0, 0x6b, 0xf8, 0xb0, 0x8e, 0xf2, 0x62, 0xf8, 0x8a, 0x33, 0x76, 0xf5, 0xdf, 0x77, 0xda,
-	0x0e, 0xac, 0xcb, 0x61, 0x8b, 0x06, 0x3e, 0x0e, 0xc7, 0x0a, 0xd7, 0xe4, 0xf0, 0x4c, 0x3d, 0xba,
-	0x14, 0xb6, 0x8d, 0x95, 0xa6, 0xba, 0xbe, 0x30, 0x75, 0x3d, 0x8c, 0x75, 0x25, 0x01, 0xb6, 0xa2,
-	0xfe, 0xc8, 0xe9, 0xbd, 0xa6, 0x06, 0xe5, 0x82, 0x74, 0x25, 0x06, 0xea, 0x72, 0xd6, 0x40, 0xcd,
-	0x4f, 0x07, 0xaa, 0xb3, 0x0f, 0x40, 0x45, 0xcb, 0xc7, 0x1e, 0xaa, 0xd3, 0xb6, 0x12, 0x9d, 0x36,
-	0x2a, 0x6a, 0x51, 0x60, 0xbc, 0xb1, 0xd3, 0xd4, 0xac, 0x36, 0x76, 0x1a, 0x62, 0x6b, 0xc5, 0x5f,
-	0x39, 0x3d, 0x2b, 0x7f, 0xa0, 0x42, 0x32, 0x4e, 0x3b, 0xa4, 0xb7, 0xd0, 0xdb, 0x83, 0x73, 0x08,
-	0x6b, 0xb7, 0xc8, 0x05, 0x65, 0x81, 0xb6, 0xa0, 0x70, 0x52, 0x1c, 0x13, 0x7e, 0x13, 0x45, 0xbd,
-	0xc9, 0x6b, 0x45, 0xd3, 0xa7, 0x1c, 0xf5, 0x35, 0x4f, 0xbb, 0xb2, 0xe1, 0xc5, 0x01, 0xf5, 0x09,
-	0x58, 0xd0, 0x1b, 0x8d, 0x6d, 0x13, 0xe5, 0x55, 0x6d, 0x5b, 0x41, 0xc5, 0x22, 0xe3, 0x84, 0xf3,
-	0x18, 0x0a, 0x7d, 0x26, 0x64, 0x8b, 0x63, 0x07, 0x03, 0x59, 0x5e, 0xd3, 0x19, 0xa0, 0x42, 0x9e,
-	0x8e, 0xb8, 0xbf, 0xc2, 0xc7, 0xd9, 0x4a, 0xa7, 0xf6, 0x7e, 0x6d, 0xda, 0xbb, 0x1f, 0xdb, 0x9b,
-	0x81, 0xb3, 0xf5, 0xf8, 0x67, 0x3d, 0xcf, 0x14, 0xcc, 0x43, 0xe2, 0x23, 0x17, 0x8b, 0xbb, 0x9d,
-	0xbd, 0x83, 0x47, 0x19, 0xa5, 0xad, 0xa6, 0xb3, 0x09, 0xba, 0xbb, 0x9a, 0xb7, 0x9c, 0xca, 0xff,
-	0x49, 0x4d, 0xb2, 0xb4, 0xb5, 0x9a, 0x24, 0xc8, 0x56, 0x4d, 0x53, 0xf7, 0xf5, 0x89, 0x17, 0xd5,
-	0xd1, 0x42, 0xee, 0x9f, 0x51, 0x97, 0x36, 0x8a, 0x5a, 0x75, 0x69, 0x03, 0x63, 0xab, 0xe2, 0x8d,
-	0x1e, 0xd1, 0x13, 0x0f, 0x24, 0x06, 0x0b, 0x12, 0x12, 0xd7, 0x1d, 0xb7, 0xa7, 0x05, 0xd5, 0x8d,
-	0xae, 0x63, 0xb3, 0x75, 0xad, 0xae, 0x63, 0xb3, 0x30, 0x5b, 0x9b, 0xe2, 0x65, 0xd3, 0x36, 0x59,
-	0x2f, 0x9b, 0x86, 0xd9, 0x9f, 0x98, 0xb2, 0x1e, 0x54, 0x67, 0x35, 0xd1, 0x0c, 0xdb, 0x7d, 0x55,
-	0x62, 0x51, 0x46, 0xfe, 0x06, 0x07, 0xf3, 0x4a, 0x4f, 0x45, 0x7d, 0x63, 0x8a, 0x7a, 0x9c, 0x9c,
-	0x9e, 0x19, 0x48, 0x5b, 0x5d, 0xdf, 0xe9, 0x29, 0x7a, 0x31, 0x54, 0xfd, 0x95, 0x0e, 0xe4, 0x7b,
-	0x04, 0xdd, 0x87, 0x15, 0x35, 0xfa, 0x27, 0x3a, 0xf2, 0x72, 0x38, 0xbd, 0xc6, 0xa5, 0x4b, 0x58,
-	0x4d, 0xbb, 0x34, 0xc4, 0x96, 0xf1, 0x9f, 0x39, 0xd8, 0xab, 0xa3, 0x7c, 0x39, 0x1d, 0x0a, 0xca,
-	0xc6, 0x73, 0xae, 0xfe, 0x49, 0x8a, 0xd8, 0x7f, 0x0b, 0x79, 0xb5, 0x84, 0x5e, 0xaf, 0x78, 0x72,
-	0x18, 0xaf, 0x37, 0x17, 0x52, 0xb9, 0x18, 0x0d, 0xd0, 0xd3, 0xa8, 0xa4, 0xf6, 0xa5, 0x94, 0xf6,
-	0x22, 0x2c, 0x51, 0x7f, 0xdc, 0xe9, 0x96, 0xa8, 0x6f, 0x3f, 0x16, 0xdd, 0x5d, 0xc8, 0xab, 0x05,
-	0x9c, 0x75, 0xc8, 0x5f, 0x36, 0x4f, 0xbd, 0xd2, 0x07, 0xea, 0xaf, 0xc6, 0x79, 0xed, 0xb4, 0x94,
-	0x73, 0xdf, 0xc2, 0x3d, 0xb5, 0x29, 0x7f, 0x6c, 0x9e, 0x37, 0xfe, 0x6d, 0x0f, 0xde, 0x82, 0x15,
-	0xfd, 0xe3, 0xcb, 0x98, 0x5b, 0xf4, 0x50, 0x7d, 0xf6, 0xcb, 0x49, 0x97, 0xca, 0xeb, 0xb0, 0x5d,
-	0xe9, 0xb0, 0xfe, 0xf1, 0xf5, 0x68, 0x80, 0xbc, 0xa7, 0xaf, 0x8f, 0x47, 0x3d, 0xd2, 0x16, 0xc7,
-	0x8c, 0x53, 0x16, 0x1c, 0x09, 0xe4, 0xb7, 0xc8, 0x8f, 0x07, 0x37, 0xdd, 0x63, 0xcd, 0xbd, 0xbd,
-	0xaa, 0x7f, 0x9c, 0x79, 0xfa, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x38, 0x9c, 0x9a, 0xc8, 0xcf,
-	0x11, 0x00, 0x00,
+	// 2052 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0xdd, 0x72, 0x1b, 0xb7,
+	0x15, 0x2e, 0x25, 0x4a, 0x96, 0x8e, 0x64, 0x89, 0x5e, 0x49, 0xb6, 0x6c, 0xd9, 0xb1, 0xbb, 0x4d,
+	0x53, 0xb9, 0x63, 0x4b, 0x89, 0x9c, 0x36, 0xed, 0x4c, 0x73, 0x11, 0xfd, 0x54, 0x51, 0x23, 0x4b,
+	0xf6, 0x52, 0xb6, 0xdb, 0x4e, 0x66, 0x38, 0x10, 0x17, 0xa4, 0x10, 0x93, 0xc0, 0x1a, 0xc0, 0x3a,
+	0x64, 0x7b, 0xd5, 0xe9, 0xf4, 0x15, 0x3a, 0xd3, 0x97, 0xe8, 0x45, 0x5f, 0xa3, 0x2f, 0x95, 0x01,
+	0xb0, 0xe4, 0xee, 0x82, 0xbb, 0x22, 0x28, 0xcb, 0x77, 0xdc, 0xb3, 0xf8, 0x0e, 0xbe, 0x0f, 0x38,
+	0x07, 0x38, 0xc0, 0x12, 0x16, 0xde, 0xc5, 0x98, 0xf7, 0xb7, 0x22, 0xce, 0x24, 0xf3, 0x66, 0x64,
+	0x3f, 0xc2, 0xe2, 0xde, 0xc6, 0x79, 0x87, 0x35, 0xdf, 0x36, 0x10, 0x0d, 0x1b, 0x92, 0x23, 0x2a,
+	0x50, 0x53, 0x12, 0x46, 0x4d, 0x1b, 0xff, 0x2d, 0xac, 0x1f, 0x62, 0xb9, 0xbf, 0x5b, 0x97, 0x48,
+	0xc6, 0xe2, 0xa5, 0x42, 0x1f, 0xd0, 0xf7, 0xb8, 0xc3, 0x22, 0xec, 0x7d, 0x01, 0x37, 0x22, 0xd4,
+	0xef, 0x30, 0x14, 0xae, 0x57, 0x1e, 0x55, 0x36, 0x17, 0x76, 0xee, 0x6c, 0x69, 0x8f, 0x5b, 0x36,
+	0x22, 0x18, 0xb4, 0xf3, 0xee, 0xc3, 0xbc, 0x20, 0x6d, 0x8a, 0x64, 0xcc, 0xf1, 0xfa, 0xd4, 0xa3,
+	0xca, 0xe6, 0x62, 0x90, 0x1a, 0xfc, 0x7d, 0xa8, 0xd9, 0x50, 0xef, 0x0e, 0xdc, 0x88, 0x05, 0xe6,
+	0x0d, 0x62, 0x3a, 0x99, 0x0f, 0x66, 0xd5, 0xe3, 0x51, 0xa8, 0x5e, 0x84, 0xe7, 0x0d, 0x8a, 0xba,
+	0xc6, 0xd1, 0x7c, 0x30, 0x1b, 0x9e, 0x9f, 0xa0, 0x2e, 0xf6, 0x11, 0xac, 0x68, 0x2f, 0x16, 0xdb,
+	0x27, 0x36, 0x5b, 0x2f, 0xcb, 0x76, 0x32, 0xa2, 0x1d, 0x58, 0xc8, 0xa0, 0xca, 0x39, 0xde, 0x86,
+	0xd9, 0x88, 0xe3, 0x16, 0xe9, 0x0d, 0x28, 0x9a, 0x27, 0x65, 0x67, 0xad, 0x96, 0xc0, 0x72, 0x7d,
+	0xfa, 0x51, 0x65, 0xb3, 0x1a, 0x24, 0x4f, 0xde, 0x2a, 0xcc, 0x74, 0x48, 0x97, 0xc8, 0xf5, 0xaa,
+	0x36, 0x9b, 0x07, 0x9f, 0xc1, 0xbd, 0x43, 0x2c, 0x8f, 0x68, 0x88, 0x7b, 0xaf, 0x04, 0x6a, 0xe3,
+	0xbc, 0xae, 0x67, 0xb6, 0xae, 0xbb, 0xa9, 0x2e, 0x0b, 0xe3, 0x2a, 0xef, 0x04, 0xbc, 0x51, 0x70,
+	0xb9, 0xca, 0x87, 0xb0, 0x10, 0xd3, 0x58, 0xe0, 0xb0, 0xc1, 0x68, 0xa7, 0xaf, 0xdd, 0xcd, 0x05,
+	0x60, 0x4c, 0xa7, 0xb4, 0xd3, 0x4f, 0x04, 0x3c, 0x27, 0x6d, 0x8e, 0x54, 0x68, 0x09, 0x77, 0x01,
+	0x16, 0xc6, 0x55, 0xc0, 0x37, 0xe0, 0x8d, 0x82, 0xcb, 0x05, 0x78, 0x50, 0xcd, 0xc4, 0x91, 0xfe,
+	0xed, 0x37, 0x61, 0x55, 0x4d, 0x31, 0x92, 0x28, 0xcf, 0xf6, 0xa9, 0xcd, 0x76, 0x25, 0x13, 0x46,
+	0x83, 0xd6, 0xae, 0x3c, 0x03, 0x58, 0xcc, 0xc2, 0x26, 0x0f, 0x76, 0xaf, 0x06, 0xd3, 0x6f, 0x71,
+	0x5f, 0x87, 0xd1, 0x7c, 0xa0, 0x7e, 0x0e, 0x32, 0x16, 0x49, 0xf4, 0x1d, 0xee, 0x4f, 0x92, 0xb1,
+	0x59, 0x84, 0xab, 0x80, 0xff, 0x56, 0xa0, 0x66, 0x63, 0xaf, 0xa0, 0x22, 0xcd, 0x93, 0xe9, 0x5c,
+	0x9e, 0x3c, 0x00, 0x68, 0xb2, 0x98, 0x4a, 0x13, 0x58, 0x55, 0x1d, 0x58, 0xf3, 0xda, 0xa2, 0xe2,
+	0xca, 0xdb, 0x80, 0x79, 0x21, 0x11, 0x97, 0x0d, 0x35, 0x04, 0x33, 0x1a, 0x39, 0xa7, 0x0d, 0xdf,
+	0xe1, 0x7e, 0x9a, 0x4b, 0xb3, 0xd9, 0x5c, 0x7a, 0x07, 0x2b, 0xa7, 0x11, 0xa6, 0x8a, 0xf0, 0x5e,
+	0xcc, 0x05, 0xe3, 0xd7, 0x4d, 0xb9, 0x06, 0xd3, 0x52, 0x76, 0x34, 0xd7, 0xf9, 0x40, 0xfd, 0xf4,
+	0xff, 0x06, 0xb7, 0x93, 0x21, 0x32, 0x3d, 0xbe, 0x18, 0x9f, 0x51, 0x1b, 0x30, 0xdf, 0xd4, 0x6d,
+	0xd5, 0x2b, 0xd3, 0xef, 0x9c, 0x31, 0x1c, 0x85, 0x4a, 0x18, 0x6a, 0x49, 0xcc, 0x93, 0x8e, 0xcd,
+	0x43, 0xc9, 0xd2, 0x71, 0x0c, 0xab, 0x7b, 0x1d, 0x26, 0xb0, 0xb3, 0xde, 0xcb, 0x7a, 0xf6, 0xbf,
+	0x87, 0x9a, 0x89, 0x0e, 0x1c, 0x75, 0x50, 0xff, 0x30, 0x46, 0x5c, 0xb3, 0xd1, 0x7b, 0x8a, 0xf6,
+	0xb3, 0x18, 0x98, 0x07, 0x65, 0xa5, 0x8c, 0x36, 0x07, 0x83, 0x66, 0x1e, 0x54, 0x2c, 0x49, 0xd2,
+	0xc5, 0x42, 0xa2, 0x6e, 0xa4, 0xd9, 0x4f, 0x07, 0xa9, 0xc1, 0xff, 0x1e, 0x6e, 0xd5, 0xb1, 0x10,
+	0x84, 0xd1, 0x63, 0xd6, 0x26, 0x74, 0x0c, 0xd1, 0x9c, 0xaf, 0x29, 0xcb, 0xd7, 0x60, 0x16, 0xa6,
+	0xd3, 0x59, 0x30, 0xf9, 0xfc, 0x4a, 0x60, 0xee, 0x9e, 0xcf, 0xc3, 0xd6, 0xae, 0xe9, 0xf0, 0x1c,
+	0x16, 0xb3, 0xb0, 0x72, 0xf6, 0x9f, 0xc2, 0x92, 0x44, 0xbc, 0x8d, 0x65, 0x63, 0xf0, 0xde, 0x0c,
+	0xd4, 0xa2, 0xb1, 0xbe, 0xd2, 0xad, 0x7c, 0x0c, 0x6b, 0x89, 0x3b, 0x2b, 0x8f, 0xb7, 0x6c, 0xd2,
+	0xab, 0x79, 0xd2, 0x93, 0x25, 0x31, 0x85, 0x9b, 0x39, 0xdc, 0xc7, 0xde, 0xcf, 0xda, 0x3a, 0x21,
+	0xf6, 0x18, 0x6d, 0x91, 0x76, 0x5e, 0xd7, 0xb6, 0xad, 0x6b, 0x2d, 0xd5, 0x95, 0x69, 0xef, 0x2a,
+	0xec, 0x31, 0x2c, 0xe5, 0x81, 0xa5, 0xca, 0x92, 0x2d, 0xea, 0x84, 0x85, 0xb8, 0x88, 0xd7, 0x65,
+	0x5b, 0x94, 0x85, 0x71, 0xe5, 0xf6, 0x47, 0xf0, 0x46, 0xc1, 0x97, 0xae, 0x43, 0x94, 0x85, 0x38,
+	0x8d, 0x94, 0x59, 0xf5, 0x78, 0x14, 0xfa, 0x91, 0x22, 0x6e, 0x5c, 0xec, 0xaa, 0x3a, 0x2e, 0x4f,
+	0xfc, 0x4b, 0x9b, 0xf8, 0x3d, 0x7b, 0x40, 0x53, 0x90, 0x2b, 0xf3, 0x97, 0xb0, 0x52, 0x80, 0x2e,
+	0xa7, 0xfe, 0x73, 0x58, 0x34, 0x15, 0x26, 0x8d, 0xbb, 0xe7, 0x98, 0x6b, 0x87, 0xd5, 0x60, 0x41,
+	0xdb, 0x4e, 0xb4, 0xc9, 0x8f, 0xe1, 0x81, 0x72, 0xd9, 0x89, 0x85, 0xc4, 0xbc, 0xa8, 0xd4, 0xfc,
+	0xad, 0xad, 0xe3, 0x7e, 0x46, 0xc7, 0x08, 0xcc, 0x55, 0xc9, 0x9f, 0x61, 0xad, 0x10, 0x5f, 0xae,
+	0xe5, 0x33, 0x58, 0xa2, 0x6c, 0x0f, 0x73, 0x49, 0x5a, 0xa4, 0x89, 0x24, 0x16, 0x49, 0xb5, 0x63,
+	0x59, 0x07, 0x82, 0xf4, 0x18, 0x7d, 0x4b, 0x84, 0x64, 0xbc, 0x3f, 0x81, 0xa0, 0x11, 0x98, 0xab,
+	0xa0, 0xcf, 0x61, 0xad, 0x10, 0x3f, 0x2e, 0xee, 0x0d, 0x62, 0x9f, 0xb4, 0x5a, 0xee, 0x71, 0x6f,
+	0x61, 0x5c, 0x29, 0xfe, 0xa3, 0x02, 0xde, 0x28, 0xba, 0x7c, 0xc4, 0x7f, 0x0d, 0xb7, 0x5a, 0x9c,
+	0x75, 0x1b, 0x05, 0x21, 0xb4, 0xac, 0x5e, 0xec, 0xa6, 0x61, 0xe4, 0x7d, 0x06, 0xcb, 0x92, 0xe5,
+	0x5b, 0x9a, 0xf5, 0xe8, 0xa6, 0x64, 0x99, 0x76, 0xbe, 0x80, 0xfb, 0x67, 0x9c, 0xb4, 0xdb, 0x98,
+	0xd7, 0x29, 0x8a, 0xc4, 0x05, 0x93, 0x79, 0xd9, 0xbf, 0xb1, 0x65, 0x6f, 0x24, 0xb2, 0x8b, 0x50,
+	0xae, 0xc2, 0xb7, 0x61, 0xb5, 0x08, 0x5e, 0x3e, 0x35, 0x7d, 0x78, 0x78, 0xa6, 0xce, 0x63, 0x2d,
+	0xcc, 0x8f, 0x31, 0x0a, 0x31, 0x17, 0x17, 0x24, 0xca, 0x13, 0xfd, 0x9d, 0x4d, 0xf4, 0x93, 0x21,
+	0xd1, 0x42, 0xa0, 0x7b, 0x62, 0xdc, 0x29, 0xf1, 0xe0, 0xb2, 0xa5, 0xe5, 0x17, 0xaa, 0x64, 0x4b,
+	0x3b, 0x31, 0xcb, 0xd5, 0x3f, 0x2b, 0xf0, 0xa9, 0x99, 0x7e, 0x81, 0xa9, 0x88, 0xc5, 0x3e, 0x41,
+	0x6d, 0xca, 0x84, 0x24, 0x4d, 0x2b, 0xe3, 0xbf, 0xb6, 0xa5, 0xfd, 0x22, 0x17, 0x7a, 0xc5, 0x68,
+	0x57, 0x7d, 0x5f, 0xc1, 0xfd, 0xcb, 0xdc, 0x94, 0xcf, 0x89, 0xc9, 0xeb, 0xba, 0x64, 0x1c, 0xb5,
+	0x71, 0x80, 0x23, 0xc6, 0xa5, 0x7b, 0x5e, 0x8f, 0xc2, 0x5c, 0xf9, 0x76, 0x61, 0xad, 0x10, 0x5f,
+	0x3e, 0x1b, 0xaa, 0x00, 0x62, 0xa6, 0x30, 0xba, 0x19, 0xa8, 0x9f, 0xde, 0x63, 0xa8, 0x99, 0xdd,
+	0xba, 0x11, 0x62, 0xbd, 0x0f, 0x0f, 0x2b, 0xc8, 0x65, 0x63, 0xdf, 0x1f, 0x98, 0xfd, 0x2e, 0xdc,
+	0xd5, 0xdd, 0x21, 0x89, 0xbf, 0x45, 0xe2, 0x22, 0xaf, 0x70, 0xc7, 0x56, 0xb8, 0x9e, 0x55, 0x98,
+	0x85, 0xb8, 0xaa, 0x3b, 0x80, 0x5b, 0x23, 0xd8, 0x2b, 0x9c, 0xfb, 0xbb, 0x70, 0x77, 0x8f, 0x75,
+	0x23, 0xd4, 0x34, 0x27, 0x57, 0x47, 0xd6, 0x23, 0x90, 0x09, 0x58, 0x8f, 0x60, 0xaf, 0xc0, 0xfa,
+	0xef, 0xf0, 0xe8, 0x10, 0xcb, 0x97, 0x31, 0xe2, 0x88, 0x4a, 0x42, 0x71, 0x58, 0xb0, 0x8b, 0xff,
+	0xde, 0x26, 0xff, 0x30, 0x1d, 0xf2, 0x42, 0xa4, 0xab, 0x86, 0x67, 0xb0, 0x5e, 0xe6, 0xa2, 0x3c,
+	0x07, 0xde, 0xc1, 0xc6, 0x21, 0x96, 0x01, 0xfe, 0x01, 0x37, 0x25, 0x0e, 0xcf, 0x7a, 0xc2, 0xbd,
+	0xe4, 0xb0, 0x41, 0xae, 0x3c, 0xb7, 0x60, 0xa5, 0x00, 0x3d, 0x8e, 0x62, 0xbd, 0xc3, 0x7e, 0x54,
+	0x0d, 0x09, 0x9e, 0x80, 0xa2, 0x0d, 0x9a, 0x8c, 0xa2, 0x8d, 0x1e, 0xb7, 0x92, 0xbc, 0x21, 0x92,
+	0x62, 0x21, 0x26, 0x2d, 0x79, 0x46, 0x61, 0x93, 0x55, 0x08, 0xa3, 0xf8, 0x71, 0x63, 0x59, 0xba,
+	0x4e, 0x5f, 0x36, 0x96, 0x57, 0x5d, 0x9e, 0x77, 0x61, 0xa5, 0x00, 0x7d, 0xe9, 0xfd, 0x4d, 0x84,
+	0xe4, 0xc5, 0xe0, 0xfe, 0x46, 0xfd, 0xf6, 0x89, 0x3e, 0xd4, 0x5c, 0x4f, 0x7d, 0xaa, 0xe8, 0xa2,
+	0xb8, 0xdd, 0xc5, 0x54, 0xe2, 0x50, 0x2f, 0x9a, 0x73, 0x41, 0x6a, 0x48, 0x8e, 0x69, 0x05, 0x79,
+	0x7b, 0xd9, 0x31, 0x6d, 0xf2, 0x64, 0x7d, 0xa2, 0x97, 0xc9, 0x63, 0x24, 0x5c, 0x54, 0x25, 0x6b,
+	0x78, 0xbe, 0xb5, 0xd3, 0x1a, 0x9e, 0x87, 0xb8, 0x92, 0xfb, 0x97, 0x29, 0xeb, 0x8e, 0x71, 0xd8,
+	0xc6, 0xfc, 0x05, 0x92, 0xe3, 0x56, 0xf1, 0x27, 0xe0, 0x99, 0xab, 0x9b, 0x82, 0xa1, 0xaf, 0xe9,
+	0x37, 0xd9, 0xc2, 0x6e, 0x13, 0x6a, 0x98, 0x86, 0x45, 0x95, 0xdd, 0x12, 0xa6, 0x61, 0xb6, 0xb4,
+	0x33, 0xf5, 0xac, 0x45, 0xc3, 0xa9, 0x9e, 0xb5, 0x30, 0xae, 0xc2, 0x2f, 0x60, 0xf9, 0x10, 0xcb,
+	0xb3, 0xde, 0x0b, 0xce, 0x58, 0xeb, 0xc3, 0x23, 0xed, 0x2e, 0xcc, 0xc9, 0x5e, 0x83, 0xa8, 0x1d,
+	0x25, 0x51, 0x78, 0x43, 0xf6, 0xf4, 0x06, 0xe3, 0x13, 0xb8, 0x63, 0xf5, 0x34, 0xd4, 0xf5, 0xb9,
+	0xad, 0xeb, 0x76, 0xaa, 0x2b, 0x0b, 0x70, 0x15, 0xf5, 0x9f, 0x8a, 0x8e, 0x35, 0x75, 0x6b, 0x74,
+	0x4d, 0xba, 0x32, 0xfb, 0xdf, 0x74, 0xd1, 0x05, 0x66, 0x75, 0x78, 0x81, 0xa9, 0x2e, 0xfd, 0x88,
+	0x50, 0x45, 0x0a, 0x56, 0xd9, 0x36, 0x63, 0xb2, 0x8d, 0x88, 0x7d, 0x63, 0x48, 0x02, 0x3b, 0x4f,
+	0xcd, 0x29, 0xb0, 0xf3, 0x10, 0xd7, 0xa1, 0xf8, 0x21, 0xf9, 0x9a, 0xa0, 0xcb, 0x93, 0x80, 0x31,
+	0xf9, 0xf1, 0xc6, 0x62, 0xb0, 0xd4, 0x5a, 0x7d, 0xb9, 0x2d, 0xb5, 0x16, 0xc8, 0x55, 0xde, 0xbf,
+	0xa7, 0xf4, 0x65, 0x8c, 0x39, 0x2c, 0x92, 0x26, 0xea, 0x5c, 0xeb, 0x65, 0xb4, 0xb7, 0x09, 0x37,
+	0xde, 0x63, 0xae, 0xee, 0xf4, 0xf4, 0x0c, 0x2f, 0xec, 0x2c, 0x25, 0x94, 0x5f, 0x1b, 0x6b, 0x30,
+	0x78, 0xad, 0x68, 0x86, 0x84, 0x63, 0xfd, 0xed, 0x29, 0xb9, 0xcb, 0x4d, 0x0d, 0x6a, 0x54, 0xd5,
+	0x15, 0x70, 0x12, 0x15, 0x42, 0xdf, 0xe9, 0xce, 0x05, 0x0b, 0xca, 0x66, 0xe2, 0x42, 0xa8, 0xaf,
+	0x10, 0x5d, 0x26, 0x64, 0x83, 0xe3, 0x26, 0xa6, 0x72, 0xfd, 0x86, 0x6e, 0x01, 0xca, 0x14, 0x68,
+	0x4b, 0xe6, 0x92, 0x6a, 0xae, 0xf8, 0x92, 0x6a, 0x3e, 0x7b, 0x49, 0xf5, 0x23, 0x7c, 0x52, 0x3c,
+	0x2e, 0xc3, 0xe9, 0xf8, 0xca, 0x9e, 0x8e, 0x07, 0xe9, 0x74, 0x14, 0xe0, 0x5c, 0x67, 0xe4, 0x2f,
+	0x26, 0xe0, 0x90, 0x44, 0x81, 0x39, 0x7a, 0x5d, 0xdf, 0xa7, 0x81, 0x24, 0xbe, 0x2c, 0xd7, 0x6e,
+	0xf1, 0x65, 0x81, 0x26, 0x57, 0xf3, 0x86, 0x13, 0xf9, 0x91, 0xd4, 0x64, 0x5d, 0x3b, 0xab, 0xc9,
+	0x82, 0x5c, 0xd5, 0xd4, 0xc1, 0x4b, 0xd0, 0x6a, 0x2c, 0x76, 0xfb, 0xd7, 0x72, 0xcb, 0x6b, 0xb6,
+	0x2c, 0xcb, 0xa9, 0xd3, 0x96, 0x65, 0x61, 0x5c, 0x55, 0xbc, 0x86, 0xb5, 0x04, 0xac, 0xc6, 0x40,
+	0x62, 0x7a, 0x4d, 0x42, 0x52, 0xbf, 0xc9, 0x5a, 0x7d, 0x4d, 0x7e, 0x4d, 0xa9, 0x3c, 0xea, 0xd7,
+	0xa9, 0x54, 0x1e, 0x85, 0xb9, 0x0e, 0x53, 0xda, 0x6d, 0x7e, 0x98, 0x9c, 0xbb, 0xcd, 0xc3, 0xdc,
+	0x33, 0x66, 0x5d, 0xef, 0xda, 0x47, 0xfb, 0xa2, 0x1e, 0x9f, 0x77, 0x89, 0x4c, 0x99, 0x7f, 0xe8,
+	0x40, 0x9a, 0xb3, 0x66, 0xa1, 0x6b, 0xa7, 0xb3, 0x66, 0x21, 0xd2, 0x55, 0xd7, 0xff, 0x2a, 0x49,
+	0xfd, 0x22, 0x76, 0xfb, 0xdf, 0x50, 0xca, 0xa4, 0xfe, 0x34, 0x3b, 0x46, 0xd7, 0x2f, 0x61, 0x09,
+	0x0d, 0xdb, 0xea, 0xcf, 0x7c, 0x46, 0xd7, 0xcd, 0xd4, 0xaa, 0xbe, 0xf5, 0x3d, 0x86, 0x5a, 0xa6,
+	0xd9, 0x7b, 0xd4, 0x89, 0x07, 0x5b, 0xeb, 0x72, 0x6a, 0x7f, 0xad, 0xcc, 0x99, 0x5d, 0xa0, 0x5a,
+	0xbc, 0x0b, 0xcc, 0x64, 0x77, 0x81, 0x3e, 0x3c, 0x2c, 0xe1, 0x3c, 0xfe, 0x0e, 0xae, 0x04, 0xe8,
+	0xfe, 0x0d, 0xfb, 0x96, 0xf6, 0xa0, 0x76, 0x2f, 0x12, 0x8d, 0x2b, 0x3b, 0x56, 0x60, 0x46, 0xf6,
+	0xd2, 0x79, 0xaf, 0xca, 0xde, 0xf0, 0x0c, 0x90, 0x77, 0xe1, 0x54, 0x2a, 0xe5, 0x21, 0xee, 0x7f,
+	0xdf, 0xf0, 0xb2, 0xd8, 0x71, 0x4b, 0xfd, 0x1a, 0xcc, 0x6a, 0xca, 0xea, 0x0e, 0x7d, 0x5a, 0x7d,
+	0x24, 0x54, 0x9c, 0x45, 0xb2, 0x1c, 0x5a, 0x5e, 0x9c, 0x96, 0x43, 0x0b, 0xe3, 0x4a, 0xfb, 0x30,
+	0x89, 0xcb, 0x00, 0x0b, 0x16, 0xf3, 0x26, 0x76, 0xf9, 0xcb, 0x43, 0xe1, 0x70, 0x0f, 0x82, 0x65,
+	0xd4, 0x91, 0x63, 0xb0, 0x8c, 0x02, 0x5d, 0x35, 0xfc, 0xbf, 0xa2, 0x6f, 0x34, 0x9f, 0x0f, 0xab,
+	0x1d, 0x95, 0xf1, 0xa7, 0x5c, 0x5d, 0xba, 0x1a, 0x25, 0x7f, 0x80, 0xaa, 0xea, 0x48, 0xf7, 0xba,
+	0xb4, 0xb3, 0x99, 0xf9, 0x87, 0x45, 0x19, 0x64, 0xeb, 0xac, 0x1f, 0xe1, 0x40, 0xa3, 0xb2, 0xe3,
+	0x30, 0x95, 0x1b, 0x87, 0x25, 0x98, 0x22, 0x61, 0x92, 0x6a, 0x53, 0x24, 0x74, 0xaf, 0xf7, 0xfc,
+	0x7b, 0x50, 0x55, 0x1d, 0x78, 0x73, 0x50, 0x7d, 0x55, 0x3f, 0x08, 0x6a, 0x3f, 0x53, 0xbf, 0x4e,
+	0x4e, 0xf7, 0x0f, 0x6a, 0x15, 0xff, 0x0d, 0xdc, 0x54, 0xeb, 0xe7, 0x9f, 0xea, 0xa7, 0x27, 0x57,
+	0x2d, 0x17, 0x86, 0x9f, 0xa5, 0x93, 0x8f, 0xe4, 0xfa, 0xc1, 0xef, 0x42, 0xed, 0xa0, 0x17, 0x75,
+	0x10, 0xa1, 0x1f, 0xe2, 0xfb, 0x57, 0xb0, 0x8c, 0x8d, 0x17, 0x1c, 0x36, 0xb2, 0xbd, 0x2c, 0x0d,
+	0xcd, 0xda, 0xb5, 0xff, 0x35, 0x2c, 0x2a, 0x1d, 0xf5, 0x97, 0xc7, 0x63, 0xba, 0x1a, 0xb2, 0x9d,
+	0xca, 0xb2, 0x3d, 0xd0, 0xfb, 0xe9, 0x0b, 0x4c, 0x43, 0x42, 0xdb, 0xca, 0xd1, 0x59, 0xef, 0x2a,
+	0x61, 0xf9, 0x05, 0xdc, 0xb6, 0xdd, 0x8c, 0x49, 0xcd, 0xdd, 0x2f, 0xff, 0xba, 0xd3, 0x26, 0xf2,
+	0x22, 0x3e, 0xdf, 0x6a, 0xb2, 0xee, 0xf6, 0x45, 0x3f, 0xc2, 0xbc, 0xa3, 0x8f, 0xc7, 0x4f, 0x3b,
+	0xe8, 0x5c, 0x6c, 0x33, 0x4e, 0x18, 0x7d, 0x2a, 0x30, 0x7f, 0x8f, 0xf9, 0x76, 0xf4, 0xb6, 0xbd,
+	0xad, 0xe7, 0xf8, 0x7c, 0x56, 0xff, 0x65, 0xec, 0xd9, 0x4f, 0x03, 0x00, 0x47, 0xf9, 0xa1, 0xb2,
+	0x65, 0x26, 0x00, 0x00,
 }
@@ -20,6 +20,85 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type RejectedTx_Category int32
+
+const (
+	// the transaction could not be decoded, or misses mandatory fields
+	RejectedTx_MALFORMED RejectedTx_Category = 0
+	// the transaction is not signed, or a signature cannot be verified
+	RejectedTx_UNAUTHENTICATED RejectedTx_Category = 1
+	// the transaction is rejected by the pre-order checks, e.g., its size or TxId
+	RejectedTx_INVALID RejectedTx_Category = 2
+	// the TxId is already in use
+	RejectedTx_DUPLICATE_TX_ID RejectedTx_Category = 3
+	// the transaction queue is full
+	RejectedTx_QUEUE_FULL RejectedTx_Category = 4
+	// the node does not accept transactions, e.g., because a block is quarantined
+	RejectedTx_UNAVAILABLE RejectedTx_Category = 5
+	// the transaction is larger than the maximal transaction size allowed by the maximal block size, so it never
+	// fits in a block
+	RejectedTx_TOO_LARGE RejectedTx_Category = 6
+)
+
+var RejectedTx_Category_name = map[int32]string{
+	0: "MALFORMED",
+	1: "UNAUTHENTICATED",
+	2: "INVALID",
+	3: "DUPLICATE_TX_ID",
+	4: "QUEUE_FULL",
+	5: "UNAVAILABLE",
+	6: "TOO_LARGE",
+}
+
+var RejectedTx_Category_value = map[string]int32{
+	"MALFORMED":       0,
+	"UNAUTHENTICATED": 1,
+	"INVALID":         2,
+	"DUPLICATE_TX_ID": 3,
+	"QUEUE_FULL":      4,
+	"UNAVAILABLE":     5,
+	"TOO_LARGE":       6,
+}
+
+func (x RejectedTx_Category) String() string {
+	return proto.EnumName(RejectedTx_Category_name, int32(x))
+}
+
+func (RejectedTx_Category) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{59, 0}
+}
+
+type IndexScan_Kind int32
+
+const (
+	// the entries of a single value are scanned, for an $eq condition
+	IndexScan_POINT IndexScan_Kind = 0
+	// the entries of a range of values are scanned, for $gt, $gte, $lt and $lte conditions
+	IndexScan_RANGE IndexScan_Kind = 1
+	// all the entries of the attribute are scanned, for $neq conditions alone
+	IndexScan_FULL IndexScan_Kind = 2
+)
+
+var IndexScan_Kind_name = map[int32]string{
+	0: "POINT",
+	1: "RANGE",
+	2: "FULL",
+}
+
+var IndexScan_Kind_value = map[string]int32{
+	"POINT": 0,
+	"RANGE": 1,
+	"FULL":  2,
+}
+
+func (x IndexScan_Kind) String() string {
+	return proto.EnumName(IndexScan_Kind_name, int32(x))
+}
+
+func (IndexScan_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{69, 0}
+}
+
 type ResponseHeader struct {
 	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -108,8 +187,10 @@ func (m *GetDBStatusResponseEnvelope) GetSignature() []byte {
 }
 
 type GetDBStatusResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Exist                bool            `protobuf:"varint,2,opt,name=exist,proto3" json:"exist,omitempty"`
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Exist  bool            `protobuf:"varint,2,opt,name=exist,proto3" json:"exist,omitempty"`
+	// the freeze of the database, if it is frozen
+	Freeze               *DatabaseFreeze `protobuf:"bytes,3,opt,name=freeze,proto3" json:"freeze,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -154,1710 +235,6438 @@ func (m *GetDBStatusResponse) GetExist() bool {
 	return false
 }
 
-// GetData
-type GetDataResponseEnvelope struct {
-	Response             *GetDataResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+func (m *GetDBStatusResponse) GetFreeze() *DatabaseFreeze {
+	if m != nil {
+		return m.Freeze
+	}
+	return nil
 }
 
-func (m *GetDataResponseEnvelope) Reset()         { *m = GetDataResponseEnvelope{} }
-func (m *GetDataResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetDataResponseEnvelope) ProtoMessage()    {}
-func (*GetDataResponseEnvelope) Descriptor() ([]byte, []int) {
+type GetDBsResponseEnvelope struct {
+	Response             *GetDBsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte          `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetDBsResponseEnvelope) Reset()         { *m = GetDBsResponseEnvelope{} }
+func (m *GetDBsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDBsResponseEnvelope) ProtoMessage()    {}
+func (*GetDBsResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{3}
 }
 
-func (m *GetDataResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataResponseEnvelope.Unmarshal(m, b)
+func (m *GetDBsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBsResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetDataResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetDBsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBsResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetDataResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataResponseEnvelope.Merge(m, src)
+func (m *GetDBsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBsResponseEnvelope.Merge(m, src)
 }
-func (m *GetDataResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetDataResponseEnvelope.Size(m)
+func (m *GetDBsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDBsResponseEnvelope.Size(m)
 }
-func (m *GetDataResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataResponseEnvelope.DiscardUnknown(m)
+func (m *GetDBsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBsResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetDBsResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetDataResponseEnvelope) GetResponse() *GetDataResponse {
+func (m *GetDBsResponseEnvelope) GetResponse() *GetDBsResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetDataResponseEnvelope) GetSignature() []byte {
+func (m *GetDBsResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetDataResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Value                []byte          `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	Metadata             *Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type GetDBsResponse struct {
+	Header  *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	DbNames []string        `protobuf:"bytes,2,rep,name=db_names,json=dbNames,proto3" json:"db_names,omitempty"`
+	// has_more is set when the databases were limited, and more databases are available
+	HasMore bool `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	// dbs holds the details of the databases listed in db_names, in the same order
+	Dbs                  []*DBInfo `protobuf:"bytes,4,rep,name=dbs,proto3" json:"dbs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *GetDataResponse) Reset()         { *m = GetDataResponse{} }
-func (m *GetDataResponse) String() string { return proto.CompactTextString(m) }
-func (*GetDataResponse) ProtoMessage()    {}
-func (*GetDataResponse) Descriptor() ([]byte, []int) {
+func (m *GetDBsResponse) Reset()         { *m = GetDBsResponse{} }
+func (m *GetDBsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDBsResponse) ProtoMessage()    {}
+func (*GetDBsResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{4}
 }
 
-func (m *GetDataResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataResponse.Unmarshal(m, b)
+func (m *GetDBsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBsResponse.Unmarshal(m, b)
 }
-func (m *GetDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataResponse.Marshal(b, m, deterministic)
+func (m *GetDBsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBsResponse.Marshal(b, m, deterministic)
 }
-func (m *GetDataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataResponse.Merge(m, src)
+func (m *GetDBsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBsResponse.Merge(m, src)
 }
-func (m *GetDataResponse) XXX_Size() int {
-	return xxx_messageInfo_GetDataResponse.Size(m)
+func (m *GetDBsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDBsResponse.Size(m)
 }
-func (m *GetDataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataResponse.DiscardUnknown(m)
+func (m *GetDBsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetDBsResponse proto.InternalMessageInfo
 
-func (m *GetDataResponse) GetHeader() *ResponseHeader {
+func (m *GetDBsResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetDataResponse) GetValue() []byte {
+func (m *GetDBsResponse) GetDbNames() []string {
 	if m != nil {
-		return m.Value
+		return m.DbNames
 	}
 	return nil
 }
 
-func (m *GetDataResponse) GetMetadata() *Metadata {
+func (m *GetDBsResponse) GetHasMore() bool {
 	if m != nil {
-		return m.Metadata
+		return m.HasMore
+	}
+	return false
+}
+
+func (m *GetDBsResponse) GetDbs() []*DBInfo {
+	if m != nil {
+		return m.Dbs
 	}
 	return nil
 }
 
-// GetUser
-type GetUserResponseEnvelope struct {
-	Response             *GetUserResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+type GetIndexUsageResponseEnvelope struct {
+	Response             *GetIndexUsageResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *GetUserResponseEnvelope) Reset()         { *m = GetUserResponseEnvelope{} }
-func (m *GetUserResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetUserResponseEnvelope) ProtoMessage()    {}
-func (*GetUserResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetIndexUsageResponseEnvelope) Reset()         { *m = GetIndexUsageResponseEnvelope{} }
+func (m *GetIndexUsageResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetIndexUsageResponseEnvelope) ProtoMessage()    {}
+func (*GetIndexUsageResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{5}
 }
 
-func (m *GetUserResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetUserResponseEnvelope.Unmarshal(m, b)
+func (m *GetIndexUsageResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexUsageResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetUserResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetUserResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetIndexUsageResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexUsageResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetUserResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetUserResponseEnvelope.Merge(m, src)
+func (m *GetIndexUsageResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexUsageResponseEnvelope.Merge(m, src)
 }
-func (m *GetUserResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetUserResponseEnvelope.Size(m)
+func (m *GetIndexUsageResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetIndexUsageResponseEnvelope.Size(m)
 }
-func (m *GetUserResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetUserResponseEnvelope.DiscardUnknown(m)
+func (m *GetIndexUsageResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexUsageResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetUserResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetIndexUsageResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetUserResponseEnvelope) GetResponse() *GetUserResponse {
+func (m *GetIndexUsageResponseEnvelope) GetResponse() *GetIndexUsageResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetUserResponseEnvelope) GetSignature() []byte {
+func (m *GetIndexUsageResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetUserResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	User                 *User           `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	Metadata             *Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+// GetIndexUsageResponse holds the usage of the index of every indexed attribute of the data databases, sorted by
+// database and attribute. The usage is held in memory, and is tracked since the node started.
+type GetIndexUsageResponse struct {
+	Header  *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Indexes []*IndexAttributeUsage `protobuf:"bytes,2,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	// The time from which the usage is tracked, in nanoseconds since the Unix epoch.
+	TrackedSince         int64    `protobuf:"varint,3,opt,name=tracked_since,json=trackedSince,proto3" json:"tracked_since,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetUserResponse) Reset()         { *m = GetUserResponse{} }
-func (m *GetUserResponse) String() string { return proto.CompactTextString(m) }
-func (*GetUserResponse) ProtoMessage()    {}
-func (*GetUserResponse) Descriptor() ([]byte, []int) {
+func (m *GetIndexUsageResponse) Reset()         { *m = GetIndexUsageResponse{} }
+func (m *GetIndexUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetIndexUsageResponse) ProtoMessage()    {}
+func (*GetIndexUsageResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{6}
 }
 
-func (m *GetUserResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetUserResponse.Unmarshal(m, b)
+func (m *GetIndexUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetIndexUsageResponse.Unmarshal(m, b)
 }
-func (m *GetUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetUserResponse.Marshal(b, m, deterministic)
+func (m *GetIndexUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetIndexUsageResponse.Marshal(b, m, deterministic)
 }
-func (m *GetUserResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetUserResponse.Merge(m, src)
+func (m *GetIndexUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetIndexUsageResponse.Merge(m, src)
 }
-func (m *GetUserResponse) XXX_Size() int {
-	return xxx_messageInfo_GetUserResponse.Size(m)
+func (m *GetIndexUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetIndexUsageResponse.Size(m)
 }
-func (m *GetUserResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetUserResponse.DiscardUnknown(m)
+func (m *GetIndexUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetIndexUsageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetUserResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetIndexUsageResponse proto.InternalMessageInfo
 
-func (m *GetUserResponse) GetHeader() *ResponseHeader {
+func (m *GetIndexUsageResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetUserResponse) GetUser() *User {
+func (m *GetIndexUsageResponse) GetIndexes() []*IndexAttributeUsage {
 	if m != nil {
-		return m.User
+		return m.Indexes
 	}
 	return nil
 }
 
-func (m *GetUserResponse) GetMetadata() *Metadata {
+func (m *GetIndexUsageResponse) GetTrackedSince() int64 {
 	if m != nil {
-		return m.Metadata
+		return m.TrackedSince
 	}
-	return nil
+	return 0
 }
 
-// GetConfig
-type GetConfigResponseEnvelope struct {
-	Response             *GetConfigResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+type GetMigrationsResponseEnvelope struct {
+	Response             *GetMigrationsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *GetConfigResponseEnvelope) Reset()         { *m = GetConfigResponseEnvelope{} }
-func (m *GetConfigResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetConfigResponseEnvelope) ProtoMessage()    {}
-func (*GetConfigResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetMigrationsResponseEnvelope) Reset()         { *m = GetMigrationsResponseEnvelope{} }
+func (m *GetMigrationsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetMigrationsResponseEnvelope) ProtoMessage()    {}
+func (*GetMigrationsResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{7}
 }
 
-func (m *GetConfigResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetConfigResponseEnvelope.Unmarshal(m, b)
+func (m *GetMigrationsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMigrationsResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetConfigResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetConfigResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetMigrationsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMigrationsResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetConfigResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigResponseEnvelope.Merge(m, src)
+func (m *GetMigrationsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMigrationsResponseEnvelope.Merge(m, src)
 }
-func (m *GetConfigResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetConfigResponseEnvelope.Size(m)
+func (m *GetMigrationsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetMigrationsResponseEnvelope.Size(m)
 }
-func (m *GetConfigResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigResponseEnvelope.DiscardUnknown(m)
+func (m *GetMigrationsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMigrationsResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetMigrationsResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetConfigResponseEnvelope) GetResponse() *GetConfigResponse {
+func (m *GetMigrationsResponseEnvelope) GetResponse() *GetMigrationsResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetConfigResponseEnvelope) GetSignature() []byte {
+func (m *GetMigrationsResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetConfigResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Config               *ClusterConfig  `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
-	Metadata             *Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+// GetMigrationsResponse holds the states of the migrations, sorted by name
+type GetMigrationsResponse struct {
+	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Migrations           []*MigrationState `protobuf:"bytes,2,rep,name=migrations,proto3" json:"migrations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *GetConfigResponse) Reset()         { *m = GetConfigResponse{} }
-func (m *GetConfigResponse) String() string { return proto.CompactTextString(m) }
-func (*GetConfigResponse) ProtoMessage()    {}
-func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+func (m *GetMigrationsResponse) Reset()         { *m = GetMigrationsResponse{} }
+func (m *GetMigrationsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMigrationsResponse) ProtoMessage()    {}
+func (*GetMigrationsResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{8}
 }
 
-func (m *GetConfigResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetConfigResponse.Unmarshal(m, b)
+func (m *GetMigrationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetMigrationsResponse.Unmarshal(m, b)
 }
-func (m *GetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetConfigResponse.Marshal(b, m, deterministic)
+func (m *GetMigrationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetMigrationsResponse.Marshal(b, m, deterministic)
 }
-func (m *GetConfigResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigResponse.Merge(m, src)
+func (m *GetMigrationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetMigrationsResponse.Merge(m, src)
 }
-func (m *GetConfigResponse) XXX_Size() int {
-	return xxx_messageInfo_GetConfigResponse.Size(m)
+func (m *GetMigrationsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetMigrationsResponse.Size(m)
 }
-func (m *GetConfigResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigResponse.DiscardUnknown(m)
+func (m *GetMigrationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetMigrationsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetMigrationsResponse proto.InternalMessageInfo
 
-func (m *GetConfigResponse) GetHeader() *ResponseHeader {
+func (m *GetMigrationsResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetConfigResponse) GetConfig() *ClusterConfig {
-	if m != nil {
-		return m.Config
-	}
-	return nil
-}
-
-func (m *GetConfigResponse) GetMetadata() *Metadata {
+func (m *GetMigrationsResponse) GetMigrations() []*MigrationState {
 	if m != nil {
-		return m.Metadata
+		return m.Migrations
 	}
 	return nil
 }
 
-// GetNodeConfig
-type GetNodeConfigResponseEnvelope struct {
-	Response             *GetNodeConfigResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+// IndexAttributeUsage is the usage of the index of an attribute of a database by the JSON and SQL queries.
+type IndexAttributeUsage struct {
+	DbName    string             `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Attribute string             `protobuf:"bytes,2,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Type      IndexAttributeType `protobuf:"varint,3,opt,name=type,proto3,enum=types.IndexAttributeType" json:"type,omitempty"`
+	// The number of queries that scanned the index.
+	Hits uint64 `protobuf:"varint,4,opt,name=hits,proto3" json:"hits,omitempty"`
+	// The time the index was last scanned, in nanoseconds since the Unix epoch; zero if it was not scanned.
+	LastUsed             int64    `protobuf:"varint,5,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetNodeConfigResponseEnvelope) Reset()         { *m = GetNodeConfigResponseEnvelope{} }
-func (m *GetNodeConfigResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetNodeConfigResponseEnvelope) ProtoMessage()    {}
-func (*GetNodeConfigResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *IndexAttributeUsage) Reset()         { *m = IndexAttributeUsage{} }
+func (m *IndexAttributeUsage) String() string { return proto.CompactTextString(m) }
+func (*IndexAttributeUsage) ProtoMessage()    {}
+func (*IndexAttributeUsage) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{9}
 }
 
-func (m *GetNodeConfigResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeConfigResponseEnvelope.Unmarshal(m, b)
+func (m *IndexAttributeUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IndexAttributeUsage.Unmarshal(m, b)
 }
-func (m *GetNodeConfigResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeConfigResponseEnvelope.Marshal(b, m, deterministic)
+func (m *IndexAttributeUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IndexAttributeUsage.Marshal(b, m, deterministic)
 }
-func (m *GetNodeConfigResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeConfigResponseEnvelope.Merge(m, src)
+func (m *IndexAttributeUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexAttributeUsage.Merge(m, src)
 }
-func (m *GetNodeConfigResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetNodeConfigResponseEnvelope.Size(m)
+func (m *IndexAttributeUsage) XXX_Size() int {
+	return xxx_messageInfo_IndexAttributeUsage.Size(m)
 }
-func (m *GetNodeConfigResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeConfigResponseEnvelope.DiscardUnknown(m)
+func (m *IndexAttributeUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexAttributeUsage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeConfigResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_IndexAttributeUsage proto.InternalMessageInfo
 
-func (m *GetNodeConfigResponseEnvelope) GetResponse() *GetNodeConfigResponse {
+func (m *IndexAttributeUsage) GetDbName() string {
 	if m != nil {
-		return m.Response
+		return m.DbName
 	}
-	return nil
+	return ""
 }
 
-func (m *GetNodeConfigResponseEnvelope) GetSignature() []byte {
+func (m *IndexAttributeUsage) GetAttribute() string {
 	if m != nil {
-		return m.Signature
+		return m.Attribute
 	}
-	return nil
+	return ""
 }
 
-type GetNodeConfigResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	NodeConfig           *NodeConfig     `protobuf:"bytes,2,opt,name=node_config,json=nodeConfig,proto3" json:"node_config,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *IndexAttributeUsage) GetType() IndexAttributeType {
+	if m != nil {
+		return m.Type
+	}
+	return IndexAttributeType_NUMBER
 }
 
-func (m *GetNodeConfigResponse) Reset()         { *m = GetNodeConfigResponse{} }
-func (m *GetNodeConfigResponse) String() string { return proto.CompactTextString(m) }
-func (*GetNodeConfigResponse) ProtoMessage()    {}
-func (*GetNodeConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{10}
+func (m *IndexAttributeUsage) GetHits() uint64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
 }
 
-func (m *GetNodeConfigResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetNodeConfigResponse.Unmarshal(m, b)
+func (m *IndexAttributeUsage) GetLastUsed() int64 {
+	if m != nil {
+		return m.LastUsed
+	}
+	return 0
 }
-func (m *GetNodeConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetNodeConfigResponse.Marshal(b, m, deterministic)
+
+// DBInfo summarizes a database
+type DBInfo struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// version is the version of the transaction that created the database. It is not set
+	// for the default database
+	Version *Version `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// indexed_attributes holds the sorted names of the attributes indexed in the database
+	IndexedAttributes    []string `protobuf:"bytes,3,rep,name=indexed_attributes,json=indexedAttributes,proto3" json:"indexed_attributes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *GetNodeConfigResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetNodeConfigResponse.Merge(m, src)
+
+func (m *DBInfo) Reset()         { *m = DBInfo{} }
+func (m *DBInfo) String() string { return proto.CompactTextString(m) }
+func (*DBInfo) ProtoMessage()    {}
+func (*DBInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{10}
 }
-func (m *GetNodeConfigResponse) XXX_Size() int {
-	return xxx_messageInfo_GetNodeConfigResponse.Size(m)
+
+func (m *DBInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBInfo.Unmarshal(m, b)
 }
-func (m *GetNodeConfigResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetNodeConfigResponse.DiscardUnknown(m)
+func (m *DBInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBInfo.Marshal(b, m, deterministic)
+}
+func (m *DBInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBInfo.Merge(m, src)
+}
+func (m *DBInfo) XXX_Size() int {
+	return xxx_messageInfo_DBInfo.Size(m)
+}
+func (m *DBInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetNodeConfigResponse proto.InternalMessageInfo
+var xxx_messageInfo_DBInfo proto.InternalMessageInfo
 
-func (m *GetNodeConfigResponse) GetHeader() *ResponseHeader {
+func (m *DBInfo) GetName() string {
 	if m != nil {
-		return m.Header
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DBInfo) GetVersion() *Version {
+	if m != nil {
+		return m.Version
 	}
 	return nil
 }
 
-func (m *GetNodeConfigResponse) GetNodeConfig() *NodeConfig {
+func (m *DBInfo) GetIndexedAttributes() []string {
 	if m != nil {
-		return m.NodeConfig
+		return m.IndexedAttributes
 	}
 	return nil
 }
 
-// GetConfigBlock
-type GetConfigBlockResponseEnvelope struct {
-	Response             *GetConfigBlockResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+// GetData
+type GetDataResponseEnvelope struct {
+	Response             *GetDataResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *GetConfigBlockResponseEnvelope) Reset()         { *m = GetConfigBlockResponseEnvelope{} }
-func (m *GetConfigBlockResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetConfigBlockResponseEnvelope) ProtoMessage()    {}
-func (*GetConfigBlockResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetDataResponseEnvelope) Reset()         { *m = GetDataResponseEnvelope{} }
+func (m *GetDataResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataResponseEnvelope) ProtoMessage()    {}
+func (*GetDataResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{11}
 }
 
-func (m *GetConfigBlockResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetConfigBlockResponseEnvelope.Unmarshal(m, b)
+func (m *GetDataResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetConfigBlockResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetConfigBlockResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetDataResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetConfigBlockResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigBlockResponseEnvelope.Merge(m, src)
+func (m *GetDataResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataResponseEnvelope.Merge(m, src)
 }
-func (m *GetConfigBlockResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetConfigBlockResponseEnvelope.Size(m)
+func (m *GetDataResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataResponseEnvelope.Size(m)
 }
-func (m *GetConfigBlockResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigBlockResponseEnvelope.DiscardUnknown(m)
+func (m *GetDataResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigBlockResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetDataResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetConfigBlockResponseEnvelope) GetResponse() *GetConfigBlockResponse {
+func (m *GetDataResponseEnvelope) GetResponse() *GetDataResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetConfigBlockResponseEnvelope) GetSignature() []byte {
+func (m *GetDataResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetConfigBlockResponse struct {
-	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	// block bytes, marshaled with proto.Marshal
-	Block                []byte   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type GetDataResponse struct {
+	Header   *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Value    []byte          `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Metadata *Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// set when the value is stored in the blob store, as the state trie, and hence a proof of the value, covers the
+	// manifest rather than the value
+	BlobManifest         *BlobManifest `protobuf:"bytes,4,opt,name=blob_manifest,json=blobManifest,proto3" json:"blob_manifest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *GetConfigBlockResponse) Reset()         { *m = GetConfigBlockResponse{} }
-func (m *GetConfigBlockResponse) String() string { return proto.CompactTextString(m) }
-func (*GetConfigBlockResponse) ProtoMessage()    {}
-func (*GetConfigBlockResponse) Descriptor() ([]byte, []int) {
+func (m *GetDataResponse) Reset()         { *m = GetDataResponse{} }
+func (m *GetDataResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataResponse) ProtoMessage()    {}
+func (*GetDataResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{12}
 }
 
-func (m *GetConfigBlockResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetConfigBlockResponse.Unmarshal(m, b)
+func (m *GetDataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataResponse.Unmarshal(m, b)
 }
-func (m *GetConfigBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetConfigBlockResponse.Marshal(b, m, deterministic)
+func (m *GetDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataResponse.Marshal(b, m, deterministic)
 }
-func (m *GetConfigBlockResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetConfigBlockResponse.Merge(m, src)
+func (m *GetDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataResponse.Merge(m, src)
 }
-func (m *GetConfigBlockResponse) XXX_Size() int {
-	return xxx_messageInfo_GetConfigBlockResponse.Size(m)
+func (m *GetDataResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataResponse.Size(m)
 }
-func (m *GetConfigBlockResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetConfigBlockResponse.DiscardUnknown(m)
+func (m *GetDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetConfigBlockResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetDataResponse proto.InternalMessageInfo
 
-func (m *GetConfigBlockResponse) GetHeader() *ResponseHeader {
+func (m *GetDataResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetConfigBlockResponse) GetBlock() []byte {
+func (m *GetDataResponse) GetValue() []byte {
 	if m != nil {
-		return m.Block
+		return m.Value
 	}
 	return nil
 }
 
-// GetClusterStatus
-type GetClusterStatusResponseEnvelope struct {
-	Response             *GetClusterStatusResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+func (m *GetDataResponse) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
 }
 
-func (m *GetClusterStatusResponseEnvelope) Reset()         { *m = GetClusterStatusResponseEnvelope{} }
-func (m *GetClusterStatusResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetClusterStatusResponseEnvelope) ProtoMessage()    {}
-func (*GetClusterStatusResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetDataResponse) GetBlobManifest() *BlobManifest {
+	if m != nil {
+		return m.BlobManifest
+	}
+	return nil
+}
+
+type PutBlobResponseEnvelope struct {
+	Response             *PutBlobResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PutBlobResponseEnvelope) Reset()         { *m = PutBlobResponseEnvelope{} }
+func (m *PutBlobResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*PutBlobResponseEnvelope) ProtoMessage()    {}
+func (*PutBlobResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{13}
 }
 
-func (m *GetClusterStatusResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetClusterStatusResponseEnvelope.Unmarshal(m, b)
+func (m *PutBlobResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutBlobResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetClusterStatusResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetClusterStatusResponseEnvelope.Marshal(b, m, deterministic)
+func (m *PutBlobResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutBlobResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetClusterStatusResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetClusterStatusResponseEnvelope.Merge(m, src)
+func (m *PutBlobResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutBlobResponseEnvelope.Merge(m, src)
 }
-func (m *GetClusterStatusResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetClusterStatusResponseEnvelope.Size(m)
+func (m *PutBlobResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_PutBlobResponseEnvelope.Size(m)
 }
-func (m *GetClusterStatusResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetClusterStatusResponseEnvelope.DiscardUnknown(m)
+func (m *PutBlobResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutBlobResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetClusterStatusResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_PutBlobResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetClusterStatusResponseEnvelope) GetResponse() *GetClusterStatusResponse {
+func (m *PutBlobResponseEnvelope) GetResponse() *PutBlobResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetClusterStatusResponseEnvelope) GetSignature() []byte {
+func (m *PutBlobResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetClusterStatusResponse struct {
-	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	// The configuration of the nodes, the part from ClusterConfig accessible to every client.
-	Nodes []*NodeConfig `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	// The version of the ClusterConfig from which the nodes are taken of.
-	Version *Version `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
-	// The leader ID, if it exists.
-	Leader string `protobuf:"bytes,4,opt,name=Leader,proto3" json:"Leader,omitempty"`
-	// The IDs of active nodes, including the leader.
-	Active               []string `protobuf:"bytes,5,rep,name=Active,proto3" json:"Active,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// PutBlobResponse holds the manifest of an uploaded blob, which a data write carries in place of the value.
+type PutBlobResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Manifest             *BlobManifest   `protobuf:"bytes,2,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetClusterStatusResponse) Reset()         { *m = GetClusterStatusResponse{} }
-func (m *GetClusterStatusResponse) String() string { return proto.CompactTextString(m) }
-func (*GetClusterStatusResponse) ProtoMessage()    {}
-func (*GetClusterStatusResponse) Descriptor() ([]byte, []int) {
+func (m *PutBlobResponse) Reset()         { *m = PutBlobResponse{} }
+func (m *PutBlobResponse) String() string { return proto.CompactTextString(m) }
+func (*PutBlobResponse) ProtoMessage()    {}
+func (*PutBlobResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{14}
 }
 
-func (m *GetClusterStatusResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetClusterStatusResponse.Unmarshal(m, b)
+func (m *PutBlobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutBlobResponse.Unmarshal(m, b)
 }
-func (m *GetClusterStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetClusterStatusResponse.Marshal(b, m, deterministic)
+func (m *PutBlobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutBlobResponse.Marshal(b, m, deterministic)
 }
-func (m *GetClusterStatusResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetClusterStatusResponse.Merge(m, src)
+func (m *PutBlobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutBlobResponse.Merge(m, src)
 }
-func (m *GetClusterStatusResponse) XXX_Size() int {
-	return xxx_messageInfo_GetClusterStatusResponse.Size(m)
+func (m *PutBlobResponse) XXX_Size() int {
+	return xxx_messageInfo_PutBlobResponse.Size(m)
 }
-func (m *GetClusterStatusResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetClusterStatusResponse.DiscardUnknown(m)
+func (m *PutBlobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutBlobResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetClusterStatusResponse proto.InternalMessageInfo
+var xxx_messageInfo_PutBlobResponse proto.InternalMessageInfo
 
-func (m *GetClusterStatusResponse) GetHeader() *ResponseHeader {
+func (m *PutBlobResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetClusterStatusResponse) GetNodes() []*NodeConfig {
-	if m != nil {
-		return m.Nodes
-	}
-	return nil
-}
-
-func (m *GetClusterStatusResponse) GetVersion() *Version {
-	if m != nil {
-		return m.Version
-	}
-	return nil
-}
-
-func (m *GetClusterStatusResponse) GetLeader() string {
-	if m != nil {
-		return m.Leader
-	}
-	return ""
-}
-
-func (m *GetClusterStatusResponse) GetActive() []string {
+func (m *PutBlobResponse) GetManifest() *BlobManifest {
 	if m != nil {
-		return m.Active
+		return m.Manifest
 	}
 	return nil
 }
 
-// GetBlock
-type GetBlockResponseEnvelope struct {
-	Response             *GetBlockResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte            `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type GetDataKeysResponseEnvelope struct {
+	Response             *GetDataKeysResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte               `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *GetBlockResponseEnvelope) Reset()         { *m = GetBlockResponseEnvelope{} }
-func (m *GetBlockResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetBlockResponseEnvelope) ProtoMessage()    {}
-func (*GetBlockResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetDataKeysResponseEnvelope) Reset()         { *m = GetDataKeysResponseEnvelope{} }
+func (m *GetDataKeysResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataKeysResponseEnvelope) ProtoMessage()    {}
+func (*GetDataKeysResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{15}
 }
 
-func (m *GetBlockResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBlockResponseEnvelope.Unmarshal(m, b)
+func (m *GetDataKeysResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataKeysResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetBlockResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBlockResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetDataKeysResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataKeysResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetBlockResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBlockResponseEnvelope.Merge(m, src)
+func (m *GetDataKeysResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataKeysResponseEnvelope.Merge(m, src)
 }
-func (m *GetBlockResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetBlockResponseEnvelope.Size(m)
+func (m *GetDataKeysResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataKeysResponseEnvelope.Size(m)
 }
-func (m *GetBlockResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBlockResponseEnvelope.DiscardUnknown(m)
+func (m *GetDataKeysResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataKeysResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBlockResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetDataKeysResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetBlockResponseEnvelope) GetResponse() *GetBlockResponse {
+func (m *GetDataKeysResponseEnvelope) GetResponse() *GetDataKeysResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetBlockResponseEnvelope) GetSignature() []byte {
+func (m *GetDataKeysResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetBlockResponse struct {
+// GetDataKeysResponse holds the keys fetched, or only their count. has_more is set when the keys were limited, and
+// more keys are available from next_start_key.
+type GetDataKeysResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	BlockHeader          *BlockHeader    `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	Keys                 []string        `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	Count                uint64          `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	HasMore              bool            `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextStartKey         string          `protobuf:"bytes,5,opt,name=next_start_key,json=nextStartKey,proto3" json:"next_start_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetBlockResponse) Reset()         { *m = GetBlockResponse{} }
-func (m *GetBlockResponse) String() string { return proto.CompactTextString(m) }
-func (*GetBlockResponse) ProtoMessage()    {}
-func (*GetBlockResponse) Descriptor() ([]byte, []int) {
+func (m *GetDataKeysResponse) Reset()         { *m = GetDataKeysResponse{} }
+func (m *GetDataKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataKeysResponse) ProtoMessage()    {}
+func (*GetDataKeysResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{16}
 }
 
-func (m *GetBlockResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetBlockResponse.Unmarshal(m, b)
+func (m *GetDataKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataKeysResponse.Unmarshal(m, b)
 }
-func (m *GetBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetBlockResponse.Marshal(b, m, deterministic)
+func (m *GetDataKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataKeysResponse.Marshal(b, m, deterministic)
 }
-func (m *GetBlockResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetBlockResponse.Merge(m, src)
+func (m *GetDataKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataKeysResponse.Merge(m, src)
 }
-func (m *GetBlockResponse) XXX_Size() int {
-	return xxx_messageInfo_GetBlockResponse.Size(m)
+func (m *GetDataKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataKeysResponse.Size(m)
 }
-func (m *GetBlockResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetBlockResponse.DiscardUnknown(m)
+func (m *GetDataKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataKeysResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetBlockResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetDataKeysResponse proto.InternalMessageInfo
 
-func (m *GetBlockResponse) GetHeader() *ResponseHeader {
+func (m *GetDataKeysResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetBlockResponse) GetBlockHeader() *BlockHeader {
+func (m *GetDataKeysResponse) GetKeys() []string {
 	if m != nil {
-		return m.BlockHeader
+		return m.Keys
 	}
 	return nil
 }
 
-// GetAugmentedBlockHeader
-type GetAugmentedBlockHeaderResponseEnvelope struct {
-	Response             *GetAugmentedBlockHeaderResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
-	XXX_unrecognized     []byte                           `json:"-"`
-	XXX_sizecache        int32                            `json:"-"`
+func (m *GetDataKeysResponse) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
 }
 
-func (m *GetAugmentedBlockHeaderResponseEnvelope) Reset() {
-	*m = GetAugmentedBlockHeaderResponseEnvelope{}
+func (m *GetDataKeysResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
 }
-func (m *GetAugmentedBlockHeaderResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetAugmentedBlockHeaderResponseEnvelope) ProtoMessage()    {}
-func (*GetAugmentedBlockHeaderResponseEnvelope) Descriptor() ([]byte, []int) {
+
+func (m *GetDataKeysResponse) GetNextStartKey() string {
+	if m != nil {
+		return m.NextStartKey
+	}
+	return ""
+}
+
+type DataCursorResponseEnvelope struct {
+	Response             *DataCursorResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DataCursorResponseEnvelope) Reset()         { *m = DataCursorResponseEnvelope{} }
+func (m *DataCursorResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DataCursorResponseEnvelope) ProtoMessage()    {}
+func (*DataCursorResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{17}
 }
 
-func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Unmarshal(m, b)
+func (m *DataCursorResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataCursorResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Marshal(b, m, deterministic)
+func (m *DataCursorResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataCursorResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Merge(m, src)
+func (m *DataCursorResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataCursorResponseEnvelope.Merge(m, src)
 }
-func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Size(m)
+func (m *DataCursorResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DataCursorResponseEnvelope.Size(m)
 }
-func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.DiscardUnknown(m)
+func (m *DataCursorResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataCursorResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_DataCursorResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetAugmentedBlockHeaderResponseEnvelope) GetResponse() *GetAugmentedBlockHeaderResponse {
+func (m *DataCursorResponseEnvelope) GetResponse() *DataCursorResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetAugmentedBlockHeaderResponseEnvelope) GetSignature() []byte {
+func (m *DataCursorResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetAugmentedBlockHeaderResponse struct {
-	Header               *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	BlockHeader          *AugmentedBlockHeader `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+// DataCursorResponse describes a cursor that is opened or closed. A cursor expires at the given time, in unix
+// nanoseconds, unless it is read before.
+type DataCursorResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	CursorId             string          `protobuf:"bytes,2,opt,name=cursor_id,json=cursorId,proto3" json:"cursor_id,omitempty"`
+	DbName               string          `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Prefix               string          `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	ExpiresAt            int64           `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetAugmentedBlockHeaderResponse) Reset()         { *m = GetAugmentedBlockHeaderResponse{} }
-func (m *GetAugmentedBlockHeaderResponse) String() string { return proto.CompactTextString(m) }
-func (*GetAugmentedBlockHeaderResponse) ProtoMessage()    {}
-func (*GetAugmentedBlockHeaderResponse) Descriptor() ([]byte, []int) {
+func (m *DataCursorResponse) Reset()         { *m = DataCursorResponse{} }
+func (m *DataCursorResponse) String() string { return proto.CompactTextString(m) }
+func (*DataCursorResponse) ProtoMessage()    {}
+func (*DataCursorResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{18}
 }
 
-func (m *GetAugmentedBlockHeaderResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAugmentedBlockHeaderResponse.Unmarshal(m, b)
+func (m *DataCursorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataCursorResponse.Unmarshal(m, b)
 }
-func (m *GetAugmentedBlockHeaderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAugmentedBlockHeaderResponse.Marshal(b, m, deterministic)
+func (m *DataCursorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataCursorResponse.Marshal(b, m, deterministic)
 }
-func (m *GetAugmentedBlockHeaderResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAugmentedBlockHeaderResponse.Merge(m, src)
+func (m *DataCursorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataCursorResponse.Merge(m, src)
 }
-func (m *GetAugmentedBlockHeaderResponse) XXX_Size() int {
-	return xxx_messageInfo_GetAugmentedBlockHeaderResponse.Size(m)
+func (m *DataCursorResponse) XXX_Size() int {
+	return xxx_messageInfo_DataCursorResponse.Size(m)
 }
-func (m *GetAugmentedBlockHeaderResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAugmentedBlockHeaderResponse.DiscardUnknown(m)
+func (m *DataCursorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataCursorResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetAugmentedBlockHeaderResponse proto.InternalMessageInfo
+var xxx_messageInfo_DataCursorResponse proto.InternalMessageInfo
 
-func (m *GetAugmentedBlockHeaderResponse) GetHeader() *ResponseHeader {
+func (m *DataCursorResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetAugmentedBlockHeaderResponse) GetBlockHeader() *AugmentedBlockHeader {
+func (m *DataCursorResponse) GetCursorId() string {
 	if m != nil {
-		return m.BlockHeader
+		return m.CursorId
 	}
-	return nil
+	return ""
 }
 
-// GetLedgerPath
-type GetLedgerPathResponseEnvelope struct {
-	Response             *GetLedgerPathResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+func (m *DataCursorResponse) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
 }
 
-func (m *GetLedgerPathResponseEnvelope) Reset()         { *m = GetLedgerPathResponseEnvelope{} }
-func (m *GetLedgerPathResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetLedgerPathResponseEnvelope) ProtoMessage()    {}
-func (*GetLedgerPathResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *DataCursorResponse) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *DataCursorResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type GetDataCursorPageResponseEnvelope struct {
+	Response             *GetDataCursorPageResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                     `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *GetDataCursorPageResponseEnvelope) Reset()         { *m = GetDataCursorPageResponseEnvelope{} }
+func (m *GetDataCursorPageResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataCursorPageResponseEnvelope) ProtoMessage()    {}
+func (*GetDataCursorPageResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{19}
 }
 
-func (m *GetLedgerPathResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetLedgerPathResponseEnvelope.Unmarshal(m, b)
+func (m *GetDataCursorPageResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataCursorPageResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetLedgerPathResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetLedgerPathResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetDataCursorPageResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataCursorPageResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetLedgerPathResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetLedgerPathResponseEnvelope.Merge(m, src)
+func (m *GetDataCursorPageResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataCursorPageResponseEnvelope.Merge(m, src)
 }
-func (m *GetLedgerPathResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetLedgerPathResponseEnvelope.Size(m)
+func (m *GetDataCursorPageResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataCursorPageResponseEnvelope.Size(m)
 }
-func (m *GetLedgerPathResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetLedgerPathResponseEnvelope.DiscardUnknown(m)
+func (m *GetDataCursorPageResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataCursorPageResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetLedgerPathResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetDataCursorPageResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetLedgerPathResponseEnvelope) GetResponse() *GetLedgerPathResponse {
+func (m *GetDataCursorPageResponseEnvelope) GetResponse() *GetDataCursorPageResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetLedgerPathResponseEnvelope) GetSignature() []byte {
+func (m *GetDataCursorPageResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetLedgerPathResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	BlockHeaders         []*BlockHeader  `protobuf:"bytes,2,rep,name=block_headers,json=blockHeaders,proto3" json:"block_headers,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+// GetDataCursorPageResponse holds a page of the key-value pairs of a cursor, ordered by key. has_more is set when the
+// cursor holds more key-value pairs after the page, which are fetched by the key of the last pair of the page.
+type GetDataCursorPageResponse struct {
+	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	KVs                  []*KVWithMetadata `protobuf:"bytes,2,rep,name=KVs,proto3" json:"KVs,omitempty"`
+	HasMore              bool              `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	ExpiresAt            int64             `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *GetLedgerPathResponse) Reset()         { *m = GetLedgerPathResponse{} }
-func (m *GetLedgerPathResponse) String() string { return proto.CompactTextString(m) }
-func (*GetLedgerPathResponse) ProtoMessage()    {}
-func (*GetLedgerPathResponse) Descriptor() ([]byte, []int) {
+func (m *GetDataCursorPageResponse) Reset()         { *m = GetDataCursorPageResponse{} }
+func (m *GetDataCursorPageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataCursorPageResponse) ProtoMessage()    {}
+func (*GetDataCursorPageResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{20}
 }
 
-func (m *GetLedgerPathResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetLedgerPathResponse.Unmarshal(m, b)
+func (m *GetDataCursorPageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataCursorPageResponse.Unmarshal(m, b)
 }
-func (m *GetLedgerPathResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetLedgerPathResponse.Marshal(b, m, deterministic)
+func (m *GetDataCursorPageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataCursorPageResponse.Marshal(b, m, deterministic)
 }
-func (m *GetLedgerPathResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetLedgerPathResponse.Merge(m, src)
+func (m *GetDataCursorPageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataCursorPageResponse.Merge(m, src)
 }
-func (m *GetLedgerPathResponse) XXX_Size() int {
-	return xxx_messageInfo_GetLedgerPathResponse.Size(m)
+func (m *GetDataCursorPageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataCursorPageResponse.Size(m)
 }
-func (m *GetLedgerPathResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetLedgerPathResponse.DiscardUnknown(m)
+func (m *GetDataCursorPageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataCursorPageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetLedgerPathResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetDataCursorPageResponse proto.InternalMessageInfo
 
-func (m *GetLedgerPathResponse) GetHeader() *ResponseHeader {
+func (m *GetDataCursorPageResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetLedgerPathResponse) GetBlockHeaders() []*BlockHeader {
+func (m *GetDataCursorPageResponse) GetKVs() []*KVWithMetadata {
 	if m != nil {
-		return m.BlockHeaders
+		return m.KVs
 	}
 	return nil
 }
 
-// GetTxProof
-type GetTxProofResponseEnvelope struct {
-	Response             *GetTxProofResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+func (m *GetDataCursorPageResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
 }
 
-func (m *GetTxProofResponseEnvelope) Reset()         { *m = GetTxProofResponseEnvelope{} }
-func (m *GetTxProofResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetTxProofResponseEnvelope) ProtoMessage()    {}
-func (*GetTxProofResponseEnvelope) Descriptor() ([]byte, []int) {
+func (m *GetDataCursorPageResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type SessionTokenResponseEnvelope struct {
+	Response             *SessionTokenResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *SessionTokenResponseEnvelope) Reset()         { *m = SessionTokenResponseEnvelope{} }
+func (m *SessionTokenResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*SessionTokenResponseEnvelope) ProtoMessage()    {}
+func (*SessionTokenResponseEnvelope) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{21}
 }
 
-func (m *GetTxProofResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTxProofResponseEnvelope.Unmarshal(m, b)
+func (m *SessionTokenResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionTokenResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetTxProofResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTxProofResponseEnvelope.Marshal(b, m, deterministic)
+func (m *SessionTokenResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionTokenResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetTxProofResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTxProofResponseEnvelope.Merge(m, src)
+func (m *SessionTokenResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionTokenResponseEnvelope.Merge(m, src)
 }
-func (m *GetTxProofResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetTxProofResponseEnvelope.Size(m)
+func (m *SessionTokenResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_SessionTokenResponseEnvelope.Size(m)
 }
-func (m *GetTxProofResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTxProofResponseEnvelope.DiscardUnknown(m)
+func (m *SessionTokenResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionTokenResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTxProofResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_SessionTokenResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetTxProofResponseEnvelope) GetResponse() *GetTxProofResponse {
+func (m *SessionTokenResponseEnvelope) GetResponse() *SessionTokenResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetTxProofResponseEnvelope) GetSignature() []byte {
+func (m *SessionTokenResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetTxProofResponse struct {
+// SessionTokenResponse holds a session token of a user, which expires at the given time, in unix nanoseconds
+type SessionTokenResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Hashes               [][]byte        `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	Token                string          `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	UserId               string          `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiresAt            int64           `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetTxProofResponse) Reset()         { *m = GetTxProofResponse{} }
-func (m *GetTxProofResponse) String() string { return proto.CompactTextString(m) }
-func (*GetTxProofResponse) ProtoMessage()    {}
-func (*GetTxProofResponse) Descriptor() ([]byte, []int) {
+func (m *SessionTokenResponse) Reset()         { *m = SessionTokenResponse{} }
+func (m *SessionTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*SessionTokenResponse) ProtoMessage()    {}
+func (*SessionTokenResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{22}
 }
 
-func (m *GetTxProofResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTxProofResponse.Unmarshal(m, b)
+func (m *SessionTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionTokenResponse.Unmarshal(m, b)
 }
-func (m *GetTxProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTxProofResponse.Marshal(b, m, deterministic)
+func (m *SessionTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionTokenResponse.Marshal(b, m, deterministic)
 }
-func (m *GetTxProofResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTxProofResponse.Merge(m, src)
+func (m *SessionTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionTokenResponse.Merge(m, src)
 }
-func (m *GetTxProofResponse) XXX_Size() int {
-	return xxx_messageInfo_GetTxProofResponse.Size(m)
+func (m *SessionTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_SessionTokenResponse.Size(m)
 }
-func (m *GetTxProofResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTxProofResponse.DiscardUnknown(m)
+func (m *SessionTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionTokenResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTxProofResponse proto.InternalMessageInfo
+var xxx_messageInfo_SessionTokenResponse proto.InternalMessageInfo
 
-func (m *GetTxProofResponse) GetHeader() *ResponseHeader {
+func (m *SessionTokenResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetTxProofResponse) GetHashes() [][]byte {
+func (m *SessionTokenResponse) GetToken() string {
 	if m != nil {
-		return m.Hashes
+		return m.Token
 	}
-	return nil
-}
-
-// GetDataProof
-type GetDataProofResponseEnvelope struct {
-	Response             *GetDataProofResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
-}
-
-func (m *GetDataProofResponseEnvelope) Reset()         { *m = GetDataProofResponseEnvelope{} }
-func (m *GetDataProofResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetDataProofResponseEnvelope) ProtoMessage()    {}
-func (*GetDataProofResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{23}
-}
-
-func (m *GetDataProofResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataProofResponseEnvelope.Unmarshal(m, b)
-}
-func (m *GetDataProofResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataProofResponseEnvelope.Marshal(b, m, deterministic)
-}
-func (m *GetDataProofResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataProofResponseEnvelope.Merge(m, src)
-}
-func (m *GetDataProofResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetDataProofResponseEnvelope.Size(m)
-}
-func (m *GetDataProofResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataProofResponseEnvelope.DiscardUnknown(m)
+	return ""
 }
 
-var xxx_messageInfo_GetDataProofResponseEnvelope proto.InternalMessageInfo
-
-func (m *GetDataProofResponseEnvelope) GetResponse() *GetDataProofResponse {
+func (m *SessionTokenResponse) GetUserId() string {
 	if m != nil {
-		return m.Response
+		return m.UserId
 	}
-	return nil
+	return ""
 }
 
-func (m *GetDataProofResponseEnvelope) GetSignature() []byte {
+func (m *SessionTokenResponse) GetExpiresAt() int64 {
 	if m != nil {
-		return m.Signature
+		return m.ExpiresAt
 	}
-	return nil
+	return 0
 }
 
-type GetDataProofResponse struct {
-	Header               *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Path                 []*MPTrieProofElement `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+// GetUser
+type GetUserResponseEnvelope struct {
+	Response             *GetUserResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *GetDataProofResponse) Reset()         { *m = GetDataProofResponse{} }
-func (m *GetDataProofResponse) String() string { return proto.CompactTextString(m) }
-func (*GetDataProofResponse) ProtoMessage()    {}
-func (*GetDataProofResponse) Descriptor() ([]byte, []int) {
+func (m *GetUserResponseEnvelope) Reset()         { *m = GetUserResponseEnvelope{} }
+func (m *GetUserResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetUserResponseEnvelope) ProtoMessage()    {}
+func (*GetUserResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{23}
+}
+
+func (m *GetUserResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUserResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetUserResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUserResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetUserResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserResponseEnvelope.Merge(m, src)
+}
+func (m *GetUserResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetUserResponseEnvelope.Size(m)
+}
+func (m *GetUserResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUserResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetUserResponseEnvelope) GetResponse() *GetUserResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetUserResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetUserResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	User                 *User           `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	Metadata             *Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetUserResponse) Reset()         { *m = GetUserResponse{} }
+func (m *GetUserResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUserResponse) ProtoMessage()    {}
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_0fbc901015fa5021, []int{24}
 }
 
-func (m *GetDataProofResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataProofResponse.Unmarshal(m, b)
+func (m *GetUserResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUserResponse.Unmarshal(m, b)
 }
-func (m *GetDataProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataProofResponse.Marshal(b, m, deterministic)
+func (m *GetUserResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUserResponse.Marshal(b, m, deterministic)
 }
-func (m *GetDataProofResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataProofResponse.Merge(m, src)
+func (m *GetUserResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUserResponse.Merge(m, src)
 }
-func (m *GetDataProofResponse) XXX_Size() int {
-	return xxx_messageInfo_GetDataProofResponse.Size(m)
+func (m *GetUserResponse) XXX_Size() int {
+	return xxx_messageInfo_GetUserResponse.Size(m)
 }
-func (m *GetDataProofResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataProofResponse.DiscardUnknown(m)
+func (m *GetUserResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUserResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataProofResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetUserResponse proto.InternalMessageInfo
 
-func (m *GetDataProofResponse) GetHeader() *ResponseHeader {
+func (m *GetUserResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetDataProofResponse) GetPath() []*MPTrieProofElement {
+func (m *GetUserResponse) GetUser() *User {
 	if m != nil {
-		return m.Path
+		return m.User
 	}
 	return nil
 }
 
-type MPTrieProofElement struct {
-	Hashes               [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+func (m *GetUserResponse) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type GetUsersResponseEnvelope struct {
+	Response             *GetUsersResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte            `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetUsersResponseEnvelope) Reset()         { *m = GetUsersResponseEnvelope{} }
+func (m *GetUsersResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetUsersResponseEnvelope) ProtoMessage()    {}
+func (*GetUsersResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{25}
+}
+
+func (m *GetUsersResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsersResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetUsersResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsersResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetUsersResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsersResponseEnvelope.Merge(m, src)
+}
+func (m *GetUsersResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetUsersResponseEnvelope.Size(m)
+}
+func (m *GetUsersResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsersResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsersResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetUsersResponseEnvelope) GetResponse() *GetUsersResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetUsersResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetUsersResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Users  []*UserInfo     `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	// has_more is set when the users were limited, and more users are available
+	HasMore              bool     `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MPTrieProofElement) Reset()         { *m = MPTrieProofElement{} }
-func (m *MPTrieProofElement) String() string { return proto.CompactTextString(m) }
-func (*MPTrieProofElement) ProtoMessage()    {}
-func (*MPTrieProofElement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{25}
+func (m *GetUsersResponse) Reset()         { *m = GetUsersResponse{} }
+func (m *GetUsersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetUsersResponse) ProtoMessage()    {}
+func (*GetUsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{26}
 }
 
-func (m *MPTrieProofElement) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MPTrieProofElement.Unmarshal(m, b)
+func (m *GetUsersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetUsersResponse.Unmarshal(m, b)
 }
-func (m *MPTrieProofElement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MPTrieProofElement.Marshal(b, m, deterministic)
+func (m *GetUsersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetUsersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetUsersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetUsersResponse.Merge(m, src)
+}
+func (m *GetUsersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetUsersResponse.Size(m)
+}
+func (m *GetUsersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetUsersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetUsersResponse proto.InternalMessageInfo
+
+func (m *GetUsersResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetUsersResponse) GetUsers() []*UserInfo {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+func (m *GetUsersResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
+}
+
+// UserInfo summarizes a user
+type UserInfo struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// version is the version of the transaction that last updated the user
+	Version              *Version   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Privilege            *Privilege `protobuf:"bytes,3,opt,name=privilege,proto3" json:"privilege,omitempty"`
+	Disabled             bool       `protobuf:"varint,4,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UserInfo) Reset()         { *m = UserInfo{} }
+func (m *UserInfo) String() string { return proto.CompactTextString(m) }
+func (*UserInfo) ProtoMessage()    {}
+func (*UserInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{27}
+}
+
+func (m *UserInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserInfo.Unmarshal(m, b)
+}
+func (m *UserInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserInfo.Marshal(b, m, deterministic)
+}
+func (m *UserInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserInfo.Merge(m, src)
+}
+func (m *UserInfo) XXX_Size() int {
+	return xxx_messageInfo_UserInfo.Size(m)
+}
+func (m *UserInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserInfo proto.InternalMessageInfo
+
+func (m *UserInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UserInfo) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *UserInfo) GetPrivilege() *Privilege {
+	if m != nil {
+		return m.Privilege
+	}
+	return nil
+}
+
+func (m *UserInfo) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
+// GetConfig
+type GetConfigResponseEnvelope struct {
+	Response             *GetConfigResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetConfigResponseEnvelope) Reset()         { *m = GetConfigResponseEnvelope{} }
+func (m *GetConfigResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigResponseEnvelope) ProtoMessage()    {}
+func (*GetConfigResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{28}
+}
+
+func (m *GetConfigResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigResponseEnvelope.Merge(m, src)
+}
+func (m *GetConfigResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigResponseEnvelope.Size(m)
+}
+func (m *GetConfigResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigResponseEnvelope) GetResponse() *GetConfigResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetConfigResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Config               *ClusterConfig  `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Metadata             *Metadata       `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetConfigResponse) Reset()         { *m = GetConfigResponse{} }
+func (m *GetConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigResponse) ProtoMessage()    {}
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{29}
+}
+
+func (m *GetConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigResponse.Unmarshal(m, b)
+}
+func (m *GetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigResponse.Merge(m, src)
+}
+func (m *GetConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConfigResponse.Size(m)
+}
+func (m *GetConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigResponse proto.InternalMessageInfo
+
+func (m *GetConfigResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetConfig() *ClusterConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetMetadata() *Metadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// GetNodeConfig
+type GetNodeConfigResponseEnvelope struct {
+	Response             *GetNodeConfigResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetNodeConfigResponseEnvelope) Reset()         { *m = GetNodeConfigResponseEnvelope{} }
+func (m *GetNodeConfigResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetNodeConfigResponseEnvelope) ProtoMessage()    {}
+func (*GetNodeConfigResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{30}
+}
+
+func (m *GetNodeConfigResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeConfigResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetNodeConfigResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeConfigResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetNodeConfigResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeConfigResponseEnvelope.Merge(m, src)
+}
+func (m *GetNodeConfigResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetNodeConfigResponseEnvelope.Size(m)
+}
+func (m *GetNodeConfigResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeConfigResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeConfigResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetNodeConfigResponseEnvelope) GetResponse() *GetNodeConfigResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetNodeConfigResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetNodeConfigResponse struct {
+	Header     *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	NodeConfig *NodeConfig     `protobuf:"bytes,2,opt,name=node_config,json=nodeConfig,proto3" json:"node_config,omitempty"`
+	// The attestation of the identity of the responding node.
+	Attestation          *NodeAttestation `protobuf:"bytes,3,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetNodeConfigResponse) Reset()         { *m = GetNodeConfigResponse{} }
+func (m *GetNodeConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeConfigResponse) ProtoMessage()    {}
+func (*GetNodeConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{31}
+}
+
+func (m *GetNodeConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeConfigResponse.Unmarshal(m, b)
+}
+func (m *GetNodeConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNodeConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeConfigResponse.Merge(m, src)
+}
+func (m *GetNodeConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNodeConfigResponse.Size(m)
+}
+func (m *GetNodeConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeConfigResponse proto.InternalMessageInfo
+
+func (m *GetNodeConfigResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetNodeConfigResponse) GetNodeConfig() *NodeConfig {
+	if m != nil {
+		return m.NodeConfig
+	}
+	return nil
+}
+
+func (m *GetNodeConfigResponse) GetAttestation() *NodeAttestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+// NodeAttestation carries the identity of the node that signed a response, so that a client can authenticate the
+// response, e.g., the discovery data it uses to pick the node to redirect to, without knowing the certificates of the
+// nodes in advance: the client verifies that the certificate is issued through the CA chain by a root CA it trusts,
+// and then verifies the signature of the response with the certificate.
+type NodeAttestation struct {
+	// The certificate of the responding node, whose key signed the response.
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// The CA certificates that issued the certificate of the node, from the issuer to the root, according to the
+	// current CA config.
+	CaChain              [][]byte `protobuf:"bytes,2,rep,name=ca_chain,json=caChain,proto3" json:"ca_chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeAttestation) Reset()         { *m = NodeAttestation{} }
+func (m *NodeAttestation) String() string { return proto.CompactTextString(m) }
+func (*NodeAttestation) ProtoMessage()    {}
+func (*NodeAttestation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{32}
+}
+
+func (m *NodeAttestation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeAttestation.Unmarshal(m, b)
+}
+func (m *NodeAttestation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeAttestation.Marshal(b, m, deterministic)
+}
+func (m *NodeAttestation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeAttestation.Merge(m, src)
+}
+func (m *NodeAttestation) XXX_Size() int {
+	return xxx_messageInfo_NodeAttestation.Size(m)
+}
+func (m *NodeAttestation) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeAttestation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeAttestation proto.InternalMessageInfo
+
+func (m *NodeAttestation) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *NodeAttestation) GetCaChain() [][]byte {
+	if m != nil {
+		return m.CaChain
+	}
+	return nil
+}
+
+// GetConfigBlock
+type GetConfigBlockResponseEnvelope struct {
+	Response             *GetConfigBlockResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetConfigBlockResponseEnvelope) Reset()         { *m = GetConfigBlockResponseEnvelope{} }
+func (m *GetConfigBlockResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigBlockResponseEnvelope) ProtoMessage()    {}
+func (*GetConfigBlockResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{33}
+}
+
+func (m *GetConfigBlockResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigBlockResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigBlockResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigBlockResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigBlockResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigBlockResponseEnvelope.Merge(m, src)
+}
+func (m *GetConfigBlockResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigBlockResponseEnvelope.Size(m)
+}
+func (m *GetConfigBlockResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigBlockResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigBlockResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigBlockResponseEnvelope) GetResponse() *GetConfigBlockResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetConfigBlockResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigBlockResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// block bytes, marshaled with proto.Marshal
+	Block                []byte   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigBlockResponse) Reset()         { *m = GetConfigBlockResponse{} }
+func (m *GetConfigBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigBlockResponse) ProtoMessage()    {}
+func (*GetConfigBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{34}
+}
+
+func (m *GetConfigBlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigBlockResponse.Unmarshal(m, b)
+}
+func (m *GetConfigBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigBlockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConfigBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigBlockResponse.Merge(m, src)
+}
+func (m *GetConfigBlockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConfigBlockResponse.Size(m)
+}
+func (m *GetConfigBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigBlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigBlockResponse proto.InternalMessageInfo
+
+func (m *GetConfigBlockResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetConfigBlockResponse) GetBlock() []byte {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// GetClusterStatus
+type GetClusterStatusResponseEnvelope struct {
+	Response             *GetClusterStatusResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetClusterStatusResponseEnvelope) Reset()         { *m = GetClusterStatusResponseEnvelope{} }
+func (m *GetClusterStatusResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetClusterStatusResponseEnvelope) ProtoMessage()    {}
+func (*GetClusterStatusResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{35}
+}
+
+func (m *GetClusterStatusResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClusterStatusResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetClusterStatusResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClusterStatusResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetClusterStatusResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterStatusResponseEnvelope.Merge(m, src)
+}
+func (m *GetClusterStatusResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetClusterStatusResponseEnvelope.Size(m)
+}
+func (m *GetClusterStatusResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterStatusResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterStatusResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetClusterStatusResponseEnvelope) GetResponse() *GetClusterStatusResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetClusterStatusResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetClusterStatusResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The configuration of the nodes, the part from ClusterConfig accessible to every client.
+	Nodes []*NodeConfig `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// The version of the ClusterConfig from which the nodes are taken of.
+	Version *Version `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	// The leader ID, if it exists.
+	Leader string `protobuf:"bytes,4,opt,name=Leader,proto3" json:"Leader,omitempty"`
+	// The IDs of active nodes, including the leader.
+	Active []string `protobuf:"bytes,5,rep,name=Active,proto3" json:"Active,omitempty"`
+	// The attestation of the identity of the responding node.
+	Attestation          *NodeAttestation `protobuf:"bytes,6,opt,name=attestation,proto3" json:"attestation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *GetClusterStatusResponse) Reset()         { *m = GetClusterStatusResponse{} }
+func (m *GetClusterStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetClusterStatusResponse) ProtoMessage()    {}
+func (*GetClusterStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{36}
+}
+
+func (m *GetClusterStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetClusterStatusResponse.Unmarshal(m, b)
+}
+func (m *GetClusterStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetClusterStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetClusterStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetClusterStatusResponse.Merge(m, src)
+}
+func (m *GetClusterStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetClusterStatusResponse.Size(m)
+}
+func (m *GetClusterStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetClusterStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetClusterStatusResponse proto.InternalMessageInfo
+
+func (m *GetClusterStatusResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetClusterStatusResponse) GetNodes() []*NodeConfig {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *GetClusterStatusResponse) GetVersion() *Version {
+	if m != nil {
+		return m.Version
+	}
+	return nil
+}
+
+func (m *GetClusterStatusResponse) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *GetClusterStatusResponse) GetActive() []string {
+	if m != nil {
+		return m.Active
+	}
+	return nil
+}
+
+func (m *GetClusterStatusResponse) GetAttestation() *NodeAttestation {
+	if m != nil {
+		return m.Attestation
+	}
+	return nil
+}
+
+// TriggerSnapshot
+type TriggerSnapshotResponseEnvelope struct {
+	Response             *TriggerSnapshotResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *TriggerSnapshotResponseEnvelope) Reset()         { *m = TriggerSnapshotResponseEnvelope{} }
+func (m *TriggerSnapshotResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TriggerSnapshotResponseEnvelope) ProtoMessage()    {}
+func (*TriggerSnapshotResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{37}
+}
+
+func (m *TriggerSnapshotResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerSnapshotResponseEnvelope.Unmarshal(m, b)
+}
+func (m *TriggerSnapshotResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerSnapshotResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TriggerSnapshotResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerSnapshotResponseEnvelope.Merge(m, src)
+}
+func (m *TriggerSnapshotResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TriggerSnapshotResponseEnvelope.Size(m)
+}
+func (m *TriggerSnapshotResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerSnapshotResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerSnapshotResponseEnvelope proto.InternalMessageInfo
+
+func (m *TriggerSnapshotResponseEnvelope) GetResponse() *TriggerSnapshotResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *TriggerSnapshotResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type TriggerSnapshotResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The number of the last block included in the snapshot.
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// The Raft index at which the snapshot was taken.
+	RaftIndex            uint64   `protobuf:"varint,3,opt,name=raft_index,json=raftIndex,proto3" json:"raft_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TriggerSnapshotResponse) Reset()         { *m = TriggerSnapshotResponse{} }
+func (m *TriggerSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*TriggerSnapshotResponse) ProtoMessage()    {}
+func (*TriggerSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{38}
+}
+
+func (m *TriggerSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TriggerSnapshotResponse.Unmarshal(m, b)
+}
+func (m *TriggerSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TriggerSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *TriggerSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TriggerSnapshotResponse.Merge(m, src)
+}
+func (m *TriggerSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_TriggerSnapshotResponse.Size(m)
+}
+func (m *TriggerSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TriggerSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TriggerSnapshotResponse proto.InternalMessageInfo
+
+func (m *TriggerSnapshotResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *TriggerSnapshotResponse) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TriggerSnapshotResponse) GetRaftIndex() uint64 {
+	if m != nil {
+		return m.RaftIndex
+	}
+	return 0
+}
+
+// TransferLeadership
+type TransferLeadershipResponseEnvelope struct {
+	Response             *TransferLeadershipResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                      `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *TransferLeadershipResponseEnvelope) Reset()         { *m = TransferLeadershipResponseEnvelope{} }
+func (m *TransferLeadershipResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipResponseEnvelope) ProtoMessage()    {}
+func (*TransferLeadershipResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{39}
+}
+
+func (m *TransferLeadershipResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipResponseEnvelope.Unmarshal(m, b)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipResponseEnvelope.Merge(m, src)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipResponseEnvelope.Size(m)
+}
+func (m *TransferLeadershipResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipResponseEnvelope proto.InternalMessageInfo
+
+func (m *TransferLeadershipResponseEnvelope) GetResponse() *TransferLeadershipResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *TransferLeadershipResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type TransferLeadershipResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The ID of the node that assumed the leadership.
+	LeaderId             string   `protobuf:"bytes,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferLeadershipResponse) Reset()         { *m = TransferLeadershipResponse{} }
+func (m *TransferLeadershipResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipResponse) ProtoMessage()    {}
+func (*TransferLeadershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{40}
+}
+
+func (m *TransferLeadershipResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransferLeadershipResponse.Unmarshal(m, b)
+}
+func (m *TransferLeadershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransferLeadershipResponse.Marshal(b, m, deterministic)
+}
+func (m *TransferLeadershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipResponse.Merge(m, src)
+}
+func (m *TransferLeadershipResponse) XXX_Size() int {
+	return xxx_messageInfo_TransferLeadershipResponse.Size(m)
+}
+func (m *TransferLeadershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferLeadershipResponse proto.InternalMessageInfo
+
+func (m *TransferLeadershipResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *TransferLeadershipResponse) GetLeaderId() string {
+	if m != nil {
+		return m.LeaderId
+	}
+	return ""
+}
+
+// GetConsensusDiagnostics
+type GetConsensusDiagnosticsResponseEnvelope struct {
+	Response             *GetConsensusDiagnosticsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *GetConsensusDiagnosticsResponseEnvelope) Reset() {
+	*m = GetConsensusDiagnosticsResponseEnvelope{}
+}
+func (m *GetConsensusDiagnosticsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusDiagnosticsResponseEnvelope) ProtoMessage()    {}
+func (*GetConsensusDiagnosticsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{41}
+}
+
+func (m *GetConsensusDiagnosticsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusDiagnosticsResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetConsensusDiagnosticsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusDiagnosticsResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusDiagnosticsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusDiagnosticsResponseEnvelope.Merge(m, src)
+}
+func (m *GetConsensusDiagnosticsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusDiagnosticsResponseEnvelope.Size(m)
+}
+func (m *GetConsensusDiagnosticsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusDiagnosticsResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusDiagnosticsResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetConsensusDiagnosticsResponseEnvelope) GetResponse() *GetConsensusDiagnosticsResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetConsensusDiagnosticsResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConsensusDiagnosticsResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	RaftId uint64          `protobuf:"varint,2,opt,name=raft_id,json=raftId,proto3" json:"raft_id,omitempty"`
+	// The Raft state of the node: StateFollower, StatePreCandidate, StateCandidate, StateLeader, or OnBoarding.
+	RaftState string `protobuf:"bytes,3,opt,name=raft_state,json=raftState,proto3" json:"raft_state,omitempty"`
+	Term      uint64 `protobuf:"varint,4,opt,name=term,proto3" json:"term,omitempty"`
+	// The ID of the leader, if it exists.
+	LeaderId     string `protobuf:"bytes,5,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	CommitIndex  uint64 `protobuf:"varint,6,opt,name=commit_index,json=commitIndex,proto3" json:"commit_index,omitempty"`
+	AppliedIndex uint64 `protobuf:"varint,7,opt,name=applied_index,json=appliedIndex,proto3" json:"applied_index,omitempty"`
+	// The number of leader changes observed since the node started.
+	LeaderChanges uint64 `protobuf:"varint,8,opt,name=leader_changes,json=leaderChanges,proto3" json:"leader_changes,omitempty"`
+	// The number of elections the node started as a candidate since it started.
+	Elections uint64 `protobuf:"varint,9,opt,name=elections,proto3" json:"elections,omitempty"`
+	// The time of the last leader change, in nanoseconds since the epoch; zero if none was observed.
+	LastLeaderChangeTime int64 `protobuf:"varint,10,opt,name=last_leader_change_time,json=lastLeaderChangeTime,proto3" json:"last_leader_change_time,omitempty"`
+	// The number of blocks proposed but not yet committed; non zero only on the leader.
+	InFlightBlocks           uint32             `protobuf:"varint,11,opt,name=in_flight_blocks,json=inFlightBlocks,proto3" json:"in_flight_blocks,omitempty"`
+	LastProposedBlockNumber  uint64             `protobuf:"varint,12,opt,name=last_proposed_block_number,json=lastProposedBlockNumber,proto3" json:"last_proposed_block_number,omitempty"`
+	LastCommittedBlockNumber uint64             `protobuf:"varint,13,opt,name=last_committed_block_number,json=lastCommittedBlockNumber,proto3" json:"last_committed_block_number,omitempty"`
+	Peers                    []*PeerDiagnostics `protobuf:"bytes,14,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}           `json:"-"`
+	XXX_unrecognized         []byte             `json:"-"`
+	XXX_sizecache            int32              `json:"-"`
+}
+
+func (m *GetConsensusDiagnosticsResponse) Reset()         { *m = GetConsensusDiagnosticsResponse{} }
+func (m *GetConsensusDiagnosticsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConsensusDiagnosticsResponse) ProtoMessage()    {}
+func (*GetConsensusDiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{42}
+}
+
+func (m *GetConsensusDiagnosticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsensusDiagnosticsResponse.Unmarshal(m, b)
+}
+func (m *GetConsensusDiagnosticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsensusDiagnosticsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConsensusDiagnosticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsensusDiagnosticsResponse.Merge(m, src)
+}
+func (m *GetConsensusDiagnosticsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConsensusDiagnosticsResponse.Size(m)
+}
+func (m *GetConsensusDiagnosticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsensusDiagnosticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsensusDiagnosticsResponse proto.InternalMessageInfo
+
+func (m *GetConsensusDiagnosticsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetRaftId() uint64 {
+	if m != nil {
+		return m.RaftId
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetRaftState() string {
+	if m != nil {
+		return m.RaftState
+	}
+	return ""
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetLeaderId() string {
+	if m != nil {
+		return m.LeaderId
+	}
+	return ""
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetCommitIndex() uint64 {
+	if m != nil {
+		return m.CommitIndex
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetAppliedIndex() uint64 {
+	if m != nil {
+		return m.AppliedIndex
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetLeaderChanges() uint64 {
+	if m != nil {
+		return m.LeaderChanges
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetElections() uint64 {
+	if m != nil {
+		return m.Elections
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetLastLeaderChangeTime() int64 {
+	if m != nil {
+		return m.LastLeaderChangeTime
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetInFlightBlocks() uint32 {
+	if m != nil {
+		return m.InFlightBlocks
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetLastProposedBlockNumber() uint64 {
+	if m != nil {
+		return m.LastProposedBlockNumber
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetLastCommittedBlockNumber() uint64 {
+	if m != nil {
+		return m.LastCommittedBlockNumber
+	}
+	return 0
+}
+
+func (m *GetConsensusDiagnosticsResponse) GetPeers() []*PeerDiagnostics {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// PeerDiagnostics describes a remote consensus member, as seen by the node.
+// The replication progress of the peer is known only when the node is the leader.
+type PeerDiagnostics struct {
+	NodeId               string   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	RaftId               uint64   `protobuf:"varint,2,opt,name=raft_id,json=raftId,proto3" json:"raft_id,omitempty"`
+	Active               bool     `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	MatchIndex           uint64   `protobuf:"varint,4,opt,name=match_index,json=matchIndex,proto3" json:"match_index,omitempty"`
+	NextIndex            uint64   `protobuf:"varint,5,opt,name=next_index,json=nextIndex,proto3" json:"next_index,omitempty"`
+	ProgressState        string   `protobuf:"bytes,6,opt,name=progress_state,json=progressState,proto3" json:"progress_state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerDiagnostics) Reset()         { *m = PeerDiagnostics{} }
+func (m *PeerDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*PeerDiagnostics) ProtoMessage()    {}
+func (*PeerDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{43}
+}
+
+func (m *PeerDiagnostics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerDiagnostics.Unmarshal(m, b)
+}
+func (m *PeerDiagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerDiagnostics.Marshal(b, m, deterministic)
+}
+func (m *PeerDiagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerDiagnostics.Merge(m, src)
+}
+func (m *PeerDiagnostics) XXX_Size() int {
+	return xxx_messageInfo_PeerDiagnostics.Size(m)
+}
+func (m *PeerDiagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerDiagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerDiagnostics proto.InternalMessageInfo
+
+func (m *PeerDiagnostics) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *PeerDiagnostics) GetRaftId() uint64 {
+	if m != nil {
+		return m.RaftId
+	}
+	return 0
+}
+
+func (m *PeerDiagnostics) GetActive() bool {
+	if m != nil {
+		return m.Active
+	}
+	return false
+}
+
+func (m *PeerDiagnostics) GetMatchIndex() uint64 {
+	if m != nil {
+		return m.MatchIndex
+	}
+	return 0
+}
+
+func (m *PeerDiagnostics) GetNextIndex() uint64 {
+	if m != nil {
+		return m.NextIndex
+	}
+	return 0
+}
+
+func (m *PeerDiagnostics) GetProgressState() string {
+	if m != nil {
+		return m.ProgressState
+	}
+	return ""
+}
+
+// GetStorageReport
+type GetStorageReportResponseEnvelope struct {
+	Response             *GetStorageReportResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetStorageReportResponseEnvelope) Reset()         { *m = GetStorageReportResponseEnvelope{} }
+func (m *GetStorageReportResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetStorageReportResponseEnvelope) ProtoMessage()    {}
+func (*GetStorageReportResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{44}
+}
+
+func (m *GetStorageReportResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageReportResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetStorageReportResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageReportResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetStorageReportResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageReportResponseEnvelope.Merge(m, src)
+}
+func (m *GetStorageReportResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetStorageReportResponseEnvelope.Size(m)
+}
+func (m *GetStorageReportResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageReportResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageReportResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetStorageReportResponseEnvelope) GetResponse() *GetStorageReportResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetStorageReportResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetStorageReportResponse reports the storage used by the data databases of the node, for capacity reviews. The
+// lists are ordered from the largest entry, and are truncated to the requested number of entries.
+type GetStorageReportResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The height of the world state the report was taken at.
+	BlockHeight uint64       `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	Dbs         []*DBStorage `protobuf:"bytes,3,rep,name=dbs,proto3" json:"dbs,omitempty"`
+	// Values held by more than one key, ordered by the bytes they waste.
+	DuplicateValues []*DuplicateValue `protobuf:"bytes,4,rep,name=duplicate_values,json=duplicateValues,proto3" json:"duplicate_values,omitempty"`
+	LargestKeys     []*KeyStorage     `protobuf:"bytes,5,rep,name=largest_keys,json=largestKeys,proto3" json:"largest_keys,omitempty"`
+	LargestPrefixes []*PrefixStorage  `protobuf:"bytes,6,rep,name=largest_prefixes,json=largestPrefixes,proto3" json:"largest_prefixes,omitempty"`
+	// Keys with the most versions in the provenance store.
+	VersionHotSpots      []*KeyStorage `protobuf:"bytes,7,rep,name=version_hot_spots,json=versionHotSpots,proto3" json:"version_hot_spots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetStorageReportResponse) Reset()         { *m = GetStorageReportResponse{} }
+func (m *GetStorageReportResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStorageReportResponse) ProtoMessage()    {}
+func (*GetStorageReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{45}
+}
+
+func (m *GetStorageReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageReportResponse.Unmarshal(m, b)
+}
+func (m *GetStorageReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageReportResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStorageReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageReportResponse.Merge(m, src)
+}
+func (m *GetStorageReportResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStorageReportResponse.Size(m)
+}
+func (m *GetStorageReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageReportResponse proto.InternalMessageInfo
+
+func (m *GetStorageReportResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetStorageReportResponse) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *GetStorageReportResponse) GetDbs() []*DBStorage {
+	if m != nil {
+		return m.Dbs
+	}
+	return nil
+}
+
+func (m *GetStorageReportResponse) GetDuplicateValues() []*DuplicateValue {
+	if m != nil {
+		return m.DuplicateValues
+	}
+	return nil
+}
+
+func (m *GetStorageReportResponse) GetLargestKeys() []*KeyStorage {
+	if m != nil {
+		return m.LargestKeys
+	}
+	return nil
+}
+
+func (m *GetStorageReportResponse) GetLargestPrefixes() []*PrefixStorage {
+	if m != nil {
+		return m.LargestPrefixes
+	}
+	return nil
+}
+
+func (m *GetStorageReportResponse) GetVersionHotSpots() []*KeyStorage {
+	if m != nil {
+		return m.VersionHotSpots
+	}
+	return nil
+}
+
+// DBStorage summarizes the storage used by a data database.
+type DBStorage struct {
+	DbName     string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Keys       uint64 `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	ValueBytes uint64 `protobuf:"varint,3,opt,name=value_bytes,json=valueBytes,proto3" json:"value_bytes,omitempty"`
+	// The number of keys whose value is stored in the blob store.
+	BlobKeys             uint64   `protobuf:"varint,4,opt,name=blob_keys,json=blobKeys,proto3" json:"blob_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DBStorage) Reset()         { *m = DBStorage{} }
+func (m *DBStorage) String() string { return proto.CompactTextString(m) }
+func (*DBStorage) ProtoMessage()    {}
+func (*DBStorage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{46}
+}
+
+func (m *DBStorage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DBStorage.Unmarshal(m, b)
+}
+func (m *DBStorage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DBStorage.Marshal(b, m, deterministic)
+}
+func (m *DBStorage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DBStorage.Merge(m, src)
+}
+func (m *DBStorage) XXX_Size() int {
+	return xxx_messageInfo_DBStorage.Size(m)
+}
+func (m *DBStorage) XXX_DiscardUnknown() {
+	xxx_messageInfo_DBStorage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DBStorage proto.InternalMessageInfo
+
+func (m *DBStorage) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *DBStorage) GetKeys() uint64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *DBStorage) GetValueBytes() uint64 {
+	if m != nil {
+		return m.ValueBytes
+	}
+	return 0
+}
+
+func (m *DBStorage) GetBlobKeys() uint64 {
+	if m != nil {
+		return m.BlobKeys
+	}
+	return 0
+}
+
+// DuplicateValue describes a value held by more than one key.
+type DuplicateValue struct {
+	// The SHA-256 hash of the value, or of its blob manifest if the value is stored in the blob store.
+	ValueHash []byte `protobuf:"bytes,1,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Size      uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Count     uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// The first keys that hold the value.
+	Keys                 []*KeyStorage `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *DuplicateValue) Reset()         { *m = DuplicateValue{} }
+func (m *DuplicateValue) String() string { return proto.CompactTextString(m) }
+func (*DuplicateValue) ProtoMessage()    {}
+func (*DuplicateValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{47}
+}
+
+func (m *DuplicateValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DuplicateValue.Unmarshal(m, b)
+}
+func (m *DuplicateValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DuplicateValue.Marshal(b, m, deterministic)
+}
+func (m *DuplicateValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DuplicateValue.Merge(m, src)
+}
+func (m *DuplicateValue) XXX_Size() int {
+	return xxx_messageInfo_DuplicateValue.Size(m)
+}
+func (m *DuplicateValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_DuplicateValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DuplicateValue proto.InternalMessageInfo
+
+func (m *DuplicateValue) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+func (m *DuplicateValue) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *DuplicateValue) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *DuplicateValue) GetKeys() []*KeyStorage {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+// KeyStorage describes the storage used by a key.
+type KeyStorage struct {
+	DbName string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Key    string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Size   uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// The number of versions of the key in the provenance store; set only in the version hot spots.
+	Versions             uint64   `protobuf:"varint,4,opt,name=versions,proto3" json:"versions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyStorage) Reset()         { *m = KeyStorage{} }
+func (m *KeyStorage) String() string { return proto.CompactTextString(m) }
+func (*KeyStorage) ProtoMessage()    {}
+func (*KeyStorage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{48}
+}
+
+func (m *KeyStorage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyStorage.Unmarshal(m, b)
+}
+func (m *KeyStorage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyStorage.Marshal(b, m, deterministic)
+}
+func (m *KeyStorage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyStorage.Merge(m, src)
+}
+func (m *KeyStorage) XXX_Size() int {
+	return xxx_messageInfo_KeyStorage.Size(m)
+}
+func (m *KeyStorage) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyStorage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyStorage proto.InternalMessageInfo
+
+func (m *KeyStorage) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *KeyStorage) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyStorage) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *KeyStorage) GetVersions() uint64 {
+	if m != nil {
+		return m.Versions
+	}
+	return 0
+}
+
+// PrefixStorage summarizes the storage used by the keys of a database that share a prefix.
+type PrefixStorage struct {
+	DbName               string   `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Keys                 uint64   `protobuf:"varint,3,opt,name=keys,proto3" json:"keys,omitempty"`
+	ValueBytes           uint64   `protobuf:"varint,4,opt,name=value_bytes,json=valueBytes,proto3" json:"value_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefixStorage) Reset()         { *m = PrefixStorage{} }
+func (m *PrefixStorage) String() string { return proto.CompactTextString(m) }
+func (*PrefixStorage) ProtoMessage()    {}
+func (*PrefixStorage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{49}
+}
+
+func (m *PrefixStorage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefixStorage.Unmarshal(m, b)
+}
+func (m *PrefixStorage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefixStorage.Marshal(b, m, deterministic)
+}
+func (m *PrefixStorage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefixStorage.Merge(m, src)
+}
+func (m *PrefixStorage) XXX_Size() int {
+	return xxx_messageInfo_PrefixStorage.Size(m)
+}
+func (m *PrefixStorage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefixStorage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefixStorage proto.InternalMessageInfo
+
+func (m *PrefixStorage) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *PrefixStorage) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *PrefixStorage) GetKeys() uint64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *PrefixStorage) GetValueBytes() uint64 {
+	if m != nil {
+		return m.ValueBytes
+	}
+	return 0
+}
+
+type GetStateHashResponseEnvelope struct {
+	Response             *GetStateHashResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetStateHashResponseEnvelope) Reset()         { *m = GetStateHashResponseEnvelope{} }
+func (m *GetStateHashResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetStateHashResponseEnvelope) ProtoMessage()    {}
+func (*GetStateHashResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{50}
+}
+
+func (m *GetStateHashResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateHashResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetStateHashResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateHashResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetStateHashResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateHashResponseEnvelope.Merge(m, src)
+}
+func (m *GetStateHashResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetStateHashResponseEnvelope.Size(m)
+}
+func (m *GetStateHashResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateHashResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateHashResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetStateHashResponseEnvelope) GetResponse() *GetStateHashResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetStateHashResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetStateHashResponse holds the canonical hash of the full contents of a database at a height of the world state.
+// Two nodes hold the same contents in a database at the same height if, and only if, they return the same hash.
+type GetStateHashResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The height of the world state the hash was computed at.
+	BlockHeight uint64 `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	DbName      string `protobuf:"bytes,3,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	Keys        uint64 `protobuf:"varint,4,opt,name=keys,proto3" json:"keys,omitempty"`
+	// The SHA-256 hash of the ordered stream of the keys, values, and metadata of the database.
+	Hash                 []byte   `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateHashResponse) Reset()         { *m = GetStateHashResponse{} }
+func (m *GetStateHashResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateHashResponse) ProtoMessage()    {}
+func (*GetStateHashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{51}
+}
+
+func (m *GetStateHashResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateHashResponse.Unmarshal(m, b)
+}
+func (m *GetStateHashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateHashResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStateHashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateHashResponse.Merge(m, src)
+}
+func (m *GetStateHashResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateHashResponse.Size(m)
+}
+func (m *GetStateHashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateHashResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateHashResponse proto.InternalMessageInfo
+
+func (m *GetStateHashResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetStateHashResponse) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *GetStateHashResponse) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *GetStateHashResponse) GetKeys() uint64 {
+	if m != nil {
+		return m.Keys
+	}
+	return 0
+}
+
+func (m *GetStateHashResponse) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+type CompactIndexResponseEnvelope struct {
+	Response             *CompactIndexResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *CompactIndexResponseEnvelope) Reset()         { *m = CompactIndexResponseEnvelope{} }
+func (m *CompactIndexResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*CompactIndexResponseEnvelope) ProtoMessage()    {}
+func (*CompactIndexResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{52}
+}
+
+func (m *CompactIndexResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactIndexResponseEnvelope.Unmarshal(m, b)
+}
+func (m *CompactIndexResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactIndexResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *CompactIndexResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactIndexResponseEnvelope.Merge(m, src)
+}
+func (m *CompactIndexResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_CompactIndexResponseEnvelope.Size(m)
+}
+func (m *CompactIndexResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactIndexResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactIndexResponseEnvelope proto.InternalMessageInfo
+
+func (m *CompactIndexResponseEnvelope) GetResponse() *CompactIndexResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *CompactIndexResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// CompactIndexResponse holds the approximate sizes on disk of the index of a database before and after its compaction.
+type CompactIndexResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	DbName               string          `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	SizeBeforeBytes      uint64          `protobuf:"varint,3,opt,name=size_before_bytes,json=sizeBeforeBytes,proto3" json:"size_before_bytes,omitempty"`
+	SizeAfterBytes       uint64          `protobuf:"varint,4,opt,name=size_after_bytes,json=sizeAfterBytes,proto3" json:"size_after_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CompactIndexResponse) Reset()         { *m = CompactIndexResponse{} }
+func (m *CompactIndexResponse) String() string { return proto.CompactTextString(m) }
+func (*CompactIndexResponse) ProtoMessage()    {}
+func (*CompactIndexResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{53}
+}
+
+func (m *CompactIndexResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactIndexResponse.Unmarshal(m, b)
+}
+func (m *CompactIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactIndexResponse.Marshal(b, m, deterministic)
+}
+func (m *CompactIndexResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactIndexResponse.Merge(m, src)
+}
+func (m *CompactIndexResponse) XXX_Size() int {
+	return xxx_messageInfo_CompactIndexResponse.Size(m)
+}
+func (m *CompactIndexResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactIndexResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactIndexResponse proto.InternalMessageInfo
+
+func (m *CompactIndexResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CompactIndexResponse) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *CompactIndexResponse) GetSizeBeforeBytes() uint64 {
+	if m != nil {
+		return m.SizeBeforeBytes
+	}
+	return 0
+}
+
+func (m *CompactIndexResponse) GetSizeAfterBytes() uint64 {
+	if m != nil {
+		return m.SizeAfterBytes
+	}
+	return 0
+}
+
+type GetQuarantinedBlockResponseEnvelope struct {
+	Response             *GetQuarantinedBlockResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                       `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *GetQuarantinedBlockResponseEnvelope) Reset()         { *m = GetQuarantinedBlockResponseEnvelope{} }
+func (m *GetQuarantinedBlockResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetQuarantinedBlockResponseEnvelope) ProtoMessage()    {}
+func (*GetQuarantinedBlockResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{54}
+}
+
+func (m *GetQuarantinedBlockResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuarantinedBlockResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetQuarantinedBlockResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuarantinedBlockResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetQuarantinedBlockResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuarantinedBlockResponseEnvelope.Merge(m, src)
+}
+func (m *GetQuarantinedBlockResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetQuarantinedBlockResponseEnvelope.Size(m)
+}
+func (m *GetQuarantinedBlockResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuarantinedBlockResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuarantinedBlockResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetQuarantinedBlockResponseEnvelope) GetResponse() *GetQuarantinedBlockResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetQuarantinedBlockResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetQuarantinedBlockResponse holds the block that the node failed to validate or commit, if any. While a block is
+// quarantined, the node commits no further blocks and rejects transactions.
+type GetQuarantinedBlockResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// Not set if no block is quarantined.
+	QuarantinedBlock     *QuarantinedBlock `protobuf:"bytes,2,opt,name=quarantined_block,json=quarantinedBlock,proto3" json:"quarantined_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetQuarantinedBlockResponse) Reset()         { *m = GetQuarantinedBlockResponse{} }
+func (m *GetQuarantinedBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetQuarantinedBlockResponse) ProtoMessage()    {}
+func (*GetQuarantinedBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{55}
+}
+
+func (m *GetQuarantinedBlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetQuarantinedBlockResponse.Unmarshal(m, b)
+}
+func (m *GetQuarantinedBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetQuarantinedBlockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetQuarantinedBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetQuarantinedBlockResponse.Merge(m, src)
+}
+func (m *GetQuarantinedBlockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetQuarantinedBlockResponse.Size(m)
+}
+func (m *GetQuarantinedBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetQuarantinedBlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetQuarantinedBlockResponse proto.InternalMessageInfo
+
+func (m *GetQuarantinedBlockResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetQuarantinedBlockResponse) GetQuarantinedBlock() *QuarantinedBlock {
+	if m != nil {
+		return m.QuarantinedBlock
+	}
+	return nil
+}
+
+// QuarantinedBlock is the diagnostic record of a block that the node failed to validate or commit, which the node
+// persists in its ledger directory.
+type QuarantinedBlock struct {
+	// The block, as it was when the failure occurred.
+	Block *Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// The error, or the value of the panic, of the failure.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The stack trace of the failure.
+	Stack string `protobuf:"bytes,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	// The time of the failure, in nanoseconds since the Unix epoch.
+	QuarantinedAt        int64    `protobuf:"varint,4,opt,name=quarantined_at,json=quarantinedAt,proto3" json:"quarantined_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuarantinedBlock) Reset()         { *m = QuarantinedBlock{} }
+func (m *QuarantinedBlock) String() string { return proto.CompactTextString(m) }
+func (*QuarantinedBlock) ProtoMessage()    {}
+func (*QuarantinedBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{56}
+}
+
+func (m *QuarantinedBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuarantinedBlock.Unmarshal(m, b)
+}
+func (m *QuarantinedBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuarantinedBlock.Marshal(b, m, deterministic)
+}
+func (m *QuarantinedBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuarantinedBlock.Merge(m, src)
+}
+func (m *QuarantinedBlock) XXX_Size() int {
+	return xxx_messageInfo_QuarantinedBlock.Size(m)
+}
+func (m *QuarantinedBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuarantinedBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuarantinedBlock proto.InternalMessageInfo
+
+func (m *QuarantinedBlock) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *QuarantinedBlock) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *QuarantinedBlock) GetStack() string {
+	if m != nil {
+		return m.Stack
+	}
+	return ""
+}
+
+func (m *QuarantinedBlock) GetQuarantinedAt() int64 {
+	if m != nil {
+		return m.QuarantinedAt
+	}
+	return 0
+}
+
+type GetRejectedTxsResponseEnvelope struct {
+	Response             *GetRejectedTxsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetRejectedTxsResponseEnvelope) Reset()         { *m = GetRejectedTxsResponseEnvelope{} }
+func (m *GetRejectedTxsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetRejectedTxsResponseEnvelope) ProtoMessage()    {}
+func (*GetRejectedTxsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{57}
+}
+
+func (m *GetRejectedTxsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRejectedTxsResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetRejectedTxsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRejectedTxsResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetRejectedTxsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRejectedTxsResponseEnvelope.Merge(m, src)
+}
+func (m *GetRejectedTxsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetRejectedTxsResponseEnvelope.Size(m)
+}
+func (m *GetRejectedTxsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRejectedTxsResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRejectedTxsResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetRejectedTxsResponseEnvelope) GetResponse() *GetRejectedTxsResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetRejectedTxsResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetRejectedTxsResponse holds the transactions that the node most recently rejected before ordering, oldest first.
+// The node holds a bounded number of rejected transactions in memory, and drops the oldest ones first.
+type GetRejectedTxsResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	RejectedTxs          []*RejectedTx   `protobuf:"bytes,2,rep,name=rejected_txs,json=rejectedTxs,proto3" json:"rejected_txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetRejectedTxsResponse) Reset()         { *m = GetRejectedTxsResponse{} }
+func (m *GetRejectedTxsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRejectedTxsResponse) ProtoMessage()    {}
+func (*GetRejectedTxsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{58}
+}
+
+func (m *GetRejectedTxsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRejectedTxsResponse.Unmarshal(m, b)
+}
+func (m *GetRejectedTxsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRejectedTxsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRejectedTxsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRejectedTxsResponse.Merge(m, src)
+}
+func (m *GetRejectedTxsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRejectedTxsResponse.Size(m)
+}
+func (m *GetRejectedTxsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRejectedTxsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRejectedTxsResponse proto.InternalMessageInfo
+
+func (m *GetRejectedTxsResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetRejectedTxsResponse) GetRejectedTxs() []*RejectedTx {
+	if m != nil {
+		return m.RejectedTxs
+	}
+	return nil
+}
+
+// RejectedTx is the record of a transaction that the node rejected before ordering, and which therefore appears in
+// no block.
+type RejectedTx struct {
+	// Empty if the transaction could not be decoded.
+	TxId string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// The submitting user, or the must sign users of a data transaction. Empty if unknown.
+	UserId   string              `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Category RejectedTx_Category `protobuf:"varint,3,opt,name=category,proto3,enum=types.RejectedTx_Category" json:"category,omitempty"`
+	Reason   string              `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The time of the rejection, in nanoseconds since the Unix epoch.
+	RejectedAt           int64    `protobuf:"varint,5,opt,name=rejected_at,json=rejectedAt,proto3" json:"rejected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectedTx) Reset()         { *m = RejectedTx{} }
+func (m *RejectedTx) String() string { return proto.CompactTextString(m) }
+func (*RejectedTx) ProtoMessage()    {}
+func (*RejectedTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{59}
+}
+
+func (m *RejectedTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectedTx.Unmarshal(m, b)
+}
+func (m *RejectedTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectedTx.Marshal(b, m, deterministic)
+}
+func (m *RejectedTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectedTx.Merge(m, src)
+}
+func (m *RejectedTx) XXX_Size() int {
+	return xxx_messageInfo_RejectedTx.Size(m)
+}
+func (m *RejectedTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectedTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectedTx proto.InternalMessageInfo
+
+func (m *RejectedTx) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *RejectedTx) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *RejectedTx) GetCategory() RejectedTx_Category {
+	if m != nil {
+		return m.Category
+	}
+	return RejectedTx_MALFORMED
+}
+
+func (m *RejectedTx) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RejectedTx) GetRejectedAt() int64 {
+	if m != nil {
+		return m.RejectedAt
+	}
+	return 0
+}
+
+type GetSlowQueriesResponseEnvelope struct {
+	Response             *GetSlowQueriesResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetSlowQueriesResponseEnvelope) Reset()         { *m = GetSlowQueriesResponseEnvelope{} }
+func (m *GetSlowQueriesResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetSlowQueriesResponseEnvelope) ProtoMessage()    {}
+func (*GetSlowQueriesResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{60}
+}
+
+func (m *GetSlowQueriesResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSlowQueriesResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetSlowQueriesResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSlowQueriesResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetSlowQueriesResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSlowQueriesResponseEnvelope.Merge(m, src)
+}
+func (m *GetSlowQueriesResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetSlowQueriesResponseEnvelope.Size(m)
+}
+func (m *GetSlowQueriesResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSlowQueriesResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSlowQueriesResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetSlowQueriesResponseEnvelope) GetResponse() *GetSlowQueriesResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetSlowQueriesResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetSlowQueriesResponse holds the data queries that the node most recently executed in more than the slow query
+// threshold, oldest first. The node holds a bounded number of slow queries in memory, and drops the oldest ones first.
+type GetSlowQueriesResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	SlowQueries          []*SlowQuery    `protobuf:"bytes,2,rep,name=slow_queries,json=slowQueries,proto3" json:"slow_queries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetSlowQueriesResponse) Reset()         { *m = GetSlowQueriesResponse{} }
+func (m *GetSlowQueriesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetSlowQueriesResponse) ProtoMessage()    {}
+func (*GetSlowQueriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{61}
+}
+
+func (m *GetSlowQueriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSlowQueriesResponse.Unmarshal(m, b)
+}
+func (m *GetSlowQueriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSlowQueriesResponse.Marshal(b, m, deterministic)
+}
+func (m *GetSlowQueriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSlowQueriesResponse.Merge(m, src)
+}
+func (m *GetSlowQueriesResponse) XXX_Size() int {
+	return xxx_messageInfo_GetSlowQueriesResponse.Size(m)
+}
+func (m *GetSlowQueriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSlowQueriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSlowQueriesResponse proto.InternalMessageInfo
+
+func (m *GetSlowQueriesResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetSlowQueriesResponse) GetSlowQueries() []*SlowQuery {
+	if m != nil {
+		return m.SlowQueries
+	}
+	return nil
+}
+
+// SlowQuery is the record of a data query whose execution took more than the slow query threshold.
+type SlowQuery struct {
+	// The querier.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DbName string `protobuf:"bytes,2,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	// The JSON query; the predicates of an SQL query are recorded as the JSON query they are compiled to.
+	Query string     `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Plan  *QueryPlan `protobuf:"bytes,4,opt,name=plan,proto3" json:"plan,omitempty"`
+	// The number of keys matched by the plan, whose values are read to check the access of the querier. The keys that
+	// the access control held by their index entries does not allow the querier to read are not read.
+	KeysRead uint64 `protobuf:"varint,5,opt,name=keys_read,json=keysRead,proto3" json:"keys_read,omitempty"`
+	// The number of key-value pairs returned to the querier.
+	Results uint64 `protobuf:"varint,6,opt,name=results,proto3" json:"results,omitempty"`
+	// The time of the execution, in nanoseconds since the Unix epoch.
+	ExecutedAt int64 `protobuf:"varint,7,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	// The duration of the execution, in nanoseconds.
+	Duration             int64    `protobuf:"varint,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SlowQuery) Reset()         { *m = SlowQuery{} }
+func (m *SlowQuery) String() string { return proto.CompactTextString(m) }
+func (*SlowQuery) ProtoMessage()    {}
+func (*SlowQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{62}
+}
+
+func (m *SlowQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SlowQuery.Unmarshal(m, b)
+}
+func (m *SlowQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SlowQuery.Marshal(b, m, deterministic)
+}
+func (m *SlowQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlowQuery.Merge(m, src)
+}
+func (m *SlowQuery) XXX_Size() int {
+	return xxx_messageInfo_SlowQuery.Size(m)
+}
+func (m *SlowQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlowQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlowQuery proto.InternalMessageInfo
+
+func (m *SlowQuery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *SlowQuery) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *SlowQuery) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SlowQuery) GetPlan() *QueryPlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+func (m *SlowQuery) GetKeysRead() uint64 {
+	if m != nil {
+		return m.KeysRead
+	}
+	return 0
+}
+
+func (m *SlowQuery) GetResults() uint64 {
+	if m != nil {
+		return m.Results
+	}
+	return 0
+}
+
+func (m *SlowQuery) GetExecutedAt() int64 {
+	if m != nil {
+		return m.ExecutedAt
+	}
+	return 0
+}
+
+func (m *SlowQuery) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+type GetWitnessStatusResponseEnvelope struct {
+	Response             *GetWitnessStatusResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetWitnessStatusResponseEnvelope) Reset()         { *m = GetWitnessStatusResponseEnvelope{} }
+func (m *GetWitnessStatusResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetWitnessStatusResponseEnvelope) ProtoMessage()    {}
+func (*GetWitnessStatusResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{63}
+}
+
+func (m *GetWitnessStatusResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWitnessStatusResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetWitnessStatusResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWitnessStatusResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetWitnessStatusResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWitnessStatusResponseEnvelope.Merge(m, src)
+}
+func (m *GetWitnessStatusResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetWitnessStatusResponseEnvelope.Size(m)
+}
+func (m *GetWitnessStatusResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWitnessStatusResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWitnessStatusResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetWitnessStatusResponseEnvelope) GetResponse() *GetWitnessStatusResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetWitnessStatusResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetWitnessStatusResponse holds the verification status of a witness or standby node, and the discrepancies it most
+// recently found in the blocks it pulled, oldest first.
+type GetWitnessStatusResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The height up to which the blocks pulled were verified.
+	VerifiedHeight uint64 `protobuf:"varint,2,opt,name=verified_height,json=verifiedHeight,proto3" json:"verified_height,omitempty"`
+	// Whether verification halted on a discrepancy.
+	Halted               bool            `protobuf:"varint,3,opt,name=halted,proto3" json:"halted,omitempty"`
+	Alerts               []*WitnessAlert `protobuf:"bytes,4,rep,name=alerts,proto3" json:"alerts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetWitnessStatusResponse) Reset()         { *m = GetWitnessStatusResponse{} }
+func (m *GetWitnessStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetWitnessStatusResponse) ProtoMessage()    {}
+func (*GetWitnessStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{64}
+}
+
+func (m *GetWitnessStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWitnessStatusResponse.Unmarshal(m, b)
+}
+func (m *GetWitnessStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWitnessStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetWitnessStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWitnessStatusResponse.Merge(m, src)
+}
+func (m *GetWitnessStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetWitnessStatusResponse.Size(m)
+}
+func (m *GetWitnessStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWitnessStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWitnessStatusResponse proto.InternalMessageInfo
+
+func (m *GetWitnessStatusResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetWitnessStatusResponse) GetVerifiedHeight() uint64 {
+	if m != nil {
+		return m.VerifiedHeight
+	}
+	return 0
+}
+
+func (m *GetWitnessStatusResponse) GetHalted() bool {
+	if m != nil {
+		return m.Halted
+	}
+	return false
+}
+
+func (m *GetWitnessStatusResponse) GetAlerts() []*WitnessAlert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+// WitnessAlert is the record of a discrepancy found in a block pulled by a witness or standby node.
+type WitnessAlert struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Reason      string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The time of the alert, in nanoseconds since the Unix epoch.
+	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WitnessAlert) Reset()         { *m = WitnessAlert{} }
+func (m *WitnessAlert) String() string { return proto.CompactTextString(m) }
+func (*WitnessAlert) ProtoMessage()    {}
+func (*WitnessAlert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{65}
+}
+
+func (m *WitnessAlert) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WitnessAlert.Unmarshal(m, b)
+}
+func (m *WitnessAlert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WitnessAlert.Marshal(b, m, deterministic)
+}
+func (m *WitnessAlert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WitnessAlert.Merge(m, src)
+}
+func (m *WitnessAlert) XXX_Size() int {
+	return xxx_messageInfo_WitnessAlert.Size(m)
+}
+func (m *WitnessAlert) XXX_DiscardUnknown() {
+	xxx_messageInfo_WitnessAlert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WitnessAlert proto.InternalMessageInfo
+
+func (m *WitnessAlert) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *WitnessAlert) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *WitnessAlert) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+type ExplainJSONQueryResponseEnvelope struct {
+	Response             *ExplainJSONQueryResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ExplainJSONQueryResponseEnvelope) Reset()         { *m = ExplainJSONQueryResponseEnvelope{} }
+func (m *ExplainJSONQueryResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ExplainJSONQueryResponseEnvelope) ProtoMessage()    {}
+func (*ExplainJSONQueryResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{66}
+}
+
+func (m *ExplainJSONQueryResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainJSONQueryResponseEnvelope.Unmarshal(m, b)
+}
+func (m *ExplainJSONQueryResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainJSONQueryResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ExplainJSONQueryResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainJSONQueryResponseEnvelope.Merge(m, src)
+}
+func (m *ExplainJSONQueryResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ExplainJSONQueryResponseEnvelope.Size(m)
+}
+func (m *ExplainJSONQueryResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainJSONQueryResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainJSONQueryResponseEnvelope proto.InternalMessageInfo
+
+func (m *ExplainJSONQueryResponseEnvelope) GetResponse() *ExplainJSONQueryResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ExplainJSONQueryResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ExplainJSONQueryResponse holds the plan that the node would execute for a JSON query. As the query is not executed,
+// the plan holds no scan statistics.
+type ExplainJSONQueryResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Plan                 *QueryPlan      `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ExplainJSONQueryResponse) Reset()         { *m = ExplainJSONQueryResponse{} }
+func (m *ExplainJSONQueryResponse) String() string { return proto.CompactTextString(m) }
+func (*ExplainJSONQueryResponse) ProtoMessage()    {}
+func (*ExplainJSONQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{67}
+}
+
+func (m *ExplainJSONQueryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExplainJSONQueryResponse.Unmarshal(m, b)
+}
+func (m *ExplainJSONQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExplainJSONQueryResponse.Marshal(b, m, deterministic)
+}
+func (m *ExplainJSONQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainJSONQueryResponse.Merge(m, src)
+}
+func (m *ExplainJSONQueryResponse) XXX_Size() int {
+	return xxx_messageInfo_ExplainJSONQueryResponse.Size(m)
+}
+func (m *ExplainJSONQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainJSONQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExplainJSONQueryResponse proto.InternalMessageInfo
+
+func (m *ExplainJSONQueryResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ExplainJSONQueryResponse) GetPlan() *QueryPlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+// QueryPlan is the execution plan of a JSON query: the conditions on every attribute are executed by a scan of the
+// index of the attribute, and the keys matched by the scans are combined with the operator.
+type QueryPlan struct {
+	// The combination operator, either $and or $or.
+	Operator string `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	// The scans of the indexes, sorted by attribute.
+	Scans                []*IndexScan `protobuf:"bytes,2,rep,name=scans,proto3" json:"scans,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *QueryPlan) Reset()         { *m = QueryPlan{} }
+func (m *QueryPlan) String() string { return proto.CompactTextString(m) }
+func (*QueryPlan) ProtoMessage()    {}
+func (*QueryPlan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{68}
+}
+
+func (m *QueryPlan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryPlan.Unmarshal(m, b)
+}
+func (m *QueryPlan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryPlan.Marshal(b, m, deterministic)
+}
+func (m *QueryPlan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPlan.Merge(m, src)
+}
+func (m *QueryPlan) XXX_Size() int {
+	return xxx_messageInfo_QueryPlan.Size(m)
+}
+func (m *QueryPlan) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPlan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPlan proto.InternalMessageInfo
+
+func (m *QueryPlan) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *QueryPlan) GetScans() []*IndexScan {
+	if m != nil {
+		return m.Scans
+	}
+	return nil
+}
+
+// IndexScan is the scan of the index of an attribute that executes the conditions of a query on the attribute.
+type IndexScan struct {
+	Attribute string             `protobuf:"bytes,1,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Type      IndexAttributeType `protobuf:"varint,2,opt,name=type,proto3,enum=types.IndexAttributeType" json:"type,omitempty"`
+	Kind      IndexScan_Kind     `protobuf:"varint,3,opt,name=kind,proto3,enum=types.IndexScan_Kind" json:"kind,omitempty"`
+	// The operators of the conditions on the attribute, sorted.
+	Operators []string `protobuf:"bytes,4,rep,name=operators,proto3" json:"operators,omitempty"`
+	// The number of index entries scanned; zero if the plan is not executed.
+	EntriesScanned uint64 `protobuf:"varint,5,opt,name=entries_scanned,json=entriesScanned,proto3" json:"entries_scanned,omitempty"`
+	// The number of seeks past the entries of the values excluded by $neq conditions; zero if the plan is not executed.
+	Seeks                uint64   `protobuf:"varint,6,opt,name=seeks,proto3" json:"seeks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IndexScan) Reset()         { *m = IndexScan{} }
+func (m *IndexScan) String() string { return proto.CompactTextString(m) }
+func (*IndexScan) ProtoMessage()    {}
+func (*IndexScan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{69}
+}
+
+func (m *IndexScan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IndexScan.Unmarshal(m, b)
+}
+func (m *IndexScan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IndexScan.Marshal(b, m, deterministic)
+}
+func (m *IndexScan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexScan.Merge(m, src)
+}
+func (m *IndexScan) XXX_Size() int {
+	return xxx_messageInfo_IndexScan.Size(m)
+}
+func (m *IndexScan) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexScan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexScan proto.InternalMessageInfo
+
+func (m *IndexScan) GetAttribute() string {
+	if m != nil {
+		return m.Attribute
+	}
+	return ""
+}
+
+func (m *IndexScan) GetType() IndexAttributeType {
+	if m != nil {
+		return m.Type
+	}
+	return IndexAttributeType_NUMBER
+}
+
+func (m *IndexScan) GetKind() IndexScan_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return IndexScan_POINT
+}
+
+func (m *IndexScan) GetOperators() []string {
+	if m != nil {
+		return m.Operators
+	}
+	return nil
+}
+
+func (m *IndexScan) GetEntriesScanned() uint64 {
+	if m != nil {
+		return m.EntriesScanned
+	}
+	return 0
+}
+
+func (m *IndexScan) GetSeeks() uint64 {
+	if m != nil {
+		return m.Seeks
+	}
+	return 0
+}
+
+// ConfigTxDryRun
+type ConfigTxDryRunResponseEnvelope struct {
+	Response             *ConfigTxDryRunResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ConfigTxDryRunResponseEnvelope) Reset()         { *m = ConfigTxDryRunResponseEnvelope{} }
+func (m *ConfigTxDryRunResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*ConfigTxDryRunResponseEnvelope) ProtoMessage()    {}
+func (*ConfigTxDryRunResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{70}
+}
+
+func (m *ConfigTxDryRunResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigTxDryRunResponseEnvelope.Unmarshal(m, b)
+}
+func (m *ConfigTxDryRunResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigTxDryRunResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *ConfigTxDryRunResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigTxDryRunResponseEnvelope.Merge(m, src)
+}
+func (m *ConfigTxDryRunResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_ConfigTxDryRunResponseEnvelope.Size(m)
+}
+func (m *ConfigTxDryRunResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigTxDryRunResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigTxDryRunResponseEnvelope proto.InternalMessageInfo
+
+func (m *ConfigTxDryRunResponseEnvelope) GetResponse() *ConfigTxDryRunResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ConfigTxDryRunResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// ConfigTxDryRunResponse reports the result of validating a config transaction against the current config, and the
+// changes it would make, without submitting it.
+type ConfigTxDryRunResponse struct {
+	Header         *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	ValidationInfo *ValidationInfo `protobuf:"bytes,2,opt,name=validation_info,json=validationInfo,proto3" json:"validation_info,omitempty"`
+	// The sections of the ClusterConfig that the transaction changes.
+	NodesChanged     bool `protobuf:"varint,3,opt,name=nodes_changed,json=nodesChanged,proto3" json:"nodes_changed,omitempty"`
+	ConsensusChanged bool `protobuf:"varint,4,opt,name=consensus_changed,json=consensusChanged,proto3" json:"consensus_changed,omitempty"`
+	CaChanged        bool `protobuf:"varint,5,opt,name=ca_changed,json=caChanged,proto3" json:"ca_changed,omitempty"`
+	AdminsChanged    bool `protobuf:"varint,6,opt,name=admins_changed,json=adminsChanged,proto3" json:"admins_changed,omitempty"`
+	// The consensus members that the transaction adds, removes, or whose endpoints it updates.
+	AddedMembers           []*PeerConfig `protobuf:"bytes,7,rep,name=added_members,json=addedMembers,proto3" json:"added_members,omitempty"`
+	RemovedMembers         []*PeerConfig `protobuf:"bytes,8,rep,name=removed_members,json=removedMembers,proto3" json:"removed_members,omitempty"`
+	UpdatedMembers         []*PeerConfig `protobuf:"bytes,9,rep,name=updated_members,json=updatedMembers,proto3" json:"updated_members,omitempty"`
+	CurrentProtocolVersion uint32        `protobuf:"varint,10,opt,name=current_protocol_version,json=currentProtocolVersion,proto3" json:"current_protocol_version,omitempty"`
+	UpdatedProtocolVersion uint32        `protobuf:"varint,11,opt,name=updated_protocol_version,json=updatedProtocolVersion,proto3" json:"updated_protocol_version,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}      `json:"-"`
+	XXX_unrecognized       []byte        `json:"-"`
+	XXX_sizecache          int32         `json:"-"`
+}
+
+func (m *ConfigTxDryRunResponse) Reset()         { *m = ConfigTxDryRunResponse{} }
+func (m *ConfigTxDryRunResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfigTxDryRunResponse) ProtoMessage()    {}
+func (*ConfigTxDryRunResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{71}
+}
+
+func (m *ConfigTxDryRunResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigTxDryRunResponse.Unmarshal(m, b)
+}
+func (m *ConfigTxDryRunResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigTxDryRunResponse.Marshal(b, m, deterministic)
+}
+func (m *ConfigTxDryRunResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigTxDryRunResponse.Merge(m, src)
+}
+func (m *ConfigTxDryRunResponse) XXX_Size() int {
+	return xxx_messageInfo_ConfigTxDryRunResponse.Size(m)
+}
+func (m *ConfigTxDryRunResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigTxDryRunResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigTxDryRunResponse proto.InternalMessageInfo
+
+func (m *ConfigTxDryRunResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ConfigTxDryRunResponse) GetValidationInfo() *ValidationInfo {
+	if m != nil {
+		return m.ValidationInfo
+	}
+	return nil
+}
+
+func (m *ConfigTxDryRunResponse) GetNodesChanged() bool {
+	if m != nil {
+		return m.NodesChanged
+	}
+	return false
+}
+
+func (m *ConfigTxDryRunResponse) GetConsensusChanged() bool {
+	if m != nil {
+		return m.ConsensusChanged
+	}
+	return false
+}
+
+func (m *ConfigTxDryRunResponse) GetCaChanged() bool {
+	if m != nil {
+		return m.CaChanged
+	}
+	return false
+}
+
+func (m *ConfigTxDryRunResponse) GetAdminsChanged() bool {
+	if m != nil {
+		return m.AdminsChanged
+	}
+	return false
+}
+
+func (m *ConfigTxDryRunResponse) GetAddedMembers() []*PeerConfig {
+	if m != nil {
+		return m.AddedMembers
+	}
+	return nil
+}
+
+func (m *ConfigTxDryRunResponse) GetRemovedMembers() []*PeerConfig {
+	if m != nil {
+		return m.RemovedMembers
+	}
+	return nil
+}
+
+func (m *ConfigTxDryRunResponse) GetUpdatedMembers() []*PeerConfig {
+	if m != nil {
+		return m.UpdatedMembers
+	}
+	return nil
+}
+
+func (m *ConfigTxDryRunResponse) GetCurrentProtocolVersion() uint32 {
+	if m != nil {
+		return m.CurrentProtocolVersion
+	}
+	return 0
+}
+
+func (m *ConfigTxDryRunResponse) GetUpdatedProtocolVersion() uint32 {
+	if m != nil {
+		return m.UpdatedProtocolVersion
+	}
+	return 0
+}
+
+// GetConfigHistory
+type GetConfigHistoryResponseEnvelope struct {
+	Response             *GetConfigHistoryResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                    `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetConfigHistoryResponseEnvelope) Reset()         { *m = GetConfigHistoryResponseEnvelope{} }
+func (m *GetConfigHistoryResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigHistoryResponseEnvelope) ProtoMessage()    {}
+func (*GetConfigHistoryResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{72}
+}
+
+func (m *GetConfigHistoryResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigHistoryResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigHistoryResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigHistoryResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigHistoryResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigHistoryResponseEnvelope.Merge(m, src)
+}
+func (m *GetConfigHistoryResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigHistoryResponseEnvelope.Size(m)
+}
+func (m *GetConfigHistoryResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigHistoryResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigHistoryResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigHistoryResponseEnvelope) GetResponse() *GetConfigHistoryResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetConfigHistoryResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigHistoryResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The committed config blocks, in ascending block order, starting with the genesis block.
+	Entries              []*ConfigHistoryEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetConfigHistoryResponse) Reset()         { *m = GetConfigHistoryResponse{} }
+func (m *GetConfigHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigHistoryResponse) ProtoMessage()    {}
+func (*GetConfigHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{73}
+}
+
+func (m *GetConfigHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetConfigHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConfigHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigHistoryResponse.Merge(m, src)
+}
+func (m *GetConfigHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConfigHistoryResponse.Size(m)
+}
+func (m *GetConfigHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigHistoryResponse proto.InternalMessageInfo
+
+func (m *GetConfigHistoryResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetConfigHistoryResponse) GetEntries() []*ConfigHistoryEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// ConfigHistoryEntry describes a committed config block, and the sections of the ClusterConfig that it changed with
+// respect to the previous config block.
+type ConfigHistoryEntry struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TxId        string `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// The admin that submitted the config transaction.
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The block timestamp, in nanoseconds since the Unix epoch.
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	NodesChanged         bool     `protobuf:"varint,5,opt,name=nodes_changed,json=nodesChanged,proto3" json:"nodes_changed,omitempty"`
+	AdminsChanged        bool     `protobuf:"varint,6,opt,name=admins_changed,json=adminsChanged,proto3" json:"admins_changed,omitempty"`
+	CaChanged            bool     `protobuf:"varint,7,opt,name=ca_changed,json=caChanged,proto3" json:"ca_changed,omitempty"`
+	ConsensusChanged     bool     `protobuf:"varint,8,opt,name=consensus_changed,json=consensusChanged,proto3" json:"consensus_changed,omitempty"`
+	ProtocolVersion      uint32   `protobuf:"varint,9,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigHistoryEntry) Reset()         { *m = ConfigHistoryEntry{} }
+func (m *ConfigHistoryEntry) String() string { return proto.CompactTextString(m) }
+func (*ConfigHistoryEntry) ProtoMessage()    {}
+func (*ConfigHistoryEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{74}
+}
+
+func (m *ConfigHistoryEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigHistoryEntry.Unmarshal(m, b)
+}
+func (m *ConfigHistoryEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigHistoryEntry.Marshal(b, m, deterministic)
+}
+func (m *ConfigHistoryEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigHistoryEntry.Merge(m, src)
+}
+func (m *ConfigHistoryEntry) XXX_Size() int {
+	return xxx_messageInfo_ConfigHistoryEntry.Size(m)
+}
+func (m *ConfigHistoryEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigHistoryEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigHistoryEntry proto.InternalMessageInfo
+
+func (m *ConfigHistoryEntry) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *ConfigHistoryEntry) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *ConfigHistoryEntry) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *ConfigHistoryEntry) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ConfigHistoryEntry) GetNodesChanged() bool {
+	if m != nil {
+		return m.NodesChanged
+	}
+	return false
+}
+
+func (m *ConfigHistoryEntry) GetAdminsChanged() bool {
+	if m != nil {
+		return m.AdminsChanged
+	}
+	return false
+}
+
+func (m *ConfigHistoryEntry) GetCaChanged() bool {
+	if m != nil {
+		return m.CaChanged
+	}
+	return false
+}
+
+func (m *ConfigHistoryEntry) GetConsensusChanged() bool {
+	if m != nil {
+		return m.ConsensusChanged
+	}
+	return false
+}
+
+func (m *ConfigHistoryEntry) GetProtocolVersion() uint32 {
+	if m != nil {
+		return m.ProtocolVersion
+	}
+	return 0
+}
+
+// GetConfigDiff
+type GetConfigDiffResponseEnvelope struct {
+	Response             *GetConfigDiffResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetConfigDiffResponseEnvelope) Reset()         { *m = GetConfigDiffResponseEnvelope{} }
+func (m *GetConfigDiffResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetConfigDiffResponseEnvelope) ProtoMessage()    {}
+func (*GetConfigDiffResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{75}
+}
+
+func (m *GetConfigDiffResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigDiffResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetConfigDiffResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigDiffResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetConfigDiffResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigDiffResponseEnvelope.Merge(m, src)
+}
+func (m *GetConfigDiffResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetConfigDiffResponseEnvelope.Size(m)
+}
+func (m *GetConfigDiffResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigDiffResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigDiffResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetConfigDiffResponseEnvelope) GetResponse() *GetConfigDiffResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetConfigDiffResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetConfigDiffResponse struct {
+	Header               *ResponseHeader    `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	FromBlockNumber      uint64             `protobuf:"varint,2,opt,name=from_block_number,json=fromBlockNumber,proto3" json:"from_block_number,omitempty"`
+	ToBlockNumber        uint64             `protobuf:"varint,3,opt,name=to_block_number,json=toBlockNumber,proto3" json:"to_block_number,omitempty"`
+	Diff                 *ClusterConfigDiff `protobuf:"bytes,4,opt,name=diff,proto3" json:"diff,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetConfigDiffResponse) Reset()         { *m = GetConfigDiffResponse{} }
+func (m *GetConfigDiffResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigDiffResponse) ProtoMessage()    {}
+func (*GetConfigDiffResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{76}
+}
+
+func (m *GetConfigDiffResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigDiffResponse.Unmarshal(m, b)
+}
+func (m *GetConfigDiffResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigDiffResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConfigDiffResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigDiffResponse.Merge(m, src)
+}
+func (m *GetConfigDiffResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConfigDiffResponse.Size(m)
+}
+func (m *GetConfigDiffResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigDiffResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigDiffResponse proto.InternalMessageInfo
+
+func (m *GetConfigDiffResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetConfigDiffResponse) GetFromBlockNumber() uint64 {
+	if m != nil {
+		return m.FromBlockNumber
+	}
+	return 0
+}
+
+func (m *GetConfigDiffResponse) GetToBlockNumber() uint64 {
+	if m != nil {
+		return m.ToBlockNumber
+	}
+	return 0
+}
+
+func (m *GetConfigDiffResponse) GetDiff() *ClusterConfigDiff {
+	if m != nil {
+		return m.Diff
+	}
+	return nil
+}
+
+// ClusterConfigDiff holds the changes between two versions of the ClusterConfig. Nodes, admins, and peers are matched
+// by their ID; an updated entry is reported with its value in the newer version.
+type ClusterConfigDiff struct {
+	AddedNodes             []*NodeConfig `protobuf:"bytes,1,rep,name=added_nodes,json=addedNodes,proto3" json:"added_nodes,omitempty"`
+	RemovedNodes           []*NodeConfig `protobuf:"bytes,2,rep,name=removed_nodes,json=removedNodes,proto3" json:"removed_nodes,omitempty"`
+	UpdatedNodes           []*NodeConfig `protobuf:"bytes,3,rep,name=updated_nodes,json=updatedNodes,proto3" json:"updated_nodes,omitempty"`
+	AddedAdmins            []*Admin      `protobuf:"bytes,4,rep,name=added_admins,json=addedAdmins,proto3" json:"added_admins,omitempty"`
+	RemovedAdmins          []*Admin      `protobuf:"bytes,5,rep,name=removed_admins,json=removedAdmins,proto3" json:"removed_admins,omitempty"`
+	UpdatedAdmins          []*Admin      `protobuf:"bytes,6,rep,name=updated_admins,json=updatedAdmins,proto3" json:"updated_admins,omitempty"`
+	AddedRootCas           [][]byte      `protobuf:"bytes,7,rep,name=added_root_cas,json=addedRootCas,proto3" json:"added_root_cas,omitempty"`
+	RemovedRootCas         [][]byte      `protobuf:"bytes,8,rep,name=removed_root_cas,json=removedRootCas,proto3" json:"removed_root_cas,omitempty"`
+	AddedIntermediateCas   [][]byte      `protobuf:"bytes,9,rep,name=added_intermediate_cas,json=addedIntermediateCas,proto3" json:"added_intermediate_cas,omitempty"`
+	RemovedIntermediateCas [][]byte      `protobuf:"bytes,10,rep,name=removed_intermediate_cas,json=removedIntermediateCas,proto3" json:"removed_intermediate_cas,omitempty"`
+	AddedMembers           []*PeerConfig `protobuf:"bytes,11,rep,name=added_members,json=addedMembers,proto3" json:"added_members,omitempty"`
+	RemovedMembers         []*PeerConfig `protobuf:"bytes,12,rep,name=removed_members,json=removedMembers,proto3" json:"removed_members,omitempty"`
+	UpdatedMembers         []*PeerConfig `protobuf:"bytes,13,rep,name=updated_members,json=updatedMembers,proto3" json:"updated_members,omitempty"`
+	AddedObservers         []*PeerConfig `protobuf:"bytes,14,rep,name=added_observers,json=addedObservers,proto3" json:"added_observers,omitempty"`
+	RemovedObservers       []*PeerConfig `protobuf:"bytes,15,rep,name=removed_observers,json=removedObservers,proto3" json:"removed_observers,omitempty"`
+	UpdatedObservers       []*PeerConfig `protobuf:"bytes,16,rep,name=updated_observers,json=updatedObservers,proto3" json:"updated_observers,omitempty"`
+	// The raft parameters are set only if they changed.
+	FromRaftConfig       *RaftConfig `protobuf:"bytes,17,opt,name=from_raft_config,json=fromRaftConfig,proto3" json:"from_raft_config,omitempty"`
+	ToRaftConfig         *RaftConfig `protobuf:"bytes,18,opt,name=to_raft_config,json=toRaftConfig,proto3" json:"to_raft_config,omitempty"`
+	FromProtocolVersion  uint32      `protobuf:"varint,19,opt,name=from_protocol_version,json=fromProtocolVersion,proto3" json:"from_protocol_version,omitempty"`
+	ToProtocolVersion    uint32      `protobuf:"varint,20,opt,name=to_protocol_version,json=toProtocolVersion,proto3" json:"to_protocol_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ClusterConfigDiff) Reset()         { *m = ClusterConfigDiff{} }
+func (m *ClusterConfigDiff) String() string { return proto.CompactTextString(m) }
+func (*ClusterConfigDiff) ProtoMessage()    {}
+func (*ClusterConfigDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{77}
+}
+
+func (m *ClusterConfigDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterConfigDiff.Unmarshal(m, b)
+}
+func (m *ClusterConfigDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterConfigDiff.Marshal(b, m, deterministic)
+}
+func (m *ClusterConfigDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterConfigDiff.Merge(m, src)
+}
+func (m *ClusterConfigDiff) XXX_Size() int {
+	return xxx_messageInfo_ClusterConfigDiff.Size(m)
+}
+func (m *ClusterConfigDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterConfigDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterConfigDiff proto.InternalMessageInfo
+
+func (m *ClusterConfigDiff) GetAddedNodes() []*NodeConfig {
+	if m != nil {
+		return m.AddedNodes
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetRemovedNodes() []*NodeConfig {
+	if m != nil {
+		return m.RemovedNodes
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetUpdatedNodes() []*NodeConfig {
+	if m != nil {
+		return m.UpdatedNodes
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetAddedAdmins() []*Admin {
+	if m != nil {
+		return m.AddedAdmins
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetRemovedAdmins() []*Admin {
+	if m != nil {
+		return m.RemovedAdmins
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetUpdatedAdmins() []*Admin {
+	if m != nil {
+		return m.UpdatedAdmins
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetAddedRootCas() [][]byte {
+	if m != nil {
+		return m.AddedRootCas
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetRemovedRootCas() [][]byte {
+	if m != nil {
+		return m.RemovedRootCas
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetAddedIntermediateCas() [][]byte {
+	if m != nil {
+		return m.AddedIntermediateCas
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetRemovedIntermediateCas() [][]byte {
+	if m != nil {
+		return m.RemovedIntermediateCas
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetAddedMembers() []*PeerConfig {
+	if m != nil {
+		return m.AddedMembers
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetRemovedMembers() []*PeerConfig {
+	if m != nil {
+		return m.RemovedMembers
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetUpdatedMembers() []*PeerConfig {
+	if m != nil {
+		return m.UpdatedMembers
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetAddedObservers() []*PeerConfig {
+	if m != nil {
+		return m.AddedObservers
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetRemovedObservers() []*PeerConfig {
+	if m != nil {
+		return m.RemovedObservers
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetUpdatedObservers() []*PeerConfig {
+	if m != nil {
+		return m.UpdatedObservers
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetFromRaftConfig() *RaftConfig {
+	if m != nil {
+		return m.FromRaftConfig
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetToRaftConfig() *RaftConfig {
+	if m != nil {
+		return m.ToRaftConfig
+	}
+	return nil
+}
+
+func (m *ClusterConfigDiff) GetFromProtocolVersion() uint32 {
+	if m != nil {
+		return m.FromProtocolVersion
+	}
+	return 0
+}
+
+func (m *ClusterConfigDiff) GetToProtocolVersion() uint32 {
+	if m != nil {
+		return m.ToProtocolVersion
+	}
+	return 0
+}
+
+// GetBlock
+type GetBlockResponseEnvelope struct {
+	Response             *GetBlockResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte            `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetBlockResponseEnvelope) Reset()         { *m = GetBlockResponseEnvelope{} }
+func (m *GetBlockResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetBlockResponseEnvelope) ProtoMessage()    {}
+func (*GetBlockResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{78}
+}
+
+func (m *GetBlockResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetBlockResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetBlockResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockResponseEnvelope.Merge(m, src)
+}
+func (m *GetBlockResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetBlockResponseEnvelope.Size(m)
+}
+func (m *GetBlockResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetBlockResponseEnvelope) GetResponse() *GetBlockResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetBlockResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetBlockResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	BlockHeader          *BlockHeader    `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetBlockResponse) Reset()         { *m = GetBlockResponse{} }
+func (m *GetBlockResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockResponse) ProtoMessage()    {}
+func (*GetBlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{79}
+}
+
+func (m *GetBlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockResponse.Unmarshal(m, b)
+}
+func (m *GetBlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockResponse.Merge(m, src)
+}
+func (m *GetBlockResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockResponse.Size(m)
+}
+func (m *GetBlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockResponse proto.InternalMessageInfo
+
+func (m *GetBlockResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetBlockResponse) GetBlockHeader() *BlockHeader {
+	if m != nil {
+		return m.BlockHeader
+	}
+	return nil
+}
+
+// GetAugmentedBlockHeader
+type GetAugmentedBlockHeaderResponseEnvelope struct {
+	Response             *GetAugmentedBlockHeaderResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                           `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *GetAugmentedBlockHeaderResponseEnvelope) Reset() {
+	*m = GetAugmentedBlockHeaderResponseEnvelope{}
+}
+func (m *GetAugmentedBlockHeaderResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetAugmentedBlockHeaderResponseEnvelope) ProtoMessage()    {}
+func (*GetAugmentedBlockHeaderResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{80}
+}
+
+func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Merge(m, src)
+}
+func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.Size(m)
+}
+func (m *GetAugmentedBlockHeaderResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAugmentedBlockHeaderResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetAugmentedBlockHeaderResponseEnvelope) GetResponse() *GetAugmentedBlockHeaderResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetAugmentedBlockHeaderResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetAugmentedBlockHeaderResponse struct {
+	Header               *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	BlockHeader          *AugmentedBlockHeader `protobuf:"bytes,2,opt,name=block_header,json=blockHeader,proto3" json:"block_header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetAugmentedBlockHeaderResponse) Reset()         { *m = GetAugmentedBlockHeaderResponse{} }
+func (m *GetAugmentedBlockHeaderResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAugmentedBlockHeaderResponse) ProtoMessage()    {}
+func (*GetAugmentedBlockHeaderResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{81}
+}
+
+func (m *GetAugmentedBlockHeaderResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAugmentedBlockHeaderResponse.Unmarshal(m, b)
+}
+func (m *GetAugmentedBlockHeaderResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAugmentedBlockHeaderResponse.Marshal(b, m, deterministic)
+}
+func (m *GetAugmentedBlockHeaderResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAugmentedBlockHeaderResponse.Merge(m, src)
+}
+func (m *GetAugmentedBlockHeaderResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAugmentedBlockHeaderResponse.Size(m)
+}
+func (m *GetAugmentedBlockHeaderResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAugmentedBlockHeaderResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAugmentedBlockHeaderResponse proto.InternalMessageInfo
+
+func (m *GetAugmentedBlockHeaderResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetAugmentedBlockHeaderResponse) GetBlockHeader() *AugmentedBlockHeader {
+	if m != nil {
+		return m.BlockHeader
+	}
+	return nil
+}
+
+// GetLedgerPath
+type GetLedgerPathResponseEnvelope struct {
+	Response             *GetLedgerPathResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetLedgerPathResponseEnvelope) Reset()         { *m = GetLedgerPathResponseEnvelope{} }
+func (m *GetLedgerPathResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetLedgerPathResponseEnvelope) ProtoMessage()    {}
+func (*GetLedgerPathResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{82}
+}
+
+func (m *GetLedgerPathResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLedgerPathResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetLedgerPathResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLedgerPathResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetLedgerPathResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLedgerPathResponseEnvelope.Merge(m, src)
+}
+func (m *GetLedgerPathResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetLedgerPathResponseEnvelope.Size(m)
+}
+func (m *GetLedgerPathResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLedgerPathResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLedgerPathResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetLedgerPathResponseEnvelope) GetResponse() *GetLedgerPathResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetLedgerPathResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetLedgerPathResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	BlockHeaders         []*BlockHeader  `protobuf:"bytes,2,rep,name=block_headers,json=blockHeaders,proto3" json:"block_headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetLedgerPathResponse) Reset()         { *m = GetLedgerPathResponse{} }
+func (m *GetLedgerPathResponse) String() string { return proto.CompactTextString(m) }
+func (*GetLedgerPathResponse) ProtoMessage()    {}
+func (*GetLedgerPathResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{83}
+}
+
+func (m *GetLedgerPathResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLedgerPathResponse.Unmarshal(m, b)
+}
+func (m *GetLedgerPathResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLedgerPathResponse.Marshal(b, m, deterministic)
+}
+func (m *GetLedgerPathResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLedgerPathResponse.Merge(m, src)
+}
+func (m *GetLedgerPathResponse) XXX_Size() int {
+	return xxx_messageInfo_GetLedgerPathResponse.Size(m)
+}
+func (m *GetLedgerPathResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLedgerPathResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLedgerPathResponse proto.InternalMessageInfo
+
+func (m *GetLedgerPathResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetLedgerPathResponse) GetBlockHeaders() []*BlockHeader {
+	if m != nil {
+		return m.BlockHeaders
+	}
+	return nil
+}
+
+// GetTxProof
+type GetTxProofResponseEnvelope struct {
+	Response             *GetTxProofResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte              `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GetTxProofResponseEnvelope) Reset()         { *m = GetTxProofResponseEnvelope{} }
+func (m *GetTxProofResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxProofResponseEnvelope) ProtoMessage()    {}
+func (*GetTxProofResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{84}
+}
+
+func (m *GetTxProofResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxProofResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxProofResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxProofResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxProofResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxProofResponseEnvelope.Merge(m, src)
+}
+func (m *GetTxProofResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxProofResponseEnvelope.Size(m)
+}
+func (m *GetTxProofResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxProofResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxProofResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetTxProofResponseEnvelope) GetResponse() *GetTxProofResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetTxProofResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetTxProofResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Hashes               [][]byte        `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetTxProofResponse) Reset()         { *m = GetTxProofResponse{} }
+func (m *GetTxProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxProofResponse) ProtoMessage()    {}
+func (*GetTxProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{85}
+}
+
+func (m *GetTxProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxProofResponse.Unmarshal(m, b)
+}
+func (m *GetTxProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxProofResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxProofResponse.Merge(m, src)
+}
+func (m *GetTxProofResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxProofResponse.Size(m)
+}
+func (m *GetTxProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxProofResponse proto.InternalMessageInfo
+
+func (m *GetTxProofResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetTxProofResponse) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+// GetDataProof
+type GetDataProofResponseEnvelope struct {
+	Response             *GetDataProofResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetDataProofResponseEnvelope) Reset()         { *m = GetDataProofResponseEnvelope{} }
+func (m *GetDataProofResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataProofResponseEnvelope) ProtoMessage()    {}
+func (*GetDataProofResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{86}
+}
+
+func (m *GetDataProofResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProofResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetDataProofResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProofResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDataProofResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProofResponseEnvelope.Merge(m, src)
+}
+func (m *GetDataProofResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataProofResponseEnvelope.Size(m)
+}
+func (m *GetDataProofResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProofResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProofResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetDataProofResponseEnvelope) GetResponse() *GetDataProofResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDataProofResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetDataProofResponse struct {
+	Header               *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Path                 []*MPTrieProofElement `protobuf:"bytes,2,rep,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetDataProofResponse) Reset()         { *m = GetDataProofResponse{} }
+func (m *GetDataProofResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataProofResponse) ProtoMessage()    {}
+func (*GetDataProofResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{87}
+}
+
+func (m *GetDataProofResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProofResponse.Unmarshal(m, b)
+}
+func (m *GetDataProofResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProofResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDataProofResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProofResponse.Merge(m, src)
+}
+func (m *GetDataProofResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataProofResponse.Size(m)
+}
+func (m *GetDataProofResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProofResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProofResponse proto.InternalMessageInfo
+
+func (m *GetDataProofResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetDataProofResponse) GetPath() []*MPTrieProofElement {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+type MPTrieProofElement struct {
+	Hashes               [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MPTrieProofElement) Reset()         { *m = MPTrieProofElement{} }
+func (m *MPTrieProofElement) String() string { return proto.CompactTextString(m) }
+func (*MPTrieProofElement) ProtoMessage()    {}
+func (*MPTrieProofElement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{88}
+}
+
+func (m *MPTrieProofElement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MPTrieProofElement.Unmarshal(m, b)
+}
+func (m *MPTrieProofElement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MPTrieProofElement.Marshal(b, m, deterministic)
+}
+func (m *MPTrieProofElement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MPTrieProofElement.Merge(m, src)
+}
+func (m *MPTrieProofElement) XXX_Size() int {
+	return xxx_messageInfo_MPTrieProofElement.Size(m)
+}
+func (m *MPTrieProofElement) XXX_DiscardUnknown() {
+	xxx_messageInfo_MPTrieProofElement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MPTrieProofElement proto.InternalMessageInfo
+
+func (m *MPTrieProofElement) GetHashes() [][]byte {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+// GetDBStateRoot
+type GetDBStateRootResponseEnvelope struct {
+	Response             *GetDBStateRootResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetDBStateRootResponseEnvelope) Reset()         { *m = GetDBStateRootResponseEnvelope{} }
+func (m *GetDBStateRootResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDBStateRootResponseEnvelope) ProtoMessage()    {}
+func (*GetDBStateRootResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{89}
+}
+
+func (m *GetDBStateRootResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBStateRootResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetDBStateRootResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBStateRootResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDBStateRootResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBStateRootResponseEnvelope.Merge(m, src)
+}
+func (m *GetDBStateRootResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDBStateRootResponseEnvelope.Size(m)
+}
+func (m *GetDBStateRootResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBStateRootResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDBStateRootResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetDBStateRootResponseEnvelope) GetResponse() *GetDBStateRootResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDBStateRootResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// GetDBStateRootResponse holds the root hash of the state trie of a database at a given block, along with the path
+// from the value that holds the root hash in the system wide state trie to the state root hash stored in the block
+// header, such that the root hash can be verified against the block header.
+type GetDBStateRootResponse struct {
+	Header               *ResponseHeader       `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	RootHash             []byte                `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	Path                 []*MPTrieProofElement `protobuf:"bytes,3,rep,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetDBStateRootResponse) Reset()         { *m = GetDBStateRootResponse{} }
+func (m *GetDBStateRootResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDBStateRootResponse) ProtoMessage()    {}
+func (*GetDBStateRootResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{90}
+}
+
+func (m *GetDBStateRootResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDBStateRootResponse.Unmarshal(m, b)
+}
+func (m *GetDBStateRootResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDBStateRootResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDBStateRootResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDBStateRootResponse.Merge(m, src)
+}
+func (m *GetDBStateRootResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDBStateRootResponse.Size(m)
+}
+func (m *GetDBStateRootResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDBStateRootResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDBStateRootResponse proto.InternalMessageInfo
+
+func (m *GetDBStateRootResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetDBStateRootResponse) GetRootHash() []byte {
+	if m != nil {
+		return m.RootHash
+	}
+	return nil
+}
+
+func (m *GetDBStateRootResponse) GetPath() []*MPTrieProofElement {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+// GetHistoricalData
+type GetHistoricalDataResponseEnvelope struct {
+	Response             *GetHistoricalDataResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                     `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *GetHistoricalDataResponseEnvelope) Reset()         { *m = GetHistoricalDataResponseEnvelope{} }
+func (m *GetHistoricalDataResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetHistoricalDataResponseEnvelope) ProtoMessage()    {}
+func (*GetHistoricalDataResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{91}
+}
+
+func (m *GetHistoricalDataResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHistoricalDataResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetHistoricalDataResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHistoricalDataResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetHistoricalDataResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHistoricalDataResponseEnvelope.Merge(m, src)
+}
+func (m *GetHistoricalDataResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetHistoricalDataResponseEnvelope.Size(m)
+}
+func (m *GetHistoricalDataResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHistoricalDataResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHistoricalDataResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetHistoricalDataResponseEnvelope) GetResponse() *GetHistoricalDataResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetHistoricalDataResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetHistoricalDataResponse struct {
+	Header *ResponseHeader      `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Values []*ValueWithMetadata `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// has_more is set when the values were limited, and more values are available
+	HasMore              bool     `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetHistoricalDataResponse) Reset()         { *m = GetHistoricalDataResponse{} }
+func (m *GetHistoricalDataResponse) String() string { return proto.CompactTextString(m) }
+func (*GetHistoricalDataResponse) ProtoMessage()    {}
+func (*GetHistoricalDataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{92}
+}
+
+func (m *GetHistoricalDataResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetHistoricalDataResponse.Unmarshal(m, b)
+}
+func (m *GetHistoricalDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetHistoricalDataResponse.Marshal(b, m, deterministic)
+}
+func (m *GetHistoricalDataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetHistoricalDataResponse.Merge(m, src)
+}
+func (m *GetHistoricalDataResponse) XXX_Size() int {
+	return xxx_messageInfo_GetHistoricalDataResponse.Size(m)
+}
+func (m *GetHistoricalDataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetHistoricalDataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetHistoricalDataResponse proto.InternalMessageInfo
+
+func (m *GetHistoricalDataResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetHistoricalDataResponse) GetValues() []*ValueWithMetadata {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *GetHistoricalDataResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
+}
+
+// GetDataReaders
+type GetDataReadersResponseEnvelope struct {
+	Response             *GetDataReadersResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetDataReadersResponseEnvelope) Reset()         { *m = GetDataReadersResponseEnvelope{} }
+func (m *GetDataReadersResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataReadersResponseEnvelope) ProtoMessage()    {}
+func (*GetDataReadersResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{93}
+}
+
+func (m *GetDataReadersResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataReadersResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetDataReadersResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataReadersResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDataReadersResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataReadersResponseEnvelope.Merge(m, src)
+}
+func (m *GetDataReadersResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataReadersResponseEnvelope.Size(m)
+}
+func (m *GetDataReadersResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataReadersResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataReadersResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetDataReadersResponseEnvelope) GetResponse() *GetDataReadersResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDataReadersResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetDataReadersResponse struct {
+	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	ReadBy               map[string]uint32 `protobuf:"bytes,2,rep,name=read_by,json=readBy,proto3" json:"read_by,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetDataReadersResponse) Reset()         { *m = GetDataReadersResponse{} }
+func (m *GetDataReadersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataReadersResponse) ProtoMessage()    {}
+func (*GetDataReadersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{94}
+}
+
+func (m *GetDataReadersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataReadersResponse.Unmarshal(m, b)
+}
+func (m *GetDataReadersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataReadersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDataReadersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataReadersResponse.Merge(m, src)
+}
+func (m *GetDataReadersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataReadersResponse.Size(m)
+}
+func (m *GetDataReadersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataReadersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataReadersResponse proto.InternalMessageInfo
+
+func (m *GetDataReadersResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetDataReadersResponse) GetReadBy() map[string]uint32 {
+	if m != nil {
+		return m.ReadBy
+	}
+	return nil
+}
+
+// GetDataWriters
+type GetDataWritersResponseEnvelope struct {
+	Response             *GetDataWritersResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetDataWritersResponseEnvelope) Reset()         { *m = GetDataWritersResponseEnvelope{} }
+func (m *GetDataWritersResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataWritersResponseEnvelope) ProtoMessage()    {}
+func (*GetDataWritersResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{95}
+}
+
+func (m *GetDataWritersResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataWritersResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetDataWritersResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataWritersResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDataWritersResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataWritersResponseEnvelope.Merge(m, src)
+}
+func (m *GetDataWritersResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataWritersResponseEnvelope.Size(m)
+}
+func (m *GetDataWritersResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataWritersResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataWritersResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetDataWritersResponseEnvelope) GetResponse() *GetDataWritersResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDataWritersResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetDataWritersResponse struct {
+	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	WrittenBy            map[string]uint32 `protobuf:"bytes,2,rep,name=written_by,json=writtenBy,proto3" json:"written_by,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetDataWritersResponse) Reset()         { *m = GetDataWritersResponse{} }
+func (m *GetDataWritersResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataWritersResponse) ProtoMessage()    {}
+func (*GetDataWritersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{96}
+}
+
+func (m *GetDataWritersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataWritersResponse.Unmarshal(m, b)
+}
+func (m *GetDataWritersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataWritersResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDataWritersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataWritersResponse.Merge(m, src)
+}
+func (m *GetDataWritersResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataWritersResponse.Size(m)
+}
+func (m *GetDataWritersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataWritersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataWritersResponse proto.InternalMessageInfo
+
+func (m *GetDataWritersResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetDataWritersResponse) GetWrittenBy() map[string]uint32 {
+	if m != nil {
+		return m.WrittenBy
+	}
+	return nil
+}
+
+// GetDataProvenance
+type GetDataProvenanceResponseEnvelope struct {
+	Response             *GetDataProvenanceResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                     `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *GetDataProvenanceResponseEnvelope) Reset()         { *m = GetDataProvenanceResponseEnvelope{} }
+func (m *GetDataProvenanceResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetDataProvenanceResponseEnvelope) ProtoMessage()    {}
+func (*GetDataProvenanceResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{97}
+}
+
+func (m *GetDataProvenanceResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProvenanceResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetDataProvenanceResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProvenanceResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetDataProvenanceResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProvenanceResponseEnvelope.Merge(m, src)
+}
+func (m *GetDataProvenanceResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetDataProvenanceResponseEnvelope.Size(m)
+}
+func (m *GetDataProvenanceResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProvenanceResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProvenanceResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetDataProvenanceResponseEnvelope) GetResponse() *GetDataProvenanceResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDataProvenanceResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetDataProvenanceResponse struct {
+	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	KVs                  []*KVWithMetadata `protobuf:"bytes,2,rep,name=KVs,proto3" json:"KVs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *GetDataProvenanceResponse) Reset()         { *m = GetDataProvenanceResponse{} }
+func (m *GetDataProvenanceResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDataProvenanceResponse) ProtoMessage()    {}
+func (*GetDataProvenanceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{98}
+}
+
+func (m *GetDataProvenanceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDataProvenanceResponse.Unmarshal(m, b)
+}
+func (m *GetDataProvenanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDataProvenanceResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDataProvenanceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDataProvenanceResponse.Merge(m, src)
+}
+func (m *GetDataProvenanceResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDataProvenanceResponse.Size(m)
+}
+func (m *GetDataProvenanceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDataProvenanceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDataProvenanceResponse proto.InternalMessageInfo
+
+func (m *GetDataProvenanceResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetDataProvenanceResponse) GetKVs() []*KVWithMetadata {
+	if m != nil {
+		return m.KVs
+	}
+	return nil
+}
+
+// GetTxIDsSubmittedBy
+type GetTxIDsSubmittedByResponseEnvelope struct {
+	Response             *GetTxIDsSubmittedByResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                       `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *GetTxIDsSubmittedByResponseEnvelope) Reset()         { *m = GetTxIDsSubmittedByResponseEnvelope{} }
+func (m *GetTxIDsSubmittedByResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxIDsSubmittedByResponseEnvelope) ProtoMessage()    {}
+func (*GetTxIDsSubmittedByResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{99}
+}
+
+func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Merge(m, src)
+}
+func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Size(m)
+}
+func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetTxIDsSubmittedByResponseEnvelope) GetResponse() *GetTxIDsSubmittedByResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetTxIDsSubmittedByResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetTxIDsSubmittedByResponse struct {
+	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	TxIDs                []string        `protobuf:"bytes,2,rep,name=txIDs,proto3" json:"txIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetTxIDsSubmittedByResponse) Reset()         { *m = GetTxIDsSubmittedByResponse{} }
+func (m *GetTxIDsSubmittedByResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxIDsSubmittedByResponse) ProtoMessage()    {}
+func (*GetTxIDsSubmittedByResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{100}
+}
+
+func (m *GetTxIDsSubmittedByResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxIDsSubmittedByResponse.Unmarshal(m, b)
+}
+func (m *GetTxIDsSubmittedByResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxIDsSubmittedByResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxIDsSubmittedByResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxIDsSubmittedByResponse.Merge(m, src)
+}
+func (m *GetTxIDsSubmittedByResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxIDsSubmittedByResponse.Size(m)
+}
+func (m *GetTxIDsSubmittedByResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxIDsSubmittedByResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxIDsSubmittedByResponse proto.InternalMessageInfo
+
+func (m *GetTxIDsSubmittedByResponse) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *GetTxIDsSubmittedByResponse) GetTxIDs() []string {
+	if m != nil {
+		return m.TxIDs
+	}
+	return nil
+}
+
+// GetTxsByAnnotation
+type GetTxsByAnnotationResponseEnvelope struct {
+	Response             *GetTxsByAnnotationResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                      `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *GetTxsByAnnotationResponseEnvelope) Reset()         { *m = GetTxsByAnnotationResponseEnvelope{} }
+func (m *GetTxsByAnnotationResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxsByAnnotationResponseEnvelope) ProtoMessage()    {}
+func (*GetTxsByAnnotationResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{101}
+}
+
+func (m *GetTxsByAnnotationResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxsByAnnotationResponseEnvelope.Unmarshal(m, b)
+}
+func (m *GetTxsByAnnotationResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxsByAnnotationResponseEnvelope.Marshal(b, m, deterministic)
+}
+func (m *GetTxsByAnnotationResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxsByAnnotationResponseEnvelope.Merge(m, src)
+}
+func (m *GetTxsByAnnotationResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxsByAnnotationResponseEnvelope.Size(m)
+}
+func (m *GetTxsByAnnotationResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxsByAnnotationResponseEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxsByAnnotationResponseEnvelope proto.InternalMessageInfo
+
+func (m *GetTxsByAnnotationResponseEnvelope) GetResponse() *GetTxsByAnnotationResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetTxsByAnnotationResponseEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type GetTxsByAnnotationResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// the annotated transactions, ordered by their location in the ledger
+	Txs []*AnnotatedTx `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`
+	// has_more is set when the transactions were limited, and more transactions are available
+	HasMore              bool     `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxsByAnnotationResponse) Reset()         { *m = GetTxsByAnnotationResponse{} }
+func (m *GetTxsByAnnotationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxsByAnnotationResponse) ProtoMessage()    {}
+func (*GetTxsByAnnotationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{102}
+}
+
+func (m *GetTxsByAnnotationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxsByAnnotationResponse.Unmarshal(m, b)
 }
-func (m *MPTrieProofElement) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MPTrieProofElement.Merge(m, src)
+func (m *GetTxsByAnnotationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxsByAnnotationResponse.Marshal(b, m, deterministic)
 }
-func (m *MPTrieProofElement) XXX_Size() int {
-	return xxx_messageInfo_MPTrieProofElement.Size(m)
+func (m *GetTxsByAnnotationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxsByAnnotationResponse.Merge(m, src)
 }
-func (m *MPTrieProofElement) XXX_DiscardUnknown() {
-	xxx_messageInfo_MPTrieProofElement.DiscardUnknown(m)
+func (m *GetTxsByAnnotationResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxsByAnnotationResponse.Size(m)
+}
+func (m *GetTxsByAnnotationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxsByAnnotationResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MPTrieProofElement proto.InternalMessageInfo
+var xxx_messageInfo_GetTxsByAnnotationResponse proto.InternalMessageInfo
 
-func (m *MPTrieProofElement) GetHashes() [][]byte {
+func (m *GetTxsByAnnotationResponse) GetHeader() *ResponseHeader {
 	if m != nil {
-		return m.Hashes
+		return m.Header
 	}
 	return nil
 }
 
-// GetHistoricalData
-type GetHistoricalDataResponseEnvelope struct {
-	Response             *GetHistoricalDataResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                     `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+func (m *GetTxsByAnnotationResponse) GetTxs() []*AnnotatedTx {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
 }
 
-func (m *GetHistoricalDataResponseEnvelope) Reset()         { *m = GetHistoricalDataResponseEnvelope{} }
-func (m *GetHistoricalDataResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetHistoricalDataResponseEnvelope) ProtoMessage()    {}
-func (*GetHistoricalDataResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{26}
+func (m *GetTxsByAnnotationResponse) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
 }
 
-func (m *GetHistoricalDataResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetHistoricalDataResponseEnvelope.Unmarshal(m, b)
+// AnnotatedTx locates a transaction that carries the searched annotation
+type AnnotatedTx struct {
+	TxId        string `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	BlockNumber uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TxIndex     uint64 `protobuf:"varint,3,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	// The block timestamp, in nanoseconds since the Unix epoch.
+	Timestamp            int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *GetHistoricalDataResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetHistoricalDataResponseEnvelope.Marshal(b, m, deterministic)
+
+func (m *AnnotatedTx) Reset()         { *m = AnnotatedTx{} }
+func (m *AnnotatedTx) String() string { return proto.CompactTextString(m) }
+func (*AnnotatedTx) ProtoMessage()    {}
+func (*AnnotatedTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{103}
 }
-func (m *GetHistoricalDataResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetHistoricalDataResponseEnvelope.Merge(m, src)
+
+func (m *AnnotatedTx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AnnotatedTx.Unmarshal(m, b)
 }
-func (m *GetHistoricalDataResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetHistoricalDataResponseEnvelope.Size(m)
+func (m *AnnotatedTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AnnotatedTx.Marshal(b, m, deterministic)
 }
-func (m *GetHistoricalDataResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetHistoricalDataResponseEnvelope.DiscardUnknown(m)
+func (m *AnnotatedTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AnnotatedTx.Merge(m, src)
+}
+func (m *AnnotatedTx) XXX_Size() int {
+	return xxx_messageInfo_AnnotatedTx.Size(m)
+}
+func (m *AnnotatedTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_AnnotatedTx.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetHistoricalDataResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_AnnotatedTx proto.InternalMessageInfo
 
-func (m *GetHistoricalDataResponseEnvelope) GetResponse() *GetHistoricalDataResponse {
+func (m *AnnotatedTx) GetTxId() string {
 	if m != nil {
-		return m.Response
+		return m.TxId
 	}
-	return nil
+	return ""
 }
 
-func (m *GetHistoricalDataResponseEnvelope) GetSignature() []byte {
+func (m *AnnotatedTx) GetBlockNumber() uint64 {
 	if m != nil {
-		return m.Signature
+		return m.BlockNumber
 	}
-	return nil
+	return 0
 }
 
-type GetHistoricalDataResponse struct {
-	Header               *ResponseHeader      `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Values               []*ValueWithMetadata `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *AnnotatedTx) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
 }
 
-func (m *GetHistoricalDataResponse) Reset()         { *m = GetHistoricalDataResponse{} }
-func (m *GetHistoricalDataResponse) String() string { return proto.CompactTextString(m) }
-func (*GetHistoricalDataResponse) ProtoMessage()    {}
-func (*GetHistoricalDataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{27}
+func (m *AnnotatedTx) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
 }
 
-func (m *GetHistoricalDataResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetHistoricalDataResponse.Unmarshal(m, b)
+type TxReceiptResponseEnvelope struct {
+	Response             *TxReceiptResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
-func (m *GetHistoricalDataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetHistoricalDataResponse.Marshal(b, m, deterministic)
+
+func (m *TxReceiptResponseEnvelope) Reset()         { *m = TxReceiptResponseEnvelope{} }
+func (m *TxReceiptResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*TxReceiptResponseEnvelope) ProtoMessage()    {}
+func (*TxReceiptResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{104}
 }
-func (m *GetHistoricalDataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetHistoricalDataResponse.Merge(m, src)
+
+func (m *TxReceiptResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxReceiptResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetHistoricalDataResponse) XXX_Size() int {
-	return xxx_messageInfo_GetHistoricalDataResponse.Size(m)
+func (m *TxReceiptResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxReceiptResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetHistoricalDataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetHistoricalDataResponse.DiscardUnknown(m)
+func (m *TxReceiptResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxReceiptResponseEnvelope.Merge(m, src)
+}
+func (m *TxReceiptResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_TxReceiptResponseEnvelope.Size(m)
+}
+func (m *TxReceiptResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxReceiptResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetHistoricalDataResponse proto.InternalMessageInfo
+var xxx_messageInfo_TxReceiptResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetHistoricalDataResponse) GetHeader() *ResponseHeader {
+func (m *TxReceiptResponseEnvelope) GetResponse() *TxReceiptResponse {
 	if m != nil {
-		return m.Header
+		return m.Response
 	}
 	return nil
 }
 
-func (m *GetHistoricalDataResponse) GetValues() []*ValueWithMetadata {
+func (m *TxReceiptResponseEnvelope) GetSignature() []byte {
 	if m != nil {
-		return m.Values
+		return m.Signature
 	}
 	return nil
 }
 
-// GetDataReaders
-type GetDataReadersResponseEnvelope struct {
-	Response             *GetDataReadersResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+type TxReceiptResponse struct {
+	Header  *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Receipt *TxReceipt      `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// The proof of inclusion of the transaction in the block of the receipt, i.e., the path of hashes in the Merkle
+	// tree of the transactions of the block, from the transaction to the TxMerkelTreeRootHash of the block header
+	TxProof              [][]byte `protobuf:"bytes,3,rep,name=tx_proof,json=txProof,proto3" json:"tx_proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetDataReadersResponseEnvelope) Reset()         { *m = GetDataReadersResponseEnvelope{} }
-func (m *GetDataReadersResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetDataReadersResponseEnvelope) ProtoMessage()    {}
-func (*GetDataReadersResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{28}
+func (m *TxReceiptResponse) Reset()         { *m = TxReceiptResponse{} }
+func (m *TxReceiptResponse) String() string { return proto.CompactTextString(m) }
+func (*TxReceiptResponse) ProtoMessage()    {}
+func (*TxReceiptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{105}
 }
 
-func (m *GetDataReadersResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataReadersResponseEnvelope.Unmarshal(m, b)
+func (m *TxReceiptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxReceiptResponse.Unmarshal(m, b)
 }
-func (m *GetDataReadersResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataReadersResponseEnvelope.Marshal(b, m, deterministic)
+func (m *TxReceiptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxReceiptResponse.Marshal(b, m, deterministic)
 }
-func (m *GetDataReadersResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataReadersResponseEnvelope.Merge(m, src)
+func (m *TxReceiptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxReceiptResponse.Merge(m, src)
 }
-func (m *GetDataReadersResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetDataReadersResponseEnvelope.Size(m)
+func (m *TxReceiptResponse) XXX_Size() int {
+	return xxx_messageInfo_TxReceiptResponse.Size(m)
 }
-func (m *GetDataReadersResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataReadersResponseEnvelope.DiscardUnknown(m)
+func (m *TxReceiptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxReceiptResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataReadersResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_TxReceiptResponse proto.InternalMessageInfo
 
-func (m *GetDataReadersResponseEnvelope) GetResponse() *GetDataReadersResponse {
+func (m *TxReceiptResponse) GetHeader() *ResponseHeader {
 	if m != nil {
-		return m.Response
+		return m.Header
 	}
 	return nil
 }
 
-func (m *GetDataReadersResponseEnvelope) GetSignature() []byte {
+func (m *TxReceiptResponse) GetReceipt() *TxReceipt {
 	if m != nil {
-		return m.Signature
+		return m.Receipt
 	}
 	return nil
 }
 
-type GetDataReadersResponse struct {
-	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	ReadBy               map[string]uint32 `protobuf:"bytes,2,rep,name=read_by,json=readBy,proto3" json:"read_by,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *TxReceiptResponse) GetTxProof() [][]byte {
+	if m != nil {
+		return m.TxProof
+	}
+	return nil
 }
 
-func (m *GetDataReadersResponse) Reset()         { *m = GetDataReadersResponse{} }
-func (m *GetDataReadersResponse) String() string { return proto.CompactTextString(m) }
-func (*GetDataReadersResponse) ProtoMessage()    {}
-func (*GetDataReadersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{29}
+type GetTxReceiptsResponseEnvelope struct {
+	Response             *GetTxReceiptsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *GetDataReadersResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataReadersResponse.Unmarshal(m, b)
+func (m *GetTxReceiptsResponseEnvelope) Reset()         { *m = GetTxReceiptsResponseEnvelope{} }
+func (m *GetTxReceiptsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxReceiptsResponseEnvelope) ProtoMessage()    {}
+func (*GetTxReceiptsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{106}
 }
-func (m *GetDataReadersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataReadersResponse.Marshal(b, m, deterministic)
+
+func (m *GetTxReceiptsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxReceiptsResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetDataReadersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataReadersResponse.Merge(m, src)
+func (m *GetTxReceiptsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxReceiptsResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetDataReadersResponse) XXX_Size() int {
-	return xxx_messageInfo_GetDataReadersResponse.Size(m)
+func (m *GetTxReceiptsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxReceiptsResponseEnvelope.Merge(m, src)
 }
-func (m *GetDataReadersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataReadersResponse.DiscardUnknown(m)
+func (m *GetTxReceiptsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxReceiptsResponseEnvelope.Size(m)
+}
+func (m *GetTxReceiptsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxReceiptsResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataReadersResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetTxReceiptsResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetDataReadersResponse) GetHeader() *ResponseHeader {
+func (m *GetTxReceiptsResponseEnvelope) GetResponse() *GetTxReceiptsResponse {
 	if m != nil {
-		return m.Header
+		return m.Response
 	}
 	return nil
 }
 
-func (m *GetDataReadersResponse) GetReadBy() map[string]uint32 {
+func (m *GetTxReceiptsResponseEnvelope) GetSignature() []byte {
 	if m != nil {
-		return m.ReadBy
+		return m.Signature
 	}
 	return nil
 }
 
-// GetDataWriters
-type GetDataWritersResponseEnvelope struct {
-	Response             *GetDataWritersResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+type GetTxReceiptsResponse struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The receipts of the requested transactions that are committed, in the order of the request
+	Receipts []*TxReceiptWithProof `protobuf:"bytes,2,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	// The requested transactions that are not committed
+	NotFoundTxIds        []string `protobuf:"bytes,3,rep,name=not_found_tx_ids,json=notFoundTxIds,proto3" json:"not_found_tx_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetDataWritersResponseEnvelope) Reset()         { *m = GetDataWritersResponseEnvelope{} }
-func (m *GetDataWritersResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetDataWritersResponseEnvelope) ProtoMessage()    {}
-func (*GetDataWritersResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{30}
+func (m *GetTxReceiptsResponse) Reset()         { *m = GetTxReceiptsResponse{} }
+func (m *GetTxReceiptsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxReceiptsResponse) ProtoMessage()    {}
+func (*GetTxReceiptsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{107}
 }
 
-func (m *GetDataWritersResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataWritersResponseEnvelope.Unmarshal(m, b)
+func (m *GetTxReceiptsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxReceiptsResponse.Unmarshal(m, b)
 }
-func (m *GetDataWritersResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataWritersResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetTxReceiptsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxReceiptsResponse.Marshal(b, m, deterministic)
 }
-func (m *GetDataWritersResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataWritersResponseEnvelope.Merge(m, src)
+func (m *GetTxReceiptsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxReceiptsResponse.Merge(m, src)
 }
-func (m *GetDataWritersResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetDataWritersResponseEnvelope.Size(m)
+func (m *GetTxReceiptsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxReceiptsResponse.Size(m)
 }
-func (m *GetDataWritersResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataWritersResponseEnvelope.DiscardUnknown(m)
+func (m *GetTxReceiptsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxReceiptsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataWritersResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetTxReceiptsResponse proto.InternalMessageInfo
 
-func (m *GetDataWritersResponseEnvelope) GetResponse() *GetDataWritersResponse {
+func (m *GetTxReceiptsResponse) GetHeader() *ResponseHeader {
 	if m != nil {
-		return m.Response
+		return m.Header
 	}
 	return nil
 }
 
-func (m *GetDataWritersResponseEnvelope) GetSignature() []byte {
+func (m *GetTxReceiptsResponse) GetReceipts() []*TxReceiptWithProof {
 	if m != nil {
-		return m.Signature
+		return m.Receipts
 	}
 	return nil
 }
 
-type GetDataWritersResponse struct {
-	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	WrittenBy            map[string]uint32 `protobuf:"bytes,2,rep,name=written_by,json=writtenBy,proto3" json:"written_by,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *GetTxReceiptsResponse) GetNotFoundTxIds() []string {
+	if m != nil {
+		return m.NotFoundTxIds
+	}
+	return nil
 }
 
-func (m *GetDataWritersResponse) Reset()         { *m = GetDataWritersResponse{} }
-func (m *GetDataWritersResponse) String() string { return proto.CompactTextString(m) }
-func (*GetDataWritersResponse) ProtoMessage()    {}
-func (*GetDataWritersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{31}
+type TxReceiptWithProof struct {
+	TxId    string     `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Receipt *TxReceipt `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// The proof of inclusion of the transaction in the block of the receipt, as in TxReceiptResponse
+	TxProof              [][]byte `protobuf:"bytes,3,rep,name=tx_proof,json=txProof,proto3" json:"tx_proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetDataWritersResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataWritersResponse.Unmarshal(m, b)
+func (m *TxReceiptWithProof) Reset()         { *m = TxReceiptWithProof{} }
+func (m *TxReceiptWithProof) String() string { return proto.CompactTextString(m) }
+func (*TxReceiptWithProof) ProtoMessage()    {}
+func (*TxReceiptWithProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{108}
 }
-func (m *GetDataWritersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataWritersResponse.Marshal(b, m, deterministic)
+
+func (m *TxReceiptWithProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxReceiptWithProof.Unmarshal(m, b)
 }
-func (m *GetDataWritersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataWritersResponse.Merge(m, src)
+func (m *TxReceiptWithProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxReceiptWithProof.Marshal(b, m, deterministic)
 }
-func (m *GetDataWritersResponse) XXX_Size() int {
-	return xxx_messageInfo_GetDataWritersResponse.Size(m)
+func (m *TxReceiptWithProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxReceiptWithProof.Merge(m, src)
 }
-func (m *GetDataWritersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataWritersResponse.DiscardUnknown(m)
+func (m *TxReceiptWithProof) XXX_Size() int {
+	return xxx_messageInfo_TxReceiptWithProof.Size(m)
+}
+func (m *TxReceiptWithProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxReceiptWithProof.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataWritersResponse proto.InternalMessageInfo
+var xxx_messageInfo_TxReceiptWithProof proto.InternalMessageInfo
 
-func (m *GetDataWritersResponse) GetHeader() *ResponseHeader {
+func (m *TxReceiptWithProof) GetTxId() string {
 	if m != nil {
-		return m.Header
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TxReceiptWithProof) GetReceipt() *TxReceipt {
+	if m != nil {
+		return m.Receipt
 	}
 	return nil
 }
 
-func (m *GetDataWritersResponse) GetWrittenBy() map[string]uint32 {
+func (m *TxReceiptWithProof) GetTxProof() [][]byte {
 	if m != nil {
-		return m.WrittenBy
+		return m.TxProof
 	}
 	return nil
 }
 
-// GetDataProvenance
-type GetDataProvenanceResponseEnvelope struct {
-	Response             *GetDataProvenanceResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                     `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+type GetTxResourceUsageResponseEnvelope struct {
+	Response             *GetTxResourceUsageResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                      `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
-func (m *GetDataProvenanceResponseEnvelope) Reset()         { *m = GetDataProvenanceResponseEnvelope{} }
-func (m *GetDataProvenanceResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetDataProvenanceResponseEnvelope) ProtoMessage()    {}
-func (*GetDataProvenanceResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{32}
+func (m *GetTxResourceUsageResponseEnvelope) Reset()         { *m = GetTxResourceUsageResponseEnvelope{} }
+func (m *GetTxResourceUsageResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetTxResourceUsageResponseEnvelope) ProtoMessage()    {}
+func (*GetTxResourceUsageResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{109}
 }
 
-func (m *GetDataProvenanceResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataProvenanceResponseEnvelope.Unmarshal(m, b)
+func (m *GetTxResourceUsageResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxResourceUsageResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetDataProvenanceResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataProvenanceResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetTxResourceUsageResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxResourceUsageResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetDataProvenanceResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataProvenanceResponseEnvelope.Merge(m, src)
+func (m *GetTxResourceUsageResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxResourceUsageResponseEnvelope.Merge(m, src)
 }
-func (m *GetDataProvenanceResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetDataProvenanceResponseEnvelope.Size(m)
+func (m *GetTxResourceUsageResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetTxResourceUsageResponseEnvelope.Size(m)
 }
-func (m *GetDataProvenanceResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataProvenanceResponseEnvelope.DiscardUnknown(m)
+func (m *GetTxResourceUsageResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxResourceUsageResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataProvenanceResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetTxResourceUsageResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetDataProvenanceResponseEnvelope) GetResponse() *GetDataProvenanceResponse {
+func (m *GetTxResourceUsageResponseEnvelope) GetResponse() *GetTxResourceUsageResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetDataProvenanceResponseEnvelope) GetSignature() []byte {
+func (m *GetTxResourceUsageResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetDataProvenanceResponse struct {
-	Header               *ResponseHeader   `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	KVs                  []*KVWithMetadata `protobuf:"bytes,2,rep,name=KVs,proto3" json:"KVs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type GetTxResourceUsageResponse struct {
+	Header               *ResponseHeader  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Usage                *TxResourceUsage `protobuf:"bytes,2,opt,name=usage,proto3" json:"usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *GetDataProvenanceResponse) Reset()         { *m = GetDataProvenanceResponse{} }
-func (m *GetDataProvenanceResponse) String() string { return proto.CompactTextString(m) }
-func (*GetDataProvenanceResponse) ProtoMessage()    {}
-func (*GetDataProvenanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{33}
+func (m *GetTxResourceUsageResponse) Reset()         { *m = GetTxResourceUsageResponse{} }
+func (m *GetTxResourceUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxResourceUsageResponse) ProtoMessage()    {}
+func (*GetTxResourceUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{110}
 }
 
-func (m *GetDataProvenanceResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetDataProvenanceResponse.Unmarshal(m, b)
+func (m *GetTxResourceUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxResourceUsageResponse.Unmarshal(m, b)
 }
-func (m *GetDataProvenanceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetDataProvenanceResponse.Marshal(b, m, deterministic)
+func (m *GetTxResourceUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxResourceUsageResponse.Marshal(b, m, deterministic)
 }
-func (m *GetDataProvenanceResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetDataProvenanceResponse.Merge(m, src)
+func (m *GetTxResourceUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxResourceUsageResponse.Merge(m, src)
 }
-func (m *GetDataProvenanceResponse) XXX_Size() int {
-	return xxx_messageInfo_GetDataProvenanceResponse.Size(m)
+func (m *GetTxResourceUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxResourceUsageResponse.Size(m)
 }
-func (m *GetDataProvenanceResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetDataProvenanceResponse.DiscardUnknown(m)
+func (m *GetTxResourceUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxResourceUsageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetDataProvenanceResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetTxResourceUsageResponse proto.InternalMessageInfo
 
-func (m *GetDataProvenanceResponse) GetHeader() *ResponseHeader {
+func (m *GetTxResourceUsageResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetDataProvenanceResponse) GetKVs() []*KVWithMetadata {
+func (m *GetTxResourceUsageResponse) GetUsage() *TxResourceUsage {
 	if m != nil {
-		return m.KVs
+		return m.Usage
 	}
 	return nil
 }
 
-// GetTxIDsSubmittedBy
-type GetTxIDsSubmittedByResponseEnvelope struct {
-	Response             *GetTxIDsSubmittedByResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte                       `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+type PendingDataTxResponseEnvelope struct {
+	Response             *PendingDataTxResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
-func (m *GetTxIDsSubmittedByResponseEnvelope) Reset()         { *m = GetTxIDsSubmittedByResponseEnvelope{} }
-func (m *GetTxIDsSubmittedByResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*GetTxIDsSubmittedByResponseEnvelope) ProtoMessage()    {}
-func (*GetTxIDsSubmittedByResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{34}
+func (m *PendingDataTxResponseEnvelope) Reset()         { *m = PendingDataTxResponseEnvelope{} }
+func (m *PendingDataTxResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*PendingDataTxResponseEnvelope) ProtoMessage()    {}
+func (*PendingDataTxResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{111}
 }
 
-func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Unmarshal(m, b)
+func (m *PendingDataTxResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingDataTxResponseEnvelope.Unmarshal(m, b)
 }
-func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Marshal(b, m, deterministic)
+func (m *PendingDataTxResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingDataTxResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Merge(m, src)
+func (m *PendingDataTxResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingDataTxResponseEnvelope.Merge(m, src)
 }
-func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.Size(m)
+func (m *PendingDataTxResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_PendingDataTxResponseEnvelope.Size(m)
 }
-func (m *GetTxIDsSubmittedByResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope.DiscardUnknown(m)
+func (m *PendingDataTxResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingDataTxResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTxIDsSubmittedByResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_PendingDataTxResponseEnvelope proto.InternalMessageInfo
 
-func (m *GetTxIDsSubmittedByResponseEnvelope) GetResponse() *GetTxIDsSubmittedByResponse {
+func (m *PendingDataTxResponseEnvelope) GetResponse() *PendingDataTxResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *GetTxIDsSubmittedByResponseEnvelope) GetSignature() []byte {
+func (m *PendingDataTxResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type GetTxIDsSubmittedByResponse struct {
+type PendingDataTxResponse struct {
 	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	TxIDs                []string        `protobuf:"bytes,2,rep,name=txIDs,proto3" json:"txIDs,omitempty"`
+	PendingTx            *PendingDataTx  `protobuf:"bytes,2,opt,name=pending_tx,json=pendingTx,proto3" json:"pending_tx,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *GetTxIDsSubmittedByResponse) Reset()         { *m = GetTxIDsSubmittedByResponse{} }
-func (m *GetTxIDsSubmittedByResponse) String() string { return proto.CompactTextString(m) }
-func (*GetTxIDsSubmittedByResponse) ProtoMessage()    {}
-func (*GetTxIDsSubmittedByResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{35}
+func (m *PendingDataTxResponse) Reset()         { *m = PendingDataTxResponse{} }
+func (m *PendingDataTxResponse) String() string { return proto.CompactTextString(m) }
+func (*PendingDataTxResponse) ProtoMessage()    {}
+func (*PendingDataTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{112}
 }
 
-func (m *GetTxIDsSubmittedByResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetTxIDsSubmittedByResponse.Unmarshal(m, b)
+func (m *PendingDataTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingDataTxResponse.Unmarshal(m, b)
 }
-func (m *GetTxIDsSubmittedByResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetTxIDsSubmittedByResponse.Marshal(b, m, deterministic)
+func (m *PendingDataTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingDataTxResponse.Marshal(b, m, deterministic)
 }
-func (m *GetTxIDsSubmittedByResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetTxIDsSubmittedByResponse.Merge(m, src)
+func (m *PendingDataTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingDataTxResponse.Merge(m, src)
 }
-func (m *GetTxIDsSubmittedByResponse) XXX_Size() int {
-	return xxx_messageInfo_GetTxIDsSubmittedByResponse.Size(m)
+func (m *PendingDataTxResponse) XXX_Size() int {
+	return xxx_messageInfo_PendingDataTxResponse.Size(m)
 }
-func (m *GetTxIDsSubmittedByResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetTxIDsSubmittedByResponse.DiscardUnknown(m)
+func (m *PendingDataTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingDataTxResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetTxIDsSubmittedByResponse proto.InternalMessageInfo
+var xxx_messageInfo_PendingDataTxResponse proto.InternalMessageInfo
 
-func (m *GetTxIDsSubmittedByResponse) GetHeader() *ResponseHeader {
+func (m *PendingDataTxResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *GetTxIDsSubmittedByResponse) GetTxIDs() []string {
+func (m *PendingDataTxResponse) GetPendingTx() *PendingDataTx {
 	if m != nil {
-		return m.TxIDs
+		return m.PendingTx
 	}
 	return nil
 }
 
-type TxReceiptResponseEnvelope struct {
-	Response             *TxReceiptResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
-	Signature            []byte             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+type GetPendingDataTxsResponseEnvelope struct {
+	Response             *GetPendingDataTxsResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Signature            []byte                     `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
 }
 
-func (m *TxReceiptResponseEnvelope) Reset()         { *m = TxReceiptResponseEnvelope{} }
-func (m *TxReceiptResponseEnvelope) String() string { return proto.CompactTextString(m) }
-func (*TxReceiptResponseEnvelope) ProtoMessage()    {}
-func (*TxReceiptResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{36}
+func (m *GetPendingDataTxsResponseEnvelope) Reset()         { *m = GetPendingDataTxsResponseEnvelope{} }
+func (m *GetPendingDataTxsResponseEnvelope) String() string { return proto.CompactTextString(m) }
+func (*GetPendingDataTxsResponseEnvelope) ProtoMessage()    {}
+func (*GetPendingDataTxsResponseEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{113}
 }
 
-func (m *TxReceiptResponseEnvelope) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TxReceiptResponseEnvelope.Unmarshal(m, b)
+func (m *GetPendingDataTxsResponseEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingDataTxsResponseEnvelope.Unmarshal(m, b)
 }
-func (m *TxReceiptResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TxReceiptResponseEnvelope.Marshal(b, m, deterministic)
+func (m *GetPendingDataTxsResponseEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingDataTxsResponseEnvelope.Marshal(b, m, deterministic)
 }
-func (m *TxReceiptResponseEnvelope) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TxReceiptResponseEnvelope.Merge(m, src)
+func (m *GetPendingDataTxsResponseEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingDataTxsResponseEnvelope.Merge(m, src)
 }
-func (m *TxReceiptResponseEnvelope) XXX_Size() int {
-	return xxx_messageInfo_TxReceiptResponseEnvelope.Size(m)
+func (m *GetPendingDataTxsResponseEnvelope) XXX_Size() int {
+	return xxx_messageInfo_GetPendingDataTxsResponseEnvelope.Size(m)
 }
-func (m *TxReceiptResponseEnvelope) XXX_DiscardUnknown() {
-	xxx_messageInfo_TxReceiptResponseEnvelope.DiscardUnknown(m)
+func (m *GetPendingDataTxsResponseEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingDataTxsResponseEnvelope.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TxReceiptResponseEnvelope proto.InternalMessageInfo
+var xxx_messageInfo_GetPendingDataTxsResponseEnvelope proto.InternalMessageInfo
 
-func (m *TxReceiptResponseEnvelope) GetResponse() *TxReceiptResponse {
+func (m *GetPendingDataTxsResponseEnvelope) GetResponse() *GetPendingDataTxsResponse {
 	if m != nil {
 		return m.Response
 	}
 	return nil
 }
 
-func (m *TxReceiptResponseEnvelope) GetSignature() []byte {
+func (m *GetPendingDataTxsResponseEnvelope) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-type TxReceiptResponse struct {
-	Header               *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Receipt              *TxReceipt      `protobuf:"bytes,2,opt,name=receipt,proto3" json:"receipt,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+type GetPendingDataTxsResponse struct {
+	Header               *ResponseHeader  `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	PendingTxs           []*PendingDataTx `protobuf:"bytes,2,rep,name=pending_txs,json=pendingTxs,proto3" json:"pending_txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *TxReceiptResponse) Reset()         { *m = TxReceiptResponse{} }
-func (m *TxReceiptResponse) String() string { return proto.CompactTextString(m) }
-func (*TxReceiptResponse) ProtoMessage()    {}
-func (*TxReceiptResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{37}
+func (m *GetPendingDataTxsResponse) Reset()         { *m = GetPendingDataTxsResponse{} }
+func (m *GetPendingDataTxsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPendingDataTxsResponse) ProtoMessage()    {}
+func (*GetPendingDataTxsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{114}
 }
 
-func (m *TxReceiptResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_TxReceiptResponse.Unmarshal(m, b)
+func (m *GetPendingDataTxsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingDataTxsResponse.Unmarshal(m, b)
 }
-func (m *TxReceiptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_TxReceiptResponse.Marshal(b, m, deterministic)
+func (m *GetPendingDataTxsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingDataTxsResponse.Marshal(b, m, deterministic)
 }
-func (m *TxReceiptResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TxReceiptResponse.Merge(m, src)
+func (m *GetPendingDataTxsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingDataTxsResponse.Merge(m, src)
 }
-func (m *TxReceiptResponse) XXX_Size() int {
-	return xxx_messageInfo_TxReceiptResponse.Size(m)
+func (m *GetPendingDataTxsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPendingDataTxsResponse.Size(m)
 }
-func (m *TxReceiptResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_TxReceiptResponse.DiscardUnknown(m)
+func (m *GetPendingDataTxsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingDataTxsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TxReceiptResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetPendingDataTxsResponse proto.InternalMessageInfo
 
-func (m *TxReceiptResponse) GetHeader() *ResponseHeader {
+func (m *GetPendingDataTxsResponse) GetHeader() *ResponseHeader {
 	if m != nil {
 		return m.Header
 	}
 	return nil
 }
 
-func (m *TxReceiptResponse) GetReceipt() *TxReceipt {
+func (m *GetPendingDataTxsResponse) GetPendingTxs() []*PendingDataTx {
 	if m != nil {
-		return m.Receipt
+		return m.PendingTxs
 	}
 	return nil
 }
@@ -1874,7 +6683,7 @@ func (m *DataQueryResponseEnvelope) Reset()         { *m = DataQueryResponseEnve
 func (m *DataQueryResponseEnvelope) String() string { return proto.CompactTextString(m) }
 func (*DataQueryResponseEnvelope) ProtoMessage()    {}
 func (*DataQueryResponseEnvelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{38}
+	return fileDescriptor_0fbc901015fa5021, []int{115}
 }
 
 func (m *DataQueryResponseEnvelope) XXX_Unmarshal(b []byte) error {
@@ -1921,7 +6730,7 @@ func (m *DataQueryResponse) Reset()         { *m = DataQueryResponse{} }
 func (m *DataQueryResponse) String() string { return proto.CompactTextString(m) }
 func (*DataQueryResponse) ProtoMessage()    {}
 func (*DataQueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0fbc901015fa5021, []int{39}
+	return fileDescriptor_0fbc901015fa5021, []int{116}
 }
 
 func (m *DataQueryResponse) XXX_Unmarshal(b []byte) error {
@@ -1956,22 +6765,250 @@ func (m *DataQueryResponse) GetKVs() []*KVWithMetadata {
 	return nil
 }
 
+// DataQueryStreamItem is a line of the result of a JSON query streamed as newline-delimited JSON. Every line holds a
+// key-value pair, except for the last line, which holds the signed trailer of the result.
+type DataQueryStreamItem struct {
+	Kv                   *KVWithMetadata                 `protobuf:"bytes,1,opt,name=kv,proto3" json:"kv,omitempty"`
+	End                  *DataQueryStreamTrailerEnvelope `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *DataQueryStreamItem) Reset()         { *m = DataQueryStreamItem{} }
+func (m *DataQueryStreamItem) String() string { return proto.CompactTextString(m) }
+func (*DataQueryStreamItem) ProtoMessage()    {}
+func (*DataQueryStreamItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{117}
+}
+
+func (m *DataQueryStreamItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataQueryStreamItem.Unmarshal(m, b)
+}
+func (m *DataQueryStreamItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataQueryStreamItem.Marshal(b, m, deterministic)
+}
+func (m *DataQueryStreamItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataQueryStreamItem.Merge(m, src)
+}
+func (m *DataQueryStreamItem) XXX_Size() int {
+	return xxx_messageInfo_DataQueryStreamItem.Size(m)
+}
+func (m *DataQueryStreamItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataQueryStreamItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataQueryStreamItem proto.InternalMessageInfo
+
+func (m *DataQueryStreamItem) GetKv() *KVWithMetadata {
+	if m != nil {
+		return m.Kv
+	}
+	return nil
+}
+
+func (m *DataQueryStreamItem) GetEnd() *DataQueryStreamTrailerEnvelope {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+type DataQueryStreamTrailerEnvelope struct {
+	Trailer              *DataQueryStreamTrailer `protobuf:"bytes,1,opt,name=trailer,proto3" json:"trailer,omitempty"`
+	Signature            []byte                  `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *DataQueryStreamTrailerEnvelope) Reset()         { *m = DataQueryStreamTrailerEnvelope{} }
+func (m *DataQueryStreamTrailerEnvelope) String() string { return proto.CompactTextString(m) }
+func (*DataQueryStreamTrailerEnvelope) ProtoMessage()    {}
+func (*DataQueryStreamTrailerEnvelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{118}
+}
+
+func (m *DataQueryStreamTrailerEnvelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataQueryStreamTrailerEnvelope.Unmarshal(m, b)
+}
+func (m *DataQueryStreamTrailerEnvelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataQueryStreamTrailerEnvelope.Marshal(b, m, deterministic)
+}
+func (m *DataQueryStreamTrailerEnvelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataQueryStreamTrailerEnvelope.Merge(m, src)
+}
+func (m *DataQueryStreamTrailerEnvelope) XXX_Size() int {
+	return xxx_messageInfo_DataQueryStreamTrailerEnvelope.Size(m)
+}
+func (m *DataQueryStreamTrailerEnvelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataQueryStreamTrailerEnvelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataQueryStreamTrailerEnvelope proto.InternalMessageInfo
+
+func (m *DataQueryStreamTrailerEnvelope) GetTrailer() *DataQueryStreamTrailer {
+	if m != nil {
+		return m.Trailer
+	}
+	return nil
+}
+
+func (m *DataQueryStreamTrailerEnvelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// DataQueryStreamTrailer authenticates the key-value pairs of a streamed result by their count and their hash.
+type DataQueryStreamTrailer struct {
+	Header *ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The number of key-value pairs streamed before the trailer.
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// The SHA-256 hash of the lines of the key-value pairs, each with its terminating newline.
+	KvsHash []byte `protobuf:"bytes,3,opt,name=kvs_hash,json=kvsHash,proto3" json:"kvs_hash,omitempty"`
+	// The error that ended the query before all the matching key-value pairs were streamed, if any.
+	ErrMsg               string   `protobuf:"bytes,4,opt,name=err_msg,json=errMsg,proto3" json:"err_msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataQueryStreamTrailer) Reset()         { *m = DataQueryStreamTrailer{} }
+func (m *DataQueryStreamTrailer) String() string { return proto.CompactTextString(m) }
+func (*DataQueryStreamTrailer) ProtoMessage()    {}
+func (*DataQueryStreamTrailer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0fbc901015fa5021, []int{119}
+}
+
+func (m *DataQueryStreamTrailer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataQueryStreamTrailer.Unmarshal(m, b)
+}
+func (m *DataQueryStreamTrailer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataQueryStreamTrailer.Marshal(b, m, deterministic)
+}
+func (m *DataQueryStreamTrailer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataQueryStreamTrailer.Merge(m, src)
+}
+func (m *DataQueryStreamTrailer) XXX_Size() int {
+	return xxx_messageInfo_DataQueryStreamTrailer.Size(m)
+}
+func (m *DataQueryStreamTrailer) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataQueryStreamTrailer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataQueryStreamTrailer proto.InternalMessageInfo
+
+func (m *DataQueryStreamTrailer) GetHeader() *ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *DataQueryStreamTrailer) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *DataQueryStreamTrailer) GetKvsHash() []byte {
+	if m != nil {
+		return m.KvsHash
+	}
+	return nil
+}
+
+func (m *DataQueryStreamTrailer) GetErrMsg() string {
+	if m != nil {
+		return m.ErrMsg
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("types.RejectedTx_Category", RejectedTx_Category_name, RejectedTx_Category_value)
+	proto.RegisterEnum("types.IndexScan_Kind", IndexScan_Kind_name, IndexScan_Kind_value)
 	proto.RegisterType((*ResponseHeader)(nil), "types.ResponseHeader")
 	proto.RegisterType((*GetDBStatusResponseEnvelope)(nil), "types.GetDBStatusResponseEnvelope")
 	proto.RegisterType((*GetDBStatusResponse)(nil), "types.GetDBStatusResponse")
+	proto.RegisterType((*GetDBsResponseEnvelope)(nil), "types.GetDBsResponseEnvelope")
+	proto.RegisterType((*GetDBsResponse)(nil), "types.GetDBsResponse")
+	proto.RegisterType((*GetIndexUsageResponseEnvelope)(nil), "types.GetIndexUsageResponseEnvelope")
+	proto.RegisterType((*GetIndexUsageResponse)(nil), "types.GetIndexUsageResponse")
+	proto.RegisterType((*GetMigrationsResponseEnvelope)(nil), "types.GetMigrationsResponseEnvelope")
+	proto.RegisterType((*GetMigrationsResponse)(nil), "types.GetMigrationsResponse")
+	proto.RegisterType((*IndexAttributeUsage)(nil), "types.IndexAttributeUsage")
+	proto.RegisterType((*DBInfo)(nil), "types.DBInfo")
 	proto.RegisterType((*GetDataResponseEnvelope)(nil), "types.GetDataResponseEnvelope")
 	proto.RegisterType((*GetDataResponse)(nil), "types.GetDataResponse")
+	proto.RegisterType((*PutBlobResponseEnvelope)(nil), "types.PutBlobResponseEnvelope")
+	proto.RegisterType((*PutBlobResponse)(nil), "types.PutBlobResponse")
+	proto.RegisterType((*GetDataKeysResponseEnvelope)(nil), "types.GetDataKeysResponseEnvelope")
+	proto.RegisterType((*GetDataKeysResponse)(nil), "types.GetDataKeysResponse")
+	proto.RegisterType((*DataCursorResponseEnvelope)(nil), "types.DataCursorResponseEnvelope")
+	proto.RegisterType((*DataCursorResponse)(nil), "types.DataCursorResponse")
+	proto.RegisterType((*GetDataCursorPageResponseEnvelope)(nil), "types.GetDataCursorPageResponseEnvelope")
+	proto.RegisterType((*GetDataCursorPageResponse)(nil), "types.GetDataCursorPageResponse")
+	proto.RegisterType((*SessionTokenResponseEnvelope)(nil), "types.SessionTokenResponseEnvelope")
+	proto.RegisterType((*SessionTokenResponse)(nil), "types.SessionTokenResponse")
 	proto.RegisterType((*GetUserResponseEnvelope)(nil), "types.GetUserResponseEnvelope")
 	proto.RegisterType((*GetUserResponse)(nil), "types.GetUserResponse")
+	proto.RegisterType((*GetUsersResponseEnvelope)(nil), "types.GetUsersResponseEnvelope")
+	proto.RegisterType((*GetUsersResponse)(nil), "types.GetUsersResponse")
+	proto.RegisterType((*UserInfo)(nil), "types.UserInfo")
 	proto.RegisterType((*GetConfigResponseEnvelope)(nil), "types.GetConfigResponseEnvelope")
 	proto.RegisterType((*GetConfigResponse)(nil), "types.GetConfigResponse")
 	proto.RegisterType((*GetNodeConfigResponseEnvelope)(nil), "types.GetNodeConfigResponseEnvelope")
 	proto.RegisterType((*GetNodeConfigResponse)(nil), "types.GetNodeConfigResponse")
+	proto.RegisterType((*NodeAttestation)(nil), "types.NodeAttestation")
 	proto.RegisterType((*GetConfigBlockResponseEnvelope)(nil), "types.GetConfigBlockResponseEnvelope")
 	proto.RegisterType((*GetConfigBlockResponse)(nil), "types.GetConfigBlockResponse")
 	proto.RegisterType((*GetClusterStatusResponseEnvelope)(nil), "types.GetClusterStatusResponseEnvelope")
 	proto.RegisterType((*GetClusterStatusResponse)(nil), "types.GetClusterStatusResponse")
+	proto.RegisterType((*TriggerSnapshotResponseEnvelope)(nil), "types.TriggerSnapshotResponseEnvelope")
+	proto.RegisterType((*TriggerSnapshotResponse)(nil), "types.TriggerSnapshotResponse")
+	proto.RegisterType((*TransferLeadershipResponseEnvelope)(nil), "types.TransferLeadershipResponseEnvelope")
+	proto.RegisterType((*TransferLeadershipResponse)(nil), "types.TransferLeadershipResponse")
+	proto.RegisterType((*GetConsensusDiagnosticsResponseEnvelope)(nil), "types.GetConsensusDiagnosticsResponseEnvelope")
+	proto.RegisterType((*GetConsensusDiagnosticsResponse)(nil), "types.GetConsensusDiagnosticsResponse")
+	proto.RegisterType((*PeerDiagnostics)(nil), "types.PeerDiagnostics")
+	proto.RegisterType((*GetStorageReportResponseEnvelope)(nil), "types.GetStorageReportResponseEnvelope")
+	proto.RegisterType((*GetStorageReportResponse)(nil), "types.GetStorageReportResponse")
+	proto.RegisterType((*DBStorage)(nil), "types.DBStorage")
+	proto.RegisterType((*DuplicateValue)(nil), "types.DuplicateValue")
+	proto.RegisterType((*KeyStorage)(nil), "types.KeyStorage")
+	proto.RegisterType((*PrefixStorage)(nil), "types.PrefixStorage")
+	proto.RegisterType((*GetStateHashResponseEnvelope)(nil), "types.GetStateHashResponseEnvelope")
+	proto.RegisterType((*GetStateHashResponse)(nil), "types.GetStateHashResponse")
+	proto.RegisterType((*CompactIndexResponseEnvelope)(nil), "types.CompactIndexResponseEnvelope")
+	proto.RegisterType((*CompactIndexResponse)(nil), "types.CompactIndexResponse")
+	proto.RegisterType((*GetQuarantinedBlockResponseEnvelope)(nil), "types.GetQuarantinedBlockResponseEnvelope")
+	proto.RegisterType((*GetQuarantinedBlockResponse)(nil), "types.GetQuarantinedBlockResponse")
+	proto.RegisterType((*QuarantinedBlock)(nil), "types.QuarantinedBlock")
+	proto.RegisterType((*GetRejectedTxsResponseEnvelope)(nil), "types.GetRejectedTxsResponseEnvelope")
+	proto.RegisterType((*GetRejectedTxsResponse)(nil), "types.GetRejectedTxsResponse")
+	proto.RegisterType((*RejectedTx)(nil), "types.RejectedTx")
+	proto.RegisterType((*GetSlowQueriesResponseEnvelope)(nil), "types.GetSlowQueriesResponseEnvelope")
+	proto.RegisterType((*GetSlowQueriesResponse)(nil), "types.GetSlowQueriesResponse")
+	proto.RegisterType((*SlowQuery)(nil), "types.SlowQuery")
+	proto.RegisterType((*GetWitnessStatusResponseEnvelope)(nil), "types.GetWitnessStatusResponseEnvelope")
+	proto.RegisterType((*GetWitnessStatusResponse)(nil), "types.GetWitnessStatusResponse")
+	proto.RegisterType((*WitnessAlert)(nil), "types.WitnessAlert")
+	proto.RegisterType((*ExplainJSONQueryResponseEnvelope)(nil), "types.ExplainJSONQueryResponseEnvelope")
+	proto.RegisterType((*ExplainJSONQueryResponse)(nil), "types.ExplainJSONQueryResponse")
+	proto.RegisterType((*QueryPlan)(nil), "types.QueryPlan")
+	proto.RegisterType((*IndexScan)(nil), "types.IndexScan")
+	proto.RegisterType((*ConfigTxDryRunResponseEnvelope)(nil), "types.ConfigTxDryRunResponseEnvelope")
+	proto.RegisterType((*ConfigTxDryRunResponse)(nil), "types.ConfigTxDryRunResponse")
+	proto.RegisterType((*GetConfigHistoryResponseEnvelope)(nil), "types.GetConfigHistoryResponseEnvelope")
+	proto.RegisterType((*GetConfigHistoryResponse)(nil), "types.GetConfigHistoryResponse")
+	proto.RegisterType((*ConfigHistoryEntry)(nil), "types.ConfigHistoryEntry")
+	proto.RegisterType((*GetConfigDiffResponseEnvelope)(nil), "types.GetConfigDiffResponseEnvelope")
+	proto.RegisterType((*GetConfigDiffResponse)(nil), "types.GetConfigDiffResponse")
+	proto.RegisterType((*ClusterConfigDiff)(nil), "types.ClusterConfigDiff")
 	proto.RegisterType((*GetBlockResponseEnvelope)(nil), "types.GetBlockResponseEnvelope")
 	proto.RegisterType((*GetBlockResponse)(nil), "types.GetBlockResponse")
 	proto.RegisterType((*GetAugmentedBlockHeaderResponseEnvelope)(nil), "types.GetAugmentedBlockHeaderResponseEnvelope")
@@ -1983,6 +7020,8 @@ func init() {
 	proto.RegisterType((*GetDataProofResponseEnvelope)(nil), "types.GetDataProofResponseEnvelope")
 	proto.RegisterType((*GetDataProofResponse)(nil), "types.GetDataProofResponse")
 	proto.RegisterType((*MPTrieProofElement)(nil), "types.MPTrieProofElement")
+	proto.RegisterType((*GetDBStateRootResponseEnvelope)(nil), "types.GetDBStateRootResponseEnvelope")
+	proto.RegisterType((*GetDBStateRootResponse)(nil), "types.GetDBStateRootResponse")
 	proto.RegisterType((*GetHistoricalDataResponseEnvelope)(nil), "types.GetHistoricalDataResponseEnvelope")
 	proto.RegisterType((*GetHistoricalDataResponse)(nil), "types.GetHistoricalDataResponse")
 	proto.RegisterType((*GetDataReadersResponseEnvelope)(nil), "types.GetDataReadersResponseEnvelope")
@@ -1995,87 +7034,316 @@ func init() {
 	proto.RegisterType((*GetDataProvenanceResponse)(nil), "types.GetDataProvenanceResponse")
 	proto.RegisterType((*GetTxIDsSubmittedByResponseEnvelope)(nil), "types.GetTxIDsSubmittedByResponseEnvelope")
 	proto.RegisterType((*GetTxIDsSubmittedByResponse)(nil), "types.GetTxIDsSubmittedByResponse")
+	proto.RegisterType((*GetTxsByAnnotationResponseEnvelope)(nil), "types.GetTxsByAnnotationResponseEnvelope")
+	proto.RegisterType((*GetTxsByAnnotationResponse)(nil), "types.GetTxsByAnnotationResponse")
+	proto.RegisterType((*AnnotatedTx)(nil), "types.AnnotatedTx")
 	proto.RegisterType((*TxReceiptResponseEnvelope)(nil), "types.TxReceiptResponseEnvelope")
 	proto.RegisterType((*TxReceiptResponse)(nil), "types.TxReceiptResponse")
+	proto.RegisterType((*GetTxReceiptsResponseEnvelope)(nil), "types.GetTxReceiptsResponseEnvelope")
+	proto.RegisterType((*GetTxReceiptsResponse)(nil), "types.GetTxReceiptsResponse")
+	proto.RegisterType((*TxReceiptWithProof)(nil), "types.TxReceiptWithProof")
+	proto.RegisterType((*GetTxResourceUsageResponseEnvelope)(nil), "types.GetTxResourceUsageResponseEnvelope")
+	proto.RegisterType((*GetTxResourceUsageResponse)(nil), "types.GetTxResourceUsageResponse")
+	proto.RegisterType((*PendingDataTxResponseEnvelope)(nil), "types.PendingDataTxResponseEnvelope")
+	proto.RegisterType((*PendingDataTxResponse)(nil), "types.PendingDataTxResponse")
+	proto.RegisterType((*GetPendingDataTxsResponseEnvelope)(nil), "types.GetPendingDataTxsResponseEnvelope")
+	proto.RegisterType((*GetPendingDataTxsResponse)(nil), "types.GetPendingDataTxsResponse")
 	proto.RegisterType((*DataQueryResponseEnvelope)(nil), "types.DataQueryResponseEnvelope")
 	proto.RegisterType((*DataQueryResponse)(nil), "types.DataQueryResponse")
+	proto.RegisterType((*DataQueryStreamItem)(nil), "types.DataQueryStreamItem")
+	proto.RegisterType((*DataQueryStreamTrailerEnvelope)(nil), "types.DataQueryStreamTrailerEnvelope")
+	proto.RegisterType((*DataQueryStreamTrailer)(nil), "types.DataQueryStreamTrailer")
 }
 
 func init() { proto.RegisterFile("response.proto", fileDescriptor_0fbc901015fa5021) }
 
 var fileDescriptor_0fbc901015fa5021 = []byte{
-	// 1153 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5d, 0x6f, 0xdb, 0x36,
-	0x14, 0x85, 0xf2, 0xe1, 0x24, 0xd7, 0x69, 0x9a, 0xa8, 0x69, 0xe2, 0x3a, 0xe9, 0xe2, 0x69, 0xc0,
-	0x9a, 0x6e, 0x89, 0x33, 0xa4, 0xed, 0xda, 0x6e, 0x45, 0x81, 0xba, 0x0d, 0xdc, 0x22, 0xed, 0x90,
-	0xa9, 0x59, 0x02, 0x74, 0x18, 0x02, 0xd9, 0xba, 0xb5, 0x85, 0x38, 0x92, 0x47, 0x52, 0x4e, 0x3c,
-	0x6c, 0xe8, 0xc3, 0x1e, 0x07, 0x0c, 0xfb, 0x03, 0xfb, 0x3b, 0x7b, 0xda, 0xd3, 0x7e, 0xd1, 0x20,
-	0x8a, 0xb2, 0x64, 0x93, 0xc9, 0x44, 0x03, 0x7b, 0xf3, 0x25, 0xef, 0x39, 0xe2, 0x39, 0x22, 0xaf,
-	0x2e, 0x0d, 0x0b, 0x04, 0x69, 0x37, 0xf0, 0x29, 0x56, 0xbb, 0x24, 0x60, 0x81, 0x39, 0xcd, 0xfa,
-	0x5d, 0xa4, 0xe5, 0x1b, 0xcd, 0xc0, 0x7f, 0xef, 0xb5, 0x42, 0xe2, 0x30, 0x2f, 0xf0, 0xe3, 0xb9,
-	0xf2, 0x5a, 0xa3, 0x13, 0x34, 0x4f, 0x4f, 0x1c, 0xdf, 0x3d, 0x61, 0xc4, 0xf1, 0xa9, 0xd3, 0x4c,
-	0x27, 0xad, 0xbb, 0xb0, 0x60, 0x0b, 0xaa, 0x97, 0xe8, 0xb8, 0x48, 0xcc, 0x55, 0x98, 0xf1, 0x03,
-	0x17, 0x4f, 0x3c, 0xb7, 0x64, 0x54, 0x8c, 0xcd, 0x39, 0xbb, 0x10, 0x85, 0xaf, 0x5c, 0x8b, 0xc2,
-	0x5a, 0x1d, 0xd9, 0x8b, 0xda, 0x5b, 0xe6, 0xb0, 0x90, 0x26, 0xa8, 0x3d, 0xbf, 0x87, 0x9d, 0xa0,
-	0x8b, 0xe6, 0x97, 0x30, 0x9b, 0x2c, 0x8a, 0x03, 0x8b, 0xbb, 0xe5, 0x2a, 0x5f, 0x55, 0x55, 0x81,
-	0xb2, 0x07, 0xb9, 0xe6, 0x3a, 0xcc, 0x51, 0xaf, 0xe5, 0x3b, 0x2c, 0x24, 0x58, 0x9a, 0xa8, 0x18,
-	0x9b, 0xf3, 0x76, 0x3a, 0x60, 0xbd, 0x83, 0x1b, 0x0a, 0xb8, 0xb9, 0x0d, 0x85, 0x36, 0x5f, 0xae,
-	0x78, 0xd4, 0x4d, 0xf1, 0xa8, 0x61, 0x2d, 0xb6, 0x48, 0x32, 0x97, 0x61, 0x1a, 0x2f, 0x3c, 0xca,
-	0x38, 0xff, 0xac, 0x1d, 0x07, 0xd6, 0x29, 0xac, 0x46, 0xdc, 0x0e, 0x73, 0x24, 0x31, 0xbb, 0x92,
-	0x98, 0x95, 0x8c, 0x98, 0x0c, 0x22, 0xb7, 0x90, 0x5f, 0x0d, 0xb8, 0x3e, 0x82, 0x1d, 0x43, 0x45,
-	0xcf, 0xe9, 0x84, 0x09, 0x79, 0x1c, 0x98, 0x9f, 0xc3, 0xec, 0x19, 0x32, 0xc7, 0x75, 0x98, 0x53,
-	0x9a, 0xe4, 0x34, 0xd7, 0x05, 0xcd, 0x1b, 0x31, 0x6c, 0x0f, 0x12, 0x84, 0xe4, 0xef, 0x28, 0x12,
-	0x3d, 0xc9, 0x59, 0x44, 0x6e, 0xc9, 0xbf, 0xc7, 0x92, 0xb3, 0x58, 0x5d, 0xc9, 0x1b, 0x30, 0x15,
-	0x52, 0x24, 0x9c, 0xbb, 0xb8, 0x5b, 0x14, 0xc9, 0x9c, 0x91, 0x4f, 0xe8, 0xa9, 0x0f, 0xe0, 0x56,
-	0x1d, 0xd9, 0x73, 0x7e, 0x46, 0x24, 0xfd, 0xf7, 0x25, 0xfd, 0xa5, 0x54, 0xff, 0x30, 0x26, 0xb7,
-	0x03, 0x7f, 0x1a, 0xb0, 0x24, 0xa1, 0x75, 0x3d, 0xd8, 0x82, 0x42, 0x7c, 0xac, 0x85, 0x0b, 0xcb,
-	0x22, 0xfd, 0x79, 0x27, 0xa4, 0x0c, 0x89, 0x20, 0x17, 0x39, 0x7a, 0x86, 0x9c, 0xc3, 0xed, 0x3a,
-	0xb2, 0x6f, 0x02, 0x17, 0x2f, 0x31, 0xe5, 0x91, 0x64, 0xca, 0x7a, 0x6a, 0x8a, 0x8c, 0xcb, 0x6d,
-	0xcc, 0x4f, 0x70, 0x53, 0x49, 0xa0, 0xeb, 0xcd, 0x2e, 0x14, 0x79, 0xb1, 0x1a, 0x32, 0x68, 0x49,
-	0x60, 0x32, 0xf4, 0xe0, 0x0f, 0x7e, 0x5b, 0x7d, 0xf8, 0x68, 0xf0, 0x4e, 0x6a, 0x51, 0x69, 0x94,
-	0x54, 0x3f, 0x96, 0x54, 0xdf, 0x1e, 0xdd, 0x0a, 0x43, 0xc0, 0xdc, 0xb2, 0x7f, 0x80, 0x15, 0x35,
-	0xc3, 0x18, 0xa5, 0x80, 0x57, 0xf5, 0xa4, 0x14, 0xf0, 0xc0, 0xfa, 0x05, 0x2a, 0x11, 0x7d, 0xbc,
-	0x2f, 0x2e, 0x29, 0xd3, 0x5f, 0x4b, 0xda, 0x36, 0x32, 0xda, 0x54, 0xd0, 0xdc, 0xea, 0xfe, 0x36,
-	0xa0, 0x74, 0x19, 0x89, 0xae, 0xc0, 0x3b, 0x30, 0x1d, 0xbd, 0x32, 0x5a, 0x9a, 0xa8, 0x4c, 0xaa,
-	0x5f, 0x69, 0x3c, 0x6f, 0x6e, 0xc2, 0x4c, 0x0f, 0x09, 0xf5, 0x02, 0x5f, 0x6c, 0xf7, 0x05, 0x91,
-	0x7a, 0x14, 0x8f, 0xda, 0xc9, 0xb4, 0xb9, 0x02, 0x85, 0xd7, 0xf1, 0x0a, 0xa6, 0xe2, 0xef, 0x5a,
-	0x1c, 0x45, 0xe3, 0xcf, 0x9a, 0xcc, 0xeb, 0x61, 0x69, 0xba, 0x32, 0x19, 0x8d, 0xc7, 0x91, 0x75,
-	0xc6, 0xd5, 0xa8, 0x77, 0xc8, 0x3d, 0xc9, 0xc5, 0xd5, 0xd4, 0xc5, 0xf1, 0xf6, 0xc6, 0x05, 0x2c,
-	0x8e, 0x62, 0x75, 0x4d, 0x7b, 0x00, 0xf3, 0xf1, 0xb7, 0x5e, 0x80, 0xe2, 0xe3, 0x60, 0x0a, 0x10,
-	0xa7, 0x16, 0x88, 0x62, 0x23, 0x0d, 0xac, 0xdf, 0x0c, 0xb8, 0x53, 0x47, 0xf6, 0x2c, 0x6c, 0x9d,
-	0xa1, 0xcf, 0xd0, 0xcd, 0x26, 0x8e, 0x0a, 0xaf, 0x49, 0xc2, 0x3f, 0x4d, 0x85, 0x5f, 0xc5, 0x90,
-	0xdb, 0x87, 0x3f, 0x0c, 0xd8, 0xf8, 0x0f, 0x2e, 0x5d, 0x5f, 0x9e, 0x2a, 0x7d, 0x59, 0x13, 0x20,
-	0xe5, 0x93, 0x86, 0x0c, 0x8a, 0xcb, 0xe4, 0x6b, 0x74, 0x5b, 0x48, 0x0e, 0x1c, 0xd6, 0xd6, 0x2b,
-	0x93, 0x32, 0x2e, 0xb7, 0x17, 0x1f, 0x78, 0x99, 0x94, 0x09, 0x74, 0x0d, 0x78, 0x08, 0xd7, 0xb2,
-	0x06, 0x24, 0xa7, 0x4a, 0xb5, 0x33, 0xe6, 0x33, 0xc2, 0xa9, 0xf5, 0x23, 0x94, 0xeb, 0xc8, 0x0e,
-	0x2f, 0x0e, 0x48, 0x10, 0xbc, 0x97, 0x64, 0x3f, 0x90, 0x64, 0xdf, 0x4a, 0x65, 0x8f, 0x80, 0x72,
-	0x6b, 0xfe, 0x1e, 0x4c, 0x19, 0xad, 0x2b, 0x78, 0x05, 0x0a, 0x6d, 0x87, 0xb6, 0x45, 0xfd, 0x98,
-	0xb7, 0x45, 0x64, 0x85, 0xb0, 0x2e, 0x9a, 0x30, 0xb5, 0xa2, 0x87, 0x92, 0xa2, 0xb5, 0xe1, 0xbe,
-	0x6f, 0x3c, 0x4d, 0x0c, 0x96, 0x55, 0x78, 0x5d, 0x55, 0xdb, 0x30, 0xd5, 0x75, 0x58, 0x5b, 0xbc,
-	0xbd, 0xc4, 0xeb, 0x37, 0x07, 0x87, 0xc4, 0x43, 0x4e, 0xbc, 0xd7, 0xc1, 0x68, 0x2b, 0xdb, 0x3c,
-	0xcd, 0xda, 0x02, 0x53, 0x9e, 0xcb, 0x58, 0x63, 0x0c, 0x59, 0xf3, 0x01, 0x3e, 0xae, 0x23, 0x7b,
-	0xe9, 0x51, 0x16, 0x10, 0xaf, 0xe9, 0x74, 0x94, 0x7d, 0xf1, 0x13, 0xc9, 0x9f, 0x4a, 0xea, 0x8f,
-	0x1a, 0x9b, 0xdb, 0xa4, 0x9f, 0x79, 0x77, 0xa6, 0x26, 0xd1, 0x75, 0xea, 0x0b, 0x28, 0xf0, 0xee,
-	0x38, 0xd9, 0xe9, 0x49, 0x2b, 0x77, 0x14, 0x0d, 0x1e, 0x7b, 0xac, 0x3d, 0x68, 0x86, 0x44, 0x9e,
-	0xe8, 0x0a, 0xe2, 0x67, 0xf2, 0xbd, 0xaf, 0xd7, 0x15, 0x28, 0x80, 0xb9, 0x85, 0xff, 0x65, 0xf0,
-	0xb6, 0x40, 0x41, 0xa1, 0x2b, 0xbb, 0x06, 0x33, 0x04, 0x1d, 0xf7, 0xa4, 0xd1, 0x17, 0xba, 0xef,
-	0x5e, 0xb9, 0xc2, 0x6a, 0x14, 0xd7, 0xfa, 0x7b, 0x3e, 0x23, 0x7d, 0xbb, 0x40, 0x78, 0x50, 0x7e,
-	0x0c, 0xc5, 0xcc, 0xb0, 0xb9, 0x08, 0x93, 0xa7, 0xd8, 0x17, 0x57, 0xc1, 0xe8, 0xe7, 0xf0, 0x35,
-	0xe4, 0x9a, 0xb8, 0x86, 0x7c, 0x35, 0xf1, 0xc8, 0xc8, 0x78, 0x78, 0x4c, 0x3c, 0x36, 0x96, 0x87,
-	0x23, 0xc0, 0xdc, 0x1e, 0xfe, 0x93, 0x7a, 0x38, 0x42, 0xa1, 0xeb, 0xe1, 0x3e, 0xc0, 0x39, 0xf1,
-	0x18, 0x43, 0x3f, 0xb5, 0x71, 0xeb, 0xca, 0x45, 0x56, 0x8f, 0xe3, 0xfc, 0xc4, 0xc9, 0xb9, 0xf3,
-	0x24, 0x2e, 0x3f, 0x81, 0x85, 0xe1, 0x49, 0x2d, 0x3f, 0xe3, 0x23, 0x29, 0xca, 0x46, 0x0f, 0x7d,
-	0xc7, 0x6f, 0xa2, 0xde, 0x91, 0x54, 0x63, 0x73, 0xbb, 0x4a, 0xf9, 0x91, 0x54, 0x93, 0xe8, 0x77,
-	0x74, 0x93, 0xfb, 0x47, 0xc9, 0x79, 0x4c, 0x72, 0xf7, 0x8f, 0x86, 0x0e, 0x63, 0x94, 0x11, 0xdd,
-	0x94, 0x3f, 0xe1, 0x5f, 0x80, 0x57, 0x2f, 0xe8, 0xdb, 0xb0, 0x71, 0x16, 0xd9, 0xe7, 0xd6, 0xfa,
-	0x92, 0xf0, 0xa7, 0x92, 0x70, 0x2b, 0xfb, 0xf5, 0x51, 0xa3, 0x73, 0x4b, 0x6f, 0xf0, 0x7f, 0x3b,
-	0x2e, 0xa3, 0x19, 0xa3, 0x5f, 0x67, 0x11, 0x15, 0x97, 0x3f, 0x67, 0xc7, 0x41, 0x74, 0x1f, 0x3d,
-	0xbc, 0xb0, 0xb1, 0x89, 0x5e, 0x97, 0x69, 0xdc, 0x47, 0x25, 0x4c, 0x6e, 0x51, 0x3e, 0x2c, 0x49,
-	0x60, 0x5d, 0x29, 0x9f, 0x45, 0x35, 0x86, 0x33, 0x88, 0x3e, 0x6a, 0x51, 0x5a, 0x56, 0x92, 0x10,
-	0x09, 0x8c, 0x36, 0xcf, 0xb7, 0x21, 0x92, 0xbe, 0x86, 0x40, 0x09, 0x93, 0x5b, 0xe0, 0x29, 0x2c,
-	0x49, 0xe0, 0xff, 0x6b, 0xa3, 0xd6, 0xee, 0xbf, 0xdb, 0x6d, 0x79, 0xac, 0x1d, 0x36, 0xaa, 0xcd,
-	0xe0, 0x6c, 0xa7, 0xdd, 0xef, 0x22, 0xe9, 0xf0, 0x56, 0x6d, 0xbb, 0xe3, 0x34, 0xe8, 0x4e, 0x40,
-	0xbc, 0xc0, 0xdf, 0xa6, 0x48, 0x7a, 0x48, 0x76, 0xba, 0xa7, 0xad, 0x1d, 0xce, 0xd4, 0x28, 0xf0,
-	0x3f, 0xde, 0xee, 0xfd, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x7e, 0x32, 0x8b, 0xa8, 0xc3, 0x13, 0x00,
-	0x00,
+	// 4583 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5c, 0x5b, 0x6f, 0x1b, 0x49,
+	0x76, 0x5e, 0x5e, 0x24, 0x91, 0x87, 0x14, 0x45, 0xb5, 0x64, 0x99, 0x96, 0x67, 0xd6, 0x1a, 0xee,
+	0x78, 0xc6, 0xb3, 0x3b, 0x96, 0x13, 0xcf, 0xcd, 0x3b, 0xb3, 0x33, 0x09, 0x75, 0x19, 0x5b, 0xb1,
+	0x2c, 0x6b, 0x5a, 0x94, 0x27, 0x48, 0xb0, 0x68, 0x14, 0xd9, 0x45, 0xb2, 0x23, 0xb2, 0x9b, 0xd3,
+	0x55, 0x94, 0xc9, 0xdd, 0xec, 0x6e, 0x16, 0x0b, 0x04, 0x9b, 0x0b, 0x82, 0x4d, 0x02, 0x24, 0x4f,
+	0x79, 0xc8, 0x4b, 0x80, 0x00, 0xb9, 0x3d, 0x04, 0xc8, 0x53, 0x5e, 0x12, 0x60, 0x91, 0xd7, 0xe4,
+	0x29, 0x7f, 0x22, 0xff, 0x21, 0xa8, 0x5b, 0xdf, 0x5b, 0xee, 0x56, 0x32, 0x6f, 0xac, 0x53, 0xe7,
+	0x3b, 0x55, 0x75, 0xea, 0xd4, 0xa9, 0xaa, 0x73, 0xaa, 0x09, 0x0d, 0x17, 0x93, 0xa9, 0x63, 0x13,
+	0xbc, 0x3b, 0x75, 0x1d, 0xea, 0x68, 0x4b, 0x74, 0x31, 0xc5, 0x64, 0x7b, 0xa3, 0xef, 0xd8, 0x03,
+	0x6b, 0x38, 0x73, 0x11, 0xb5, 0x1c, 0x5b, 0xd4, 0x6d, 0xdf, 0xee, 0x8d, 0x9d, 0xfe, 0x85, 0x81,
+	0x6c, 0xd3, 0xa0, 0x2e, 0xb2, 0x09, 0xea, 0xfb, 0x95, 0xed, 0x77, 0xa0, 0xa1, 0x4b, 0x51, 0x4f,
+	0x30, 0x32, 0xb1, 0xab, 0xdd, 0x84, 0x15, 0xdb, 0x31, 0xb1, 0x61, 0x99, 0xad, 0xc2, 0x4e, 0xe1,
+	0x5e, 0x55, 0x5f, 0x66, 0xc5, 0x23, 0xb3, 0x4d, 0xe0, 0xf6, 0x63, 0x4c, 0x0f, 0xf6, 0xce, 0x28,
+	0xa2, 0x33, 0xa2, 0x50, 0x87, 0xf6, 0x25, 0x1e, 0x3b, 0x53, 0xac, 0x7d, 0x08, 0x15, 0xd5, 0x29,
+	0x0e, 0xac, 0x3d, 0xdc, 0xde, 0xe5, 0xbd, 0xda, 0x4d, 0x40, 0xe9, 0x1e, 0xaf, 0xf6, 0x1a, 0x54,
+	0x89, 0x35, 0xb4, 0x11, 0x9d, 0xb9, 0xb8, 0x55, 0xdc, 0x29, 0xdc, 0xab, 0xeb, 0x3e, 0xa1, 0xfd,
+	0x07, 0x05, 0xd8, 0x48, 0xc0, 0x6b, 0xf7, 0x61, 0x79, 0xc4, 0xfb, 0x2b, 0xdb, 0xba, 0x21, 0xdb,
+	0x0a, 0x0f, 0x46, 0x97, 0x4c, 0xda, 0x26, 0x2c, 0xe1, 0xb9, 0x45, 0x28, 0x6f, 0xa0, 0xa2, 0x8b,
+	0x02, 0x13, 0x32, 0x70, 0x31, 0xfe, 0x01, 0x6e, 0x95, 0x42, 0x42, 0x0e, 0x10, 0x45, 0x3d, 0x44,
+	0xf0, 0xe7, 0xbc, 0x52, 0x97, 0x4c, 0x6d, 0x0b, 0xb6, 0x78, 0x57, 0xe2, 0x63, 0xff, 0xd5, 0xd8,
+	0xd8, 0x6f, 0x04, 0xc7, 0x9e, 0x7f, 0xd8, 0x7f, 0x51, 0x80, 0x46, 0x18, 0x9a, 0x77, 0xc4, 0xb7,
+	0xa0, 0x62, 0xf6, 0x0c, 0x1b, 0x4d, 0x30, 0x69, 0x15, 0x77, 0x4a, 0xf7, 0xaa, 0xfa, 0x8a, 0xd9,
+	0x3b, 0x61, 0x45, 0x56, 0x35, 0x42, 0xc4, 0x98, 0x38, 0xae, 0x18, 0x78, 0x45, 0x5f, 0x19, 0x21,
+	0xf2, 0xcc, 0x71, 0xb1, 0x76, 0x07, 0x4a, 0x66, 0x8f, 0xb4, 0xca, 0x3b, 0xa5, 0x7b, 0xb5, 0x87,
+	0xab, 0x4a, 0x1d, 0x7b, 0x47, 0xf6, 0xc0, 0xd1, 0x59, 0x4d, 0xfb, 0x25, 0xbc, 0xfe, 0x18, 0xd3,
+	0x23, 0xdb, 0xc4, 0xf3, 0x73, 0x82, 0x86, 0x38, 0xa6, 0x8a, 0x47, 0x31, 0x55, 0xbc, 0xe6, 0xab,
+	0x22, 0x8e, 0xcb, 0xac, 0x91, 0xbf, 0x2e, 0xc0, 0x8d, 0x44, 0x09, 0x79, 0x15, 0xf3, 0x3e, 0xac,
+	0x58, 0x4c, 0x88, 0xd4, 0x8b, 0x6f, 0xa6, 0x5c, 0x74, 0x87, 0x52, 0xd7, 0xea, 0xcd, 0x28, 0x16,
+	0x6d, 0x28, 0x56, 0xed, 0x5b, 0xb0, 0x4a, 0x5d, 0xd4, 0xbf, 0xc0, 0xa6, 0x41, 0x2c, 0xbb, 0x2f,
+	0x14, 0x57, 0xd2, 0xeb, 0x92, 0x78, 0xc6, 0x68, 0x52, 0x39, 0xcf, 0xac, 0xa1, 0x58, 0x7f, 0x24,
+	0x9f, 0x72, 0xe2, 0xb8, 0xcc, 0xca, 0xf9, 0x11, 0xdc, 0x48, 0x14, 0x90, 0x57, 0x37, 0x1f, 0x00,
+	0x4c, 0x3c, 0x21, 0x52, 0x3d, 0x0a, 0xe2, 0x49, 0x67, 0x2b, 0x11, 0xeb, 0x01, 0xc6, 0xf6, 0xdf,
+	0x15, 0x60, 0x23, 0x41, 0x7b, 0xcc, 0x95, 0x48, 0x1b, 0x54, 0xae, 0x44, 0x98, 0x20, 0x1b, 0x0d,
+	0x52, 0xac, 0x7c, 0x34, 0x55, 0xdd, 0x27, 0x68, 0xf7, 0xa1, 0xcc, 0x9a, 0xe4, 0x2a, 0x6e, 0x3c,
+	0xbc, 0x95, 0x38, 0x3d, 0xdd, 0xc5, 0x14, 0xeb, 0x9c, 0x4d, 0xd3, 0xa0, 0x3c, 0xb2, 0x28, 0x33,
+	0xda, 0xc2, 0xbd, 0xb2, 0xce, 0x7f, 0x6b, 0xb7, 0xa1, 0x3a, 0x46, 0x84, 0x1a, 0x33, 0x82, 0xcd,
+	0xd6, 0x12, 0x9f, 0xaa, 0x0a, 0x23, 0x9c, 0x13, 0x6c, 0xb6, 0x67, 0xb0, 0x2c, 0x4c, 0x9a, 0x41,
+	0x03, 0xbd, 0xe3, 0xbf, 0xb5, 0x7b, 0xb0, 0x72, 0x89, 0x5d, 0x62, 0x39, 0x36, 0xef, 0x59, 0xed,
+	0x61, 0x43, 0x76, 0xe0, 0x85, 0xa0, 0xea, 0xaa, 0x5a, 0xbb, 0x0f, 0x9a, 0x30, 0x0f, 0xd3, 0xf0,
+	0x3a, 0x4f, 0x5a, 0x25, 0xbe, 0xd8, 0xd6, 0x65, 0x8d, 0xd7, 0x61, 0xd2, 0xbe, 0x80, 0x9b, 0x6c,
+	0x49, 0x23, 0x8a, 0x62, 0x76, 0xf1, 0x30, 0x66, 0x17, 0x5b, 0x01, 0xff, 0x11, 0x40, 0x64, 0xb6,
+	0x88, 0x7f, 0x2d, 0xc0, 0x5a, 0x04, 0x7b, 0x0d, 0x9f, 0x79, 0x89, 0xc6, 0x33, 0x25, 0x5c, 0x14,
+	0xb4, 0xef, 0x40, 0x65, 0x82, 0x29, 0x32, 0x11, 0x45, 0xd2, 0x6b, 0xae, 0x29, 0x03, 0x91, 0x64,
+	0xdd, 0x63, 0xd0, 0x1e, 0xc1, 0x6a, 0x6f, 0xec, 0xf4, 0x8c, 0x09, 0xb2, 0xad, 0x01, 0x26, 0x94,
+	0xcf, 0x51, 0xed, 0xe1, 0x86, 0x44, 0xec, 0x8d, 0x9d, 0xde, 0x33, 0x59, 0xa5, 0xd7, 0x7b, 0x81,
+	0x12, 0x53, 0xd6, 0xe9, 0x8c, 0x32, 0x86, 0x1c, 0xca, 0x8a, 0x20, 0x32, 0x2b, 0xeb, 0x2b, 0x58,
+	0x8b, 0x40, 0xf3, 0xea, 0xea, 0x01, 0x54, 0xbc, 0x31, 0x16, 0xd3, 0xc7, 0xe8, 0x31, 0xa9, 0xcd,
+	0x14, 0x51, 0xf4, 0x14, 0x2f, 0xf2, 0x6e, 0xa6, 0x11, 0x54, 0xe6, 0x71, 0xfe, 0xa3, 0xdc, 0x4c,
+	0x23, 0xf8, 0xbc, 0x83, 0xd5, 0xa0, 0x7c, 0x81, 0x17, 0x6a, 0x5b, 0xe1, 0xbf, 0x99, 0xb1, 0xf4,
+	0x9d, 0x99, 0x4d, 0xb9, 0x4d, 0x94, 0x75, 0x51, 0x08, 0xed, 0x34, 0xe5, 0xf0, 0x4e, 0xf3, 0x26,
+	0x34, 0x6c, 0x3c, 0xa7, 0x06, 0xa1, 0xc8, 0xa5, 0xc6, 0x05, 0x5e, 0xf0, 0x65, 0x5a, 0xd5, 0xeb,
+	0x8c, 0x7a, 0xc6, 0x88, 0x4f, 0xf1, 0xa2, 0xfd, 0x15, 0x6c, 0xb3, 0xde, 0xee, 0xcf, 0x5c, 0xe2,
+	0xb8, 0x31, 0x2d, 0x7d, 0x10, 0xd3, 0xd2, 0xad, 0xc0, 0x0e, 0x1e, 0x06, 0x65, 0x56, 0xd2, 0x3f,
+	0x15, 0x40, 0x8b, 0xc3, 0xf3, 0xea, 0xe8, 0x36, 0x54, 0xfb, 0x5c, 0x00, 0x3b, 0x47, 0x09, 0x0f,
+	0x57, 0x11, 0x84, 0x23, 0x33, 0xe8, 0x17, 0x4b, 0x21, 0xbf, 0xb8, 0x05, 0xcb, 0x53, 0x17, 0x0f,
+	0xac, 0x39, 0xd7, 0x56, 0x55, 0x97, 0x25, 0xed, 0x75, 0x00, 0x3c, 0x9f, 0x5a, 0x2e, 0x26, 0x06,
+	0xa2, 0xd2, 0x9f, 0x55, 0x25, 0xa5, 0x43, 0xdb, 0x3f, 0x81, 0x37, 0xe4, 0xb4, 0x8a, 0x4e, 0x9f,
+	0x26, 0x6d, 0xcc, 0xdf, 0x8b, 0x29, 0x6b, 0x27, 0x6c, 0x52, 0x71, 0x6c, 0x66, 0x9d, 0xfd, 0x7d,
+	0x01, 0x6e, 0xa5, 0x4a, 0xc9, 0xab, 0xba, 0xb7, 0xa1, 0xf4, 0xf4, 0x45, 0x74, 0xf7, 0x79, 0xfa,
+	0xe2, 0x4b, 0x8b, 0x8e, 0x3c, 0x17, 0xc3, 0x38, 0xae, 0x3a, 0xc7, 0x84, 0x15, 0x56, 0x8e, 0x2a,
+	0x6c, 0x06, 0xaf, 0x9d, 0x61, 0xc2, 0x9c, 0x78, 0xd7, 0xb9, 0xc0, 0x76, 0x4c, 0x57, 0x1f, 0xc5,
+	0x74, 0x75, 0x5b, 0xf6, 0x23, 0x09, 0x96, 0x59, 0x4d, 0x7f, 0x5e, 0x80, 0xcd, 0x24, 0x01, 0xd7,
+	0xf0, 0xcc, 0x94, 0xe1, 0xa5, 0x61, 0x89, 0x02, 0xb3, 0xaa, 0x19, 0xc1, 0xdc, 0xe0, 0xa4, 0x55,
+	0xb1, 0xe2, 0x91, 0xf9, 0x2a, 0x65, 0x88, 0x7d, 0xe9, 0x9c, 0x60, 0x37, 0xdf, 0xbe, 0x14, 0x44,
+	0x64, 0x56, 0xc1, 0x9f, 0x88, 0x7d, 0x29, 0x88, 0xcd, 0x3b, 0xfa, 0x3b, 0x50, 0x66, 0x03, 0x93,
+	0x7e, 0xb6, 0x26, 0x99, 0xb9, 0x44, 0x5e, 0x91, 0x6b, 0x8b, 0x6a, 0x4f, 0xa0, 0x25, 0xfb, 0x13,
+	0xf7, 0xc2, 0xef, 0xc5, 0x86, 0x7f, 0x33, 0x3c, 0xfc, 0xfc, 0x2e, 0xf8, 0x67, 0x05, 0x68, 0x46,
+	0xc1, 0x79, 0x15, 0x70, 0x17, 0x96, 0xd8, 0x38, 0xd5, 0x12, 0x59, 0x0b, 0x68, 0x80, 0x1f, 0xd4,
+	0x45, 0xed, 0x15, 0xcb, 0xa3, 0xfd, 0x8b, 0x02, 0x54, 0x14, 0xbb, 0xd6, 0x80, 0xa2, 0x77, 0xd7,
+	0x2b, 0x5a, 0x66, 0x8e, 0x03, 0xd0, 0x2e, 0x54, 0xa7, 0xae, 0x75, 0x69, 0x8d, 0xf1, 0x50, 0x5d,
+	0xa1, 0x9a, 0x6a, 0x2b, 0x56, 0x74, 0xdd, 0x67, 0xd1, 0xb6, 0xa1, 0x62, 0x5a, 0x04, 0xf5, 0xc6,
+	0xd8, 0x94, 0xdb, 0x81, 0x57, 0x6e, 0x3b, 0xdc, 0x83, 0xec, 0xf3, 0xfb, 0x6b, 0x6c, 0x22, 0xde,
+	0x8f, 0x4d, 0x44, 0xcb, 0x9f, 0x88, 0x30, 0x26, 0xf3, 0x4c, 0xfc, 0x55, 0x01, 0xd6, 0x63, 0xe8,
+	0xbc, 0x53, 0xf1, 0x2e, 0x2c, 0x8b, 0x2b, 0xb7, 0x54, 0xd5, 0xa6, 0x64, 0xdf, 0x1f, 0xcf, 0x08,
+	0xc5, 0xae, 0x14, 0x2e, 0x79, 0xf2, 0x19, 0xa6, 0xb8, 0x4c, 0x9c, 0x38, 0x26, 0x4e, 0x51, 0xca,
+	0x95, 0x97, 0x89, 0x38, 0x2e, 0xb3, 0x62, 0xfe, 0x59, 0xdc, 0xb4, 0xe2, 0x12, 0xf2, 0x2a, 0xe7,
+	0x21, 0xd4, 0x78, 0x24, 0x21, 0xa4, 0xa1, 0x75, 0x89, 0x09, 0x88, 0x07, 0xdb, 0xfb, 0xad, 0x3d,
+	0x82, 0x1a, 0xa2, 0x14, 0x13, 0xca, 0xaf, 0x16, 0xad, 0x52, 0xc8, 0xe9, 0x30, 0x4c, 0xc7, 0xaf,
+	0xd5, 0x83, 0xac, 0xed, 0x13, 0x58, 0x8b, 0xd4, 0x6b, 0x3b, 0x50, 0xeb, 0x63, 0x97, 0x5a, 0x03,
+	0xab, 0x8f, 0xa8, 0x50, 0x52, 0x5d, 0x0f, 0x92, 0xd8, 0x1a, 0xe9, 0x23, 0xa3, 0x3f, 0x42, 0x96,
+	0xcd, 0x57, 0x53, 0x5d, 0x5f, 0xe9, 0xa3, 0x7d, 0x56, 0x6c, 0x2f, 0xe0, 0x9b, 0x9e, 0x79, 0xec,
+	0xb1, 0x08, 0x4a, 0x6c, 0x02, 0xbe, 0x1b, 0x9b, 0x80, 0xd7, 0xa3, 0x56, 0x19, 0x02, 0x66, 0x9e,
+	0x81, 0xef, 0xc3, 0x56, 0xb2, 0x84, 0x6b, 0x6c, 0x14, 0x3c, 0xf8, 0xa3, 0x8e, 0xf0, 0xbc, 0xd0,
+	0xfe, 0x11, 0xec, 0x30, 0xf1, 0xc2, 0x44, 0x53, 0xa2, 0x39, 0x9f, 0xc4, 0xc6, 0x76, 0x27, 0x30,
+	0xb6, 0x24, 0x68, 0xe6, 0xd1, 0xfd, 0x7e, 0x11, 0x5a, 0x69, 0x42, 0xf2, 0x9f, 0x15, 0x96, 0x98,
+	0xf1, 0x28, 0x57, 0x98, 0x60, 0x5c, 0xa2, 0x3e, 0xe8, 0xd4, 0x4a, 0x57, 0x3b, 0xb5, 0x2d, 0x58,
+	0x3e, 0x16, 0x3d, 0x90, 0x67, 0x30, 0x51, 0x62, 0xf4, 0x4e, 0x9f, 0x5a, 0x97, 0xb8, 0xb5, 0xc4,
+	0xcf, 0xbd, 0xb2, 0x14, 0xb5, 0xd8, 0xe5, 0xec, 0x16, 0xfb, 0x43, 0xb8, 0xd3, 0x75, 0xad, 0xe1,
+	0x10, 0xbb, 0x67, 0x36, 0x9a, 0x92, 0x91, 0x43, 0x63, 0xd3, 0xf0, 0x71, 0x6c, 0x1a, 0xbe, 0x29,
+	0x25, 0xa7, 0x20, 0x33, 0xcf, 0xc2, 0x1f, 0x16, 0xe0, 0x66, 0x8a, 0x8c, 0xbc, 0x93, 0xf0, 0x06,
+	0xd4, 0x45, 0x88, 0xd1, 0x9e, 0x4d, 0x7a, 0x72, 0x63, 0x2e, 0xeb, 0x35, 0x4e, 0x3b, 0xe1, 0x24,
+	0x76, 0x04, 0x71, 0xd1, 0x80, 0x1a, 0xfc, 0x56, 0x2c, 0xef, 0x08, 0x55, 0x46, 0xe1, 0xb7, 0xfa,
+	0xf6, 0x4f, 0x0b, 0xd0, 0xee, 0xba, 0xc8, 0x26, 0x03, 0xec, 0x0a, 0x75, 0x93, 0x91, 0x35, 0x8d,
+	0x69, 0xe3, 0xd3, 0x98, 0x36, 0xde, 0xf0, 0xb4, 0x91, 0x06, 0xce, 0xac, 0x90, 0x11, 0x6c, 0xa7,
+	0x4b, 0xb9, 0xc6, 0xf1, 0x7f, 0xcc, 0x7f, 0x05, 0x8e, 0xff, 0x82, 0x70, 0x64, 0xb6, 0xff, 0xa8,
+	0x00, 0x6f, 0x8b, 0xf5, 0x4d, 0xb0, 0x4d, 0x66, 0xe4, 0xc0, 0x42, 0x43, 0xdb, 0x21, 0xd4, 0xea,
+	0xc7, 0xd7, 0xe1, 0x5e, 0x6c, 0xc8, 0x6f, 0x85, 0x7c, 0x4c, 0xaa, 0x84, 0xcc, 0xe3, 0xfe, 0xcf,
+	0x32, 0xdc, 0x79, 0x85, 0xac, 0xbc, 0xa3, 0xbf, 0x09, 0x2b, 0x62, 0xb6, 0x4d, 0x69, 0x0b, 0xcb,
+	0x7c, 0xaa, 0x4d, 0xcf, 0x0c, 0xd8, 0x0a, 0x50, 0x77, 0x1f, 0x6e, 0x06, 0x3c, 0xa6, 0xc4, 0x2e,
+	0x96, 0x14, 0xbb, 0x13, 0x15, 0xc9, 0x61, 0xbf, 0xc3, 0x9a, 0x5c, 0x0a, 0x6b, 0x92, 0x59, 0x5e,
+	0xdf, 0x99, 0x4c, 0x2c, 0x65, 0x58, 0xcb, 0xc2, 0xf2, 0x04, 0x8d, 0x9b, 0x16, 0x0b, 0xdc, 0xa1,
+	0xe9, 0x74, 0x6c, 0x61, 0x53, 0xf2, 0xac, 0x70, 0x9e, 0xba, 0x24, 0x0a, 0xa6, 0xbb, 0xd0, 0x90,
+	0x8d, 0xf4, 0x47, 0xc8, 0x1e, 0x62, 0xd2, 0xaa, 0x70, 0xae, 0x55, 0x41, 0xdd, 0x17, 0x44, 0xa6,
+	0x48, 0x3c, 0xc6, 0x7d, 0x11, 0x1d, 0xab, 0x0a, 0x23, 0xf6, 0x08, 0xda, 0x07, 0x70, 0x93, 0xc7,
+	0x9c, 0x42, 0x92, 0x0c, 0x6a, 0x4d, 0x70, 0x0b, 0xf8, 0x99, 0x7b, 0x93, 0x55, 0x1f, 0x07, 0x24,
+	0x76, 0x2d, 0x1e, 0x6f, 0x6a, 0x5a, 0xb6, 0x31, 0x18, 0x5b, 0xc3, 0x11, 0x35, 0xf8, 0x9a, 0x21,
+	0xad, 0xda, 0x4e, 0xe1, 0xde, 0xaa, 0xde, 0xb0, 0xec, 0xcf, 0x39, 0x99, 0xef, 0x01, 0x44, 0xfb,
+	0x04, 0xb6, 0x79, 0x03, 0x53, 0xd7, 0x99, 0x3a, 0x04, 0x9b, 0x46, 0x68, 0xd5, 0xd5, 0x79, 0x7f,
+	0x78, 0x17, 0x4e, 0x25, 0xc3, 0x5e, 0x60, 0x05, 0x7e, 0x0a, 0xb7, 0x39, 0x58, 0xe8, 0x86, 0x46,
+	0xd1, 0xab, 0x1c, 0xdd, 0x62, 0x2c, 0xfb, 0x8a, 0x23, 0x08, 0x7f, 0x17, 0x96, 0xa6, 0x98, 0x9d,
+	0x39, 0x1b, 0x3b, 0xa5, 0x80, 0x7f, 0x3b, 0xc5, 0xd8, 0x0d, 0x1a, 0x8c, 0x60, 0x6a, 0xff, 0x5b,
+	0x01, 0xd6, 0x22, 0x55, 0xa9, 0x79, 0x85, 0x74, 0x6b, 0xd9, 0x82, 0x65, 0x24, 0x3c, 0xae, 0x38,
+	0xbe, 0xca, 0x92, 0x76, 0x07, 0x6a, 0x13, 0x44, 0xfb, 0x23, 0x39, 0xa1, 0xc2, 0x5a, 0x80, 0x93,
+	0xc4, 0x74, 0xbe, 0x0e, 0xc0, 0x63, 0x0b, 0xa2, 0x7e, 0x49, 0x4c, 0x14, 0xa3, 0x78, 0xb3, 0x3d,
+	0x75, 0x9d, 0xa1, 0x8b, 0x09, 0x91, 0x96, 0xb8, 0xcc, 0x3b, 0xb4, 0xaa, 0xa8, 0xdc, 0x1a, 0xe5,
+	0x36, 0x79, 0x46, 0x1d, 0x97, 0x5f, 0x66, 0xa7, 0x8e, 0x4b, 0xf3, 0x6d, 0x93, 0x89, 0xd0, 0xcc,
+	0xeb, 0xf2, 0xe7, 0x25, 0x68, 0xa5, 0x09, 0xb9, 0xb6, 0x87, 0x1e, 0x61, 0x66, 0x4f, 0x21, 0x0f,
+	0xfd, 0x84, 0x93, 0xb4, 0xb6, 0x88, 0xfc, 0x97, 0x76, 0x4a, 0x81, 0x53, 0xfc, 0xc1, 0x9e, 0x6a,
+	0x9e, 0x55, 0x6a, 0xbf, 0x0e, 0x4d, 0x73, 0x36, 0x1d, 0xf3, 0xa3, 0x93, 0xc1, 0xc3, 0x81, 0x2a,
+	0x55, 0xe0, 0x65, 0x4e, 0x54, 0xf5, 0x0b, 0x56, 0xab, 0xaf, 0x99, 0xa1, 0x32, 0xd1, 0xde, 0x87,
+	0xfa, 0x18, 0xb9, 0x43, 0x4c, 0x78, 0xc8, 0x87, 0xb4, 0x96, 0x42, 0xdb, 0xf6, 0x53, 0xbc, 0x50,
+	0xed, 0xd5, 0x24, 0x1b, 0x8b, 0x53, 0x69, 0xbf, 0x06, 0x4d, 0x85, 0x12, 0x01, 0x11, 0x4c, 0x5a,
+	0xcb, 0x3b, 0xa5, 0xc0, 0x79, 0xfb, 0x94, 0x93, 0x15, 0x78, 0x4d, 0x72, 0x9f, 0x4a, 0x66, 0xed,
+	0x53, 0x58, 0x97, 0xdb, 0xbb, 0x31, 0x72, 0xa8, 0x41, 0xa6, 0x0e, 0x25, 0xad, 0x95, 0xb4, 0xb6,
+	0xd7, 0x24, 0xef, 0x13, 0x87, 0x9e, 0x31, 0xce, 0xf6, 0x25, 0x54, 0x3d, 0x4d, 0xa4, 0x07, 0xb5,
+	0xfd, 0xb0, 0x18, 0xf7, 0x5e, 0xec, 0x37, 0x33, 0x55, 0xae, 0x27, 0xa3, 0xb7, 0x10, 0xb1, 0x61,
+	0x56, 0x05, 0x9c, 0xb4, 0xc7, 0x28, 0xcc, 0xbd, 0xf1, 0x08, 0x29, 0x47, 0x0a, 0x4b, 0xae, 0x30,
+	0x02, 0x1b, 0x77, 0xfb, 0xf7, 0x0a, 0xd0, 0x08, 0x6b, 0x94, 0x99, 0xb6, 0x10, 0x38, 0x42, 0x64,
+	0x24, 0x4f, 0xb4, 0x55, 0x4e, 0x79, 0x82, 0xc8, 0x88, 0xf5, 0x81, 0x58, 0x3f, 0xc0, 0xaa, 0x0f,
+	0xec, 0x77, 0x4a, 0x68, 0xee, 0xae, 0xec, 0x6d, 0x39, 0x4d, 0x0b, 0xbc, 0xba, 0x3d, 0x04, 0xf0,
+	0x69, 0xe9, 0x63, 0x6f, 0x42, 0x89, 0x85, 0xf0, 0xc4, 0x4e, 0xc7, 0x7e, 0x7a, 0x3d, 0x29, 0x05,
+	0x7a, 0xb2, 0x0d, 0x15, 0xa9, 0x5a, 0x6f, 0xac, 0xaa, 0xdc, 0x9e, 0xc1, 0x6a, 0x68, 0x12, 0xd3,
+	0xdb, 0xf2, 0x83, 0x64, 0xc5, 0x50, 0x90, 0x4c, 0xe9, 0xbf, 0x94, 0xae, 0xff, 0x72, 0x54, 0xff,
+	0x2c, 0x12, 0xc4, 0x17, 0x19, 0xa2, 0x5c, 0x81, 0x39, 0x22, 0x41, 0x49, 0xb0, 0xcc, 0x8b, 0xfb,
+	0x6f, 0x0b, 0xb0, 0x99, 0x24, 0xe0, 0x6b, 0x58, 0xd8, 0xa9, 0xc1, 0x46, 0xcd, 0xb3, 0x00, 0x5f,
+	0x5f, 0x2c, 0x97, 0xc2, 0x0c, 0x6b, 0x89, 0x77, 0x98, 0xff, 0x66, 0x2a, 0xda, 0x77, 0x26, 0x53,
+	0xd4, 0x17, 0xee, 0x33, 0x87, 0x8a, 0x92, 0x60, 0x79, 0xae, 0xa1, 0x9b, 0x49, 0x02, 0xae, 0x71,
+	0x18, 0x51, 0xe3, 0x2f, 0x86, 0xc6, 0xff, 0x6d, 0x58, 0x67, 0x56, 0x69, 0xf4, 0xf0, 0xc0, 0x71,
+	0xc3, 0x2b, 0x74, 0x8d, 0x55, 0xec, 0x71, 0xba, 0x58, 0xa6, 0xf7, 0xa0, 0xc9, 0x79, 0xd1, 0x80,
+	0x62, 0x37, 0x64, 0x4c, 0x0d, 0x46, 0xef, 0x30, 0xb2, 0x30, 0xa8, 0x9f, 0x15, 0xe0, 0x5b, 0x8f,
+	0x31, 0xfd, 0x62, 0x86, 0x5c, 0x64, 0x53, 0xcb, 0x96, 0xdb, 0x68, 0x4c, 0x6b, 0x9f, 0xc5, 0xb4,
+	0xd6, 0xf6, 0x0d, 0x2b, 0x0d, 0x9d, 0x59, 0x79, 0x7f, 0x56, 0x80, 0xdb, 0x57, 0xc8, 0xc9, 0xab,
+	0xc3, 0x03, 0x58, 0xff, 0xca, 0x17, 0x65, 0xf8, 0x77, 0x4a, 0x3f, 0x22, 0x16, 0x6b, 0xaa, 0xf9,
+	0x55, 0x84, 0xc2, 0x72, 0xf9, 0xcd, 0x28, 0x9b, 0xd6, 0x56, 0x57, 0x54, 0xd1, 0x91, 0xba, 0x9f,
+	0x36, 0xe9, 0x5f, 0xc8, 0x0b, 0x2b, 0xcf, 0xde, 0xbb, 0xae, 0xe3, 0xaa, 0x78, 0x27, 0x2f, 0x30,
+	0x2a, 0xa1, 0xa8, 0x7f, 0x21, 0xcd, 0x5a, 0x14, 0xd8, 0xe6, 0x1e, 0xec, 0xaa, 0x17, 0xf0, 0x5c,
+	0x0d, 0x50, 0x3b, 0x54, 0xde, 0xee, 0x75, 0xfc, 0x3b, 0xb8, 0x4f, 0xb1, 0xd9, 0x9d, 0x93, 0x7c,
+	0xb7, 0xfb, 0x04, 0x60, 0x8e, 0x64, 0xed, 0x56, 0xb2, 0x84, 0xfc, 0x99, 0xec, 0xba, 0x2b, 0xa5,
+	0x18, 0x74, 0x1e, 0xbd, 0x03, 0xfb, 0x0d, 0xe8, 0x35, 0xd7, 0x6f, 0xac, 0xfd, 0x0f, 0x45, 0x00,
+	0xbf, 0x4e, 0xdb, 0x80, 0x25, 0x3a, 0xf7, 0x0f, 0x65, 0x65, 0x3a, 0x17, 0x47, 0x32, 0x15, 0x4a,
+	0x2e, 0x86, 0x42, 0xc9, 0x1f, 0xb2, 0x78, 0x09, 0xc5, 0x43, 0xc7, 0x5d, 0xc8, 0xf4, 0xec, 0x76,
+	0xac, 0xb9, 0xdd, 0x7d, 0xc9, 0xa1, 0x7b, 0xbc, 0xcc, 0x67, 0xbb, 0x18, 0x11, 0xc7, 0x56, 0x97,
+	0x6a, 0x51, 0x62, 0xfe, 0xd9, 0x1b, 0x82, 0x97, 0xd9, 0x00, 0x45, 0xea, 0x50, 0xb6, 0x05, 0x56,
+	0x94, 0x3c, 0x6d, 0x15, 0xaa, 0xcf, 0x3a, 0xc7, 0x9f, 0x3f, 0xd7, 0x9f, 0x1d, 0x1e, 0x34, 0xbf,
+	0xa1, 0x6d, 0xc0, 0xda, 0xf9, 0x49, 0xe7, 0xbc, 0xfb, 0xe4, 0xf0, 0xa4, 0x7b, 0xb4, 0xdf, 0xe9,
+	0x1e, 0x1e, 0x34, 0x0b, 0x5a, 0x0d, 0x56, 0x8e, 0x4e, 0x5e, 0x74, 0x8e, 0x8f, 0x0e, 0x9a, 0x45,
+	0xc6, 0x71, 0x70, 0x7e, 0x7a, 0xcc, 0x2b, 0x8d, 0xee, 0x6f, 0x1a, 0x47, 0x07, 0xcd, 0x92, 0xd6,
+	0x00, 0xf8, 0xe2, 0xfc, 0xf0, 0xfc, 0xd0, 0xf8, 0xfc, 0xfc, 0xf8, 0xb8, 0x59, 0xd6, 0xd6, 0xa0,
+	0x76, 0x7e, 0xd2, 0x79, 0xd1, 0x39, 0x3a, 0xee, 0xec, 0x1d, 0x1f, 0x36, 0x97, 0x58, 0x33, 0xdd,
+	0xe7, 0xcf, 0x8d, 0xe3, 0x8e, 0xfe, 0xf8, 0xb0, 0xb9, 0x2c, 0x4d, 0xe5, 0x6c, 0xec, 0xbc, 0xfc,
+	0x62, 0x86, 0x5d, 0x0b, 0xe7, 0x34, 0x95, 0x04, 0x60, 0x66, 0x53, 0xf9, 0x5d, 0xd8, 0x4a, 0x96,
+	0x90, 0xd7, 0x54, 0xde, 0x83, 0x3a, 0x19, 0x3b, 0x2f, 0x8d, 0xaf, 0x84, 0x98, 0x56, 0x31, 0x74,
+	0xcc, 0x53, 0x0d, 0x2c, 0xf4, 0x1a, 0xf1, 0xdb, 0x6a, 0xff, 0x4f, 0x01, 0xaa, 0x5e, 0x55, 0xd0,
+	0x26, 0x0a, 0x21, 0x9b, 0x48, 0x75, 0xb0, 0x9b, 0xb0, 0xc4, 0xda, 0x5b, 0xa8, 0x05, 0xca, 0x0b,
+	0xda, 0x9b, 0x50, 0x9e, 0x8e, 0x91, 0x2d, 0x53, 0xc1, 0x4d, 0xcf, 0x7d, 0x60, 0x77, 0x71, 0x3a,
+	0x46, 0xb6, 0xce, 0x6b, 0xd9, 0xb9, 0x88, 0x6d, 0x48, 0x86, 0x8b, 0x91, 0x29, 0x4f, 0xf0, 0x95,
+	0x0b, 0x9e, 0xb3, 0x44, 0xa6, 0xd6, 0x82, 0x15, 0x17, 0x93, 0xd9, 0x98, 0x12, 0x79, 0xe3, 0x53,
+	0x45, 0x66, 0x4f, 0x78, 0x8e, 0xfb, 0x33, 0x69, 0x4f, 0x2b, 0xc2, 0x9e, 0x14, 0xa9, 0x43, 0x79,
+	0x08, 0x5a, 0x3e, 0x8f, 0xe2, 0x77, 0xbc, 0x92, 0xee, 0x95, 0xe5, 0x81, 0xff, 0x4b, 0x8b, 0xda,
+	0xf2, 0x0e, 0x90, 0x37, 0x2e, 0x96, 0x08, 0xcd, 0x3c, 0xd9, 0xff, 0x52, 0x80, 0x56, 0x9a, 0x90,
+	0xfc, 0x71, 0x31, 0x76, 0x88, 0xb5, 0x06, 0xec, 0xda, 0x1b, 0x3a, 0x1a, 0x34, 0x14, 0x59, 0x9e,
+	0x0e, 0xb6, 0x60, 0x79, 0x84, 0xc6, 0x14, 0x9b, 0xea, 0x8e, 0x25, 0x4a, 0xda, 0x77, 0x60, 0x19,
+	0x8d, 0xb1, 0x4b, 0xd5, 0x01, 0x51, 0xa5, 0xb3, 0x65, 0xef, 0x3a, 0xac, 0x4e, 0x97, 0x2c, 0xed,
+	0xef, 0x43, 0x3d, 0x48, 0x8f, 0x05, 0x84, 0x0a, 0xf1, 0x80, 0x90, 0xef, 0x10, 0x8a, 0x21, 0x87,
+	0xc0, 0x42, 0x00, 0xd6, 0x44, 0x3d, 0xaf, 0xe1, 0xbf, 0xd9, 0xbc, 0x1c, 0xce, 0xa7, 0x63, 0x64,
+	0xd9, 0xbf, 0x71, 0xf6, 0xfc, 0x44, 0x18, 0x6a, 0xf6, 0x79, 0x49, 0x83, 0x66, 0x9e, 0x17, 0x07,
+	0x5a, 0x69, 0x32, 0xf2, 0x4e, 0x8b, 0xb2, 0xfd, 0xe2, 0x55, 0xb6, 0xdf, 0x7e, 0x0e, 0x55, 0x8f,
+	0xc4, 0x0c, 0xd6, 0x99, 0x62, 0x17, 0x51, 0xc7, 0x95, 0xeb, 0xce, 0x2b, 0x6b, 0x6f, 0xc1, 0x12,
+	0xe9, 0x23, 0x3b, 0xba, 0x9c, 0xf9, 0x71, 0xe9, 0xac, 0x8f, 0x6c, 0x5d, 0x54, 0xb7, 0x7f, 0x5e,
+	0x84, 0xaa, 0x47, 0x0c, 0x3f, 0xbe, 0x29, 0xa4, 0x3d, 0xbe, 0x29, 0x66, 0x7b, 0x7c, 0xf3, 0x0e,
+	0x94, 0x2f, 0x2c, 0xdb, 0x94, 0x9b, 0xc1, 0x8d, 0x68, 0x0f, 0x76, 0x9f, 0x5a, 0xb6, 0xa9, 0x73,
+	0x16, 0xd6, 0xae, 0xea, 0xb9, 0xb0, 0xaa, 0xaa, 0xee, 0x13, 0x98, 0xc5, 0x62, 0x9b, 0x32, 0xbf,
+	0x63, 0xb0, 0x4e, 0xdb, 0x58, 0x2d, 0xfb, 0x86, 0x24, 0x9f, 0x09, 0x2a, 0xdf, 0xf6, 0x31, 0xbe,
+	0x50, 0x4b, 0x5f, 0x14, 0xda, 0x6f, 0x41, 0x99, 0x35, 0xa5, 0x55, 0x61, 0xe9, 0xf4, 0xf9, 0xd1,
+	0x49, 0xb7, 0xf9, 0x0d, 0xf6, 0x53, 0xef, 0x9c, 0x3c, 0x3e, 0x6c, 0x16, 0xb4, 0x0a, 0x94, 0xb9,
+	0xb3, 0x2f, 0x32, 0x67, 0x2e, 0x02, 0xc3, 0xdd, 0xf9, 0x81, 0xbb, 0xd0, 0x67, 0x76, 0x0e, 0x67,
+	0x9e, 0x0c, 0xcc, 0x6c, 0x47, 0xff, 0x5e, 0x86, 0xad, 0x64, 0x11, 0x79, 0xcd, 0xe8, 0x33, 0x58,
+	0xbb, 0x44, 0x63, 0xcb, 0xe4, 0x6e, 0xcb, 0xb0, 0xec, 0x81, 0xd3, 0x2a, 0x86, 0x70, 0x2f, 0xbc,
+	0x5a, 0x9e, 0x10, 0x6c, 0x5c, 0x86, 0xca, 0x2c, 0x26, 0xc6, 0xa3, 0xe2, 0x32, 0x46, 0xa5, 0xd6,
+	0x7e, 0x9d, 0x13, 0x45, 0x68, 0x8a, 0x79, 0x80, 0xf5, 0xbe, 0x8a, 0x09, 0x7a, 0x8c, 0x22, 0x6b,
+	0xd7, 0xf4, 0x2a, 0x14, 0xf3, 0xeb, 0x00, 0x22, 0x8f, 0x62, 0x0f, 0xe5, 0xc4, 0x55, 0xf4, 0x2a,
+	0xcf, 0xa4, 0xf0, 0xea, 0xbb, 0xd0, 0x40, 0xe6, 0xc4, 0xb2, 0x7d, 0x41, 0xcb, 0x9c, 0x65, 0x55,
+	0x50, 0x15, 0xdb, 0x87, 0xb0, 0x8a, 0x4c, 0x13, 0x9b, 0xc6, 0x04, 0x33, 0x27, 0x11, 0xbd, 0xa2,
+	0xb3, 0x88, 0x92, 0x8c, 0xea, 0xd7, 0x39, 0xdf, 0x33, 0xc1, 0xa6, 0x7d, 0x0c, 0x6b, 0x2e, 0x9e,
+	0x38, 0x97, 0x01, 0x64, 0x25, 0x0d, 0xd9, 0x90, 0x9c, 0x01, 0xec, 0x6c, 0x6a, 0x22, 0x1a, 0xc0,
+	0x56, 0x53, 0xb1, 0x92, 0x53, 0x61, 0x1f, 0x41, 0xab, 0x3f, 0x73, 0x5d, 0x6c, 0xf3, 0x98, 0x1c,
+	0x75, 0xfa, 0xce, 0xd8, 0x50, 0x59, 0x06, 0xe0, 0x21, 0xbc, 0x2d, 0x59, 0x7f, 0x2a, 0xab, 0x65,
+	0xb6, 0x81, 0x21, 0x55, 0xab, 0x31, 0xa4, 0x08, 0xfe, 0x6d, 0xc9, 0xfa, 0x08, 0x52, 0x25, 0x6f,
+	0x78, 0x87, 0x9e, 0x58, 0x84, 0x3a, 0xb9, 0x9c, 0x61, 0x1a, 0x34, 0xb3, 0x11, 0xff, 0x18, 0x5a,
+	0x69, 0x32, 0xf2, 0x9f, 0x49, 0x56, 0xe4, 0xd2, 0x96, 0xfe, 0xeb, 0x56, 0x68, 0x9d, 0x49, 0xe9,
+	0x87, 0x36, 0x75, 0x17, 0xba, 0xe2, 0x6c, 0xff, 0xb2, 0x08, 0x5a, 0xbc, 0x3e, 0xcb, 0x8e, 0xe3,
+	0x1d, 0x74, 0x8b, 0xc9, 0x07, 0xdd, 0xf0, 0x9b, 0x89, 0xd7, 0xa0, 0xca, 0xf6, 0x1e, 0x42, 0xd1,
+	0x64, 0xaa, 0x9e, 0x4c, 0x78, 0x84, 0xf8, 0x02, 0x5a, 0x4a, 0x58, 0x40, 0x19, 0x8d, 0x3e, 0xbc,
+	0x74, 0x56, 0xa2, 0x4b, 0x27, 0x71, 0x19, 0x56, 0x52, 0x96, 0xe1, 0x3b, 0xd0, 0x8c, 0x99, 0x53,
+	0x95, 0x9b, 0xd3, 0xda, 0x34, 0x62, 0x47, 0x22, 0xbd, 0x2c, 0x54, 0x79, 0x60, 0x0d, 0x06, 0xf9,
+	0xd2, 0xcb, 0x71, 0x5c, 0x66, 0x0b, 0xfa, 0x0f, 0x91, 0x5e, 0x8e, 0x4b, 0xc8, 0x6b, 0x3f, 0xdf,
+	0x86, 0xf5, 0x81, 0xeb, 0x4c, 0x8c, 0x84, 0xdc, 0xd3, 0x1a, 0xab, 0x08, 0x86, 0xaf, 0xdf, 0x82,
+	0x35, 0xea, 0x84, 0x39, 0xc5, 0x4d, 0x7f, 0x95, 0x3a, 0xe1, 0x30, 0x77, 0xd9, 0xb4, 0x06, 0x83,
+	0x56, 0x39, 0xf4, 0xc8, 0x20, 0x94, 0xcd, 0xe7, 0x5d, 0xe6, 0x5c, 0xed, 0xff, 0xae, 0xc0, 0x7a,
+	0xac, 0x8e, 0xa5, 0xbd, 0x85, 0x17, 0x13, 0x99, 0xc9, 0x42, 0x5a, 0x66, 0x12, 0x38, 0x17, 0x23,
+	0x10, 0xe6, 0xf9, 0x94, 0x07, 0x7b, 0x45, 0x3e, 0xb3, 0x2e, 0xf9, 0x3c, 0x9c, 0xf2, 0x23, 0x02,
+	0x57, 0x4a, 0xc5, 0x49, 0x3e, 0x81, 0x7b, 0x00, 0xc2, 0x83, 0x1a, 0xc2, 0x16, 0xe5, 0x21, 0x4f,
+	0x5d, 0xbe, 0x3b, 0x8c, 0xa8, 0x8b, 0x51, 0xf0, 0xdf, 0x44, 0x7b, 0x0f, 0x94, 0xe3, 0x54, 0x90,
+	0xa5, 0x04, 0x88, 0x1a, 0x84, 0x0f, 0x52, 0xbd, 0x93, 0xa0, 0xe5, 0x24, 0x90, 0xe4, 0x91, 0xa0,
+	0x37, 0xa1, 0x21, 0xba, 0xe6, 0x3a, 0x0e, 0x35, 0xfa, 0x48, 0xec, 0x02, 0x75, 0xe9, 0xf2, 0x75,
+	0xc7, 0xa1, 0xfb, 0x88, 0x07, 0x64, 0x54, 0x7f, 0x3c, 0xbe, 0x0a, 0xe7, 0x53, 0xfd, 0x54, 0x9c,
+	0xef, 0xc3, 0x96, 0x90, 0x67, 0xd9, 0x2c, 0xa1, 0x84, 0x4d, 0x8b, 0x45, 0xaf, 0xfb, 0x48, 0xf8,
+	0xf9, 0xba, 0xbe, 0xc9, 0x6b, 0x8f, 0x02, 0x95, 0x0c, 0xf5, 0x08, 0x5a, 0x4a, 0x7e, 0x0c, 0x07,
+	0x1c, 0xb7, 0x25, 0xeb, 0xa3, 0xc8, 0xd8, 0x26, 0x56, 0xbb, 0xf6, 0x26, 0x56, 0xff, 0x3f, 0x6c,
+	0x62, 0xab, 0x59, 0x37, 0xb1, 0x8f, 0x61, 0x4d, 0xf4, 0xd7, 0xe9, 0x11, 0xec, 0x5e, 0xfa, 0x39,
+	0x9e, 0x24, 0x2c, 0xe7, 0x7c, 0xae, 0x18, 0xb5, 0xcf, 0x60, 0x5d, 0xf5, 0xd9, 0x47, 0xaf, 0xa5,
+	0xa1, 0xd5, 0x8c, 0x85, 0xf0, 0xaa, 0xdf, 0x3e, 0xbe, 0x99, 0x8a, 0x97, 0xbc, 0x3e, 0xfe, 0x13,
+	0x68, 0x72, 0x17, 0xc0, 0xf3, 0x47, 0xf2, 0x99, 0xc9, 0x7a, 0xe8, 0x99, 0x89, 0x8e, 0x06, 0xea,
+	0x89, 0x4f, 0x83, 0xb1, 0xfa, 0x65, 0xed, 0x23, 0x68, 0x50, 0x27, 0x04, 0xd5, 0xd2, 0xa0, 0x75,
+	0xea, 0x04, 0x80, 0x0f, 0xe1, 0x06, 0x6f, 0x35, 0xe6, 0x6a, 0x37, 0xb8, 0xab, 0xdd, 0x60, 0x95,
+	0xd1, 0x0d, 0x7f, 0x17, 0x36, 0xa8, 0x13, 0x47, 0x6c, 0x72, 0xc4, 0x3a, 0x75, 0xa2, 0xdb, 0xbc,
+	0x78, 0x96, 0x96, 0x1c, 0x3a, 0xbc, 0xf2, 0x59, 0xda, 0xf5, 0xe2, 0x85, 0x73, 0x68, 0x46, 0xb1,
+	0xf9, 0xbf, 0x1d, 0xf0, 0x42, 0xd1, 0x1c, 0x24, 0x4e, 0xa4, 0x5a, 0x30, 0x9e, 0x27, 0x11, 0xb5,
+	0x9e, 0x5f, 0x50, 0xc9, 0xf0, 0xce, 0x6c, 0x38, 0xc1, 0xb6, 0x4a, 0x3a, 0x4a, 0xc6, 0x5c, 0xc9,
+	0xf0, 0xab, 0x24, 0x64, 0xd6, 0xc3, 0x2f, 0x0a, 0x70, 0xe7, 0x15, 0xb2, 0xf2, 0x1f, 0xd6, 0x93,
+	0xf4, 0xa2, 0x42, 0xe4, 0x89, 0x2d, 0x85, 0x14, 0x24, 0x36, 0xea, 0x63, 0x6c, 0x0e, 0xb1, 0x7b,
+	0x8a, 0xe8, 0x28, 0xdf, 0x46, 0x1d, 0xc7, 0x65, 0xd6, 0xc5, 0x4f, 0xe0, 0x46, 0xa2, 0x80, 0xbc,
+	0x0a, 0xf8, 0x08, 0x56, 0x83, 0x0a, 0x50, 0x7b, 0x5b, 0x92, 0x65, 0xd4, 0x03, 0x03, 0x27, 0xec,
+	0xf1, 0xf7, 0x63, 0x4c, 0xbb, 0xf3, 0x53, 0xd7, 0x71, 0x06, 0x39, 0x1e, 0x7f, 0xc7, 0x41, 0x99,
+	0xc7, 0xfc, 0xdb, 0xa0, 0xc5, 0xd1, 0x79, 0x07, 0xcc, 0x63, 0x2a, 0x64, 0x24, 0x77, 0xf1, 0xba,
+	0x2e, 0x4b, 0x32, 0xd7, 0xc4, 0x1e, 0x49, 0x27, 0x8f, 0xe8, 0xca, 0x5c, 0x53, 0x0c, 0x96, 0x79,
+	0x4c, 0x14, 0x36, 0x93, 0xf0, 0x79, 0x47, 0x75, 0x1f, 0xca, 0x53, 0x44, 0x47, 0x91, 0xb3, 0xfa,
+	0xb3, 0xd3, 0xae, 0x6b, 0x61, 0x2e, 0xf8, 0x70, 0x8c, 0x99, 0x29, 0xeb, 0x9c, 0xad, 0xfd, 0x2e,
+	0x68, 0xf1, 0xba, 0x80, 0x6a, 0x0a, 0x21, 0xd5, 0x88, 0x18, 0xab, 0xf8, 0xca, 0x0f, 0xb3, 0x9d,
+	0x3b, 0x5f, 0x8c, 0x35, 0x01, 0x98, 0xe7, 0x51, 0xf6, 0x56, 0xb2, 0x88, 0x6b, 0x3c, 0xfa, 0xe1,
+	0x67, 0x11, 0x9e, 0x41, 0x13, 0xed, 0x54, 0x18, 0x81, 0x67, 0x66, 0x95, 0xfa, 0x4a, 0xd9, 0xd4,
+	0x27, 0x9e, 0xf4, 0x8b, 0x3b, 0x8e, 0xd5, 0x47, 0xe3, 0xc4, 0xcf, 0x86, 0xae, 0x7c, 0xd2, 0x9f,
+	0x8c, 0xcd, 0xac, 0x96, 0xbf, 0x14, 0x4f, 0xfa, 0x93, 0xa5, 0xe4, 0xd5, 0xcc, 0xaf, 0xc0, 0xb2,
+	0x7c, 0x2e, 0x20, 0xac, 0xa7, 0xe5, 0xc7, 0x29, 0x66, 0x38, 0xf4, 0xb0, 0x5f, 0xf2, 0x5d, 0xf5,
+	0x78, 0x59, 0xda, 0x0a, 0xef, 0x0e, 0x93, 0x9e, 0x33, 0x1e, 0x9f, 0x00, 0xcc, 0xac, 0x94, 0x5f,
+	0x4a, 0x5b, 0x89, 0x8b, 0xc8, 0xab, 0x91, 0x3d, 0x16, 0xc2, 0x46, 0xa6, 0xd1, 0x5b, 0x48, 0x95,
+	0xbc, 0x73, 0x65, 0x0f, 0x77, 0x59, 0x79, 0x4f, 0x5e, 0x86, 0x59, 0xac, 0xd4, 0xdc, 0x5b, 0x6c,
+	0x7f, 0x17, 0x6a, 0x01, 0xb2, 0xca, 0xc1, 0x17, 0xfc, 0x1c, 0x7c, 0xe8, 0x0b, 0xae, 0x55, 0xf9,
+	0x05, 0xd7, 0xc7, 0xc5, 0x47, 0x85, 0x80, 0x0e, 0xbf, 0x74, 0x2d, 0x7a, 0x2d, 0x1d, 0x46, 0x80,
+	0x99, 0x75, 0xf8, 0x5f, 0xbe, 0x0e, 0x23, 0x22, 0xf2, 0xea, 0xf0, 0x29, 0xc0, 0x4b, 0xd7, 0xa2,
+	0x14, 0xdb, 0xbe, 0x1a, 0xdf, 0xbd, 0xb2, 0x93, 0xbb, 0x5f, 0x0a, 0x7e, 0xa5, 0xc9, 0xea, 0x4b,
+	0x55, 0xde, 0xfe, 0x1e, 0x34, 0xc2, 0x95, 0xb9, 0xf4, 0xe9, 0x7f, 0x81, 0x73, 0xea, 0x3a, 0x97,
+	0xd8, 0x46, 0x76, 0xff, 0x1a, 0x5f, 0xe0, 0xc4, 0xb1, 0x99, 0xb5, 0x4a, 0xe0, 0x56, 0xaa, 0x90,
+	0xaf, 0xeb, 0x03, 0x1c, 0x95, 0xeb, 0xee, 0xce, 0x8f, 0x0e, 0xc8, 0xd9, 0xac, 0x27, 0x5f, 0x8d,
+	0x2d, 0xf2, 0xe5, 0xba, 0xd3, 0xd0, 0x99, 0x87, 0xde, 0x83, 0xdb, 0x57, 0x88, 0xb9, 0xce, 0xb7,
+	0x35, 0x4c, 0x94, 0xfc, 0xba, 0x4d, 0x14, 0xf8, 0x03, 0x55, 0xde, 0x08, 0xd9, 0x5b, 0x74, 0x6c,
+	0xdb, 0x91, 0xcf, 0x79, 0xb3, 0x3f, 0x50, 0x4d, 0x07, 0x67, 0x1e, 0xe7, 0x1f, 0x17, 0x60, 0x3b,
+	0x5d, 0x4c, 0xfe, 0x54, 0x44, 0x89, 0xce, 0xa3, 0x67, 0x31, 0x29, 0x96, 0x27, 0x8d, 0x59, 0xf5,
+	0x55, 0x6e, 0xf8, 0xc7, 0x50, 0x0b, 0xb0, 0x27, 0xe7, 0x91, 0x33, 0xbc, 0x0c, 0xbe, 0x05, 0x15,
+	0x86, 0x0b, 0xbc, 0x0b, 0x5e, 0xa1, 0x73, 0xf1, 0x4e, 0xef, 0xca, 0x18, 0x1c, 0xfb, 0x60, 0xa4,
+	0x3b, 0xd7, 0x71, 0x1f, 0x5b, 0x53, 0x9a, 0xe3, 0x83, 0x91, 0x18, 0x26, 0xcf, 0x5f, 0x11, 0xac,
+	0xc7, 0xd0, 0xf9, 0x83, 0x56, 0x2b, 0xae, 0x90, 0x10, 0x49, 0x02, 0xf9, 0x92, 0x15, 0x83, 0x54,
+	0xcd, 0x94, 0x1d, 0x0e, 0xf8, 0xb1, 0xa1, 0xce, 0x54, 0xc3, 0xcf, 0x0a, 0xf2, 0x52, 0xe0, 0x61,
+	0x72, 0x7e, 0x69, 0x1e, 0xc7, 0x65, 0x56, 0xc2, 0xdf, 0x88, 0xe8, 0x5d, 0x5c, 0x42, 0xfe, 0xeb,
+	0x62, 0x45, 0x8e, 0x33, 0x1a, 0xfe, 0xf5, 0x64, 0x33, 0x87, 0x23, 0x8e, 0xac, 0x1e, 0xab, 0xf6,
+	0x36, 0x34, 0x6d, 0x87, 0x1a, 0x03, 0x67, 0x66, 0xb3, 0x47, 0x0f, 0x86, 0x65, 0xaa, 0x2f, 0xae,
+	0x57, 0x6d, 0x87, 0x7e, 0xce, 0xc8, 0xdd, 0xf9, 0x91, 0x49, 0xda, 0x53, 0xd0, 0xe2, 0x82, 0x92,
+	0xad, 0xf4, 0xff, 0x69, 0x4e, 0x3c, 0x1f, 0xa1, 0x63, 0xe2, 0xcc, 0xdc, 0x3e, 0x4e, 0xfe, 0x83,
+	0x84, 0x57, 0xf8, 0x88, 0x44, 0x70, 0xe6, 0xe9, 0x59, 0xc0, 0x76, 0xba, 0x94, 0xfc, 0x1f, 0x37,
+	0x2d, 0xcd, 0x18, 0x5e, 0x6a, 0x65, 0x2b, 0xa0, 0x95, 0xa0, 0x74, 0xc1, 0xc4, 0x4c, 0xf2, 0x14,
+	0xdb, 0xa6, 0x65, 0x0f, 0xd9, 0x2e, 0xd4, 0x9d, 0xe7, 0x30, 0xc9, 0x44, 0x5c, 0xe6, 0x31, 0xff,
+	0x10, 0x6e, 0x24, 0x0a, 0xc8, 0x9f, 0x8f, 0x80, 0xa9, 0x90, 0x63, 0xd0, 0x79, 0xe4, 0x7b, 0xae,
+	0x70, 0x03, 0x55, 0xc9, 0xd7, 0x9d, 0xcb, 0x8d, 0x3f, 0x54, 0x4d, 0xf2, 0x6d, 0xfc, 0xc9, 0xd8,
+	0xcc, 0xa3, 0xff, 0xa9, 0x38, 0xa7, 0x27, 0x4b, 0xc9, 0xbf, 0x28, 0x6b, 0xbe, 0x0a, 0xd4, 0xba,
+	0x4c, 0xd6, 0x01, 0x78, 0x3a, 0x20, 0xcc, 0x15, 0x33, 0x6a, 0x72, 0x66, 0x3e, 0xdd, 0x15, 0xc7,
+	0x30, 0x99, 0x07, 0x7d, 0x01, 0xeb, 0x31, 0xf0, 0xd7, 0x76, 0xca, 0x99, 0xc1, 0x86, 0xd7, 0xd8,
+	0x19, 0x75, 0x31, 0x9a, 0x1c, 0x51, 0x3c, 0xd1, 0xee, 0x42, 0xf1, 0xe2, 0x32, 0xd2, 0x54, 0x04,
+	0x5e, 0xbc, 0xb8, 0xd4, 0x3e, 0x82, 0x12, 0xb6, 0x4d, 0x69, 0x4e, 0x77, 0xa3, 0x23, 0x17, 0xf2,
+	0xba, 0x2e, 0xb2, 0xc6, 0xd8, 0x55, 0x2a, 0xd3, 0x19, 0xa2, 0xfd, 0x12, 0xbe, 0x79, 0x35, 0x9b,
+	0xf6, 0x11, 0xac, 0x50, 0x41, 0x8a, 0x9c, 0xd0, 0x93, 0x71, 0xba, 0xe2, 0x7e, 0x85, 0x72, 0xff,
+	0xb4, 0x00, 0x5b, 0xc9, 0x12, 0xae, 0x71, 0x96, 0x12, 0x2f, 0x8f, 0x8b, 0x91, 0x3f, 0x05, 0xb8,
+	0xb8, 0x24, 0xe2, 0x96, 0x5c, 0xe2, 0x8d, 0xaf, 0x5c, 0x5c, 0x12, 0x7e, 0x49, 0xbe, 0x09, 0x2b,
+	0xd8, 0x75, 0x8d, 0x09, 0x19, 0xaa, 0x77, 0x62, 0xd8, 0x75, 0x9f, 0x91, 0xe1, 0xde, 0xfb, 0xbf,
+	0xf5, 0x70, 0x68, 0xd1, 0xd1, 0xac, 0xb7, 0xdb, 0x77, 0x26, 0x0f, 0x46, 0x8b, 0x29, 0x76, 0xc7,
+	0x3c, 0x30, 0x75, 0x7f, 0x8c, 0x7a, 0xe4, 0x81, 0xe3, 0x5a, 0x8e, 0x7d, 0x5f, 0x44, 0x85, 0x1f,
+	0x4c, 0x2f, 0x86, 0x0f, 0x78, 0xb7, 0x7a, 0xcb, 0x3c, 0xde, 0xfa, 0xde, 0xff, 0x0e, 0x00, 0xce,
+	0x96, 0x90, 0x8d, 0x2e, 0x49, 0x00, 0x00,
 }
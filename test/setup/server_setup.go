@@ -300,7 +300,7 @@ func (s *Server) createConfigFile() error {
 			LogLevel: "info",
 		},
 		BlockCreation: config.BlockCreationConf{
-			MaxBlockSize:                1024 * 1024,
+			MaxBlockSizeBytes:           1024 * 1024,
 			MaxTransactionCountPerBlock: 10,
 			BlockTimeout:                50 * time.Millisecond,
 		},